@@ -23,6 +23,9 @@ const (
 
 	soloAuthValidity = 10 * time.Hour * 24
 	rpAuthValidity   = 15 * time.Hour * 24
+
+	// The longest time limit that can be set when enabling the Rescue Node from the CLI
+	MaxTimeLimit = 7 * time.Hour * 24
 )
 
 type credentialDetails struct {
@@ -141,6 +144,16 @@ func (r *RescueNode) PrintStatusText(nodeAddr common.Address) {
 		}
 	}
 
+	expiresAt := r.GetExpiry()
+	if !expiresAt.IsZero() {
+		timeLeft := time.Until(expiresAt).Truncate(time.Second)
+		if timeLeft < 0 {
+			fmt.Printf("%sWARNING: The Rescue Node's time limit passed %s ago! Run `rocketpool service rescue-node disable` to switch back to your own Beacon Node.%s\n", colorRed, (-timeLeft).String(), colorReset)
+		} else {
+			fmt.Printf("The Rescue Node's time limit runs out in %s%s%s, at %s. The Validator Client won't switch back on its own; run `rocketpool service rescue-node disable` once your own Beacon Node is synced.\n", colorBlue, timeLeft.String(), colorReset, expiresAt.Local().Format(time.RFC1123))
+		}
+	}
+
 	credentialDetails, err := r.getCredentialDetails()
 	if err != nil {
 		fmt.Printf("%s%v%s\n", colorRed, err, colorReset)
@@ -166,11 +179,50 @@ type RescueNodeOverrides struct {
 	VcAdditionalFlags string
 }
 
+// Get the time the Rescue Node should stop being used, or a zero time if it doesn't have a limit
+func (r *RescueNode) GetExpiry() time.Time {
+	expiresAt, _ := r.cfg.ExpiresAt.Value.(uint64)
+	if expiresAt == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(expiresAt), 0)
+}
+
+// Check if the Rescue Node is enabled but its time limit has passed
+func (r *RescueNode) IsExpired() bool {
+	if !r.cfg.Enabled.Value.(bool) {
+		return false
+	}
+	expiresAt := r.GetExpiry()
+	return !expiresAt.IsZero() && time.Now().After(expiresAt)
+}
+
+// Use the Rescue Node with the provided credentials until the provided time
+func (r *RescueNode) Enable(username string, password string, expiresAt time.Time) {
+	r.cfg.Enabled.Value = true
+	r.cfg.Username.Value = username
+	r.cfg.Password.Value = password
+	r.cfg.ExpiresAt.Value = uint64(expiresAt.Unix())
+}
+
+// Stop using the Rescue Node and forget its credentials
+func (r *RescueNode) Disable() {
+	r.cfg.Enabled.Value = false
+	r.cfg.Username.Value = ""
+	r.cfg.Password.Value = ""
+	r.cfg.ExpiresAt.Value = uint64(0)
+}
+
 func (r *RescueNode) GetOverrides(cc cfgtypes.ConsensusClient) (*RescueNodeOverrides, error) {
 	if !r.cfg.Enabled.Value.(bool) {
 		return nil, nil
 	}
 
+	// Go back to the node's own Beacon Node once the time limit passes
+	if r.IsExpired() {
+		return nil, nil
+	}
+
 	username := r.cfg.Username.Value.(string)
 	password := r.cfg.Password.Value.(string)
 
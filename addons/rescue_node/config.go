@@ -11,6 +11,8 @@ type RescueNodeConfig struct {
 
 	Username config.Parameter `yaml:"username,omitempty"`
 	Password config.Parameter `yaml:"username,omitempty"`
+
+	ExpiresAt config.Parameter `yaml:"expiresAt,omitempty"`
 }
 
 // Creates a new configuration instance
@@ -50,6 +52,17 @@ func NewConfig() *RescueNodeConfig {
 			CanBeBlank:         true,
 			OverwriteOnUpgrade: false,
 		},
+
+		ExpiresAt: config.Parameter{
+			ID:                 "expiresAt",
+			Name:               "Time Limit",
+			Description:        "The time (as a Unix timestamp) to stop using the Rescue Node and go back to your own Beacon Node, or 0 for no limit. `rocketpool service rescue-node enable` sets this for you.\n\nOnce it passes, the Validator Client is switched back the next time the Smartnode is started, and the node daemon alerts you to run `rocketpool service rescue-node disable`.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Validator},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
 	}
 }
 
@@ -59,6 +72,7 @@ func (cfg *RescueNodeConfig) GetParameters() []*config.Parameter {
 		&cfg.Enabled,
 		&cfg.Username,
 		&cfg.Password,
+		&cfg.ExpiresAt,
 	}
 }
 
@@ -0,0 +1,102 @@
+package rescue_node
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// The Rescue Node's endpoint for issuing credentials to node operators
+	credentialApiUrl string = "https://api.rescuenode.com/rescue/v1/credentials/?operator_type=rp"
+
+	credentialRequestTimeout = 30 * time.Second
+)
+
+// A signed request for a Rescue Node credential
+type credentialRequest struct {
+	Address   common.Address `json:"address"`
+	Message   string         `json:"msg"`
+	Signature string         `json:"sig"`
+	Version   string         `json:"version"`
+}
+
+// The Rescue Node's response to a credential request
+type credentialResponse struct {
+	Data struct {
+		Username  string `json:"username"`
+		Password  string `json:"password"`
+		Timestamp int64  `json:"timestamp"`
+		ExpiresAt int64  `json:"expiresAt"`
+	} `json:"data"`
+	Error string `json:"error"`
+}
+
+// A credential issued by the Rescue Node
+type Credential struct {
+	Username  string
+	Password  string
+	ExpiresAt time.Time
+}
+
+// Get the message the node account signs to prove it's requesting a credential for itself
+func GetCredentialRequestMessage(timestamp time.Time) string {
+	return fmt.Sprintf("Rescue Node %d", timestamp.Unix())
+}
+
+// Request a credential for a node from the Rescue Node, using an EIP-191 signature of the request message by the node account
+func RequestCredential(nodeAddress common.Address, message string, signature string) (*Credential, error) {
+	body, err := json.Marshal(credentialRequest{
+		Address:   nodeAddress,
+		Message:   message,
+		Signature: signature,
+		Version:   "1",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error serializing the credential request: %w", err)
+	}
+
+	client := http.Client{Timeout: credentialRequestTimeout}
+	resp, err := client.Post(credentialApiUrl, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error requesting a Rescue Node credential: %w", err)
+	}
+	defer resp.Body.Close()
+	contents, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the Rescue Node's response: %w", err)
+	}
+
+	var response credentialResponse
+	if err := json.Unmarshal(contents, &response); err != nil {
+		return nil, fmt.Errorf("error parsing the Rescue Node's response (HTTP %d): %w", resp.StatusCode, err)
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("the Rescue Node didn't issue a credential: %s", response.Error)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("the Rescue Node didn't issue a credential (HTTP %d)", resp.StatusCode)
+	}
+	if response.Data.Username == "" || response.Data.Password == "" {
+		return nil, fmt.Errorf("the Rescue Node's response didn't include a username and password")
+	}
+
+	credential := &Credential{
+		Username: response.Data.Username,
+		Password: response.Data.Password,
+	}
+	switch {
+	case response.Data.ExpiresAt != 0:
+		credential.ExpiresAt = time.Unix(response.Data.ExpiresAt, 0)
+	case response.Data.Timestamp != 0:
+		credential.ExpiresAt = time.Unix(response.Data.Timestamp, 0).Add(rpAuthValidity)
+	default:
+		credential.ExpiresAt = time.Now().Add(rpAuthValidity)
+	}
+	return credential, nil
+}
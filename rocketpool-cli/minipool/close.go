@@ -237,6 +237,9 @@ func closeMinipools(c *cli.Context) error {
 		} else {
 			fmt.Printf("Successfully closed minipool %s.\n", minipool.Address.Hex())
 		}
+		if response.KeyRemoved {
+			fmt.Println("Its validator key was removed from your Validator Client.")
+		}
 	}
 
 	// Return
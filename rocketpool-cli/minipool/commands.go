@@ -358,6 +358,68 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "rewards-ledger",
+				Aliases:   []string{"rl"},
+				Usage:     "Reconcile the Beacon Chain withdrawals sent to each minipool against its balance and distributions",
+				UsageText: "rocketpool minipool rewards-ledger [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "minipool, m",
+						Usage: "Show the full withdrawal and distribution history of a single minipool",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("minipool") != "" {
+						if _, err := cliutils.ValidateAddress("minipool address", c.String("minipool")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return getRewardsLedger(c)
+
+				},
+			},
+
+			{
+				Name:      "exit-estimate",
+				Aliases:   []string{"ee"},
+				Usage:     "Estimate when a minipool's exit would be processed and when its ETH would arrive at the minipool contract",
+				UsageText: "rocketpool minipool exit-estimate [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "minipool, m",
+						Usage: "The minipool to estimate the exit timeline for",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("minipool") != "" {
+						if _, err := cliutils.ValidateAddress("minipool address", c.String("minipool")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return getExitEstimate(c)
+
+				},
+			},
+
 			{
 				Name:      "close",
 				Aliases:   []string{"c"},
@@ -424,6 +486,41 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "upgrade-campaign",
+				Aliases:   []string{"uc"},
+				Usage:     "Survey your minipools for outdated delegates, see the progress of automatic upgrades, and opt minipools in or out of them",
+				UsageText: "rocketpool minipool upgrade-campaign [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "auto-upgrade, a",
+						Usage: "Opt the selected minipools in or out of automatic delegate upgrades ('on' or 'off')",
+					},
+					cli.StringFlag{
+						Name:  "minipool, m",
+						Usage: "The minipool to change the auto-upgrade setting for (address or 'all')",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("minipool") != "" && c.String("minipool") != "all" {
+						if _, err := cliutils.ValidateAddress("minipool address", c.String("minipool")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return delegateUpgradeCampaign(c)
+
+				},
+			},
+
 			{
 				Name:      "delegate-rollback",
 				Aliases:   []string{"b"},
@@ -504,6 +601,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "salt, s",
 						Usage: "The salt to start searching from (must start with 0x)",
 					},
+					cli.StringFlag{
+						Name:  "end-salt, e",
+						Usage: "The salt to stop searching at (must start with 0x); use this to split a search across several machines",
+					},
 					cli.IntFlag{
 						Name:  "threads, t",
 						Usage: "The number of threads to use for searching (defaults to your CPU thread count)",
@@ -516,6 +617,18 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "amount, a",
 						Usage: "The bond amount to be used for the minipool, in ETH (impacts vanity address generation)",
 					},
+					cli.StringFlag{
+						Name:  "checkpoint, c",
+						Usage: "A file to save the search progress to; if it already exists, the search resumes from it",
+					},
+					cli.StringFlag{
+						Name:  "worker, w",
+						Usage: "A command that runs an external searcher (such as a GPU searcher) using the Smartnode's worker protocol, instead of searching on the CPU",
+					},
+					cli.StringFlag{
+						Name:  "export-job, x",
+						Usage: "Save the search parameters to this file for an external searcher instead of searching",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -532,6 +645,69 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "verify-vanity-salt",
+				Usage:     "Show the minipool address a salt creates, and check that it matches a prefix",
+				UsageText: "rocketpool minipool verify-vanity-salt [options] salt",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "prefix, p",
+						Usage: "The prefix the address should start with (must start with 0x)",
+					},
+					cli.StringFlag{
+						Name:  "node-address, n",
+						Usage: "The node address the salt is for (leave blank to use the local node)",
+					},
+					cli.StringFlag{
+						Name:  "amount, a",
+						Usage: "The bond amount to be used for the minipool, in ETH (impacts vanity address generation)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return verifyVanitySalt(c, c.Args().Get(0))
+
+				},
+			},
+
+			{
+				Name:      "export-deposit-data",
+				Usage:     "Create the standard deposit data and keystore files for a minipool's validator, so it can be run by a third party",
+				UsageText: "rocketpool minipool export-deposit-data [options] minipool-address",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The folder to save the files to",
+						Value: ".",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the export",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return exportMinipoolDepositData(c, minipoolAddress)
+
+				},
+			},
+
 			{
 				Name:      "rescue-dissolved",
 				Aliases:   []string{"rd"},
@@ -566,6 +742,41 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "recover",
+				Aliases:   []string{"rc"},
+				Usage:     "Diagnose minipools that are stuck in prelaunch or dissolved, and apply the supported fix",
+				UsageText: "rocketpool minipool recover [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "minipool, m",
+						Usage: "The minipool to fix (address, starting with 0x)",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the fix",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("minipool") != "" {
+						if _, err := cliutils.ValidateAddress("minipool address", c.String("minipool")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return recoverMinipools(c)
+
+				},
+			},
 		},
 	})
 }
@@ -0,0 +1,101 @@
+package minipool
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getExitEstimate(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get minipool statuses
+	status, err := rp.MinipoolStatus()
+	if err != nil {
+		return err
+	}
+
+	// Get minipools with a validator on the Beacon Chain
+	eligibleMinipools := []api.MinipoolDetails{}
+	for _, minipool := range status.Minipools {
+		if (minipool.Status.Status == types.Staking || minipool.Status.Status == types.Dissolved) && !minipool.Finalised && minipool.Validator.Exists {
+			eligibleMinipools = append(eligibleMinipools, minipool)
+		}
+	}
+	if len(eligibleMinipools) == 0 {
+		fmt.Println("No minipools have a validator on the Beacon Chain.")
+		return nil
+	}
+
+	// Get the selected minipool
+	var selectedAddress common.Address
+	if c.String("minipool") == "" {
+		options := make([]string, len(eligibleMinipools))
+		for mi, minipool := range eligibleMinipools {
+			options[mi] = fmt.Sprintf("%s (validator %s)", minipool.Address.Hex(), minipool.Validator.Index)
+		}
+		selected, _ := cliutils.Select("Please select a minipool to estimate the exit timeline for:", options)
+		selectedAddress = eligibleMinipools[selected].Address
+	} else {
+		selectedAddress = common.HexToAddress(c.String("minipool"))
+		found := false
+		for _, minipool := range eligibleMinipools {
+			if bytes.Equal(minipool.Address.Bytes(), selectedAddress.Bytes()) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("The minipool %s does not have a validator on the Beacon Chain.", selectedAddress.Hex())
+		}
+	}
+
+	// Get the estimate
+	estimate, err := rp.MinipoolExitEstimate(selectedAddress)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Minipool %s (validator %s, status %s)\n\n", estimate.MinipoolAddress.Hex(), estimate.ValidatorIndex, estimate.ValidatorStatus)
+	if estimate.WithdrawalComplete {
+		fmt.Println("This validator has already exited and its balance has been withdrawn to the minipool.")
+		return nil
+	}
+
+	if estimate.AlreadyExiting {
+		fmt.Println("This validator has already exited or is in the exit queue, so the times below are based on its actual exit epoch.")
+	} else {
+		fmt.Printf("If you exited this validator now, it would join an exit queue of %d validators (%d can exit per epoch).\n", estimate.ExitQueueLength, estimate.ChurnLimit)
+	}
+	fmt.Println()
+	fmt.Printf("Exit:         epoch %d, %s\n", estimate.ExitEpoch, formatEstimateTime(estimate.ExitTime))
+	fmt.Printf("Withdrawable: epoch %d, %s\n", estimate.WithdrawableEpoch, formatEstimateTime(estimate.WithdrawableTime))
+	fmt.Printf("ETH arrives:  slot %d, %s\n", estimate.WithdrawalSlot, formatEstimateTime(estimate.WithdrawalTime))
+	fmt.Println()
+	fmt.Printf("%sNOTE: The withdrawal sweep currently takes about %s to pass over every validator. These are estimates; a busier exit queue or missed slots will delay them.%s\n", colorYellow, estimate.SweepDuration.Round(time.Minute), colorReset)
+	return nil
+
+}
+
+// Format an estimated time along with how far away it is
+func formatEstimateTime(t time.Time) string {
+	remaining := time.Until(t).Round(time.Minute)
+	if remaining <= 0 {
+		return fmt.Sprintf("%s (passed)", t.Format(TimeFormat))
+	}
+	return fmt.Sprintf("%s (in %s)", t.Format(TimeFormat), remaining)
+}
@@ -0,0 +1,53 @@
+package minipool
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool-cli/wallet"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+func exportMinipoolDepositData(c *cli.Context, minipoolAddress common.Address) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Explain what's about to happen
+	fmt.Printf("%sThis will create the deposit data and keystore for minipool %s's validator, so it can be run by a staking service or another Validator Client.\n", colorYellow, minipoolAddress.Hex())
+	fmt.Println("Anyone with the keystore and its password can run the validator. Never run it in more than one place at a time, or it will be slashed.")
+	fmt.Printf("If you move it somewhere else, stop your own Validator Client from running it first and wait at least 15 minutes.%s\n\n", colorReset)
+	if !(c.Bool("yes") || cliutils.Confirm("Would you like to continue?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Get the keystore password
+	password := wallet.PromptKeystorePassword()
+
+	// Create the files
+	response, err := rp.ExportMinipoolDepositData(minipoolAddress, password)
+	if err != nil {
+		return err
+	}
+	depositDataPath, keystorePath, err := validator.SaveExportedValidator(c.String("output"), response)
+	if err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Println()
+	fmt.Printf("Validator pubkey:  %s\n", response.Pubkey.Hex())
+	fmt.Printf("Deposit data:      %s\n", depositDataPath)
+	fmt.Printf("Keystore:          %s\n", keystorePath)
+	return nil
+
+}
@@ -0,0 +1,254 @@
+package minipool
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	rocketpoolapi "github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func recoverMinipools(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Diagnose the stuck minipools
+	response, err := rp.GetStuckMinipools()
+	if err != nil {
+		return err
+	}
+	if len(response.Minipools) == 0 {
+		fmt.Println("None of your minipools are stuck in prelaunch or dissolved.")
+		return nil
+	}
+
+	// Print the diagnoses
+	actionable := []api.StuckMinipoolDiagnosis{}
+	for _, mp := range response.Minipools {
+		fmt.Printf("%sMinipool %s%s (%s since %s)\n", colorBlue, mp.Address.Hex(), colorReset, mp.MinipoolStatus.String(), mp.StatusTime.Format(TimeFormat))
+		fmt.Printf("\t%s\n", mp.Explanation)
+		switch mp.Remediation {
+		case api.StuckMinipoolRemediation_Wait:
+			if mp.WaitTime > 0 {
+				fmt.Printf("\tNext step: wait about %s.\n", mp.WaitTime.Round(time.Minute))
+			} else {
+				fmt.Println("\tNext step: wait.")
+			}
+		case api.StuckMinipoolRemediation_RecoverKey:
+			fmt.Printf("\t%sNext step: recover the validator key within %s.%s\n", colorYellow, mp.WaitTime.Round(time.Minute), colorReset)
+		case api.StuckMinipoolRemediation_Stake:
+			fmt.Printf("\t%sNext step: stake the minipool within %s.%s\n", colorYellow, mp.WaitTime.Round(time.Minute), colorReset)
+		case api.StuckMinipoolRemediation_None:
+			fmt.Printf("\t%sThere is no supported fix for this minipool.%s\n", colorRed, colorReset)
+		default:
+			fmt.Printf("\tNext step: %s the minipool.\n", getRemediationName(mp.Remediation))
+		}
+		if mp.RefundAvailable {
+			fmt.Printf("\tThe minipool also has %.6f ETH to refund to the node; use `rocketpool minipool refund` to claim it.\n", eth.WeiToEth(mp.RefundBalance))
+		}
+		fmt.Println()
+		if isActionable(mp.Remediation) {
+			actionable = append(actionable, mp)
+		}
+	}
+	if len(actionable) == 0 {
+		fmt.Println("There is nothing to do right now.")
+		return nil
+	}
+
+	// Pick the minipool to fix
+	var selected api.StuckMinipoolDiagnosis
+	if c.String("minipool") != "" {
+		address := common.HexToAddress(c.String("minipool"))
+		found := false
+		for _, mp := range actionable {
+			if mp.Address == address {
+				selected = mp
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("Minipool %s doesn't have a fix that can be applied right now.", address.Hex())
+		}
+	} else {
+		options := make([]string, len(actionable))
+		for i, mp := range actionable {
+			options[i] = fmt.Sprintf("%s (%s)", mp.Address.Hex(), getRemediationName(mp.Remediation))
+		}
+		index, _ := cliutils.Select("Please select a minipool to fix:", options)
+		selected = actionable[index]
+	}
+
+	// Apply the fix
+	switch selected.Remediation {
+	case api.StuckMinipoolRemediation_Stake:
+		return recoverByStaking(c, rp, selected.Address)
+	case api.StuckMinipoolRemediation_Dissolve:
+		return recoverByDissolving(c, rp, selected.Address)
+	case api.StuckMinipoolRemediation_Close:
+		return recoverByClosing(c, rp, selected.Address)
+	case api.StuckMinipoolRemediation_Rescue:
+		// Rescues send ETH to the Beacon deposit contract, so they go through the dedicated command and its checks
+		fmt.Printf("Rescuing a minipool means depositing the rest of its 32 ETH yourself. Run `rocketpool minipool rescue-dissolved --minipool %s` to do it.\n", selected.Address.Hex())
+	case api.StuckMinipoolRemediation_Exit:
+		// Exits can't be undone, so they go through the dedicated command and its checks
+		fmt.Printf("Exiting a validator can't be undone. Run `rocketpool minipool exit --minipool %s` to do it, then come back here to close the minipool once its balance is withdrawn.\n", selected.Address.Hex())
+	}
+	return nil
+
+}
+
+// Stake a minipool that passed its scrub check
+func recoverByStaking(c *cli.Context, rp *rocketpool.Client, address common.Address) error {
+
+	// Check it can still be staked
+	canStake, err := rp.CanStakeMinipool(address)
+	if err != nil {
+		return err
+	}
+	if !canStake.CanStake {
+		return fmt.Errorf("Minipool %s can't be staked right now.", address.Hex())
+	}
+
+	// Submit it
+	fmt.Println("NOTE: Your Validator Client must be restarted after staking so it loads the new validator key.")
+	return submitRecovery(c, rp, address, canStake.GasInfo, "stake", func() (common.Hash, error) {
+		response, err := rp.StakeMinipool(address)
+		return response.TxHash, err
+	})
+
+}
+
+// Dissolve a minipool that missed its launch timeout
+func recoverByDissolving(c *cli.Context, rp *rocketpool.Client, address common.Address) error {
+
+	// Check it can still be dissolved
+	canDissolve, err := rp.CanDissolveMinipool(address)
+	if err != nil {
+		return err
+	}
+	if !canDissolve.CanDissolve {
+		return fmt.Errorf("Minipool %s can't be dissolved right now.", address.Hex())
+	}
+	if canDissolve.GasInfo.EstGasLimit == 0 {
+		return fmt.Errorf("The dissolve transaction for minipool %s would fail; its launch timeout may not have passed yet.", address.Hex())
+	}
+
+	// Submit it
+	err = submitRecovery(c, rp, address, canDissolve.GasInfo, "dissolve", func() (common.Hash, error) {
+		response, err := rp.DissolveMinipool(address)
+		return response.TxHash, err
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println("Run `rocketpool minipool recover` again to see how to get the minipool's bond back.")
+	return nil
+
+}
+
+// Close a dissolved minipool that doesn't have a validator left on the Beacon Chain
+func recoverByClosing(c *cli.Context, rp *rocketpool.Client, address common.Address) error {
+
+	// Check it can still be closed
+	details, err := rp.GetMinipoolCloseDetailsForNode()
+	if err != nil {
+		return err
+	}
+	if !details.IsFeeDistributorInitialized {
+		return fmt.Errorf("Minipools can't be closed until your fee distributor has been initialized. Please run `rocketpool node initialize-fee-distributor` first.")
+	}
+	var closeDetails *api.MinipoolCloseDetails
+	for i, mp := range details.Details {
+		if mp.Address == address {
+			closeDetails = &details.Details[i]
+			break
+		}
+	}
+	if closeDetails == nil || !closeDetails.CanClose {
+		return fmt.Errorf("Minipool %s can't be closed right now.", address.Hex())
+	}
+	fmt.Printf("Closing the minipool will send %.6f ETH to your node's withdrawal address.\n", eth.WeiToEth(closeDetails.NodeShare))
+
+	// Submit it
+	return submitRecovery(c, rp, address, closeDetails.GasInfo, "close", func() (common.Hash, error) {
+		response, err := rp.CloseMinipool(address)
+		return response.TxHash, err
+	})
+
+}
+
+// Confirm a recovery transaction, submit it, and wait for it
+func submitRecovery(c *cli.Context, rp *rocketpool.Client, address common.Address, gasInfo rocketpoolapi.GasInfo, action string, submit func() (common.Hash, error)) error {
+
+	// Assign max fees
+	err := gas.AssignMaxFeeAndLimit(gasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to %s minipool %s?", action, address.Hex()))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Submit the transaction
+	txHash, err := submit()
+	if err != nil {
+		return fmt.Errorf("Could not %s minipool %s: %w", action, address.Hex(), err)
+	}
+	fmt.Printf("Submitting the %s transaction for minipool %s...\n", action, address.Hex())
+	cliutils.PrintTransactionHash(rp, txHash)
+	if _, err = rp.WaitForTransaction(txHash); err != nil {
+		return fmt.Errorf("Could not %s minipool %s: %w", action, address.Hex(), err)
+	}
+	fmt.Printf("Successfully submitted the %s transaction for minipool %s.\n", action, address.Hex())
+	return nil
+
+}
+
+// Check if a remediation can be started from the recovery assistant
+func isActionable(remediation api.StuckMinipoolRemediation) bool {
+	switch remediation {
+	case api.StuckMinipoolRemediation_Stake,
+		api.StuckMinipoolRemediation_Dissolve,
+		api.StuckMinipoolRemediation_Close,
+		api.StuckMinipoolRemediation_Rescue,
+		api.StuckMinipoolRemediation_Exit:
+		return true
+	}
+	return false
+}
+
+// Get a readable name for a remediation
+func getRemediationName(remediation api.StuckMinipoolRemediation) string {
+	switch remediation {
+	case api.StuckMinipoolRemediation_Stake:
+		return "stake"
+	case api.StuckMinipoolRemediation_Dissolve:
+		return "dissolve"
+	case api.StuckMinipoolRemediation_Close:
+		return "close"
+	case api.StuckMinipoolRemediation_Rescue:
+		return "rescue"
+	case api.StuckMinipoolRemediation_Exit:
+		return "exit"
+	case api.StuckMinipoolRemediation_RecoverKey:
+		return "recover the key for"
+	}
+	return string(remediation)
+}
@@ -0,0 +1,95 @@
+package minipool
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+func getRewardsLedger(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the ledger
+	response, err := rp.GetRewardsLedger()
+	if err != nil {
+		return err
+	}
+	if !response.LedgerExists {
+		fmt.Println("The node daemon hasn't started tracking your minipools' withdrawals yet. Please make sure it's running and check back in a few minutes.")
+		return nil
+	}
+	fmt.Printf("Tracking withdrawals since slot %d; scanned up to slot %d (EL block %d).\n\n", response.StartSlot, response.LastScannedSlot, response.LastScannedBlock)
+	if len(response.Reconciliations) == 0 {
+		fmt.Println("None of the node's minipools have a validator on the Beacon Chain yet.")
+		return nil
+	}
+
+	// Print the history of a single minipool
+	if c.String("minipool") != "" {
+		address := common.HexToAddress(c.String("minipool"))
+		history, exists := response.History[address]
+		if !exists {
+			return fmt.Errorf("minipool %s is not in the rewards ledger", address.Hex())
+		}
+		fmt.Printf("Minipool %s (validator %s), tracked since block %d with a starting balance of %.6f ETH\n\n", address.Hex(), history.ValidatorIndex, history.StartBlock, math.RoundDown(eth.WeiToEth(history.StartBalance), 6))
+		fmt.Println("Withdrawals:")
+		if len(history.Withdrawals) == 0 {
+			fmt.Println("  (none)")
+		}
+		for _, withdrawal := range history.Withdrawals {
+			fmt.Printf("  Slot %-10d Block %-10d %.6f ETH\n", withdrawal.Slot, withdrawal.Block, float64(withdrawal.AmountGwei)/1e9)
+		}
+		fmt.Println()
+		fmt.Println("Distributions:")
+		if len(history.Distributions) == 0 {
+			fmt.Println("  (none)")
+		}
+		for _, distribution := range history.Distributions {
+			fmt.Printf("  Block %-10d %.6f ETH to the node, %.6f ETH to rETH (tx %s)\n", distribution.Block, math.RoundDown(eth.WeiToEth(distribution.NodeAmount), 6), math.RoundDown(eth.WeiToEth(distribution.UserAmount), 6), distribution.TxHash.Hex())
+		}
+		fmt.Println()
+	}
+
+	// Print the reconciliation of each minipool
+	fmt.Printf("%-42s  %9s  %14s  %14s  %14s  %14s\n", "Minipool", "Skims", "Withdrawn", "Distributed", "Balance", "Discrepancy")
+	discrepancies := 0
+	for _, reconciliation := range response.Reconciliations {
+		if c.String("minipool") != "" && reconciliation.Address != common.HexToAddress(c.String("minipool")) {
+			continue
+		}
+		line := fmt.Sprintf("%-42s  %9d  %14.6f  %14.6f  %14.6f  %+14.6f",
+			reconciliation.Address.Hex(),
+			reconciliation.WithdrawalCount,
+			eth.WeiToEth(reconciliation.TotalWithdrawn),
+			eth.WeiToEth(reconciliation.TotalDistributed),
+			eth.WeiToEth(reconciliation.ActualBalance),
+			eth.WeiToEth(reconciliation.Discrepancy),
+		)
+		if reconciliation.Discrepancy.Sign() != 0 {
+			discrepancies++
+			line = colorYellow + line + colorReset
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+
+	if discrepancies == 0 {
+		fmt.Println("Every tracked withdrawal and distribution is accounted for in the minipools' balances.")
+	} else {
+		fmt.Printf("%s%d minipool(s) have a balance that doesn't match their tracked withdrawals and distributions.%s\n", colorYellow, discrepancies, colorReset)
+		fmt.Println("This can be caused by ETH sent to the minipool directly, refunds, or withdrawals the daemon missed while it was offline. Use --minipool to see a minipool's full history.")
+	}
+	return nil
+
+}
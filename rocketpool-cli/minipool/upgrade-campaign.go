@@ -0,0 +1,175 @@
+package minipool
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func delegateUpgradeCampaign(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Update the auto-upgrade settings if requested
+	if c.String("auto-upgrade") != "" {
+		if err := setAutoUpgradeDelegate(c, rp); err != nil {
+			return err
+		}
+	}
+
+	// Survey the minipools
+	campaign, err := rp.GetDelegateUpgradeCampaign()
+	if err != nil {
+		return err
+	}
+	if len(campaign.Minipools) == 0 {
+		fmt.Println("The node does not have any minipools yet.")
+		return nil
+	}
+
+	// Get the current gas price so the costs can be estimated
+	maxFee, err := gas.GetHeadlessMaxFeeWei()
+	if err != nil {
+		fmt.Printf("%sWARNING: couldn't get the current gas price, so costs won't be shown: %s%s\n\n", colorYellow, err.Error(), colorReset)
+		maxFee = nil
+	}
+
+	// Print the survey
+	fmt.Printf("The latest minipool delegate is %s.\n\n", campaign.LatestDelegate.Hex())
+	outdated := 0
+	optedInOutdated := 0
+	totalGas := uint64(0)
+	optedInGas := uint64(0)
+	for _, mp := range campaign.Minipools {
+		var state string
+		switch {
+		case mp.UseLatestDelegate:
+			state = fmt.Sprintf("%sup to date (always uses the latest delegate)%s", colorGreen, colorReset)
+		case !mp.Outdated:
+			state = fmt.Sprintf("%sup to date%s", colorGreen, colorReset)
+		default:
+			state = fmt.Sprintf("%soutdated (%s)%s", colorYellow, mp.Delegate.Hex(), colorReset)
+			outdated++
+			totalGas += mp.GasInfo.EstGasLimit
+			if mp.AutoUpgrade {
+				optedInOutdated++
+				optedInGas += mp.GasInfo.EstGasLimit
+			}
+		}
+		autoUpgrade := "no"
+		if mp.AutoUpgrade {
+			autoUpgrade = "yes"
+		}
+		fmt.Printf("%s: %s, auto-upgrade: %s", mp.Address.Hex(), state, autoUpgrade)
+		if mp.Outdated && mp.GasInfo.EstGasLimit > 0 {
+			fmt.Printf(", ~%d gas", mp.GasInfo.EstGasLimit)
+		}
+		fmt.Println()
+		if mp.LastUpgradeTxHash != (common.Hash{}) {
+			fmt.Printf("\tlast upgraded automatically on %s (%s)\n", mp.LastUpgradeTime.Format("2006-01-02 15:04 MST"), mp.LastUpgradeTxHash.Hex())
+		}
+	}
+	fmt.Println()
+
+	// Print the totals
+	if outdated == 0 {
+		fmt.Printf("%sAll of your minipools are up to date.%s\n", colorGreen, colorReset)
+	} else {
+		fmt.Printf("%d of %d minipool(s) have an outdated delegate, needing about %d gas in total", outdated, len(campaign.Minipools), totalGas)
+		if maxFee != nil {
+			fmt.Printf(" (%.6f ETH at %.2f gwei)", getDelegateUpgradeCost(totalGas, maxFee), eth.WeiToGwei(maxFee))
+		}
+		fmt.Println(".")
+	}
+
+	// Print the campaign progress
+	fmt.Println()
+	fmt.Printf("%d delegate upgrade(s) have been submitted automatically so far, costing up to %.6f ETH in the last 24 hours.\n", campaign.UpgradeCount, eth.WeiToEth(campaign.SpentInWindow))
+	if optedInOutdated > 0 {
+		fmt.Printf("%d opted-in minipool(s) are waiting for an upgrade", optedInOutdated)
+		if campaign.BatchSize > 0 {
+			fmt.Printf(", which will be made in batches of up to %d", campaign.BatchSize)
+		}
+		if maxFee != nil && campaign.DailyBudget.Sign() > 0 {
+			budget := eth.WeiToEth(campaign.DailyBudget)
+			days := math.Ceil(getDelegateUpgradeCost(optedInGas, maxFee) / budget)
+			fmt.Printf(" within a daily gas budget of %.6f ETH (about %.0f day(s) at the current gas price)", budget, days)
+		}
+		fmt.Println(".")
+	}
+	if !campaign.AutoUpgradeEnabled {
+		fmt.Printf("\n%sAutomatic delegate upgrades are disabled, so opted-in minipools won't be upgraded. Enable them with the 'Auto-Upgrade Delegates' setting in `rocketpool service config`.%s\n", colorYellow, colorReset)
+	}
+	if outdated > optedInOutdated {
+		fmt.Println("\nOpt minipools into automatic upgrades with `rocketpool minipool upgrade-campaign --auto-upgrade on --minipool <address or 'all'>`, or upgrade them right away with `rocketpool minipool delegate-upgrade`.")
+	}
+
+	// Return
+	return nil
+
+}
+
+// Opt the selected minipools in or out of automatic delegate upgrades
+func setAutoUpgradeDelegate(c *cli.Context, rp *rocketpool.Client) error {
+
+	// Get the setting
+	var enabled bool
+	switch c.String("auto-upgrade") {
+	case "on":
+		enabled = true
+	case "off":
+		enabled = false
+	default:
+		return fmt.Errorf("Invalid auto-upgrade setting '%s' - must be 'on' or 'off'", c.String("auto-upgrade"))
+	}
+
+	// Get the selected minipools
+	var addresses []common.Address
+	if c.String("minipool") == "" || c.String("minipool") == "all" {
+		status, err := rp.MinipoolStatus()
+		if err != nil {
+			return err
+		}
+		for _, mp := range status.Minipools {
+			if !mp.Finalised {
+				addresses = append(addresses, mp.Address)
+			}
+		}
+	} else {
+		addresses = []common.Address{common.HexToAddress(c.String("minipool"))}
+	}
+	if len(addresses) == 0 {
+		fmt.Println("No minipools were selected.")
+		return nil
+	}
+
+	// Update them
+	response, err := rp.SetAutoUpgradeDelegate(addresses, enabled)
+	if err != nil {
+		return err
+	}
+	if enabled {
+		fmt.Printf("Opted %d minipool(s) into automatic delegate upgrades.\n\n", len(response.Updated))
+	} else {
+		fmt.Printf("Opted %d minipool(s) out of automatic delegate upgrades.\n\n", len(response.Updated))
+	}
+	return nil
+
+}
+
+// Get the cost of upgrades in ETH
+func getDelegateUpgradeCost(gas uint64, maxFee *big.Int) float64 {
+	return eth.WeiToEth(new(big.Int).Mul(maxFee, new(big.Int).SetUint64(gas)))
+}
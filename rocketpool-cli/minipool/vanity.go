@@ -1,23 +1,31 @@
 package minipool
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"math"
 	"math/big"
+	"os"
+	"os/signal"
 	"runtime"
 	"strings"
-	"sync"
+	"syscall"
 	"time"
 
 	"github.com/dustin/go-humanize"
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/vanity"
+	"github.com/rocket-pool/smartnode/shared/types/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
+// How often to print the search progress and save the checkpoint
+const vanityReportInterval = 5 * time.Second
+
 func findVanitySalt(c *cli.Context) error {
 
 	// Get RP client
@@ -35,20 +43,25 @@ func findVanitySalt(c *cli.Context) error {
 	if !strings.HasPrefix(prefix, "0x") {
 		return fmt.Errorf("Prefix must start with 0x.")
 	}
-	targetPrefix, success := big.NewInt(0).SetString(prefix, 0)
-	if !success {
-		return fmt.Errorf("Invalid prefix: %s", prefix)
-	}
 
-	// Get the starting salt
-	saltString := c.String("salt")
-	var salt *big.Int
-	if saltString == "" {
-		salt = big.NewInt(0)
-	} else {
-		salt, success = big.NewInt(0).SetString(saltString, 0)
+	// Get the search range
+	start := big.NewInt(0)
+	if c.String("salt") != "" {
+		var success bool
+		start, success = big.NewInt(0).SetString(c.String("salt"), 0)
 		if !success {
-			return fmt.Errorf("Invalid starting salt: %s", salt)
+			return fmt.Errorf("Invalid starting salt: %s", c.String("salt"))
+		}
+	}
+	var end *big.Int
+	if c.String("end-salt") != "" {
+		var success bool
+		end, success = big.NewInt(0).SetString(c.String("end-salt"), 0)
+		if !success {
+			return fmt.Errorf("Invalid ending salt: %s", c.String("end-salt"))
+		}
+		if end.Cmp(start) <= 0 {
+			return fmt.Errorf("The ending salt must be greater than the starting salt.")
 		}
 	}
 
@@ -62,6 +75,178 @@ func findVanitySalt(c *cli.Context) error {
 		threads = runtime.GOMAXPROCS(0)
 	}
 
+	// Get the vanity generation artifacts
+	vanityArtifacts, err := getVanityArtifacts(c, rp)
+	if err != nil {
+		return err
+	}
+	target, err := vanity.NewTarget(vanityArtifacts.NodeAddress, vanityArtifacts.MinipoolFactoryAddress, vanityArtifacts.InitHash, prefix)
+	if err != nil {
+		return err
+	}
+	job := vanity.NewJob(target, start, end)
+
+	// Export the job for an external searcher if requested
+	if c.String("export-job") != "" {
+		jobBytes, err := json.MarshalIndent(job, "", "  ")
+		if err != nil {
+			return fmt.Errorf("Error serializing the search job: %w", err)
+		}
+		if err := os.WriteFile(c.String("export-job"), jobBytes, 0644); err != nil {
+			return fmt.Errorf("Error saving the search job: %w", err)
+		}
+		fmt.Printf("Saved the search job to %s.\n", c.String("export-job"))
+		fmt.Println("Once your searcher finds a salt, check it with `rocketpool minipool verify-vanity-salt` before using it.")
+		return nil
+	}
+
+	// Resume from the checkpoint if there is one
+	statePath := c.String("checkpoint")
+	state := &vanity.SearchState{
+		Job:        job,
+		Checkpoint: job.Start,
+	}
+	if statePath != "" {
+		savedState, err := vanity.LoadState(statePath)
+		if err != nil {
+			return err
+		}
+		if savedState != nil {
+			if !savedState.Matches(job) {
+				return fmt.Errorf("The checkpoint file %s is for a different search; please use a new file or remove it.", statePath)
+			}
+			if savedState.FoundSalt != "" {
+				fmt.Printf("This search already finished: salt %s = %s\n", savedState.FoundSalt, savedState.FoundAddress)
+				return nil
+			}
+			state = savedState
+			fmt.Printf("Resuming from salt %s (%s salts already checked).\n", state.Checkpoint, humanize.Comma(int64(state.Checked)))
+		}
+	}
+	resumeFrom, err := state.GetCheckpoint()
+	if err != nil {
+		return err
+	}
+	previouslyChecked := state.Checked
+
+	// Stop cleanly on Ctrl+C so the checkpoint is saved
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Print the progress and save the checkpoint as the search goes
+	expectedAttempts := target.ExpectedAttempts()
+	report := func(progress vanity.Progress) {
+		checked := previouslyChecked + progress.Checked
+		chance := (1 - math.Exp(-float64(checked)/expectedAttempts)) * 100
+		line := fmt.Sprintf("At salt 0x%x... %s checked, %.1f%% chance of a match by now", progress.Checkpoint, humanize.Comma(int64(checked)), chance)
+		if progress.Rate > 0 {
+			rate, suffix := humanize.ComputeSI(progress.Rate)
+			line += fmt.Sprintf(" (%s salts/sec, %s)", humanize.FtoaWithDigits(rate, 2)+suffix, progress.Elapsed.Round(time.Second))
+		}
+		fmt.Println(line)
+		if statePath != "" {
+			state.Checkpoint = fmt.Sprintf("0x%x", progress.Checkpoint)
+			state.Checked = checked
+			if err := vanity.SaveState(statePath, state); err != nil {
+				fmt.Printf("WARNING: couldn't save the checkpoint: %s\n", err.Error())
+			}
+		}
+	}
+
+	// Run the search
+	fmt.Printf("Searching for a minipool address starting with %s; this takes about %s salts on average.\n", target.Prefix, humanize.Comma(int64(expectedAttempts)))
+	var result *vanity.Result
+	startTime := time.Now()
+	if c.String("worker") != "" {
+		fmt.Printf("Running external worker: %s\n", c.String("worker"))
+		result, err = vanity.RunWorker(ctx, c.String("worker"), vanity.NewJob(target, resumeFrom, end), report)
+	} else {
+		fmt.Printf("Running with %d threads.\n", threads)
+		var progress vanity.Progress
+		result, progress, err = vanity.Search(ctx, target, resumeFrom, end, threads, vanityReportInterval, report)
+		if err == nil && result == nil {
+			report(progress)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Finished in %s\n", time.Since(startTime))
+
+	// Print the result
+	if result == nil {
+		if ctx.Err() != nil {
+			fmt.Println("The search was interrupted.")
+			if statePath != "" {
+				fmt.Printf("Run the same command with `--checkpoint %s` to resume it.\n", statePath)
+			}
+		} else {
+			fmt.Println("No matching salt was found in the search range.")
+		}
+		return nil
+	}
+	fmt.Printf("Found salt 0x%x = %s\n", result.Salt, result.Address.Hex())
+	fmt.Printf("Use it with `rocketpool node deposit --salt 0x%x`.\n", result.Salt)
+	if statePath != "" {
+		state.FoundSalt = fmt.Sprintf("0x%x", result.Salt)
+		state.FoundAddress = result.Address.Hex()
+		if err := vanity.SaveState(statePath, state); err != nil {
+			return err
+		}
+	}
+
+	// Return
+	return nil
+
+}
+
+// Show the minipool address a salt produces, and check it against a prefix if one was provided
+func verifyVanitySalt(c *cli.Context, saltString string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Parse the salt
+	salt, success := big.NewInt(0).SetString(saltString, 0)
+	if !success || salt.Sign() < 0 {
+		return fmt.Errorf("Invalid salt: %s", saltString)
+	}
+
+	// Get the vanity generation artifacts
+	vanityArtifacts, err := getVanityArtifacts(c, rp)
+	if err != nil {
+		return err
+	}
+	prefix := c.String("prefix")
+	if prefix == "" {
+		prefix = "0x0"
+	}
+	target, err := vanity.NewTarget(vanityArtifacts.NodeAddress, vanityArtifacts.MinipoolFactoryAddress, vanityArtifacts.InitHash, prefix)
+	if err != nil {
+		return err
+	}
+
+	// Check the salt
+	address := target.GetAddress(salt)
+	fmt.Printf("Salt 0x%x creates minipool %s for node %s.\n", salt, address.Hex(), vanityArtifacts.NodeAddress.Hex())
+	if c.String("prefix") == "" {
+		return nil
+	}
+	if _, err := target.Verify(salt); err != nil {
+		return fmt.Errorf("The salt doesn't match: %w", err)
+	}
+	fmt.Printf("%sThe address matches the prefix %s.%s\n", colorGreen, target.Prefix, colorReset)
+	return nil
+
+}
+
+// Get the node address and the contract details that determine minipool addresses
+func getVanityArtifacts(c *cli.Context, rp *rocketpool.Client) (api.GetVanityArtifactsResponse, error) {
+
 	// Get the node address
 	nodeAddressStr := c.String("node-address")
 	if nodeAddressStr == "" {
@@ -72,8 +257,9 @@ func findVanitySalt(c *cli.Context) error {
 	var amount float64
 	if c.String("amount") != "" {
 		// Parse amount
+		var err error
 		if amount, err = cliutils.ValidatePositiveEthAmount("deposit", c.String("amount")); err != nil {
-			return err
+			return api.GetVanityArtifactsResponse{}, err
 		}
 	} else {
 		// Get deposit amount options
@@ -93,123 +279,6 @@ func findVanitySalt(c *cli.Context) error {
 	}
 	amountWei := eth.EthToWei(amount)
 
-	// Get the vanity generation artifacts
-	vanityArtifacts, err := rp.GetVanityArtifacts(amountWei, nodeAddressStr)
-	if err != nil {
-		return err
-	}
-
-	// Set up some variables
-	nodeAddress := vanityArtifacts.NodeAddress.Bytes()
-	minipoolFactoryAddress := vanityArtifacts.MinipoolFactoryAddress
-	initHash := vanityArtifacts.InitHash.Bytes()
-	shiftAmount := uint(42 - len(prefix))
-
-	// Run the search
-	fmt.Printf("Running with %d threads.\n", threads)
-
-	wg := new(sync.WaitGroup)
-	wg.Add(threads)
-	stop := false
-	stopPtr := &stop
-
-	// Spawn worker threads
-	start := time.Now()
-	for i := 0; i < threads; i++ {
-		saltOffset := big.NewInt(int64(i))
-		workerSalt := big.NewInt(0).Add(salt, saltOffset)
-
-		go func(i int) {
-			foundSalt, foundAddress := runWorker(i == 0, stopPtr, targetPrefix, nodeAddress, minipoolFactoryAddress, initHash, workerSalt, int64(threads), shiftAmount)
-			if foundSalt != nil {
-				fmt.Printf("Found on thread %d: salt 0x%x = %s\n", i, foundSalt, foundAddress.Hex())
-				*stopPtr = true
-			}
-			wg.Done()
-		}(i)
-	}
-
-	// Wait for the workers to finish and print the elapsed time
-	wg.Wait()
-	end := time.Now()
-	elapsed := end.Sub(start)
-	fmt.Printf("Finished in %s\n", elapsed)
+	return rp.GetVanityArtifacts(amountWei, nodeAddressStr)
 
-	// Return
-	return nil
-
-}
-
-func runWorker(report bool, stop *bool, targetPrefix *big.Int, nodeAddress []byte, minipoolManagerAddress common.Address, initHash []byte, salt *big.Int, increment int64, shiftAmount uint) (*big.Int, common.Address) {
-	saltBytes := [32]byte{}
-	hashInt := big.NewInt(0)
-	incrementInt := big.NewInt(increment)
-	hasher := crypto.NewKeccakState()
-	nodeSalt := common.Hash{}
-	addressResult := common.Hash{}
-
-	// Set up the reporting ticker if requested
-	var ticker *time.Ticker
-	var tickerChan chan struct{}
-	lastSalt := big.NewInt(0).Set(salt)
-	if report {
-		start := time.Now()
-		reportInterval := 5 * time.Second
-		ticker = time.NewTicker(reportInterval)
-		tickerChan = make(chan struct{})
-		go func() {
-			for {
-				select {
-				case <-ticker.C:
-					delta := big.NewInt(0).Sub(salt, lastSalt)
-					deltaFloat, suffix := humanize.ComputeSI(float64(delta.Uint64()) / 5.0)
-					deltaString := humanize.FtoaWithDigits(deltaFloat, 2) + suffix
-					fmt.Printf("At salt 0x%x... %s (%s salts/sec)\n", salt, time.Since(start), deltaString)
-					lastSalt.Set(salt)
-				case <-tickerChan:
-					ticker.Stop()
-					return
-				}
-			}
-		}()
-	}
-
-	// Run the main salt finder loop
-	for {
-		if *stop {
-			return nil, common.Address{}
-		}
-
-		// Some speed optimizations -
-		// This block is the fast way to do `nodeSalt := crypto.Keccak256Hash(nodeAddress, saltBytes)`
-		salt.FillBytes(saltBytes[:])
-		hasher.Write(nodeAddress)
-		hasher.Write(saltBytes[:])
-		hasher.Read(nodeSalt[:])
-		hasher.Reset()
-
-		// This block is the fast way to do `crypto.CreateAddress2(minipoolManagerAddress, nodeSalt, initHash)`
-		// except instead of capturing the returned value as an address, we keep it as bytes. The first 12 bytes
-		// are ignored, since they are not part of the resulting address.
-		//
-		// Because we didn't call CreateAddress2 here, we have to call common.BytesToAddress below, but we can
-		// postpone that until we find the correct salt.
-		hasher.Write([]byte{0xff})
-		hasher.Write(minipoolManagerAddress.Bytes())
-		hasher.Write(nodeSalt[:])
-		hasher.Write(initHash)
-		hasher.Read(addressResult[:])
-		hasher.Reset()
-
-		hashInt.SetBytes(addressResult[12:])
-		hashInt.Rsh(hashInt, shiftAmount*4)
-		if hashInt.Cmp(targetPrefix) == 0 {
-			if report {
-				close(tickerChan)
-			}
-			address := common.BytesToAddress(addressResult[12:])
-			return salt, address
-		}
-		salt.Add(salt, incrementInt)
-	}
 }
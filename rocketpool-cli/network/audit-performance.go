@@ -0,0 +1,92 @@
+package network
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+func auditPerformance(c *cli.Context, interval uint64) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+	if c.Uint64("samples") == 0 {
+		return fmt.Errorf("the number of samples must be greater than 0")
+	}
+
+	// Pick a seed if one wasn't provided, so the audit can still be reproduced later
+	seed := c.String("seed")
+	if seed == "" {
+		seedBytes := make([]byte, 8)
+		if _, err := rand.Read(seedBytes); err != nil {
+			return fmt.Errorf("error generating a seed: %w", err)
+		}
+		seed = hex.EncodeToString(seedBytes)
+	}
+
+	fmt.Printf("Auditing %d minipools from the performance file for interval %d with seed %s.\n", c.Uint64("samples"), interval, seed)
+	fmt.Println("This checks every sampled minipool's attestations against your Beacon Node, so it can take a while...")
+	fmt.Println()
+
+	// Run the audit
+	response, err := rp.AuditPerformance(interval, c.Uint64("samples"), c.Uint64("epochs"), seed)
+	if err != nil {
+		return err
+	}
+	if response.PerformanceFileDownloaded {
+		fmt.Println("Downloaded the minipool performance file.")
+	}
+	fullAudit := response.CheckedEpochs == response.TotalEpochs
+	if fullAudit {
+		fmt.Printf("Checked all %d epochs of the interval for %d of the %d minipools in the file.\n\n", response.TotalEpochs, len(response.Minipools), response.TotalMinipools)
+	} else {
+		fmt.Printf("Checked %d of the %d epochs in the interval for %d of the %d minipools in the file.\n", response.CheckedEpochs, response.TotalEpochs, len(response.Minipools), response.TotalMinipools)
+		fmt.Println("The attestation counts and scores only cover those epochs, so they can't be compared with the file's totals.")
+		fmt.Println()
+	}
+
+	// Print the results
+	failed := 0
+	for _, audit := range response.Minipools {
+		result := fmt.Sprintf("%sPASSED%s", colorGreen, colorReset)
+		if !audit.Passed {
+			result = fmt.Sprintf("%sFAILED%s", colorRed, colorReset)
+			failed++
+		}
+		fmt.Printf("%s: %s\n", audit.Address.Hex(), result)
+		if audit.Note != "" {
+			fmt.Printf("\tNote: %s\n", audit.Note)
+		}
+		if fullAudit {
+			fmt.Printf("\tAttestations: %d successful, %d missed (the file reports %d successful, %d missed)\n", audit.Successful, audit.Missed, audit.ReportedSuccessful, audit.ReportedMissed)
+			if audit.Score != nil && audit.ReportedScore != nil {
+				fmt.Printf("\tScore:        %.6f (the file reports %.6f)\n", math.RoundDown(eth.WeiToEth(&audit.Score.Int), 6), math.RoundDown(eth.WeiToEth(&audit.ReportedScore.Int), 6))
+			}
+		} else {
+			fmt.Printf("\tAttestations: %d successful, %d missed in the checked epochs\n", audit.Successful, audit.Missed)
+		}
+		if len(audit.MismatchedSlots) > 0 {
+			fmt.Printf("\tThe file and the Beacon Chain disagree on the duties in slots %v\n", audit.MismatchedSlots)
+		}
+	}
+	fmt.Println()
+
+	if failed == 0 {
+		fmt.Printf("%sAll %d sampled minipools match the performance file.%s\n", colorGreen, len(response.Minipools), colorReset)
+	} else {
+		fmt.Printf("%s%d of the %d sampled minipools don't match the performance file.%s\n", colorRed, failed, len(response.Minipools), colorReset)
+	}
+	fmt.Printf("Use `--seed %s` to repeat this audit with the same sample.\n", seed)
+	return nil
+
+}
@@ -1,6 +1,8 @@
 package network
 
 import (
+	"fmt"
+
 	"github.com/urfave/cli"
 
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
@@ -32,6 +34,24 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "queue-stats",
+				Aliases:   []string{"q"},
+				Usage:     "Show the deposit pool and minipool queue, the recent assignment rate, and how long a new minipool would wait to be matched",
+				UsageText: "rocketpool network queue-stats",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getQueueStats(c)
+
+				},
+			},
+
 			{
 				Name:      "timezone-map",
 				Aliases:   []string{"t"},
@@ -118,6 +138,120 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "rewards-history",
+				Usage:     "Show your node's rewards and attestation performance for every past rewards interval",
+				UsageText: "rocketpool network rewards-history [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "format, f",
+						Usage: "The output format: 'table' or 'csv'",
+						Value: "table",
+					},
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The file to save the history to (prints it if this isn't set)",
+					},
+					cli.BoolFlag{
+						Name:  "skip-performance",
+						Usage: "Don't download the minipool performance files, so the participation rates and percentiles aren't included",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getRewardsHistory(c)
+
+				},
+			},
+
+			{
+				Name:      "minipool-performance",
+				Usage:     "Show the attestation performance of minipools in an interval's performance file, for your own minipools or the worst performers network-wide",
+				UsageText: "rocketpool network minipool-performance [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "interval, i",
+						Usage: "The rewards interval to check (defaults to the latest finalized one)",
+					},
+					cli.StringFlag{
+						Name:  "node, n",
+						Usage: "Only show the minipools of this node address (or 'node' for your own node)",
+					},
+					cli.Float64Flag{
+						Name:  "below, b",
+						Usage: "Only show minipools with a participation rate below this percentage",
+					},
+					cli.Uint64Flag{
+						Name:  "limit, l",
+						Usage: "The most minipools to show (0 to show all of them)",
+						Value: 25,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("node") != "" && c.String("node") != "node" {
+						if _, err := cliutils.ValidateAddress("node address", c.String("node")); err != nil {
+							return err
+						}
+					}
+					if _, err := cliutils.ValidatePercentage("participation rate", fmt.Sprint(c.Float64("below"))); err != nil {
+						return err
+					}
+
+					// Run
+					return getMinipoolPerformance(c)
+
+				},
+			},
+
+			{
+				Name:      "audit-performance",
+				Usage:     "Check a random sample of minipools in an interval's performance file by recomputing their attestation performance from the Beacon Chain",
+				UsageText: "rocketpool network audit-performance [options] interval",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "samples, n",
+						Usage: "The number of minipools to check",
+						Value: 10,
+					},
+					cli.Uint64Flag{
+						Name:  "epochs, e",
+						Usage: "The number of epochs in the interval to check for each minipool (0 to check all of them, which is required to compare the attestation scores)",
+					},
+					cli.StringFlag{
+						Name:  "seed, s",
+						Usage: "The seed for picking the sample; use the seed of a previous audit to repeat it (a random one is used if this isn't set)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					interval, err := cliutils.ValidateUint("interval", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return auditPerformance(c, interval)
+
+				},
+			},
+
 			{
 				Name:      "dao-proposals",
 				Aliases:   []string{"d"},
@@ -134,6 +268,39 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					return getActiveDAOProposals(c)
 
 				},
+				Subcommands: []cli.Command{
+					{
+						Name:      "vote",
+						Aliases:   []string{"v"},
+						Usage:     "Cast a gasless vote on an active Snapshot proposal, signed by the node wallet",
+						UsageText: "rocketpool network dao-proposals vote [options]",
+						Flags: []cli.Flag{
+							cli.StringFlag{
+								Name:  "proposal, p",
+								Usage: "The ID of the Snapshot proposal to vote on",
+							},
+							cli.Uint64Flag{
+								Name:  "choice, c",
+								Usage: "The number of the choice to vote for, starting at 1",
+							},
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm all interactive questions",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return voteOnDAOProposal(c)
+
+						},
+					},
+				},
 			},
 		},
 	})
@@ -27,7 +27,7 @@ func getActiveDAOProposals(c *cli.Context) error {
 	}
 
 	// Print what network we're on
-	err = cliutils.PrintNetwork(cfg.GetNetwork(), isNew)
+	err = cliutils.PrintNetwork(cfg, isNew)
 	if err != nil {
 		return err
 	}
@@ -69,6 +69,7 @@ func getActiveDAOProposals(c *cli.Context) error {
 
 	for _, proposal := range snapshotProposalsResponse.ActiveSnapshotProposals {
 		fmt.Printf("\nTitle: %s\n", proposal.Title)
+		fmt.Printf("ID: %s\n", proposal.Id)
 		currentTimestamp := time.Now().Unix()
 		if currentTimestamp < proposal.Start {
 			fmt.Printf("Start: %s (in %s)\n", cliutils.GetDateTimeString(uint64(proposal.Start)), time.Until(time.Unix(proposal.Start, 0)).Round(time.Second))
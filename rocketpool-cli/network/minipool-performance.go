@@ -0,0 +1,78 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+func getMinipoolPerformance(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the interval
+	interval := "latest"
+	if c.IsSet("interval") {
+		interval = fmt.Sprint(c.Uint64("interval"))
+	}
+
+	// Query the performance file
+	response, err := rp.MinipoolPerformance(interval, c.Float64("below"), c.Uint64("limit"), c.String("node"))
+	if err != nil {
+		return err
+	}
+	if response.PerformanceFileDownloaded {
+		fmt.Println("Downloaded the minipool performance file.")
+	}
+
+	// Print the summary
+	fmt.Printf("%s=== Interval %d ===%s\n", colorGreen, response.Index, colorReset)
+	fmt.Printf("Minipools in the Smoothing Pool: %d\n", response.TotalMinipools)
+	fmt.Printf("Network participation rate:      %.2f%%\n", response.NetworkParticipation)
+	if response.NodeAddress != nil {
+		fmt.Printf("Showing the minipools of node %s", response.NodeAddress.Hex())
+	} else {
+		fmt.Print("Showing the worst performers network-wide")
+	}
+	if c.Float64("below") > 0 {
+		fmt.Printf(" with a participation rate below %.2f%%", c.Float64("below"))
+	}
+	fmt.Printf(" (%d of %d matching).\n\n", len(response.Minipools), response.MatchingMinipools)
+
+	if len(response.Minipools) == 0 {
+		fmt.Println("No minipools matched.")
+		return nil
+	}
+
+	// Print the minipools
+	fmt.Printf("%-42s  %10s  %8s  %13s  %10s  %10s  %s\n", "Minipool", "Successful", "Missed", "Participation", "Percentile", "Score", "ETH Earned")
+	for _, minipool := range response.Minipools {
+		score := "-"
+		if minipool.AttestationScore != nil {
+			score = fmt.Sprintf("%.4f", math.RoundDown(eth.WeiToEth(minipool.AttestationScore), 4))
+		}
+		participationColor := colorReset
+		if minipool.Participation < response.NetworkParticipation {
+			participationColor = colorYellow
+		}
+		fmt.Printf("%-42s  %10d  %8d  %s%12.2f%%%s  %10.1f  %10s  %.6f\n",
+			minipool.Address.Hex(),
+			minipool.SuccessfulAttestations,
+			minipool.MissedAttestations,
+			participationColor, minipool.Participation, colorReset,
+			minipool.Percentile,
+			score,
+			math.RoundDown(eth.WeiToEth(minipool.EthEarned), 6))
+	}
+	return nil
+
+}
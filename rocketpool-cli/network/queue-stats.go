@@ -0,0 +1,82 @@
+package network
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/queue"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// The number of days of history to show in the trend table
+const queueTrendDays int = 7
+
+func getQueueStats(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the queue stats
+	response, err := rp.QueueStats()
+	if err != nil {
+		return err
+	}
+
+	// Print the current state
+	fmt.Printf("%s========== Deposit Pool ==========%s\n", colorGreen, colorReset)
+	fmt.Printf("Block:                   %d\n", response.Block)
+	fmt.Printf("Deposit Pool Balance:    %.6f ETH\n", eth.WeiToEth(response.DepositPoolBalance))
+	fmt.Printf("Queue Demand:            %.6f ETH\n", eth.WeiToEth(response.QueueCapacity))
+	fmt.Printf("Fundable Right Now:      %d minipool(s)\n\n", response.FundableMinipools)
+
+	fmt.Printf("%s========= Minipool Queue =========%s\n", colorGreen, colorReset)
+	fmt.Printf("Queue Length:            %d\n", response.QueueLength)
+	for _, summary := range response.QueueComposition {
+		fmt.Printf("    %2.0f ETH bond:          %d (%.2f ETH needed)\n", eth.WeiToEth(summary.BondAmount), summary.Count, eth.WeiToEth(summary.TotalCapacity))
+	}
+	fmt.Println()
+
+	// Print the estimates
+	fmt.Printf("%s============ Estimates ===========%s\n", colorGreen, colorReset)
+	if !response.AssignmentRateKnown {
+		fmt.Printf("%sThe node daemon hasn't recorded enough queue samples yet to estimate the assignment rate. It records one every %s, so please check back later.%s\n", colorYellow, queue.SampleInterval, colorReset)
+		return nil
+	}
+	historyStart := response.History[0].Time
+	fmt.Printf("Assignment Rate:         %.2f minipools per day (since %s)\n", response.AssignmentsPerDay, historyStart.Local().Format(time.RFC822))
+	if response.NewMinipoolEta == 0 {
+		fmt.Printf("New Minipool ETA:        %sunknown, no minipools have been assigned recently%s\n\n", colorYellow, colorReset)
+	} else {
+		fmt.Printf("New Minipool ETA:        %s\n\n", response.NewMinipoolEta.Round(time.Hour))
+	}
+
+	// Print the trend, using the latest sample on each day
+	fmt.Printf("%s============== Trend =============%s\n", colorGreen, colorReset)
+	fmt.Printf("%-12s %16s %8s\n", "Date", "Deposit Pool", "Queue")
+	daily := []queue.Sample{}
+	for _, sample := range response.History {
+		date := sample.Time.Local().Format(time.DateOnly)
+		if len(daily) > 0 && daily[len(daily)-1].Time.Local().Format(time.DateOnly) == date {
+			daily[len(daily)-1] = sample
+		} else {
+			daily = append(daily, sample)
+		}
+	}
+	if len(daily) > queueTrendDays {
+		daily = daily[len(daily)-queueTrendDays:]
+	}
+	for _, sample := range daily {
+		fmt.Printf("%-12s %12.2f ETH %8d\n", sample.Time.Local().Format(time.DateOnly), eth.WeiToEth(sample.DepositPoolBalance), sample.QueueLength)
+	}
+
+	// Return
+	return nil
+
+}
@@ -0,0 +1,145 @@
+package network
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The date format used in the history
+const historyDateFormat string = "2006-01-02"
+
+func getRewardsHistory(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the history; this goes to stderr so it doesn't end up in a CSV on stdout
+	fmt.Fprintln(os.Stderr, "Getting the rewards history... rewards and performance files that haven't been downloaded yet will be, which can take a while the first time.")
+	response, err := rp.RewardsHistory(!c.Bool("skip-performance"))
+	if err != nil {
+		return err
+	}
+	if len(response.MissingIntervals) > 0 {
+		fmt.Fprintf(os.Stderr, "%sWARNING: the rewards files for intervals %v couldn't be downloaded, so their rewards are not included.%s\n", colorYellow, response.MissingIntervals, colorReset)
+	}
+
+	// Write the history
+	var writer io.Writer = os.Stdout
+	if c.String("output") != "" {
+		file, err := os.Create(c.String("output"))
+		if err != nil {
+			return fmt.Errorf("error creating [%s]: %w", c.String("output"), err)
+		}
+		defer file.Close()
+		writer = file
+	}
+	switch c.String("format") {
+	case "table":
+		printRewardsHistory(writer, response)
+	case "csv":
+		err = writeRewardsHistoryCsv(writer, response.Intervals)
+	default:
+		err = fmt.Errorf("unknown format [%s]; expected 'table' or 'csv'", c.String("format"))
+	}
+	if err != nil {
+		return err
+	}
+
+	if c.String("output") != "" {
+		fmt.Fprintf(os.Stderr, "Saved the rewards history for %d intervals to %s.\n", len(response.Intervals), c.String("output"))
+	}
+	return nil
+
+}
+
+// Print the history as a table with totals
+func printRewardsHistory(writer io.Writer, response api.RewardsHistoryResponse) {
+	fmt.Fprintf(writer, "%s=== Rewards History for %s ===%s\n\n", colorGreen, response.NodeAddress.Hex(), colorReset)
+	fmt.Fprintf(writer, "%8s  %-10s  %-10s  %14s  %14s  %14s  %9s  %13s  %10s  %s\n", "Interval", "Start", "End", "Collateral RPL", "Oracle DAO RPL", "Smoothing ETH", "Minipools", "Participation", "Percentile", "Claimed")
+
+	totalRpl := big.NewInt(0)
+	totalEth := big.NewInt(0)
+	for _, interval := range response.Intervals {
+		participation := "-"
+		percentile := "-"
+		if interval.Minipools > 0 {
+			participation = fmt.Sprintf("%.2f%%", interval.Participation)
+		}
+		if interval.Percentile != nil {
+			percentile = fmt.Sprintf("%.1f", *interval.Percentile)
+		}
+		claimed := "no"
+		if interval.Claimed {
+			claimed = "yes"
+		}
+		if !interval.Available {
+			claimed = "(missing)"
+		}
+		fmt.Fprintf(writer, "%8d  %-10s  %-10s  %14.6f  %14.6f  %14.6f  %9d  %13s  %10s  %s\n",
+			interval.Index,
+			interval.StartTime.Format(historyDateFormat),
+			interval.EndTime.Format(historyDateFormat),
+			eth.WeiToEth(interval.CollateralRpl),
+			eth.WeiToEth(interval.OracleDaoRpl),
+			eth.WeiToEth(interval.SmoothingPoolEth),
+			interval.Minipools,
+			participation,
+			percentile,
+			claimed)
+		totalRpl.Add(totalRpl, interval.CollateralRpl)
+		totalRpl.Add(totalRpl, interval.OracleDaoRpl)
+		totalEth.Add(totalEth, interval.SmoothingPoolEth)
+	}
+
+	fmt.Fprintln(writer)
+	fmt.Fprintf(writer, "Total RPL earned:           %.6f\n", eth.WeiToEth(totalRpl))
+	fmt.Fprintf(writer, "Total Smoothing Pool ETH:   %.6f\n", eth.WeiToEth(totalEth))
+}
+
+// Write the history as a CSV with one row per interval
+func writeRewardsHistoryCsv(writer io.Writer, intervals []api.RewardsHistoryInterval) error {
+	csvWriter := csv.NewWriter(writer)
+	err := csvWriter.Write([]string{"interval", "start", "end", "available", "claimed", "collateral_rpl", "oracle_dao_rpl", "smoothing_pool_eth", "minipools", "participation", "percentile"})
+	if err != nil {
+		return fmt.Errorf("error writing the CSV header: %w", err)
+	}
+	for _, interval := range intervals {
+		percentile := ""
+		if interval.Percentile != nil {
+			percentile = fmt.Sprintf("%.4f", *interval.Percentile)
+		}
+		err := csvWriter.Write([]string{
+			fmt.Sprint(interval.Index),
+			interval.StartTime.UTC().Format(time.RFC3339),
+			interval.EndTime.UTC().Format(time.RFC3339),
+			fmt.Sprint(interval.Available),
+			fmt.Sprint(interval.Claimed),
+			strconv.FormatFloat(eth.WeiToEth(interval.CollateralRpl), 'f', 18, 64),
+			strconv.FormatFloat(eth.WeiToEth(interval.OracleDaoRpl), 'f', 18, 64),
+			strconv.FormatFloat(eth.WeiToEth(interval.SmoothingPoolEth), 'f', 18, 64),
+			fmt.Sprint(interval.Minipools),
+			fmt.Sprintf("%.4f", interval.Participation),
+			percentile,
+		})
+		if err != nil {
+			return fmt.Errorf("error writing interval %d: %w", interval.Index, err)
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
@@ -0,0 +1,130 @@
+package network
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func voteOnDAOProposal(c *cli.Context) error {
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get active DAO proposals
+	snapshotProposalsResponse, err := rp.GetActiveDAOProposals()
+	if err != nil {
+		return err
+	}
+
+	// Get the proposals that have started voting
+	votableProposals := []api.SnapshotProposal{}
+	currentTimestamp := time.Now().Unix()
+	for _, proposal := range snapshotProposalsResponse.ActiveSnapshotProposals {
+		if currentTimestamp >= proposal.Start {
+			votableProposals = append(votableProposals, proposal)
+		}
+	}
+	if len(votableProposals) == 0 {
+		fmt.Println("There are no Rocket Pool governance proposals that can be voted on.")
+		return nil
+	}
+
+	// Get the selected proposal
+	var selectedProposal api.SnapshotProposal
+	if c.String("proposal") != "" {
+		found := false
+		for _, proposal := range votableProposals {
+			if proposal.Id == c.String("proposal") {
+				selectedProposal = proposal
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("Proposal %s can not be voted on.", c.String("proposal"))
+		}
+	} else {
+		options := make([]string, len(votableProposals))
+		for i, proposal := range votableProposals {
+			options[i] = fmt.Sprintf("%s (ends %s)", proposal.Title, cliutils.GetDateTimeString(uint64(proposal.End)))
+		}
+		selected, _ := cliutils.Select("Please select a proposal to vote on:", options)
+		selectedProposal = votableProposals[selected]
+	}
+
+	// Get the selected choice (Snapshot choices are 1-based)
+	var choice uint64
+	if c.Uint64("choice") != 0 {
+		choice = c.Uint64("choice")
+		if choice > uint64(len(selectedProposal.Choices)) {
+			return fmt.Errorf("Invalid choice %d; proposal %s only has %d choices.", choice, selectedProposal.Id, len(selectedProposal.Choices))
+		}
+	} else {
+		selected, _ := cliutils.Select("How would you like to vote on the proposal?", selectedProposal.Choices)
+		choice = uint64(selected + 1)
+	}
+	choiceLabel := selectedProposal.Choices[choice-1]
+
+	// Check if the vote can be cast
+	canVote, err := rp.CanVoteOnDAOProposal(selectedProposal.Id, choice)
+	if err != nil {
+		return err
+	}
+	if !canVote.CanVote {
+		fmt.Println("Cannot vote on proposal:")
+		if canVote.SnapshotUnavailable {
+			fmt.Println("Snapshot voting is not available on this network.")
+		}
+		if canVote.DoesNotExist {
+			fmt.Println("The proposal is no longer active.")
+		}
+		if canVote.InvalidState {
+			fmt.Println("Voting on the proposal has not started yet.")
+		}
+		if canVote.UnsupportedType {
+			fmt.Printf("The proposal uses '%s' voting, which can only be voted on through the Snapshot website: %s\n", selectedProposal.Type, selectedProposal.Link)
+		}
+		if canVote.InvalidChoice {
+			fmt.Println("The selected choice is not valid for this proposal.")
+		}
+		if canVote.InsufficientPower {
+			fmt.Println("Your node does not have any Snapshot voting power.")
+		}
+		return nil
+	}
+
+	// Print the voting power and any delegate override
+	fmt.Printf("Your node's Snapshot voting power: %.2f\n\n", canVote.VotingPower)
+	if canVote.AlreadyVoted {
+		fmt.Printf("%sYour node has already voted on this proposal; Snapshot will reject a second vote unless the proposal allows vote changes.%s\n\n", colorYellow, colorReset)
+	}
+	if snapshotProposalsResponse.VotingDelegate != snapshotProposalsResponse.AccountAddress && snapshotProposalsResponse.VotingDelegate != (common.Address{}) {
+		fmt.Printf("Your node has a voting delegate of %s%s%s. Voting directly will override their vote on your behalf for this proposal.\n\n", colorBlue, snapshotProposalsResponse.VotingDelegate.Hex(), colorReset)
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to vote '%s' on proposal '%s'? This vote will be signed with your node wallet.", choiceLabel, selectedProposal.Title))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Sign and submit the vote
+	response, err := rp.VoteOnDAOProposal(selectedProposal.Id, choice)
+	if err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("Successfully voted '%s' on proposal '%s' (vote ID %s).\n", choiceLabel, selectedProposal.Title, response.VoteID)
+	return nil
+}
@@ -1,8 +1,11 @@
 package node
 
 import (
+	"fmt"
+
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/shared/services/income"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
@@ -32,6 +35,48 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "validators",
+				Aliases:   []string{"v"},
+				Usage:     "List the node's minipools and their validators, using the node process's network state snapshot if it's recent enough",
+				UsageText: "rocketpool node validators [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "refresh, r",
+						Usage: "Ignore the network state snapshot and query the Execution and Beacon clients directly",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getValidators(c)
+
+				},
+			},
+
+			{
+				Name:      "sync-committee",
+				Aliases:   []string{"sc"},
+				Usage:     "Show which of the node's validators are in the current or next sync committee, and check that the node is ready for their duties",
+				UsageText: "rocketpool node sync-committee",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getSyncCommittee(c)
+
+				},
+			},
+
 			{
 				Name:      "sync",
 				Aliases:   []string{"y"},
@@ -60,6 +105,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "timezone, t",
 						Usage: "The timezone location to register the node with (in the format 'Country/City')",
 					},
+					cli.StringFlag{
+						Name:  "privacy-mode, p",
+						Usage: "How precisely to publish the node's timezone: 'exact' (default), 'region' (a representative timezone for the region), or 'hidden' (Etc/UTC)",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -74,6 +123,9 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 							return err
 						}
 					}
+					if err := validateTimezonePrivacyMode(c.String("privacy-mode")); err != nil {
+						return err
+					}
 
 					// Run
 					return registerNode(c)
@@ -99,6 +151,24 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "preview-rewards",
+				Aliases:   []string{"pr"},
+				Usage:     "Preview your RPL and Smoothing Pool rewards for the current interval so far, generated from the local rolling record up to the latest finalized epoch",
+				UsageText: "rocketpool node preview-rewards",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return previewRewards(c)
+
+				},
+			},
+
 			{
 				Name:      "set-primary-withdrawal-address",
 				Aliases:   []string{"w"},
@@ -258,6 +328,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "timezone, t",
 						Usage: "The timezone location to set for the node (in the format 'Country/City')",
 					},
+					cli.StringFlag{
+						Name:  "privacy-mode, p",
+						Usage: "How precisely to publish the node's timezone: 'exact' (default), 'region' (a representative timezone for the region), or 'hidden' (Etc/UTC)",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -272,6 +346,9 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 							return err
 						}
 					}
+					if err := validateTimezonePrivacyMode(c.String("privacy-mode")); err != nil {
+						return err
+					}
 
 					// Run
 					return setTimezoneLocation(c)
@@ -279,6 +356,80 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "metadata",
+				Aliases:   []string{"md"},
+				Usage:     "Show the information your node publishes on-chain",
+				UsageText: "rocketpool node metadata",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getMetadataAudit(c)
+
+				},
+			},
+
+			{
+				Name:      "update-metadata",
+				Aliases:   []string{"um"},
+				Usage:     "Update several of the node's on-chain settings in one batch",
+				UsageText: "rocketpool node update-metadata [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the changes",
+					},
+					cli.StringFlag{
+						Name:  "timezone, t",
+						Usage: "The timezone location to set for the node (in the format 'Country/City')",
+					},
+					cli.StringFlag{
+						Name:  "privacy-mode, p",
+						Usage: "How precisely to publish the node's timezone: 'exact', 'region' (a representative timezone for the region), or 'hidden' (Etc/UTC); applies to the current timezone if --timezone isn't set",
+					},
+					cli.StringFlag{
+						Name:  "smoothing-pool, s",
+						Usage: "Join or leave the Smoothing Pool ('join' or 'leave')",
+					},
+					cli.StringFlag{
+						Name:  "rpl-locking, r",
+						Usage: "Allow or deny locking the node's RPL for governance proposals and challenges ('allow' or 'deny')",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("timezone") != "" {
+						if _, err := cliutils.ValidateTimezoneLocation("timezone location", c.String("timezone")); err != nil {
+							return err
+						}
+					}
+					if err := validateTimezonePrivacyMode(c.String("privacy-mode")); err != nil {
+						return err
+					}
+					if c.String("smoothing-pool") != "" && c.String("smoothing-pool") != "join" && c.String("smoothing-pool") != "leave" {
+						return fmt.Errorf("Invalid smoothing-pool value '%s' - must be 'join' or 'leave'", c.String("smoothing-pool"))
+					}
+					if c.String("rpl-locking") != "" && c.String("rpl-locking") != "allow" && c.String("rpl-locking") != "deny" {
+						return fmt.Errorf("Invalid rpl-locking value '%s' - must be 'allow' or 'deny'", c.String("rpl-locking"))
+					}
+
+					// Run
+					return updateMetadata(c)
+
+				},
+			},
+
 			{
 				Name:      "swap-rpl",
 				Aliases:   []string{"p"},
@@ -513,6 +664,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "salt, l",
 						Usage: "An optional seed to use when generating the new minipool's address. Use this if you want it to have a custom vanity address.",
 					},
+					cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "Only run the pre-flight checks and print the go/no-go report, without making the deposit",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -544,6 +699,68 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "export-deposit-data",
+				Usage:     "Create the standard deposit data and keystore files for your next minipool's validator, so it can be run by a third party",
+				UsageText: "rocketpool node export-deposit-data [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "salt, l",
+						Usage: "The salt you'll create the minipool with (a random one is picked if this isn't set)",
+					},
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The folder to save the files to",
+						Value: ".",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the export",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("salt") != "" {
+						if _, err := cliutils.ValidateBigInt("salt", c.String("salt")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return exportDepositData(c)
+
+				},
+			},
+
+			{
+				Name:      "validate-deposit-data",
+				Usage:     "Check a deposit_data.json file created elsewhere against the minipool you'll create with the given salt",
+				UsageText: "rocketpool node validate-deposit-data --salt value deposit-data-file",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "salt, l",
+						Usage: "The salt you'll create the minipool with",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return validateDepositData(c, c.Args().Get(0))
+
+				},
+			},
+
 			{
 				Name:      "create-vacant-minipool",
 				Aliases:   []string{"cvm"},
@@ -641,6 +858,94 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "export-income",
+				Aliases:   []string{"ei"},
+				Usage:     "Export the node's income (rewards, smoothing pool, and distributions) over a date range for tax or accounting purposes",
+				UsageText: "rocketpool node export-income [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "format, f",
+						Usage: "The export format ('csv' or 'json')",
+						Value: "csv",
+					},
+					cli.StringFlag{
+						Name:  "start, s",
+						Usage: "The first day to include, as YYYY-MM-DD (defaults to the start of this year)",
+					},
+					cli.StringFlag{
+						Name:  "end, e",
+						Usage: "The last day to include, as YYYY-MM-DD (defaults to today)",
+					},
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The file to write the export to (defaults to the terminal)",
+					},
+					cli.StringFlag{
+						Name:  "price-feed, p",
+						Usage: "The price feed to value each event with ('coingecko' or 'none')",
+						Value: income.PriceFeed_CoinGecko,
+					},
+					cli.StringFlag{
+						Name:  "currency, c",
+						Usage: "The fiat currency to value each event in",
+						Value: "usd",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return exportIncome(c)
+
+				},
+			},
+
+			{
+				Name:      "reth-arbitrage",
+				Aliases:   []string{"ra"},
+				Usage:     "Compare the protocol's rETH mint or burn rate against the DEX quotes configured in the Smartnode settings, accounting for gas and deposit pool capacity. <direction> can be 'deposit' (ETH to rETH) or 'withdraw' (rETH to ETH).",
+				UsageText: "rocketpool node reth-arbitrage [options] amount direction",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "route, r",
+						Usage: "Deposit through the cheapest route after confirmation (deposits only)",
+					},
+					cli.Float64Flag{
+						Name:  "max-slippage, s",
+						Usage: "The maximum percent the chosen route's output can drop by before the deposit is sent",
+						Value: 0.5,
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the routed deposit",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					amount, err := cliutils.ValidatePositiveEthAmount("amount", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					direction, err := cliutils.ValidateRethArbitrageDirection("direction", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return rethArbitrage(c, amount, direction)
+
+				},
+			},
+
 			{
 				Name:      "set-voting-delegate",
 				Aliases:   []string{"sv"},
@@ -788,7 +1093,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 			{
 				Name:      "sign-message",
 				Aliases:   []string{"sm"},
-				Usage:     "Sign an arbitrary message with the node's private key",
+				Usage:     "Sign an arbitrary message with the node's private key (requires Enable Message Signing in the Smartnode settings)",
 				UsageText: "rocketpool node sign-message [-m message]",
 				Flags: []cli.Flag{
 					cli.StringFlag{
@@ -802,6 +1107,161 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "verify-message",
+				Aliases:   []string{"vm"},
+				Usage:     "Check that a message was signed by an address, such as the output of `rocketpool node sign-message`",
+				UsageText: "rocketpool node verify-message [-f signed-message-file | -a address -m message -s signature]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "file, f",
+						Usage: "A file with the signed message JSON printed by `rocketpool node sign-message`",
+					},
+					cli.StringFlag{
+						Name:  "address, a",
+						Usage: "The address that signed the message",
+					},
+					cli.StringFlag{
+						Name:  "message, m",
+						Usage: "The 'quoted message' that was signed",
+					},
+					cli.StringFlag{
+						Name:  "signature, s",
+						Usage: "The hex-encoded signature",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return verifyMessage(c)
+
+				},
+			},
+
+			{
+				Name:      "decode-tx",
+				Aliases:   []string{"dt"},
+				Usage:     "Decode a serialized transaction or its calldata so you can check what it does before signing it",
+				UsageText: "rocketpool node decode-tx hex-data",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return decodeTransaction(c, c.Args().Get(0))
+
+				},
+			},
+
+			{
+				Name:    "sign-offline",
+				Aliases: []string{"so"},
+				Usage:   "Sign node transactions on an air-gapped machine that holds the node wallet",
+				Subcommands: []cli.Command{
+
+					{
+						Name:      "export",
+						Aliases:   []string{"e"},
+						Usage:     "Add an unsigned transaction to an offline transaction file, to be signed on the air-gapped machine",
+						UsageText: "rocketpool node sign-offline export [options] to-address hex-data",
+						Flags: []cli.Flag{
+							cli.StringFlag{
+								Name:  "from, f",
+								Usage: "The node address the air-gapped machine signs for, if this node doesn't have its wallet",
+							},
+							cli.Float64Flag{
+								Name:  "amount, a",
+								Usage: "The amount of ETH to send with the transaction",
+							},
+							cli.StringFlag{
+								Name:  "file",
+								Usage: "The offline transaction file to add the transaction to",
+								Value: "offline-transactions.json",
+							},
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm adding the transaction",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 2); err != nil {
+								return err
+							}
+							toAddress, err := cliutils.ValidateAddress("to address", c.Args().Get(0))
+							if err != nil {
+								return err
+							}
+							data, err := cliutils.ValidateByteArray("data", c.Args().Get(1))
+							if err != nil {
+								return err
+							}
+
+							// Run
+							return exportOfflineTransaction(c, toAddress, data)
+
+						},
+					},
+
+					{
+						Name:      "sign",
+						Aliases:   []string{"s"},
+						Usage:     "Review and sign the transactions in an offline transaction file with this machine's node wallet",
+						UsageText: "rocketpool node sign-offline sign [-y] file",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm signing the transactions",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run
+							return signOfflineTransactions(c, c.Args().Get(0))
+
+						},
+					},
+
+					{
+						Name:      "broadcast",
+						Aliases:   []string{"b"},
+						Usage:     "Broadcast the signed transactions in an offline transaction file",
+						UsageText: "rocketpool node sign-offline broadcast [-y] file",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm broadcasting the transactions",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run
+							return broadcastOfflineTransactions(c, c.Args().Get(0))
+
+						},
+					},
+				},
+			},
+
 			{
 				Name:      "send-message",
 				Usage:     "Send a zero-ETH transaction to the target address (or ENS) with the provided hex-encoded message as the data payload",
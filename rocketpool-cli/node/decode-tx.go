@@ -0,0 +1,43 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func decodeTransaction(c *cli.Context, data string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Decode the data
+	response, err := rp.DecodeTransaction(data)
+	if err != nil {
+		return err
+	}
+
+	// Print the transaction's own fields if the data was a whole transaction
+	if response.IsTransaction {
+		if response.IsSigned {
+			fmt.Printf("Signed transaction from %s\n", response.From.Hex())
+		} else {
+			fmt.Println("Unsigned transaction")
+		}
+		fmt.Printf("\tChain ID:         %s\n", response.ChainID.String())
+		fmt.Printf("\tNonce:            %d\n", response.Nonce)
+		fmt.Printf("\tGas limit:        %d\n", response.GasLimit)
+		fmt.Printf("\tMax fee:          %.2f gwei\n", eth.WeiToGwei(response.MaxFee))
+		fmt.Printf("\tMax priority fee: %.2f gwei\n", eth.WeiToGwei(response.MaxPriorityFee))
+	} else {
+		fmt.Println("Calldata without a transaction; the target address and value aren't known")
+	}
+	cliutils.PrintTransactionPreview(response.Preview)
+	return nil
+
+}
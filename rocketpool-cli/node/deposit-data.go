@@ -0,0 +1,139 @@
+package node
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool-cli/wallet"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+func exportDepositData(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the minipool salt; it has to be the same one used for the deposit, so pick it now if it wasn't provided
+	var salt *big.Int
+	if c.String("salt") != "" {
+		var success bool
+		salt, success = big.NewInt(0).SetString(c.String("salt"), 0)
+		if !success {
+			return fmt.Errorf("Invalid minipool salt: %s", c.String("salt"))
+		}
+	} else {
+		buffer := make([]byte, 32)
+		_, err = rand.Read(buffer)
+		if err != nil {
+			return fmt.Errorf("Error generating random salt: %w", err)
+		}
+		salt = big.NewInt(0).SetBytes(buffer)
+	}
+
+	// Explain what's about to happen
+	fmt.Printf("%sThis will create the deposit data and keystore for your next minipool's validator, so it can be run by a staking service or another Validator Client.\n", colorYellow)
+	fmt.Println("Anyone with the keystore and its password can run the validator. Never run it in more than one place at a time, or it will be slashed.")
+	fmt.Printf("The files are only valid until another validator key is created, so use them for your next deposit and don't create any other minipool first.%s\n\n", colorReset)
+	if !(c.Bool("yes") || cliutils.Confirm("Would you like to continue?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Get the keystore password
+	password := wallet.PromptKeystorePassword()
+
+	// Create the files
+	response, err := rp.ExportDepositData(salt, password)
+	if err != nil {
+		return err
+	}
+	depositDataPath, keystorePath, err := validator.SaveExportedValidator(c.String("output"), response)
+	if err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Println()
+	fmt.Printf("Validator pubkey:  %s\n", response.Pubkey.Hex())
+	fmt.Printf("Derivation path:   %s\n", response.DerivationPath)
+	fmt.Printf("Minipool address:  %s\n", response.MinipoolAddress.Hex())
+	fmt.Printf("Deposit data:      %s\n", depositDataPath)
+	fmt.Printf("Keystore:          %s\n", keystorePath)
+	fmt.Println()
+	fmt.Println("The deposit data has the 1 ETH deposit made when the minipool is created and the 31 ETH deposit made when it's staked.")
+	fmt.Printf("%sWhen you're ready, create the minipool with `rocketpool node deposit --salt 0x%x` so it uses the same address.%s\n", colorGreen, response.Salt, colorReset)
+	return nil
+
+}
+
+func validateDepositData(c *cli.Context, path string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the minipool salt; the deposit has to use the same one, so it's required
+	if c.String("salt") == "" {
+		return fmt.Errorf("Please provide the salt you'll create the minipool with using --salt.")
+	}
+	salt, success := big.NewInt(0).SetString(c.String("salt"), 0)
+	if !success {
+		return fmt.Errorf("Invalid minipool salt: %s", c.String("salt"))
+	}
+
+	// Read the file
+	depositDataBytes, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Error reading deposit data file [%s]: %w", path, err)
+	}
+
+	// Check it
+	response, err := rp.ValidateDepositData(salt, string(depositDataBytes))
+	if err != nil {
+		return err
+	}
+
+	// Print the results
+	fmt.Printf("Minipool address:       %s\n", response.MinipoolAddress.Hex())
+	fmt.Printf("Withdrawal credentials: %s\n", response.ExpectedWithdrawalCredentials.Hex())
+	if response.MinipoolExists {
+		fmt.Printf("%sA minipool already exists at this address, so this salt can't be used for a new deposit.%s\n", colorRed, colorReset)
+	}
+	fmt.Println()
+	valid := !response.MinipoolExists
+	for i, entry := range response.Entries {
+		fmt.Printf("Entry %d: %s (%.4f ETH)\n", i+1, entry.Pubkey, float64(entry.Amount)/1e9)
+		for _, problem := range entry.Problems {
+			fmt.Printf("\t%sERROR: %s%s\n", colorRed, problem, colorReset)
+		}
+		for _, warning := range entry.Warnings {
+			fmt.Printf("\t%sWARNING: %s%s\n", colorYellow, warning, colorReset)
+		}
+		if len(entry.Problems) == 0 && len(entry.Warnings) == 0 {
+			fmt.Printf("\t%sOK%s\n", colorGreen, colorReset)
+		}
+		if len(entry.Problems) > 0 {
+			valid = false
+		}
+	}
+	fmt.Println()
+	if !valid {
+		return fmt.Errorf("The deposit data can't be used for this minipool.")
+	}
+	fmt.Printf("The deposit data is valid for this minipool. Create it with `rocketpool node deposit --salt 0x%x`.\n", salt)
+	return nil
+
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
@@ -149,11 +150,18 @@ func nodeDeposit(c *cli.Context) error {
 		salt = big.NewInt(0).SetBytes(buffer)
 	}
 
-	// Check deposit can be made
-	canDeposit, err := rp.CanNodeDeposit(amountWei, minNodeFee, salt)
+	// Run the pre-flight checks
+	simulation, err := rp.SimulateNodeDeposit(amountWei, minNodeFee, salt)
 	if err != nil {
 		return err
 	}
+	printDepositPreflightReport(simulation)
+	if c.Bool("dry-run") {
+		return nil
+	}
+
+	// Check deposit can be made
+	canDeposit := simulation.Deposit
 	if !canDeposit.CanDeposit {
 		fmt.Println("Cannot make node deposit:")
 		if canDeposit.InsufficientBalanceWithoutCredit {
@@ -179,6 +187,10 @@ func nodeDeposit(c *cli.Context) error {
 		}
 		return nil
 	}
+	if !simulation.Go {
+		fmt.Println("Cannot make node deposit because some of the pre-flight checks failed.")
+		return nil
+	}
 
 	useCreditBalance := false
 	fmt.Printf("You currently have %.2f ETH in your credit balance plus ETH staked on your behalf.\n", eth.WeiToEth(canDeposit.CreditBalance))
@@ -273,3 +285,20 @@ func nodeDeposit(c *cli.Context) error {
 	return nil
 
 }
+
+// Print the results of the deposit pre-flight checks
+func printDepositPreflightReport(simulation api.NodeSimulateDepositResponse) {
+	fmt.Printf("%s=== Deposit Pre-flight Checks ===%s\n", colorGreen, colorReset)
+	for _, check := range simulation.Checks {
+		if check.Passed {
+			fmt.Printf("%s[PASS]%s %s: %s\n", colorGreen, colorReset, check.Name, check.Message)
+		} else {
+			fmt.Printf("%s[FAIL]%s %s: %s\n", colorRed, colorReset, check.Name, check.Message)
+		}
+	}
+	if simulation.Go {
+		fmt.Printf("\nResult: %sGO%s\n\n", colorGreen, colorReset)
+	} else {
+		fmt.Printf("\nResult: %sNO-GO%s\n\n", colorRed, colorReset)
+	}
+}
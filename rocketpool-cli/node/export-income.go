@@ -0,0 +1,106 @@
+package node
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/income"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+const incomeDateFormat string = "2006-01-02"
+
+func exportIncome(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the date range, defaulting to the start of this year until now
+	now := time.Now().UTC()
+	startTime := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	endTime := now
+	if c.String("start") != "" {
+		startTime, err = time.Parse(incomeDateFormat, c.String("start"))
+		if err != nil {
+			return fmt.Errorf("invalid start date [%s], expected YYYY-MM-DD: %w", c.String("start"), err)
+		}
+	}
+	if c.String("end") != "" {
+		endTime, err = time.Parse(incomeDateFormat, c.String("end"))
+		if err != nil {
+			return fmt.Errorf("invalid end date [%s], expected YYYY-MM-DD: %w", c.String("end"), err)
+		}
+		// Make the end date inclusive
+		endTime = endTime.AddDate(0, 0, 1)
+	}
+	if !startTime.Before(endTime) {
+		return fmt.Errorf("the start date must be before the end date")
+	}
+
+	// Set up the price feed before doing any work so a bad name fails fast
+	priceFeed, err := income.NewPriceFeed(c.String("price-feed"), c.String("currency"))
+	if err != nil {
+		return err
+	}
+
+	// Get the events
+	response, err := rp.GetIncomeEvents(startTime, endTime)
+	if err != nil {
+		return err
+	}
+
+	// Warn about anything the export can't cover; these go to stderr so they don't end up in the export itself
+	if len(response.MissingIntervals) > 0 {
+		fmt.Fprintf(os.Stderr, "%sWARNING: the rewards tree files for intervals %v are missing or invalid, so their rewards are not included. Run `rocketpool node claim-rewards` to download them.%s\n", colorYellow, response.MissingIntervals, colorReset)
+	}
+	if !response.LedgerExists {
+		fmt.Fprintf(os.Stderr, "%sWARNING: the node daemon hasn't started its rewards ledger yet, so minipool and fee distributor distributions are not included.%s\n", colorYellow, colorReset)
+	} else {
+		fmt.Fprintf(os.Stderr, "NOTE: minipool and fee distributor distributions are only included from slot %d, when the node daemon started recording them.\n", response.LedgerStartSlot)
+	}
+
+	// Value the events
+	if priceFeed != nil {
+		fmt.Fprintf(os.Stderr, "Getting %s prices for %d events...\n", c.String("currency"), len(response.Events))
+		err = income.AddFiatValues(response.Events, priceFeed, c.String("currency"))
+		if err != nil {
+			return err
+		}
+	}
+
+	// Write the export
+	var writer io.Writer = os.Stdout
+	if c.String("output") != "" {
+		file, err := os.Create(c.String("output"))
+		if err != nil {
+			return fmt.Errorf("error creating [%s]: %w", c.String("output"), err)
+		}
+		defer file.Close()
+		writer = file
+	}
+	switch c.String("format") {
+	case "csv":
+		err = income.WriteCsv(writer, response.Events)
+	case "json":
+		err = income.WriteJson(writer, response.Events)
+	default:
+		err = fmt.Errorf("unknown format [%s]; expected 'csv' or 'json'", c.String("format"))
+	}
+	if err != nil {
+		return err
+	}
+
+	if c.String("output") != "" {
+		fmt.Fprintf(os.Stderr, "Exported %d income events from %s to %s.\n", len(response.Events), startTime.Format(incomeDateFormat), c.String("output"))
+	}
+	return nil
+
+}
@@ -0,0 +1,247 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	rpsvc "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// A single change to the node's on-chain metadata, submitted as part of a batch
+type metadataUpdate struct {
+	description string
+	gasInfo     rocketpool.GasInfo
+	submit      func() (common.Hash, error)
+}
+
+// Print everything the node publishes about itself on-chain, so operators can see what's visible to anyone
+func getMetadataAudit(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rpsvc.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get node status
+	status, err := rp.NodeStatus()
+	if err != nil {
+		return err
+	}
+	if !status.Registered {
+		fmt.Println("The node is not registered with Rocket Pool, so it hasn't published any metadata.")
+		return nil
+	}
+
+	fmt.Println("The following information about your node is stored on-chain and is visible to anyone:")
+	fmt.Println()
+	fmt.Printf("Node address:             %s\n", status.AccountAddressFormatted)
+
+	precision := getTimezonePrecision(status.TimezoneLocation)
+	fmt.Printf("Timezone location:        %s (%s)\n", status.TimezoneLocation, precision)
+	fmt.Printf("Primary withdrawal:       %s\n", status.PrimaryWithdrawalAddressFormatted)
+	if status.PendingPrimaryWithdrawalAddress != (common.Address{}) {
+		fmt.Printf("Pending withdrawal:       %s\n", status.PendingPrimaryWithdrawalAddressFormatted)
+	}
+	if status.IsRPLWithdrawalAddressSet {
+		fmt.Printf("RPL withdrawal:           %s\n", status.RPLWithdrawalAddressFormatted)
+	} else {
+		fmt.Println("RPL withdrawal:           not set (uses the primary withdrawal address)")
+	}
+	fmt.Printf("Smoothing Pool:           %s\n", formatOptedIn(status.FeeRecipientInfo.IsInSmoothingPool))
+	if status.IsFeeDistributorInitialized {
+		fmt.Printf("Fee distributor:          %s\n", status.FeeRecipientInfo.FeeDistributorAddress.Hex())
+	} else {
+		fmt.Println("Fee distributor:          not initialized")
+	}
+	fmt.Printf("RPL locking allowed:      %t\n", status.IsRPLLockingAllowed)
+	if status.IsVotingInitialized {
+		fmt.Printf("On-chain voting delegate: %s\n", status.OnchainVotingDelegateFormatted)
+	} else {
+		fmt.Println("On-chain voting delegate: not initialized")
+	}
+	fmt.Printf("Snapshot delegate:        %s\n", status.SnapshotVotingDelegateFormatted)
+	fmt.Printf("Minipools:                %d\n", status.MinipoolCounts.Total)
+	fmt.Println()
+
+	switch precision {
+	case TimezonePrivacyExact:
+		fmt.Printf("%sYour timezone location identifies the city your node is registered in. Use `rocketpool node set-timezone --privacy-mode region` to publish a region-level timezone instead.%s\n", colorYellow, colorReset)
+	case TimezonePrivacyRegion:
+		fmt.Println("Your timezone location only identifies the region your node is in.")
+	case TimezonePrivacyHidden:
+		fmt.Println("Your timezone location doesn't identify where your node is.")
+	}
+	fmt.Println("Addresses shown with an ENS name are resolved from that address's public ENS reverse record.")
+	return nil
+
+}
+
+// Apply several metadata changes in one batch, with a single gas prompt and confirmation
+func updateMetadata(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rpsvc.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get node status
+	status, err := rp.NodeStatus()
+	if err != nil {
+		return err
+	}
+	if !status.Registered {
+		fmt.Println("The node must be registered with Rocket Pool before it can update its metadata.")
+		return nil
+	}
+
+	// Collect the requested changes, skipping any that are already in place
+	updates := []metadataUpdate{}
+	if c.String("timezone") != "" || c.String("privacy-mode") != "" {
+		timezoneLocation := c.String("timezone")
+		if timezoneLocation == "" {
+			timezoneLocation = status.TimezoneLocation
+		}
+		timezoneLocation, err = getTimezoneForPrivacyMode(timezoneLocation, c.String("privacy-mode"))
+		if err != nil {
+			return err
+		}
+		if timezoneLocation == status.TimezoneLocation {
+			fmt.Printf("The node's timezone location is already '%s'.\n", timezoneLocation)
+		} else {
+			canResponse, err := rp.CanSetNodeTimezone(timezoneLocation)
+			if err != nil {
+				return err
+			}
+			updates = append(updates, metadataUpdate{
+				description: fmt.Sprintf("Set the timezone location to '%s'", timezoneLocation),
+				gasInfo:     canResponse.GasInfo,
+				submit: func() (common.Hash, error) {
+					response, err := rp.SetNodeTimezone(timezoneLocation)
+					return response.TxHash, err
+				},
+			})
+		}
+	}
+
+	if c.String("smoothing-pool") != "" {
+		join := c.String("smoothing-pool") == "join"
+		action := "Leave"
+		if join {
+			action = "Join"
+		}
+		if join == status.FeeRecipientInfo.IsInSmoothingPool {
+			fmt.Printf("The node is already %s the Smoothing Pool.\n", formatOptedIn(join))
+		} else {
+			poolStatus, err := rp.NodeGetSmoothingPoolRegistrationStatus()
+			if err != nil {
+				return err
+			}
+			if poolStatus.TimeLeftUntilChangeable > 0 {
+				return fmt.Errorf("The node's Smoothing Pool status can't be changed for another %s.", poolStatus.TimeLeftUntilChangeable)
+			}
+			canResponse, err := rp.CanNodeSetSmoothingPoolStatus(join)
+			if err != nil {
+				return err
+			}
+			updates = append(updates, metadataUpdate{
+				description: fmt.Sprintf("%s the Smoothing Pool (this will restart your Validator Client)", action),
+				gasInfo:     canResponse.GasInfo,
+				submit: func() (common.Hash, error) {
+					response, err := rp.NodeSetSmoothingPoolStatus(join)
+					return response.TxHash, err
+				},
+			})
+		}
+	}
+
+	if c.String("rpl-locking") != "" {
+		allow := c.String("rpl-locking") == "allow"
+		if allow == status.IsRPLLockingAllowed {
+			fmt.Printf("RPL locking is already set to %t.\n", allow)
+		} else {
+			canResponse, err := rp.CanSetRPLLockingAllowed(allow)
+			if err != nil {
+				return err
+			}
+			if !canResponse.CanSet {
+				return fmt.Errorf("RPL locking can only be changed by the node's RPL withdrawal address.")
+			}
+			updates = append(updates, metadataUpdate{
+				description: fmt.Sprintf("Set RPL locking allowed to %t", allow),
+				gasInfo:     canResponse.GasInfo,
+				submit: func() (common.Hash, error) {
+					response, err := rp.SetRPLLockingAllowed(allow)
+					return response.SetTxHash, err
+				},
+			})
+		}
+	}
+
+	if len(updates) == 0 {
+		fmt.Println("There are no metadata changes to make.")
+		return nil
+	}
+
+	// Print the changes
+	fmt.Println("The following changes will be submitted together:")
+	var gasInfo rocketpool.GasInfo
+	for _, update := range updates {
+		fmt.Printf("\t- %s\n", update.description)
+		gasInfo.EstGasLimit += update.gasInfo.EstGasLimit
+		gasInfo.SafeGasLimit += update.gasInfo.SafeGasLimit
+	}
+	fmt.Println()
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(gasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to submit these %d changes?", len(updates)))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Submit all of the transactions before waiting for them, so they can land in the same block
+	hashes := []common.Hash{}
+	for _, update := range updates {
+		hash, err := update.submit()
+		if err != nil {
+			fmt.Printf("Could not submit '%s': %s\n", update.description, err.Error())
+			continue
+		}
+		fmt.Printf("Submitted '%s'.\n", update.description)
+		cliutils.PrintTransactionHashNoCancel(rp, hash)
+		hashes = append(hashes, hash)
+	}
+	fmt.Println("Waiting for the transactions to be included in a block...")
+	for _, hash := range hashes {
+		if _, err = rp.WaitForTransaction(hash); err != nil {
+			return err
+		}
+	}
+
+	// Log & return
+	fmt.Printf("Successfully applied %d of %d metadata changes.\n", len(hashes), len(updates))
+	return nil
+
+}
+
+// Format whether the node is in the Smoothing Pool
+func formatOptedIn(optedIn bool) string {
+	if optedIn {
+		return "in"
+	}
+	return "not in"
+}
@@ -0,0 +1,76 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Print the node's rewards for the current interval so far, generated from the local rolling record
+func previewRewards(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	fmt.Println("Generating the rewards tree for the current interval up to the latest finalized epoch. This can take a few minutes...")
+	response, err := rp.PreviewRewards()
+	if err != nil {
+		return err
+	}
+	if !response.Registered {
+		fmt.Println("This node is not currently registered.")
+		return nil
+	}
+	if !response.RollingRecordsEnabled {
+		fmt.Println("Rewards previews are made from the rolling record, which is disabled. Enable rolling records in the Smartnode section of `rocketpool service config` and run this again once the watchtower has caught up.")
+		return nil
+	}
+	preview := response.Preview
+
+	// Print the part of the interval the preview covers
+	fmt.Println()
+	elapsed := preview.EndTime.Sub(preview.StartTime)
+	length := preview.IntervalEndTime.Sub(preview.StartTime)
+	fmt.Printf("%s=== Interval %d so far (ruleset v%d) ===%s\n", colorGreen, preview.Index, preview.RulesetVersion, colorReset)
+	fmt.Printf("Covers %s to %s (slots %d to %d), %.1f%% of the interval.\n", preview.StartTime.Local().Format(time.RFC822), preview.EndTime.Local().Format(time.RFC822), preview.ConsensusStartBlock, preview.ConsensusEndBlock, elapsed.Seconds()/length.Seconds()*100)
+	fmt.Printf("The interval ends at %s.\n", preview.IntervalEndTime.Local().Format(time.RFC822))
+	if preview.HasCheckpoint {
+		fmt.Printf("Started from the rolling record checkpoint at slot %d.\n", preview.CheckpointSlot)
+	} else {
+		fmt.Printf("%sThere was no usable rolling record checkpoint, so the whole interval so far was processed.%s\n", colorYellow, colorReset)
+	}
+	fmt.Println()
+
+	// Print the node's totals
+	fmt.Printf("Collateral RPL:     %.6f RPL\n", eth.WeiToEth(&preview.CollateralRpl.Int))
+	if preview.OracleDaoRpl.Sign() > 0 {
+		fmt.Printf("Oracle DAO RPL:     %.6f RPL\n", eth.WeiToEth(&preview.OracleDaoRpl.Int))
+	}
+	fmt.Printf("Smoothing Pool ETH: %.6f ETH\n", eth.WeiToEth(&preview.SmoothingPoolEth.Int))
+	fmt.Println()
+
+	// Print each minipool's part
+	if len(preview.Minipools) > 0 {
+		fmt.Printf("%s=== Minipools ===%s\n", colorGreen, colorReset)
+		for _, minipool := range preview.Minipools {
+			if !minipool.InSmoothingPool {
+				fmt.Printf("%s: not earning Smoothing Pool rewards this interval\n", minipool.Address.Hex())
+				continue
+			}
+			fmt.Printf("%s: %.6f ETH (%d attestations, %d missed)\n", minipool.Address.Hex(), eth.WeiToEth(&minipool.SmoothingPoolEth.Int), minipool.SuccessfulAttestations, minipool.MissedAttestations)
+		}
+		fmt.Println()
+	}
+
+	fmt.Println("These are projections of your share so far; they change as the interval goes on and other nodes' performance is counted. Run this again at any time for an updated preview.")
+	return nil
+
+}
@@ -20,11 +20,9 @@ func registerNode(c *cli.Context) error {
 	defer rp.Close()
 
 	// Prompt for timezone location
-	var timezoneLocation string
-	if c.String("timezone") != "" {
-		timezoneLocation = c.String("timezone")
-	} else {
-		timezoneLocation = promptTimezone()
+	timezoneLocation, err := getTimezoneLocation(c)
+	if err != nil {
+		return err
 	}
 
 	// Check node can be registered
@@ -0,0 +1,153 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// The source name the API uses for minting rETH with the protocol directly
+const protocolRethRoute string = "protocol"
+
+func rethArbitrage(c *cli.Context, amount float64, direction string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check the flags
+	route := c.Bool("route")
+	if route && direction != "deposit" {
+		return fmt.Errorf("Only deposits can be routed; use `rocketpool node burn` to burn rETH with the protocol.")
+	}
+	maxSlippage := c.Float64("max-slippage")
+	if maxSlippage < 0 || maxSlippage > 100 {
+		return fmt.Errorf("Invalid max slippage %.2f%% - must be between 0 and 100", maxSlippage)
+	}
+
+	// Get the routes
+	amountWei := eth.EthToWei(amount)
+	response, err := rp.GetRethArbitrage(amountWei, direction)
+	if err != nil {
+		return err
+	}
+	sellToken, buyToken := "ETH", "rETH"
+	if direction == "withdraw" {
+		sellToken, buyToken = "rETH", "ETH"
+	}
+
+	// Print the routes
+	fmt.Printf("Routes for selling %.6f %s for %s:\n\n", amount, sellToken, buyToken)
+	for i, r := range response.Routes {
+		marker := " "
+		if i == response.BestRoute {
+			marker = "*"
+		}
+		if !r.Available {
+			fmt.Printf("%s %-24s %sunavailable: %s%s\n", marker, r.Source, colorYellow, r.UnavailableReason, colorReset)
+			continue
+		}
+		fmt.Printf("%s %-24s %.6f %s (gas %.6f ETH, %.6f %s after gas)\n", marker, r.Source, eth.WeiToEth(r.Output), buyToken, eth.WeiToEth(r.GasCost), eth.WeiToEth(r.NetOutput), buyToken)
+	}
+	fmt.Println()
+	if len(response.Routes) == 1 {
+		fmt.Println("No DEX quote URLs are configured, so only the protocol rate is shown. You can add some in the Smartnode section of `rocketpool service config`.")
+		fmt.Println()
+	}
+	if response.BestRoute == -1 {
+		fmt.Println("None of the routes are currently available.")
+		return nil
+	}
+	best := response.Routes[response.BestRoute]
+	fmt.Printf("The best route is %s%s%s.\n", colorGreen, best.Source, colorReset)
+	if response.InsufficientBalance {
+		fmt.Printf("%sThe node does not have %.6f %s, so it can't use any of these routes right now.%s\n", colorYellow, amount, sellToken, colorReset)
+		return nil
+	}
+	if !route {
+		return nil
+	}
+	if !response.CanRoute {
+		fmt.Println("The deposit can't be routed right now.")
+		return nil
+	}
+
+	// Get the swap transaction and show what it will do
+	gasInfo := response.GasInfo
+	expectedOutput := best.Output
+	var swap *api.NodeRethDepositSwapResponse
+	if best.Source != protocolRethRoute {
+		swapResponse, err := rp.GetRethDepositSwap(amountWei, best.Source)
+		if err != nil {
+			return err
+		}
+		swap = &swapResponse
+		printRethDepositSwap(swap)
+		gasInfo = swap.GasInfo
+		expectedOutput = swap.SimulatedOutput
+	}
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(gasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Get the minimum output the deposit is allowed to have
+	minOutput := big.NewInt(0).Mul(expectedOutput, big.NewInt(int64((100-maxSlippage)*100)))
+	minOutput.Div(minOutput, big.NewInt(100*100))
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to deposit %.6f ETH through %s for at least %.6f rETH?", amount, best.Source, eth.WeiToEth(minOutput)))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Route the deposit
+	var routeResponse api.NodeRouteRethDepositResponse
+	if swap == nil {
+		routeResponse, err = rp.RouteRethDeposit(amountWei, best.Source, minOutput)
+	} else {
+		routeResponse, err = rp.SwapRethDeposit(swap.Value, swap.To, swap.Data, minOutput)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Depositing ETH for rETH...\n")
+	cliutils.PrintTransactionHash(rp, routeResponse.TxHash)
+	if _, err = rp.WaitForTransaction(routeResponse.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("Successfully deposited %.6f ETH for rETH through %s.\n", amount, best.Source)
+	return nil
+
+}
+
+// Print the transaction a DEX swap will send, so it can be checked before it's signed
+func printRethDepositSwap(swap *api.NodeRethDepositSwapResponse) {
+	fmt.Printf("The swap through %s will send this transaction:\n", swap.Source)
+	fmt.Printf("\tTo:       %s (%s)\n", swap.To.Hex(), swap.RouterName)
+	fmt.Printf("\tValue:    %.6f ETH\n", eth.WeiToEth(swap.Value))
+	if swap.Call.Function != "" {
+		fmt.Printf("\tFunction: %s\n", swap.Call.Function)
+	} else {
+		fmt.Printf("\tFunction: unknown (selector %s)\n", swap.Call.Selector)
+	}
+	for _, arg := range swap.Call.Arguments {
+		fmt.Printf("\t\t%s (%s): %s\n", arg.Name, arg.Type, arg.Value)
+	}
+	fmt.Printf("\nThe quote was for %.6f rETH, and the node received %s%.6f rETH%s when the swap was simulated.\n\n", eth.WeiToEth(swap.QuotedOutput), colorGreen, eth.WeiToEth(swap.SimulatedOutput), colorReset)
+}
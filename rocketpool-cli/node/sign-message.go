@@ -2,6 +2,8 @@ package node
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/goccy/go-json"
@@ -42,7 +44,20 @@ func signMessage(c *cli.Context) error {
 		message = cliutils.Prompt("Please enter the message you want to sign: (EIP-191 personal_sign)", "^.+$", "Please enter the message you want to sign: (EIP-191 personal_sign)")
 	}
 
-	response, err := rp.SignMessage(message)
+	// Have the user type the daemon's one-time code, since the signature proves the node account approved the message
+	request, err := rp.RequestSignMessage(message)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("The node account %s will sign this message:\n\n%s\n\n", status.AccountAddress.Hex(), message)
+	fmt.Println("Only sign messages from services you trust, since anyone can use the signature to prove this node approved the message.")
+	code := cliutils.Prompt(fmt.Sprintf("To sign it, type the confirmation code %s%s%s (or anything else to cancel):", colorYellow, request.Code, colorReset), "^.*$", "")
+	if !strings.EqualFold(strings.TrimSpace(code), request.Code) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	response, err := rp.SignMessage(request.Code, message)
 	if err != nil {
 		return err
 	}
@@ -64,3 +79,53 @@ func signMessage(c *cli.Context) error {
 	return nil
 
 }
+
+func verifyMessage(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the signature, either from the output of sign-message or from its individual parts
+	var signature PersonalSignature
+	if c.String("file") != "" {
+		bytes, err := os.ReadFile(c.String("file"))
+		if err != nil {
+			return fmt.Errorf("error reading the signed message: %w", err)
+		}
+		if err := json.Unmarshal(bytes, &signature); err != nil {
+			return fmt.Errorf("error parsing the signed message: %w", err)
+		}
+	} else {
+		addressString := c.String("address")
+		if addressString == "" {
+			addressString = cliutils.Prompt("Please enter the address that signed the message:", "^0x[0-9a-fA-F]{40}$", "Invalid address")
+		}
+		address, err := cliutils.ValidateAddress("address", addressString)
+		if err != nil {
+			return err
+		}
+		signature.Address = address
+		signature.Message = c.String("message")
+		for signature.Message == "" {
+			signature.Message = cliutils.Prompt("Please enter the message that was signed:", "^.+$", "Please enter the message that was signed:")
+		}
+		signature.Signature = c.String("signature")
+		if signature.Signature == "" {
+			signature.Signature = cliutils.Prompt("Please enter the signature:", "^(0x)?[0-9a-fA-F]{130}$", "Invalid signature")
+		}
+	}
+
+	response, err := rp.VerifyMessage(signature.Address, signature.Message, signature.Signature)
+	if err != nil {
+		return err
+	}
+
+	if response.Valid {
+		fmt.Printf("%sThe signature is valid: the message was signed by %s.%s\n", colorGreen, signature.Address.Hex(), colorReset)
+	} else {
+		fmt.Printf("%sThe signature is NOT valid for %s; it was made by %s instead.%s\n", colorRed, signature.Address.Hex(), response.Signer.Hex(), colorReset)
+	}
+	return nil
+
+}
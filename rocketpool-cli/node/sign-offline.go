@@ -0,0 +1,230 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/offlinetx"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Add an unsigned transaction to an offline transaction file, so it can be signed on an air-gapped machine
+func exportOfflineTransaction(c *cli.Context, toAddress common.Address, data []byte) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the sender, which is the node wallet unless it's kept on the air-gapped machine
+	var from common.Address
+	if c.String("from") != "" {
+		from, err = cliutils.ValidateAddress("from address", c.String("from"))
+		if err != nil {
+			return err
+		}
+	} else {
+		status, err := rp.WalletStatus()
+		if err != nil {
+			return err
+		}
+		if !status.WalletInitialized {
+			return fmt.Errorf("This node doesn't have a wallet. Please provide the node address the air-gapped machine signs for with --from.")
+		}
+		from = status.AccountAddress
+	}
+	amountWei := eth.EthToWei(c.Float64("amount"))
+
+	// Get the chain ID, nonce, and gas estimate
+	prepared, err := rp.PrepareOfflineTransaction(from, toAddress, amountWei, data)
+	if err != nil {
+		return err
+	}
+
+	// Add to an existing file if there is one, so several transactions can be signed together
+	path := c.String("file")
+	envelope, err := offlinetx.Load(path)
+	if errors.Is(err, os.ErrNotExist) {
+		envelope = offlinetx.NewEnvelope(prepared.ChainID, from)
+	} else if err != nil {
+		return err
+	}
+	if envelope.Domain.ChainID != prepared.ChainID || envelope.From != from {
+		return fmt.Errorf("%s has transactions from %s on chain %d; please use a different file for transactions from %s on chain %d", path, envelope.From.Hex(), envelope.Domain.ChainID, from.Hex(), prepared.ChainID)
+	}
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(prepared.GasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+	maxFeeGwei, maxPriorityFeeGwei, gasLimit := rp.GetGasSettings()
+	if gasLimit == 0 {
+		gasLimit = prepared.GasInfo.SafeGasLimit
+	}
+	nonce := envelope.GetNextNonce(prepared.PendingNonce)
+	tx, err := envelope.AddTransaction(toAddress, amountWei, data, nonce, gasLimit, eth.GweiToWei(maxFeeGwei), eth.GweiToWei(maxPriorityFeeGwei))
+	if err != nil {
+		return err
+	}
+
+	// Show what will be signed
+	decoded, err := rp.DecodeTransaction(hexutil.Encode(tx.UnsignedTx))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Transaction %d from %s:\n", nonce, from.Hex())
+	cliutils.PrintTransactionPreview(decoded.Preview)
+	fmt.Println()
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to add this transaction to %s?", path))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	if err := envelope.Save(path); err != nil {
+		return err
+	}
+
+	fmt.Printf("Added the transaction to %s, which now has %d unsigned transactions.\n", path, len(envelope.Transactions))
+	fmt.Printf("Copy it to your air-gapped machine and run `rocketpool node sign-offline sign %s` there, then bring it back and run `rocketpool node sign-offline broadcast %s` here.\n", path, path)
+	fmt.Println("Don't send any other transactions from this address until then, or the nonces in the file will be used up.")
+	return nil
+
+}
+
+// Sign the transactions in an offline transaction file with the node wallet on an air-gapped machine
+func signOfflineTransactions(c *cli.Context, path string) error {
+
+	// Get RP client; the clients aren't needed since nothing is sent from here
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	envelope, err := offlinetx.Load(path)
+	if err != nil {
+		return err
+	}
+	if len(envelope.Transactions) == 0 {
+		fmt.Printf("%s doesn't have any transactions.\n", path)
+		return nil
+	}
+
+	// Show everything that will be signed, making sure the details shown are the ones in the serialized transactions
+	fmt.Printf("%s has %d transactions from %s on chain %d:\n\n", path, len(envelope.Transactions), envelope.From.Hex(), envelope.Domain.ChainID)
+	for _, tx := range envelope.Transactions {
+		if _, err := envelope.CheckUnsignedTransaction(tx); err != nil {
+			return err
+		}
+		decoded, err := rp.DecodeTransaction(hexutil.Encode(tx.UnsignedTx))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Nonce %d (signing hash %s)\n", tx.Nonce, tx.SigningHash.Hex())
+		fmt.Printf("\tGas limit:        %d\n", tx.GasLimit)
+		fmt.Printf("\tMax fee:          %.2f gwei\n", eth.WeiToGwei(tx.MaxFeePerGas))
+		fmt.Printf("\tMax priority fee: %.2f gwei\n", eth.WeiToGwei(tx.MaxPriorityFeePerGas))
+		cliutils.PrintTransactionPreview(decoded.Preview)
+		fmt.Println()
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to sign these %d transactions?", len(envelope.Transactions)))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Sign them
+	for _, tx := range envelope.Transactions {
+		response, err := rp.SignOfflineTransaction(tx.UnsignedTx)
+		if err != nil {
+			return err
+		}
+		if response.From != envelope.From {
+			return fmt.Errorf("This machine's node wallet is %s, but the transactions are from %s.", response.From.Hex(), envelope.From.Hex())
+		}
+		tx.SignedTx, err = hexutil.Decode(response.SignedTx)
+		if err != nil {
+			return fmt.Errorf("error parsing the signed transaction with nonce %d: %w", tx.Nonce, err)
+		}
+		if _, err := envelope.CheckSignedTransaction(tx); err != nil {
+			return err
+		}
+	}
+	if err := envelope.Save(path); err != nil {
+		return err
+	}
+
+	fmt.Printf("Signed %d transactions. Copy %s back to your online node and run `rocketpool node sign-offline broadcast %s` there.\n", len(envelope.Transactions), path, path)
+	return nil
+
+}
+
+// Broadcast the signed transactions in an offline transaction file
+func broadcastOfflineTransactions(c *cli.Context, path string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	envelope, err := offlinetx.Load(path)
+	if err != nil {
+		return err
+	}
+	if len(envelope.Transactions) == 0 {
+		fmt.Printf("%s doesn't have any transactions.\n", path)
+		return nil
+	}
+
+	// Make sure every transaction was signed by the sender without being changed
+	for _, tx := range envelope.Transactions {
+		if _, err := envelope.CheckSignedTransaction(tx); err != nil {
+			return err
+		}
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to broadcast the %d signed transactions from %s?", len(envelope.Transactions), envelope.From.Hex()))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Send them in nonce order so none of them are stuck behind a gap
+	transactions := append([]*offlinetx.Transaction{}, envelope.Transactions...)
+	sort.Slice(transactions, func(i, j int) bool {
+		return transactions[i].Nonce < transactions[j].Nonce
+	})
+	hashes := []common.Hash{}
+	for _, tx := range transactions {
+		response, err := rp.BroadcastOfflineTransaction(tx.SignedTx)
+		if err != nil {
+			return fmt.Errorf("error broadcasting the transaction with nonce %d: %w", tx.Nonce, err)
+		}
+		hashes = append(hashes, response.TxHash)
+	}
+
+	// Wait for them
+	for _, hash := range hashes {
+		cliutils.PrintTransactionHashNoCancel(rp, hash)
+		if _, err = rp.WaitForTransaction(hash); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Successfully broadcast the %d transactions from %s.\n", len(hashes), path)
+	return nil
+
+}
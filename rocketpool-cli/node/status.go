@@ -61,7 +61,7 @@ func getStatus(c *cli.Context) error {
 	}
 
 	// Print what network we're on
-	err = cliutils.PrintNetwork(cfg.GetNetwork(), isNew)
+	err = cliutils.PrintNetwork(cfg, isNew)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,68 @@
+package node
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getSyncCommittee(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the assignments and readiness checks
+	response, err := rp.NodeSyncCommittee()
+	if err != nil {
+		return err
+	}
+
+	// Print the assignments
+	fmt.Printf("The current epoch is %d.\n\n", response.CurrentEpoch)
+	printSyncCommitteePeriod("Current", response.CurrentPeriod)
+	printSyncCommitteePeriod("Next", response.NextPeriod)
+
+	// Print the readiness checks
+	fmt.Println("Readiness checks:")
+	failed := 0
+	for _, check := range response.Checks {
+		if check.Passed {
+			fmt.Printf("\t%sPASSED%s  %s: %s\n", colorGreen, colorReset, check.Name, check.Details)
+		} else {
+			failed++
+			fmt.Printf("\t%sFAILED%s  %s: %s\n", colorRed, colorReset, check.Name, check.Details)
+		}
+	}
+	fmt.Println()
+	if failed > 0 {
+		fmt.Printf("%s%d readiness checks failed. Fix them before your validators' sync committee duties start, or they'll miss sync committee rewards and be penalized for every slot they miss.%s\n", colorYellow, failed, colorReset)
+	} else {
+		fmt.Printf("%sYour node is ready for sync committee duties.%s\n", colorGreen, colorReset)
+	}
+	return nil
+
+}
+
+// Print one sync committee period and the node's validators in it
+func printSyncCommitteePeriod(label string, period api.SyncCommitteePeriod) {
+	fmt.Printf("%s sync committee period (%d): epochs %d to %d, %s to %s\n", label, period.Period, period.StartEpoch, period.EndEpoch,
+		period.StartTime.Local().Format(time.RFC822), period.EndTime.Local().Format(time.RFC822))
+	if len(period.Validators) == 0 {
+		fmt.Println("\tNone of your validators are in this committee.")
+	} else {
+		fmt.Printf("\t%s%d of your validators are in this committee:%s %s\n", colorGreen, len(period.Validators), colorReset, strings.Join(period.Validators, ", "))
+		if time.Now().Before(period.StartTime) {
+			fmt.Printf("\tTheir duties start in %s.\n", time.Until(period.StartTime).Round(time.Minute))
+		}
+	}
+	fmt.Println()
+}
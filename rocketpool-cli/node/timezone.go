@@ -0,0 +1,132 @@
+package node
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/urfave/cli"
+)
+
+// Timezone privacy modes, which control how precisely the node's location is published on-chain
+const (
+	TimezonePrivacyExact  string = "exact"
+	TimezonePrivacyRegion string = "region"
+	TimezonePrivacyHidden string = "hidden"
+
+	// The timezone published when the node's location is hidden
+	hiddenTimezone string = "Etc/UTC"
+)
+
+// The region-level timezones that coarse locations are mapped to, by continent.
+// Each continent has one entry per common UTC offset so the mapped timezone stays useful for scheduling.
+var regionTimezones = map[string][]string{
+	"Africa":     {"Africa/Abidjan", "Africa/Lagos", "Africa/Johannesburg", "Africa/Nairobi"},
+	"America":    {"America/Anchorage", "America/Los_Angeles", "America/Denver", "America/Chicago", "America/New_York", "America/Halifax", "America/Sao_Paulo"},
+	"Antarctica": {hiddenTimezone},
+	"Asia":       {"Asia/Dubai", "Asia/Karachi", "Asia/Kolkata", "Asia/Dhaka", "Asia/Bangkok", "Asia/Shanghai", "Asia/Tokyo"},
+	"Atlantic":   {"Atlantic/Azores", "Atlantic/Reykjavik"},
+	"Australia":  {"Australia/Perth", "Australia/Adelaide", "Australia/Sydney"},
+	"Europe":     {"Europe/London", "Europe/Berlin", "Europe/Athens", "Europe/Moscow"},
+	"Indian":     {"Indian/Mauritius", "Indian/Maldives"},
+	"Pacific":    {"Pacific/Honolulu", "Pacific/Guam", "Pacific/Auckland"},
+}
+
+// Validate a timezone privacy mode
+func validateTimezonePrivacyMode(mode string) error {
+	switch mode {
+	case "", TimezonePrivacyExact, TimezonePrivacyRegion, TimezonePrivacyHidden:
+		return nil
+	default:
+		return fmt.Errorf("Invalid privacy mode '%s' - must be '%s', '%s', or '%s'", mode, TimezonePrivacyExact, TimezonePrivacyRegion, TimezonePrivacyHidden)
+	}
+}
+
+// Get the timezone to publish for a node's real timezone under the provided privacy mode
+func getTimezoneForPrivacyMode(timezone string, mode string) (string, error) {
+	switch mode {
+	case "", TimezonePrivacyExact:
+		return timezone, nil
+	case TimezonePrivacyHidden:
+		return hiddenTimezone, nil
+	case TimezonePrivacyRegion:
+		return getRegionTimezone(timezone)
+	default:
+		return "", validateTimezonePrivacyMode(mode)
+	}
+}
+
+// Map a timezone to the region-level timezone on the same continent with the closest standard UTC offset
+func getRegionTimezone(timezone string) (string, error) {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return "", fmt.Errorf("error loading timezone '%s': %w", timezone, err)
+	}
+	continent, _, _ := strings.Cut(timezone, "/")
+	candidates, exists := regionTimezones[continent]
+	if !exists {
+		return hiddenTimezone, nil
+	}
+
+	offset := getStandardOffset(location)
+	bestTimezone := hiddenTimezone
+	bestDifference := math.MaxInt
+	for _, candidate := range candidates {
+		candidateLocation, err := time.LoadLocation(candidate)
+		if err != nil {
+			return "", fmt.Errorf("error loading timezone '%s': %w", candidate, err)
+		}
+		difference := getStandardOffset(candidateLocation) - offset
+		if difference < 0 {
+			difference = -difference
+		}
+		if difference < bestDifference {
+			bestTimezone = candidate
+			bestDifference = difference
+		}
+	}
+	return bestTimezone, nil
+}
+
+// Get a location's UTC offset in seconds, ignoring daylight saving time (the smaller of its January and July offsets)
+func getStandardOffset(location *time.Location) int {
+	year := time.Now().Year()
+	_, januaryOffset := time.Date(year, time.January, 1, 0, 0, 0, 0, location).Zone()
+	_, julyOffset := time.Date(year, time.July, 1, 0, 0, 0, 0, location).Zone()
+	return min(januaryOffset, julyOffset)
+}
+
+// Get how precisely a published timezone reveals the node's location
+func getTimezonePrecision(timezone string) string {
+	if timezone == hiddenTimezone {
+		return TimezonePrivacyHidden
+	}
+	continent, _, _ := strings.Cut(timezone, "/")
+	for _, candidate := range regionTimezones[continent] {
+		if candidate == timezone {
+			return TimezonePrivacyRegion
+		}
+	}
+	return TimezonePrivacyExact
+}
+
+// Prompt for the node's timezone (unless it was provided) and apply the selected privacy mode to it
+func getTimezoneLocation(c *cli.Context) (string, error) {
+	var timezoneLocation string
+	if c.String("timezone") != "" {
+		timezoneLocation = c.String("timezone")
+	} else {
+		timezoneLocation = promptTimezone()
+	}
+
+	publishedTimezone, err := getTimezoneForPrivacyMode(timezoneLocation, c.String("privacy-mode"))
+	if err != nil {
+		return "", err
+	}
+	if publishedTimezone != timezoneLocation {
+		fmt.Printf("Using '%s' as the node's timezone location for the '%s' privacy mode.\n", publishedTimezone, c.String("privacy-mode"))
+	}
+	return publishedTimezone, nil
+}
@@ -0,0 +1,51 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+func getValidators(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the node's validators
+	response, err := rp.NodeValidators(c.Bool("refresh"))
+	if err != nil {
+		return err
+	}
+
+	// Print the staleness of the data
+	if response.Cached {
+		fmt.Printf("%sShowing the network state saved by the node process %s ago (slot %d, block %d). Use --refresh to get the latest state.%s\n\n", colorYellow, time.Since(response.CachedTime).Round(time.Second), response.BeaconSlotNumber, response.ElBlockNumber, colorReset)
+	} else {
+		fmt.Printf("Showing the network state at slot %d (block %d).\n\n", response.BeaconSlotNumber, response.ElBlockNumber)
+	}
+
+	if len(response.Validators) == 0 {
+		fmt.Println("The node does not have any minipools yet.")
+		return nil
+	}
+	for _, validator := range response.Validators {
+		fmt.Printf("Minipool %s (%s)\n", validator.MinipoolAddress.Hex(), validator.MinipoolStatus.String())
+		if validator.ValidatorStatus == "" {
+			fmt.Printf("\tValidator %s is not on the Beacon Chain yet.\n", validator.Pubkey.Hex())
+		} else {
+			fmt.Printf("\tValidator %s (index %s): %s, %.6f ETH on the Beacon Chain\n", validator.Pubkey.Hex(), validator.Index, validator.ValidatorStatus, math.RoundDown(eth.WeiToEth(eth.GweiToWei(float64(validator.BeaconBalance))), 6))
+		}
+		fmt.Printf("\tYour share of its total balance: %.6f ETH\n", math.RoundDown(eth.WeiToEth(validator.NodeShareOfTotal), 6))
+	}
+	return nil
+
+}
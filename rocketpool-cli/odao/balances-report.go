@@ -0,0 +1,46 @@
+package odao
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+const (
+	colorReset  string = "\033[0m"
+	colorYellow string = "\033[33m"
+
+	// The change (in percent) versus the on-chain balances that warrants a warning
+	largeBalanceChangePercent float64 = 1
+)
+
+func getBalancesReport(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the report
+	response, err := rp.TNDAOBalancesReport()
+	if err != nil {
+		return err
+	}
+	if !response.ReportExists {
+		fmt.Println("Your watchtower has not generated any balances reports yet.")
+		return nil
+	}
+
+	// Print & return
+	fmt.Println(response.Report.String())
+	maxChange := response.Report.GetMaxOnChainChangePercent()
+	if maxChange > largeBalanceChangePercent {
+		fmt.Printf("%sNOTE: at least one balance changed by %.4f%% since the on-chain balances. Please verify this is expected before the submission reaches consensus.%s\n", colorYellow, maxChange, colorReset)
+	}
+	return nil
+
+}
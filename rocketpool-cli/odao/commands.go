@@ -89,6 +89,63 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "balances-report",
+				Usage:     "Show what changed in the network balances calculated by your watchtower since the previous submission",
+				UsageText: "rocketpool odao balances-report",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getBalancesReport(c)
+
+				},
+			},
+
+			{
+				Name:      "import-rewards-tree",
+				Usage:     "Submit a rewards tree that was generated on a separate machine in offline mode",
+				UsageText: "rocketpool odao import-rewards-tree [options] payload-path",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the submission",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return importRewardsTree(c, c.Args().Get(0))
+
+				},
+			},
+
+			{
+				Name:      "pinning-status",
+				Usage:     "Show which pinning services have your watchtower's rewards files pinned, and whether the public gateways can retrieve them",
+				UsageText: "rocketpool odao pinning-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getPinningStatus(c)
+
+				},
+			},
+
 			{
 				Name:    "propose",
 				Aliases: []string{"p"},
@@ -0,0 +1,136 @@
+package odao
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+func importRewardsTree(c *cli.Context, payloadPath string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	// Load the payload
+	payloadPath, err = homedir.Expand(payloadPath)
+	if err != nil {
+		return fmt.Errorf("error expanding payload path: %w", err)
+	}
+	payload, err := rprewards.LoadSubmissionPayload(payloadPath)
+	if err != nil {
+		return err
+	}
+	index := payload.RewardIndex
+	fmt.Printf("Importing the rewards tree for interval %d (Merkle root %s, CID %s).\n", index, payload.MerkleRoot, payload.MerkleTreeCID)
+
+	// Copy the payload and the files next to it into the rewards folder, where the daemons can see them
+	rewardsTreePath, err := homedir.Expand(cfg.Smartnode.GetRewardsTreePath(index, false))
+	if err != nil {
+		return fmt.Errorf("error expanding rewards tree path: %w", err)
+	}
+	minipoolPerformancePath, err := homedir.Expand(cfg.Smartnode.GetMinipoolPerformancePath(index, false))
+	if err != nil {
+		return fmt.Errorf("error expanding minipool performance path: %w", err)
+	}
+	submissionPath, err := homedir.Expand(cfg.Smartnode.GetRewardsSubmissionPath(index, false))
+	if err != nil {
+		return fmt.Errorf("error expanding submission payload path: %w", err)
+	}
+	sourceFolder := filepath.Dir(payloadPath)
+	for _, target := range []string{
+		submissionPath,
+		rewardsTreePath,
+		rewardsTreePath + config.RewardsTreeIpfsExtension,
+		minipoolPerformancePath,
+		minipoolPerformancePath + config.RewardsTreeIpfsExtension,
+	} {
+		source := filepath.Join(sourceFolder, filepath.Base(target))
+		if target == submissionPath {
+			source = payloadPath
+		}
+		if filepath.Clean(source) == filepath.Clean(target) {
+			continue
+		}
+		bytes, err := os.ReadFile(source)
+		if os.IsNotExist(err) && target != submissionPath && target != rewardsTreePath {
+			// The minipool performance files and compressed tree are checked by the daemon if they're required
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("error reading [%s]: %w", source, err)
+		}
+		if err := files.WriteFileAtomic(target, bytes, 0644); err != nil {
+			return fmt.Errorf("error copying [%s] to [%s]: %w", source, target, err)
+		}
+		fmt.Printf("Copied %s to %s.\n", filepath.Base(source), filepath.Dir(target))
+	}
+	fmt.Println()
+
+	// Check if the tree can be submitted
+	canImport, err := rp.CanImportRewardsTree(index)
+	if err != nil {
+		return err
+	}
+	if !canImport.CanImport {
+		fmt.Println("Cannot submit the rewards tree:")
+		if canImport.WrongInterval {
+			fmt.Printf("The tree is for interval %d, but the current interval is %d.\n", index, canImport.CurrentIndex)
+		}
+		if canImport.AlreadySubmitted {
+			fmt.Printf("Your node has already submitted a tree for interval %d.\n", index)
+		}
+		if canImport.InvalidReason != "" {
+			fmt.Printf("The tree didn't pass verification: %s\n", canImport.InvalidReason)
+		}
+		return nil
+	}
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(canImport.GasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to submit the rewards tree for interval %d?", index))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Submit the tree
+	response, err := rp.ImportRewardsTree(index)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Submitting the rewards tree...\n")
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("Successfully submitted the rewards tree for interval %d.\n", index)
+	return nil
+
+}
@@ -0,0 +1,80 @@
+package odao
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/pinning"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+const (
+	colorGreen string = "\033[32m"
+	colorRed   string = "\033[31m"
+)
+
+func getPinningStatus(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the status
+	response, err := rp.TNDAOPinningStatus()
+	if err != nil {
+		return err
+	}
+	if !response.Enabled {
+		fmt.Println("IPFS pinning is not enabled. You can enable it in the Smartnode section of the `rocketpool service config` TUI.")
+		if len(response.Files) == 0 {
+			return nil
+		}
+		fmt.Println()
+	}
+	if len(response.Files) == 0 {
+		fmt.Println("Your watchtower hasn't pinned any rewards files yet.")
+		return nil
+	}
+
+	// Print & return
+	for _, file := range response.Files {
+		fmt.Printf("Interval %d: %s\n", file.Interval, file.Filename)
+		fmt.Printf("\tCID:       %s\n", file.Cid)
+		if file.LastChecked.IsZero() {
+			fmt.Println("\tNot checked yet.")
+			fmt.Println()
+			continue
+		}
+		fmt.Printf("\tChecked:   %s\n", file.LastChecked.Format(time.RFC1123))
+		if file.UploadError != "" {
+			fmt.Printf("\t%sUpload:    %s%s\n", colorRed, file.UploadError, colorReset)
+		} else if file.Uploaded {
+			fmt.Printf("\tUpload:    uploaded to the IPFS node\n")
+		}
+		if file.RepinCount > 0 {
+			fmt.Printf("\t%sRe-pinned: %d times%s\n", colorYellow, file.RepinCount, colorReset)
+		}
+		for _, pin := range file.Pins {
+			switch {
+			case pin.Error != "":
+				fmt.Printf("\t%s%s: %s%s\n", colorRed, pin.Service, pin.Error, colorReset)
+			case pin.Status == pinning.PinState_Pinned:
+				fmt.Printf("\t%s%s: pinned%s\n", colorGreen, pin.Service, colorReset)
+			default:
+				fmt.Printf("\t%s%s: %s%s\n", colorYellow, pin.Service, pin.Status, colorReset)
+			}
+		}
+		for _, gateway := range file.Gateways {
+			if gateway.Reachable {
+				fmt.Printf("\t%s%s: reachable%s\n", colorGreen, gateway.Gateway, colorReset)
+			} else {
+				fmt.Printf("\t%s%s: %s%s\n", colorRed, gateway.Gateway, gateway.Error, colorReset)
+			}
+		}
+		fmt.Println()
+	}
+	return nil
+
+}
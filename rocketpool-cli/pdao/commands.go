@@ -2137,7 +2137,12 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Flags: []cli.Flag{
 							cli.StringFlag{
 								Name:  "states, s",
-								Usage: "Comma separated list of states to filter ('pending', 'active', 'succeeded', 'executed', 'cancelled', 'defeated', or 'expired')",
+								Usage: "Comma separated list of states to filter ('pending', 'phase1', 'phase2', 'succeeded', 'executed', 'destroyed', 'vetoed', 'quorum-not-met', 'defeated', or 'expired')",
+								Value: "",
+							},
+							cli.StringFlag{
+								Name:  "proposer",
+								Usage: "Only show proposals created by this address",
 								Value: "",
 							},
 						},
@@ -2148,8 +2153,15 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 								return err
 							}
 
+							// Validate flags
+							if c.String("proposer") != "" {
+								if _, err := cliutils.ValidateAddress("proposer", c.String("proposer")); err != nil {
+									return err
+								}
+							}
+
 							// Run
-							return getProposals(c, c.String("states"))
+							return getProposals(c, c.String("states"), c.String("proposer"))
 
 						},
 					},
@@ -2243,6 +2255,28 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						},
 					},
 
+					{
+						Name:      "verify",
+						Aliases:   []string{"r"},
+						Usage:     "Compare a proposal's voting power tree against one generated by your node, and show your node's Merkle proof",
+						UsageText: "rocketpool pdao proposals verify proposal-id",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+							proposalId, err := cliutils.ValidatePositiveUint("proposal-id", c.Args().Get(0))
+							if err != nil {
+								return err
+							}
+
+							// Run
+							return verifyProposal(c, proposalId)
+
+						},
+					},
+
 					{
 						Name:      "defeat",
 						Aliases:   []string{"t"},
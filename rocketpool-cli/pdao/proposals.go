@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
@@ -32,7 +33,15 @@ func filterProposalState(state string, stateFilter string) bool {
 	return true
 }
 
-func getProposals(c *cli.Context, stateFilter string) error {
+func filterProposalProposer(proposer common.Address, proposerFilter string) bool {
+	// Easy out
+	if proposerFilter == "" {
+		return false
+	}
+	return !strings.EqualFold(proposer.Hex(), proposerFilter)
+}
+
+func getProposals(c *cli.Context, stateFilter string, proposerFilter string) error {
 
 	// Get RP client
 	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
@@ -60,6 +69,9 @@ func getProposals(c *cli.Context, stateFilter string) error {
 	// Get proposals by state
 	stateProposals := map[string][]api.PDAOProposalWithNodeVoteDirection{}
 	for _, proposal := range allProposals.Proposals {
+		if filterProposalProposer(proposal.ProposerAddress, proposerFilter) {
+			continue
+		}
 		stateName := types.ProtocolDaoProposalStates[proposal.State]
 		if _, ok := stateProposals[stateName]; !ok {
 			stateProposals[stateName] = []api.PDAOProposalWithNodeVoteDirection{}
@@ -91,6 +103,17 @@ func getProposals(c *cli.Context, stateFilter string) error {
 		// Proposals
 		for _, proposal := range proposals {
 			fmt.Printf("%d: %s - Proposed by: %s\n", proposal.ID, proposal.Message, proposal.ProposerAddress)
+			if proposal.State == types.ProtocolDaoProposalState_ActivePhase1 || proposal.State == types.ProtocolDaoProposalState_ActivePhase2 {
+				fmt.Printf("    For: %.2f, Against: %.2f, Abstained: %.2f, Veto: %.2f (%.2f%% of quorum)\n",
+					eth.WeiToEth(proposal.VotingPowerFor),
+					eth.WeiToEth(proposal.VotingPowerAgainst),
+					eth.WeiToEth(proposal.VotingPowerAbstained),
+					eth.WeiToEth(proposal.VotingPowerToVeto),
+					getQuorumPercent(proposal))
+				if proposal.NodeVoteDirection != types.VoteDirection_NoVote {
+					fmt.Printf("    Node has voted: %s\n", types.VoteDirections[proposal.NodeVoteDirection])
+				}
+			}
 		}
 
 		count += len(proposals)
@@ -178,7 +201,8 @@ func getProposal(c *cli.Context, id uint64) error {
 	fmt.Printf("Voting power for:       %.10f\n", eth.WeiToEth(proposal.VotingPowerFor))
 	fmt.Printf("Voting power against:   %.10f\n", eth.WeiToEth(proposal.VotingPowerAgainst))
 	fmt.Printf("Voting power abstained: %.10f\n", eth.WeiToEth(proposal.VotingPowerAbstained))
-	fmt.Printf("Voting power against:   %.10f\n", eth.WeiToEth(proposal.VotingPowerToVeto))
+	fmt.Printf("Voting power to veto:   %.10f\n", eth.WeiToEth(proposal.VotingPowerToVeto))
+	fmt.Printf("Quorum progress:        %.2f%%\n", getQuorumPercent(*proposal))
 	if proposal.NodeVoteDirection != types.VoteDirection_NoVote {
 		fmt.Printf("Node has voted:         %s\n", types.VoteDirections[proposal.NodeVoteDirection])
 	} else {
@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/types/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/urfave/cli"
 )
@@ -40,3 +41,18 @@ func parseFloat(c *cli.Context, name string, value string, isFraction bool) (*bi
 	}
 	return trueVal, nil
 }
+
+// Get the voting power that has been cast on a proposal as a percentage of the quorum it needs
+func getQuorumPercent(proposal api.PDAOProposalWithNodeVoteDirection) float64 {
+	if proposal.VotingPowerRequired == nil || proposal.VotingPowerRequired.Sign() == 0 {
+		return 0
+	}
+	totalVotes := big.NewInt(0)
+	for _, votes := range []*big.Int{proposal.VotingPowerFor, proposal.VotingPowerAgainst, proposal.VotingPowerAbstained} {
+		if votes != nil {
+			totalVotes.Add(totalVotes, votes)
+		}
+	}
+	percent, _ := big.NewFloat(0).Quo(new(big.Float).SetInt(totalVotes), new(big.Float).SetInt(proposal.VotingPowerRequired)).Float64()
+	return percent * 100
+}
@@ -0,0 +1,74 @@
+package pdao
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+const (
+	colorReset string = "\033[0m"
+	colorRed   string = "\033[31m"
+	colorGreen string = "\033[32m"
+)
+
+func verifyProposal(c *cli.Context, proposalID uint64) error {
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check for Houston
+	houston, err := rp.IsHoustonDeployed()
+	if err != nil {
+		return fmt.Errorf("error checking if Houston has been deployed: %w", err)
+	}
+	if !houston.IsHoustonDeployed {
+		fmt.Println("This command cannot be used until Houston has been deployed.")
+		return nil
+	}
+
+	// Generate the local tree and compare it
+	fmt.Println("Generating the voting power tree for the proposal's target block, this may take a while...")
+	response, err := rp.PDAOVerifyProposalRoot(proposalID)
+	if err != nil {
+		return err
+	}
+	if response.DoesNotExist {
+		fmt.Printf("Proposal with ID %d does not exist.\n", proposalID)
+		return nil
+	}
+
+	// Print the roots
+	fmt.Printf("Target block:           %d\n", response.TargetBlock)
+	fmt.Printf("Proposal root hash:     %s\n", response.ProposalRoot.Hash.Hex())
+	fmt.Printf("Proposal root sum:      %.10f\n", eth.WeiToEth(response.ProposalRoot.Sum))
+	fmt.Printf("Local root hash:        %s\n", response.LocalRoot.Hash.Hex())
+	fmt.Printf("Local root sum:         %.10f\n", eth.WeiToEth(response.LocalRoot.Sum))
+	fmt.Println()
+	if response.RootsMatch {
+		fmt.Printf("%sThe proposal's voting power tree matches the one generated by your node.%s\n", colorGreen, colorReset)
+	} else {
+		fmt.Printf("%sThe proposal's voting power tree does NOT match the one generated by your node.%s\n", colorRed, colorReset)
+		fmt.Println("If you have enabled the 'Enable PDAO Proposal Checker' setting, your node will challenge it automatically.")
+	}
+	fmt.Println()
+
+	// Print the node's own leaf
+	if !response.NodeInTree {
+		fmt.Println("Your node was not registered at the proposal's target block, so it does not have a leaf in the tree.")
+		return nil
+	}
+	fmt.Printf("Your node index:        %d\n", response.NodeIndex)
+	fmt.Printf("Your voting power:      %.10f\n", eth.WeiToEth(response.NodeVotingPower))
+	fmt.Printf("Your proof length:      %d\n", len(response.NodeProof))
+	for i, node := range response.NodeProof {
+		fmt.Printf("\t%d: %s (%.10f)\n", i, node.Hash.Hex(), eth.WeiToEth(node.Sum))
+	}
+	return nil
+}
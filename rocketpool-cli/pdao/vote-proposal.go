@@ -160,7 +160,18 @@ func voteOnProposal(c *cli.Context) error {
 	}
 
 	// Print the voting power
-	fmt.Printf("\n\nYour current voting power: %d\n\n", canVote.VotingPower)
+	fmt.Printf("\n\nYour current voting power: %.10f\n\n", eth.WeiToEth(canVote.VotingPower))
+
+	// Show who the node's vote is delegated to
+	if selectedProposal.State == types.ProtocolDaoProposalState_ActivePhase2 {
+		delegate, err := rp.GetCurrentVotingDelegate()
+		if err != nil {
+			return err
+		}
+		if delegate.VotingDelegate != delegate.AccountAddress {
+			fmt.Printf("Your on-chain voting delegate is %s. Voting in phase 2 will override the vote they cast on your behalf.\n\n", delegate.VotingDelegate.Hex())
+		}
+	}
 
 	// Assign max fees
 	err = gas.AssignMaxFeeAndLimit(canVote.GasInfo, rp, c.Bool("yes"))
@@ -17,7 +17,9 @@ import (
 	"github.com/rocket-pool/smartnode/rocketpool-cli/security"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/service"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/wallet"
+	"github.com/rocket-pool/smartnode/rocketpool-cli/watchtower"
 	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
@@ -76,6 +78,10 @@ ______           _        _    ______           _
 			Usage: "Rocket Pool config asset `path`",
 			Value: "~/.rocketpool",
 		},
+		cli.StringFlag{
+			Name:  "instance, n",
+			Usage: "Use the additional Smartnode instance with this `name` (such as a testnet instance running alongside Mainnet), which keeps its own config, data, containers, and ports",
+		},
 		cli.StringFlag{
 			Name:  "daemon-path, d",
 			Usage: "Interact with a Rocket Pool service daemon at a `path` on the host OS, running outside of docker",
@@ -118,6 +124,7 @@ ______           _        _    ______           _
 	security.RegisterCommands(app, "security", []string{"c"})
 	service.RegisterCommands(app, "service", []string{"s"})
 	wallet.RegisterCommands(app, "wallet", []string{"w"})
+	watchtower.RegisterCommands(app, "watchtower", []string{"t"})
 
 	app.Before = func(c *cli.Context) error {
 		// Check user ID
@@ -127,6 +134,15 @@ ______           _        _    ______           _
 			os.Exit(1)
 		}
 
+		// If set, validate the instance name
+		instanceName := c.GlobalString("instance")
+		if instanceName != "" {
+			if err := rocketpool.ValidateInstanceName(instanceName); err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+		}
+
 		// If set, validate custom nonce
 		customNonce := c.GlobalString("nonce")
 		if customNonce != "" {
@@ -3,6 +3,7 @@ package security
 import (
 	"fmt"
 
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
@@ -62,6 +63,21 @@ func getStatus(c *cli.Context) error {
 		}
 		if status.ProposalCounts.Active > 0 {
 			fmt.Printf("- %d proposal(s) are active and can be voted on\n", status.ProposalCounts.Active)
+			if status.IsMember {
+				proposals, err := rp.SecurityProposals()
+				if err != nil {
+					return err
+				}
+				unvotedCount := 0
+				for _, proposal := range proposals.Proposals {
+					if proposal.State == rptypes.Active && !proposal.MemberVoted {
+						unvotedCount++
+					}
+				}
+				if unvotedCount > 0 {
+					fmt.Printf("  - %d of those are waiting for your vote ('rocketpool security proposals vote')\n", unvotedCount)
+				}
+			}
 		}
 		if status.ProposalCounts.Succeeded > 0 {
 			fmt.Printf("- %d proposal(s) have passed and can be executed\n", status.ProposalCounts.Succeeded)
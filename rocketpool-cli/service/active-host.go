@@ -0,0 +1,77 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Show the active host status, and claim this machine as the active host if requested
+func activeHost(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the status
+	status, err := rp.GetActiveHostStatus()
+	if err != nil {
+		return err
+	}
+	if !status.Enabled {
+		fmt.Printf("%sThe active host guard is disabled. Enable it in the Smartnode section of `rocketpool service config`.%s\n\n", colorYellow, colorReset)
+	}
+	fmt.Printf("This machine's host ID: %s\n", status.HostId)
+	printActiveHostBeacon("Last beacon from this machine", status.LocalBeacon)
+	if status.RemoteError != "" {
+		fmt.Printf("%sCouldn't check the beacon in remote storage: %s%s\n", colorYellow, status.RemoteError, colorReset)
+	} else {
+		printActiveHostBeacon("Beacon in remote storage", status.RemoteBeacon)
+		if status.RemoteBeacon != nil && status.RemoteBeacon.HostId != status.HostId {
+			fmt.Printf("%sThe remote beacon was published by a different machine.%s\n", colorYellow, colorReset)
+		}
+	}
+	fmt.Println()
+	if status.Conflict == nil {
+		fmt.Printf("%sNo conflict; validator duties aren't blocked.%s\n", colorGreen, colorReset)
+	} else {
+		fmt.Printf("%sThe Validator Client was stopped at %s because another machine appears to be running your validators: %s.%s\n",
+			colorRed, status.Conflict.Time.Local().Format(time.RFC1123), status.Conflict.Reason, colorReset)
+	}
+	if !c.Bool("claim") {
+		if status.Conflict != nil {
+			fmt.Println("Once you've made sure no other machine is running your validators, run `rocketpool service active-host --claim` to resume validator duties on this one.")
+		}
+		return nil
+	}
+
+	// Claim this machine
+	fmt.Println()
+	if !(c.Bool("yes") || cliutils.ConfirmWithIAgree("Running the same validator keys on two machines at once will get them slashed. Have you made sure every other machine running this node is stopped?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	if _, err := rp.ClaimActiveHost(); err != nil {
+		return err
+	}
+	fmt.Printf("%sThis machine is now the active host.%s\n", colorGreen, colorReset)
+	if status.Conflict != nil {
+		fmt.Println("The node daemon will restart the Validator Client on its next run.")
+	}
+	return nil
+
+}
+
+// Print an active host beacon
+func printActiveHostBeacon(label string, beacon *api.ActiveHostBeacon) {
+	if beacon == nil {
+		fmt.Printf("%s: none\n", label)
+		return
+	}
+	fmt.Printf("%s: host %s (%s) at %s\n", label, beacon.HostId, beacon.Hostname, beacon.Timestamp.Local().Format(time.RFC1123))
+}
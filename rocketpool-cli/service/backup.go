@@ -0,0 +1,424 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/backup"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Back up the Smartnode's state into an encrypted archive
+func backupService(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	// Get the components
+	components := backup.DefaultComponents
+	if c.IsSet("components") {
+		components, err = backup.ParseComponents(c.String("components"))
+		if err != nil {
+			return err
+		}
+	}
+	if c.Bool("include-validator-keys") && !hasComponent(components, backup.Component_ValidatorKeys) {
+		components = append(components, backup.Component_ValidatorKeys)
+	}
+	fmt.Println("The backup will include:")
+	for _, component := range components {
+		fmt.Printf("\t%s: %s\n", component, backup.ComponentDescriptions[component])
+	}
+	if hasComponent(components, backup.Component_ValidatorKeys) {
+		fmt.Printf("\n%sWARNING: This backup will include your validator keys. Anyone with the backup and its password can get you slashed by running them on another machine, so store it somewhere safe.%s\n", colorYellow, colorReset)
+	}
+	fmt.Println()
+
+	// Get the password
+	password := promptBackupPassword()
+
+	// Stop the Validator Client while its slashing protection database is copied, so the copy is consistent
+	if hasComponent(components, backup.Component_SlashingProtection) && !cfg.IsNativeMode {
+		restart, err := stopValidatorForBackup(rp)
+		if err != nil {
+			return err
+		}
+		if restart {
+			defer startValidatorAfterBackup(rp)
+		}
+	}
+
+	// Create the backup
+	fmt.Println("Creating the backup...")
+	response, err := rp.CreateBackup(backup.FormatComponents(components), password)
+	if err != nil {
+		return err
+	}
+	backupsFolder, err := homedir.Expand(cfg.Smartnode.GetBackupsFolder(false))
+	if err != nil {
+		return fmt.Errorf("error expanding backups folder: %w", err)
+	}
+	path := filepath.Join(backupsFolder, response.Filename)
+
+	// Copy it somewhere else if requested
+	if c.IsSet("output") {
+		output, err := homedir.Expand(c.String("output"))
+		if err != nil {
+			return fmt.Errorf("error expanding output path: %w", err)
+		}
+		archive, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading backup [%s]: %w", path, err)
+		}
+		if backup.GetArchiveHash(archive) != response.Sha256 {
+			return fmt.Errorf("the backup at [%s] doesn't match the one that was just created", path)
+		}
+		err = os.WriteFile(output, archive, 0600)
+		if err != nil {
+			return fmt.Errorf("error copying backup to [%s]: %w", output, err)
+		}
+		path = output
+	}
+
+	fmt.Printf("%sBackup created with %d files.%s\n", colorGreen, len(response.Manifest.Files), colorReset)
+	fmt.Printf("\tPath:    %s\n", path)
+	fmt.Printf("\tSize:    %.2f MiB\n", float64(response.Size)/1024/1024)
+	fmt.Printf("\tSHA-256: %s\n", response.Sha256)
+	fmt.Println("\nKeep the password safe; the backup can't be restored without it. Run `rocketpool service restore --verify-only` on a copy to make sure it's intact.")
+	return nil
+
+}
+
+// Verify a backup and restore some or all of its components
+func restoreService(c *cli.Context, path string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode before restoring a backup; the config can be restored over it.")
+	}
+
+	// Give the daemon access to the backup
+	filename, cleanup, err := prepareBackupForRestore(cfg, path)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	// Verify it
+	password := cliutils.PromptPassword("Please enter the backup's password:", "^.+$", "")
+	fmt.Println("Verifying the backup...")
+	verifyResponse, err := rp.RestoreBackup(filename, "all", password, true)
+	if err != nil {
+		return err
+	}
+	manifest := verifyResponse.Manifest
+	printBackupManifest(manifest)
+	fmt.Printf("%sEvery file in the backup matches its checksum.%s\n\n", colorGreen, colorReset)
+	if c.Bool("verify-only") {
+		return nil
+	}
+
+	// Get the components to restore
+	components := []backup.Component{}
+	for _, name := range manifest.Components {
+		components = append(components, backup.Component(name))
+	}
+	if c.IsSet("components") {
+		components, err = backup.ParseComponents(c.String("components"))
+		if err != nil {
+			return err
+		}
+		for _, component := range components {
+			if !containsString(manifest.Components, string(component)) {
+				return fmt.Errorf("The backup doesn't include the %s component.", component)
+			}
+		}
+	}
+	restoringConfig := hasComponent(components, backup.Component_Config)
+	restoringValidators := hasComponent(components, backup.Component_ValidatorKeys) || hasComponent(components, backup.Component_SlashingProtection)
+
+	// Check that the backup is for this node
+	currentNetwork := string(cfg.Smartnode.Network.Value.(cfgtypes.Network))
+	if manifest.Network != currentNetwork && !restoringConfig {
+		fmt.Printf("%sWARNING: This backup is from %s, but your node is configured for %s.%s\n\n", colorYellow, manifest.Network, currentNetwork, colorReset)
+	}
+	if manifest.NativeMode != cfg.IsNativeMode {
+		fmt.Printf("%sWARNING: This backup was made in %s, but your node is in %s. Check the restored paths and settings carefully.%s\n\n", colorYellow, getModeName(manifest.NativeMode), getModeName(cfg.IsNativeMode), colorReset)
+	}
+
+	// Confirm
+	fmt.Println("The following components will be restored, overwriting what's currently on this node:")
+	for _, component := range components {
+		fmt.Printf("\t%s: %s\n", component, backup.ComponentDescriptions[component])
+	}
+	fmt.Println()
+	if restoringValidators {
+		fmt.Printf("%sWARNING: Only restore validator keys or slashing protection if the machine the backup came from is permanently offline. "+
+			"Running the same validator keys on two machines at once WILL get them slashed, and restoring an old slashing protection database over a newer one removes the protection for everything signed since the backup.%s\n\n", colorRed, colorReset)
+	}
+	if !c.Bool("yes") {
+		if restoringValidators && !cliutils.ConfirmWithIAgree("Have you made sure the validators in this backup aren't running anywhere else?") {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		if !cliutils.Confirm("Are you sure you want to restore these components?") {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	// Stop the Validator Client so it doesn't sign anything while its keys and history are replaced
+	if restoringValidators && !cfg.IsNativeMode {
+		if _, err := stopValidatorForBackup(rp); err != nil {
+			return err
+		}
+	}
+
+	// Restore the data folder
+	fmt.Println("Restoring...")
+	response, err := rp.RestoreBackup(filename, backup.FormatComponents(components), password, false)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Restored %d files into the data folder.\n", response.RestoredFiles)
+
+	// Restore the config
+	if restoringConfig {
+		if err := restoreBackedUpConfig(rp, cfg, response.Settings); err != nil {
+			return err
+		}
+		fmt.Println("Restored the config.")
+	}
+
+	// Restart the services so they load what was restored
+	if cfg.IsNativeMode {
+		fmt.Printf("%sThe backup has been restored. Please restart your node daemon and Validator Client so they load it.%s\n", colorGreen, colorReset)
+		return nil
+	}
+	fmt.Println("Restarting the Smartnode...")
+	if err := restartDaemonsAfterRestore(rp); err != nil {
+		return err
+	}
+	if err := rp.StartService(getComposeFiles(c)); err != nil {
+		return fmt.Errorf("Error starting the Smartnode: %w", err)
+	}
+	fmt.Printf("%sThe backup has been restored.%s\n", colorGreen, colorReset)
+	return nil
+
+}
+
+// Set the password the node daemon encrypts scheduled backups with
+func setBackupPassword(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	// Save the password
+	fmt.Println("Scheduled backups are encrypted with this password. Store it somewhere safe that isn't on this machine; without it, the backups can't be restored.")
+	fmt.Println()
+	password := promptBackupPassword()
+	if _, err := rp.SetBackupPassword(password); err != nil {
+		return err
+	}
+
+	fmt.Printf("%sThe backup password was saved.%s\n", colorGreen, colorReset)
+	if cfg.Backup.Schedule.Value.(string) == "" {
+		fmt.Println("Scheduled backups are disabled. Set a Backup Schedule in the Smartnode section of `rocketpool service config` to enable them.")
+	}
+	return nil
+
+}
+
+// Give the daemon access to a backup by copying it into the data folder, unless it's in the backups folder already.
+// Returns the name to pass to the daemon, and a function that removes the copy.
+func prepareBackupForRestore(cfg *config.RocketPoolConfig, path string) (string, func(), error) {
+	path, err := homedir.Expand(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("error expanding backup path: %w", err)
+	}
+	backupsFolder, err := homedir.Expand(cfg.Smartnode.GetBackupsFolder(false))
+	if err != nil {
+		return "", nil, fmt.Errorf("error expanding backups folder: %w", err)
+	}
+	if filepath.Clean(filepath.Dir(path)) == filepath.Clean(backupsFolder) {
+		return filepath.Base(path), func() {}, nil
+	}
+
+	archive, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("error reading backup [%s]: %w", path, err)
+	}
+	uploadPath, err := homedir.Expand(cfg.Smartnode.GetBackupRestorePath(false))
+	if err != nil {
+		return "", nil, fmt.Errorf("error expanding data directory: %w", err)
+	}
+	err = os.WriteFile(uploadPath, archive, 0600)
+	if err != nil {
+		return "", nil, fmt.Errorf("error copying backup to [%s]: %w", uploadPath, err)
+	}
+	return config.BackupRestoreFilename, func() { os.Remove(uploadPath) }, nil
+}
+
+// Save the config from a backup as the node's config
+func restoreBackedUpConfig(rp *rocketpool.Client, cfg *config.RocketPoolConfig, serializedSettings string) error {
+	var settings map[string]map[string]string
+	if err := yaml.Unmarshal([]byte(serializedSettings), &settings); err != nil {
+		return fmt.Errorf("error parsing the backed up config: %w", err)
+	}
+	configPath, err := homedir.Expand(rp.ConfigPath())
+	if err != nil {
+		return fmt.Errorf("error expanding config path: %w", err)
+	}
+	restoredCfg := config.NewRocketPoolConfig(configPath, cfg.IsNativeMode)
+	if err := restoredCfg.Deserialize(settings); err != nil {
+		return fmt.Errorf("error loading the backed up config: %w", err)
+	}
+	if err := rp.SaveConfig(restoredCfg); err != nil {
+		return fmt.Errorf("error saving the backed up config: %w", err)
+	}
+	return nil
+}
+
+// Restart the node and watchtower containers if they're running, so they load the restored state
+func restartDaemonsAfterRestore(rp *rocketpool.Client) error {
+	prefix, err := rp.GetContainerPrefix()
+	if err != nil {
+		return fmt.Errorf("Error getting container prefix: %w", err)
+	}
+	for _, suffix := range []string{NodeContainerSuffix, WatchtowerContainerSuffix} {
+		if status, err := rp.GetDockerStatus(prefix + suffix); err == nil && status == "running" {
+			if _, err := rp.RestartContainer(prefix + suffix); err != nil {
+				fmt.Printf("%sCouldn't restart %s: %s%s\n", colorYellow, prefix+suffix, err.Error(), colorReset)
+			}
+		}
+	}
+	return nil
+}
+
+// Print the contents of a backup
+func printBackupManifest(manifest api.BackupManifest) {
+	fileCounts := map[string]int{}
+	totalSize := uint64(0)
+	for _, file := range manifest.Files {
+		fileCounts[file.Component]++
+		totalSize += file.Size
+	}
+	fmt.Printf("Backup created %s by Smartnode %s for %s (%s).\n", manifest.Created.Local().Format(time.RFC822), manifest.SmartnodeVersion, manifest.Network, getModeName(manifest.NativeMode))
+	for _, component := range manifest.Components {
+		fmt.Printf("\t%s: %d files\n", component, fileCounts[component])
+	}
+	fmt.Printf("\t%.2f MiB in total\n", float64(totalSize)/1024/1024)
+}
+
+// Prompt for a new backup password
+func promptBackupPassword() string {
+	for {
+		password := cliutils.PromptPassword(
+			"Please enter a password to encrypt the backup with:",
+			fmt.Sprintf("^.{%d,}$", passwords.MinPasswordLength),
+			fmt.Sprintf("The password must be at least %d characters long. Please try again:", passwords.MinPasswordLength),
+		)
+		confirmation := cliutils.PromptPassword("Please confirm the password:", "^.*$", "")
+		if password == confirmation {
+			return password
+		}
+		fmt.Println("Password confirmation does not match.")
+		fmt.Println("")
+	}
+}
+
+// Stop the Validator Client if it's running. Returns true if it was stopped.
+func stopValidatorForBackup(rp *rocketpool.Client) (bool, error) {
+	prefix, err := rp.GetContainerPrefix()
+	if err != nil {
+		return false, fmt.Errorf("Error getting container prefix: %w", err)
+	}
+	status, err := rp.GetDockerStatus(prefix + ValidatorContainerSuffix)
+	if err != nil || status != "running" {
+		return false, nil
+	}
+	fmt.Println("Stopping the Validator Client while its slashing protection database is in use...")
+	if _, err := rp.StopContainer(prefix + ValidatorContainerSuffix); err != nil {
+		return false, fmt.Errorf("Error stopping the Validator Client: %w", err)
+	}
+	return true, nil
+}
+
+// Start the Validator Client again after a backup
+func startValidatorAfterBackup(rp *rocketpool.Client) {
+	prefix, err := rp.GetContainerPrefix()
+	if err == nil {
+		fmt.Println("Starting the Validator Client...")
+		_, err = rp.StartContainer(prefix + ValidatorContainerSuffix)
+	}
+	if err != nil {
+		fmt.Printf("%sCouldn't start the Validator Client again: %s. Please run `rocketpool service start`.%s\n", colorRed, err.Error(), colorReset)
+	}
+}
+
+// Check if a list of backup components has one
+func hasComponent(components []backup.Component, component backup.Component) bool {
+	for _, c := range components {
+		if c == component {
+			return true
+		}
+	}
+	return false
+}
+
+// Check if a list of strings has one
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the name of a mode for display
+func getModeName(nativeMode bool) string {
+	if nativeMode {
+		return "Native Mode"
+	}
+	return "Docker Mode"
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/profiling"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Capture the running daemons' profiles and bundle them for a bug report
+func captureProfile(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+	if cfg.Smartnode.EnableProfiling.Value != true {
+		return fmt.Errorf("Profiling isn't enabled. Turn on 'Enable Profiling' in the Smartnode section of `rocketpool service config`, restart the service, and try again once the slowdown is happening.")
+	}
+
+	// Check the duration
+	duration := c.Duration("duration")
+	if duration <= 0 || duration > profiling.MaxCpuProfileDuration {
+		return fmt.Errorf("The duration must be more than 0 and at most %s.", profiling.MaxCpuProfileDuration)
+	}
+
+	// Capture the profiles
+	fmt.Printf("Profiling the node and watchtower daemons for %s...\n", duration)
+	response, err := rp.CaptureProfile(duration)
+	if err != nil {
+		return err
+	}
+	profilesFolder, err := homedir.Expand(cfg.Smartnode.GetProfilesFolder(false))
+	if err != nil {
+		return fmt.Errorf("error expanding profiles folder: %w", err)
+	}
+
+	// Print the results
+	captured := 0
+	for _, profile := range response.Manifest.Profiles {
+		if profile.Error != "" {
+			fmt.Printf("%sCouldn't capture %s: %s%s\n", colorYellow, profile.Name, profile.Error, colorReset)
+			continue
+		}
+		captured++
+	}
+	if captured == 0 {
+		return fmt.Errorf("None of the profiles could be captured; make sure the node and watchtower are running with `rocketpool service status`.")
+	}
+	fmt.Printf("%sCaptured %d of %d profiles.%s\n", colorGreen, captured, len(response.Manifest.Profiles), colorReset)
+	fmt.Printf("\tPath: %s\n", filepath.Join(profilesFolder, response.Filename))
+	fmt.Printf("\tSize: %.2f MiB\n", float64(response.Size)/1024/1024)
+	fmt.Println("\nAttach the bundle to your bug report. It holds stack traces and memory statistics from the daemons, but no keys or passwords.")
+	return nil
+
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/portcheck"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Check the ports the Smartnode publishes for conflicts and make sure the P2P ports can be reached from the internet
+func checkPorts(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+	if cfg.IsNativeMode {
+		fmt.Println("Your clients are managed outside of the Smartnode in Native mode, so their ports can't be checked here.")
+		return nil
+	}
+
+	// Get the ports the Smartnode is already using so they aren't counted as conflicts
+	ownPorts, err := rp.GetPublishedPorts(cfg.Smartnode.ProjectName.Value.(string))
+	if err != nil {
+		return fmt.Errorf("Error getting the ports used by the Smartnode's containers: %w", err)
+	}
+
+	// Run the checks
+	checks := portcheck.CheckLocalPorts(cfg, ownPorts)
+	if !c.Bool("skip-reachability") {
+		fmt.Println("Checking if your P2P ports can be reached from the internet...")
+		portcheck.CheckReachability(cfg, checks)
+		fmt.Println()
+	}
+
+	// Print the results
+	conflicts := 0
+	unreachable := 0
+	for _, check := range checks {
+		if check.Conflict != "" {
+			conflicts++
+			fmt.Printf("%s%s: port %s.%s\n", colorRed, check.Name(), check.Conflict, colorReset)
+			if check.SuggestedPort != 0 {
+				fmt.Printf("\tPort %d is free, so it can be used instead.\n", check.SuggestedPort)
+			} else {
+				fmt.Println("\tNo free port could be found to use instead.")
+			}
+			continue
+		}
+		switch {
+		case !check.ReachabilityChecked:
+			fmt.Printf("%s: port %d is free.\n", check.Name(), check.Port)
+		case check.ReachabilityError != nil:
+			fmt.Printf("%s%s: port %d is free, but its reachability couldn't be checked: %s%s\n", colorYellow, check.Name(), check.Port, check.ReachabilityError.Error(), colorReset)
+		case check.Reachable:
+			fmt.Printf("%s%s: port %d is free and can be reached from the internet.%s\n", colorGreen, check.Name(), check.Port, colorReset)
+		default:
+			unreachable++
+			fmt.Printf("%s%s: port %d can't be reached from the internet.%s\n", colorYellow, check.Name(), check.Port, colorReset)
+		}
+	}
+	fmt.Println()
+	if unreachable > 0 {
+		fmt.Printf("%sYour clients will still work, but they'll have fewer peers. Forward the unreachable ports to this machine in your router and allow them through your firewall (both TCP and UDP) to fix it.%s\n\n", colorYellow, colorReset)
+	}
+	if cfg.Smartnode.EnableIpv6.Value == true {
+		checkIpv6(cfg, checks, c.Bool("skip-reachability"))
+	}
+	if conflicts == 0 {
+		fmt.Println("None of the Smartnode's ports conflict with anything else on this machine.")
+		return nil
+	}
+
+	// Offer to use the suggested ports
+	suggestions := 0
+	for _, check := range checks {
+		if check.Conflict != "" && check.SuggestedPort != 0 {
+			suggestions++
+		}
+	}
+	if suggestions == 0 {
+		return nil
+	}
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Would you like to switch %d conflicting port(s) to the suggested free ones?", suggestions))) {
+		fmt.Println("Your ports were not changed.")
+		return nil
+	}
+	portcheck.ApplySuggestions(checks)
+	if err := rp.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("error saving config: %w", err)
+	}
+	fmt.Println("Your ports have been updated. Please run `rocketpool service start` to apply the new settings, and remember to update any port forwarding or firewall rules for them.")
+	return nil
+
+}
+
+// Check the host's IPv6 connectivity and, if it has some, whether the P2P ports can be reached over IPv6
+func checkIpv6(cfg *config.RocketPoolConfig, checks []portcheck.PortCheck, skipReachability bool) {
+
+	fmt.Println("Checking your IPv6 connectivity...")
+	ipv6 := portcheck.CheckIpv6(cfg)
+	switch {
+	case ipv6.AddressError != nil:
+		fmt.Printf("%sYour network interfaces couldn't be read: %s%s\n", colorYellow, ipv6.AddressError.Error(), colorReset)
+	case len(ipv6.Addresses) == 0:
+		fmt.Printf("%sThis machine doesn't have a public IPv6 address, so other nodes won't be able to connect to your clients over IPv6.%s\n", colorYellow, colorReset)
+	default:
+		fmt.Printf("This machine's public IPv6 address(es): %s\n", strings.Join(ipv6.Addresses, ", "))
+	}
+	if ipv6.AdvertisedAddressMissing {
+		fmt.Printf("%sYour Advertised IPv6 Address (%s) isn't assigned to this machine, so other nodes may not be able to reach your Consensus client with it.%s\n", colorYellow, cfg.Smartnode.Ipv6AdvertisedAddress.Value, colorReset)
+	}
+	if ipv6.ConnectivityError != nil {
+		fmt.Printf("%sThis machine can't connect to the internet over IPv6 (%s). Your clients will keep using IPv4; check that your router and ISP provide IPv6, or disable IPv6 in the Smartnode settings.%s\n\n", colorYellow, ipv6.ConnectivityError.Error(), colorReset)
+		return
+	}
+	fmt.Printf("%sThis machine can connect to the internet over IPv6.%s\n", colorGreen, colorReset)
+	if skipReachability {
+		fmt.Println()
+		return
+	}
+
+	portcheck.CheckReachabilityOverIpv6(cfg, checks)
+	for _, check := range checks {
+		switch {
+		case !check.Ipv6ReachabilityChecked:
+			continue
+		case check.Ipv6ReachabilityError != nil:
+			fmt.Printf("%s%s: port %d's reachability over IPv6 couldn't be checked: %s%s\n", colorYellow, check.Name(), check.Port, check.Ipv6ReachabilityError.Error(), colorReset)
+		case check.Ipv6Reachable:
+			fmt.Printf("%s%s: port %d can be reached from the internet over IPv6.%s\n", colorGreen, check.Name(), check.Port, colorReset)
+		default:
+			fmt.Printf("%s%s: port %d can't be reached from the internet over IPv6. Allow it through your router's IPv6 firewall so other nodes can connect to it.%s\n", colorYellow, check.Name(), check.Port, colorReset)
+		}
+	}
+	fmt.Println()
+
+}
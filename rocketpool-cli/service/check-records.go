@@ -0,0 +1,77 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Check the watchtower's rolling record checkpoints, optionally dropping the bad ones
+func checkRecords(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Confirm the repair
+	repair := c.Bool("repair")
+	if repair && !(c.Bool("yes") || cliutils.Confirm("Repairing removes the checkpoints that fail the check, and the watchtower will rebuild them from the latest good one. Are you sure you want to continue?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Check the records
+	response, err := rp.CheckRecords(repair)
+	if err != nil {
+		return err
+	}
+	if !response.RollingRecordsEnabled {
+		fmt.Printf("%sNOTE: rolling records are disabled, so these checkpoints aren't being used.%s\n\n", colorYellow, colorReset)
+	}
+	result := response.Result
+	if result.NeedsMigration {
+		fmt.Println("The checkpoints are still in the old layout; the watchtower will move them into the record store the next time it starts.")
+	}
+	if !result.ManifestExists {
+		fmt.Println("No rolling record checkpoints have been saved yet.")
+		return nil
+	}
+
+	fmt.Printf("Checked %d checkpoints across %d intervals.\n", result.CheckpointCount, len(result.Intervals))
+	if result.LatestLoadable {
+		fmt.Printf("The latest usable checkpoint is for slot %d of interval %d.\n", result.LatestSlot, result.LatestInterval)
+	} else {
+		fmt.Printf("%sNone of the checkpoints can be loaded, so the watchtower will have to rebuild the record from the start of the interval.%s\n", colorRed, colorReset)
+	}
+	if !result.HasProblems() {
+		fmt.Printf("%sAll checkpoints are intact.%s\n", colorGreen, colorReset)
+		return nil
+	}
+
+	// Print the problems
+	fmt.Println()
+	if result.OutOfOrder {
+		fmt.Println("A manifest's checkpoints are out of order.")
+	}
+	for _, issue := range result.Issues {
+		if issue.Hash != "" {
+			fmt.Printf("%sInterval %d, slot %d (blob %s): %s%s\n", colorRed, issue.Interval, issue.Slot, issue.Hash, issue.Problem, colorReset)
+		} else {
+			fmt.Printf("%sInterval %d, slot %d: %s%s\n", colorRed, issue.Interval, issue.Slot, issue.Problem, colorReset)
+		}
+	}
+	if len(result.UnusedBlobs) > 0 {
+		fmt.Printf("%d blobs aren't used by any checkpoint.\n", len(result.UnusedBlobs))
+	}
+	fmt.Println()
+	if result.Repaired {
+		fmt.Printf("%sThe records were repaired.%s\n", colorGreen, colorReset)
+	} else {
+		fmt.Println("Run `rocketpool service check-records --repair` while the watchtower is stopped to repair them, or let the watchtower repair them on its next check.")
+	}
+	return nil
+
+}
@@ -3,10 +3,12 @@ package service
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/backup"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
@@ -164,13 +166,228 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					return configureService(c)
 
 				},
+				Subcommands: []cli.Command{
+					{
+						Name:      "set",
+						Usage:     "Change a single setting without opening the config TUI",
+						UsageText: "rocketpool service config set name value",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 2); err != nil {
+								return err
+							}
+
+							// Run command
+							return setConfigParameter(c, c.Args().Get(0), c.Args().Get(1))
+
+						},
+					},
+					{
+						Name:      "search",
+						Usage:     "Find settings by their name or description, and show the names to use with `config set`",
+						UsageText: "rocketpool service config search query",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run command
+							return searchConfigParameters(c, c.Args().Get(0))
+
+						},
+					},
+				},
+			},
+
+			{
+				Name:      "check-ports",
+				Usage:     "Check the ports the Smartnode uses for conflicts with other programs, and whether the P2P ports can be reached from the internet (over IPv6 too if it's enabled)",
+				UsageText: "rocketpool service check-ports [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically switch conflicting ports to the suggested free ones",
+					},
+					cli.BoolFlag{
+						Name:  "skip-reachability, s",
+						Usage: "Only check for local conflicts, without contacting the port checker",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return checkPorts(c)
+
+				},
+			},
+
+			{
+				Name:      "port-forwarding",
+				Usage:     "Show the P2P ports the Node process has forwarded on your router with UPnP or NAT-PMP, and your external IP address",
+				UsageText: "rocketpool service port-forwarding",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return portForwardingStatus(c)
+
+				},
+			},
+
+			{
+				Name:      "tune-host",
+				Usage:     "Check the host's swappiness, open file limit, time synchronization, mount options and huge pages against the recommended values for staking, and optionally apply the recommendations",
+				UsageText: "rocketpool service tune-host [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "apply, a",
+						Usage: "Apply the recommended changes with root privileges",
+					},
+					cli.BoolFlag{
+						Name:  "undo, u",
+						Usage: "Revert the changes made by earlier runs with --apply",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm applying or reverting the changes",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+					if c.Bool("apply") && c.Bool("undo") {
+						return fmt.Errorf("--apply and --undo can't be used together")
+					}
+
+					// Run command
+					return tuneHost(c)
+
+				},
+			},
+
+			{
+				Name:  "rescue-node",
+				Usage: "Temporarily use the community Rescue Node as your Validator Client's Beacon Node while your own is down for a long time, such as during a resync",
+				Subcommands: []cli.Command{
+					{
+						Name:      "enable",
+						Aliases:   []string{"e"},
+						Usage:     "Request a Rescue Node credential signed by the node account and switch the Validator Client to it, with a time limit the node daemon alerts on",
+						UsageText: "rocketpool service rescue-node enable [options]",
+						Flags: []cli.Flag{
+							cli.DurationFlag{
+								Name:  "time-limit, t",
+								Usage: "How long to use the Rescue Node before switching back to your own Beacon Node, e.g. 36h (at most 168h)",
+								Value: 24 * time.Hour,
+							},
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm using the Rescue Node",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return enableRescueNode(c)
+
+						},
+					},
+					{
+						Name:      "disable",
+						Aliases:   []string{"d"},
+						Usage:     "Stop using the Rescue Node and switch the Validator Client back to your own Beacon Node",
+						UsageText: "rocketpool service rescue-node disable [options]",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm switching back",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return disableRescueNode(c)
+
+						},
+					},
+					{
+						Name:      "status",
+						Aliases:   []string{"s"},
+						Usage:     "Show whether the Validator Client is using the Rescue Node, and how long it has left",
+						UsageText: "rocketpool service rescue-node status",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return getRescueNodeStatus(c)
+
+						},
+					},
+				},
+			},
+
+			{
+				Name:      "instances",
+				Usage:     "List the Smartnode instances this CLI manages; use `rocketpool -n <name> ...` to run a command against an additional instance",
+				UsageText: "rocketpool service instances",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return listInstances(c)
+
+				},
 			},
 
 			{
 				Name:      "status",
 				Aliases:   []string{"u"},
 				Usage:     "View the Rocket Pool service status",
-				UsageText: "rocketpool service status",
+				UsageText: "rocketpool service status [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "tui",
+						Usage: "Show a live dashboard of the clients, validator duties, rolling records, pending transactions, and alerts",
+					},
+					cli.Uint64Flag{
+						Name:  "refresh-interval, r",
+						Usage: "How often the live dashboard should refresh, in seconds",
+						Value: 12,
+					},
+				},
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -348,6 +565,81 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "doctor",
+				Usage:     "Check your node for the most common problems and list the ones found, most urgent first, with how to fix them",
+				UsageText: "rocketpool service doctor [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "all, a",
+						Usage: "Show the details of the checks that passed as well",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return runDoctor(c)
+
+				},
+			},
+
+			{
+				Name:      "support-bundle",
+				Usage:     "Collect your redacted config, recent container logs, daemon and client status, versions, and host info into a single archive for a bug report",
+				UsageText: "rocketpool service support-bundle [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The path to save the bundle to; defaults to a file named after the current time in this folder",
+					},
+					cli.StringFlag{
+						Name:  "tail, t",
+						Usage: "The number of lines to include from the end of each container's logs (number or \"all\")",
+						Value: "1000",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return createSupportBundle(c)
+
+				},
+			},
+
+			{
+				Name:      "capture-profile",
+				Usage:     "Capture CPU, heap, and goroutine profiles from the running daemons and bundle them for a bug report",
+				UsageText: "rocketpool service capture-profile [options]",
+				Flags: []cli.Flag{
+					cli.DurationFlag{
+						Name:  "duration, d",
+						Usage: "How long to profile the CPU for, e.g. 30s (at most 5m)",
+						Value: 30 * time.Second,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return captureProfile(c)
+
+				},
+			},
+
 			{
 				Name:      "compose",
 				Usage:     "View the Rocket Pool service docker compose config",
@@ -369,7 +661,13 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				Name:      "version",
 				Aliases:   []string{"v"},
 				Usage:     "View the Rocket Pool service version information",
-				UsageText: "rocketpool service version",
+				UsageText: "rocketpool service version [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "check, c",
+						Usage: "Check GitHub and Docker Hub for new releases of your clients and the Smartnode, and show their changelogs",
+					},
+				},
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -383,6 +681,256 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "upgrade-clients",
+				Usage:     "Upgrade your locally managed clients to their latest releases, rolling back any that fail their health checks or, with --canary, their bake period",
+				UsageText: "rocketpool service upgrade-clients [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the upgrade",
+					},
+					cli.Uint64Flag{
+						Name:  "health-check-time, t",
+						Usage: "How long, in seconds, the upgraded containers need to stay up before the upgrade is kept",
+						Value: 120,
+					},
+					cli.BoolFlag{
+						Name:  "canary",
+						Usage: "After the health checks pass, keep watching the clients' sync health and your validators' attestations for the bake period, and roll back if either error budget is exceeded",
+					},
+					cli.Uint64Flag{
+						Name:  "bake-period",
+						Usage: "How long, in minutes, a canary upgrade is watched before it's kept",
+						Value: 60,
+					},
+					cli.Float64Flag{
+						Name:  "min-efficiency",
+						Usage: "The attestation efficiency, as a percentage, an epoch needs during a canary upgrade to count as healthy (defaults to the alerting threshold in your config)",
+					},
+					cli.Uint64Flag{
+						Name:  "max-low-epochs",
+						Usage: "How many epochs in a row can be below the minimum efficiency before a canary upgrade is rolled back (defaults to the alerting setting in your config)",
+					},
+					cli.Uint64Flag{
+						Name:  "max-sync-failures",
+						Usage: "How many failed sync checks are allowed before a canary upgrade is rolled back",
+						Value: 3,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return upgradeClients(c)
+
+				},
+			},
+
+			{
+				Name:      "backup",
+				Usage:     "Back up your Smartnode's config, wallet, slashing protection, rolling records, and daemon state into an encrypted archive",
+				UsageText: "rocketpool service backup [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "components, c",
+						Usage: fmt.Sprintf("A comma-separated list of the components to back up (%s); defaults to %s", backup.FormatComponents(backup.AllComponents), backup.FormatComponents(backup.DefaultComponents)),
+					},
+					cli.BoolFlag{
+						Name:  "include-validator-keys, k",
+						Usage: "Include your validator keys in the backup; they can be regenerated from your wallet, so only do this if you have keys that can't be",
+					},
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "Copy the backup to this path as well as keeping it in the backups folder",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return backupService(c)
+
+				},
+			},
+
+			{
+				Name:      "restore",
+				Usage:     "Verify a backup made with `rocketpool service backup` and restore some or all of its components",
+				UsageText: "rocketpool service restore [options] path",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "components, c",
+						Usage: "A comma-separated list of the components to restore; defaults to everything in the backup",
+					},
+					cli.BoolFlag{
+						Name:  "verify-only",
+						Usage: "Only check that the backup can be decrypted and that every file in it is intact",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the restore",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run command
+					return restoreService(c, c.Args().Get(0))
+
+				},
+			},
+
+			{
+				Name:  "migrate",
+				Usage: "Move your node to a new machine without risking your validators being slashed",
+				Subcommands: []cli.Command{
+					{
+						Name:      "export",
+						Aliases:   []string{"e"},
+						Usage:     "On the old machine: stop the validators, package the node into an encrypted backup, and deactivate the Smartnode",
+						UsageText: "rocketpool service migrate export [options]",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "include-validator-keys, k",
+								Usage: "Include your validator keys in the backup; only needed for keys that can't be regenerated from your wallet",
+							},
+							cli.StringFlag{
+								Name:  "output, o",
+								Usage: "Copy the backup to this path as well as keeping it in the backups folder",
+							},
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm stopping the validators",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return migrateExport(c)
+
+						},
+					},
+					{
+						Name:      "import",
+						Aliases:   []string{"i"},
+						Usage:     "On the new machine: restore the exported backup, wait for the clients to sync and the validators to be offline everywhere else, then start validating",
+						UsageText: "rocketpool service migrate import [options] path",
+						Flags: []cli.Flag{
+							cli.Uint64Flag{
+								Name:  "safety-epochs",
+								Usage: "The number of finished epochs in a row with none of your validators' attestations on chain before they're started on this machine",
+								Value: 3,
+							},
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm the import and the old machine being offline",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run command
+							return migrateImport(c, c.Args().Get(0))
+
+						},
+					},
+					{
+						Name:      "resume",
+						Aliases:   []string{"r"},
+						Usage:     "On the new machine: continue an import that was interrupted, starting with the sync and safety checks",
+						UsageText: "rocketpool service migrate resume [options]",
+						Flags: []cli.Flag{
+							cli.Uint64Flag{
+								Name:  "safety-epochs",
+								Usage: "The number of finished epochs in a row with none of your validators' attestations on chain before they're started on this machine",
+								Value: 3,
+							},
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm the old machine being offline",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return migrateResume(c)
+
+						},
+					},
+				},
+			},
+
+			{
+				Name:      "set-backup-password",
+				Usage:     "Set the password that scheduled backups are encrypted with",
+				UsageText: "rocketpool service set-backup-password",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return setBackupPassword(c)
+
+				},
+			},
+
+			{
+				Name:      "active-host",
+				Usage:     "Show which machine is running the node's validators, and claim this one as the active host to clear a conflict",
+				UsageText: "rocketpool service active-host [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "claim, c",
+						Usage: "Claim this machine as the active host so the node daemon resumes validator duties",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the claim",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return activeHost(c)
+
+				},
+			},
+
 			{
 				Name:      "prune-eth1",
 				Aliases:   []string{"n"},
@@ -452,6 +1000,144 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "check-records",
+				Usage:     "Checks the watchtower's rolling record checkpoints for corruption, and optionally drops the bad ones so they don't block the next rewards submission",
+				UsageText: "rocketpool service check-records [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "repair, r",
+						Usage: "Remove the checkpoints that fail the check, and the blobs no checkpoint uses. The watchtower must be stopped.",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the repair",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return checkRecords(c)
+
+				},
+			},
+
+			{
+				Name:      "test-alert",
+				Usage:     "Send a test alert to every enabled notification sink (email, ntfy, and Pushover) to check that they're set up correctly",
+				UsageText: "rocketpool service test-alert [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "severity, s",
+						Usage: "The severity of the test alert ('critical', 'warning', or 'info')",
+						Value: "critical",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+					if _, err := cliutils.ValidateAlertSeverity("severity", c.String("severity")); err != nil {
+						return err
+					}
+
+					// Run command
+					return testAlert(c)
+
+				},
+			},
+
+			{
+				Name:      "test-failure",
+				Usage:     "Inject a synthetic failure ('client-unreachable', 'missed-attestation', or 'low-disk') into the alerting pipeline to check your notifications end to end, without touching any of your real services",
+				UsageText: "rocketpool service test-failure scenario",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					scenario, err := cliutils.ValidateFailureScenario("scenario", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run command
+					return testFailure(c, scenario)
+
+				},
+			},
+
+			{
+				Name:  "devnet",
+				Usage: "Helpers for testing the Smartnode against a local devnet",
+				Subcommands: []cli.Command{
+					{
+						Name:      "fund",
+						Aliases:   []string{"f"},
+						Usage:     "Send ETH and RPL to the node wallet from the devnet's funder account",
+						UsageText: "rocketpool service devnet fund [options]",
+						Flags: []cli.Flag{
+							cli.Float64Flag{
+								Name:  "eth, e",
+								Usage: "The amount of ETH to send",
+								Value: 100,
+							},
+							cli.Float64Flag{
+								Name:  "rpl, r",
+								Usage: "The amount of RPL to send",
+								Value: 10000,
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return devnetFund(c)
+
+						},
+					},
+					{
+						Name:      "fast-forward",
+						Aliases:   []string{"ff"},
+						Usage:     "Advance the devnet's clock past the end of the current rewards interval, plus any extra intervals, so the next rewards tree can be generated",
+						UsageText: "rocketpool service devnet fast-forward [options]",
+						Flags: []cli.Flag{
+							cli.Uint64Flag{
+								Name:  "intervals, i",
+								Usage: "The number of intervals to advance; 1 ends the current interval",
+								Value: 1,
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+							if c.Uint64("intervals") == 0 {
+								return fmt.Errorf("intervals must be at least 1")
+							}
+
+							// Run command
+							return devnetFastForward(c)
+
+						},
+					},
+				},
+			},
+
 			{
 				Name:      "get-config-yaml",
 				Usage:     "Generate YAML that shows the current configuration schema, including all of the parameters and their descriptions",
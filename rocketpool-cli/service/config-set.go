@@ -0,0 +1,128 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The most search results to print
+const maxConfigSearchResults int = 15
+
+// Change a single setting without opening the config TUI
+func setConfigParameter(c *cli.Context, name string, value string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	// Find the setting
+	result, err := cfg.FindParameter(name)
+	if err != nil {
+		return err
+	}
+	if result.Parameter.ID == config.NetworkID {
+		return fmt.Errorf("Changing networks removes your chain data, wallet, and validator keys, so it can only be done with `rocketpool service config`.")
+	}
+
+	// Change it and make sure the config is still valid
+	oldCfg := cfg.CreateCopy()
+	oldValue := fmt.Sprint(result.Parameter.Value)
+	if err := result.Parameter.SetValueFromString(value); err != nil {
+		return fmt.Errorf("Invalid value for %s: %w", result.FlagName, err)
+	}
+	if err := cfg.CheckParameterDependencies(result); err != nil {
+		return err
+	}
+	profileChanges := []*cfgtypes.Parameter{}
+	if result.Parameter.ID == config.HardwareProfileID {
+		profileChanges = cfg.ApplyHardwareProfile()
+	}
+	errors := cfg.Validate()
+	if len(errors) > 0 {
+		fmt.Printf("%sThe new value would leave your configuration with errors, so it wasn't saved:\n\n", colorRed)
+		for _, err := range errors {
+			fmt.Printf("%s\n\n", err)
+		}
+		fmt.Print(colorReset)
+		return nil
+	}
+
+	// Save it
+	if err := rp.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("error saving config: %w", err)
+	}
+	fmt.Printf("%s (%s) changed from [%s] to [%s].\n", result.Parameter.Name, result.FlagName, oldValue, fmt.Sprint(result.Parameter.Value))
+	if len(profileChanges) > 0 {
+		fmt.Println("The hardware profile changed these settings:")
+		for _, param := range profileChanges {
+			fmt.Printf("\t%s: [%s]\n", param.Name, fmt.Sprint(param.Value))
+		}
+	}
+	for _, warning := range cfg.GetWarnings() {
+		fmt.Printf("%sNOTE: %s%s\n", colorYellow, warning, colorReset)
+	}
+
+	// Print the containers to restart
+	_, affectedContainers, _ := cfg.GetChanges(oldCfg)
+	if len(affectedContainers) > 0 && !cfg.IsNativeMode {
+		prefix := fmt.Sprint(cfg.Smartnode.ProjectName.Value)
+		fmt.Println("The following containers must be restarted for the change to take effect:")
+		for container := range affectedContainers {
+			fmt.Printf("\t%s_%s\n", prefix, container)
+		}
+		fmt.Println("Please run `rocketpool service start` when you are ready to apply it.")
+	} else if cfg.IsNativeMode {
+		fmt.Println("Please restart your daemon service for it to take effect.")
+	}
+	return nil
+
+}
+
+// Search the settings by their ID, name, and description
+func searchConfigParameters(c *cli.Context, query string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	results := cfg.SearchParameters(query)
+	if len(results) == 0 {
+		fmt.Printf("No settings match [%s].\n", query)
+		return nil
+	}
+	for i, result := range results {
+		if i == maxConfigSearchResults {
+			fmt.Printf("...and %d more. Try a more specific search to narrow them down.\n", len(results)-maxConfigSearchResults)
+			break
+		}
+		fmt.Printf("%s%s%s (%s)\n", colorGreen, result.FlagName, colorReset, result.Parameter.Name)
+		fmt.Printf("\tCurrent value: [%s]\n", fmt.Sprint(result.Parameter.Value))
+	}
+	fmt.Println()
+	fmt.Println("Use `rocketpool service config set <name> <value>` to change one of them.")
+	return nil
+
+}
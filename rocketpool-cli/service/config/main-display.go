@@ -30,6 +30,9 @@ type mainDisplay struct {
 	ShouldSave          bool
 	ContainersToRestart []cfgtypes.ContainerID
 	ChangeNetworks      bool
+
+	// The host ports the Smartnode's running containers already publish, so the wizard doesn't count them as conflicts
+	PublishedPorts map[string]bool
 }
 
 // Creates a new MainDisplay instance.
@@ -78,6 +78,15 @@ func NewReviewPage(md *mainDisplay, oldConfig *config.RocketPoolConfig, newConfi
 				containersToRestart = append(containersToRestart, container)
 			}
 		}
+
+		// Add anything that can be saved but needs a second look
+		warnings := newConfig.GetWarnings()
+		if len(warnings) > 0 {
+			builder.WriteString("\n\n[orange]NOTE: These settings may not work the way you expect:\n\n")
+			for _, warning := range warnings {
+				builder.WriteString(fmt.Sprintf("%s\n\n", warning))
+			}
+		}
 	}
 
 	changeBox.SetText(builder.String())
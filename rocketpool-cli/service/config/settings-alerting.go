@@ -19,6 +19,32 @@ var alertingParametersNativeMode map[string]interface{} = map[string]interface{}
 	"alertEnabled_MinipoolStaked":              nil,
 	"alertEnabled_ExecutionClientSyncComplete": nil,
 	"alertEnabled_BeaconClientSyncComplete":    nil,
+	"alertEnabled_RplPriceDivergence":          nil,
+	"alertEnabled_SecurityCouncilProposal":     nil,
+	"alertEnabled_RplStakeAboveUpperBound":     nil,
+	"alertEnabled_GasLimitNotHonored":          nil,
+	"alertEnabled_FallbackClientsChanged":      nil,
+	"alertEnabled_ContractsUpgraded":           nil,
+	"alertEnabled_RecordsInconsistent":         nil,
+	"alertEnabled_HostDiskFull":                nil,
+	"alertEnabled_HostHighIoWait":              nil,
+	"alertEnabled_NvmeWear":                    nil,
+	"alertEnabled_MissedOdaoSubmission":        nil,
+	"alertEnabled_RewardsReadinessFailed":      nil,
+	"alertEnabled_LowAttestationEfficiency":    nil,
+	"alertEnabled_SyncCommitteeReadiness":      nil,
+	"alertEnabled_ClockDrift":                  nil,
+	"alertEnabled_ClientUpdatesAvailable":      nil,
+	"alertEnabled_BackupFailed":                nil,
+	"alertEnabled_BackupOutOfDate":             nil,
+	"alertEnabled_ActiveHostConflict":          nil,
+	"hostDiskUsageThreshold":                   nil,
+	"hostIoWaitThreshold":                      nil,
+	"nvmeWearThreshold":                        nil,
+	"missedSubmissionLag":                      nil,
+	"attestationEfficiencyThreshold":           nil,
+	"attestationEfficiencyEpochs":              nil,
+	"clockDriftThreshold":                      nil,
 }
 
 var alertingParametersDockerMode map[string]interface{} = map[string]interface{}{
@@ -43,6 +69,32 @@ var alertingParametersDockerMode map[string]interface{} = map[string]interface{}
 	"alertEnabled_MinipoolStaked":              nil,
 	"alertEnabled_ExecutionClientSyncComplete": nil,
 	"alertEnabled_BeaconClientSyncComplete":    nil,
+	"alertEnabled_RplPriceDivergence":          nil,
+	"alertEnabled_SecurityCouncilProposal":     nil,
+	"alertEnabled_RplStakeAboveUpperBound":     nil,
+	"alertEnabled_GasLimitNotHonored":          nil,
+	"alertEnabled_FallbackClientsChanged":      nil,
+	"alertEnabled_ContractsUpgraded":           nil,
+	"alertEnabled_RecordsInconsistent":         nil,
+	"alertEnabled_HostDiskFull":                nil,
+	"alertEnabled_HostHighIoWait":              nil,
+	"alertEnabled_NvmeWear":                    nil,
+	"alertEnabled_MissedOdaoSubmission":        nil,
+	"alertEnabled_RewardsReadinessFailed":      nil,
+	"alertEnabled_LowAttestationEfficiency":    nil,
+	"alertEnabled_SyncCommitteeReadiness":      nil,
+	"alertEnabled_ClockDrift":                  nil,
+	"alertEnabled_ClientUpdatesAvailable":      nil,
+	"alertEnabled_BackupFailed":                nil,
+	"alertEnabled_BackupOutOfDate":             nil,
+	"alertEnabled_ActiveHostConflict":          nil,
+	"hostDiskUsageThreshold":                   nil,
+	"hostIoWaitThreshold":                      nil,
+	"nvmeWearThreshold":                        nil,
+	"missedSubmissionLag":                      nil,
+	"attestationEfficiencyThreshold":           nil,
+	"attestationEfficiencyEpochs":              nil,
+	"clockDriftThreshold":                      nil,
 }
 
 // The page wrapper for the alerting config
@@ -54,6 +106,8 @@ type AlertingConfigPage struct {
 	masterConfig        *config.RocketPoolConfig
 	alertingEnabledItem parameterizedFormItem
 	otherItems          []*parameterizedFormItem
+	notificationItems   []*parameterizedFormItem
+	heartbeatItems      []*parameterizedFormItem
 }
 
 func NewAlertingConfigPage(home *settingsHome) *AlertingConfigPage {
@@ -138,6 +192,14 @@ func (configPage *AlertingConfigPage) createContent() {
 		fmt.Println("Error: enableAlerting checkbox not found in alertmanagerItems")
 	}
 
+	// Set up the notification settings; these deliver Alertmanager's alerts, so they're only shown when alerting is enabled
+	configPage.notificationItems = createParameterizedFormItems(configPage.masterConfig.Notifications.GetParameters(), configPage.layout.descriptionBox)
+	configPage.layout.mapParameterizedFormItems(configPage.notificationItems...)
+
+	// Set up the heartbeat settings, which don't depend on Alertmanager
+	configPage.heartbeatItems = createParameterizedFormItems(configPage.masterConfig.Heartbeat.GetParameters(), configPage.layout.descriptionBox)
+	configPage.layout.mapParameterizedFormItems(configPage.heartbeatItems...)
+
 	// Do the initial draw
 	configPage.handleLayoutChanged()
 }
@@ -148,6 +210,8 @@ func (configPage *AlertingConfigPage) handleLayoutChanged() {
 	configPage.layout.addFormItems([]*parameterizedFormItem{&configPage.alertingEnabledItem})
 	if configPage.masterConfig.Alertmanager.EnableAlerting.Value == true {
 		configPage.layout.addFormItems(configPage.otherItems)
+		configPage.layout.addFormItems(configPage.notificationItems)
 	}
+	configPage.layout.addFormItems(configPage.heartbeatItems)
 	configPage.layout.refresh()
 }
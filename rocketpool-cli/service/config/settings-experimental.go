@@ -0,0 +1,78 @@
+package config
+
+import (
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The page wrapper for the experimental features config
+type ExperimentalConfigPage struct {
+	mainDisplay  *mainDisplay
+	homePage     *page
+	page         *page
+	layout       *standardLayout
+	masterConfig *config.RocketPoolConfig
+}
+
+// Creates a new page for the experimental features
+func NewExperimentalConfigPage(home *settingsHome) *ExperimentalConfigPage {
+	configPage := &ExperimentalConfigPage{
+		mainDisplay:  home.md,
+		homePage:     home.homePage,
+		masterConfig: home.md.Config,
+	}
+
+	configPage.createContent()
+	configPage.initPage(false)
+
+	return configPage
+}
+
+// Creates a new page for the experimental features in Native mode
+func NewExperimentalConfigPageForNative(home *settingsNativeHome) *ExperimentalConfigPage {
+	configPage := &ExperimentalConfigPage{
+		mainDisplay:  home.md,
+		homePage:     home.homePage,
+		masterConfig: home.md.Config,
+	}
+
+	configPage.createContent()
+	configPage.initPage(true)
+
+	return configPage
+}
+
+func (configPage *ExperimentalConfigPage) initPage(isNative bool) {
+	id := "settings-experimental"
+	if isNative {
+		id = "settings-experimental-native"
+	}
+	configPage.page = newPage(
+		configPage.homePage,
+		id,
+		"Experimental Features",
+		"Select this to turn on features that are still being tested. They're disabled by default and may change or be removed in future releases, so only enable them if you're comfortable troubleshooting them.",
+		configPage.layout.grid,
+	)
+}
+
+// Get the underlying page
+func (configPage *ExperimentalConfigPage) getPage() *page {
+	return configPage.page
+}
+
+// Creates the content for the experimental features page
+func (configPage *ExperimentalConfigPage) createContent() {
+	configPage.layout = newStandardLayout()
+	configPage.layout.createForm(&configPage.masterConfig.Smartnode.Network, "Experimental Features")
+	configPage.layout.setupEscapeReturnHomeHandler(configPage.mainDisplay, configPage.homePage)
+
+	formItems := createParameterizedFormItems(configPage.masterConfig.Experimental.GetParameters(), configPage.layout.descriptionBox)
+	configPage.layout.mapParameterizedFormItems(formItems...)
+	configPage.layout.addFormItems(formItems)
+	configPage.layout.refresh()
+}
+
+// Handle a bulk redraw request
+func (configPage *ExperimentalConfigPage) handleLayoutChanged() {
+	configPage.layout.refresh()
+}
@@ -16,6 +16,7 @@ type FallbackConfigPage struct {
 	reconnectDelay      *parameterizedFormItem
 	fallbackNormalItems []*parameterizedFormItem
 	fallbackPrysmItems  []*parameterizedFormItem
+	additionalBox       *parameterizedFormItem
 }
 
 // Creates a new page for the fallback client settings
@@ -57,11 +58,13 @@ func (configPage *FallbackConfigPage) createContent() {
 	configPage.reconnectDelay = createParameterizedStringField(&configPage.masterConfig.ReconnectDelay)
 	configPage.fallbackNormalItems = createParameterizedFormItems(configPage.masterConfig.FallbackNormal.GetParameters(), configPage.layout.descriptionBox)
 	configPage.fallbackPrysmItems = createParameterizedFormItems(configPage.masterConfig.FallbackPrysm.GetParameters(), configPage.layout.descriptionBox)
+	configPage.additionalBox = createParameterizedStringField(&configPage.masterConfig.AdditionalFallbackClients)
 
 	// Map the parameters to the form items in the layout
 	configPage.layout.mapParameterizedFormItems(configPage.useFallbackBox, configPage.reconnectDelay)
 	configPage.layout.mapParameterizedFormItems(configPage.fallbackNormalItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.fallbackPrysmItems...)
+	configPage.layout.mapParameterizedFormItems(configPage.additionalBox)
 
 	// Set up the setting callbacks
 	configPage.useFallbackBox.item.(*tview.Checkbox).SetChangedFunc(func(checked bool) {
@@ -94,6 +97,7 @@ func (configPage *FallbackConfigPage) handleUseFallbackChanged() {
 	default:
 		configPage.layout.addFormItems(configPage.fallbackNormalItems)
 	}
+	configPage.layout.form.AddFormItem(configPage.additionalBox.item)
 
 	configPage.layout.refresh()
 }
@@ -5,7 +5,6 @@ import (
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
-	"github.com/rocket-pool/smartnode/shared/types/config"
 )
 
 const settingsHomeID string = "settings-home"
@@ -22,7 +21,10 @@ type settingsHome struct {
 	mevBoostPage     *MevBoostConfigPage
 	metricsPage      *MetricsConfigPage
 	alertingPage     *AlertingConfigPage
+	resourcesPage    *ResourcesConfigPage
+	experimentalPage *ExperimentalConfigPage
 	addonsPage       *AddonsPage
+	searchPage       *settingsSearchPage
 	categoryList     *tview.List
 	settingsSubpages []settingsPage
 	content          tview.Primitive
@@ -48,6 +50,8 @@ func newSettingsHome(md *mainDisplay) *settingsHome {
 	home.mevBoostPage = NewMevBoostConfigPage(home)
 	home.metricsPage = NewMetricsConfigPage(home)
 	home.alertingPage = NewAlertingConfigPage(home)
+	home.resourcesPage = NewResourcesConfigPage(home)
+	home.experimentalPage = NewExperimentalConfigPage(home)
 	home.addonsPage = NewAddonsPage(home)
 	settingsSubpages := []settingsPage{
 		home.smartnodePage,
@@ -57,6 +61,8 @@ func newSettingsHome(md *mainDisplay) *settingsHome {
 		home.mevBoostPage,
 		home.metricsPage,
 		home.alertingPage,
+		home.resourcesPage,
+		home.experimentalPage,
 		home.addonsPage,
 	}
 	home.settingsSubpages = settingsSubpages
@@ -65,6 +71,21 @@ func newSettingsHome(md *mainDisplay) *settingsHome {
 	for _, subpage := range settingsSubpages {
 		md.pages.AddPage(subpage.getPage().id, subpage.getPage().content, true, false)
 	}
+
+	// Create the search page
+	home.searchPage = newSettingsSearchPage(md, homePage, "settings-search", []searchablePage{
+		{home.smartnodePage.page, home.smartnodePage.layout},
+		{home.ecPage.page, home.ecPage.layout},
+		{home.ccPage.page, home.ccPage.layout},
+		{home.fallbackPage.page, home.fallbackPage.layout},
+		{home.mevBoostPage.page, home.mevBoostPage.layout},
+		{home.metricsPage.page, home.metricsPage.layout},
+		{home.alertingPage.page, home.alertingPage.layout},
+		{home.resourcesPage.page, home.resourcesPage.layout},
+		{home.experimentalPage.page, home.experimentalPage.layout},
+		{home.addonsPage.gwwPage.page, home.addonsPage.gwwPage.layout},
+		{home.addonsPage.rescueNodePage.page, home.addonsPage.rescueNodePage.layout},
+	})
 	home.createContent()
 	homePage.content = home.content
 	md.pages.AddPage(homePage.id, home.content, true, false)
@@ -80,7 +101,7 @@ func (home *settingsHome) createContent() {
 	// Create the category list
 	categoryList := tview.NewList().
 		SetChangedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
-			if (home.md.Config.Smartnode.Network.Value == config.Network_Holesky || home.md.Config.Smartnode.Network.Value == config.Network_Devnet) && home.settingsSubpages[index].getPage().id == "settings-mev-boost" {
+			if !home.md.Config.IsMevBoostAvailable() && home.settingsSubpages[index].getPage().id == "settings-mev-boost" {
 				// Disable MEV-Boost for Holesky
 				layout.descriptionBox.SetText("MEV-Boost is currently disabled for the Holesky test network.")
 			} else {
@@ -91,12 +112,26 @@ func (home *settingsHome) createContent() {
 	categoryList.SetBorderPadding(0, 0, 1, 1)
 	home.categoryList = categoryList
 
-	// Set tab to switch to the save and quit buttons
+	// Set tab to switch to the save and quit buttons, / to search, and A to toggle the advanced settings
 	categoryList.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		if event.Key() == tcell.KeyTab || event.Key() == tcell.KeyBacktab {
 			home.md.app.SetFocus(home.saveButton)
 			return nil
 		}
+		if event.Rune() == '/' {
+			home.searchPage.show()
+			return nil
+		}
+		if event.Rune() == 'a' || event.Rune() == 'A' {
+			showAdvancedSettings = !showAdvancedSettings
+			home.smartnodePage.handleLayoutChanged()
+			if showAdvancedSettings {
+				layout.descriptionBox.SetText("Advanced settings are now shown. They're meant for fine-tuning and troubleshooting, and the defaults are right for most node operators. Press A again to hide them.")
+			} else {
+				layout.descriptionBox.SetText("Advanced settings are now hidden. Press A again to show them.")
+			}
+			return nil
+		}
 		return event
 	})
 
@@ -105,7 +140,7 @@ func (home *settingsHome) createContent() {
 		categoryList.AddItem(subpage.getPage().title, "", 0, nil)
 	}
 	categoryList.SetSelectedFunc(func(i int, s1, s2 string, r rune) {
-		if (home.md.Config.Smartnode.Network.Value == config.Network_Holesky || home.md.Config.Smartnode.Network.Value == config.Network_Devnet) && home.settingsSubpages[i].getPage().id == "settings-mev-boost" {
+		if !home.md.Config.IsMevBoostAvailable() && home.settingsSubpages[i].getPage().id == "settings-mev-boost" {
 			// Disable MEV-Boost for Holesky
 			return
 		} else {
@@ -131,7 +166,7 @@ func (home *settingsHome) createContent() {
 func (home *settingsHome) createFooter() (tview.Primitive, int) {
 
 	// Nav bar
-	navString1 := "Arrow keys: Navigate             Space/Enter: Select"
+	navString1 := "Arrow keys: Navigate   Space/Enter: Select   A: Toggle Advanced Settings"
 	navTextView1 := tview.NewTextView().
 		SetDynamicColors(false).
 		SetRegions(false).
@@ -142,7 +177,7 @@ func (home *settingsHome) createFooter() (tview.Primitive, int) {
 		AddItem(nil, 0, 1, false)
 	fmt.Fprint(navTextView1, navString1)
 
-	navString2 := "Tab: Go to the Buttons   Ctrl+C: Quit without Saving"
+	navString2 := "Tab: Go to the Buttons   /: Search   Ctrl+C: Quit without Saving"
 	navTextView2 := tview.NewTextView().
 		SetDynamicColors(false).
 		SetRegions(false).
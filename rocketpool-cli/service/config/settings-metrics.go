@@ -23,6 +23,7 @@ type MetricsConfigPage struct {
 	grafanaItems               []*parameterizedFormItem
 	prometheusItems            []*parameterizedFormItem
 	exporterItems              []*parameterizedFormItem
+	metricsExportItems         []*parameterizedFormItem
 	enableBitflyNodeMetricsBox *parameterizedFormItem
 	bitflyNodeMetricsItems     []*parameterizedFormItem
 }
@@ -73,6 +74,7 @@ func (configPage *MetricsConfigPage) createContent() {
 	configPage.grafanaItems = createParameterizedFormItems(configPage.masterConfig.Grafana.GetParameters(), configPage.layout.descriptionBox)
 	configPage.prometheusItems = createParameterizedFormItems(configPage.masterConfig.Prometheus.GetParameters(), configPage.layout.descriptionBox)
 	configPage.exporterItems = createParameterizedFormItems(configPage.masterConfig.Exporter.GetParameters(), configPage.layout.descriptionBox)
+	configPage.metricsExportItems = createParameterizedFormItems(configPage.masterConfig.MetricsExport.GetParameters(), configPage.layout.descriptionBox)
 	configPage.enableBitflyNodeMetricsBox = createParameterizedCheckbox(&configPage.masterConfig.EnableBitflyNodeMetrics)
 	configPage.bitflyNodeMetricsItems = createParameterizedFormItems(configPage.masterConfig.BitflyNodeMetrics.GetParameters(), configPage.layout.descriptionBox)
 
@@ -81,6 +83,7 @@ func (configPage *MetricsConfigPage) createContent() {
 	configPage.layout.mapParameterizedFormItems(configPage.grafanaItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.prometheusItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.exporterItems...)
+	configPage.layout.mapParameterizedFormItems(configPage.metricsExportItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.enableBitflyNodeMetricsBox)
 	configPage.layout.mapParameterizedFormItems(configPage.bitflyNodeMetricsItems...)
 
@@ -114,6 +117,7 @@ func (configPage *MetricsConfigPage) handleLayoutChanged() {
 		configPage.layout.addFormItems(configPage.grafanaItems)
 		configPage.layout.addFormItems(configPage.prometheusItems)
 		configPage.layout.addFormItems(configPage.exporterItems)
+		configPage.layout.addFormItems(configPage.metricsExportItems)
 	}
 
 	switch configPage.masterConfig.ConsensusClient.Value.(cfgtypes.ConsensusClient) {
@@ -19,6 +19,8 @@ type settingsNativeHome struct {
 	fallbackPage     *NativeFallbackConfigPage
 	metricsPage      *NativeMetricsConfigPage
 	alertingPage     *AlertingConfigPage
+	experimentalPage *ExperimentalConfigPage
+	searchPage       *settingsSearchPage
 	categoryList     *tview.List
 	settingsSubpages []*page
 	content          tview.Primitive
@@ -42,12 +44,14 @@ func newSettingsNativeHome(md *mainDisplay) *settingsNativeHome {
 	home.fallbackPage = NewNativeFallbackConfigPage(home)
 	home.metricsPage = NewNativeMetricsConfigPage(home)
 	home.alertingPage = NewAlertingConfigPageForNative(home)
+	home.experimentalPage = NewExperimentalConfigPageForNative(home)
 	settingsSubpages := []*page{
 		home.smartnodePage.page,
 		home.nativePage.page,
 		home.fallbackPage.page,
 		home.metricsPage.page,
 		home.alertingPage.page,
+		home.experimentalPage.page,
 	}
 	home.settingsSubpages = settingsSubpages
 
@@ -55,6 +59,16 @@ func newSettingsNativeHome(md *mainDisplay) *settingsNativeHome {
 	for _, subpage := range settingsSubpages {
 		md.pages.AddPage(subpage.id, subpage.content, true, false)
 	}
+
+	// Create the search page
+	home.searchPage = newSettingsSearchPage(md, homePage, "settings-search-native", []searchablePage{
+		{home.smartnodePage.page, home.smartnodePage.layout},
+		{home.nativePage.page, home.nativePage.layout},
+		{home.fallbackPage.page, home.fallbackPage.layout},
+		{home.metricsPage.page, home.metricsPage.layout},
+		{home.alertingPage.page, home.alertingPage.layout},
+		{home.experimentalPage.page, home.experimentalPage.layout},
+	})
 	home.createContent()
 	homePage.content = home.content
 	md.pages.AddPage(homePage.id, home.content, true, false)
@@ -76,12 +90,26 @@ func (home *settingsNativeHome) createContent() {
 	categoryList.SetBorderPadding(0, 0, 1, 1)
 	home.categoryList = categoryList
 
-	// Set tab to switch to the save and quit buttons
+	// Set tab to switch to the save and quit buttons, / to search, and A to toggle the advanced settings
 	categoryList.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		if event.Key() == tcell.KeyTab || event.Key() == tcell.KeyBacktab {
 			home.md.app.SetFocus(home.saveButton)
 			return nil
 		}
+		if event.Rune() == '/' {
+			home.searchPage.show()
+			return nil
+		}
+		if event.Rune() == 'a' || event.Rune() == 'A' {
+			showAdvancedSettings = !showAdvancedSettings
+			home.smartnodePage.handleLayoutChanged()
+			if showAdvancedSettings {
+				layout.descriptionBox.SetText("Advanced settings are now shown. They're meant for fine-tuning and troubleshooting, and the defaults are right for most node operators. Press A again to hide them.")
+			} else {
+				layout.descriptionBox.SetText("Advanced settings are now hidden. Press A again to show them.")
+			}
+			return nil
+		}
 		return event
 	})
 
@@ -110,7 +138,7 @@ func (home *settingsNativeHome) createContent() {
 func (home *settingsNativeHome) createFooter() (tview.Primitive, int) {
 
 	// Nav bar
-	navString1 := "Arrow keys: Navigate             Space/Enter: Select"
+	navString1 := "Arrow keys: Navigate   Space/Enter: Select   A: Toggle Advanced Settings"
 	navTextView1 := tview.NewTextView().
 		SetDynamicColors(false).
 		SetRegions(false).
@@ -121,7 +149,7 @@ func (home *settingsNativeHome) createFooter() (tview.Primitive, int) {
 		AddItem(nil, 0, 1, false)
 	fmt.Fprint(navTextView1, navString1)
 
-	navString2 := "Tab: Go to the Buttons   Ctrl+C: Quit without Saving"
+	navString2 := "Tab: Go to the Buttons   /: Search   Ctrl+C: Quit without Saving"
 	navTextView2 := tview.NewTextView().
 		SetDynamicColors(false).
 		SetRegions(false).
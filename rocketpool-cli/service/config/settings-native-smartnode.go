@@ -7,9 +7,10 @@ import (
 
 // The page wrapper for the Smartnode config
 type NativeSmartnodeConfigPage struct {
-	home   *settingsNativeHome
-	page   *page
-	layout *standardLayout
+	home      *settingsNativeHome
+	page      *page
+	layout    *standardLayout
+	formItems []*parameterizedFormItem
 }
 
 // Creates a new page for the Native Smartnode settings
@@ -43,14 +44,14 @@ func (configPage *NativeSmartnodeConfigPage) createContent() {
 	layout.setupEscapeReturnHomeHandler(configPage.home.md, configPage.home.homePage)
 
 	// Set up the form items
-	formItems := createParameterizedFormItems(masterConfig.Smartnode.GetParameters(), layout.descriptionBox)
+	formItems := createParameterizedFormItems(append(masterConfig.Smartnode.GetParameters(), masterConfig.Backup.GetParameters()...), layout.descriptionBox)
 	for _, formItem := range formItems {
 		if formItem.parameter.ID == config.ProjectNameID {
 			// Ignore the project name ID since it doesn't apply to native mode
 			continue
 		}
 
-		layout.form.AddFormItem(formItem.item)
+		configPage.formItems = append(configPage.formItems, formItem)
 		layout.parameters[formItem.item] = formItem
 		if formItem.parameter.ID == config.NetworkID {
 			dropDown := formItem.item.(*DropDown)
@@ -60,7 +61,29 @@ func (configPage *NativeSmartnodeConfigPage) createContent() {
 				configPage.home.refresh()
 			})
 		}
+		if formItem.parameter.ID == config.HardwareProfileID {
+			dropDown := formItem.item.(*DropDown)
+			dropDown.SetSelectedFunc(func(text string, index int) {
+				profile := &configPage.home.md.Config.Smartnode.HardwareProfile
+				if profile.Value == profile.Options[index].Value {
+					return
+				}
+				profile.Value = profile.Options[index].Value
+				configPage.home.md.Config.ApplyHardwareProfile()
+				configPage.home.refresh()
+				configPage.layout.refresh()
+			})
+		}
 	}
-	layout.refresh()
+	layout.rebuildOnDependencyChange(configPage.formItems, configPage.handleLayoutChanged)
+	configPage.handleLayoutChanged()
+
+}
 
+// Handle a bulk redraw request
+func (configPage *NativeSmartnodeConfigPage) handleLayoutChanged() {
+	// Rebuild the form so it only has the settings that apply
+	configPage.layout.form.Clear(true)
+	configPage.layout.addFormItems(configPage.formItems)
+	configPage.layout.refresh()
 }
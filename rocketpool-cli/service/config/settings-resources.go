@@ -0,0 +1,59 @@
+package config
+
+import (
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The page wrapper for the container resource limits config
+type ResourcesConfigPage struct {
+	mainDisplay  *mainDisplay
+	homePage     *page
+	page         *page
+	layout       *standardLayout
+	masterConfig *config.RocketPoolConfig
+	formItems    []*parameterizedFormItem
+}
+
+// Creates a new page for the container resource limits
+func NewResourcesConfigPage(home *settingsHome) *ResourcesConfigPage {
+	configPage := &ResourcesConfigPage{
+		mainDisplay:  home.md,
+		homePage:     home.homePage,
+		masterConfig: home.md.Config,
+	}
+
+	configPage.createContent()
+	configPage.page = newPage(
+		configPage.homePage,
+		"settings-resources",
+		"Resource Limits",
+		"Select this to limit how much RAM and how many CPU cores each of the Smartnode's containers can use, so one busy client can't starve the others.",
+		configPage.layout.grid,
+	)
+
+	return configPage
+}
+
+// Get the underlying page
+func (configPage *ResourcesConfigPage) getPage() *page {
+	return configPage.page
+}
+
+// Creates the content for the resource limits page
+func (configPage *ResourcesConfigPage) createContent() {
+	configPage.layout = newStandardLayout()
+	configPage.layout.createForm(&configPage.masterConfig.Smartnode.Network, "Resource Limit Settings")
+	configPage.layout.setupEscapeReturnHomeHandler(configPage.mainDisplay, configPage.homePage)
+
+	configPage.formItems = createParameterizedFormItems(configPage.masterConfig.Resources.GetParameters(), configPage.layout.descriptionBox)
+	configPage.layout.mapParameterizedFormItems(configPage.formItems...)
+	configPage.handleLayoutChanged()
+}
+
+// Handle a bulk redraw request
+func (configPage *ResourcesConfigPage) handleLayoutChanged() {
+	// Rebuild the form so it only has the containers that will run
+	configPage.layout.form.Clear(true)
+	configPage.layout.addFormItems(configPage.formItems)
+	configPage.layout.refresh()
+}
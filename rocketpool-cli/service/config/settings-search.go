@@ -0,0 +1,235 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The most results to show on the search page
+const maxSearchResults int = 50
+
+// A settings page and the layout its parameters are shown in
+type searchablePage struct {
+	page   *page
+	layout *standardLayout
+}
+
+// Check if a parameter belongs to this page, whether or not it's currently shown
+func (target searchablePage) hasParameter(param *cfgtypes.Parameter) bool {
+	for _, item := range target.layout.parameters {
+		if item.parameter == param {
+			return true
+		}
+	}
+	return false
+}
+
+// The page for searching all of the settings and jumping straight to one
+type settingsSearchPage struct {
+	md       *mainDisplay
+	homePage *page
+	page     *page
+	layout   *standardLayout
+	input    *tview.InputField
+	list     *tview.List
+	targets  []searchablePage
+	results  []config.ParameterSearchResult
+}
+
+// Creates a new search page for the settings pages of a home page
+func newSettingsSearchPage(md *mainDisplay, homePage *page, id string, targets []searchablePage) *settingsSearchPage {
+	searchPage := &settingsSearchPage{
+		md:       md,
+		homePage: homePage,
+		targets:  targets,
+	}
+	searchPage.createContent()
+	searchPage.page = newPage(homePage, id, "Search", "", searchPage.layout.grid)
+	md.pages.AddPage(searchPage.page.id, searchPage.page.content, true, false)
+	return searchPage
+}
+
+// Creates the content for the search page
+func (searchPage *settingsSearchPage) createContent() {
+	layout := newStandardLayout()
+	searchPage.layout = layout
+
+	// Create the search box
+	input := tview.NewInputField().
+		SetLabel("Search: ").
+		SetFieldBackgroundColor(tcell.ColorBlack)
+	input.SetBackgroundColor(tview.Styles.ContrastBackgroundColor)
+	input.SetChangedFunc(func(text string) {
+		searchPage.updateResults(text)
+	})
+	searchPage.input = input
+
+	// Create the results list
+	list := tview.NewList().
+		ShowSecondaryText(true).
+		SetChangedFunc(func(index int, mainText string, secondaryText string, shortcut rune) {
+			if index < len(searchPage.results) {
+				searchPage.describeResult(searchPage.results[index])
+			}
+		}).
+		SetSelectedFunc(func(index int, mainText string, secondaryText string, shortcut rune) {
+			if index < len(searchPage.results) {
+				searchPage.goToParameter(searchPage.results[index].Parameter)
+			}
+		})
+	list.SetBackgroundColor(tview.Styles.ContrastBackgroundColor)
+	searchPage.list = list
+
+	// Move between the box and the list, and go back home with Escape
+	input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			searchPage.md.setPage(searchPage.homePage)
+			return nil
+		case tcell.KeyDown, tcell.KeyTab, tcell.KeyEnter:
+			if list.GetItemCount() > 0 {
+				searchPage.md.app.SetFocus(list)
+			}
+			return nil
+		}
+		return event
+	})
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			searchPage.md.setPage(searchPage.homePage)
+			return nil
+		case tcell.KeyTab, tcell.KeyBacktab:
+			searchPage.md.app.SetFocus(input)
+			return nil
+		case tcell.KeyUp:
+			if list.GetCurrentItem() == 0 {
+				searchPage.md.app.SetFocus(input)
+				return nil
+			}
+		}
+		return event
+	})
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(input, 1, 0, true).
+		AddItem(tview.NewBox().SetBackgroundColor(tview.Styles.ContrastBackgroundColor), 1, 0, false).
+		AddItem(list, 0, 1, false)
+	content.SetBackgroundColor(tview.Styles.ContrastBackgroundColor)
+	layout.setContent(content, content.Box, "Search Settings")
+	layout.descriptionBox.SetText("Type part of a setting's name, ID, or description to find it.")
+
+	// Make the footer
+	navString1 := "Type: Search   Down/Tab: Go to the Results   Enter: Go to the Setting"
+	navTextView1 := tview.NewTextView().
+		SetDynamicColors(false).
+		SetRegions(false).
+		SetWrap(false)
+	fmt.Fprint(navTextView1, navString1)
+
+	navString2 := "Esc: Go Back to Categories"
+	navTextView2 := tview.NewTextView().
+		SetDynamicColors(false).
+		SetRegions(false).
+		SetWrap(false)
+	fmt.Fprint(navTextView2, navString2)
+
+	navBar := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(tview.NewFlex().
+			AddItem(tview.NewBox(), 0, 1, false).
+			AddItem(navTextView1, len(navString1), 1, false).
+			AddItem(tview.NewBox(), 0, 1, false),
+			1, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(tview.NewBox(), 0, 1, false).
+			AddItem(navTextView2, len(navString2), 1, false).
+			AddItem(tview.NewBox(), 0, 1, false),
+			1, 1, false)
+	layout.setFooter(navBar, 2)
+}
+
+// Show the search page with an empty search
+func (searchPage *settingsSearchPage) show() {
+	searchPage.input.SetText("")
+	searchPage.updateResults("")
+	searchPage.md.setPage(searchPage.page)
+	searchPage.md.app.SetFocus(searchPage.input)
+}
+
+// Update the results list for a new query
+func (searchPage *settingsSearchPage) updateResults(query string) {
+	searchPage.list.Clear()
+	searchPage.results = []config.ParameterSearchResult{}
+	for _, result := range searchPage.md.Config.SearchParameters(query) {
+		target := searchPage.getTarget(result.Parameter)
+		if target == nil {
+			// Settings that aren't on any page here, such as the other mode's settings, can't be jumped to
+			continue
+		}
+		searchPage.results = append(searchPage.results, result)
+		searchPage.list.AddItem(result.Parameter.Name, fmt.Sprintf("  %s > %s", target.page.title, result.FlagName), 0, nil)
+		if len(searchPage.results) == maxSearchResults {
+			break
+		}
+	}
+	if len(searchPage.results) > 0 {
+		searchPage.describeResult(searchPage.results[0])
+	} else if query != "" {
+		searchPage.layout.descriptionBox.SetText(fmt.Sprintf("No settings match [%s].", tview.Escape(query)))
+	} else {
+		searchPage.layout.descriptionBox.SetText("Type part of a setting's name, ID, or description to find it.")
+	}
+}
+
+// Show a result's details in the description box
+func (searchPage *settingsSearchPage) describeResult(result config.ParameterSearchResult) {
+	param := result.Parameter
+	defaultValue, _ := param.GetDefault(searchPage.md.Config.Smartnode.Network.Value.(cfgtypes.Network))
+	searchPage.layout.descriptionBox.SetText(fmt.Sprintf("Current: %v\nDefault: %v\nCommand-line name: %s\n\n%s", param.Value, defaultValue, result.FlagName, param.Description))
+	searchPage.layout.descriptionBox.ScrollToBeginning()
+}
+
+// Get the page a parameter is on
+func (searchPage *settingsSearchPage) getTarget(param *cfgtypes.Parameter) *searchablePage {
+	for i, target := range searchPage.targets {
+		if target.hasParameter(param) {
+			return &searchPage.targets[i]
+		}
+	}
+	return nil
+}
+
+// Go to the page a parameter is on and select it
+func (searchPage *settingsSearchPage) goToParameter(param *cfgtypes.Parameter) {
+	target := searchPage.getTarget(param)
+	if target == nil {
+		return
+	}
+	form := target.layout.form
+	for i := 0; i < form.GetFormItemCount(); i++ {
+		if target.layout.parameters[form.GetFormItem(i)].parameter == param {
+			searchPage.md.setPage(target.page)
+			form.SetFocus(i)
+			searchPage.md.app.SetFocus(form)
+			return
+		}
+	}
+
+	// Explain why it isn't shown
+	if param.Advanced && !showAdvancedSettings {
+		searchPage.layout.descriptionBox.SetText(fmt.Sprintf("%s is on the %s page, but it's an advanced setting so it's hidden. Press A on the categories page to show the advanced settings.", param.Name, target.page.title))
+		return
+	}
+	conditions := []string{}
+	for _, dependency := range param.GetUnmetDependencies() {
+		conditions = append(conditions, fmt.Sprintf("%s %s", dependency.Parameter.Name, dependency.DescribeValues()))
+	}
+	searchPage.layout.descriptionBox.SetText(fmt.Sprintf("%s is on the %s page, but it isn't shown with your current settings because it only applies when %s.", param.Name, target.page.title, strings.Join(conditions, " and ")))
+}
@@ -8,9 +8,10 @@ import (
 
 // The page wrapper for the Smartnode config
 type SmartnodeConfigPage struct {
-	home   *settingsHome
-	page   *page
-	layout *standardLayout
+	home      *settingsHome
+	page      *page
+	layout    *standardLayout
+	formItems []*parameterizedFormItem
 }
 
 // Creates a new page for the Smartnode settings
@@ -67,10 +68,11 @@ func (configPage *SmartnodeConfigPage) createContent() {
 	})
 
 	// Set up the form items
-	formItems := createParameterizedFormItems(masterConfig.Smartnode.GetParameters(), layout.descriptionBox)
+	formItems := createParameterizedFormItems(append(append(masterConfig.Smartnode.GetParameters(), masterConfig.Backup.GetParameters()...), masterConfig.Bandwidth.GetParameters()...), layout.descriptionBox)
+	configPage.formItems = formItems
+	layout.mapParameterizedFormItems(formItems...)
+	layout.rebuildOnDependencyChange(formItems, configPage.handleLayoutChanged)
 	for _, formItem := range formItems {
-		layout.form.AddFormItem(formItem.item)
-		layout.parameters[formItem.item] = formItem
 		if formItem.parameter.ID == config.NetworkID {
 			dropDown := formItem.item.(*DropDown)
 			dropDown.SetSelectedFunc(func(text string, index int) {
@@ -79,12 +81,28 @@ func (configPage *SmartnodeConfigPage) createContent() {
 				configPage.home.refresh()
 			})
 		}
+		if formItem.parameter.ID == config.HardwareProfileID {
+			dropDown := formItem.item.(*DropDown)
+			dropDown.SetSelectedFunc(func(text string, index int) {
+				profile := &configPage.home.md.Config.Smartnode.HardwareProfile
+				if profile.Value == profile.Options[index].Value {
+					return
+				}
+				profile.Value = profile.Options[index].Value
+				configPage.home.md.Config.ApplyHardwareProfile()
+				configPage.home.refresh()
+				configPage.layout.refresh()
+			})
+		}
 	}
-	layout.refresh()
+	configPage.handleLayoutChanged()
 
 }
 
 // Handle a bulk redraw request
 func (configPage *SmartnodeConfigPage) handleLayoutChanged() {
+	// Rebuild the form so it only has the settings that apply
+	configPage.layout.form.Clear(true)
+	configPage.layout.addFormItems(configPage.formItems)
 	configPage.layout.refresh()
 }
@@ -8,6 +8,9 @@ import (
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 )
 
+// Whether the settings pages include the advanced parameters
+var showAdvancedSettings bool = false
+
 // A layout container with the standard elements and design
 type standardLayout struct {
 	grid           *tview.Grid
@@ -170,7 +173,9 @@ func (layout *standardLayout) createSettingFooter() {
 // Add a collection of form items to this layout's form
 func (layout *standardLayout) addFormItems(params []*parameterizedFormItem) {
 	for _, param := range params {
-		layout.form.AddFormItem(param.item)
+		if param.parameter.IsVisible(showAdvancedSettings) {
+			layout.form.AddFormItem(param.item)
+		}
 	}
 }
 
@@ -188,16 +193,46 @@ func (layout *standardLayout) addFormItemsWithCommonParams(commonParams []*param
 			}
 		}
 
-		if isSupported {
+		if isSupported && commonParam.parameter.IsVisible(showAdvancedSettings) {
 			layout.form.AddFormItem(commonParam.item)
 		}
 	}
 
 	// Add all of the specific params
-	for _, specificParam := range specificParams {
-		layout.form.AddFormItem(specificParam.item)
+	layout.addFormItems(specificParams)
+
+}
+
+// Rebuild the form with the given function whenever one of the items changes a setting that another one depends on
+func (layout *standardLayout) rebuildOnDependencyChange(params []*parameterizedFormItem, rebuild func()) {
+	dependencies := map[*cfgtypes.Parameter]bool{}
+	for _, param := range params {
+		for _, dependency := range param.parameter.Dependencies {
+			dependencies[dependency.Parameter] = true
+		}
 	}
 
+	for _, param := range params {
+		parameter := param.parameter
+		if !dependencies[parameter] {
+			continue
+		}
+		switch item := param.item.(type) {
+		case *tview.Checkbox:
+			item.SetChangedFunc(func(checked bool) {
+				parameter.Value = checked
+				rebuild()
+			})
+		case *DropDown:
+			item.SetSelectedFunc(func(text string, index int) {
+				if parameter.Value == parameter.Options[index].Value {
+					return
+				}
+				parameter.Value = parameter.Options[index].Value
+				rebuild()
+			})
+		}
+	}
 }
 
 func (layout *standardLayout) mapParameterizedFormItems(params ...*parameterizedFormItem) {
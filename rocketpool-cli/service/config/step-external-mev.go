@@ -29,7 +29,7 @@ func createExternalMevStep(wiz *wizard, currentStep int, totalSteps int) *textBo
 		wiz.md.Config.EnableMevBoost.Value = true
 		wiz.md.Config.MevBoost.Mode.Value = cfgtypes.Mode_External
 		wiz.md.Config.MevBoost.ExternalUrl.Value = text[urlLabel]
-		wiz.finishedModal.show()
+		wiz.portCheckModal.show()
 	}
 
 	back := func() {
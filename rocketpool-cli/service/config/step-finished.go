@@ -5,7 +5,6 @@ import (
 	"strings"
 
 	"github.com/rivo/tview"
-	"github.com/rocket-pool/smartnode/shared/types/config"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 )
 
@@ -34,12 +33,7 @@ func createFinishedStep(wiz *wizard, currentStep int, totalSteps int) *choiceWiz
 	}
 
 	back := func() {
-		if wiz.md.Config.Smartnode.Network.Value == config.Network_Holesky || wiz.md.Config.Smartnode.Network.Value == config.Network_Devnet {
-			// Skip MEV for Holesky
-			wiz.metricsModal.show()
-		} else {
-			wiz.mevModeModal.show()
-		}
+		wiz.portCheckModal.show()
 	}
 
 	return newChoiceStep(
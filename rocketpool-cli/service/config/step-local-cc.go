@@ -3,12 +3,12 @@ package config
 import (
 	"fmt"
 	"math/rand"
-	"runtime"
 	"strings"
 	"time"
 
 	"github.com/pbnjay/memory"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/sys"
 )
 
 const localCcStepID string = "step-local-cc"
@@ -71,7 +71,7 @@ func createLocalCcStep(wiz *wizard, currentStep int, totalSteps int) *choiceWiza
 			//	wiz.consensusLocalPrysmWarning.show()
 			case cfgtypes.ConsensusClient_Teku:
 				totalMemoryGB := memory.TotalMemory() / 1024 / 1024 / 1024
-				if runtime.GOARCH == "arm64" || totalMemoryGB < 15 {
+				if sys.GetHostArchitecture() == "arm64" || totalMemoryGB < 15 {
 					wiz.consensusLocalTekuWarning.show()
 				} else {
 					wiz.graffitiModal.show()
@@ -109,7 +109,7 @@ func selectRandomCC(goodOptions []cfgtypes.ParameterOption, includeSupermajority
 
 	// Get system specs
 	totalMemoryGB := memory.TotalMemory() / 1024 / 1024 / 1024
-	isLowPower := (totalMemoryGB < 15 || runtime.GOARCH == "arm64")
+	isLowPower := (totalMemoryGB < 15 || sys.GetHostArchitecture() == "arm64")
 
 	// Filter out the clients based on system specs
 	filteredClients := []cfgtypes.ConsensusClient{}
@@ -161,7 +161,7 @@ func getAugmentedCcDescription(client cfgtypes.ConsensusClient, originalDescript
 	*/
 	case cfgtypes.ConsensusClient_Teku:
 		totalMemoryGB := memory.TotalMemory() / 1024 / 1024 / 1024
-		if runtime.GOARCH == "arm64" || totalMemoryGB < 15 {
+		if sys.GetHostArchitecture() == "arm64" || totalMemoryGB < 15 {
 			return fmt.Sprintf("%s\n\n[orange]WARNING: Teku is a resource-heavy client and will likely not perform well on your system given your CPU power or amount of available RAM. We recommend you pick a lighter client instead.", originalDescription)
 		}
 	}
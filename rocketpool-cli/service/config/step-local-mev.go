@@ -41,7 +41,7 @@ func createLocalMevStep(wiz *wizard, currentStep int, totalSteps int) *checkBoxW
 		}
 
 		wiz.md.Config.EnableMevBoost.Value = atLeastOneEnabled
-		wiz.finishedModal.show()
+		wiz.portCheckModal.show()
 	}
 
 	back := func() {
@@ -43,7 +43,7 @@ func createMevModeStep(wiz *wizard, currentStep int, totalSteps int) *choiceWiza
 			case cfgtypes.Mode_External:
 				wiz.md.Config.EnableMevBoost.Value = true
 				wiz.md.Config.MevBoost.Mode.Value = cfgtypes.Mode_External
-				wiz.finishedModal.show()
+				wiz.portCheckModal.show()
 			default:
 				panic(fmt.Sprintf("Unknown EC mode %s during MEV mode selection", wiz.md.Config.ExecutionClientMode.Value))
 			}
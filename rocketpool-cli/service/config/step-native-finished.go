@@ -5,7 +5,6 @@ import (
 	"strings"
 
 	"github.com/rivo/tview"
-	"github.com/rocket-pool/smartnode/shared/types/config"
 )
 
 func createNativeFinishedStep(wiz *wizard, currentStep int, totalSteps int) *choiceWizardStep {
@@ -33,8 +32,8 @@ func createNativeFinishedStep(wiz *wizard, currentStep int, totalSteps int) *cho
 	}
 
 	back := func() {
-		if wiz.md.Config.Smartnode.Network.Value == config.Network_Holesky || wiz.md.Config.Smartnode.Network.Value == config.Network_Devnet {
-			// Skip MEV for Holesky
+		if !wiz.md.Config.IsMevBoostAvailable() {
+			// Skip MEV on networks that don't have it
 			wiz.nativeMetricsModal.show()
 		} else {
 			wiz.nativeMevModal.show()
@@ -0,0 +1,165 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/services/portcheck"
+)
+
+const portCheckStepID string = "step-port-check"
+
+// The wizard step that checks the Smartnode's ports for conflicts and reachability before it starts.
+// Its text depends on the results, so the underlying choice step is rebuilt every time it's shown.
+type portCheckWizardStep struct {
+	wiz         *wizard
+	currentStep int
+	totalSteps  int
+	checks      []portcheck.PortCheck
+
+	// Incremented every time the checks run, so results from a stale reachability check are ignored
+	generation int
+}
+
+func createPortCheckStep(wiz *wizard, currentStep int, totalSteps int) *portCheckWizardStep {
+	return &portCheckWizardStep{
+		wiz:         wiz,
+		currentStep: currentStep,
+		totalSteps:  totalSteps,
+	}
+}
+
+// Run the checks and show the results
+func (step *portCheckWizardStep) show() {
+	cfg := step.wiz.md.Config
+	step.generation++
+	step.checks = portcheck.CheckLocalPorts(cfg, step.wiz.md.PublishedPorts)
+
+	// Check reachability in the background since it has to wait on the port checker
+	checkingReachability := false
+	if strings.TrimSpace(cfg.Smartnode.PortCheckerUrl.Value.(string)) != "" {
+		for _, check := range step.checks {
+			if check.P2P && check.Conflict == "" {
+				checkingReachability = true
+				break
+			}
+		}
+	}
+	step.showResults(checkingReachability)
+	if !checkingReachability {
+		return
+	}
+
+	generation := step.generation
+	checks := append([]portcheck.PortCheck{}, step.checks...)
+	go func() {
+		portcheck.CheckReachability(cfg, checks)
+		step.wiz.md.app.QueueUpdateDraw(func() {
+			frontPage, _ := step.wiz.md.pages.GetFrontPage()
+			if generation != step.generation || frontPage != portCheckStepID {
+				return
+			}
+			step.checks = checks
+			step.showResults(false)
+		})
+	}()
+}
+
+// Show the results of the checks
+func (step *portCheckWizardStep) showResults(checkingReachability bool) {
+	builder := strings.Builder{}
+	suggestions := 0
+	conflicts := 0
+	unreachable := []string{}
+	uncheckable := []string{}
+	for _, check := range step.checks {
+		if check.Conflict != "" {
+			conflicts++
+			if check.SuggestedPort != 0 {
+				suggestions++
+			}
+		} else if check.ReachabilityChecked {
+			if check.ReachabilityError != nil {
+				uncheckable = append(uncheckable, check.Name())
+			} else if !check.Reachable {
+				unreachable = append(unreachable, fmt.Sprintf("%s: %d", check.Name(), check.Port))
+			}
+		}
+	}
+
+	// Local conflicts
+	switch {
+	case len(step.checks) == 0:
+		builder.WriteString("None of the Smartnode's containers publish ports on this machine with your settings, so there's nothing to check.")
+	case conflicts == 0:
+		builder.WriteString("[green]None of the Smartnode's ports conflict with other programs on this machine.[white]")
+	default:
+		builder.WriteString("[orange]Some of the Smartnode's ports can't be used:[white]\n")
+		for _, check := range step.checks {
+			if check.Conflict == "" {
+				continue
+			}
+			builder.WriteString(fmt.Sprintf("\n%s: port %s.", check.Name(), check.Conflict))
+			if check.SuggestedPort != 0 {
+				builder.WriteString(fmt.Sprintf(" Port %d is free instead.", check.SuggestedPort))
+			}
+		}
+	}
+
+	// Reachability
+	if checkingReachability {
+		builder.WriteString("\n\nChecking if your P2P ports can be reached from the internet...")
+	} else if len(unreachable) > 0 {
+		builder.WriteString("\n\n[orange]These P2P ports can't be reached from the internet:[white]\n\n")
+		builder.WriteString(strings.Join(unreachable, "\n"))
+		builder.WriteString("\n\nYour clients will still work, but they'll have fewer peers. Forward these ports to this machine in your router and allow them through your firewall (both TCP and UDP) to fix it.")
+	}
+	if !checkingReachability && len(uncheckable) > 0 {
+		builder.WriteString(fmt.Sprintf("\n\nThe reachability of %s couldn't be checked. You can try again later with `rocketpool service check-ports`.", strings.Join(uncheckable, ", ")))
+	}
+
+	buttons := []string{"Next"}
+	if suggestions > 0 {
+		buttons = []string{"Use the Free Ports", "Keep My Ports"}
+	}
+
+	show := func(modal *choiceModalLayout) {
+		step.wiz.md.setPage(modal.page)
+		modal.focus(0)
+	}
+
+	done := func(buttonIndex int, buttonLabel string) {
+		step.generation++
+		if suggestions > 0 && buttonIndex == 0 {
+			portcheck.ApplySuggestions(step.checks)
+		}
+		step.wiz.finishedModal.show()
+	}
+
+	back := func() {
+		step.generation++
+		if !step.wiz.md.Config.IsMevBoostAvailable() {
+			// Skip MEV on networks that don't have it
+			step.wiz.metricsModal.show()
+		} else {
+			step.wiz.mevModeModal.show()
+		}
+	}
+
+	modal := newChoiceStep(
+		step.wiz,
+		step.currentStep,
+		step.totalSteps,
+		builder.String(),
+		buttons,
+		[]string{},
+		76,
+		"Ports",
+		DirectionalModalHorizontal,
+		show,
+		done,
+		back,
+		portCheckStepID,
+	)
+	modal.show()
+}
@@ -24,6 +24,7 @@ type wizard struct {
 	mevModeModal                    *choiceWizardStep
 	localMevModal                   *checkBoxWizardStep
 	externalMevModal                *textBoxWizardStep
+	portCheckModal                  *portCheckWizardStep
 	finishedModal                   *choiceWizardStep
 	consensusLocalRandomModal       *choiceWizardStep
 	consensusLocalRandomPrysmModal  *choiceWizardStep
@@ -55,7 +56,7 @@ func newWizard(md *mainDisplay) *wizard {
 		md: md,
 	}
 
-	totalDockerSteps := 9
+	totalDockerSteps := 10
 	totalNativeSteps := 10
 
 	// Docker mode
@@ -85,7 +86,8 @@ func newWizard(md *mainDisplay) *wizard {
 	wiz.mevModeModal = createMevModeStep(wiz, 8, totalDockerSteps)
 	wiz.localMevModal = createLocalMevStep(wiz, 8, totalDockerSteps)
 	wiz.externalMevModal = createExternalMevStep(wiz, 8, totalDockerSteps)
-	wiz.finishedModal = createFinishedStep(wiz, 9, totalDockerSteps)
+	wiz.portCheckModal = createPortCheckStep(wiz, 9, totalDockerSteps)
+	wiz.finishedModal = createFinishedStep(wiz, 10, totalDockerSteps)
 
 	// Native mode
 	wiz.nativeWelcomeModal = createNativeWelcomeStep(wiz, 1, totalNativeSteps)
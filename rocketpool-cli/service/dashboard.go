@@ -0,0 +1,330 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The most proposals and alerts to list on the dashboard
+const maxDashboardItems int = 8
+
+// The live terminal dashboard shown by `rocketpool service status --tui`
+type dashboard struct {
+	app      *tview.Application
+	rp       *rocketpool.Client
+	cfg      *config.RocketPoolConfig
+	interval time.Duration
+
+	header       *tview.TextView
+	clients      *tview.TextView
+	duties       *tview.TextView
+	records      *tview.TextView
+	transactions *tview.TextView
+	alerts       *tview.TextView
+	hardware     *tview.TextView
+
+	lock        sync.Mutex
+	status      *api.DashboardResponse
+	statusError error
+	lastUpdate  time.Time
+}
+
+// Run the live dashboard until the user exits it
+func runDashboard(c *cli.Context, rp *rocketpool.Client, cfg *config.RocketPoolConfig) error {
+	interval := time.Duration(c.Uint64("refresh-interval")) * time.Second
+	if interval < time.Second {
+		return fmt.Errorf("the refresh interval must be at least 1 second")
+	}
+
+	d := &dashboard{
+		app:          tview.NewApplication(),
+		rp:           rp,
+		cfg:          cfg,
+		interval:     interval,
+		header:       tview.NewTextView().SetDynamicColors(true),
+		clients:      newDashboardPanel("Clients"),
+		duties:       newDashboardPanel("Validator Duties"),
+		records:      newDashboardPanel("Rolling Records"),
+		transactions: newDashboardPanel("Transactions"),
+		alerts:       newDashboardPanel("Alerts"),
+		hardware:     newDashboardPanel("Hardware"),
+	}
+
+	grid := tview.NewGrid().
+		SetRows(1, 0, 0, 0).
+		SetColumns(0, 0).
+		AddItem(d.header, 0, 0, 1, 2, 0, 0, false).
+		AddItem(d.clients, 1, 0, 1, 1, 0, 0, false).
+		AddItem(d.duties, 1, 1, 1, 1, 0, 0, false).
+		AddItem(d.records, 2, 0, 1, 1, 0, 0, false).
+		AddItem(d.transactions, 2, 1, 1, 1, 0, 0, false).
+		AddItem(d.alerts, 3, 0, 1, 1, 0, 0, false).
+		AddItem(d.hardware, 3, 1, 1, 1, 0, 0, false)
+
+	d.app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || event.Key() == tcell.KeyCtrlC || event.Rune() == 'q' {
+			d.app.Stop()
+			return nil
+		}
+		return event
+	})
+
+	// Refresh the status in the background, and redraw every second so the countdowns keep moving
+	stop := make(chan struct{})
+	defer close(stop)
+	go d.refreshLoop(stop)
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				d.app.QueueUpdateDraw(d.render)
+			}
+		}
+	}()
+
+	d.render()
+	return d.app.SetRoot(grid, true).Run()
+}
+
+// Create a bordered panel for one section of the dashboard
+func newDashboardPanel(title string) *tview.TextView {
+	panel := tview.NewTextView().SetDynamicColors(true).SetWrap(true)
+	panel.SetBorder(true).SetTitle(" "+title+" ").SetBorderPadding(0, 0, 1, 1)
+	return panel
+}
+
+// Get the latest status from the daemon on every interval
+func (d *dashboard) refreshLoop(stop chan struct{}) {
+	for {
+		status, err := d.rp.GetDashboard()
+		d.lock.Lock()
+		if err == nil {
+			d.status = &status
+			d.lastUpdate = time.Now()
+		}
+		d.statusError = err
+		d.lock.Unlock()
+		d.app.QueueUpdateDraw(d.render)
+
+		select {
+		case <-stop:
+			return
+		case <-time.After(d.interval):
+		}
+	}
+}
+
+// Redraw every panel from the latest status
+func (d *dashboard) render() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	header := fmt.Sprintf("[::b]Rocket Pool Dashboard[::-] - %s", d.cfg.Smartnode.Network.Value)
+	if !d.lastUpdate.IsZero() {
+		header += fmt.Sprintf(" - updated %s ago", time.Since(d.lastUpdate).Round(time.Second))
+	}
+	if d.statusError != nil {
+		header += fmt.Sprintf(" - [red]%s[-]", tview.Escape(d.statusError.Error()))
+	}
+	d.header.SetText(header + " - press q to exit")
+
+	if d.status == nil {
+		d.clients.SetText("Loading...")
+		return
+	}
+	status := d.status
+	d.clients.SetText(renderClients(status))
+	d.duties.SetText(renderDuties(status))
+	d.records.SetText(renderRollingRecords(status))
+	d.transactions.SetText(renderTransactions(status))
+	d.alerts.SetText(renderAlerts(status))
+	d.hardware.SetText(renderHardware(status))
+}
+
+// Render the sync status of the primary and fallback clients
+func renderClients(status *api.DashboardResponse) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "Slot %d, epoch %d (next epoch in %s)\n\n", status.CurrentSlot, status.CurrentEpoch, formatCountdown(status.NextEpochTime))
+	fmt.Fprintf(&text, "Primary execution client:  %s\n", formatClientStatus(status.EcManagerStatus.PrimaryClientStatus))
+	if status.EcManagerStatus.FallbackEnabled {
+		fmt.Fprintf(&text, "Fallback execution client: %s\n", formatClientStatus(status.EcManagerStatus.FallbackClientStatus))
+	}
+	fmt.Fprintf(&text, "Primary consensus client:  %s\n", formatClientStatus(status.BcManagerStatus.PrimaryClientStatus))
+	if status.BcManagerStatus.FallbackEnabled {
+		fmt.Fprintf(&text, "Fallback consensus client: %s\n", formatClientStatus(status.BcManagerStatus.FallbackClientStatus))
+	}
+	return text.String()
+}
+
+// Render the upcoming proposals and sync committee duties of the node's validators
+func renderDuties(status *api.DashboardResponse) string {
+	if !status.WalletInitialized {
+		return "The node wallet hasn't been initialized yet."
+	}
+	if status.ValidatorCount == 0 {
+		return "The node doesn't have any validators on the Beacon Chain yet."
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "%d validators\n\n", status.ValidatorCount)
+	if len(status.Proposals) == 0 {
+		text.WriteString("No proposals left this epoch.\n")
+	}
+	for i, proposal := range status.Proposals {
+		if i == maxDashboardItems {
+			fmt.Fprintf(&text, "...and %d more\n", len(status.Proposals)-i)
+			break
+		}
+		fmt.Fprintf(&text, "[green]Proposal[-] by validator %s in slot %d: %s\n", proposal.ValidatorIndex, proposal.Slot, formatCountdown(proposal.Time))
+	}
+	text.WriteString("\n")
+	if status.SyncCommitteeCount > 0 {
+		fmt.Fprintf(&text, "[green]%d validators are in the current sync committee[-] (ends in %s)\n", status.SyncCommitteeCount, formatCountdown(status.SyncPeriodEndTime))
+	}
+	if status.NextSyncCommitteeCount > 0 {
+		fmt.Fprintf(&text, "[yellow]%d validators are in the next sync committee[-] (starts in %s)\n", status.NextSyncCommitteeCount, formatCountdown(status.SyncPeriodEndTime))
+	}
+	if status.SyncCommitteeCount == 0 && status.NextSyncCommitteeCount == 0 {
+		text.WriteString("No sync committee duties.\n")
+	}
+	return text.String()
+}
+
+// Render how far the rolling record is behind the chain head, and the progress of the Watchtower's catch-up if it's running one
+func renderRollingRecords(status *api.DashboardResponse) string {
+	if !status.RollingRecordsEnabled {
+		return "Rolling records are disabled."
+	}
+	var text strings.Builder
+	if !status.RollingRecordSaved {
+		text.WriteString("No rolling record checkpoints have been saved yet.")
+	} else {
+		fmt.Fprintf(&text, "Latest checkpoint: slot %d (%d slots behind)\nNext checkpoint: %s", status.RollingRecordSlot, status.RollingRecordSlotsBehind, formatCountdown(status.RollingRecordCheckpointAt))
+	}
+
+	progress := status.RollingRecordCatchUp
+	if progress != nil && progress.Active {
+		fmt.Fprintf(&text, "\n[yellow]Catching up: epoch %d of %d (%d remaining)[-]", progress.CurrentEpoch, progress.TargetEpoch, progress.EpochsRemaining)
+		if progress.EpochsPerMinute > 0 {
+			fmt.Fprintf(&text, "\n%.2f epochs/min, done in %s", progress.EpochsPerMinute, formatCountdown(progress.Eta))
+		}
+		if progress.Bottleneck != "" {
+			fmt.Fprintf(&text, "\nBottleneck: %s (updated %s ago)", progress.Bottleneck, time.Since(progress.UpdatedTime).Round(time.Second))
+		}
+	}
+	return text.String()
+}
+
+// Render the node's pending transactions
+func renderTransactions(status *api.DashboardResponse) string {
+	if !status.WalletInitialized {
+		return "The node wallet hasn't been initialized yet."
+	}
+	switch status.PendingTransactionCount {
+	case 0:
+		return fmt.Sprintf("Node %s has no pending transactions.", status.NodeAddress.Hex())
+	case 1:
+		return fmt.Sprintf("Node %s has [yellow]1 pending transaction[-].", status.NodeAddress.Hex())
+	default:
+		return fmt.Sprintf("Node %s has [yellow]%d pending transactions[-].", status.NodeAddress.Hex(), status.PendingTransactionCount)
+	}
+}
+
+// Render the active alerts from Alertmanager
+func renderAlerts(status *api.DashboardResponse) string {
+	var text strings.Builder
+	if status.Warning != "" {
+		fmt.Fprintf(&text, "[yellow]%s[-]\n", tview.Escape(status.Warning))
+	}
+	activeCount := 0
+	for _, alert := range status.Alerts {
+		if !alert.IsActive() {
+			continue
+		}
+		if activeCount < maxDashboardItems {
+			fmt.Fprintf(&text, "[red]%s[-]: %s\n", tview.Escape(alert.Name()), tview.Escape(alert.Summary()))
+		}
+		activeCount++
+	}
+	if activeCount > maxDashboardItems {
+		fmt.Fprintf(&text, "...and %d more\n", activeCount-maxDashboardItems)
+	}
+	if activeCount == 0 {
+		text.WriteString("[green]No active alerts.[-]")
+	}
+	return text.String()
+}
+
+// Render the host's CPU, memory, and disk usage
+func renderHardware(status *api.DashboardResponse) string {
+	metrics := status.Hardware
+	if metrics == nil {
+		return "Hardware metrics unavailable."
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "CPU: %s (IO wait %.1f%%)\n", formatUsage(metrics.CpuUsagePercent, 90), metrics.IoWaitPercent)
+	fmt.Fprintf(&text, "Memory: %s of %.1f GiB\n", formatUsage(metrics.MemoryUsedPercent, 90), float64(metrics.MemoryTotal)/(1<<30))
+	for _, fs := range metrics.Filesystems {
+		fmt.Fprintf(&text, "Disk %s: %s of %.0f GiB\n", tview.Escape(fs.Path), formatUsage(fs.UsedPercent, 90), float64(fs.Total)/(1<<30))
+	}
+	for _, device := range metrics.Devices {
+		if device.ReadsPerSecond == 0 && device.WritesPerSecond == 0 {
+			continue
+		}
+		fmt.Fprintf(&text, "%s: %.0f r/s (%.1f ms), %.0f w/s (%.1f ms), %s busy\n", device.Name, device.ReadsPerSecond, device.ReadLatencyMs, device.WritesPerSecond, device.WriteLatencyMs, formatUsage(device.UtilizationPercent, 90))
+	}
+	for _, drive := range metrics.NvmeDrives {
+		if drive.SmartUnavailableNote != "" {
+			continue
+		}
+		line := fmt.Sprintf("%s: %d%% worn, %.0f°C, %.1f TB written", drive.Name, drive.PercentageUsed, drive.TemperatureCelsius, drive.DataUnitsWrittenTb)
+		if drive.CriticalWarning != 0 {
+			line = fmt.Sprintf("[red]%s, critical warning 0x%02x[-]", line, drive.CriticalWarning)
+		}
+		text.WriteString(line + "\n")
+	}
+	return text.String()
+}
+
+// Format a usage percentage, highlighting it once it passes the warning level
+func formatUsage(percent float64, warning float64) string {
+	if percent >= warning {
+		return fmt.Sprintf("[red]%.1f%%[-]", percent)
+	}
+	return fmt.Sprintf("%.1f%%", percent)
+}
+
+// Format the status of a single client
+func formatClientStatus(status api.ClientStatus) string {
+	if status.IsSynced {
+		return "[green]synced and ready[-]"
+	}
+	if status.IsWorking {
+		return fmt.Sprintf("[yellow]syncing (%.2f%%)[-]", rocketpool.SyncRatioToPercent(status.SyncProgress))
+	}
+	return fmt.Sprintf("[red]unavailable[-] (%s)", tview.Escape(status.Error))
+}
+
+// Format the time left until a point in time
+func formatCountdown(t time.Time) string {
+	remaining := time.Until(t).Round(time.Second)
+	if remaining <= 0 {
+		return "now"
+	}
+	return remaining.String()
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Fund the node wallet from the local devnet's funder account
+func devnetFund(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Check the amounts
+	ethAmount := c.Float64("eth")
+	rplAmount := c.Float64("rpl")
+	if ethAmount < 0 || rplAmount < 0 {
+		return fmt.Errorf("the amounts to send can't be negative")
+	}
+	if ethAmount == 0 && rplAmount == 0 {
+		fmt.Println("Nothing to send.")
+		return nil
+	}
+
+	// Fund the node
+	response, err := rp.DevnetFund(eth.EthToWei(ethAmount), eth.EthToWei(rplAmount))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Funding node %s from %s...\n", response.NodeAddress.Hex(), response.FunderAddress.Hex())
+	for _, hash := range []common.Hash{response.EthTxHash, response.RplTxHash} {
+		if hash == (common.Hash{}) {
+			continue
+		}
+		cliutils.PrintTransactionHashNoCancel(rp, hash)
+		if _, err = rp.WaitForTransaction(hash); err != nil {
+			return err
+		}
+	}
+
+	// Log & return
+	fmt.Printf("%sSuccessfully sent %.6f ETH and %.6f RPL to the node.%s\n", colorGreen, ethAmount, rplAmount, colorReset)
+	return nil
+
+}
+
+// Advance the local devnet's clock to the end of the current rewards interval
+func devnetFastForward(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Fast-forward
+	response, err := rp.DevnetFastForward(c.Uint64("intervals"))
+	if err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("%sAdvanced the devnet's clock by %s.%s\n", colorGreen, (time.Duration(response.SecondsAdvanced) * time.Second).String(), colorReset)
+	fmt.Printf("The latest block is now %d, with a timestamp of %s.\n", response.BlockNumber, response.BlockTime.Format(time.RFC1123))
+	return nil
+
+}
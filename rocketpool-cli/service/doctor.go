@@ -0,0 +1,80 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Check the node for the most common problems and print the ones it finds, most urgent first
+func runDoctor(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Run the checks
+	fmt.Println("Checking your node, this may take a few seconds...")
+	response, err := rp.RunDoctor()
+	if err != nil {
+		return fmt.Errorf("%w\n\nThe checks run inside the Smartnode's node process, so make sure it's running with `rocketpool service status` and look for errors with `rocketpool service logs node`.", err)
+	}
+
+	// Split the problems from the checks that passed
+	problems := []api.DoctorCheck{}
+	passed := []api.DoctorCheck{}
+	for _, check := range response.Checks {
+		if check.Severity == api.DoctorSeverity_Ok {
+			passed = append(passed, check)
+		} else {
+			problems = append(problems, check)
+		}
+	}
+	fmt.Println()
+
+	// Print the problems, which are already ranked
+	if len(problems) == 0 {
+		fmt.Printf("%sNo problems found.%s\n", colorGreen, colorReset)
+	} else {
+		fmt.Printf("Found %d problem(s), most urgent first:\n\n", len(problems))
+		for i, check := range problems {
+			fmt.Printf("%d. %s %s: %s\n", i+1, formatSeverity(check.Severity), check.Name, check.Details)
+			if check.Remedy != "" {
+				fmt.Printf("   Fix: %s\n", check.Remedy)
+			}
+			fmt.Println()
+		}
+	}
+
+	// Print the checks that passed
+	if c.Bool("all") {
+		fmt.Println("Passed:")
+		for _, check := range passed {
+			fmt.Printf("\t%s: %s\n", check.Name, check.Details)
+		}
+	} else if len(passed) > 0 {
+		names := make([]string, len(passed))
+		for i, check := range passed {
+			names[i] = check.Name
+		}
+		fmt.Printf("Passed: %s\n", strings.Join(names, ", "))
+	}
+	return nil
+
+}
+
+// Get the label for a problem's severity
+func formatSeverity(severity api.DoctorSeverity) string {
+	switch severity {
+	case api.DoctorSeverity_Critical:
+		return fmt.Sprintf("%s[CRITICAL]%s", colorRed, colorReset)
+	case api.DoctorSeverity_Warning:
+		return fmt.Sprintf("%s[WARNING]%s", colorYellow, colorReset)
+	default:
+		return "[COULDN'T CHECK]"
+	}
+}
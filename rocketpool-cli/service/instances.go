@@ -0,0 +1,59 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// List the Smartnode instances managed by this CLI
+func listInstances(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the instances
+	instances, err := rp.GetInstances()
+	if err != nil {
+		return err
+	}
+
+	for _, instance := range instances {
+		name := instance.Name
+		if name == "" {
+			name = "(main)"
+		}
+		if instance.Name == rp.InstanceName() {
+			name = fmt.Sprintf("%s%s (selected)%s", colorGreen, name, colorReset)
+		}
+		fmt.Println(name)
+		fmt.Printf("\tConfig folder: %s\n", instance.ConfigPath)
+		if instance.Config == nil {
+			fmt.Printf("\t%sNot configured yet; run `rocketpool%s service config` to set it up.%s\n\n", colorYellow, getInstanceFlag(instance.Name), colorReset)
+			continue
+		}
+		network := fmt.Sprint(instance.Config.GetNetwork())
+		if descriptor := instance.Config.GetNetworkDescriptor(instance.Config.GetNetwork()); descriptor != nil {
+			network = descriptor.Name
+		}
+		fmt.Printf("\tNetwork: %s\n", network)
+		fmt.Printf("\tProject name: %s\n", instance.Config.Smartnode.ProjectName.Value)
+		fmt.Printf("\tExecution client HTTP port: %d\n", instance.Config.ExecutionCommon.HttpPort.Value)
+		fmt.Printf("\tBeacon node API port: %d\n\n", instance.Config.ConsensusCommon.ApiPort.Value)
+	}
+
+	fmt.Println("Create a new instance with `rocketpool -n <name> service install` followed by `rocketpool -n <name> service config`; its ports are moved out of the way of the other instances automatically.")
+	return nil
+
+}
+
+// Get the global flag that selects an instance
+func getInstanceFlag(name string) string {
+	if name == "" {
+		return ""
+	}
+	return fmt.Sprintf(" -n %s", name)
+}
@@ -0,0 +1,346 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/backup"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// How often to check on the new machine's sync progress and the validators' attestations during a migration
+const migrationCheckInterval = time.Minute
+
+// Package up the node on the machine it's moving away from, and deactivate it there
+func migrateExport(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+	_, migrated, err := rp.GetMigrationTime()
+	if err != nil {
+		return err
+	}
+	if migrated {
+		return fmt.Errorf("This node has already been migrated to another machine. Run `rocketpool service start` first if you want to cancel that migration and move it again.")
+	}
+
+	// Get the components
+	components := append([]backup.Component{}, backup.DefaultComponents...)
+	if c.Bool("include-validator-keys") {
+		components = append(components, backup.Component_ValidatorKeys)
+	}
+
+	// Explain what's going to happen
+	fmt.Println("This will move your node to a new machine. On this machine, it will:")
+	fmt.Println("\t1. Stop the Validator Client, so your validators stop attesting here.")
+	fmt.Printf("\t2. Back up your %s into an encrypted archive.\n", backup.FormatComponents(components))
+	fmt.Println("\t3. Stop the rest of the Smartnode, and mark it as migrated so it isn't started here again by accident.")
+	fmt.Println()
+	fmt.Println("Your validators will be offline until the new machine is synced and has confirmed they aren't attesting anywhere else, which takes a few epochs.")
+	fmt.Println("To keep that short, install the Smartnode on the new machine and let its clients sync before you start.")
+	fmt.Println()
+	if !c.Bool("yes") && !cliutils.Confirm("Are you ready to stop validating on this machine?") {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Get the password
+	password := promptBackupPassword()
+
+	// Stop the Validator Client before its slashing protection database is copied
+	if cfg.IsNativeMode {
+		fmt.Println("Please stop your Validator Client now, and disable it so it doesn't start again if this machine reboots.")
+		if !cliutils.ConfirmWithIAgree("Have you stopped and disabled the Validator Client?") {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	} else {
+		if _, err := stopValidatorForBackup(rp); err != nil {
+			return err
+		}
+	}
+	stopTime := time.Now()
+
+	// Create the backup
+	fmt.Println("Creating the backup...")
+	response, err := rp.CreateBackup(backup.FormatComponents(components), password)
+	if err != nil {
+		fmt.Printf("%sThe Validator Client is still stopped. Run `rocketpool service start` to resume validating on this machine.%s\n", colorYellow, colorReset)
+		return err
+	}
+	backupsFolder, err := homedir.Expand(cfg.Smartnode.GetBackupsFolder(false))
+	if err != nil {
+		return fmt.Errorf("error expanding backups folder: %w", err)
+	}
+	path := filepath.Join(backupsFolder, response.Filename)
+	if c.IsSet("output") {
+		output, err := homedir.Expand(c.String("output"))
+		if err != nil {
+			return fmt.Errorf("error expanding output path: %w", err)
+		}
+		archive, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading backup [%s]: %w", path, err)
+		}
+		err = os.WriteFile(output, archive, 0600)
+		if err != nil {
+			return fmt.Errorf("error copying backup to [%s]: %w", output, err)
+		}
+		path = output
+	}
+
+	// Deactivate the node here
+	if cfg.IsNativeMode {
+		fmt.Println("Please stop and disable your node daemon and watchtower as well.")
+	} else {
+		fmt.Println("Stopping the Smartnode...")
+		if err := rp.PauseService(getComposeFiles(c)); err != nil {
+			return fmt.Errorf("Error stopping the Smartnode: %w", err)
+		}
+	}
+	if err := rp.SetMigrated(stopTime); err != nil {
+		return err
+	}
+
+	fmt.Printf("%sYour node has been packaged up and deactivated on this machine.%s\n", colorGreen, colorReset)
+	fmt.Printf("\tPath:    %s\n", path)
+	fmt.Printf("\tSHA-256: %s\n", response.Sha256)
+	fmt.Printf("\tStopped: %s\n", stopTime.Format(time.RFC1123))
+	fmt.Println()
+	fmt.Println("Copy the backup to the new machine (for example with `scp`), check that its SHA-256 matches, and run `rocketpool service migrate import <path>` there.")
+	fmt.Printf("%sDon't start the Smartnode on this machine again. If you need to cancel the migration, run `rocketpool service start` here BEFORE importing the backup on the new machine.%s\n", colorYellow, colorReset)
+	return nil
+
+}
+
+// Restore a node packaged with `migrate export`, then resume its duties once it's safe
+func migrateImport(c *cli.Context, path string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up the Smartnode on this machine first; the config from the old machine will be restored over it.")
+	}
+
+	// Verify the backup
+	filename, cleanup, err := prepareBackupForRestore(cfg, path)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	password := cliutils.PromptPassword("Please enter the backup's password:", "^.+$", "")
+	fmt.Println("Verifying the backup...")
+	verifyResponse, err := rp.RestoreBackup(filename, "all", password, true)
+	if err != nil {
+		return err
+	}
+	manifest := verifyResponse.Manifest
+	printBackupManifest(manifest)
+	fmt.Printf("%sEvery file in the backup matches its checksum.%s\n\n", colorGreen, colorReset)
+	if !containsString(manifest.Components, string(backup.Component_SlashingProtection)) {
+		fmt.Printf("%sWARNING: This backup doesn't include the slashing protection database, so this machine won't know what your validators have already signed.%s\n\n", colorYellow, colorReset)
+	}
+
+	// Confirm
+	fmt.Println("The backup will be restored over this machine's config and data. The Validator Client will stay stopped until the clients are synced and your validators are confirmed to be offline everywhere else.")
+	if !c.Bool("yes") && !cliutils.Confirm("Are you sure you want to import this node?") {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Restore everything with the Validator Client stopped
+	if !cfg.IsNativeMode {
+		if _, err := stopValidatorForBackup(rp); err != nil {
+			return err
+		}
+	}
+	fmt.Println("Restoring...")
+	response, err := rp.RestoreBackup(filename, "all", password, false)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Restored %d files into the data folder.\n", response.RestoredFiles)
+	if containsString(manifest.Components, string(backup.Component_Config)) {
+		if err := restoreBackedUpConfig(rp, cfg, response.Settings); err != nil {
+			return err
+		}
+		fmt.Println("Restored the config.")
+	}
+	if cfg.IsNativeMode {
+		fmt.Println("Please restart your node daemon and watchtower now, but leave the Validator Client stopped.")
+	} else if err := restartDaemonsAfterRestore(rp); err != nil {
+		return err
+	}
+	fmt.Println()
+
+	return finishMigration(c, rp, cfg)
+
+}
+
+// Pick up an imported migration where it left off
+func migrateResume(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service migrate import` first.")
+	}
+
+	return finishMigration(c, rp, cfg)
+
+}
+
+// Wait for the clients to sync and for the validators to be offline everywhere, then start the Validator Client
+func finishMigration(c *cli.Context, rp *rocketpool.Client, cfg *config.RocketPoolConfig) error {
+
+	composeFiles := getComposeFiles(c)
+	if !cfg.IsNativeMode {
+		fmt.Println("Starting the Smartnode without the Validator Client...")
+		if err := rp.StartServiceWithoutValidator(composeFiles); err != nil {
+			return fmt.Errorf("Error starting the Smartnode: %w", err)
+		}
+	}
+
+	// Wait for the clients to sync
+	fmt.Println("Waiting for this machine's clients to sync...")
+	for {
+		problems := getMigrationSyncProblems(rp)
+		if len(problems) == 0 {
+			break
+		}
+		for _, problem := range problems {
+			fmt.Printf("\t%s\n", problem)
+		}
+		time.Sleep(migrationCheckInterval)
+	}
+	fmt.Printf("%sThe clients are synced.%s\n\n", colorGreen, colorReset)
+
+	// Don't count the epoch that's in progress, since the old machine may have attested in it before it was stopped
+	performance, err := rp.NodeAttestationPerformance(math.MaxUint64)
+	if err != nil {
+		return err
+	}
+	if performance.ActiveValidators == 0 {
+		fmt.Printf("%sThe node doesn't have any active validators, so there are no attestations to check.%s\n\n", colorYellow, colorReset)
+	} else if err := waitForValidatorsOffline(c, rp, performance.CurrentEpoch+1); err != nil {
+		return err
+	}
+
+	// Confirm the old machine is deactivated
+	if !c.Bool("yes") && !cliutils.ConfirmWithIAgree("Is the old machine shut down, with its Smartnode stopped so it can't start validating again?") {
+		fmt.Println("The Validator Client will stay stopped. Run `rocketpool service migrate resume` when you're ready.")
+		return nil
+	}
+
+	// Tell the active host guard that this machine has taken over, so it doesn't block the Validator Client
+	if cfg.Smartnode.EnableActiveHostGuard.Value.(bool) {
+		if _, err := rp.ClaimActiveHost(); err != nil {
+			return err
+		}
+	}
+
+	// Resume duties
+	if cfg.IsNativeMode {
+		fmt.Printf("%sThe migration is ready. Please start your Validator Client now.%s\n", colorGreen, colorReset)
+		return nil
+	}
+	fmt.Println("Starting the Validator Client...")
+	if err := rp.StartService(composeFiles); err != nil {
+		return fmt.Errorf("Error starting the Smartnode: %w", err)
+	}
+	fmt.Printf("%sThe migration is complete, and your validators are running on this machine.%s\n", colorGreen, colorReset)
+	return nil
+
+}
+
+// Wait for enough finished epochs in a row with none of the node's attestations included, which shows the validators aren't running anywhere else
+func waitForValidatorsOffline(c *cli.Context, rp *rocketpool.Client, startEpoch uint64) error {
+	safetyEpochs := c.Uint64("safety-epochs")
+	fmt.Printf("Waiting for %d epochs in a row with none of your validators' attestations on chain, starting at epoch %d, to make sure they aren't running anywhere else...\n", safetyEpochs, startEpoch)
+
+	nextEpoch := startEpoch
+	offlineEpochs := uint64(0)
+	for offlineEpochs < safetyEpochs {
+		time.Sleep(migrationCheckInterval)
+		performance, err := rp.NodeAttestationPerformance(nextEpoch)
+		if err != nil {
+			fmt.Printf("%sCouldn't check the attestations: %s%s\n", colorYellow, err.Error(), colorReset)
+			continue
+		}
+		for _, epoch := range performance.Epochs {
+			nextEpoch = epoch.Epoch + 1
+			if epoch.Included > 0 {
+				fmt.Printf("%s%d of your validators' attestations were included in epoch %d, so they're still running somewhere else.\n"+
+					"Make sure the Validator Client on the old machine is stopped, then run `rocketpool service migrate resume` to start the check again.%s\n", colorRed, epoch.Included, epoch.Epoch, colorReset)
+				return fmt.Errorf("the validators are still attesting on another machine")
+			}
+			if offlineEpochs < safetyEpochs {
+				offlineEpochs++
+				fmt.Printf("Epoch %d: none of your %d attestations were included (%d of %d).\n", epoch.Epoch, epoch.Duties, offlineEpochs, safetyEpochs)
+			}
+		}
+	}
+	fmt.Printf("%sYour validators haven't attested anywhere for %d epochs.%s\n\n", colorGreen, safetyEpochs, colorReset)
+	return nil
+}
+
+// Get the reasons the clients aren't ready yet, if there are any
+func getMigrationSyncProblems(rp *rocketpool.Client) []string {
+	status, err := rp.GetClientStatus()
+	if err != nil {
+		return []string{fmt.Sprintf("Couldn't get the client status: %s", err.Error())}
+	}
+	problems := []string{}
+	clients := []struct {
+		name    string
+		manager api.ClientManagerStatus
+	}{
+		{"The Execution client", status.EcManagerStatus},
+		{"The Beacon Node", status.BcManagerStatus},
+	}
+	for _, client := range clients {
+		primary := client.manager.PrimaryClientStatus
+		if primary.IsSynced || (client.manager.FallbackEnabled && client.manager.FallbackClientStatus.IsSynced) {
+			continue
+		}
+		if !primary.IsWorking {
+			problems = append(problems, fmt.Sprintf("%s isn't responding: %s", client.name, primary.Error))
+		} else {
+			problems = append(problems, fmt.Sprintf("%s is still syncing (%.2f%%)", client.name, primary.SyncProgress*100))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Show the router's port mappings for the P2P ports
+func portForwardingStatus(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the status
+	response, err := rp.GetPortForwardingStatus()
+	if err != nil {
+		return err
+	}
+	if !response.Enabled {
+		fmt.Printf("%sAutomatic port forwarding is disabled. Enable it in the Smartnode section of `rocketpool service config`.%s\n", colorYellow, colorReset)
+		return nil
+	}
+	status := response.Forwarding
+	if status == nil {
+		fmt.Println("The Node process hasn't tried to forward any ports yet. Make sure it's running with `rocketpool service status`.")
+		return nil
+	}
+
+	fmt.Printf("Last checked: %s\n", status.UpdatedAt.Local().Format(time.RFC1123))
+	if status.Error != "" {
+		fmt.Printf("%sThe router couldn't be reached with %s: %s%s\n", colorRed, status.Method, status.Error, colorReset)
+		fmt.Println("Make sure UPnP or NAT-PMP is enabled on your router.")
+	}
+	if status.ExternalIp != "" {
+		fmt.Printf("External IP address: %s (your clients advertise it after `rocketpool service start`)\n", status.ExternalIp)
+	}
+	if len(status.Mappings) == 0 {
+		fmt.Println("No ports are being forwarded.")
+		return nil
+	}
+
+	fmt.Println()
+	for _, mapping := range status.Mappings {
+		switch {
+		case mapping.Error != "":
+			fmt.Printf("%s%s (%d/%s): couldn't be forwarded: %s%s\n", colorRed, mapping.Name, mapping.InternalPort, mapping.Protocol, mapping.Error, colorReset)
+		case mapping.ExternalPort != mapping.InternalPort:
+			fmt.Printf("%s%s (%d/%s): forwarded from external port %d instead, which peers won't use; free up the port on your router%s\n", colorYellow, mapping.Name, mapping.InternalPort, mapping.Protocol, mapping.ExternalPort, colorReset)
+		default:
+			fmt.Printf("%s%s (%d/%s): forwarded until %s%s\n", colorGreen, mapping.Name, mapping.InternalPort, mapping.Protocol, mapping.ExpiresAt.Local().Format(time.RFC1123), colorReset)
+		}
+	}
+	return nil
+
+}
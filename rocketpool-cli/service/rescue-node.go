@@ -0,0 +1,177 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/addons/rescue_node"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Load the config for the Rescue Node commands, which only work in Docker mode
+func loadRescueNodeConfig(rp *rocketpool.Client) (*config.RocketPoolConfig, *rescue_node.RescueNode, error) {
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if isNew {
+		return nil, nil, fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+	if cfg.IsNativeMode {
+		return nil, nil, fmt.Errorf("The Rescue Node add-on isn't supported in Native Mode. Visit https://rescuenode.com to connect to it manually.")
+	}
+	return cfg, cfg.RescueNode.(*rescue_node.RescueNode), nil
+}
+
+// Get a Rescue Node credential and point the Validator Client at it for a limited time
+func enableRescueNode(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	cfg, rescueNode, err := loadRescueNodeConfig(rp)
+	if err != nil {
+		return err
+	}
+
+	// Check the time limit
+	timeLimit := c.Duration("time-limit")
+	if timeLimit <= 0 || timeLimit > rescue_node.MaxTimeLimit {
+		return fmt.Errorf("The time limit must be more than 0 and at most %s.", rescue_node.MaxTimeLimit)
+	}
+
+	// Get the node account
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		return fmt.Errorf("The node wallet is not initialized. The Rescue Node only issues credentials to registered Rocket Pool nodes.")
+	}
+
+	// Explain what this does
+	fmt.Printf("%s=== Using the Rescue Node ===%s\n", colorYellow, colorReset)
+	fmt.Println("The Rescue Node is a community-run Beacon Node your Validator Client can use while your own Beacon Node is down for a long time, such as during a resync.")
+	fmt.Printf("%sPlease read the following carefully:%s\n", colorYellow, colorReset)
+	fmt.Println("\t- Your validator keys stay on this machine, but the Rescue Node's operators will see every attestation and block your validators make while it's in use.")
+	fmt.Println("\t- Credentials are rate limited, so only use it when you have to. Rocket Pool nodes get a credential that lasts 15 days, and can't get another one for a while after that.")
+	fmt.Printf("\t- NEVER run your validators on another machine while this one is using the Rescue Node. %sThat WILL get them slashed.%s\n", colorRed, colorReset)
+	fmt.Printf("\t- The Validator Client won't switch back to your own Beacon Node on its own when the %s time limit passes; it keeps using the Rescue Node until you run `rocketpool service rescue-node disable`, or `rocketpool service start` after the limit. Disable it as soon as your Beacon Node finishes syncing.\n", timeLimit)
+	if doppelgangerEnabled, err := cfg.IsDoppelgangerEnabled(); err == nil && doppelgangerEnabled {
+		fmt.Printf("%s\t- You have Doppelganger Protection enabled, so your validators will miss a few attestations each time the Validator Client restarts.%s\n", colorYellow, colorReset)
+	}
+	fmt.Println()
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Do you want to sign a credential request with node account %s and switch your Validator Client to the Rescue Node?", status.AccountAddress.Hex()))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Prove ownership of the node to get a credential
+	message := rescue_node.GetCredentialRequestMessage(time.Now())
+	signRequest, err := rp.RequestSignMessage(message)
+	if err != nil {
+		return err
+	}
+	signResponse, err := rp.SignMessage(signRequest.Code, message)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Requesting a credential from the Rescue Node...")
+	credential, err := rescue_node.RequestCredential(status.AccountAddress, message, signResponse.SignedData)
+	if err != nil {
+		return err
+	}
+
+	// Don't let the time limit run past the credential
+	expiresAt := time.Now().Add(timeLimit)
+	if credential.ExpiresAt.Before(expiresAt) {
+		expiresAt = credential.ExpiresAt
+		fmt.Printf("%sNOTE: The credential expires at %s, which is sooner than the time limit, so the time limit was shortened to match it.%s\n", colorYellow, expiresAt.Local().Format(time.RFC1123), colorReset)
+	}
+
+	// Point the Validator Client at it
+	rescueNode.Enable(credential.Username, credential.Password, expiresAt)
+	if errors := cfg.Validate(); len(errors) > 0 {
+		return fmt.Errorf("The Rescue Node couldn't be enabled because your configuration has errors; please fix them with `rocketpool service config` first:\n\n%s", errors[0])
+	}
+	if err := rp.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("Error saving the Rescue Node credential: %w", err)
+	}
+	fmt.Println("Restarting the Validator Client with the Rescue Node...")
+	if err := rp.StartService(getComposeFiles(c)); err != nil {
+		return fmt.Errorf("The Rescue Node is enabled, but the containers couldn't be restarted; please run `rocketpool service start`: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Printf("%sYour Validator Client is now using the Rescue Node. Its time limit runs out at %s.%s\n", colorGreen, expiresAt.Local().Format(time.RFC1123), colorReset)
+	fmt.Println("The node daemon will alert you as the time limit gets close, and once it's passed. Run `rocketpool service rescue-node disable` once your own Beacon Node is synced.")
+	return nil
+
+}
+
+// Stop using the Rescue Node and go back to the node's own Beacon Node
+func disableRescueNode(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	cfg, rescueNode, err := loadRescueNodeConfig(rp)
+	if err != nil {
+		return err
+	}
+	if rescueNode.GetEnabledParameter().Value != true {
+		fmt.Println("The Rescue Node isn't enabled.")
+		return nil
+	}
+
+	// Make sure the local Beacon Node is ready to take over
+	fmt.Printf("%sMake sure your own Beacon Node has finished syncing first, or your validators will miss their duties until it has.%s\n", colorYellow, colorReset)
+	if !(c.Bool("yes") || cliutils.Confirm("Do you want to switch your Validator Client back to your own Beacon Node?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	rescueNode.Disable()
+	if err := rp.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("Error saving configuration: %w", err)
+	}
+	fmt.Println("Restarting the Validator Client with your own Beacon Node...")
+	if err := rp.StartService(getComposeFiles(c)); err != nil {
+		return fmt.Errorf("The Rescue Node is disabled, but the containers couldn't be restarted; please run `rocketpool service start`: %w", err)
+	}
+
+	fmt.Printf("%sYour Validator Client is using your own Beacon Node again.%s\n", colorGreen, colorReset)
+	return nil
+
+}
+
+// Print whether the Rescue Node is in use, and how long it has left
+func getRescueNodeStatus(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	_, rescueNode, err := loadRescueNodeConfig(rp)
+	if err != nil {
+		return err
+	}
+	if rescueNode.GetEnabledParameter().Value != true {
+		fmt.Println("The Rescue Node isn't enabled; your Validator Client is using your own Beacon Node.")
+		return nil
+	}
+
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	rescueNode.PrintStatusText(status.AccountAddress)
+	return nil
+
+}
@@ -16,6 +16,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/dustin/go-humanize"
+	"github.com/rocket-pool/smartnode/addons/rescue_node"
 	cliconfig "github.com/rocket-pool/smartnode/rocketpool-cli/service/config"
 	"github.com/rocket-pool/smartnode/shared"
 	"github.com/rocket-pool/smartnode/shared/services/config"
@@ -83,8 +84,14 @@ func installService(c *cli.Context) error {
 		}
 	}
 
+	// Install an additional instance into its own config folder
+	installPath := c.String("path")
+	if installPath == "" && rp.InstanceName() != "" {
+		installPath = rp.ConfigPath()
+	}
+
 	// Install service
-	err = rp.InstallService(c.Bool("verbose"), c.Bool("no-deps"), c.String("version"), c.String("path"), dataPath)
+	err = rp.InstallService(c.Bool("verbose"), c.Bool("no-deps"), c.String("version"), installPath, dataPath)
 	if err != nil {
 		return err
 	}
@@ -185,11 +192,16 @@ func serviceStatus(c *cli.Context) error {
 	}
 
 	// Print what network we're on
-	err = cliutils.PrintNetwork(cfg.GetNetwork(), isNew)
+	err = cliutils.PrintNetwork(cfg, isNew)
 	if err != nil {
 		return err
 	}
 
+	// Show the live dashboard if requested
+	if c.Bool("tui") {
+		return runDashboard(c, rp, cfg)
+	}
+
 	// Print service status
 	return rp.PrintServiceStatus(getComposeFiles(c))
 
@@ -251,6 +263,10 @@ func configureService(c *cli.Context) error {
 
 	app := tview.NewApplication()
 	md := cliconfig.NewMainDisplay(app, oldCfg, cfg, isNew, isUpdate, isNative)
+	if !isNative {
+		// If the ports can't be read, the wizard will show the ones the containers use as conflicts and the user can keep them
+		md.PublishedPorts, _ = rp.GetPublishedPorts(cfg.Smartnode.ProjectName.Value.(string))
+	}
 	err = app.Run()
 	if err != nil {
 		return err
@@ -264,6 +280,9 @@ func configureService(c *cli.Context) error {
 			return fmt.Errorf("error saving config: %w", err)
 		}
 		fmt.Println("Your changes have been saved!")
+		for _, warning := range md.Config.GetWarnings() {
+			fmt.Printf("%sNOTE: %s%s\n", colorYellow, warning, colorReset)
+		}
 
 		// Exit immediately if we're in native mode
 		if isNative {
@@ -338,6 +357,14 @@ func configureService(c *cli.Context) error {
 // Updates a configuration from the provided CLI arguments headlessly
 func configureHeadless(c *cli.Context, cfg *config.RocketPoolConfig) error {
 
+	// Apply the hardware profile first, so any settings passed along with it override the profile's values
+	if c.IsSet("smartnode-" + config.HardwareProfileID) {
+		if err := updateConfigParamFromCliArg(c, "smartnode", &cfg.Smartnode.HardwareProfile, cfg); err != nil {
+			return err
+		}
+		cfg.ApplyHardwareProfile()
+	}
+
 	// Root params
 	for _, param := range cfg.GetParameters() {
 		err := updateConfigParamFromCliArg(c, "", param, cfg)
@@ -356,6 +383,15 @@ func configureHeadless(c *cli.Context, cfg *config.RocketPoolConfig) error {
 		}
 	}
 
+	// Make sure the settings that were changed actually apply
+	for _, result := range cfg.GetAllParameters() {
+		if c.IsSet(result.FlagName) {
+			if err := cfg.CheckParameterDependencies(result); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 
 }
@@ -497,6 +533,32 @@ func startService(c *cli.Context, ignoreConfigSuggestion bool) error {
 		return fmt.Errorf("No configuration detected. Please run `rocketpool service config` to set up your Smartnode before running it.")
 	}
 
+	// Make sure a node that was migrated to another machine isn't started here again by accident
+	migrationTime, migrated, err := rp.GetMigrationTime()
+	if err != nil {
+		return err
+	}
+	if migrated {
+		fmt.Printf("%sThis node was migrated to another machine on %s. If its validators are running there, starting them here as well WILL get them slashed.%s\n\n", colorRed, migrationTime.Local().Format(time.RFC1123), colorReset)
+		if !cliutils.ConfirmWithIAgree("Are you sure the other machine is permanently offline, and that you want to run this node here again?") {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		if err := rp.ClearMigrated(); err != nil {
+			return err
+		}
+	}
+
+	// Go back to the node's own Beacon Node once the Rescue Node's time limit has passed
+	rescueNode := cfg.RescueNode.(*rescue_node.RescueNode)
+	if rescueNode.IsExpired() {
+		fmt.Printf("%sThe Rescue Node's time limit passed at %s, so the Validator Client will use your own Beacon Node again.%s\n\n", colorYellow, rescueNode.GetExpiry().Local().Format(time.RFC1123), colorReset)
+		rescueNode.Disable()
+		if err := rp.SaveConfig(cfg); err != nil {
+			return fmt.Errorf("error disabling the Rescue Node: %w", err)
+		}
+	}
+
 	// Check if this is a new install
 	isUpdate, err := rp.IsFirstRun()
 	if err != nil {
@@ -540,6 +602,9 @@ func startService(c *cli.Context, ignoreConfigSuggestion bool) error {
 		fmt.Println(colorReset)
 		return nil
 	}
+	for _, warning := range cfg.GetWarnings() {
+		fmt.Printf("%sNOTE: %s%s\n\n", colorYellow, warning, colorReset)
+	}
 
 	if !c.Bool("ignore-slash-timer") {
 		// Do the client swap check
@@ -608,6 +673,12 @@ func startService(c *cli.Context, ignoreConfigSuggestion bool) error {
 		fmt.Printf("%sNOTE: You currently have Doppelganger Protection enabled.\nYour validator will miss up to 3 attestations when it starts.\nThis is *intentional* and does not indicate a problem with your node.%s\n\n", colorYellow, colorReset)
 	}
 
+	// Keep the validator keys in memory if they're encrypted at rest
+	err = rp.MountValidatorKeysInMemory(getComposeFiles(c))
+	if err != nil {
+		return err
+	}
+
 	// Start service
 	err = rp.StartService(getComposeFiles(c))
 	if err != nil {
@@ -1309,7 +1380,7 @@ func serviceVersion(c *cli.Context) error {
 	}
 
 	// Print what network we're on
-	err = cliutils.PrintNetwork(cfg.GetNetwork(), isNew)
+	err = cliutils.PrintNetwork(cfg, isNew)
 	if err != nil {
 		return err
 	}
@@ -1416,6 +1487,10 @@ func serviceVersion(c *cli.Context) error {
 	fmt.Printf("Selected Eth 1.0 client: %s\n", eth1ClientString)
 	fmt.Printf("Selected Eth 2.0 client: %s\n", eth2ClientString)
 	fmt.Printf("MEV-Boost client: %s\n", mevBoostString)
+
+	if c.Bool("check") {
+		printUpdateCheck(cfg, c.App.Version)
+	}
 	return nil
 
 }
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/support"
+)
+
+// Collect the node's config, logs, and status into a single archive for a bug report
+func createSupportBundle(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+	composeFiles := getComposeFiles(c)
+	bundle := support.NewBundle(c.App.Version)
+
+	// Versions and config
+	fmt.Println("Collecting versions and settings...")
+	serviceVersion, err := rp.GetServiceVersion()
+	if err != nil {
+		fmt.Printf("%sCouldn't get the service version: %s%s\n", colorYellow, err.Error(), colorReset)
+	}
+	bundle.Manifest.SmartnodeVersion = serviceVersion
+	bundle.AddJson("config.json", support.RedactConfig(cfg), nil)
+
+	// Host info
+	fmt.Println("Collecting host info...")
+	paths := []string{"/"}
+	dataPath, err := homedir.Expand(cfg.Smartnode.DataPath.Value.(string))
+	if err == nil {
+		paths = append(paths, dataPath)
+	}
+	if !cfg.IsNativeMode {
+		dockerRoot, err := rp.GetDockerRootDir()
+		if err == nil {
+			paths = append(paths, dockerRoot)
+		}
+	}
+	hostInfo, err := support.GetHostInfo(paths)
+	bundle.AddJson("host.json", hostInfo, err)
+
+	// Daemon and client status
+	fmt.Println("Collecting the status of the daemons and clients...")
+	clientStatus, err := rp.GetClientStatus()
+	bundle.AddJson("client-status.json", clientStatus, err)
+	dashboard, err := rp.GetDashboard()
+	bundle.AddJson("daemon-status.json", dashboard, err)
+
+	// Containers and their logs
+	if !cfg.IsNativeMode {
+		fmt.Println("Collecting container status and logs...")
+		status, err := rp.GetServiceStatus(composeFiles)
+		bundle.AddFile("containers.txt", status, err)
+		serviceNames, err := rp.GetServiceNames(composeFiles)
+		if err != nil {
+			bundle.AddFile("logs", nil, err)
+		}
+		for _, serviceName := range serviceNames {
+			logs, err := rp.GetServiceLogs(composeFiles, c.String("tail"), serviceName)
+			bundle.AddFile(fmt.Sprintf("logs/%s.log", serviceName), logs, err)
+		}
+	}
+
+	// Save it
+	path := bundle.GetFilename()
+	if c.IsSet("output") {
+		path, err = homedir.Expand(c.String("output"))
+		if err != nil {
+			return fmt.Errorf("error expanding output path: %w", err)
+		}
+	}
+	path, err = filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("error getting the absolute path of [%s]: %w", path, err)
+	}
+	if err := bundle.Save(path); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	for _, file := range bundle.Manifest.Files {
+		if file.Error != "" {
+			fmt.Printf("%sCouldn't collect %s: %s%s\n", colorYellow, file.Name, file.Error, colorReset)
+		}
+	}
+	fmt.Printf("%sSupport bundle created with %d of %d items.%s\n", colorGreen, len(bundle.Manifest.Files)-bundle.GetErrorCount(), len(bundle.Manifest.Files), colorReset)
+	fmt.Printf("\tPath: %s\n", path)
+	if cfg.IsNativeMode {
+		fmt.Println("\nIn Native Mode the logs are kept by your own service manager, so please attach the recent logs of your clients and daemons separately.")
+	}
+	fmt.Println("\nPasswords, tokens, and the API keys in URLs have been redacted, but please look through the bundle before sharing it in case your logs have anything else you'd rather keep private.")
+	return nil
+
+}
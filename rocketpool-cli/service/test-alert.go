@@ -0,0 +1,46 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Send a test alert to every enabled notification sink
+func testAlert(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Send the alert
+	response, err := rp.TestAlert(strings.ToLower(c.String("severity")))
+	if err != nil {
+		return err
+	}
+	if len(response.Results) == 0 {
+		fmt.Println("You don't have any notification sinks enabled. You can set up email, ntfy, and Pushover in the Alerting section of the `rocketpool service config` TUI.")
+		return nil
+	}
+
+	// Print the results
+	failed := 0
+	for _, result := range response.Results {
+		if result.Error == "" {
+			fmt.Printf("%s%s: sent.%s\n", colorGreen, result.Sink, colorReset)
+		} else {
+			fmt.Printf("%s%s: failed - %s%s\n", colorRed, result.Sink, result.Error, colorReset)
+			failed++
+		}
+	}
+	fmt.Println()
+	if failed > 0 {
+		return fmt.Errorf("%d of %d notification sinks couldn't send the test alert", failed, len(response.Results))
+	}
+	fmt.Println("The test alert was sent to all of your notification sinks. Check that it arrived on each of them.")
+	return nil
+
+}
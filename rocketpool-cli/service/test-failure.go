@@ -0,0 +1,51 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Inject a synthetic failure into the alerting pipeline so the notifications can be checked end to end
+func testFailure(c *cli.Context, scenario string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Send the failure
+	response, err := rp.TestFailure(scenario)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Sent a synthetic %s failure to Alertmanager as a %s %s alert:\n", scenario, response.Severity, response.AlertName)
+	fmt.Printf("\t%s\n\n", response.Summary)
+
+	// Print what Alertmanager did with it
+	switch response.AlertState {
+	case "active":
+		fmt.Printf("%sAlertmanager accepted the alert and it's active.%s\n", colorGreen, colorReset)
+	case "unprocessed":
+		fmt.Printf("%sAlertmanager accepted the alert and is still processing it.%s\n", colorGreen, colorReset)
+	case "suppressed":
+		fmt.Printf("%sAlertmanager accepted the alert, but it's suppressed by a silence or inhibition rule. The real alert would be hidden the same way, so it won't be delivered anywhere.%s\n", colorYellow, colorReset)
+		return nil
+	default:
+		return fmt.Errorf("Alertmanager didn't report the alert after it was sent; please check its logs with `rocketpool service logs alertmanager`")
+	}
+
+	// Explain where it will go
+	fmt.Println("It will be sent to any receivers you've set up in Alertmanager itself, such as a Discord webhook.")
+	if len(response.Sinks) == 0 {
+		fmt.Println("You don't have any notification sinks enabled. You can set up email, ntfy, and Pushover in the Alerting section of the `rocketpool service config` TUI.")
+	} else {
+		fmt.Printf("The node daemon will also deliver it to your notification sinks (%s) the next time it checks for alerts, within about 5 minutes. Sinks that send digests or batches will include it in their next one.\n", strings.Join(response.Sinks, ", "))
+	}
+	fmt.Printf("\nThe alert is marked as a test and will resolve on its own at %s.\n", response.ExpiresAt.Local().Format(time.RFC822))
+	return nil
+
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/hardware"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+const (
+	hostTuningUndoFile   string = "host-tuning-undo.sh"
+	hostTuningUndoHeader string = "#!/bin/sh\n# Reverts the changes made by `rocketpool service tune-host`, newest first\n"
+)
+
+// Inspect the host's kernel and system settings, and apply the recommended changes if requested
+func tuneHost(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the undo file path
+	configPath, err := homedir.Expand(rp.ConfigPath())
+	if err != nil {
+		return fmt.Errorf("error expanding config path: %w", err)
+	}
+	undoPath := filepath.Join(configPath, hostTuningUndoFile)
+	if c.Bool("undo") {
+		return undoHostTuning(c, rp, undoPath)
+	}
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	// Get the paths the chain data is stored in
+	dataPaths := []string{}
+	if cfg.IsNativeMode {
+		dataPaths = append(dataPaths, cfg.Smartnode.DataPath.Value.(string))
+	} else {
+		dockerRoot, err := rp.GetDockerRootDir()
+		if err != nil {
+			return fmt.Errorf("Error getting the Docker root directory: %w", err)
+		}
+		dataPaths = append(dataPaths, dockerRoot)
+	}
+
+	// Inspect the host
+	tunings, err := hardware.GetHostTunings(dataPaths)
+	if err != nil {
+		return err
+	}
+	pending := []hardware.HostTuning{}
+	for _, tuning := range tunings {
+		if tuning.Tuned {
+			fmt.Printf("%s✓ %s: %s%s\n", colorGreen, tuning.Name, tuning.Current, colorReset)
+			continue
+		}
+		fmt.Printf("%s✗ %s: %s (recommended: %s)%s\n", colorYellow, tuning.Name, tuning.Current, tuning.Recommended, colorReset)
+		fmt.Printf("\t%s\n", tuning.Description)
+		if tuning.ApplyScript == "" {
+			fmt.Println("\tThis has to be changed by hand.")
+			continue
+		}
+		pending = append(pending, tuning)
+	}
+	fmt.Println()
+
+	if len(pending) == 0 {
+		fmt.Println("There are no changes to apply.")
+		return nil
+	}
+	if !c.Bool("apply") {
+		fmt.Println("These commands would apply the recommendations:")
+		for _, tuning := range pending {
+			fmt.Printf("\t%s\n", tuning.ApplyScript)
+		}
+		fmt.Println("\nRun `rocketpool service tune-host --apply` to apply them.")
+		return nil
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Do you want to apply the %d recommended changes? They'll be run with root privileges, and can be reverted with `rocketpool service tune-host --undo`.", len(pending)))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Apply the changes, recording how to revert each one that succeeds
+	undoScripts := []string{}
+	for _, tuning := range pending {
+		fmt.Printf("Applying the recommended %s...\n", tuning.Name)
+		if err := rp.RunHostScript(tuning.ApplyScript); err != nil {
+			fmt.Printf("%sCouldn't change the %s: %s%s\n", colorRed, tuning.Name, err.Error(), colorReset)
+			continue
+		}
+		undoScripts = append([]string{fmt.Sprintf("# %s\n%s\n", tuning.Name, tuning.UndoScript)}, undoScripts...)
+	}
+	if len(undoScripts) == 0 {
+		return nil
+	}
+
+	// Add them ahead of any earlier changes so they're reverted first
+	previous, err := os.ReadFile(undoPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error reading the host tuning undo file: %w", err)
+	}
+	contents := hostTuningUndoHeader + strings.Join(undoScripts, "") + strings.TrimPrefix(string(previous), hostTuningUndoHeader)
+	if err := os.WriteFile(undoPath, []byte(contents), 0600); err != nil {
+		return fmt.Errorf("error writing the host tuning undo file: %w", err)
+	}
+	fmt.Printf("%sApplied %d changes. Run `rocketpool service tune-host --undo` to revert them.%s\n", colorGreen, len(undoScripts), colorReset)
+	return nil
+
+}
+
+// Revert the changes made by tune-host
+func undoHostTuning(c *cli.Context, rp *rocketpool.Client, undoPath string) error {
+
+	contents, err := os.ReadFile(undoPath)
+	if os.IsNotExist(err) {
+		fmt.Println("There are no host tuning changes to revert.")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading the host tuning undo file: %w", err)
+	}
+
+	fmt.Printf("These commands will revert the host tuning changes:\n\n%s\n", strings.TrimPrefix(string(contents), hostTuningUndoHeader))
+	if !(c.Bool("yes") || cliutils.Confirm("Do you want to run them with root privileges?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	if err := rp.RunHostScript(string(contents)); err != nil {
+		return fmt.Errorf("error reverting the host tuning changes: %w", err)
+	}
+	if err := os.Remove(undoPath); err != nil {
+		return fmt.Errorf("error removing the host tuning undo file: %w", err)
+	}
+	fmt.Printf("%sThe host tuning changes have been reverted.%s\n", colorGreen, colorReset)
+	return nil
+
+}
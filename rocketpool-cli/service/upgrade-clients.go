@@ -0,0 +1,335 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/updates"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Settings
+const (
+	// How many lines of each changelog to print before pointing at the full release notes
+	changelogPreviewLines int = 15
+
+	// How often to check an upgraded container while waiting for it to prove it's healthy
+	upgradeHealthCheckInterval = 10 * time.Second
+
+	// How often to check the clients and the validators' attestations during a canary upgrade's bake period
+	canaryCheckInterval = 1 * time.Minute
+)
+
+// Check for new releases of the clients and the Smartnode, and print their changelogs
+func printUpdateCheck(cfg *config.RocketPoolConfig, smartnodeVersion string) {
+	fmt.Println()
+	fmt.Println("Checking for updates...")
+	results := updates.CheckForUpdates(cfg, smartnodeVersion)
+	available := 0
+	for _, update := range results {
+		if update.Error != "" {
+			fmt.Printf("%s%s: couldn't check for updates: %s%s\n", colorYellow, update.Name, update.Error, colorReset)
+			continue
+		}
+		if !update.UpdateAvailable {
+			fmt.Printf("%s: %s is the latest release.\n", update.Name, update.CurrentTag)
+			continue
+		}
+
+		available++
+		fmt.Printf("\n%s%s: %s is available%s (currently using %s, released %s)\n", colorGreen, update.Name, update.LatestVersion, colorReset, update.CurrentTag, update.ReleaseTime.Local().Format(time.RFC822))
+		lines := strings.Split(update.Changelog, "\n")
+		for i, line := range lines {
+			if i == changelogPreviewLines {
+				fmt.Printf("\t... (%d more lines)\n", len(lines)-changelogPreviewLines)
+				break
+			}
+			fmt.Printf("\t%s\n", strings.TrimRight(line, "\r"))
+		}
+		fmt.Printf("\tFull release notes: %s\n", update.ReleaseUrl)
+	}
+
+	fmt.Println()
+	if available == 0 {
+		fmt.Println("Everything is up to date.")
+	} else {
+		fmt.Println("Run `rocketpool service upgrade-clients` to upgrade the clients. Smartnode upgrades still use the installer; see the release notes for instructions.")
+	}
+}
+
+// Upgrade the clients to their latest releases, rolling back any that don't stay healthy
+func upgradeClients(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+	if cfg.IsNativeMode {
+		return fmt.Errorf("Client upgrades are managed by you in Native Mode.")
+	}
+
+	// Find the upgrades
+	fmt.Println("Checking for updates...")
+	images := updates.GetManagedImages(cfg)
+	upgrades := []updates.ImageUpdate{}
+	targets := []updates.ManagedImage{}
+	for _, image := range images {
+		update := updates.CheckImage(image)
+		if update.Error != "" {
+			fmt.Printf("%s%s: couldn't check for updates: %s%s\n", colorYellow, update.Name, update.Error, colorReset)
+			continue
+		}
+		if update.UpdateAvailable {
+			upgrades = append(upgrades, update)
+			targets = append(targets, image)
+		}
+	}
+	if len(upgrades) == 0 {
+		fmt.Println("All of your clients are already on their latest releases.")
+		return nil
+	}
+
+	// Confirm
+	fmt.Println("\nThe following clients will be upgraded:")
+	for _, update := range upgrades {
+		fmt.Printf("\t%s: %s -> %s\n", update.Name, update.CurrentTag, update.LatestTag)
+	}
+	fmt.Println()
+	if doppelgangerEnabled, err := cfg.IsDoppelgangerEnabled(); err == nil && doppelgangerEnabled {
+		fmt.Printf("%sNOTE: You have Doppelganger Protection enabled, so your validators will miss a few attestations if the validator client restarts.%s\n\n", colorYellow, colorReset)
+	}
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to upgrade these clients and restart their containers?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Apply the new tags
+	for i, image := range targets {
+		image.Param.Value = upgrades[i].LatestTag
+	}
+	if err := rp.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("Error saving the new container tags: %w", err)
+	}
+	fmt.Println("Starting the upgraded containers...")
+	if err := rp.StartService(getComposeFiles(c)); err != nil {
+		fmt.Printf("%sError starting the upgraded containers: %s%s\n", colorRed, err.Error(), colorReset)
+		return rollbackClients(c, rp, cfg, targets, upgrades, upgrades)
+	}
+
+	// Watch the upgraded containers
+	prefix, err := rp.GetContainerPrefix()
+	if err != nil {
+		return fmt.Errorf("Error getting container prefix: %w", err)
+	}
+	healthPeriod := time.Duration(c.Uint64("health-check-time")) * time.Second
+	fmt.Printf("Waiting %s to make sure the upgraded containers stay up...\n", healthPeriod)
+	failed := []updates.ImageUpdate{}
+	unhealthy := map[int]bool{}
+	for start := time.Now(); time.Since(start) < healthPeriod; time.Sleep(upgradeHealthCheckInterval) {
+		for i, update := range upgrades {
+			if unhealthy[i] {
+				continue
+			}
+			status, err := rp.GetDockerStatus(prefix + update.ContainerSuffix)
+			if err != nil || status != "running" {
+				fmt.Printf("%s%s isn't running after the upgrade (status: %s).%s\n", colorRed, update.Name, status, colorReset)
+				unhealthy[i] = true
+				failed = append(failed, update)
+			}
+		}
+	}
+
+	// Make sure the clients still answer through the API
+	clientStatus, err := rp.GetClientStatus()
+	if err != nil {
+		fmt.Printf("%sCouldn't get the client status after the upgrade: %s%s\n", colorYellow, err.Error(), colorReset)
+	} else {
+		for i, update := range upgrades {
+			if unhealthy[i] {
+				continue
+			}
+			working := true
+			switch update.ContainerSuffix {
+			case ExecutionContainerSuffix:
+				working = clientStatus.EcManagerStatus.PrimaryClientStatus.IsWorking
+			case BeaconContainerSuffix:
+				working = clientStatus.BcManagerStatus.PrimaryClientStatus.IsWorking
+			}
+			if !working {
+				fmt.Printf("%s%s is running but isn't responding to the Smartnode after the upgrade.%s\n", colorRed, update.Name, colorReset)
+				unhealthy[i] = true
+				failed = append(failed, update)
+			}
+		}
+	}
+
+	if len(failed) > 0 {
+		return rollbackClients(c, rp, cfg, targets, upgrades, failed)
+	}
+	fmt.Printf("%sAll of the upgraded clients are healthy.%s\n", colorGreen, colorReset)
+	if !c.Bool("canary") {
+		return nil
+	}
+
+	// Bake the upgrade; a bad attestation record can't be pinned on one client, so every upgrade is rolled back if it fails
+	if !bakeClients(c, rp, cfg, prefix, upgrades) {
+		return rollbackClients(c, rp, cfg, targets, upgrades, upgrades)
+	}
+	fmt.Printf("%sThe upgraded clients made it through the bake period within their error budgets, so the upgrade has been kept.%s\n", colorGreen, colorReset)
+	return nil
+
+}
+
+// Watch the sync health of the upgraded clients and the attestation performance of the node's validators during the bake period.
+// Returns false as soon as either error budget is exceeded.
+func bakeClients(c *cli.Context, rp *rocketpool.Client, cfg *config.RocketPoolConfig, prefix string, upgrades []updates.ImageUpdate) bool {
+
+	// Get the error budgets
+	bakePeriod := time.Duration(c.Uint64("bake-period")) * time.Minute
+	minEfficiency := c.Float64("min-efficiency")
+	if !c.IsSet("min-efficiency") {
+		minEfficiency = float64(cfg.Alertmanager.AttestationEfficiencyThreshold.Value.(uint64))
+	}
+	maxLowEpochs := c.Uint64("max-low-epochs")
+	if !c.IsSet("max-low-epochs") {
+		maxLowEpochs = cfg.Alertmanager.AttestationEfficiencyEpochs.Value.(uint64)
+	}
+	maxSyncFailures := c.Uint64("max-sync-failures")
+
+	// Attestations missed while the containers restarted are expected, so start with the next epoch.
+	// Only the current epoch is needed here, so ask for epochs that haven't happened yet.
+	performance, err := rp.NodeAttestationPerformance(math.MaxUint64)
+	if err != nil {
+		fmt.Printf("%sCouldn't get the current epoch to start the bake period: %s%s\n", colorRed, err.Error(), colorReset)
+		return false
+	}
+	nextEpoch := performance.CurrentEpoch + 1
+	if performance.ActiveValidators == 0 {
+		fmt.Printf("%sThe node doesn't have any active validators, so only the clients' sync health will be checked.%s\n", colorYellow, colorReset)
+	}
+
+	fmt.Printf("Baking the upgrade for %s. It will be rolled back if the clients fail %d sync checks, or if attestation efficiency is below %.1f%% for %d epochs in a row.\n", bakePeriod, maxSyncFailures, minEfficiency, maxLowEpochs)
+	syncFailures := uint64(0)
+	lowEpochs := uint64(0)
+	for start := time.Now(); time.Since(start) < bakePeriod; time.Sleep(canaryCheckInterval) {
+
+		// Check the sync health of the upgraded clients
+		problems := getCanarySyncProblems(rp, prefix, upgrades)
+		if len(problems) > 0 {
+			syncFailures++
+			for _, problem := range problems {
+				fmt.Printf("%sSync check %d of %d failed: %s.%s\n", colorYellow, syncFailures, maxSyncFailures, problem, colorReset)
+			}
+			if syncFailures >= maxSyncFailures {
+				fmt.Printf("%sThe upgraded clients failed too many sync checks.%s\n", colorRed, colorReset)
+				return false
+			}
+			continue
+		}
+
+		// Check the attestations in the epochs that have finished since the last check
+		if performance.ActiveValidators == 0 {
+			continue
+		}
+		performance, err = rp.NodeAttestationPerformance(nextEpoch)
+		if err != nil {
+			fmt.Printf("%sCouldn't get the attestation performance: %s%s\n", colorYellow, err.Error(), colorReset)
+			continue
+		}
+		for _, epoch := range performance.Epochs {
+			nextEpoch = epoch.Epoch + 1
+			if epoch.Duties == 0 {
+				continue
+			}
+			if epoch.Efficiency >= minEfficiency {
+				fmt.Printf("Epoch %d: %.1f%% attestation efficiency (%d of %d attestations included).\n", epoch.Epoch, epoch.Efficiency, epoch.Included, epoch.Duties)
+				lowEpochs = 0
+				continue
+			}
+			lowEpochs++
+			fmt.Printf("%sEpoch %d: %.1f%% attestation efficiency (%d of %d attestations included), %d of %d low epochs allowed.%s\n", colorYellow, epoch.Epoch, epoch.Efficiency, epoch.Included, epoch.Duties, lowEpochs, maxLowEpochs, colorReset)
+			for _, cause := range epoch.LikelyCauses {
+				fmt.Printf("\tLikely cause: %s.\n", cause)
+			}
+			if lowEpochs >= maxLowEpochs {
+				fmt.Printf("%sAttestation efficiency stayed below %.1f%% for too long.%s\n", colorRed, minEfficiency, colorReset)
+				return false
+			}
+		}
+
+	}
+	return true
+
+}
+
+// Check that the upgraded containers are still running, and that the upgraded clients are still synced
+func getCanarySyncProblems(rp *rocketpool.Client, prefix string, upgrades []updates.ImageUpdate) []string {
+	problems := []string{}
+	for _, update := range upgrades {
+		status, err := rp.GetDockerStatus(prefix + update.ContainerSuffix)
+		if err != nil || status != "running" {
+			problems = append(problems, fmt.Sprintf("%s isn't running (status: %s)", update.Name, status))
+		}
+	}
+	if len(problems) > 0 {
+		return problems
+	}
+
+	clientStatus, err := rp.GetClientStatus()
+	if err != nil {
+		return []string{fmt.Sprintf("couldn't get the client status: %s", err.Error())}
+	}
+	for _, update := range upgrades {
+		var status *api.ClientStatus
+		switch update.ContainerSuffix {
+		case ExecutionContainerSuffix:
+			status = &clientStatus.EcManagerStatus.PrimaryClientStatus
+		case BeaconContainerSuffix:
+			status = &clientStatus.BcManagerStatus.PrimaryClientStatus
+		default:
+			continue
+		}
+		if !status.IsWorking {
+			problems = append(problems, fmt.Sprintf("%s isn't responding: %s", update.Name, status.Error))
+		} else if !status.IsSynced {
+			problems = append(problems, fmt.Sprintf("%s has fallen out of sync (%.2f%%)", update.Name, status.SyncProgress*100))
+		}
+	}
+	return problems
+}
+
+// Put the previous tags back for the clients that failed their health checks, and restart them
+func rollbackClients(c *cli.Context, rp *rocketpool.Client, cfg *config.RocketPoolConfig, targets []updates.ManagedImage, upgrades []updates.ImageUpdate, failed []updates.ImageUpdate) error {
+	fmt.Println("Rolling back the clients that failed...")
+	for _, failure := range failed {
+		for i, update := range upgrades {
+			if update.Name == failure.Name && update.ContainerSuffix == failure.ContainerSuffix {
+				targets[i].Param.Value = update.CurrentTag
+				fmt.Printf("\t%s: %s -> %s\n", update.Name, update.LatestTag, update.CurrentTag)
+			}
+		}
+	}
+	if err := rp.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("Error saving the previous container tags: %w", err)
+	}
+	if err := rp.StartService(getComposeFiles(c)); err != nil {
+		return fmt.Errorf("Error restarting the rolled back containers: %w", err)
+	}
+	fmt.Printf("%sRolled back %d clients to their previous releases. Check their logs with `rocketpool service logs` to see why the upgrade failed.%s\n", colorYellow, len(failed), colorReset)
+	return nil
+}
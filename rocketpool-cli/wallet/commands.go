@@ -104,6 +104,18 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "address, a",
 						Usage: "If you are recovering a wallet that was not generated by the Smartnode and don't know the derivation path or index of it, enter the address here. The Smartnode will search through its library of paths and indices to try to find it.",
 					},
+					cli.BoolFlag{
+						Name:  "wizard, w",
+						Usage: "Run the guided disaster recovery wizard, which verifies each validator key against the Beacon Chain and imports slashing protection before loading the keys",
+					},
+					cli.StringFlag{
+						Name:  "slashing-protection",
+						Usage: "The path to an EIP-3076 slashing protection interchange file exported from your old node (only used with --wizard)",
+					},
+					cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "Only check that the mnemonic recovers this node's address and all of its minipools' validator keys, without touching the node wallet or keystores. With --address, checks against that address instead of the node wallet's.",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -113,6 +125,17 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					}
 
 					// Validate flags
+					if c.Bool("wizard") && c.Bool("skip-validator-key-recovery") {
+						return fmt.Errorf("the recovery wizard can't be used with --skip-validator-key-recovery")
+					}
+					if c.Bool("dry-run") && (c.Bool("wizard") || c.Bool("skip-validator-key-recovery")) {
+						return fmt.Errorf("--dry-run can't be used with --wizard or --skip-validator-key-recovery")
+					}
+					if c.String("address") != "" {
+						if _, err := cliutils.ValidateAddress("address", c.String("address")); err != nil {
+							return err
+						}
+					}
 					if c.String("password") != "" {
 						if _, err := cliutils.ValidateNodePassword("password", c.String("password")); err != nil {
 							return err
@@ -125,6 +148,12 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					}
 
 					// Run
+					if c.Bool("dry-run") {
+						return verifyRecovery(c)
+					}
+					if c.Bool("wizard") {
+						return recoverWalletWizard(c)
+					}
 					return recoverWallet(c)
 
 				},
@@ -148,6 +177,29 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "encrypt-validator-keys",
+				Usage:     "Encrypt your validator keystores into an archive with the node wallet password so they aren't stored on disk in plain form, or re-encrypt the archive after changing your password",
+				UsageText: "rocketpool wallet encrypt-validator-keys [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm encrypting the keys",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return encryptValidatorKeys(c)
+
+				},
+			},
+
 			{
 				Name:      "test-recovery",
 				Aliases:   []string{"t"},
@@ -213,6 +265,48 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "send-credential-changes",
+				Aliases:   []string{"cc"},
+				Usage:     "Change the withdrawal credentials of the node's vacant minipools from 0x00 (BLS) to their minipool addresses in batches, for migrating solo validators",
+				UsageText: "rocketpool wallet send-credential-changes [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "mnemonic, m",
+						Usage: "The mnemonic that the validators' keys were derived from",
+					},
+					cli.StringFlag{
+						Name:  "minipools, p",
+						Usage: "A comma-separated list of the minipool addresses to change, or 'all' for every minipool that is ready",
+					},
+					cli.UintFlag{
+						Name:  "batch-size, b",
+						Usage: "The number of changes to broadcast at once",
+						Value: 16,
+					},
+					cli.StringFlag{
+						Name:  "wait-timeout, t",
+						Usage: "How long to wait for each batch to land on the Beacon Chain (e.g. 30m)",
+						Value: "30m",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the changes",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return sendCredentialChanges(c)
+
+				},
+			},
+
 			{
 				Name:      "set-ens-name",
 				Aliases:   []string{"ens"},
@@ -231,6 +325,53 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "gas-wallet",
+				Aliases:   []string{"g"},
+				Usage:     "Manage the optional gas wallet, a separate hot account that pays for low-risk automated transactions instead of the node account",
+				UsageText: "rocketpool wallet gas-wallet [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "create, c",
+						Usage: "Generate a new key for the gas wallet",
+					},
+					cli.BoolFlag{
+						Name:  "import, i",
+						Usage: "Use an existing private key for the gas wallet",
+					},
+					cli.BoolFlag{
+						Name:  "delete, d",
+						Usage: "Remove the gas wallet's key from the node",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm deleting the gas wallet",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					selected := 0
+					for _, flag := range []string{"create", "import", "delete"} {
+						if c.Bool(flag) {
+							selected++
+						}
+					}
+					if selected > 1 {
+						return fmt.Errorf("Only one of --create, --import, and --delete can be used at a time")
+					}
+
+					// Run
+					return gasWallet(c)
+
+				},
+			},
+
 			{
 				Name:      "purge",
 				Usage:     fmt.Sprintf("%sDeletes your node wallet, your validator keys, and restarts your Validator Client while preserving your chain data. WARNING: Only use this if you want to stop validating with this machine!%s", colorRed, colorReset),
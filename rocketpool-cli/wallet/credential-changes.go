@@ -0,0 +1,177 @@
+package wallet
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// How often to check if a batch of credential changes has landed
+const credentialChangePollInterval time.Duration = 30 * time.Second
+
+func sendCredentialChanges(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check the flags
+	batchSize := c.Uint("batch-size")
+	if batchSize == 0 {
+		return fmt.Errorf("Invalid batch size - must be at least 1")
+	}
+	waitTimeout, err := time.ParseDuration(c.String("wait-timeout"))
+	if err != nil {
+		return fmt.Errorf("Invalid wait timeout '%s': %w", c.String("wait-timeout"), err)
+	}
+
+	fmt.Println("This will convert the withdrawal credentials of your vacant minipools' validators from the old 0x00 (BLS) value to their minipool addresses. This is meant for solo validator conversion **only**.")
+	fmt.Println()
+
+	// Get the mnemonic
+	mnemonic := ""
+	if c.IsSet("mnemonic") {
+		mnemonic = c.String("mnemonic")
+	} else {
+		mnemonic = PromptMnemonic()
+	}
+
+	// Get the validators
+	fmt.Println("Checking your vacant minipools, this may take a while...")
+	response, err := rp.GetCredentialChanges(mnemonic)
+	if err != nil {
+		return err
+	}
+	if len(response.Validators) == 0 {
+		fmt.Println("The node does not have any vacant minipools.")
+		return nil
+	}
+	eligible := []common.Address{}
+	for _, validator := range response.Validators {
+		switch {
+		case validator.RequiresChange && validator.KeyFound:
+			fmt.Printf("%s%s%s: ready to change (validator %s)\n", colorGreen, validator.MinipoolAddress.Hex(), colorReset, validator.ValidatorIndex)
+			eligible = append(eligible, validator.MinipoolAddress)
+		case validator.ChangeLanded:
+			fmt.Printf("%s: already changed\n", validator.MinipoolAddress.Hex())
+		default:
+			fmt.Printf("%s%s%s: cannot change, %s\n", colorYellow, validator.MinipoolAddress.Hex(), colorReset, validator.Reason)
+		}
+	}
+	fmt.Println()
+	if len(eligible) == 0 {
+		fmt.Println("None of the node's minipools are ready for a withdrawal credentials change.")
+		return nil
+	}
+
+	// Get the selected minipools
+	selected := eligible
+	selection := c.String("minipools")
+	if selection == "" {
+		selection = cliutils.Prompt("Please enter the minipool addresses to change (comma-separated), or 'all' for every minipool that is ready:", "^.+$", "Please enter a selection.")
+	}
+	if selection != "all" {
+		selected = []common.Address{}
+		for _, element := range strings.Split(selection, ",") {
+			address, err := cliutils.ValidateAddress("minipool address", strings.TrimSpace(element))
+			if err != nil {
+				return err
+			}
+			found := false
+			for _, candidate := range eligible {
+				if candidate == address {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf("Minipool %s is not ready for a withdrawal credentials change.", address.Hex())
+			}
+			selected = append(selected, address)
+		}
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("%sThis change is permanent.%s Are you sure you want to change the withdrawal credentials of %d validator(s) to their minipool addresses?", colorYellow, colorReset, len(selected)))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Send each batch and wait for it to land
+	failed := 0
+	for start := 0; start < len(selected); start += int(batchSize) {
+		end := start + int(batchSize)
+		if end > len(selected) {
+			end = len(selected)
+		}
+		batch := selected[start:end]
+
+		fmt.Printf("Broadcasting %d withdrawal credential change(s)... ", len(batch))
+		if _, err := rp.SendCredentialChanges(batch, mnemonic); err != nil {
+			fmt.Println("error!")
+			return err
+		}
+		fmt.Println("done!")
+
+		fmt.Println("Waiting for the changes to be included on the Beacon Chain...")
+		pending, err := waitForCredentialChanges(rp, mnemonic, batch, waitTimeout)
+		if err != nil {
+			return err
+		}
+		for _, address := range pending {
+			fmt.Printf("%sThe change for minipool %s was not included within %s.%s\n", colorYellow, address.Hex(), waitTimeout, colorReset)
+		}
+		failed += len(pending)
+	}
+
+	// Log & return
+	fmt.Println()
+	if failed == 0 {
+		fmt.Printf("%sAll %d withdrawal credential change(s) have landed on the Beacon Chain.%s\n", colorGreen, len(selected), colorReset)
+	} else {
+		fmt.Printf("%d of %d withdrawal credential change(s) have landed on the Beacon Chain. The rest may still be in the Beacon Chain's queue; you can check again later by re-running this command.\n", len(selected)-failed, len(selected))
+	}
+	return nil
+
+}
+
+// Wait for a batch of withdrawal credential changes to land, returning the minipools that are still pending
+func waitForCredentialChanges(rp *rocketpool.Client, mnemonic string, batch []common.Address, timeout time.Duration) ([]common.Address, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		time.Sleep(credentialChangePollInterval)
+		response, err := rp.GetCredentialChanges(mnemonic)
+		if err != nil {
+			return nil, err
+		}
+
+		landed := map[common.Address]api.CredentialChangeValidator{}
+		for _, validator := range response.Validators {
+			if validator.ChangeLanded {
+				landed[validator.MinipoolAddress] = validator
+			}
+		}
+		pending := []common.Address{}
+		for _, address := range batch {
+			if validator, exists := landed[address]; exists {
+				fmt.Printf("Minipool %s now has withdrawal credentials %s.\n", address.Hex(), validator.WithdrawalCredentials.Hex())
+			} else {
+				pending = append(pending, address)
+			}
+		}
+		batch = pending
+		if len(pending) == 0 || time.Now().After(deadline) {
+			return pending, nil
+		}
+	}
+}
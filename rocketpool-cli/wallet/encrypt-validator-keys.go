@@ -0,0 +1,62 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func encryptValidatorKeys(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Load the config
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet is not initialized.")
+		return nil
+	}
+	if !cfg.Smartnode.EncryptValidatorKeys.Value.(bool) {
+		fmt.Printf("%sNOTE: Validator key encryption is not enabled in the `rocketpool service config` TUI, so the node will not unlock or update this archive until you enable it.%s\n\n", colorYellow, colorReset)
+	}
+
+	// Prompt for confirmation
+	fmt.Println("This will encrypt your validator keystores into an archive with your node wallet password. If an archive already exists, it will be re-encrypted with your current password and a new key, so you can also use this after changing your password.")
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to encrypt your validator keys?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Encrypt the keys
+	response, err := rp.EncryptValidatorKeys()
+	if err != nil {
+		return err
+	}
+	if response.Rekeyed {
+		fmt.Printf("The validator key archive at %s was successfully re-encrypted.\n", response.ArchivePath)
+	} else {
+		fmt.Printf("Your validator keys were successfully encrypted into %s.\n", response.ArchivePath)
+	}
+	if !response.KeysInMemory {
+		fmt.Printf("\n%sYour unlocked validator keys are still stored on disk. Run `rocketpool service start` to move them into memory; they will be unlocked from the archive whenever the node starts.%s\n", colorYellow, colorReset)
+	}
+	return nil
+
+}
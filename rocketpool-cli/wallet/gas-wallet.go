@@ -0,0 +1,93 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func gasWallet(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the current state
+	status, err := rp.GasWalletStatus()
+	if err != nil {
+		return err
+	}
+
+	// Make the requested change
+	switch {
+	case c.Bool("create") || c.Bool("import"):
+		if status.Initialized {
+			return fmt.Errorf("The gas wallet has already been set up with the address %s. Delete it with `rocketpool wallet gas-wallet --delete` first if you want to replace it.", status.Address.Hex())
+		}
+		if c.Bool("create") {
+			response, err := rp.CreateGasWallet()
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Created the gas wallet with the address %s%s%s.\n", colorGreen, response.Address.Hex(), colorReset)
+		} else {
+			privateKey := cliutils.PromptPassword("Please enter the gas wallet's private key, in hex:", "^(0x)?[0-9a-fA-F]{64}$", "Invalid private key")
+			response, err := rp.ImportGasWallet(privateKey)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Imported the gas wallet with the address %s%s%s.\n", colorGreen, response.Address.Hex(), colorReset)
+		}
+		fmt.Println("Send it a small amount of ETH to pay for the transactions below, then restart the node daemon with `rocketpool service start` so it starts using it.")
+		fmt.Println()
+		if status, err = rp.GasWalletStatus(); err != nil {
+			return err
+		}
+
+	case c.Bool("delete"):
+		if !status.Initialized {
+			fmt.Println("The gas wallet hasn't been set up.")
+			return nil
+		}
+		if status.Balance.Sign() > 0 {
+			fmt.Printf("%sThe gas wallet still holds %.6f ETH. Deleting its key will make that ETH unrecoverable unless you have a copy of the key elsewhere.%s\n", colorRed, eth.WeiToEth(status.Balance), colorReset)
+		}
+		if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to delete the gas wallet %s? Its transactions will be paid by the node account again.", status.Address.Hex()))) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		if _, err := rp.DeleteGasWallet(); err != nil {
+			return err
+		}
+		fmt.Println("Deleted the gas wallet. Restart the node daemon with `rocketpool service start` so it stops using it.")
+		return nil
+	}
+
+	// Print the status and routing rules
+	if status.Initialized {
+		fmt.Printf("Gas wallet: %s\n", status.Address.Hex())
+		fmt.Printf("Balance:    %.6f ETH\n", eth.WeiToEth(status.Balance))
+	} else {
+		fmt.Println("The gas wallet hasn't been set up, so the node account pays for all of its transactions.")
+		fmt.Println("Set one up with `rocketpool wallet gas-wallet --create` or `--import`.")
+	}
+	fmt.Println()
+	fmt.Println("Transactions paid by the gas wallet once it's set up:")
+	for _, route := range status.Routes {
+		if route.UsesGasWallet {
+			fmt.Printf("\t%s%s%s (%s)\n", colorGreen, route.Description, colorReset, route.Reason)
+		}
+	}
+	fmt.Println("Transactions that always come from the node account:")
+	for _, route := range status.Routes {
+		if !route.UsesGasWallet {
+			fmt.Printf("\t%s%s%s (%s)\n", colorYellow, route.Description, colorReset, route.Reason)
+		}
+	}
+	return nil
+
+}
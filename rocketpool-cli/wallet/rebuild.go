@@ -70,6 +70,9 @@ func rebuildWallet(c *cli.Context) error {
 		for _, key := range response.ValidatorKeys {
 			fmt.Println(key.Hex())
 		}
+		if response.KeysLoaded {
+			fmt.Println("The validator keys were loaded into your Validator Client.")
+		}
 	} else {
 		fmt.Println("No validator keys were found.")
 	}
@@ -0,0 +1,267 @@
+package wallet
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// The parts of an EIP-3076 slashing protection interchange file that are checked before uploading it
+type slashingProtectionInterchange struct {
+	Metadata struct {
+		InterchangeFormatVersion string `json:"interchange_format_version"`
+		GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+	} `json:"metadata"`
+	Data []struct {
+		Pubkey string `json:"pubkey"`
+	} `json:"data"`
+}
+
+// Walk through a full disaster recovery: restore the node wallet, regenerate and verify every minipool's validator key,
+// import the old node's slashing protection, and only then load the keys into the Validator Client
+func recoverWalletWizard(c *cli.Context) error {
+
+	// Get RP client
+	rp, ready, err := rocketpool.NewClientFromCtx(c).WithStatus()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Load the config
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if status.WalletInitialized {
+		fmt.Println("The node wallet is already initialized. If you want to rebuild its validator keys, use `rocketpool wallet rebuild` instead.")
+		return nil
+	}
+	if !ready {
+		fmt.Printf("%sEth Clients are not available.%s The recovery wizard needs them to find and verify your validator keys, so please wait until they are synced and ready.\n", colorYellow, colorReset)
+		fmt.Println("If you only want to recover the node wallet now, use `rocketpool wallet recover --skip-validator-key-recovery`.")
+		return nil
+	}
+
+	fmt.Println("This wizard will restore your node from its mnemonic in five steps:")
+	fmt.Println("\t1. Restore the node wallet")
+	fmt.Println("\t2. Confirm your old node has stopped validating")
+	fmt.Println("\t3. Regenerate the validator keys for every minipool and verify them against the Beacon Chain")
+	fmt.Println("\t4. Import the slashing protection database from your old node")
+	fmt.Println("\t5. Load the verified keys into your Validator Client")
+	fmt.Println()
+
+	// Step 1: restore the node wallet
+	fmt.Printf("%sStep 1: restore the node wallet%s\n", colorGreen, colorReset)
+	if !status.PasswordSet {
+		var password string
+		if c.String("password") != "" {
+			password = c.String("password")
+		} else {
+			password = promptPassword()
+		}
+		if _, err := rp.SetPassword(password); err != nil {
+			return err
+		}
+	}
+	var mnemonic string
+	if c.String("mnemonic") != "" {
+		mnemonic = c.String("mnemonic")
+	} else {
+		mnemonic = PromptMnemonic()
+	}
+	mnemonic = strings.TrimSpace(mnemonic)
+
+	var nodeAddress common.Address
+	if c.String("address") != "" {
+		address := common.HexToAddress(c.String("address"))
+		fmt.Printf("Searching for the derivation path and index for wallet %s...\nNOTE: this may take several minutes depending on how large your wallet's index is.\n", address.Hex())
+		response, err := rp.SearchAndRecoverWallet(mnemonic, address, true)
+		if err != nil {
+			return err
+		}
+		nodeAddress = response.AccountAddress
+	} else {
+		response, err := rp.RecoverWallet(mnemonic, true, c.String("derivation-path"), c.Uint("wallet-index"))
+		if err != nil {
+			return err
+		}
+		nodeAddress = response.AccountAddress
+	}
+	fmt.Printf("The node wallet was recovered with the address %s%s%s.\n", colorGreen, nodeAddress.Hex(), colorReset)
+	if !cliutils.Confirm("Is this the node address you expected?") {
+		fmt.Println("Please run `rocketpool wallet purge` to remove the recovered wallet, then run the wizard again with the correct mnemonic, derivation path, or wallet index.")
+		return nil
+	}
+	fmt.Println()
+
+	// Step 2: make sure the old node has stopped
+	fmt.Printf("%sStep 2: confirm your old node has stopped validating%s\n", colorGreen, colorReset)
+	fmt.Printf("%sIf your old node, or any other machine with these validator keys, is still running, loading them here WILL RESULT IN YOUR VALIDATORS BEING SLASHED.%s\n", colorRed, colorReset)
+	doppelgangerEnabled, err := cfg.IsDoppelgangerEnabled()
+	if err == nil && !doppelgangerEnabled {
+		fmt.Printf("%sDoppelganger detection is disabled, so your Validator Client won't check for another machine attesting with these keys before it starts. Consider enabling it with `rocketpool service config` before continuing.%s\n", colorYellow, colorReset)
+	}
+	if !cliutils.Confirm("Please confirm that your old node is permanently shut down (or its validator keys have been deleted), and that you have checked on a Beacon Chain explorer such as https://beaconcha.in that your validators have missed at least two attestations since then.") {
+		fmt.Println("Cancelled. Your node wallet has been recovered; once your old node is offline, run `rocketpool wallet rebuild` to regenerate your validator keys.")
+		return nil
+	}
+	fmt.Println()
+
+	// Step 3: regenerate and verify the validator keys
+	fmt.Printf("%sStep 3: regenerate and verify your validator keys%s\n", colorGreen, colorReset)
+	customKeyPasswordFile, err := promptForCustomKeyPasswords(rp, cfg, false)
+	if err != nil {
+		return err
+	}
+	if customKeyPasswordFile != "" {
+		defer func() {
+			err := deleteCustomKeyPasswordFile(customKeyPasswordFile)
+			if err != nil {
+				fmt.Printf("*** WARNING ***\nAn error occurred while removing the custom keystore password file: %s\n\nThis file contains the passwords to your custom validator keys.\nYou *must* delete it manually as soon as possible so nobody can read it.\n\nThe file is located here:\n\n\t%s\n\n", err.Error(), customKeyPasswordFile)
+			}
+		}()
+	}
+	fmt.Println("Scanning your minipools and regenerating their validator keys...")
+	recoverResponse, err := rp.RecoverValidatorKeys()
+	if err != nil {
+		return err
+	}
+	if len(recoverResponse.Validators) == 0 {
+		fmt.Println("Your node doesn't have any minipools with validator keys, so the recovery is complete.")
+		return nil
+	}
+	verifiedCount := printRecoveryValidators(recoverResponse.Validators)
+	fmt.Println()
+	if verifiedCount == 0 {
+		fmt.Printf("%sNone of your validator keys passed verification, so none of them will be loaded. Please review the issues above.%s\n", colorRed, colorReset)
+		return nil
+	}
+
+	// Step 4: import the old node's slashing protection
+	fmt.Printf("%sStep 4: import your slashing protection database%s\n", colorGreen, colorReset)
+	slashingProtectionPath := c.String("slashing-protection")
+	if slashingProtectionPath == "" && cliutils.Confirm("Do you have a slashing protection database (an EIP-3076 interchange file) exported from your old node's Validator Client?") {
+		slashingProtectionPath = cliutils.Prompt("Please enter the path to the slashing protection file:", "^.+$", "Please enter a path")
+	}
+	if slashingProtectionPath != "" {
+		uploadPath, err := uploadSlashingProtection(cfg, slashingProtectionPath)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			err := os.Remove(uploadPath)
+			if err != nil && !os.IsNotExist(err) {
+				fmt.Printf("WARNING: couldn't remove the uploaded slashing protection file at %s: %s\n", uploadPath, err.Error())
+			}
+		}()
+	} else {
+		fmt.Printf("%sWithout a slashing protection database, your Validator Client can't tell which duties your old node already signed. Waiting until your validators have missed at least two attestations (as confirmed in step 2) protects you from this.%s\n", colorYellow, colorReset)
+	}
+	fmt.Println()
+
+	// Step 5: load the keys
+	fmt.Printf("%sStep 5: load your validator keys%s\n", colorGreen, colorReset)
+	if !cliutils.Confirm(fmt.Sprintf("Are you ready to start validating with %d verified validator keys?", verifiedCount)) {
+		fmt.Println("Cancelled. Your keys have been regenerated but not loaded; they will be loaded the next time your Validator Client restarts.")
+		return nil
+	}
+	loadResponse, err := rp.LoadRecoveredValidatorKeys()
+	if err != nil {
+		return err
+	}
+	if loadResponse.SlashingProtectionImported {
+		fmt.Println("Imported the slashing protection database.")
+	}
+	if loadResponse.Restarted {
+		fmt.Printf("Restarted the Validator Client to load %d validator keys.\n", len(loadResponse.LoadedKeys))
+	} else {
+		fmt.Printf("Loaded %d validator keys into the Validator Client.\n", len(loadResponse.LoadedKeys))
+	}
+	if len(loadResponse.SkippedKeys) > 0 {
+		fmt.Printf("%sSkipped %d keys that didn't pass verification:%s\n", colorYellow, len(loadResponse.SkippedKeys), colorReset)
+		for _, pubkey := range loadResponse.SkippedKeys {
+			fmt.Printf("\t%s\n", pubkey.Hex())
+		}
+		fmt.Printf("%sNOTE: keys that failed verification during the recovery weren't saved, but if any of these were already on disk (for example, because they stopped passing verification since step 3), your Validator Client will load them the next time it restarts. Delete them with `rocketpool wallet purge` or resolve the issues before then.%s\n", colorYellow, colorReset)
+	}
+	fmt.Println("The recovery is complete.")
+	return nil
+
+}
+
+// Print the verification result of each recovered validator, returning how many passed
+func printRecoveryValidators(validators []api.RecoveryValidator) int {
+	verifiedCount := 0
+	for _, validator := range validators {
+		if validator.Verified {
+			verifiedCount++
+			fmt.Printf("%s✓%s Minipool %s (validator %s, %s)\n", colorGreen, colorReset, validator.MinipoolAddress.Hex(), validator.Index, validator.ValidatorStatus)
+			continue
+		}
+
+		issues := []string{}
+		if !validator.ExistsOnBeacon {
+			issues = append(issues, "the validator isn't on the Beacon Chain yet")
+		}
+		if validator.Slashed {
+			issues = append(issues, "the validator has been slashed")
+		}
+		if validator.ExistsOnBeacon && !validator.CredentialsMatch {
+			issues = append(issues, "its withdrawal credentials don't point to the minipool")
+		}
+		if !validator.KeyRecovered {
+			issues = append(issues, "its key couldn't be regenerated from this wallet")
+		}
+		fmt.Printf("%s✗%s Minipool %s (pubkey %s): %s\n", colorRed, colorReset, validator.MinipoolAddress.Hex(), validator.Pubkey.Hex(), strings.Join(issues, "; "))
+	}
+	fmt.Printf("\n%d of %d validator keys passed verification.\n", verifiedCount, len(validators))
+	return verifiedCount
+}
+
+// Check a slashing protection interchange file and copy it into the data folder for the daemon to import
+func uploadSlashingProtection(cfg *config.RocketPoolConfig, path string) (string, error) {
+	path, err := homedir.Expand(strings.TrimSpace(path))
+	if err != nil {
+		return "", fmt.Errorf("error expanding slashing protection path: %w", err)
+	}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("error reading slashing protection file [%s]: %w", path, err)
+	}
+	var interchange slashingProtectionInterchange
+	err = json.Unmarshal(contents, &interchange)
+	if err != nil {
+		return "", fmt.Errorf("[%s] is not a valid slashing protection interchange file: %w", path, err)
+	}
+	if interchange.Metadata.InterchangeFormatVersion == "" {
+		return "", fmt.Errorf("[%s] is not a valid slashing protection interchange file: it has no interchange format version", path)
+	}
+	fmt.Printf("The slashing protection file has records for %d validators.\n", len(interchange.Data))
+
+	uploadPath, err := homedir.Expand(cfg.Smartnode.GetSlashingProtectionImportPath(false))
+	if err != nil {
+		return "", fmt.Errorf("error expanding data directory: %w", err)
+	}
+	err = os.WriteFile(uploadPath, contents, 0600)
+	if err != nil {
+		return "", fmt.Errorf("error copying slashing protection file to [%s]: %w", uploadPath, err)
+	}
+	return uploadPath, nil
+}
@@ -158,3 +158,74 @@ func testRecovery(c *cli.Context) error {
 	return nil
 
 }
+
+// Check that a mnemonic backup still regenerates the node wallet and every minipool's validator key, without writing anything
+func verifyRecovery(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Load the config
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%sNOTE:\nThis is a dry run: your mnemonic will only be used in memory to check that it can recover this node. Your node wallet and validator keystores will not be modified.%s\n\n", colorYellow, colorReset)
+
+	// Prompt for mnemonic
+	var mnemonic string
+	if c.String("mnemonic") != "" {
+		mnemonic = c.String("mnemonic")
+	} else {
+		mnemonic = PromptMnemonic()
+	}
+	mnemonic = strings.TrimSpace(mnemonic)
+
+	// Check for custom keys
+	customKeyPasswordFile, err := promptForCustomKeyPasswords(rp, cfg, true)
+	if err != nil {
+		return err
+	}
+	if customKeyPasswordFile != "" {
+		defer func() {
+			err := deleteCustomKeyPasswordFile(customKeyPasswordFile)
+			if err != nil {
+				fmt.Printf("*** WARNING ***\nAn error occurred while removing the custom keystore password file: %s\n\nThis file contains the passwords to your custom validator keys.\nYou *must* delete it manually as soon as possible so nobody can read it.\n\nThe file is located here:\n\n\t%s\n\n", err.Error(), customKeyPasswordFile)
+			}
+		}()
+	}
+
+	// Verify the recovery
+	fmt.Println("Verifying the recovery of your node wallet and validator keys...")
+	var expectedAddress common.Address
+	if c.String("address") != "" {
+		expectedAddress = common.HexToAddress(c.String("address"))
+	}
+	response, err := rp.VerifyRecovery(mnemonic, expectedAddress, c.String("derivation-path"), c.Uint("wallet-index"))
+	if err != nil {
+		return err
+	}
+
+	// Log & return
+	if !response.AddressMatches {
+		fmt.Printf("%sThe mnemonic recovered the address %s, but your node's address is %s.%s\n", colorRed, response.AccountAddress.Hex(), response.NodeAddress.Hex(), colorReset)
+		fmt.Println("If you used a custom derivation path or wallet index when creating this wallet, specify it with --derivation-path and --wallet-index.")
+		return fmt.Errorf("recovery verification failed")
+	}
+	fmt.Printf("The mnemonic recovers your node address (%s).\n", response.NodeAddress.Hex())
+	if len(response.MissingKeys) > 0 {
+		fmt.Printf("%s%d of %d validator keys could not be regenerated:%s\n", colorRed, len(response.MissingKeys), len(response.ValidatorKeys), colorReset)
+		for _, pubkey := range response.MissingKeys {
+			fmt.Printf("\t%s\n", pubkey.Hex())
+		}
+		return fmt.Errorf("recovery verification failed")
+	}
+	fmt.Printf("%sAll %d validator keys for your minipools can be regenerated - your backup is good.%s\n", colorGreen, len(response.ValidatorKeys), colorReset)
+	return nil
+
+}
@@ -41,6 +41,23 @@ func promptPassword() string {
 	}
 }
 
+// Prompt for a password to encrypt an exported validator keystore with
+func PromptKeystorePassword() string {
+	for {
+		password := cliutils.PromptPassword(
+			"Please enter a password to encrypt the keystore with:",
+			fmt.Sprintf("^.{%d,}$", passwords.MinPasswordLength),
+			fmt.Sprintf("The password must be at least %d characters long. Please try again:", passwords.MinPasswordLength),
+		)
+		confirmation := cliutils.PromptPassword("Please confirm the password:", "^.*$", "")
+		if password == confirmation {
+			return password
+		}
+		fmt.Println("Password confirmation does not match.")
+		fmt.Println("")
+	}
+}
+
 // Prompt for a recovery mnemonic phrase
 func PromptMnemonic() string {
 	for {
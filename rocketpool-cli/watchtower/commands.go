@@ -0,0 +1,52 @@
+package watchtower
+
+import (
+	"github.com/urfave/cli"
+
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Register commands
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   "Inspect the Oracle DAO watchtower",
+		Subcommands: []cli.Command{
+
+			{
+				Name:      "ledger",
+				Aliases:   []string{"l"},
+				Usage:     "Show the balances, prices, and rewards trees the watchtower has submitted, and what happened to each submission",
+				UsageText: "rocketpool watchtower ledger [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "kind, k",
+						Usage: "Only show one kind of submission: 'balances', 'prices', or 'rewards-tree'",
+						Value: "all",
+					},
+					cli.UintFlag{
+						Name:  "limit, l",
+						Usage: "The number of the most recent submissions to show, or 0 for all of them",
+						Value: 20,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+					kind, err := cliutils.ValidateSubmissionKind("kind", c.String("kind"))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return getLedger(c, kind)
+
+				},
+			},
+		},
+	})
+}
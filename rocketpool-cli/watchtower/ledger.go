@@ -0,0 +1,77 @@
+package watchtower
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/submissions"
+)
+
+const (
+	colorReset  string = "\033[0m"
+	colorRed    string = "\033[31m"
+	colorGreen  string = "\033[32m"
+	colorYellow string = "\033[33m"
+)
+
+// Print the watchtower's record of the submissions it has made, newest first
+func getLedger(c *cli.Context, kind string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	response, err := rp.GetSubmissionLedger(kind)
+	if err != nil {
+		return err
+	}
+	if len(response.Submissions) == 0 {
+		fmt.Println("The watchtower hasn't recorded any submissions yet.")
+		return nil
+	}
+
+	shown := response.Submissions
+	limit := int(c.Uint("limit"))
+	if limit > 0 && len(shown) > limit {
+		shown = shown[:limit]
+		fmt.Printf("Showing the latest %d of %d submissions.\n\n", limit, len(response.Submissions))
+	}
+
+	for _, submission := range shown {
+		// Balances and prices are identified by their block, rewards trees by their interval
+		idLabel := "block"
+		if submission.Kind == submissions.Kind_RewardsTree {
+			idLabel = "interval"
+		}
+		fmt.Printf("%s for %s %d: %s\n", submission.Kind, idLabel, submission.ID, formatStatus(submission.Status))
+		fmt.Printf("\tTransaction: %s\n", submission.TxHash.Hex())
+		fmt.Printf("\tSubmitted:   %s\n", submission.SubmittedAt.Local().Format(time.RFC1123))
+		if !submission.ResolvedAt.IsZero() {
+			fmt.Printf("\tResolved:    %s\n", submission.ResolvedAt.Local().Format(time.RFC1123))
+		}
+		if submission.Block != 0 {
+			fmt.Printf("\tIncluded in: block %d\n", submission.Block)
+		}
+		if submission.Result != "" {
+			fmt.Printf("\tResult:      %s\n", submission.Result)
+		}
+		fmt.Println()
+	}
+	return nil
+
+}
+
+// Color a submission's status by how it turned out
+func formatStatus(status submissions.Status) string {
+	switch status {
+	case submissions.Status_Succeeded:
+		return fmt.Sprintf("%s%s%s", colorGreen, status, colorReset)
+	case submissions.Status_Pending:
+		return fmt.Sprintf("%s%s%s", colorYellow, status, colorReset)
+	default:
+		return fmt.Sprintf("%s%s%s", colorRed, status, colorReset)
+	}
+}
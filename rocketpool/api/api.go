@@ -59,6 +59,13 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 		Subcommands: []cli.Command{},
 	}
 
+	// Preview the transactions each command estimates gas for, so the CLI can show them before asking for confirmation
+	command.Before = func(c *cli.Context) error {
+		services.EnableTransactionPreviews()
+		api.SetTransactionPreviewSource(services.GetTransactionPreviews)
+		return nil
+	}
+
 	// Don't show help message for api errors because of JSON serialisation
 	command.OnUsageError = func(context *cli.Context, err error, isSubcommand bool) error {
 		return err
@@ -1,7 +1,6 @@
 package minipool
 
 import (
-	"bytes"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -9,7 +8,6 @@ import (
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/urfave/cli"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
-	util "github.com/wealdtech/go-eth2-util"
 
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
@@ -99,23 +97,10 @@ func canChangeWithdrawalCreds(c *cli.Context, minipoolAddress common.Address, mn
 	}
 
 	// Get the index for this validator based on the mnemonic
-	index := uint(0)
 	validatorKeyPath := validator.ValidatorKeyPath
-	var validatorKey *eth2types.BLSPrivateKey
-	for index < validatorLimit {
-		key, err := validator.GetPrivateKey(mnemonic, index, validatorKeyPath)
-		if err != nil {
-			return nil, fmt.Errorf("error deriving key for index %d: %w", index, err)
-		}
-		candidatePubkey := key.PublicKey().Marshal()
-		if bytes.Equal(pubkey[:], candidatePubkey) {
-			validatorKey = key
-			break
-		}
-		index++
-	}
-	if validatorKey == nil {
-		return nil, fmt.Errorf("couldn't find the validator key for this mnemonic after %d tries", validatorLimit)
+	_, index, err := validator.FindValidatorKey(mnemonic, pubkey, validatorKeyPath, validatorLimit)
+	if err != nil {
+		return nil, err
 	}
 
 	// Get the withdrawal creds from this index
@@ -123,10 +108,7 @@ func canChangeWithdrawalCreds(c *cli.Context, minipoolAddress common.Address, mn
 	if err != nil {
 		return nil, err
 	}
-	withdrawalPubkey := withdrawalKey.PublicKey().Marshal()
-	withdrawalPubkeyHashBytes := util.SHA256(withdrawalPubkey) // Withdrawal creds use sha256, *not* Keccak
-	withdrawalPubkeyHash := common.BytesToHash(withdrawalPubkeyHashBytes)
-	withdrawalPubkeyHash[0] = 0x00 // BLS prefix
+	withdrawalPubkeyHash := validator.GetBlsWithdrawalCredentials(withdrawalKey)
 
 	// Make sure they match what's on Beacon
 	if beaconStatus.WithdrawalCredentials != withdrawalPubkeyHash {
@@ -167,23 +149,10 @@ func changeWithdrawalCreds(c *cli.Context, minipoolAddress common.Address, mnemo
 	}
 
 	// Get the index for this validator based on the mnemonic
-	index := uint(0)
 	validatorKeyPath := validator.ValidatorKeyPath
-	var validatorKey *eth2types.BLSPrivateKey
-	for index < validatorLimit {
-		key, err := validator.GetPrivateKey(mnemonic, index, validatorKeyPath)
-		if err != nil {
-			return nil, fmt.Errorf("error deriving key for index %d: %w", index, err)
-		}
-		candidatePubkey := key.PublicKey().Marshal()
-		if bytes.Equal(pubkey[:], candidatePubkey) {
-			validatorKey = key
-			break
-		}
-		index++
-	}
-	if validatorKey == nil {
-		return nil, fmt.Errorf("couldn't find the validator key for this mnemonic after %d tries", validatorLimit)
+	_, index, err := validator.FindValidatorKey(mnemonic, pubkey, validatorKeyPath, validatorLimit)
+	if err != nil {
+		return nil, err
 	}
 
 	// Get the withdrawal creds from this index
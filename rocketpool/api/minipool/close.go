@@ -17,6 +17,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
 )
@@ -391,7 +392,45 @@ func closeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.CloseMi
 		response.TxHash = hash
 	}
 
+	// Remove the key from the Validator Client now that it can't perform any more duties
+	response.KeyRemoved, err = removeWithdrawnValidatorKey(c, rp, minipoolAddress)
+	if err != nil {
+		return nil, err
+	}
+
 	// Return response
 	return &response, nil
 
 }
+
+// Remove a minipool's validator key from the Validator Client with the keymanager API, if it's enabled and the validator has been fully withdrawn.
+// The keystores on disk are left alone so the key can still be recovered.
+func removeWithdrawnValidatorKey(c *cli.Context, rp *rocketpool.RocketPool, minipoolAddress common.Address) (bool, error) {
+	km, err := services.GetKeymanager(c)
+	if err != nil || km == nil {
+		return false, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return false, err
+	}
+
+	pubkey, err := minipool.GetMinipoolPubkey(rp, minipoolAddress, nil)
+	if err != nil {
+		return false, err
+	}
+	status, err := bc.GetValidatorStatus(pubkey, nil)
+	if err != nil {
+		return false, err
+	}
+	if status.Status != beacon.ValidatorState_WithdrawalDone {
+		return false, nil
+	}
+
+	results, _, err := km.DeleteKeys([]types.ValidatorPubkey{pubkey})
+	if err != nil {
+		// Closing the minipool matters more than tidying up the Validator Client
+		return false, nil
+	}
+	return len(results) == 1 && results[0].Status == keymanager.KeyStatus_Deleted, nil
+}
@@ -252,6 +252,45 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "get-rewards-ledger",
+				Usage:     "Get the tracked withdrawals and distributions of the node's minipools, reconciled against their balances",
+				UsageText: "rocketpool api minipool get-rewards-ledger",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getRewardsLedger(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-exit-estimate",
+				Usage:     "Estimate when a minipool's exit would be processed and when its balance would be withdrawn",
+				UsageText: "rocketpool api minipool get-exit-estimate minipool-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getExitEstimate(c, minipoolAddress))
+					return nil
+
+				},
+			},
 
 			{
 				Name:      "get-minipool-close-details-for-node",
@@ -336,6 +375,49 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "get-delegate-upgrade-campaign",
+				Usage:     "Survey the node's minipools for outdated delegates, and get the progress of the delegate upgrade campaign",
+				UsageText: "rocketpool api minipool get-delegate-upgrade-campaign",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getDelegateUpgradeCampaign(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "set-auto-upgrade-delegate",
+				Usage:     "Opt minipools in or out of automatic delegate upgrades",
+				UsageText: "rocketpool api minipool set-auto-upgrade-delegate minipool-addresses enabled",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					minipoolAddresses, err := cliutils.ValidateAddresses("minipool addresses", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					enabled, err := cliutils.ValidateBool("enabled", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(setAutoUpgradeDelegate(c, minipoolAddresses, enabled))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "can-delegate-rollback",
 				Usage:     "Check whether the minipool delegate can be rolled back",
@@ -542,6 +624,32 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "export-deposit-data",
+				Usage:     "Create the deposit data and keystore for a minipool's validator, so it can be run by a third party",
+				UsageText: "rocketpool api minipool export-deposit-data minipool-address keystore-password",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					password, err := cliutils.ValidateNodePassword("keystore password", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(exportMinipoolDepositData(c, minipoolAddress, password))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "can-begin-reduce-bond-amount",
 				Usage:     "Check whether the minipool can begin the bond reduction process",
@@ -770,6 +878,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "get-stuck-minipools",
+				Usage:     "Find the node's minipools that are stuck in prelaunch or dissolved, and diagnose why",
+				UsageText: "rocketpool api minipool get-stuck-minipools",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getStuckMinipools(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "rescue-dissolved",
 				Usage:     "Rescue a dissolved minipool by depositing ETH for it to the Beacon deposit contract",
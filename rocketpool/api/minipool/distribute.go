@@ -1,7 +1,6 @@
 package minipool
 
 import (
-	"context"
 	"fmt"
 	"math/big"
 
@@ -13,6 +12,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
 )
@@ -23,6 +23,10 @@ func getDistributeBalanceDetails(c *cli.Context) (*api.GetDistributeBalanceDetai
 	if err := services.RequireNodeRegistered(c); err != nil {
 		return nil, err
 	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -31,6 +35,10 @@ func getDistributeBalanceDetails(c *cli.Context) (*api.GetDistributeBalanceDetai
 	if err != nil {
 		return nil, err
 	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Response
 	response := api.GetDistributeBalanceDetailsResponse{}
@@ -40,21 +48,28 @@ func getDistributeBalanceDetails(c *cli.Context) (*api.GetDistributeBalanceDetai
 		return nil, fmt.Errorf("error getting node account: %w", err)
 	}
 
-	addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAccount.Address, nil)
+	// Get the node's network state, which only loads the node's own minipools
+	mgr, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating network state manager: %w", err)
+	}
+	networkState, _, err := mgr.GetHeadStateForNode(nodeAccount.Address, false)
 	if err != nil {
-		return nil, fmt.Errorf("error getting minipool addresses: %w", err)
+		return nil, fmt.Errorf("error getting network state: %w", err)
 	}
+	minipools := networkState.MinipoolDetailsByNode[nodeAccount.Address]
 
-	// Load details in batches
+	// Check the minipools in batches, since the distributable ones need gas estimates
 	zero := big.NewInt(0)
-	details := make([]api.MinipoolBalanceDistributionDetails, len(addresses))
-	for bsi := 0; bsi < len(addresses); bsi += MinipoolDetailsBatchSize {
+	eight := eth.EthToWei(8)
+	details := make([]api.MinipoolBalanceDistributionDetails, len(minipools))
+	for bsi := 0; bsi < len(minipools); bsi += MinipoolDetailsBatchSize {
 
 		// Get batch start & end index
 		msi := bsi
 		mei := bsi + MinipoolDetailsBatchSize
-		if mei > len(addresses) {
-			mei = len(addresses)
+		if mei > len(minipools) {
+			mei = len(minipools)
 		}
 
 		// Load details
@@ -62,62 +77,24 @@ func getDistributeBalanceDetails(c *cli.Context) (*api.GetDistributeBalanceDetai
 		for mi := msi; mi < mei; mi++ {
 			mi := mi
 			wg.Go(func() error {
-				address := addresses[mi]
+				mpd := minipools[mi]
+				address := mpd.MinipoolAddress
 				minipoolDetails := &details[mi]
 				minipoolDetails.Address = address
 				minipoolDetails.Balance = big.NewInt(0)
 				minipoolDetails.Refund = big.NewInt(0)
 				minipoolDetails.NodeShareOfBalance = big.NewInt(0)
-				mp, err := minipool.NewMinipool(rp, address, nil)
-				if err != nil {
-					return fmt.Errorf("error creating binding for minipool %s: %w", address.Hex(), err)
-				}
-				minipoolDetails.MinipoolVersion = mp.GetVersion()
+				minipoolDetails.MinipoolVersion = mpd.Version
 
 				// Ignore minipools that are too old
 				if minipoolDetails.MinipoolVersion < 3 {
 					minipoolDetails.CanDistribute = false
 					return nil
 				}
-
-				var wg2 errgroup.Group
-				wg2.Go(func() error {
-					var err error
-					minipoolDetails.Balance, err = rp.Client.BalanceAt(context.Background(), address, nil)
-					if err != nil {
-						return fmt.Errorf("error getting balance of minipool %s: %w", address.Hex(), err)
-					}
-					return nil
-				})
-				wg2.Go(func() error {
-					var err error
-					minipoolDetails.Refund, err = mp.GetNodeRefundBalance(nil)
-					if err != nil {
-						return fmt.Errorf("error getting refund balance of minipool %s: %w", address.Hex(), err)
-					}
-					return nil
-				})
-				wg2.Go(func() error {
-					var err error
-					minipoolDetails.Status, err = mp.GetStatus(nil)
-					if err != nil {
-						return fmt.Errorf("error getting status of minipool %s: %w", address.Hex(), err)
-					}
-					return nil
-				})
-				wg2.Go(func() error {
-					var err error
-					minipoolDetails.IsFinalized, err = mp.GetFinalised(nil)
-					if err != nil {
-						return fmt.Errorf("error getting finalized status of minipool %s: %w", address.Hex(), err)
-					}
-					return nil
-				})
-
-				// Wait for data
-				if err := wg2.Wait(); err != nil {
-					return err
-				}
+				minipoolDetails.Balance = mpd.Balance
+				minipoolDetails.Refund = mpd.NodeRefundBalance
+				minipoolDetails.Status = mpd.Status
+				minipoolDetails.IsFinalized = mpd.Finalised
 
 				// Can't distribute a minipool that's already finalized
 				if minipoolDetails.IsFinalized {
@@ -140,18 +117,13 @@ func getDistributeBalanceDetails(c *cli.Context) (*api.GetDistributeBalanceDetai
 					}
 
 					// Ignore minipools with an effective balance higher than v3 rewards-vs-exit cap
-					distributableBalance := big.NewInt(0).Sub(minipoolDetails.Balance, minipoolDetails.Refund)
-					eight := eth.EthToWei(8)
-					if distributableBalance.Cmp(eight) >= 0 {
+					if mpd.DistributableBalance.Cmp(eight) >= 0 {
 						minipoolDetails.CanDistribute = false
 						return nil
 					}
 
-					// Get the node share of the balance
-					minipoolDetails.NodeShareOfBalance, err = mp.CalculateNodeShare(distributableBalance, nil)
-					if err != nil {
-						return fmt.Errorf("error calculating node share for minipool %s: %w", address.Hex(), err)
-					}
+					// The node share of the distributable balance
+					minipoolDetails.NodeShareOfBalance = mpd.NodeShareOfBalance
 				} else if minipoolDetails.Status == types.Dissolved {
 					// Dissolved but non-finalized / non-closed minipools can just have the whole balance sent back to the NO
 					minipoolDetails.NodeShareOfBalance = minipoolDetails.Balance
@@ -162,6 +134,10 @@ func getDistributeBalanceDetails(c *cli.Context) (*api.GetDistributeBalanceDetai
 				}
 
 				// Get gas estimate
+				mp, err := minipool.NewMinipoolFromVersion(rp, address, mpd.Version, nil)
+				if err != nil {
+					return fmt.Errorf("error creating binding for minipool %s: %w", address.Hex(), err)
+				}
 				opts, err := w.GetNodeAccountTransactor()
 				if err != nil {
 					return err
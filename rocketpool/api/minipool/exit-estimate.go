@@ -0,0 +1,227 @@
+package minipool
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth2"
+)
+
+// The number of slots to search back through for a block with withdrawals in it
+const withdrawalSearchSlots uint64 = 32
+
+func getExitEstimate(c *cli.Context, minipoolAddress common.Address) (*api.MinipoolExitEstimateResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.MinipoolExitEstimateResponse{
+		MinipoolAddress: minipoolAddress,
+	}
+
+	// Validate minipool owner
+	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	if err := validateMinipoolOwner(mp, nodeAccount.Address); err != nil {
+		return nil, err
+	}
+
+	// Get the minipool's validator
+	pubkey, err := minipool.GetMinipoolPubkey(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	validator, err := bc.GetValidatorStatus(pubkey, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !validator.Exists {
+		return nil, fmt.Errorf("minipool %s does not have a validator on the Beacon Chain yet", minipoolAddress.Hex())
+	}
+	validatorIndex, err := strconv.ParseUint(validator.Index, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing validator index [%s]: %w", validator.Index, err)
+	}
+	response.ValidatorIndex = validator.Index
+	response.ValidatorStatus = validator.Status
+	if validator.Status == beacon.ValidatorState_WithdrawalDone {
+		response.WithdrawalComplete = true
+		return &response, nil
+	}
+
+	// Get the chain config and head
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, err
+	}
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return nil, err
+	}
+	headBlock, nextWithdrawalIndex, err := getNextWithdrawalValidatorIndex(bc)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the active validator count from the current committees
+	committees, err := bc.GetCommitteesForEpoch(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting committees: %w", err)
+	}
+	activeValidatorCount := uint64(0)
+	for i := 0; i < committees.Count(); i++ {
+		activeValidatorCount += uint64(len(committees.Validators(i)))
+	}
+	committees.Release()
+	response.ChurnLimit = eth2.GetChurnLimit(activeValidatorCount)
+
+	// Get the validators currently in the exit queue
+	exitingValidators, err := bc.GetValidatorStatusesByState([]beacon.ValidatorState{beacon.ValidatorState_ActiveExiting})
+	if err != nil {
+		return nil, err
+	}
+	pendingExitEpochs := make([]uint64, 0, len(exitingValidators))
+	for _, exitingValidator := range exitingValidators {
+		if exitingValidator.Index != validator.Index {
+			pendingExitEpochs = append(pendingExitEpochs, exitingValidator.ExitEpoch)
+		}
+	}
+	response.ExitQueueLength = uint64(len(pendingExitEpochs))
+
+	// Get the exit and withdrawable epochs, using the real ones if the validator has already exited
+	if validator.ExitEpoch != eth2.FarFutureEpoch {
+		response.AlreadyExiting = true
+		response.ExitEpoch = validator.ExitEpoch
+		response.WithdrawableEpoch = validator.WithdrawableEpoch
+	} else {
+		response.ExitEpoch = eth2.GetExitQueueEpoch(head.Epoch, activeValidatorCount, pendingExitEpochs)
+		response.WithdrawableEpoch = response.ExitEpoch + eth2.MinValidatorWithdrawabilityDelay
+	}
+
+	// Estimate when the withdrawal sweep will pick the validator up
+	validatorCount, err := getValidatorRegistrySize(bc, validatorIndex, nextWithdrawalIndex-1)
+	if err != nil {
+		return nil, err
+	}
+	withdrawableSlot := (response.WithdrawableEpoch - eth2Config.GenesisEpoch) * eth2Config.SlotsPerEpoch
+	withdrawalSlot, sweepSlots := eth2.GetWithdrawalSweepSlot(headBlock.Slot, nextWithdrawalIndex, validatorIndex, validatorCount, withdrawableSlot)
+	response.WithdrawalSlot = withdrawalSlot
+	response.SweepDuration = time.Duration(sweepSlots*eth2Config.SecondsPerSlot) * time.Second
+
+	// Convert everything to times
+	response.ExitTime = getEpochTime(eth2Config, response.ExitEpoch)
+	response.WithdrawableTime = getEpochTime(eth2Config, response.WithdrawableEpoch)
+	response.WithdrawalTime = time.Unix(int64(eth2Config.GenesisTime+withdrawalSlot*eth2Config.SecondsPerSlot), 0)
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the index of the next validator the withdrawal sweep will process, based on the most recent block with withdrawals in it
+func getNextWithdrawalValidatorIndex(bc beacon.Client) (beacon.BeaconBlock, uint64, error) {
+	headBlock, exists, err := bc.GetBeaconBlock("head")
+	if err != nil {
+		return beacon.BeaconBlock{}, 0, fmt.Errorf("error getting head block: %w", err)
+	}
+	if !exists {
+		return beacon.BeaconBlock{}, 0, fmt.Errorf("the Beacon Node did not return a head block")
+	}
+
+	block := headBlock
+	for i := uint64(1); len(block.Withdrawals) == 0; i++ {
+		if i > withdrawalSearchSlots || i > headBlock.Slot {
+			return beacon.BeaconBlock{}, 0, fmt.Errorf("could not find a block with withdrawals in the last %d slots", withdrawalSearchSlots)
+		}
+		block, exists, err = bc.GetBeaconBlock(strconv.FormatUint(headBlock.Slot-i, 10))
+		if err != nil {
+			return beacon.BeaconBlock{}, 0, fmt.Errorf("error getting block for slot %d: %w", headBlock.Slot-i, err)
+		}
+	}
+
+	lastWithdrawal := block.Withdrawals[len(block.Withdrawals)-1]
+	return headBlock, lastWithdrawal.ValidatorIndex + 1, nil
+}
+
+// Get the total number of validators in the Beacon Chain registry, starting from indices that are known to exist
+func getValidatorRegistrySize(bc beacon.Client, knownIndices ...uint64) (uint64, error) {
+	validatorExists := func(index uint64) (bool, error) {
+		status, err := bc.GetValidatorStatusByIndex(strconv.FormatUint(index, 10), nil)
+		if err != nil {
+			return false, fmt.Errorf("error getting validator %d: %w", index, err)
+		}
+		return status.Exists, nil
+	}
+
+	// Find an upper bound that doesn't exist yet
+	low := uint64(0)
+	for _, index := range knownIndices {
+		if index > low {
+			low = index
+		}
+	}
+	high := low*2 + 1
+	for {
+		exists, err := validatorExists(high)
+		if err != nil {
+			return 0, err
+		}
+		if !exists {
+			break
+		}
+		low = high
+		high *= 2
+	}
+
+	// Binary search for the first missing index
+	for high-low > 1 {
+		mid := low + (high-low)/2
+		exists, err := validatorExists(mid)
+		if err != nil {
+			return 0, err
+		}
+		if exists {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return high, nil
+}
+
+// Get the start time of an epoch
+func getEpochTime(eth2Config beacon.Eth2Config, epoch uint64) time.Time {
+	return time.Unix(int64(eth2Config.GenesisTime+(epoch-eth2Config.GenesisEpoch)*eth2Config.SecondsPerEpoch), 0)
+}
@@ -0,0 +1,111 @@
+package minipool
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+// Export the deposit data and keystore for an existing minipool's validator, so it can be run by a third party
+func exportMinipoolDepositData(c *cli.Context, minipoolAddress common.Address, password string) (*api.ExportDepositDataResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get eth2 config
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ExportDepositDataResponse{
+		MinipoolAddress: minipoolAddress,
+	}
+
+	// Make sure the minipool belongs to this node
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := mp.GetNodeAddress(nil)
+	if err != nil {
+		return nil, err
+	}
+	if owner != nodeAccount.Address {
+		return nil, fmt.Errorf("minipool %s belongs to node %s, not this one", minipoolAddress.Hex(), owner.Hex())
+	}
+
+	// Get the validator key
+	response.Pubkey, err = minipool.GetMinipoolPubkey(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	validatorKey, err := w.GetValidatorKeyByPubkey(response.Pubkey)
+	if err != nil {
+		return nil, err
+	}
+	if validatorKey == nil {
+		return nil, fmt.Errorf("the validator key for minipool %s (%s) isn't in the node wallet", minipoolAddress.Hex(), response.Pubkey.Hex())
+	}
+
+	// Get the deposits it was made with
+	withdrawalCredentials, err := minipool.GetMinipoolWithdrawalCredentials(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	depositType, err := minipool.GetMinipoolDepositType(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting deposit type for minipool %s: %w", minipoolAddress.Hex(), err)
+	}
+	var depositAmounts []uint64
+	switch depositType {
+	case rptypes.Full, rptypes.Half, rptypes.Empty:
+		depositAmounts = []uint64{uint64(16e9), uint64(16e9)} // 16 ETH in gwei, twice
+	case rptypes.Variable:
+		depositAmounts = []uint64{uint64(1e9), uint64(31e9)} // 1 ETH then 31 ETH in gwei
+	default:
+		return nil, fmt.Errorf("unknown deposit type %d for minipool %s", depositType, minipoolAddress.Hex())
+	}
+
+	// Create the deposit data and keystore; the derivation path of keys loaded from the keystores isn't known, so it's left out
+	networkName := string(cfg.Smartnode.Network.Value.(cfgtypes.Network))
+	response.DepositData, response.Keystore, err = validator.ExportMinipoolValidator(validatorKey, "", password, withdrawalCredentials, eth2Config, networkName, depositAmounts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
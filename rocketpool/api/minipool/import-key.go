@@ -88,6 +88,15 @@ func importKey(c *cli.Context, minipoolAddress common.Address, mnemonic string)
 		return nil, fmt.Errorf("error saving keystore: %w", err)
 	}
 
+	// Load it into the Validator Client if the keymanager API is enabled
+	km, err := services.GetKeymanager(c)
+	if err != nil {
+		return nil, err
+	}
+	if km != nil {
+		response.KeyLoaded = validator.ImportValidatorKeys(km, []*eth2types.BLSPrivateKey{validatorKey}, []string{derivationPath}, "") == nil
+	}
+
 	// Return response
 	return &response, nil
 }
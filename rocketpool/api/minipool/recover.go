@@ -0,0 +1,244 @@
+package minipool
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/settings/trustednode"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Find the node's minipools that are stuck before staking or after being dissolved, and work out why
+func getStuckMinipools(c *cli.Context) (*api.GetStuckMinipoolsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.GetStuckMinipoolsResponse{
+		Minipools: []api.StuckMinipoolDiagnosis{},
+	}
+
+	// Get minipool details
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	legacyMinipoolQueueAddress := cfg.Smartnode.GetV110MinipoolQueueAddress()
+	details, err := getNodeMinipoolDetails(rp, bc, nodeAccount.Address, &legacyMinipoolQueueAddress)
+	if err != nil {
+		return nil, err
+	}
+	stuck := []api.MinipoolDetails{}
+	pubkeys := []types.ValidatorPubkey{}
+	for _, mp := range details {
+		switch mp.Status.Status {
+		case types.Initialized, types.Prelaunch:
+		case types.Dissolved:
+			if mp.Finalised {
+				continue
+			}
+		default:
+			continue
+		}
+		stuck = append(stuck, mp)
+		pubkeys = append(pubkeys, mp.ValidatorPubkey)
+	}
+	if len(stuck) == 0 {
+		return &response, nil
+	}
+
+	// Get the Beacon Chain status of each one
+	beaconStatuses, err := bc.GetValidatorStatuses(pubkeys, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting beacon status of minipools: %w", err)
+	}
+
+	// Get the scrub period and launch timeout
+	scrubPeriodSeconds, err := trustednode.GetScrubPeriod(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	scrubPeriod := time.Duration(scrubPeriodSeconds) * time.Second
+	launchTimeout, err := protocol.GetMinipoolLaunchTimeout(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the time of the latest block
+	latestEth1Block, err := rp.Client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("Can't get the latest block time: %w", err)
+	}
+	latestBlockTime := time.Unix(int64(latestEth1Block.Time), 0)
+
+	// Diagnose each one
+	for _, mp := range stuck {
+		expectedCredentials, err := minipool.GetMinipoolWithdrawalCredentials(rp, mp.Address, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting withdrawal credentials for minipool %s: %w", mp.Address.Hex(), err)
+		}
+		key, _ := w.GetValidatorKeyByPubkey(mp.ValidatorPubkey)
+		version, err := getMinipoolVersion(rp, mp.Address)
+		if err != nil {
+			return nil, err
+		}
+		diagnosis := diagnoseStuckMinipool(mp, version, beaconStatuses[mp.ValidatorPubkey], expectedCredentials, key != nil, scrubPeriod, launchTimeout, latestBlockTime)
+		response.Minipools = append(response.Minipools, diagnosis)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get a minipool's delegate version
+func getMinipoolVersion(rp *rocketpool.RocketPool, minipoolAddress common.Address) (uint8, error) {
+	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
+	if err != nil {
+		return 0, err
+	}
+	return mp.GetVersion(), nil
+}
+
+// Work out why a minipool is stuck and what can be done about it
+func diagnoseStuckMinipool(mp api.MinipoolDetails, version uint8, beaconStatus beacon.ValidatorStatus, expectedCredentials common.Hash, hasKey bool, scrubPeriod time.Duration, launchTimeout time.Duration, now time.Time) api.StuckMinipoolDiagnosis {
+
+	diagnosis := api.StuckMinipoolDiagnosis{
+		Address:                       mp.Address,
+		MinipoolStatus:                mp.Status.Status,
+		MinipoolVersion:               version,
+		StatusTime:                    mp.Status.StatusTime,
+		HasValidatorKey:               hasKey,
+		BeaconState:                   beaconStatus.Status,
+		BeaconBalance:                 new(big.Int).Mul(new(big.Int).SetUint64(beaconStatus.Balance), big.NewInt(1e9)),
+		ExpectedWithdrawalCredentials: expectedCredentials,
+		ActualWithdrawalCredentials:   beaconStatus.WithdrawalCredentials,
+		RefundAvailable:               mp.RefundAvailable,
+		RefundBalance:                 mp.Node.RefundBalance,
+	}
+
+	switch mp.Status.Status {
+	case types.Initialized:
+		diagnosis.Problem = api.StuckMinipoolProblem_InQueue
+		diagnosis.Explanation = "The minipool is waiting in the deposit queue for ETH from the deposit pool. It will move to prelaunch once it's assigned, so there's nothing to do."
+		diagnosis.Remediation = api.StuckMinipoolRemediation_Wait
+
+	case types.Prelaunch:
+		launchDeadline := mp.Status.StatusTime.Add(launchTimeout)
+		scrubEnd := mp.Status.StatusTime.Add(scrubPeriod)
+		switch {
+		case !now.Before(launchDeadline):
+			diagnosis.Problem = api.StuckMinipoolProblem_LaunchTimeout
+			diagnosis.Explanation = "The minipool wasn't staked before the launch timeout, so it can't be staked anymore. Dissolving it returns the pool's ETH to the deposit pool; afterwards it can be rescued or closed to get your bond back."
+			diagnosis.Remediation = api.StuckMinipoolRemediation_Dissolve
+
+		case beaconStatus.Exists && beaconStatus.WithdrawalCredentials != expectedCredentials:
+			diagnosis.Problem = api.StuckMinipoolProblem_WrongCredentials
+			diagnosis.Explanation = fmt.Sprintf("The validator was deposited with the withdrawal credentials %s instead of the minipool's (%s). The Oracle DAO will scrub it, which dissolves the minipool and penalizes its bond; this can't be fixed.", beaconStatus.WithdrawalCredentials.Hex(), expectedCredentials.Hex())
+			diagnosis.Remediation = api.StuckMinipoolRemediation_None
+
+		case !hasKey:
+			diagnosis.Problem = api.StuckMinipoolProblem_MissingKey
+			diagnosis.Explanation = "The node wallet doesn't have the minipool's validator key, so it can't make the staking deposit. Recover the key with `rocketpool wallet rebuild` (or `rocketpool wallet recover` if the wallet itself is missing) before the launch timeout."
+			diagnosis.Remediation = api.StuckMinipoolRemediation_RecoverKey
+			diagnosis.WaitTime = launchDeadline.Sub(now)
+
+		case !beaconStatus.Exists:
+			diagnosis.Problem = api.StuckMinipoolProblem_DepositPending
+			diagnosis.Explanation = "The Beacon Chain hasn't processed the minipool's first deposit yet, which usually takes 12 to 24 hours. It can be staked once the deposit is processed and the scrub check is over."
+			diagnosis.Remediation = api.StuckMinipoolRemediation_Wait
+			if now.Before(scrubEnd) {
+				diagnosis.WaitTime = scrubEnd.Sub(now)
+			}
+
+		case now.Before(scrubEnd):
+			diagnosis.Problem = api.StuckMinipoolProblem_ScrubPeriod
+			diagnosis.Explanation = "The Oracle DAO is still checking the minipool's deposit. It can be staked once the scrub check is over."
+			diagnosis.Remediation = api.StuckMinipoolRemediation_Wait
+			diagnosis.WaitTime = scrubEnd.Sub(now)
+
+		default:
+			diagnosis.Problem = api.StuckMinipoolProblem_NotStaked
+			diagnosis.Explanation = "The minipool passed the scrub check and is ready to stake. The node daemon normally does this automatically, so it may be offline or waiting for the gas price to drop below your automatic transaction threshold. It must be staked before the launch timeout."
+			diagnosis.Remediation = api.StuckMinipoolRemediation_Stake
+			diagnosis.WaitTime = launchDeadline.Sub(now)
+		}
+
+	case types.Dissolved:
+		if version < 3 {
+			diagnosis.Problem = api.StuckMinipoolProblem_LegacyDissolved
+			diagnosis.Explanation = "The minipool was dissolved and uses a legacy delegate that can't be rescued or closed by the Smartnode. Please ask the Rocket Pool team for help."
+			diagnosis.Remediation = api.StuckMinipoolRemediation_None
+			break
+		}
+		switch {
+		case !beaconStatus.Exists:
+			diagnosis.Problem = api.StuckMinipoolProblem_DissolvedNoDeposit
+			diagnosis.Explanation = "The minipool was dissolved and doesn't have a validator on the Beacon Chain, so it can be closed to withdraw its balance."
+			diagnosis.Remediation = api.StuckMinipoolRemediation_Close
+
+		case beaconStatus.Status == beacon.ValidatorState_PendingInitialized || beaconStatus.Status == beacon.ValidatorState_PendingQueued:
+			if diagnosis.BeaconBalance.Cmp(eth.EthToWei(32)) < 0 {
+				diagnosis.Problem = api.StuckMinipoolProblem_DissolvedUnfunded
+				diagnosis.Explanation = fmt.Sprintf("The minipool was dissolved, but its validator was created on the Beacon Chain with only %.6f ETH. It needs to be topped up to 32 ETH so it activates and can be exited, which returns that ETH to the minipool.", eth.WeiToEth(diagnosis.BeaconBalance))
+				diagnosis.Remediation = api.StuckMinipoolRemediation_Rescue
+			} else {
+				diagnosis.Problem = api.StuckMinipoolProblem_DissolvedPending
+				diagnosis.Explanation = "The minipool was dissolved and its validator has been rescued. It's waiting to be activated on the Beacon Chain, after which it can be exited."
+				diagnosis.Remediation = api.StuckMinipoolRemediation_Wait
+			}
+
+		case beaconStatus.Status == beacon.ValidatorState_ActiveOngoing:
+			diagnosis.Problem = api.StuckMinipoolProblem_DissolvedActive
+			diagnosis.Explanation = "The minipool was dissolved and its validator is active on the Beacon Chain. Exit it so its balance is withdrawn to the minipool, then close the minipool."
+			diagnosis.Remediation = api.StuckMinipoolRemediation_Exit
+
+		case beaconStatus.Status == beacon.ValidatorState_WithdrawalDone:
+			diagnosis.Problem = api.StuckMinipoolProblem_DissolvedExited
+			diagnosis.Explanation = "The minipool was dissolved and its validator has exited and been withdrawn, so it can be closed to withdraw its balance."
+			diagnosis.Remediation = api.StuckMinipoolRemediation_Close
+
+		default:
+			diagnosis.Problem = api.StuckMinipoolProblem_DissolvedExited
+			diagnosis.Explanation = fmt.Sprintf("The minipool was dissolved and its validator is %s. Once its balance has been withdrawn, the minipool can be closed.", beaconStatus.Status)
+			diagnosis.Remediation = api.StuckMinipoolRemediation_Wait
+		}
+	}
+
+	return diagnosis
+
+}
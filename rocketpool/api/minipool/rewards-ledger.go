@@ -0,0 +1,64 @@
+package minipool
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/ledger"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getRewardsLedger(c *cli.Context) (*api.MinipoolRewardsLedgerResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.MinipoolRewardsLedgerResponse{}
+
+	// Load the ledger the node daemon has been building
+	rewardsLedger, err := ledger.LoadLedger(cfg.Smartnode.GetRewardsLedgerPath(true))
+	if err != nil {
+		return nil, err
+	}
+	if rewardsLedger == nil {
+		return &response, nil
+	}
+	response.LedgerExists = true
+	response.StartSlot = rewardsLedger.StartSlot
+	response.LastScannedSlot = rewardsLedger.LastScannedSlot
+	response.LastScannedBlock = rewardsLedger.LastScannedBlock
+	response.History = rewardsLedger.Minipools
+
+	// Reconcile each minipool against its balance at the last block the ledger covers
+	blockNumber := big.NewInt(0).SetUint64(rewardsLedger.LastScannedBlock)
+	for _, address := range rewardsLedger.GetMinipoolAddresses() {
+		balance, err := rp.Client.BalanceAt(context.Background(), address, blockNumber)
+		if err != nil {
+			return nil, fmt.Errorf("error getting balance of minipool %s at block %d: %w", address.Hex(), rewardsLedger.LastScannedBlock, err)
+		}
+		reconciliation, err := rewardsLedger.Reconcile(address, balance)
+		if err != nil {
+			return nil, err
+		}
+		response.Reconciliations = append(response.Reconciliations, reconciliation)
+	}
+
+	// Return response
+	return &response, nil
+
+}
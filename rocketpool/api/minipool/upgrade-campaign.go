@@ -0,0 +1,187 @@
+package minipool
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/delegates"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Survey the node's minipools for outdated delegates, along with the auto-upgrade settings and progress
+func getDelegateUpgradeCampaign(c *cli.Context) (*api.DelegateUpgradeCampaignResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.DelegateUpgradeCampaignResponse{
+		AutoUpgradeEnabled: cfg.Smartnode.AutoUpgradeDelegates.Value.(bool),
+		DailyBudget:        eth.EthToWei(cfg.Smartnode.DelegateUpgradeDailyBudget.Value.(float64)),
+		BatchSize:          cfg.Smartnode.DelegateUpgradeBatchSize.Value.(uint64),
+	}
+
+	// Get the campaign progress
+	campaign, err := delegates.LoadCampaign(cfg.Smartnode.GetDelegateUpgradesPath(true))
+	if err != nil {
+		return nil, err
+	}
+	response.SpentInWindow = campaign.GetSpentSince(time.Now().Add(-delegates.BudgetWindow))
+	response.UpgradeCount = len(campaign.Upgrades)
+
+	// Get the latest delegate
+	latestDelegate, err := rp.GetAddress("rocketMinipoolDelegate", nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting latest minipool delegate: %w", err)
+	}
+	response.LatestDelegate = *latestDelegate
+
+	// Get minipool details
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	legacyMinipoolQueueAddress := cfg.Smartnode.GetV110MinipoolQueueAddress()
+	details, err := getNodeMinipoolDetails(rp, bc, nodeAccount.Address, &legacyMinipoolQueueAddress)
+	if err != nil {
+		return nil, err
+	}
+	response.Minipools = make([]api.DelegateUpgradeCampaignMinipool, len(details))
+	for i, mpDetails := range details {
+		mp := api.DelegateUpgradeCampaignMinipool{
+			Address:           mpDetails.Address,
+			Status:            mpDetails.Status.Status,
+			Delegate:          mpDetails.Delegate,
+			EffectiveDelegate: mpDetails.EffectiveDelegate,
+			UseLatestDelegate: mpDetails.UseLatestDelegate,
+			Outdated:          !mpDetails.Finalised && !mpDetails.UseLatestDelegate && mpDetails.Delegate != *latestDelegate,
+			AutoUpgrade:       campaign.AutoUpgrade[mpDetails.Address],
+		}
+		lastUpgrade := campaign.GetLastUpgrade(mpDetails.Address)
+		if lastUpgrade != nil {
+			mp.LastUpgradeTxHash = lastUpgrade.TxHash
+			mp.LastUpgradeTime = lastUpgrade.Time
+		}
+		response.Minipools[i] = mp
+	}
+
+	// Estimate the gas for each outdated minipool
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	var wg errgroup.Group
+	for i := range response.Minipools {
+		mp := &response.Minipools[i]
+		if !mp.Outdated {
+			continue
+		}
+		wg.Go(func() error {
+			binding, err := minipool.NewMinipool(rp, mp.Address, nil)
+			if err != nil {
+				return err
+			}
+			gasInfo, err := binding.EstimateDelegateUpgradeGas(opts)
+			if err == nil {
+				mp.GasInfo = gasInfo
+			}
+			return nil
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Opt minipools in or out of automatic delegate upgrades
+func setAutoUpgradeDelegate(c *cli.Context, minipoolAddresses []common.Address, enabled bool) (*api.SetAutoUpgradeDelegateResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SetAutoUpgradeDelegateResponse{}
+
+	// Make sure the minipools belong to this node
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	for _, minipoolAddress := range minipoolAddresses {
+		mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateMinipoolOwner(mp, nodeAccount.Address); err != nil {
+			return nil, err
+		}
+	}
+
+	// Update the campaign
+	path := cfg.Smartnode.GetDelegateUpgradesPath(true)
+	campaign, err := delegates.LoadCampaign(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, minipoolAddress := range minipoolAddresses {
+		if enabled {
+			campaign.AutoUpgrade[minipoolAddress] = true
+		} else {
+			delete(campaign.AutoUpgrade, minipoolAddress)
+		}
+	}
+	if err := campaign.Save(path); err != nil {
+		return nil, err
+	}
+	response.Updated = minipoolAddresses
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,76 @@
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Recompute the attestation performance of a random sample of minipools in an interval's performance file from the Beacon Chain
+func auditPerformance(c *cli.Context, interval uint64, samples uint64, epochCount uint64, seed string) (*api.AuditPerformanceResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.AuditPerformanceResponse{
+		Index: interval,
+		Seed:  seed,
+	}
+
+	// Get the performance file
+	header, performanceFile, downloaded, err := loadMinipoolPerformanceFile(rp, cfg, interval)
+	if err != nil {
+		return nil, err
+	}
+	response.PerformanceFileDownloaded = downloaded
+	response.TotalMinipools = len(performanceFile.GetMinipoolAddresses())
+
+	// Get the network state the tree was generated with
+	stateMgr, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, nil)
+	if err != nil {
+		return nil, err
+	}
+	networkState, err := stateMgr.GetStateForSlot(context.Background(), header.ConsensusEndBlock)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the network state at slot %d: %w", header.ConsensusEndBlock, err)
+	}
+
+	// Pick the sample and check it
+	slotsPerEpoch := networkState.BeaconConfig.SlotsPerEpoch
+	addresses := rprewards.SampleMinipools(performanceFile, int(samples), seed)
+	epochs := rprewards.SampleEpochs(header.ConsensusStartBlock/slotsPerEpoch, header.ConsensusEndBlock/slotsPerEpoch, epochCount, seed)
+	response.CheckedEpochs = len(epochs)
+	response.TotalEpochs = int(header.ConsensusEndBlock/slotsPerEpoch - header.ConsensusStartBlock/slotsPerEpoch + 1)
+	response.Minipools, err = rprewards.AuditMinipoolPerformance(context.Background(), bc, networkState, header, performanceFile, addresses, epochs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
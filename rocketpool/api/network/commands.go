@@ -72,6 +72,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "queue-stats",
+				Usage:     "Get stats about the deposit pool and minipool queue",
+				UsageText: "rocketpool api network queue-stats",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getQueueStats(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "timezone-map",
 				Aliases:   []string{"t"},
@@ -156,6 +174,50 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "can-vote-dao-proposal",
+				Usage:     "Check whether the node can vote on a Snapshot DAO proposal",
+				UsageText: "rocketpool api network can-vote-dao-proposal proposal-id choice",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					choice, err := cliutils.ValidatePositiveUint("choice", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canVoteOnDAOProposal(c, c.Args().Get(0), choice))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "vote-dao-proposal",
+				Usage:     "Sign and submit a vote on a Snapshot DAO proposal with the node wallet",
+				UsageText: "rocketpool api network vote-dao-proposal proposal-id choice",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					choice, err := cliutils.ValidatePositiveUint("choice", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(voteOnDAOProposal(c, c.Args().Get(0), choice))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "download-rewards-file",
 				Aliases:   []string{"drf"},
@@ -180,6 +242,84 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "rewards-history",
+				Usage:     "Get the node's rewards and attestation performance for every finalized interval",
+				UsageText: "rocketpool api network rewards-history include-performance",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					includePerformance, err := cliutils.ValidateBool("include-performance", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getRewardsHistory(c, includePerformance))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "minipool-performance",
+				Usage:     "Query an interval's minipool performance file, optionally filtered to a node's minipools or the ones below a participation rate",
+				UsageText: "rocketpool api network minipool-performance interval below limit node",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 4); err != nil {
+						return err
+					}
+					below, err := cliutils.ValidatePercentage("below", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					limit, err := cliutils.ValidateUint("limit", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getMinipoolPerformance(c, c.Args().Get(0), below, limit, c.Args().Get(3)))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "audit-performance",
+				Usage:     "Recompute the attestation performance of a random sample of minipools in an interval's performance file from the Beacon Chain",
+				UsageText: "rocketpool api network audit-performance interval samples epochs seed",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 4); err != nil {
+						return err
+					}
+					interval, err := cliutils.ValidateUint("interval", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					samples, err := cliutils.ValidatePositiveUint("samples", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					epochs, err := cliutils.ValidateUint("epochs", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(auditPerformance(c, interval, samples, epochs, c.Args().Get(3)))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "is-houston-deployed",
 				Aliases:   []string{"ihd"},
@@ -1,6 +1,9 @@
 package network
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/rocket-pool/smartnode/rocketpool/api/node"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/types/api"
@@ -59,3 +62,111 @@ func getActiveDAOProposals(c *cli.Context) (*api.NetworkDAOProposalsResponse, er
 	response.ActiveSnapshotProposals = snapshotResponse.Data.Proposals
 	return &response, nil
 }
+
+func canVoteOnDAOProposal(c *cli.Context, proposalId string, choice uint64) (*api.NetworkCanVoteOnDAOProposalResponse, error) {
+
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response := api.NetworkCanVoteOnDAOProposalResponse{}
+
+	// Check that Snapshot is available on this network
+	if cfg.Smartnode.GetSnapshotSequencerDomain() == "" {
+		response.SnapshotUnavailable = true
+		return &response, nil
+	}
+
+	// Find the proposal
+	proposal, err := getActiveSnapshotProposal(cfg.Smartnode.GetSnapshotApiDomain(), cfg.Smartnode.GetSnapshotID(), proposalId)
+	if err != nil {
+		return nil, err
+	}
+	if proposal == nil {
+		response.DoesNotExist = true
+		return &response, nil
+	}
+	response.InvalidState = (time.Now().Unix() < proposal.Start)
+	response.UnsupportedType = (proposal.Type != "single-choice" && proposal.Type != "basic")
+	response.InvalidChoice = (choice < 1 || choice > uint64(len(proposal.Choices)))
+
+	// Check the node's voting power
+	votingPower, err := node.GetSnapshotVotingPower(cfg.Smartnode.GetSnapshotApiDomain(), cfg.Smartnode.GetSnapshotID(), nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	response.VotingPower = votingPower.Data.Vp.Vp
+	response.InsufficientPower = (response.VotingPower == 0)
+
+	// Check if the node has already voted directly
+	votedProposals, err := node.GetSnapshotVotedProposals(cfg.Smartnode.GetSnapshotApiDomain(), cfg.Smartnode.GetSnapshotID(), nodeAccount.Address, nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	for _, vote := range votedProposals.Data.Votes {
+		if vote.Proposal.Id == proposalId {
+			response.AlreadyVoted = true
+			break
+		}
+	}
+
+	response.CanVote = !(response.InvalidState || response.UnsupportedType || response.InvalidChoice || response.InsufficientPower)
+	return &response, nil
+}
+
+func voteOnDAOProposal(c *cli.Context, proposalId string, choice uint64) (*api.NetworkVoteOnDAOProposalResponse, error) {
+
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response := api.NetworkVoteOnDAOProposalResponse{}
+
+	// Sign the vote with the node wallet
+	typedData := node.CreateSnapshotVoteTypedData(cfg.Smartnode.GetSnapshotID(), proposalId, choice, nodeAccount.Address, time.Now().Unix())
+	signature, err := w.SignTypedData(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("error signing snapshot vote: %w", err)
+	}
+
+	// Submit it
+	response.VoteID, err = node.SubmitSnapshotVote(cfg.Smartnode.GetSnapshotSequencerDomain(), nodeAccount.Address, signature, typedData)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Get an active Snapshot proposal by its ID, or nil if there isn't one
+func getActiveSnapshotProposal(apiDomain string, space string, proposalId string) (*api.SnapshotProposal, error) {
+	snapshotResponse, err := node.GetSnapshotProposals(apiDomain, space, "active")
+	if err != nil {
+		return nil, err
+	}
+	for i, proposal := range snapshotResponse.Data.Proposals {
+		if proposal.Id == proposalId {
+			return &snapshotResponse.Data.Proposals[i], nil
+		}
+	}
+	return nil, nil
+}
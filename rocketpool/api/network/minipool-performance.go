@@ -0,0 +1,212 @@
+package network
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Query the minipool performance file for an interval, optionally filtered to one node's minipools or the ones below a participation rate.
+// The results are sorted from the worst performer to the best.
+func getMinipoolPerformance(c *cli.Context, intervalArg string, below float64, limit uint64, node string) (*api.MinipoolPerformanceResponse, error) {
+
+	// Get services
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.MinipoolPerformanceResponse{
+		Minipools: []api.MinipoolPerformanceDetails{},
+	}
+
+	// Use the latest finalized interval by default
+	var interval uint64
+	if intervalArg == "latest" {
+		currentIndex, err := rewards.GetRewardIndex(rp, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the current rewards interval: %w", err)
+		}
+		if currentIndex.Uint64() == 0 {
+			return nil, fmt.Errorf("no rewards intervals have been finalized yet")
+		}
+		interval = currentIndex.Uint64() - 1
+	} else {
+		interval, err = cliutils.ValidateUint("interval", intervalArg)
+		if err != nil {
+			return nil, err
+		}
+	}
+	response.Index = interval
+
+	// Get the node's minipools
+	var nodeMinipools map[common.Address]bool
+	if node != "" {
+		var nodeAddress common.Address
+		if node == "node" {
+			w, err := services.GetWallet(c)
+			if err != nil {
+				return nil, err
+			}
+			nodeAccount, err := w.GetNodeAccount()
+			if err != nil {
+				return nil, err
+			}
+			nodeAddress = nodeAccount.Address
+		} else if common.IsHexAddress(node) {
+			nodeAddress = common.HexToAddress(node)
+		} else {
+			return nil, fmt.Errorf("invalid node address '%s'", node)
+		}
+		response.NodeAddress = &nodeAddress
+		addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAddress, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the minipools of node %s: %w", nodeAddress.Hex(), err)
+		}
+		nodeMinipools = map[common.Address]bool{}
+		for _, address := range addresses {
+			nodeMinipools[address] = true
+		}
+	}
+
+	// Get the performance file
+	_, performanceFile, downloaded, err := loadMinipoolPerformanceFile(rp, cfg, interval)
+	if err != nil {
+		return nil, err
+	}
+	response.PerformanceFileDownloaded = downloaded
+
+	// Get every minipool's participation rate
+	all := []api.MinipoolPerformanceDetails{}
+	totalSuccessful := uint64(0)
+	totalDuties := uint64(0)
+	for _, address := range performanceFile.GetMinipoolAddresses() {
+		performance, _ := performanceFile.GetSmoothingPoolPerformance(address)
+		details := api.MinipoolPerformanceDetails{
+			Address:                address,
+			SuccessfulAttestations: performance.GetSuccessfulAttestationCount(),
+			MissedAttestations:     performance.GetMissedAttestationCount(),
+			EthEarned:              performance.GetEthEarned(),
+		}
+		if pubkey, err := performance.GetPubkey(); err == nil {
+			details.Pubkey = pubkey
+		}
+		if v3, ok := performance.(*rprewards.SmoothingPoolMinipoolPerformance_v3); ok && v3.AttestationScore != nil {
+			details.AttestationScore = new(big.Int).Set(&v3.AttestationScore.Int)
+		}
+		duties := details.SuccessfulAttestations + details.MissedAttestations
+		if duties > 0 {
+			details.Participation = float64(details.SuccessfulAttestations) / float64(duties) * 100
+		}
+		totalSuccessful += details.SuccessfulAttestations
+		totalDuties += duties
+		all = append(all, details)
+	}
+	response.TotalMinipools = len(all)
+	if totalDuties > 0 {
+		response.NetworkParticipation = float64(totalSuccessful) / float64(totalDuties) * 100
+	}
+
+	// Sort them from worst to best and work out each one's percentile
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].Participation != all[j].Participation {
+			return all[i].Participation < all[j].Participation
+		}
+		return all[i].Address.Hex() < all[j].Address.Hex()
+	})
+	worse := 0
+	for i := range all {
+		if i > 0 && all[i].Participation != all[i-1].Participation {
+			worse = i
+		}
+		all[i].Percentile = float64(worse) / float64(len(all)) * 100
+	}
+
+	// Filter them
+	for _, details := range all {
+		if nodeMinipools != nil && !nodeMinipools[details.Address] {
+			continue
+		}
+		if below > 0 && details.Participation >= below {
+			continue
+		}
+		response.MatchingMinipools++
+		if limit == 0 || uint64(len(response.Minipools)) < limit {
+			response.Minipools = append(response.Minipools, details)
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Load the minipool performance file for a finalized interval, downloading it and its rewards file if they aren't on disk yet
+func loadMinipoolPerformanceFile(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, interval uint64) (*rprewards.RewardsFileHeader, rprewards.IMinipoolPerformanceFile, bool, error) {
+
+	// Make sure the interval has been finalized
+	currentIndex, err := rewards.GetRewardIndex(rp, nil)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("error getting the current rewards interval: %w", err)
+	}
+	if interval >= currentIndex.Uint64() {
+		return nil, nil, false, fmt.Errorf("interval %d hasn't been finalized yet", interval)
+	}
+
+	// Get the rewards file, which says where the performance file is
+	intervalInfo, err := rprewards.GetIntervalInfo(rp, cfg, common.Address{}, interval, nil)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("error getting interval %d info: %w", interval, err)
+	}
+	if !intervalInfo.TreeFileExists || !intervalInfo.MerkleRootValid {
+		if err := intervalInfo.DownloadRewardsFile(cfg, true); err != nil {
+			return nil, nil, false, err
+		}
+	}
+	localRewardsFile, err := rprewards.ReadLocalRewardsFile(intervalInfo.TreeFilePath)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	header := localRewardsFile.Impl().GetHeader()
+	cid := header.MinipoolPerformanceFileCID
+	if cid == "" || cid == "---" {
+		return nil, nil, false, fmt.Errorf("the rewards file for interval %d doesn't have a minipool performance file", interval)
+	}
+
+	// Get the performance file
+	performancePath := cfg.Smartnode.GetMinipoolPerformancePath(interval, true)
+	if _, err := os.Stat(performancePath); err == nil {
+		localPerformanceFile, err := rprewards.ReadLocalMinipoolPerformanceFile(performancePath)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		return header, localPerformanceFile.Impl(), false, nil
+	}
+	performanceFile, err := rprewards.DownloadMinipoolPerformanceFile(cfg, interval, cid, true)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	return header, performanceFile, true, nil
+
+}
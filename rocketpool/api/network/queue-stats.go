@@ -0,0 +1,142 @@
+package network
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/queue"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The number of queue positions to look up concurrently
+const queueStatsThreadLimit int = 6
+
+func getQueueStats(c *cli.Context) (*api.NetworkQueueStatsResponse, error) {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkQueueStatsResponse{}
+
+	// Get the network details at the head block
+	isHoustonDeployed, err := state.IsHoustonDeployed(rp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error checking if Houston has been deployed: %w", err)
+	}
+	multicallerAddress := common.HexToAddress(cfg.Smartnode.GetMulticallAddress())
+	balanceBatcherAddress := common.HexToAddress(cfg.Smartnode.GetBalanceBatcherAddress())
+	contracts, err := rpstate.NewNetworkContracts(rp, multicallerAddress, balanceBatcherAddress, isHoustonDeployed, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network contracts: %w", err)
+	}
+	details, err := rpstate.NewNetworkDetails(rp, contracts, isHoustonDeployed)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network details: %w", err)
+	}
+	response.Block = contracts.ElBlockNumber.Uint64()
+	response.DepositPoolBalance = details.DepositPoolBalance
+	response.QueueLength = details.QueueLength.Uint64()
+	response.QueueCapacity = details.QueueCapacity.Effective
+
+	// Get the bond of each minipool in the queue, in queue order
+	opts := &bind.CallOpts{
+		BlockNumber: contracts.ElBlockNumber,
+	}
+	bonds := make([]*big.Int, response.QueueLength)
+	var wg errgroup.Group
+	wg.SetLimit(queueStatsThreadLimit)
+	for i := uint64(0); i < response.QueueLength; i++ {
+		i := i
+		wg.Go(func() error {
+			address, err := minipool.GetQueueMinipoolAtPosition(rp, i, opts)
+			if err != nil {
+				return fmt.Errorf("error getting minipool at queue position %d: %w", i, err)
+			}
+			mp, err := minipool.NewMinipool(rp, address, opts)
+			if err != nil {
+				return fmt.Errorf("error creating binding for minipool %s: %w", address.Hex(), err)
+			}
+			bonds[i], err = mp.GetNodeDepositBalance(opts)
+			if err != nil {
+				return fmt.Errorf("error getting node deposit balance of minipool %s: %w", address.Hex(), err)
+			}
+			return nil
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Group the queue by bond size and find how many minipools the deposit pool can fund right now
+	launchBalance := eth.EthToWei(32)
+	summaries := map[string]*api.QueuedBondSummary{}
+	remainingBalance := big.NewInt(0).Set(response.DepositPoolBalance)
+	canFund := true
+	for _, bond := range bonds {
+		capacity := big.NewInt(0).Sub(launchBalance, bond)
+		summary, exists := summaries[bond.String()]
+		if !exists {
+			summary = &api.QueuedBondSummary{
+				BondAmount:    bond,
+				TotalCapacity: big.NewInt(0),
+			}
+			summaries[bond.String()] = summary
+		}
+		summary.Count++
+		summary.TotalCapacity.Add(summary.TotalCapacity, capacity)
+
+		if canFund && remainingBalance.Cmp(capacity) >= 0 {
+			remainingBalance.Sub(remainingBalance, capacity)
+			response.FundableMinipools++
+		} else {
+			canFund = false
+		}
+	}
+	response.QueueComposition = make([]api.QueuedBondSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		response.QueueComposition = append(response.QueueComposition, *summary)
+	}
+	sort.Slice(response.QueueComposition, func(i, j int) bool {
+		return response.QueueComposition[i].BondAmount.Cmp(response.QueueComposition[j].BondAmount) < 0
+	})
+
+	// Get the samples recorded by the node daemon
+	response.History, err = queue.LoadSamples(cfg.Smartnode.GetQueueStatsPath(false))
+	if err != nil {
+		return nil, err
+	}
+
+	// Estimate how long a new minipool would wait to be matched
+	response.AssignmentsPerDay, response.AssignmentRateKnown = queue.GetAssignmentRate(response.History)
+	if response.AssignmentRateKnown && response.AssignmentsPerDay > 0 {
+		days := float64(response.QueueLength+1) / response.AssignmentsPerDay
+		response.NewMinipoolEta = time.Duration(days * float64(24*time.Hour))
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,157 @@
+package network
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Get the node's rewards and attestation performance for every finalized interval.
+// Rewards and performance files that aren't on disk yet are downloaded and kept for next time.
+func getRewardsHistory(c *cli.Context, includePerformance bool) (*api.RewardsHistoryResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.RewardsHistoryResponse{
+		Intervals: []api.RewardsHistoryInterval{},
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.NodeAddress = nodeAccount.Address
+
+	// Get the intervals and which ones have been claimed
+	currentIndex, err := rewards.GetRewardIndex(rp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the current rewards interval: %w", err)
+	}
+	_, claimed, err := rprewards.GetClaimStatus(rp, nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	claimedMap := map[uint64]bool{}
+	for _, interval := range claimed {
+		claimedMap[interval] = true
+	}
+
+	// Get the node's minipools
+	nodeMinipools := map[common.Address]bool{}
+	if includePerformance {
+		addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAccount.Address, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the node's minipools: %w", err)
+		}
+		for _, address := range addresses {
+			nodeMinipools[address] = true
+		}
+	}
+
+	for index := uint64(0); index < currentIndex.Uint64(); index++ {
+		interval := api.RewardsHistoryInterval{
+			Index:            index,
+			Claimed:          claimedMap[index],
+			CollateralRpl:    big.NewInt(0),
+			OracleDaoRpl:     big.NewInt(0),
+			SmoothingPoolEth: big.NewInt(0),
+		}
+
+		// Get the rewards file
+		intervalInfo, err := rprewards.GetIntervalInfo(rp, cfg, nodeAccount.Address, index, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting interval %d info: %w", index, err)
+		}
+		interval.StartTime = intervalInfo.StartTime
+		interval.EndTime = intervalInfo.EndTime
+		if !intervalInfo.TreeFileExists || !intervalInfo.MerkleRootValid {
+			if err := intervalInfo.DownloadRewardsFile(cfg, true); err != nil {
+				response.MissingIntervals = append(response.MissingIntervals, index)
+				response.Intervals = append(response.Intervals, interval)
+				continue
+			}
+			intervalInfo, err = rprewards.GetIntervalInfo(rp, cfg, nodeAccount.Address, index, nil)
+			if err != nil {
+				return nil, fmt.Errorf("error getting interval %d info: %w", index, err)
+			}
+		}
+		interval.Available = true
+		if intervalInfo.NodeExists {
+			interval.CollateralRpl = &intervalInfo.CollateralRplAmount.Int
+			interval.OracleDaoRpl = &intervalInfo.ODaoRplAmount.Int
+			interval.SmoothingPoolEth = &intervalInfo.SmoothingPoolEthAmount.Int
+		}
+
+		// Get the node's attestation performance and how it ranks against the rest of the Smoothing Pool
+		if includePerformance && len(nodeMinipools) > 0 {
+			_, performanceFile, _, err := loadMinipoolPerformanceFile(rp, cfg, index)
+			if err == nil {
+				addPerformanceToHistory(&interval, performanceFile, nodeMinipools)
+			}
+		}
+
+		response.Intervals = append(response.Intervals, interval)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Work out the node's participation rate in an interval and its percentile among all of the minipools in the performance file
+func addPerformanceToHistory(interval *api.RewardsHistoryInterval, performanceFile rprewards.IMinipoolPerformanceFile, nodeMinipools map[common.Address]bool) {
+	nodeSuccessful := uint64(0)
+	nodeDuties := uint64(0)
+	rates := []float64{}
+	for _, address := range performanceFile.GetMinipoolAddresses() {
+		performance, _ := performanceFile.GetSmoothingPoolPerformance(address)
+		successful := performance.GetSuccessfulAttestationCount()
+		duties := successful + performance.GetMissedAttestationCount()
+		if duties == 0 {
+			continue
+		}
+		rates = append(rates, float64(successful)/float64(duties))
+		if nodeMinipools[address] {
+			interval.Minipools++
+			nodeSuccessful += successful
+			nodeDuties += duties
+		}
+	}
+	if nodeDuties == 0 || len(rates) == 0 {
+		return
+	}
+
+	nodeRate := float64(nodeSuccessful) / float64(nodeDuties)
+	interval.Participation = nodeRate * 100
+	worse := 0
+	for _, rate := range rates {
+		if rate < nodeRate {
+			worse++
+		}
+	}
+	percentile := float64(worse) / float64(len(rates)) * 100
+	interval.Percentile = &percentile
+}
@@ -0,0 +1,111 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/attestation"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Settings
+const (
+	// The most epochs checked in one request, since each one needs a few dozen blocks from the Beacon Node
+	maxAttestationPerformanceEpochs uint64 = 16
+)
+
+// Get the attestation performance of the node's active validators in each finished epoch since the start epoch
+func getAttestationPerformance(c *cli.Context, startEpoch uint64) (*api.NodeAttestationPerformanceResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeAttestationPerformanceResponse{
+		Epochs: []api.EpochAttestationPerformance{},
+	}
+
+	// Get the node's active validators
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	pubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool pubkeys: %w", err)
+	}
+	statuses, err := bc.GetValidatorStatuses(pubkeys, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting validator statuses: %w", err)
+	}
+	validators := map[string]bool{}
+	for _, status := range statuses {
+		if !status.Exists {
+			continue
+		}
+		switch status.Status {
+		case beacon.ValidatorState_ActiveOngoing, beacon.ValidatorState_ActiveExiting, beacon.ValidatorState_ActiveSlashed:
+			validators[status.Index] = true
+		}
+	}
+	response.ActiveValidators = uint64(len(validators))
+
+	// An epoch's attestations can be included until the end of the next one, so only check epochs that are two behind the head
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, err
+	}
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return nil, fmt.Errorf("error getting the Beacon head: %w", err)
+	}
+	response.CurrentEpoch = head.Epoch
+	if len(validators) == 0 || head.Epoch < 2 || startEpoch > head.Epoch-2 {
+		return &response, nil
+	}
+	lastComplete := head.Epoch - 2
+	if lastComplete >= startEpoch+maxAttestationPerformanceEpochs {
+		startEpoch = lastComplete - maxAttestationPerformanceEpochs + 1
+	}
+
+	// Check each epoch
+	checker := attestation.NewChecker(bc)
+	for epoch := startEpoch; epoch <= lastComplete; epoch++ {
+		stats, err := checker.CheckEpoch(eth2Config.SlotsPerEpoch, epoch, validators)
+		if err != nil {
+			return nil, err
+		}
+		response.Epochs = append(response.Epochs, api.EpochAttestationPerformance{
+			Epoch:        epoch,
+			Duties:       stats.Duties,
+			Included:     stats.Included,
+			TimelySource: stats.TimelySource,
+			TimelyTarget: stats.TimelyTarget,
+			TimelyHead:   stats.TimelyHead,
+			Efficiency:   stats.Efficiency(),
+			LikelyCauses: stats.LikelyCauses(),
+		})
+	}
+
+	// Return response
+	return &response, nil
+
+}
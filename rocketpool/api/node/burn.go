@@ -129,6 +129,7 @@ func nodeBurn(c *cli.Context, amountWei *big.Int, token string) (*api.NodeBurnRe
 	if err != nil {
 		return nil, err
 	}
+	services.UsePrivateRelay(opts, services.PrivateTx_Burn)
 
 	// Override the provided pending TX if requested
 	err = eth1.CheckForNonceOverride(c, opts)
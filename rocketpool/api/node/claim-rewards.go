@@ -20,6 +20,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/types/errcodes"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
 	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 )
@@ -45,6 +46,10 @@ func getRewardsInfo(c *cli.Context) (*api.NodeGetRewardsInfoResponse, error) {
 	if err != nil {
 		return nil, err
 	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Response
 	response := api.NodeGetRewardsInfoResponse{}
@@ -88,7 +93,7 @@ func getRewardsInfo(c *cli.Context) (*api.NodeGetRewardsInfoResponse, error) {
 	// Get collateral info for restaking
 	var totalMinipools int
 	var finalizedMinipools int
-	details, err := getNodeMinipoolCountDetails(rp, nodeAccount.Address)
+	details, err := getNodeMinipoolCountDetails(rp, cfg, bc, nodeAccount.Address)
 	if err == nil {
 		totalMinipools = len(details)
 		for _, mpDetails := range details {
@@ -255,6 +260,7 @@ func claimRewards(c *cli.Context, indicesString string) (*api.NodeClaimRewardsRe
 	if err != nil {
 		return nil, err
 	}
+	services.UsePrivateRelay(opts, services.PrivateTx_ClaimRewards)
 
 	// Override the provided pending TX if requested
 	err = eth1.CheckForNonceOverride(c, opts)
@@ -355,6 +361,7 @@ func claimAndStakeRewards(c *cli.Context, indicesString string, stakeAmount *big
 	if err != nil {
 		return nil, err
 	}
+	services.UsePrivateRelay(opts, services.PrivateTx_ClaimRewards)
 
 	// Get the rewards
 	indices, amountRPL, amountETH, merkleProofs, err := getRewardsForIntervals(rp, cfg, nodeAccount.Address, indicesString)
@@ -418,7 +425,7 @@ func getRewardsForIntervals(rp *rocketpool.RocketPool, cfg *config.RocketPoolCon
 			return nil, nil, nil, nil, fmt.Errorf("rewards tree file '%s' doesn't exist", intervalInfo.TreeFilePath)
 		}
 		if !intervalInfo.MerkleRootValid {
-			return nil, nil, nil, nil, fmt.Errorf("merkle root for rewards tree file '%s' doesn't match the canonical merkle root for interval %d", intervalInfo.TreeFilePath, index.Uint64())
+			return nil, nil, nil, nil, errcodes.Wrap(errcodes.ConsensusMismatch, fmt.Errorf("merkle root for rewards tree file '%s' doesn't match the canonical merkle root for interval %d", intervalInfo.TreeFilePath, index.Uint64()))
 		}
 
 		// Get the rewards from it
@@ -1,6 +1,8 @@
 package node
 
 import (
+	"time"
+
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -34,6 +36,68 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "validators",
+				Usage:     "Get the node's minipools and their validators from the network state",
+				UsageText: "rocketpool api node validators refresh",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					refresh, err := cliutils.ValidateBool("refresh", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getValidators(c, refresh))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "sync-committee",
+				Usage:     "Get the node's sync committee assignments and check that it's ready for them",
+				UsageText: "rocketpool api node sync-committee",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getSyncCommittee(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "attestation-performance",
+				Usage:     "Get the attestation performance of the node's validators in each finished epoch since the start epoch",
+				UsageText: "rocketpool api node attestation-performance start-epoch",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					startEpoch, err := cliutils.ValidateUint("start epoch", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getAttestationPerformance(c, startEpoch))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "sync",
 				Aliases:   []string{"y"},
@@ -799,6 +863,106 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "get-income-events",
+				Usage:     "Get the node's income events between two times for accounting purposes",
+				UsageText: "rocketpool api node get-income-events start-timestamp end-timestamp",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					startTimestamp, err := cliutils.ValidateUint("start timestamp", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					endTimestamp, err := cliutils.ValidatePositiveUint("end timestamp", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getIncomeEvents(c, time.Unix(int64(startTimestamp), 0), time.Unix(int64(endTimestamp), 0)))
+					return nil
+
+				},
+			},
+			{
+				Name:      "simulate-deposit",
+				Usage:     "Run all of the pre-flight checks for a node deposit and get a go/no-go report",
+				UsageText: "rocketpool api node simulate-deposit amount min-fee salt",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					amountWei, err := cliutils.ValidatePositiveWeiAmount("deposit amount", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					minNodeFee, err := cliutils.ValidateFraction("minimum node fee", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					salt, err := cliutils.ValidateBigInt("salt", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(simulateNodeDeposit(c, amountWei, minNodeFee, salt))
+					return nil
+
+				},
+			},
+			{
+				Name:      "export-deposit-data",
+				Usage:     "Create the deposit data and keystore for the validator of the node's next minipool, so it can be run by a third party",
+				UsageText: "rocketpool api node export-deposit-data salt keystore-password",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					salt, err := cliutils.ValidateBigInt("salt", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					password, err := cliutils.ValidateNodePassword("keystore password", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(exportDepositData(c, salt, password))
+					return nil
+
+				},
+			},
+			{
+				Name:      "validate-deposit-data",
+				Usage:     "Check deposit data created elsewhere against the minipool the node would create with the given salt",
+				UsageText: "rocketpool api node validate-deposit-data salt deposit-data-json",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					salt, err := cliutils.ValidateBigInt("salt", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(validateDepositData(c, salt, c.Args().Get(1)))
+					return nil
+
+				},
+			},
 			{
 				Name:      "deposit",
 				Aliases:   []string{"d"},
@@ -953,6 +1117,113 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "get-reth-arbitrage",
+				Usage:     "Compare the protocol's rETH rate against DEX quotes for depositing ETH or withdrawing rETH",
+				UsageText: "rocketpool api node get-reth-arbitrage amount direction",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					amountWei, err := cliutils.ValidatePositiveWeiAmount("amount", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					direction, err := cliutils.ValidateRethArbitrageDirection("direction", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getRethArbitrage(c, amountWei, direction))
+					return nil
+
+				},
+			},
+			{
+				Name:      "route-reth-deposit",
+				Usage:     "Deposit ETH for rETH through the protocol or a DEX quote source",
+				UsageText: "rocketpool api node route-reth-deposit amount source min-output",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					amountWei, err := cliutils.ValidatePositiveWeiAmount("amount", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					source := c.Args().Get(1)
+					minOutput, err := cliutils.ValidatePositiveWeiAmount("min output", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(routeRethDeposit(c, amountWei, source, minOutput))
+					return nil
+
+				},
+			},
+			{
+				Name:      "get-reth-deposit-swap",
+				Usage:     "Get and simulate the swap transaction for depositing ETH for rETH through a DEX quote source",
+				UsageText: "rocketpool api node get-reth-deposit-swap amount source",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					amountWei, err := cliutils.ValidatePositiveWeiAmount("amount", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					source := c.Args().Get(1)
+
+					// Run
+					api.PrintResponse(getRethDepositSwap(c, amountWei, source))
+					return nil
+
+				},
+			},
+			{
+				Name:      "swap-reth-deposit",
+				Usage:     "Send a previewed DEX swap of ETH for rETH after simulating it again",
+				UsageText: "rocketpool api node swap-reth-deposit value to data min-output",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 4); err != nil {
+						return err
+					}
+					value, err := cliutils.ValidatePositiveOrZeroWeiAmount("value", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					to, err := cliutils.ValidateAddress("to", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					data, err := cliutils.ValidateByteArray("data", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+					minOutput, err := cliutils.ValidatePositiveWeiAmount("min output", c.Args().Get(3))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(swapRethDeposit(c, value, to, data, minOutput))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "can-claim-rpl-rewards",
 				Usage:     "Check whether the node has RPL rewards available to claim",
@@ -1006,6 +1277,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "preview-rewards",
+				Usage:     "Preview the node's rewards for the current interval so far from the local rolling record",
+				UsageText: "rocketpool api node preview-rewards",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(previewRewards(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "deposit-contract-info",
 				Usage:     "Get information about the deposit contract specified by Rocket Pool and the Beacon Chain client",
@@ -1045,9 +1334,99 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 			},
 
 			{
-				Name:      "sign-message",
-				Usage:     "Signs an arbitrary message with the node's private key.",
-				UsageText: "rocketpool api node sign-message 'message'",
+				Name:      "decode-tx",
+				Usage:     "Decodes a serialized transaction or its calldata with the bundled Rocket Pool ABIs. The data must be a hex string.",
+				UsageText: "rocketpool api node decode-tx data",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					data := c.Args().Get(0)
+
+					// Run
+					api.PrintResponse(decodeTransaction(c, data))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "prepare-offline-tx",
+				Usage:     "Get the chain ID, pending nonce, and gas estimate for a transaction that will be signed on an air-gapped machine",
+				UsageText: "rocketpool api node prepare-offline-tx from to amount-wei data",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 4); err != nil {
+						return err
+					}
+					from, err := cliutils.ValidateAddress("from address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					to, err := cliutils.ValidateAddress("to address", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					amountWei, err := cliutils.ValidateWeiAmount("amount", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+					data, err := cliutils.ValidateByteArray("data", c.Args().Get(3))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(prepareOfflineTransaction(c, from, to, amountWei, data))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "sign-offline-tx",
+				Usage:     "Signs a transaction exported from an online node with the node's private key, without needing an Execution client. The TX must be serialized as a hex string.",
+				UsageText: "rocketpool api node sign-offline-tx tx",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(signOfflineTransaction(c, c.Args().Get(0)))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "broadcast-offline-tx",
+				Usage:     "Broadcasts a transaction that was signed on an air-gapped machine. The TX must be serialized as a hex string.",
+				UsageText: "rocketpool api node broadcast-offline-tx tx",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(broadcastOfflineTransaction(c, c.Args().Get(0)))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "request-sign-message",
+				Usage:     "Issues a one-time confirmation code that has to be provided to sign a message with the node's private key. Only available when message signing is enabled in the Smartnode settings.",
+				UsageText: "rocketpool api node request-sign-message 'message'",
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -1058,7 +1437,54 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					message := c.Args().Get(0)
 
 					// Run
-					api.PrintResponse(signMessage(c, message))
+					api.PrintResponse(requestSignMessage(c, message))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "sign-message",
+				Usage:     "Signs an arbitrary message with the node's private key, using the confirmation code from request-sign-message.",
+				UsageText: "rocketpool api node sign-message code 'message'",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+
+					code := c.Args().Get(0)
+					message := c.Args().Get(1)
+
+					// Run
+					api.PrintResponse(signMessage(c, code, message))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "verify-message",
+				Usage:     "Checks that an EIP-191 signature of a message was made by the provided address.",
+				UsageText: "rocketpool api node verify-message address 'message' signature",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					address, err := cliutils.ValidateAddress("address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					signature, err := cliutils.ValidateByteArray("signature", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(verifyMessage(c, address, c.Args().Get(1), signature))
 					return nil
 
 				},
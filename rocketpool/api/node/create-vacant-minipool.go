@@ -16,6 +16,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/types/errcodes"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
 	"github.com/urfave/cli"
 	"golang.org/x/sync/errgroup"
@@ -205,11 +206,11 @@ func createVacantMinipool(c *cli.Context, amountWei *big.Int, minNodeFee float64
 	}
 	if depositContractInfo.RPNetwork != depositContractInfo.BeaconNetwork ||
 		depositContractInfo.RPDepositContract != depositContractInfo.BeaconDepositContract {
-		return nil, fmt.Errorf("Beacon network mismatch! Expected %s on chain %d, but beacon is using %s on chain %d.",
+		return nil, errcodes.Wrap(errcodes.NetworkMismatch, fmt.Errorf("Beacon network mismatch! Expected %s on chain %d, but beacon is using %s on chain %d.",
 			depositContractInfo.RPDepositContract.Hex(),
 			depositContractInfo.RPNetwork,
 			depositContractInfo.BeaconDepositContract.Hex(),
-			depositContractInfo.BeaconNetwork)
+			depositContractInfo.BeaconNetwork))
 	}
 
 	// Get the scrub period
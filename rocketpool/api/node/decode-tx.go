@@ -0,0 +1,79 @@
+package node
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/txdecoder"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	hexutils "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+func decodeTransaction(c *cli.Context, serializedData string) (*api.NodeDecodeTransactionResponse, error) {
+
+	// Response
+	response := api.NodeDecodeTransactionResponse{}
+
+	serializedData = hexutils.RemovePrefix(serializedData)
+	bytes, err := hex.DecodeString(serializedData)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing TX bytes [%s]: %w", serializedData, err)
+	}
+
+	// The data can be a whole serialized transaction, signed or not, or just its calldata
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(bytes); err != nil {
+		response.Preview, err = txdecoder.Decode(nil, nil, bytes, "")
+		if err != nil {
+			return nil, fmt.Errorf("Error decoding calldata: %w", err)
+		}
+		return &response, nil
+	}
+	response.IsTransaction = true
+	response.ChainID = tx.ChainId()
+	response.Nonce = tx.Nonce()
+	response.GasLimit = tx.Gas()
+	response.MaxFee = tx.GasFeeCap()
+	response.MaxPriorityFee = tx.GasTipCap()
+	v, r, s := tx.RawSignatureValues()
+	if v.Sign() != 0 || r.Sign() != 0 || s.Sign() != 0 {
+		from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+		if err != nil {
+			return nil, fmt.Errorf("Error recovering the TX sender: %w", err)
+		}
+		response.IsSigned = true
+		response.From = &from
+	}
+
+	// Name the target contract if the Execution client is available; the calldata can be decoded without it
+	contractName := ""
+	if tx.To() != nil && services.RequireRocketStorage(c) == nil {
+		rp, err := services.GetRocketPool(c)
+		if err == nil {
+			var from common.Address
+			if response.From != nil {
+				from = *response.From
+			} else if w, err := services.GetWallet(c); err == nil {
+				if nodeAccount, err := w.GetNodeAccount(); err == nil {
+					from = nodeAccount.Address
+				}
+			}
+			contractName = txdecoder.ResolveContract(rp, *tx.To(), from)
+		}
+	}
+
+	// Decode it
+	response.Preview, err = txdecoder.Decode(tx.To(), tx.Value(), tx.Data(), contractName)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding TX: %w", err)
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,234 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+// The deposits a new minipool's validator receives: 1 ETH when it's created, then the other 31 ETH when it's staked
+var newMinipoolDepositAmounts = []uint64{
+	uint64(1e9),  // 1 ETH in gwei
+	uint64(31e9), // 31 ETH in gwei
+}
+
+// Export the deposit data and keystore for the validator of the node's next minipool, so it can be run by a third party
+func exportDepositData(c *cli.Context, salt *big.Int, password string) (*api.ExportDepositDataResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get eth2 config
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ExportDepositDataResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Adjust the salt the same way deposits do
+	if salt.Cmp(big.NewInt(0)) == 0 {
+		nonce, err := ec.NonceAt(context.Background(), nodeAccount.Address, nil)
+		if err != nil {
+			return nil, err
+		}
+		salt.SetUint64(nonce)
+	}
+	response.Salt = salt
+
+	// Get the next validator key; the deposit will create the same one as long as no other key is created first
+	keyCount, err := w.GetValidatorKeyCount()
+	if err != nil {
+		return nil, err
+	}
+	validatorKeys, err := w.GetValidatorKeys(keyCount, 1)
+	if err != nil {
+		return nil, err
+	}
+	validatorKey := validatorKeys[0]
+	response.Pubkey = validatorKey.PublicKey
+	response.DerivationPath = validatorKey.DerivationPath
+
+	// Get the next minipool address and withdrawal credentials
+	response.MinipoolAddress, err = minipool.GetExpectedAddress(rp, nodeAccount.Address, salt, nil)
+	if err != nil {
+		return nil, err
+	}
+	withdrawalCredentials, err := minipool.GetMinipoolWithdrawalCredentials(rp, response.MinipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the deposit data and keystore
+	networkName := string(cfg.Smartnode.Network.Value.(cfgtypes.Network))
+	response.DepositData, response.Keystore, err = validator.ExportMinipoolValidator(validatorKey.PrivateKey, validatorKey.DerivationPath, password, withdrawalCredentials, eth2Config, networkName, newMinipoolDepositAmounts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Check deposit data produced somewhere else against the minipool the node would create with the given salt
+func validateDepositData(c *cli.Context, salt *big.Int, depositDataJson string) (*api.ValidateDepositDataResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get eth2 config
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the deposit data; the deposit CLI writes a list, but a single entry is fine too
+	var entries []api.ExportedDepositData
+	if err := json.Unmarshal([]byte(depositDataJson), &entries); err != nil {
+		var entry api.ExportedDepositData
+		if err := json.Unmarshal([]byte(depositDataJson), &entry); err != nil {
+			return nil, fmt.Errorf("error decoding deposit data: %w", err)
+		}
+		entries = []api.ExportedDepositData{entry}
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("the deposit data doesn't have any entries")
+	}
+
+	// Response
+	response := api.ValidateDepositDataResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Adjust the salt the same way deposits do
+	if salt.Cmp(big.NewInt(0)) == 0 {
+		nonce, err := ec.NonceAt(context.Background(), nodeAccount.Address, nil)
+		if err != nil {
+			return nil, err
+		}
+		salt.SetUint64(nonce)
+	}
+
+	// Get the minipool address and withdrawal credentials
+	response.MinipoolAddress, err = minipool.GetExpectedAddress(rp, nodeAccount.Address, salt, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.ExpectedWithdrawalCredentials, err = minipool.GetMinipoolWithdrawalCredentials(rp, response.MinipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	code, err := ec.CodeAt(context.Background(), response.MinipoolAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error checking if minipool %s exists: %w", response.MinipoolAddress.Hex(), err)
+	}
+	response.MinipoolExists = len(code) > 0
+
+	// Check each entry
+	for _, entry := range entries {
+		validation := api.DepositDataValidation{
+			Pubkey:   hexutil.AddPrefix(hexutil.RemovePrefix(entry.Pubkey)),
+			Amount:   entry.Amount,
+			Problems: validator.VerifyExportedDepositData(entry, response.ExpectedWithdrawalCredentials, eth2Config),
+			Warnings: []string{},
+		}
+		switch entry.Amount {
+		case newMinipoolDepositAmounts[0], newMinipoolDepositAmounts[1]:
+		case uint64(32e9):
+			validation.Warnings = append(validation.Warnings, "this is a 32 ETH deposit, but minipools deposit 1 ETH when they're created and 31 ETH when they're staked, so separate 1 ETH and 31 ETH entries are needed")
+		default:
+			validation.Warnings = append(validation.Warnings, fmt.Sprintf("minipools deposit 1 ETH and 31 ETH, but this entry is for %d gwei", entry.Amount))
+		}
+
+		// Make sure the key isn't already in use
+		if len(validation.Problems) == 0 {
+			pubkey, err := rptypes.HexToValidatorPubkey(hexutil.RemovePrefix(entry.Pubkey))
+			if err != nil {
+				return nil, err
+			}
+			existingMinipool, err := minipool.GetMinipoolByPubkey(rp, pubkey, nil)
+			if err != nil {
+				return nil, fmt.Errorf("error checking if pubkey %s is in use: %w", pubkey.Hex(), err)
+			}
+			if existingMinipool != (common.Address{}) {
+				validation.Problems = append(validation.Problems, fmt.Sprintf("the key is already used by minipool %s", existingMinipool.Hex()))
+			}
+			status, err := bc.GetValidatorStatus(pubkey, nil)
+			if err != nil {
+				return nil, fmt.Errorf("error getting the Beacon Chain status of %s: %w", pubkey.Hex(), err)
+			}
+			if status.Exists && status.WithdrawalCredentials != response.ExpectedWithdrawalCredentials {
+				validation.Problems = append(validation.Problems, fmt.Sprintf("the key already has a validator on the Beacon Chain with withdrawal credentials %s", status.WithdrawalCredentials.Hex()))
+			}
+		}
+		response.Entries = append(response.Entries, validation)
+	}
+
+	// Return response
+	return &response, nil
+
+}
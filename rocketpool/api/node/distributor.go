@@ -10,6 +10,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
 )
@@ -139,7 +140,7 @@ func initializeFeeDistributor(c *cli.Context) (*api.NodeInitializeFeeDistributor
 
 func canDistribute(c *cli.Context) (*api.NodeCanDistributeResponse, error) {
 	// Get services
-	if err := services.RequireNodeWallet(c); err != nil {
+	if err := services.RequireNodeAddress(c); err != nil {
 		return nil, err
 	}
 	if err := services.RequireRocketStorage(c); err != nil {
@@ -149,6 +150,10 @@ func canDistribute(c *cli.Context) (*api.NodeCanDistributeResponse, error) {
 	if err != nil {
 		return nil, err
 	}
+	gw, err := services.GetGasWallet(c)
+	if err != nil {
+		return nil, err
+	}
 	rp, err := services.GetRocketPool(c)
 	if err != nil {
 		return nil, err
@@ -157,14 +162,14 @@ func canDistribute(c *cli.Context) (*api.NodeCanDistributeResponse, error) {
 	// Response
 	response := api.NodeCanDistributeResponse{}
 
-	// Get node account
-	nodeAccount, err := w.GetNodeAccount()
+	// Get the node address; the gas wallet can pay for distributions without the node's key
+	nodeAddress, err := w.GetNodeAddress()
 	if err != nil {
 		return nil, err
 	}
 
 	// Get the fee distributor
-	distributorAddress, err := node.GetDistributorAddress(rp, nodeAccount.Address, nil)
+	distributorAddress, err := node.GetDistributorAddress(rp, nodeAddress, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -196,7 +201,7 @@ func canDistribute(c *cli.Context) (*api.NodeCanDistributeResponse, error) {
 	// Get gas estimates
 	wg.Go(func() error {
 		var err error
-		opts, err := w.GetNodeAccountTransactor()
+		opts, _, err := wallet.GetTransactorForTx(w, gw, wallet.GasWalletTx_DistributeFees)
 		if err != nil {
 			return err
 		}
@@ -217,7 +222,7 @@ func canDistribute(c *cli.Context) (*api.NodeCanDistributeResponse, error) {
 
 func distribute(c *cli.Context) (*api.NodeDistributeResponse, error) {
 	// Get services
-	if err := services.RequireNodeWallet(c); err != nil {
+	if err := services.RequireNodeAddress(c); err != nil {
 		return nil, err
 	}
 	if err := services.RequireRocketStorage(c); err != nil {
@@ -227,6 +232,10 @@ func distribute(c *cli.Context) (*api.NodeDistributeResponse, error) {
 	if err != nil {
 		return nil, err
 	}
+	gw, err := services.GetGasWallet(c)
+	if err != nil {
+		return nil, err
+	}
 	rp, err := services.GetRocketPool(c)
 	if err != nil {
 		return nil, err
@@ -235,14 +244,14 @@ func distribute(c *cli.Context) (*api.NodeDistributeResponse, error) {
 	// Response
 	response := api.NodeDistributeResponse{}
 
-	// Get node account
-	nodeAccount, err := w.GetNodeAccount()
+	// Get the node address; the gas wallet can pay for distributions without the node's key
+	nodeAddress, err := w.GetNodeAddress()
 	if err != nil {
 		return nil, err
 	}
 
 	// Get fee distributor address
-	distributorAddress, err := node.GetDistributorAddress(rp, nodeAccount.Address, nil)
+	distributorAddress, err := node.GetDistributorAddress(rp, nodeAddress, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -253,8 +262,8 @@ func distribute(c *cli.Context) (*api.NodeDistributeResponse, error) {
 		return nil, err
 	}
 
-	// Get gas estimates
-	opts, err := w.GetNodeAccountTransactor()
+	// Get transactor; fee distributions are open to anyone, so the gas wallet pays for them if there is one
+	opts, _, err := wallet.GetTransactorForTx(w, gw, wallet.GasWalletTx_DistributeFees)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,137 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/income"
+	"github.com/rocket-pool/smartnode/shared/services/ledger"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getIncomeEvents(c *cli.Context, startTime time.Time, endTime time.Time) (*api.NodeIncomeEventsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeIncomeEventsResponse{
+		Events: []income.Event{},
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the rewards from each interval that ended in the range, using the local rewards tree files
+	unclaimed, claimed, err := rprewards.GetClaimStatus(rp, nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	claimedMap := map[uint64]bool{}
+	for _, interval := range claimed {
+		claimedMap[interval] = true
+	}
+	intervals := append(claimed, unclaimed...)
+	for _, interval := range intervals {
+		intervalInfo, err := rprewards.GetIntervalInfo(rp, cfg, nodeAccount.Address, interval, nil)
+		if err != nil {
+			return nil, err
+		}
+		if intervalInfo.EndTime.Before(startTime) || !intervalInfo.EndTime.Before(endTime) {
+			continue
+		}
+		if !intervalInfo.TreeFileExists || !intervalInfo.MerkleRootValid {
+			response.MissingIntervals = append(response.MissingIntervals, interval)
+			continue
+		}
+		if !intervalInfo.NodeExists {
+			continue
+		}
+
+		source := fmt.Sprintf("interval %d", interval)
+		rplAmount := big.NewInt(0).Add(&intervalInfo.CollateralRplAmount.Int, &intervalInfo.ODaoRplAmount.Int)
+		if rplAmount.Sign() > 0 {
+			response.Events = append(response.Events, income.Event{
+				Time:    intervalInfo.EndTime,
+				Type:    income.EventType_RplRewards,
+				Asset:   income.Asset_Rpl,
+				Amount:  rplAmount,
+				Source:  source,
+				Claimed: claimedMap[interval],
+			})
+		}
+		if intervalInfo.SmoothingPoolEthAmount.Sign() > 0 {
+			response.Events = append(response.Events, income.Event{
+				Time:    intervalInfo.EndTime,
+				Type:    income.EventType_SmoothingPool,
+				Asset:   income.Asset_Eth,
+				Amount:  big.NewInt(0).Set(&intervalInfo.SmoothingPoolEthAmount.Int),
+				Source:  source,
+				Claimed: claimedMap[interval],
+			})
+		}
+	}
+
+	// Get the minipool and fee distributor distributions the node daemon has recorded
+	rewardsLedger, err := ledger.LoadLedger(cfg.Smartnode.GetRewardsLedgerPath(true))
+	if err != nil {
+		return nil, err
+	}
+	if rewardsLedger != nil {
+		response.LedgerExists = true
+		response.LedgerStartSlot = rewardsLedger.StartSlot
+		for _, address := range rewardsLedger.GetMinipoolAddresses() {
+			for _, distribution := range rewardsLedger.Minipools[address].Distributions {
+				addDistributionEvent(&response, distribution, income.EventType_MinipoolDistribution, address.Hex(), startTime, endTime)
+			}
+		}
+		for _, distribution := range rewardsLedger.FeeDistributions {
+			addDistributionEvent(&response, distribution, income.EventType_FeeDistribution, "fee distributor", startTime, endTime)
+		}
+	}
+
+	// Return response
+	income.SortEvents(response.Events)
+	return &response, nil
+
+}
+
+// Add the node's share of a recorded distribution to the response if it happened in the time range
+func addDistributionEvent(response *api.NodeIncomeEventsResponse, distribution ledger.Distribution, eventType income.EventType, source string, startTime time.Time, endTime time.Time) {
+	eventTime := time.Unix(int64(distribution.Time), 0)
+	if eventTime.Before(startTime) || !eventTime.Before(endTime) || distribution.NodeAmount.Sign() == 0 {
+		return
+	}
+	response.Events = append(response.Events, income.Event{
+		Time:    eventTime,
+		Type:    eventType,
+		Asset:   income.Asset_Eth,
+		Amount:  distribution.NodeAmount,
+		Source:  source,
+		Block:   distribution.Block,
+		TxHash:  distribution.TxHash,
+		Claimed: true,
+	})
+}
@@ -0,0 +1,75 @@
+package node
+
+import (
+	"context"
+
+	"github.com/fatih/color"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Generate the current interval's rewards tree up to the latest finalized epoch from the local rolling record, and get the node's share of it
+func previewRewards(c *cli.Context) (*api.NodePreviewRewardsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodePreviewRewardsResponse{
+		RollingRecordsEnabled: cfg.Smartnode.UseRollingRecords.Value.(bool),
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.Registered, err = node.GetNodeExists(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !response.Registered || !response.RollingRecordsEnabled {
+		return &response, nil
+	}
+
+	// Generate the preview; the progress goes to stderr so it doesn't get mixed into the response
+	logger := log.NewColorLogger(color.FgHiCyan)
+	preview, err := rprewards.PreviewNodeRewards(context.Background(), &logger, cfg, rp, bc, nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	response.Preview = *preview
+
+	// Return response
+	return &response, nil
+
+}
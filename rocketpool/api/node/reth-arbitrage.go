@@ -0,0 +1,576 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/deposit"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/tokens"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/dex"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+const (
+	// The source name for minting or burning rETH with the protocol directly
+	protocolRethRoute string = "protocol"
+
+	// Gas used for each route when it can't be estimated for this node
+	rethDepositGasFallback uint64 = 200000
+	rethBurnGasFallback    uint64 = 100000
+	dexSwapGasFallback     uint64 = 250000
+)
+
+func getRethArbitrage(c *cli.Context, amountWei *big.Int, direction string) (*api.NodeRethArbitrageResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeRethArbitrageResponse{
+		Direction: direction,
+		Amount:    amountWei,
+		BestRoute: -1,
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the token addresses and the gas price to compare routes with
+	rethAddress, err := rp.GetAddress("rocketTokenRETH", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rETH address: %w", err)
+	}
+	ethAddress := common.HexToAddress(dex.NativeEthAddress)
+	gasPrice, err := rp.Client.SuggestGasPrice(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("error getting gas price: %w", err)
+	}
+
+	// Check the node's balance of the token being sold
+	var balance *big.Int
+	if direction == "deposit" {
+		balance, err = rp.Client.BalanceAt(context.Background(), nodeAccount.Address, nil)
+	} else {
+		balance, err = tokens.GetRETHBalance(rp, nodeAccount.Address, nil)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting node balance: %w", err)
+	}
+	response.InsufficientBalance = (amountWei.Cmp(balance) > 0)
+
+	// Get the protocol route
+	var protocolRoute api.RethArbitrageRoute
+	var protocolGasInfo rocketpool.GasInfo
+	if direction == "deposit" {
+		protocolRoute, protocolGasInfo, err = getProtocolDepositRoute(rp, amountWei, !response.InsufficientBalance, opts)
+	} else {
+		protocolRoute, protocolGasInfo, err = getProtocolBurnRoute(rp, amountWei, !response.InsufficientBalance, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	setRouteGasCost(rp, &protocolRoute, protocolGasInfo.EstGasLimit, gasPrice, direction)
+	response.Routes = append(response.Routes, protocolRoute)
+
+	// Get the DEX routes
+	sellToken, buyToken := ethAddress, *rethAddress
+	if direction == "withdraw" {
+		sellToken, buyToken = *rethAddress, ethAddress
+	}
+	gasInfos := []rocketpool.GasInfo{protocolGasInfo}
+	routers, err := dex.GetAllowedRouters(cfg.Smartnode.Network.Value.(cfgtypes.Network), cfg.Smartnode.RethDexRouters.Value.(string))
+	if err != nil {
+		return nil, err
+	}
+	for _, quoteUrl := range dex.ParseQuoteUrls(cfg.Smartnode.RethDexQuoteUrls.Value.(string)) {
+		quote, err := dex.GetQuote(quoteUrl, sellToken, buyToken, amountWei, nodeAccount.Address)
+		route := api.RethArbitrageRoute{
+			Source: quote.Source,
+		}
+		gasInfo := rocketpool.GasInfo{
+			EstGasLimit:  dexSwapGasFallback,
+			SafeGasLimit: dexSwapGasFallback,
+		}
+		if err != nil {
+			route.UnavailableReason = err.Error()
+		} else if _, exists := routers[quote.To]; direction == "deposit" && !exists {
+			route.UnavailableReason = fmt.Sprintf("the swap is sent to %s, which isn't a known DEX router", quote.To.Hex())
+		} else {
+			route.Available = true
+			route.Output = quote.BuyAmount
+			if quote.Gas > 0 {
+				gasInfo.EstGasLimit = quote.Gas
+				gasInfo.SafeGasLimit = quote.Gas
+			}
+
+			// Get a more accurate estimate for deposits the node can make
+			if direction == "deposit" && !response.InsufficientBalance {
+				opts.Value = quote.Value
+				estimate, err := eth.EstimateSendTransactionGas(rp.Client, quote.To, quote.Data, false, opts)
+				opts.Value = nil
+				if err == nil {
+					gasInfo = estimate
+				}
+			}
+		}
+		setRouteGasCost(rp, &route, gasInfo.EstGasLimit, gasPrice, direction)
+		response.Routes = append(response.Routes, route)
+		gasInfos = append(gasInfos, gasInfo)
+	}
+
+	// Find the route with the best output after gas
+	for i, route := range response.Routes {
+		if !route.Available {
+			continue
+		}
+		if response.BestRoute == -1 || route.NetOutput.Cmp(response.Routes[response.BestRoute].NetOutput) > 0 {
+			response.BestRoute = i
+		}
+	}
+
+	// Deposits can be routed through the best path if the node can afford them
+	if direction == "deposit" && response.BestRoute != -1 && !response.InsufficientBalance {
+		response.GasInfo = gasInfos[response.BestRoute]
+		response.CanRoute = true
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func routeRethDeposit(c *cli.Context, amountWei *big.Int, source string, minOutput *big.Int) (*api.NodeRouteRethDepositResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeRouteRethDepositResponse{}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	services.UsePrivateRelay(opts, services.PrivateTx_RethArbitrage)
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Deposit with the protocol directly; DEX swaps are previewed and sent with swapRethDeposit instead
+	if source != protocolRethRoute {
+		return nil, fmt.Errorf("only the protocol route can be used here; swaps through %s must be previewed first", source)
+	}
+	route, _, err := getProtocolDepositRoute(rp, amountWei, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !route.Available {
+		return nil, fmt.Errorf("cannot deposit with the protocol: %s", route.UnavailableReason)
+	}
+	if route.Output.Cmp(minOutput) < 0 {
+		return nil, fmt.Errorf("the protocol would only mint %.6f rETH, which is less than the confirmed %.6f rETH", eth.WeiToEth(route.Output), eth.WeiToEth(minOutput))
+	}
+	opts.Value = amountWei
+	hash, err := deposit.Deposit(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
+
+func getRethDepositSwap(c *cli.Context, amountWei *big.Int, source string) (*api.NodeRethDepositSwapResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeRethDepositSwapResponse{
+		Source: source,
+	}
+
+	// Get a fresh quote from the requested source
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	rethAddress, err := rp.GetAddress("rocketTokenRETH", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rETH address: %w", err)
+	}
+	var quote *dex.Quote
+	for _, quoteUrl := range dex.ParseQuoteUrls(cfg.Smartnode.RethDexQuoteUrls.Value.(string)) {
+		newQuote, err := dex.GetQuote(quoteUrl, common.HexToAddress(dex.NativeEthAddress), *rethAddress, amountWei, nodeAccount.Address)
+		if newQuote.Source != source {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		quote = &newQuote
+		break
+	}
+	if quote == nil {
+		return nil, fmt.Errorf("no quote URL is configured for source [%s]", source)
+	}
+	if quote.Value.Cmp(amountWei) > 0 {
+		return nil, fmt.Errorf("the quote from %s requires sending %.6f ETH, which is more than the requested %.6f ETH", source, eth.WeiToEth(quote.Value), eth.WeiToEth(amountWei))
+	}
+	response.To = quote.To
+	response.Value = quote.Value
+	response.Data = quote.Data
+	response.QuotedOutput = quote.BuyAmount
+	response.Call = dex.DecodeCall(quote.Data)
+
+	// Check the swap is to a trusted router and actually pays the node
+	response.RouterName, response.SimulatedOutput, err = checkRethDepositSwap(cfg, ec, *rethAddress, nodeAccount.Address, quote.To, quote.Value, quote.Data)
+	if err != nil {
+		return nil, fmt.Errorf("the swap from %s can't be used: %w", source, err)
+	}
+
+	// Get the gas estimate
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	opts.Value = quote.Value
+	gasInfo, err := eth.EstimateSendTransactionGas(rp.Client, quote.To, quote.Data, false, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error estimating gas for the swap from %s: %w", source, err)
+	}
+	response.GasInfo = gasInfo
+
+	// Return response
+	return &response, nil
+
+}
+
+func swapRethDeposit(c *cli.Context, value *big.Int, to common.Address, data []byte, minOutput *big.Int) (*api.NodeRouteRethDepositResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeRouteRethDepositResponse{}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	services.UsePrivateRelay(opts, services.PrivateTx_RethArbitrage)
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Check the confirmed swap again right before sending it, since the router's state could have changed since the preview
+	rethAddress, err := rp.GetAddress("rocketTokenRETH", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rETH address: %w", err)
+	}
+	_, simulatedOutput, err := checkRethDepositSwap(cfg, ec, *rethAddress, opts.From, to, value, data)
+	if err != nil {
+		return nil, err
+	}
+	if simulatedOutput.Cmp(minOutput) < 0 {
+		return nil, fmt.Errorf("the swap would now only pay %.6f rETH to the node, which is less than the confirmed %.6f rETH; please try again", eth.WeiToEth(simulatedOutput), eth.WeiToEth(minOutput))
+	}
+
+	// Send the swap
+	opts.Value = value
+	hash, err := eth.SendTransaction(rp.Client, to, w.GetChainID(), data, false, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error sending swap transaction: %w", err)
+	}
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
+
+// Check that a swap is sent to one of the allowed routers, then simulate it and get how much the node's rETH balance goes up by.
+// Returns the name of the router and the simulated rETH the node receives.
+func checkRethDepositSwap(cfg *config.RocketPoolConfig, ec *services.ExecutionClientManager, rethAddress common.Address, nodeAddress common.Address, to common.Address, value *big.Int, data []byte) (string, *big.Int, error) {
+	if to == (common.Address{}) || len(data) == 0 {
+		return "", nil, fmt.Errorf("there is no swap transaction")
+	}
+	routers, err := dex.GetAllowedRouters(cfg.Smartnode.Network.Value.(cfgtypes.Network), cfg.Smartnode.RethDexRouters.Value.(string))
+	if err != nil {
+		return "", nil, err
+	}
+	routerName, exists := routers[to]
+	if !exists {
+		return "", nil, fmt.Errorf("the swap is sent to %s, which isn't a known DEX router; if you trust it, you can add it to the extra rETH DEX routers in the Smartnode section of `rocketpool service config`", to.Hex())
+	}
+
+	// Check the node's rETH balance before and after the swap in the same simulated block
+	balanceData := append(crypto.Keccak256([]byte("balanceOf(address)"))[:4], common.LeftPadBytes(nodeAddress.Bytes(), 32)...)
+	balanceCall := ethereum.CallMsg{
+		From: nodeAddress,
+		To:   &rethAddress,
+		Data: balanceData,
+	}
+	results, err := ec.SimulateCalls(context.Background(), []ethereum.CallMsg{
+		balanceCall,
+		{
+			From:  nodeAddress,
+			To:    &to,
+			Value: value,
+			Data:  data,
+		},
+		balanceCall,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	if results[1].Error != "" {
+		return "", nil, fmt.Errorf("the simulated swap failed: %s", results[1].Error)
+	}
+	if results[0].Error != "" || results[2].Error != "" || len(results[0].ReturnData) != 32 || len(results[2].ReturnData) != 32 {
+		return "", nil, fmt.Errorf("couldn't get the node's rETH balance in the simulation")
+	}
+	before := big.NewInt(0).SetBytes(results[0].ReturnData)
+	after := big.NewInt(0).SetBytes(results[2].ReturnData)
+	output := big.NewInt(0).Sub(after, before)
+	if output.Sign() <= 0 {
+		return "", nil, fmt.Errorf("the simulated swap didn't send any rETH to the node")
+	}
+	return routerName, output, nil
+}
+
+// Get the route for minting rETH by depositing into the deposit pool
+func getProtocolDepositRoute(rp *rocketpool.RocketPool, amountWei *big.Int, estimateGas bool, opts *bind.TransactOpts) (api.RethArbitrageRoute, rocketpool.GasInfo, error) {
+	route := api.RethArbitrageRoute{
+		Source: protocolRethRoute,
+	}
+	gasInfo := rocketpool.GasInfo{
+		EstGasLimit:  rethDepositGasFallback,
+		SafeGasLimit: rethDepositGasFallback,
+	}
+
+	// Get the deposit settings
+	depositEnabled, err := protocol.GetDepositEnabled(rp, nil)
+	if err != nil {
+		return route, gasInfo, fmt.Errorf("error checking if deposits are enabled: %w", err)
+	}
+	minimumDeposit, err := protocol.GetMinimumDeposit(rp, nil)
+	if err != nil {
+		return route, gasInfo, fmt.Errorf("error getting minimum deposit: %w", err)
+	}
+	depositFee, err := protocol.GetDepositFee(rp, nil)
+	if err != nil {
+		return route, gasInfo, fmt.Errorf("error getting deposit fee: %w", err)
+	}
+
+	// Get the deposit pool's remaining capacity, which includes the queue if deposits can be assigned
+	maxPoolSize, err := protocol.GetMaximumDepositPoolSize(rp, nil)
+	if err != nil {
+		return route, gasInfo, fmt.Errorf("error getting maximum deposit pool size: %w", err)
+	}
+	assignEnabled, err := protocol.GetAssignDepositsEnabled(rp, nil)
+	if err != nil {
+		return route, gasInfo, fmt.Errorf("error checking if deposit assignments are enabled: %w", err)
+	}
+	poolBalance, err := deposit.GetBalance(rp, nil)
+	if err != nil {
+		return route, gasInfo, fmt.Errorf("error getting deposit pool balance: %w", err)
+	}
+	capacity := big.NewInt(0).Set(maxPoolSize)
+	if assignEnabled {
+		queueCapacity, err := minipool.GetQueueEffectiveCapacity(rp, nil)
+		if err != nil {
+			return route, gasInfo, fmt.Errorf("error getting minipool queue capacity: %w", err)
+		}
+		capacity.Add(capacity, queueCapacity)
+	}
+	capacity.Sub(capacity, poolBalance)
+
+	// Get the rETH minted after the deposit fee
+	fee := big.NewInt(0).Mul(amountWei, depositFee)
+	fee.Div(fee, eth.EthToWei(1))
+	output, err := tokens.GetRETHValueOfETH(rp, big.NewInt(0).Sub(amountWei, fee), nil)
+	if err != nil {
+		return route, gasInfo, fmt.Errorf("error getting rETH value of deposit: %w", err)
+	}
+	route.Output = output
+
+	// Check if the deposit can be made
+	switch {
+	case !depositEnabled:
+		route.UnavailableReason = "deposits are currently disabled"
+	case amountWei.Cmp(minimumDeposit) < 0:
+		route.UnavailableReason = fmt.Sprintf("the minimum deposit is %.6f ETH", eth.WeiToEth(minimumDeposit))
+	case amountWei.Cmp(capacity) > 0:
+		route.UnavailableReason = fmt.Sprintf("the deposit pool only has room for %.6f ETH", eth.WeiToEth(capacity))
+	default:
+		route.Available = true
+	}
+
+	// Estimate the gas for the deposit
+	if route.Available && estimateGas {
+		opts.Value = amountWei
+		estimate, err := deposit.EstimateDepositGas(rp, opts)
+		opts.Value = nil
+		if err == nil {
+			gasInfo = estimate
+		}
+	}
+	return route, gasInfo, nil
+}
+
+// Get the route for burning rETH for ETH with the protocol
+func getProtocolBurnRoute(rp *rocketpool.RocketPool, amountWei *big.Int, estimateGas bool, opts *bind.TransactOpts) (api.RethArbitrageRoute, rocketpool.GasInfo, error) {
+	route := api.RethArbitrageRoute{
+		Source: protocolRethRoute,
+	}
+	gasInfo := rocketpool.GasInfo{
+		EstGasLimit:  rethBurnGasFallback,
+		SafeGasLimit: rethBurnGasFallback,
+	}
+
+	output, err := tokens.GetETHValueOfRETH(rp, amountWei, nil)
+	if err != nil {
+		return route, gasInfo, fmt.Errorf("error getting ETH value of rETH: %w", err)
+	}
+	route.Output = output
+
+	collateral, err := tokens.GetRETHTotalCollateral(rp, nil)
+	if err != nil {
+		return route, gasInfo, fmt.Errorf("error getting rETH collateral: %w", err)
+	}
+	if output.Cmp(collateral) > 0 {
+		route.UnavailableReason = fmt.Sprintf("there is only %.6f ETH of rETH collateral available", eth.WeiToEth(collateral))
+	} else {
+		route.Available = true
+	}
+
+	if route.Available && estimateGas {
+		estimate, err := tokens.EstimateBurnRETHGas(rp, amountWei, opts)
+		if err == nil {
+			gasInfo = estimate
+		}
+	}
+	return route, gasInfo, nil
+}
+
+// Set the gas cost of a route and its output after gas, denominated in the token being bought
+func setRouteGasCost(rp *rocketpool.RocketPool, route *api.RethArbitrageRoute, gasLimit uint64, gasPrice *big.Int, direction string) {
+	route.GasCost = big.NewInt(0).Mul(gasPrice, big.NewInt(0).SetUint64(gasLimit))
+	if !route.Available {
+		return
+	}
+
+	gasCostInOutput := route.GasCost
+	if direction == "deposit" {
+		rethCost, err := tokens.GetRETHValueOfETH(rp, route.GasCost, nil)
+		if err == nil {
+			gasCostInOutput = rethCost
+		}
+	}
+	route.NetOutput = big.NewInt(0).Sub(route.Output, gasCostInOutput)
+}
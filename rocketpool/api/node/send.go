@@ -223,6 +223,7 @@ func nodeSend(c *cli.Context, amountWei *big.Int, token string, to common.Addres
 	if err != nil {
 		return nil, err
 	}
+	services.UsePrivateRelay(opts, services.PrivateTx_Send)
 
 	// Override the provided pending TX if requested
 	err = eth1.CheckForNonceOverride(c, opts)
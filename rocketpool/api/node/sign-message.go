@@ -1,28 +1,159 @@
 package node
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
+	"time"
 	_ "time/tzdata"
 
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/goccy/go-json"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
 	hexutils "github.com/rocket-pool/smartnode/shared/utils/hex"
 )
 
-func signMessage(c *cli.Context, message string) (*api.NodeSignResponse, error) {
+// Settings
+const (
+	// How long a confirmation code for signing a message can be used for
+	signRequestTimeout time.Duration = 5 * time.Minute
+
+	// The length of a confirmation code, in bytes
+	signRequestCodeLength int = 4
+
+	signRequestFileMode os.FileMode = 0600
+)
+
+// A message waiting for its signature to be confirmed
+type signRequest struct {
+	Code        string    `json:"code"`
+	MessageHash string    `json:"messageHash"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
+
+// Make sure the user has opted in to signing messages with the node key
+func requireMessageSigning(cfg *config.RocketPoolConfig) error {
+	if !cfg.Smartnode.EnableMessageSigning.Value.(bool) {
+		return errors.New("Message signing is disabled. If you want to sign messages with your node's private key, turn on the Enable Message Signing setting in the Smartnode section of `rocketpool service config` first")
+	}
+	return nil
+}
+
+// Issue a one-time confirmation code for signing a message. Only the latest request is kept, so issuing a new one cancels the last one.
+func requestSignMessage(c *cli.Context, message string) (*api.NodeRequestSignMessageResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireMessageSigning(cfg); err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeRequestSignMessageResponse{}
+
+	// Create the code
+	codeBytes := make([]byte, signRequestCodeLength)
+	if _, err := rand.Read(codeBytes); err != nil {
+		return nil, fmt.Errorf("Error generating confirmation code: %w", err)
+	}
+	messageHash := sha256.Sum256([]byte(message))
+	request := signRequest{
+		Code:        strings.ToUpper(hex.EncodeToString(codeBytes)),
+		MessageHash: hex.EncodeToString(messageHash[:]),
+		ExpiresAt:   time.Now().Add(signRequestTimeout),
+	}
+
+	// Save it
+	bytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("Error serializing sign request: %w", err)
+	}
+	path := cfg.Smartnode.GetSignRequestPath()
+	if err := files.WriteFileAtomic(path, bytes, signRequestFileMode); err != nil {
+		return nil, fmt.Errorf("Error saving sign request to [%s]: %w", path, err)
+	}
+	response.Code = request.Code
+	response.ExpiresAt = request.ExpiresAt
+
+	// Return response
+	return &response, nil
+
+}
+
+// Use up the pending sign request, making sure it was issued for the message and code.
+// The request is removed whether or not it matches, so a code can't be guessed.
+func useSignRequest(path string, code string, message string) error {
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return errors.New("No signature has been requested for this message; please run `rocketpool node sign-message` to sign it")
+	}
+	if err != nil {
+		return fmt.Errorf("Error reading sign request [%s]: %w", path, err)
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("Error removing sign request [%s]: %w", path, err)
+	}
+
+	var request signRequest
+	if err := json.Unmarshal(bytes, &request); err != nil {
+		return fmt.Errorf("Error deserializing sign request: %w", err)
+	}
+	messageHash := sha256.Sum256([]byte(message))
+	if time.Now().After(request.ExpiresAt) {
+		return errors.New("The confirmation code has expired; please run `rocketpool node sign-message` again")
+	}
+	if subtle.ConstantTimeCompare([]byte(strings.ToUpper(code)), []byte(request.Code)) != 1 || request.MessageHash != hex.EncodeToString(messageHash[:]) {
+		return errors.New("The confirmation code doesn't match the message it was issued for; please run `rocketpool node sign-message` again")
+	}
+	return nil
+}
+
+func signMessage(c *cli.Context, code string, message string) (*api.NodeSignResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireMessageSigning(cfg); err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
 	}
 
+	// Signatures can prove ownership of the node to anyone, so they need the one-time code issued for the message
+	if err := useSignRequest(cfg.Smartnode.GetSignRequestPath(), code, message); err != nil {
+		return nil, err
+	}
+
 	// Response
 	response := api.NodeSignResponse{}
 	signedBytes, err := w.SignMessage(message)
 	if err != nil {
-		return nil, fmt.Errorf("Error signing message [%s]: %w", message, err)
+		return nil, fmt.Errorf("Error signing message: %w", err)
 	}
 	response.SignedData = hexutils.AddPrefix(hex.EncodeToString(signedBytes))
 
@@ -30,3 +161,29 @@ func signMessage(c *cli.Context, message string) (*api.NodeSignResponse, error)
 	return &response, nil
 
 }
+
+func verifyMessage(c *cli.Context, address common.Address, message string, signature []byte) (*api.NodeVerifyMessageResponse, error) {
+
+	// Response
+	response := api.NodeVerifyMessageResponse{}
+
+	// Recover the signer of the EIP-191 hash of the message
+	if len(signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf("Invalid signature length: expected %d bytes but got %d.", crypto.SignatureLength, len(signature))
+	}
+	sig := make([]byte, crypto.SignatureLength)
+	copy(sig, signature)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	pubkey, err := crypto.SigToPub(accounts.TextHash([]byte(message)), sig)
+	if err != nil {
+		return nil, fmt.Errorf("Error recovering the signer: %w", err)
+	}
+	response.Signer = crypto.PubkeyToAddress(*pubkey)
+	response.Valid = (response.Signer == address)
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,139 @@
+package node
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	hexutils "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+func prepareOfflineTransaction(c *cli.Context, from common.Address, to common.Address, amountWei *big.Int, data []byte) (*api.NodePrepareOfflineTransactionResponse, error) {
+
+	// Get services
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodePrepareOfflineTransactionResponse{}
+
+	// Get the chain ID and the sender's next nonce
+	response.ChainID = uint64(cfg.Smartnode.GetChainID())
+	response.PendingNonce, err = ec.PendingNonceAt(context.Background(), from)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting the pending nonce for %s: %w", from.Hex(), err)
+	}
+
+	// Get gas estimate as the sender, without needing its key
+	opts := &bind.TransactOpts{
+		From:  from,
+		Value: amountWei,
+	}
+	gasInfo, err := eth.EstimateSendTransactionGas(ec, to, data, true, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error estimating gas for the transaction: %w", err)
+	}
+	response.GasInfo = gasInfo
+
+	// Return response
+	return &response, nil
+
+}
+
+func signOfflineTransaction(c *cli.Context, serializedTx string) (*api.NodeSignOfflineTransactionResponse, error) {
+
+	// Get services; the EC isn't needed, since this runs on an air-gapped machine
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeSignOfflineTransactionResponse{}
+
+	serializedTx = hexutils.RemovePrefix(serializedTx)
+	bytes, err := hex.DecodeString(serializedTx)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing TX bytes [%s]: %w", serializedTx, err)
+	}
+
+	// Make sure it's for the network this wallet signs for, or the signature won't be valid
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(bytes); err != nil {
+		return nil, fmt.Errorf("Error parsing the transaction: %w", err)
+	}
+	if tx.ChainId().Cmp(w.GetChainID()) != 0 {
+		return nil, fmt.Errorf("The transaction is for chain %s, but this node is set up for chain %s. Please set this machine to the same network as the online node.", tx.ChainId().String(), w.GetChainID().String())
+	}
+
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.From = nodeAccount.Address
+	signedBytes, err := w.Sign(bytes)
+	if err != nil {
+		return nil, fmt.Errorf("Error signing TX [%s]: %w", serializedTx, err)
+	}
+	response.SignedTx = hexutils.AddPrefix(hex.EncodeToString(signedBytes))
+
+	// Return response
+	return &response, nil
+
+}
+
+func broadcastOfflineTransaction(c *cli.Context, serializedTx string) (*api.NodeBroadcastOfflineTransactionResponse, error) {
+
+	// Get services
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeBroadcastOfflineTransactionResponse{}
+
+	serializedTx = hexutils.RemovePrefix(serializedTx)
+	bytes, err := hex.DecodeString(serializedTx)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing TX bytes [%s]: %w", serializedTx, err)
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(bytes); err != nil {
+		return nil, fmt.Errorf("Error parsing the transaction: %w", err)
+	}
+
+	// Send it
+	if err := ec.SendTransaction(context.Background(), tx); err != nil {
+		return nil, fmt.Errorf("Error broadcasting the transaction: %w", err)
+	}
+	response.TxHash = tx.Hash()
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,177 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/deposit"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/settings/trustednode"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func simulateNodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt *big.Int) (*api.NodeSimulateDepositResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeSimulateDepositResponse{}
+	addCheck := func(name string, passed bool, message string, args ...interface{}) {
+		response.Checks = append(response.Checks, api.DepositPreflightCheck{
+			Name:    name,
+			Passed:  passed,
+			Message: fmt.Sprintf(message, args...),
+		})
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Run the regular deposit checks, which also adjust the salt and estimate the gas
+	canDeposit, err := canNodeDeposit(c, amountWei, minNodeFee, salt)
+	if err != nil {
+		addCheck("Deposit transaction", false, "Simulating the deposit failed: %s", err.Error())
+	} else {
+		response.Deposit = *canDeposit
+		if canDeposit.DepositDisabled {
+			addCheck("Node deposits", false, "Node deposits are currently disabled")
+		} else {
+			addCheck("Node deposits", true, "Node deposits are enabled")
+		}
+		balanceOk := !(canDeposit.InsufficientBalance || canDeposit.InsufficientBalanceWithoutCredit)
+		addCheck("ETH balance", balanceOk, "The node has %.6f ETH and a credit balance of %.6f ETH for a %.6f ETH bond", eth.WeiToEth(canDeposit.NodeBalance), eth.WeiToEth(canDeposit.CreditBalance), eth.WeiToEth(amountWei))
+		if canDeposit.InsufficientRplStake {
+			addCheck("RPL collateral", false, "The node has not staked enough RPL to collateralize a new minipool with a %.0f ETH bond", eth.WeiToEth(amountWei))
+		} else {
+			addCheck("RPL collateral", true, "The node's RPL stake can collateralize the new minipool")
+		}
+		if canDeposit.CanDeposit {
+			gasPrice, err := rp.Client.SuggestGasPrice(context.Background())
+			if err != nil {
+				return nil, fmt.Errorf("error getting gas price: %w", err)
+			}
+			gasCost := big.NewInt(0).Mul(gasPrice, big.NewInt(0).SetUint64(canDeposit.GasInfo.EstGasLimit))
+			addCheck("Gas estimate", true, "The deposit should use about %d gas (roughly %.6f ETH at the current gas price)", canDeposit.GasInfo.EstGasLimit, eth.WeiToEth(gasCost))
+		}
+	}
+
+	// Check the deposit pool and queue
+	depositPoolBalance, err := deposit.GetBalance(rp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting deposit pool balance: %w", err)
+	}
+	queueLength, err := minipool.GetQueueTotalLength(rp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool queue length: %w", err)
+	}
+	queueCapacity, err := minipool.GetQueueEffectiveCapacity(rp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool queue capacity: %w", err)
+	}
+	assignEnabled, err := protocol.GetAssignDepositsEnabled(rp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error checking if deposit assignments are enabled: %w", err)
+	}
+	matchRequest := big.NewInt(0).Sub(eth.EthToWei(ValidatorEth), amountWei)
+	neededForMatch := big.NewInt(0).Add(queueCapacity, matchRequest)
+	if assignEnabled && depositPoolBalance.Cmp(neededForMatch) >= 0 {
+		addCheck("Deposit pool capacity", true, "The deposit pool has %.6f ETH, so the new minipool should be matched right away", eth.WeiToEth(depositPoolBalance))
+	} else {
+		// Not being matched right away is normal, so this doesn't block the deposit
+		addCheck("Deposit pool capacity", true, "The deposit pool has %.6f ETH but %.6f ETH is needed to reach the new minipool, so it will wait in the queue until more ETH is deposited", eth.WeiToEth(depositPoolBalance), eth.WeiToEth(neededForMatch))
+	}
+	addCheck("Queue position", true, "The new minipool will be number %d in the queue", queueLength+1)
+
+	// Check the next validator key
+	validatorKey, err := w.GetNextValidatorKey()
+	if err != nil {
+		addCheck("Validator key", false, "The next validator key couldn't be derived: %s", err.Error())
+	} else {
+		pubkey := rptypes.BytesToValidatorPubkey(validatorKey.PublicKey().Marshal())
+		response.ValidatorPubkey = pubkey
+		existingMinipool, err := minipool.GetMinipoolByPubkey(rp, pubkey, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error checking if pubkey %s is in use: %w", pubkey.Hex(), err)
+		}
+		if existingMinipool != (common.Address{}) {
+			addCheck("Validator key", false, "The next validator key %s is already used by minipool %s; please run `rocketpool wallet recover` to resync your wallet", pubkey.Hex(), existingMinipool.Hex())
+		} else {
+			addCheck("Validator key", true, "The new minipool will use validator key %s", pubkey.Hex())
+		}
+
+		// An existing Beacon deposit for the key would mean its withdrawal credentials have already been set by someone else
+		status, err := bc.GetValidatorStatus(pubkey, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting Beacon status of pubkey %s: %w", pubkey.Hex(), err)
+		}
+		if status.Exists {
+			addCheck("Front-running protection", false, "Validator key %s already has a deposit on the Beacon Chain with withdrawal credentials %s", pubkey.Hex(), status.WithdrawalCredentials.Hex())
+		} else {
+			scrubPeriod, err := trustednode.GetScrubPeriod(rp, nil)
+			if err != nil {
+				return nil, fmt.Errorf("error getting scrub period: %w", err)
+			}
+			addCheck("Front-running protection", true, "The validator key has no existing Beacon deposit; the Oracle DAO will verify its withdrawal credentials during the %s scrub period", time.Duration(scrubPeriod)*time.Second)
+		}
+	}
+
+	// Check the minipool address
+	if canDeposit != nil {
+		exists, err := minipool.GetMinipoolExists(rp, canDeposit.MinipoolAddress, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error checking if minipool %s exists: %w", canDeposit.MinipoolAddress.Hex(), err)
+		}
+		addCheck("Minipool address", !exists, "The new minipool will be created at %s", canDeposit.MinipoolAddress.Hex())
+	}
+
+	// Check the fee distributor
+	isInitialized, err := node.GetFeeDistributorInitialized(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error checking if the fee distributor is initialized: %w", err)
+	}
+	if isInitialized {
+		addCheck("Fee distributor", true, "The node's fee distributor is initialized")
+	} else {
+		addCheck("Fee distributor", false, "The node's fee distributor has not been initialized; please run `rocketpool node initialize-fee-distributor` first")
+	}
+
+	// Get the verdict
+	response.Go = true
+	for _, check := range response.Checks {
+		if !check.Passed {
+			response.Go = false
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
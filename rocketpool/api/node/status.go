@@ -222,7 +222,7 @@ func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
 
 	// Get node minipool counts
 	wg.Go(func() error {
-		details, err := getNodeMinipoolCountDetails(rp, nodeAccount.Address)
+		details, err := getNodeMinipoolCountDetails(rp, cfg, bc, nodeAccount.Address)
 		if err == nil {
 			response.MinipoolCounts.Total = len(details)
 			for _, mpDetails := range details {
@@ -0,0 +1,86 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/readiness"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Get the node's sync committee assignments for the current and next periods, and check that it's ready for them
+func getSyncCommittee(c *cli.Context) (*api.NodeSyncCommitteeResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeSyncCommitteeResponse{}
+
+	// Get the node's validator indices
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	pubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool pubkeys: %w", err)
+	}
+	statuses, err := bc.GetValidatorStatuses(pubkeys, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting validator statuses: %w", err)
+	}
+	indices := []string{}
+	for _, status := range statuses {
+		if status.Exists {
+			indices = append(indices, status.Index)
+		}
+	}
+
+	// Get the assignments
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, err
+	}
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return nil, fmt.Errorf("error getting the Beacon head: %w", err)
+	}
+	response.CurrentEpoch = head.Epoch
+	response.CurrentPeriod, response.NextPeriod, err = readiness.GetSyncCommitteePeriods(bc, eth2Config, indices, head.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the node's health
+	response.Checks = readiness.CheckDutyReadiness(cfg, ec, bc)
+
+	// Return response
+	return &response, nil
+
+}
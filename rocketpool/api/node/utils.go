@@ -1,18 +1,17 @@
 package node
 
 import (
-	"context"
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/types"
-	"golang.org/x/sync/errgroup"
-)
 
-// Settings
-const MinipoolCountDetailsBatchSize = 10
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+)
 
 // Minipool count details
 type minipoolCountDetails struct {
@@ -25,122 +24,35 @@ type minipoolCountDetails struct {
 	Penalties           uint64
 }
 
-// Get all node minipool count details
-func getNodeMinipoolCountDetails(rp *rocketpool.RocketPool, nodeAddress common.Address) ([]minipoolCountDetails, error) {
-
-	// Data
-	var wg1 errgroup.Group
-	var addresses []common.Address
-	var currentBlock uint64
-
-	// Get minipool addresses
-	wg1.Go(func() error {
-		var err error
-		addresses, err = minipool.GetNodeMinipoolAddresses(rp, nodeAddress, nil)
-		return err
-	})
-
-	// Get current block
-	wg1.Go(func() error {
-		header, err := rp.Client.HeaderByNumber(context.Background(), nil)
-		if err == nil {
-			currentBlock = header.Number.Uint64()
-		}
-		return err
-	})
-
-	// Wait for data
-	if err := wg1.Wait(); err != nil {
-		return []minipoolCountDetails{}, err
-	}
-
-	// Load details in batches
-	details := make([]minipoolCountDetails, len(addresses))
-	for bsi := 0; bsi < len(addresses); bsi += MinipoolCountDetailsBatchSize {
-
-		// Get batch start & end index
-		msi := bsi
-		mei := bsi + MinipoolCountDetailsBatchSize
-		if mei > len(addresses) {
-			mei = len(addresses)
-		}
-
-		// Load details
-		var wg errgroup.Group
-		for mi := msi; mi < mei; mi++ {
-			mi := mi
-			wg.Go(func() error {
-				address := addresses[mi]
-				mpDetails, err := getMinipoolCountDetails(rp, address, currentBlock)
-				if err == nil {
-					details[mi] = mpDetails
-				}
-				return err
-			})
-		}
-		if err := wg.Wait(); err != nil {
-			return []minipoolCountDetails{}, err
-		}
+// Get all node minipool count details from the node's network state, which only loads the node's own minipools
+func getNodeMinipoolCountDetails(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client, nodeAddress common.Address) ([]minipoolCountDetails, error) {
 
+	// Get the node's network state
+	mgr, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating network state manager: %w", err)
 	}
-
-	// Return
-	return details, nil
-
-}
-
-// Get a minipool's count details
-func getMinipoolCountDetails(rp *rocketpool.RocketPool, minipoolAddress common.Address, currentBlock uint64) (minipoolCountDetails, error) {
-
-	// Create minipool
-	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
+	networkState, _, err := mgr.GetHeadStateForNode(nodeAddress, false)
 	if err != nil {
-		return minipoolCountDetails{}, err
+		return nil, fmt.Errorf("error getting network state: %w", err)
 	}
 
-	// Data
-	var wg errgroup.Group
-	var status types.MinipoolStatus
-	var refundBalance *big.Int
-	var finalised bool
-	var penaltyCount uint64
-
-	// Load data
-	wg.Go(func() error {
-		var err error
-		status, err = mp.GetStatus(nil)
-		return err
-	})
-	wg.Go(func() error {
-		var err error
-		refundBalance, err = mp.GetNodeRefundBalance(nil)
-		return err
-	})
-	wg.Go(func() error {
-		var err error
-		finalised, err = mp.GetFinalised(nil)
-		return err
-	})
-	wg.Go(func() error {
-		var err error
-		penaltyCount, err = minipool.GetMinipoolPenaltyCount(rp, minipoolAddress, nil)
-		return err
-	})
-
-	// Wait for data
-	if err := wg.Wait(); err != nil {
-		return minipoolCountDetails{}, err
+	// Get the details of each minipool
+	minipools := networkState.MinipoolDetailsByNode[nodeAddress]
+	details := make([]minipoolCountDetails, len(minipools))
+	for i, mpd := range minipools {
+		details[i] = minipoolCountDetails{
+			Address:             mpd.MinipoolAddress,
+			Status:              mpd.Status,
+			RefundAvailable:     (mpd.NodeRefundBalance.Cmp(big.NewInt(0)) > 0),
+			WithdrawalAvailable: (mpd.Status == types.Withdrawable),
+			CloseAvailable:      (mpd.Status == types.Dissolved),
+			Finalised:           mpd.Finalised,
+			Penalties:           mpd.PenaltyCount.Uint64(),
+		}
 	}
 
 	// Return
-	return minipoolCountDetails{
-		Address:             minipoolAddress,
-		Status:              status,
-		RefundAvailable:     (refundBalance.Cmp(big.NewInt(0)) > 0),
-		WithdrawalAvailable: (status == types.Withdrawable),
-		CloseAvailable:      (status == types.Dissolved),
-		Finalised:           finalised,
-		Penalties:           penaltyCount,
-	}, nil
+	return details, nil
 
 }
@@ -0,0 +1,79 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Get the node's minipools and their validators from the network state, using the snapshot saved by the node process unless a refresh is requested
+func getValidators(c *cli.Context, refresh bool) (*api.NodeValidatorsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeValidatorsResponse{
+		Validators: []api.NodeValidatorDetails{},
+	}
+
+	// Get the node's state
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	mgr, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, nil)
+	if err != nil {
+		return nil, err
+	}
+	networkState, _, cachedTime, err := mgr.GetCachedHeadStateForNode(nodeAccount.Address, false, refresh)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network state: %w", err)
+	}
+	response.ElBlockNumber = networkState.ElBlockNumber
+	response.BeaconSlotNumber = networkState.BeaconSlotNumber
+	response.Cached = !cachedTime.IsZero()
+	response.CachedTime = cachedTime
+
+	for _, mpd := range networkState.MinipoolDetailsByNode[nodeAccount.Address] {
+		validator := api.NodeValidatorDetails{
+			MinipoolAddress:  mpd.MinipoolAddress,
+			MinipoolStatus:   mpd.Status,
+			Pubkey:           mpd.Pubkey,
+			NodeShareOfTotal: mpd.NodeShareOfBalanceIncludingBeacon,
+		}
+		status, exists := networkState.ValidatorDetails[mpd.Pubkey]
+		if exists && status.Exists {
+			validator.Index = status.Index
+			validator.ValidatorStatus = string(status.Status)
+			validator.BeaconBalance = status.Balance
+		}
+		response.Validators = append(response.Validators, validator)
+	}
+
+	// Return response
+	return &response, nil
+
+}
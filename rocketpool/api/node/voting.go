@@ -1,8 +1,10 @@
 package node
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
 	"net/url"
 	"strings"
@@ -11,6 +13,8 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/goccy/go-json"
 	"github.com/urfave/cli"
 
@@ -343,6 +347,7 @@ func GetSnapshotProposals(apiDomain string, space string, state string) (*api.Sn
 	proposals(where: {space: "%s"%s}, orderBy: "created", orderDirection: desc) {
 	    id
 	    title
+	    type
 	    choices
 	    start
 	    end
@@ -381,3 +386,102 @@ func GetSnapshotProposals(apiDomain string, space string, state string) (*api.Sn
 
 	return &snapshotResponse, nil
 }
+
+// Create the EIP-712 typed data for a single-choice Snapshot vote
+func CreateSnapshotVoteTypedData(space string, proposalId string, choice uint64, voter common.Address, timestamp int64) apitypes.TypedData {
+	// Proposal IDs are bytes32 hashes, but very old proposals used IPFS hashes instead
+	proposalType := "bytes32"
+	if !strings.HasPrefix(proposalId, "0x") {
+		proposalType = "string"
+	}
+
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": []apitypes.Type{
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+			},
+			"Vote": []apitypes.Type{
+				{Name: "from", Type: "address"},
+				{Name: "space", Type: "string"},
+				{Name: "timestamp", Type: "uint64"},
+				{Name: "proposal", Type: proposalType},
+				{Name: "choice", Type: "uint32"},
+				{Name: "reason", Type: "string"},
+				{Name: "app", Type: "string"},
+				{Name: "metadata", Type: "string"},
+			},
+		},
+		PrimaryType: "Vote",
+		Domain: apitypes.TypedDataDomain{
+			Name:    "snapshot",
+			Version: "0.1.4",
+		},
+		Message: apitypes.TypedDataMessage{
+			"from":      voter.Hex(),
+			"space":     space,
+			"timestamp": math.NewHexOrDecimal256(timestamp),
+			"proposal":  proposalId,
+			"choice":    math.NewHexOrDecimal256(int64(choice)),
+			"reason":    "",
+			"app":       "smartnode",
+			"metadata":  "{}",
+		},
+	}
+}
+
+// Submit a signed vote to the Snapshot sequencer, returning the ID of the vote
+func SubmitSnapshotVote(sequencerDomain string, voter common.Address, signature []byte, typedData apitypes.TypedData) (string, error) {
+	// Snapshot expects the types without the domain type, and plain numbers in the message
+	types := apitypes.Types{}
+	for name, fields := range typedData.Types {
+		if name != "EIP712Domain" {
+			types[name] = fields
+		}
+	}
+	message := map[string]interface{}{}
+	for key, value := range typedData.Message {
+		if number, ok := value.(*math.HexOrDecimal256); ok {
+			value = (*big.Int)(number)
+		}
+		message[key] = value
+	}
+	envelope := map[string]interface{}{
+		"address": voter.Hex(),
+		"sig":     fmt.Sprintf("0x%x", signature),
+		"data": map[string]interface{}{
+			"domain":  typedData.Domain,
+			"types":   types,
+			"message": message,
+		},
+	}
+	requestBody, err := json.Marshal(envelope)
+	if err != nil {
+		return "", fmt.Errorf("could not serialize snapshot vote: %w", err)
+	}
+
+	client := getHttpClientWithTimeout()
+	resp, err := client.Post(fmt.Sprintf("https://%s/", sequencerDomain), "application/json", bytes.NewReader(requestBody))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	// Get response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	var voteResponse struct {
+		ID               string `json:"id"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.Unmarshal(body, &voteResponse); err != nil {
+		return "", fmt.Errorf("could not decode snapshot vote response (code %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || voteResponse.Error != "" {
+		return "", fmt.Errorf("snapshot rejected the vote (code %d): %s %s", resp.StatusCode, voteResponse.Error, voteResponse.ErrorDescription)
+	}
+	return voteResponse.ID, nil
+}
@@ -0,0 +1,33 @@
+package odao
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/balances"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getBalancesReport(c *cli.Context) (*api.TNDAOBalancesReportResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.TNDAOBalancesReportResponse{}
+
+	// Get the latest report
+	report, err := balances.LoadLatestReport(cfg.Smartnode.GetBalancesReportsFolder(true), 0)
+	if err != nil {
+		return nil, err
+	}
+	response.ReportExists = (report != nil)
+	response.Report = report
+
+	// Return response
+	return &response, nil
+
+}
@@ -1053,6 +1053,82 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "balances-report",
+				Usage:     "Get the latest report of the changes in network balances calculated by the watchtower",
+				UsageText: "rocketpool api odao balances-report",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getBalancesReport(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "can-import-rewards-tree",
+				Usage:     "Check whether the node can submit a rewards tree that was generated on another machine",
+				UsageText: "rocketpool api odao can-import-rewards-tree index",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					index, err := cliutils.ValidateUint("index", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canImportRewardsTree(c, index))
+					return nil
+
+				},
+			},
+			{
+				Name:      "import-rewards-tree",
+				Usage:     "Submit a rewards tree that was generated on another machine",
+				UsageText: "rocketpool api odao import-rewards-tree index",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					index, err := cliutils.ValidateUint("index", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(importRewardsTree(c, index))
+					return nil
+
+				},
+			},
+			{
+				Name:      "pinning-status",
+				Usage:     "Get the pinning status of the rewards files submitted by the watchtower",
+				UsageText: "rocketpool api odao pinning-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getPinningStatus(c))
+					return nil
+
+				},
+			},
 			{
 				Name:      "get-minipool-settings",
 				Usage:     "Get the ODAO settings related to minipools",
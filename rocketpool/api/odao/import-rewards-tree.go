@@ -0,0 +1,185 @@
+package odao
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+func canImportRewardsTree(c *cli.Context, index uint64) (*api.CanImportRewardsTreeResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeTrusted(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanImportRewardsTreeResponse{}
+
+	// Make sure the tree is for the interval being submitted
+	currentIndex, err := rewards.GetRewardIndex(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.CurrentIndex = currentIndex.Uint64()
+	if index != response.CurrentIndex {
+		response.WrongInterval = true
+		return &response, nil
+	}
+
+	// Check if the node has already submitted a tree for it
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.AlreadySubmitted, err = rewards.GetTrustedNodeSubmitted(rp, nodeAccount.Address, index, nil)
+	if err != nil {
+		return nil, err
+	}
+	if response.AlreadySubmitted {
+		return &response, nil
+	}
+
+	// Check the files
+	submission, err := getImportedSubmission(cfg, bc, index)
+	if err != nil {
+		response.InvalidReason = err.Error()
+		return &response, nil
+	}
+
+	// Get gas estimate
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	gasInfo, err := rewards.EstimateSubmitRewardSnapshotGas(rp, submission, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Could not estimate the gas required to submit the rewards tree: %w", err)
+	}
+	response.GasInfo = gasInfo
+
+	// Update & return response
+	response.CanImport = true
+	return &response, nil
+
+}
+
+func importRewardsTree(c *cli.Context, index uint64) (*api.ImportRewardsTreeResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeTrusted(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ImportRewardsTreeResponse{}
+
+	// Check the files again, in case they changed since they were checked
+	submission, err := getImportedSubmission(cfg, bc, index)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Submit the tree
+	hash, err := rewards.SubmitRewardSnapshot(rp, submission, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
+
+// Load the imported payload and rewards files for an interval, make sure they match each other and the chain, and build the submission from them
+func getImportedSubmission(cfg *config.RocketPoolConfig, bc beacon.Client, index uint64) (rewards.RewardSubmission, error) {
+
+	payload, err := rprewards.LoadSubmissionPayload(cfg.Smartnode.GetRewardsSubmissionPath(index, true))
+	if err != nil {
+		return rewards.RewardSubmission{}, err
+	}
+	if payload.RewardIndex != index {
+		return rewards.RewardSubmission{}, fmt.Errorf("the submission payload is for interval %d", payload.RewardIndex)
+	}
+
+	// Check the files against the payload
+	rewardsTreePath := cfg.Smartnode.GetRewardsTreePath(index, true)
+	localRewardsFile, err := rprewards.ReadLocalRewardsFile(rewardsTreePath)
+	if err != nil {
+		return rewards.RewardSubmission{}, err
+	}
+	rewardsFile := localRewardsFile.Impl()
+	compressedMinipoolPerformancePath := cfg.Smartnode.GetMinipoolPerformancePath(index, true) + config.RewardsTreeIpfsExtension
+	err = payload.Verify(rewardsFile, rewardsTreePath+config.RewardsTreeIpfsExtension, compressedMinipoolPerformancePath)
+	if err != nil {
+		return rewards.RewardSubmission{}, err
+	}
+
+	// Make sure the snapshot blocks match this node's view of the chain
+	block, exists, err := bc.GetBeaconBlock(fmt.Sprint(payload.ConsensusBlock))
+	if err != nil {
+		return rewards.RewardSubmission{}, fmt.Errorf("error getting Beacon block %d: %w", payload.ConsensusBlock, err)
+	}
+	if !exists {
+		return rewards.RewardSubmission{}, fmt.Errorf("Beacon block %d doesn't exist on this node's chain", payload.ConsensusBlock)
+	}
+	if block.ExecutionBlockNumber != payload.ExecutionBlock {
+		return rewards.RewardSubmission{}, fmt.Errorf("Beacon block %d has EL block %d on this node's chain, but the tree uses EL block %d", payload.ConsensusBlock, block.ExecutionBlockNumber, payload.ExecutionBlock)
+	}
+
+	return rprewards.NewRewardSubmission(index, payload.ConsensusBlock, payload.ExecutionBlock, rewardsFile.GetHeader(), payload.MerkleTreeCID, payload.IntervalsPassed)
+
+}
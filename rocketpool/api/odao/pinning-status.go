@@ -0,0 +1,33 @@
+package odao
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/pinning"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getPinningStatus(c *cli.Context) (*api.TNDAOPinningStatusResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.TNDAOPinningStatusResponse{}
+	response.Enabled = cfg.Smartnode.EnableIpfsPinning.Value == true
+
+	// Get the status written by the watchtower
+	status, err := pinning.LoadStatus(cfg.Smartnode.GetPinningStatusPath(true))
+	if err != nil {
+		return nil, err
+	}
+	response.Files = status.Files
+
+	// Return response
+	return &response, nil
+
+}
@@ -880,6 +880,28 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "verify-proposal-root",
+				Usage:     "Compare a proposal's submitted voting power tree root against a locally generated tree",
+				UsageText: "rocketpool api pdao verify-proposal-root proposal-id",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					proposalId, err := cliutils.ValidatePositiveUint("proposal-id", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(verifyProposalRoot(c, proposalId))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "can-finalize-proposal",
 				Usage:     "Check whether a proposal can be finalized after being vetoed",
@@ -0,0 +1,90 @@
+package pdao
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/proposals"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func verifyProposalRoot(c *cli.Context, proposalId uint64) (*api.PDAOVerifyProposalRootResponse, error) {
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.PDAOVerifyProposalRootResponse{}
+
+	// Check proposal exists
+	proposalCount, err := protocol.GetTotalProposalCount(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.DoesNotExist = (proposalId == 0 || proposalId > proposalCount)
+	if response.DoesNotExist {
+		return &response, nil
+	}
+
+	// Get the proposal's target block and the root node submitted by the proposer
+	response.TargetBlock, err = protocol.GetProposalBlock(rp, proposalId, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.ProposalRoot, err = protocol.GetNode(rp, proposalId, 1, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting root node for proposal %d: %w", proposalId, err)
+	}
+
+	// Get (or regenerate) the local tree
+	propMgr, err := proposals.NewProposalManager(nil, cfg, rp, bc)
+	if err != nil {
+		return nil, err
+	}
+	networkTree, err := propMgr.GetNetworkTree(response.TargetBlock, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network tree for proposal %d: %w", proposalId, err)
+	}
+	localRoot := networkTree.Nodes[0]
+	response.LocalRoot = *localRoot
+	response.RootsMatch = (response.ProposalRoot.Sum.Cmp(localRoot.Sum) == 0 && response.ProposalRoot.Hash == localRoot.Hash)
+
+	// Get the node's own artifacts from the tree, if it was part of the snapshot
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	totalDelegatedVP, nodeIndex, proof, err := propMgr.GetArtifactsForVoting(response.TargetBlock, nodeAccount.Address)
+	if errors.Is(err, proposals.ErrNodeNotInSnapshot) {
+		// The node wasn't part of the snapshot, so it has no voting power for this proposal
+		return &response, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting the node's voting artifacts for proposal %d: %w", proposalId, err)
+	}
+	response.NodeInTree = true
+	response.NodeIndex = nodeIndex
+	response.NodeVotingPower = totalDelegatedVP
+	response.NodeProof = proof
+
+	// Return response
+	return &response, nil
+}
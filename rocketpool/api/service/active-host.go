@@ -0,0 +1,113 @@
+package service
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/backup"
+	"github.com/rocket-pool/smartnode/shared/services/hostguard"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Get this machine's host ID, the beacons it and the remote storage have, and any conflict that's blocking validator duties
+func getActiveHostStatus(c *cli.Context) (*api.ActiveHostStatusResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ActiveHostStatusResponse{
+		Enabled: cfg.Smartnode.EnableActiveHostGuard.Value.(bool),
+	}
+
+	// Get the local state
+	response.HostId, err = hostguard.GetHostId(cfg)
+	if err != nil {
+		return nil, err
+	}
+	response.Conflict, err = hostguard.LoadConflict(cfg)
+	if err != nil {
+		return nil, err
+	}
+	response.LocalBeacon, err = hostguard.LoadLocalBeacon(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the remote beacon; problems with remote storage are reported rather than failing the whole status
+	storage, err := backup.GetRemoteStorage(cfg)
+	if err != nil {
+		response.RemoteError = err.Error()
+	} else if storage != nil {
+		network := string(cfg.Smartnode.Network.Value.(cfgtypes.Network))
+		response.RemoteBeacon, err = hostguard.LoadRemoteBeacon(storage, network, nodeAccount.Address)
+		if err != nil {
+			response.RemoteError = err.Error()
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Claim this machine as the active host, clearing any conflict so the node daemon resumes validator duties
+func claimActiveHost(c *cli.Context) (*api.ClaimActiveHostResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	storage, err := backup.GetRemoteStorage(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ClaimActiveHostResponse{}
+
+	// Publish a fresh beacon so other machines see this one has taken over
+	hostId, err := hostguard.GetHostId(cfg)
+	if err != nil {
+		return nil, err
+	}
+	response.Beacon, err = hostguard.NewBeacon(cfg, w, hostId)
+	if err != nil {
+		return nil, err
+	}
+	if err := hostguard.PublishBeacon(cfg, storage, response.Beacon); err != nil {
+		return nil, err
+	}
+
+	// Clear the conflict
+	if err := hostguard.ClearConflict(cfg); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
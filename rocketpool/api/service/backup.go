@@ -0,0 +1,129 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/backup"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// The backup password is only readable by the daemon
+const backupPasswordFileMode os.FileMode = 0600
+
+// Back up the selected components into an encrypted archive in the backups folder
+func createBackup(c *cli.Context, components []backup.Component, password string) (*api.CreateBackupResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CreateBackupResponse{}
+
+	// Create the backup
+	settingsPath := os.ExpandEnv(c.GlobalString("settings"))
+	archive, manifest, err := backup.Create(cfg, settingsPath, components, password, shared.RocketPoolVersion)
+	if err != nil {
+		return nil, err
+	}
+	response.Filename, err = backup.Save(cfg.Smartnode.GetBackupsFolder(true), archive, manifest, false)
+	if err != nil {
+		return nil, err
+	}
+	response.Size = uint64(len(archive))
+	response.Sha256 = backup.GetArchiveHash(archive)
+	response.Manifest = manifest
+
+	// Return response
+	return &response, nil
+
+}
+
+// Save the password the node daemon encrypts scheduled backups with
+func setBackupPassword(c *cli.Context, password string) (*api.SetBackupPasswordResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SetBackupPasswordResponse{}
+
+	// Save the password
+	path := cfg.Smartnode.GetBackupPasswordPath(true)
+	if err := files.WriteFileAtomic(path, []byte(password), backupPasswordFileMode); err != nil {
+		return nil, fmt.Errorf("error saving backup password to [%s]: %w", path, err)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Verify a backup and restore the selected components into the data folder.
+// The config is returned instead of written, since the CLI owns the config folder.
+func restoreBackup(c *cli.Context, filename string, components []backup.Component, password string, verifyOnly bool) (*api.RestoreBackupResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.RestoreBackupResponse{}
+
+	// Open the backup
+	path := filepath.Join(cfg.Smartnode.GetBackupsFolder(true), filepath.Base(filename))
+	if filename == config.BackupRestoreFilename {
+		path = cfg.Smartnode.GetBackupRestorePath(true)
+	}
+	archive, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading backup [%s]: %w", path, err)
+	}
+	contents, err := backup.Open(archive, password)
+	if err != nil {
+		return nil, err
+	}
+	response.Manifest = contents.Manifest
+	if verifyOnly {
+		return &response, nil
+	}
+
+	// Restore it
+	if components == nil {
+		for _, name := range contents.Manifest.Components {
+			components = append(components, backup.Component(name))
+		}
+	}
+	for _, component := range components {
+		if component == backup.Component_Config {
+			settings, exists := contents.GetSettings()
+			if !exists {
+				return nil, fmt.Errorf("the backup doesn't include the config")
+			}
+			response.Settings = string(settings)
+		}
+	}
+	response.RestoredFiles, err = contents.Restore(cfg, components)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
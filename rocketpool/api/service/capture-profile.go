@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/profiling"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Capture the daemons' profiles at the same time and save them as a bundle in the profiles folder
+func captureProfile(c *cli.Context, duration time.Duration) (*api.CaptureProfileResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Smartnode.EnableProfiling.Value != true {
+		return nil, fmt.Errorf("profiling isn't enabled; turn it on in the Smartnode settings of `rocketpool service config` first")
+	}
+
+	// Response
+	response := api.CaptureProfileResponse{}
+
+	// Capture the profiles
+	daemons := []profiling.Daemon{
+		{Name: "node", Url: cfg.Smartnode.GetNodeProfilingUrl()},
+		{Name: "watchtower", Url: cfg.Smartnode.GetWatchtowerProfilingUrl()},
+	}
+	bundle, manifest, err := profiling.Capture(context.Background(), daemons, duration, cfg.IsNativeMode, shared.RocketPoolVersion)
+	if err != nil {
+		return nil, err
+	}
+	response.Filename, err = profiling.Save(cfg.Smartnode.GetProfilesFolder(true), bundle, manifest)
+	if err != nil {
+		return nil, err
+	}
+	response.Size = uint64(len(bundle))
+	response.Manifest = manifest
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// Check the rolling record checkpoints for corruption, optionally repairing them
+func checkRecords(c *cli.Context, repair bool) (*api.CheckRecordsResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CheckRecordsResponse{
+		RollingRecordsEnabled: cfg.Smartnode.UseRollingRecords.Value.(bool),
+	}
+
+	// Only repair the records while the watchtower isn't using them, since it checks and repairs them itself
+	if repair {
+		recordsPath := cfg.Smartnode.GetRecordsPath()
+		err = os.MkdirAll(recordsPath, 0755)
+		if err != nil {
+			return nil, fmt.Errorf("error creating rolling records folder: %w", err)
+		}
+		lock, err := files.AcquireLock(services.GetDaemonLockPath("watchtower", recordsPath))
+		if errors.Is(err, files.ErrLocked) {
+			return nil, fmt.Errorf("the watchtower is running and repairs the rolling records itself; stop it before repairing them manually (%w)", err)
+		}
+		if err != nil {
+			return nil, err
+		}
+		defer lock.Release()
+	}
+
+	result, err := rewards.CheckRecords(cfg, repair)
+	if err != nil {
+		return nil, fmt.Errorf("error checking rolling records: %w", err)
+	}
+	response.Result = *result
+
+	// Return response
+	return &response, nil
+
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/shared/services/backup"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
@@ -52,6 +53,328 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "get-dashboard",
+				Usage:     "Gets the client, duty, rolling record, transaction, and alert status shown on the live dashboard",
+				UsageText: "rocketpool api service get-dashboard",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getDashboard(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "doctor",
+				Usage:     "Checks the node for the most common problems and ranks the ones it finds by urgency",
+				UsageText: "rocketpool api service doctor",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(runDoctor(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "check-records",
+				Usage:     "Checks the rolling record checkpoints for corruption, and optionally drops the bad ones",
+				UsageText: "rocketpool api service check-records repair",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					repair, err := cliutils.ValidateBool("repair", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(checkRecords(c, repair))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "submission-ledger",
+				Usage:     "Gets the watchtower's record of the submissions it has made",
+				UsageText: "rocketpool api service submission-ledger kind",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					kind, err := cliutils.ValidateSubmissionKind("kind", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getSubmissionLedger(c, kind))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "capture-profile",
+				Usage:     "Captures the CPU, heap, and goroutine profiles of the running daemons and bundles them in the profiles folder",
+				UsageText: "rocketpool api service capture-profile duration",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					duration, err := cliutils.ValidateDuration("duration", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(captureProfile(c, duration))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "create-backup",
+				Usage:     "Backs up the selected components of the Smartnode's state into an encrypted archive in the backups folder",
+				UsageText: "rocketpool api service create-backup components password",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					components, err := backup.ParseComponents(c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(createBackup(c, components, c.Args().Get(1)))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "restore-backup",
+				Usage:     "Verifies a backup and restores the selected components, or every component in it if 'all' is provided",
+				UsageText: "rocketpool api service restore-backup filename components password verify-only",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 4); err != nil {
+						return err
+					}
+					var components []backup.Component
+					if c.Args().Get(1) != "all" {
+						var err error
+						components, err = backup.ParseComponents(c.Args().Get(1))
+						if err != nil {
+							return err
+						}
+					}
+					verifyOnly, err := cliutils.ValidateBool("verify-only", c.Args().Get(3))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(restoreBackup(c, c.Args().Get(0), components, c.Args().Get(2), verifyOnly))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "set-backup-password",
+				Usage:     "Sets the password the node daemon encrypts scheduled backups with",
+				UsageText: "rocketpool api service set-backup-password password",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					password, err := cliutils.ValidateNodePassword("password", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(setBackupPassword(c, password))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-active-host",
+				Usage:     "Gets this machine's host ID, the active host beacons, and any conflict that's blocking validator duties",
+				UsageText: "rocketpool api service get-active-host",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getActiveHostStatus(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "claim-active-host",
+				Usage:     "Claims this machine as the active host and clears any conflict so validator duties resume",
+				UsageText: "rocketpool api service claim-active-host",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(claimActiveHost(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-port-forwarding",
+				Usage:     "Gets the port mappings and external IP address the node daemon last got from the router",
+				UsageText: "rocketpool api service get-port-forwarding",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getPortForwardingStatus(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "devnet-fund",
+				Usage:     "Sends ETH and RPL to the node wallet from the local devnet's funder account",
+				UsageText: "rocketpool api service devnet-fund eth-amount-wei rpl-amount-wei",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					ethAmountWei, err := cliutils.ValidatePositiveOrZeroWeiAmount("ETH amount", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					rplAmountWei, err := cliutils.ValidatePositiveOrZeroWeiAmount("RPL amount", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(devnetFund(c, ethAmountWei, rplAmountWei))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "devnet-fast-forward",
+				Usage:     "Advances the local devnet's clock to the end of the current rewards interval, plus any extra intervals",
+				UsageText: "rocketpool api service devnet-fast-forward intervals",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					intervals, err := cliutils.ValidatePositiveUint("intervals", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(devnetFastForward(c, intervals))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "test-alert",
+				Usage:     "Sends a test alert to every enabled notification sink",
+				UsageText: "rocketpool api service test-alert severity",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					severity, err := cliutils.ValidateAlertSeverity("severity", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(testAlert(c, severity))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "test-failure",
+				Usage:     "Sends a synthetic failure alert through the alerting pipeline",
+				UsageText: "rocketpool api service test-failure scenario",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					scenario, err := cliutils.ValidateFailureScenario("scenario", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(testFailure(c, scenario))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "restart-vc",
 				Usage:     "Restarts the validator client",
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/hardware"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// How long to measure the host's CPU and disk IO rates for on each refresh
+const dashboardHardwareSampleInterval = time.Second
+
+// Gets everything shown on the live dashboard in a single call, so it can be refreshed cheaply
+func getDashboard(c *cli.Context) (*api.DashboardResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.DashboardResponse{
+		Proposals: []api.DashboardProposal{},
+		Alerts:    []api.NodeAlert{},
+	}
+
+	// Get the client statuses; the rest of the dashboard needs working clients
+	response.EcManagerStatus = *ec.CheckStatus(cfg)
+	response.BcManagerStatus = *bc.CheckStatus()
+	clientsWorking := (response.EcManagerStatus.PrimaryClientStatus.IsWorking || response.EcManagerStatus.FallbackClientStatus.IsWorking) &&
+		(response.BcManagerStatus.PrimaryClientStatus.IsWorking || response.BcManagerStatus.FallbackClientStatus.IsWorking)
+
+	// Get the current slot from the wall clock, so it keeps counting while the clients sync
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, err
+	}
+	genesisTime := time.Unix(int64(eth2Config.GenesisTime), 0)
+	slotDuration := time.Duration(eth2Config.SecondsPerSlot) * time.Second
+	if time.Now().After(genesisTime) {
+		response.CurrentSlot = uint64(time.Since(genesisTime) / slotDuration)
+	}
+	response.CurrentEpoch = response.CurrentSlot / eth2Config.SlotsPerEpoch
+	response.NextEpochTime = getSlotTime(eth2Config, (response.CurrentEpoch+1)*eth2Config.SlotsPerEpoch)
+
+	// Get alerts from Alertmanager
+	alerts, err := alerting.FetchAlerts(cfg)
+	if err != nil {
+		response.Warning = fmt.Sprintf("Error fetching alerts from Alertmanager: %s", err)
+	}
+	for _, a := range alerts {
+		response.Alerts = append(response.Alerts, api.NodeAlert{
+			State:       *a.Status.State,
+			Labels:      a.Labels,
+			Annotations: a.Annotations,
+		})
+	}
+
+	// Get the rolling record progress
+	response.RollingRecordsEnabled = cfg.Smartnode.UseRollingRecords.Value.(bool)
+	if response.RollingRecordsEnabled {
+		response.RollingRecordSlot, response.RollingRecordSaved, err = rewards.GetLatestRecordSlot(cfg)
+		if err != nil {
+			return nil, err
+		}
+		if response.RollingRecordSaved {
+			if response.CurrentSlot > response.RollingRecordSlot {
+				response.RollingRecordSlotsBehind = response.CurrentSlot - response.RollingRecordSlot
+			}
+			checkpointInterval := cfg.Smartnode.RecordCheckpointInterval.Value.(uint64)
+			response.RollingRecordCheckpointAt = getSlotTime(eth2Config, response.RollingRecordSlot+checkpointInterval*eth2Config.SlotsPerEpoch)
+		}
+		response.RollingRecordCatchUp, err = rewards.LoadCatchUpProgress(cfg.Smartnode.GetCatchUpProgressPath(true))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Get the host's hardware usage
+	response.Hardware, err = hardware.Sample(hardware.GetMonitoredPaths(cfg), dashboardHardwareSampleInterval)
+	if err != nil {
+		response.Warning = fmt.Sprintf("Error getting the hardware metrics: %s", err)
+	}
+
+	// Get the node's details if it has a wallet
+	response.WalletInitialized = w.IsInitialized()
+	if !response.WalletInitialized || !clientsWorking {
+		return &response, nil
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.NodeAddress = nodeAccount.Address
+
+	// Get the number of transactions sent by the node that haven't been included in a block yet
+	pendingNonce, err := ec.PendingNonceAt(context.Background(), nodeAccount.Address)
+	if err != nil {
+		return nil, fmt.Errorf("error getting pending nonce: %w", err)
+	}
+	nonce, err := ec.NonceAt(context.Background(), nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest nonce: %w", err)
+	}
+	if pendingNonce > nonce {
+		response.PendingTransactionCount = pendingNonce - nonce
+	}
+
+	// Get the node's validator indices
+	pubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool pubkeys: %w", err)
+	}
+	if len(pubkeys) == 0 {
+		return &response, nil
+	}
+	statuses, err := bc.GetValidatorStatuses(pubkeys, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting validator statuses: %w", err)
+	}
+	indices := []string{}
+	for _, status := range statuses {
+		if status.Exists {
+			indices = append(indices, status.Index)
+		}
+	}
+	response.ValidatorCount = len(indices)
+	if len(indices) == 0 {
+		return &response, nil
+	}
+
+	// Get the validator duties; proposals are only known for the current epoch
+	var wg errgroup.Group
+	wg.Go(func() error {
+		slots, err := bc.GetValidatorProposerSlots(indices, response.CurrentEpoch)
+		if err != nil {
+			return err
+		}
+		for index, indexSlots := range slots {
+			for _, slot := range indexSlots {
+				if slot < response.CurrentSlot {
+					continue
+				}
+				response.Proposals = append(response.Proposals, api.DashboardProposal{
+					ValidatorIndex: index,
+					Slot:           slot,
+					Time:           getSlotTime(eth2Config, slot),
+				})
+			}
+		}
+		sort.Slice(response.Proposals, func(i, j int) bool {
+			return response.Proposals[i].Slot < response.Proposals[j].Slot
+		})
+		return nil
+	})
+	wg.Go(func() error {
+		duties, err := bc.GetValidatorSyncDuties(indices, response.CurrentEpoch)
+		if err != nil {
+			return err
+		}
+		response.SyncCommitteeCount = countSyncDuties(duties)
+		return nil
+	})
+	wg.Go(func() error {
+		duties, err := bc.GetValidatorSyncDuties(indices, response.CurrentEpoch+eth2Config.EpochsPerSyncCommitteePeriod)
+		if err != nil {
+			return err
+		}
+		response.NextSyncCommitteeCount = countSyncDuties(duties)
+		return nil
+	})
+	if err := wg.Wait(); err != nil {
+		return nil, fmt.Errorf("error getting validator duties: %w", err)
+	}
+	periodEndEpoch := (response.CurrentEpoch/eth2Config.EpochsPerSyncCommitteePeriod + 1) * eth2Config.EpochsPerSyncCommitteePeriod
+	response.SyncPeriodEndTime = getSlotTime(eth2Config, periodEndEpoch*eth2Config.SlotsPerEpoch)
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the time a slot starts
+func getSlotTime(eth2Config beacon.Eth2Config, slot uint64) time.Time {
+	return time.Unix(int64(eth2Config.GenesisTime+slot*eth2Config.SecondsPerSlot), 0)
+}
+
+// Count the validators with sync committee duties
+func countSyncDuties(duties map[string]bool) int {
+	count := 0
+	for _, duty := range duties {
+		if duty {
+			count++
+		}
+	}
+	return count
+}
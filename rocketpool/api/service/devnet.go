@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/tokens"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Make sure the Smartnode is running against a local devnet, since the devnet helpers rewrite its chain
+func requireLocalDevnet(cfg *config.RocketPoolConfig) error {
+	if !cfg.Devnet.IsLocalDevnet() {
+		return fmt.Errorf("this command can only be used with a local devnet; set the network to Devnet and enable the local devnet in the devnet settings")
+	}
+	return nil
+}
+
+// Send ETH and RPL to the node wallet from the local devnet's funder account
+func devnetFund(c *cli.Context, ethAmountWei *big.Int, rplAmountWei *big.Int) (*api.DevnetFundResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireLocalDevnet(cfg); err != nil {
+		return nil, err
+	}
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.DevnetFundResponse{}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.NodeAddress = nodeAccount.Address
+
+	// Get the funder's transactor
+	funderKeyString := strings.TrimPrefix(cfg.Devnet.FunderKey.Value.(string), "0x")
+	if funderKeyString == "" {
+		return nil, fmt.Errorf("the local devnet doesn't have a funder key set")
+	}
+	funderKey, err := crypto.HexToECDSA(funderKeyString)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the funder key: %w", err)
+	}
+	chainID := big.NewInt(int64(cfg.Smartnode.GetChainID()))
+	opts, err := bind.NewKeyedTransactorWithChainID(funderKey, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("error creating the funder transactor: %w", err)
+	}
+	response.FunderAddress = opts.From
+
+	// Send the ETH
+	if ethAmountWei.Sign() > 0 {
+		opts.Value = ethAmountWei
+		response.EthTxHash, err = eth.SendTransaction(ec, nodeAccount.Address, chainID, nil, false, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error sending ETH to the node: %w", err)
+		}
+		opts.Value = nil
+		opts.Nonce = nil
+	}
+
+	// Send the RPL
+	if rplAmountWei.Sign() > 0 {
+		if err := services.RequireRocketStorage(c); err != nil {
+			return nil, err
+		}
+		if ethAmountWei.Sign() > 0 {
+			// Use the next nonce so this doesn't replace the ETH transfer while it's still pending
+			nonce, err := ec.PendingNonceAt(context.Background(), opts.From)
+			if err != nil {
+				return nil, fmt.Errorf("error getting the funder's nonce: %w", err)
+			}
+			opts.Nonce = new(big.Int).SetUint64(nonce)
+		}
+		response.RplTxHash, err = tokens.TransferRPL(rp, nodeAccount.Address, rplAmountWei, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error sending RPL to the node: %w", err)
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Advance the local devnet's clock past the end of the current rewards interval, plus any extra intervals, and mine a block at the new time.
+// This only works with Execution clients that support the evm_increaseTime call, such as anvil and Hardhat.
+func devnetFastForward(c *cli.Context, intervals uint64) (*api.DevnetFastForwardResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireLocalDevnet(cfg); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.DevnetFastForwardResponse{}
+
+	// Get the time the target interval ends
+	intervalStart, err := rewards.GetClaimIntervalTimeStart(rp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the start of the current rewards interval: %w", err)
+	}
+	intervalTime, err := rewards.GetClaimIntervalTime(rp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the rewards interval time: %w", err)
+	}
+	latestHeader, err := ec.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the latest block: %w", err)
+	}
+	chainTime := time.Unix(int64(latestHeader.Time), 0)
+	target := intervalStart.Add(intervalTime * time.Duration(intervals))
+	if !target.After(chainTime) {
+		target = chainTime.Add(intervalTime * time.Duration(intervals))
+	}
+	seconds := uint64(target.Sub(chainTime).Seconds()) + 1
+
+	// Move the clock forward and mine a block so the new time takes effect
+	client, err := rpc.Dial(cfg.ExternalExecution.HttpUrl.Value.(string))
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to the devnet's Execution client: %w", err)
+	}
+	defer client.Close()
+	var ignored interface{}
+	err = client.Call(&ignored, "evm_increaseTime", seconds)
+	if err != nil {
+		return nil, fmt.Errorf("error advancing the devnet's clock (does your Execution client support evm_increaseTime?): %w", err)
+	}
+	err = client.Call(&ignored, "evm_mine")
+	if err != nil {
+		return nil, fmt.Errorf("error mining a block on the devnet: %w", err)
+	}
+
+	newHeader, err := ec.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the latest block: %w", err)
+	}
+	response.SecondsAdvanced = seconds
+	response.BlockNumber = newHeader.Number.Uint64()
+	response.BlockTime = time.Unix(int64(newHeader.Time), 0)
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/doctor"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Run the doctor's checks and get the problems they found, most urgent first
+func runDoctor(c *cli.Context) (*api.DoctorResponse, error) {
+
+	// Get services; none of them need the clients to be synced, since finding out why they aren't is the point
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.DoctorResponse{}
+	response.Checks = doctor.RunChecks(&doctor.Environment{
+		Cfg:    cfg,
+		Ec:     ec,
+		Bc:     bc,
+		Rp:     rp,
+		Wallet: w,
+	})
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/portmap"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Get the port mappings and external IP address the node daemon last got from the router
+func getPortForwardingStatus(c *cli.Context) (*api.PortForwardingStatusResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.PortForwardingStatusResponse{
+		Enabled: cfg.Smartnode.EnablePortForwarding.Value == true,
+	}
+	response.Forwarding, err = portmap.LoadStatus(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/submissions"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Get the watchtower's record of the submissions it has made
+func getSubmissionLedger(c *cli.Context, kind string) (*api.SubmissionLedgerResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the ledger; it's empty if the watchtower hasn't submitted anything yet
+	ledger, err := submissions.LoadLedger(cfg.Smartnode.GetSubmissionLedgerPath(true))
+	if err != nil {
+		return nil, err
+	}
+	if kind == "all" {
+		kind = ""
+	}
+
+	// Return response
+	response := api.SubmissionLedgerResponse{
+		Submissions: ledger.GetSubmissions(submissions.Kind(kind)),
+	}
+	return &response, nil
+
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Send a test alert to every enabled notification sink
+func testAlert(c *cli.Context, severity string) (*api.TestAlertResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.TestAlertResponse{
+		Results: []api.TestAlertSinkResult{},
+	}
+
+	// Send the alert
+	results, err := notifications.SendTestNotification(cfg, severity)
+	if err != nil {
+		return nil, err
+	}
+	for sink, err := range results {
+		result := api.TestAlertSinkResult{
+			Sink: sink,
+		}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		response.Results = append(response.Results, result)
+	}
+	sort.Slice(response.Results, func(i, j int) bool {
+		return response.Results[i].Sink < response.Results[j].Sink
+	})
+
+	// Return response
+	return &response, nil
+
+}
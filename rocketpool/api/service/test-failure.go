@@ -0,0 +1,58 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Send a synthetic failure alert to Alertmanager and check that it was accepted
+func testFailure(c *cli.Context, scenario string) (*api.TestFailureResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.TestFailureResponse{
+		Sinks: []string{},
+	}
+
+	// Get the sinks the notification dispatcher will deliver it to
+	sinks, err := notifications.GetSinks(cfg)
+	if err != nil {
+		return nil, err
+	}
+	for _, sink := range sinks {
+		response.Sinks = append(response.Sinks, sink.Name())
+	}
+	sort.Strings(response.Sinks)
+
+	// Send the failure
+	failure, err := alerting.SendTestFailure(cfg, alerting.FailureScenario(scenario))
+	if err != nil {
+		return nil, err
+	}
+	response.AlertName = failure.Name
+	response.Summary = failure.Summary
+	response.Severity = string(failure.Severity)
+	response.ExpiresAt = failure.ExpiresAt
+
+	// Check what Alertmanager did with it
+	alerts, err := alerting.FetchAlerts(cfg)
+	if err != nil {
+		return nil, err
+	}
+	response.AlertState = alerting.GetTestFailureState(alerts, failure)
+
+	// Return response
+	return &response, nil
+
+}
@@ -172,6 +172,42 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "recover-validator-keys",
+				Usage:     "Regenerate the validator keys for the node's minipools without loading them, and verify them against the Beacon Chain",
+				UsageText: "rocketpool api wallet recover-validator-keys",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(recoverValidatorKeys(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "load-recovered-validator-keys",
+				Usage:     "Load the verified validator keys into the Validator Client, importing the uploaded slashing protection database if there is one",
+				UsageText: "rocketpool api wallet load-recovered-validator-keys",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(loadRecoveredValidatorKeys(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "test-recovery",
 				Aliases:   []string{"r"},
@@ -210,6 +246,48 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "verify-recovery",
+				Usage:     "Verify that a mnemonic regenerates the node address and every minipool's validator key, without touching the node wallet",
+				UsageText: "rocketpool api wallet verify-recovery mnemonic",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "derivation-path, d",
+						Usage: "Specify the derivation path for the wallet.\nOmit this flag (or leave it blank) for the default of \"m/44'/60'/0'/0/%d\" (where %d is the index).\nSet this to \"ledgerLive\" to use Ledger Live's path of \"m/44'/60'/%d/0/0\".\nSet this to \"mew\" to use MyEtherWallet's path of \"m/44'/60'/0'/%d\".\nFor custom paths, simply enter them here.",
+					},
+					cli.UintFlag{
+						Name:  "wallet-index, i",
+						Usage: "Specify the index to use with the derivation path when recovering your wallet",
+						Value: 0,
+					},
+					cli.StringFlag{
+						Name:  "address, a",
+						Usage: "The node address the mnemonic is expected to regenerate (defaults to the address of the node wallet)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					mnemonic, err := cliutils.ValidateWalletMnemonic("mnemonic", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					if c.String("address") != "" {
+						if _, err := cliutils.ValidateAddress("address", c.String("address")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					api.PrintResponse(verifyRecovery(c, mnemonic))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "test-search-and-recover",
 				Aliases:   []string{"r"},
@@ -243,6 +321,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "encrypt-validator-keys",
+				Usage:     "Encrypt the validator keystores into an archive with the node wallet password, or re-encrypt an existing one",
+				UsageText: "rocketpool api wallet encrypt-validator-keys",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(encryptValidatorKeys(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "export",
 				Aliases:   []string{"e"},
@@ -262,6 +358,53 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "get-credential-changes",
+				Usage:     "Get the withdrawal credentials state of the node's vacant minipools, and whether the provided mnemonic can change them",
+				UsageText: "rocketpool api wallet get-credential-changes mnemonic",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					mnemonic, err := cliutils.ValidateWalletMnemonic("mnemonic", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getCredentialChanges(c, mnemonic))
+					return nil
+
+				},
+			},
+			{
+				Name:      "send-credential-changes",
+				Usage:     "Sign and broadcast 0x00 to 0x01 withdrawal credential changes for a batch of the node's vacant minipools",
+				UsageText: "rocketpool api wallet send-credential-changes minipool-addresses mnemonic",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					minipoolAddresses, err := cliutils.ValidateAddresses("minipool addresses", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					mnemonic, err := cliutils.ValidateWalletMnemonic("mnemonic", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(sendCredentialChanges(c, mnemonic, minipoolAddresses))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "estimate-gas-set-ens-name",
 				Usage:     "Estimate the gas required to set the name for the node wallet's ENS reverse record",
@@ -296,6 +439,75 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "gas-wallet-status",
+				Usage:     "Get the gas wallet's address, balance, and routing rules",
+				UsageText: "rocketpool api wallet gas-wallet-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getGasWalletStatus(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "create-gas-wallet",
+				Usage:     "Generate a new key for the gas wallet",
+				UsageText: "rocketpool api wallet create-gas-wallet",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(createGasWallet(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "import-gas-wallet",
+				Usage:     "Use an existing private key for the gas wallet",
+				UsageText: "rocketpool api wallet import-gas-wallet private-key",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(importGasWallet(c, c.Args().Get(0)))
+					return nil
+
+				},
+			},
+			{
+				Name:      "delete-gas-wallet",
+				Usage:     "Remove the gas wallet's key from the node",
+				UsageText: "rocketpool api wallet delete-gas-wallet",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(deleteGasWallet(c))
+					return nil
+
+				},
+			},
 		},
 	})
 }
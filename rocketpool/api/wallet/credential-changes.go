@@ -0,0 +1,247 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+const (
+	// How many indices of the mnemonic to search for each validator key
+	credentialChangeKeySearchLimit uint = 2000
+)
+
+func getCredentialChanges(c *cli.Context, mnemonic string) (*api.WalletCredentialChangesResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.WalletCredentialChangesResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the node's vacant minipools, which hold the migrated solo validators
+	addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting node minipool addresses: %w", err)
+	}
+	for _, address := range addresses {
+		validator, isVacant, err := getCredentialChangeCandidate(rp, bc, address, mnemonic)
+		if err != nil {
+			return nil, err
+		}
+		if isVacant {
+			response.Validators = append(response.Validators, validator)
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func sendCredentialChanges(c *cli.Context, mnemonic string, minipoolAddresses []common.Address) (*api.WalletSendCredentialChangesResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.WalletSendCredentialChangesResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the BlsToExecutionChange signature domain
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return nil, err
+	}
+	signatureDomain, err := bc.GetDomainData(eth2types.DomainBlsToExecutionChange[:], head.Epoch, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sign a change for each minipool
+	changes := make([]beacon.WithdrawalCredentialsChange, 0, len(minipoolAddresses))
+	for _, address := range minipoolAddresses {
+
+		// Make sure the change is still required
+		mp, err := minipool.NewMinipool(rp, address, nil)
+		if err != nil {
+			return nil, err
+		}
+		owner, err := mp.GetNodeAddress(nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting owner of minipool %s: %w", address.Hex(), err)
+		}
+		if owner != nodeAccount.Address {
+			return nil, fmt.Errorf("minipool %s does not belong to this node", address.Hex())
+		}
+		candidate, isVacant, err := getCredentialChangeCandidate(rp, bc, address, mnemonic)
+		if err != nil {
+			return nil, err
+		}
+		if !isVacant {
+			return nil, fmt.Errorf("minipool %s is not vacant", address.Hex())
+		}
+		if !candidate.RequiresChange || !candidate.KeyFound {
+			return nil, fmt.Errorf("cannot change the withdrawal credentials for minipool %s: %s", address.Hex(), candidate.Reason)
+		}
+
+		// Get the withdrawal key and sign the change
+		withdrawalKey, err := validator.GetWithdrawalKey(mnemonic, candidate.KeyIndex, validator.ValidatorKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		signature, err := validator.GetSignedWithdrawalCredsChangeMessage(withdrawalKey, candidate.ValidatorIndex, address, signatureDomain)
+		if err != nil {
+			return nil, fmt.Errorf("error signing withdrawal credentials change for minipool %s: %w", address.Hex(), err)
+		}
+		changes = append(changes, beacon.WithdrawalCredentialsChange{
+			ValidatorIndex:     candidate.ValidatorIndex,
+			FromBlsPubkey:      types.BytesToValidatorPubkey(withdrawalKey.PublicKey().Marshal()),
+			ToExecutionAddress: address,
+			Signature:          signature,
+		})
+	}
+
+	// Broadcast the batch
+	if err := bc.ChangeWithdrawalCredentialsBatch(changes); err != nil {
+		return nil, err
+	}
+	response.Sent = minipoolAddresses
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the withdrawal credentials state of a minipool's validator. Returns false if the minipool isn't vacant.
+func getCredentialChangeCandidate(rp *rocketpool.RocketPool, bc beacon.Client, address common.Address, mnemonic string) (api.CredentialChangeValidator, bool, error) {
+	candidate := api.CredentialChangeValidator{
+		MinipoolAddress: address,
+	}
+
+	// Check the minipool status
+	mp, err := minipool.NewMinipool(rp, address, nil)
+	if err != nil {
+		return candidate, false, err
+	}
+	mpv3, success := minipool.GetMinipoolAsV3(mp)
+	if !success {
+		return candidate, false, nil
+	}
+	details, err := mpv3.GetStatusDetails(nil)
+	if err != nil {
+		return candidate, false, fmt.Errorf("error getting status details for minipool %s: %w", address.Hex(), err)
+	}
+	if !details.IsVacant {
+		return candidate, false, nil
+	}
+
+	// Get the validator's Beacon status
+	candidate.Pubkey, err = minipool.GetMinipoolPubkey(rp, address, nil)
+	if err != nil {
+		return candidate, false, fmt.Errorf("couldn't get the pubkey for minipool %s: %w", address.Hex(), err)
+	}
+	beaconStatus, err := bc.GetValidatorStatus(candidate.Pubkey, nil)
+	if err != nil {
+		return candidate, false, fmt.Errorf("error getting Beacon status for minipool %s (pubkey %s): %w", address.Hex(), candidate.Pubkey.Hex(), err)
+	}
+	candidate.ValidatorIndex = beaconStatus.Index
+	candidate.WithdrawalCredentials = beaconStatus.WithdrawalCredentials
+
+	// Check if the change has already landed
+	expectedCredentials := common.BytesToHash(address.Bytes())
+	expectedCredentials[0] = 0x01
+	candidate.ChangeLanded = (beaconStatus.WithdrawalCredentials == expectedCredentials)
+
+	// Check if a change is still required
+	switch {
+	case candidate.ChangeLanded:
+		candidate.Reason = "its withdrawal credentials already point to the minipool"
+	case !beaconStatus.Exists:
+		candidate.Reason = "the validator does not exist on the Beacon Chain"
+	case beaconStatus.WithdrawalCredentials[0] != 0x00:
+		candidate.Reason = fmt.Sprintf("its withdrawal credentials have already been changed to %s", beaconStatus.WithdrawalCredentials.Hex())
+	case details.Status != types.Prelaunch:
+		candidate.Reason = fmt.Sprintf("the minipool is in %s status instead of prelaunch", details.Status.String())
+	case beaconStatus.Status != beacon.ValidatorState_ActiveOngoing:
+		candidate.Reason = fmt.Sprintf("the validator is %s instead of active_ongoing", beaconStatus.Status)
+	default:
+		candidate.RequiresChange = true
+	}
+	if !candidate.RequiresChange {
+		return candidate, true, nil
+	}
+
+	// Make sure the mnemonic has the key for this validator and its withdrawal credentials match
+	_, index, err := validator.FindValidatorKey(mnemonic, candidate.Pubkey, validator.ValidatorKeyPath, credentialChangeKeySearchLimit)
+	if err != nil {
+		candidate.Reason = fmt.Sprintf("the validator key is not part of the provided mnemonic: %s", err.Error())
+		return candidate, true, nil
+	}
+	withdrawalKey, err := validator.GetWithdrawalKey(mnemonic, index, validator.ValidatorKeyPath)
+	if err != nil {
+		return candidate, true, err
+	}
+	if validator.GetBlsWithdrawalCredentials(withdrawalKey) != beaconStatus.WithdrawalCredentials {
+		candidate.Reason = fmt.Sprintf("the mnemonic's withdrawal key for index %d does not match the validator's withdrawal credentials", index)
+		return candidate, true, nil
+	}
+	candidate.KeyFound = true
+	candidate.KeyIndex = index
+	return candidate, true, nil
+}
@@ -0,0 +1,80 @@
+package wallet
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/keyarchive"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func encryptValidatorKeys(c *cli.Context) (*api.EncryptValidatorKeysResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	pm, err := services.GetPasswordManager(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.EncryptValidatorKeysResponse{}
+
+	// Make sure an existing archive isn't replaced by a folder that was never unlocked from it
+	keychainPath := os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath())
+	archivePath := cfg.Smartnode.GetValidatorKeyArchivePath()
+	_, err = os.Stat(archivePath)
+	if err == nil {
+		response.Rekeyed = true
+		_, unlocked, err := keyarchive.GetUnlockedHash(keychainPath)
+		if err != nil {
+			return nil, err
+		}
+		if !unlocked {
+			return nil, fmt.Errorf("an encrypted archive already exists but the validator keys have not been unlocked from it; start the node daemon to unlock them before encrypting again")
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error checking for the validator key archive: %w", err)
+	}
+
+	// Encrypt the keys with the current password, then make sure the archive can be decrypted before relying on it
+	password, err := pm.GetPassword()
+	if err != nil {
+		return nil, err
+	}
+	hash, err := keyarchive.EncryptDir(keychainPath, archivePath, password)
+	if err != nil {
+		return nil, err
+	}
+	verifiedHash, err := keyarchive.VerifyArchive(archivePath, password)
+	if err != nil {
+		return nil, fmt.Errorf("error verifying the new archive: %w", err)
+	}
+	if verifiedHash != hash {
+		return nil, fmt.Errorf("the new archive's contents did not match the validator key folder")
+	}
+	err = keyarchive.MarkUnlocked(keychainPath, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check where the unlocked keys live
+	response.ArchivePath = cfg.Smartnode.GetValidatorKeyArchivePathInCLI()
+	response.KeysInMemory, err = keyarchive.IsTmpfs(keychainPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
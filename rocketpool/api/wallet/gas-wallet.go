@@ -0,0 +1,131 @@
+package wallet
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getGasWalletStatus(c *cli.Context) (*api.GasWalletStatusResponse, error) {
+
+	// Get services; the gas wallet has its own password, so this doesn't need the node wallet
+	gw, err := services.GetGasWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.GasWalletStatusResponse{
+		Initialized: gw.IsInitialized(),
+		Balance:     big.NewInt(0),
+		Routes:      make([]api.GasWalletRoute, len(wallet.GasWalletRoutes)),
+	}
+	for i, route := range wallet.GasWalletRoutes {
+		response.Routes[i] = api.GasWalletRoute{
+			Tx:            string(route.Tx),
+			Description:   route.Description,
+			UsesGasWallet: route.UsesGasWallet,
+			Reason:        route.Reason,
+		}
+	}
+
+	// Get the address and balance if it's set up
+	if response.Initialized {
+		response.Address, err = gw.GetAddress()
+		if err != nil {
+			return nil, err
+		}
+		ec, err := services.GetEthClient(c)
+		if err != nil {
+			return nil, err
+		}
+		response.Balance, err = ec.BalanceAt(context.Background(), response.Address, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func createGasWallet(c *cli.Context) (*api.SetGasWalletResponse, error) {
+
+	// Get services; loading the node wallet saves its address, which the gas wallet's tasks use while the node's key isn't available
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	gw, err := services.GetGasWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SetGasWalletResponse{}
+
+	// Create the key
+	response.Address, err = gw.Create()
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func importGasWallet(c *cli.Context, privateKey string) (*api.SetGasWalletResponse, error) {
+
+	// Get services; loading the node wallet saves its address, which the gas wallet's tasks use while the node's key isn't available
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	gw, err := services.GetGasWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SetGasWalletResponse{}
+
+	// Import the key
+	response.Address, err = gw.Import(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func deleteGasWallet(c *cli.Context) (*api.DeleteGasWalletResponse, error) {
+
+	// Get services; the gas wallet has its own password, so this doesn't need the node wallet
+	gw, err := services.GetGasWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.DeleteGasWalletResponse{}
+
+	// Delete the key
+	response.Address, err = gw.GetAddress()
+	if err != nil {
+		return nil, err
+	}
+	if err := gw.Delete(); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
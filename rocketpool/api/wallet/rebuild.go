@@ -40,6 +40,7 @@ func rebuildWallet(c *cli.Context) (*api.RebuildWalletResponse, error) {
 	if err != nil {
 		return nil, err
 	}
+	response.KeysLoaded = walletutils.LoadRecoveredKeys(c, w, response.ValidatorKeys)
 
 	// Save wallet
 	if err := w.Save(); err != nil {
@@ -0,0 +1,260 @@
+package wallet
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+	walletutils "github.com/rocket-pool/smartnode/shared/utils/wallet"
+)
+
+// Regenerate the keys for every one of the node's minipools without loading them into the Validator Client,
+// and verify each one against the Beacon Chain so the caller can decide whether it's safe to start validating
+func recoverValidatorKeys(c *cli.Context) (*api.RecoverValidatorKeysResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the node's validators from the network state
+	validators, err := getRecoveryValidators(c, w)
+	if err != nil {
+		return nil, err
+	}
+	verifiedPubkeys := []types.ValidatorPubkey{}
+	unverifiedPubkeys := []types.ValidatorPubkey{}
+	for _, validator := range validators {
+		if validator.Verified {
+			verifiedPubkeys = append(verifiedPubkeys, validator.Pubkey)
+		} else {
+			unverifiedPubkeys = append(unverifiedPubkeys, validator.Pubkey)
+		}
+	}
+
+	// Only save the keys that passed verification, since the Validator Client loads every key on disk when it restarts
+	if len(verifiedPubkeys) > 0 {
+		err = walletutils.RecoverKeysForPubkeys(cfg, w, verifiedPubkeys, false)
+		if err != nil {
+			return nil, err
+		}
+		if err := w.Save(); err != nil {
+			return nil, err
+		}
+	}
+	unrecoverable := map[types.ValidatorPubkey]bool{}
+	if len(unverifiedPubkeys) > 0 {
+		missing, err := walletutils.FindUnrecoverableKeys(cfg, w, unverifiedPubkeys)
+		if err != nil {
+			return nil, err
+		}
+		for _, pubkey := range missing {
+			unrecoverable[pubkey] = true
+		}
+	}
+	for i := range validators {
+		if validators[i].Verified {
+			_, err := w.GetValidatorKeyByPubkey(validators[i].Pubkey)
+			validators[i].KeyRecovered = (err == nil)
+			validators[i].Verified = validators[i].KeyRecovered
+		} else {
+			validators[i].KeyRecovered = !unrecoverable[validators[i].Pubkey]
+		}
+	}
+
+	// Return response
+	return &api.RecoverValidatorKeysResponse{
+		Validators: validators,
+	}, nil
+
+}
+
+// Load the recovered keys that passed verification into the Validator Client, importing the slashing protection database first if one was provided
+func loadRecoveredValidatorKeys(c *cli.Context) (*api.LoadRecoveredValidatorKeysResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+	km, err := services.GetKeymanager(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.LoadRecoveredValidatorKeysResponse{
+		LoadedKeys:  []types.ValidatorPubkey{},
+		SkippedKeys: []types.ValidatorPubkey{},
+	}
+
+	// Re-verify the validators, since the chain may have moved on since the keys were recovered
+	validators, err := getRecoveryValidators(c, w)
+	if err != nil {
+		return nil, err
+	}
+	keys := []*eth2types.BLSPrivateKey{}
+	unverifiedKeysOnDisk := []string{}
+	for _, validator := range validators {
+		key, err := w.GetValidatorKeyByPubkey(validator.Pubkey)
+		if err != nil || !validator.Verified {
+			response.SkippedKeys = append(response.SkippedKeys, validator.Pubkey)
+			if err == nil {
+				unverifiedKeysOnDisk = append(unverifiedKeysOnDisk, validator.Pubkey.Hex())
+			}
+			continue
+		}
+		keys = append(keys, key)
+		response.LoadedKeys = append(response.LoadedKeys, validator.Pubkey)
+	}
+	if len(keys) == 0 {
+		return &response, nil
+	}
+
+	// Get the slashing protection database uploaded by the CLI
+	slashingProtection, err := readSlashingProtectionImport(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the keys with the keymanager API if possible, since it's the only way to import slashing protection alongside them
+	if km != nil {
+		err = validator.ImportValidatorKeys(km, keys, nil, slashingProtection)
+		if err != nil {
+			return nil, fmt.Errorf("error loading validator keys with the keymanager API: %w", err)
+		}
+		response.SlashingProtectionImported = (slashingProtection != "")
+		return &response, nil
+	}
+	if slashingProtection != "" {
+		return nil, fmt.Errorf("a slashing protection database can only be imported with the keymanager API, which is disabled; enable it with `rocketpool service config` and try again")
+	}
+
+	// Restarting loads every key on disk, so don't restart if any of them no longer pass verification
+	if len(unverifiedKeysOnDisk) > 0 {
+		return nil, fmt.Errorf("the keys for %s are saved on disk but no longer pass verification, so the Validator Client won't be restarted to load the others; resolve their issues or delete them with `rocketpool wallet purge` first", strings.Join(unverifiedKeysOnDisk, ", "))
+	}
+	err = validator.RestartValidator(cfg, bc, nil, d)
+	if err != nil {
+		return nil, err
+	}
+	response.Restarted = true
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the node's minipool validators from the network state, along with their status on the Beacon Chain
+func getRecoveryValidators(c *cli.Context, w *wallet.Wallet) ([]api.RecoveryValidator, error) {
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	mgr, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, nil)
+	if err != nil {
+		return nil, err
+	}
+	networkState, _, err := mgr.GetHeadStateForNode(nodeAccount.Address, false)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network state: %w", err)
+	}
+
+	zeroPubkey := types.ValidatorPubkey{}
+	validators := []api.RecoveryValidator{}
+	for _, mpd := range networkState.MinipoolDetailsByNode[nodeAccount.Address] {
+		if mpd.Pubkey == zeroPubkey {
+			continue
+		}
+		validator := api.RecoveryValidator{
+			MinipoolAddress: mpd.MinipoolAddress,
+			Pubkey:          mpd.Pubkey,
+		}
+		status, exists := networkState.ValidatorDetails[mpd.Pubkey]
+		if exists && status.Exists {
+			validator.Index = status.Index
+			validator.ValidatorStatus = string(status.Status)
+			validator.ExistsOnBeacon = true
+			validator.Slashed = status.Slashed
+			validator.CredentialsMatch = bytes.Equal(status.WithdrawalCredentials.Bytes(), getMinipoolWithdrawalCredentials(mpd.MinipoolAddress).Bytes())
+		}
+		validator.Verified = validator.ExistsOnBeacon && !validator.Slashed && validator.CredentialsMatch
+		validators = append(validators, validator)
+	}
+	return validators, nil
+}
+
+// Get the 0x01 withdrawal credentials that point to a minipool
+func getMinipoolWithdrawalCredentials(minipoolAddress common.Address) common.Hash {
+	credentials := common.Hash{}
+	credentials[0] = 0x01
+	copy(credentials[12:], minipoolAddress.Bytes())
+	return credentials
+}
+
+// Read the slashing protection database the CLI uploaded for the recovery wizard, if there was one
+func readSlashingProtectionImport(cfg *config.RocketPoolConfig) (string, error) {
+	path := cfg.Smartnode.GetSlashingProtectionImportPath(true)
+	contents, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error reading slashing protection database from [%s]: %w", path, err)
+	}
+	return string(contents), nil
+}
@@ -78,6 +78,7 @@ func recoverWallet(c *cli.Context, mnemonic string) (*api.RecoverWalletResponse,
 		if err != nil {
 			return nil, err
 		}
+		response.KeysLoaded = walletutils.LoadRecoveredKeys(c, w, response.ValidatorKeys)
 	}
 
 	// Save wallet
@@ -128,7 +129,7 @@ func searchAndRecoverWallet(c *cli.Context, mnemonic string, address common.Addr
 	for i := uint(0); i < findIterations; i++ {
 		for j := 0; j < len(paths); j++ {
 			derivationPath := paths[j]
-			recoveredWallet, err := wallet.NewWallet("", uint(w.GetChainID().Uint64()), nil, nil, 0, nil)
+			recoveredWallet, err := wallet.NewWallet("", "", uint(w.GetChainID().Uint64()), nil, nil, 0, nil)
 			if err != nil {
 				return nil, fmt.Errorf("error generating new wallet: %w", err)
 			}
@@ -176,6 +177,7 @@ func searchAndRecoverWallet(c *cli.Context, mnemonic string, address common.Addr
 		if err != nil {
 			return nil, err
 		}
+		response.KeysLoaded = walletutils.LoadRecoveredKeys(c, w, response.ValidatorKeys)
 	}
 
 	// Save wallet
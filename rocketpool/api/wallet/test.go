@@ -4,7 +4,9 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
@@ -33,7 +35,7 @@ func testRecoverWallet(c *cli.Context, mnemonic string) (*api.RecoverWalletRespo
 
 	// Create a blank wallet
 	chainId := cfg.Smartnode.GetChainID()
-	w, err := wallet.NewWallet("", chainId, nil, nil, 0, nil)
+	w, err := wallet.NewWallet("", "", chainId, nil, nil, 0, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -99,7 +101,7 @@ func testSearchAndRecoverWallet(c *cli.Context, mnemonic string, address common.
 
 	// Create a blank wallet
 	chainId := cfg.Smartnode.GetChainID()
-	w, err := wallet.NewWallet("", chainId, nil, nil, 0, nil)
+	w, err := wallet.NewWallet("", "", chainId, nil, nil, 0, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -116,7 +118,7 @@ func testSearchAndRecoverWallet(c *cli.Context, mnemonic string, address common.
 	for i := uint(0); i < findIterations; i++ {
 		for j := 0; j < len(paths); j++ {
 			derivationPath := paths[j]
-			recoveredWallet, err := wallet.NewWallet("", uint(w.GetChainID().Uint64()), nil, nil, 0, nil)
+			recoveredWallet, err := wallet.NewWallet("", "", uint(w.GetChainID().Uint64()), nil, nil, 0, nil)
 			if err != nil {
 				return nil, fmt.Errorf("error generating new wallet: %w", err)
 			}
@@ -170,3 +172,88 @@ func testSearchAndRecoverWallet(c *cli.Context, mnemonic string, address common.
 	return &response, nil
 
 }
+
+func verifyRecovery(c *cli.Context, mnemonic string) (*api.VerifyRecoveryResponse, error) {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.VerifyRecoveryResponse{}
+
+	// Get the node address the mnemonic should regenerate
+	if c.String("address") != "" {
+		response.NodeAddress = common.HexToAddress(c.String("address"))
+	} else {
+		if !w.IsInitialized() {
+			return nil, fmt.Errorf("the node wallet is not initialized, so the address the mnemonic should regenerate must be provided")
+		}
+		nodeAccount, err := w.GetNodeAccount()
+		if err != nil {
+			return nil, err
+		}
+		response.NodeAddress = nodeAccount.Address
+	}
+
+	// Recover the wallet in memory only
+	recoveredWallet, err := wallet.NewWallet("", "", cfg.Smartnode.GetChainID(), nil, nil, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	path := c.String("derivation-path")
+	switch path {
+	case "":
+		path = wallet.DefaultNodeKeyPath
+	case "ledgerLive":
+		path = wallet.LedgerLiveNodeKeyPath
+	case "mew":
+		path = wallet.MyEtherWalletNodeKeyPath
+	}
+	if err := recoveredWallet.TestRecovery(path, c.Uint("wallet-index"), mnemonic); err != nil {
+		return nil, err
+	}
+	recoveredAccount, err := recoveredWallet.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.AccountAddress = recoveredAccount.Address
+	response.AddressMatches = (response.AccountAddress == response.NodeAddress)
+	if !response.AddressMatches {
+		return &response, nil
+	}
+
+	// Check that every minipool's validator key can be regenerated
+	pubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(rp, response.NodeAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	zeroPubkey := types.ValidatorPubkey{}
+	response.ValidatorKeys = []types.ValidatorPubkey{}
+	for _, pubkey := range pubkeys {
+		if pubkey != zeroPubkey {
+			response.ValidatorKeys = append(response.ValidatorKeys, pubkey)
+		}
+	}
+	response.MissingKeys, err = walletutils.FindUnrecoverableKeys(cfg, recoveredWallet, response.ValidatorKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
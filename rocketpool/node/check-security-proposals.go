@@ -0,0 +1,108 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/rocket-pool/rocketpool-go/dao"
+	"github.com/rocket-pool/rocketpool-go/dao/security"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Check security council proposals task
+type checkSecurityProposals struct {
+	c   *cli.Context
+	log log.ColorLogger
+	cfg *config.RocketPoolConfig
+	w   *wallet.Wallet
+	rp  *rocketpool.RocketPool
+
+	// Proposals that the node has already been notified about
+	notifiedProposals map[uint64]bool
+}
+
+// Create check security council proposals task
+func newCheckSecurityProposals(c *cli.Context, logger log.ColorLogger) (*checkSecurityProposals, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkSecurityProposals{
+		c:                 c,
+		log:               logger,
+		cfg:               cfg,
+		w:                 w,
+		rp:                rp,
+		notifiedProposals: map[uint64]bool{},
+	}, nil
+
+}
+
+// Check for security council proposals the node needs to vote on
+func (t *checkSecurityProposals) run(state *state.NetworkState) error {
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Only security council members can act on these proposals
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+	}
+	isMember, err := security.GetMemberExists(t.rp, nodeAccount.Address, opts)
+	if err != nil {
+		return fmt.Errorf("error checking security council membership: %w", err)
+	}
+	if !isMember {
+		return nil
+	}
+
+	// Get the proposals
+	proposals, err := dao.GetDAOProposalsWithMember(t.rp, "rocketDAOSecurityProposals", nodeAccount.Address, opts)
+	if err != nil {
+		return fmt.Errorf("error getting security council proposals: %w", err)
+	}
+
+	// Notify about each active proposal that hasn't been voted on yet
+	for _, proposal := range proposals {
+		if proposal.State != rptypes.Active || proposal.MemberVoted || t.notifiedProposals[proposal.ID] {
+			continue
+		}
+
+		endTime := time.Unix(int64(proposal.EndTime), 0)
+		t.log.Printlnf("Security council proposal %d (%s) is open for voting until %s and the node has not voted on it yet.", proposal.ID, proposal.Message, endTime.Format(time.RFC1123))
+		if err := alerting.AlertSecurityCouncilProposal(t.cfg, proposal.ID, proposal.Message, endTime); err != nil {
+			t.log.Printlnf("WARNING: could not send alert for security council proposal %d: %s", proposal.ID, err.Error())
+		}
+		t.notifiedProposals[proposal.ID] = true
+	}
+
+	// Return
+	return nil
+
+}
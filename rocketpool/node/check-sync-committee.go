@@ -0,0 +1,163 @@
+package node
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/readiness"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// How often to look for new sync committee assignments; they only change once per period, so there's no need to check every cycle
+	syncCommitteeCheckInterval = 15 * time.Minute
+
+	// How long before a sync committee period starts to run the readiness checks a second time
+	preDutyAuditWindow = 1 * time.Hour
+)
+
+// Check sync committee task
+type checkSyncCommittee struct {
+	c           *cli.Context
+	log         log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	ec          *services.ExecutionClientManager
+	bc          *services.BeaconClientManager
+	nodeAddress common.Address
+
+	lastCheckTime  time.Time
+	activePeriod   uint64
+	notifiedPeriod uint64
+	auditedPeriod  uint64
+}
+
+// Create check sync committee task
+func newCheckSyncCommittee(c *cli.Context, logger log.ColorLogger) (*checkSyncCommittee, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkSyncCommittee{
+		c:           c,
+		log:         logger,
+		cfg:         cfg,
+		ec:          ec,
+		bc:          bc,
+		nodeAddress: nodeAccount.Address,
+	}, nil
+
+}
+
+// Look for sync committee assignments for the node's validators, and check that the node is ready for them.
+// The checks run once when an assignment for the next period is found, and again shortly before that period starts.
+func (t *checkSyncCommittee) run(state *state.NetworkState) error {
+	if time.Since(t.lastCheckTime) < syncCommitteeCheckInterval {
+		return nil
+	}
+	t.lastCheckTime = time.Now()
+
+	// Get the node's active validators
+	indices := []string{}
+	for _, mpd := range state.MinipoolDetailsByNode[t.nodeAddress] {
+		status, exists := state.ValidatorDetails[mpd.Pubkey]
+		if !exists || !status.Exists {
+			continue
+		}
+		switch status.Status {
+		case beacon.ValidatorState_ActiveOngoing, beacon.ValidatorState_ActiveExiting:
+			indices = append(indices, status.Index)
+		}
+	}
+	if len(indices) == 0 {
+		return nil
+	}
+
+	// Get the assignments
+	epoch := state.BeaconSlotNumber / state.BeaconConfig.SlotsPerEpoch
+	current, next, err := readiness.GetSyncCommitteePeriods(t.bc, state.BeaconConfig, indices, epoch)
+	if err != nil {
+		return err
+	}
+	if len(current.Validators) > 0 && t.activePeriod != current.Period {
+		t.activePeriod = current.Period
+		t.log.Printlnf("Validators %s are in the current sync committee, which ends in %s.", strings.Join(current.Validators, ", "), time.Until(current.EndTime).Round(time.Minute))
+	}
+	if len(next.Validators) == 0 {
+		return nil
+	}
+
+	timeLeft := time.Until(next.StartTime)
+	if t.notifiedPeriod != next.Period {
+		// Give advance notice as soon as the assignment is known
+		t.notifiedPeriod = next.Period
+		t.log.Printlnf("Validators %s will be in the next sync committee (period %d), which starts in %s. Running the readiness checks...", strings.Join(next.Validators, ", "), next.Period, timeLeft.Round(time.Minute))
+		failures := t.audit()
+		if err := alerting.AlertUpcomingSyncCommitteeDuty(t.cfg, next.Period, len(next.Validators), timeLeft, failures); err != nil {
+			t.log.Printlnf("WARNING: couldn't send the sync committee alert: %s", err.Error())
+		}
+	} else if timeLeft <= preDutyAuditWindow && t.auditedPeriod != next.Period {
+		// Check again right before the duties start, and only alert if something's wrong
+		t.auditedPeriod = next.Period
+		t.log.Printlnf("Sync committee period %d starts in %s. Running the readiness checks again...", next.Period, timeLeft.Round(time.Minute))
+		failures := t.audit()
+		if len(failures) > 0 {
+			if err := alerting.AlertUpcomingSyncCommitteeDuty(t.cfg, next.Period, len(next.Validators), timeLeft, failures); err != nil {
+				t.log.Printlnf("WARNING: couldn't send the sync committee alert: %s", err.Error())
+			}
+		}
+	}
+
+	return nil
+}
+
+// Run the readiness checks and log the results
+func (t *checkSyncCommittee) audit() []string {
+	checks := readiness.CheckDutyReadiness(t.cfg, t.ec, t.bc)
+	for _, check := range checks {
+		t.logCheck(check)
+	}
+	failures := readiness.GetFailures(checks)
+	if len(failures) == 0 {
+		t.log.Println("All of the readiness checks passed.")
+	}
+	return failures
+}
+
+// Log the result of a readiness check
+func (t *checkSyncCommittee) logCheck(check api.DutyReadinessCheck) {
+	if check.Passed {
+		t.log.Printlnf("\tPASSED %s: %s", check.Name, check.Details)
+	} else {
+		t.log.Printlnf("\tFAILED %s: %s", check.Name, check.Details)
+	}
+}
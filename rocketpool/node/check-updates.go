@@ -0,0 +1,82 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/updates"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How often to check for new client and Smartnode releases
+const updateCheckInterval = 6 * time.Hour
+
+// Check updates task
+type checkUpdates struct {
+	c   *cli.Context
+	log log.ColorLogger
+	cfg *config.RocketPoolConfig
+
+	lastCheckTime time.Time
+
+	// The latest release of each image that's already been reported, so each one is only alerted on once
+	reported map[string]string
+}
+
+// Create check updates task
+func newCheckUpdates(c *cli.Context, logger log.ColorLogger) (*checkUpdates, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkUpdates{
+		c:        c,
+		log:      logger,
+		cfg:      cfg,
+		reported: map[string]string{},
+	}, nil
+
+}
+
+// Check GitHub and Docker Hub for new releases of the configured clients and the Smartnode
+func (t *checkUpdates) run() error {
+	if time.Since(t.lastCheckTime) < updateCheckInterval {
+		return nil
+	}
+	t.lastCheckTime = time.Now()
+
+	newUpdates := []string{}
+	for _, update := range updates.CheckForUpdates(t.cfg, shared.RocketPoolVersion) {
+		if update.Error != "" {
+			t.log.Printlnf("Couldn't check %s for updates: %s", update.Name, update.Error)
+			continue
+		}
+		if !update.UpdateAvailable {
+			continue
+		}
+		key := update.Name + update.ContainerSuffix
+		if t.reported[key] == update.LatestTag {
+			continue
+		}
+		t.reported[key] = update.LatestTag
+		t.log.Printlnf("%s %s is available (currently using %s): %s", update.Name, update.LatestVersion, update.CurrentTag, update.ReleaseUrl)
+		newUpdates = append(newUpdates, fmt.Sprintf("%s %s", update.Name, update.LatestVersion))
+	}
+
+	if len(newUpdates) > 0 {
+		if err := alerting.AlertClientUpdatesAvailable(t.cfg, newUpdates); err != nil {
+			t.log.Printlnf("WARNING: couldn't send the update alert: %s", err.Error())
+		}
+	}
+	return nil
+}
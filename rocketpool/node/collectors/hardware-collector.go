@@ -0,0 +1,212 @@
+package collectors
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/rocket-pool/smartnode/shared/services/hardware"
+)
+
+// Represents the collector for the host's hardware metrics
+type HardwareCollector struct {
+
+	// The percentage of CPU time in use
+	cpuUsageDesc *prometheus.Desc
+
+	// The percentage of CPU time spent waiting on disk IO
+	ioWaitDesc *prometheus.Desc
+
+	// The total and used memory
+	memoryTotalDesc *prometheus.Desc
+	memoryUsedDesc  *prometheus.Desc
+
+	// The total and used space on each filesystem
+	filesystemTotalDesc       *prometheus.Desc
+	filesystemUsedDesc        *prometheus.Desc
+	filesystemUsedPercentDesc *prometheus.Desc
+
+	// The IO activity of each device
+	deviceReadsDesc        *prometheus.Desc
+	deviceWritesDesc       *prometheus.Desc
+	deviceReadLatencyDesc  *prometheus.Desc
+	deviceWriteLatencyDesc *prometheus.Desc
+	deviceUtilizationDesc  *prometheus.Desc
+
+	// The health of each NVMe drive
+	nvmePercentageUsedDesc  *prometheus.Desc
+	nvmeAvailableSpareDesc  *prometheus.Desc
+	nvmeCriticalWarningDesc *prometheus.Desc
+	nvmeTemperatureDesc     *prometheus.Desc
+	nvmeMediaErrorsDesc     *prometheus.Desc
+
+	// The latest sample
+	metrics *hardware.HostMetrics
+
+	// Mutex
+	updateLock *sync.Mutex
+}
+
+// Create a new HardwareCollector instance
+func NewHardwareCollector() *HardwareCollector {
+	subsystem := "hardware"
+	return &HardwareCollector{
+		cpuUsageDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "cpu_usage_percent"),
+			"The percentage of CPU time in use",
+			nil, nil,
+		),
+		ioWaitDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "io_wait_percent"),
+			"The percentage of CPU time spent waiting on disk IO",
+			nil, nil,
+		),
+		memoryTotalDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "memory_total_bytes"),
+			"The total memory on the host",
+			nil, nil,
+		),
+		memoryUsedDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "memory_used_bytes"),
+			"The memory in use on the host",
+			nil, nil,
+		),
+		filesystemTotalDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "filesystem_total_bytes"),
+			"The size of a filesystem the Smartnode uses",
+			[]string{"path"}, nil,
+		),
+		filesystemUsedDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "filesystem_used_bytes"),
+			"The space used on a filesystem the Smartnode uses",
+			[]string{"path"}, nil,
+		),
+		filesystemUsedPercentDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "filesystem_used_percent"),
+			"The percentage of space used on a filesystem the Smartnode uses",
+			[]string{"path"}, nil,
+		),
+		deviceReadsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "device_reads_per_second"),
+			"The number of reads per second from a block device",
+			[]string{"device"}, nil,
+		),
+		deviceWritesDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "device_writes_per_second"),
+			"The number of writes per second to a block device",
+			[]string{"device"}, nil,
+		),
+		deviceReadLatencyDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "device_read_latency_ms"),
+			"The average time a read from a block device took",
+			[]string{"device"}, nil,
+		),
+		deviceWriteLatencyDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "device_write_latency_ms"),
+			"The average time a write to a block device took",
+			[]string{"device"}, nil,
+		),
+		deviceUtilizationDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "device_utilization_percent"),
+			"The percentage of time a block device was busy",
+			[]string{"device"}, nil,
+		),
+		nvmePercentageUsedDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "nvme_percentage_used"),
+			"The percentage of an NVMe drive's rated endurance that has been used",
+			[]string{"drive", "model"}, nil,
+		),
+		nvmeAvailableSpareDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "nvme_available_spare_percent"),
+			"The percentage of an NVMe drive's spare capacity that's still available",
+			[]string{"drive", "model"}, nil,
+		),
+		nvmeCriticalWarningDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "nvme_critical_warning"),
+			"The critical warning flags in an NVMe drive's SMART log",
+			[]string{"drive", "model"}, nil,
+		),
+		nvmeTemperatureDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "nvme_temperature_celsius"),
+			"The temperature of an NVMe drive",
+			[]string{"drive", "model"}, nil,
+		),
+		nvmeMediaErrorsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "nvme_media_errors"),
+			"The number of unrecovered data integrity errors on an NVMe drive",
+			[]string{"drive", "model"}, nil,
+		),
+		updateLock: &sync.Mutex{},
+	}
+}
+
+// Store the latest hardware sample
+func (collector *HardwareCollector) Update(metrics *hardware.HostMetrics) {
+	collector.updateLock.Lock()
+	defer collector.updateLock.Unlock()
+	collector.metrics = metrics
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *HardwareCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.cpuUsageDesc
+	channel <- collector.ioWaitDesc
+	channel <- collector.memoryTotalDesc
+	channel <- collector.memoryUsedDesc
+	channel <- collector.filesystemTotalDesc
+	channel <- collector.filesystemUsedDesc
+	channel <- collector.filesystemUsedPercentDesc
+	channel <- collector.deviceReadsDesc
+	channel <- collector.deviceWritesDesc
+	channel <- collector.deviceReadLatencyDesc
+	channel <- collector.deviceWriteLatencyDesc
+	channel <- collector.deviceUtilizationDesc
+	channel <- collector.nvmePercentageUsedDesc
+	channel <- collector.nvmeAvailableSpareDesc
+	channel <- collector.nvmeCriticalWarningDesc
+	channel <- collector.nvmeTemperatureDesc
+	channel <- collector.nvmeMediaErrorsDesc
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *HardwareCollector) Collect(channel chan<- prometheus.Metric) {
+
+	// Sync
+	collector.updateLock.Lock()
+	defer collector.updateLock.Unlock()
+	metrics := collector.metrics
+	if metrics == nil {
+		return
+	}
+
+	// Update all of the metrics
+	channel <- prometheus.MustNewConstMetric(
+		collector.cpuUsageDesc, prometheus.GaugeValue, metrics.CpuUsagePercent)
+	channel <- prometheus.MustNewConstMetric(
+		collector.ioWaitDesc, prometheus.GaugeValue, metrics.IoWaitPercent)
+	channel <- prometheus.MustNewConstMetric(
+		collector.memoryTotalDesc, prometheus.GaugeValue, float64(metrics.MemoryTotal))
+	channel <- prometheus.MustNewConstMetric(
+		collector.memoryUsedDesc, prometheus.GaugeValue, float64(metrics.MemoryUsed))
+
+	for _, fs := range metrics.Filesystems {
+		channel <- prometheus.MustNewConstMetric(
+			collector.filesystemTotalDesc, prometheus.GaugeValue, float64(fs.Total), fs.Path)
+		channel <- prometheus.MustNewConstMetric(
+			collector.filesystemUsedDesc, prometheus.GaugeValue, float64(fs.Used), fs.Path)
+		channel <- prometheus.MustNewConstMetric(
+			collector.filesystemUsedPercentDesc, prometheus.GaugeValue, fs.UsedPercent, fs.Path)
+	}
+
+	for _, device := range metrics.Devices {
+		channel <- prometheus.MustNewConstMetric(
+			collector.deviceReadsDesc, prometheus.GaugeValue, device.ReadsPerSecond, device.Name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.deviceWritesDesc, prometheus.GaugeValue, device.WritesPerSecond, device.Name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.deviceReadLatencyDesc, prometheus.GaugeValue, device.ReadLatencyMs, device.Name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.deviceWriteLatencyDesc, prometheus.GaugeValue, device.WriteLatencyMs, device.Name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.deviceUtilizationDesc, prometheus.GaugeValue, device.UtilizationPercent, device.Name)
+	}
+
+	for _, drive := range metrics.NvmeDrives {
+		if drive.SmartUnavailableNote != "" {
+			continue
+		}
+		channel <- prometheus.MustNewConstMetric(
+			collector.nvmePercentageUsedDesc, prometheus.GaugeValue, float64(drive.PercentageUsed), drive.Name, drive.Model)
+		channel <- prometheus.MustNewConstMetric(
+			collector.nvmeAvailableSpareDesc, prometheus.GaugeValue, float64(drive.AvailableSparePct), drive.Name, drive.Model)
+		channel <- prometheus.MustNewConstMetric(
+			collector.nvmeCriticalWarningDesc, prometheus.GaugeValue, float64(drive.CriticalWarning), drive.Name, drive.Model)
+		channel <- prometheus.MustNewConstMetric(
+			collector.nvmeTemperatureDesc, prometheus.GaugeValue, drive.TemperatureCelsius, drive.Name, drive.Model)
+		channel <- prometheus.MustNewConstMetric(
+			collector.nvmeMediaErrorsDesc, prometheus.GaugeValue, float64(drive.MediaErrors), drive.Name, drive.Model)
+	}
+}
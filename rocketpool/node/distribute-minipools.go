@@ -17,6 +17,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/alerting"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/bundler"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/state"
@@ -31,6 +32,7 @@ type distributeMinipools struct {
 	log                 log.ColorLogger
 	cfg                 *config.RocketPoolConfig
 	w                   *wallet.Wallet
+	gw                  *wallet.GasWallet
 	rp                  *rocketpool.RocketPool
 	bc                  beacon.Client
 	d                   *client.Client
@@ -41,6 +43,8 @@ type distributeMinipools struct {
 	maxFee              *big.Int
 	maxPriorityFee      *big.Int
 	gasLimit            uint64
+	bundle              bool
+	multicallAddress    common.Address
 }
 
 // Create distribute minipools task
@@ -55,6 +59,10 @@ func newDistributeMinipools(c *cli.Context, logger log.ColorLogger) (*distribute
 	if err != nil {
 		return nil, err
 	}
+	gw, err := services.GetGasWallet(c)
+	if err != nil {
+		return nil, err
+	}
 	rp, err := services.GetRocketPool(c)
 	if err != nil {
 		return nil, err
@@ -105,12 +113,17 @@ func newDistributeMinipools(c *cli.Context, logger log.ColorLogger) (*distribute
 		priorityFee = eth.GweiToWei(priorityFeeGwei)
 	}
 
+	// Check if distributions should be bundled
+	multicallAddress := cfg.Smartnode.GetMulticallAddress()
+	bundle := cfg.Experimental.IsEnabled(config.FeatureFlag_BundleMaintenanceTxs) && multicallAddress != ""
+
 	// Return task
 	return &distributeMinipools{
 		c:                   c,
 		log:                 logger,
 		cfg:                 cfg,
 		w:                   w,
+		gw:                  gw,
 		rp:                  rp,
 		bc:                  bc,
 		d:                   d,
@@ -121,6 +134,8 @@ func newDistributeMinipools(c *cli.Context, logger log.ColorLogger) (*distribute
 		maxFee:              maxFee,
 		maxPriorityFee:      priorityFee,
 		gasLimit:            0,
+		bundle:              bundle,
+		multicallAddress:    common.HexToAddress(multicallAddress),
 	}, nil
 
 }
@@ -141,14 +156,14 @@ func (t *distributeMinipools) run(state *state.NetworkState) error {
 		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
 	}
 
-	// Get node account
-	nodeAccount, err := t.w.GetNodeAccount()
+	// Get the node address; the gas wallet pays for distributions, so this doesn't need the node's key
+	nodeAddress, err := t.w.GetNodeAddress()
 	if err != nil {
 		return err
 	}
 
 	// Get prelaunch minipools
-	minipools, err := t.getDistributableMinipools(nodeAccount.Address, state, opts)
+	minipools, err := t.getDistributableMinipools(nodeAddress, state, opts)
 	if err != nil {
 		return err
 	}
@@ -159,6 +174,11 @@ func (t *distributeMinipools) run(state *state.NetworkState) error {
 	// Log
 	t.log.Printlnf("%d minipool(s) can have their balances distributed...", len(minipools))
 
+	// Bundle the distributions into a single transaction if there are several of them
+	if t.bundle && len(minipools) > 1 {
+		return t.distributeMinipoolBundle(minipools, opts)
+	}
+
 	// Distribute minipools
 	successCount := 0
 	for _, mpd := range minipools {
@@ -217,11 +237,14 @@ func (t *distributeMinipools) distributeMinipool(mpd *rpstate.NativeMinipoolDeta
 		return false, fmt.Errorf("cannot create binding for minipool %s: %w", mpd.MinipoolAddress.Hex(), err)
 	}
 
-	// Get transactor
-	opts, err := t.w.GetNodeAccountTransactor()
+	// Get transactor; distributing skimmed rewards is open to anyone, so the gas wallet pays for it if there is one
+	opts, fromGasWallet, err := wallet.GetTransactorForTx(t.w, t.gw, wallet.GasWalletTx_DistributeMinipool)
 	if err != nil {
 		return false, err
 	}
+	if fromGasWallet {
+		t.log.Printlnf("Paying for the distribution from the gas wallet (%s).", opts.From.Hex())
+	}
 
 	// Get the gas limit
 	mpv3, success := minipool.GetMinipoolAsV3(mp)
@@ -276,3 +299,110 @@ func (t *distributeMinipools) distributeMinipool(mpd *rpstate.NativeMinipoolDeta
 	return true, nil
 
 }
+
+// Distribute several minipools in a single multicall transaction. Each distribution is simulated first; the ones that would
+// fail are left out and reported, and the rest go into the bundle.
+func (t *distributeMinipools) distributeMinipoolBundle(minipools []*rpstate.NativeMinipoolDetails, callOpts *bind.CallOpts) error {
+
+	// Log
+	t.log.Printlnf("Bundling the distributions of %d minipools into one transaction...", len(minipools))
+
+	// Build the bundle; distributing skimmed rewards is open to anyone, so the multicall contract can send it
+	b, err := bundler.NewBundler(t.rp.Client, t.multicallAddress)
+	if err != nil {
+		return err
+	}
+	for _, mpd := range minipools {
+		mp, err := minipool.NewMinipoolFromVersion(t.rp, mpd.MinipoolAddress, mpd.Version, callOpts)
+		if err != nil {
+			return fmt.Errorf("cannot create binding for minipool %s: %w", mpd.MinipoolAddress.Hex(), err)
+		}
+		callData, err := mp.GetContract().ABI.Pack("distributeBalance", true)
+		if err != nil {
+			return fmt.Errorf("error encoding the distribution of minipool %s: %w", mpd.MinipoolAddress.Hex(), err)
+		}
+		b.Add(bundler.Item{
+			Description:    fmt.Sprintf("distribute minipool %s", mpd.MinipoolAddress.Hex()),
+			Target:         mpd.MinipoolAddress,
+			CallData:       callData,
+			Permissionless: true,
+		})
+	}
+
+	// Get transactor
+	opts, fromGasWallet, err := wallet.GetTransactorForTx(t.w, t.gw, wallet.GasWalletTx_DistributeMinipool)
+	if err != nil {
+		return err
+	}
+	if fromGasWallet {
+		t.log.Printlnf("Paying for the bundle from the gas wallet (%s).", opts.From.Hex())
+	}
+
+	// Simulate each distribution and report the ones that would fail
+	results, err := b.Simulate(opts.From)
+	if err != nil {
+		return err
+	}
+	bundled := []common.Address{}
+	for i, result := range results {
+		if result.Bundled {
+			bundled = append(bundled, minipools[i].MinipoolAddress)
+			continue
+		}
+		t.log.Printlnf("Leaving minipool %s out of the bundle: %s", minipools[i].MinipoolAddress.Hex(), result.Error.Error())
+		alerting.AlertMinipoolBalanceDistributed(t.cfg, minipools[i].MinipoolAddress, false)
+	}
+	if len(bundled) == 0 {
+		return nil
+	}
+
+	// Get the gas limit
+	gasInfo, err := b.GetGasInfo(results, opts)
+	if err != nil {
+		return fmt.Errorf("Could not estimate the gas required to distribute the minipool bundle: %w", err)
+	}
+	var gas *big.Int
+	if t.gasLimit != 0 {
+		gas = new(big.Int).SetUint64(t.gasLimit)
+	} else {
+		gas = new(big.Int).SetUint64(gasInfo.SafeGasLimit)
+	}
+
+	// Get the max fee
+	maxFee := t.maxFee
+	if maxFee == nil || maxFee.Uint64() == 0 {
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Print the gas info
+	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &t.log, maxFee, t.gasLimit) {
+		return nil
+	}
+
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = t.maxPriorityFee
+	opts.GasLimit = gas.Uint64()
+
+	// Distribute the bundle
+	hash, err := b.Submit(results, opts)
+	if err == nil {
+		// Print TX info and wait for it to be included in a block
+		err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	}
+	for _, address := range bundled {
+		alerting.AlertMinipoolBalanceDistributed(t.cfg, address, err == nil)
+	}
+	if err != nil {
+		return fmt.Errorf("Could not distribute the minipool bundle: %w", err)
+	}
+
+	// Log
+	t.log.Printlnf("Successfully distributed the balances of %d minipool(s) in one transaction.", len(bundled))
+
+	// Return
+	return nil
+
+}
@@ -0,0 +1,40 @@
+package node
+
+import (
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/portmap"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How often to check if the router's port mappings need to be renewed
+const portForwardingInterval = 1 * time.Minute
+
+// Forward ports task
+type forwardPorts struct {
+	forwarder *portmap.Forwarder
+}
+
+// Create forward ports task
+func newForwardPorts(c *cli.Context, logger log.ColorLogger) (*forwardPorts, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &forwardPorts{
+		forwarder: portmap.NewForwarder(cfg, &logger),
+	}, nil
+
+}
+
+// Add or renew the router's mappings for the P2P ports
+func (t *forwardPorts) run() error {
+	return t.forwarder.Refresh()
+}
@@ -0,0 +1,464 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/client"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/attestation"
+	"github.com/rocket-pool/smartnode/shared/services/backup"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/hostguard"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/eth2"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+// Settings
+const (
+	// How long another machine's beacon counts as active; a few task loops, so one slow loop doesn't let two machines run at once
+	activeHostTimeout = 20 * time.Minute
+
+	// How many epochs before the Validator Client started to check for attestations it couldn't have made
+	activeHostLookbackEpochs uint64 = 4
+)
+
+// Guard active host task
+type guardActiveHost struct {
+	c           *cli.Context
+	log         log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	w           *wallet.Wallet
+	bc          beacon.Client
+	d           *client.Client
+	nodeAddress common.Address
+	hostId      string
+	checker     *attestation.Checker
+
+	// The epochs to check for attestations made while this machine's Validator Client wasn't running.
+	// The local beacon is refreshed while the Validator Client runs, so the one from before the daemon started marks when it was last seen.
+	startupBeacon     *api.ActiveHostBeacon
+	chainCheckStarted bool
+	nextCheckEpoch    uint64
+	lastCheckEpoch    uint64
+
+	stoppedForConflict bool
+
+	// Whether the Validator Client was paused when the daemon started, until the chain check shows no other machine is attesting.
+	// Its start and stop times are recorded first, since unlocking the validator keys would otherwise restart it and replace them.
+	heldAtStartup bool
+	vcStartedAt   time.Time
+	vcFinishedAt  time.Time
+
+	// run() and refresh() are called from different loops
+	lock sync.Mutex
+}
+
+// Create guard active host task
+func newGuardActiveHost(c *cli.Context, logger log.ColorLogger) (*guardActiveHost, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	hostId, err := hostguard.GetHostId(cfg)
+	if err != nil {
+		return nil, err
+	}
+	startupBeacon, err := hostguard.LoadLocalBeacon(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &guardActiveHost{
+		c:             c,
+		log:           logger,
+		cfg:           cfg,
+		w:             w,
+		bc:            bc,
+		d:             d,
+		nodeAddress:   nodeAccount.Address,
+		hostId:        hostId,
+		checker:       attestation.NewChecker(bc),
+		startupBeacon: startupBeacon,
+	}, nil
+
+}
+
+// Keep the Validator Client from performing duties when the daemon starts until it's clear no other machine is running the validators.
+// Saved conflicts and the remote beacon are checked right away; if the Validator Client only just started, it's paused until run() has checked the chain too.
+// This runs before the clients are synced, so the Validator Client can't keep signing while the daemon waits for them.
+func (t *guardActiveHost) holdAtStartup() error {
+	if !t.cfg.Smartnode.EnableActiveHostGuard.Value.(bool) {
+		return nil
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	// Keep the Validator Client stopped if there's an unresolved conflict
+	conflict, err := hostguard.LoadConflict(t.cfg)
+	if err != nil {
+		return err
+	}
+	if conflict != nil {
+		t.stopValidator(conflict.Reason)
+		return nil
+	}
+
+	// Check the beacon another machine may have published
+	storage, err := backup.GetRemoteStorage(t.cfg)
+	if err != nil {
+		t.log.Printlnf("WARNING: couldn't get the remote storage to check the active host: %s", err.Error())
+	}
+	if storage != nil {
+		network := string(t.cfg.Smartnode.Network.Value.(cfgtypes.Network))
+		remoteBeacon, err := hostguard.LoadRemoteBeacon(storage, network, t.nodeAddress)
+		if err != nil {
+			t.log.Printlnf("WARNING: couldn't check the remote active host beacon: %s", err.Error())
+		} else if reason := getBeaconConflict(remoteBeacon, t.hostId); reason != "" {
+			return t.raiseConflict(reason)
+		}
+	}
+
+	// Only a Validator Client that just started has epochs to check, since startChainCheck() skips the rest
+	if t.cfg.IsNativeMode {
+		t.log.Println("The Validator Client can't be held while the chain is checked in Native Mode; only the remote beacon was checked before it started.")
+		return nil
+	}
+	clientType, _ := t.bc.GetClientType()
+	if clientType == beacon.SingleProcess {
+		t.log.Println("The Validator Client runs in the Beacon Node's container, so it can't be held while the chain is checked; only the remote beacon was checked before it started.")
+		return nil
+	}
+	containerName, err := getValidatorContainerName(t.cfg, t.bc)
+	if err != nil {
+		return err
+	}
+	container, err := t.d.ContainerInspect(context.Background(), containerName)
+	if client.IsErrNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error inspecting container %s: %w", containerName, err)
+	}
+	startedAt, err := time.Parse(time.RFC3339Nano, container.State.StartedAt)
+	if err != nil {
+		return fmt.Errorf("error parsing the start time of container %s: %w", containerName, err)
+	}
+	t.vcStartedAt = startedAt
+	t.vcFinishedAt, _ = time.Parse(time.RFC3339Nano, container.State.FinishedAt)
+	if !container.State.Running || container.State.Paused || time.Since(startedAt) > activeHostTimeout {
+		return nil
+	}
+
+	// Pausing it keeps its start time, so the chain check still covers the epochs before it started
+	t.log.Println("Holding the Validator Client until the chain has been checked for another machine running this node's validators.")
+	if err := validator.StopValidator(t.cfg, t.bc, &t.log, t.d); err != nil {
+		return fmt.Errorf("couldn't hold the Validator Client: %w", err)
+	}
+	t.heldAtStartup = true
+	return nil
+}
+
+// Stop the Validator Client if another machine appears to be running the node's validators, otherwise publish this machine's beacon
+func (t *guardActiveHost) run(state *state.NetworkState) error {
+	if !t.cfg.Smartnode.EnableActiveHostGuard.Value.(bool) {
+		return nil
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	// Keep the Validator Client stopped until the conflict is cleared
+	conflict, err := hostguard.LoadConflict(t.cfg)
+	if err != nil {
+		return err
+	}
+	if conflict != nil {
+		if !t.stoppedForConflict {
+			t.stopValidator(conflict.Reason)
+		}
+		return nil
+	}
+	if t.stoppedForConflict {
+		// The conflict was claimed, which means this machine is the active host and the startup check doesn't apply
+		t.log.Println("The active host conflict was cleared, restarting the Validator Client.")
+		if err := validator.RestartValidator(t.cfg, t.bc, &t.log, t.d); err != nil {
+			return err
+		}
+		t.stoppedForConflict = false
+		t.heldAtStartup = false
+		t.chainCheckStarted = true
+		t.nextCheckEpoch = t.lastCheckEpoch + 1
+	}
+
+	// Check the beacon another machine may have published
+	storage, err := backup.GetRemoteStorage(t.cfg)
+	if err != nil {
+		t.log.Printlnf("WARNING: couldn't get the remote storage to check the active host: %s", err.Error())
+	}
+	if storage != nil {
+		network := string(t.cfg.Smartnode.Network.Value.(cfgtypes.Network))
+		remoteBeacon, err := hostguard.LoadRemoteBeacon(storage, network, t.nodeAddress)
+		if err != nil {
+			t.log.Printlnf("WARNING: couldn't check the remote active host beacon: %s", err.Error())
+		} else if reason := getBeaconConflict(remoteBeacon, t.hostId); reason != "" {
+			return t.raiseConflict(reason)
+		}
+	}
+
+	// Check the chain for attestations made while this machine's Validator Client wasn't running
+	if !t.chainCheckStarted {
+		if err := t.startChainCheck(state); err != nil {
+			t.log.Printlnf("WARNING: couldn't start the active host check: %s", err.Error())
+		}
+	}
+	reason, err := t.checkChain(state)
+	if err != nil {
+		return err
+	}
+	if reason != "" {
+		return t.raiseConflict(reason)
+	}
+
+	// Let the Validator Client start its duties once every epoch has been checked
+	if t.heldAtStartup && (t.nextCheckEpoch == 0 || t.nextCheckEpoch > t.lastCheckEpoch) {
+		t.log.Println("No other machine was running this node's validators, releasing the Validator Client.")
+		if err := validator.RestartValidator(t.cfg, t.bc, &t.log, t.d); err != nil {
+			return err
+		}
+		t.heldAtStartup = false
+	}
+
+	// Claim this machine as the active host while it's running the validators
+	running, err := t.isValidatorRunning()
+	if err != nil || !running {
+		return err
+	}
+	beacon, err := hostguard.NewBeacon(t.cfg, t.w, t.hostId)
+	if err != nil {
+		return err
+	}
+	return hostguard.PublishBeacon(t.cfg, storage, beacon)
+}
+
+// Check if the Validator Client is being kept stopped, either until the startup check finishes or because of a conflict
+func (t *guardActiveHost) isHoldingValidator() bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.heldAtStartup || t.stoppedForConflict
+}
+
+// Refresh the local beacon while the Validator Client is running, so it records the last time this machine was attesting.
+// This runs more often than the main task loop; the beacon is only uploaded by run().
+func (t *guardActiveHost) refresh() error {
+	if !t.cfg.Smartnode.EnableActiveHostGuard.Value.(bool) {
+		return nil
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.stoppedForConflict {
+		return nil
+	}
+	conflict, err := hostguard.LoadConflict(t.cfg)
+	if err != nil || conflict != nil {
+		return err
+	}
+	running, err := t.isValidatorRunning()
+	if err != nil || !running {
+		return err
+	}
+	beacon, err := hostguard.NewBeacon(t.cfg, t.w, t.hostId)
+	if err != nil {
+		return err
+	}
+	return hostguard.PublishBeacon(t.cfg, nil, beacon)
+}
+
+// Work out which epochs to check for attestations made by another machine, using when the Validator Client last stopped and started
+func (t *guardActiveHost) startChainCheck(state *state.NetworkState) error {
+	t.chainCheckStarted = true
+	if t.cfg.IsNativeMode {
+		t.log.Println("The Validator Client's start time isn't available in Native Mode, so only the remote beacon will be used to check the active host.")
+		return nil
+	}
+
+	// Get when the Validator Client container last started and stopped, preferring the times from before the daemon touched it
+	startedAt, finishedAt := t.vcStartedAt, t.vcFinishedAt
+	if startedAt.IsZero() {
+		containerName, err := getValidatorContainerName(t.cfg, t.bc)
+		if err != nil {
+			return err
+		}
+		container, err := t.d.ContainerInspect(context.Background(), containerName)
+		if err != nil {
+			return fmt.Errorf("error inspecting container %s: %w", containerName, err)
+		}
+		startedAt, err = time.Parse(time.RFC3339Nano, container.State.StartedAt)
+		if err != nil {
+			return fmt.Errorf("error parsing the start time of container %s: %w", containerName, err)
+		}
+		finishedAt, _ = time.Parse(time.RFC3339Nano, container.State.FinishedAt)
+	}
+
+	// If it's been running for a while, the epochs before it started don't say anything about who's attesting now
+	if time.Since(startedAt) > activeHostTimeout {
+		return nil
+	}
+
+	// Only check the time this machine couldn't have been attesting; it could have kept going for one hardware check after the last beacon
+	lowerBound := startedAt.Add(-time.Duration(activeHostLookbackEpochs*state.BeaconConfig.SecondsPerEpoch) * time.Second)
+	if finishedAt.After(lowerBound) && finishedAt.Before(startedAt) {
+		lowerBound = finishedAt
+	}
+	if t.startupBeacon != nil && t.startupBeacon.Timestamp.Add(hardwareMonitorInterval).After(lowerBound) {
+		lowerBound = t.startupBeacon.Timestamp.Add(hardwareMonitorInterval)
+	}
+	if !lowerBound.Before(startedAt) || lowerBound.Unix() < int64(state.BeaconConfig.GenesisTime) {
+		return nil
+	}
+
+	// Only whole epochs inside that window count
+	firstEpoch := eth2.EpochAt(state.BeaconConfig, uint64(lowerBound.Unix())) + 1
+	lastEpoch := eth2.EpochAt(state.BeaconConfig, uint64(startedAt.Unix()))
+	if lastEpoch <= firstEpoch {
+		return nil
+	}
+	t.nextCheckEpoch = firstEpoch
+	t.lastCheckEpoch = lastEpoch - 1
+	t.log.Printlnf("Checking epochs %d to %d for attestations made while the Validator Client wasn't running.", t.nextCheckEpoch, t.lastCheckEpoch)
+	return nil
+}
+
+// Check the pending epochs for attestations by the node's validators, returning the reason for a conflict if there were any
+func (t *guardActiveHost) checkChain(state *state.NetworkState) (string, error) {
+	if t.nextCheckEpoch == 0 || t.nextCheckEpoch > t.lastCheckEpoch {
+		return "", nil
+	}
+
+	// Get the node's active validators
+	validators := map[string]bool{}
+	for _, mpd := range state.MinipoolDetailsByNode[t.nodeAddress] {
+		status, exists := state.ValidatorDetails[mpd.Pubkey]
+		if !exists || !status.Exists {
+			continue
+		}
+		switch status.Status {
+		case beacon.ValidatorState_ActiveOngoing, beacon.ValidatorState_ActiveExiting, beacon.ValidatorState_ActiveSlashed:
+			validators[status.Index] = true
+		}
+	}
+	if len(validators) == 0 {
+		t.nextCheckEpoch = t.lastCheckEpoch + 1
+		return "", nil
+	}
+
+	// An epoch's attestations can be included until the end of the next one, so wait until they're all in
+	head, err := t.bc.GetBeaconHead()
+	if err != nil {
+		return "", fmt.Errorf("error getting the Beacon head: %w", err)
+	}
+	for ; t.nextCheckEpoch <= t.lastCheckEpoch && t.nextCheckEpoch+2 <= head.Epoch; t.nextCheckEpoch++ {
+		stats, err := t.checker.CheckEpoch(state.BeaconConfig.SlotsPerEpoch, t.nextCheckEpoch, validators)
+		if err != nil {
+			return "", err
+		}
+		if stats.Included > 0 {
+			return fmt.Sprintf("%d of the node's validators attested in epoch %d, before this machine's Validator Client started", stats.Included, t.nextCheckEpoch), nil
+		}
+	}
+	t.checker.Prune(t.nextCheckEpoch * state.BeaconConfig.SlotsPerEpoch)
+	return "", nil
+}
+
+// Record a conflict, stop the Validator Client, and alert
+func (t *guardActiveHost) raiseConflict(reason string) error {
+	if _, err := hostguard.SaveConflict(t.cfg, reason); err != nil {
+		return err
+	}
+	t.stopValidator(reason)
+	if err := alerting.AlertActiveHostConflict(t.cfg, reason); err != nil {
+		t.log.Printlnf("WARNING: couldn't send the active host conflict alert: %s", err.Error())
+	}
+	return nil
+}
+
+// Stop the Validator Client because of a conflict
+func (t *guardActiveHost) stopValidator(reason string) {
+	t.log.Printlnf("WARNING: another machine appears to be running this node's validators: %s.", reason)
+	t.log.Println("Stopping the Validator Client to avoid being slashed. Run `rocketpool service active-host --claim` once only one machine is running them.")
+	if err := validator.StopValidator(t.cfg, t.bc, &t.log, t.d); err != nil {
+		t.log.Printlnf("WARNING: couldn't stop the Validator Client: %s", err.Error())
+		return
+	}
+	t.stoppedForConflict = true
+}
+
+// Check if the Validator Client is running; in Native Mode there's no way to tell, so it's assumed to be
+func (t *guardActiveHost) isValidatorRunning() (bool, error) {
+	if t.cfg.IsNativeMode {
+		return true, nil
+	}
+	containerName, err := getValidatorContainerName(t.cfg, t.bc)
+	if err != nil {
+		return false, err
+	}
+	container, err := t.d.ContainerInspect(context.Background(), containerName)
+	if client.IsErrNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error inspecting container %s: %w", containerName, err)
+	}
+	return container.State.Running && !container.State.Paused, nil
+}
+
+// Check if a recent beacon from another machine means it's running the validators, returning the reason if so
+func getBeaconConflict(remoteBeacon *api.ActiveHostBeacon, hostId string) string {
+	if remoteBeacon == nil || remoteBeacon.HostId == hostId || time.Since(remoteBeacon.Timestamp) > activeHostTimeout {
+		return ""
+	}
+	return fmt.Sprintf("host %s (%s) claimed to be the active host at %s", remoteBeacon.Hostname, remoteBeacon.HostId, remoteBeacon.Timestamp.Format(time.RFC1123))
+}
+
+// Get the name of the container that runs the Validator Client
+func getValidatorContainerName(cfg *config.RocketPoolConfig, bc beacon.Client) (string, error) {
+	clientType, _ := bc.GetClientType()
+	switch clientType {
+	case beacon.SplitProcess:
+		return cfg.Smartnode.ProjectName.Value.(string) + validator.ValidatorContainerSuffix, nil
+	case beacon.SingleProcess:
+		return cfg.Smartnode.ProjectName.Value.(string) + validator.BeaconContainerSuffix, nil
+	default:
+		return "", fmt.Errorf("unknown client type '%d'", clientType)
+	}
+}
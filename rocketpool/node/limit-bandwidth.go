@@ -0,0 +1,44 @@
+package node
+
+import (
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/bandwidth"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How often to check the bandwidth limit schedule and for restarted containers
+const bandwidthLimitInterval = 1 * time.Minute
+
+// Limit bandwidth task
+type limitBandwidth struct {
+	limiter *bandwidth.Limiter
+}
+
+// Create limit bandwidth task
+func newLimitBandwidth(c *cli.Context, logger log.ColorLogger) (*limitBandwidth, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &limitBandwidth{
+		limiter: bandwidth.NewLimiter(cfg, d, &logger),
+	}, nil
+
+}
+
+// Apply the bandwidth limits for the current time
+func (t *limitBandwidth) run() error {
+	return t.limiter.Refresh()
+}
@@ -29,10 +29,13 @@ type manageFeeRecipient struct {
 	rp  *rocketpool.RocketPool
 	d   *client.Client
 	bc  beacon.Client
+
+	fallbackMonitor *services.FallbackMonitor
+	checkedPair     string
 }
 
 // Create manage fee recipient task
-func newManageFeeRecipient(c *cli.Context, logger log.ColorLogger) (*manageFeeRecipient, error) {
+func newManageFeeRecipient(c *cli.Context, logger log.ColorLogger, fallbackMonitor *services.FallbackMonitor) (*manageFeeRecipient, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -65,6 +68,9 @@ func newManageFeeRecipient(c *cli.Context, logger log.ColorLogger) (*manageFeeRe
 		rp:  rp,
 		d:   d,
 		bc:  bc,
+
+		fallbackMonitor: fallbackMonitor,
+		checkedPair:     "primary client pair",
 	}, nil
 
 }
@@ -72,10 +78,24 @@ func newManageFeeRecipient(c *cli.Context, logger log.ColorLogger) (*manageFeeRe
 // Manage fee recipient
 func (m *manageFeeRecipient) run(state *state.NetworkState) error {
 
-	// Wait for eth client to sync
+	// Wait for both clients of the active pair to sync, so the fee recipient is never checked against a mismatched pair
 	if err := services.WaitEthClientSynced(m.c, true); err != nil {
 		return err
 	}
+	if err := services.WaitBeaconClientSynced(m.c, true); err != nil {
+		return err
+	}
+
+	// Don't touch the fee recipient files if none of the client pairs are healthy
+	pair := m.fallbackMonitor.GetActivePairName()
+	if pair == "" {
+		m.log.Println("WARNING: none of the client pairs are healthy, skipping the fee recipient check.")
+		return nil
+	}
+	if pair != m.checkedPair {
+		m.log.Printlnf("The Smartnode has switched to the %s, re-checking the fee recipient against it.", pair)
+		m.checkedPair = pair
+	}
 
 	// Log
 	m.log.Println("Checking for correct fee recipient...")
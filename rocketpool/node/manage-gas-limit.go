@@ -0,0 +1,79 @@
+package node
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Manage gas limit task
+type manageGasLimit struct {
+	c   *cli.Context
+	log log.ColorLogger
+	cfg *config.RocketPoolConfig
+	km  *keymanager.Client
+}
+
+// Create manage gas limit task
+func newManageGasLimit(c *cli.Context, logger log.ColorLogger) (*manageGasLimit, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	km, err := services.GetKeymanager(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &manageGasLimit{
+		c:   c,
+		log: logger,
+		cfg: cfg,
+		km:  km,
+	}, nil
+
+}
+
+// Apply the preferred gas limit to any validators in the running Validator Client that aren't using it yet.
+// The Validator Client's own flag covers it after a restart; this keeps keys loaded with the keymanager API in line too.
+func (m *manageGasLimit) run(state *state.NetworkState) error {
+
+	preferredGasLimit := m.cfg.Smartnode.PreferredGasLimit.Value.(uint64)
+	if m.km == nil || preferredGasLimit == 0 {
+		return nil
+	}
+
+	keys, err := m.km.ListKeys()
+	if err != nil {
+		return err
+	}
+	updatedCount := 0
+	for _, key := range keys {
+		gasLimit, err := m.km.GetGasLimit(key.Pubkey)
+		if err != nil {
+			return err
+		}
+		if gasLimit == preferredGasLimit {
+			continue
+		}
+		err = m.km.SetGasLimit(key.Pubkey, preferredGasLimit)
+		if err != nil {
+			return err
+		}
+		updatedCount++
+	}
+	if updatedCount > 0 {
+		m.log.Printlnf("Set the gas limit of %d validator(s) to %d.", updatedCount, preferredGasLimit)
+	}
+
+	// Return
+	return nil
+
+}
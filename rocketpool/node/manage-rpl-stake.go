@@ -0,0 +1,406 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/tokens"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How often to repeat the notification about excess RPL
+const excessRplNotificationInterval time.Duration = 24 * time.Hour
+
+// Manage RPL stake task
+type manageRplStake struct {
+	c              *cli.Context
+	log            log.ColorLogger
+	cfg            *config.RocketPoolConfig
+	w              *wallet.Wallet
+	rp             *rocketpool.RocketPool
+	gasThreshold   float64
+	maxFee         *big.Int
+	maxPriorityFee *big.Int
+	gasLimit       uint64
+	disabled       bool
+
+	// Collateral settings, in percent of borrowed ETH
+	targetRatio  float64
+	lowerBound   float64
+	upperBound   float64
+	autoWithdraw bool
+	simulate     bool
+
+	lastExcessNotification time.Time
+}
+
+// Create manage RPL stake task
+func newManageRplStake(c *cli.Context, logger log.ColorLogger) (*manageRplStake, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the collateral band
+	targetRatio := cfg.Smartnode.RplStakeTargetRatio.Value.(float64)
+	lowerBound := cfg.Smartnode.RplStakeLowerBound.Value.(float64)
+	upperBound := cfg.Smartnode.RplStakeUpperBound.Value.(float64)
+	gasThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
+	disabled := false
+	if lowerBound > targetRatio || targetRatio > upperBound {
+		logger.Printlnf("WARNING: RPL stake target (%.2f%%) must be between the lower bound (%.2f%%) and the upper bound (%.2f%%), disabling RPL stake management.", targetRatio, lowerBound, upperBound)
+		disabled = true
+	} else if gasThreshold == 0 {
+		logger.Println("Automatic tx gas threshold is 0, disabling RPL stake management.")
+		disabled = true
+	}
+
+	// Get the user-requested max fee
+	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
+	var maxFee *big.Int
+	if maxFeeGwei == 0 {
+		maxFee = nil
+	} else {
+		maxFee = eth.GweiToWei(maxFeeGwei)
+	}
+
+	// Get the user-requested priority fee
+	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
+	var priorityFee *big.Int
+	if priorityFeeGwei == 0 {
+		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		priorityFee = eth.GweiToWei(2)
+	} else {
+		priorityFee = eth.GweiToWei(priorityFeeGwei)
+	}
+
+	// Return task
+	return &manageRplStake{
+		c:              c,
+		log:            logger,
+		cfg:            cfg,
+		w:              w,
+		rp:             rp,
+		gasThreshold:   gasThreshold,
+		maxFee:         maxFee,
+		maxPriorityFee: priorityFee,
+		gasLimit:       0,
+		disabled:       disabled,
+		targetRatio:    targetRatio,
+		lowerBound:     lowerBound,
+		upperBound:     upperBound,
+		autoWithdraw:   cfg.Smartnode.RplStakeAutoWithdraw.Value.(bool),
+		simulate:       cfg.Smartnode.RplStakeSimulationMode.Value.(bool),
+	}, nil
+
+}
+
+// Keep the node's collateral ratio inside the configured band
+func (t *manageRplStake) run(state *state.NetworkState) error {
+
+	// Check if RPL stake management is disabled
+	if t.disabled {
+		return nil
+	}
+
+	// Log
+	t.log.Println("Checking the node's RPL collateral ratio...")
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the node's collateral ratio
+	nodeDetails, exists := state.NodeDetailsByAddress[nodeAccount.Address]
+	if !exists {
+		return fmt.Errorf("node %s was not found in the network state", nodeAccount.Address.Hex())
+	}
+	borrowedEth := nodeDetails.EthMatched
+	rplPrice := state.NetworkDetails.RplPrice
+	if borrowedEth.Sign() == 0 || rplPrice.Sign() == 0 {
+		t.log.Println("The node isn't borrowing any ETH, so there is no collateral ratio to manage.")
+		return nil
+	}
+	ratio := getCollateralRatio(nodeDetails.RplStake, rplPrice, borrowedEth)
+	t.log.Printlnf("Current collateral ratio is %.2f%% (band %.2f%% - %.2f%%, target %.2f%%).", ratio, t.lowerBound, t.upperBound, t.targetRatio)
+
+	// Get the amount of RPL that would put the node on target
+	targetStake := getRplForCollateralRatio(t.targetRatio, rplPrice, borrowedEth)
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+	}
+
+	if ratio < t.lowerBound {
+		// Stake what's needed, limited by what's in the node wallet
+		amount := big.NewInt(0).Sub(targetStake, nodeDetails.RplStake)
+		if amount.Cmp(nodeDetails.BalanceRPL) > 0 {
+			amount.Set(nodeDetails.BalanceRPL)
+		}
+		if amount.Sign() <= 0 {
+			t.log.Println("The node is below its lower collateral bound, but there is no RPL in the node wallet to stake.")
+			return nil
+		}
+		return t.stakeRpl(nodeAccount.Address, amount)
+	}
+
+	if ratio > t.upperBound {
+		excess := big.NewInt(0).Sub(nodeDetails.RplStake, targetStake)
+		if !t.autoWithdraw {
+			t.notifyExcess(ratio, excess)
+			return nil
+		}
+		amount, err := t.getWithdrawableRpl(nodeAccount.Address, nodeDetails.RplStake, nodeDetails.MaximumRPLStake, excess, state, opts)
+		if err != nil {
+			return err
+		}
+		if amount.Sign() <= 0 {
+			t.notifyExcess(ratio, excess)
+			return nil
+		}
+		return t.withdrawRpl(nodeAccount.Address, amount)
+	}
+
+	// Return
+	return nil
+
+}
+
+// Log and send an alert about excess RPL, at most once per notification interval
+func (t *manageRplStake) notifyExcess(ratio float64, excess *big.Int) {
+	if time.Since(t.lastExcessNotification) < excessRplNotificationInterval {
+		return
+	}
+	t.log.Printlnf("The node's collateral ratio (%.2f%%) is above the upper bound (%.2f%%); about %.6f RPL could be withdrawn to get back to the target.", ratio, t.upperBound, eth.WeiToEth(excess))
+	if err := alerting.AlertRplStakeAboveUpperBound(t.cfg, ratio, t.upperBound, eth.WeiToEth(excess)); err != nil {
+		t.log.Printlnf("WARNING: could not send excess RPL alert: %s", err.Error())
+	}
+	t.lastExcessNotification = time.Now()
+}
+
+// Get how much of the excess RPL can be withdrawn right now
+func (t *manageRplStake) getWithdrawableRpl(nodeAddress common.Address, rplStake *big.Int, maximumRplStake *big.Int, excess *big.Int, state *state.NetworkState, opts *bind.CallOpts) (*big.Int, error) {
+
+	// RPL can only be withdrawn by the node if it doesn't have a separate RPL withdrawal address
+	if state.IsHoustonDeployed {
+		isRplWithdrawalAddressSet, err := node.GetNodeRPLWithdrawalAddressIsSet(t.rp, nodeAddress, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error checking RPL withdrawal address: %w", err)
+		}
+		if isRplWithdrawalAddressSet {
+			rplWithdrawalAddress, err := node.GetNodeRPLWithdrawalAddress(t.rp, nodeAddress, opts)
+			if err != nil {
+				return nil, fmt.Errorf("error getting RPL withdrawal address: %w", err)
+			}
+			if rplWithdrawalAddress != nodeAddress {
+				t.log.Println("The node has an RPL withdrawal address set, so it can't withdraw RPL on its own.")
+				return big.NewInt(0), nil
+			}
+		}
+	}
+
+	// Check the withdrawal cooldown
+	rplStakedTime, err := node.GetNodeRPLStakedTime(t.rp, nodeAddress, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting RPL staked time: %w", err)
+	}
+	withdrawalDelay, err := protocol.GetRewardsClaimIntervalTime(t.rp, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting RPL withdrawal delay: %w", err)
+	}
+	unlockTime := time.Unix(int64(rplStakedTime), 0).Add(withdrawalDelay)
+	if time.Until(unlockTime) > 0 {
+		t.log.Printlnf("The node's RPL is locked until %s.", unlockTime.Format(time.RFC1123))
+		return big.NewInt(0), nil
+	}
+
+	// The remaining stake can't drop below the maximum stake or the locked amount
+	lockedRpl := big.NewInt(0)
+	if state.IsHoustonDeployed {
+		lockedRpl, err = node.GetNodeRPLLocked(t.rp, nodeAddress, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error getting locked RPL: %w", err)
+		}
+	}
+	available := big.NewInt(0).Sub(rplStake, maximumRplStake)
+	available.Sub(available, lockedRpl)
+	if excess.Cmp(available) < 0 {
+		return excess, nil
+	}
+	return available, nil
+
+}
+
+// Stake RPL from the node wallet
+func (t *manageRplStake) stakeRpl(nodeAddress common.Address, amount *big.Int) error {
+
+	if t.simulate {
+		t.log.Printlnf("[SIMULATION] Would stake %.6f RPL from the node wallet.", eth.WeiToEth(amount))
+		return nil
+	}
+	t.log.Printlnf("Staking %.6f RPL from the node wallet...", eth.WeiToEth(amount))
+
+	// Make sure the staking contract can spend the RPL
+	rocketNodeStakingAddress, err := t.rp.GetAddress("rocketNodeStaking", nil)
+	if err != nil {
+		return err
+	}
+	allowance, err := tokens.GetRPLAllowance(t.rp, nodeAddress, *rocketNodeStakingAddress, nil)
+	if err != nil {
+		return fmt.Errorf("error getting RPL allowance: %w", err)
+	}
+	if allowance.Cmp(amount) < 0 {
+		opts, err := t.w.GetNodeAccountTransactor()
+		if err != nil {
+			return err
+		}
+		gasInfo, err := tokens.EstimateApproveRPLGas(t.rp, *rocketNodeStakingAddress, amount, opts)
+		if err != nil {
+			return fmt.Errorf("could not estimate the gas required to approve RPL: %w", err)
+		}
+		if !t.assignGas(opts, gasInfo) {
+			return nil
+		}
+		hash, err := tokens.ApproveRPL(t.rp, *rocketNodeStakingAddress, amount, opts)
+		if err != nil {
+			return fmt.Errorf("error approving RPL: %w", err)
+		}
+		err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Stake the RPL
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return err
+	}
+	gasInfo, err := node.EstimateStakeGas(t.rp, amount, opts)
+	if err != nil {
+		return fmt.Errorf("could not estimate the gas required to stake RPL: %w", err)
+	}
+	if !t.assignGas(opts, gasInfo) {
+		return nil
+	}
+	hash, err := node.StakeRPL(t.rp, amount, opts)
+	if err != nil {
+		return fmt.Errorf("error staking RPL: %w", err)
+	}
+	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	if err != nil {
+		return err
+	}
+
+	// Log
+	t.log.Printlnf("Successfully staked %.6f RPL.", eth.WeiToEth(amount))
+	return nil
+
+}
+
+// Withdraw excess RPL
+func (t *manageRplStake) withdrawRpl(nodeAddress common.Address, amount *big.Int) error {
+
+	if t.simulate {
+		t.log.Printlnf("[SIMULATION] Would withdraw %.6f RPL.", eth.WeiToEth(amount))
+		return nil
+	}
+	t.log.Printlnf("Withdrawing %.6f excess RPL...", eth.WeiToEth(amount))
+
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return err
+	}
+	gasInfo, err := node.EstimateWithdrawRPLGas(t.rp, nodeAddress, amount, opts)
+	if err != nil {
+		return fmt.Errorf("could not estimate the gas required to withdraw RPL: %w", err)
+	}
+	if !t.assignGas(opts, gasInfo) {
+		return nil
+	}
+	hash, err := node.WithdrawRPL(t.rp, nodeAddress, amount, opts)
+	if err != nil {
+		return fmt.Errorf("error withdrawing RPL: %w", err)
+	}
+	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	if err != nil {
+		return err
+	}
+
+	// Log
+	t.log.Printlnf("Successfully withdrew %.6f RPL.", eth.WeiToEth(amount))
+	return nil
+
+}
+
+// Set the gas settings on the transactor, returning false if the gas price is above the threshold
+func (t *manageRplStake) assignGas(opts *bind.TransactOpts, gasInfo rocketpool.GasInfo) bool {
+	var gas *big.Int
+	if t.gasLimit != 0 {
+		gas = new(big.Int).SetUint64(t.gasLimit)
+	} else {
+		gas = new(big.Int).SetUint64(gasInfo.SafeGasLimit)
+	}
+
+	// Get the max fee
+	maxFee := t.maxFee
+	if maxFee == nil || maxFee.Uint64() == 0 {
+		var err error
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		if err != nil {
+			t.log.Printlnf("Could not get the max fee: %s", err.Error())
+			return false
+		}
+	}
+
+	// Print the gas info
+	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &t.log, maxFee, t.gasLimit) {
+		return false
+	}
+
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = t.maxPriorityFee
+	opts.GasLimit = gas.Uint64()
+	return true
+}
+
+// Get the value of an RPL stake as a percentage of the borrowed ETH
+func getCollateralRatio(rplStake *big.Int, rplPrice *big.Int, borrowedEth *big.Int) float64 {
+	stakeValue := big.NewInt(0).Mul(rplStake, rplPrice)
+	stakeValue.Div(stakeValue, eth.EthToWei(1))
+	ratio, _ := big.NewFloat(0).Quo(new(big.Float).SetInt(stakeValue), new(big.Float).SetInt(borrowedEth)).Float64()
+	return ratio * 100
+}
+
+// Get the RPL stake required for a collateral ratio (as a percentage of the borrowed ETH)
+func getRplForCollateralRatio(ratio float64, rplPrice *big.Int, borrowedEth *big.Int) *big.Int {
+	stake := big.NewInt(0).Mul(borrowedEth, eth.EthToWei(ratio/100))
+	return stake.Div(stake, rplPrice)
+}
@@ -10,11 +10,12 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/metrics"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/urfave/cli"
 )
 
-func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *collectors.StateLocker) error {
+func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *collectors.StateLocker, hardwareCollector *collectors.HardwareCollector) error {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -78,6 +79,7 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 	registry.MustRegister(trustedNodeCollector)
 	registry.MustRegister(beaconCollector)
 	registry.MustRegister(smoothingPoolCollector)
+	registry.MustRegister(hardwareCollector)
 
 	// Set up snapshot checking if enabled
 	votingId := cfg.Smartnode.GetVotingSnapshotID()
@@ -90,6 +92,15 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 		registry.MustRegister(snapshotCollector)
 	}
 
+	// Start pushing the metrics to the remote endpoint if enabled
+	exporter, err := metrics.NewExporter(cfg, registry, "node", logger)
+	if err != nil {
+		return fmt.Errorf("Error creating metrics exporter: %w", err)
+	}
+	if exporter != nil {
+		go exporter.Run()
+	}
+
 	// Start the HTTP server
 	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
 	metricsAddress := c.GlobalString("metricsAddress")
@@ -0,0 +1,179 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/attestation"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/clock"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// The most epochs checked in one run, so the daemon doesn't spend a whole cycle catching up after downtime
+	maxAttestationEpochsPerRun uint64 = 4
+
+	// How long to wait before repeating the alert
+	attestationAlertCooldown = 1 * time.Hour
+)
+
+// Monitor attestations task
+type monitorAttestations struct {
+	c           *cli.Context
+	log         log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	bc          beacon.Client
+	nodeAddress common.Address
+
+	// The last epoch that was checked, and how many in a row have been below the threshold
+	lastEpoch     uint64
+	lowEpochCount uint64
+	lastAlertTime time.Time
+
+	checker *attestation.Checker
+}
+
+// Create monitor attestations task
+func newMonitorAttestations(c *cli.Context, logger log.ColorLogger) (*monitorAttestations, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &monitorAttestations{
+		c:           c,
+		log:         logger,
+		cfg:         cfg,
+		bc:          bc,
+		nodeAddress: nodeAccount.Address,
+		checker:     attestation.NewChecker(bc),
+	}, nil
+
+}
+
+// Check the attestations of the node's validators in every epoch that has finished since the last run
+func (t *monitorAttestations) run(state *state.NetworkState) error {
+
+	// Get the node's active validators
+	validators := map[string]bool{}
+	for _, mpd := range state.MinipoolDetailsByNode[t.nodeAddress] {
+		status, exists := state.ValidatorDetails[mpd.Pubkey]
+		if !exists || !status.Exists {
+			continue
+		}
+		switch status.Status {
+		case beacon.ValidatorState_ActiveOngoing, beacon.ValidatorState_ActiveExiting, beacon.ValidatorState_ActiveSlashed:
+			validators[status.Index] = true
+		}
+	}
+	if len(validators) == 0 {
+		return nil
+	}
+
+	// An epoch's attestations can be included until the end of the next one, so only check epochs that are two behind the head
+	head, err := t.bc.GetBeaconHead()
+	if err != nil {
+		return fmt.Errorf("error getting the Beacon head: %w", err)
+	}
+	if head.Epoch < 2 {
+		return nil
+	}
+	lastComplete := head.Epoch - 2
+	start := t.getStartEpoch(lastComplete)
+
+	for epoch := start; epoch <= lastComplete; epoch++ {
+		stats, err := t.checker.CheckEpoch(state.BeaconConfig.SlotsPerEpoch, epoch, validators)
+		if err != nil {
+			return err
+		}
+		t.lastEpoch = epoch
+		t.handleEpoch(epoch, stats)
+	}
+
+	// Drop the block data that no later epoch needs
+	t.checker.Prune(start * state.BeaconConfig.SlotsPerEpoch)
+	return nil
+
+}
+
+// Get the first epoch to check in this run, skipping ahead if too many have finished since the last one
+func (t *monitorAttestations) getStartEpoch(lastComplete uint64) uint64 {
+	start := t.lastEpoch + 1
+	if t.lastEpoch == 0 {
+		start = lastComplete
+	}
+	if lastComplete >= start+maxAttestationEpochsPerRun {
+		skipped := lastComplete - maxAttestationEpochsPerRun + 1
+		t.log.Printlnf("Skipping attestation checks for epochs %d to %d.", start, skipped-1)
+		start = skipped
+
+		// The skipped epochs were never checked, so a low-efficiency streak can't carry over them
+		t.lowEpochCount = 0
+	}
+	return start
+}
+
+// Log the epoch's results and alert if the efficiency has been low for too long
+func (t *monitorAttestations) handleEpoch(epoch uint64, stats *attestation.EpochStats) {
+	if stats.Duties == 0 {
+		return
+	}
+	efficiency := stats.Efficiency()
+	threshold := float64(t.cfg.Alertmanager.AttestationEfficiencyThreshold.Value.(uint64))
+	if efficiency >= threshold {
+		if t.lowEpochCount > 0 {
+			t.log.Printlnf("Attestation efficiency recovered to %.1f%% in epoch %d.", efficiency, epoch)
+		}
+		t.lowEpochCount = 0
+		return
+	}
+
+	t.lowEpochCount++
+	t.log.Printlnf("WARNING: attestation efficiency was %.1f%% in epoch %d (%d duties: %d included, %d timely source, %d timely target, %d timely head).",
+		efficiency, epoch, stats.Duties, stats.Included, stats.TimelySource, stats.TimelyTarget, stats.TimelyHead)
+	requiredEpochs := t.cfg.Alertmanager.AttestationEfficiencyEpochs.Value.(uint64)
+	if t.lowEpochCount < requiredEpochs {
+		return
+	}
+
+	// Clock skew makes attestations late or wrong without any other sign of a problem, so check it first
+	causes := stats.LikelyCauses()
+	clockStatus := clock.CheckClock(t.cfg, t.bc)
+	t.log.Printlnf("\tClock: %s.", clockStatus.Summary())
+	if clockStatus.Drifted {
+		causes = append([]string{clockStatus.Problem}, causes...)
+	}
+	for _, cause := range causes {
+		t.log.Printlnf("\tLikely cause: %s.", cause)
+	}
+	if time.Since(t.lastAlertTime) >= attestationAlertCooldown {
+		t.lastAlertTime = time.Now()
+		if err := alerting.AlertLowAttestationEfficiency(t.cfg, efficiency, t.lowEpochCount, causes); err != nil {
+			t.log.Printlnf("WARNING: couldn't send the attestation efficiency alert: %s", err.Error())
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+func TestGetStartEpochContinuesStreak(t *testing.T) {
+	task := &monitorAttestations{
+		log:           log.NewColorLogger(color.FgWhite),
+		lastEpoch:     10,
+		lowEpochCount: 2,
+	}
+
+	start := task.getStartEpoch(12)
+	if start != 11 {
+		t.Fatalf("expected start epoch 11, got %d", start)
+	}
+	if task.lowEpochCount != 2 {
+		t.Fatalf("expected the low epoch count to be kept at 2, got %d", task.lowEpochCount)
+	}
+}
+
+func TestGetStartEpochResetsStreakWhenSkipping(t *testing.T) {
+	task := &monitorAttestations{
+		log:           log.NewColorLogger(color.FgWhite),
+		lastEpoch:     10,
+		lowEpochCount: 2,
+	}
+
+	lastComplete := 10 + maxAttestationEpochsPerRun + 5
+	start := task.getStartEpoch(lastComplete)
+	if start != lastComplete-maxAttestationEpochsPerRun+1 {
+		t.Fatalf("expected start epoch %d, got %d", lastComplete-maxAttestationEpochsPerRun+1, start)
+	}
+	if task.lowEpochCount != 0 {
+		t.Fatalf("expected the low epoch count to be reset after skipping epochs, got %d", task.lowEpochCount)
+	}
+}
+
+func TestGetStartEpochFirstRun(t *testing.T) {
+	task := &monitorAttestations{
+		log: log.NewColorLogger(color.FgWhite),
+	}
+
+	start := task.getStartEpoch(100)
+	if start != 100 {
+		t.Fatalf("expected start epoch 100, got %d", start)
+	}
+}
@@ -0,0 +1,90 @@
+package node
+
+import (
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/clock"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// How often to compare the clock against the NTP servers
+	clockCheckInterval = 5 * time.Minute
+
+	// How long to wait before repeating the alert while the clock is still off
+	clockAlertCooldown = 1 * time.Hour
+)
+
+// Monitor clock task
+type monitorClock struct {
+	c   *cli.Context
+	log log.ColorLogger
+	cfg *config.RocketPoolConfig
+	bc  beacon.Client
+
+	lastCheckTime time.Time
+	lastAlertTime time.Time
+	drifted       bool
+}
+
+// Create monitor clock task
+func newMonitorClock(c *cli.Context, logger log.ColorLogger) (*monitorClock, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &monitorClock{
+		c:   c,
+		log: logger,
+		cfg: cfg,
+		bc:  bc,
+	}, nil
+
+}
+
+// Compare the system clock with the NTP servers and the Beacon Chain, and alert if it's drifted
+func (t *monitorClock) run() error {
+	if time.Since(t.lastCheckTime) < clockCheckInterval {
+		return nil
+	}
+	t.lastCheckTime = time.Now()
+
+	status := clock.CheckClock(t.cfg, t.bc)
+	if !status.NtpAvailable {
+		for _, result := range status.NtpOffsets {
+			t.log.Printlnf("WARNING: couldn't query NTP server %s: %s", result.Server, result.Error)
+		}
+	}
+	if !status.Drifted {
+		if t.drifted {
+			t.log.Printlnf("The system clock is back in sync: %s.", status.Summary())
+		}
+		t.drifted = false
+		return nil
+	}
+
+	t.drifted = true
+	t.log.Printlnf("WARNING: %s.", status.Problem)
+	if time.Since(t.lastAlertTime) >= clockAlertCooldown {
+		t.lastAlertTime = time.Now()
+		if err := alerting.AlertClockDrift(t.cfg, status.Problem); err != nil {
+			t.log.Printlnf("WARNING: couldn't send the clock drift alert: %s", err.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Rough gas costs of the node's duties, on the high side of what they use on mainnet
+const (
+	stakeMinipoolGas      uint64 = 200000
+	promoteMinipoolGas    uint64 = 150000
+	distributeMinipoolGas uint64 = 150000
+	reduceBondGas         uint64 = 200000
+	claimRewardsGas       uint64 = 300000
+)
+
+// How often to repeat the alert while the balance stays low
+const lowGasFundsAlertCooldown = 24 * time.Hour
+
+// How worried the watchdog is about the node wallet's balance
+type gasFundsLevel int
+
+const (
+	gasFundsLevel_Ok gasFundsLevel = iota
+	gasFundsLevel_Warning
+	gasFundsLevel_Critical
+)
+
+// Monitor gas funds task
+type monitorGasFunds struct {
+	c                   *cli.Context
+	log                 log.ColorLogger
+	cfg                 *config.RocketPoolConfig
+	nodeAddress         common.Address
+	distributeThreshold *big.Int
+	maxFee              *big.Int
+
+	lastLevel     gasFundsLevel
+	lastAlertTime time.Time
+}
+
+// Create monitor gas funds task
+func newMonitorGasFunds(c *cli.Context, logger log.ColorLogger) (*monitorGasFunds, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Balances are only distributed automatically if the threshold is set; it's clamped the same way the distribute task does
+	var distributeThreshold *big.Int
+	distributeThresholdEth := cfg.Smartnode.DistributeThreshold.Value.(float64)
+	if cfg.Smartnode.AutoTxGasThreshold.Value.(float64) != 0 && distributeThresholdEth != 0 {
+		if distributeThresholdEth >= 8 {
+			distributeThresholdEth = 7.5
+		}
+		distributeThreshold = eth.EthToWei(distributeThresholdEth)
+	}
+
+	// Get the user-requested max fee
+	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
+	var maxFee *big.Int
+	if maxFeeGwei == 0 {
+		maxFee = nil
+	} else {
+		maxFee = eth.GweiToWei(maxFeeGwei)
+	}
+
+	// Return task
+	return &monitorGasFunds{
+		c:                   c,
+		log:                 logger,
+		cfg:                 cfg,
+		nodeAddress:         nodeAccount.Address,
+		distributeThreshold: distributeThreshold,
+		maxFee:              maxFee,
+	}, nil
+
+}
+
+// Compare the node wallet's balance to the projected gas cost of its upcoming duties
+func (t *monitorGasFunds) run(state *state.NetworkState) error {
+
+	// Get the node's balance
+	nodeDetails, exists := state.NodeDetailsByAddress[t.nodeAddress]
+	if !exists {
+		return nil
+	}
+	balance := nodeDetails.BalanceETH
+
+	// Project the gas needed for the upcoming duties
+	gas, duties := t.getUpcomingDuties(state)
+	maxFee := t.maxFee
+	if maxFee == nil || maxFee.Uint64() == 0 {
+		var err error
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		if err != nil {
+			return fmt.Errorf("error getting the current gas price: %w", err)
+		}
+	}
+	projectedCost := new(big.Int).Mul(maxFee, new(big.Int).SetUint64(gas))
+
+	// Work out how worried to be
+	warningThreshold := multiplyWei(projectedCost, t.cfg.Alertmanager.GasFundsWarningMultiplier.Value.(float64))
+	minimumReserve := eth.EthToWei(t.cfg.Alertmanager.GasFundsMinimumReserve.Value.(float64))
+	if warningThreshold.Cmp(minimumReserve) < 0 {
+		warningThreshold = minimumReserve
+	}
+	criticalThreshold := multiplyWei(projectedCost, t.cfg.Alertmanager.GasFundsCriticalMultiplier.Value.(float64))
+	level := gasFundsLevel_Ok
+	if balance.Cmp(criticalThreshold) < 0 {
+		level = gasFundsLevel_Critical
+	} else if balance.Cmp(warningThreshold) < 0 {
+		level = gasFundsLevel_Warning
+	}
+
+	// Alert when things get worse, and every so often while they stay bad
+	if level == gasFundsLevel_Ok {
+		if t.lastLevel != gasFundsLevel_Ok {
+			t.log.Printlnf("The node wallet's balance of %.6f ETH is enough for its upcoming duties again.", eth.WeiToEth(balance))
+		}
+		t.lastLevel = level
+		return nil
+	}
+	if level > t.lastLevel || time.Since(t.lastAlertTime) > lowGasFundsAlertCooldown {
+		if len(duties) > 0 {
+			t.log.Printlnf("WARNING: the node wallet has %.6f ETH, and its upcoming duties (%s) are projected to cost %.6f ETH at %.2f gwei.", eth.WeiToEth(balance), strings.Join(duties, ", "), eth.WeiToEth(projectedCost), eth.WeiToGwei(maxFee))
+		} else {
+			t.log.Printlnf("WARNING: the node wallet has %.6f ETH, which is below the minimum reserve of %.6f ETH.", eth.WeiToEth(balance), eth.WeiToEth(minimumReserve))
+		}
+		if err := alerting.AlertLowGasFunds(t.cfg, eth.WeiToEth(balance), eth.WeiToEth(projectedCost), duties, level == gasFundsLevel_Critical); err != nil {
+			t.log.Printlnf("WARNING: couldn't send the low gas funds alert: %s", err.Error())
+		}
+		t.lastAlertTime = time.Now()
+	}
+	t.lastLevel = level
+	return nil
+
+}
+
+// Get the gas the node's upcoming duties will need, and a description of each kind
+func (t *monitorGasFunds) getUpcomingDuties(state *state.NetworkState) (uint64, []string) {
+	stakeCount := 0
+	promoteCount := 0
+	distributeCount := 0
+	reduceBondCount := 0
+	activeCount := 0
+	eight := eth.EthToWei(8)
+	for _, mpd := range state.MinipoolDetailsByNode[t.nodeAddress] {
+		if mpd.Finalised {
+			continue
+		}
+		activeCount++
+		switch mpd.Status {
+		case rptypes.Prelaunch:
+			if mpd.IsVacant {
+				promoteCount++
+			} else {
+				stakeCount++
+			}
+		case rptypes.Staking:
+			if t.distributeThreshold != nil && mpd.Version >= 3 && mpd.DistributableBalance.Cmp(t.distributeThreshold) >= 0 && mpd.DistributableBalance.Cmp(eight) < 0 {
+				distributeCount++
+			}
+			if mpd.ReduceBondTime != nil && mpd.ReduceBondTime.Sign() > 0 && !mpd.ReduceBondCancelled {
+				reduceBondCount++
+			}
+		}
+	}
+
+	gas := uint64(0)
+	duties := []string{}
+	if stakeCount > 0 {
+		gas += uint64(stakeCount) * stakeMinipoolGas
+		duties = append(duties, fmt.Sprintf("staking %d minipool(s)", stakeCount))
+	}
+	if promoteCount > 0 {
+		gas += uint64(promoteCount) * promoteMinipoolGas
+		duties = append(duties, fmt.Sprintf("promoting %d minipool(s)", promoteCount))
+	}
+	if distributeCount > 0 {
+		gas += uint64(distributeCount) * distributeMinipoolGas
+		duties = append(duties, fmt.Sprintf("distributing %d minipool balance(s)", distributeCount))
+	}
+	if reduceBondCount > 0 {
+		gas += uint64(reduceBondCount) * reduceBondGas
+		duties = append(duties, fmt.Sprintf("reducing %d minipool bond(s)", reduceBondCount))
+	}
+	if activeCount > 0 {
+		gas += claimRewardsGas
+		duties = append(duties, "the next rewards claim")
+	}
+	return gas, duties
+}
+
+// Multiply an amount of wei by a float
+func multiplyWei(wei *big.Int, multiplier float64) *big.Int {
+	result, _ := new(big.Float).Mul(new(big.Float).SetInt(wei), big.NewFloat(multiplier)).Int(nil)
+	return result
+}
@@ -0,0 +1,124 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/hardware"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// How long to measure the CPU and disk IO rates for
+	hardwareSampleInterval = 5 * time.Second
+
+	// How many checks in a row the IO wait has to be high for before alerting, so a single busy epoch doesn't trigger it
+	highIoWaitChecks int = 3
+
+	// How long to wait before repeating an alert for the same problem
+	hardwareAlertCooldown = 6 * time.Hour
+)
+
+// Monitor hardware task
+type monitorHardware struct {
+	c         *cli.Context
+	log       log.ColorLogger
+	cfg       *config.RocketPoolConfig
+	collector *collectors.HardwareCollector
+	paths     []string
+
+	// The number of checks in a row the IO wait has been high for
+	highIoWaitCount int
+
+	// When each alert was last sent
+	lastAlertTimes map[string]time.Time
+}
+
+// Create monitor hardware task
+func newMonitorHardware(c *cli.Context, logger log.ColorLogger, collector *collectors.HardwareCollector) (*monitorHardware, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &monitorHardware{
+		c:              c,
+		log:            logger,
+		cfg:            cfg,
+		collector:      collector,
+		paths:          hardware.GetMonitoredPaths(cfg),
+		lastAlertTimes: map[string]time.Time{},
+	}, nil
+
+}
+
+// Sample the host's hardware, update the metrics, and alert on any problems
+func (t *monitorHardware) run() error {
+
+	metrics, err := hardware.Sample(t.paths, hardwareSampleInterval)
+	if err != nil {
+		return fmt.Errorf("error sampling hardware metrics: %w", err)
+	}
+	t.collector.Update(metrics)
+
+	// Check the disk space
+	diskThreshold := float64(t.cfg.Alertmanager.HostDiskUsageThreshold.Value.(uint64))
+	for _, fs := range metrics.Filesystems {
+		if fs.UsedPercent >= diskThreshold {
+			t.log.Printlnf("WARNING: the disk holding %s is %.1f%% full.", fs.Path, fs.UsedPercent)
+			if t.shouldAlert("disk-" + fs.Path) {
+				alerting.AlertHostDiskFull(t.cfg, fs.Path, fs.UsedPercent)
+			}
+		}
+	}
+
+	// Check the IO wait
+	ioWaitThreshold := float64(t.cfg.Alertmanager.HostIoWaitThreshold.Value.(uint64))
+	if metrics.IoWaitPercent >= ioWaitThreshold {
+		t.highIoWaitCount++
+		if t.highIoWaitCount >= highIoWaitChecks {
+			t.log.Printlnf("WARNING: the CPU has spent %.1f%% of its time waiting on disk IO for the last %d checks.", metrics.IoWaitPercent, t.highIoWaitCount)
+			if t.shouldAlert("iowait") {
+				alerting.AlertHostHighIoWait(t.cfg, metrics.IoWaitPercent)
+			}
+		}
+	} else {
+		t.highIoWaitCount = 0
+	}
+
+	// Check the NVMe wear
+	wearThreshold := t.cfg.Alertmanager.NvmeWearThreshold.Value.(uint64)
+	for _, drive := range metrics.NvmeDrives {
+		if drive.SmartUnavailableNote != "" {
+			continue
+		}
+		if uint64(drive.PercentageUsed) >= wearThreshold || drive.CriticalWarning != 0 {
+			t.log.Printlnf("WARNING: NVMe drive %s has used %d%% of its rated endurance (critical warning flags: 0x%02x).", drive.Name, drive.PercentageUsed, drive.CriticalWarning)
+			if t.shouldAlert("nvme-" + drive.Name) {
+				alerting.AlertNvmeWear(t.cfg, drive.Name, drive.PercentageUsed, drive.CriticalWarning)
+			}
+		}
+	}
+
+	return nil
+
+}
+
+// Check if an alert hasn't been sent recently, and mark it as sent if so
+func (t *monitorHardware) shouldAlert(key string) bool {
+	if time.Since(t.lastAlertTimes[key]) < hardwareAlertCooldown {
+		return false
+	}
+	t.lastAlertTimes[key] = time.Now()
+	return true
+}
@@ -0,0 +1,110 @@
+package node
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/addons/rescue_node"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Settings
+const (
+	// How often to check the Rescue Node's time limit
+	rescueNodeCheckInterval = 5 * time.Minute
+
+	// How long before the time limit runs out to start warning about it
+	rescueNodeWarningTime = 6 * time.Hour
+
+	// How long to wait before repeating the alert
+	rescueNodeAlertCooldown = 1 * time.Hour
+)
+
+// Monitor rescue node task
+type monitorRescueNode struct {
+	c   *cli.Context
+	log log.ColorLogger
+	bc  beacon.Client
+
+	lastCheckTime time.Time
+	lastAlertTime time.Time
+	announcedSync bool
+}
+
+// Create monitor rescue node task
+func newMonitorRescueNode(c *cli.Context, logger log.ColorLogger) (*monitorRescueNode, error) {
+
+	// Get services
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &monitorRescueNode{
+		c:   c,
+		log: logger,
+		bc:  bc,
+	}, nil
+
+}
+
+// Warn while the Validator Client is using the Rescue Node, and alert as its time limit runs out
+func (t *monitorRescueNode) run() error {
+	if time.Since(t.lastCheckTime) < rescueNodeCheckInterval {
+		return nil
+	}
+	t.lastCheckTime = time.Now()
+
+	// Reload the settings, since the Rescue Node is turned on and off from the CLI while the daemon runs
+	cfg, err := rp.LoadConfigFromFile(os.ExpandEnv(t.c.GlobalString("settings")))
+	if err != nil {
+		return fmt.Errorf("error loading the settings to check the Rescue Node: %w", err)
+	}
+	if cfg == nil || cfg.RescueNode.GetEnabledParameter().Value != true {
+		t.announcedSync = false
+		return nil
+	}
+	rescueNode := cfg.RescueNode.(*rescue_node.RescueNode)
+
+	// Let the user know once their own Beacon Node can take over again
+	syncStatus, err := t.bc.GetSyncStatus()
+	if err == nil && !syncStatus.Syncing && !t.announcedSync {
+		t.log.Println("Your own Beacon Node is synced. Run `rocketpool service rescue-node disable` to stop using the Rescue Node.")
+		t.announcedSync = true
+	}
+
+	// Check the time limit
+	expiresAt := rescueNode.GetExpiry()
+	if expiresAt.IsZero() {
+		t.log.Println("WARNING: The Validator Client is using the Rescue Node without a time limit.")
+		return nil
+	}
+	timeLeft := time.Until(expiresAt).Truncate(time.Second)
+	var problem string
+	switch {
+	case timeLeft <= 0:
+		problem = fmt.Sprintf("The Rescue Node's time limit passed %s ago, but the Validator Client keeps using it until you run `rocketpool service rescue-node disable` or `rocketpool service start`", (-timeLeft).String())
+	case timeLeft <= rescueNodeWarningTime:
+		problem = fmt.Sprintf("The Rescue Node's time limit runs out in %s", timeLeft.String())
+	default:
+		t.log.Printlnf("The Validator Client is using the Rescue Node for another %s.", timeLeft.String())
+		return nil
+	}
+
+	t.log.Printlnf("WARNING: %s.", problem)
+	if time.Since(t.lastAlertTime) >= rescueNodeAlertCooldown {
+		t.lastAlertTime = time.Now()
+		if err := alerting.AlertRescueNodeExpiring(cfg, problem, timeLeft <= 0); err != nil {
+			t.log.Printlnf("WARNING: couldn't send the Rescue Node alert: %s", err.Error())
+		}
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package node
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"math/big"
 	"net/http"
@@ -16,11 +18,14 @@ import (
 	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/heartbeat"
+	"github.com/rocket-pool/smartnode/shared/services/profiling"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/prysm"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/teku"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
@@ -28,6 +33,7 @@ import (
 var tasksInterval, _ = time.ParseDuration("5m")
 var taskCooldown, _ = time.ParseDuration("10s")
 var totalEffectiveStakeCooldown, _ = time.ParseDuration("1h")
+var hardwareMonitorInterval, _ = time.ParseDuration("1m")
 
 const (
 	MaxConcurrentEth1Requests = 200
@@ -35,12 +41,35 @@ const (
 	StakePrelaunchMinipoolsColor = color.FgBlue
 	DownloadRewardsTreesColor    = color.FgGreen
 	MetricsColor                 = color.FgHiYellow
+	MonitorHardwareColor         = color.FgHiBlack
 	ManageFeeRecipientColor      = color.FgHiCyan
+	ManageGasLimitColor          = color.FgHiCyan
 	PromoteMinipoolsColor        = color.FgMagenta
 	ReduceBondAmountColor        = color.FgHiBlue
 	DefendPdaoPropsColor         = color.FgYellow
 	VerifyPdaoPropsColor         = color.FgYellow
+	CheckSecurityProposalsColor  = color.FgHiMagenta
+	ManageRplStakeColor          = color.FgCyan
+	RecordQueueStatsColor        = color.FgHiBlack
+	TrackWithdrawalsColor        = color.FgHiBlack
+	VerifyProposalGasLimitsColor = color.FgHiCyan
+	MonitorAttestationsColor     = color.FgHiYellow
+	CheckSyncCommitteeColor      = color.FgHiCyan
+	MonitorClockColor            = color.FgHiBlack
+	CheckUpdatesColor            = color.FgHiWhite
+	RunScheduledBackupsColor     = color.FgGreen
+	GuardActiveHostColor         = color.FgHiRed
+	SyncValidatorKeysColor       = color.FgHiRed
 	DistributeMinipoolsColor     = color.FgHiGreen
+	UpgradeDelegatesColor        = color.FgHiBlue
+	SendNotificationsColor       = color.FgWhite
+	ProofServerColor             = color.FgBlue
+	WatchMinipoolEventsColor     = color.FgHiMagenta
+	MonitorGasFundsColor         = color.FgHiYellow
+	ForwardPortsColor            = color.FgHiCyan
+	LimitBandwidthColor          = color.FgHiBlue
+	MonitorRescueNodeColor       = color.FgHiYellow
+	ProfilingColor               = color.FgHiBlack
 	ErrorColor                   = color.FgRed
 	WarningColor                 = color.FgYellow
 	UpdateColor                  = color.FgHiWhite
@@ -61,8 +90,19 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 // Run daemon
 func run(c *cli.Context) error {
 
+	// Make sure this is the only node daemon writing to the wallet and validator keys
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	releaseLocks, err := services.LockDaemonFolders("node", filepath.Dir(cfg.Smartnode.GetWalletPath()), cfg.Smartnode.GetValidatorKeychainPath())
+	if err != nil {
+		return err
+	}
+	defer releaseLocks()
+
 	// Handle the initial fee recipient file deployment
-	err := deployDefaultFeeRecipientFile(c)
+	err = deployDefaultFeeRecipientFile(c)
 	if err != nil {
 		return err
 	}
@@ -73,6 +113,12 @@ func run(c *cli.Context) error {
 		return err
 	}
 
+	// Create the keymanager API token if it's enabled
+	err = deployKeymanagerToken(c)
+	if err != nil {
+		return err
+	}
+
 	// Configure
 	configureHTTP()
 
@@ -82,10 +128,6 @@ func run(c *cli.Context) error {
 	}
 
 	// Get services
-	cfg, err := services.GetConfig(c)
-	if err != nil {
-		return err
-	}
 	rp, err := services.GetRocketPool(c)
 	if err != nil {
 		return err
@@ -115,15 +157,31 @@ func run(c *cli.Context) error {
 	errorLog := log.NewColorLogger(ErrorColor)
 	updateLog := log.NewColorLogger(UpdateColor)
 
+	// Clean up after any writes that were interrupted the last time the daemon stopped
+	services.CleanupPartialWrites(cfg, &updateLog)
+
+	// Create the heartbeat for the external uptime monitor
+	nodeHeartbeat := heartbeat.NewHeartbeat(cfg, cfg.Heartbeat.NodeUrl.Value.(string), &updateLog)
+	updateLog.Printlnf("Heartbeat %s.", nodeHeartbeat)
+
 	// Create the state manager
 	m, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, &updateLog)
 	if err != nil {
 		return err
 	}
 	stateLocker := collectors.NewStateLocker()
+	hardwareCollector := collectors.NewHardwareCollector()
 
 	// Initialize tasks
-	manageFeeRecipient, err := newManageFeeRecipient(c, log.NewColorLogger(ManageFeeRecipientColor))
+	fallbackMonitor, err := services.NewFallbackMonitor(c, &updateLog)
+	if err != nil {
+		return err
+	}
+	manageFeeRecipient, err := newManageFeeRecipient(c, log.NewColorLogger(ManageFeeRecipientColor), fallbackMonitor)
+	if err != nil {
+		return err
+	}
+	manageGasLimit, err := newManageGasLimit(c, log.NewColorLogger(ManageGasLimitColor))
 	if err != nil {
 		return err
 	}
@@ -151,6 +209,94 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	var manageRplStake *manageRplStake
+	// Make sure the user opted into RPL stake management
+	if cfg.Smartnode.ManageRplStake.Value.(bool) {
+		manageRplStake, err = newManageRplStake(c, log.NewColorLogger(ManageRplStakeColor))
+		if err != nil {
+			return err
+		}
+	}
+	var upgradeDelegates *upgradeDelegates
+	// Make sure the user opted into delegate auto-upgrades
+	if cfg.Smartnode.AutoUpgradeDelegates.Value.(bool) {
+		upgradeDelegates, err = newUpgradeDelegates(c, log.NewColorLogger(UpgradeDelegatesColor))
+		if err != nil {
+			return err
+		}
+	}
+	recordQueueStats, err := newRecordQueueStats(c, log.NewColorLogger(RecordQueueStatsColor))
+	if err != nil {
+		return err
+	}
+	trackWithdrawals, err := newTrackWithdrawals(c, log.NewColorLogger(TrackWithdrawalsColor))
+	if err != nil {
+		return err
+	}
+	verifyProposalGasLimits, err := newVerifyProposalGasLimits(c, log.NewColorLogger(VerifyProposalGasLimitsColor))
+	if err != nil {
+		return err
+	}
+	monitorAttestations, err := newMonitorAttestations(c, log.NewColorLogger(MonitorAttestationsColor))
+	if err != nil {
+		return err
+	}
+	checkSyncCommittee, err := newCheckSyncCommittee(c, log.NewColorLogger(CheckSyncCommitteeColor))
+	if err != nil {
+		return err
+	}
+	syncValidatorKeys, err := newSyncValidatorKeys(c, log.NewColorLogger(SyncValidatorKeysColor))
+	if err != nil {
+		return err
+	}
+	checkSecurityProposals, err := newCheckSecurityProposals(c, log.NewColorLogger(CheckSecurityProposalsColor))
+	if err != nil {
+		return err
+	}
+	sendNotifications, err := newSendNotifications(c, log.NewColorLogger(SendNotificationsColor))
+	if err != nil {
+		return err
+	}
+	monitorHardware, err := newMonitorHardware(c, log.NewColorLogger(MonitorHardwareColor), hardwareCollector)
+	if err != nil {
+		return err
+	}
+	monitorClock, err := newMonitorClock(c, log.NewColorLogger(MonitorClockColor))
+	if err != nil {
+		return err
+	}
+	checkUpdates, err := newCheckUpdates(c, log.NewColorLogger(CheckUpdatesColor))
+	if err != nil {
+		return err
+	}
+	runScheduledBackups, err := newRunScheduledBackups(c, log.NewColorLogger(RunScheduledBackupsColor))
+	if err != nil {
+		return err
+	}
+	guardActiveHost, err := newGuardActiveHost(c, log.NewColorLogger(GuardActiveHostColor))
+	if err != nil {
+		return err
+	}
+	monitorGasFunds, err := newMonitorGasFunds(c, log.NewColorLogger(MonitorGasFundsColor))
+	if err != nil {
+		return err
+	}
+	watchMinipoolEvents, err := newWatchMinipoolEvents(c, log.NewColorLogger(WatchMinipoolEventsColor))
+	if err != nil {
+		return err
+	}
+	forwardPorts, err := newForwardPorts(c, log.NewColorLogger(ForwardPortsColor))
+	if err != nil {
+		return err
+	}
+	limitBandwidth, err := newLimitBandwidth(c, log.NewColorLogger(LimitBandwidthColor))
+	if err != nil {
+		return err
+	}
+	monitorRescueNode, err := newMonitorRescueNode(c, log.NewColorLogger(MonitorRescueNodeColor))
+	if err != nil {
+		return err
+	}
 	var verifyPdaoProps *verifyPdaoProps
 	// Make sure the user opted into this duty
 	verifyEnabled := cfg.Smartnode.VerifyProposals.Value.(bool)
@@ -161,9 +307,24 @@ func run(c *cli.Context) error {
 		}
 	}
 
+	contractMonitor, err := services.NewContractUpgradeMonitor(c, &updateLog)
+	if err != nil {
+		return err
+	}
+
+	// Don't let the Validator Client start its duties until it's clear no other machine is running them
+	if err := guardActiveHost.holdAtStartup(); err != nil {
+		errorLog.Println(err)
+	}
+
+	// Unlock the validator keys if they're encrypted at rest; a held Validator Client loads them when it's released
+	if err := syncValidatorKeys.unlock(!guardActiveHost.isHoldingValidator()); err != nil {
+		errorLog.Println(err)
+	}
+
 	// Wait group to handle the various threads
 	wg := new(sync.WaitGroup)
-	wg.Add(2)
+	wg.Add(9)
 
 	// Timestamp for caching total effective RPL stake
 	lastTotalEffectiveStakeTime := time.Unix(0, 0)
@@ -175,11 +336,15 @@ func run(c *cli.Context) error {
 		wasExecutionClientSynced := true
 		wasBeaconClientSynced := true
 		for {
+			// Switch to the first healthy client pair before checking the sync status
+			fallbackMonitor.Check()
+
 			// Check the EC status
 			err := services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
 			if err != nil {
 				wasExecutionClientSynced = false
 				errorLog.Printlnf("Execution client not synced: %s. Waiting for sync...", err.Error())
+				nodeHeartbeat.Failure(fmt.Errorf("execution client not synced: %w", err))
 				time.Sleep(taskCooldown)
 				continue
 			}
@@ -196,6 +361,7 @@ func run(c *cli.Context) error {
 				// NOTE: if not synced, it returns an error - so there isn't necessarily an underlying issue
 				wasBeaconClientSynced = false
 				errorLog.Printlnf("Beacon client not synced: %s. Waiting for sync...", err.Error())
+				nodeHeartbeat.Failure(fmt.Errorf("beacon client not synced: %w", err))
 				time.Sleep(taskCooldown)
 				continue
 			}
@@ -206,6 +372,11 @@ func run(c *cli.Context) error {
 				alerting.AlertBeaconClientSyncComplete(cfg)
 			}
 
+			// Pick up any contract upgrades before the tasks use the contracts
+			if _, err := contractMonitor.Check(); err != nil {
+				errorLog.Println(err)
+			}
+
 			// Update the network state
 			updateTotalEffectiveStake := false
 			if time.Since(lastTotalEffectiveStakeTime) > totalEffectiveStakeCooldown {
@@ -215,6 +386,7 @@ func run(c *cli.Context) error {
 			state, totalEffectiveStake, err := updateNetworkState(m, &updateLog, nodeAccount.Address, updateTotalEffectiveStake)
 			if err != nil {
 				errorLog.Println(err)
+				nodeHeartbeat.Failure(err)
 				time.Sleep(taskCooldown)
 				continue
 			}
@@ -226,18 +398,61 @@ func run(c *cli.Context) error {
 				isHoustonDeployedMasterFlag = true
 			}
 
+			// Make sure no other machine is running the node's validators
+			if err := guardActiveHost.run(state); err != nil {
+				errorLog.Println(err)
+			}
+
 			// Manage the fee recipient for the node
 			if err := manageFeeRecipient.run(state); err != nil {
 				errorLog.Println(err)
 			}
 			time.Sleep(taskCooldown)
 
+			// Apply the preferred gas limit to the running validators
+			if err := manageGasLimit.run(state); err != nil {
+				errorLog.Println(err)
+			}
+
 			// Run the rewards download check
 			if err := downloadRewardsTrees.run(state); err != nil {
 				errorLog.Println(err)
 			}
 			time.Sleep(taskCooldown)
 
+			// Record the deposit pool and queue stats
+			if err := recordQueueStats.run(state); err != nil {
+				errorLog.Println(err)
+			}
+
+			// Record withdrawals to and distributions from the node's minipools
+			if err := trackWithdrawals.run(state); err != nil {
+				errorLog.Println(err)
+			}
+
+			// Make sure the node's proposals honored its gas limit preference
+			if err := verifyProposalGasLimits.run(state); err != nil {
+				errorLog.Println(err)
+			}
+			time.Sleep(taskCooldown)
+
+			// Check how well the node's validators attested in the epochs since the last run
+			if err := monitorAttestations.run(state); err != nil {
+				errorLog.Println(err)
+			}
+			time.Sleep(taskCooldown)
+
+			// Look for upcoming sync committee duties and check that the node is ready for them
+			if err := checkSyncCommittee.run(state); err != nil {
+				errorLog.Println(err)
+			}
+			time.Sleep(taskCooldown)
+
+			// Save any new validator keys to the encrypted archive
+			if err := syncValidatorKeys.run(state); err != nil {
+				errorLog.Println(err)
+			}
+
 			if state.IsHoustonDeployed {
 				// Run the pDAO proposal defender
 				if err := defendPdaoProps.run(state); err != nil {
@@ -252,6 +467,12 @@ func run(c *cli.Context) error {
 					}
 					time.Sleep(taskCooldown)
 				}
+
+				// Check for security council proposals to vote on
+				if err := checkSecurityProposals.run(state); err != nil {
+					errorLog.Println(err)
+				}
+				time.Sleep(taskCooldown)
 			}
 
 			// Run the minipool stake check
@@ -277,21 +498,137 @@ func run(c *cli.Context) error {
 				errorLog.Println(err)
 			}
 
+			// Run the RPL stake management check
+			if manageRplStake != nil {
+				time.Sleep(taskCooldown)
+				if err := manageRplStake.run(state); err != nil {
+					errorLog.Println(err)
+				}
+			}
+
+			// Run the delegate upgrade campaign
+			if upgradeDelegates != nil {
+				time.Sleep(taskCooldown)
+				if err := upgradeDelegates.run(state); err != nil {
+					errorLog.Println(err)
+				}
+			}
+
+			// Make sure the node wallet can pay for the gas of its upcoming duties
+			if err := monitorGasFunds.run(state); err != nil {
+				errorLog.Println(err)
+			}
+
+			// Deliver new alerts and any digests that are due
+			if err := sendNotifications.run(state); err != nil {
+				errorLog.Println(err)
+			}
+
+			// Let the uptime monitor know the node finished its duties
+			nodeHeartbeat.Success()
+
 			time.Sleep(tasksInterval)
 		}
 		wg.Done()
 	}()
 
+	// Run hardware, clock, update, and Rescue Node monitoring loop; this doesn't need the clients, so it keeps running while they sync
+	go func() {
+		for {
+			if err := monitorHardware.run(); err != nil {
+				errorLog.Println(err)
+			}
+			if err := monitorClock.run(); err != nil {
+				errorLog.Println(err)
+			}
+			if err := checkUpdates.run(); err != nil {
+				errorLog.Println(err)
+			}
+			if err := guardActiveHost.refresh(); err != nil {
+				errorLog.Println(err)
+			}
+			if err := monitorRescueNode.run(); err != nil {
+				errorLog.Println(err)
+			}
+			time.Sleep(hardwareMonitorInterval)
+		}
+		wg.Done()
+	}()
+
+	// Run scheduled backup loop; uploads can take a while, so this gets its own thread
+	go func() {
+		for {
+			if err := runScheduledBackups.run(); err != nil {
+				errorLog.Println(err)
+			}
+			time.Sleep(scheduledBackupCheckInterval)
+		}
+		wg.Done()
+	}()
+
+	// Run the minipool event watcher; it checks every slot so alerts go out as soon as the events are in a block
+	go func() {
+		for {
+			if err := watchMinipoolEvents.run(); err != nil {
+				errorLog.Println(err)
+			}
+			time.Sleep(minipoolEventWatchInterval)
+		}
+		wg.Done()
+	}()
+
+	// Run the port forwarding loop; talking to the router can be slow, so this gets its own thread
+	go func() {
+		for {
+			if err := forwardPorts.run(); err != nil {
+				errorLog.Println(err)
+			}
+			time.Sleep(portForwardingInterval)
+		}
+		wg.Done()
+	}()
+
+	// Run the bandwidth limit loop; it follows the schedule and catches restarted containers, so it runs more often than the tasks
+	go func() {
+		for {
+			if err := limitBandwidth.run(); err != nil {
+				errorLog.Println(err)
+			}
+			time.Sleep(bandwidthLimitInterval)
+		}
+		wg.Done()
+	}()
+
 	// Run metrics loop
 	go func() {
-		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), stateLocker)
+		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), stateLocker, hardwareCollector)
+		if err != nil {
+			errorLog.Println(err)
+		}
+		wg.Done()
+	}()
+
+	// Run the proof server
+	go func() {
+		err := runProofServer(c, log.NewColorLogger(ProofServerColor))
 		if err != nil {
 			errorLog.Println(err)
 		}
 		wg.Done()
 	}()
 
-	// Wait for both threads to stop
+	// Run the profiling server, if it's enabled
+	go func() {
+		if cfg.Smartnode.EnableProfiling.Value == true {
+			err := profiling.RunServer(c.GlobalString("metricsAddress"), cfg.Smartnode.NodeProfilingPort.Value.(uint16), log.NewColorLogger(ProfilingColor))
+			if err != nil {
+				errorLog.Println(err)
+			}
+		}
+		wg.Done()
+	}()
+
+	// Wait for all of the threads to stop
 	wg.Wait()
 	return nil
 
@@ -334,7 +671,7 @@ func deployDefaultFeeRecipientFile(c *cli.Context) error {
 			// Docker and Hybrid just need the address itself
 			defaultFeeRecipientFileContents = cfg.Smartnode.GetRethAddress().Hex()
 		}
-		err := os.WriteFile(feeRecipientPath, []byte(defaultFeeRecipientFileContents), 0664)
+		err := files.WriteFileAtomic(feeRecipientPath, []byte(defaultFeeRecipientFileContents), 0664)
 		if err != nil {
 			return fmt.Errorf("could not write default fee recipient file to %s: %w", feeRecipientPath, err)
 		}
@@ -346,6 +683,43 @@ func deployDefaultFeeRecipientFile(c *cli.Context) error {
 
 }
 
+// Generate the token the Validator Client requires for its keymanager API, if it doesn't exist yet
+func deployKeymanagerToken(c *cli.Context) error {
+
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	if !cfg.Smartnode.EnableKeymanagerApi.Value.(bool) {
+		return nil
+	}
+
+	tokenPath := cfg.Smartnode.GetKeymanagerTokenPath()
+	_, err = os.Stat(tokenPath)
+	if err == nil {
+		return nil
+	}
+	if !os.IsNotExist(err) {
+		return fmt.Errorf("Error checking keymanager API token status: %w", err)
+	}
+
+	token := make([]byte, 32)
+	_, err = rand.Read(token)
+	if err != nil {
+		return fmt.Errorf("could not generate keymanager API token: %w", err)
+	}
+	err = os.MkdirAll(filepath.Dir(tokenPath), 0775)
+	if err != nil {
+		return fmt.Errorf("could not create keymanager API token folder: %w", err)
+	}
+	err = files.WriteFileAtomic(tokenPath, []byte(hex.EncodeToString(token)), 0640)
+	if err != nil {
+		return fmt.Errorf("could not write keymanager API token to %s: %w", tokenPath, err)
+	}
+	return nil
+
+}
+
 // Remove the old fee recipient files that were created in v1.5.0
 func removeLegacyFeeRecipientFiles(c *cli.Context) error {
 
@@ -382,6 +756,12 @@ func updateNetworkState(m *state.NetworkStateManager, log *log.ColorLogger, node
 	if err != nil {
 		return nil, nil, fmt.Errorf("error updating network state: %w", err)
 	}
+
+	// Save it for the CLI
+	err = m.SaveStateForNode(nodeAddress, state, totalEffectiveStake)
+	if err != nil {
+		log.Printlnf("WARNING: couldn't save the network state cache: %s", err.Error())
+	}
 	return state, totalEffectiveStake, nil
 }
 
@@ -0,0 +1,271 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// A rewards interval the proof server knows about
+type proofServerInterval struct {
+	Interval   uint64    `json:"interval"`
+	MerkleRoot string    `json:"merkleRoot"`
+	StartTime  time.Time `json:"startTime"`
+	EndTime    time.Time `json:"endTime"`
+	Available  bool      `json:"available"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// A node's Merkle proof for a single interval
+type proofServerProof struct {
+	Interval         uint64                  `json:"interval"`
+	NodeAddress      common.Address          `json:"nodeAddress"`
+	MerkleRoot       string                  `json:"merkleRoot"`
+	CollateralRpl    *rprewards.QuotedBigInt `json:"collateralRpl"`
+	OracleDaoRpl     *rprewards.QuotedBigInt `json:"oracleDaoRpl"`
+	SmoothingPoolEth *rprewards.QuotedBigInt `json:"smoothingPoolEth"`
+	MerkleProof      []common.Hash           `json:"merkleProof"`
+}
+
+// A rewards file that has been checked against the chain
+type verifiedRewardsFile struct {
+	modTime time.Time
+	file    rprewards.IRewardsFile
+	info    proofServerInterval
+}
+
+// Serves Merkle proofs from the local rewards files
+type proofServer struct {
+	cfg   *config.RocketPoolConfig
+	rp    *rocketpool.RocketPool
+	log   log.ColorLogger
+	files map[uint64]*verifiedRewardsFile
+	lock  sync.Mutex
+}
+
+// Run the read-only proof server, if it's enabled
+func runProofServer(c *cli.Context, logger log.ColorLogger) error {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	if cfg.Smartnode.EnableProofServer.Value == false {
+		return nil
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return err
+	}
+
+	server := &proofServer{
+		cfg:   cfg,
+		rp:    rp,
+		log:   logger,
+		files: map[uint64]*verifiedRewardsFile{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/intervals", server.handleIntervals)
+	mux.HandleFunc("/v1/proofs/", server.handleProofs)
+
+	port := cfg.Smartnode.ProofServerPort.Value.(uint16)
+	logger.Printlnf("Starting proof server on port %d.", port)
+	err = http.ListenAndServe(fmt.Sprintf(":%d", port), mux)
+	if err != nil {
+		return fmt.Errorf("Error running proof server: %w", err)
+	}
+
+	return nil
+
+}
+
+// List every finished interval and whether its proofs can be served
+func (s *proofServer) handleIntervals(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeProofServerError(w, http.StatusMethodNotAllowed, fmt.Errorf("only GET is supported"))
+		return
+	}
+
+	currentIndex, err := rewards.GetRewardIndex(s.rp, nil)
+	if err != nil {
+		writeProofServerError(w, http.StatusServiceUnavailable, fmt.Errorf("error getting the current rewards interval: %w", err))
+		return
+	}
+
+	intervals := []proofServerInterval{}
+	for interval := uint64(0); interval < currentIndex.Uint64(); interval++ {
+		verified, err := s.getRewardsFile(interval)
+		if err != nil {
+			intervals = append(intervals, proofServerInterval{
+				Interval: interval,
+				Error:    err.Error(),
+			})
+			continue
+		}
+		if verified == nil {
+			intervals = append(intervals, proofServerInterval{
+				Interval: interval,
+			})
+			continue
+		}
+		intervals = append(intervals, verified.info)
+	}
+	writeProofServerResponse(w, intervals)
+}
+
+// Serve the proofs for a node, either for every interval (`/v1/proofs/<address>`) or a single one (`/v1/proofs/<address>/<interval>`)
+func (s *proofServer) handleProofs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeProofServerError(w, http.StatusMethodNotAllowed, fmt.Errorf("only GET is supported"))
+		return
+	}
+
+	// Parse the path
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/v1/proofs/"), "/"), "/")
+	if len(parts) < 1 || len(parts) > 2 || !common.IsHexAddress(parts[0]) {
+		writeProofServerError(w, http.StatusBadRequest, fmt.Errorf("expected /v1/proofs/<address> or /v1/proofs/<address>/<interval>"))
+		return
+	}
+	nodeAddress := common.HexToAddress(parts[0])
+
+	// Get the intervals to serve
+	intervals := []uint64{}
+	if len(parts) == 2 {
+		interval, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			writeProofServerError(w, http.StatusBadRequest, fmt.Errorf("invalid interval [%s]", parts[1]))
+			return
+		}
+		intervals = append(intervals, interval)
+	} else {
+		currentIndex, err := rewards.GetRewardIndex(s.rp, nil)
+		if err != nil {
+			writeProofServerError(w, http.StatusServiceUnavailable, fmt.Errorf("error getting the current rewards interval: %w", err))
+			return
+		}
+		for interval := uint64(0); interval < currentIndex.Uint64(); interval++ {
+			intervals = append(intervals, interval)
+		}
+	}
+
+	proofs := []proofServerProof{}
+	for _, interval := range intervals {
+		verified, err := s.getRewardsFile(interval)
+		if err != nil {
+			writeProofServerError(w, http.StatusServiceUnavailable, err)
+			return
+		}
+		if verified == nil {
+			if len(parts) == 2 {
+				writeProofServerError(w, http.StatusNotFound, fmt.Errorf("the rewards file for interval %d isn't available", interval))
+				return
+			}
+			continue
+		}
+
+		nodeRewards, exists := verified.file.GetNodeRewardsInfo(nodeAddress)
+		if !exists {
+			continue
+		}
+		proof, err := nodeRewards.GetMerkleProof()
+		if err != nil {
+			writeProofServerError(w, http.StatusInternalServerError, fmt.Errorf("error reading the Merkle proof for interval %d: %w", interval, err))
+			return
+		}
+		proofs = append(proofs, proofServerProof{
+			Interval:         interval,
+			NodeAddress:      nodeAddress,
+			MerkleRoot:       verified.info.MerkleRoot,
+			CollateralRpl:    nodeRewards.GetCollateralRpl(),
+			OracleDaoRpl:     nodeRewards.GetOracleDaoRpl(),
+			SmoothingPoolEth: nodeRewards.GetSmoothingPoolEth(),
+			MerkleProof:      proof,
+		})
+	}
+	if len(parts) == 2 && len(proofs) == 0 {
+		writeProofServerError(w, http.StatusNotFound, fmt.Errorf("node %s has no rewards in interval %d", nodeAddress.Hex(), intervals[0]))
+		return
+	}
+	writeProofServerResponse(w, proofs)
+}
+
+// Get the rewards file for an interval, verifying it against the chain the first time it's loaded or after it changes.
+// Returns nil if there's no file for the interval.
+func (s *proofServer) getRewardsFile(interval uint64) (*verifiedRewardsFile, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	path := s.cfg.Smartnode.GetRewardsTreePath(interval, true)
+	stat, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		delete(s.files, interval)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error checking the rewards file for interval %d: %w", interval, err)
+	}
+	if cached, exists := s.files[interval]; exists && cached.modTime.Equal(stat.ModTime()) {
+		return cached, nil
+	}
+
+	// Load and verify it
+	event, err := rprewards.GetRewardSnapshotEvent(s.rp, s.cfg, interval, nil)
+	if err != nil {
+		return nil, err
+	}
+	localFile, err := rprewards.ReadLocalRewardsFile(path)
+	if err != nil {
+		return nil, err
+	}
+	file := localFile.Impl()
+	if err := rprewards.VerifyMerkleRoot(file, event.MerkleRoot.Hex()); err != nil {
+		return nil, fmt.Errorf("the rewards file for interval %d is invalid: %w", interval, err)
+	}
+	s.log.Printlnf("Verified the rewards file for interval %d.", interval)
+
+	verified := &verifiedRewardsFile{
+		modTime: stat.ModTime(),
+		file:    file,
+		info: proofServerInterval{
+			Interval:   interval,
+			MerkleRoot: event.MerkleRoot.Hex(),
+			StartTime:  event.IntervalStartTime,
+			EndTime:    event.IntervalEndTime,
+			Available:  true,
+		},
+	}
+	s.files[interval] = verified
+	return verified, nil
+}
+
+// Write a successful response
+func writeProofServerResponse(w http.ResponseWriter, response interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// Write an error response
+func writeProofServerError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,96 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/queue"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Record queue stats task
+type recordQueueStats struct {
+	c          *cli.Context
+	log        log.ColorLogger
+	cfg        *config.RocketPoolConfig
+	rp         *rocketpool.RocketPool
+	lastSample time.Time
+}
+
+// Create record queue stats task
+func newRecordQueueStats(c *cli.Context, logger log.ColorLogger) (*recordQueueStats, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pick up where the last run left off
+	lastSample := time.Time{}
+	samples, err := queue.LoadSamples(cfg.Smartnode.GetQueueStatsPath(true))
+	if err != nil {
+		logger.Printlnf("WARNING: %s", err.Error())
+	} else if len(samples) > 0 {
+		lastSample = samples[len(samples)-1].Time
+	}
+
+	// Return task
+	return &recordQueueStats{
+		c:          c,
+		log:        logger,
+		cfg:        cfg,
+		rp:         rp,
+		lastSample: lastSample,
+	}, nil
+
+}
+
+// Record a sample of the deposit pool and minipool queue if one is due
+func (t *recordQueueStats) run(state *state.NetworkState) error {
+
+	if time.Since(t.lastSample) < queue.SampleInterval {
+		return nil
+	}
+
+	// Get the total minipool count, which isn't part of the node's network state
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+	}
+	minipoolCount, err := minipool.GetMinipoolCount(t.rp, opts)
+	if err != nil {
+		return fmt.Errorf("error getting minipool count: %w", err)
+	}
+
+	// Save the sample
+	sample := queue.Sample{
+		Time:               time.Now().UTC(),
+		Block:              state.ElBlockNumber,
+		DepositPoolBalance: state.NetworkDetails.DepositPoolBalance,
+		QueueLength:        state.NetworkDetails.QueueLength.Uint64(),
+		QueueCapacity:      state.NetworkDetails.QueueCapacity.Effective,
+		MinipoolCount:      minipoolCount,
+	}
+	err = queue.AddSample(t.cfg.Smartnode.GetQueueStatsPath(true), sample)
+	if err != nil {
+		return err
+	}
+	t.lastSample = sample.Time
+
+	// Return
+	return nil
+
+}
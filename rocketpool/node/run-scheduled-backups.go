@@ -0,0 +1,208 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/backup"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/cron"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// How often to check if a backup is due; cron schedules can't be more precise than this
+	scheduledBackupCheckInterval = time.Minute
+
+	// How many scheduled runs can be missed before the backups are considered out of date
+	missedBackupsBeforeAlert = 2
+
+	// How often to repeat the out of date alert while the backups stay out of date
+	backupOutOfDateAlertCooldown = 24 * time.Hour
+
+	scheduledBackupsStateMode os.FileMode = 0600
+)
+
+// The results of the scheduled backups, saved so they survive restarts
+type scheduledBackupsState struct {
+	LastAttempt  time.Time `json:"lastAttempt"`
+	LastSuccess  time.Time `json:"lastSuccess"`
+	LastFilename string    `json:"lastFilename,omitempty"`
+	LastError    string    `json:"lastError,omitempty"`
+}
+
+// Run scheduled backups task
+type runScheduledBackups struct {
+	c            *cli.Context
+	log          log.ColorLogger
+	cfg          *config.RocketPoolConfig
+	settingsPath string
+	statePath    string
+	state        scheduledBackupsState
+
+	startTime          time.Time
+	lastOutOfDateAlert time.Time
+}
+
+// Create run scheduled backups task
+func newRunScheduledBackups(c *cli.Context, logger log.ColorLogger) (*runScheduledBackups, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the results of the previous backups
+	task := &runScheduledBackups{
+		c:            c,
+		log:          logger,
+		cfg:          cfg,
+		settingsPath: os.ExpandEnv(c.GlobalString("settings")),
+		statePath:    cfg.Smartnode.GetScheduledBackupsStatePath(true),
+		startTime:    time.Now(),
+	}
+	bytes, err := os.ReadFile(task.statePath)
+	if err == nil {
+		if err := json.Unmarshal(bytes, &task.state); err != nil {
+			logger.Printlnf("WARNING: couldn't read the scheduled backup state, starting fresh: %s", err.Error())
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error reading scheduled backup state [%s]: %w", task.statePath, err)
+	}
+
+	// Return task
+	return task, nil
+
+}
+
+// Make a backup if one is due, and alert if the backups have fallen out of date
+func (t *runScheduledBackups) run() error {
+	expression := strings.TrimSpace(t.cfg.Backup.Schedule.Value.(string))
+	if expression == "" {
+		return nil
+	}
+	schedule, err := cron.Parse(expression)
+	if err != nil {
+		return fmt.Errorf("error parsing the backup schedule: %w", err)
+	}
+
+	// A backup is due once the schedule has passed a run since the last attempt, or since the daemon started if there hasn't been one
+	lastRun := t.state.LastAttempt
+	if lastRun.IsZero() {
+		lastRun = t.startTime
+	}
+	next := schedule.Next(lastRun)
+	if !next.IsZero() && !next.After(time.Now()) {
+		if err := t.backUp(); err != nil {
+			t.state.LastError = err.Error()
+			t.log.Printlnf("Scheduled backup failed: %s", err.Error())
+			if err := alerting.AlertBackupFailed(t.cfg, err.Error()); err != nil {
+				t.log.Printlnf("WARNING: couldn't send the backup failure alert: %s", err.Error())
+			}
+		}
+		if err := t.saveState(); err != nil {
+			return err
+		}
+	}
+
+	// Check that a backup has succeeded recently enough
+	lastSuccess := t.state.LastSuccess
+	if lastSuccess.IsZero() {
+		lastSuccess = t.startTime
+	}
+	interval := schedule.Interval(lastSuccess)
+	if interval > 0 && time.Since(lastSuccess) > missedBackupsBeforeAlert*interval && time.Since(t.lastOutOfDateAlert) > backupOutOfDateAlertCooldown {
+		t.lastOutOfDateAlert = time.Now()
+		t.log.Println("WARNING: the scheduled backups are out of date.")
+		if err := alerting.AlertBackupOutOfDate(t.cfg, t.state.LastSuccess); err != nil {
+			t.log.Printlnf("WARNING: couldn't send the out of date backup alert: %s", err.Error())
+		}
+	}
+	return nil
+}
+
+// Create a backup, upload it, and remove the ones that have fallen out of the retention window
+func (t *runScheduledBackups) backUp() error {
+	t.state.LastAttempt = time.Now()
+
+	// Get the password and components
+	password, err := os.ReadFile(t.cfg.Smartnode.GetBackupPasswordPath(true))
+	if os.IsNotExist(err) {
+		return fmt.Errorf("the backup password hasn't been set; please run `rocketpool service set-backup-password`")
+	}
+	if err != nil {
+		return fmt.Errorf("error reading the backup password: %w", err)
+	}
+	components, err := backup.ParseComponents(t.cfg.Backup.Components.Value.(string))
+	if err != nil {
+		return err
+	}
+	storage, err := backup.GetRemoteStorage(t.cfg)
+	if err != nil {
+		return err
+	}
+
+	// Create the backup and upload it
+	t.log.Printlnf("Creating scheduled backup of %s...", backup.FormatComponents(components))
+	archive, manifest, err := backup.Create(t.cfg, t.settingsPath, components, string(password), shared.RocketPoolVersion)
+	if err != nil {
+		return err
+	}
+	backupsFolder := t.cfg.Smartnode.GetBackupsFolder(true)
+	filename, err := backup.Save(backupsFolder, archive, manifest, true)
+	if err != nil {
+		return err
+	}
+	t.log.Printlnf("Saved %s (%d bytes, sha256 %s).", filename, len(archive), backup.GetArchiveHash(archive))
+	if storage != nil {
+		if err := storage.Upload(filename, archive); err != nil {
+			return err
+		}
+		t.log.Printlnf("Uploaded %s to %s storage.", filename, t.cfg.Backup.Storage.Value.(cfgtypes.BackupStorage))
+	}
+	t.state.LastSuccess = manifest.Created
+	t.state.LastFilename = filename
+	t.state.LastError = ""
+
+	// Enforce the retention window; the backup itself succeeded, so problems here are only logged
+	retention := time.Duration(t.cfg.Backup.RetentionDays.Value.(uint64)) * 24 * time.Hour
+	deleted, err := backup.PruneLocal(backupsFolder, manifest.Network, retention, time.Now())
+	if err != nil {
+		t.log.Printlnf("WARNING: couldn't remove expired backups from the backups folder: %s", err.Error())
+	}
+	if storage != nil {
+		remoteDeleted, err := backup.PruneRemote(storage, manifest.Network, retention, time.Now())
+		if err != nil {
+			t.log.Printlnf("WARNING: couldn't remove expired backups from remote storage: %s", err.Error())
+		}
+		deleted = append(deleted, remoteDeleted...)
+	}
+	for _, name := range deleted {
+		t.log.Printlnf("Removed expired backup %s.", name)
+	}
+	return nil
+}
+
+// Save the results of the scheduled backups
+func (t *runScheduledBackups) saveState() error {
+	bytes, err := json.Marshal(t.state)
+	if err != nil {
+		return fmt.Errorf("error serializing scheduled backup state: %w", err)
+	}
+	if err := files.WriteFileAtomic(t.statePath, bytes, scheduledBackupsStateMode); err != nil {
+		return fmt.Errorf("error saving scheduled backup state [%s]: %w", t.statePath, err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/ledger"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How far back the digest's rewards summary looks
+const notificationSummaryPeriod time.Duration = 24 * time.Hour
+
+// Send notifications task
+type sendNotifications struct {
+	c           *cli.Context
+	log         log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	nodeAddress common.Address
+	dispatcher  *notifications.Dispatcher
+}
+
+// Create send notifications task
+func newSendNotifications(c *cli.Context, logger log.ColorLogger) (*sendNotifications, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	dispatcher, err := notifications.NewDispatcher(cfg, &logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &sendNotifications{
+		c:           c,
+		log:         logger,
+		cfg:         cfg,
+		nodeAddress: nodeAccount.Address,
+		dispatcher:  dispatcher,
+	}, nil
+
+}
+
+// Deliver any new alerts to the enabled notification sinks, and send any digests that are due
+func (t *sendNotifications) run(state *state.NetworkState) error {
+	if !t.dispatcher.HasSinks() {
+		return nil
+	}
+	return t.dispatcher.Run(func() (*notifications.Summary, error) {
+		return t.getSummary(state)
+	})
+}
+
+// Create an overview of the node for the digests
+func (t *sendNotifications) getSummary(state *state.NetworkState) (*notifications.Summary, error) {
+	nodeDetails, exists := state.NodeDetailsByAddress[t.nodeAddress]
+	if !exists {
+		return nil, fmt.Errorf("node %s isn't registered", t.nodeAddress.Hex())
+	}
+
+	summary := &notifications.Summary{
+		Time:                    time.Now(),
+		Network:                 string(t.cfg.GetNetwork()),
+		NodeAddress:             t.nodeAddress,
+		EthBalance:              eth.WeiToEth(nodeDetails.BalanceETH),
+		RplBalance:              eth.WeiToEth(nodeDetails.BalanceRPL),
+		RplStake:                eth.WeiToEth(nodeDetails.RplStake),
+		EffectiveRplStake:       eth.WeiToEth(nodeDetails.EffectiveRPLStake),
+		SmoothingPoolRegistered: nodeDetails.SmoothingPoolRegistrationState,
+		MinipoolStatusCounts:    map[string]int{},
+		ValidatorStatusCounts:   map[string]int{},
+	}
+	for _, mpd := range state.MinipoolDetailsByNode[t.nodeAddress] {
+		summary.MinipoolStatusCounts[mpd.Status.String()]++
+		validator, exists := state.ValidatorDetails[mpd.Pubkey]
+		if exists && validator.Exists {
+			summary.ValidatorStatusCounts[string(validator.Status)]++
+		}
+	}
+
+	// Add up the rewards from the ledger, if it's been started
+	rewardsLedger, err := ledger.LoadLedger(t.cfg.Smartnode.GetRewardsLedgerPath(true))
+	if err != nil {
+		t.log.Printlnf("WARNING: couldn't load the rewards ledger for the digest: %s", err.Error())
+		return summary, nil
+	}
+	if rewardsLedger == nil {
+		return summary, nil
+	}
+	cutoff := time.Now().Add(-notificationSummaryPeriod)
+	genesisTime := time.Unix(int64(state.BeaconConfig.GenesisTime), 0)
+	withdrawnGwei := uint64(0)
+	distributed := big.NewInt(0)
+	for _, mpLedger := range rewardsLedger.Minipools {
+		for _, withdrawal := range mpLedger.Withdrawals {
+			slotTime := genesisTime.Add(time.Duration(withdrawal.Slot*state.BeaconConfig.SecondsPerSlot) * time.Second)
+			if slotTime.After(cutoff) {
+				withdrawnGwei += withdrawal.AmountGwei
+			}
+		}
+		addDistributions(distributed, mpLedger.Distributions, cutoff)
+	}
+	addDistributions(distributed, rewardsLedger.FeeDistributions, cutoff)
+	summary.BeaconWithdrawalsEth = eth.WeiToEth(eth.GweiToWei(float64(withdrawnGwei)))
+	summary.NodeDistributionsEth = eth.WeiToEth(distributed)
+
+	return summary, nil
+}
+
+// Add the node's share of the distributions made after the cutoff to the total
+func addDistributions(total *big.Int, distributions []ledger.Distribution, cutoff time.Time) {
+	for _, distribution := range distributions {
+		if distribution.NodeAmount != nil && time.Unix(int64(distribution.Time), 0).After(cutoff) {
+			total.Add(total, distribution.NodeAmount)
+		}
+	}
+}
@@ -15,12 +15,14 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
 	"github.com/urfave/cli"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
 
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/alerting"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -37,6 +39,7 @@ type stakePrelaunchMinipools struct {
 	rp             *rocketpool.RocketPool
 	bc             beacon.Client
 	d              *client.Client
+	km             *keymanager.Client
 	gasThreshold   float64
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
@@ -67,6 +70,10 @@ func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakeP
 	if err != nil {
 		return nil, err
 	}
+	km, err := services.GetKeymanager(c)
+	if err != nil {
+		return nil, err
+	}
 
 	gasThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
 
@@ -98,6 +105,7 @@ func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakeP
 		rp:             rp,
 		bc:             bc,
 		d:              d,
+		km:             km,
 		gasThreshold:   gasThreshold,
 		maxFee:         maxFee,
 		maxPriorityFee: priorityFee,
@@ -141,7 +149,7 @@ func (t *stakePrelaunchMinipools) run(state *state.NetworkState) error {
 	t.log.Printlnf("%d minipool(s) are ready for staking...", len(minipools))
 
 	// Stake minipools
-	successCount := 0
+	stakedKeys := []*eth2types.BLSPrivateKey{}
 	for _, mpd := range minipools {
 		success, err := t.stakeMinipool(mpd, state, opts)
 		alerting.AlertMinipoolStaked(t.cfg, mpd.MinipoolAddress, success && err == nil)
@@ -150,15 +158,17 @@ func (t *stakePrelaunchMinipools) run(state *state.NetworkState) error {
 			return err
 		}
 		if success {
-			successCount++
+			key, err := t.w.GetValidatorKeyByPubkey(mpd.Pubkey)
+			if err != nil {
+				return err
+			}
+			stakedKeys = append(stakedKeys, key)
 		}
 	}
 
-	// Restart validator process if any minipools were staked successfully
-	if successCount > 0 {
-		if err := validator.RestartValidator(t.cfg, t.bc, &t.log, t.d); err != nil {
-			return err
-		}
+	// Load the keys of any minipools that were staked successfully into the validator process
+	if _, err := validator.LoadValidatorKeys(t.cfg, t.bc, &t.log, t.d, t.km, stakedKeys, nil); err != nil {
+		return err
 	}
 
 	// Return
@@ -0,0 +1,169 @@
+package node
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/docker/docker/client"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/keyarchive"
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+// Sync validator keys task
+type syncValidatorKeys struct {
+	c   *cli.Context
+	log log.ColorLogger
+	cfg *config.RocketPoolConfig
+	pm  *passwords.PasswordManager
+	bc  beacon.Client
+	d   *client.Client
+}
+
+// Create sync validator keys task
+func newSyncValidatorKeys(c *cli.Context, logger log.ColorLogger) (*syncValidatorKeys, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	pm, err := services.GetPasswordManager(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &syncValidatorKeys{
+		c:   c,
+		log: logger,
+		cfg: cfg,
+		pm:  pm,
+		bc:  bc,
+		d:   d,
+	}, nil
+
+}
+
+// Decrypt the validator key archive into the validator key folder if it hasn't been unlocked since the last reboot,
+// then restart the Validator Client so it loads them if requested
+func (t *syncValidatorKeys) unlock(restartValidator bool) error {
+
+	// Check if encryption is enabled and there's an archive to unlock
+	if !t.cfg.Smartnode.EncryptValidatorKeys.Value.(bool) {
+		return nil
+	}
+	archivePath := t.cfg.Smartnode.GetValidatorKeyArchivePath()
+	_, err := os.Stat(archivePath)
+	if os.IsNotExist(err) {
+		t.log.Println("Validator key encryption is enabled but there is no archive yet; run `rocketpool wallet encrypt-validator-keys` to create it.")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error checking for the validator key archive: %w", err)
+	}
+
+	// Don't unlock over a folder that's already unlocked, since it may have keys that haven't been archived yet
+	keychainPath := os.ExpandEnv(t.cfg.Smartnode.GetValidatorKeychainPath())
+	_, unlocked, err := keyarchive.GetUnlockedHash(keychainPath)
+	if err != nil {
+		return err
+	}
+	if unlocked {
+		return nil
+	}
+
+	// Never write the decrypted keys to disk; the folder has to be mounted in memory first
+	isTmpfs, err := keyarchive.IsTmpfs(keychainPath)
+	if err != nil {
+		return fmt.Errorf("couldn't check if the validator key folder is in memory, so the validator keys will stay locked: %w", err)
+	}
+	if !isTmpfs {
+		if t.cfg.IsNativeMode {
+			return fmt.Errorf("the validator key folder (%s) is not a tmpfs mount, so the validator keys will stay locked instead of being decrypted to disk; mount a tmpfs there and restart the node daemon to unlock them", keychainPath)
+		}
+		return fmt.Errorf("the validator key folder (%s) is not a tmpfs mount, so the validator keys will stay locked instead of being decrypted to disk; run `rocketpool service start` to mount it in memory and unlock them", keychainPath)
+	}
+
+	// Decrypt the archive
+	t.log.Println("Unlocking validator keys...")
+	password, err := t.pm.GetPassword()
+	if err != nil {
+		return fmt.Errorf("error getting the node wallet password to unlock the validator keys: %w", err)
+	}
+	_, err = keyarchive.DecryptToDir(archivePath, keychainPath, password)
+	if err != nil {
+		return err
+	}
+
+	// Restart the Validator Client to load them
+	if !restartValidator {
+		t.log.Println("Validator keys unlocked; the Validator Client will load them when it's restarted.")
+		return nil
+	}
+	err = validator.RestartValidator(t.cfg, t.bc, &t.log, t.d)
+	if err != nil {
+		return fmt.Errorf("validator keys were unlocked but the Validator Client couldn't be restarted: %w", err)
+	}
+	t.log.Println("Validator keys unlocked.")
+	return nil
+
+}
+
+// Save any changes to the unlocked validator keys (such as new minipool keys) back into the archive
+func (t *syncValidatorKeys) run(state *state.NetworkState) error {
+
+	if !t.cfg.Smartnode.EncryptValidatorKeys.Value.(bool) {
+		return nil
+	}
+
+	// Only save folders that were unlocked from the archive, so an empty folder after a reboot never replaces it
+	keychainPath := os.ExpandEnv(t.cfg.Smartnode.GetValidatorKeychainPath())
+	unlockedHash, unlocked, err := keyarchive.GetUnlockedHash(keychainPath)
+	if err != nil {
+		return err
+	}
+	if !unlocked {
+		return nil
+	}
+	currentHash, err := keyarchive.GetDirHash(keychainPath)
+	if err != nil {
+		return err
+	}
+	if currentHash == unlockedHash {
+		return nil
+	}
+
+	// Re-encrypt the archive
+	t.log.Println("Validator keys have changed, updating the encrypted archive...")
+	password, err := t.pm.GetPassword()
+	if err != nil {
+		return fmt.Errorf("error getting the node wallet password to encrypt the validator keys: %w", err)
+	}
+	hash, err := keyarchive.EncryptDir(keychainPath, t.cfg.Smartnode.GetValidatorKeyArchivePath(), password)
+	if err != nil {
+		return err
+	}
+	err = keyarchive.MarkUnlocked(keychainPath, hash)
+	if err != nil {
+		return err
+	}
+	t.log.Println("Encrypted archive updated.")
+	return nil
+
+}
@@ -0,0 +1,224 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/ledger"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The most Beacon Chain slots to scan for withdrawals in one run, so catching up doesn't hold up the other tasks
+const maxWithdrawalTrackingSlotsPerRun uint64 = 300
+
+// Track withdrawals task
+type trackWithdrawals struct {
+	c           *cli.Context
+	log         log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	rp          *rocketpool.RocketPool
+	bc          beacon.Client
+	nodeAddress common.Address
+	ledger      *ledger.Ledger
+}
+
+// Create track withdrawals task
+func newTrackWithdrawals(c *cli.Context, logger log.ColorLogger) (*trackWithdrawals, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Pick up where the last run left off
+	rewardsLedger, err := ledger.LoadLedger(cfg.Smartnode.GetRewardsLedgerPath(true))
+	if err != nil {
+		logger.Printlnf("WARNING: %s; starting a new ledger.", err.Error())
+		rewardsLedger = nil
+	}
+
+	// Return task
+	return &trackWithdrawals{
+		c:           c,
+		log:         logger,
+		cfg:         cfg,
+		rp:          rp,
+		bc:          bc,
+		nodeAddress: nodeAccount.Address,
+		ledger:      rewardsLedger,
+	}, nil
+
+}
+
+// Record any new withdrawals to, and distributions from, the node's minipools and fee distributor
+func (t *trackWithdrawals) run(state *state.NetworkState) error {
+
+	// Start tracking from the current head if this is the first run
+	if t.ledger == nil {
+		t.log.Printlnf("Starting the rewards ledger at slot %d.", state.BeaconSlotNumber)
+		t.ledger = ledger.NewLedger(state.BeaconSlotNumber)
+		t.ledger.LastScannedBlock = state.ElBlockNumber
+	}
+
+	// Add any minipools that have a validator now
+	err := t.addNewMinipools(state)
+	if err != nil {
+		return err
+	}
+	minipoolsByIndex := map[uint64]common.Address{}
+	addresses := make([]common.Address, 0, len(t.ledger.Minipools))
+	for address, mpLedger := range t.ledger.Minipools {
+		index, err := strconv.ParseUint(mpLedger.ValidatorIndex, 10, 64)
+		if err != nil {
+			return fmt.Errorf("error parsing validator index [%s] for minipool %s: %w", mpLedger.ValidatorIndex, address.Hex(), err)
+		}
+		minipoolsByIndex[index] = address
+		addresses = append(addresses, address)
+	}
+
+	// Scan the new slots for withdrawals
+	endSlot := state.BeaconSlotNumber
+	if endSlot > t.ledger.LastScannedSlot+maxWithdrawalTrackingSlotsPerRun {
+		endSlot = t.ledger.LastScannedSlot + maxWithdrawalTrackingSlotsPerRun
+	}
+	if endSlot <= t.ledger.LastScannedSlot {
+		return nil
+	}
+	endBlock := t.ledger.LastScannedBlock
+	withdrawalCount := 0
+	for slot := t.ledger.LastScannedSlot + 1; slot <= endSlot; slot++ {
+		block, exists, err := t.bc.GetBeaconBlock(strconv.FormatUint(slot, 10))
+		if err != nil {
+			return fmt.Errorf("error getting Beacon block for slot %d: %w", slot, err)
+		}
+		if !exists || !block.HasExecutionPayload {
+			continue
+		}
+		endBlock = block.ExecutionBlockNumber
+
+		for _, withdrawal := range block.Withdrawals {
+			address, exists := minipoolsByIndex[withdrawal.ValidatorIndex]
+			if !exists {
+				continue
+			}
+			mpLedger := t.ledger.Minipools[address]
+			mpLedger.Withdrawals = append(mpLedger.Withdrawals, ledger.Withdrawal{
+				Slot:       slot,
+				Block:      block.ExecutionBlockNumber,
+				AmountGwei: withdrawal.Amount,
+			})
+			withdrawalCount++
+		}
+	}
+
+	// Get the distributions in the same range of EL blocks
+	distributionCount := 0
+	logInterval, err := t.cfg.GetEventLogInterval()
+	if err != nil {
+		return err
+	}
+	if endBlock > t.ledger.LastScannedBlock && len(addresses) > 0 {
+		logs, err := eth.GetLogs(t.rp, addresses, [][]common.Hash{{ledger.EtherWithdrawalProcessedTopic}}, big.NewInt(int64(logInterval)), big.NewInt(0).SetUint64(t.ledger.LastScannedBlock+1), big.NewInt(0).SetUint64(endBlock), nil)
+		if err != nil {
+			return fmt.Errorf("error getting minipool distribution events: %w", err)
+		}
+		for _, log := range logs {
+			mpLedger, exists := t.ledger.Minipools[log.Address]
+			if !exists {
+				continue
+			}
+			distribution, err := ledger.ParseDistribution(log)
+			if err != nil {
+				return err
+			}
+			mpLedger.Distributions = append(mpLedger.Distributions, distribution)
+			distributionCount++
+		}
+	}
+
+	// Get the fee distributor's distributions too
+	nodeDetails, exists := state.NodeDetailsByAddress[t.nodeAddress]
+	if endBlock > t.ledger.LastScannedBlock && exists && nodeDetails.FeeDistributorInitialised {
+		logs, err := eth.GetLogs(t.rp, []common.Address{nodeDetails.FeeDistributorAddress}, [][]common.Hash{{ledger.FeesDistributedTopic}}, big.NewInt(int64(logInterval)), big.NewInt(0).SetUint64(t.ledger.LastScannedBlock+1), big.NewInt(0).SetUint64(endBlock), nil)
+		if err != nil {
+			return fmt.Errorf("error getting fee distributor distribution events: %w", err)
+		}
+		for _, log := range logs {
+			distribution, err := ledger.ParseFeeDistribution(log)
+			if err != nil {
+				return err
+			}
+			t.ledger.FeeDistributions = append(t.ledger.FeeDistributions, distribution)
+			distributionCount++
+		}
+	}
+
+	// Save the ledger
+	t.ledger.LastScannedSlot = endSlot
+	t.ledger.LastScannedBlock = endBlock
+	err = t.ledger.Save(t.cfg.Smartnode.GetRewardsLedgerPath(true))
+	if err != nil {
+		return err
+	}
+	if withdrawalCount > 0 || distributionCount > 0 {
+		t.log.Printlnf("Recorded %d withdrawals and %d distributions up to slot %d.", withdrawalCount, distributionCount, endSlot)
+	}
+
+	// Return
+	return nil
+
+}
+
+// Add the node's minipools that aren't tracked yet, starting from their balance at the last scanned block
+func (t *trackWithdrawals) addNewMinipools(state *state.NetworkState) error {
+	for _, mpd := range state.MinipoolDetailsByNode[t.nodeAddress] {
+		if _, exists := t.ledger.Minipools[mpd.MinipoolAddress]; exists {
+			continue
+		}
+		validator, exists := state.ValidatorDetails[mpd.Pubkey]
+		if !exists || !validator.Exists {
+			continue
+		}
+
+		balance, err := t.rp.Client.BalanceAt(context.Background(), mpd.MinipoolAddress, big.NewInt(0).SetUint64(t.ledger.LastScannedBlock))
+		if err != nil {
+			return fmt.Errorf("error getting balance of minipool %s: %w", mpd.MinipoolAddress.Hex(), err)
+		}
+		t.ledger.Minipools[mpd.MinipoolAddress] = &ledger.MinipoolLedger{
+			ValidatorIndex: validator.Index,
+			StartBlock:     t.ledger.LastScannedBlock,
+			StartBalance:   balance,
+			Withdrawals:    []ledger.Withdrawal{},
+			Distributions:  []ledger.Distribution{},
+		}
+	}
+	return nil
+}
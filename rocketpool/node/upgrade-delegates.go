@@ -0,0 +1,315 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/delegates"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Upgrade delegates task
+type upgradeDelegates struct {
+	c              *cli.Context
+	log            log.ColorLogger
+	cfg            *config.RocketPoolConfig
+	w              *wallet.Wallet
+	rp             *rocketpool.RocketPool
+	gasThreshold   float64
+	dailyBudget    *big.Int
+	batchSize      int
+	maxFee         *big.Int
+	maxPriorityFee *big.Int
+	gasLimit       uint64
+	disabled       bool
+}
+
+// Create upgrade delegates task
+func newUpgradeDelegates(c *cli.Context, logger log.ColorLogger) (*upgradeDelegates, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if auto-upgrading is disabled
+	gasThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
+	disabled := false
+	if gasThreshold == 0 {
+		logger.Println("Automatic tx gas threshold is 0, disabling delegate auto-upgrades.")
+		disabled = true
+	}
+
+	// Get the budget; 0 means there's no limit
+	var dailyBudget *big.Int
+	dailyBudgetEth := cfg.Smartnode.DelegateUpgradeDailyBudget.Value.(float64)
+	if dailyBudgetEth > 0 {
+		dailyBudget = eth.EthToWei(dailyBudgetEth)
+	}
+
+	// Get the user-requested max fee
+	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
+	var maxFee *big.Int
+	if maxFeeGwei == 0 {
+		maxFee = nil
+	} else {
+		maxFee = eth.GweiToWei(maxFeeGwei)
+	}
+
+	// Get the user-requested priority fee
+	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
+	var priorityFee *big.Int
+	if priorityFeeGwei == 0 {
+		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		priorityFee = eth.GweiToWei(2)
+	} else {
+		priorityFee = eth.GweiToWei(priorityFeeGwei)
+	}
+
+	// Return task
+	return &upgradeDelegates{
+		c:              c,
+		log:            logger,
+		cfg:            cfg,
+		w:              w,
+		rp:             rp,
+		gasThreshold:   gasThreshold,
+		dailyBudget:    dailyBudget,
+		batchSize:      int(cfg.Smartnode.DelegateUpgradeBatchSize.Value.(uint64)),
+		maxFee:         maxFee,
+		maxPriorityFee: priorityFee,
+		gasLimit:       0,
+		disabled:       disabled,
+	}, nil
+
+}
+
+// Upgrade the delegates of opted-in minipools, a batch at a time
+func (t *upgradeDelegates) run(state *state.NetworkState) error {
+
+	// Check if auto-upgrading is disabled
+	if t.disabled {
+		return nil
+	}
+
+	// Get the opted-in minipools
+	campaignPath := t.cfg.Smartnode.GetDelegateUpgradesPath(true)
+	campaign, err := delegates.LoadCampaign(campaignPath)
+	if err != nil {
+		return err
+	}
+	optedIn := 0
+	for _, enabled := range campaign.AutoUpgrade {
+		if enabled {
+			optedIn++
+		}
+	}
+	if optedIn == 0 {
+		return nil
+	}
+
+	// Log
+	t.log.Println("Checking for minipools with outdated delegates...")
+
+	// Get the latest state
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+	}
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the latest delegate
+	latestDelegate, err := t.rp.GetAddress("rocketMinipoolDelegate", opts)
+	if err != nil {
+		return fmt.Errorf("error getting latest minipool delegate: %w", err)
+	}
+
+	// Get the max fee
+	maxFee := t.maxFee
+	if maxFee == nil || maxFee.Uint64() == 0 {
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Find the opted-in minipools that need upgrading and what each one costs
+	candidates := []delegates.Candidate{}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAccount.Address] {
+		if !campaign.AutoUpgrade[mpd.MinipoolAddress] {
+			continue
+		}
+		if mpd.Finalised || mpd.UseLatestDelegate || mpd.Delegate == *latestDelegate {
+			continue
+		}
+		gas, err := t.estimateUpgradeGas(mpd.MinipoolAddress, mpd.Version, opts)
+		if err != nil {
+			t.log.Printlnf("WARNING: Could not estimate the gas required to upgrade minipool %s: %s", mpd.MinipoolAddress.Hex(), err.Error())
+			continue
+		}
+		candidates = append(candidates, delegates.Candidate{
+			Minipool: mpd.MinipoolAddress,
+			Delegate: mpd.Delegate,
+			Gas:      gas,
+			Cost:     new(big.Int).Mul(maxFee, new(big.Int).SetUint64(gas)),
+		})
+	}
+	if len(candidates) == 0 {
+		t.log.Printlnf("All %d opted-in minipool(s) are using the latest delegate.", optedIn)
+		return nil
+	}
+
+	// Pick the batch that fits in what's left of the budget
+	var remainingBudget *big.Int
+	if t.dailyBudget != nil {
+		remainingBudget = new(big.Int).Sub(t.dailyBudget, campaign.GetSpentSince(time.Now().Add(-delegates.BudgetWindow)))
+		if remainingBudget.Sign() < 0 {
+			remainingBudget.SetUint64(0)
+		}
+	}
+	batch := delegates.PlanBatch(candidates, remainingBudget, t.batchSize)
+	t.log.Printlnf("%d of %d opted-in minipool(s) need a delegate upgrade; upgrading %d now.", len(candidates), optedIn, len(batch))
+	if len(batch) == 0 {
+		t.log.Printlnf("The daily gas budget has %.6f ETH left, which isn't enough for the next upgrade; waiting for it to free up.", eth.WeiToEth(remainingBudget))
+		return nil
+	}
+
+	// Upgrade the batch
+	for _, candidate := range batch {
+		upgraded, err := t.upgradeDelegate(candidate, *latestDelegate, maxFee, campaignPath)
+		if err != nil {
+			t.log.Println(fmt.Errorf("Could not upgrade the delegate of minipool %s: %w", candidate.Minipool.Hex(), err))
+			return err
+		}
+		if !upgraded {
+			// The gas price is too high, so try again later
+			return nil
+		}
+	}
+
+	// Log progress
+	remaining := len(candidates) - len(batch)
+	if remaining > 0 {
+		t.log.Printlnf("%d minipool(s) are still waiting for a delegate upgrade.", remaining)
+	} else {
+		t.log.Println("All opted-in minipools have been upgraded to the latest delegate.")
+	}
+
+	// Return
+	return nil
+
+}
+
+// Estimate the gas needed to upgrade a minipool's delegate
+func (t *upgradeDelegates) estimateUpgradeGas(minipoolAddress common.Address, version uint8, callOpts *bind.CallOpts) (uint64, error) {
+	mp, err := minipool.NewMinipoolFromVersion(t.rp, minipoolAddress, version, callOpts)
+	if err != nil {
+		return 0, fmt.Errorf("cannot create binding for minipool %s: %w", minipoolAddress.Hex(), err)
+	}
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return 0, err
+	}
+	gasInfo, err := mp.EstimateDelegateUpgradeGas(opts)
+	if err != nil {
+		return 0, err
+	}
+	if t.gasLimit != 0 {
+		return t.gasLimit, nil
+	}
+	return gasInfo.SafeGasLimit, nil
+}
+
+// Upgrade a minipool's delegate and record it in the campaign
+func (t *upgradeDelegates) upgradeDelegate(candidate delegates.Candidate, latestDelegate common.Address, maxFee *big.Int, campaignPath string) (bool, error) {
+
+	// Log
+	t.log.Printlnf("Upgrading the delegate of minipool %s from %s to %s...", candidate.Minipool.Hex(), candidate.Delegate.Hex(), latestDelegate.Hex())
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return false, err
+	}
+
+	// Print the gas info
+	gasInfo := rocketpool.GasInfo{
+		EstGasLimit:  candidate.Gas,
+		SafeGasLimit: candidate.Gas,
+	}
+	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &t.log, maxFee, t.gasLimit) {
+		return false, nil
+	}
+
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = t.maxPriorityFee
+	opts.GasLimit = candidate.Gas
+
+	// Upgrade the delegate
+	mp, err := minipool.NewMinipool(t.rp, candidate.Minipool, nil)
+	if err != nil {
+		return false, err
+	}
+	hash, err := mp.DelegateUpgrade(opts)
+	if err != nil {
+		return false, err
+	}
+
+	// Record it before waiting so its cost counts against the budget even if the daemon restarts;
+	// the campaign is reloaded first so opt-in changes made in the meantime aren't lost
+	campaign, err := delegates.LoadCampaign(campaignPath)
+	if err != nil {
+		return false, err
+	}
+	campaign.Upgrades = append(campaign.Upgrades, delegates.Upgrade{
+		Minipool:     candidate.Minipool,
+		FromDelegate: candidate.Delegate,
+		ToDelegate:   latestDelegate,
+		TxHash:       hash,
+		Cost:         candidate.Cost,
+		Time:         time.Now(),
+	})
+	if err := campaign.Save(campaignPath); err != nil {
+		t.log.Printlnf("WARNING: %s", err.Error())
+	}
+
+	// Print TX info and wait for it to be included in a block
+	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	if err != nil {
+		return false, err
+	}
+
+	// Log
+	t.log.Printlnf("Successfully upgraded the delegate of minipool %s.", candidate.Minipool.Hex())
+
+	// Return
+	return true, nil
+
+}
@@ -0,0 +1,159 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Verify proposal gas limits task
+type verifyProposalGasLimits struct {
+	c               *cli.Context
+	log             log.ColorLogger
+	cfg             *config.RocketPoolConfig
+	rp              *rocketpool.RocketPool
+	bc              beacon.Client
+	nodeAddress     common.Address
+	lastCheckedSlot uint64
+}
+
+// Create verify proposal gas limits task
+func newVerifyProposalGasLimits(c *cli.Context, logger log.ColorLogger) (*verifyProposalGasLimits, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &verifyProposalGasLimits{
+		c:           c,
+		log:         logger,
+		cfg:         cfg,
+		rp:          rp,
+		bc:          bc,
+		nodeAddress: nodeAccount.Address,
+	}, nil
+
+}
+
+// Check every block proposed by the node's validators since the last run, and warn about any that didn't move the gas limit towards the preferred one.
+// Only the previous and current epochs are checked, since older proposer duties aren't available from every Beacon Node.
+func (t *verifyProposalGasLimits) run(state *state.NetworkState) error {
+
+	if t.cfg.Smartnode.PreferredGasLimit.Value.(uint64) == 0 {
+		return nil
+	}
+
+	// Get the node's validators
+	indices := []string{}
+	for _, mpd := range state.MinipoolDetailsByNode[t.nodeAddress] {
+		validator, exists := state.ValidatorDetails[mpd.Pubkey]
+		if exists && validator.Exists {
+			indices = append(indices, validator.Index)
+		}
+	}
+	headSlot := state.BeaconSlotNumber
+	if len(indices) == 0 {
+		t.lastCheckedSlot = headSlot
+		return nil
+	}
+
+	// Get the node's proposals since the last run
+	headEpoch := headSlot / state.BeaconConfig.SlotsPerEpoch
+	startEpoch := headEpoch
+	if startEpoch > 0 {
+		startEpoch--
+	}
+	if lastCheckedEpoch := t.lastCheckedSlot / state.BeaconConfig.SlotsPerEpoch; lastCheckedEpoch > startEpoch {
+		startEpoch = lastCheckedEpoch
+	}
+	proposals := []uint64{}
+	for epoch := startEpoch; epoch <= headEpoch; epoch++ {
+		slotsByValidator, err := t.bc.GetValidatorProposerSlots(indices, epoch)
+		if err != nil {
+			return fmt.Errorf("error getting the proposer duties for epoch %d: %w", epoch, err)
+		}
+		for _, slots := range slotsByValidator {
+			for _, slot := range slots {
+				if slot > t.lastCheckedSlot && slot <= headSlot {
+					proposals = append(proposals, slot)
+				}
+			}
+		}
+	}
+
+	// Check each proposal
+	for _, slot := range proposals {
+		block, exists, err := t.bc.GetBeaconBlock(strconv.FormatUint(slot, 10))
+		if err != nil {
+			return fmt.Errorf("error getting Beacon block for slot %d: %w", slot, err)
+		}
+		if !exists {
+			t.log.Printlnf("The node's proposal for slot %d was missed, so it has no gas limit to check.", slot)
+			continue
+		}
+		if block.HasExecutionPayload {
+			t.verifyProposalGasLimit(slot, block)
+		}
+	}
+
+	// Return
+	t.lastCheckedSlot = headSlot
+	return nil
+
+}
+
+// Check that a block proposed by one of the node's validators moved the gas limit towards the preferred one, and warn if it didn't
+func (t *verifyProposalGasLimits) verifyProposalGasLimit(slot uint64, block beacon.BeaconBlock) {
+	preferredGasLimit := t.cfg.Smartnode.PreferredGasLimit.Value.(uint64)
+
+	header, err := t.rp.Client.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(block.ExecutionBlockNumber))
+	if err != nil {
+		t.log.Printlnf("WARNING: couldn't get execution block %d to check its gas limit: %s", block.ExecutionBlockNumber, err.Error())
+		return
+	}
+	parentHeader, err := t.rp.Client.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(block.ExecutionBlockNumber-1))
+	if err != nil {
+		t.log.Printlnf("WARNING: couldn't get execution block %d to check its gas limit: %s", block.ExecutionBlockNumber-1, err.Error())
+		return
+	}
+
+	expectedGasLimit := eth1.GetExpectedGasLimit(parentHeader.GasLimit, preferredGasLimit)
+	if header.GasLimit == expectedGasLimit {
+		t.log.Printlnf("Validator %s proposed the block in slot %d with a gas limit of %d, as preferred.", block.ProposerIndex, slot, header.GasLimit)
+		return
+	}
+	t.log.Printlnf("WARNING: validator %s proposed the block in slot %d with a gas limit of %d, but it should have been %d to move towards your preferred gas limit of %d. Check your Validator Client's gas limit setting and your MEV-Boost relays.", block.ProposerIndex, slot, header.GasLimit, expectedGasLimit, preferredGasLimit)
+	alerting.AlertGasLimitNotHonored(t.cfg, slot, header.GasLimit, expectedGasLimit)
+}
@@ -0,0 +1,363 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// How often to check for new events; this is one slot, so alerts go out about as soon as the events are in a block
+	minipoolEventWatchInterval = 12 * time.Second
+
+	// How often to refresh the minipool list and contract addresses, even if the minipool count hasn't changed
+	minipoolEventRefreshInterval = 10 * time.Minute
+
+	// The most blocks to scan in one check, so catching up after downtime is spread out
+	maxMinipoolEventBlocksPerCheck uint64 = 1000
+
+	minipoolEventsStateMode os.FileMode = 0600
+)
+
+// Events emitted by the minipools themselves
+var (
+	minipoolStatusUpdatedTopic = crypto.Keccak256Hash([]byte("StatusUpdated(uint8,uint256)"))
+	minipoolScrubVotedTopic    = crypto.Keccak256Hash([]byte("ScrubVoted(address,uint256)"))
+	minipoolBondReducedTopic   = crypto.Keccak256Hash([]byte("BondReduced(uint256,uint256,uint256)"))
+)
+
+// Events emitted by the bond reducer and network penalties contracts, with the minipool as the first indexed topic
+var (
+	beginBondReductionTopic   = crypto.Keccak256Hash([]byte("BeginBondReduction(address,uint256,uint256)"))
+	cancelReductionVotedTopic = crypto.Keccak256Hash([]byte("CancelReductionVoted(address,address,uint256)"))
+	reductionCancelledTopic   = crypto.Keccak256Hash([]byte("ReductionCancelled(address,uint256)"))
+	penaltyUpdatedTopic       = crypto.Keccak256Hash([]byte("PenaltyUpdated(address,uint256,uint256)"))
+)
+
+// The progress of the event watcher, saved so events that happen while the daemon is down still get reported
+type minipoolEventsState struct {
+	LastScannedBlock uint64 `json:"lastScannedBlock"`
+}
+
+// A change to one of the node's minipools
+type minipoolEvent struct {
+	minipool    common.Address
+	block       uint64
+	name        string
+	summary     string
+	description string
+	severity    alerting.Severity
+}
+
+// Watch minipool events task
+type watchMinipoolEvents struct {
+	c           *cli.Context
+	log         log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	rp          *rocketpool.RocketPool
+	nodeAddress common.Address
+	statePath   string
+	state       minipoolEventsState
+
+	minipools       []common.Address
+	minipoolTopics  []common.Hash
+	bondReducer     common.Address
+	penalties       common.Address
+	lastRefreshTime time.Time
+}
+
+// Create watch minipool events task
+func newWatchMinipoolEvents(c *cli.Context, logger log.ColorLogger) (*watchMinipoolEvents, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Pick up where the last run left off
+	task := &watchMinipoolEvents{
+		c:           c,
+		log:         logger,
+		cfg:         cfg,
+		rp:          rp,
+		nodeAddress: nodeAccount.Address,
+		statePath:   cfg.Smartnode.GetMinipoolEventsStatePath(true),
+	}
+	bytes, err := os.ReadFile(task.statePath)
+	if err == nil {
+		if err := json.Unmarshal(bytes, &task.state); err != nil {
+			logger.Printlnf("WARNING: couldn't read the minipool event watcher state, starting from the latest block: %s", err.Error())
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error reading minipool event watcher state [%s]: %w", task.statePath, err)
+	}
+
+	// Return task
+	return task, nil
+
+}
+
+// Check the new blocks for events on the node's minipools, and alert on each one
+func (t *watchMinipoolEvents) run() error {
+
+	// Logs from an out of sync client would be stale, so wait until it catches up
+	synced, _, err := services.IsSyncWithinThreshold(t.rp.Client)
+	if err != nil || !synced {
+		return nil
+	}
+
+	// Get the range of blocks to scan
+	latestBlock, err := t.rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return fmt.Errorf("error getting the latest block: %w", err)
+	}
+	if t.state.LastScannedBlock == 0 {
+		// Start from the head on the first run rather than alerting on the whole history
+		t.log.Printlnf("Watching for minipool events from block %d.", latestBlock)
+		t.state.LastScannedBlock = latestBlock
+		return t.saveState()
+	}
+	if latestBlock <= t.state.LastScannedBlock {
+		return nil
+	}
+	startBlock := t.state.LastScannedBlock + 1
+	endBlock := latestBlock
+	if endBlock-startBlock+1 > maxMinipoolEventBlocksPerCheck {
+		endBlock = startBlock + maxMinipoolEventBlocksPerCheck - 1
+	}
+
+	// Refresh the minipools and contracts to watch
+	if err := t.refresh(); err != nil {
+		return err
+	}
+
+	// Get the events
+	events := []minipoolEvent{}
+	if len(t.minipools) > 0 {
+		logInterval, err := t.cfg.GetEventLogInterval()
+		if err != nil {
+			return err
+		}
+		intervalSize := big.NewInt(int64(logInterval))
+		fromBlock := big.NewInt(0).SetUint64(startBlock)
+		toBlock := big.NewInt(0).SetUint64(endBlock)
+
+		minipoolLogs, err := eth.GetLogs(t.rp, t.minipools, [][]common.Hash{{minipoolStatusUpdatedTopic, minipoolScrubVotedTopic, minipoolBondReducedTopic}}, intervalSize, fromBlock, toBlock, nil)
+		if err != nil {
+			return fmt.Errorf("error getting minipool events: %w", err)
+		}
+		networkLogs, err := eth.GetLogs(t.rp, []common.Address{t.bondReducer, t.penalties}, [][]common.Hash{{beginBondReductionTopic, cancelReductionVotedTopic, reductionCancelledTopic, penaltyUpdatedTopic}, t.minipoolTopics}, intervalSize, fromBlock, toBlock, nil)
+		if err != nil {
+			return fmt.Errorf("error getting bond reduction and penalty events: %w", err)
+		}
+		for _, log := range append(minipoolLogs, networkLogs...) {
+			event, ok := parseMinipoolEvent(log)
+			if ok {
+				event.block = log.BlockNumber
+				events = append(events, event)
+			}
+		}
+	}
+
+	// Report them
+	for _, event := range events {
+		t.log.Printlnf("Minipool %s: %s", event.minipool.Hex(), event.description)
+		if err := alerting.AlertMinipoolEvent(t.cfg, event.minipool, event.name, event.block, event.summary, event.description, event.severity); err != nil {
+			t.log.Printlnf("WARNING: couldn't send the minipool event alert: %s", err.Error())
+		}
+	}
+
+	// Save the progress
+	t.state.LastScannedBlock = endBlock
+	return t.saveState()
+
+}
+
+// Reload the node's minipools if the count changed, and the contract addresses every so often in case they were upgraded
+func (t *watchMinipoolEvents) refresh() error {
+	count, err := minipool.GetNodeMinipoolCount(t.rp, t.nodeAddress, nil)
+	if err != nil {
+		return fmt.Errorf("error getting node minipool count: %w", err)
+	}
+	if count == uint64(len(t.minipools)) && time.Since(t.lastRefreshTime) < minipoolEventRefreshInterval {
+		return nil
+	}
+
+	addresses, err := minipool.GetNodeMinipoolAddresses(t.rp, t.nodeAddress, nil)
+	if err != nil {
+		return fmt.Errorf("error getting node minipool addresses: %w", err)
+	}
+	bondReducer, err := t.rp.GetAddress("rocketMinipoolBondReducer", nil)
+	if err != nil {
+		return fmt.Errorf("error getting bond reducer address: %w", err)
+	}
+	penalties, err := t.rp.GetAddress("rocketNetworkPenalties", nil)
+	if err != nil {
+		return fmt.Errorf("error getting network penalties address: %w", err)
+	}
+
+	t.minipools = addresses
+	t.minipoolTopics = make([]common.Hash, len(addresses))
+	for i, address := range addresses {
+		t.minipoolTopics[i] = common.BytesToHash(address.Bytes())
+	}
+	t.bondReducer = *bondReducer
+	t.penalties = *penalties
+	t.lastRefreshTime = time.Now()
+	return nil
+}
+
+// Save the progress of the event watcher
+func (t *watchMinipoolEvents) saveState() error {
+	bytes, err := json.Marshal(t.state)
+	if err != nil {
+		return fmt.Errorf("error serializing minipool event watcher state: %w", err)
+	}
+	if err := files.WriteFileAtomic(t.statePath, bytes, minipoolEventsStateMode); err != nil {
+		return fmt.Errorf("error saving minipool event watcher state [%s]: %w", t.statePath, err)
+	}
+	return nil
+}
+
+// Turn a log into a description of what happened to the minipool
+func parseMinipoolEvent(log ethtypes.Log) (minipoolEvent, bool) {
+	if len(log.Topics) == 0 {
+		return minipoolEvent{}, false
+	}
+
+	// Events from the minipool contracts
+	switch log.Topics[0] {
+	case minipoolStatusUpdatedTopic:
+		if len(log.Topics) < 2 {
+			return minipoolEvent{}, false
+		}
+		status := types.MinipoolStatus(log.Topics[1].Big().Uint64())
+		event := minipoolEvent{
+			minipool:    log.Address,
+			name:        "StatusUpdated",
+			summary:     fmt.Sprintf("Minipool %s is now %s", log.Address.Hex(), status.String()),
+			description: fmt.Sprintf("The minipool's status changed to %s in block %d (transaction %s).", status.String(), log.BlockNumber, log.TxHash.Hex()),
+			severity:    alerting.SeverityInfo,
+		}
+		if status == types.Dissolved {
+			event.description += " Run `rocketpool minipool recover` to see how to get its bond back."
+			event.severity = alerting.SeverityCritical
+		}
+		return event, true
+
+	case minipoolScrubVotedTopic:
+		member := ""
+		if len(log.Topics) > 1 {
+			member = common.BytesToAddress(log.Topics[1].Bytes()).Hex()
+		}
+		return minipoolEvent{
+			minipool:    log.Address,
+			name:        "ScrubVoted",
+			summary:     fmt.Sprintf("Minipool %s was voted to be scrubbed", log.Address.Hex()),
+			description: fmt.Sprintf("Oracle DAO member %s voted to scrub the minipool in block %d (transaction %s). If enough members agree, the minipool will be dissolved and its bond penalized.", member, log.BlockNumber, log.TxHash.Hex()),
+			severity:    alerting.SeverityCritical,
+		}, true
+
+	case minipoolBondReducedTopic:
+		previousBond, newBond := getUint256(log.Data, 0), getUint256(log.Data, 1)
+		return minipoolEvent{
+			minipool:    log.Address,
+			name:        "BondReduced",
+			summary:     fmt.Sprintf("Minipool %s's bond was reduced", log.Address.Hex()),
+			description: fmt.Sprintf("The minipool's bond was reduced from %.2f ETH to %.2f ETH in block %d (transaction %s).", eth.WeiToEth(previousBond), eth.WeiToEth(newBond), log.BlockNumber, log.TxHash.Hex()),
+			severity:    alerting.SeverityInfo,
+		}, true
+	}
+
+	// Events from the network contracts, which index the minipool
+	if len(log.Topics) < 2 {
+		return minipoolEvent{}, false
+	}
+	minipoolAddress := common.BytesToAddress(log.Topics[1].Bytes())
+	switch log.Topics[0] {
+	case beginBondReductionTopic:
+		newBond := getUint256(log.Data, 0)
+		return minipoolEvent{
+			minipool:    minipoolAddress,
+			name:        "BeginBondReduction",
+			summary:     fmt.Sprintf("Minipool %s started a bond reduction", minipoolAddress.Hex()),
+			description: fmt.Sprintf("A bond reduction to %.2f ETH was started in block %d (transaction %s). If you didn't start it, make sure your node wallet hasn't been compromised.", eth.WeiToEth(newBond), log.BlockNumber, log.TxHash.Hex()),
+			severity:    alerting.SeverityWarning,
+		}, true
+
+	case cancelReductionVotedTopic:
+		member := ""
+		if len(log.Topics) > 2 {
+			member = common.BytesToAddress(log.Topics[2].Bytes()).Hex()
+		}
+		return minipoolEvent{
+			minipool:    minipoolAddress,
+			name:        "CancelReductionVoted",
+			summary:     fmt.Sprintf("Minipool %s's bond reduction was voted to be cancelled", minipoolAddress.Hex()),
+			description: fmt.Sprintf("Oracle DAO member %s voted to cancel the minipool's bond reduction in block %d (transaction %s).", member, log.BlockNumber, log.TxHash.Hex()),
+			severity:    alerting.SeverityWarning,
+		}, true
+
+	case reductionCancelledTopic:
+		return minipoolEvent{
+			minipool:    minipoolAddress,
+			name:        "ReductionCancelled",
+			summary:     fmt.Sprintf("Minipool %s's bond reduction was cancelled", minipoolAddress.Hex()),
+			description: fmt.Sprintf("The Oracle DAO cancelled the minipool's bond reduction in block %d (transaction %s), so its bond can't be reduced anymore.", log.BlockNumber, log.TxHash.Hex()),
+			severity:    alerting.SeverityCritical,
+		}, true
+
+	case penaltyUpdatedTopic:
+		penalty := getUint256(log.Data, 0)
+		return minipoolEvent{
+			minipool:    minipoolAddress,
+			name:        "PenaltyUpdated",
+			summary:     fmt.Sprintf("Minipool %s was penalized", minipoolAddress.Hex()),
+			description: fmt.Sprintf("The minipool's penalty rate was set to %.2f%% in block %d (transaction %s), which comes out of your share of its balance. This usually means its validator used the wrong fee recipient.", eth.WeiToEth(penalty)*100, log.BlockNumber, log.TxHash.Hex()),
+			severity:    alerting.SeverityCritical,
+		}, true
+	}
+
+	return minipoolEvent{}, false
+}
+
+// Get one of the uint256 values from a log's data
+func getUint256(data []byte, index int) *big.Int {
+	start := index * common.HashLength
+	if len(data) < start+common.HashLength {
+		return big.NewInt(0)
+	}
+	return new(big.Int).SetBytes(data[start : start+common.HashLength])
+}
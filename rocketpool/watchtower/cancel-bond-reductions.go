@@ -8,7 +8,9 @@ import (
 
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
@@ -151,6 +153,14 @@ func (t *cancelBondReductions) checkBondReductions(state *state.NetworkState) er
 	balanceTooLowCount := float64(0)
 	invalidStateCount := float64(0)
 
+	// Cancel them together as one bundle if a bundle relay is set up
+	round := newTransactionRound(t.cfg, t.ec, &t.log)
+	defer func() {
+		if err := round.submit(); err != nil {
+			t.printMessage(fmt.Sprintf("could not vote to cancel the bond reductions: %s", err.Error()))
+		}
+	}()
+
 	// Check the status of each one
 	threshold := uint64(32000000000) - scrubBuffer
 	for _, mpd := range reductionMps {
@@ -166,7 +176,7 @@ func (t *cancelBondReductions) checkBondReductions(state *state.NetworkState) er
 				// Check the balance
 				if validator.Balance < threshold {
 					// Cancel because it's under-balance
-					t.cancelBondReduction(mpd.MinipoolAddress, fmt.Sprintf("minipool balance is %d (below the threshold)", validator.Balance))
+					t.cancelBondReduction(round, mpd.MinipoolAddress, fmt.Sprintf("minipool balance is %d (below the threshold)", validator.Balance))
 					balanceTooLowCount += 1
 				}
 
@@ -176,7 +186,7 @@ func (t *cancelBondReductions) checkBondReductions(state *state.NetworkState) er
 				beacon.ValidatorState_ExitedSlashed,
 				beacon.ValidatorState_WithdrawalPossible,
 				beacon.ValidatorState_WithdrawalDone:
-				t.cancelBondReduction(mpd.MinipoolAddress, "minipool is already slashed, exiting, or exited")
+				t.cancelBondReduction(round, mpd.MinipoolAddress, "minipool is already slashed, exiting, or exited")
 				invalidStateCount += 1
 
 			default:
@@ -211,7 +221,7 @@ func (t *cancelBondReductions) updateMetricsCollector(state *state.NetworkState,
 }
 
 // Cancel a bond reduction
-func (t *cancelBondReductions) cancelBondReduction(address common.Address, reason string) {
+func (t *cancelBondReductions) cancelBondReduction(round *transactionRound, address common.Address, reason string) {
 
 	// Log
 	t.printMessage("=== CANCELLING BOND REDUCTION ===")
@@ -245,22 +255,22 @@ func (t *cancelBondReductions) cancelBondReduction(address common.Address, reaso
 	opts.GasLimit = gasInfo.SafeGasLimit
 
 	// Cancel the reduction
-	hash, err := minipool.VoteCancelReduction(t.rp, address, opts)
+	rocketMinipoolBondReducer, err := t.rp.GetContract("rocketMinipoolBondReducer", nil)
 	if err != nil {
 		t.printMessage(fmt.Sprintf("could not vote to cancel bond reduction: %s", err.Error()))
 		return
 	}
-
-	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	err = round.send(opts, fmt.Sprintf("the vote to cancel the bond reduction of minipool %s", address.Hex()), func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		tx, err := rocketMinipoolBondReducer.Transact(opts, "voteCancelReduction", address)
+		if err != nil {
+			return nil, fmt.Errorf("error voting to cancel bond reduction for minipool %s: %w", address.Hex(), err)
+		}
+		return tx, nil
+	})
 	if err != nil {
-		t.printMessage(fmt.Sprintf("error waiting for cancel transaction: %s", err.Error()))
-		return
+		t.printMessage(fmt.Sprintf("could not vote to cancel bond reduction: %s", err.Error()))
 	}
 
-	// Log
-	t.log.Printlnf("Successfully voted to cancel the bond reduction of minipool %s.", address.Hex())
-
 }
 
 func (t *cancelBondReductions) handleError(err error) {
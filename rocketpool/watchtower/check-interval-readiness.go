@@ -0,0 +1,209 @@
+package watchtower
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/utils"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/pinning"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// How often the check is repeated while it's failing
+	readinessRetryInterval time.Duration = time.Hour
+
+	// How many epochs past the checkpoint interval the latest rolling record checkpoint can fall behind the finalized head
+	readinessRecordSlackEpochs uint64 = 5
+
+	// The gas to keep in the node wallet for the rewards tree, balances, and prices submissions around the end of an interval
+	readinessGasReserve uint64 = 2000000
+
+	// How much the gas price can rise before the submissions are due
+	readinessGasPriceMargin int64 = 2
+)
+
+// Check interval readiness task
+type checkIntervalReadiness struct {
+	c                 *cli.Context
+	log               log.ColorLogger
+	cfg               *config.RocketPoolConfig
+	w                 *wallet.Wallet
+	ec                *services.ExecutionClientManager
+	bc                beacon.Client
+	pinMgr            *pinning.Manager
+	useRollingRecords bool
+	checkedInterval   uint64
+	lastCheck         time.Time
+	passed            bool
+}
+
+// Create check interval readiness task
+func newCheckIntervalReadiness(c *cli.Context, logger log.ColorLogger, pinMgr *pinning.Manager) (*checkIntervalReadiness, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkIntervalReadiness{
+		c:                 c,
+		log:               logger,
+		cfg:               cfg,
+		w:                 w,
+		ec:                ec,
+		bc:                bc,
+		pinMgr:            pinMgr,
+		useRollingRecords: cfg.Smartnode.UseRollingRecords.Value == true,
+	}, nil
+
+}
+
+// In the hours before the current rewards interval ends, make sure nothing will stop the watchtower from building and submitting its tree
+func (t *checkIntervalReadiness) run(state *state.NetworkState) error {
+
+	leadTime := time.Duration(t.cfg.Smartnode.ReadinessCheckLeadTime.Value.(uint64)) * time.Hour
+	if leadTime == 0 {
+		return nil
+	}
+
+	// Only check inside the window before the end of the interval
+	interval := state.NetworkDetails.RewardIndex
+	intervalEnd := state.NetworkDetails.IntervalStart.Add(state.NetworkDetails.IntervalDuration)
+	timeLeft := time.Until(intervalEnd)
+	if timeLeft <= 0 || timeLeft > leadTime {
+		return nil
+	}
+
+	// Check once per interval, and then every hour while it's failing
+	if t.checkedInterval == interval && (t.passed || time.Since(t.lastCheck) < readinessRetryInterval) {
+		return nil
+	}
+	t.checkedInterval = interval
+	t.lastCheck = time.Now()
+
+	// Log
+	t.log.Printlnf("Rewards interval %d ends in %s, checking that the watchtower is ready to submit its tree...", interval, timeLeft.Round(time.Minute))
+
+	problems := []string{}
+	if t.useRollingRecords {
+		if problem := t.checkRollingRecord(state); problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+	if problem := t.checkIntervalStartState(state); problem != "" {
+		problems = append(problems, problem)
+	}
+	if problem := t.checkGasFunds(); problem != "" {
+		problems = append(problems, problem)
+	}
+	if t.pinMgr.IsEnabled() {
+		problems = append(problems, t.pinMgr.CheckCredentials()...)
+	}
+
+	t.passed = len(problems) == 0
+	if t.passed {
+		t.log.Println("The watchtower is ready for the end of the interval.")
+		return nil
+	}
+	for _, problem := range problems {
+		t.log.Printlnf("WARNING: %s", problem)
+	}
+	alerting.AlertRewardsReadinessFailed(t.cfg, interval, timeLeft, problems)
+	return nil
+
+}
+
+// Make sure the latest rolling record checkpoint isn't far behind the finalized head
+func (t *checkIntervalReadiness) checkRollingRecord(state *state.NetworkState) string {
+	recordSlot, exists, err := rewards.GetLatestRecordSlot(t.cfg)
+	if err != nil {
+		return fmt.Sprintf("couldn't read the rolling record checkpoints: %s", err.Error())
+	}
+	if !exists {
+		return "there aren't any rolling record checkpoints for this interval yet"
+	}
+
+	head, err := t.bc.GetBeaconHead()
+	if err != nil {
+		return fmt.Sprintf("couldn't get the Beacon head to compare the rolling record against: %s", err.Error())
+	}
+	recordEpoch := recordSlot / state.BeaconConfig.SlotsPerEpoch
+	allowedLag := t.cfg.Smartnode.RecordCheckpointInterval.Value.(uint64) + readinessRecordSlackEpochs
+	if head.FinalizedEpoch > recordEpoch+allowedLag {
+		return fmt.Sprintf("the latest rolling record checkpoint is for epoch %d, %d epochs behind the finalized epoch %d", recordEpoch, head.FinalizedEpoch-recordEpoch, head.FinalizedEpoch)
+	}
+	return ""
+}
+
+// Make sure the state of the block at the start of the interval can still be read, either from the local clients or the archive EC
+func (t *checkIntervalReadiness) checkIntervalStartState(state *state.NetworkState) string {
+	genesisTime := time.Unix(int64(state.BeaconConfig.GenesisTime), 0)
+	startSlot := uint64(state.NetworkDetails.IntervalStart.Sub(genesisTime).Seconds()) / state.BeaconConfig.SecondsPerSlot
+	startBlock, err := utils.FindLastBlockWithExecutionPayload(t.bc, startSlot)
+	if err != nil {
+		return fmt.Sprintf("couldn't find the Beacon block at the start of the interval (slot %d): %s", startSlot, err.Error())
+	}
+
+	storageAddress := common.HexToAddress(t.cfg.Smartnode.GetStorageAddress())
+	_, err = t.ec.BalanceAt(context.Background(), storageAddress, big.NewInt(0).SetUint64(startBlock.ExecutionBlockNumber))
+	if err != nil {
+		if t.cfg.Smartnode.ArchiveECUrl.Value.(string) == "" {
+			return fmt.Sprintf("the state of EL block %d at the start of the interval isn't available, and no Archive-Mode EC is set: %s", startBlock.ExecutionBlockNumber, err.Error())
+		}
+		return fmt.Sprintf("the state of EL block %d at the start of the interval isn't available from your clients or the Archive-Mode EC: %s", startBlock.ExecutionBlockNumber, err.Error())
+	}
+	return ""
+}
+
+// Make sure the node wallet can pay for the submissions around the end of the interval
+func (t *checkIntervalReadiness) checkGasFunds() string {
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return fmt.Sprintf("couldn't load the node account: %s", err.Error())
+	}
+	balance, err := t.ec.BalanceAt(context.Background(), nodeAccount.Address, nil)
+	if err != nil {
+		return fmt.Sprintf("couldn't get the node wallet's balance: %s", err.Error())
+	}
+	gasPrice, err := t.ec.SuggestGasPrice(context.Background())
+	if err != nil {
+		return fmt.Sprintf("couldn't get the current gas price: %s", err.Error())
+	}
+
+	required := big.NewInt(0).SetUint64(readinessGasReserve)
+	required.Mul(required, gasPrice)
+	required.Mul(required, big.NewInt(readinessGasPriceMargin))
+	if balance.Cmp(required) < 0 {
+		return fmt.Sprintf("the node wallet has %.6f ETH, but should have at least %.6f ETH to cover the submissions at the current gas price", eth.WeiToEth(balance), eth.WeiToEth(required))
+	}
+	return ""
+}
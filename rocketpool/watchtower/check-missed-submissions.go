@@ -0,0 +1,253 @@
+package watchtower
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/utils"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	rewardsSnapshotSubmissionKey string = "rewards.snapshot.submitted.node"
+
+	// How long after the Oracle DAO reaches consensus without the node that it's still worth reporting, so restarts don't bring up old rounds
+	missedRoundReportWindow time.Duration = 24 * time.Hour
+)
+
+// A balances, prices, or rewards round that the Oracle DAO votes on
+type submissionRound struct {
+	roundType        string
+	id               uint64
+	startTime        time.Time
+	consensusReached bool
+	hasSubmitted     func(nodeAddress common.Address) (bool, error)
+}
+
+// Check missed submissions task
+type checkMissedSubmissions struct {
+	c        *cli.Context
+	log      log.ColorLogger
+	cfg      *config.RocketPoolConfig
+	w        *wallet.Wallet
+	rp       *rocketpool.RocketPool
+	bc       beacon.Client
+	reported map[string]bool
+}
+
+// Create check missed submissions task
+func newCheckMissedSubmissions(c *cli.Context, logger log.ColorLogger) (*checkMissedSubmissions, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkMissedSubmissions{
+		c:        c,
+		log:      logger,
+		cfg:      cfg,
+		w:        w,
+		rp:       rp,
+		bc:       bc,
+		reported: map[string]bool{},
+	}, nil
+
+}
+
+// Check whether the other Oracle DAO members are close to consensus on a round this node hasn't submitted yet.
+// That means the other members' watchtowers are working but this one isn't, so the operator needs to step in before the round closes.
+func (t *checkMissedSubmissions) run(state *state.NetworkState) error {
+
+	// The balances and prices rounds are only on a fixed schedule after Houston
+	if !state.IsHoustonDeployed {
+		return nil
+	}
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the other members and the number of submissions needed for consensus
+	var joinedTime time.Time
+	peers := []common.Address{}
+	for _, member := range state.OracleDaoMemberDetails {
+		if !member.Exists {
+			continue
+		}
+		if member.Address == nodeAccount.Address {
+			joinedTime = member.JoinedTime
+			continue
+		}
+		peers = append(peers, member.Address)
+	}
+	threshold, err := protocol.GetNodeConsensusThreshold(t.rp, nil)
+	if err != nil {
+		return fmt.Errorf("error getting the Oracle DAO consensus threshold: %w", err)
+	}
+	quorum := int(math.Ceil(threshold * float64(len(peers)+1)))
+
+	// Check each round
+	rounds, err := t.getRounds(state)
+	if err != nil {
+		return err
+	}
+	lag := time.Duration(t.cfg.Alertmanager.MissedSubmissionLag.Value.(uint64)) * time.Minute
+	for _, round := range rounds {
+		key := fmt.Sprintf("%s-%d", round.roundType, round.id)
+		if t.reported[key] || time.Since(round.startTime) < lag || round.startTime.Before(joinedTime) {
+			continue
+		}
+		if round.consensusReached && time.Since(round.startTime) > missedRoundReportWindow {
+			continue
+		}
+
+		submitted, err := round.hasSubmitted(nodeAccount.Address)
+		if err != nil {
+			return fmt.Errorf("error checking the node's %s submission: %w", round.roundType, err)
+		}
+		if submitted {
+			continue
+		}
+
+		// Count the other members' submissions
+		peerCount := 0
+		if !round.consensusReached {
+			for _, peer := range peers {
+				submitted, err := round.hasSubmitted(peer)
+				if err != nil {
+					return fmt.Errorf("error checking the %s submission for member %s: %w", round.roundType, peer.Hex(), err)
+				}
+				if submitted {
+					peerCount++
+				}
+			}
+			if peerCount+1 < quorum {
+				continue
+			}
+		}
+
+		// Report it
+		if round.consensusReached {
+			t.log.Printlnf("WARNING: the Oracle DAO reached consensus on the %s round (%d) without this node's submission.", round.roundType, round.id)
+		} else {
+			t.log.Printlnf("WARNING: %d of the other %d Oracle DAO members have submitted the %s round (%d), which started %s ago, but this node hasn't; %d submissions are needed for consensus.", peerCount, len(peers), round.roundType, round.id, time.Since(round.startTime).Round(time.Minute), quorum)
+		}
+		alerting.AlertMissedOdaoSubmission(t.cfg, round.roundType, round.id, peerCount, quorum, round.consensusReached)
+		t.reported[key] = true
+	}
+
+	// Return
+	return nil
+
+}
+
+// Get the latest balances, prices, and rewards rounds
+func (t *checkMissedSubmissions) getRounds(state *state.NetworkState) ([]submissionRound, error) {
+	rounds := []submissionRound{}
+
+	// Get the target block of the current balances and prices rounds, following the same schedule as the submission tasks
+	if state.NetworkDetails.SubmitBalancesEnabled || state.NetworkDetails.SubmitPricesEnabled {
+		latestEth1Block, err := t.rp.Client.HeaderByNumber(context.Background(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("Can't get the latest block time: %w", err)
+		}
+		referenceTimestamp := t.cfg.Smartnode.PriceBalanceSubmissionReferenceTimestamp.Value.(int64)
+		submissionTimestamp, err := utils.FindNextSubmissionTimestamp(int64(latestEth1Block.Time), referenceTimestamp, int64(state.NetworkDetails.PricesSubmissionFrequency))
+		if err != nil {
+			return nil, err
+		}
+		slotNumber := uint64(submissionTimestamp-int64(state.BeaconConfig.GenesisTime)) / state.BeaconConfig.SecondsPerSlot
+		targetBlock, err := utils.FindLastBlockWithExecutionPayload(t.bc, slotNumber)
+		if err != nil {
+			return nil, err
+		}
+		targetBlockNumber := targetBlock.ExecutionBlockNumber
+		startTime := time.Unix(submissionTimestamp, 0)
+
+		balancesBlock := state.NetworkDetails.BalancesBlock.Uint64()
+		if state.NetworkDetails.SubmitBalancesEnabled && targetBlockNumber >= balancesBlock {
+			rounds = append(rounds, submissionRound{
+				roundType:        "balances",
+				id:               targetBlockNumber,
+				startTime:        startTime,
+				consensusReached: targetBlockNumber == balancesBlock,
+				hasSubmitted: func(nodeAddress common.Address) (bool, error) {
+					return t.rp.RocketStorage.GetBool(nil, crypto.Keccak256Hash([]byte(networkBalanceSubmissionKey), nodeAddress.Bytes(), getUint256Bytes(targetBlockNumber)))
+				},
+			})
+		}
+		if state.NetworkDetails.SubmitPricesEnabled && targetBlockNumber >= state.NetworkDetails.PricesBlock {
+			rounds = append(rounds, submissionRound{
+				roundType:        "prices",
+				id:               targetBlockNumber,
+				startTime:        startTime,
+				consensusReached: targetBlockNumber == state.NetworkDetails.PricesBlock,
+				hasSubmitted: func(nodeAddress common.Address) (bool, error) {
+					return t.rp.RocketStorage.GetBool(nil, crypto.Keccak256Hash([]byte(SubmissionKey), nodeAddress.Bytes(), getUint256Bytes(targetBlockNumber), getUint256Bytes(uint64(submissionTimestamp))))
+				},
+			})
+		}
+	}
+
+	// Get the rewards interval being voted on, or the one that just reached consensus
+	rewardsRound := submissionRound{
+		roundType: "rewards",
+		id:        state.NetworkDetails.RewardIndex,
+		startTime: state.NetworkDetails.IntervalStart.Add(state.NetworkDetails.IntervalDuration),
+	}
+	if time.Now().Before(rewardsRound.startTime) {
+		if rewardsRound.id == 0 {
+			return rounds, nil
+		}
+		rewardsRound.id--
+		rewardsRound.startTime = state.NetworkDetails.IntervalStart
+		rewardsRound.consensusReached = true
+	}
+	index := rewardsRound.id
+	rewardsRound.hasSubmitted = func(nodeAddress common.Address) (bool, error) {
+		return t.rp.RocketStorage.GetBool(nil, crypto.Keccak256Hash([]byte(rewardsSnapshotSubmissionKey), nodeAddress.Bytes(), getUint256Bytes(index)))
+	}
+	rounds = append(rounds, rewardsRound)
+
+	return rounds, nil
+}
+
+// Get a number as a 32-byte big-endian word, the way the contracts hash it for their submission keys
+func getUint256Bytes(value uint64) []byte {
+	buffer := make([]byte, 32)
+	big.NewInt(0).SetUint64(value).FillBytes(buffer)
+	return buffer
+}
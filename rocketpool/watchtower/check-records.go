@@ -0,0 +1,108 @@
+package watchtower
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How often the rolling record checkpoints are checked
+const recordCheckInterval time.Duration = 6 * time.Hour
+
+// Check rolling records task
+type checkRecords struct {
+	log       log.ColorLogger
+	errLog    log.ColorLogger
+	cfg       *config.RocketPoolConfig
+	records   *submitRewardsTree_Rolling
+	lastCheck time.Time
+}
+
+// Create check rolling records task
+func newCheckRecords(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, records *submitRewardsTree_Rolling) (*checkRecords, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkRecords{
+		log:     logger,
+		errLog:  errorLogger,
+		cfg:     cfg,
+		records: records,
+	}, nil
+
+}
+
+// Re-hash the saved checkpoints and repair any problems, so a corrupted checkpoint is caught well before the next rewards submission needs it
+func (t *checkRecords) run() error {
+
+	if time.Since(t.lastCheck) < recordCheckInterval {
+		return nil
+	}
+
+	// Repairing and collecting blobs while the record is being updated could drop the checkpoint the update is building on,
+	// so wait for the update to finish and check again on a later loop
+	var result *rewards.RecordCheckResult
+	var err error
+	idle := t.records.runWhileIdle(func() {
+		t.log.Println("Checking the rolling record checkpoints...")
+		result, err = rewards.CheckRecords(t.cfg, true)
+	})
+	if !idle {
+		t.log.Println("A record update is in progress; the rolling record checkpoints will be checked once it's done.")
+		return nil
+	}
+	t.lastCheck = time.Now()
+	if err != nil {
+		return fmt.Errorf("error checking rolling records: %w", err)
+	}
+	if !result.HasProblems() {
+		t.log.Printlnf("All %d checkpoints are intact.", result.CheckpointCount)
+		return nil
+	}
+
+	for _, issue := range result.Issues {
+		if issue.Hash != "" {
+			t.log.Printlnf("WARNING: checkpoint for slot %d of interval %d (blob %s) failed the check: %s", issue.Slot, issue.Interval, issue.Hash, issue.Problem)
+		} else {
+			t.log.Printlnf("WARNING: checkpoint for slot %d of interval %d failed the check: %s", issue.Slot, issue.Interval, issue.Problem)
+		}
+	}
+	if result.OutOfOrder {
+		t.log.Println("WARNING: a record manifest was out of order.")
+	}
+	if len(result.UnusedBlobs) > 0 {
+		t.log.Printlnf("Removed %d blobs that no checkpoint used.", len(result.UnusedBlobs))
+	}
+
+	// Only alert for problems that affect the checkpoints themselves
+	problemCount := len(result.Issues)
+	if result.OutOfOrder {
+		problemCount++
+	}
+	if problemCount == 0 {
+		return nil
+	}
+	if result.LatestLoadable {
+		t.log.Printlnf("Repaired the record manifests; the latest usable checkpoint is now for slot %d of interval %d.", result.LatestSlot, result.LatestInterval)
+	} else {
+		t.log.Println("Repaired the record manifests; none of the checkpoints were usable, so the record will be rebuilt from the start of the interval.")
+	}
+	err = alerting.AlertRecordsInconsistent(t.cfg, problemCount, result.Repaired)
+	if err != nil {
+		t.errLog.Printlnf("WARNING: couldn't send the record check alert: %s", err.Error())
+	}
+	return nil
+
+}
@@ -8,7 +8,9 @@ import (
 
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/types"
@@ -155,6 +157,9 @@ func (t *checkSoloMigrations) checkSoloMigrations(state *state.NetworkState) err
 	invalidCredentialsCount := float64(0)
 	balanceTooLowCount := float64(0)
 
+	// Scrub them together as one bundle if a bundle relay is set up
+	round := newTransactionRound(t.cfg, t.ec, &t.log)
+
 	// Go through each minipool
 	threshold := uint64(32000000000)
 	buffer := uint64(migrationBalanceBuffer * eth.WeiPerGwei)
@@ -174,14 +179,14 @@ func (t *checkSoloMigrations) checkSoloMigrations(state *state.NetworkState) err
 		// Scrub minipools that aren't seen on Beacon yet
 		validator := state.ValidatorDetails[mpd.Pubkey]
 		if !validator.Exists {
-			t.scrubVacantMinipool(mpd.MinipoolAddress, fmt.Sprintf("minipool %s (pubkey %s) did not exist on Beacon yet, but is required to be active_ongoing for migration", mpd.MinipoolAddress.Hex(), mpd.Pubkey.Hex()))
+			t.scrubVacantMinipool(round, mpd.MinipoolAddress, fmt.Sprintf("minipool %s (pubkey %s) did not exist on Beacon yet, but is required to be active_ongoing for migration", mpd.MinipoolAddress.Hex(), mpd.Pubkey.Hex()))
 			doesntExistCount += 1
 			continue
 		}
 
 		// Scrub minipools that are in the wrong state
 		if validator.Status != beacon.ValidatorState_ActiveOngoing {
-			t.scrubVacantMinipool(mpd.MinipoolAddress, fmt.Sprintf("minipool %s (pubkey %s) was in state %v, but is required to be active_ongoing for migration", mpd.MinipoolAddress.Hex(), mpd.Pubkey.Hex(), validator.Status))
+			t.scrubVacantMinipool(round, mpd.MinipoolAddress, fmt.Sprintf("minipool %s (pubkey %s) was in state %v, but is required to be active_ongoing for migration", mpd.MinipoolAddress.Hex(), mpd.Pubkey.Hex(), validator.Status))
 			invalidStateCount += 1
 			continue
 		}
@@ -193,19 +198,19 @@ func (t *checkSoloMigrations) checkSoloMigrations(state *state.NetworkState) err
 			creationTime := time.Unix(mpd.StatusTime.Int64(), 0)
 			remainingTime := creationTime.Add(scrubThreshold).Sub(blockTime)
 			if remainingTime < 0 {
-				t.scrubVacantMinipool(mpd.MinipoolAddress, fmt.Sprintf("minipool timed out (created %s, current time %s, scrubbed after %s)", creationTime, blockTime, scrubThreshold))
+				t.scrubVacantMinipool(round, mpd.MinipoolAddress, fmt.Sprintf("minipool timed out (created %s, current time %s, scrubbed after %s)", creationTime, blockTime, scrubThreshold))
 				timedOutCount += 1
 				continue
 			}
 			continue
 		case elPrefix:
 			if withdrawalCreds != mpd.WithdrawalCredentials {
-				t.scrubVacantMinipool(mpd.MinipoolAddress, fmt.Sprintf("withdrawal credentials do not match (expected %s, actual %s)", mpd.WithdrawalCredentials.Hex(), withdrawalCreds.Hex()))
+				t.scrubVacantMinipool(round, mpd.MinipoolAddress, fmt.Sprintf("withdrawal credentials do not match (expected %s, actual %s)", mpd.WithdrawalCredentials.Hex(), withdrawalCreds.Hex()))
 				invalidCredentialsCount += 1
 				continue
 			}
 		default:
-			t.scrubVacantMinipool(mpd.MinipoolAddress, fmt.Sprintf("unexpected prefix in withdrawal credentials: %s", withdrawalCreds.Hex()))
+			t.scrubVacantMinipool(round, mpd.MinipoolAddress, fmt.Sprintf("unexpected prefix in withdrawal credentials: %s", withdrawalCreds.Hex()))
 			invalidCredentialsCount += 1
 			continue
 		}
@@ -219,18 +224,23 @@ func (t *checkSoloMigrations) checkSoloMigrations(state *state.NetworkState) err
 		currentBalance += minipoolBalanceGwei
 
 		if currentBalance < threshold {
-			t.scrubVacantMinipool(mpd.MinipoolAddress, fmt.Sprintf("current balance of %d is lower than the threshold of %d", currentBalance, threshold))
+			t.scrubVacantMinipool(round, mpd.MinipoolAddress, fmt.Sprintf("current balance of %d is lower than the threshold of %d", currentBalance, threshold))
 			balanceTooLowCount += 1
 			continue
 		}
 		if currentBalance < (creationBalanceGwei - buffer) {
-			t.scrubVacantMinipool(mpd.MinipoolAddress, fmt.Sprintf("current balance of %d is lower than the creation balance of %d, and below the acceptable buffer threshold of %d", currentBalance, creationBalanceGwei, buffer))
+			t.scrubVacantMinipool(round, mpd.MinipoolAddress, fmt.Sprintf("current balance of %d is lower than the creation balance of %d, and below the acceptable buffer threshold of %d", currentBalance, creationBalanceGwei, buffer))
 			balanceTooLowCount += 1
 			continue
 		}
 
 	}
 
+	// Submit the scrubs
+	if err := round.submit(); err != nil {
+		t.printMessage(fmt.Sprintf("could not vote to scrub the minipools: %s", err.Error()))
+	}
+
 	// Update the metrics collector
 	if t.coll != nil {
 		t.coll.UpdateLock.Lock()
@@ -256,7 +266,7 @@ func (t *checkSoloMigrations) checkSoloMigrations(state *state.NetworkState) err
 }
 
 // Scrub a vacant minipool
-func (t *checkSoloMigrations) scrubVacantMinipool(address common.Address, reason string) {
+func (t *checkSoloMigrations) scrubVacantMinipool(round *transactionRound, address common.Address, reason string) {
 
 	// Log
 	t.printMessage("=== SCRUBBING SOLO MIGRATION ===")
@@ -296,23 +306,18 @@ func (t *checkSoloMigrations) scrubVacantMinipool(address common.Address, reason
 	opts.GasTipCap = eth.GweiToWei(utils.GetWatchtowerPrioFee(t.cfg))
 	opts.GasLimit = gasInfo.SafeGasLimit
 
-	// Cancel the reduction
-	hash, err := mp.VoteScrub(opts)
+	// Scrub the minipool
+	err = round.send(opts, fmt.Sprintf("the vote to scrub minipool %s", mp.GetAddress().Hex()), func(opts *bind.TransactOpts) (*ethtypes.Transaction, error) {
+		tx, err := mp.GetContract().Transact(opts, "voteScrub")
+		if err != nil {
+			return nil, fmt.Errorf("error voting to scrub minipool %s: %w", mp.GetAddress().Hex(), err)
+		}
+		return tx, nil
+	})
 	if err != nil {
 		t.printMessage(fmt.Sprintf("could not vote to scrub the minipool: %s", err.Error()))
-		return
 	}
 
-	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
-	if err != nil {
-		t.printMessage(fmt.Sprintf("error waiting for scrub transaction: %s", err.Error()))
-		return
-	}
-
-	// Log
-	t.log.Printlnf("Successfully voted to scrub minipool %s.", mp.GetAddress().Hex())
-
 }
 
 func (t *checkSoloMigrations) handleError(err error) {
@@ -0,0 +1,137 @@
+package collectors
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Represents the collector for the rolling record catch-up progress metrics
+type CatchUpCollector struct {
+
+	// Whether a record update is in progress
+	activeDesc *prometheus.Desc
+
+	// The latest epoch processed by the record update
+	currentEpochDesc *prometheus.Desc
+
+	// The epoch the record update is catching up to
+	targetEpochDesc *prometheus.Desc
+
+	// The number of epochs processed by the record update
+	epochsDoneDesc *prometheus.Desc
+
+	// The number of epochs left for the record update to process
+	epochsRemainingDesc *prometheus.Desc
+
+	// The number of epochs processed per minute
+	epochsPerMinuteDesc *prometheus.Desc
+
+	// The estimated time the record update will finish
+	etaDesc *prometheus.Desc
+
+	// The seconds spent waiting on the Beacon Node
+	beaconSecondsDesc *prometheus.Desc
+
+	// The seconds spent processing the Beacon Node's data
+	processingSecondsDesc *prometheus.Desc
+
+	// Counters
+	Active            float64
+	CurrentEpoch      float64
+	TargetEpoch       float64
+	EpochsDone        float64
+	EpochsRemaining   float64
+	EpochsPerMinute   float64
+	Eta               float64
+	BeaconSeconds     float64
+	ProcessingSeconds float64
+
+	// Mutex
+	UpdateLock *sync.Mutex
+}
+
+// Create a new CatchUpCollector instance
+func NewCatchUpCollector() *CatchUpCollector {
+	subsystem := "rolling_record_catch_up"
+	return &CatchUpCollector{
+		activeDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "active"),
+			"Whether a record update is in progress",
+			nil, nil,
+		),
+		currentEpochDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "current_epoch"),
+			"The latest epoch processed by the record update",
+			nil, nil,
+		),
+		targetEpochDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "target_epoch"),
+			"The epoch the record update is catching up to",
+			nil, nil,
+		),
+		epochsDoneDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "epochs_done"),
+			"The number of epochs processed by the record update",
+			nil, nil,
+		),
+		epochsRemainingDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "epochs_remaining"),
+			"The number of epochs left for the record update to process",
+			nil, nil,
+		),
+		epochsPerMinuteDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "epochs_per_minute"),
+			"The number of epochs processed per minute",
+			nil, nil,
+		),
+		etaDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "eta"),
+			"The estimated time the record update will finish",
+			nil, nil,
+		),
+		beaconSecondsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "beacon_seconds"),
+			"The seconds spent waiting on the Beacon Node",
+			nil, nil,
+		),
+		processingSecondsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "processing_seconds"),
+			"The seconds spent processing the Beacon Node's data",
+			nil, nil,
+		),
+		UpdateLock: &sync.Mutex{},
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *CatchUpCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.activeDesc
+	channel <- collector.currentEpochDesc
+	channel <- collector.targetEpochDesc
+	channel <- collector.epochsDoneDesc
+	channel <- collector.epochsRemainingDesc
+	channel <- collector.epochsPerMinuteDesc
+	channel <- collector.etaDesc
+	channel <- collector.beaconSecondsDesc
+	channel <- collector.processingSecondsDesc
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *CatchUpCollector) Collect(channel chan<- prometheus.Metric) {
+
+	// Sync
+	collector.UpdateLock.Lock()
+	defer collector.UpdateLock.Unlock()
+
+	// Update all of the metrics
+	channel <- prometheus.MustNewConstMetric(
+		collector.activeDesc, prometheus.GaugeValue, collector.Active)
+	channel <- prometheus.MustNewConstMetric(
+		collector.currentEpochDesc, prometheus.GaugeValue, collector.CurrentEpoch)
+	channel <- prometheus.MustNewConstMetric(
+		collector.targetEpochDesc, prometheus.GaugeValue, collector.TargetEpoch)
+	channel <- prometheus.MustNewConstMetric(
+		collector.epochsDoneDesc, prometheus.GaugeValue, collector.EpochsDone)
+	channel <- prometheus.MustNewConstMetric(
+		collector.epochsRemainingDesc, prometheus.GaugeValue, collector.EpochsRemaining)
+	channel <- prometheus.MustNewConstMetric(
+		collector.epochsPerMinuteDesc, prometheus.GaugeValue, collector.EpochsPerMinute)
+	channel <- prometheus.MustNewConstMetric(
+		collector.etaDesc, prometheus.GaugeValue, collector.Eta)
+	channel <- prometheus.MustNewConstMetric(
+		collector.beaconSecondsDesc, prometheus.GaugeValue, collector.BeaconSeconds)
+	channel <- prometheus.MustNewConstMetric(
+		collector.processingSecondsDesc, prometheus.GaugeValue, collector.ProcessingSeconds)
+}
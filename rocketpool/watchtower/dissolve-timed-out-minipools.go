@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	rptypes "github.com/rocket-pool/rocketpool-go/types"
@@ -89,12 +90,16 @@ func (t *dissolveTimedOutMinipools) run(state *state.NetworkState) error {
 	// Log
 	t.log.Printlnf("%d minipool(s) have timed out and will be dissolved...", len(minipools))
 
-	// Dissolve minipools
+	// Dissolve minipools, together as one bundle if a bundle relay is set up
+	round := newTransactionRound(t.cfg, t.ec, &t.log)
 	for _, mp := range minipools {
-		if err := t.dissolveMinipool(mp); err != nil {
+		if err := t.dissolveMinipool(round, mp); err != nil {
 			t.log.Println(fmt.Errorf("Could not dissolve minipool %s: %w", mp.GetAddress().Hex(), err))
 		}
 	}
+	if err := round.submit(); err != nil {
+		t.log.Println(fmt.Errorf("Could not dissolve minipools: %w", err))
+	}
 
 	// Return
 	return nil
@@ -133,7 +138,7 @@ func (t *dissolveTimedOutMinipools) getTimedOutMinipools(state *state.NetworkSta
 }
 
 // Dissolve a minipool
-func (t *dissolveTimedOutMinipools) dissolveMinipool(mp minipool.Minipool) error {
+func (t *dissolveTimedOutMinipools) dissolveMinipool(round *transactionRound, mp minipool.Minipool) error {
 
 	// Log
 	t.log.Printlnf("Dissolving minipool %s...", mp.GetAddress().Hex())
@@ -162,21 +167,12 @@ func (t *dissolveTimedOutMinipools) dissolveMinipool(mp minipool.Minipool) error
 	opts.GasLimit = gasInfo.SafeGasLimit
 
 	// Dissolve
-	hash, err := mp.Dissolve(opts)
-	if err != nil {
-		return err
-	}
-
-	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
-	if err != nil {
-		return err
-	}
-
-	// Log
-	t.log.Printlnf("Successfully dissolved minipool %s.", mp.GetAddress().Hex())
-
-	// Return
-	return nil
+	return round.send(opts, fmt.Sprintf("the dissolution of minipool %s", mp.GetAddress().Hex()), func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		tx, err := mp.GetContract().Transact(opts, "dissolve")
+		if err != nil {
+			return nil, fmt.Errorf("error dissolving minipool %s: %w", mp.GetAddress().Hex(), err)
+		}
+		return tx, nil
+	})
 
 }
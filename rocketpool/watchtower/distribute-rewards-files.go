@@ -0,0 +1,61 @@
+package watchtower
+
+import (
+	"os"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/mirror"
+	"github.com/rocket-pool/smartnode/shared/services/pinning"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Hands the rewards files to the distribution channels once their tree has been submitted
+type rewardsDistributor struct {
+	cfg       *config.RocketPoolConfig
+	pinMgr    *pinning.Manager
+	publisher *mirror.Publisher
+}
+
+// Create a new rewards file distributor
+func newRewardsDistributor(cfg *config.RocketPoolConfig, pinMgr *pinning.Manager, publisher *mirror.Publisher) *rewardsDistributor {
+	return &rewardsDistributor{
+		cfg:       cfg,
+		pinMgr:    pinMgr,
+		publisher: publisher,
+	}
+}
+
+// Pin and publish the compressed rewards tree and minipool performance file for an interval once its tree has been submitted
+func (d *rewardsDistributor) submitted(logger *log.ColorLogger, index uint64, cid string, header *rprewards.RewardsFileHeader) {
+
+	if d == nil || (!d.pinMgr.IsEnabled() && !d.publisher.IsEnabled()) {
+		return
+	}
+
+	rewardsTreePath := d.cfg.Smartnode.GetRewardsTreePath(index, true) + config.RewardsTreeIpfsExtension
+	d.distribute(logger, index, rewardsTreePath, cid)
+
+	// The minipool performance file is only compressed when its CID is part of the tree
+	minipoolPerformanceCid := header.MinipoolPerformanceFileCID
+	if minipoolPerformanceCid == "" || minipoolPerformanceCid == "---" {
+		return
+	}
+	minipoolPerformancePath := d.cfg.Smartnode.GetMinipoolPerformancePath(index, true) + config.RewardsTreeIpfsExtension
+	if _, err := os.Stat(minipoolPerformancePath); err != nil {
+		logger.Printlnf("WARNING: couldn't find the minipool performance file for interval %d to distribute: %s", index, err.Error())
+		return
+	}
+	d.distribute(logger, index, minipoolPerformancePath, minipoolPerformanceCid)
+
+}
+
+// Send a single file to each distribution channel
+func (d *rewardsDistributor) distribute(logger *log.ColorLogger, index uint64, path string, cid string) {
+	if err := d.pinMgr.Track(index, path, cid); err != nil {
+		logger.Printlnf("WARNING: couldn't start pinning %s: %s", path, err.Error())
+	}
+	if err := d.publisher.Publish(path, cid); err != nil {
+		logger.Printlnf("WARNING: couldn't publish %s: %s", path, err.Error())
+	}
+}
@@ -2,7 +2,10 @@ package watchtower
 
 import (
 	"fmt"
+	"math/big"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/rocket-pool/rocketpool-go/dao/protocol"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/types"
@@ -80,12 +83,16 @@ func (t *finalizePdaoProposals) run(state *state.NetworkState) error {
 	// Log
 	t.log.Printlnf("%d proposal(s) have been vetoed and will be finalized...", len(propIDs))
 
-	// Finalize proposals
+	// Finalize proposals, together as one bundle if a bundle relay is set up
+	round := newTransactionRound(t.cfg, t.ec, &t.log)
 	for _, propID := range propIDs {
-		if err := t.finalizeProposal(propID); err != nil {
+		if err := t.finalizeProposal(round, propID); err != nil {
 			t.log.Println(fmt.Errorf("Could not finalize proposal %d: %w", propID, err))
 		}
 	}
+	if err := round.submit(); err != nil {
+		t.log.Println(fmt.Errorf("Could not finalize proposals: %w", err))
+	}
 
 	// Return
 	return nil
@@ -103,7 +110,7 @@ func (t *finalizePdaoProposals) getFinalizableProposals(state *state.NetworkStat
 }
 
 // Dissolve a minipool
-func (t *finalizePdaoProposals) finalizeProposal(propID uint64) error {
+func (t *finalizePdaoProposals) finalizeProposal(round *transactionRound, propID uint64) error {
 
 	// Log
 	t.log.Printlnf("Finalizing proposal %d...", propID)
@@ -131,22 +138,17 @@ func (t *finalizePdaoProposals) finalizeProposal(propID uint64) error {
 	opts.GasTipCap = eth.GweiToWei(utils.GetWatchtowerPrioFee(t.cfg))
 	opts.GasLimit = gasInfo.SafeGasLimit
 
-	// Dissolve
-	hash, err := protocol.Finalize(t.rp, propID, opts)
-	if err != nil {
-		return err
-	}
-
-	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	// Finalize
+	rocketDAOProtocolProposal, err := t.rp.GetContract("rocketDAOProtocolProposal", nil)
 	if err != nil {
 		return err
 	}
-
-	// Log
-	t.log.Printlnf("Successfully finalized proposal %d.", propID)
-
-	// Return
-	return nil
+	return round.send(opts, fmt.Sprintf("the finalization of proposal %d", propID), func(opts *bind.TransactOpts) (*ethtypes.Transaction, error) {
+		tx, err := rocketDAOProtocolProposal.Transact(opts, "finalise", big.NewInt(int64(propID)))
+		if err != nil {
+			return nil, fmt.Errorf("error finalizing Protocol DAO proposal %d: %w", propID, err)
+		}
+		return tx, nil
+	})
 
 }
@@ -35,12 +35,13 @@ type generateRewardsTree struct {
 	rp        *rocketpool.RocketPool
 	ec        rocketpool.ExecutionClient
 	bc        beacon.Client
+	bg        *services.BackgroundTasks
 	lock      *sync.Mutex
 	isRunning bool
 }
 
 // Create generate rewards Merkle Tree task
-func newGenerateRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*generateRewardsTree, error) {
+func newGenerateRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, bg *services.BackgroundTasks) (*generateRewardsTree, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -69,6 +70,7 @@ func newGenerateRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger
 		ec:        ec,
 		bc:        bc,
 		rp:        rp,
+		bg:        bg,
 		lock:      lock,
 		isRunning: false,
 	}
@@ -123,7 +125,9 @@ func (t *generateRewardsTree) run() error {
 			t.lock.Lock()
 			t.isRunning = true
 			t.lock.Unlock()
-			go t.generateRewardsTree(index)
+			t.bg.Go(func(ctx context.Context) {
+				t.generateRewardsTree(ctx, index)
+			})
 
 			// Return after the first request, do others at other intervals
 			return nil
@@ -133,7 +137,7 @@ func (t *generateRewardsTree) run() error {
 	return nil
 }
 
-func (t *generateRewardsTree) generateRewardsTree(index uint64) {
+func (t *generateRewardsTree) generateRewardsTree(ctx context.Context, index uint64) {
 
 	// Begin generation of the tree
 	generationPrefix := fmt.Sprintf("[Interval %d Tree]", index)
@@ -148,7 +152,7 @@ func (t *generateRewardsTree) generateRewardsTree(index uint64) {
 	t.log.Printlnf("%s Found snapshot event: Beacon block %s, execution block %s", generationPrefix, rewardsEvent.ConsensusBlock.String(), rewardsEvent.ExecutionBlock.String())
 
 	// Get the EL block
-	elBlockHeader, err := t.ec.HeaderByNumber(context.Background(), rewardsEvent.ExecutionBlock)
+	elBlockHeader, err := t.ec.HeaderByNumber(ctx, rewardsEvent.ExecutionBlock)
 	if err != nil {
 		t.handleError(fmt.Errorf("%s Error getting execution block: %w", generationPrefix, err))
 		return
@@ -173,10 +177,7 @@ func (t *generateRewardsTree) generateRewardsTree(index uint64) {
 		// Check if an Archive EC is provided, and if using it would potentially resolve the error
 		errMessage := err.Error()
 		t.log.Printlnf("%s Error getting state for block %d: %s", generationPrefix, elBlockHeader.Number.Uint64(), errMessage)
-		if strings.Contains(errMessage, "missing trie node") || // Geth
-			strings.Contains(errMessage, "No state available for block") || // Nethermind
-			strings.Contains(errMessage, "Internal error") { // Besu
-			// TODO add Reth string
+		if services.IsMissingStateError(err) {
 
 			// The state was missing so fall back to the archive node
 			archiveEcUrl := t.cfg.Smartnode.ArchiveECUrl.Value.(string)
@@ -221,18 +222,19 @@ func (t *generateRewardsTree) generateRewardsTree(index uint64) {
 	}
 
 	// Get the state for the target slot
-	state, err := stateManager.GetStateForSlot(rewardsEvent.ConsensusBlock.Uint64())
+	state, err := stateManager.GetStateForSlot(ctx, rewardsEvent.ConsensusBlock.Uint64())
 	if err != nil {
+		t.requeueIfStopped(ctx, index)
 		t.handleError(fmt.Errorf("%s error getting state for beacon slot %d: %w", generationPrefix, rewardsEvent.ConsensusBlock.Uint64(), err))
 		return
 	}
 
 	// Generate the tree
-	t.generateRewardsTreeImpl(client, index, generationPrefix, rewardsEvent, elBlockHeader, state)
+	t.generateRewardsTreeImpl(ctx, client, index, generationPrefix, rewardsEvent, elBlockHeader, state)
 }
 
 // Implementation for rewards tree generation using a viable EC
-func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool, index uint64, generationPrefix string, rewardsEvent rewards.RewardsEvent, elBlockHeader *types.Header, state *state.NetworkState) {
+func (t *generateRewardsTree) generateRewardsTreeImpl(ctx context.Context, rp *rocketpool.RocketPool, index uint64, generationPrefix string, rewardsEvent rewards.RewardsEvent, elBlockHeader *types.Header, state *state.NetworkState) {
 
 	// Generate the rewards file
 	start := time.Now()
@@ -241,8 +243,9 @@ func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool,
 		t.handleError(fmt.Errorf("%s Error creating Merkle tree generator: %w", generationPrefix, err))
 		return
 	}
-	rewardsFile, err := treegen.GenerateTree()
+	rewardsFile, err := treegen.GenerateTree(ctx)
 	if err != nil {
+		t.requeueIfStopped(ctx, index)
 		t.handleError(fmt.Errorf("%s Error generating Merkle tree: %w", generationPrefix, err))
 		return
 	}
@@ -263,14 +266,24 @@ func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool,
 	// Create the JSON files
 	rewardsFile.SetMinipoolPerformanceFileCID("---")
 	t.log.Printlnf("%s Saving JSON files...", generationPrefix)
-	localMinipoolPerformanceFile := rprewards.NewLocalFile[rprewards.IMinipoolPerformanceFile](
+	localMinipoolPerformanceFile, err := rprewards.NewCompressedLocalFile[rprewards.IMinipoolPerformanceFile](
 		rewardsFile.GetMinipoolPerformanceFile(),
 		t.cfg.Smartnode.GetMinipoolPerformancePath(index, true),
+		t.cfg,
 	)
-	localRewardsFile := rprewards.NewLocalFile[rprewards.IRewardsFile](
+	if err != nil {
+		t.handleError(fmt.Errorf("%s %w", generationPrefix, err))
+		return
+	}
+	localRewardsFile, err := rprewards.NewCompressedLocalFile[rprewards.IRewardsFile](
 		rewardsFile,
 		t.cfg.Smartnode.GetRewardsTreePath(index, true),
+		t.cfg,
 	)
+	if err != nil {
+		t.handleError(fmt.Errorf("%s %w", generationPrefix, err))
+		return
+	}
 
 	// Write the files
 	err = localMinipoolPerformanceFile.Write()
@@ -291,6 +304,20 @@ func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool,
 
 }
 
+// Put the generation request back if it was interrupted by the daemon shutting down, so it starts over on the next run
+func (t *generateRewardsTree) requeueIfStopped(ctx context.Context, index uint64) {
+	if ctx.Err() == nil {
+		return
+	}
+	path := t.cfg.Smartnode.GetRegenerateRewardsTreeRequestPath(index, true)
+	err := os.WriteFile(path, []byte{}, 0644)
+	if err != nil {
+		t.errLog.Printlnf("WARNING: couldn't restore the generation request for interval %d: %s", index, err.Error())
+		return
+	}
+	t.log.Printlnf("Tree generation for interval %d was stopped; it will restart when the watchtower does.", index)
+}
+
 func (t *generateRewardsTree) handleError(err error) {
 	t.errLog.Println(err)
 	t.errLog.Println("*** Rewards tree generation failed. ***")
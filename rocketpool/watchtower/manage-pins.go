@@ -0,0 +1,43 @@
+package watchtower
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/pinning"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Manage pins task
+type managePins struct {
+	c      *cli.Context
+	log    log.ColorLogger
+	pinMgr *pinning.Manager
+}
+
+// Create manage pins task
+func newManagePins(c *cli.Context, logger log.ColorLogger, pinMgr *pinning.Manager) (*managePins, error) {
+
+	// Return task
+	return &managePins{
+		c:      c,
+		log:    logger,
+		pinMgr: pinMgr,
+	}, nil
+
+}
+
+// Upload and pin any new rewards files, and check that the older ones can still be retrieved
+func (t *managePins) run() error {
+
+	if !t.pinMgr.IsEnabled() {
+		return nil
+	}
+
+	if err := t.pinMgr.Run(); err != nil {
+		return fmt.Errorf("error checking the rewards file pins: %w", err)
+	}
+	return nil
+
+}
@@ -10,11 +10,12 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/metrics"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/urfave/cli"
 )
 
-func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *collectors.ScrubCollector, bondReductionCollector *collectors.BondReductionCollector, soloMigrationCollector *collectors.SoloMigrationCollector) error {
+func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *collectors.ScrubCollector, bondReductionCollector *collectors.BondReductionCollector, soloMigrationCollector *collectors.SoloMigrationCollector, catchUpCollector *collectors.CatchUpCollector) error {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -36,6 +37,17 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *co
 	registry.MustRegister(scrubCollector)
 	registry.MustRegister(bondReductionCollector)
 	registry.MustRegister(soloMigrationCollector)
+	registry.MustRegister(catchUpCollector)
+
+	// Start pushing the metrics to the remote endpoint if enabled
+	exporter, err := metrics.NewExporter(cfg, registry, "watchtower", logger)
+	if err != nil {
+		return fmt.Errorf("Error creating metrics exporter: %w", err)
+	}
+	if exporter != nil {
+		go exporter.Run()
+	}
+
 	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
 
 	// Start the HTTP server
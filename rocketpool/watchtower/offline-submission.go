@@ -0,0 +1,19 @@
+package watchtower
+
+import (
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Save the payload an online Oracle DAO watchtower needs to submit a tree that was generated on this machine
+func saveOfflineSubmission(logger *log.ColorLogger, cfg *config.RocketPoolConfig, index uint64, consensusBlock uint64, executionBlock uint64, header *rprewards.RewardsFileHeader, cid string, intervalsPassed uint64) error {
+	payload := rprewards.NewSubmissionPayload(index, consensusBlock, executionBlock, header, cid, intervalsPassed)
+	path := cfg.Smartnode.GetRewardsSubmissionPath(index, true)
+	if err := payload.Save(path); err != nil {
+		return err
+	}
+	logger.Printlnf("Saved the submission payload for interval %d to %s.", index, path)
+	logger.Println("Copy it to your Oracle DAO node along with the rewards files, and run `rocketpool odao import-rewards-tree` there to submit it.")
+	return nil
+}
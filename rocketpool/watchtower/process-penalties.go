@@ -29,6 +29,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
@@ -173,7 +174,7 @@ func (s *penaltyState) saveState(path string) error {
 	if err != nil {
 		return fmt.Errorf("error creating watchtower directory: %w", err)
 	}
-	return os.WriteFile(path, data, 0644)
+	return files.WriteFileAtomic(path, data, 0644)
 }
 
 // Process penalties
@@ -22,10 +22,12 @@ import (
 
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/utils"
 	"github.com/rocket-pool/smartnode/shared/services"
+	rpbalances "github.com/rocket-pool/smartnode/shared/services/balances"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/submissions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
@@ -33,7 +35,8 @@ import (
 )
 
 const (
-	networkBalanceSubmissionKey string = "network.balances.submitted.node"
+	networkBalanceSubmissionKey  string = "network.balances.submitted.node"
+	balancesReportRetentionLimit int    = 100
 )
 
 // Submit network balances task
@@ -46,6 +49,8 @@ type submitNetworkBalances struct {
 	ec        rocketpool.ExecutionClient
 	rp        *rocketpool.RocketPool
 	bc        beacon.Client
+	bg        *services.BackgroundTasks
+	ledger    *submissions.Ledger
 	lock      *sync.Mutex
 	isRunning bool
 }
@@ -69,7 +74,7 @@ type minipoolBalanceDetails struct {
 }
 
 // Create submit network balances task
-func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*submitNetworkBalances, error) {
+func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, bg *services.BackgroundTasks, ledger *submissions.Ledger) (*submitNetworkBalances, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -104,6 +109,8 @@ func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogge
 		ec:        ec,
 		rp:        rp,
 		bc:        bc,
+		bg:        bg,
+		ledger:    ledger,
 		lock:      lock,
 		isRunning: false,
 	}, nil
@@ -205,7 +212,7 @@ func (t *submitNetworkBalances) run(state *state.NetworkState) error {
 		}
 		t.lock.Unlock()
 
-		go func() {
+		t.bg.Go(func(ctx context.Context) {
 			t.lock.Lock()
 			t.isRunning = true
 			t.lock.Unlock()
@@ -216,7 +223,7 @@ func (t *submitNetworkBalances) run(state *state.NetworkState) error {
 			t.log.Printlnf("Calculating network balances for block %d...", targetBlockNumber)
 
 			// Get network balances at block
-			balances, err := t.getNetworkBalances(targetBlockHeader, big.NewInt(int64(targetBlockNumber)), slotNumber, time.Unix(int64(targetBlockHeader.Time), 0))
+			balances, err := t.getNetworkBalances(ctx, targetBlockHeader, big.NewInt(int64(targetBlockNumber)), slotNumber, time.Unix(int64(targetBlockHeader.Time), 0))
 			if err != nil {
 				t.handleError(fmt.Errorf("%s %w", logPrefix, err))
 				return
@@ -232,8 +239,11 @@ func (t *submitNetworkBalances) run(state *state.NetworkState) error {
 			t.log.Printlnf("rETH contract balance: %s wei", balances.RETHContract.String())
 			t.log.Printlnf("rETH token supply: %s wei", balances.RETHSupply.String())
 
-			// Check if we have reported these specific values before
+			// Save a report of what changed since the last submission
 			balances.SlotTimestamp = uint64(nextSubmissionTime.Unix())
+			t.createBalancesReport(balances, state)
+
+			// Check if we have reported these specific values before
 			hasSubmittedSpecific, err := t.hasSubmittedSpecificBlockBalances(nodeAccount.Address, targetBlockNumber, balances)
 			if err != nil {
 				t.handleError(fmt.Errorf("%s %w", logPrefix, err))
@@ -273,7 +283,7 @@ func (t *submitNetworkBalances) run(state *state.NetworkState) error {
 			t.lock.Lock()
 			t.isRunning = false
 			t.lock.Unlock()
-		}()
+		})
 	} else { // Houston still not deployed, using legacy submission
 		// Get block to submit balances for
 		blockNumber := state.NetworkDetails.LatestReportableBalancesBlock
@@ -318,7 +328,7 @@ func (t *submitNetworkBalances) run(state *state.NetworkState) error {
 		}
 		t.lock.Unlock()
 
-		go func() {
+		t.bg.Go(func(ctx context.Context) {
 			t.lock.Lock()
 			t.isRunning = true
 			t.lock.Unlock()
@@ -329,7 +339,7 @@ func (t *submitNetworkBalances) run(state *state.NetworkState) error {
 			t.log.Printlnf("Calculating network balances for block %d...", blockNumber)
 
 			// Get network balances at block
-			balances, err := t.getNetworkBalances(header, blockNumberBig, slotNumber, blockTime)
+			balances, err := t.getNetworkBalances(ctx, header, blockNumberBig, slotNumber, blockTime)
 			if err != nil {
 				t.handleError(fmt.Errorf("%s %w", logPrefix, err))
 				return
@@ -345,6 +355,9 @@ func (t *submitNetworkBalances) run(state *state.NetworkState) error {
 			t.log.Printlnf("rETH contract balance: %s wei", balances.RETHContract.String())
 			t.log.Printlnf("rETH token supply: %s wei", balances.RETHSupply.String())
 
+			// Save a report of what changed since the last submission
+			t.createBalancesReport(balances, state)
+
 			// Check if we have reported these specific values before
 			hasSubmittedSpecific, err := t.hasSubmittedSpecificBlockBalances(nodeAccount.Address, blockNumber, balances)
 			if err != nil {
@@ -382,7 +395,7 @@ func (t *submitNetworkBalances) run(state *state.NetworkState) error {
 			t.lock.Lock()
 			t.isRunning = false
 			t.lock.Unlock()
-		}()
+		})
 	}
 	// Return
 	return nil
@@ -437,13 +450,63 @@ func (t *submitNetworkBalances) hasSubmittedSpecificBlockBalances(nodeAddress co
 
 }
 
+// Creates a report of the changes between these balances and the previous submission, and saves it to disk
+func (t *submitNetworkBalances) createBalancesReport(balances networkBalances, state *state.NetworkState) {
+	reportFolder := t.cfg.Smartnode.GetBalancesReportsFolder(true)
+
+	// Get the previous report to compare against
+	var previous *rpbalances.Snapshot
+	previousReport, err := rpbalances.LoadLatestReport(reportFolder, balances.Block)
+	if err != nil {
+		t.log.Printlnf("WARNING: couldn't load the previous balances report: %s", err.Error())
+	} else if previousReport != nil {
+		previous = &previousReport.Current
+	}
+
+	// Create the report
+	current := rpbalances.Snapshot{
+		Block:                 balances.Block,
+		SlotTimestamp:         balances.SlotTimestamp,
+		DepositPool:           balances.DepositPool,
+		NodeCreditBalance:     balances.NodeCreditBalance,
+		MinipoolsTotal:        balances.MinipoolsTotal,
+		MinipoolsStaking:      balances.MinipoolsStaking,
+		DistributorShareTotal: balances.DistributorShareTotal,
+		SmoothingPoolShare:    balances.SmoothingPoolShare,
+		RETHContract:          balances.RETHContract,
+		RETHSupply:            balances.RETHSupply,
+	}
+	onChain := rpbalances.OnChainBalances{
+		Block:      state.NetworkDetails.BalancesBlock.Uint64(),
+		TotalETH:   state.NetworkDetails.TotalETHBalance,
+		StakingETH: state.NetworkDetails.StakingETHBalance,
+		RETHSupply: state.NetworkDetails.TotalRETHSupply,
+	}
+	report := rpbalances.NewReport(current, previous, onChain)
+	t.log.Println(report.String())
+
+	// Save it
+	path, err := report.Save(reportFolder)
+	if err != nil {
+		t.log.Printlnf("WARNING: couldn't save the balances report: %s", err.Error())
+		return
+	}
+	t.log.Printlnf("Saved balances report to %s.", path)
+
+	// Prune old reports
+	err = rpbalances.PruneReports(reportFolder, balancesReportRetentionLimit)
+	if err != nil {
+		t.log.Printlnf("WARNING: couldn't prune old balances reports: %s", err.Error())
+	}
+}
+
 // Prints a message to the log
 func (t *submitNetworkBalances) printMessage(message string) {
 	t.log.Println(message)
 }
 
 // Get the network balances at a specific block
-func (t *submitNetworkBalances) getNetworkBalances(elBlockHeader *types.Header, elBlock *big.Int, beaconBlock uint64, slotTime time.Time) (networkBalances, error) {
+func (t *submitNetworkBalances) getNetworkBalances(ctx context.Context, elBlockHeader *types.Header, elBlock *big.Int, beaconBlock uint64, slotTime time.Time) (networkBalances, error) {
 
 	// Get a client with the block number available
 	client, err := eth1.GetBestApiClient(t.rp, t.cfg, t.printMessage, elBlock)
@@ -458,7 +521,7 @@ func (t *submitNetworkBalances) getNetworkBalances(elBlockHeader *types.Header,
 	}
 
 	// Create a new state for the target block
-	state, err := mgr.GetStateForSlot(beaconBlock)
+	state, err := mgr.GetStateForSlot(ctx, beaconBlock)
 	if err != nil {
 		return networkBalances{}, fmt.Errorf("couldn't get network state for EL block %s, Beacon slot %d: %w", elBlock, beaconBlock, err)
 	}
@@ -514,7 +577,7 @@ func (t *submitNetworkBalances) getNetworkBalances(elBlockHeader *types.Header,
 		if err != nil {
 			return fmt.Errorf("error creating merkle tree generator to approximate share of smoothing pool: %w", err)
 		}
-		smoothingPoolShare, err = treegen.ApproximateStakerShareOfSmoothingPool()
+		smoothingPoolShare, err = treegen.ApproximateStakerShareOfSmoothingPool(ctx)
 		if err != nil {
 			return fmt.Errorf("error getting approximate share of smoothing pool: %w", err)
 		}
@@ -646,6 +709,17 @@ func (t *submitNetworkBalances) submitBalances(balances networkBalances, isHoust
 	t.log.Printlnf("Total ETH = %s\n", totalEth)
 	t.log.Printlnf("Calculated ratio = %.6f\n", ratio)
 
+	// Make sure this doesn't repeat or conflict with an earlier submission
+	payloadHash := submissions.HashPayload(balances.Block, balances.SlotTimestamp, totalEth, balances.MinipoolsStaking, balances.RETHSupply)
+	canSubmit, reason, err := t.ledger.CheckSubmission(t.rp.Client, submissions.Kind_Balances, balances.Block, payloadHash)
+	if err != nil {
+		return fmt.Errorf("error checking the submission ledger: %w", err)
+	}
+	if !canSubmit {
+		t.log.Printlnf("Not submitting network balances for block %d because %s.", balances.Block, reason)
+		return nil
+	}
+
 	// Log
 	t.log.Printlnf("Submitting network balances for block %d...", balances.Block)
 
@@ -701,9 +775,15 @@ func (t *submitNetworkBalances) submitBalances(balances networkBalances, isHoust
 			return fmt.Errorf("error submitting balances: %w", err)
 		}
 	}
+	if err := t.ledger.RecordSubmission(submissions.Kind_Balances, balances.Block, payloadHash, hash); err != nil {
+		t.log.Printlnf("WARNING: couldn't record the submission: %s", err.Error())
+	}
 
 	// Print TX info and wait for it to be included in a block
 	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	if ledgerErr := t.ledger.RecordResult(t.rp.Client, hash, err); ledgerErr != nil {
+		t.log.Printlnf("WARNING: couldn't record the submission's result: %s", ledgerErr.Error())
+	}
 	if err != nil {
 		return fmt.Errorf("error waiting for transaction: %w", err)
 	}
@@ -18,12 +18,15 @@ import (
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/tokens"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/utils"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/heartbeat"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/submissions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
@@ -45,14 +48,23 @@ type submitRewardsTree_Rolling struct {
 	genesisTime time.Time
 	recordMgr   *rprewards.RollingRecordManager
 	stateMgr    *state.NetworkStateManager
+	bg          *services.BackgroundTasks
+	distributor *rewardsDistributor
+	ledger      *submissions.Ledger
 	logPrefix   string
 
+	// Prometheus
+	catchUpCollector *collectors.CatchUpCollector
+
+	// The external uptime monitor for record updates
+	heartbeat *heartbeat.Heartbeat
+
 	lock      *sync.Mutex
 	isRunning bool
 }
 
 // Create submit rewards tree with rolling record support
-func newSubmitRewardsTree_Rolling(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, stateMgr *state.NetworkStateManager) (*submitRewardsTree_Rolling, error) {
+func newSubmitRewardsTree_Rolling(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, stateMgr *state.NetworkStateManager, bg *services.BackgroundTasks, catchUpCollector *collectors.CatchUpCollector, distributor *rewardsDistributor, ledger *submissions.Ledger) (*submitRewardsTree_Rolling, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -126,17 +138,24 @@ func newSubmitRewardsTree_Rolling(c *cli.Context, logger log.ColorLogger, errorL
 		rp:          rp,
 		bc:          bc,
 		stateMgr:    stateMgr,
+		bg:          bg,
+		distributor: distributor,
+		ledger:      ledger,
 		genesisTime: genesisTime,
 		logPrefix:   logPrefix,
 		lock:        lock,
 		isRunning:   false,
+
+		catchUpCollector: catchUpCollector,
 	}
+	task.heartbeat = heartbeat.NewHeartbeat(cfg, cfg.Heartbeat.RollingRecordUrl.Value.(string), &task.log)
 
 	// Make a new rolling manager
 	recordMgr, err := rprewards.NewRollingRecordManager(&task.log, &task.errLog, cfg, rp, bc, stateMgr, startSlot, beaconCfg, currentIndex)
 	if err != nil {
 		return nil, fmt.Errorf("error creating rolling record manager: %w", err)
 	}
+	recordMgr.SetCatchUpProgressHandler(task.updateCatchUpProgress)
 
 	// Load the latest checkpoint
 	beaconHead, err := bc.GetBeaconHead()
@@ -155,6 +174,32 @@ func newSubmitRewardsTree_Rolling(c *cli.Context, logger log.ColorLogger, errorL
 
 }
 
+// Save the progress of a record update for the dashboard and update its metrics
+func (t *submitRewardsTree_Rolling) updateCatchUpProgress(progress rprewards.CatchUpProgress) {
+	err := rprewards.SaveCatchUpProgress(t.cfg.Smartnode.GetCatchUpProgressPath(true), progress)
+	if err != nil {
+		t.log.Printlnf("%s WARNING: couldn't save the catch-up progress: %s", t.logPrefix, err.Error())
+	}
+
+	t.catchUpCollector.UpdateLock.Lock()
+	defer t.catchUpCollector.UpdateLock.Unlock()
+	t.catchUpCollector.Active = 0
+	if progress.Active {
+		t.catchUpCollector.Active = 1
+	}
+	t.catchUpCollector.CurrentEpoch = float64(progress.CurrentEpoch)
+	t.catchUpCollector.TargetEpoch = float64(progress.TargetEpoch)
+	t.catchUpCollector.EpochsDone = float64(progress.EpochsDone)
+	t.catchUpCollector.EpochsRemaining = float64(progress.EpochsRemaining)
+	t.catchUpCollector.EpochsPerMinute = progress.EpochsPerMinute
+	t.catchUpCollector.Eta = 0
+	if !progress.Eta.IsZero() {
+		t.catchUpCollector.Eta = float64(progress.Eta.Unix())
+	}
+	t.catchUpCollector.BeaconSeconds = progress.BeaconTime.Seconds()
+	t.catchUpCollector.ProcessingSeconds = progress.ProcessingTime.Seconds()
+}
+
 // Update the rolling record and run the submission process if applicable
 func (t *submitRewardsTree_Rolling) run(headState *state.NetworkState) error {
 	// Wait for clients to sync
@@ -179,7 +224,7 @@ func (t *submitRewardsTree_Rolling) run(headState *state.NetworkState) error {
 	}
 	nodeAddress := nodeAccount.Address
 
-	go func() {
+	t.bg.Go(func(ctx context.Context) {
 		t.lock.Lock()
 		t.isRunning = true
 		t.lock.Unlock()
@@ -195,7 +240,7 @@ func (t *submitRewardsTree_Rolling) run(headState *state.NetworkState) error {
 			}
 
 			// Get the state of the network
-			headState, err = t.stateMgr.GetStateForSlot(latestBlock.Slot)
+			headState, err = t.stateMgr.GetStateForSlot(ctx, latestBlock.Slot)
 			if err != nil {
 				t.handleError(fmt.Errorf("error getting network state: %w", err))
 				return
@@ -228,11 +273,12 @@ func (t *submitRewardsTree_Rolling) run(headState *state.NetworkState) error {
 
 		// If no special upcoming state is required, update normally
 		if !isRewardsSubmissionDue {
-			err = t.recordMgr.UpdateRecordToState(headState, latestFinalizedBlock.Slot)
+			err = t.recordMgr.UpdateRecordToState(ctx, headState, latestFinalizedBlock.Slot)
 			if err != nil {
 				t.handleError(fmt.Errorf("error updating record: %w", err))
 				return
 			}
+			t.heartbeat.Success()
 
 			t.lock.Lock()
 			t.isRunning = false
@@ -288,7 +334,7 @@ func (t *submitRewardsTree_Rolling) run(headState *state.NetworkState) error {
 				t.handleError(fmt.Errorf("error creating state manager for rewards slot: %w", err))
 				return
 			}
-			state, err := stateMgr.GetStateForSlot(rewardsSlot)
+			state, err := stateMgr.GetStateForSlot(ctx, rewardsSlot)
 			if err != nil {
 				t.handleError(fmt.Errorf("error getting state for rewards slot: %w", err))
 				return
@@ -296,7 +342,7 @@ func (t *submitRewardsTree_Rolling) run(headState *state.NetworkState) error {
 
 			// Process the rewards interval
 			t.log.Printlnf("%s Running rewards interval submission.", t.logPrefix)
-			err = t.runRewardsIntervalReport(client, state, isInOdao, intervalsPassed, startTime, endTime, mustRegenerate, existingRewardsFile)
+			err = t.runRewardsIntervalReport(ctx, client, state, isInOdao, intervalsPassed, startTime, endTime, mustRegenerate, existingRewardsFile)
 			if err != nil {
 				t.handleError(fmt.Errorf("error running rewards interval report: %w", err))
 				return
@@ -304,11 +350,12 @@ func (t *submitRewardsTree_Rolling) run(headState *state.NetworkState) error {
 		} else {
 			t.log.Printlnf("%s Rewards submission for interval %d is due... waiting for epoch %d to be finalized (currently on epoch %d)", t.logPrefix, headState.NetworkDetails.RewardIndex, requiredRewardsEpoch, latestFinalizedEpoch)
 		}
+		t.heartbeat.Success()
 
 		t.lock.Lock()
 		t.isRunning = false
 		t.lock.Unlock()
-	}()
+	})
 
 	return nil
 }
@@ -318,10 +365,23 @@ func (t *submitRewardsTree_Rolling) printMessage(message string) {
 	t.log.Printlnf("%s %s", t.logPrefix, message)
 }
 
+// Run a function that touches the record store while no record update is in flight.
+// The lock is held while it runs, so an update can't start until it's done; returns false without running it if an update is already in flight.
+func (t *submitRewardsTree_Rolling) runWhileIdle(fn func()) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.isRunning {
+		return false
+	}
+	fn()
+	return true
+}
+
 // Print an error and unlock the mutex
 func (t *submitRewardsTree_Rolling) handleError(err error) {
 	t.errLog.Printlnf("%s %s", t.logPrefix, err.Error())
 	t.errLog.Println("*** Rolling Record processing failed. ***")
+	t.heartbeat.Failure(err)
 	t.lock.Lock()
 	t.isRunning = false
 	t.lock.Unlock()
@@ -461,9 +521,9 @@ func (t *submitRewardsTree_Rolling) isExistingRewardsFileValid(rewardIndex uint6
 }
 
 // Run a rewards interval report submission
-func (t *submitRewardsTree_Rolling) runRewardsIntervalReport(client *rocketpool.RocketPool, state *state.NetworkState, isInOdao bool, intervalsPassed uint64, startTime time.Time, endTime time.Time, mustRegenerate bool, existingRewardsFile *rprewards.LocalRewardsFile) error {
+func (t *submitRewardsTree_Rolling) runRewardsIntervalReport(ctx context.Context, client *rocketpool.RocketPool, state *state.NetworkState, isInOdao bool, intervalsPassed uint64, startTime time.Time, endTime time.Time, mustRegenerate bool, existingRewardsFile *rprewards.LocalRewardsFile) error {
 	// Prep the record for reporting
-	err := t.recordMgr.PrepareRecordForReport(state)
+	err := t.recordMgr.PrepareRecordForReport(ctx, state)
 	if err != nil {
 		return fmt.Errorf("error preparing record for report: %w", err)
 	}
@@ -512,11 +572,12 @@ func (t *submitRewardsTree_Rolling) runRewardsIntervalReport(client *rocketpool.
 		}
 
 		t.log.Printlnf("%s Successfully submitted rewards snapshot for interval %d.", t.logPrefix, currentIndex)
+		t.distributor.submitted(&t.log, currentIndex, cid.String(), existingRewardsFile.Impl().GetHeader())
 		return nil
 	}
 
 	// Generate the tree
-	err = t.generateTree(client, state, intervalsPassed, isInOdao, currentIndex, snapshotBeaconBlock, elBlockIndex, startTime, endTime, snapshotElBlockHeader, rewardsTreePath, compressedRewardsTreePath, minipoolPerformancePath, compressedMinipoolPerformancePath)
+	err = t.generateTree(ctx, client, state, intervalsPassed, isInOdao, currentIndex, snapshotBeaconBlock, elBlockIndex, startTime, endTime, snapshotElBlockHeader, rewardsTreePath, compressedRewardsTreePath, minipoolPerformancePath, compressedMinipoolPerformancePath)
 	if err != nil {
 		return fmt.Errorf("error generating rewards tree: %w", err)
 	}
@@ -525,7 +586,7 @@ func (t *submitRewardsTree_Rolling) runRewardsIntervalReport(client *rocketpool.
 }
 
 // Implementation for rewards tree generation using a viable EC
-func (t *submitRewardsTree_Rolling) generateTree(rp *rocketpool.RocketPool, state *state.NetworkState, intervalsPassed uint64, nodeTrusted bool, currentIndex uint64, snapshotBeaconBlock uint64, elBlockIndex uint64, startTime time.Time, endTime time.Time, snapshotElBlockHeader *types.Header, rewardsTreePath string, compressedRewardsTreePath string, minipoolPerformancePath string, compressedMinipoolPerformancePath string) error {
+func (t *submitRewardsTree_Rolling) generateTree(ctx context.Context, rp *rocketpool.RocketPool, state *state.NetworkState, intervalsPassed uint64, nodeTrusted bool, currentIndex uint64, snapshotBeaconBlock uint64, elBlockIndex uint64, startTime time.Time, endTime time.Time, snapshotElBlockHeader *types.Header, rewardsTreePath string, compressedRewardsTreePath string, minipoolPerformancePath string, compressedMinipoolPerformancePath string) error {
 
 	// Log
 	if intervalsPassed > 1 {
@@ -538,7 +599,7 @@ func (t *submitRewardsTree_Rolling) generateTree(rp *rocketpool.RocketPool, stat
 	if err != nil {
 		return fmt.Errorf("Error creating Merkle tree generator: %w", err)
 	}
-	rewardsFile, err := treegen.GenerateTree()
+	rewardsFile, err := treegen.GenerateTree(ctx)
 	if err != nil {
 		return fmt.Errorf("Error generating Merkle tree: %w", err)
 	}
@@ -547,16 +608,21 @@ func (t *submitRewardsTree_Rolling) generateTree(rp *rocketpool.RocketPool, stat
 	}
 
 	// Serialize the minipool performance file
-	localMinipoolPerformanceFile := rprewards.NewLocalFile[rprewards.IMinipoolPerformanceFile](
+	localMinipoolPerformanceFile, err := rprewards.NewCompressedLocalFile[rprewards.IMinipoolPerformanceFile](
 		rewardsFile.GetMinipoolPerformanceFile(),
 		minipoolPerformancePath,
+		t.cfg,
 	)
+	if err != nil {
+		return err
+	}
 	err = localMinipoolPerformanceFile.Write()
 	if err != nil {
 		return fmt.Errorf("Error serializing minipool performance file into JSON: %w", err)
 	}
 
-	if nodeTrusted {
+	offline := t.cfg.Smartnode.OfflineRewardsGeneration.Value == true
+	if nodeTrusted || offline {
 		minipoolPerformanceCid, err := localMinipoolPerformanceFile.CreateCompressedFileAndCid()
 		if err != nil {
 			return fmt.Errorf("Error getting the CID for file %s: %w", compressedMinipoolPerformancePath, err)
@@ -569,10 +635,14 @@ func (t *submitRewardsTree_Rolling) generateTree(rp *rocketpool.RocketPool, stat
 	}
 
 	// Serialize the rewards tree to JSON
-	localRewardsFile := rprewards.NewLocalFile[rprewards.IRewardsFile](
+	localRewardsFile, err := rprewards.NewCompressedLocalFile[rprewards.IRewardsFile](
 		rewardsFile,
 		rewardsTreePath,
+		t.cfg,
 	)
+	if err != nil {
+		return err
+	}
 	t.printMessage("Generation complete! Saving tree...")
 
 	// Write the rewards tree to disk
@@ -581,12 +651,18 @@ func (t *submitRewardsTree_Rolling) generateTree(rp *rocketpool.RocketPool, stat
 		return fmt.Errorf("Error saving rewards tree file to %s: %w", rewardsTreePath, err)
 	}
 
-	if nodeTrusted {
+	if nodeTrusted || offline {
 		cid, err := localRewardsFile.CreateCompressedFileAndCid()
 		if err != nil {
 			return fmt.Errorf("Error getting CID for file %s: %w", compressedRewardsTreePath, err)
 		}
 		t.printMessage(fmt.Sprintf("Calculated rewards tree CID: %s", cid))
+
+		// Leave the submission to the online watchtower
+		if offline {
+			return saveOfflineSubmission(&t.log, t.cfg, currentIndex, snapshotBeaconBlock, elBlockIndex, rewardsFile.GetHeader(), cid.String(), intervalsPassed)
+		}
+
 		// Submit to the contracts
 		err = t.submitRewardsSnapshot(big.NewInt(int64(currentIndex)), snapshotBeaconBlock, elBlockIndex, rewardsFile.GetHeader(), cid.String(), big.NewInt(int64(intervalsPassed)))
 		if err != nil {
@@ -594,6 +670,7 @@ func (t *submitRewardsTree_Rolling) generateTree(rp *rocketpool.RocketPool, stat
 		}
 
 		t.printMessage(fmt.Sprintf("Successfully submitted rewards snapshot for interval %d.", currentIndex))
+		t.distributor.submitted(&t.log, currentIndex, cid.String(), rewardsFile.GetHeader())
 	} else {
 		t.printMessage(fmt.Sprintf("Successfully generated rewards snapshot for interval %d.", currentIndex))
 	}
@@ -631,6 +708,17 @@ func (t *submitRewardsTree_Rolling) submitRewardsSnapshot(index *big.Int, consen
 		network++
 	}
 
+	// Make sure this doesn't repeat or conflict with an earlier submission
+	payloadHash := submissions.HashPayload(index, consensusBlock, executionBlock, treeRoot.Hex(), cid, intervalsPassed)
+	canSubmit, reason, err := t.ledger.CheckSubmission(t.rp.Client, submissions.Kind_RewardsTree, index.Uint64(), payloadHash)
+	if err != nil {
+		return fmt.Errorf("error checking the submission ledger: %w", err)
+	}
+	if !canSubmit {
+		t.log.Printlnf("Not submitting the rewards tree for interval %s because %s.", index.String(), reason)
+		return nil
+	}
+
 	// Get transactor
 	opts, err := t.w.GetNodeAccountTransactor()
 	if err != nil {
@@ -677,18 +765,23 @@ func (t *submitRewardsTree_Rolling) submitRewardsSnapshot(index *big.Int, consen
 	opts.GasTipCap = eth.GweiToWei(utils.GetWatchtowerPrioFee(t.cfg))
 	opts.GasLimit = gasInfo.SafeGasLimit
 
-	// Submit RPL price
-	hash, err := rewards.SubmitRewardSnapshot(t.rp, submission, opts)
+	// Submit the rewards snapshot, privately as a bundle if a bundle relay is set up
+	rocketRewardsPool, err := t.rp.GetContract("rocketRewardsPool", nil)
 	if err != nil {
 		return err
 	}
-
-	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	round := newTransactionRound(t.cfg, t.ec, &t.log)
+	err = round.sendToLedger(opts, fmt.Sprintf("the rewards tree for interval %s", index.String()), func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		tx, err := rocketRewardsPool.Transact(opts, "submitRewardSnapshot", submission)
+		if err != nil {
+			return nil, fmt.Errorf("error submitting rewards snapshot: %w", err)
+		}
+		return tx, nil
+	}, t.ledger, submissions.Kind_RewardsTree, index.Uint64(), payloadHash)
 	if err != nil {
 		return err
 	}
 
 	// Return
-	return nil
+	return round.submit()
 }
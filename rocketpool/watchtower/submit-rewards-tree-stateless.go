@@ -25,6 +25,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/submissions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -48,10 +49,13 @@ type submitRewardsTree_Stateless struct {
 	isRunning        bool
 	generationPrefix string
 	m                *state.NetworkStateManager
+	bg               *services.BackgroundTasks
+	distributor      *rewardsDistributor
+	ledger           *submissions.Ledger
 }
 
 // Create submit rewards Merkle Tree task
-func newSubmitRewardsTree_Stateless(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, m *state.NetworkStateManager) (*submitRewardsTree_Stateless, error) {
+func newSubmitRewardsTree_Stateless(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, m *state.NetworkStateManager, bg *services.BackgroundTasks, distributor *rewardsDistributor, ledger *submissions.Ledger) (*submitRewardsTree_Stateless, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -89,6 +93,9 @@ func newSubmitRewardsTree_Stateless(c *cli.Context, logger log.ColorLogger, erro
 		isRunning:        false,
 		generationPrefix: "[Merkle Tree]",
 		m:                m,
+		bg:               bg,
+		distributor:      distributor,
+		ledger:           ledger,
 	}
 
 	return generator, nil
@@ -117,7 +124,7 @@ func (t *submitRewardsTree_Stateless) Run(nodeTrusted bool, state *state.Network
 			return nil
 		} else {
 			// Create the state, since it's not done except for manual generators
-			state, err = t.m.GetStateForSlot(beaconSlot)
+			state, err = t.m.GetStateForSlot(t.bg.Context(), beaconSlot)
 			if err != nil {
 				return fmt.Errorf("error getting state for beacon slot %d: %w", beaconSlot, err)
 			}
@@ -227,6 +234,7 @@ func (t *submitRewardsTree_Stateless) Run(nodeTrusted bool, state *state.Network
 		}
 
 		t.log.Printlnf("Successfully submitted rewards snapshot for interval %d.", currentIndex)
+		t.distributor.submitted(t.log, currentIndex, cid.String(), proofWrapper.GetHeader())
 		return nil
 	}
 
@@ -282,7 +290,7 @@ func (t *submitRewardsTree_Stateless) isExistingRewardsFileValid(rewardsTreePath
 // Kick off the tree generation goroutine
 func (t *submitRewardsTree_Stateless) generateTree(intervalsPassed time.Duration, nodeTrusted bool, currentIndex uint64, snapshotBeaconBlock uint64, elBlockIndex uint64, startTime time.Time, endTime time.Time, snapshotElBlockHeader *types.Header, rewardsTreePath string, compressedRewardsTreePath string, minipoolPerformancePath string, compressedMinipoolPerformancePath string) {
 
-	go func() {
+	t.bg.Go(func(ctx context.Context) {
 		t.lock.Lock()
 		t.isRunning = true
 		t.lock.Unlock()
@@ -295,7 +303,7 @@ func (t *submitRewardsTree_Stateless) generateTree(intervalsPassed time.Duration
 		}
 
 		// Generate the tree
-		err = t.generateTreeImpl(client, intervalsPassed, nodeTrusted, currentIndex, snapshotBeaconBlock, elBlockIndex, startTime, endTime, snapshotElBlockHeader, rewardsTreePath, compressedRewardsTreePath, minipoolPerformancePath, compressedMinipoolPerformancePath)
+		err = t.generateTreeImpl(ctx, client, intervalsPassed, nodeTrusted, currentIndex, snapshotBeaconBlock, elBlockIndex, startTime, endTime, snapshotElBlockHeader, rewardsTreePath, compressedRewardsTreePath, minipoolPerformancePath, compressedMinipoolPerformancePath)
 		if err != nil {
 			t.handleError(err)
 		}
@@ -303,12 +311,12 @@ func (t *submitRewardsTree_Stateless) generateTree(intervalsPassed time.Duration
 		t.lock.Lock()
 		t.isRunning = false
 		t.lock.Unlock()
-	}()
+	})
 
 }
 
 // Implementation for rewards tree generation using a viable EC
-func (t *submitRewardsTree_Stateless) generateTreeImpl(rp *rocketpool.RocketPool, intervalsPassed time.Duration, nodeTrusted bool, currentIndex uint64, snapshotBeaconBlock uint64, elBlockIndex uint64, startTime time.Time, endTime time.Time, snapshotElBlockHeader *types.Header, rewardsTreePath string, compressedRewardsTreePath string, minipoolPerformancePath string, compressedMinipoolPerformancePath string) error {
+func (t *submitRewardsTree_Stateless) generateTreeImpl(ctx context.Context, rp *rocketpool.RocketPool, intervalsPassed time.Duration, nodeTrusted bool, currentIndex uint64, snapshotBeaconBlock uint64, elBlockIndex uint64, startTime time.Time, endTime time.Time, snapshotElBlockHeader *types.Header, rewardsTreePath string, compressedRewardsTreePath string, minipoolPerformancePath string, compressedMinipoolPerformancePath string) error {
 
 	// Log
 	if uint64(intervalsPassed) > 1 {
@@ -323,7 +331,7 @@ func (t *submitRewardsTree_Stateless) generateTreeImpl(rp *rocketpool.RocketPool
 	}
 
 	// Create a new state for the target block
-	state, err := mgr.GetStateForSlot(snapshotBeaconBlock)
+	state, err := mgr.GetStateForSlot(ctx, snapshotBeaconBlock)
 	if err != nil {
 		return fmt.Errorf("couldn't get network state for EL block %d, Beacon slot %d: %w", elBlockIndex, snapshotBeaconBlock, err)
 	}
@@ -333,7 +341,7 @@ func (t *submitRewardsTree_Stateless) generateTreeImpl(rp *rocketpool.RocketPool
 	if err != nil {
 		return fmt.Errorf("Error creating Merkle tree generator: %w", err)
 	}
-	rewardsFile, err := treegen.GenerateTree()
+	rewardsFile, err := treegen.GenerateTree(ctx)
 	if err != nil {
 		return fmt.Errorf("Error generating Merkle tree: %w", err)
 	}
@@ -342,10 +350,14 @@ func (t *submitRewardsTree_Stateless) generateTreeImpl(rp *rocketpool.RocketPool
 	}
 
 	// Serialize the minipool performance file
-	localMinipoolPerformanceFile := rprewards.NewLocalFile[rprewards.IMinipoolPerformanceFile](
+	localMinipoolPerformanceFile, err := rprewards.NewCompressedLocalFile[rprewards.IMinipoolPerformanceFile](
 		rewardsFile.GetMinipoolPerformanceFile(),
 		minipoolPerformancePath,
+		t.cfg,
 	)
+	if err != nil {
+		return err
+	}
 
 	// Write it to disk
 	err = localMinipoolPerformanceFile.Write()
@@ -353,7 +365,8 @@ func (t *submitRewardsTree_Stateless) generateTreeImpl(rp *rocketpool.RocketPool
 		return fmt.Errorf("Error saving minipool performance file to %s: %w", minipoolPerformancePath, err)
 	}
 
-	if nodeTrusted {
+	offline := t.cfg.Smartnode.OfflineRewardsGeneration.Value == true
+	if nodeTrusted || offline {
 		minipoolPerformanceCid, err := localMinipoolPerformanceFile.CreateCompressedFileAndCid()
 		if err != nil {
 			return fmt.Errorf("Error getting CID for file %s: %w", compressedMinipoolPerformancePath, err)
@@ -366,10 +379,14 @@ func (t *submitRewardsTree_Stateless) generateTreeImpl(rp *rocketpool.RocketPool
 	}
 
 	// Serialize the rewards tree to JSON
-	localRewardsFile := rprewards.NewLocalFile[rprewards.IRewardsFile](
+	localRewardsFile, err := rprewards.NewCompressedLocalFile[rprewards.IRewardsFile](
 		rewardsFile,
 		rewardsTreePath,
+		t.cfg,
 	)
+	if err != nil {
+		return err
+	}
 	t.printMessage("Generation complete! Saving tree...")
 
 	// Write the rewards tree to disk
@@ -378,7 +395,7 @@ func (t *submitRewardsTree_Stateless) generateTreeImpl(rp *rocketpool.RocketPool
 		return fmt.Errorf("Error saving rewards tree file to %s: %w", rewardsTreePath, err)
 	}
 
-	if nodeTrusted {
+	if nodeTrusted || offline {
 		// Save the compressed file and get the CID for it
 		cid, err := localRewardsFile.CreateCompressedFileAndCid()
 		if err != nil {
@@ -386,6 +403,11 @@ func (t *submitRewardsTree_Stateless) generateTreeImpl(rp *rocketpool.RocketPool
 		}
 		t.printMessage(fmt.Sprintf("Calculated rewards tree CID: %s", cid))
 
+		// Leave the submission to the online watchtower
+		if offline {
+			return saveOfflineSubmission(t.log, t.cfg, currentIndex, snapshotBeaconBlock, elBlockIndex, rewardsFile.GetHeader(), cid.String(), uint64(intervalsPassed))
+		}
+
 		// Submit to the contracts
 		err = t.submitRewardsSnapshot(big.NewInt(int64(currentIndex)), snapshotBeaconBlock, elBlockIndex, rewardsFile.GetHeader(), cid.String(), big.NewInt(int64(intervalsPassed)))
 		if err != nil {
@@ -393,6 +415,7 @@ func (t *submitRewardsTree_Stateless) generateTreeImpl(rp *rocketpool.RocketPool
 		}
 
 		t.printMessage(fmt.Sprintf("Successfully submitted rewards snapshot for interval %d.", currentIndex))
+		t.distributor.submitted(t.log, currentIndex, cid.String(), rewardsFile.GetHeader())
 	} else {
 		t.printMessage(fmt.Sprintf("Successfully generated rewards snapshot for interval %d.", currentIndex))
 	}
@@ -430,6 +453,17 @@ func (t *submitRewardsTree_Stateless) submitRewardsSnapshot(index *big.Int, cons
 		network++
 	}
 
+	// Make sure this doesn't repeat or conflict with an earlier submission
+	payloadHash := submissions.HashPayload(index, consensusBlock, executionBlock, treeRoot.Hex(), cid, intervalsPassed)
+	canSubmit, reason, err := t.ledger.CheckSubmission(t.rp.Client, submissions.Kind_RewardsTree, index.Uint64(), payloadHash)
+	if err != nil {
+		return fmt.Errorf("error checking the submission ledger: %w", err)
+	}
+	if !canSubmit {
+		t.log.Printlnf("Not submitting the rewards tree for interval %s because %s.", index.String(), reason)
+		return nil
+	}
+
 	// Get transactor
 	opts, err := t.w.GetNodeAccountTransactor()
 	if err != nil {
@@ -476,20 +510,25 @@ func (t *submitRewardsTree_Stateless) submitRewardsSnapshot(index *big.Int, cons
 	opts.GasTipCap = eth.GweiToWei(utils.GetWatchtowerPrioFee(t.cfg))
 	opts.GasLimit = gasInfo.SafeGasLimit
 
-	// Submit RPL price
-	hash, err := rewards.SubmitRewardSnapshot(t.rp, submission, opts)
+	// Submit the rewards snapshot, privately as a bundle if a bundle relay is set up
+	rocketRewardsPool, err := t.rp.GetContract("rocketRewardsPool", nil)
 	if err != nil {
 		return err
 	}
-
-	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	round := newTransactionRound(t.cfg, t.ec, t.log)
+	err = round.sendToLedger(opts, fmt.Sprintf("the rewards tree for interval %s", index.String()), func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		tx, err := rocketRewardsPool.Transact(opts, "submitRewardSnapshot", submission)
+		if err != nil {
+			return nil, fmt.Errorf("error submitting rewards snapshot: %w", err)
+		}
+		return tx, nil
+	}, t.ledger, submissions.Kind_RewardsTree, index.Uint64(), payloadHash)
 	if err != nil {
 		return err
 	}
 
 	// Return
-	return nil
+	return round.submit()
 }
 
 // Get the first finalized, successful consensus block that occurred after the given target time
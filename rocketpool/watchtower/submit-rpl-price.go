@@ -3,8 +3,10 @@ package watchtower
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"math/big"
+	"net/http"
 	"strings"
 	"sync"
 	"time"
@@ -14,6 +16,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
 	v120_network "github.com/rocket-pool/rocketpool-go/legacy/v1.2.0/network"
@@ -24,10 +27,12 @@ import (
 
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/utils"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/submissions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
@@ -233,6 +238,7 @@ const (
 	BlocksPerTurn uint64 = 75 // Approx. 15 minutes
 
 	twapNumberOfSeconds uint32 = 60 * 60 * 12 // 12 hours
+	rplPriceApiTimeout         = 10 * time.Second
 )
 
 type poolObserveResponse struct {
@@ -250,12 +256,13 @@ type submitRplPrice struct {
 	w         *wallet.Wallet
 	rp        *rocketpool.RocketPool
 	bc        beacon.Client
+	ledger    *submissions.Ledger
 	lock      *sync.Mutex
 	isRunning bool
 }
 
 // Create submit RPL price task
-func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*submitRplPrice, error) {
+func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, ledger *submissions.Ledger) (*submitRplPrice, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -290,6 +297,7 @@ func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.C
 		w:      w,
 		rp:     rp,
 		bc:     bc,
+		ledger: ledger,
 		lock:   lock,
 	}, nil
 
@@ -314,55 +322,65 @@ func (t *submitRplPrice) run(state *state.NetworkState) error {
 		return nil
 	}
 
+	// Submit the L2 rates together, as one bundle if a bundle relay is set up
+	round := newTransactionRound(t.cfg, t.ec, &t.log)
+
 	// Check if Optimism rate is stale and submit
-	err = t.submitOptimismPrice()
+	err = t.submitOptimismPrice(round)
 	if err != nil {
 		// Error is not fatal for this task so print and continue
 		t.log.Printlnf("Error submitting Optimism price: %s", err.Error())
 	}
 
 	// Check if Polygon rate is stale and submit
-	err = t.submitPolygonPrice()
+	err = t.submitPolygonPrice(round)
 	if err != nil {
 		// Error is not fatal for this task so print and continue
 		t.log.Printlnf("Error submitting Polygon price: %s", err.Error())
 	}
 
 	// Check if Arbitrum rate is stale and submit. This messenger will be deprecated soon.
-	err = t.submitArbitrumPrice(t.cfg.Smartnode.GetArbitrumMessengerAddress())
+	err = t.submitArbitrumPrice(round, t.cfg.Smartnode.GetArbitrumMessengerAddress())
 	if err != nil {
 		// Error is not fatal for this task so print and continue
 		t.log.Printlnf("Error submitting Arbitrum V1 price: %s", err.Error())
 	}
 
 	// Temporarily submit to both Arbitrum messengers until the first one sunsets
-	err = t.submitArbitrumPrice(t.cfg.Smartnode.GetArbitrumMessengerAddressV2())
+	err = t.submitArbitrumPrice(round, t.cfg.Smartnode.GetArbitrumMessengerAddressV2())
 	if err != nil {
 		// Error is not fatal for this task so print and continue
 		t.log.Printlnf("Error submitting Arbitrum price to messenger v2: %s", err.Error())
 	}
 
 	// Check if zkSync rate is stale and submit
-	err = t.submitZkSyncEraPrice()
+	err = t.submitZkSyncEraPrice(round)
 	if err != nil {
 		// Error is not fatal for this task so print and continue
 		t.log.Printlnf("Error submitting zkSync Era price: %s", err.Error())
 	}
 
 	// Check if Base rate is stale and submit
-	err = t.submitBasePrice()
+	err = t.submitBasePrice(round)
 	if err != nil {
 		// Error is not fatal for this task so print and continue
 		t.log.Printlnf("Error submitting Base price: %s", err.Error())
 	}
 
 	// Check if Scroll rate is stale and submit
-	err = t.submitScrollPrice()
+	err = t.submitScrollPrice(round)
 	if err != nil {
 		// Error is not fatal for this task so print and continue
 		t.log.Printlnf("Error submitting Scroll price: %s", err.Error())
 	}
 
+	// Submit the bundle, if there is one
+	err = round.submit()
+	if err != nil {
+		// Error is not fatal for this task so print and continue
+		t.log.Printlnf("Error submitting the L2 rates: %s", err.Error())
+	}
+
 	// Log
 	t.log.Println("Checking for RPL price checkpoint...")
 
@@ -445,6 +463,12 @@ func (t *submitRplPrice) run(state *state.NetworkState) error {
 			// Log
 			t.log.Printlnf("RPL price: %.6f ETH", mathutils.RoundDown(eth.WeiToEth(rplPrice), 6))
 
+			// Check the price against the secondary sources
+			if err := t.validateRplPrice(targetBlockNumber, rplPrice); err != nil {
+				t.handleError(fmt.Errorf("%s %w", logPrefix, err))
+				return
+			}
+
 			// Check if we have reported these specific values before
 			hasSubmittedSpecific, err := t.hasSubmittedSpecificBlockPrices(nodeAccount.Address, targetBlockNumber, uint64(submissionTimestamp), rplPrice, true)
 			if err != nil {
@@ -547,6 +571,12 @@ func (t *submitRplPrice) run(state *state.NetworkState) error {
 			// Log
 			t.log.Printlnf("RPL price: %.6f ETH", mathutils.RoundDown(eth.WeiToEth(rplPrice), 6))
 
+			// Check the price against the secondary sources
+			if err := t.validateRplPrice(blockNumber, rplPrice); err != nil {
+				t.handleError(fmt.Errorf("%s %w", logPrefix, err))
+				return
+			}
+
 			// Check if we have reported these specific values before
 			hasSubmittedSpecific, err := t.hasSubmittedSpecificBlockPrices(nodeAccount.Address, blockNumber, 0, rplPrice, false)
 			if err != nil {
@@ -636,17 +666,21 @@ func (t *submitRplPrice) hasSubmittedSpecificBlockPrices(nodeAddress common.Addr
 
 // Get RPL price via TWAP at block
 func (t *submitRplPrice) getRplTwap(blockNumber uint64) (*big.Int, error) {
+	poolAddress := t.cfg.Smartnode.GetRplTwapPoolAddress()
+	if poolAddress == "" {
+		return nil, fmt.Errorf("RPL TWAP pool contract not deployed on this network")
+	}
+	return t.getRplTwapFromPool(poolAddress, blockNumber)
+}
+
+// Get RPL price via the TWAP of the provided pool at block
+func (t *submitRplPrice) getRplTwapFromPool(poolAddress string, blockNumber uint64) (*big.Int, error) {
 
 	// Initialize call options
 	opts := &bind.CallOpts{
 		BlockNumber: big.NewInt(int64(blockNumber)),
 	}
 
-	poolAddress := t.cfg.Smartnode.GetRplTwapPoolAddress()
-	if poolAddress == "" {
-		return nil, fmt.Errorf("RPL TWAP pool contract not deployed on this network")
-	}
-
 	// Get a client with the block number available
 	client, err := eth1.GetBestApiClient(t.rp, t.cfg, t.printMessage, opts.BlockNumber)
 	if err != nil {
@@ -700,6 +734,119 @@ func (t *submitRplPrice) getRplTwap(blockNumber uint64) (*big.Int, error) {
 
 }
 
+// Compares the RPL price against the configured secondary sources, returning an error if any of them diverge too far
+func (t *submitRplPrice) validateRplPrice(blockNumber uint64, rplPrice *big.Int) error {
+	maxDivergence := t.cfg.Smartnode.RplPriceMaxDivergence.Value.(float64)
+	rplPriceEth := eth.WeiToEth(rplPrice)
+
+	// Get the prices from each secondary source
+	sourcePrices := map[string]*big.Int{}
+	secondaryPool := strings.TrimSpace(t.cfg.Smartnode.RplPriceSecondaryTwapPool.Value.(string))
+	if secondaryPool != "" {
+		if !common.IsHexAddress(secondaryPool) {
+			t.log.Printlnf("WARNING: secondary RPL price pool [%s] is not a valid address, skipping it", secondaryPool)
+		} else {
+			price, err := t.getRplTwapFromPool(secondaryPool, blockNumber)
+			if err != nil {
+				// Source failures are not fatal so the submission can't be blocked by an unavailable source
+				t.log.Printlnf("WARNING: could not get RPL price from the secondary pool: %s", err.Error())
+			} else {
+				sourcePrices["the secondary TWAP pool"] = price
+			}
+		}
+	}
+	apiUrl := strings.TrimSpace(t.cfg.Smartnode.RplPriceApiUrl.Value.(string))
+	if apiUrl != "" {
+		price, err := getRplPriceFromApi(apiUrl, t.cfg.Smartnode.RplPriceApiJsonPath.Value.(string))
+		if err != nil {
+			t.log.Printlnf("WARNING: could not get RPL price from the external API: %s", err.Error())
+		} else {
+			sourcePrices["the external price API"] = price
+		}
+	}
+
+	// Check the divergence of each one
+	for source, price := range sourcePrices {
+		divergence, err := utils.GetPriceDivergence(rplPrice, price)
+		if err != nil {
+			return err
+		}
+		sourcePriceEth := eth.WeiToEth(price)
+		t.log.Printlnf("RPL price from %s: %.6f ETH (%.2f%% divergence)", source, mathutils.RoundDown(sourcePriceEth, 6), divergence)
+		if divergence > maxDivergence {
+			alertErr := alerting.AlertRplPriceDivergence(t.cfg, blockNumber, source, rplPriceEth, sourcePriceEth, divergence)
+			if alertErr != nil {
+				t.log.Printlnf("WARNING: could not send RPL price divergence alert: %s", alertErr.Error())
+			}
+			return fmt.Errorf("RPL price %.6f ETH diverges from %s (%.6f ETH) by %.2f%%, which is more than the limit of %.2f%%; refusing to submit", rplPriceEth, source, sourcePriceEth, divergence, maxDivergence)
+		}
+	}
+
+	return nil
+}
+
+// Get the RPL price from an external API that returns it (in ETH) in a JSON response
+func getRplPriceFromApi(url string, jsonPath string) (*big.Int, error) {
+
+	// Send request
+	client := http.Client{
+		Timeout: rplPriceApiTimeout,
+	}
+	response, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	// Check the response code
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request failed with code %d", response.StatusCode)
+	}
+
+	// Deserialize the response
+	var value interface{}
+	decoder := json.NewDecoder(response.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("could not decode RPL price API response: %w", err)
+	}
+
+	// Walk the path to the price field
+	jsonPath = strings.TrimSpace(jsonPath)
+	if jsonPath != "" {
+		for _, key := range strings.Split(jsonPath, ".") {
+			obj, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("RPL price API response did not contain an object at [%s]", key)
+			}
+			value, ok = obj[key]
+			if !ok {
+				return nil, fmt.Errorf("RPL price API response did not contain the key [%s]", key)
+			}
+		}
+	}
+
+	// Parse the price
+	var priceString string
+	switch price := value.(type) {
+	case json.Number:
+		priceString = price.String()
+	case string:
+		priceString = price
+	default:
+		return nil, fmt.Errorf("RPL price API response had an unexpected value at [%s]: %v", jsonPath, value)
+	}
+	priceEth, ok := big.NewFloat(0).SetString(priceString)
+	if !ok || priceEth.Sign() <= 0 {
+		return nil, fmt.Errorf("RPL price API response [%s] is not a valid price", priceString)
+	}
+	priceWei, _ := priceEth.Mul(priceEth, big.NewFloat(eth.WeiPerEth)).Int(nil)
+	return priceWei, nil
+
+}
+
 func (t *submitRplPrice) printMessage(message string) {
 	t.log.Println(message)
 }
@@ -707,6 +854,17 @@ func (t *submitRplPrice) printMessage(message string) {
 // Submit RPL price and total effective RPL stake
 func (t *submitRplPrice) submitRplPrice(blockNumber uint64, slotTimestamp uint64, rplPrice *big.Int, isHoustonDeployed bool) error {
 
+	// Make sure this doesn't repeat or conflict with an earlier submission
+	payloadHash := submissions.HashPayload(blockNumber, slotTimestamp, rplPrice)
+	canSubmit, reason, err := t.ledger.CheckSubmission(t.rp.Client, submissions.Kind_Prices, blockNumber, payloadHash)
+	if err != nil {
+		return fmt.Errorf("error checking the submission ledger: %w", err)
+	}
+	if !canSubmit {
+		t.log.Printlnf("Not submitting the RPL price for block %d because %s.", blockNumber, reason)
+		return nil
+	}
+
 	// Log
 	t.log.Printlnf("Submitting RPL price for block %d...", blockNumber)
 
@@ -758,9 +916,15 @@ func (t *submitRplPrice) submitRplPrice(blockNumber uint64, slotTimestamp uint64
 			return err
 		}
 	}
+	if err := t.ledger.RecordSubmission(submissions.Kind_Prices, blockNumber, payloadHash, hash); err != nil {
+		t.log.Printlnf("WARNING: couldn't record the submission: %s", err.Error())
+	}
 
 	// Print TX info and wait for it to be included in a block
 	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	if ledgerErr := t.ledger.RecordResult(t.rp.Client, hash, err); ledgerErr != nil {
+		t.log.Printlnf("WARNING: couldn't record the submission's result: %s", ledgerErr.Error())
+	}
 	if err != nil {
 		return err
 	}
@@ -774,7 +938,7 @@ func (t *submitRplPrice) submitRplPrice(blockNumber uint64, slotTimestamp uint64
 }
 
 // Checks if Optimism rate is stale and if it's our turn to submit, calls submitRate on the messenger
-func (t *submitRplPrice) submitOptimismPrice() error {
+func (t *submitRplPrice) submitOptimismPrice(round *transactionRound) error {
 	priceMessengerAddress := t.cfg.Smartnode.GetOptimismMessengerAddress()
 
 	if priceMessengerAddress == "" {
@@ -894,27 +1058,20 @@ func (t *submitRplPrice) submitOptimismPrice() error {
 		t.log.Println("Submitting rate to Optimism...")
 
 		// Submit rates
-		tx, err := priceMessenger.Transact(opts, "submitRate")
+		err = round.send(opts, fmt.Sprintf("Optimism price for block %d", blockNumber), func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return priceMessenger.Transact(opts, "submitRate")
+		})
 		if err != nil {
 			return fmt.Errorf("Failed to submit rate: %q", err)
 		}
 
-		// Print TX info and wait for it to be included in a block
-		err = api.PrintAndWaitForTransaction(t.cfg, tx.Hash(), t.rp.Client, &t.log)
-		if err != nil {
-			return err
-		}
-
-		// Log
-		t.log.Printlnf("Successfully submitted Optimism price for block %d.", blockNumber)
-
 	}
 
 	return nil
 }
 
 // Checks if Polygon rate is stale and if it's our turn to submit, calls submitRate on the messenger
-func (t *submitRplPrice) submitPolygonPrice() error {
+func (t *submitRplPrice) submitPolygonPrice(round *transactionRound) error {
 	priceMessengerAddress := t.cfg.Smartnode.GetPolygonMessengerAddress()
 
 	if priceMessengerAddress == "" {
@@ -1034,27 +1191,20 @@ func (t *submitRplPrice) submitPolygonPrice() error {
 		t.log.Println("Submitting rate to Polygon...")
 
 		// Submit rates
-		tx, err := priceMessenger.Transact(opts, "submitRate")
+		err = round.send(opts, fmt.Sprintf("Polygon price for block %d", blockNumber), func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return priceMessenger.Transact(opts, "submitRate")
+		})
 		if err != nil {
 			return fmt.Errorf("Failed to submit rate to Polygon: %q", err)
 		}
 
-		// Print TX info and wait for it to be included in a block
-		err = api.PrintAndWaitForTransaction(t.cfg, tx.Hash(), t.rp.Client, &t.log)
-		if err != nil {
-			return err
-		}
-
-		// Log
-		t.log.Printlnf("Successfully submitted Polygon price for block %d.", blockNumber)
-
 	}
 
 	return nil
 }
 
 // Checks if Arbitrum rate is stale and if it's our turn to submit, calls submitRate on the messenger
-func (t *submitRplPrice) submitArbitrumPrice(priceMessengerAddress string) error {
+func (t *submitRplPrice) submitArbitrumPrice(round *transactionRound, priceMessengerAddress string) error {
 	if priceMessengerAddress == "" {
 		// No price messenger deployed on the current network
 		return nil
@@ -1197,27 +1347,20 @@ func (t *submitRplPrice) submitArbitrumPrice(priceMessengerAddress string) error
 		t.log.Println("Submitting rate to Arbitrum %s...", priceMessengerAddress)
 
 		// Submit rates
-		tx, err := priceMessenger.Transact(opts, "submitRate", maxSubmissionCost, arbitrumGasLimit, arbitrumMaxFeePerGas)
+		err = round.send(opts, fmt.Sprintf("Arbitrum price for block %d - messenger %s", blockNumber, priceMessengerAddress), func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return priceMessenger.Transact(opts, "submitRate", maxSubmissionCost, arbitrumGasLimit, arbitrumMaxFeePerGas)
+		})
 		if err != nil {
 			return fmt.Errorf("Failed to submit Arbitrum rate: %q", err)
 		}
 
-		// Print TX info and wait for it to be included in a block
-		err = api.PrintAndWaitForTransaction(t.cfg, tx.Hash(), t.rp.Client, &t.log)
-		if err != nil {
-			return err
-		}
-
-		// Log
-		t.log.Printlnf("Successfully submitted Arbitrum price for block %d - messenger %s.", blockNumber, priceMessengerAddress)
-
 	}
 
 	return nil
 }
 
 // Checks if zkSync Era rate is stale and if it's our turn to submit, calls submitRate on the messenger
-func (t *submitRplPrice) submitZkSyncEraPrice() error {
+func (t *submitRplPrice) submitZkSyncEraPrice(round *transactionRound) error {
 	priceMessengerAddress := t.cfg.Smartnode.GetZkSyncEraMessengerAddress()
 
 	if priceMessengerAddress == "" {
@@ -1355,27 +1498,20 @@ func (t *submitRplPrice) submitZkSyncEraPrice() error {
 		t.log.Println("Submitting rate to zkSync Era...")
 
 		// Submit rates
-		tx, err := priceMessenger.Transact(opts, "submitRate", l2GasLimit, gasPerPubdataByte)
+		err = round.send(opts, fmt.Sprintf("zkSync Era price for block %d", blockNumber), func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return priceMessenger.Transact(opts, "submitRate", l2GasLimit, gasPerPubdataByte)
+		})
 		if err != nil {
 			return fmt.Errorf("Failed to submit zkSync Era rate: %q", err)
 		}
 
-		// Print TX info and wait for it to be included in a block
-		err = api.PrintAndWaitForTransaction(t.cfg, tx.Hash(), t.rp.Client, &t.log)
-		if err != nil {
-			return err
-		}
-
-		// Log
-		t.log.Printlnf("Successfully submitted zkSync Era price for block %d.", blockNumber)
-
 	}
 
 	return nil
 }
 
 // Checks if Base rate is stale and if it's our turn to submit, calls submitRate on the messenger
-func (t *submitRplPrice) submitBasePrice() error {
+func (t *submitRplPrice) submitBasePrice(round *transactionRound) error {
 	priceMessengerAddress := t.cfg.Smartnode.GetBaseMessengerAddress()
 
 	if priceMessengerAddress == "" {
@@ -1495,27 +1631,20 @@ func (t *submitRplPrice) submitBasePrice() error {
 		t.log.Println("Submitting rate to Base...")
 
 		// Submit rates
-		tx, err := priceMessenger.Transact(opts, "submitRate")
+		err = round.send(opts, fmt.Sprintf("Base price for block %d", blockNumber), func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return priceMessenger.Transact(opts, "submitRate")
+		})
 		if err != nil {
 			return fmt.Errorf("Failed to submit rate: %q", err)
 		}
 
-		// Print TX info and wait for it to be included in a block
-		err = api.PrintAndWaitForTransaction(t.cfg, tx.Hash(), t.rp.Client, &t.log)
-		if err != nil {
-			return err
-		}
-
-		// Log
-		t.log.Printlnf("Successfully submitted Base price for block %d.", blockNumber)
-
 	}
 
 	return nil
 }
 
 // Checks if Scroll rate is stale and if it's our turn to submit, calls submitRate on the messenger
-func (t *submitRplPrice) submitScrollPrice() error {
+func (t *submitRplPrice) submitScrollPrice(round *transactionRound) error {
 	priceMessengerAddress := t.cfg.Smartnode.GetScrollMessengerAddress()
 
 	if priceMessengerAddress == "" {
@@ -1660,20 +1789,13 @@ func (t *submitRplPrice) submitScrollPrice() error {
 		t.log.Println("Submitting rate to Scroll...")
 
 		// Submit rates
-		tx, err := priceMessenger.Transact(opts, "submitRate", l2GasLimit)
+		err = round.send(opts, fmt.Sprintf("Scroll price for block %d", blockNumber), func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return priceMessenger.Transact(opts, "submitRate", l2GasLimit)
+		})
 		if err != nil {
 			return fmt.Errorf("Failed to submit Scroll rate: %w", err)
 		}
 
-		// Print TX info and wait for it to be included in a block
-		err = api.PrintAndWaitForTransaction(t.cfg, tx.Hash(), t.rp.Client, &t.log)
-		if err != nil {
-			return err
-		}
-
-		// Log
-		t.log.Printlnf("Successfully submitted Scroll price for block %d.", blockNumber)
-
 	}
 
 	return nil
@@ -9,6 +9,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
 	prdeposit "github.com/prysmaticlabs/prysm/v5/contracts/deposit"
 	"github.com/rocket-pool/rocketpool-go/minipool"
@@ -315,12 +316,7 @@ func (t *submitScrubMinipools) verifyBeaconWithdrawalCredentials(state *state.Ne
 	}
 
 	// Scrub the offending minipools
-	for _, minipool := range minipoolsToScrub {
-		err := t.submitVoteScrubMinipool(minipool)
-		if err != nil {
-			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
-		}
-	}
+	t.scrubMinipools(minipoolsToScrub)
 
 	return nil
 }
@@ -409,12 +405,7 @@ func (t *submitScrubMinipools) verifyPrestakeEvents() {
 	}
 
 	// Scrub the offending minipools
-	for _, minipool := range minipoolsToScrub {
-		err := t.submitVoteScrubMinipool(minipool)
-		if err != nil {
-			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
-		}
-	}
+	t.scrubMinipools(minipoolsToScrub)
 
 }
 
@@ -487,12 +478,7 @@ func (t *submitScrubMinipools) verifyDeposits() error {
 	}
 
 	// Scrub the offending minipools
-	for _, minipool := range minipoolsToScrub {
-		err := t.submitVoteScrubMinipool(minipool)
-		if err != nil {
-			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
-		}
-	}
+	t.scrubMinipools(minipoolsToScrub)
 
 	return nil
 
@@ -547,19 +533,28 @@ func (t *submitScrubMinipools) checkSafetyScrub(state *state.NetworkState) error
 	}
 
 	// Scrub the offending minipools
-	for _, minipool := range minipoolsToScrub {
-		err := t.submitVoteScrubMinipool(minipool)
+	t.scrubMinipools(minipoolsToScrub)
+
+	return nil
+
+}
+
+// Vote to scrub the minipools, together as one bundle if a bundle relay is set up
+func (t *submitScrubMinipools) scrubMinipools(minipools []minipool.Minipool) {
+	round := newTransactionRound(t.cfg, t.ec, &t.log)
+	for _, minipool := range minipools {
+		err := t.submitVoteScrubMinipool(round, minipool)
 		if err != nil {
 			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
 		}
 	}
-
-	return nil
-
+	if err := round.submit(); err != nil {
+		t.log.Printlnf("ALERT: Couldn't scrub the minipools: %s", err.Error())
+	}
 }
 
 // Submit minipool scrub status
-func (t *submitScrubMinipools) submitVoteScrubMinipool(mp minipool.Minipool) error {
+func (t *submitScrubMinipools) submitVoteScrubMinipool(round *transactionRound, mp minipool.Minipool) error {
 
 	// Log
 	t.log.Printlnf("Voting to scrub minipool %s...", mp.GetAddress().Hex())
@@ -587,23 +582,14 @@ func (t *submitScrubMinipools) submitVoteScrubMinipool(mp minipool.Minipool) err
 	opts.GasTipCap = eth.GweiToWei(utils.GetWatchtowerPrioFee(t.cfg))
 	opts.GasLimit = gasInfo.SafeGasLimit
 
-	// Dissolve
-	hash, err := mp.VoteScrub(opts)
-	if err != nil {
-		return fmt.Errorf("error voting to scrub minipool %s: %w", mp.GetAddress().Hex(), err)
-	}
-
-	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
-	if err != nil {
-		return err
-	}
-
-	// Log
-	t.log.Printlnf("Successfully voted to scrub the minipool %s.", mp.GetAddress().Hex())
-
-	// Return
-	return nil
+	// Scrub the minipool
+	return round.send(opts, fmt.Sprintf("the vote to scrub minipool %s", mp.GetAddress().Hex()), func(opts *bind.TransactOpts) (*ethtypes.Transaction, error) {
+		tx, err := mp.GetContract().Transact(opts, "voteScrub")
+		if err != nil {
+			return nil, fmt.Errorf("error voting to scrub minipool %s: %w", mp.GetAddress().Hex(), err)
+		}
+		return tx, nil
+	})
 
 }
 
@@ -0,0 +1,145 @@
+package watchtower
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/submissions"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// A round of watchtower transactions that belong together. If a bundle relay is set up, they're collected and submitted as one
+// atomic bundle so the round can't be left half done; otherwise each one is sent and waited for as soon as it's ready.
+// Transactions that would revert are left out of the bundle when they're added, so one failing duty doesn't sink the rest.
+type transactionRound struct {
+	cfg          *config.RocketPoolConfig
+	ec           rocketpool.ExecutionClient
+	log          *log.ColorLogger
+	ecManager    *services.ExecutionClientManager
+	nextNonce    uint64
+	txs          []*types.Transaction
+	descriptions []string
+	onResults    []func(hash common.Hash, err error)
+}
+
+// Create a new transaction round
+func newTransactionRound(cfg *config.RocketPoolConfig, ec rocketpool.ExecutionClient, logger *log.ColorLogger) *transactionRound {
+	round := &transactionRound{
+		cfg: cfg,
+		ec:  ec,
+		log: logger,
+	}
+	if ecManager, ok := ec.(*services.ExecutionClientManager); ok && ecManager.CanSendTransactionBundles() {
+		round.ecManager = ecManager
+	}
+	return round
+}
+
+// Create a transaction for the round with the provided transactor. It's sent right away unless the round is being bundled.
+func (r *transactionRound) send(opts *bind.TransactOpts, description string, transact func(opts *bind.TransactOpts) (*types.Transaction, error)) error {
+	return r.add(opts, description, transact, nil, nil)
+}
+
+// Create a transaction for the round like send, and record it in the submission ledger once it's created and again once it's been waited for
+func (r *transactionRound) sendToLedger(opts *bind.TransactOpts, description string, transact func(opts *bind.TransactOpts) (*types.Transaction, error), ledger *submissions.Ledger, kind submissions.Kind, id uint64, payloadHash common.Hash) error {
+	onCreated := func(hash common.Hash) {
+		if err := ledger.RecordSubmission(kind, id, payloadHash, hash); err != nil {
+			r.log.Printlnf("WARNING: couldn't record the submission: %s", err.Error())
+		}
+	}
+	onResult := func(hash common.Hash, err error) {
+		if ledgerErr := ledger.RecordResult(r.ec, hash, err); ledgerErr != nil {
+			r.log.Printlnf("WARNING: couldn't record the submission's result: %s", ledgerErr.Error())
+		}
+	}
+	return r.add(opts, description, transact, onCreated, onResult)
+}
+
+// Create a transaction for the round, calling the optional hooks once it's been created and once it's been waited for
+func (r *transactionRound) add(opts *bind.TransactOpts, description string, transact func(opts *bind.TransactOpts) (*types.Transaction, error), onCreated func(hash common.Hash), onResult func(hash common.Hash, err error)) error {
+	if r.ecManager == nil {
+		tx, err := transact(opts)
+		if err != nil {
+			return err
+		}
+		if onCreated != nil {
+			onCreated(tx.Hash())
+		}
+		err = api.PrintAndWaitForTransaction(r.cfg, tx.Hash(), r.ec, r.log)
+		if onResult != nil {
+			onResult(tx.Hash(), err)
+		}
+		if err != nil {
+			return err
+		}
+		r.log.Printlnf("Successfully submitted %s.", description)
+		return nil
+	}
+
+	// Sign it with the next nonce without sending it
+	if len(r.txs) == 0 {
+		nonce, err := r.ec.PendingNonceAt(context.Background(), opts.From)
+		if err != nil {
+			return fmt.Errorf("error getting the node's next nonce: %w", err)
+		}
+		r.nextNonce = nonce
+	}
+	opts.Nonce = new(big.Int).SetUint64(r.nextNonce)
+	opts.NoSend = true
+	tx, err := transact(opts)
+	if err != nil {
+		return err
+	}
+
+	// Leave it out if it would revert; its nonce goes to the next transaction instead
+	if err := r.ecManager.SimulateBundleTransaction(context.Background(), tx); err != nil {
+		r.log.Printlnf("Leaving %s out of the submission bundle because it would fail.", description)
+		return fmt.Errorf("error simulating the transaction: %w", err)
+	}
+	if onCreated != nil {
+		onCreated(tx.Hash())
+	}
+	r.nextNonce++
+	r.txs = append(r.txs, tx)
+	r.descriptions = append(r.descriptions, description)
+	r.onResults = append(r.onResults, onResult)
+	r.log.Printlnf("Added %s to the submission bundle.", description)
+	return nil
+}
+
+// Submit the transactions collected for the bundle and wait for them to be included
+func (r *transactionRound) submit() error {
+	if len(r.txs) == 0 {
+		return nil
+	}
+	r.log.Printlnf("Submitting a bundle of %d transactions...", len(r.txs))
+	if err := r.ecManager.SendTransactionBundle(context.Background(), r.txs); err != nil {
+		err = fmt.Errorf("error submitting the bundle: %w", err)
+		for i, tx := range r.txs {
+			if r.onResults[i] != nil {
+				r.onResults[i](tx.Hash(), err)
+			}
+		}
+		return err
+	}
+	for i, tx := range r.txs {
+		err := api.PrintAndWaitForTransaction(r.cfg, tx.Hash(), r.ec, r.log)
+		if r.onResults[i] != nil {
+			r.onResults[i](tx.Hash(), err)
+		}
+		if err != nil {
+			return err
+		}
+		r.log.Printlnf("Successfully submitted %s.", r.descriptions[i])
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"math/big"
 	"strconv"
 
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
@@ -33,6 +34,21 @@ func GetWatchtowerPrioFee(cfg *config.RocketPoolConfig) float64 {
 	return setting
 }
 
+// Get the divergence of a price from a reference price, as a percentage of the reference price
+func GetPriceDivergence(referencePrice *big.Int, price *big.Int) (float64, error) {
+	if referencePrice == nil || referencePrice.Sign() <= 0 {
+		return 0, fmt.Errorf("GetPriceDivergence requires a positive reference price")
+	}
+	if price == nil {
+		return 0, fmt.Errorf("GetPriceDivergence can't use a nil price")
+	}
+
+	delta := big.NewInt(0).Sub(price, referencePrice)
+	delta.Abs(delta)
+	divergence, _ := big.NewFloat(0).Quo(new(big.Float).SetInt(delta), new(big.Float).SetInt(referencePrice)).Float64()
+	return divergence * 100, nil
+}
+
 func FindLastBlockWithExecutionPayload(bc beacon.Client, slotNumber uint64) (beacon.BeaconBlock, error) {
 	beaconBlock := beacon.BeaconBlock{}
 	var err error
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"math/big"
 	"testing"
 	"time"
 )
@@ -46,3 +47,41 @@ func TestFindNextSubmission(t *testing.T) {
 		t.Fatalf("Should have error when using a reference date in the future")
 	}
 }
+
+func TestGetPriceDivergence(t *testing.T) {
+	reference := big.NewInt(1000)
+
+	divergence, err := GetPriceDivergence(reference, big.NewInt(1050))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if divergence != 5 {
+		t.Fatalf("Expected a divergence of 5%%, got %f", divergence)
+	}
+
+	divergence, err = GetPriceDivergence(reference, big.NewInt(900))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if divergence != 10 {
+		t.Fatalf("Expected a divergence of 10%%, got %f", divergence)
+	}
+
+	divergence, err = GetPriceDivergence(reference, reference)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if divergence != 0 {
+		t.Fatalf("Expected no divergence, got %f", divergence)
+	}
+
+	// Test invalid reference prices
+	_, err = GetPriceDivergence(big.NewInt(0), reference)
+	if err == nil {
+		t.Fatalf("Should have errored after using 0 for the reference price")
+	}
+	_, err = GetPriceDivergence(nil, reference)
+	if err == nil {
+		t.Fatalf("Should have errored after using nil for the reference price")
+	}
+}
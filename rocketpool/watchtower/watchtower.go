@@ -1,10 +1,12 @@
 package watchtower
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"math/rand"
 	"net/http"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -18,7 +20,14 @@ import (
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/hardware"
+	"github.com/rocket-pool/smartnode/shared/services/heartbeat"
+	"github.com/rocket-pool/smartnode/shared/services/mirror"
+	"github.com/rocket-pool/smartnode/shared/services/pinning"
+	"github.com/rocket-pool/smartnode/shared/services/profiling"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/submissions"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
@@ -30,6 +39,9 @@ var taskCooldown, _ = time.ParseDuration("5s")
 const (
 	MaxConcurrentEth1Requests = 200
 
+	// The number of epochs of validator statuses to share between tasks
+	validatorStatusCacheEpochs = 8
+
 	RespondChallengesColor         = color.FgWhite
 	ClaimRplRewardsColor           = color.FgGreen
 	SubmitRplPriceColor            = color.FgYellow
@@ -45,6 +57,12 @@ const (
 	CheckSoloMigrationsColor       = color.FgCyan
 	FinalizeProposalsColor         = color.FgMagenta
 	UpdateColor                    = color.FgHiWhite
+	CheckRecordsColor              = color.FgHiBlue
+	CheckMissedSubmissionsColor    = color.FgHiRed
+	CheckIntervalReadinessColor    = color.FgHiCyan
+	ManagePinsColor                = color.FgBlue
+	GovernorColor                  = color.FgHiBlack
+	ProfilingColor                 = color.FgHiBlack
 )
 
 // Register watchtower command
@@ -62,8 +80,20 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 // Run daemon
 func run(c *cli.Context) error {
 
+	// Make sure this is the only watchtower writing to the wallet and the rolling records
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	releaseLocks, err := services.LockDaemonFolders("watchtower", filepath.Dir(cfg.Smartnode.GetWalletPath()), cfg.Smartnode.GetRecordsPath())
+	if err != nil {
+		return err
+	}
+	defer releaseLocks()
+
 	// Configure
 	configureHTTP()
+	rewards.SetThreadLimit(int(cfg.Smartnode.WatchtowerConcurrency.Value.(uint64)))
 
 	// Wait until node is registered
 	if err := services.WaitNodeRegistered(c, true); err != nil {
@@ -71,10 +101,6 @@ func run(c *cli.Context) error {
 	}
 
 	// Get services
-	cfg, err := services.GetConfig(c)
-	if err != nil {
-		return err
-	}
 	rp, err := services.GetRocketPool(c)
 	if err != nil {
 		return err
@@ -105,11 +131,24 @@ func run(c *cli.Context) error {
 	scrubCollector := collectors.NewScrubCollector()
 	bondReductionCollector := collectors.NewBondReductionCollector()
 	soloMigrationCollector := collectors.NewSoloMigrationCollector()
+	catchUpCollector := collectors.NewCatchUpCollector()
 
 	// Initialize error logger
 	errorLog := log.NewColorLogger(ErrorColor)
 	updateLog := log.NewColorLogger(UpdateColor)
 
+	// Clean up after any writes that were interrupted the last time the daemon stopped
+	services.CleanupPartialWrites(cfg, &updateLog)
+
+	// Create the heartbeat for the external uptime monitor
+	watchtowerHeartbeat := heartbeat.NewHeartbeat(cfg, cfg.Heartbeat.WatchtowerUrl.Value.(string), &updateLog)
+	updateLog.Printlnf("Heartbeat %s.", watchtowerHeartbeat)
+
+	// Stop the tasks cleanly when the daemon is shut down
+	ctx, stop := services.NewShutdownContext()
+	defer stop()
+	backgroundTasks := services.NewBackgroundTasks(ctx)
+
 	// Create the state manager
 	m, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, &updateLog)
 	if err != nil {
@@ -122,16 +161,27 @@ func run(c *cli.Context) error {
 		return fmt.Errorf("error getting node account: %w", err)
 	}
 
+	// Create the manager that keeps the submitted rewards files pinned, and the distributor that publishes them
+	pinLog := log.NewColorLogger(ManagePinsColor)
+	pinMgr := pinning.NewManager(cfg, &pinLog)
+	distributor := newRewardsDistributor(cfg, pinMgr, mirror.NewPublisher(cfg, &pinLog))
+
+	// Load the record of earlier submissions, so a restart doesn't repeat any of them
+	ledger, err := submissions.LoadLedger(cfg.Smartnode.GetSubmissionLedgerPath(true))
+	if err != nil {
+		return err
+	}
+
 	// Initialize tasks
 	respondChallenges, err := newRespondChallenges(c, log.NewColorLogger(RespondChallengesColor), m)
 	if err != nil {
 		return fmt.Errorf("error during respond-to-challenges check: %w", err)
 	}
-	submitRplPrice, err := newSubmitRplPrice(c, log.NewColorLogger(SubmitRplPriceColor), errorLog)
+	submitRplPrice, err := newSubmitRplPrice(c, log.NewColorLogger(SubmitRplPriceColor), errorLog, ledger)
 	if err != nil {
 		return fmt.Errorf("error during rpl price check: %w", err)
 	}
-	submitNetworkBalances, err := newSubmitNetworkBalances(c, log.NewColorLogger(SubmitNetworkBalancesColor), errorLog)
+	submitNetworkBalances, err := newSubmitNetworkBalances(c, log.NewColorLogger(SubmitNetworkBalancesColor), errorLog, backgroundTasks, ledger)
 	if err != nil {
 		return fmt.Errorf("error during network balances check: %w", err)
 	}
@@ -146,12 +196,12 @@ func run(c *cli.Context) error {
 	var submitRewardsTree_Stateless *submitRewardsTree_Stateless
 	var submitRewardsTree_Rolling *submitRewardsTree_Rolling
 	if !useRollingRecords {
-		submitRewardsTree_Stateless, err = newSubmitRewardsTree_Stateless(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m)
+		submitRewardsTree_Stateless, err = newSubmitRewardsTree_Stateless(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m, backgroundTasks, distributor, ledger)
 		if err != nil {
 			return fmt.Errorf("error during stateless rewards tree check: %w", err)
 		}
 	} else {
-		submitRewardsTree_Rolling, err = newSubmitRewardsTree_Rolling(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m)
+		submitRewardsTree_Rolling, err = newSubmitRewardsTree_Rolling(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m, backgroundTasks, catchUpCollector, distributor, ledger)
 		if err != nil {
 			return fmt.Errorf("error during rolling rewards tree check: %w", err)
 		}
@@ -160,7 +210,7 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("error during penalties check: %w", err)
 	}*/
-	generateRewardsTree, err := newGenerateRewardsTree(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog)
+	generateRewardsTree, err := newGenerateRewardsTree(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, backgroundTasks)
 	if err != nil {
 		return fmt.Errorf("error during manual tree generation check: %w", err)
 	}
@@ -176,26 +226,58 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("error creating finalize-pdao-proposals task: %w", err)
 	}
+	checkMissedSubmissions, err := newCheckMissedSubmissions(c, log.NewColorLogger(CheckMissedSubmissionsColor))
+	if err != nil {
+		return fmt.Errorf("error creating check-missed-submissions task: %w", err)
+	}
+	checkIntervalReadiness, err := newCheckIntervalReadiness(c, log.NewColorLogger(CheckIntervalReadinessColor), pinMgr)
+	if err != nil {
+		return fmt.Errorf("error creating check-interval-readiness task: %w", err)
+	}
+	managePins, err := newManagePins(c, pinLog, pinMgr)
+	if err != nil {
+		return fmt.Errorf("error creating manage-pins task: %w", err)
+	}
+	var checkRecords *checkRecords
+	if useRollingRecords {
+		checkRecords, err = newCheckRecords(c, log.NewColorLogger(CheckRecordsColor), errorLog, submitRewardsTree_Rolling)
+		if err != nil {
+			return fmt.Errorf("error creating check-records task: %w", err)
+		}
+	}
+	fallbackMonitor, err := services.NewFallbackMonitor(c, &updateLog)
+	if err != nil {
+		return fmt.Errorf("error creating fallback client monitor: %w", err)
+	}
+	contractMonitor, err := services.NewContractUpgradeMonitor(c, &updateLog)
+	if err != nil {
+		return fmt.Errorf("error creating contract upgrade monitor: %w", err)
+	}
 
 	intervalDelta := maxTasksInterval - minTasksInterval
 	secondsDelta := intervalDelta.Seconds()
 
-	// Wait group to handle the various threads
+	// Wait group to handle the task loop
 	wg := new(sync.WaitGroup)
-	wg.Add(2)
+	wg.Add(1)
 
 	// Run task loop
 	isHoustonDeployedMasterFlag := false
+	var statusCache *beacon.ValidatorStatusCache
 	go func() {
-		for {
+		for ctx.Err() == nil {
 			// Randomize the next interval
 			randomSeconds := rand.Intn(int(secondsDelta))
 			interval := time.Duration(randomSeconds)*time.Second + minTasksInterval
 
+			// Switch to the first healthy client pair before checking the sync status
+			fallbackMonitor.Check()
+
 			// Check the EC status
 			err := services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
 			if err != nil {
 				errorLog.Println(err)
+				watchtowerHeartbeat.Failure(err)
 				time.Sleep(taskCooldown)
 				continue
 			}
@@ -204,15 +286,45 @@ func run(c *cli.Context) error {
 			err = services.WaitBeaconClientSynced(c, false) // Force refresh the primary / fallback BC status
 			if err != nil {
 				errorLog.Println(err)
+				watchtowerHeartbeat.Failure(err)
 				time.Sleep(taskCooldown)
 				continue
 			}
 
+			// Share validator statuses between the tasks, and drop the ones that may have changed once their epoch is finalized
+			if statusCache == nil {
+				eth2Config, err := bc.GetEth2Config()
+				if err != nil {
+					errorLog.Println(fmt.Errorf("error getting Beacon config: %w", err))
+					time.Sleep(taskCooldown)
+					continue
+				}
+				statusCache = beacon.NewValidatorStatusCache(eth2Config.SlotsPerEpoch, validatorStatusCacheEpochs)
+				bc.SetValidatorStatusCache(statusCache)
+
+				// Throttle the rewards calculations while the machine is busy, so they don't starve the Validator Client
+				governorLog := log.NewColorLogger(GovernorColor)
+				rewards.SetGovernor(hardware.NewGovernor(cfg, time.Unix(int64(eth2Config.GenesisTime), 0), eth2Config.SecondsPerSlot, &governorLog))
+			}
+			head, err := bc.GetBeaconHead()
+			if err != nil {
+				errorLog.Println(fmt.Errorf("error getting Beacon head: %w", err))
+				time.Sleep(taskCooldown)
+				continue
+			}
+			statusCache.SetFinalizedEpoch(head.FinalizedEpoch)
+
+			// Pick up any contract upgrades before the tasks use the contracts
+			if _, err := contractMonitor.Check(); err != nil {
+				errorLog.Println(err)
+			}
+
 			// Get the Beacon block
 			//latestBlock, err := m.GetLatestFinalizedBeaconBlock()
 			latestBlock, err := m.GetLatestBeaconBlock()
 			if err != nil {
 				errorLog.Println(fmt.Errorf("error getting latest Beacon block: %w", err))
+				watchtowerHeartbeat.Failure(err)
 				time.Sleep(taskCooldown)
 				continue
 			}
@@ -231,6 +343,13 @@ func run(c *cli.Context) error {
 			}
 			time.Sleep(taskCooldown)
 
+			// Check the rolling record checkpoints
+			if useRollingRecords {
+				if err := checkRecords.run(); err != nil {
+					errorLog.Println(err)
+				}
+			}
+
 			if isOnOdao {
 				// Run the challenge check
 				if err := respondChallenges.run(); err != nil {
@@ -239,9 +358,10 @@ func run(c *cli.Context) error {
 				time.Sleep(taskCooldown)
 
 				// Update the network state
-				state, err := updateNetworkState(m, &updateLog, latestBlock)
+				state, err := updateNetworkState(ctx, m, &updateLog, latestBlock)
 				if err != nil {
 					errorLog.Println(err)
+					watchtowerHeartbeat.Failure(err)
 					time.Sleep(taskCooldown)
 					continue
 				}
@@ -278,6 +398,24 @@ func run(c *cli.Context) error {
 				}
 				time.Sleep(taskCooldown)
 
+				// Make sure this node hasn't fallen behind the rest of the Oracle DAO
+				if err := checkMissedSubmissions.run(state); err != nil {
+					errorLog.Println(err)
+				}
+				time.Sleep(taskCooldown)
+
+				// Make sure the watchtower is ready for the end of the rewards interval
+				if err := checkIntervalReadiness.run(state); err != nil {
+					errorLog.Println(err)
+				}
+				time.Sleep(taskCooldown)
+
+				// Keep the submitted rewards files pinned
+				if err := managePins.run(); err != nil {
+					errorLog.Println(err)
+				}
+				time.Sleep(taskCooldown)
+
 				// Run the minipool dissolve check
 				if err := dissolveTimedOutMinipools.run(state); err != nil {
 					errorLog.Println(err)
@@ -331,22 +469,42 @@ func run(c *cli.Context) error {
 				}
 			}
 
-			time.Sleep(interval)
+			// Let the uptime monitor know the watchtower finished its round
+			watchtowerHeartbeat.Success()
+
+			// Wait for the next run, or stop early if the daemon is shutting down
+			select {
+			case <-ctx.Done():
+			case <-time.After(interval):
+			}
 		}
 		wg.Done()
 	}()
 
 	// Run metrics loop
 	go func() {
-		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), scrubCollector, bondReductionCollector, soloMigrationCollector)
+		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), scrubCollector, bondReductionCollector, soloMigrationCollector, catchUpCollector)
 		if err != nil {
 			errorLog.Println(err)
 		}
-		wg.Done()
 	}()
 
-	// Wait for both threads to stop
+	// Run the profiling server, if it's enabled
+	if cfg.Smartnode.EnableProfiling.Value == true {
+		go func() {
+			err := profiling.RunServer(c.GlobalString("metricsAddress"), cfg.Smartnode.WatchtowerProfilingPort.Value.(uint16), log.NewColorLogger(ProfilingColor))
+			if err != nil {
+				errorLog.Println(err)
+			}
+		}()
+	}
+
+	// Wait for the task loop to stop, then give the background tasks time to save their progress
 	wg.Wait()
+	updateLog.Println("Shutting down, waiting for background tasks to finish...")
+	if !backgroundTasks.Wait(services.ShutdownTimeout) {
+		errorLog.Printlnf("Background tasks didn't stop within %s; any progress since their last checkpoint will be redone on the next start.", services.ShutdownTimeout)
+	}
 	return nil
 }
 
@@ -361,10 +519,10 @@ func configureHTTP() {
 }
 
 // Update the latest network state at each cycle
-func updateNetworkState(m *state.NetworkStateManager, log *log.ColorLogger, block beacon.BeaconBlock) (*state.NetworkState, error) {
+func updateNetworkState(ctx context.Context, m *state.NetworkStateManager, log *log.ColorLogger, block beacon.BeaconBlock) (*state.NetworkState, error) {
 	log.Print("Getting latest network state... ")
 	// Get the state of the network
-	state, err := m.GetStateForSlot(block.Slot)
+	state, err := m.GetStateForSlot(ctx, block.Slot)
 	if err != nil {
 		return nil, fmt.Errorf("error getting network state: %w", err)
 	}
@@ -3,6 +3,7 @@ package alerting
 import (
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -177,6 +178,614 @@ func AlertMinipoolStaked(cfg *config.RocketPoolConfig, minipoolAddress common.Ad
 	return sendAlert(alert, cfg)
 }
 
+// Sends an alert when the watchtower refused to submit the RPL price because it diverged too far from a secondary source.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertRplPriceDivergence(cfg *config.RocketPoolConfig, blockNumber uint64, source string, rplPrice float64, sourcePrice float64, divergence float64) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertRplPriceDivergence.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_RplPriceDivergence.Value != true {
+		logMessage("alert for RplPriceDivergence is disabled, not sending.")
+		return nil
+	}
+
+	// prepare the alert information:
+	alert := createAlert(
+		fmt.Sprintf("RplPriceDivergence-%d-%s", blockNumber, source),
+		fmt.Sprintf("RPL price for block %d diverges from %s", blockNumber, source),
+		fmt.Sprintf("The RPL price for block %d (%.6f ETH) differs from the price reported by %s (%.6f ETH) by %.2f%%. The price submission was skipped.", blockNumber, rplPrice, source, sourcePrice, divergence),
+		SeverityCritical,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{
+			"source": source,
+		},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when a security council proposal opens for voting and the node hasn't voted on it yet.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertSecurityCouncilProposal(cfg *config.RocketPoolConfig, proposalId uint64, message string, endTime time.Time) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertSecurityCouncilProposal.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_SecurityCouncilProposal.Value != true {
+		logMessage("alert for SecurityCouncilProposal is disabled, not sending.")
+		return nil
+	}
+
+	// prepare the alert information:
+	alert := createAlert(
+		fmt.Sprintf("SecurityCouncilProposal-%d", proposalId),
+		fmt.Sprintf("Security council proposal %d is open for voting", proposalId),
+		fmt.Sprintf("Security council proposal %d (%s) is open for voting until %s and your node has not voted on it yet. Use 'rocketpool security proposals vote' to vote.", proposalId, message, endTime.Format(time.RFC1123)),
+		SeverityWarning,
+		strfmt.DateTime(endTime),
+		map[string]string{},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the node's collateral ratio is above the RPL stake manager's upper bound.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertRplStakeAboveUpperBound(cfg *config.RocketPoolConfig, ratio float64, upperBound float64, excessRpl float64) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertRplStakeAboveUpperBound.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_RplStakeAboveUpperBound.Value != true {
+		logMessage("alert for RplStakeAboveUpperBound is disabled, not sending.")
+		return nil
+	}
+
+	// prepare the alert information:
+	alert := createAlert(
+		"RplStakeAboveUpperBound",
+		"Node collateral ratio is above the upper bound",
+		fmt.Sprintf("The node's collateral ratio is %.2f%%, which is above the upper bound of %.2f%%. About %.6f RPL could be withdrawn to get back to the target.", ratio, upperBound, excessRpl),
+		SeverityInfo,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when one of the node's proposed blocks didn't move the gas limit towards its preferred gas limit.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertGasLimitNotHonored(cfg *config.RocketPoolConfig, slot uint64, gasLimit uint64, expectedGasLimit uint64) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertGasLimitNotHonored.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_GasLimitNotHonored.Value != true {
+		logMessage("alert for GasLimitNotHonored is disabled, not sending.")
+		return nil
+	}
+
+	// prepare the alert information:
+	alert := createAlert(
+		fmt.Sprintf("GasLimitNotHonored-%d", slot),
+		"Proposed block did not honor the preferred gas limit",
+		fmt.Sprintf("The block your node proposed in slot %d had a gas limit of %d instead of %d. Check your Validator Client's gas limit setting and your MEV-Boost relays.", slot, gasLimit, expectedGasLimit),
+		SeverityWarning,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the Smartnode switches to a different client pair.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertFallbackClientsChanged(cfg *config.RocketPoolConfig, pairName string, healthy bool) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertFallbackClientsChanged.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_FallbackClientsChanged.Value != true {
+		logMessage("alert for FallbackClientsChanged is disabled, not sending.")
+		return nil
+	}
+
+	// prepare the alert information:
+	summary := fmt.Sprintf("Switched to the %s", pairName)
+	description := fmt.Sprintf("The Smartnode is now using the %s for its Execution and Beacon clients.", pairName)
+	severity := SeverityWarning
+	if !healthy {
+		summary = "No healthy client pairs"
+		description = "None of your primary or fallback Execution and Beacon client pairs are healthy, so the Smartnode can't reliably perform its duties."
+		severity = SeverityCritical
+	}
+	alert := createAlert(
+		"FallbackClientsChanged",
+		summary,
+		description,
+		severity,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the Rocket Pool network contracts are upgraded.
+func AlertContractsUpgraded(cfg *config.RocketPoolConfig, contractNames []string) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertContractsUpgraded.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_ContractsUpgraded.Value != true {
+		logMessage("alert for ContractsUpgraded is disabled, not sending.")
+		return nil
+	}
+
+	// prepare the alert information:
+	alert := createAlert(
+		"ContractsUpgraded",
+		"Rocket Pool contracts upgraded",
+		fmt.Sprintf("The following Rocket Pool contracts were upgraded, and the Smartnode has switched to the new versions: %s. Please check that your Smartnode is up to date, since a new release may be required to support the upgrade.", strings.Join(contractNames, ", ")),
+		SeverityWarning,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the watchtower finds problems with its rolling record checkpoints.
+func AlertRecordsInconsistent(cfg *config.RocketPoolConfig, problemCount int, repaired bool) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertRecordsInconsistent.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_RecordsInconsistent.Value != true {
+		logMessage("alert for RecordsInconsistent is disabled, not sending.")
+		return nil
+	}
+
+	// prepare the alert information:
+	description := fmt.Sprintf("The watchtower found %d problems with its rolling record checkpoints and couldn't repair them. Run `rocketpool service check-records` for details.", problemCount)
+	severity := SeverityCritical
+	if repaired {
+		description = fmt.Sprintf("The watchtower found %d problems with its rolling record checkpoints and dropped the affected checkpoints; it will rebuild the record from the latest good one.", problemCount)
+		severity = SeverityWarning
+	}
+	alert := createAlert(
+		"RecordsInconsistent",
+		"Rolling record checkpoints inconsistent",
+		description,
+		severity,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the other Oracle DAO members are close to (or have reached) consensus on a round the node hasn't submitted.
+func AlertMissedOdaoSubmission(cfg *config.RocketPoolConfig, roundType string, roundID uint64, peerCount int, quorum int, consensusReached bool) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertMissedOdaoSubmission.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_MissedOdaoSubmission.Value != true {
+		logMessage("alert for MissedOdaoSubmission is disabled, not sending.")
+		return nil
+	}
+
+	// prepare the alert information:
+	description := fmt.Sprintf("%d other Oracle DAO members have submitted the %s round (%d), and %d are needed for consensus, but your watchtower hasn't. Check its logs and fix the problem before the round closes.", peerCount, roundType, roundID, quorum)
+	if consensusReached {
+		description = fmt.Sprintf("The Oracle DAO reached consensus on the %s round (%d) without your watchtower's submission. Check its logs for the problem before the next round.", roundType, roundID)
+	}
+	alert := createAlert(
+		fmt.Sprintf("MissedOdaoSubmission-%s-%d", roundType, roundID),
+		"Watchtower missed an Oracle DAO submission",
+		description,
+		SeverityCritical,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{
+			"round": roundType,
+		},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the watchtower's readiness check before the end of a rewards interval fails.
+func AlertRewardsReadinessFailed(cfg *config.RocketPoolConfig, interval uint64, timeLeft time.Duration, problems []string) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertRewardsReadinessFailed.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_RewardsReadinessFailed.Value != true {
+		logMessage("alert for RewardsReadinessFailed is disabled, not sending.")
+		return nil
+	}
+
+	// prepare the alert information:
+	description := fmt.Sprintf("Rewards interval %d ends in %s, but the watchtower isn't ready to submit its tree: %s", interval, timeLeft.Round(time.Minute), strings.Join(problems, "; "))
+	alert := createAlert(
+		fmt.Sprintf("RewardsReadinessFailed-%d", interval),
+		"Watchtower not ready for the end of the rewards interval",
+		description,
+		SeverityCritical,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when a disk the Smartnode uses is nearly full.
+func AlertHostDiskFull(cfg *config.RocketPoolConfig, path string, usedPercent float64) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertHostDiskFull.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_HostDiskFull.Value != true {
+		logMessage("alert for HostDiskFull is disabled, not sending.")
+		return nil
+	}
+
+	// prepare the alert information:
+	alert := createAlert(
+		"HostDiskFull",
+		"Disk nearly full",
+		fmt.Sprintf("The disk holding %s is %.1f%% full. Your clients will stop working if it runs out of space; consider pruning your Execution client or moving to a larger disk.", path, usedPercent),
+		SeverityWarning,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{
+			"path": path,
+		},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the CPU has spent a long time waiting on disk IO.
+func AlertHostHighIoWait(cfg *config.RocketPoolConfig, ioWaitPercent float64) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertHostHighIoWait.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_HostHighIoWait.Value != true {
+		logMessage("alert for HostHighIoWait is disabled, not sending.")
+		return nil
+	}
+
+	// prepare the alert information:
+	alert := createAlert(
+		"HostHighIoWait",
+		"High disk IO wait",
+		fmt.Sprintf("The CPU has been spending %.1f%% of its time waiting on disk IO. Your disk may be too slow to keep up with your clients, which can cause missed attestations during epoch processing.", ioWaitPercent),
+		SeverityWarning,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityInfo)),
+		map[string]string{},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when an NVMe drive is worn out or reports a critical warning.
+func AlertNvmeWear(cfg *config.RocketPoolConfig, drive string, percentageUsed uint8, criticalWarning uint8) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertNvmeWear.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_NvmeWear.Value != true {
+		logMessage("alert for NvmeWear is disabled, not sending.")
+		return nil
+	}
+
+	// prepare the alert information:
+	description := fmt.Sprintf("NVMe drive %s has used %d%% of its rated endurance. Plan to replace it before it fails.", drive, percentageUsed)
+	severity := SeverityWarning
+	if criticalWarning != 0 {
+		description = fmt.Sprintf("NVMe drive %s is reporting a critical warning (0x%02x) in its SMART log, and has used %d%% of its rated endurance. Back up your node and replace the drive as soon as possible.", drive, criticalWarning, percentageUsed)
+		severity = SeverityCritical
+	}
+	alert := createAlert(
+		"NvmeWear",
+		"NVMe drive wearing out",
+		description,
+		severity,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{
+			"drive": drive,
+		},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the attestation efficiency of the node's validators has been low for several epochs in a row.
+func AlertLowAttestationEfficiency(cfg *config.RocketPoolConfig, efficiency float64, epochs uint64, causes []string) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertLowAttestationEfficiency.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_LowAttestationEfficiency.Value != true {
+		logMessage("alert for LowAttestationEfficiency is disabled, not sending.")
+		return nil
+	}
+
+	// prepare the alert information:
+	description := fmt.Sprintf("Your validators' attestation efficiency has been below the threshold for the last %d epochs, and was %.1f%% in the latest one.", epochs, efficiency)
+	if len(causes) > 0 {
+		description += " Likely causes: " + strings.Join(causes, "; ") + "."
+	}
+	alert := createAlert(
+		"LowAttestationEfficiency",
+		"Low attestation efficiency",
+		description,
+		SeverityWarning,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityInfo)),
+		map[string]string{},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when some of the node's validators will be in the next sync committee, with the results of the readiness checks.
+func AlertUpcomingSyncCommitteeDuty(cfg *config.RocketPoolConfig, period uint64, validatorCount int, timeLeft time.Duration, failures []string) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertUpcomingSyncCommitteeDuty.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_SyncCommitteeReadiness.Value != true {
+		logMessage("alert for SyncCommitteeReadiness is disabled, not sending.")
+		return nil
+	}
+
+	// prepare the alert information:
+	description := fmt.Sprintf("%d of your validators will be in sync committee period %d, which starts in %s.", validatorCount, period, timeLeft.Round(time.Minute))
+	severity := SeverityInfo
+	if len(failures) > 0 {
+		description += " Some of the readiness checks failed, so fix them before the period starts: " + strings.Join(failures, "; ") + "."
+		severity = SeverityWarning
+	} else {
+		description += " All of the readiness checks passed."
+	}
+	alert := createAlert(
+		fmt.Sprintf("SyncCommitteeReadiness-%d", period),
+		"Upcoming sync committee duty",
+		description,
+		severity,
+		strfmt.DateTime(time.Now().Add(timeLeft)),
+		map[string]string{},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the system clock has drifted too far from the NTP servers or the Beacon Chain.
+func AlertClockDrift(cfg *config.RocketPoolConfig, problem string) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertClockDrift.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_ClockDrift.Value != true {
+		logMessage("alert for ClockDrift is disabled, not sending.")
+		return nil
+	}
+
+	// prepare the alert information:
+	description := fmt.Sprintf("Your node's clock is wrong: %s. Validators with a skewed clock send their attestations and blocks at the wrong time and miss duties, so make sure time synchronization (e.g. chrony or systemd-timesyncd) is running.", problem)
+	alert := createAlert(
+		"ClockDrift",
+		"System clock drift",
+		description,
+		SeverityCritical,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when new releases are available for the Smartnode or the clients it manages.
+func AlertClientUpdatesAvailable(cfg *config.RocketPoolConfig, updates []string) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertClientUpdatesAvailable.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_ClientUpdatesAvailable.Value != true {
+		logMessage("alert for ClientUpdatesAvailable is disabled, not sending.")
+		return nil
+	}
+
+	// prepare the alert information:
+	description := fmt.Sprintf("New releases are available: %s. Run `rocketpool service version --check` to see the changelogs, and `rocketpool service upgrade-clients` to upgrade the clients.", strings.Join(updates, "; "))
+	alert := createAlert(
+		"ClientUpdatesAvailable",
+		"Client updates available",
+		description,
+		SeverityInfo,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityInfo)),
+		map[string]string{},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when a scheduled backup couldn't be created or uploaded.
+func AlertBackupFailed(cfg *config.RocketPoolConfig, problem string) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertBackupFailed.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_BackupFailed.Value != true {
+		logMessage("alert for BackupFailed is disabled, not sending.")
+		return nil
+	}
+
+	// prepare the alert information:
+	description := fmt.Sprintf("The scheduled backup of your node failed: %s. Check the node container's logs for details.", problem)
+	alert := createAlert(
+		"BackupFailed",
+		"Scheduled backup failed",
+		description,
+		SeverityCritical,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the last successful scheduled backup is older than the schedule allows.
+func AlertBackupOutOfDate(cfg *config.RocketPoolConfig, lastSuccess time.Time) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertBackupOutOfDate.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_BackupOutOfDate.Value != true {
+		logMessage("alert for BackupOutOfDate is disabled, not sending.")
+		return nil
+	}
+
+	// prepare the alert information:
+	description := "Your node hasn't made a successful scheduled backup yet, even though backups are scheduled."
+	if !lastSuccess.IsZero() {
+		description = fmt.Sprintf("Your node's last successful scheduled backup was made at %s, which is longer ago than the backup schedule allows.", lastSuccess.UTC().Format(time.RFC1123))
+	}
+	alert := createAlert(
+		"BackupOutOfDate",
+		"Backups out of date",
+		description,
+		SeverityWarning,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the Validator Client has been stopped because another machine appears to be running the node's validators.
+func AlertActiveHostConflict(cfg *config.RocketPoolConfig, reason string) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertActiveHostConflict.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_ActiveHostConflict.Value != true {
+		logMessage("alert for ActiveHostConflict is disabled, not sending.")
+		return nil
+	}
+
+	// prepare the alert information:
+	description := fmt.Sprintf("Your node stopped its Validator Client to avoid being slashed: %s. Make sure only one machine is running your validators, then run `rocketpool service active-host --claim` on it.", reason)
+	alert := createAlert(
+		"ActiveHostConflict",
+		"Validator Client stopped: another host is active",
+		description,
+		SeverityCritical,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the event watcher sees a change to one of the node's minipools.
+func AlertMinipoolEvent(cfg *config.RocketPoolConfig, minipoolAddress common.Address, event string, blockNumber uint64, summary string, description string, severity Severity) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertMinipoolEvent.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_MinipoolEvent.Value != true {
+		logMessage("alert for MinipoolEvent is disabled, not sending.")
+		return nil
+	}
+
+	// prepare the alert information:
+	endsAt := strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityInfo))
+	if severity != SeverityInfo {
+		endsAt = strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical))
+	}
+	alert := createAlert(
+		fmt.Sprintf("MinipoolEvent-%s-%s-%d", event, minipoolAddress.Hex(), blockNumber),
+		summary,
+		description,
+		severity,
+		endsAt,
+		map[string]string{
+			"minipool": minipoolAddress.Hex(),
+		},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the node wallet's ETH balance is too low for the gas of its upcoming duties.
+func AlertLowGasFunds(cfg *config.RocketPoolConfig, balance float64, projectedCost float64, duties []string, critical bool) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertLowGasFunds.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_LowGasFunds.Value != true {
+		logMessage("alert for LowGasFunds is disabled, not sending.")
+		return nil
+	}
+
+	// prepare the alert information:
+	severity := SeverityWarning
+	if critical {
+		severity = SeverityCritical
+	}
+	description := fmt.Sprintf("Your node wallet has %.6f ETH", balance)
+	if len(duties) > 0 {
+		description += fmt.Sprintf(", but its upcoming duties (%s) are projected to cost %.6f ETH in gas at the current gas price", strings.Join(duties, ", "), projectedCost)
+	} else {
+		description += ", which is below your minimum reserve"
+	}
+	description += ". Send more ETH to the node wallet so it can keep paying for its transactions."
+	alert := createAlert(
+		"LowGasFunds",
+		"Node wallet is low on ETH for gas",
+		description,
+		severity,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the Rescue Node's time limit or credential is about to run out, or already has.
+func AlertRescueNodeExpiring(cfg *config.RocketPoolConfig, problem string, expired bool) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertRescueNodeExpiring.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_RescueNodeExpiring.Value != true {
+		logMessage("alert for RescueNodeExpiring is disabled, not sending.")
+		return nil
+	}
+
+	// prepare the alert information:
+	severity := SeverityWarning
+	if expired {
+		severity = SeverityCritical
+	}
+	description := fmt.Sprintf("%s. Run `rocketpool service rescue-node disable` once your own Beacon Node is synced, or `rocketpool service rescue-node enable` to renew it if you still need it.", problem)
+	alert := createAlert(
+		"RescueNodeExpiring",
+		"Rescue Node expiring",
+		description,
+		severity,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{},
+	)
+	return sendAlert(alert, cfg)
+}
+
 // Gets various settings for an alert based on whether a process succeeded or failed.
 func getAlertSettingsForEvent(succeeded bool) (strfmt.DateTime, Severity, string) {
 	endsAt := strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityInfo))
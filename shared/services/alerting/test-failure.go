@@ -0,0 +1,101 @@
+package alerting
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/rocket-pool/smartnode/shared/services/alerting/alertmanager/models"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// A synthetic failure that can be injected into the alerting pipeline
+type FailureScenario string
+
+const (
+	FailureScenario_ClientUnreachable FailureScenario = "client-unreachable"
+	FailureScenario_MissedAttestation FailureScenario = "missed-attestation"
+	FailureScenario_LowDisk           FailureScenario = "low-disk"
+)
+
+// Settings
+const (
+	// How long a test failure stays active; this is longer than the node's task loop, so the notification dispatcher always sees it before it resolves
+	TestFailureDuration time.Duration = 15 * time.Minute
+
+	testLabel   string = "test"
+	testIdLabel string = "testId"
+)
+
+// The alert that was sent for a test failure
+type TestFailure struct {
+	Id        string
+	Name      string
+	Summary   string
+	Severity  Severity
+	ExpiresAt time.Time
+}
+
+// Sends a synthetic alert for the scenario to Alertmanager, without touching any of the real services.
+// The alert uses the same name and severity as the real one, so it follows the same routes to the notification sinks.
+// It's marked with a test label and a unique ID so it's never mistaken for a real failure, and it's always sent even if the real alert is disabled.
+func SendTestFailure(cfg *config.RocketPoolConfig, scenario FailureScenario) (TestFailure, error) {
+	if !isAlertingEnabled(cfg) {
+		return TestFailure{}, fmt.Errorf("alerting is disabled; enable it in the Monitoring / Alerting section of the `rocketpool service config` TUI first")
+	}
+
+	failure := TestFailure{
+		Id:        strconv.FormatInt(time.Now().UnixNano(), 10),
+		ExpiresAt: time.Now().Add(TestFailureDuration),
+	}
+	var description string
+	switch scenario {
+	case FailureScenario_ClientUnreachable:
+		failure.Name = "FallbackClientsChanged"
+		failure.Summary = "No healthy client pairs"
+		failure.Severity = SeverityCritical
+		description = "None of your primary or fallback Execution and Beacon client pairs are healthy, so the Smartnode can't reliably perform its duties."
+	case FailureScenario_MissedAttestation:
+		failure.Name = "LowAttestationEfficiency"
+		failure.Summary = "Low attestation efficiency"
+		failure.Severity = SeverityWarning
+		description = "Your validators' attestation efficiency has been below the threshold for the last 3 epochs, and was 0.0% in the latest one."
+	case FailureScenario_LowDisk:
+		failure.Name = "HostDiskFull"
+		failure.Summary = "Disk nearly full"
+		failure.Severity = SeverityWarning
+		description = "The disk holding / is 97.0% full. Your clients will stop working if it runs out of space; consider pruning your Execution client or moving to a larger disk."
+	default:
+		return TestFailure{}, fmt.Errorf("unknown failure scenario [%s]", scenario)
+	}
+
+	failure.Summary = "[TEST] " + failure.Summary
+	description = fmt.Sprintf("This is a synthetic %s failure sent with `rocketpool service test-failure`; nothing is actually wrong with your node. %s", scenario, description)
+	alert := createAlert(
+		failure.Name,
+		failure.Summary,
+		description,
+		failure.Severity,
+		strfmt.DateTime(failure.ExpiresAt),
+		map[string]string{
+			testLabel:   "true",
+			testIdLabel: failure.Id,
+		},
+	)
+	return failure, sendAlert(alert, cfg)
+}
+
+// Get the state Alertmanager reports for a test failure, such as active or suppressed, or an empty string if it doesn't have the alert
+func GetTestFailureState(alerts []*models.GettableAlert, failure TestFailure) string {
+	for _, alert := range alerts {
+		if alert.Labels[testIdLabel] != failure.Id {
+			continue
+		}
+		if alert.Status == nil || alert.Status.State == nil {
+			return ""
+		}
+		return *alert.Status.State
+	}
+	return ""
+}
@@ -0,0 +1,245 @@
+package attestation
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// Settings
+const (
+	// The Beacon Chain's reward weights for each part of an attestation, so efficiency is scored the same way rewards are
+	timelySourceWeight uint64 = 14
+	timelyTargetWeight uint64 = 26
+	timelyHeadWeight   uint64 = 14
+
+	// The latest an attestation can be included and still earn its source reward
+	timelySourceMaxDelay uint64 = 5
+
+	// How far back to look for a block when the slots before an attestation are empty
+	maxEmptySlotLookback uint64 = 64
+)
+
+// Checks how a set of validators attested, using the blocks on the Beacon Chain
+type Checker struct {
+	bc beacon.Client
+
+	// Block data shared between epochs, since an epoch's attestations can be included in the next one
+	attestationCache map[uint64][]beacon.AttestationInfo
+	rootCache        map[uint64]common.Hash
+	blockCache       map[uint64]bool
+}
+
+// Create a new attestation checker
+func NewChecker(bc beacon.Client) *Checker {
+	return &Checker{
+		bc:               bc,
+		attestationCache: map[uint64][]beacon.AttestationInfo{},
+		rootCache:        map[uint64]common.Hash{},
+		blockCache:       map[uint64]bool{},
+	}
+}
+
+// How the node's validators did in one epoch
+type EpochStats struct {
+	Duties       uint64
+	Included     uint64
+	TimelySource uint64
+	TimelyTarget uint64
+	TimelyHead   uint64
+
+	// Counts of the likely reasons for lost rewards
+	NotIncluded   uint64
+	LateInclusion uint64
+	WrongTarget   uint64
+	LateBlockHead uint64
+	WrongHead     uint64
+}
+
+// Get the epoch's efficiency as a percentage of the maximum attestation reward
+func (s *EpochStats) Efficiency() float64 {
+	if s.Duties == 0 {
+		return 100
+	}
+	earned := s.TimelySource*timelySourceWeight + s.TimelyTarget*timelyTargetWeight + s.TimelyHead*timelyHeadWeight
+	possible := s.Duties * (timelySourceWeight + timelyTargetWeight + timelyHeadWeight)
+	return float64(earned) / float64(possible) * 100
+}
+
+// Describe the most likely reasons for the lost rewards, from the most common to the least
+func (s *EpochStats) LikelyCauses() []string {
+	type cause struct {
+		count       uint64
+		description string
+	}
+	causes := []cause{
+		{s.NotIncluded, "attestations weren't included at all, so your validator client may be offline or your Beacon Node may have too few peers"},
+		{s.LateInclusion, "attestations were included late, which usually means they're propagating slowly because of a low peer count or limited bandwidth"},
+		{s.LateBlockHead, "the blocks your validators voted on arrived late, which is usually the proposer's fault rather than yours"},
+		{s.WrongHead, "your Beacon Node was slow to process new blocks; check its CPU and disk load"},
+		{s.WrongTarget, "your Beacon Node may have been out of sync or following the wrong fork"},
+	}
+	sort.SliceStable(causes, func(i, j int) bool {
+		return causes[i].count > causes[j].count
+	})
+	descriptions := []string{}
+	for _, cause := range causes {
+		if cause.count > 0 {
+			descriptions = append(descriptions, cause.description)
+		}
+	}
+	return descriptions
+}
+
+// Work out how each of the provided validators did in an epoch.
+// The epoch after it has to be finished too, since attestations can be included until the end of the next epoch.
+func (t *Checker) CheckEpoch(slotsPerEpoch uint64, epoch uint64, validators map[string]bool) (*EpochStats, error) {
+	stats := &EpochStats{}
+
+	// Get the duties
+	committees, err := t.bc.GetCommitteesForEpoch(&epoch)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the committees for epoch %d: %w", epoch, err)
+	}
+	type duty struct {
+		slot           uint64
+		committeeIndex uint64
+		position       uint64
+	}
+	duties := []duty{}
+	for idx := 0; idx < committees.Count(); idx++ {
+		for position, validator := range committees.Validators(idx) {
+			if validators[validator] {
+				duties = append(duties, duty{
+					slot:           committees.Slot(idx),
+					committeeIndex: committees.Index(idx),
+					position:       uint64(position),
+				})
+			}
+		}
+	}
+	committees.Release()
+
+	// The target every attestation in this epoch should vote for
+	targetRoot, err := t.getRootAtSlot(epoch * slotsPerEpoch)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, duty := range duties {
+		stats.Duties++
+
+		// Find the first block that included this attestation
+		var inclusion *beacon.AttestationInfo
+		inclusionSlot := uint64(0)
+		lastSlot := (epoch+2)*slotsPerEpoch - 1
+		for slot := duty.slot + 1; slot <= lastSlot && inclusion == nil; slot++ {
+			attestations, err := t.getAttestations(slot)
+			if err != nil {
+				return nil, err
+			}
+			for i, attestation := range attestations {
+				if attestation.SlotIndex == duty.slot && attestation.CommitteeIndex == duty.committeeIndex && attestation.AggregationBits.BitAt(duty.position) {
+					inclusion = &attestations[i]
+					inclusionSlot = slot
+					break
+				}
+			}
+		}
+		if inclusion == nil {
+			stats.NotIncluded++
+			continue
+		}
+		stats.Included++
+		delay := inclusionSlot - duty.slot
+
+		// Check each of the votes
+		if delay <= timelySourceMaxDelay {
+			stats.TimelySource++
+		} else {
+			stats.LateInclusion++
+		}
+		if inclusion.TargetRoot == targetRoot {
+			stats.TimelyTarget++
+		} else {
+			stats.WrongTarget++
+		}
+		headRoot, err := t.getRootAtSlot(duty.slot)
+		if err != nil {
+			return nil, err
+		}
+		if inclusion.BeaconBlockRoot != headRoot {
+			// If the vote was for the block before this slot's, this slot's block most likely arrived late
+			parentRoot, err := t.getRootAtSlot(duty.slot - 1)
+			if err != nil {
+				return nil, err
+			}
+			if t.blockCache[duty.slot] && inclusion.BeaconBlockRoot == parentRoot {
+				stats.LateBlockHead++
+			} else {
+				stats.WrongHead++
+			}
+		} else if delay == 1 {
+			stats.TimelyHead++
+		} else if delay <= timelySourceMaxDelay {
+			stats.LateInclusion++
+		}
+	}
+
+	return stats, nil
+}
+
+// Get the attestations included in a slot's block
+func (t *Checker) getAttestations(slot uint64) ([]beacon.AttestationInfo, error) {
+	if attestations, exists := t.attestationCache[slot]; exists {
+		return attestations, nil
+	}
+	attestations, found, err := t.bc.GetAttestations(fmt.Sprint(slot))
+	if err != nil {
+		return nil, fmt.Errorf("error getting the attestations in slot %d: %w", slot, err)
+	}
+	if !found {
+		attestations = []beacon.AttestationInfo{}
+	}
+	t.attestationCache[slot] = attestations
+	return attestations, nil
+}
+
+// Get the root of the latest block at or before a slot, which is what an attestation for that slot should vote for
+func (t *Checker) getRootAtSlot(slot uint64) (common.Hash, error) {
+	if root, exists := t.rootCache[slot]; exists {
+		return root, nil
+	}
+	for back := uint64(0); back <= maxEmptySlotLookback && back <= slot; back++ {
+		header, found, err := t.bc.GetBeaconBlockHeader(fmt.Sprint(slot - back))
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("error getting the block header for slot %d: %w", slot-back, err)
+		}
+		if back == 0 {
+			t.blockCache[slot] = found
+		}
+		if found {
+			t.rootCache[slot] = header.Root
+			return header.Root, nil
+		}
+	}
+	return common.Hash{}, fmt.Errorf("couldn't find a block in the %d slots before slot %d", maxEmptySlotLookback, slot)
+}
+
+// Remove the cached block data from before a slot, once no later epoch needs it
+func (t *Checker) Prune(slot uint64) {
+	for cachedSlot := range t.attestationCache {
+		if cachedSlot < slot {
+			delete(t.attestationCache, cachedSlot)
+		}
+	}
+	for cachedSlot := range t.rootCache {
+		if cachedSlot+1 < slot {
+			delete(t.rootCache, cachedSlot)
+			delete(t.blockCache, cachedSlot)
+		}
+	}
+}
@@ -0,0 +1,532 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/keyarchive"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// Settings
+const (
+	// The extension of backup archives
+	ArchiveExtension string = ".rpbk"
+
+	// The version of the archive layout, bumped whenever a restore would need to handle it differently
+	formatVersion uint64 = 1
+
+	manifestFilename string = "manifest.json"
+	timestampFormat  string = "20060102-150405"
+
+	// Scheduled backups are named differently so the retention window never deletes backups made by hand
+	manualFilenamePrefix    string = "rocketpool-backup-"
+	scheduledFilenamePrefix string = "rocketpool-scheduled-backup-"
+	configRoot              string = "config"
+	dataRoot                string = "data"
+
+	// Encryption settings, matching the validator key archive
+	archiveMagic string = "RPBK1"
+	saltLength   int    = 16
+	keyLength    int    = 32
+	scryptN      int    = 262144
+	scryptR      int    = 8
+	scryptP      int    = 1
+
+	// The archives are encrypted, so the backups folder is readable by the node operator's account for copying them elsewhere
+	dirMode         os.FileMode = 0770
+	backupsDirMode  os.FileMode = 0755
+	archiveFileMode os.FileMode = 0644
+)
+
+// A part of the Smartnode's state that can be backed up and restored on its own
+type Component string
+
+const (
+	Component_Config             Component = "config"
+	Component_Wallet             Component = "wallet"
+	Component_ValidatorKeys      Component = "validator-keys"
+	Component_SlashingProtection Component = "slashing-protection"
+	Component_Records            Component = "records"
+	Component_State              Component = "state"
+)
+
+// Every component, in the order they're backed up and restored
+var AllComponents = []Component{
+	Component_Config,
+	Component_Wallet,
+	Component_ValidatorKeys,
+	Component_SlashingProtection,
+	Component_Records,
+	Component_State,
+}
+
+// The components backed up when none are specified.
+// Validator keys are left out because the wallet can regenerate them, and every extra copy of them is a slashing risk.
+var DefaultComponents = []Component{
+	Component_Config,
+	Component_Wallet,
+	Component_SlashingProtection,
+	Component_Records,
+	Component_State,
+}
+
+// Descriptions of each component for the CLI
+var ComponentDescriptions = map[Component]string{
+	Component_Config:             "the Smartnode configuration (user-settings.yml)",
+	Component_Wallet:             "the node wallet and its password file",
+	Component_ValidatorKeys:      "the validator keystores, including custom keys and the encrypted key archive",
+	Component_SlashingProtection: "the Validator Client's slashing protection database",
+	Component_Records:            "the rolling record checkpoints",
+	Component_State:              "the daemon's state and event history: Watchtower progress, the rewards ledger, queue stats, and sent notifications",
+}
+
+// The slashing protection databases each Validator Client keeps in the validators folder
+var slashingProtectionPaths = []string{
+	"lighthouse/validators/slashing_protection.sqlite",
+	"lodestar/validator-db",
+	"nimbus/validators/slashing_protection.sqlite",
+	"prysm-non-hd/direct/validator.db",
+	"teku/slashprotection",
+}
+
+// A file or folder that belongs to a component, relative to its root
+type source struct {
+	component Component
+	root      string
+	path      string
+	exclude   []string
+}
+
+// A decrypted backup whose contents have been verified against its manifest
+type Backup struct {
+	Manifest api.BackupManifest
+	contents map[string][]byte
+}
+
+// Parse a comma-separated list of components
+func ParseComponents(value string) ([]Component, error) {
+	components := []Component{}
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		component := Component(name)
+		if _, exists := ComponentDescriptions[component]; !exists {
+			return nil, fmt.Errorf("unknown backup component [%s]", name)
+		}
+		components = append(components, component)
+	}
+	if len(components) == 0 {
+		return nil, fmt.Errorf("no backup components were provided")
+	}
+	return components, nil
+}
+
+// Get the names of a list of components
+func FormatComponents(components []Component) string {
+	names := make([]string, len(components))
+	for i, component := range components {
+		names[i] = string(component)
+	}
+	return strings.Join(names, ",")
+}
+
+// Get the data folder as the daemon sees it
+func getDataPath(cfg *config.RocketPoolConfig) string {
+	if cfg.IsNativeMode {
+		return cfg.Smartnode.DataPath.Value.(string)
+	}
+	return config.DaemonDataPath
+}
+
+// Get the files and folders that make up each component
+func getSources(settingsPath string) []source {
+	slashingProtection := make([]string, len(slashingProtectionPaths))
+	for i, path := range slashingProtectionPaths {
+		slashingProtection[i] = filepath.Join("validators", path)
+	}
+
+	sources := []source{
+		{component: Component_Config, root: configRoot, path: filepath.Base(settingsPath)},
+		{component: Component_Wallet, root: dataRoot, path: "wallet"},
+		{component: Component_Wallet, root: dataRoot, path: "password"},
+		{component: Component_ValidatorKeys, root: dataRoot, path: "validators", exclude: slashingProtection},
+		{component: Component_ValidatorKeys, root: dataRoot, path: "custom-keys"},
+		{component: Component_ValidatorKeys, root: dataRoot, path: config.ValidatorKeyArchiveFilename},
+	}
+	for _, path := range slashingProtection {
+		sources = append(sources, source{component: Component_SlashingProtection, root: dataRoot, path: path})
+	}
+	sources = append(sources,
+		source{component: Component_Records, root: dataRoot, path: "records"},
+		source{component: Component_State, root: dataRoot, path: config.WatchtowerFolder},
+		source{component: Component_State, root: dataRoot, path: config.RewardsLedgerFilename},
+		source{component: Component_State, root: dataRoot, path: config.QueueStatsFilename},
+		source{component: Component_State, root: dataRoot, path: config.NotificationsStateFilename},
+	)
+	return sources
+}
+
+// Get the folder a root refers to
+func getRootPath(cfg *config.RocketPoolConfig, settingsPath string, root string) string {
+	if root == configRoot {
+		return filepath.Dir(settingsPath)
+	}
+	return getDataPath(cfg)
+}
+
+// Back up the components into an encrypted archive.
+// The settings path is where the caller loaded the config from, since it isn't in the data folder.
+func Create(cfg *config.RocketPoolConfig, settingsPath string, components []Component, password string, smartnodeVersion string) ([]byte, api.BackupManifest, error) {
+	manifest := api.BackupManifest{
+		Version:          formatVersion,
+		Created:          time.Now().UTC(),
+		SmartnodeVersion: smartnodeVersion,
+		Network:          string(cfg.Smartnode.Network.Value.(cfgtypes.Network)),
+		NativeMode:       cfg.IsNativeMode,
+		Files:            []api.BackupFile{},
+	}
+	for _, component := range components {
+		manifest.Components = append(manifest.Components, string(component))
+	}
+
+	// Read every file in the selected components
+	selected := map[Component]bool{}
+	for _, component := range components {
+		selected[component] = true
+	}
+	contents := map[string][]byte{}
+	for _, source := range getSources(settingsPath) {
+		if !selected[source.component] {
+			continue
+		}
+		rootPath := getRootPath(cfg, settingsPath, source.root)
+		err := filepath.Walk(filepath.Join(rootPath, source.path), func(path string, info os.FileInfo, err error) error {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			relPath, err := filepath.Rel(rootPath, path)
+			if err != nil {
+				return err
+			}
+			for _, exclude := range source.exclude {
+				if relPath == exclude || strings.HasPrefix(relPath, exclude+string(os.PathSeparator)) {
+					if info.IsDir() {
+						return filepath.SkipDir
+					}
+					return nil
+				}
+			}
+			if !info.Mode().IsRegular() || info.Name() == keyarchive.MarkerFilename || strings.HasSuffix(info.Name(), files.TempFileSuffix) {
+				return nil
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			name := source.root + "/" + filepath.ToSlash(relPath)
+			hash := sha256.Sum256(data)
+			contents[name] = data
+			manifest.Files = append(manifest.Files, api.BackupFile{
+				Component: string(source.component),
+				Name:      name,
+				Size:      uint64(len(data)),
+				Mode:      uint32(info.Mode().Perm()),
+				Sha256:    hex.EncodeToString(hash[:]),
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, api.BackupManifest{}, fmt.Errorf("error reading [%s] for the %s backup: %w", source.path, source.component, err)
+		}
+	}
+
+	// Build the tarball, with the manifest first so a restore can check it before anything else
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, api.BackupManifest{}, fmt.Errorf("error serializing backup manifest: %w", err)
+	}
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	tarWriter := tar.NewWriter(gzipWriter)
+	if err := writeTarFile(tarWriter, manifestFilename, 0600, manifestBytes); err != nil {
+		return nil, api.BackupManifest{}, err
+	}
+	for _, file := range manifest.Files {
+		if err := writeTarFile(tarWriter, file.Name, os.FileMode(file.Mode), contents[file.Name]); err != nil {
+			return nil, api.BackupManifest{}, err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return nil, api.BackupManifest{}, fmt.Errorf("error building backup archive: %w", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, api.BackupManifest{}, fmt.Errorf("error compressing backup archive: %w", err)
+	}
+
+	archive, err := encrypt(compressed.Bytes(), password)
+	if err != nil {
+		return nil, api.BackupManifest{}, err
+	}
+	return archive, manifest, nil
+}
+
+// Write a backup archive into the folder, named after the network and the time it was created. Returns the archive's filename.
+func Save(dir string, archive []byte, manifest api.BackupManifest, scheduled bool) (string, error) {
+	err := os.MkdirAll(dir, backupsDirMode)
+	if err != nil {
+		return "", fmt.Errorf("error creating backup folder [%s]: %w", dir, err)
+	}
+	filename := GetFilename(manifest, scheduled)
+	path := filepath.Join(dir, filename)
+	err = files.WriteFileAtomic(path, archive, archiveFileMode)
+	if err != nil {
+		return "", fmt.Errorf("error writing backup to [%s]: %w", path, err)
+	}
+	return filename, nil
+}
+
+// Get the name of a backup archive, which includes the network and when it was created
+func GetFilename(manifest api.BackupManifest, scheduled bool) string {
+	prefix := manualFilenamePrefix
+	if scheduled {
+		prefix = scheduledFilenamePrefix
+	}
+	return fmt.Sprintf("%s%s-%s%s", prefix, manifest.Network, manifest.Created.Format(timestampFormat), ArchiveExtension)
+}
+
+// Get the hash of an encrypted archive, so copies of it can be checked without the password
+func GetArchiveHash(archive []byte) string {
+	hash := sha256.Sum256(archive)
+	return hex.EncodeToString(hash[:])
+}
+
+// Decrypt a backup archive and verify every file in it against its manifest
+func Open(archive []byte, password string) (*Backup, error) {
+	compressed, err := decrypt(archive, password)
+	if err != nil {
+		return nil, err
+	}
+	gzipReader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing backup: %w", err)
+	}
+
+	backup := &Backup{
+		contents: map[string][]byte{},
+	}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading backup: %w", err)
+		}
+		data, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, fmt.Errorf("error reading [%s] from backup: %w", header.Name, err)
+		}
+		backup.contents[header.Name] = data
+	}
+
+	// Check the manifest
+	manifestBytes, exists := backup.contents[manifestFilename]
+	if !exists {
+		return nil, fmt.Errorf("the backup doesn't have a manifest")
+	}
+	delete(backup.contents, manifestFilename)
+	if err := json.Unmarshal(manifestBytes, &backup.Manifest); err != nil {
+		return nil, fmt.Errorf("error decoding backup manifest: %w", err)
+	}
+	if backup.Manifest.Version > formatVersion {
+		return nil, fmt.Errorf("the backup uses format version %d, but this Smartnode only supports up to version %d; please upgrade before restoring it", backup.Manifest.Version, formatVersion)
+	}
+
+	// Verify every file, and make sure there's nothing extra
+	if len(backup.contents) != len(backup.Manifest.Files) {
+		return nil, fmt.Errorf("the backup has %d files but its manifest lists %d", len(backup.contents), len(backup.Manifest.Files))
+	}
+	for _, file := range backup.Manifest.Files {
+		data, exists := backup.contents[file.Name]
+		if !exists {
+			return nil, fmt.Errorf("[%s] is listed in the backup manifest but is missing", file.Name)
+		}
+		hash := sha256.Sum256(data)
+		if hex.EncodeToString(hash[:]) != file.Sha256 || uint64(len(data)) != file.Size {
+			return nil, fmt.Errorf("[%s] doesn't match its checksum in the backup manifest", file.Name)
+		}
+		if err := checkName(file.Name); err != nil {
+			return nil, err
+		}
+	}
+	return backup, nil
+}
+
+// Check if the backup has a component
+func (b *Backup) HasComponent(component Component) bool {
+	for _, name := range b.Manifest.Components {
+		if name == string(component) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the backed up settings file, if the config was part of the backup
+func (b *Backup) GetSettings() ([]byte, bool) {
+	for _, file := range b.Manifest.Files {
+		if file.Component == string(Component_Config) {
+			return b.contents[file.Name], true
+		}
+	}
+	return nil, false
+}
+
+// Restore the data folder files of the components. The config has to be restored by the caller with GetSettings,
+// since the daemon doesn't own the config folder. Existing files are overwritten; files that aren't in the backup are kept.
+// Returns the number of files that were restored.
+func (b *Backup) Restore(cfg *config.RocketPoolConfig, components []Component) (uint64, error) {
+	selected := map[string]bool{}
+	for _, component := range components {
+		if component == Component_Config {
+			continue
+		}
+		if !b.HasComponent(component) {
+			return 0, fmt.Errorf("the backup doesn't include the %s component", component)
+		}
+		selected[string(component)] = true
+	}
+
+	// The manifest is in component order, so each component's files are written together
+	restoreFiles := []api.BackupFile{}
+	for _, file := range b.Manifest.Files {
+		if selected[file.Component] && strings.HasPrefix(file.Name, dataRoot+"/") {
+			restoreFiles = append(restoreFiles, file)
+		}
+	}
+
+	dataPath := getDataPath(cfg)
+	restored := uint64(0)
+	for _, file := range restoreFiles {
+		path := filepath.Join(dataPath, filepath.FromSlash(strings.TrimPrefix(file.Name, dataRoot+"/")))
+		if err := os.MkdirAll(filepath.Dir(path), dirMode); err != nil {
+			return restored, fmt.Errorf("error creating folder for [%s]: %w", path, err)
+		}
+		if err := files.WriteFileAtomic(path, b.contents[file.Name], os.FileMode(file.Mode)); err != nil {
+			return restored, fmt.Errorf("error restoring [%s]: %w", path, err)
+		}
+		restored++
+	}
+	return restored, nil
+}
+
+// Make sure a file in the backup can't be written outside of its root
+func checkName(name string) error {
+	parts := strings.SplitN(name, "/", 2)
+	if len(parts) != 2 || (parts[0] != configRoot && parts[0] != dataRoot) {
+		return fmt.Errorf("the backup has an invalid entry [%s]", name)
+	}
+	cleaned := filepath.Clean(filepath.FromSlash(parts[1]))
+	if filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(os.PathSeparator)) {
+		return fmt.Errorf("the backup has an invalid entry [%s]", name)
+	}
+	return nil
+}
+
+// Add a file to a tarball
+func writeTarFile(tarWriter *tar.Writer, name string, mode os.FileMode, data []byte) error {
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     int64(mode),
+		Size:     int64(len(data)),
+		ModTime:  time.Unix(0, 0),
+	}
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return fmt.Errorf("error adding [%s] to backup: %w", name, err)
+	}
+	if _, err := tarWriter.Write(data); err != nil {
+		return fmt.Errorf("error adding [%s] to backup: %w", name, err)
+	}
+	return nil
+}
+
+// Encrypt an archive with a fresh salt and nonce
+func encrypt(plaintext []byte, password string) ([]byte, error) {
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("error generating salt: %w", err)
+	}
+	aead, err := getCipher(password, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("error generating nonce: %w", err)
+	}
+	header := append([]byte(archiveMagic), salt...)
+	ciphertext := aead.Seal(nil, nonce, plaintext, header)
+	return append(append(header, nonce...), ciphertext...), nil
+}
+
+// Decrypt an archive, which also authenticates it
+func decrypt(archive []byte, password string) ([]byte, error) {
+	headerLength := len(archiveMagic) + saltLength
+	if len(archive) < headerLength || string(archive[:len(archiveMagic)]) != archiveMagic {
+		return nil, fmt.Errorf("this is not a Smartnode backup")
+	}
+	header := archive[:headerLength]
+	aead, err := getCipher(password, archive[len(archiveMagic):headerLength])
+	if err != nil {
+		return nil, err
+	}
+	if len(archive) < headerLength+aead.NonceSize() {
+		return nil, fmt.Errorf("the backup is truncated")
+	}
+	nonce := archive[headerLength : headerLength+aead.NonceSize()]
+	plaintext, err := aead.Open(nil, nonce, archive[headerLength+aead.NonceSize():], header)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting the backup; the password may be incorrect or the backup may be corrupt")
+	}
+	return plaintext, nil
+}
+
+// Derive the archive cipher from the password
+func getCipher(password string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(password), salt, scryptN, scryptR, scryptP, keyLength)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving backup key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating backup cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,347 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Settings
+const (
+	remoteRequestTimeout = 10 * time.Minute
+	s3Algorithm          = "AWS4-HMAC-SHA256"
+	s3Service            = "s3"
+	s3TimeFormat         = "20060102T150405Z"
+	s3DateFormat         = "20060102"
+)
+
+// Returned by remote storage when a file doesn't exist
+var ErrNotFound = errors.New("the file doesn't exist")
+
+// Remote storage that scheduled backups are uploaded to
+type RemoteStorage interface {
+	// Upload an archive under the provided name
+	Upload(name string, data []byte) error
+
+	// Download an archive, returning ErrNotFound if it doesn't exist
+	Download(name string) ([]byte, error)
+
+	// Get the names of the archives in the storage
+	List() ([]string, error)
+
+	// Delete an archive
+	Delete(name string) error
+}
+
+// Get the remote storage from the config, or nil if uploads are disabled
+func GetRemoteStorage(cfg *config.RocketPoolConfig) (RemoteStorage, error) {
+	storage := cfg.Backup.Storage.Value.(cfgtypes.BackupStorage)
+	if storage == cfgtypes.BackupStorage_None {
+		return nil, nil
+	}
+
+	storageUrl, err := url.Parse(strings.TrimSuffix(strings.TrimSpace(cfg.Backup.StorageUrl.Value.(string)), "/"))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the backup storage URL: %w", err)
+	}
+	if storageUrl.Scheme != "http" && storageUrl.Scheme != "https" {
+		return nil, fmt.Errorf("the backup storage URL [%s] must start with http:// or https://", storageUrl.Redacted())
+	}
+	client := &http.Client{Timeout: remoteRequestTimeout}
+	accessKey := cfg.Backup.AccessKey.Value.(string)
+	secretKey := cfg.Backup.SecretKey.Value.(string)
+
+	switch storage {
+	case cfgtypes.BackupStorage_S3, cfgtypes.BackupStorage_B2:
+		// Both use path-style S3 URLs, with the bucket as the first part of the path
+		parts := strings.SplitN(strings.TrimPrefix(storageUrl.Path, "/"), "/", 2)
+		if parts[0] == "" {
+			return nil, fmt.Errorf("the backup storage URL [%s] doesn't include a bucket", storageUrl.Redacted())
+		}
+		s3 := &s3Storage{
+			client:    client,
+			endpoint:  storageUrl.Scheme + "://" + storageUrl.Host,
+			bucket:    parts[0],
+			region:    cfg.Backup.S3Region.Value.(string),
+			accessKey: accessKey,
+			secretKey: secretKey,
+		}
+		if len(parts) == 2 && parts[1] != "" {
+			s3.prefix = parts[1] + "/"
+		}
+		return s3, nil
+
+	case cfgtypes.BackupStorage_WebDav:
+		return &webDavStorage{
+			client:   client,
+			url:      storageUrl.String(),
+			username: accessKey,
+			password: secretKey,
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown backup storage [%s]", storage)
+	}
+}
+
+// An S3 bucket, or an S3-compatible one such as Backblaze B2
+type s3Storage struct {
+	client    *http.Client
+	endpoint  string
+	bucket    string
+	prefix    string
+	region    string
+	accessKey string
+	secretKey string
+}
+
+// The response to a ListObjectsV2 request
+type s3ListResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (s *s3Storage) Upload(name string, data []byte) error {
+	_, err := s.do(http.MethodPut, s.prefix+name, nil, data)
+	if err != nil {
+		return fmt.Errorf("error uploading %s to the %s bucket: %w", name, s.bucket, err)
+	}
+	return nil
+}
+
+func (s *s3Storage) Download(name string) ([]byte, error) {
+	data, err := s.do(http.MethodGet, s.prefix+name, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading %s from the %s bucket: %w", name, s.bucket, err)
+	}
+	return data, nil
+}
+
+func (s *s3Storage) List() ([]string, error) {
+	names := []string{}
+	token := ""
+	for {
+		query := url.Values{
+			"list-type": {"2"},
+			"prefix":    {s.prefix},
+		}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		body, err := s.do(http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error listing the %s bucket: %w", s.bucket, err)
+		}
+		var result s3ListResult
+		if err := xml.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("error decoding the %s bucket listing: %w", s.bucket, err)
+		}
+		for _, object := range result.Contents {
+			name := strings.TrimPrefix(object.Key, s.prefix)
+			if !strings.Contains(name, "/") {
+				names = append(names, name)
+			}
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return names, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+func (s *s3Storage) Delete(name string) error {
+	_, err := s.do(http.MethodDelete, s.prefix+name, nil, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting %s from the %s bucket: %w", name, s.bucket, err)
+	}
+	return nil
+}
+
+// Send a request signed with AWS Signature Version 4
+func (s *s3Storage) do(method string, key string, query url.Values, payload []byte) ([]byte, error) {
+	canonicalUri := "/" + s3Escape(s.bucket, false)
+	if key != "" {
+		canonicalUri += "/" + s3Escape(key, false)
+	}
+	canonicalQuery := s3CanonicalQuery(query)
+	requestUrl := s.endpoint + canonicalUri
+	if canonicalQuery != "" {
+		requestUrl += "?" + canonicalQuery
+	}
+	request, err := http.NewRequest(method, requestUrl, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	// Sign the request
+	now := time.Now().UTC()
+	timestamp := now.Format(s3TimeFormat)
+	date := now.Format(s3DateFormat)
+	payloadHash := sha256.Sum256(payload)
+	payloadHashString := hex.EncodeToString(payloadHash[:])
+	request.Header.Set("x-amz-content-sha256", payloadHashString)
+	request.Header.Set("x-amz-date", timestamp)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := fmt.Sprintf("host:%s\nx-amz-content-sha256:%s\nx-amz-date:%s\n", request.URL.Host, payloadHashString, timestamp)
+	canonicalRequest := strings.Join([]string{method, canonicalUri, canonicalQuery, canonicalHeaders, signedHeaders, payloadHashString}, "\n")
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+	scope := fmt.Sprintf("%s/%s/%s/aws4_request", date, s.region, s3Service)
+	stringToSign := strings.Join([]string{s3Algorithm, timestamp, scope, hex.EncodeToString(canonicalRequestHash[:])}, "\n")
+
+	signingKey := hmacSha256([]byte("AWS4"+s.secretKey), date)
+	signingKey = hmacSha256(signingKey, s.region)
+	signingKey = hmacSha256(signingKey, s3Service)
+	signingKey = hmacSha256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSha256(signingKey, stringToSign))
+	request.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s", s3Algorithm, s.accessKey, scope, signedHeaders, signature))
+
+	return doRemoteRequest(s.client, request)
+}
+
+// Escape a path or query value the way Signature Version 4 expects
+func s3Escape(value string, escapeSlash bool) string {
+	var builder strings.Builder
+	for _, b := range []byte(value) {
+		if (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9') || b == '-' || b == '_' || b == '.' || b == '~' || (b == '/' && !escapeSlash) {
+			builder.WriteByte(b)
+		} else {
+			fmt.Fprintf(&builder, "%%%02X", b)
+		}
+	}
+	return builder.String()
+}
+
+// Build a query string with its parameters sorted, as Signature Version 4 expects
+func s3CanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := []string{}
+	for _, key := range keys {
+		for _, value := range query[key] {
+			parts = append(parts, s3Escape(key, true)+"="+s3Escape(value, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+func hmacSha256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// A WebDAV folder
+type webDavStorage struct {
+	client   *http.Client
+	url      string
+	username string
+	password string
+}
+
+// The response to a PROPFIND request
+type webDavMultistatus struct {
+	Responses []struct {
+		Href string `xml:"href"`
+	} `xml:"response"`
+}
+
+func (s *webDavStorage) Upload(name string, data []byte) error {
+	_, err := s.do(http.MethodPut, s.url+"/"+url.PathEscape(name), nil, data)
+	if err != nil {
+		return fmt.Errorf("error uploading %s to WebDAV: %w", name, err)
+	}
+	return nil
+}
+
+func (s *webDavStorage) Download(name string) ([]byte, error) {
+	data, err := s.do(http.MethodGet, s.url+"/"+url.PathEscape(name), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading %s from WebDAV: %w", name, err)
+	}
+	return data, nil
+}
+
+func (s *webDavStorage) List() ([]string, error) {
+	body, err := s.do("PROPFIND", s.url+"/", map[string]string{"Depth": "1", "Content-Type": "application/xml"}, []byte(`<?xml version="1.0" encoding="utf-8"?><propfind xmlns="DAV:"><prop><resourcetype/></prop></propfind>`))
+	if err != nil {
+		return nil, fmt.Errorf("error listing the WebDAV folder: %w", err)
+	}
+	var result webDavMultistatus
+	if err := xml.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("error decoding the WebDAV folder listing: %w", err)
+	}
+	names := []string{}
+	for _, response := range result.Responses {
+		href, err := url.PathUnescape(response.Href)
+		if err != nil || strings.HasSuffix(href, "/") {
+			// Skip the folder itself and anything in it that isn't a file
+			continue
+		}
+		names = append(names, path.Base(href))
+	}
+	return names, nil
+}
+
+func (s *webDavStorage) Delete(name string) error {
+	_, err := s.do(http.MethodDelete, s.url+"/"+url.PathEscape(name), nil, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting %s from WebDAV: %w", name, err)
+	}
+	return nil
+}
+
+// Send a WebDAV request with basic authentication
+func (s *webDavStorage) do(method string, requestUrl string, headers map[string]string, payload []byte) ([]byte, error) {
+	request, err := http.NewRequest(method, requestUrl, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+	if s.username != "" || s.password != "" {
+		request.SetBasicAuth(s.username, s.password)
+	}
+	return doRemoteRequest(s.client, request)
+}
+
+// Send a request and read its response, treating anything other than a 2xx status as an error
+func doRemoteRequest(client *http.Client, request *http.Request) ([]byte, error) {
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the response: %w", err)
+	}
+	if response.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf("HTTP status %d; response body: '%s'", response.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
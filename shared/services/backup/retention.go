@@ -0,0 +1,86 @@
+package backup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// A scheduled backup archive and when it was created
+type scheduledArchive struct {
+	name    string
+	created time.Time
+}
+
+// Get the scheduled backups for a network that are older than the retention window, leaving the newest one even if it's expired.
+// Archives that weren't made by the scheduler are never included.
+func GetExpiredBackups(names []string, network string, retention time.Duration, now time.Time) []string {
+	prefix := scheduledFilenamePrefix + network + "-"
+	archives := []scheduledArchive{}
+	for _, name := range names {
+		if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ArchiveExtension) {
+			continue
+		}
+		created, err := time.Parse(timestampFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), ArchiveExtension))
+		if err != nil {
+			continue
+		}
+		archives = append(archives, scheduledArchive{name: name, created: created})
+	}
+
+	var newest *scheduledArchive
+	for i := range archives {
+		if newest == nil || archives[i].created.After(newest.created) {
+			newest = &archives[i]
+		}
+	}
+	expired := []string{}
+	for _, archive := range archives {
+		if archive.name != newest.name && now.Sub(archive.created) > retention {
+			expired = append(expired, archive.name)
+		}
+	}
+	return expired
+}
+
+// Delete the expired scheduled backups from the backups folder, returning the names of the ones that were deleted
+func PruneLocal(dir string, network string, retention time.Duration, now time.Time) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading backup folder [%s]: %w", dir, err)
+	}
+	names := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+
+	deleted := []string{}
+	for _, name := range GetExpiredBackups(names, network, retention, now) {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil {
+			return deleted, fmt.Errorf("error deleting expired backup [%s]: %w", name, err)
+		}
+		deleted = append(deleted, name)
+	}
+	return deleted, nil
+}
+
+// Delete the expired scheduled backups from remote storage, returning the names of the ones that were deleted
+func PruneRemote(storage RemoteStorage, network string, retention time.Duration, now time.Time) ([]string, error) {
+	names, err := storage.List()
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := []string{}
+	for _, name := range GetExpiredBackups(names, network, retention, now) {
+		if err := storage.Delete(name); err != nil {
+			return deleted, err
+		}
+		deleted = append(deleted, name)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,289 @@
+package balances
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+const (
+	ReportFilenamePrefix    string = "balances-"
+	ReportFilenameExtension string = ".json"
+	ReportFilenameFormat    string = ReportFilenamePrefix + "%d" + ReportFilenameExtension
+)
+
+// A snapshot of the network balances calculated for a single submission
+type Snapshot struct {
+	Block                 uint64   `json:"block"`
+	SlotTimestamp         uint64   `json:"slotTimestamp"`
+	DepositPool           *big.Int `json:"depositPool"`
+	NodeCreditBalance     *big.Int `json:"nodeCreditBalance"`
+	MinipoolsTotal        *big.Int `json:"minipoolsTotal"`
+	MinipoolsStaking      *big.Int `json:"minipoolsStaking"`
+	DistributorShareTotal *big.Int `json:"distributorShareTotal"`
+	SmoothingPoolShare    *big.Int `json:"smoothingPoolShare"`
+	RETHContract          *big.Int `json:"rethContract"`
+	RETHSupply            *big.Int `json:"rethSupply"`
+	TotalETH              *big.Int `json:"totalEth"`
+}
+
+// The balances stored on-chain from the last submission that reached consensus
+type OnChainBalances struct {
+	Block      uint64   `json:"block"`
+	TotalETH   *big.Int `json:"totalEth"`
+	StakingETH *big.Int `json:"stakingEth"`
+	RETHSupply *big.Int `json:"rethSupply"`
+}
+
+// A single line of a report, describing how one balance category changed
+type Delta struct {
+	Name          string   `json:"name"`
+	Previous      *big.Int `json:"previous"`
+	Current       *big.Int `json:"current"`
+	Change        *big.Int `json:"change"`
+	ChangePercent float64  `json:"changePercent"`
+}
+
+// A report of what changed between two network balance submissions
+type Report struct {
+	GeneratedAt time.Time `json:"generatedAt"`
+
+	// The balances that are about to be submitted
+	Current Snapshot `json:"current"`
+
+	// The balances from the last report this node generated, if there was one
+	Previous *Snapshot `json:"previous,omitempty"`
+
+	// The balances that are currently stored on-chain
+	OnChain OnChainBalances `json:"onChain"`
+
+	// Changes versus the on-chain balances
+	OnChainDeltas []Delta `json:"onChainDeltas"`
+
+	// Per-category changes versus the previous report
+	CategoryDeltas []Delta `json:"categoryDeltas"`
+}
+
+// Calculate the total ETH balance of the network from a snapshot's components
+func (s *Snapshot) CalculateTotalETH() *big.Int {
+	totalEth := big.NewInt(0)
+	totalEth.Sub(totalEth, s.NodeCreditBalance)
+	totalEth.Add(totalEth, s.DepositPool)
+	totalEth.Add(totalEth, s.MinipoolsTotal)
+	totalEth.Add(totalEth, s.RETHContract)
+	totalEth.Add(totalEth, s.DistributorShareTotal)
+	totalEth.Add(totalEth, s.SmoothingPoolShare)
+	return totalEth
+}
+
+// Create a new report comparing the current snapshot with the on-chain balances and the previous snapshot (which may be nil)
+func NewReport(current Snapshot, previous *Snapshot, onChain OnChainBalances) *Report {
+	if current.TotalETH == nil {
+		current.TotalETH = current.CalculateTotalETH()
+	}
+
+	report := &Report{
+		GeneratedAt: time.Now().UTC(),
+		Current:     current,
+		Previous:    previous,
+		OnChain:     onChain,
+		OnChainDeltas: []Delta{
+			newDelta("Total ETH", onChain.TotalETH, current.TotalETH),
+			newDelta("Staking ETH", onChain.StakingETH, current.MinipoolsStaking),
+			newDelta("rETH Supply", onChain.RETHSupply, current.RETHSupply),
+		},
+	}
+
+	if previous != nil {
+		report.CategoryDeltas = []Delta{
+			newDelta("Deposit Pool", previous.DepositPool, current.DepositPool),
+			newDelta("Node Credit", previous.NodeCreditBalance, current.NodeCreditBalance),
+			newDelta("Minipools (Total)", previous.MinipoolsTotal, current.MinipoolsTotal),
+			newDelta("Minipools (Staking)", previous.MinipoolsStaking, current.MinipoolsStaking),
+			newDelta("Fee Distributors", previous.DistributorShareTotal, current.DistributorShareTotal),
+			newDelta("Smoothing Pool", previous.SmoothingPoolShare, current.SmoothingPoolShare),
+			newDelta("rETH Contract", previous.RETHContract, current.RETHContract),
+			newDelta("rETH Supply", previous.RETHSupply, current.RETHSupply),
+			newDelta("Total ETH", previous.TotalETH, current.TotalETH),
+		}
+	}
+
+	return report
+}
+
+// Get the largest absolute percent change versus the on-chain balances
+func (r *Report) GetMaxOnChainChangePercent() float64 {
+	max := float64(0)
+	for _, delta := range r.OnChainDeltas {
+		change := delta.ChangePercent
+		if change < 0 {
+			change = -change
+		}
+		if change > max {
+			max = change
+		}
+	}
+	return max
+}
+
+// Get the report as human-readable text
+func (r *Report) String() string {
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("Balances report for EL block %d (generated %s)\n", r.Current.Block, r.GeneratedAt.Format(time.RFC1123)))
+	builder.WriteString(fmt.Sprintf("\nChanges since the on-chain balances for block %d:\n", r.OnChain.Block))
+	writeDeltas(&builder, r.OnChainDeltas)
+	if r.Previous == nil {
+		builder.WriteString("\nNo previous report was found, so a per-category breakdown is not available.\n")
+	} else {
+		builder.WriteString(fmt.Sprintf("\nChanges by category since the previous report for block %d:\n", r.Previous.Block))
+		writeDeltas(&builder, r.CategoryDeltas)
+	}
+	return builder.String()
+}
+
+// Save the report to the provided folder
+func (r *Report) Save(folder string) (string, error) {
+	err := os.MkdirAll(folder, 0755)
+	if err != nil {
+		return "", fmt.Errorf("error creating balances report folder [%s]: %w", folder, err)
+	}
+
+	bytes, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error serializing balances report: %w", err)
+	}
+
+	path := filepath.Join(folder, fmt.Sprintf(ReportFilenameFormat, r.Current.Block))
+	err = files.WriteFileAtomic(path, bytes, 0644)
+	if err != nil {
+		return "", fmt.Errorf("error saving balances report to [%s]: %w", path, err)
+	}
+	return path, nil
+}
+
+// Load the report with the highest block number in the provided folder that is lower than maxBlock.
+// Use a maxBlock of 0 to get the latest report. Returns nil if there is no such report.
+func LoadLatestReport(folder string, maxBlock uint64) (*Report, error) {
+	blocks, err := getReportBlocks(folder)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(blocks) - 1; i >= 0; i-- {
+		if maxBlock != 0 && blocks[i] >= maxBlock {
+			continue
+		}
+		return LoadReport(folder, blocks[i])
+	}
+	return nil, nil
+}
+
+// Load the report for a specific block
+func LoadReport(folder string, block uint64) (*Report, error) {
+	path := filepath.Join(folder, fmt.Sprintf(ReportFilenameFormat, block))
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading balances report [%s]: %w", path, err)
+	}
+
+	var report Report
+	err = json.Unmarshal(bytes, &report)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing balances report [%s]: %w", path, err)
+	}
+	return &report, nil
+}
+
+// Delete all but the newest reports in the provided folder
+func PruneReports(folder string, retentionLimit int) error {
+	blocks, err := getReportBlocks(folder)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(blocks)-retentionLimit; i++ {
+		path := filepath.Join(folder, fmt.Sprintf(ReportFilenameFormat, blocks[i]))
+		err = os.Remove(path)
+		if err != nil {
+			return fmt.Errorf("error deleting old balances report [%s]: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// Get the block numbers of each report in the folder, in ascending order
+func getReportBlocks(folder string) ([]uint64, error) {
+	entries, err := os.ReadDir(folder)
+	if os.IsNotExist(err) {
+		return []uint64{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error enumerating balances reports in [%s]: %w", folder, err)
+	}
+
+	blocks := []uint64{}
+	for _, file := range entries {
+		filename := file.Name()
+		if file.IsDir() || !strings.HasPrefix(filename, ReportFilenamePrefix) || !strings.HasSuffix(filename, ReportFilenameExtension) {
+			continue
+		}
+		blockString := strings.TrimSuffix(strings.TrimPrefix(filename, ReportFilenamePrefix), ReportFilenameExtension)
+		block, err := strconv.ParseUint(blockString, 10, 64)
+		if err != nil {
+			continue
+		}
+		blocks = append(blocks, block)
+	}
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i] < blocks[j]
+	})
+	return blocks, nil
+}
+
+// Create a delta between two balances
+func newDelta(name string, previous *big.Int, current *big.Int) Delta {
+	if previous == nil {
+		previous = big.NewInt(0)
+	}
+	if current == nil {
+		current = big.NewInt(0)
+	}
+
+	change := big.NewInt(0).Sub(current, previous)
+	changePercent := float64(0)
+	if previous.Sign() != 0 {
+		changePercent, _ = big.NewFloat(0).Quo(new(big.Float).SetInt(change), new(big.Float).SetInt(previous)).Float64()
+		changePercent *= 100
+	}
+
+	return Delta{
+		Name:          name,
+		Previous:      previous,
+		Current:       current,
+		Change:        change,
+		ChangePercent: changePercent,
+	}
+}
+
+// Write a table of deltas to the builder
+func writeDeltas(builder *strings.Builder, deltas []Delta) {
+	for _, delta := range deltas {
+		builder.WriteString(fmt.Sprintf("  %-20s %18.6f -> %18.6f  (%+.6f, %+.4f%%)\n",
+			delta.Name+":",
+			eth.WeiToEth(delta.Previous),
+			eth.WeiToEth(delta.Current),
+			eth.WeiToEth(delta.Change),
+			delta.ChangePercent,
+		))
+	}
+}
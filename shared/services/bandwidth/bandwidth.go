@@ -0,0 +1,216 @@
+package bandwidth
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/cron"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// The network interface Docker gives containers on a bridge network
+	containerInterface string = "eth0"
+
+	// How long to wait for tc to finish in a container
+	applyTimeout = 1 * time.Minute
+)
+
+// A container's rate limits in Mbps, where 0 means no limit
+type Limits struct {
+	Upload   uint64
+	Download uint64
+}
+
+// Check if there are any limits
+func (l Limits) IsLimited() bool {
+	return l.Upload > 0 || l.Download > 0
+}
+
+// The limits applied to a container, and which run of it they were applied to
+type appliedLimits struct {
+	containerId string
+	startedAt   string
+	limits      Limits
+}
+
+// Applies the bandwidth limits to the clients' containers with tc, following the schedule
+type Limiter struct {
+	cfg     *config.RocketPoolConfig
+	d       *client.Client
+	log     *log.ColorLogger
+	applied map[string]appliedLimits
+}
+
+// Create a new limiter
+func NewLimiter(cfg *config.RocketPoolConfig, d *client.Client, logger *log.ColorLogger) *Limiter {
+	return &Limiter{
+		cfg:     cfg,
+		d:       d,
+		log:     logger,
+		applied: map[string]appliedLimits{},
+	}
+}
+
+// Get the limits each of the clients' containers should have at the given time, by container name.
+// Containers without limits are included too, so limits left over from an earlier schedule or config get removed.
+func GetLimits(cfg *config.RocketPoolConfig, t time.Time) (map[string]Limits, error) {
+	limits := map[string]Limits{}
+	if cfg.IsNativeMode {
+		return limits, nil
+	}
+
+	active := true
+	if schedule := strings.TrimSpace(cfg.Bandwidth.Schedule.Value.(string)); schedule != "" {
+		window, err := cron.ParseWindow(schedule, cfg.Bandwidth.ScheduleDays.Value.(string))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing the bandwidth limit schedule: %w", err)
+		}
+		active = window.Contains(t)
+	}
+
+	projectName := cfg.Smartnode.ProjectName.Value.(string)
+	if cfg.ExecutionClientLocal() {
+		ecLimits := Limits{}
+		if active {
+			ecLimits.Upload = cfg.Bandwidth.EcUploadLimit.Value.(uint64)
+			ecLimits.Download = cfg.Bandwidth.EcDownloadLimit.Value.(uint64)
+		}
+		limits[projectName+"_eth1"] = ecLimits
+	}
+	if cfg.ConsensusClientLocal() {
+		bnLimits := Limits{}
+		if active {
+			bnLimits.Upload = cfg.Bandwidth.BnUploadLimit.Value.(uint64)
+			bnLimits.Download = cfg.Bandwidth.BnDownloadLimit.Value.(uint64)
+		}
+		limits[projectName+"_eth2"] = bnLimits
+	}
+	return limits, nil
+}
+
+// Bring the containers' limits up to date with the schedule, reapplying them to containers that have restarted since
+func (l *Limiter) Refresh() error {
+	limits, err := GetLimits(l.cfg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for containerName, containerLimits := range limits {
+		info, err := l.d.ContainerInspect(context.Background(), containerName)
+		if client.IsErrNotFound(err) {
+			delete(l.applied, containerName)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("error inspecting container %s: %w", containerName, err)
+		}
+		if !info.State.Running {
+			delete(l.applied, containerName)
+			continue
+		}
+
+		// A restarted container starts without limits, so only skip the ones already applied to this run of it
+		state := appliedLimits{
+			containerId: info.ID,
+			startedAt:   info.State.StartedAt,
+			limits:      containerLimits,
+		}
+		if previous, exists := l.applied[containerName]; exists && previous == state {
+			continue
+		}
+		if err := l.apply(containerName, containerLimits); err != nil {
+			return err
+		}
+		l.applied[containerName] = state
+		if containerLimits.IsLimited() {
+			l.log.Printlnf("Limited %s to %s up and %s down.", containerName, formatLimit(containerLimits.Upload), formatLimit(containerLimits.Download))
+		} else {
+			l.log.Printlnf("%s has no bandwidth limits.", containerName)
+		}
+	}
+	return nil
+}
+
+// Set a container's limits by running tc in its network namespace with the Smartnode image
+func (l *Limiter) apply(containerName string, limits Limits) error {
+	ctx, cancel := context.WithTimeout(context.Background(), applyTimeout)
+	defer cancel()
+
+	helper, err := l.d.ContainerCreate(ctx, &container.Config{
+		Image:      l.cfg.Smartnode.GetSmartnodeContainerTag(),
+		Entrypoint: []string{"sh", "-c"},
+		Cmd:        []string{getTcScript(limits)},
+	}, &container.HostConfig{
+		NetworkMode: container.NetworkMode("container:" + containerName),
+		CapAdd:      []string{"NET_ADMIN"},
+	}, nil, nil, "")
+	if err != nil {
+		return fmt.Errorf("error creating the container to set %s's bandwidth limits: %w", containerName, err)
+	}
+	defer l.d.ContainerRemove(context.Background(), helper.ID, types.ContainerRemoveOptions{Force: true})
+
+	if err := l.d.ContainerStart(ctx, helper.ID, types.ContainerStartOptions{}); err != nil {
+		return fmt.Errorf("error starting the container to set %s's bandwidth limits: %w", containerName, err)
+	}
+	statusChannel, errChannel := l.d.ContainerWait(ctx, helper.ID, container.WaitConditionNotRunning)
+	select {
+	case err := <-errChannel:
+		return fmt.Errorf("error waiting for %s's bandwidth limits to be set: %w", containerName, err)
+	case status := <-statusChannel:
+		if status.StatusCode == 0 {
+			return nil
+		}
+		output := &bytes.Buffer{}
+		if logs, err := l.d.ContainerLogs(context.Background(), helper.ID, types.ContainerLogsOptions{ShowStdout: true, ShowStderr: true}); err == nil {
+			stdcopy.StdCopy(output, output, logs)
+			logs.Close()
+		}
+		return fmt.Errorf("tc couldn't set %s's bandwidth limits (exit code %d): %s", containerName, status.StatusCode, strings.TrimSpace(output.String()))
+	}
+}
+
+// Get the tc commands that clear a container's limits and set the new ones.
+// Uploads are shaped with a token bucket; downloads can only be policed, so packets over the limit are dropped.
+func getTcScript(limits Limits) string {
+	commands := []string{
+		fmt.Sprintf("tc qdisc del dev %s root 2>/dev/null; tc qdisc del dev %s ingress 2>/dev/null; true", containerInterface, containerInterface),
+	}
+	if limits.Upload > 0 {
+		commands = append(commands, fmt.Sprintf("tc qdisc add dev %s root tbf rate %dmbit burst %dkb latency 400ms", containerInterface, limits.Upload, getBurst(limits.Upload)))
+	}
+	if limits.Download > 0 {
+		commands = append(commands,
+			fmt.Sprintf("tc qdisc add dev %s handle ffff: ingress", containerInterface),
+			fmt.Sprintf("tc filter add dev %s parent ffff: protocol all u32 match u32 0 0 police rate %dmbit burst %dkb drop flowid :1", containerInterface, limits.Download, getBurst(limits.Download)),
+		)
+	}
+	return strings.Join(commands, " && ")
+}
+
+// Get the burst size in kilobytes for a rate, which covers about 100ms of traffic
+func getBurst(rate uint64) uint64 {
+	burst := rate * 125 / 10
+	if burst < 32 {
+		return 32
+	}
+	return burst
+}
+
+// Format a limit for the logs
+func formatLimit(limit uint64) string {
+	if limit == 0 {
+		return "no limit"
+	}
+	return fmt.Sprintf("%d Mbps", limit)
+}
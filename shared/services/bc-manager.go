@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -21,8 +22,16 @@ const bnContainerName string = "eth2"
 type BeaconClientManager struct {
 	primaryBc       beacon.Client
 	fallbackBc      beacon.Client
+	fallbackBcUrls  []string
+	fallbackBcs     []beacon.Client
+	fallbackIndex   int
+	statusCache     *beacon.ValidatorStatusCache
 	logger          log.ColorLogger
+	policy          clientCallPolicy
+	primaryBreaker  *circuitBreaker
+	fallbackBreaker *circuitBreaker
 	primaryReady    bool
+	primaryDisabled bool
 	fallbackReady   bool
 	ignoreSyncCheck bool
 }
@@ -41,52 +50,53 @@ func NewBeaconClientManager(cfg *config.RocketPoolConfig) (*BeaconClientManager,
 
 	// Primary CC
 	var primaryProvider string
-	var selectedCC cfgtypes.ConsensusClient
 	if cfg.IsNativeMode {
 		primaryProvider = cfg.Native.CcHttpUrl.Value.(string)
-		selectedCC = cfg.Native.ConsensusClient.Value.(cfgtypes.ConsensusClient)
 	} else if cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
 		primaryProvider = fmt.Sprintf("http://%s:%d", bnContainerName, cfg.ConsensusCommon.ApiPort.Value.(uint16))
-		selectedCC = cfg.ConsensusClient.Value.(cfgtypes.ConsensusClient)
 	} else if cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_External {
 		selectedConsensusConfig, err := cfg.GetSelectedConsensusClientConfig()
 		if err != nil {
 			return nil, err
 		}
 		primaryProvider = selectedConsensusConfig.(cfgtypes.ExternalConsensusConfig).GetApiUrl()
-		selectedCC = cfg.ExternalConsensusClient.Value.(cfgtypes.ConsensusClient)
 	} else {
 		return nil, fmt.Errorf("Unknown Consensus client mode '%v'", cfg.ConsensusClientMode.Value)
 	}
 
-	// Fallback CC
-	var fallbackProvider string
-	if cfg.UseFallbackClients.Value == true {
-		if cfg.IsNativeMode {
-			fallbackProvider = cfg.FallbackNormal.CcHttpUrl.Value.(string)
-		} else {
-			switch selectedCC {
-			case cfgtypes.ConsensusClient_Prysm:
-				fallbackProvider = cfg.FallbackPrysm.CcHttpUrl.Value.(string)
-			default:
-				fallbackProvider = cfg.FallbackNormal.CcHttpUrl.Value.(string)
-			}
-		}
+	chunkSize := int(cfg.Smartnode.ValidatorStatusChunkSize.Value.(uint64))
+
+	// Fallback CCs
+	pairs, err := cfg.GetFallbackClientPairs()
+	if err != nil {
+		return nil, err
+	}
+	fallbackBcUrls := []string{}
+	fallbackBcs := []beacon.Client{}
+	for _, pair := range pairs {
+		fallbackBcUrls = append(fallbackBcUrls, pair.CcHttpUrl)
+		fallbackBcs = append(fallbackBcs, client.NewStandardHttpClient(pair.CcHttpUrl, chunkSize))
 	}
 
 	var primaryBc beacon.Client
 	var fallbackBc beacon.Client
-	primaryBc = client.NewStandardHttpClient(primaryProvider)
-	if fallbackProvider != "" {
-		fallbackBc = client.NewStandardHttpClient(fallbackProvider)
+	primaryBc = client.NewStandardHttpClient(primaryProvider, chunkSize)
+	if len(fallbackBcs) > 0 {
+		fallbackBc = fallbackBcs[0]
 	}
 
+	policy := newClientCallPolicy(cfg)
 	return &BeaconClientManager{
-		primaryBc:     primaryBc,
-		fallbackBc:    fallbackBc,
-		logger:        log.NewColorLogger(color.FgHiBlue),
-		primaryReady:  true,
-		fallbackReady: fallbackBc != nil,
+		primaryBc:       primaryBc,
+		fallbackBc:      fallbackBc,
+		fallbackBcUrls:  fallbackBcUrls,
+		fallbackBcs:     fallbackBcs,
+		logger:          log.NewColorLogger(color.FgHiBlue),
+		policy:          policy,
+		primaryBreaker:  &circuitBreaker{threshold: policy.breakerThreshold},
+		fallbackBreaker: &circuitBreaker{threshold: policy.breakerThreshold},
+		primaryReady:    true,
+		fallbackReady:   fallbackBc != nil,
 	}, nil
 
 }
@@ -106,6 +116,28 @@ func (m *BeaconClientManager) GetClientType() (beacon.BeaconClientType, error) {
 	return result.(beacon.BeaconClientType), nil
 }
 
+// Get the name and version the client reports
+func (m *BeaconClientManager) GetNodeVersion() (string, error) {
+	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		return client.GetNodeVersion()
+	})
+	if err != nil {
+		return "", err
+	}
+	return result.(string), nil
+}
+
+// Get the number of peers the client is connected to
+func (m *BeaconClientManager) GetPeerCount() (uint64, error) {
+	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		return client.GetPeerCount()
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(uint64), nil
+}
+
 // Get the client's sync status
 func (m *BeaconClientManager) GetSyncStatus() (beacon.SyncStatus, error) {
 	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
@@ -206,13 +238,55 @@ func (m *BeaconClientManager) GetValidatorStatus(pubkey types.ValidatorPubkey, o
 
 // Get the statuses of multiple validators by their pubkeys
 func (m *BeaconClientManager) GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (map[types.ValidatorPubkey]beacon.ValidatorStatus, error) {
+	if m.statusCache == nil {
+		result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+			return client.GetValidatorStatuses(pubkeys, opts)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result.(map[types.ValidatorPubkey]beacon.ValidatorStatus), nil
+	}
+
+	// Only request the statuses that aren't cached yet
+	statuses, uncached := m.statusCache.Get(pubkeys, opts)
+	if len(uncached) == 0 {
+		return statuses, nil
+	}
 	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
-		return client.GetValidatorStatuses(pubkeys, opts)
+		return client.GetValidatorStatuses(uncached, opts)
 	})
 	if err != nil {
 		return nil, err
 	}
-	return result.(map[types.ValidatorPubkey]beacon.ValidatorStatus), nil
+	newStatuses := result.(map[types.ValidatorPubkey]beacon.ValidatorStatus)
+	m.statusCache.Add(uncached, newStatuses, opts)
+	for pubkey, status := range newStatuses {
+		statuses[pubkey] = status
+	}
+	return statuses, nil
+}
+
+// Get multiple validators' statuses by their indices
+func (m *BeaconClientManager) GetValidatorStatusesByIndex(indices []string, opts *beacon.ValidatorStatusOptions) (map[string]beacon.ValidatorStatus, error) {
+	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		return client.GetValidatorStatusesByIndex(indices, opts)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[string]beacon.ValidatorStatus), nil
+}
+
+// Get the statuses of every validator in one of the provided states
+func (m *BeaconClientManager) GetValidatorStatusesByState(states []beacon.ValidatorState) ([]beacon.ValidatorStatus, error) {
+	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		return client.GetValidatorStatusesByState(states)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]beacon.ValidatorStatus), nil
 }
 
 // Get a validator's index
@@ -248,6 +322,17 @@ func (m *BeaconClientManager) GetValidatorProposerDuties(indices []string, epoch
 	return result.(map[string]uint64), nil
 }
 
+// Get the slots of a validator's proposer duties
+func (m *BeaconClientManager) GetValidatorProposerSlots(indices []string, epoch uint64) (map[string][]uint64, error) {
+	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		return client.GetValidatorProposerSlots(indices, epoch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[string][]uint64), nil
+}
+
 // Get the Beacon chain's domain data
 func (m *BeaconClientManager) GetDomainData(domainType []byte, epoch uint64, useGenesisFork bool) ([]byte, error) {
 	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
@@ -308,6 +393,17 @@ func (m *BeaconClientManager) ChangeWithdrawalCredentials(validatorIndex string,
 	return nil
 }
 
+// Change the withdrawal credentials for several validators at once
+func (m *BeaconClientManager) ChangeWithdrawalCredentialsBatch(changes []beacon.WithdrawalCredentialsChange) error {
+	err := m.runFunction0(func(client beacon.Client) error {
+		return client.ChangeWithdrawalCredentialsBatch(changes)
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 /// ==================
 /// Internal Functions
 /// ==================
@@ -340,6 +436,10 @@ func (m *BeaconClientManager) CheckStatus() *api.ClientManagerStatus {
 	// Flag the ready clients
 	m.primaryReady = (status.PrimaryClientStatus.IsWorking && status.PrimaryClientStatus.IsSynced)
 	m.fallbackReady = (status.FallbackEnabled && status.FallbackClientStatus.IsWorking && status.FallbackClientStatus.IsSynced)
+	if m.primaryReady && m.primaryDisabled {
+		m.primaryReady = false
+		status.PrimaryClientStatus.Error = "The primary Beacon Node is unavailable, so the Smartnode has switched to a fallback client pair"
+	}
 
 	return status
 
@@ -375,53 +475,22 @@ func checkBcStatus(client beacon.Client) api.ClientStatus {
 
 // Attempts to run a function progressively through each client until one succeeds or they all fail.
 func (m *BeaconClientManager) runFunction0(function bcFunction0) error {
-
-	// Check if we can use the primary
-	if m.primaryReady {
-		// Try to run the function on the primary
-		err := function(m.primaryBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Primary Beacon client disconnected (%s), using fallback...", err.Error())
-				m.primaryReady = false
-				return m.runFunction0(function)
-			}
-			// If it's a different error, just return it
-			return err
-		}
-		// If there's no error, return the result
-		return nil
-	}
-
-	if m.fallbackReady {
-		// Try to run the function on the fallback
-		err := function(m.fallbackBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Fallback Beacon client disconnected (%s)", err.Error())
-				m.fallbackReady = false
-				return fmt.Errorf("all Beacon clients failed")
-			}
-
-			// If it's a different error, just return it
-			return err
-		}
-		// If there's no error, return the result
-		return nil
-	}
-
-	return fmt.Errorf("no Beacon clients were ready")
+	_, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		return nil, function(client)
+	})
+	return err
 }
 
 // Attempts to run a function progressively through each client until one succeeds or they all fail.
+// Transient failures are retried on the same client, and a client that keeps failing is skipped until its circuit breaker's cooldown is over.
 func (m *BeaconClientManager) runFunction1(function bcFunction1) (interface{}, error) {
 
-	// Check if we can use the primary
-	if m.primaryReady {
+	// Check if we can use the primary; its breaker only matters if there's a fallback to use instead
+	if m.primaryReady && (m.primaryBreaker.allow() || !m.fallbackReady) {
 		// Try to run the function on the primary
-		result, err := function(m.primaryBc)
+		result, err := callWithRetry(context.Background(), m.policy, m.primaryBreaker, m.fallbackReady, func() (interface{}, error) {
+			return function(m.primaryBc)
+		})
 		if err != nil {
 			if m.isDisconnected(err) {
 				// If it's disconnected, log it and try the fallback
@@ -429,6 +498,11 @@ func (m *BeaconClientManager) runFunction1(function bcFunction1) (interface{}, e
 				m.primaryReady = false
 				return m.runFunction1(function)
 			}
+			if m.primaryBreaker.isOpen() && m.fallbackReady {
+				// If it keeps failing, stop using it until the breaker's cooldown is over
+				m.logger.Printlnf("WARNING: Primary Beacon client keeps failing (%s), using fallback for %s...", err.Error(), circuitBreakerCooldown)
+				return m.runFunction1(function)
+			}
 			// If it's a different error, just return it
 			return nil, err
 		}
@@ -436,9 +510,12 @@ func (m *BeaconClientManager) runFunction1(function bcFunction1) (interface{}, e
 		return result, nil
 	}
 
-	if m.fallbackReady {
+	// Likewise, the fallback's breaker only matters if the primary is ready
+	if m.fallbackReady && (m.fallbackBreaker.allow() || !m.primaryReady) {
 		// Try to run the function on the fallback
-		result, err := function(m.fallbackBc)
+		result, err := callWithRetry(context.Background(), m.policy, m.fallbackBreaker, false, func() (interface{}, error) {
+			return function(m.fallbackBc)
+		})
 		if err != nil {
 			if m.isDisconnected(err) {
 				// If it's disconnected, log it and try the fallback
@@ -453,50 +530,47 @@ func (m *BeaconClientManager) runFunction1(function bcFunction1) (interface{}, e
 		return result, nil
 	}
 
+	if m.primaryBreaker.isOpen() || m.fallbackBreaker.isOpen() {
+		return nil, fmt.Errorf("all available Beacon clients have failed too many times in a row; waiting for them to recover")
+	}
 	return nil, fmt.Errorf("no Beacon clients were ready")
 
 }
 
+// The results of a wrapped Beacon client function that returns 2 vars
+type bcResultPair struct {
+	result1 interface{}
+	result2 interface{}
+}
+
 // Attempts to run a function progressively through each client until one succeeds or they all fail.
 func (m *BeaconClientManager) runFunction2(function bcFunction2) (interface{}, interface{}, error) {
-
-	// Check if we can use the primary
-	if m.primaryReady {
-		// Try to run the function on the primary
-		result1, result2, err := function(m.primaryBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Primary Beacon client disconnected (%s), using fallback...", err.Error())
-				m.primaryReady = false
-				return m.runFunction2(function)
-			}
-			// If it's a different error, just return it
-			return nil, nil, err
-		}
-		// If there's no error, return the result
-		return result1, result2, nil
-	}
-
-	if m.fallbackReady {
-		// Try to run the function on the fallback
-		result1, result2, err := function(m.fallbackBc)
+	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		result1, result2, err := function(client)
 		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Fallback Beacon client disconnected (%s)", err.Error())
-				m.fallbackReady = false
-				return nil, nil, fmt.Errorf("all Beacon clients failed")
-			}
-			// If it's a different error, just return it
-			return nil, nil, err
+			return nil, err
 		}
-		// If there's no error, return the result
-		return result1, result2, nil
+		return bcResultPair{result1: result1, result2: result2}, nil
+	})
+	if err != nil {
+		return nil, nil, err
 	}
+	pair := result.(bcResultPair)
+	return pair.result1, pair.result2, nil
+}
 
-	return nil, nil, fmt.Errorf("no Beacon clients were ready")
+// Share a validator status cache between every caller of this manager.
+// Only requests for a specific slot or epoch are cached.
+func (m *BeaconClientManager) SetValidatorStatusCache(cache *beacon.ValidatorStatusCache) {
+	m.statusCache = cache
+}
 
+// Switch to the fallback client at the given index
+func (m *BeaconClientManager) setActiveFallback(index int) {
+	m.fallbackIndex = index
+	m.fallbackBc = m.fallbackBcs[index]
+	m.fallbackReady = true
+	m.fallbackBreaker.reset()
 }
 
 // Returns true if the error was a connection failure and a backup client is available
@@ -51,6 +51,12 @@ type ValidatorStatus struct {
 	WithdrawableEpoch          uint64
 	Exists                     bool
 }
+type WithdrawalCredentialsChange struct {
+	ValidatorIndex     string
+	FromBlsPubkey      types.ValidatorPubkey
+	ToExecutionAddress common.Address
+	Signature          types.ValidatorSignature
+}
 type Eth1Data struct {
 	DepositRoot  common.Hash
 	DepositCount uint64
@@ -63,10 +69,16 @@ type BeaconBlock struct {
 	Attestations         []AttestationInfo
 	FeeRecipient         common.Address
 	ExecutionBlockNumber uint64
+	Withdrawals          []WithdrawalInfo
+}
+type WithdrawalInfo struct {
+	ValidatorIndex uint64
+	Amount         uint64
 }
 type BeaconBlockHeader struct {
 	Slot          uint64
 	ProposerIndex string
+	Root          common.Hash
 }
 
 // Committees is an interface as an optimization- since committees responses
@@ -96,6 +108,8 @@ type AttestationInfo struct {
 	AggregationBits bitfield.Bitlist
 	SlotIndex       uint64
 	CommitteeIndex  uint64
+	BeaconBlockRoot common.Hash
+	TargetRoot      common.Hash
 }
 
 // Beacon client type
@@ -134,6 +148,8 @@ const (
 type Client interface {
 	GetClientType() (BeaconClientType, error)
 	GetSyncStatus() (SyncStatus, error)
+	GetNodeVersion() (string, error)
+	GetPeerCount() (uint64, error)
 	GetEth2Config() (Eth2Config, error)
 	GetEth2DepositContract() (Eth2DepositContract, error)
 	GetAttestations(blockId string) ([]AttestationInfo, bool, error)
@@ -143,13 +159,17 @@ type Client interface {
 	GetValidatorStatusByIndex(index string, opts *ValidatorStatusOptions) (ValidatorStatus, error)
 	GetValidatorStatus(pubkey types.ValidatorPubkey, opts *ValidatorStatusOptions) (ValidatorStatus, error)
 	GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *ValidatorStatusOptions) (map[types.ValidatorPubkey]ValidatorStatus, error)
+	GetValidatorStatusesByIndex(indices []string, opts *ValidatorStatusOptions) (map[string]ValidatorStatus, error)
+	GetValidatorStatusesByState(states []ValidatorState) ([]ValidatorStatus, error)
 	GetValidatorIndex(pubkey types.ValidatorPubkey) (string, error)
 	GetValidatorSyncDuties(indices []string, epoch uint64) (map[string]bool, error)
 	GetValidatorProposerDuties(indices []string, epoch uint64) (map[string]uint64, error)
+	GetValidatorProposerSlots(indices []string, epoch uint64) (map[string][]uint64, error)
 	GetDomainData(domainType []byte, epoch uint64, useGenesisFork bool) ([]byte, error)
 	ExitValidator(validatorIndex string, epoch uint64, signature types.ValidatorSignature) error
 	Close() error
 	GetEth1DataForEth2Block(blockId string) (Eth1Data, bool, error)
 	GetCommitteesForEpoch(epoch *uint64) (Committees, error)
 	ChangeWithdrawalCredentials(validatorIndex string, fromBlsPubkey types.ValidatorPubkey, toExecutionAddress common.Address, signature types.ValidatorSignature) error
+	ChangeWithdrawalCredentialsBatch(changes []WithdrawalCredentialsChange) error
 }
@@ -3,6 +3,7 @@ package client
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -29,6 +30,8 @@ const (
 	RequestContentType = "application/json"
 
 	RequestSyncStatusPath                  = "/eth/v1/node/syncing"
+	RequestNodeVersionPath                 = "/eth/v1/node/version"
+	RequestPeerCountPath                   = "/eth/v1/node/peer_count"
 	RequestEth2ConfigPath                  = "/eth/v1/config/spec"
 	RequestEth2DepositContractMethod       = "/eth/v1/config/deposit_contract"
 	RequestGenesisPath                     = "/eth/v1/beacon/genesis"
@@ -46,17 +49,28 @@ const (
 
 	MaxRequestValidatorsCount     = 600
 	threadLimit               int = 12
+
+	validatorRequestRetries = 3
+	validatorRequestBackoff = time.Second
 )
 
+// Returned when the Beacon Node rejects a validator request for asking for too many validators at once
+var errValidatorRequestTooLarge = errors.New("the request had too many validators")
+
 // Beacon client using the standard Beacon HTTP REST API (https://ethereum.github.io/beacon-APIs/)
 type StandardHttpClient struct {
-	providerAddress string
+	providerAddress    string
+	validatorChunkSize int
 }
 
-// Create a new client instance
-func NewStandardHttpClient(providerAddress string) *StandardHttpClient {
+// Create a new client instance; validatorChunkSize is the most validators to request at once, or 0 for the default
+func NewStandardHttpClient(providerAddress string, validatorChunkSize int) *StandardHttpClient {
+	if validatorChunkSize <= 0 {
+		validatorChunkSize = MaxRequestValidatorsCount
+	}
 	return &StandardHttpClient{
-		providerAddress: providerAddress,
+		providerAddress:    providerAddress,
+		validatorChunkSize: validatorChunkSize,
 	}
 }
 
@@ -90,6 +104,38 @@ func (c *StandardHttpClient) GetSyncStatus() (beacon.SyncStatus, error) {
 
 }
 
+// Get the name and version the node reports
+func (c *StandardHttpClient) GetNodeVersion() (string, error) {
+	responseBody, status, err := c.getRequest(RequestNodeVersionPath)
+	if err != nil {
+		return "", fmt.Errorf("Could not get node version: %w", err)
+	}
+	if status != http.StatusOK {
+		return "", fmt.Errorf("Could not get node version: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	var version NodeVersionResponse
+	if err := json.Unmarshal(responseBody, &version); err != nil {
+		return "", fmt.Errorf("Could not decode node version: %w", err)
+	}
+	return version.Data.Version, nil
+}
+
+// Get the number of peers the client is connected to
+func (c *StandardHttpClient) GetPeerCount() (uint64, error) {
+	responseBody, status, err := c.getRequest(RequestPeerCountPath)
+	if err != nil {
+		return 0, fmt.Errorf("Could not get peer count: %w", err)
+	}
+	if status != http.StatusOK {
+		return 0, fmt.Errorf("Could not get peer count: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	var peerCount PeerCountResponse
+	if err := json.Unmarshal(responseBody, &peerCount); err != nil {
+		return 0, fmt.Errorf("Could not decode peer count: %w", err)
+	}
+	return uint64(peerCount.Data.Connected), nil
+}
+
 // Get the eth2 config
 func (c *StandardHttpClient) GetEth2Config() (beacon.Eth2Config, error) {
 
@@ -231,6 +277,44 @@ func (c *StandardHttpClient) getValidatorStatus(pubkeyOrIndex string, opts *beac
 
 }
 
+// Get the statuses of every validator in one of the provided states at the head of the chain
+func (c *StandardHttpClient) GetValidatorStatusesByState(states []beacon.ValidatorState) ([]beacon.ValidatorStatus, error) {
+	stateStrings := make([]string, len(states))
+	for i, state := range states {
+		stateStrings[i] = string(state)
+	}
+	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestValidatorsPath, "head") + fmt.Sprintf("?status=%s", strings.Join(stateStrings, ",")))
+	if err != nil {
+		return nil, fmt.Errorf("Could not get validators by state: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("Could not get validators by state: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	var validators ValidatorsResponse
+	if err := json.Unmarshal(responseBody, &validators); err != nil {
+		return nil, fmt.Errorf("Could not decode validators: %w", err)
+	}
+
+	statuses := make([]beacon.ValidatorStatus, len(validators.Data))
+	for i, validator := range validators.Data {
+		statuses[i] = beacon.ValidatorStatus{
+			Pubkey:                     types.BytesToValidatorPubkey(validator.Validator.Pubkey),
+			Index:                      validator.Index,
+			WithdrawalCredentials:      common.BytesToHash(validator.Validator.WithdrawalCredentials),
+			Balance:                    uint64(validator.Balance),
+			EffectiveBalance:           uint64(validator.Validator.EffectiveBalance),
+			Status:                     beacon.ValidatorState(validator.Status),
+			Slashed:                    validator.Validator.Slashed,
+			ActivationEligibilityEpoch: uint64(validator.Validator.ActivationEligibilityEpoch),
+			ActivationEpoch:            uint64(validator.Validator.ActivationEpoch),
+			ExitEpoch:                  uint64(validator.Validator.ExitEpoch),
+			WithdrawableEpoch:          uint64(validator.Validator.WithdrawableEpoch),
+			Exists:                     true,
+		}
+	}
+	return statuses, nil
+}
+
 // Get multiple validators' statuses
 func (c *StandardHttpClient) GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (map[types.ValidatorPubkey]beacon.ValidatorStatus, error) {
 
@@ -311,6 +395,50 @@ func (c *StandardHttpClient) GetValidatorStatuses(pubkeys []types.ValidatorPubke
 
 }
 
+// Get multiple validators' statuses by their indices, which is cheaper for the Beacon Node than looking them up by pubkey
+func (c *StandardHttpClient) GetValidatorStatusesByIndex(indices []string, opts *beacon.ValidatorStatusOptions) (map[string]beacon.ValidatorStatus, error) {
+
+	// Filter out empty and duplicate indices
+	realIndices := []string{}
+	seen := map[string]bool{}
+	for _, index := range indices {
+		if index == "" || seen[index] {
+			continue
+		}
+		seen[index] = true
+		realIndices = append(realIndices, index)
+	}
+
+	// Get validators
+	validators, err := c.getValidatorsByOpts(realIndices, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build validator status map
+	statuses := make(map[string]beacon.ValidatorStatus)
+	for _, validator := range validators.Data {
+		statuses[validator.Index] = beacon.ValidatorStatus{
+			Pubkey:                     types.BytesToValidatorPubkey(validator.Validator.Pubkey),
+			Index:                      validator.Index,
+			WithdrawalCredentials:      common.BytesToHash(validator.Validator.WithdrawalCredentials),
+			Balance:                    uint64(validator.Balance),
+			EffectiveBalance:           uint64(validator.Validator.EffectiveBalance),
+			Status:                     beacon.ValidatorState(validator.Status),
+			Slashed:                    validator.Validator.Slashed,
+			ActivationEligibilityEpoch: uint64(validator.Validator.ActivationEligibilityEpoch),
+			ActivationEpoch:            uint64(validator.Validator.ActivationEpoch),
+			ExitEpoch:                  uint64(validator.Validator.ExitEpoch),
+			WithdrawableEpoch:          uint64(validator.Validator.WithdrawableEpoch),
+			Exists:                     true,
+		}
+	}
+
+	// Return
+	return statuses, nil
+
+}
+
 // Get whether validators have sync duties to perform at given epoch
 func (c *StandardHttpClient) GetValidatorSyncDuties(indices []string, epoch uint64) (map[string]bool, error) {
 
@@ -379,6 +507,38 @@ func (c *StandardHttpClient) GetValidatorProposerDuties(indices []string, epoch
 	return proposerMap, nil
 }
 
+// Get the slots that validators are scheduled to propose in during an epoch
+func (c *StandardHttpClient) GetValidatorProposerSlots(indices []string, epoch uint64) (map[string][]uint64, error) {
+
+	// Perform the post request
+	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestValidatorProposerDuties, strconv.FormatUint(epoch, 10)))
+
+	if err != nil {
+		return nil, fmt.Errorf("Could not get validator proposer duties: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("Could not get validator proposer duties: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+
+	var response ProposerDutiesResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("Could not decode validator proposer duties data: %w", err)
+	}
+
+	// Map the results
+	slotMap := make(map[string][]uint64, len(indices))
+	for _, index := range indices {
+		slotMap[index] = []uint64{}
+	}
+	for _, duty := range response.Data {
+		if slots, exists := slotMap[duty.ValidatorIndex]; exists {
+			slotMap[duty.ValidatorIndex] = append(slots, uint64(duty.Slot))
+		}
+	}
+
+	return slotMap, nil
+}
+
 // Get a validator's index
 func (c *StandardHttpClient) GetValidatorIndex(pubkey types.ValidatorPubkey) (string, error) {
 
@@ -489,6 +649,8 @@ func (c *StandardHttpClient) GetAttestations(blockId string) ([]beacon.Attestati
 		bitString := hexutil.RemovePrefix(attestation.AggregationBits)
 		attestationInfo[i].SlotIndex = uint64(attestation.Data.Slot)
 		attestationInfo[i].CommitteeIndex = uint64(attestation.Data.Index)
+		attestationInfo[i].BeaconBlockRoot = common.HexToHash(attestation.Data.BeaconBlockRoot)
+		attestationInfo[i].TargetRoot = common.HexToHash(attestation.Data.Target.Root)
 		attestationInfo[i].AggregationBits, err = hex.DecodeString(bitString)
 		if err != nil {
 			return nil, false, fmt.Errorf("Error decoding aggregation bits for attestation %d of block %s: %w", i, blockId, err)
@@ -519,14 +681,22 @@ func (c *StandardHttpClient) GetBeaconBlock(blockId string) (beacon.BeaconBlock,
 		beaconBlock.HasExecutionPayload = true
 		beaconBlock.FeeRecipient = common.BytesToAddress(block.Data.Message.Body.ExecutionPayload.FeeRecipient)
 		beaconBlock.ExecutionBlockNumber = uint64(block.Data.Message.Body.ExecutionPayload.BlockNumber)
+		for _, withdrawal := range block.Data.Message.Body.ExecutionPayload.Withdrawals {
+			beaconBlock.Withdrawals = append(beaconBlock.Withdrawals, beacon.WithdrawalInfo{
+				ValidatorIndex: uint64(withdrawal.ValidatorIndex),
+				Amount:         uint64(withdrawal.Amount),
+			})
+		}
 	}
 
 	// Add attestation info
 	for i, attestation := range block.Data.Message.Body.Attestations {
 		bitString := hexutil.RemovePrefix(attestation.AggregationBits)
 		info := beacon.AttestationInfo{
-			SlotIndex:      uint64(attestation.Data.Slot),
-			CommitteeIndex: uint64(attestation.Data.Index),
+			SlotIndex:       uint64(attestation.Data.Slot),
+			CommitteeIndex:  uint64(attestation.Data.Index),
+			BeaconBlockRoot: common.HexToHash(attestation.Data.BeaconBlockRoot),
+			TargetRoot:      common.HexToHash(attestation.Data.Target.Root),
 		}
 		info.AggregationBits, err = hex.DecodeString(bitString)
 		if err != nil {
@@ -550,6 +720,7 @@ func (c *StandardHttpClient) GetBeaconBlockHeader(blockId string) (beacon.Beacon
 	beaconBlock := beacon.BeaconBlockHeader{
 		Slot:          uint64(block.Data.Header.Message.Slot),
 		ProposerIndex: block.Data.Header.Message.ProposerIndex,
+		Root:          common.HexToHash(block.Data.Root),
 	}
 	return beaconBlock, true, nil
 }
@@ -576,6 +747,29 @@ func (c *StandardHttpClient) ChangeWithdrawalCredentials(validatorIndex string,
 	})
 }
 
+// Change the withdrawal credentials for several validators in a single request
+func (c *StandardHttpClient) ChangeWithdrawalCredentialsBatch(changes []beacon.WithdrawalCredentialsChange) error {
+	requestArray := make([]BLSToExecutionChangeRequest, len(changes))
+	for i, change := range changes {
+		requestArray[i] = BLSToExecutionChangeRequest{
+			Message: BLSToExecutionChangeMessage{
+				ValidatorIndex:     change.ValidatorIndex,
+				FromBLSPubkey:      change.FromBlsPubkey[:],
+				ToExecutionAddress: change.ToExecutionAddress[:],
+			},
+			Signature: change.Signature.Bytes(),
+		}
+	}
+	responseBody, status, err := c.postRequest(RequestWithdrawalCredentialsChangePath, requestArray)
+	if err != nil {
+		return fmt.Errorf("Could not broadcast %d withdrawal credentials changes: %w", len(changes), err)
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("Could not broadcast %d withdrawal credentials changes: HTTP status %d; response body: '%s'", len(changes), status, string(responseBody))
+	}
+	return nil
+}
+
 // Get sync status
 func (c *StandardHttpClient) getSyncStatus() (SyncStatusResponse, error) {
 	responseBody, status, err := c.getRequest(RequestSyncStatusPath)
@@ -682,6 +876,9 @@ func (c *StandardHttpClient) getValidators(stateId string, pubkeys []string) (Va
 	if err != nil {
 		return ValidatorsResponse{}, fmt.Errorf("Could not get validators: %w", err)
 	}
+	if status == http.StatusRequestURITooLong || status == http.StatusRequestEntityTooLarge {
+		return ValidatorsResponse{}, fmt.Errorf("Could not get validators: %w (HTTP status %d)", errValidatorRequestTooLarge, status)
+	}
 	if status != http.StatusOK {
 		return ValidatorsResponse{}, fmt.Errorf("Could not get validators: HTTP status %d; response body: '%s'", status, string(responseBody))
 	}
@@ -692,6 +889,39 @@ func (c *StandardHttpClient) getValidators(stateId string, pubkeys []string) (Va
 	return validators, nil
 }
 
+// Get validators, retrying with a backoff if the request fails and splitting it in half if the Beacon Node says it's too large.
+// Each chunk is retried on its own, so one failed chunk of a large query doesn't make the client manager repeat all of them.
+func (c *StandardHttpClient) getValidatorsWithRetry(stateId string, pubkeysOrIndices []string) (ValidatorsResponse, error) {
+	var err error
+	for attempt := 0; attempt <= validatorRequestRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(validatorRequestBackoff << (attempt - 1))
+		}
+
+		var validators ValidatorsResponse
+		validators, err = c.getValidators(stateId, pubkeysOrIndices)
+		if err == nil {
+			return validators, nil
+		}
+		if !errors.Is(err, errValidatorRequestTooLarge) || len(pubkeysOrIndices) < 2 {
+			continue
+		}
+
+		// Split the request, since retrying it as-is will never work
+		half := len(pubkeysOrIndices) / 2
+		first, err := c.getValidatorsWithRetry(stateId, pubkeysOrIndices[:half])
+		if err != nil {
+			return ValidatorsResponse{}, err
+		}
+		second, err := c.getValidatorsWithRetry(stateId, pubkeysOrIndices[half:])
+		if err != nil {
+			return ValidatorsResponse{}, err
+		}
+		return ValidatorsResponse{Data: append(first.Data, second.Data...)}, nil
+	}
+	return ValidatorsResponse{}, err
+}
+
 // Get validators by pubkeys and status options
 func (c *StandardHttpClient) getValidatorsByOpts(pubkeysOrIndices []string, opts *beacon.ValidatorStatusOptions) (ValidatorsResponse, error) {
 
@@ -722,9 +952,9 @@ func (c *StandardHttpClient) getValidatorsByOpts(pubkeysOrIndices []string, opts
 	validFlags := make([]bool, count)
 	var wg errgroup.Group
 	wg.SetLimit(threadLimit)
-	for i := 0; i < count; i += MaxRequestValidatorsCount {
+	for i := 0; i < count; i += c.validatorChunkSize {
 		i := i
-		max := i + MaxRequestValidatorsCount
+		max := i + c.validatorChunkSize
 		if max > count {
 			max = count
 		}
@@ -732,7 +962,7 @@ func (c *StandardHttpClient) getValidatorsByOpts(pubkeysOrIndices []string, opts
 		wg.Go(func() error {
 			// Get & add validators
 			batch := pubkeysOrIndices[i:max]
-			validators, err := c.getValidators(stateId, batch)
+			validators, err := c.getValidatorsWithRetry(stateId, batch)
 			if err != nil {
 				return fmt.Errorf("error getting validator statuses: %w", err)
 			}
@@ -37,6 +37,16 @@ type SyncStatusResponse struct {
 		SyncDistance uinteger `json:"sync_distance"`
 	} `json:"data"`
 }
+type NodeVersionResponse struct {
+	Data struct {
+		Version string `json:"version"`
+	} `json:"data"`
+}
+type PeerCountResponse struct {
+	Data struct {
+		Connected uinteger `json:"connected"`
+	} `json:"data"`
+}
 type Eth2ConfigResponse struct {
 	Data struct {
 		SecondsPerSlot               uinteger  `json:"SECONDS_PER_SLOT"`
@@ -96,6 +106,10 @@ type BeaconBlockResponse struct {
 				ExecutionPayload *struct {
 					FeeRecipient byteArray `json:"fee_recipient"`
 					BlockNumber  uinteger  `json:"block_number"`
+					Withdrawals  []struct {
+						ValidatorIndex uinteger `json:"validator_index"`
+						Amount         uinteger `json:"amount"`
+					} `json:"withdrawals"`
 				} `json:"execution_payload"`
 			} `json:"body"`
 		} `json:"message"`
@@ -144,7 +158,8 @@ type ProposerDutiesResponse struct {
 	Data []ProposerDuty `json:"data"`
 }
 type ProposerDuty struct {
-	ValidatorIndex string `json:"validator_index"`
+	ValidatorIndex string   `json:"validator_index"`
+	Slot           uinteger `json:"slot"`
 }
 
 type CommitteesResponse struct {
@@ -154,8 +169,12 @@ type CommitteesResponse struct {
 type Attestation struct {
 	AggregationBits string `json:"aggregation_bits"`
 	Data            struct {
-		Slot  uinteger `json:"slot"`
-		Index uinteger `json:"index"`
+		Slot            uinteger `json:"slot"`
+		Index           uinteger `json:"index"`
+		BeaconBlockRoot string   `json:"beacon_block_root"`
+		Target          struct {
+			Root string `json:"root"`
+		} `json:"target"`
 	} `json:"data"`
 }
 
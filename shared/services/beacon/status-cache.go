@@ -0,0 +1,151 @@
+package beacon
+
+import (
+	"sync"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// The validator statuses retrieved for a single beacon state
+type stateStatuses struct {
+	statuses map[types.ValidatorPubkey]ValidatorStatus
+	missing  map[types.ValidatorPubkey]bool
+}
+
+// The cached beacon states for one epoch
+type epochStatuses struct {
+	finalized bool
+	states    map[uint64]*stateStatuses
+}
+
+// A bounded cache of validator statuses at specific slots, grouped by epoch so it can be shared between tasks that look up the same states.
+// Statuses cached before their epoch was finalized are dropped once it is, since a reorg could have changed them.
+type ValidatorStatusCache struct {
+	slotsPerEpoch  uint64
+	maxEpochs      int
+	finalizedEpoch uint64
+	epochs         map[uint64]*epochStatuses
+	lock           sync.Mutex
+}
+
+// Creates a new cache that holds the validator statuses for up to maxEpochs epochs
+func NewValidatorStatusCache(slotsPerEpoch uint64, maxEpochs int) *ValidatorStatusCache {
+	return &ValidatorStatusCache{
+		slotsPerEpoch: slotsPerEpoch,
+		maxEpochs:     maxEpochs,
+		epochs:        map[uint64]*epochStatuses{},
+	}
+}
+
+// Get the slot of the state a status request is for; requests for the head state can't be cached
+func (c *ValidatorStatusCache) getStateSlot(opts *ValidatorStatusOptions) (uint64, bool) {
+	if opts == nil {
+		return 0, false
+	}
+	if opts.Slot != nil {
+		return *opts.Slot, true
+	}
+	if opts.Epoch != nil {
+		return *opts.Epoch * c.slotsPerEpoch, true
+	}
+	return 0, false
+}
+
+// Get the cached statuses for the requested state, along with the pubkeys that still need to be retrieved.
+// If the state can't be cached, every pubkey is returned as uncached.
+func (c *ValidatorStatusCache) Get(pubkeys []types.ValidatorPubkey, opts *ValidatorStatusOptions) (map[types.ValidatorPubkey]ValidatorStatus, []types.ValidatorPubkey) {
+	statuses := map[types.ValidatorPubkey]ValidatorStatus{}
+	slot, cacheable := c.getStateSlot(opts)
+	if !cacheable {
+		return statuses, pubkeys
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var state *stateStatuses
+	if epoch, exists := c.epochs[slot/c.slotsPerEpoch]; exists {
+		state = epoch.states[slot]
+	}
+	if state == nil {
+		return statuses, pubkeys
+	}
+
+	uncached := []types.ValidatorPubkey{}
+	for _, pubkey := range pubkeys {
+		if status, exists := state.statuses[pubkey]; exists {
+			statuses[pubkey] = status
+		} else if !state.missing[pubkey] {
+			uncached = append(uncached, pubkey)
+		}
+	}
+	return statuses, uncached
+}
+
+// Add the statuses retrieved for the requested state; pubkeys without a status are remembered as not being on the Beacon Chain yet
+func (c *ValidatorStatusCache) Add(pubkeys []types.ValidatorPubkey, statuses map[types.ValidatorPubkey]ValidatorStatus, opts *ValidatorStatusOptions) {
+	slot, cacheable := c.getStateSlot(opts)
+	if !cacheable {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	epochNumber := slot / c.slotsPerEpoch
+	epoch, exists := c.epochs[epochNumber]
+	if !exists {
+		epoch = &epochStatuses{
+			finalized: epochNumber <= c.finalizedEpoch,
+			states:    map[uint64]*stateStatuses{},
+		}
+		c.epochs[epochNumber] = epoch
+		c.evict()
+	}
+	state, exists := epoch.states[slot]
+	if !exists {
+		state = &stateStatuses{
+			statuses: map[types.ValidatorPubkey]ValidatorStatus{},
+			missing:  map[types.ValidatorPubkey]bool{},
+		}
+		epoch.states[slot] = state
+	}
+	for _, pubkey := range pubkeys {
+		if status, exists := statuses[pubkey]; exists {
+			state.statuses[pubkey] = status
+		} else {
+			state.missing[pubkey] = true
+		}
+	}
+}
+
+// Record that the chain has finalized up to the given epoch, dropping any statuses for those epochs that were cached before they were final
+func (c *ValidatorStatusCache) SetFinalizedEpoch(finalizedEpoch uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if finalizedEpoch <= c.finalizedEpoch {
+		return
+	}
+	c.finalizedEpoch = finalizedEpoch
+	for epochNumber, epoch := range c.epochs {
+		if epochNumber <= finalizedEpoch && !epoch.finalized {
+			delete(c.epochs, epochNumber)
+		}
+	}
+}
+
+// Remove the oldest epochs until the cache is back within its limit
+func (c *ValidatorStatusCache) evict() {
+	for len(c.epochs) > c.maxEpochs {
+		oldest := uint64(0)
+		first := true
+		for epochNumber := range c.epochs {
+			if first || epochNumber < oldest {
+				oldest = epochNumber
+				first = false
+			}
+		}
+		delete(c.epochs, oldest)
+	}
+}
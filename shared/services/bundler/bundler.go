@@ -0,0 +1,157 @@
+package bundler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
+
+	"github.com/rocket-pool/smartnode/shared/services/revert"
+)
+
+// The error for items that have to be sent from the node account, so they can't go through the multicall contract
+var ErrNotBundleable = errors.New("the contract only accepts this call from the node account, so it has to be sent on its own")
+
+// A piece of routine maintenance that could go into a bundle
+type Item struct {
+	Description string
+	Target      common.Address
+	CallData    []byte
+
+	// Whether the target accepts the call from any sender. Calls that check the sender can't be bundled, because the
+	// multicall contract becomes their sender.
+	Permissionless bool
+}
+
+// What happened to an item when the bundle was put together
+type ItemResult struct {
+	Item    Item
+	Bundled bool
+	Error   error
+}
+
+// The result of a single call in the bundle, as returned by tryAggregate
+type callResult struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// Bundles independent maintenance calls into a single transaction through the Multicall contract's tryAggregate method,
+// which runs each call on its own so one failing doesn't revert the others
+type Bundler struct {
+	multicall *rocketpool.Contract
+	items     []Item
+}
+
+// Create a new bundler for the Multicall contract at the given address
+func NewBundler(client rocketpool.ExecutionClient, multicallAddress common.Address) (*Bundler, error) {
+	multicallAbi, err := abi.JSON(strings.NewReader(multicall.MulticallABI))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the multicall ABI: %w", err)
+	}
+	return &Bundler{
+		multicall: &rocketpool.Contract{
+			Contract: bind.NewBoundContract(multicallAddress, multicallAbi, client, client, client),
+			Address:  &multicallAddress,
+			ABI:      &multicallAbi,
+			Client:   client,
+		},
+	}, nil
+}
+
+// Add an item to the bundle
+func (b *Bundler) Add(item Item) {
+	b.items = append(b.items, item)
+}
+
+// Simulate each item as the multicall contract would send it, then the bundle as a whole, and work out which items go into it.
+// Items that would fail are left out and reported with their decoded revert reason.
+func (b *Bundler) Simulate(from common.Address) ([]ItemResult, error) {
+
+	// Simulate the items on their own
+	results := make([]ItemResult, len(b.items))
+	for i, item := range b.items {
+		results[i].Item = item
+		if !item.Permissionless {
+			results[i].Error = ErrNotBundleable
+			continue
+		}
+		target := item.Target
+		_, err := b.multicall.Client.CallContract(context.Background(), ethereum.CallMsg{
+			From: *b.multicall.Address,
+			To:   &target,
+			Data: item.CallData,
+		}, nil)
+		if err != nil {
+			results[i].Error = revert.Wrap(err, nil)
+			continue
+		}
+		results[i].Bundled = true
+	}
+
+	// Simulate the bundle, since items can affect each other once they run together
+	calls := getCalls(results)
+	if len(calls) == 0 {
+		return results, nil
+	}
+	var callResults []callResult
+	err := b.multicall.Call(&bind.CallOpts{From: from}, &callResults, "tryAggregate", false, calls)
+	if err != nil {
+		return nil, fmt.Errorf("error simulating the bundle: %w", revert.Wrap(err, nil))
+	}
+	if len(callResults) != len(calls) {
+		return nil, fmt.Errorf("the bundle simulation returned %d results for %d calls", len(callResults), len(calls))
+	}
+	index := 0
+	for i := range results {
+		if !results[i].Bundled {
+			continue
+		}
+		if !callResults[index].Success {
+			results[i].Bundled = false
+			results[i].Error = fmt.Errorf("the call failed inside the bundle: %s", revert.Decode(callResults[index].ReturnData, nil))
+		}
+		index++
+	}
+	return results, nil
+
+}
+
+// Get the gas info for a bundle of the items that passed simulation
+func (b *Bundler) GetGasInfo(results []ItemResult, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	return b.multicall.GetTransactionGasInfo(opts, "tryAggregate", false, getCalls(results))
+}
+
+// Submit a bundle of the items that passed simulation
+func (b *Bundler) Submit(results []ItemResult, opts *bind.TransactOpts) (common.Hash, error) {
+	calls := getCalls(results)
+	if len(calls) == 0 {
+		return common.Hash{}, errors.New("none of the items can be bundled")
+	}
+	tx, err := b.multicall.Transact(opts, "tryAggregate", false, calls)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error submitting the bundle: %w", err)
+	}
+	return tx.Hash(), nil
+}
+
+// Get the multicall calls for the bundled items
+func getCalls(results []ItemResult) []multicall.MultiCall {
+	calls := []multicall.MultiCall{}
+	for _, result := range results {
+		if result.Bundled {
+			calls = append(calls, multicall.MultiCall{
+				Target:   result.Item.Target,
+				CallData: result.Item.CallData,
+			})
+		}
+	}
+	return calls
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Settings for the client call middleware
+const (
+	clientRetryInitialBackoff time.Duration = 500 * time.Millisecond
+	clientRetryMaxBackoff     time.Duration = 8 * time.Second
+	circuitBreakerCooldown    time.Duration = time.Minute
+)
+
+// How the client managers retry failed calls, and when they stop sending calls to a failing client
+type clientCallPolicy struct {
+	retries          int
+	breakerThreshold int
+}
+
+// Get the client call policy from the Smartnode config
+func newClientCallPolicy(cfg *config.RocketPoolConfig) clientCallPolicy {
+	return clientCallPolicy{
+		retries:          int(cfg.Smartnode.ClientCallRetries.Value.(uint64)),
+		breakerThreshold: int(cfg.Smartnode.ClientCircuitBreakerThreshold.Value.(uint64)),
+	}
+}
+
+// A circuit breaker for a single client. After too many consecutive failed calls it opens, and calls go to the next client instead;
+// once the cooldown has passed, a single call is let through to see if the client has recovered. If that call doesn't close the
+// breaker, the next one is let through after another cooldown.
+type circuitBreaker struct {
+	threshold int
+	failures  int
+	openUntil time.Time
+	lock      sync.Mutex
+}
+
+// Check if the breaker will let a call through to its client
+func (b *circuitBreaker) allow() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.threshold == 0 || b.failures < b.threshold {
+		return true
+	}
+	now := time.Now()
+	if now.Before(b.openUntil) {
+		return false
+	}
+
+	// Half open: let this call through as the probe, and keep the rest away until it's done or the next cooldown passes
+	b.openUntil = now.Add(circuitBreakerCooldown)
+	return true
+}
+
+// Check if the breaker is currently keeping calls away from its client, without using up the probe
+func (b *circuitBreaker) isOpen() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.threshold > 0 && b.failures >= b.threshold && time.Now().Before(b.openUntil)
+}
+
+// Record a successful call, closing the breaker
+func (b *circuitBreaker) recordSuccess() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.failures = 0
+}
+
+// Record a failed call, opening the breaker if there have been too many in a row
+func (b *circuitBreaker) recordFailure() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.failures++
+	if b.threshold > 0 && b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(circuitBreakerCooldown)
+	}
+}
+
+// Reset the breaker, used when the client behind it changes
+func (b *circuitBreaker) reset() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.failures = 0
+	b.openUntil = time.Time{}
+}
+
+// Run a call against a single client, retrying transient failures with an exponential backoff until the policy's retries are used up,
+// the breaker opens, or the context is done.
+// If failFast is set, because there's another client to fail over to, errors connecting to the client are returned right away instead of being retried.
+func callWithRetry[T any](ctx context.Context, policy clientCallPolicy, breaker *circuitBreaker, failFast bool, call func() (T, error)) (T, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	backoff := clientRetryInitialBackoff
+	for attempt := 0; ; attempt++ {
+		result, err := call()
+		if err == nil {
+			breaker.recordSuccess()
+			return result, nil
+		}
+		if !isTransientClientError(err) {
+			return result, err
+		}
+		breaker.recordFailure()
+		if attempt >= policy.retries || breaker.isOpen() || ctx.Err() != nil || (failFast && isDialError(err)) {
+			return result, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return result, err
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, clientRetryMaxBackoff)
+	}
+}
+
+// Check if an error means the client couldn't be reached at all
+func isDialError(err error) bool {
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "dial tcp") || strings.Contains(message, "connection refused")
+}
+
+// Check if an error from a client is likely to go away if the call is retried
+func isTransientClientError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	message := strings.ToLower(err.Error())
+	for _, pattern := range []string{
+		"dial tcp",
+		"connection refused",
+		"connection reset",
+		"broken pipe",
+		"eof",
+		"timeout",
+		"429 too many requests",
+		"502 bad gateway",
+		"503 service unavailable",
+		"504 gateway timeout",
+		"http status 429",
+		"http status 502",
+		"http status 503",
+		"http status 504",
+	} {
+		if strings.Contains(message, pattern) {
+			return true
+		}
+	}
+	return false
+}
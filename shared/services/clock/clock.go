@@ -0,0 +1,194 @@
+package clock
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/net"
+)
+
+// Settings
+const (
+	ntpTimeout time.Duration = 5 * time.Second
+
+	// How many slots the Beacon head can be behind the wall clock before the clock is suspected of running ahead.
+	// A late or missed block or two is normal, so this is only a hint unless the NTP servers can't be reached.
+	maxHeadSlotLag uint64 = 4
+)
+
+// The offset reported by one NTP server
+type NtpOffset struct {
+	Server string        `json:"server"`
+	Offset time.Duration `json:"offset"`
+	Error  string        `json:"error,omitempty"`
+}
+
+// How the local clock compares to the NTP servers and the Beacon Chain
+type ClockStatus struct {
+	Time time.Time `json:"time"`
+
+	// The individual NTP server results, and their median if any of them answered
+	NtpOffsets   []NtpOffset   `json:"ntpOffsets"`
+	NtpAvailable bool          `json:"ntpAvailable"`
+	Offset       time.Duration `json:"offset"`
+
+	// The slot the wall clock says it is, and the slot of the Beacon Node's head
+	WallClockSlot uint64 `json:"wallClockSlot"`
+	HeadSlot      uint64 `json:"headSlot"`
+	BeaconChecked bool   `json:"beaconChecked"`
+
+	// The drift that's allowed, and whether it was exceeded
+	Threshold time.Duration `json:"threshold"`
+	Drifted   bool          `json:"drifted"`
+	Problem   string        `json:"problem,omitempty"`
+}
+
+// Get the NTP servers from the config
+func GetNtpServers(cfg *config.RocketPoolConfig) []string {
+	servers := []string{}
+	for _, server := range strings.Split(cfg.Smartnode.NtpServers.Value.(string), ",") {
+		server = strings.TrimSpace(server)
+		if server != "" {
+			servers = append(servers, server)
+		}
+	}
+	return servers
+}
+
+// Get the allowed clock drift from the config
+func GetThreshold(cfg *config.RocketPoolConfig) time.Duration {
+	return time.Duration(cfg.Alertmanager.ClockDriftThreshold.Value.(uint64)) * time.Millisecond
+}
+
+// Compare the local clock against the configured NTP servers and the Beacon Chain's slot timing.
+// The Beacon client is optional; if it's nil, only the NTP servers are used.
+func CheckClock(cfg *config.RocketPoolConfig, bc beacon.Client) ClockStatus {
+	status := ClockStatus{
+		Time:      time.Now(),
+		Threshold: GetThreshold(cfg),
+	}
+
+	// Query the NTP servers in parallel
+	servers := GetNtpServers(cfg)
+	status.NtpOffsets = make([]NtpOffset, len(servers))
+	var wg sync.WaitGroup
+	for i, server := range servers {
+		wg.Add(1)
+		go func(i int, server string) {
+			defer wg.Done()
+			result := NtpOffset{Server: server}
+			offset, err := net.GetClockOffset(server, ntpTimeout)
+			if err != nil {
+				result.Error = err.Error()
+			} else {
+				result.Offset = offset
+			}
+			status.NtpOffsets[i] = result
+		}(i, server)
+	}
+	wg.Wait()
+
+	offsets := []time.Duration{}
+	for _, result := range status.NtpOffsets {
+		if result.Error == "" {
+			offsets = append(offsets, result.Offset)
+		}
+	}
+	if len(offsets) > 0 {
+		sort.Slice(offsets, func(i, j int) bool {
+			return offsets[i] < offsets[j]
+		})
+		status.NtpAvailable = true
+		status.Offset = offsets[len(offsets)/2]
+		if status.Offset > status.Threshold || status.Offset < -status.Threshold {
+			status.Drifted = true
+			status.Problem = fmt.Sprintf("the system clock is %s the NTP servers, which is more than the %s limit", FormatOffset(status.Offset), status.Threshold)
+		}
+	}
+
+	// Compare against the Beacon Chain, which also works when NTP traffic is blocked
+	if bc != nil {
+		if err := checkBeaconTiming(bc, &status); err != nil && !status.NtpAvailable {
+			status.Problem = fmt.Sprintf("couldn't reach any NTP servers or check the Beacon Chain's slot timing: %s", err.Error())
+		}
+	}
+	if !status.NtpAvailable && !status.BeaconChecked && status.Problem == "" {
+		status.Problem = "couldn't reach any of the NTP servers"
+	}
+
+	return status
+}
+
+// Compare the slot the wall clock says it is with the Beacon Node's head slot
+func checkBeaconTiming(bc beacon.Client, status *ClockStatus) error {
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return err
+	}
+	syncStatus, err := bc.GetSyncStatus()
+	if err != nil {
+		return err
+	}
+	if syncStatus.Syncing {
+		// The head isn't meaningful while the client catches up
+		return nil
+	}
+	head, found, err := bc.GetBeaconBlockHeader("head")
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("the Beacon Node doesn't have a head block")
+	}
+
+	genesisTime := time.Unix(int64(eth2Config.GenesisTime), 0)
+	if status.Time.Before(genesisTime) {
+		return nil
+	}
+	slotDuration := time.Duration(eth2Config.SecondsPerSlot) * time.Second
+	status.WallClockSlot = uint64(status.Time.Sub(genesisTime) / slotDuration)
+	status.HeadSlot = head.Slot
+	status.BeaconChecked = true
+
+	// The NTP result is more precise, so only use the Beacon Chain to decide when it's the only source
+	if status.NtpAvailable {
+		return nil
+	}
+	if status.HeadSlot > status.WallClockSlot {
+		status.Drifted = true
+		status.Problem = fmt.Sprintf("the Beacon Node's head is at slot %d but the system clock says it's only slot %d, so the clock is running behind", status.HeadSlot, status.WallClockSlot)
+	} else if status.WallClockSlot-status.HeadSlot > maxHeadSlotLag {
+		status.Drifted = true
+		status.Problem = fmt.Sprintf("the system clock says it's slot %d but the synced Beacon Node's head is only at slot %d, so the clock may be running ahead", status.WallClockSlot, status.HeadSlot)
+	}
+	return nil
+}
+
+// Describe a clock offset in a way that makes its direction clear, for use before "the NTP servers"
+func FormatOffset(offset time.Duration) string {
+	rounded := offset.Round(time.Millisecond)
+	if rounded < 0 {
+		return fmt.Sprintf("%s ahead of", -rounded)
+	}
+	return fmt.Sprintf("%s behind", rounded)
+}
+
+// Summarize a clock status in a single line
+func (s ClockStatus) Summary() string {
+	if s.Problem != "" {
+		return s.Problem
+	}
+	parts := []string{}
+	if s.NtpAvailable {
+		parts = append(parts, fmt.Sprintf("the system clock is %s the NTP servers", FormatOffset(s.Offset)))
+	}
+	if s.BeaconChecked {
+		parts = append(parts, fmt.Sprintf("the Beacon head is at slot %d and the wall clock is at slot %d", s.HeadSlot, s.WallClockSlot))
+	}
+	return strings.Join(parts, "; ")
+}
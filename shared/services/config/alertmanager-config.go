@@ -24,6 +24,16 @@ const AlertingRulesConfigFile string = "alerting/rules/default.yml"
 const defaultAlertmanagerPort uint16 = 9093
 const defaultAlertmanagerHost string = "localhost"
 const defaultAlertmanagerOpenPort config.RPCMode = config.RPC_Closed
+const defaultHostDiskUsageThreshold uint64 = 90
+const defaultHostIoWaitThreshold uint64 = 20
+const defaultNvmeWearThreshold uint64 = 80
+const defaultMissedSubmissionLag uint64 = 60
+const defaultAttestationEfficiencyThreshold uint64 = 90
+const defaultAttestationEfficiencyEpochs uint64 = 3
+const defaultClockDriftThreshold uint64 = 500
+const defaultGasFundsWarningMultiplier float64 = 3
+const defaultGasFundsCriticalMultiplier float64 = 1
+const defaultGasFundsMinimumReserve float64 = 0.05
 
 // Configuration for Alertmanager
 type AlertmanagerConfig struct {
@@ -72,6 +82,48 @@ type AlertmanagerConfig struct {
 	AlertEnabled_MinipoolStaked              config.Parameter `yaml:"alertEnabled_MinipoolStaked,omitempty"`
 	AlertEnabled_ExecutionClientSyncComplete config.Parameter `yaml:"alertEnabled_ExecutionClientSyncComplete,omitempty"`
 	AlertEnabled_BeaconClientSyncComplete    config.Parameter `yaml:"alertEnabled_BeaconClientSyncComplete,omitempty"`
+	AlertEnabled_RplPriceDivergence          config.Parameter `yaml:"alertEnabled_RplPriceDivergence,omitempty"`
+	AlertEnabled_SecurityCouncilProposal     config.Parameter `yaml:"alertEnabled_SecurityCouncilProposal,omitempty"`
+	AlertEnabled_RplStakeAboveUpperBound     config.Parameter `yaml:"alertEnabled_RplStakeAboveUpperBound,omitempty"`
+	AlertEnabled_GasLimitNotHonored          config.Parameter `yaml:"alertEnabled_GasLimitNotHonored,omitempty"`
+	AlertEnabled_FallbackClientsChanged      config.Parameter `yaml:"alertEnabled_FallbackClientsChanged,omitempty"`
+	AlertEnabled_ContractsUpgraded           config.Parameter `yaml:"alertEnabled_ContractsUpgraded,omitempty"`
+	AlertEnabled_RecordsInconsistent         config.Parameter `yaml:"alertEnabled_RecordsInconsistent,omitempty"`
+	AlertEnabled_HostDiskFull                config.Parameter `yaml:"alertEnabled_HostDiskFull,omitempty"`
+	AlertEnabled_HostHighIoWait              config.Parameter `yaml:"alertEnabled_HostHighIoWait,omitempty"`
+	AlertEnabled_NvmeWear                    config.Parameter `yaml:"alertEnabled_NvmeWear,omitempty"`
+	AlertEnabled_MissedOdaoSubmission        config.Parameter `yaml:"alertEnabled_MissedOdaoSubmission,omitempty"`
+	AlertEnabled_RewardsReadinessFailed      config.Parameter `yaml:"alertEnabled_RewardsReadinessFailed,omitempty"`
+	AlertEnabled_LowAttestationEfficiency    config.Parameter `yaml:"alertEnabled_LowAttestationEfficiency,omitempty"`
+	AlertEnabled_SyncCommitteeReadiness      config.Parameter `yaml:"alertEnabled_SyncCommitteeReadiness,omitempty"`
+	AlertEnabled_ClockDrift                  config.Parameter `yaml:"alertEnabled_ClockDrift,omitempty"`
+	AlertEnabled_ClientUpdatesAvailable      config.Parameter `yaml:"alertEnabled_ClientUpdatesAvailable,omitempty"`
+	AlertEnabled_BackupFailed                config.Parameter `yaml:"alertEnabled_BackupFailed,omitempty"`
+	AlertEnabled_BackupOutOfDate             config.Parameter `yaml:"alertEnabled_BackupOutOfDate,omitempty"`
+	AlertEnabled_ActiveHostConflict          config.Parameter `yaml:"alertEnabled_ActiveHostConflict,omitempty"`
+	AlertEnabled_MinipoolEvent               config.Parameter `yaml:"alertEnabled_MinipoolEvent,omitempty"`
+	AlertEnabled_LowGasFunds                 config.Parameter `yaml:"alertEnabled_LowGasFunds,omitempty"`
+	AlertEnabled_RescueNodeExpiring          config.Parameter `yaml:"alertEnabled_RescueNodeExpiring,omitempty"`
+
+	// Thresholds for the hardware alerts sent by the node daemon
+	HostDiskUsageThreshold config.Parameter `yaml:"hostDiskUsageThreshold,omitempty"`
+	HostIoWaitThreshold    config.Parameter `yaml:"hostIoWaitThreshold,omitempty"`
+	NvmeWearThreshold      config.Parameter `yaml:"nvmeWearThreshold,omitempty"`
+
+	// How long after a submission round starts the watchtower can go without submitting before it sends an alert, in minutes
+	MissedSubmissionLag config.Parameter `yaml:"missedSubmissionLag,omitempty"`
+
+	// The attestation efficiency the node's validators need to stay above, and for how many epochs in a row it can drop below that before the node daemon alerts
+	AttestationEfficiencyThreshold config.Parameter `yaml:"attestationEfficiencyThreshold,omitempty"`
+	AttestationEfficiencyEpochs    config.Parameter `yaml:"attestationEfficiencyEpochs,omitempty"`
+
+	// How far the system clock can drift from the NTP servers before the node daemon alerts, in milliseconds
+	ClockDriftThreshold config.Parameter `yaml:"clockDriftThreshold,omitempty"`
+
+	// How many times the projected gas cost of the node's upcoming duties its wallet should hold before the node daemon warns or alerts, and the least it should hold either way
+	GasFundsWarningMultiplier  config.Parameter `yaml:"gasFundsWarningMultiplier,omitempty"`
+	GasFundsCriticalMultiplier config.Parameter `yaml:"gasFundsCriticalMultiplier,omitempty"`
+	GasFundsMinimumReserve     config.Parameter `yaml:"gasFundsMinimumReserve,omitempty"`
 }
 
 func NewAlertmanagerConfig(cfg *RocketPoolConfig) *AlertmanagerConfig {
@@ -226,6 +278,204 @@ func NewAlertmanagerConfig(cfg *RocketPoolConfig) *AlertmanagerConfig {
 		AlertEnabled_BeaconClientSyncComplete: createParameterForAlertEnablement(
 			"BeaconClientSyncComplete",
 			"beacon client is synced"),
+
+		AlertEnabled_RplPriceDivergence: createParameterForAlertEnablement(
+			"RplPriceDivergence",
+			"the RPL price diverges from a secondary price source"),
+
+		AlertEnabled_SecurityCouncilProposal: createParameterForAlertEnablement(
+			"SecurityCouncilProposal",
+			"a security council proposal is open for voting"),
+
+		AlertEnabled_RplStakeAboveUpperBound: createParameterForAlertEnablement(
+			"RplStakeAboveUpperBound",
+			"the node's collateral ratio is above the RPL stake manager's upper bound"),
+
+		AlertEnabled_GasLimitNotHonored: createParameterForAlertEnablement(
+			"GasLimitNotHonored",
+			"a block proposed by the node did not honor its preferred gas limit"),
+
+		AlertEnabled_FallbackClientsChanged: createParameterForAlertEnablement(
+			"FallbackClientsChanged",
+			"the Smartnode fails over to a fallback client pair or recovers its primary clients"),
+
+		AlertEnabled_ContractsUpgraded: createParameterForAlertEnablement(
+			"ContractsUpgraded",
+			"the Rocket Pool network contracts are upgraded"),
+
+		AlertEnabled_RecordsInconsistent: createParameterForAlertEnablement(
+			"RecordsInconsistent",
+			"the watchtower finds corrupted or inconsistent rolling record checkpoints"),
+
+		AlertEnabled_HostDiskFull: createParameterForAlertEnablement(
+			"HostDiskFull",
+			"a disk the Smartnode uses is nearly full"),
+
+		AlertEnabled_HostHighIoWait: createParameterForAlertEnablement(
+			"HostHighIoWait",
+			"the CPU spends a long time waiting on disk IO"),
+
+		AlertEnabled_NvmeWear: createParameterForAlertEnablement(
+			"NvmeWear",
+			"an NVMe drive is worn out or reports a critical warning"),
+
+		AlertEnabled_MissedOdaoSubmission: createParameterForAlertEnablement(
+			"MissedOdaoSubmission",
+			"the other Oracle DAO members are close to consensus on a balances, prices, or rewards round that your watchtower hasn't submitted"),
+
+		AlertEnabled_RewardsReadinessFailed: createParameterForAlertEnablement(
+			"RewardsReadinessFailed",
+			"the watchtower's check before the end of a rewards interval finds something that would stop it from submitting the tree"),
+
+		AlertEnabled_LowAttestationEfficiency: createParameterForAlertEnablement(
+			"LowAttestationEfficiency",
+			"the attestation efficiency of the node's validators stays below the threshold for several epochs"),
+
+		AlertEnabled_SyncCommitteeReadiness: createParameterForAlertEnablement(
+			"SyncCommitteeReadiness",
+			"the node's validators will be in the next sync committee, along with its readiness check results"),
+
+		AlertEnabled_ClockDrift: createParameterForAlertEnablement(
+			"ClockDrift",
+			"the system clock drifts too far from the NTP servers or the Beacon Chain"),
+
+		AlertEnabled_ClientUpdatesAvailable: createParameterForAlertEnablement(
+			"ClientUpdatesAvailable",
+			"new releases of your clients or the Smartnode are available"),
+
+		AlertEnabled_BackupFailed: createParameterForAlertEnablement(
+			"BackupFailed",
+			"a scheduled backup fails to be created or uploaded"),
+
+		AlertEnabled_BackupOutOfDate: createParameterForAlertEnablement(
+			"BackupOutOfDate",
+			"the last successful scheduled backup is older than the schedule allows"),
+
+		AlertEnabled_ActiveHostConflict: createParameterForAlertEnablement(
+			"ActiveHostConflict",
+			"the Validator Client is stopped because another machine appears to be running your validators"),
+
+		AlertEnabled_MinipoolEvent: createParameterForAlertEnablement(
+			"MinipoolEvent",
+			"one of your minipools changes status, is voted to be scrubbed, is penalized, or has a bond reduction started or cancelled"),
+
+		AlertEnabled_LowGasFunds: createParameterForAlertEnablement(
+			"LowGasFunds",
+			"the node wallet's ETH balance is too low to pay for the gas of its upcoming duties"),
+
+		AlertEnabled_RescueNodeExpiring: createParameterForAlertEnablement(
+			"RescueNodeExpiring",
+			"the Rescue Node's time limit or credential is about to run out, or already has"),
+
+		HostDiskUsageThreshold: config.Parameter{
+			ID:                 "hostDiskUsageThreshold",
+			Name:               "Disk Usage Alert Threshold",
+			Description:        "The percentage of a disk's space that can be used before the node daemon sends an alert that it's nearly full.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultHostDiskUsageThreshold},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		HostIoWaitThreshold: config.Parameter{
+			ID:                 "hostIoWaitThreshold",
+			Name:               "IO Wait Alert Threshold",
+			Description:        "The percentage of CPU time spent waiting on disk IO that the node daemon will send an alert for, if it lasts for several checks in a row. High IO wait usually means your disk is too slow to keep up with your clients, which can cause missed attestations.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultHostIoWaitThreshold},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		NvmeWearThreshold: config.Parameter{
+			ID:                 "nvmeWearThreshold",
+			Name:               "NVMe Wear Alert Threshold",
+			Description:        "The percentage of an NVMe drive's rated endurance that can be used up before the node daemon sends an alert. The daemon can only read the drive's health if it has access to the drive's device node.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultNvmeWearThreshold},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		MissedSubmissionLag: config.Parameter{
+			ID:                 "missedSubmissionLag",
+			Name:               "Missed Submission Lag",
+			Description:        "Oracle DAO members only. How long, in minutes, after a balances, prices, or rewards round starts the watchtower can go without submitting before it sends an alert, once the other members are within one vote of consensus.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultMissedSubmissionLag},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		AttestationEfficiencyThreshold: config.Parameter{
+			ID:                 "attestationEfficiencyThreshold",
+			Name:               "Attestation Efficiency Alert Threshold",
+			Description:        "The attestation efficiency, as a percentage, that your validators need to stay above. Efficiency weighs each attestation's source, target, and head votes the same way the Beacon Chain's rewards do, so 100% means every vote was correct and included on time.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultAttestationEfficiencyThreshold},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		AttestationEfficiencyEpochs: config.Parameter{
+			ID:                 "attestationEfficiencyEpochs",
+			Name:               "Attestation Efficiency Alert Epochs",
+			Description:        "How many epochs in a row your validators' attestation efficiency has to be below the threshold before the node daemon sends an alert.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultAttestationEfficiencyEpochs},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		ClockDriftThreshold: config.Parameter{
+			ID:                 "clockDriftThreshold",
+			Name:               "Clock Drift Alert Threshold",
+			Description:        "How far, in milliseconds, your system clock can drift from the NTP servers before the node daemon sends an alert. Attestations are only on time if they're sent within a few seconds of the start of their slot, so drift of more than half a second starts to cost rewards.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultClockDriftThreshold},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		GasFundsWarningMultiplier: config.Parameter{
+			ID:                 "gasFundsWarningMultiplier",
+			Name:               "Gas Funds Warning Multiplier",
+			Description:        "The node daemon projects the gas cost of your node's upcoming duties, such as staking prelaunch minipools, distributing balances, reducing bonds, and claiming rewards, at the current gas price. It sends a warning when the node wallet holds less than this many times that cost, so you have time to top it up before a gas spike.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultGasFundsWarningMultiplier},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		GasFundsCriticalMultiplier: config.Parameter{
+			ID:                 "gasFundsCriticalMultiplier",
+			Name:               "Gas Funds Critical Multiplier",
+			Description:        "The node daemon sends a critical alert when the node wallet holds less than this many times the projected gas cost of its upcoming duties. At 1, the wallet can't pay for all of them at the current gas price.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultGasFundsCriticalMultiplier},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		GasFundsMinimumReserve: config.Parameter{
+			ID:                 "gasFundsMinimumReserve",
+			Name:               "Gas Funds Minimum Reserve",
+			Description:        "The least ETH the node wallet should hold even when there aren't any duties coming up, so it can always pay for an unexpected transaction such as an exit or a fee recipient fix. The node daemon sends a warning when the balance drops below this.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultGasFundsMinimumReserve},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
 	}
 }
 
@@ -269,6 +519,38 @@ func (cfg *AlertmanagerConfig) GetParameters() []*config.Parameter {
 		&cfg.AlertEnabled_MinipoolStaked,
 		&cfg.AlertEnabled_ExecutionClientSyncComplete,
 		&cfg.AlertEnabled_BeaconClientSyncComplete,
+		&cfg.AlertEnabled_RplPriceDivergence,
+		&cfg.AlertEnabled_SecurityCouncilProposal,
+		&cfg.AlertEnabled_RplStakeAboveUpperBound,
+		&cfg.AlertEnabled_GasLimitNotHonored,
+		&cfg.AlertEnabled_FallbackClientsChanged,
+		&cfg.AlertEnabled_ContractsUpgraded,
+		&cfg.AlertEnabled_RecordsInconsistent,
+		&cfg.AlertEnabled_HostDiskFull,
+		&cfg.AlertEnabled_HostHighIoWait,
+		&cfg.AlertEnabled_NvmeWear,
+		&cfg.AlertEnabled_MissedOdaoSubmission,
+		&cfg.AlertEnabled_RewardsReadinessFailed,
+		&cfg.AlertEnabled_LowAttestationEfficiency,
+		&cfg.AlertEnabled_SyncCommitteeReadiness,
+		&cfg.AlertEnabled_ClockDrift,
+		&cfg.AlertEnabled_ClientUpdatesAvailable,
+		&cfg.AlertEnabled_BackupFailed,
+		&cfg.AlertEnabled_BackupOutOfDate,
+		&cfg.AlertEnabled_ActiveHostConflict,
+		&cfg.AlertEnabled_MinipoolEvent,
+		&cfg.AlertEnabled_LowGasFunds,
+		&cfg.AlertEnabled_RescueNodeExpiring,
+		&cfg.HostDiskUsageThreshold,
+		&cfg.HostIoWaitThreshold,
+		&cfg.NvmeWearThreshold,
+		&cfg.MissedSubmissionLag,
+		&cfg.AttestationEfficiencyThreshold,
+		&cfg.AttestationEfficiencyEpochs,
+		&cfg.ClockDriftThreshold,
+		&cfg.GasFundsWarningMultiplier,
+		&cfg.GasFundsCriticalMultiplier,
+		&cfg.GasFundsMinimumReserve,
 	}
 }
 
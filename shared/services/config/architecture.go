@@ -0,0 +1,133 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/sys"
+)
+
+// The architectures the Smartnode's own images and most of the clients are published for, with Docker's names for them
+var defaultImageArchitectures = []string{"amd64", "arm64"}
+
+// The architectures each Execution Client publishes images for
+var executionClientArchitectures = map[config.ExecutionClient][]string{
+	config.ExecutionClient_Geth:       defaultImageArchitectures,
+	config.ExecutionClient_Nethermind: defaultImageArchitectures,
+	config.ExecutionClient_Besu:       defaultImageArchitectures,
+	config.ExecutionClient_Reth:       defaultImageArchitectures,
+}
+
+// The architectures each Consensus Client publishes images for
+var consensusClientArchitectures = map[config.ConsensusClient][]string{
+	config.ConsensusClient_Lighthouse: defaultImageArchitectures,
+	config.ConsensusClient_Lodestar:   defaultImageArchitectures,
+	config.ConsensusClient_Nimbus:     defaultImageArchitectures,
+	config.ConsensusClient_Prysm:      defaultImageArchitectures,
+	config.ConsensusClient_Teku:       defaultImageArchitectures,
+}
+
+// Image tags that are only built for one architecture, and how to turn each one into the multiarch tag for the same version.
+// Clients that publish multiarch images don't need these; Docker pulls the right one on its own.
+var architectureTagVariants = []struct {
+	// Matches the tag's version, e.g. `amd64-` for `statusim/nimbus-eth2:amd64-v24.3.0`
+	prefix string
+	suffix string
+
+	architecture string
+
+	// The prefix and suffix to use for the multiarch variant of the tag
+	multiarchPrefix string
+	multiarchSuffix string
+}{
+	// Lighthouse's modern builds use x86-64 instructions
+	{suffix: "-modern", architecture: "amd64"},
+	// Nimbus publishes a build for each architecture alongside its multiarch images
+	{prefix: "amd64-", architecture: "amd64", multiarchPrefix: "multiarch-"},
+	{prefix: "arm64v8-", architecture: "arm64", multiarchPrefix: "multiarch-"},
+}
+
+// Get the architecture an image tag is limited to and the tag that works on every architecture instead, or a blank architecture if it isn't limited to one
+func getTagArchitecture(image string) (string, string) {
+	separator := strings.LastIndex(image, ":")
+	if separator == -1 || strings.Contains(image[separator:], "/") {
+		return "", image
+	}
+	repository, tag := image[:separator], image[separator+1:]
+	for _, variant := range architectureTagVariants {
+		if variant.prefix != "" && !strings.HasPrefix(tag, variant.prefix) {
+			continue
+		}
+		if variant.suffix != "" && !strings.HasSuffix(tag, variant.suffix) {
+			continue
+		}
+		version := strings.TrimSuffix(strings.TrimPrefix(tag, variant.prefix), variant.suffix)
+		return variant.architecture, fmt.Sprintf("%s:%s%s%s", repository, variant.multiarchPrefix, version, variant.multiarchSuffix)
+	}
+	return "", image
+}
+
+// Make sure the selected clients publish images for the host's architecture, returning a description of each problem
+func (cfg *RocketPoolConfig) validateArchitecture() []string {
+	errors := []string{}
+	if cfg.IsNativeMode {
+		return errors
+	}
+	architecture := sys.GetHostArchitecture()
+
+	if !slices.Contains(defaultImageArchitectures, architecture) {
+		errors = append(errors, fmt.Sprintf("This machine's CPU is %s, but the Smartnode's images are only published for %s.", architecture, strings.Join(defaultImageArchitectures, " and ")))
+		return errors
+	}
+
+	// Check the clients themselves
+	if cfg.ExecutionClientLocal() {
+		client := cfg.ExecutionClient.Value.(config.ExecutionClient)
+		if !slices.Contains(executionClientArchitectures[client], architecture) {
+			clientName := string(client)
+			for _, option := range cfg.ExecutionClient.Options {
+				if option.Value == client {
+					clientName = option.Name
+				}
+			}
+			errors = append(errors, fmt.Sprintf("%s isn't published for %s CPUs like this machine's. Please choose a different Execution Client.", clientName, architecture))
+		}
+	}
+	consensusClient, _ := cfg.GetSelectedConsensusClient()
+	if !slices.Contains(consensusClientArchitectures[consensusClient], architecture) {
+		clientName := string(consensusClient)
+		if ccConfig, err := cfg.GetSelectedConsensusClientConfig(); err == nil {
+			clientName = ccConfig.GetName()
+		}
+		errors = append(errors, fmt.Sprintf("%s isn't published for %s CPUs like this machine's. Please choose a different Consensus Client.", clientName, architecture))
+	}
+
+	// Check the tags, which may have been set to a build for a single architecture
+	images := map[string]string{}
+	if tag, err := cfg.GetECContainerTag(); err == nil {
+		images["Execution Client"] = tag
+	}
+	if ccConfig, err := cfg.GetSelectedConsensusClientConfig(); err == nil {
+		if cfg.ConsensusClientLocal() {
+			images["Beacon Node"] = ccConfig.GetBeaconNodeImage()
+		}
+		images["Validator Client"] = ccConfig.GetValidatorImage()
+	}
+	if cfg.EnableMevBoost.Value == true && cfg.MevBoost.Mode.Value == config.Mode_Local {
+		images["MEV-Boost"] = cfg.MevBoost.ContainerTag.Value.(string)
+	}
+	for _, name := range []string{"Execution Client", "Beacon Node", "Validator Client", "MEV-Boost"} {
+		image, exists := images[name]
+		if !exists {
+			continue
+		}
+		tagArchitecture, multiarchImage := getTagArchitecture(image)
+		if tagArchitecture != "" && tagArchitecture != architecture {
+			errors = append(errors, fmt.Sprintf("The %s container tag [%s] is only built for %s CPUs, but this machine's CPU is %s. Please change it to [%s].", name, image, tagArchitecture, architecture, multiarchImage))
+		}
+	}
+
+	return errors
+}
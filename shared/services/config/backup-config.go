@@ -0,0 +1,170 @@
+package config
+
+import (
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Defaults
+const (
+	defaultBackupComponents    string = "config,wallet,slashing-protection,records,state"
+	defaultBackupRetentionDays uint64 = 30
+	defaultBackupS3Region      string = "us-east-1"
+)
+
+// Configuration for the node daemon's scheduled backups
+type BackupConfig struct {
+	Title string `yaml:"-"`
+
+	// When to make the backups, as a cron expression
+	Schedule config.Parameter `yaml:"schedule,omitempty"`
+
+	// The components to back up
+	Components config.Parameter `yaml:"components,omitempty"`
+
+	// How many days of backups to keep
+	RetentionDays config.Parameter `yaml:"retentionDays,omitempty"`
+
+	// Where to upload the backups
+	Storage config.Parameter `yaml:"storage,omitempty"`
+
+	// The bucket or folder URL to upload the backups to
+	StorageUrl config.Parameter `yaml:"storageUrl,omitempty"`
+
+	// The region used to sign S3 requests
+	S3Region config.Parameter `yaml:"s3Region,omitempty"`
+
+	// The credentials for the storage
+	AccessKey config.Parameter `yaml:"accessKey,omitempty"`
+	SecretKey config.Parameter `yaml:"secretKey,omitempty"`
+}
+
+// Generates a new backup config
+func NewBackupConfig(cfg *RocketPoolConfig) *BackupConfig {
+	return &BackupConfig{
+		Title: "Backup Settings",
+
+		Schedule: config.Parameter{
+			ID:                 "schedule",
+			Name:               "Backup Schedule",
+			Description:        "When the node daemon should back up your Smartnode automatically, as a cron expression in UTC: minute, hour, day of month, month, and day of week. For example, `0 3 * * *` makes a backup every day at 03:00 UTC. The shortcuts `@hourly`, `@daily`, and `@weekly` also work.\n\nLeave this blank to disable scheduled backups. Before enabling them, set the password the backups are encrypted with by running `rocketpool service set-backup-password`.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		Components: config.Parameter{
+			ID:                 "components",
+			Name:               "Backup Components",
+			Description:        "A comma-separated list of what to include in each scheduled backup: `config`, `wallet`, `validator-keys`, `slashing-protection`, `records`, and `state`.\n\nThe Validator Client keeps running during scheduled backups, so its slashing protection database is copied while it's in use. Use `rocketpool service backup` for a backup that stops it first.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultBackupComponents},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		RetentionDays: config.Parameter{
+			ID:                 "retentionDays",
+			Name:               "Backup Retention",
+			Description:        "How many days to keep scheduled backups for, both in the backups folder and in remote storage. The most recent backup is always kept.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultBackupRetentionDays},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		Storage: config.Parameter{
+			ID:                 "storage",
+			Name:               "Remote Storage",
+			Description:        "Where to upload the scheduled backups, in addition to keeping them in the backups folder. The backups are encrypted before they're uploaded.",
+			Type:               config.ParameterType_Choice,
+			Default:            map[config.Network]interface{}{config.Network_All: config.BackupStorage_None},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+			Options: []config.ParameterOption{{
+				Name:        "None",
+				Description: "Only keep the backups in the backups folder.",
+				Value:       config.BackupStorage_None,
+			}, {
+				Name:        "S3",
+				Description: "Upload the backups to an Amazon S3 bucket, or to any S3-compatible service such as MinIO or Cloudflare R2.",
+				Value:       config.BackupStorage_S3,
+			}, {
+				Name:        "Backblaze B2",
+				Description: "Upload the backups to a Backblaze B2 bucket, using its S3-compatible API.",
+				Value:       config.BackupStorage_B2,
+			}, {
+				Name:        "WebDAV",
+				Description: "Upload the backups to a WebDAV folder, such as one on Nextcloud or a NAS.",
+				Value:       config.BackupStorage_WebDav,
+			}},
+		},
+
+		StorageUrl: config.Parameter{
+			ID:                 "storageUrl",
+			Name:               "Storage URL",
+			Description:        "For S3 and B2, the bucket's URL, with an optional folder to put the backups in (for example `https://s3.us-east-1.amazonaws.com/my-bucket/rocketpool` or `https://s3.us-west-002.backblazeb2.com/my-bucket`).\n\nFor WebDAV, the URL of the folder to put the backups in.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		S3Region: config.Parameter{
+			ID:                 "s3Region",
+			Name:               "S3 Region",
+			Description:        "The region of the S3 or B2 bucket (for example `us-east-1` or `us-west-002`), which is used to sign the requests.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultBackupS3Region},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		AccessKey: config.Parameter{
+			ID:                 "accessKey",
+			Name:               "Access Key / Username",
+			Description:        "The access key ID for S3, the application key ID for B2, or the username for WebDAV.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		SecretKey: config.Parameter{
+			ID:                 "secretKey",
+			Name:               "Secret Key / Password",
+			Description:        "The secret access key for S3, the application key for B2, or the password for WebDAV.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+	}
+}
+
+// Get the parameters for this config
+func (cfg *BackupConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.Schedule,
+		&cfg.Components,
+		&cfg.RetentionDays,
+		&cfg.Storage,
+		&cfg.StorageUrl,
+		&cfg.S3Region,
+		&cfg.AccessKey,
+		&cfg.SecretKey,
+	}
+}
+
+// The the title for the config
+func (cfg *BackupConfig) GetConfigTitle() string {
+	return cfg.Title
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Configuration for limiting the bandwidth the clients' containers use
+type BandwidthConfig struct {
+	Title string `yaml:"-"`
+
+	// The rate limits for the Execution client's container, in Mbps
+	EcUploadLimit   config.Parameter `yaml:"ecUploadLimit,omitempty"`
+	EcDownloadLimit config.Parameter `yaml:"ecDownloadLimit,omitempty"`
+
+	// The rate limits for the Beacon Node's container, in Mbps
+	BnUploadLimit   config.Parameter `yaml:"bnUploadLimit,omitempty"`
+	BnDownloadLimit config.Parameter `yaml:"bnDownloadLimit,omitempty"`
+
+	// The daily window to apply the limits in, or blank to apply them all the time
+	Schedule config.Parameter `yaml:"schedule,omitempty"`
+
+	// The days of the week the window applies to
+	ScheduleDays config.Parameter `yaml:"scheduleDays,omitempty"`
+}
+
+// Generates a new bandwidth config
+func NewBandwidthConfig(cfg *RocketPoolConfig) *BandwidthConfig {
+	return &BandwidthConfig{
+		Title: "Bandwidth Settings",
+
+		EcUploadLimit: config.Parameter{
+			ID:                 "ecUploadLimit",
+			Name:               "Execution Client Upload Limit",
+			Description:        "The most upload bandwidth your Execution client's container can use, in megabits per second. Use 0 for no limit.\n\nThis is useful if you're on a metered or shared connection, but setting it too low will cost you peers and can make your client fall behind the chain. The limits are applied by the Node process in Docker Mode only.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		EcDownloadLimit: config.Parameter{
+			ID:                 "ecDownloadLimit",
+			Name:               "Execution Client Download Limit",
+			Description:        "The most download bandwidth your Execution client's container can use, in megabits per second. Use 0 for no limit.\n\nDownloads over the limit are dropped so the senders slow down, which is less precise than the upload limit.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		BnUploadLimit: config.Parameter{
+			ID:                 "bnUploadLimit",
+			Name:               "Beacon Node Upload Limit",
+			Description:        "The most upload bandwidth your Beacon Node's container can use, in megabits per second. Use 0 for no limit.\n\nThe Beacon Node needs enough bandwidth to publish your attestations and blocks on time, so don't set this below 10.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		BnDownloadLimit: config.Parameter{
+			ID:                 "bnDownloadLimit",
+			Name:               "Beacon Node Download Limit",
+			Description:        "The most download bandwidth your Beacon Node's container can use, in megabits per second. Use 0 for no limit.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		Schedule: config.Parameter{
+			ID:                 "schedule",
+			Name:               "Bandwidth Limit Schedule",
+			Description:        "The time of day to apply the bandwidth limits, in UTC, such as `08:00-17:00` to only throttle your clients during work hours. Windows that end before they start run past midnight, like `22:00-06:00`.\n\nLeave this blank to apply the limits all the time.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		ScheduleDays: config.Parameter{
+			ID:                 "scheduleDays",
+			Name:               "Bandwidth Limit Days",
+			Description:        "A comma-separated list of the days the schedule applies to, such as `mon,tue,wed,thu,fri`. Leave this blank to use the schedule every day.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+	}
+}
+
+// Get the parameters for this config
+func (cfg *BandwidthConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.EcUploadLimit,
+		&cfg.EcDownloadLimit,
+		&cfg.BnUploadLimit,
+		&cfg.BnDownloadLimit,
+		&cfg.Schedule,
+		&cfg.ScheduleDays,
+	}
+}
+
+// The the title for the config
+func (cfg *BandwidthConfig) GetConfigTitle() string {
+	return cfg.Title
+}
@@ -9,6 +9,7 @@ const GraffitiID string = "graffiti"
 const CheckpointSyncUrlID string = "checkpointSyncUrl"
 const P2pPortID string = "p2pPort"
 const P2pQuicPortID string = "p2pQuicPort"
+const Ipv6P2pPortID string = "ipv6P2pPort"
 const ApiPortID string = "apiPort"
 const OpenApiPortID string = "openApiPort"
 const DoppelgangerDetectionID string = "doppelgangerDetection"
@@ -18,6 +19,8 @@ const defaultGraffiti string = ""
 const defaultCheckpointSyncProvider string = ""
 const defaultP2pPort uint16 = 9001
 const defaultP2pQuicPort uint16 = 8001
+const defaultIpv6P2pPort uint16 = 9002
+const defaultP2pQuicIpv6Port uint16 = 8002
 const defaultBnApiPort uint16 = 5052
 const defaultOpenBnApiPort string = string(config.RPC_Closed)
 const defaultDoppelgangerDetection bool = true
@@ -35,6 +38,9 @@ type ConsensusCommonConfig struct {
 	// The port to use for gossip traffic
 	P2pPort config.Parameter `yaml:"p2pPort,omitempty"`
 
+	// The port to use for gossip traffic over IPv6, for clients that listen on IPv4 and IPv6 separately
+	Ipv6P2pPort config.Parameter `yaml:"ipv6P2pPort,omitempty"`
+
 	// The port to expose the HTTP API on
 	ApiPort config.Parameter `yaml:"apiPort,omitempty"`
 
@@ -88,6 +94,17 @@ func NewConsensusCommonConfig(cfg *RocketPoolConfig) *ConsensusCommonConfig {
 			OverwriteOnUpgrade: false,
 		},
 
+		Ipv6P2pPort: config.Parameter{
+			ID:                 Ipv6P2pPortID,
+			Name:               "IPv6 P2P Port",
+			Description:        "The port to use for P2P (blockchain) traffic over IPv6 when IPv6 is enabled. Lighthouse, Lodestar, and Teku listen on IPv4 and IPv6 separately, so this has to be different from the P2P Port.",
+			Type:               config.ParameterType_Uint16,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultIpv6P2pPort},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Eth2},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
 		ApiPort: config.Parameter{
 			ID:                 ApiPortID,
 			Name:               "HTTP API Port",
@@ -130,6 +147,7 @@ func (cfg *ConsensusCommonConfig) GetParameters() []*config.Parameter {
 		&cfg.Graffiti,
 		&cfg.CheckpointSyncProvider,
 		&cfg.P2pPort,
+		&cfg.Ipv6P2pPort,
 		&cfg.ApiPort,
 		&cfg.OpenApiPort,
 		&cfg.DoppelgangerDetection,
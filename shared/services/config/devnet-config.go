@@ -0,0 +1,162 @@
+package config
+
+import (
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Defaults
+const (
+	// The chain ID anvil and Hardhat use by default
+	defaultLocalDevnetChainID uint64 = 31337
+)
+
+// Configuration for running against a local devnet, such as one started with Kurtosis or anvil, instead of the shared Rocket Pool devnet
+type DevnetConfig struct {
+	Title string `yaml:"-"`
+
+	// Toggle for using a local devnet
+	UseLocalDevnet config.Parameter `yaml:"useLocalDevnet,omitempty"`
+
+	// The chain ID of the local devnet
+	ChainID config.Parameter `yaml:"chainID,omitempty"`
+
+	// The address of RocketStorage on the local devnet
+	StorageAddress config.Parameter `yaml:"storageAddress,omitempty"`
+
+	// The address of the RPL token on the local devnet
+	RplTokenAddress config.Parameter `yaml:"rplTokenAddress,omitempty"`
+
+	// The address of the rETH token on the local devnet
+	RethAddress config.Parameter `yaml:"rethAddress,omitempty"`
+
+	// The address of the multicall contract on the local devnet
+	MulticallAddress config.Parameter `yaml:"multicallAddress,omitempty"`
+
+	// The address of the balance batcher contract on the local devnet
+	BalanceBatcherAddress config.Parameter `yaml:"balanceBatcherAddress,omitempty"`
+
+	// The private key of a funded account used to fund the node on the local devnet
+	FunderKey config.Parameter `yaml:"funderKey,omitempty"`
+
+	parent *RocketPoolConfig
+}
+
+// Generates a new devnet config
+func NewDevnetConfig(cfg *RocketPoolConfig) *DevnetConfig {
+	return &DevnetConfig{
+		Title:  "Devnet Settings",
+		parent: cfg,
+
+		UseLocalDevnet: config.Parameter{
+			ID:                 "useLocalDevnet",
+			Name:               "Use Local Devnet",
+			Description:        "Enable this to point the Smartnode at a devnet running on your own machine (for example one started with Kurtosis or anvil) with your own deployment of the Rocket Pool contracts, instead of the shared Rocket Pool devnet.\n\nThe Execution and Consensus clients must be set to Externally Managed, pointing at the devnet's clients.\n\nOnly used when the network is set to Devnet.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower, config.ContainerID_Validator},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		ChainID: config.Parameter{
+			ID:                 "chainID",
+			Name:               "Chain ID",
+			Description:        "The chain ID of the local devnet.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultLocalDevnetChainID},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower, config.ContainerID_Validator},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		StorageAddress: config.Parameter{
+			ID:                 "storageAddress",
+			Name:               "RocketStorage Address",
+			Description:        "The address of the RocketStorage contract in your deployment of the Rocket Pool contracts. Every other network contract is found through it.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower, config.ContainerID_Validator},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		RplTokenAddress: config.Parameter{
+			ID:                 "rplTokenAddress",
+			Name:               "RPL Token Address",
+			Description:        "The address of the RPL token in your deployment of the Rocket Pool contracts.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		RethAddress: config.Parameter{
+			ID:                 "rethAddress",
+			Name:               "rETH Token Address",
+			Description:        "The address of the rETH token in your deployment of the Rocket Pool contracts.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		MulticallAddress: config.Parameter{
+			ID:                 "multicallAddress",
+			Name:               "Multicall Address",
+			Description:        "The address of a Multicall contract deployed on the local devnet, used to query the network state in bulk.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		BalanceBatcherAddress: config.Parameter{
+			ID:                 "balanceBatcherAddress",
+			Name:               "Balance Batcher Address",
+			Description:        "The address of a balance batcher contract deployed on the local devnet, used to query account balances in bulk.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		FunderKey: config.Parameter{
+			ID:                 "funderKey",
+			Name:               "Funder Private Key",
+			Description:        "The hex-encoded private key of an account on the local devnet that holds ETH and RPL, such as the account that deployed the contracts. It's used by `rocketpool service devnet fund` to fund the node.\n\n[orange]This key is stored in plain text in your settings, so only ever use a throwaway devnet key.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+	}
+}
+
+// Get the parameters for this config
+func (cfg *DevnetConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.UseLocalDevnet,
+		&cfg.ChainID,
+		&cfg.StorageAddress,
+		&cfg.RplTokenAddress,
+		&cfg.RethAddress,
+		&cfg.MulticallAddress,
+		&cfg.BalanceBatcherAddress,
+		&cfg.FunderKey,
+	}
+}
+
+// The the title for the config
+func (cfg *DevnetConfig) GetConfigTitle() string {
+	return cfg.Title
+}
+
+// Check if the Smartnode is running against a local devnet
+func (cfg *DevnetConfig) IsLocalDevnet() bool {
+	return cfg.parent.Smartnode.Network.Value.(config.Network) == config.Network_Devnet && cfg.UseLocalDevnet.Value == true
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// A feature that ships disabled and can be turned on in the Experimental Features section. Its value is the ID of the
+// parameter that toggles it.
+type FeatureFlag string
+
+const (
+	FeatureFlag_BundleMaintenanceTxs FeatureFlag = "bundleMaintenanceTxs"
+)
+
+// Configuration for the features that aren't ready to be turned on for everyone yet
+type ExperimentalConfig struct {
+	Title string `yaml:"-"`
+
+	// Toggle for bundling routine minipool maintenance into one transaction
+	BundleMaintenanceTxs config.Parameter `yaml:"bundleMaintenanceTxs,omitempty"`
+}
+
+// Generates a new experimental features config
+func NewExperimentalConfig(cfg *RocketPoolConfig) *ExperimentalConfig {
+	return &ExperimentalConfig{
+		Title: "Experimental Features",
+
+		BundleMaintenanceTxs: config.Parameter{
+			ID:                 string(FeatureFlag_BundleMaintenanceTxs),
+			Name:               "Bundle Maintenance Transactions",
+			Description:        "Enable this to have the Smartnode bundle routine maintenance that several of your minipools need at the same time, such as distributing their balances, into a single transaction through the network's Multicall contract. This saves the base cost of sending each one separately.\n\nOnly calls the contracts accept from any sender can be bundled; anything that has to come from your node account is still sent on its own. Each call is simulated before it goes into the bundle, and one that fails won't stop the others.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+	}
+}
+
+// Get the parameters for this config
+func (cfg *ExperimentalConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.BundleMaintenanceTxs,
+	}
+}
+
+// The the title for the config
+func (cfg *ExperimentalConfig) GetConfigTitle() string {
+	return cfg.Title
+}
+
+// Check if an experimental feature is turned on
+func (cfg *ExperimentalConfig) IsEnabled(flag FeatureFlag) bool {
+	for _, param := range cfg.GetParameters() {
+		if param.ID == string(flag) {
+			enabled, ok := param.Value.(bool)
+			return ok && enabled
+		}
+	}
+	return false
+}
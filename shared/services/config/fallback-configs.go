@@ -1,9 +1,18 @@
 package config
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/rocket-pool/smartnode/shared/types/config"
 )
 
+// A fallback Execution client and Beacon Node that are used together
+type FallbackClientPair struct {
+	EcHttpUrl string
+	CcHttpUrl string
+}
+
 // Configuration for fallback Lighthouse
 type FallbackNormalConfig struct {
 	Title string `yaml:"-"`
@@ -124,3 +133,48 @@ func (config *FallbackNormalConfig) GetConfigTitle() string {
 func (config *FallbackPrysmConfig) GetConfigTitle() string {
 	return config.Title
 }
+
+// Get every fallback client pair in order of preference, starting with the main fallback clients
+func (cfg *RocketPoolConfig) GetFallbackClientPairs() ([]FallbackClientPair, error) {
+	if cfg.UseFallbackClients.Value != true {
+		return nil, nil
+	}
+
+	// Get the main fallback pair
+	pairs := []FallbackClientPair{}
+	mainPair := FallbackClientPair{
+		EcHttpUrl: cfg.FallbackNormal.EcHttpUrl.Value.(string),
+		CcHttpUrl: cfg.FallbackNormal.CcHttpUrl.Value.(string),
+	}
+	if !cfg.IsNativeMode {
+		cc, _ := cfg.GetSelectedConsensusClient()
+		if cc == config.ConsensusClient_Prysm {
+			mainPair.EcHttpUrl = cfg.FallbackPrysm.EcHttpUrl.Value.(string)
+			mainPair.CcHttpUrl = cfg.FallbackPrysm.CcHttpUrl.Value.(string)
+		}
+	}
+	if mainPair.EcHttpUrl != "" && mainPair.CcHttpUrl != "" {
+		pairs = append(pairs, mainPair)
+	}
+
+	// Parse the additional pairs
+	additional := strings.TrimSpace(cfg.AdditionalFallbackClients.Value.(string))
+	if additional == "" {
+		return pairs, nil
+	}
+	for _, entry := range strings.Split(additional, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		urls := strings.Split(entry, "|")
+		if len(urls) != 2 || strings.TrimSpace(urls[0]) == "" || strings.TrimSpace(urls[1]) == "" {
+			return nil, fmt.Errorf("invalid fallback client pair [%s]: expected `<Execution client URL>|<Beacon Node URL>`", entry)
+		}
+		pairs = append(pairs, FallbackClientPair{
+			EcHttpUrl: strings.TrimSpace(urls[0]),
+			CcHttpUrl: strings.TrimSpace(urls[1]),
+		})
+	}
+	return pairs, nil
+}
@@ -1,9 +1,8 @@
 package config
 
 import (
-	"runtime"
-
 	"github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/sys"
 )
 
 // Constants
@@ -109,7 +108,7 @@ func NewGethConfig(cfg *RocketPoolConfig) *GethConfig {
 
 // Calculate the default number of Geth peers
 func calculateGethPeers() uint16 {
-	if runtime.GOARCH == "arm64" {
+	if sys.GetHostArchitecture() == "arm64" {
 		return 25
 	}
 	return 50
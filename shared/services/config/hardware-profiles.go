@@ -0,0 +1,102 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// A setting a hardware profile changes, and the value it changes it to
+type profileSetting struct {
+	parameter *config.Parameter
+	value     interface{}
+}
+
+// Get the settings the selected hardware profile tunes, covering every client so switching clients later keeps the profile
+func (cfg *RocketPoolConfig) getHardwareProfileSettings(profile config.HardwareProfile) []profileSetting {
+	switch profile {
+	case config.HardwareProfile_LowPower:
+		return []profileSetting{
+			{&cfg.Geth.EnablePbss, true},
+			{&cfg.Geth.MaxPeers, uint16(25)},
+			{&cfg.Nethermind.CacheSize, uint64(512)},
+			{&cfg.Nethermind.MaxPeers, uint16(25)},
+			{&cfg.Nethermind.PruneMemSize, uint64(512)},
+			{&cfg.Nethermind.FullPruneMemoryBudget, uint64(1024)},
+			{&cfg.Besu.JvmHeapSize, uint64(3072)},
+			{&cfg.Besu.MaxPeers, uint16(25)},
+			{&cfg.Besu.ArchiveMode, false},
+			{&cfg.Reth.CacheSize, uint64(1024)},
+			{&cfg.Reth.MaxPeers, uint16(25)},
+			{&cfg.Lighthouse.MaxPeers, uint16(50)},
+			{&cfg.Lodestar.MaxPeers, uint16(50)},
+			{&cfg.Nimbus.MaxPeers, uint16(60)},
+			{&cfg.Nimbus.PruningMode, config.NimbusPruningMode_Prune},
+			{&cfg.Prysm.MaxPeers, uint16(45)},
+			{&cfg.Teku.JvmHeapSize, uint64(2048)},
+			{&cfg.Teku.MaxPeers, uint16(50)},
+			{&cfg.Teku.ArchiveMode, false},
+			{&cfg.Smartnode.WatchtowerConcurrency, uint64(4)},
+		}
+
+	case config.HardwareProfile_Standard:
+		return []profileSetting{
+			{&cfg.Geth.EnablePbss, true},
+			{&cfg.Geth.MaxPeers, uint16(50)},
+			{&cfg.Nethermind.CacheSize, uint64(1024)},
+			{&cfg.Nethermind.MaxPeers, uint16(50)},
+			{&cfg.Nethermind.PruneMemSize, uint64(1024)},
+			{&cfg.Nethermind.FullPruneMemoryBudget, uint64(2048)},
+			{&cfg.Besu.JvmHeapSize, uint64(0)},
+			{&cfg.Besu.MaxPeers, uint16(25)},
+			{&cfg.Besu.ArchiveMode, false},
+			{&cfg.Reth.CacheSize, uint64(4096)},
+			{&cfg.Reth.MaxPeers, uint16(50)},
+			{&cfg.Lighthouse.MaxPeers, uint16(100)},
+			{&cfg.Lodestar.MaxPeers, uint16(100)},
+			{&cfg.Nimbus.MaxPeers, uint16(160)},
+			{&cfg.Nimbus.PruningMode, config.NimbusPruningMode_Prune},
+			{&cfg.Prysm.MaxPeers, uint16(70)},
+			{&cfg.Teku.JvmHeapSize, uint64(0)},
+			{&cfg.Teku.MaxPeers, uint16(100)},
+			{&cfg.Teku.ArchiveMode, false},
+			{&cfg.Smartnode.WatchtowerConcurrency, uint64(12)},
+		}
+
+	case config.HardwareProfile_Server:
+		return []profileSetting{
+			{&cfg.Geth.EnablePbss, true},
+			{&cfg.Geth.MaxPeers, uint16(100)},
+			{&cfg.Nethermind.CacheSize, uint64(2048)},
+			{&cfg.Nethermind.MaxPeers, uint16(100)},
+			{&cfg.Nethermind.PruneMemSize, uint64(2048)},
+			{&cfg.Nethermind.FullPruneMemoryBudget, uint64(4096)},
+			{&cfg.Besu.JvmHeapSize, uint64(0)},
+			{&cfg.Besu.MaxPeers, uint16(50)},
+			{&cfg.Reth.CacheSize, uint64(16384)},
+			{&cfg.Reth.MaxPeers, uint16(100)},
+			{&cfg.Lighthouse.MaxPeers, uint16(150)},
+			{&cfg.Lodestar.MaxPeers, uint16(150)},
+			{&cfg.Nimbus.MaxPeers, uint16(240)},
+			{&cfg.Prysm.MaxPeers, uint16(100)},
+			{&cfg.Teku.JvmHeapSize, uint64(0)},
+			{&cfg.Teku.MaxPeers, uint16(150)},
+			{&cfg.Smartnode.WatchtowerConcurrency, uint64(24)},
+		}
+	}
+
+	return []profileSetting{}
+}
+
+// Apply the selected hardware profile's values to the settings it tunes, returning the settings that changed
+func (cfg *RocketPoolConfig) ApplyHardwareProfile() []*config.Parameter {
+	changed := []*config.Parameter{}
+	profile := config.HardwareProfile(fmt.Sprint(cfg.Smartnode.HardwareProfile.Value))
+	for _, setting := range cfg.getHardwareProfileSettings(profile) {
+		if setting.parameter.Value != setting.value {
+			setting.parameter.Value = setting.value
+			changed = append(changed, setting.parameter)
+		}
+	}
+	return changed
+}
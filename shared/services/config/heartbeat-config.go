@@ -0,0 +1,88 @@
+package config
+
+import (
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Configuration for pinging an external uptime monitor, such as Healthchecks.io or Better Uptime, whenever the daemons finish a round of their duties
+type HeartbeatConfig struct {
+	Title string `yaml:"-"`
+
+	// The URL to ping after each node daemon cycle
+	NodeUrl config.Parameter `yaml:"nodeUrl,omitempty"`
+
+	// The URL to ping after each watchtower round
+	WatchtowerUrl config.Parameter `yaml:"watchtowerUrl,omitempty"`
+
+	// The URL to ping after each rolling record update
+	RollingRecordUrl config.Parameter `yaml:"rollingRecordUrl,omitempty"`
+
+	// Toggle for reporting failed cycles to the monitor
+	ReportFailures config.Parameter `yaml:"reportFailures,omitempty"`
+}
+
+// Generates a new heartbeat config
+func NewHeartbeatConfig(cfg *RocketPoolConfig) *HeartbeatConfig {
+	return &HeartbeatConfig{
+		Title: "Heartbeat Settings",
+
+		NodeUrl: config.Parameter{
+			ID:                 "nodeUrl",
+			Name:               "Node Heartbeat URL",
+			Description:        "The URL to ping each time the node daemon finishes checking its clients and running its duties, such as a Healthchecks.io or Better Uptime heartbeat URL. The monitor will alert you if the pings stop, which means the node has stopped working.\n\nLeave this blank to disable the heartbeat.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		WatchtowerUrl: config.Parameter{
+			ID:                 "watchtowerUrl",
+			Name:               "Watchtower Heartbeat URL",
+			Description:        "The URL to ping each time the watchtower daemon finishes a round of its duties.\n\nLeave this blank to disable the heartbeat.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		RollingRecordUrl: config.Parameter{
+			ID:                 "rollingRecordUrl",
+			Name:               "Rolling Record Heartbeat URL",
+			Description:        "The URL to ping each time the watchtower finishes updating its rolling record. Only used when rolling records are enabled.\n\nLeave this blank to disable the heartbeat.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		ReportFailures: config.Parameter{
+			ID:                 "reportFailures",
+			Name:               "Report Failures",
+			Description:        "Enable this to ping the `/fail` endpoint of the heartbeat URLs when a cycle fails (for example because the clients aren't synced), so the monitor alerts you right away instead of waiting for the pings to stop. Healthchecks.io and Better Uptime both support this.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+	}
+}
+
+// Get the parameters for this config
+func (cfg *HeartbeatConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.NodeUrl,
+		&cfg.WatchtowerUrl,
+		&cfg.RollingRecordUrl,
+		&cfg.ReportFailures,
+	}
+}
+
+// The the title for the config
+func (cfg *HeartbeatConfig) GetConfigTitle() string {
+	return cfg.Title
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// A port one of the Smartnode's containers publishes on the host
+type HostPort struct {
+	// The parameter that holds the port
+	Parameter *config.Parameter
+
+	// The flag name for the parameter, used by `rocketpool service config`
+	FlagName string
+
+	// The container that publishes the port
+	Container config.ContainerID
+
+	// The protocols the port is published for
+	Protocols []string
+
+	// Whether the port has to be reachable from the internet so the client can find peers
+	P2P bool
+}
+
+// Get the ports the Smartnode's containers will publish on the host with the current settings
+func (cfg *RocketPoolConfig) GetHostPorts() []HostPort {
+	ports := []HostPort{}
+	if cfg.IsNativeMode {
+		return ports
+	}
+
+	flagNames := map[*config.Parameter]string{}
+	for _, result := range cfg.GetAllParameters() {
+		flagNames[result.Parameter] = result.FlagName
+	}
+	add := func(param *config.Parameter, container config.ContainerID, p2p bool, protocols ...string) {
+		ports = append(ports, HostPort{
+			Parameter: param,
+			FlagName:  flagNames[param],
+			Container: container,
+			Protocols: protocols,
+			P2P:       p2p,
+		})
+	}
+	isOpen := func(param *config.Parameter) bool {
+		// Some defaults are plain strings until the config has been saved and loaded again
+		return config.RPCMode(fmt.Sprint(param.Value)).Open()
+	}
+
+	// Execution client
+	if cfg.ExecutionClientLocal() {
+		add(&cfg.ExecutionCommon.P2pPort, config.ContainerID_Eth1, true, "tcp", "udp")
+		if isOpen(&cfg.ExecutionCommon.OpenRpcPorts) {
+			add(&cfg.ExecutionCommon.HttpPort, config.ContainerID_Eth1, false, "tcp")
+			add(&cfg.ExecutionCommon.WsPort, config.ContainerID_Eth1, false, "tcp")
+		}
+	}
+
+	// Beacon node
+	if cfg.ConsensusClientLocal() {
+		add(&cfg.ConsensusCommon.P2pPort, config.ContainerID_Eth2, true, "tcp", "udp")
+		consensusClient := cfg.ConsensusClient.Value.(config.ConsensusClient)
+		if consensusClient == config.ConsensusClient_Lighthouse {
+			add(&cfg.Lighthouse.P2pQuicPort, config.ContainerID_Eth2, true, "udp")
+		}
+		if cfg.Smartnode.EnableIpv6.Value == true && cfg.usesSeparateIpv6Ports() {
+			add(&cfg.ConsensusCommon.Ipv6P2pPort, config.ContainerID_Eth2, true, "tcp", "udp")
+			if consensusClient == config.ConsensusClient_Lighthouse {
+				add(&cfg.Lighthouse.P2pQuicIpv6Port, config.ContainerID_Eth2, true, "udp")
+			}
+		}
+		if isOpen(&cfg.ConsensusCommon.OpenApiPort) {
+			add(&cfg.ConsensusCommon.ApiPort, config.ContainerID_Eth2, false, "tcp")
+		}
+		if consensusClient == config.ConsensusClient_Prysm && isOpen(&cfg.Prysm.OpenRpcPort) {
+			add(&cfg.Prysm.RpcPort, config.ContainerID_Eth2, false, "tcp")
+		}
+	}
+
+	// MEV-Boost
+	if cfg.EnableMevBoost.Value == true && cfg.MevBoost.Mode.Value == config.Mode_Local && isOpen(&cfg.MevBoost.OpenRpcPort) {
+		add(&cfg.MevBoost.Port, config.ContainerID_MevBoost, false, "tcp")
+	}
+
+	// Metrics
+	if cfg.EnableMetrics.Value == true {
+		add(&cfg.Grafana.Port, config.ContainerID_Grafana, false, "tcp")
+		if isOpen(&cfg.Prometheus.OpenPort) {
+			add(&cfg.Prometheus.Port, config.ContainerID_Prometheus, false, "tcp")
+		}
+		if isOpen(&cfg.Alertmanager.OpenPort) {
+			add(&cfg.Alertmanager.Port, config.ContainerID_Alertmanager, false, "tcp")
+		}
+	}
+
+	return ports
+}
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Check if the Beacon Node listens on IPv4 and IPv6 separately, so it needs its own IPv6 P2P ports
+func (cfg *RocketPoolConfig) usesSeparateIpv6Ports() bool {
+	switch cfg.ConsensusClient.Value.(config.ConsensusClient) {
+	case config.ConsensusClient_Lighthouse, config.ConsensusClient_Lodestar, config.ConsensusClient_Teku:
+		return true
+	}
+	return false
+}
+
+// Gets the flags that set up the Execution Client's IPv6 networking
+// Used by text/template to format eth1.yml
+func (cfg *RocketPoolConfig) GetECIpv6Flags() (string, error) {
+	if cfg.Smartnode.EnableIpv6.Value != true {
+		return "", nil
+	}
+	if !cfg.ExecutionClientLocal() {
+		return "", fmt.Errorf("Execution client is external, there are no IPv6 flags")
+	}
+
+	listenAddress := strings.TrimSpace(cfg.Smartnode.Ipv6ListenAddress.Value.(string))
+	switch cfg.ExecutionClient.Value.(config.ExecutionClient) {
+	case config.ExecutionClient_Geth:
+		// Geth always listens on every address and learns its IPv6 endpoint from its peers
+		return "", nil
+	case config.ExecutionClient_Nethermind:
+		return fmt.Sprintf("--Network.LocalIp=%s", listenAddress), nil
+	case config.ExecutionClient_Besu:
+		return fmt.Sprintf("--p2p-interface=%s", listenAddress), nil
+	case config.ExecutionClient_Reth:
+		return fmt.Sprintf("--addr=%s --discovery.addr=%s", listenAddress, listenAddress), nil
+	}
+
+	return "", fmt.Errorf("Unknown Execution Client %s", string(cfg.ExecutionClient.Value.(config.ExecutionClient)))
+}
+
+// Gets the flags that set up the Beacon Node's IPv6 networking
+// Used by text/template to format eth2.yml
+func (cfg *RocketPoolConfig) GetBNIpv6Flags() (string, error) {
+	if cfg.Smartnode.EnableIpv6.Value != true {
+		return "", nil
+	}
+	if !cfg.ConsensusClientLocal() {
+		return "", fmt.Errorf("Consensus client is external, there are no IPv6 flags")
+	}
+
+	listenAddress := strings.TrimSpace(cfg.Smartnode.Ipv6ListenAddress.Value.(string))
+	discovery := cfg.Smartnode.Ipv6Discovery.Value == true
+	advertisedAddress := strings.TrimSpace(cfg.Smartnode.Ipv6AdvertisedAddress.Value.(string))
+	port := cfg.ConsensusCommon.Ipv6P2pPort.Value.(uint16)
+
+	flags := []string{}
+	switch cfg.ConsensusClient.Value.(config.ConsensusClient) {
+	case config.ConsensusClient_Lighthouse:
+		quicPort := cfg.Lighthouse.P2pQuicIpv6Port.Value.(uint16)
+		flags = append(flags, "--listen-address=0.0.0.0", fmt.Sprintf("--listen-address=%s", listenAddress), fmt.Sprintf("--port6=%d", port), fmt.Sprintf("--quic-port6=%d", quicPort))
+		if discovery {
+			flags = append(flags, fmt.Sprintf("--enr-tcp6-port=%d", port), fmt.Sprintf("--enr-udp6-port=%d", port), fmt.Sprintf("--enr-quic6-port=%d", quicPort))
+			if advertisedAddress != "" {
+				flags = append(flags, fmt.Sprintf("--enr-address=%s", advertisedAddress))
+			}
+		}
+	case config.ConsensusClient_Lodestar:
+		flags = append(flags, fmt.Sprintf("--listenAddress6=%s", listenAddress), fmt.Sprintf("--port6=%d", port))
+		if discovery {
+			flags = append(flags, fmt.Sprintf("--enr.tcp6=%d", port), fmt.Sprintf("--enr.udp6=%d", port))
+			if advertisedAddress != "" {
+				flags = append(flags, fmt.Sprintf("--enr.ip6=%s", advertisedAddress))
+			}
+		}
+	case config.ConsensusClient_Teku:
+		flags = append(flags, fmt.Sprintf("--p2p-interfaces=0.0.0.0,%s", listenAddress), fmt.Sprintf("--p2p-port-ipv6=%d", port))
+		if discovery {
+			flags = append(flags, fmt.Sprintf("--p2p-advertised-port-ipv6=%d", port))
+			if advertisedAddress != "" {
+				// Teku replaces both of its advertised addresses, so the IPv4 one has to come along
+				ip := cfg.GetExternalIp()
+				if net.ParseIP(ip).To4() != nil {
+					flags = append(flags, fmt.Sprintf("--p2p-advertised-ips=%s,%s", ip, advertisedAddress))
+				} else {
+					fmt.Println("Warning: couldn't get your public IPv4 address, so Teku will discover its advertised addresses on its own.")
+				}
+			}
+		}
+	case config.ConsensusClient_Nimbus:
+		// Nimbus listens on IPv4 and IPv6 together and only advertises IPv4
+		flags = append(flags, fmt.Sprintf("--listen-address=%s", listenAddress))
+	case config.ConsensusClient_Prysm:
+		// Prysm doesn't support IPv6 for P2P traffic yet
+	default:
+		return "", fmt.Errorf("Unknown Consensus Client %s", string(cfg.ConsensusClient.Value.(config.ConsensusClient)))
+	}
+
+	return strings.Join(flags, " "), nil
+}
+
+// Gets the extra port mappings the Beacon Node needs for IPv6
+// Used by text/template to format eth2.yml
+func (cfg *RocketPoolConfig) GetBnIpv6Ports() []string {
+	ports := []string{}
+	if cfg.Smartnode.EnableIpv6.Value != true || !cfg.ConsensusClientLocal() || !cfg.usesSeparateIpv6Ports() {
+		return ports
+	}
+
+	port := cfg.ConsensusCommon.Ipv6P2pPort.Value.(uint16)
+	ports = append(ports, fmt.Sprintf("%d:%d/tcp", port, port), fmt.Sprintf("%d:%d/udp", port, port))
+	if cfg.ConsensusClient.Value.(config.ConsensusClient) == config.ConsensusClient_Lighthouse {
+		quicPort := cfg.Lighthouse.P2pQuicIpv6Port.Value.(uint16)
+		ports = append(ports, fmt.Sprintf("%d:%d/udp", quicPort, quicPort))
+	}
+	return ports
+}
+
+// Check the IPv6 settings, returning a description of each problem
+func (cfg *RocketPoolConfig) validateIpv6() []string {
+	errors := []string{}
+	if cfg.Smartnode.EnableIpv6.Value != true {
+		return errors
+	}
+
+	listenAddress := strings.TrimSpace(cfg.Smartnode.Ipv6ListenAddress.Value.(string))
+	if ip := net.ParseIP(listenAddress); ip == nil || ip.To4() != nil {
+		errors = append(errors, fmt.Sprintf("The IPv6 Listen Address [%s] isn't a valid IPv6 address.", listenAddress))
+	}
+
+	advertisedAddress := strings.TrimSpace(cfg.Smartnode.Ipv6AdvertisedAddress.Value.(string))
+	if cfg.Smartnode.Ipv6Discovery.Value == true && advertisedAddress != "" {
+		ip := net.ParseIP(advertisedAddress)
+		if ip == nil || ip.To4() != nil {
+			errors = append(errors, fmt.Sprintf("The Advertised IPv6 Address [%s] isn't a valid IPv6 address.", advertisedAddress))
+		} else if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+			errors = append(errors, fmt.Sprintf("The Advertised IPv6 Address [%s] has to be a public address that other nodes can reach.", advertisedAddress))
+		}
+	}
+
+	if cfg.ConsensusClientLocal() && cfg.usesSeparateIpv6Ports() {
+		if cfg.ConsensusCommon.Ipv6P2pPort.Value.(uint16) == cfg.ConsensusCommon.P2pPort.Value.(uint16) {
+			clientName := string(cfg.ConsensusClient.Value.(config.ConsensusClient))
+			if cCfg, err := cfg.GetSelectedConsensusClientConfig(); err == nil {
+				clientName = cCfg.GetName()
+			}
+			errors = append(errors, fmt.Sprintf("The IPv6 P2P Port has to be different from the P2P Port, because %s listens on IPv4 and IPv6 separately.", clientName))
+		}
+		if cfg.ConsensusClient.Value.(config.ConsensusClient) == config.ConsensusClient_Lighthouse && cfg.Lighthouse.P2pQuicIpv6Port.Value.(uint16) == cfg.Lighthouse.P2pQuicPort.Value.(uint16) {
+			errors = append(errors, "The IPv6 P2P QUIC Port has to be different from the P2P QUIC Port, because Lighthouse listens on IPv4 and IPv6 separately.")
+		}
+	}
+
+	return errors
+}
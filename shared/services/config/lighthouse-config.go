@@ -34,6 +34,9 @@ type LighthouseConfig struct {
 
 	// The port to use for gossip traffic using the QUIC protocol
 	P2pQuicPort config.Parameter `yaml:"p2pQuicPort,omitempty"`
+
+	// The port to use for gossip traffic using the QUIC protocol over IPv6
+	P2pQuicIpv6Port config.Parameter `yaml:"p2pQuicIpv6Port,omitempty"`
 }
 
 // Generates a new Lighthouse configuration
@@ -61,6 +64,16 @@ func NewLighthouseConfig(cfg *RocketPoolConfig) *LighthouseConfig {
 			CanBeBlank:         false,
 			OverwriteOnUpgrade: false,
 		},
+		P2pQuicIpv6Port: config.Parameter{
+			ID:                 "p2pQuicIpv6Port",
+			Name:               "IPv6 P2P QUIC Port",
+			Description:        "The port to use for P2P (blockchain) traffic using the QUIC protocol over IPv6 when IPv6 is enabled.",
+			Type:               config.ParameterType_Uint16,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultP2pQuicIpv6Port},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Eth2},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
 
 		ContainerTag: config.Parameter{
 			ID:          "containerTag",
@@ -106,6 +119,7 @@ func (cfg *LighthouseConfig) GetParameters() []*config.Parameter {
 	return []*config.Parameter{
 		&cfg.MaxPeers,
 		&cfg.P2pQuicPort,
+		&cfg.P2pQuicIpv6Port,
 		&cfg.ContainerTag,
 		&cfg.AdditionalBnFlags,
 		&cfg.AdditionalVcFlags,
@@ -139,8 +153,9 @@ func (cfg *LighthouseConfig) GetConfigTitle() string {
 
 // Get the appropriate LH default tag for production
 func getLighthouseTagProd() string {
+	// The modern image is only built for x86-64
 	missingFeatures := sys.GetMissingModernCpuFeatures()
-	if len(missingFeatures) > 0 {
+	if sys.GetHostArchitecture() != "amd64" || len(missingFeatures) > 0 {
 		return lighthouseTagPortableProd
 	}
 	return lighthouseTagModernProd
@@ -149,7 +164,7 @@ func getLighthouseTagProd() string {
 // Get the appropriate LH default tag for testnets
 func getLighthouseTagTest() string {
 	missingFeatures := sys.GetMissingModernCpuFeatures()
-	if len(missingFeatures) > 0 {
+	if sys.GetHostArchitecture() != "amd64" || len(missingFeatures) > 0 {
 		return lighthouseTagPortableTest
 	}
 	return lighthouseTagModernTest
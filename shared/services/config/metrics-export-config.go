@@ -0,0 +1,165 @@
+package config
+
+import (
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Defaults
+const (
+	defaultMetricsExportInterval  uint64 = 60
+	defaultMetricsExportBatchSize uint64 = 500
+	defaultMetricsExportJob       string = "rocketpool"
+)
+
+// Configuration for pushing the daemons' metrics to a Prometheus pushgateway or remote-write endpoint,
+// for setups where Prometheus can't scrape the daemons directly
+type MetricsExportConfig struct {
+	Title string `yaml:"-"`
+
+	// How to export the metrics
+	Mode config.Parameter `yaml:"mode,omitempty"`
+
+	// The URL of the pushgateway or remote-write endpoint
+	Url config.Parameter `yaml:"url,omitempty"`
+
+	// How often to push the metrics, in seconds
+	Interval config.Parameter `yaml:"interval,omitempty"`
+
+	// The most time series to send in a single remote-write request
+	BatchSize config.Parameter `yaml:"batchSize,omitempty"`
+
+	// The job label to attach to the metrics
+	Job config.Parameter `yaml:"job,omitempty"`
+
+	// The credentials for the endpoint
+	Username    config.Parameter `yaml:"username,omitempty"`
+	Password    config.Parameter `yaml:"password,omitempty"`
+	BearerToken config.Parameter `yaml:"bearerToken,omitempty"`
+}
+
+// Generates a new metrics export config
+func NewMetricsExportConfig(cfg *RocketPoolConfig) *MetricsExportConfig {
+	return &MetricsExportConfig{
+		Title: "Metrics Export Settings",
+
+		Mode: config.Parameter{
+			ID:                 "mode",
+			Name:               "Export Mode",
+			Description:        "Push the Smartnode daemons' metrics to a remote Prometheus server, for setups where it can't scrape them directly. The metrics are still available on the usual metrics ports.",
+			Type:               config.ParameterType_Choice,
+			Default:            map[config.Network]interface{}{config.Network_All: config.MetricsExportMode_None},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+			Options: []config.ParameterOption{{
+				Name:        "None",
+				Description: "Don't push the metrics anywhere.",
+				Value:       config.MetricsExportMode_None,
+			}, {
+				Name:        "Pushgateway",
+				Description: "Push the metrics to a Prometheus pushgateway, which the Prometheus server then scrapes.",
+				Value:       config.MetricsExportMode_Pushgateway,
+			}, {
+				Name:        "Remote Write",
+				Description: "Send the metrics to a Prometheus remote-write endpoint, such as Prometheus itself with the remote-write receiver enabled, Grafana Cloud, Mimir, or VictoriaMetrics.",
+				Value:       config.MetricsExportMode_RemoteWrite,
+			}},
+		},
+
+		Url: config.Parameter{
+			ID:                 "url",
+			Name:               "Endpoint URL",
+			Description:        "The URL of the pushgateway (for example `http://pushgateway:9091`) or of the remote-write endpoint (for example `https://prometheus.example.com/api/v1/write`).",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		Interval: config.Parameter{
+			ID:                 "interval",
+			Name:               "Push Interval",
+			Description:        "How often to push the metrics, in seconds.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultMetricsExportInterval},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		BatchSize: config.Parameter{
+			ID:                 "batchSize",
+			Name:               "Remote Write Batch Size",
+			Description:        "The most time series to send in a single remote-write request. Lower this if your endpoint rejects large requests.\n\nOnly used in Remote Write mode.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultMetricsExportBatchSize},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		Job: config.Parameter{
+			ID:                 "job",
+			Name:               "Job Name",
+			Description:        "The `job` label to attach to the pushed metrics. Each daemon also adds its own `instance` label, so the metrics from different daemons and machines don't overwrite each other.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultMetricsExportJob},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		Username: config.Parameter{
+			ID:                 "username",
+			Name:               "Username",
+			Description:        "The username for the endpoint, if it uses basic authentication.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		Password: config.Parameter{
+			ID:                 "password",
+			Name:               "Password",
+			Description:        "The password for the endpoint, if it uses basic authentication.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		BearerToken: config.Parameter{
+			ID:                 "bearerToken",
+			Name:               "Bearer Token",
+			Description:        "The bearer token for the endpoint, if it uses token authentication instead of a username and password.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+	}
+}
+
+// Get the parameters for this config
+func (cfg *MetricsExportConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.Mode,
+		&cfg.Url,
+		&cfg.Interval,
+		&cfg.BatchSize,
+		&cfg.Job,
+		&cfg.Username,
+		&cfg.Password,
+		&cfg.BearerToken,
+	}
+}
+
+// The the title for the config
+func (cfg *MetricsExportConfig) GetConfigTitle() string {
+	return cfg.Title
+}
@@ -1,10 +1,9 @@
 package config
 
 import (
-	"runtime"
-
 	"github.com/pbnjay/memory"
 	"github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/sys"
 )
 
 // Constants
@@ -241,7 +240,7 @@ func calculateNethermindFullPruneMemBudget() uint64 {
 
 // Calculate the default number of Nethermind peers
 func calculateNethermindPeers() uint16 {
-	if runtime.GOARCH == "arm64" {
+	if sys.GetHostArchitecture() == "arm64" {
 		return 25
 	}
 	return 50
@@ -0,0 +1,302 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/smartnode/shared/types/config"
+	"gopkg.in/yaml.v2"
+)
+
+// The folder inside the Rocket Pool directory that holds the descriptors for additional networks
+const NetworksFolder string = "networks"
+
+// Describes an Ethereum network the Smartnode can run on, including the Rocket Pool contracts deployed to it.
+// The built-in networks are described below; new networks (such as a fresh testnet) can be added without code changes
+// by dropping a descriptor file into the networks folder of the Rocket Pool directory.
+type NetworkDescriptor struct {
+	// The ID of the network, as used in the settings file
+	Network config.Network `yaml:"network"`
+
+	// The name and description shown in the network selector
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+
+	// True if the network should only be offered in development builds
+	DevOnly bool `yaml:"devOnly,omitempty"`
+
+	// The network whose client defaults (container tags, MEV-Boost relays, and so on) this network inherits
+	BaseNetwork config.Network `yaml:"baseNetwork,omitempty"`
+
+	// The execution chain ID
+	ChainID uint `yaml:"chainID"`
+
+	// The URL to provide the user so they can follow pending transactions
+	TxWatchUrl string `yaml:"txWatchUrl,omitempty"`
+
+	// The URL to use for staking rETH
+	StakeUrl string `yaml:"stakeUrl,omitempty"`
+
+	// The default checkpoint sync URL for the Consensus client
+	CheckpointSyncUrl string `yaml:"checkpointSyncUrl,omitempty"`
+
+	// The FlashBots Protect RPC endpoint
+	FlashbotsProtectUrl string `yaml:"flashbotsProtectUrl,omitempty"`
+
+	// True if MEV-Boost isn't available on the network
+	DisableMevBoost bool `yaml:"disableMevBoost,omitempty"`
+
+	// The Snapshot API domain
+	SnapshotApiDomain string `yaml:"snapshotApiDomain,omitempty"`
+
+	// The Snapshot sequencer domain, used to submit signed votes
+	SnapshotSequencerDomain string `yaml:"snapshotSequencerDomain,omitempty"`
+
+	// The first interval each rewards ruleset applies to, by ruleset version.
+	// Only used for additional networks; rulesets that aren't listed start at interval 0.
+	RewardsRulesetStartIntervals map[uint64]uint64 `yaml:"rewardsRulesetStartIntervals,omitempty"`
+
+	// The addresses of the contracts on the network
+	Contracts NetworkContracts `yaml:"contracts"`
+}
+
+// The addresses of the Rocket Pool and supporting contracts on a network
+type NetworkContracts struct {
+	// The contract address of RocketStorage
+	Storage string `yaml:"storage"`
+
+	// The contract address of the RPL token
+	RplToken string `yaml:"rplToken,omitempty"`
+
+	// The contract address of rETH
+	Reth string `yaml:"reth,omitempty"`
+
+	// The multicall contract address
+	Multicall string `yaml:"multicall,omitempty"`
+
+	// The BalanceChecker contract address
+	BalanceBatcher string `yaml:"balanceBatcher,omitempty"`
+
+	// The UniswapV3 pool address (used for RPL price TWAP info)
+	RplTwapPool string `yaml:"rplTwapPool,omitempty"`
+
+	// The contract address for Snapshot delegation
+	SnapshotDelegation string `yaml:"snapshotDelegation,omitempty"`
+
+	// The contract addresses of legacy contracts from v1.0.0
+	V100RewardsPool      string `yaml:"v100RewardsPool,omitempty"`
+	V100ClaimNode        string `yaml:"v100ClaimNode,omitempty"`
+	V100ClaimTrustedNode string `yaml:"v100ClaimTrustedNode,omitempty"`
+	V100MinipoolManager  string `yaml:"v100MinipoolManager,omitempty"`
+	V110NetworkPrices    string `yaml:"v110NetworkPrices,omitempty"`
+	V110NodeStaking      string `yaml:"v110NodeStaking,omitempty"`
+	V110NodeDeposit      string `yaml:"v110NodeDeposit,omitempty"`
+	V110MinipoolQueue    string `yaml:"v110MinipoolQueue,omitempty"`
+	V110MinipoolFactory  string `yaml:"v110MinipoolFactory,omitempty"`
+	V120NetworkPrices    string `yaml:"v120NetworkPrices,omitempty"`
+	V120NetworkBalances  string `yaml:"v120NetworkBalances,omitempty"`
+
+	// Addresses for contracts that have been upgraded during development
+	PreviousRewardsPools        []string `yaml:"previousRewardsPools,omitempty"`
+	PreviousDAOProtocolVerifier []string `yaml:"previousDAOProtocolVerifier,omitempty"`
+	PreviousNetworkPrices       []string `yaml:"previousNetworkPrices,omitempty"`
+	PreviousNetworkBalances     []string `yaml:"previousNetworkBalances,omitempty"`
+
+	// The price messenger contracts for the L2s
+	OptimismPriceMessenger   string `yaml:"optimismPriceMessenger,omitempty"`
+	PolygonPriceMessenger    string `yaml:"polygonPriceMessenger,omitempty"`
+	ArbitrumPriceMessenger   string `yaml:"arbitrumPriceMessenger,omitempty"`
+	ArbitrumPriceMessengerV2 string `yaml:"arbitrumPriceMessengerV2,omitempty"`
+	ZkSyncEraPriceMessenger  string `yaml:"zkSyncEraPriceMessenger,omitempty"`
+	BasePriceMessenger       string `yaml:"basePriceMessenger,omitempty"`
+	ScrollPriceMessenger     string `yaml:"scrollPriceMessenger,omitempty"`
+	ScrollFeeEstimator       string `yaml:"scrollFeeEstimator,omitempty"`
+}
+
+// Get the descriptors for the networks built into the Smartnode
+func getBuiltinNetworkDescriptors() []*NetworkDescriptor {
+	return []*NetworkDescriptor{
+		{
+			Network:                 config.Network_Mainnet,
+			Name:                    "Ethereum Mainnet",
+			Description:             "This is the real Ethereum main network, using real ETH and real RPL to make real validators.",
+			ChainID:                 1,
+			TxWatchUrl:              "https://etherscan.io/tx",
+			StakeUrl:                "https://stake.rocketpool.net",
+			FlashbotsProtectUrl:     "https://rpc.flashbots.net/",
+			SnapshotApiDomain:       "hub.snapshot.org",
+			SnapshotSequencerDomain: "seq.snapshot.org",
+			Contracts: NetworkContracts{
+				Storage:                  "0x1d8f8f00cfa6758d7bE78336684788Fb0ee0Fa46",
+				RplToken:                 "0xD33526068D116cE69F19A9ee46F0bd304F21A51f",
+				Reth:                     "0xae78736Cd615f374D3085123A210448E74Fc6393",
+				Multicall:                "0x5BA1e12693Dc8F9c48aAD8770482f4739bEeD696",
+				BalanceBatcher:           "0xb1f8e55c7f64d203c1400b9d8555d050f94adf39",
+				RplTwapPool:              "0xe42318ea3b998e8355a3da364eb9d48ec725eb45",
+				SnapshotDelegation:       "0x469788fE6E9E9681C6ebF3bF78e7Fd26Fc015446",
+				V100RewardsPool:          "0xA3a18348e6E2d3897B6f2671bb8c120e36554802",
+				V100ClaimNode:            "0x899336A2a86053705E65dB61f52C686dcFaeF548",
+				V100ClaimTrustedNode:     "0x6af730deB0463b432433318dC8002C0A4e9315e8",
+				V100MinipoolManager:      "0x6293B8abC1F36aFB22406Be5f96D893072A8cF3a",
+				V110NetworkPrices:        "0xd3f500F550F46e504A4D2153127B47e007e11166",
+				V110NodeStaking:          "0xA73ec45Fe405B5BFCdC0bF4cbc9014Bb32a01cd2",
+				V110NodeDeposit:          "0x1Cc9cF5586522c6F483E84A19c3C2B0B6d027bF0",
+				V110MinipoolQueue:        "0x5870dA524635D1310Dc0e6F256Ce331012C9C19E",
+				V110MinipoolFactory:      "0x54705f80D7C51Fcffd9C659ce3f3C9a7dCCf5788",
+				V120NetworkPrices:        "0x751826b107672360b764327631cC5764515fFC37",
+				V120NetworkBalances:      "0x07FCaBCbe4ff0d80c2b1eb42855C0131b6cba2F4",
+				PreviousRewardsPools:     []string{"0x594Fb75D3dc2DFa0150Ad03F99F97817747dd4E1"},
+				PreviousNetworkPrices:    []string{"0x751826b107672360b764327631cC5764515fFC37"},
+				PreviousNetworkBalances:  []string{"0x07FCaBCbe4ff0d80c2b1eb42855C0131b6cba2F4"},
+				OptimismPriceMessenger:   "0xdddcf2c25d50ec22e67218e873d46938650d03a7",
+				PolygonPriceMessenger:    "0xb1029Ac2Be4e08516697093e2AFeC435057f3511",
+				ArbitrumPriceMessenger:   "0x05330300f829AD3fC8f33838BC88CFC4093baD53",
+				ArbitrumPriceMessengerV2: "0x312FcFB03eC9B1Ea38CB7BFCd26ee7bC3b505aB1",
+				ZkSyncEraPriceMessenger:  "0x6cf6CB29754aEBf88AF12089224429bD68b0b8c8",
+				BasePriceMessenger:       "0x64A5856869C06B0188C84A5F83d712bbAc03517d",
+				ScrollPriceMessenger:     "0x0f22dc9b9c03757d4676539203d7549c8f22c15c",
+				ScrollFeeEstimator:       "0x0d7E906BD9cAFa154b048cFa766Cc1E54E39AF9B",
+			},
+		},
+		{
+			Network:         config.Network_Holesky,
+			Name:            "Holesky Testnet",
+			Description:     "This is the Holešky (Holešovice) test network, which is the next generation of long-lived testnets for Ethereum. It uses free fake ETH and free fake RPL to make fake validators.\nUse this if you want to practice running the Smartnode in a free, safe environment before moving to Mainnet.",
+			ChainID:         17000,
+			TxWatchUrl:      "https://holesky.etherscan.io/tx",
+			StakeUrl:        "https://testnet.rocketpool.net",
+			DisableMevBoost: true,
+			Contracts: NetworkContracts{
+				Storage:                 "0x594Fb75D3dc2DFa0150Ad03F99F97817747dd4E1",
+				RplToken:                "0x1Cc9cF5586522c6F483E84A19c3C2B0B6d027bF0",
+				Reth:                    "0x7322c24752f79c05FFD1E2a6FCB97020C1C264F1",
+				Multicall:               "0x0540b786f03c9491f3a2ab4b0e3ae4ecd4f63ce7",
+				BalanceBatcher:          "0xfAa2e7C84eD801dd9D27Ac1ed957274530796140",
+				RplTwapPool:             "0x7bb10d2a3105ed5cc150c099a06cafe43d8aa15d",
+				V120NetworkPrices:       "0x029d946F28F93399a5b0D09c879FC8c94E596AEb",
+				V120NetworkBalances:     "0x9294Fc6F03c64Cc217f5BE8697EA3Ed2De77e2F8",
+				PreviousRewardsPools:    []string{"0x4a625C617a44E60F74E3fe3bf6d6333b63766e91"},
+				PreviousNetworkPrices:   []string{"0x029d946f28f93399a5b0d09c879fc8c94e596aeb"},
+				PreviousNetworkBalances: []string{"0x9294Fc6F03c64Cc217f5BE8697EA3Ed2De77e2F8"},
+			},
+		},
+		{
+			Network:             config.Network_Devnet,
+			Name:                "Devnet",
+			Description:         "This is a development network used by Rocket Pool engineers to test new features and contract upgrades before they are promoted to a Testnet for staging. You should not use this network unless invited to do so by the developers.",
+			DevOnly:             true,
+			ChainID:             17000, // Also Holesky
+			TxWatchUrl:          "https://holesky.etherscan.io/tx",
+			StakeUrl:            "TBD",
+			FlashbotsProtectUrl: "https://rpc-holesky.flashbots.net/",
+			DisableMevBoost:     true,
+			Contracts: NetworkContracts{
+				Storage:              "0xf04de123993761Bb9F08c9C39112b0E0b0eccE50",
+				RplToken:             "0x59A1a7AebCbF103B3C4f85261fbaC166117E1979",
+				Reth:                 "0x4be7161080b5d890500194cee2c40B1428002Bd3",
+				Multicall:            "0x0540b786f03c9491f3a2ab4b0e3ae4ecd4f63ce7",
+				BalanceBatcher:       "0xfAa2e7C84eD801dd9D27Ac1ed957274530796140",
+				RplTwapPool:          "0x7bb10d2a3105ed5cc150c099a06cafe43d8aa15d",
+				V100RewardsPool:      "0x4A1b5Ab9F6C36E7168dE5F994172028Ca8554e02",
+				V120NetworkPrices:    "0xBba3FBCD4Bdbfc79118B1B31218602E5A71B426c",
+				V120NetworkBalances:  "0xBe8Dc8CA5f339c196Aef634DfcDFbA61E30DC743",
+				PreviousRewardsPools: []string{"0x4d581a552490fb6fce5F978e66560C8b7E481818"},
+			},
+		},
+	}
+}
+
+// Load the descriptors for additional networks from the provided folder.
+// A missing folder isn't an error, since most users never add any networks.
+func LoadNetworkDescriptors(folder string) ([]*NetworkDescriptor, error) {
+	entries, err := os.ReadDir(folder)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading network descriptor folder [%s]: %w", folder, err)
+	}
+
+	// Load them in alphabetical order so the network selector is stable
+	names := []string{}
+	for _, entry := range entries {
+		extension := filepath.Ext(entry.Name())
+		if entry.IsDir() || (extension != ".yml" && extension != ".yaml") {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	descriptors := make([]*NetworkDescriptor, 0, len(names))
+	for _, name := range names {
+		path := filepath.Join(folder, name)
+		bytes, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading network descriptor [%s]: %w", path, err)
+		}
+		descriptor := new(NetworkDescriptor)
+		err = yaml.Unmarshal(bytes, descriptor)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing network descriptor [%s]: %w", path, err)
+		}
+		err = descriptor.Validate()
+		if err != nil {
+			return nil, fmt.Errorf("network descriptor [%s] is invalid: %w", path, err)
+		}
+		descriptors = append(descriptors, descriptor)
+	}
+	return descriptors, nil
+}
+
+// Make sure the descriptor has everything the Smartnode needs to use the network
+func (d *NetworkDescriptor) Validate() error {
+	if d.Network == config.Network_Unknown || d.Network == config.Network_All {
+		return fmt.Errorf("network ID [%s] can't be used", d.Network)
+	}
+	if strings.TrimSpace(d.Name) == "" {
+		return fmt.Errorf("network [%s] doesn't have a name", d.Network)
+	}
+	if d.ChainID == 0 {
+		return fmt.Errorf("network [%s] doesn't have a chain ID", d.Network)
+	}
+	if !common.IsHexAddress(d.Contracts.Storage) {
+		return fmt.Errorf("network [%s] has an invalid RocketStorage address [%s]", d.Network, d.Contracts.Storage)
+	}
+	if d.BaseNetwork == d.Network {
+		return fmt.Errorf("network [%s] can't be its own base network", d.Network)
+	}
+	return nil
+}
+
+// Merge the additional descriptors into the built-in ones; a descriptor for a built-in network replaces it
+func mergeNetworkDescriptors(builtin []*NetworkDescriptor, additional []*NetworkDescriptor) []*NetworkDescriptor {
+	merged := append([]*NetworkDescriptor{}, builtin...)
+	for _, descriptor := range additional {
+		replaced := false
+		for i, existing := range merged {
+			if existing.Network == descriptor.Network {
+				merged[i] = descriptor
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, descriptor)
+		}
+	}
+	return merged
+}
+
+// Convert a list of hex addresses into addresses
+func toAddresses(hexAddresses []string) []common.Address {
+	addresses := make([]common.Address, len(hexAddresses))
+	for i, address := range hexAddresses {
+		addresses[i] = common.HexToAddress(address)
+	}
+	return addresses
+}
@@ -1,9 +1,8 @@
 package config
 
 import (
-	"runtime"
-
 	"github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/sys"
 )
 
 const (
@@ -50,6 +49,8 @@ func NewNimbusConfig(cfg *RocketPoolConfig) *NimbusConfig {
 	return &NimbusConfig{
 		Title: "Nimbus Settings",
 
+		UnsupportedCommonParams: []string{Ipv6P2pPortID},
+
 		MaxPeers: config.Parameter{
 			ID:                 "maxPeers",
 			Name:               "Max Peers",
@@ -173,7 +174,7 @@ func (cfg *NimbusConfig) GetConfigTitle() string {
 }
 
 func getNimbusDefaultPeers() uint16 {
-	if runtime.GOARCH == "arm64" {
+	if sys.GetHostArchitecture() == "arm64" {
 		return defaultNimbusMaxPeersArm
 	}
 
@@ -0,0 +1,313 @@
+package config
+
+import (
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Defaults
+const (
+	defaultSmtpPort    uint16 = 587
+	defaultDigestHour  uint64 = 8
+	defaultEmailPrefix string = "[Rocket Pool]"
+	defaultNtfyServer  string = "https://ntfy.sh"
+	defaultPushBatch   uint64 = 60
+)
+
+// Configuration for delivering alerts straight from the node daemon, alongside Alertmanager's own receivers
+type NotificationsConfig struct {
+	Title string `yaml:"-"`
+
+	// When to send alert emails
+	EmailMode config.Parameter `yaml:"emailMode,omitempty"`
+
+	// The SMTP server to send emails through
+	SmtpHost     config.Parameter `yaml:"smtpHost,omitempty"`
+	SmtpPort     config.Parameter `yaml:"smtpPort,omitempty"`
+	SmtpSecurity config.Parameter `yaml:"smtpSecurity,omitempty"`
+	SmtpUsername config.Parameter `yaml:"smtpUsername,omitempty"`
+	SmtpPassword config.Parameter `yaml:"smtpPassword,omitempty"`
+
+	// The addresses to send emails from and to
+	EmailFrom config.Parameter `yaml:"emailFrom,omitempty"`
+	EmailTo   config.Parameter `yaml:"emailTo,omitempty"`
+
+	// The prefix for the email subjects
+	EmailSubjectPrefix config.Parameter `yaml:"emailSubjectPrefix,omitempty"`
+
+	// The hour of the day (UTC) to send the daily digest
+	DigestHour config.Parameter `yaml:"digestHour,omitempty"`
+
+	// The ntfy server and topic to push alerts to
+	NtfyServer config.Parameter `yaml:"ntfyServer,omitempty"`
+	NtfyTopic  config.Parameter `yaml:"ntfyTopic,omitempty"`
+	NtfyToken  config.Parameter `yaml:"ntfyToken,omitempty"`
+
+	// The Pushover application and user to push alerts to
+	PushoverAppToken config.Parameter `yaml:"pushoverAppToken,omitempty"`
+	PushoverUserKey  config.Parameter `yaml:"pushoverUserKey,omitempty"`
+
+	// The lowest severity that's pushed right away, and how often the rest are pushed together
+	PushImmediateSeverity config.Parameter `yaml:"pushImmediateSeverity,omitempty"`
+	PushBatchInterval     config.Parameter `yaml:"pushBatchInterval,omitempty"`
+}
+
+// Generates a new notifications config
+func NewNotificationsConfig(cfg *RocketPoolConfig) *NotificationsConfig {
+	return &NotificationsConfig{
+		Title: "Notification Settings",
+
+		EmailMode: config.Parameter{
+			ID:                 "emailMode",
+			Name:               "Email Alerts",
+			Description:        "Choose whether the node daemon should email you about alerts, and when. The alerts are read from Alertmanager, so alerting must be enabled for them to be included.",
+			Type:               config.ParameterType_Choice,
+			Default:            map[config.Network]interface{}{config.Network_All: config.EmailMode_Disabled},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+			Options: []config.ParameterOption{{
+				Name:        "Disabled",
+				Description: "Don't send any alert emails.",
+				Value:       config.EmailMode_Disabled,
+			}, {
+				Name:        "Immediate",
+				Description: "Send an email as soon as each new alert fires.",
+				Value:       config.EmailMode_Immediate,
+			}, {
+				Name:        "Daily Digest",
+				Description: "Send one email a day summarizing your node's status, its rewards, and every alert from the last 24 hours.",
+				Value:       config.EmailMode_Digest,
+			}},
+		},
+
+		SmtpHost: config.Parameter{
+			ID:                 "smtpHost",
+			Name:               "SMTP Server",
+			Description:        "The hostname of the SMTP server to send the emails through, such as `smtp.gmail.com`.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		SmtpPort: config.Parameter{
+			ID:                 "smtpPort",
+			Name:               "SMTP Port",
+			Description:        "The port of the SMTP server. This is usually 587 for STARTTLS, 465 for TLS, or 25 for an unencrypted connection.",
+			Type:               config.ParameterType_Uint16,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultSmtpPort},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		SmtpSecurity: config.Parameter{
+			ID:                 "smtpSecurity",
+			Name:               "SMTP Security",
+			Description:        "How the connection to the SMTP server is secured.",
+			Type:               config.ParameterType_Choice,
+			Default:            map[config.Network]interface{}{config.Network_All: config.SmtpSecurity_StartTls},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+			Options: []config.ParameterOption{{
+				Name:        "STARTTLS",
+				Description: "Connect without encryption, then upgrade the connection with STARTTLS. The server must support it.",
+				Value:       config.SmtpSecurity_StartTls,
+			}, {
+				Name:        "TLS",
+				Description: "Use TLS for the whole connection (sometimes called SMTPS).",
+				Value:       config.SmtpSecurity_Tls,
+			}, {
+				Name:        "None",
+				Description: "Don't encrypt the connection. Only use this for a mail server on your local network.",
+				Value:       config.SmtpSecurity_None,
+			}},
+		},
+
+		SmtpUsername: config.Parameter{
+			ID:                 "smtpUsername",
+			Name:               "SMTP Username",
+			Description:        "The username to log into the SMTP server with. Leave this blank if the server doesn't need a login.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		SmtpPassword: config.Parameter{
+			ID:                 "smtpPassword",
+			Name:               "SMTP Password",
+			Description:        "The password to log into the SMTP server with. Many providers require an app-specific password here.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		EmailFrom: config.Parameter{
+			ID:                 "emailFrom",
+			Name:               "From Address",
+			Description:        "The address to send the emails from.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		EmailTo: config.Parameter{
+			ID:                 "emailTo",
+			Name:               "To Addresses",
+			Description:        "The addresses to send the emails to, separated by commas.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		EmailSubjectPrefix: config.Parameter{
+			ID:                 "emailSubjectPrefix",
+			Name:               "Subject Prefix",
+			Description:        "The text to put at the start of each email's subject, which is handy for filtering them or telling several nodes apart.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultEmailPrefix},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		DigestHour: config.Parameter{
+			ID:                 "digestHour",
+			Name:               "Digest Hour (UTC)",
+			Description:        "The hour of the day, in UTC (0 to 23), to send the daily digest email.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultDigestHour},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		NtfyServer: config.Parameter{
+			ID:                 "ntfyServer",
+			Name:               "ntfy Server",
+			Description:        "The URL of the ntfy server to push alerts through. Change this if you host your own server.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultNtfyServer},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		NtfyTopic: config.Parameter{
+			ID:                 "ntfyTopic",
+			Name:               "ntfy Topic",
+			Description:        "The ntfy topic to push alerts to; subscribe to it in the ntfy app to get them on your phone. Anyone who knows the name of a topic on a public server can read it, so pick one that's hard to guess. Leave this blank to disable ntfy.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		NtfyToken: config.Parameter{
+			ID:                 "ntfyToken",
+			Name:               "ntfy Access Token",
+			Description:        "The access token to publish to the topic with, if it's protected. Leave this blank for public topics.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		PushoverAppToken: config.Parameter{
+			ID:                 "pushoverAppToken",
+			Name:               "Pushover App Token",
+			Description:        "The API token of the Pushover application to push alerts with. You can create one at https://pushover.net/apps/build. Leave this blank to disable Pushover.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		PushoverUserKey: config.Parameter{
+			ID:                 "pushoverUserKey",
+			Name:               "Pushover User Key",
+			Description:        "Your Pushover user key (or a group key), shown on your Pushover dashboard.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		PushImmediateSeverity: config.Parameter{
+			ID:                 "pushImmediateSeverity",
+			Name:               "Push Immediately",
+			Description:        "Alerts at or above this severity are pushed to ntfy and Pushover as soon as they fire. Less severe alerts are held and pushed together as one summary every batch interval.",
+			Type:               config.ParameterType_Choice,
+			Default:            map[config.Network]interface{}{config.Network_All: config.PushSeverity_Critical},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+			Options: []config.ParameterOption{{
+				Name:        "Critical",
+				Description: "Only push critical alerts right away.",
+				Value:       config.PushSeverity_Critical,
+			}, {
+				Name:        "Warning",
+				Description: "Push critical alerts and warnings right away.",
+				Value:       config.PushSeverity_Warning,
+			}, {
+				Name:        "All",
+				Description: "Push every alert right away.",
+				Value:       config.PushSeverity_Info,
+			}},
+		},
+
+		PushBatchInterval: config.Parameter{
+			ID:                 "pushBatchInterval",
+			Name:               "Push Batch Interval",
+			Description:        "How often, in minutes, to push a summary of the less severe alerts that weren't pushed right away.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultPushBatch},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+	}
+}
+
+// Get the parameters for this config
+func (cfg *NotificationsConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.EmailMode,
+		&cfg.SmtpHost,
+		&cfg.SmtpPort,
+		&cfg.SmtpSecurity,
+		&cfg.SmtpUsername,
+		&cfg.SmtpPassword,
+		&cfg.EmailFrom,
+		&cfg.EmailTo,
+		&cfg.EmailSubjectPrefix,
+		&cfg.DigestHour,
+		&cfg.NtfyServer,
+		&cfg.NtfyTopic,
+		&cfg.NtfyToken,
+		&cfg.PushoverAppToken,
+		&cfg.PushoverUserKey,
+		&cfg.PushImmediateSeverity,
+		&cfg.PushBatchInterval,
+	}
+}
+
+// The the title for the config
+func (cfg *NotificationsConfig) GetConfigTitle() string {
+	return cfg.Title
+}
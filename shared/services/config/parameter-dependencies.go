@@ -0,0 +1,132 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Make each of the parameters depend on the same conditions
+func dependOn(params []*config.Parameter, dependencies ...config.ParameterDependency) {
+	for _, param := range params {
+		param.Dependencies = append(param.Dependencies, dependencies...)
+	}
+}
+
+// Declare which parameters only apply for certain values of the others, so the TUI can hide them and headless changes to them
+// can be rejected when they'd have no effect
+func (cfg *RocketPoolConfig) setParameterDependencies() {
+	localEc := config.ParameterDependency{Parameter: &cfg.ExecutionClientMode, Values: []interface{}{config.Mode_Local}}
+	externalEc := config.ParameterDependency{Parameter: &cfg.ExecutionClientMode, Values: []interface{}{config.Mode_External}}
+	localCc := config.ParameterDependency{Parameter: &cfg.ConsensusClientMode, Values: []interface{}{config.Mode_Local}}
+	externalCc := config.ParameterDependency{Parameter: &cfg.ConsensusClientMode, Values: []interface{}{config.Mode_External}}
+	ec := func(client config.ExecutionClient) config.ParameterDependency {
+		return config.ParameterDependency{Parameter: &cfg.ExecutionClient, Values: []interface{}{client}}
+	}
+	cc := func(client config.ConsensusClient) config.ParameterDependency {
+		return config.ParameterDependency{Parameter: &cfg.ConsensusClient, Values: []interface{}{client}}
+	}
+	externalCcClient := func(client config.ConsensusClient) config.ParameterDependency {
+		return config.ParameterDependency{Parameter: &cfg.ExternalConsensusClient, Values: []interface{}{client}}
+	}
+	enabled := func(param *config.Parameter) config.ParameterDependency {
+		return config.ParameterDependency{Parameter: param, Values: []interface{}{true}}
+	}
+	set := func(param *config.Parameter) config.ParameterDependency {
+		return config.ParameterDependency{Parameter: param}
+	}
+
+	// Client settings only apply to the selected client
+	dependOn(cfg.Geth.GetParameters(), localEc, ec(config.ExecutionClient_Geth))
+	dependOn(cfg.Nethermind.GetParameters(), localEc, ec(config.ExecutionClient_Nethermind))
+	dependOn(cfg.Besu.GetParameters(), localEc, ec(config.ExecutionClient_Besu))
+	dependOn(cfg.Reth.GetParameters(), localEc, ec(config.ExecutionClient_Reth))
+	dependOn(cfg.ExternalExecution.GetParameters(), externalEc)
+	dependOn(cfg.Lighthouse.GetParameters(), localCc, cc(config.ConsensusClient_Lighthouse))
+	dependOn(cfg.Lodestar.GetParameters(), localCc, cc(config.ConsensusClient_Lodestar))
+	dependOn(cfg.Nimbus.GetParameters(), localCc, cc(config.ConsensusClient_Nimbus))
+	dependOn(cfg.Prysm.GetParameters(), localCc, cc(config.ConsensusClient_Prysm))
+	dependOn(cfg.Teku.GetParameters(), localCc, cc(config.ConsensusClient_Teku))
+	dependOn(cfg.ExternalLighthouse.GetParameters(), externalCc, externalCcClient(config.ConsensusClient_Lighthouse))
+	dependOn(cfg.ExternalLodestar.GetParameters(), externalCc, externalCcClient(config.ConsensusClient_Lodestar))
+	dependOn(cfg.ExternalNimbus.GetParameters(), externalCc, externalCcClient(config.ConsensusClient_Nimbus))
+	dependOn(cfg.ExternalPrysm.GetParameters(), externalCc, externalCcClient(config.ConsensusClient_Prysm))
+	dependOn(cfg.ExternalTeku.GetParameters(), externalCc, externalCcClient(config.ConsensusClient_Teku))
+
+	// MEV-Boost
+	mevBoost := cfg.MevBoost
+	dependOn(mevBoost.GetParameters(), enabled(&cfg.EnableMevBoost))
+	localMevBoost := config.ParameterDependency{Parameter: &mevBoost.Mode, Values: []interface{}{config.Mode_Local}}
+	dependOn([]*config.Parameter{&mevBoost.SelectionMode, &mevBoost.Port, &mevBoost.OpenRpcPort, &mevBoost.ContainerTag, &mevBoost.AdditionalFlags}, localMevBoost)
+	dependOn([]*config.Parameter{&mevBoost.EnableRegulatedAllMev, &mevBoost.EnableUnregulatedAllMev},
+		localMevBoost, config.ParameterDependency{Parameter: &mevBoost.SelectionMode, Values: []interface{}{config.MevSelectionMode_Profile}})
+	dependOn([]*config.Parameter{&mevBoost.FlashbotsRelay, &mevBoost.BloxRouteMaxProfitRelay, &mevBoost.BloxRouteRegulatedRelay, &mevBoost.EdenRelay, &mevBoost.UltrasoundRelay, &mevBoost.AestusRelay},
+		localMevBoost, config.ParameterDependency{Parameter: &mevBoost.SelectionMode, Values: []interface{}{config.MevSelectionMode_Relay}})
+	dependOn([]*config.Parameter{&mevBoost.ExternalUrl}, config.ParameterDependency{Parameter: &mevBoost.Mode, Values: []interface{}{config.Mode_External}})
+
+	// Smartnode settings that only apply when a feature they belong to is turned on
+	sn := cfg.Smartnode
+	dependOn([]*config.Parameter{&sn.RplStakeTargetRatio, &sn.RplStakeLowerBound, &sn.RplStakeUpperBound, &sn.RplStakeAutoWithdraw, &sn.RplStakeSimulationMode}, enabled(&sn.ManageRplStake))
+	dependOn([]*config.Parameter{&sn.DelegateUpgradeDailyBudget, &sn.DelegateUpgradeBatchSize}, enabled(&sn.AutoUpgradeDelegates))
+	dependOn([]*config.Parameter{&sn.PasswordKeyringService}, config.ParameterDependency{Parameter: &sn.PasswordSource, Values: []interface{}{config.PasswordSource_Keyring}})
+	dependOn([]*config.Parameter{&sn.PasswordVaultAddress, &sn.PasswordVaultSecretPath, &sn.PasswordVaultField, &sn.PasswordVaultTokenPath}, config.ParameterDependency{Parameter: &sn.PasswordSource, Values: []interface{}{config.PasswordSource_Vault}})
+	dependOn([]*config.Parameter{&sn.PasswordCommand}, config.ParameterDependency{Parameter: &sn.PasswordSource, Values: []interface{}{config.PasswordSource_Command}})
+	dependOn([]*config.Parameter{&sn.KeymanagerApiPort}, enabled(&sn.EnableKeymanagerApi))
+	dependOn([]*config.Parameter{&sn.PortForwardingMethod, &sn.PortForwardingGateway, &sn.PortForwardingHostIp}, enabled(&sn.EnablePortForwarding))
+	dependOn([]*config.Parameter{&sn.Ipv6ListenAddress, &sn.Ipv6Discovery, &cfg.ConsensusCommon.Ipv6P2pPort, &cfg.Lighthouse.P2pQuicIpv6Port}, enabled(&sn.EnableIpv6))
+	dependOn([]*config.Parameter{&sn.Ipv6AdvertisedAddress}, enabled(&sn.EnableIpv6), enabled(&sn.Ipv6Discovery))
+	dependOn([]*config.Parameter{&sn.IpfsApiUrl, &sn.IpfsPinningServices, &sn.IpfsVerificationGateways, &sn.IpfsPinCheckInterval}, enabled(&sn.EnableIpfsPinning))
+	dependOn([]*config.Parameter{&sn.RewardsMirrorUploadAuth}, set(&sn.RewardsMirrorUploadUrl))
+	dependOn([]*config.Parameter{&sn.RewardsTorrentTrackers}, enabled(&sn.GenerateRewardsTorrents))
+	dependOn([]*config.Parameter{&sn.ProofServerPort}, enabled(&sn.EnableProofServer))
+	dependOn([]*config.Parameter{&sn.NodeProfilingPort, &sn.WatchtowerProfilingPort}, enabled(&sn.EnableProfiling))
+	dependOn([]*config.Parameter{&sn.ArchiveECRoutingHorizon}, set(&sn.ArchiveECUrl))
+	dependOn([]*config.Parameter{&sn.PrivateTxTypes, &sn.PrivateTxTimeout}, set(&sn.PrivateTxRelayUrl))
+	dependOn([]*config.Parameter{&sn.WatchtowerBundleBlocks}, set(&sn.WatchtowerBundleRelayUrl))
+	dependOn([]*config.Parameter{&sn.RplPriceApiJsonPath}, set(&sn.RplPriceApiUrl))
+	dependOn([]*config.Parameter{&sn.RecordCheckpointInterval, &sn.CheckpointRetentionLimit, &sn.RecordCompressionCodec}, enabled(&sn.UseRollingRecords))
+	dependOn([]*config.Parameter{&sn.RecordCompressionLevel, &sn.UseRecordCompressionDictionary}, config.ParameterDependency{Parameter: &sn.RecordCompressionCodec, Values: []interface{}{config.RecordCodec_Zstd}})
+
+	// Backups
+	remoteStorage := config.ParameterDependency{Parameter: &cfg.Backup.Storage, Values: []interface{}{config.BackupStorage_S3, config.BackupStorage_B2, config.BackupStorage_WebDav}}
+	dependOn([]*config.Parameter{&cfg.Backup.StorageUrl, &cfg.Backup.AccessKey, &cfg.Backup.SecretKey}, remoteStorage)
+	dependOn([]*config.Parameter{&cfg.Backup.S3Region}, config.ParameterDependency{Parameter: &cfg.Backup.Storage, Values: []interface{}{config.BackupStorage_S3}})
+
+	// Bandwidth limits are applied to the local clients' containers
+	dependOn([]*config.Parameter{&cfg.Bandwidth.EcUploadLimit, &cfg.Bandwidth.EcDownloadLimit}, localEc)
+	dependOn([]*config.Parameter{&cfg.Bandwidth.BnUploadLimit, &cfg.Bandwidth.BnDownloadLimit}, localCc)
+	dependOn([]*config.Parameter{&cfg.Bandwidth.ScheduleDays}, set(&cfg.Bandwidth.Schedule))
+
+	// Resource limits only apply to containers that will run
+	resources := cfg.Resources
+	dependOn([]*config.Parameter{&resources.EcMemoryLimit, &resources.EcCpuLimit}, localEc)
+	dependOn([]*config.Parameter{&resources.BnMemoryLimit, &resources.BnCpuLimit}, localCc)
+	dependOn([]*config.Parameter{&resources.MevBoostMemoryLimit, &resources.MevBoostCpuLimit}, enabled(&cfg.EnableMevBoost), localMevBoost)
+}
+
+// Check that a parameter applies with the current settings, returning an error that says what to change if it doesn't
+func (cfg *RocketPoolConfig) CheckParameterDependencies(result ParameterSearchResult) error {
+	unmet := result.Parameter.GetUnmetDependencies()
+	if len(unmet) == 0 {
+		return nil
+	}
+
+	flagNames := map[*config.Parameter]string{}
+	for _, other := range cfg.GetAllParameters() {
+		flagNames[other.Parameter] = other.FlagName
+	}
+	conditions := make([]string, len(unmet))
+	for i, dependency := range unmet {
+		conditions[i] = fmt.Sprintf("%s (%s) %s", dependency.Parameter.Name, flagNames[dependency.Parameter], dependency.DescribeValues())
+	}
+	return fmt.Errorf("%s (%s) only applies when %s, so changing it would have no effect. Change %s first.", result.Parameter.Name, result.FlagName, strings.Join(conditions, " and "), pluralize(len(unmet), "that setting", "those settings"))
+}
+
+// Pick the singular or plural form of a phrase
+func pluralize(count int, singular string, plural string) string {
+	if count == 1 {
+		return singular
+	}
+	return plural
+}
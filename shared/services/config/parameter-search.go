@@ -0,0 +1,156 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// A parameter found in the config, along with the name it's set by on the command line
+type ParameterSearchResult struct {
+	// The name of the subconfig the parameter belongs to; blank for the top-level parameters
+	Section string
+
+	// The name used for the parameter by `rocketpool service config`, which is `section-id` or just the ID for the top-level ones
+	FlagName string
+
+	Parameter *config.Parameter
+
+	score int
+}
+
+// Get all of the parameters in the config, sorted by section
+func (cfg *RocketPoolConfig) GetAllParameters() []ParameterSearchResult {
+	results := []ParameterSearchResult{}
+	for _, param := range cfg.GetParameters() {
+		results = append(results, ParameterSearchResult{
+			FlagName:  param.ID,
+			Parameter: param,
+		})
+	}
+
+	subconfigs := cfg.GetSubconfigs()
+	sectionNames := make([]string, 0, len(subconfigs))
+	for sectionName := range subconfigs {
+		sectionNames = append(sectionNames, sectionName)
+	}
+	sort.Strings(sectionNames)
+	for _, sectionName := range sectionNames {
+		for _, param := range subconfigs[sectionName].GetParameters() {
+			results = append(results, ParameterSearchResult{
+				Section:   sectionName,
+				FlagName:  fmt.Sprintf("%s-%s", sectionName, param.ID),
+				Parameter: param,
+			})
+		}
+	}
+	return results
+}
+
+// Find a parameter by the name it's set by on the command line. The section can be left off if only one section has a parameter
+// with that ID.
+func (cfg *RocketPoolConfig) FindParameter(name string) (ParameterSearchResult, error) {
+	name = strings.TrimSpace(name)
+	matches := []ParameterSearchResult{}
+	for _, result := range cfg.GetAllParameters() {
+		if strings.EqualFold(result.FlagName, name) {
+			return result, nil
+		}
+		if strings.EqualFold(result.Parameter.ID, name) {
+			matches = append(matches, result)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		suggestions := []string{}
+		for _, result := range cfg.SearchParameters(name) {
+			suggestions = append(suggestions, result.FlagName)
+			if len(suggestions) == 3 {
+				break
+			}
+		}
+		if len(suggestions) == 0 {
+			return ParameterSearchResult{}, fmt.Errorf("there is no setting called [%s]", name)
+		}
+		return ParameterSearchResult{}, fmt.Errorf("there is no setting called [%s]; did you mean %s?", name, strings.Join(suggestions, ", "))
+	case 1:
+		return matches[0], nil
+	}
+	names := make([]string, len(matches))
+	for i, match := range matches {
+		names[i] = match.FlagName
+	}
+	return ParameterSearchResult{}, fmt.Errorf("several sections have a setting called [%s], please use one of: %s", name, strings.Join(names, ", "))
+}
+
+// Search the parameters by their ID, name, and description, with the best matches first
+func (cfg *RocketPoolConfig) SearchParameters(query string) []ParameterSearchResult {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return []ParameterSearchResult{}
+	}
+
+	results := []ParameterSearchResult{}
+	for _, result := range cfg.GetAllParameters() {
+		result.score = scoreParameter(query, result)
+		if result.score > 0 {
+			results = append(results, result)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].score > results[j].score
+	})
+	return results
+}
+
+// Score how well a parameter matches a lowercase query; 0 means it doesn't match at all
+func scoreParameter(query string, result ParameterSearchResult) int {
+	id := strings.ToLower(result.Parameter.ID)
+	flagName := strings.ToLower(result.FlagName)
+	name := strings.ToLower(result.Parameter.Name)
+	description := strings.ToLower(result.Parameter.Description)
+
+	switch {
+	case query == id || query == flagName:
+		return 100
+	case query == name:
+		return 90
+	case strings.HasPrefix(id, query) || strings.HasPrefix(name, query):
+		return 80
+	case strings.Contains(flagName, query) || strings.Contains(name, query):
+		return 60
+	}
+
+	// Every word of the query appears somewhere
+	allWords := true
+	for _, word := range strings.Fields(query) {
+		if !strings.Contains(name, word) && !strings.Contains(flagName, word) && !strings.Contains(description, word) {
+			allWords = false
+			break
+		}
+	}
+	if allWords {
+		return 40
+	}
+
+	// The query's letters appear in order in the name or ID, like an abbreviation
+	compactQuery := strings.ReplaceAll(query, " ", "")
+	if isSubsequence(compactQuery, id) || isSubsequence(compactQuery, name) {
+		return 20
+	}
+	return 0
+}
+
+// Check if the characters of a string appear in another in the same order
+func isSubsequence(sub string, s string) bool {
+	index := 0
+	for i := 0; i < len(s) && index < len(sub); i++ {
+		if s[i] == sub[index] {
+			index++
+		}
+	}
+	return index == len(sub)
+}
@@ -50,7 +50,7 @@ func NewPrysmConfig(cfg *RocketPoolConfig) *PrysmConfig {
 	return &PrysmConfig{
 		Title: "Prysm Settings",
 
-		UnsupportedCommonParams: []string{},
+		UnsupportedCommonParams: []string{Ipv6P2pPortID},
 
 		MaxPeers: config.Parameter{
 			ID:                 "maxPeers",
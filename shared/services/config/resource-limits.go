@@ -0,0 +1,98 @@
+package config
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+
+	"github.com/pbnjay/memory"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Get the memory and CPU limit parameters for a container, or nils if it doesn't have any
+func (cfg *RocketPoolConfig) getResourceLimitParams(container config.ContainerID) (*config.Parameter, *config.Parameter) {
+	switch container {
+	case config.ContainerID_Eth1:
+		return &cfg.Resources.EcMemoryLimit, &cfg.Resources.EcCpuLimit
+	case config.ContainerID_Eth2:
+		return &cfg.Resources.BnMemoryLimit, &cfg.Resources.BnCpuLimit
+	case config.ContainerID_Validator:
+		return &cfg.Resources.VcMemoryLimit, &cfg.Resources.VcCpuLimit
+	case config.ContainerID_Node:
+		return &cfg.Resources.NodeMemoryLimit, &cfg.Resources.NodeCpuLimit
+	case config.ContainerID_Watchtower:
+		return &cfg.Resources.WatchtowerMemoryLimit, &cfg.Resources.WatchtowerCpuLimit
+	case config.ContainerID_MevBoost:
+		return &cfg.Resources.MevBoostMemoryLimit, &cfg.Resources.MevBoostCpuLimit
+	}
+	return nil, nil
+}
+
+// Get the containers that will run with the current settings and can have resource limits
+func (cfg *RocketPoolConfig) getLimitedContainers() []config.ContainerID {
+	containers := []config.ContainerID{}
+	if cfg.IsNativeMode {
+		return containers
+	}
+	if cfg.ExecutionClientLocal() {
+		containers = append(containers, config.ContainerID_Eth1)
+	}
+	if cfg.ConsensusClientLocal() {
+		containers = append(containers, config.ContainerID_Eth2)
+	}
+	containers = append(containers, config.ContainerID_Validator, config.ContainerID_Node, config.ContainerID_Watchtower)
+	if cfg.EnableMevBoost.Value == true && cfg.MevBoost.Mode.Value == config.Mode_Local {
+		containers = append(containers, config.ContainerID_MevBoost)
+	}
+	return containers
+}
+
+// Gets a container's memory limit for its compose resource constraints, or a blank string if it doesn't have one
+// Used by text/template to format the containers' compose files
+func (cfg *RocketPoolConfig) GetContainerMemoryLimit(container string) string {
+	memoryLimit, _ := cfg.getResourceLimitParams(config.ContainerID(container))
+	if memoryLimit == nil || memoryLimit.Value.(uint64) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%dm", memoryLimit.Value.(uint64))
+}
+
+// Gets a container's CPU limit for its compose resource constraints, or a blank string if it doesn't have one
+// Used by text/template to format the containers' compose files
+func (cfg *RocketPoolConfig) GetContainerCpuLimit(container string) string {
+	_, cpuLimit := cfg.getResourceLimitParams(config.ContainerID(container))
+	if cpuLimit == nil || cpuLimit.Value.(float64) <= 0 {
+		return ""
+	}
+	return strconv.FormatFloat(cpuLimit.Value.(float64), 'f', -1, 64)
+}
+
+// Check the resource limits against this machine's RAM and CPU cores, returning a warning for each problem
+func (cfg *RocketPoolConfig) getResourceLimitWarnings() []string {
+	warnings := []string{}
+	totalMemoryMb := memory.TotalMemory() / 1024 / 1024
+	cpuCount := float64(runtime.NumCPU())
+
+	memorySum := uint64(0)
+	cpuSum := float64(0)
+	for _, container := range cfg.getLimitedContainers() {
+		memoryLimit, cpuLimit := cfg.getResourceLimitParams(container)
+		memorySum += memoryLimit.Value.(uint64)
+		cpuSum += cpuLimit.Value.(float64)
+		if cpuLimit.Value.(float64) > cpuCount {
+			warnings = append(warnings, fmt.Sprintf("The %s (%s cores) is more than this machine's %d cores, so it won't have any effect.", cpuLimit.Name, cfg.GetContainerCpuLimit(string(container)), runtime.NumCPU()))
+		}
+		if cpuLimit.Value.(float64) < 0 {
+			warnings = append(warnings, fmt.Sprintf("The %s is negative, so it will be ignored.", cpuLimit.Name))
+		}
+	}
+
+	if totalMemoryMb > 0 && memorySum > totalMemoryMb {
+		warnings = append(warnings, fmt.Sprintf("The memory limits for your containers add up to %d MB, but this machine only has %d MB of RAM. They can all use their limit at once, so the machine could still run out of memory.", memorySum, totalMemoryMb))
+	}
+	if cpuSum > cpuCount {
+		warnings = append(warnings, fmt.Sprintf("The CPU limits for your containers add up to %s cores, but this machine only has %d. The containers will compete for CPU time when they're all busy.", strconv.FormatFloat(cpuSum, 'f', -1, 64), runtime.NumCPU()))
+	}
+	return warnings
+}
@@ -0,0 +1,199 @@
+package config
+
+import (
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Explains what happens when a container hits its memory limit
+const memoryLimitNote string = "\n\nIf the container needs more than this, Docker stops it and it's restarted, so don't set this much lower than it normally uses."
+
+// Configuration for the CPU and memory the Smartnode containers can use
+type ResourcesConfig struct {
+	Title string `yaml:"-"`
+
+	// The limits for the Execution Client container
+	EcMemoryLimit config.Parameter `yaml:"ecMemoryLimit,omitempty"`
+	EcCpuLimit    config.Parameter `yaml:"ecCpuLimit,omitempty"`
+
+	// The limits for the Beacon Node container
+	BnMemoryLimit config.Parameter `yaml:"bnMemoryLimit,omitempty"`
+	BnCpuLimit    config.Parameter `yaml:"bnCpuLimit,omitempty"`
+
+	// The limits for the Validator Client container
+	VcMemoryLimit config.Parameter `yaml:"vcMemoryLimit,omitempty"`
+	VcCpuLimit    config.Parameter `yaml:"vcCpuLimit,omitempty"`
+
+	// The limits for the Node container
+	NodeMemoryLimit config.Parameter `yaml:"nodeMemoryLimit,omitempty"`
+	NodeCpuLimit    config.Parameter `yaml:"nodeCpuLimit,omitempty"`
+
+	// The limits for the Watchtower container
+	WatchtowerMemoryLimit config.Parameter `yaml:"watchtowerMemoryLimit,omitempty"`
+	WatchtowerCpuLimit    config.Parameter `yaml:"watchtowerCpuLimit,omitempty"`
+
+	// The limits for the MEV-Boost container
+	MevBoostMemoryLimit config.Parameter `yaml:"mevBoostMemoryLimit,omitempty"`
+	MevBoostCpuLimit    config.Parameter `yaml:"mevBoostCpuLimit,omitempty"`
+}
+
+// Generates a new resource limits config
+func NewResourcesConfig(cfg *RocketPoolConfig) *ResourcesConfig {
+	return &ResourcesConfig{
+		Title: "Resource Limit Settings",
+
+		EcMemoryLimit: config.Parameter{
+			ID:                 "ecMemoryLimit",
+			Name:               "Execution Client Memory Limit",
+			Description:        "The most RAM the Execution Client container can use, in MB. Use 0 for no limit." + memoryLimitNote,
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Eth1},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		EcCpuLimit: config.Parameter{
+			ID:                 "ecCpuLimit",
+			Name:               "Execution Client CPU Limit",
+			Description:        "The most CPU time the Execution Client container can use, in cores; for example, 1.5 lets it use one and a half cores' worth. Use 0 for no limit.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Eth1},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		BnMemoryLimit: config.Parameter{
+			ID:                 "bnMemoryLimit",
+			Name:               "Beacon Node Memory Limit",
+			Description:        "The most RAM the Beacon Node container can use, in MB. Use 0 for no limit." + memoryLimitNote,
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Eth2},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		BnCpuLimit: config.Parameter{
+			ID:                 "bnCpuLimit",
+			Name:               "Beacon Node CPU Limit",
+			Description:        "The most CPU time the Beacon Node container can use, in cores; for example, 1.5 lets it use one and a half cores' worth. Use 0 for no limit.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Eth2},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		VcMemoryLimit: config.Parameter{
+			ID:                 "vcMemoryLimit",
+			Name:               "Validator Client Memory Limit",
+			Description:        "The most RAM the Validator Client container can use, in MB. Use 0 for no limit." + memoryLimitNote,
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Validator},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		VcCpuLimit: config.Parameter{
+			ID:                 "vcCpuLimit",
+			Name:               "Validator Client CPU Limit",
+			Description:        "The most CPU time the Validator Client container can use, in cores; for example, 1.5 lets it use one and a half cores' worth. Use 0 for no limit.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Validator},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		NodeMemoryLimit: config.Parameter{
+			ID:                 "nodeMemoryLimit",
+			Name:               "Node Memory Limit",
+			Description:        "The most RAM the Node container can use, in MB. Use 0 for no limit." + memoryLimitNote,
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		NodeCpuLimit: config.Parameter{
+			ID:                 "nodeCpuLimit",
+			Name:               "Node CPU Limit",
+			Description:        "The most CPU time the Node container can use, in cores; for example, 1.5 lets it use one and a half cores' worth. Use 0 for no limit.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		WatchtowerMemoryLimit: config.Parameter{
+			ID:                 "watchtowerMemoryLimit",
+			Name:               "Watchtower Memory Limit",
+			Description:        "The most RAM the Watchtower container can use, in MB. Use 0 for no limit." + memoryLimitNote,
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		WatchtowerCpuLimit: config.Parameter{
+			ID:                 "watchtowerCpuLimit",
+			Name:               "Watchtower CPU Limit",
+			Description:        "The most CPU time the Watchtower container can use, in cores; for example, 1.5 lets it use one and a half cores' worth. Use 0 for no limit.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		MevBoostMemoryLimit: config.Parameter{
+			ID:                 "mevBoostMemoryLimit",
+			Name:               "MEV-Boost Memory Limit",
+			Description:        "The most RAM the MEV-Boost container can use, in MB. Use 0 for no limit." + memoryLimitNote,
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_MevBoost},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		MevBoostCpuLimit: config.Parameter{
+			ID:                 "mevBoostCpuLimit",
+			Name:               "MEV-Boost CPU Limit",
+			Description:        "The most CPU time the MEV-Boost container can use, in cores; for example, 1.5 lets it use one and a half cores' worth. Use 0 for no limit.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_MevBoost},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+	}
+}
+
+// Get the parameters for this config
+func (cfg *ResourcesConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.EcMemoryLimit,
+		&cfg.EcCpuLimit,
+		&cfg.BnMemoryLimit,
+		&cfg.BnCpuLimit,
+		&cfg.VcMemoryLimit,
+		&cfg.VcCpuLimit,
+		&cfg.NodeMemoryLimit,
+		&cfg.NodeCpuLimit,
+		&cfg.WatchtowerMemoryLimit,
+		&cfg.WatchtowerCpuLimit,
+		&cfg.MevBoostMemoryLimit,
+		&cfg.MevBoostCpuLimit,
+	}
+}
+
+// The the title for the config
+func (cfg *ResourcesConfig) GetConfigTitle() string {
+	return cfg.Title
+}
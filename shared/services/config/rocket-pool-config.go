@@ -9,6 +9,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/alessio/shellescape"
 	externalip "github.com/glendc/go-external-ip"
@@ -20,6 +21,7 @@ import (
 	addontypes "github.com/rocket-pool/smartnode/shared/types/addons"
 	"github.com/rocket-pool/smartnode/shared/types/config"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/cron"
 	"gopkg.in/yaml.v2"
 )
 
@@ -48,6 +50,9 @@ const defaultNodeMetricsPort uint16 = 9102
 const defaultExporterMetricsPort uint16 = 9103
 const defaultWatchtowerMetricsPort uint16 = 9104
 const defaultEcMetricsPort uint16 = 9105
+const defaultProofServerPort uint16 = 9106
+const defaultNodeProfilingPort uint16 = 9107
+const defaultWatchtowerProfilingPort uint16 = 9108
 
 // The master configuration struct
 type RocketPoolConfig struct {
@@ -59,13 +64,19 @@ type RocketPoolConfig struct {
 
 	IsNativeMode bool `yaml:"-"`
 
+	// The networks the Smartnode can run on, and the additional ones that were loaded from the networks folder
+	networks           []*NetworkDescriptor `yaml:"-"`
+	additionalNetworks []*NetworkDescriptor `yaml:"-"`
+	networksLoadError  error                `yaml:"-"`
+
 	// Execution client settings
 	ExecutionClientMode config.Parameter `yaml:"executionClientMode,omitempty"`
 	ExecutionClient     config.Parameter `yaml:"executionClient,omitempty"`
 
 	// Fallback settings
-	UseFallbackClients config.Parameter `yaml:"useFallbackClients,omitempty"`
-	ReconnectDelay     config.Parameter `yaml:"reconnectDelay,omitempty"`
+	UseFallbackClients        config.Parameter `yaml:"useFallbackClients,omitempty"`
+	ReconnectDelay            config.Parameter `yaml:"reconnectDelay,omitempty"`
+	AdditionalFallbackClients config.Parameter `yaml:"additionalFallbackClients,omitempty"`
 
 	// Consensus client settings
 	ConsensusClientMode     config.Parameter `yaml:"consensusClientMode,omitempty"`
@@ -117,10 +128,22 @@ type RocketPoolConfig struct {
 	Alertmanager      *AlertmanagerConfig      `yaml:"alertmanager,omitempty"`
 	Exporter          *ExporterConfig          `yaml:"exporter,omitempty"`
 	BitflyNodeMetrics *BitflyNodeMetricsConfig `yaml:"bitflyNodeMetrics,omitempty"`
+	MetricsExport     *MetricsExportConfig     `yaml:"metricsExport,omitempty"`
+	Heartbeat         *HeartbeatConfig         `yaml:"heartbeat,omitempty"`
+	Notifications     *NotificationsConfig     `yaml:"notifications,omitempty"`
+	Backup            *BackupConfig            `yaml:"backup,omitempty"`
+	Bandwidth         *BandwidthConfig         `yaml:"bandwidth,omitempty"`
+	Resources         *ResourcesConfig         `yaml:"resources,omitempty"`
+
+	// Experimental features
+	Experimental *ExperimentalConfig `yaml:"experimental,omitempty"`
 
 	// Native mode
 	Native *NativeConfig `yaml:"native,omitempty"`
 
+	// Local devnet
+	Devnet *DevnetConfig `yaml:"devnet,omitempty"`
+
 	// MEV-Boost
 	EnableMevBoost config.Parameter `yaml:"enableMevBoost,omitempty"`
 	MevBoost       *MevBoostConfig  `yaml:"mevBoost,omitempty"`
@@ -181,6 +204,16 @@ func LoadFromFile(path string) (*RocketPoolConfig, error) {
 
 // Creates a new Rocket Pool configuration instance
 func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
+	var additionalNetworks []*NetworkDescriptor
+	var err error
+	if rpDir != "" {
+		additionalNetworks, err = LoadNetworkDescriptors(filepath.Join(rpDir, NetworksFolder))
+	}
+	return newRocketPoolConfig(rpDir, isNativeMode, additionalNetworks, err)
+}
+
+// Creates a new Rocket Pool configuration instance with the provided additional networks
+func newRocketPoolConfig(rpDir string, isNativeMode bool, additionalNetworks []*NetworkDescriptor, networksLoadError error) *RocketPoolConfig {
 
 	clientModes := []config.ParameterOption{{
 		Name:        "Locally Managed",
@@ -196,6 +229,9 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 		Title:               "Top-level Settings",
 		RocketPoolDirectory: rpDir,
 		IsNativeMode:        isNativeMode,
+		networks:            mergeNetworkDescriptors(getBuiltinNetworkDescriptors(), additionalNetworks),
+		additionalNetworks:  additionalNetworks,
+		networksLoadError:   networksLoadError,
 
 		ExecutionClientMode: config.Parameter{
 			ID:                 "executionClientMode",
@@ -259,6 +295,17 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 			OverwriteOnUpgrade: false,
 		},
 
+		AdditionalFallbackClients: config.Parameter{
+			ID:                 "additionalFallbackClients",
+			Name:               "Additional Fallback Clients",
+			Description:        "An optional, comma-separated list of extra fallback client pairs to use if your fallback clients also go offline, in order of preference. Enter each pair as `<Execution client URL>|<Beacon Node URL>`, for example `http://192.168.1.46:8545|http://192.168.1.46:5052`.\n\nThe Smartnode and Watchtower check every pair regularly and switch to the first one where both clients are healthy. Your Validator Client only uses the fallback clients above.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
 		ConsensusClientMode: config.Parameter{
 			ID:                 "consensusClientMode",
 			Name:               "Consensus Client Mode",
@@ -475,13 +522,27 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 	cfg.Alertmanager = NewAlertmanagerConfig(cfg)
 	cfg.Exporter = NewExporterConfig(cfg)
 	cfg.BitflyNodeMetrics = NewBitflyNodeMetricsConfig(cfg)
+	cfg.MetricsExport = NewMetricsExportConfig(cfg)
+	cfg.Heartbeat = NewHeartbeatConfig(cfg)
+	cfg.Notifications = NewNotificationsConfig(cfg)
+	cfg.Backup = NewBackupConfig(cfg)
+	cfg.Bandwidth = NewBandwidthConfig(cfg)
+	cfg.Resources = NewResourcesConfig(cfg)
+	cfg.Experimental = NewExperimentalConfig(cfg)
 	cfg.Native = NewNativeConfig(cfg)
 	cfg.MevBoost = NewMevBoostConfig(cfg)
+	cfg.Devnet = NewDevnetConfig(cfg)
 
 	// Addons
 	cfg.GraffitiWallWriter = addons.NewGraffitiWallWriter()
 	cfg.RescueNode = addons.NewRescueNode()
 
+	// Link the parameters to the ones they depend on
+	cfg.setParameterDependencies()
+
+	// Give the additional networks the defaults of the networks they're based on
+	cfg.applyNetworkDescriptorDefaults()
+
 	// Apply the default values for mainnet
 	cfg.Smartnode.Network.Value = cfg.Smartnode.Network.Options[0].Value
 	cfg.applyAllDefaults()
@@ -506,7 +567,7 @@ func getAugmentedEcDescription(client config.ExecutionClient, originalDescriptio
 
 // Create a copy of this configuration.
 func (cfg *RocketPoolConfig) CreateCopy() *RocketPoolConfig {
-	newConfig := NewRocketPoolConfig(cfg.RocketPoolDirectory, cfg.IsNativeMode)
+	newConfig := newRocketPoolConfig(cfg.RocketPoolDirectory, cfg.IsNativeMode, cfg.additionalNetworks, cfg.networksLoadError)
 
 	// Set the network
 	network := cfg.Smartnode.Network.Value.(config.Network)
@@ -537,6 +598,7 @@ func (cfg *RocketPoolConfig) GetParameters() []*config.Parameter {
 		&cfg.ExecutionClient,
 		&cfg.UseFallbackClients,
 		&cfg.ReconnectDelay,
+		&cfg.AdditionalFallbackClients,
 		&cfg.ConsensusClientMode,
 		&cfg.ConsensusClient,
 		&cfg.ExternalConsensusClient,
@@ -581,8 +643,16 @@ func (cfg *RocketPoolConfig) GetSubconfigs() map[string]config.Config {
 		"alertmanager":       cfg.Alertmanager,
 		"exporter":           cfg.Exporter,
 		"bitflyNodeMetrics":  cfg.BitflyNodeMetrics,
+		"metricsExport":      cfg.MetricsExport,
+		"heartbeat":          cfg.Heartbeat,
+		"notifications":      cfg.Notifications,
+		"backup":             cfg.Backup,
+		"bandwidth":          cfg.Bandwidth,
+		"resources":          cfg.Resources,
+		"experimental":       cfg.Experimental,
 		"native":             cfg.Native,
 		"mevBoost":           cfg.MevBoost,
+		"devnet":             cfg.Devnet,
 		"addons-gww":         cfg.GraffitiWallWriter.GetConfig(),
 		"addons-rescue-node": cfg.RescueNode.GetConfig(),
 	}
@@ -1106,11 +1176,62 @@ func (cfg *RocketPoolConfig) VcAdditionalFlags() (string, error) {
 		if !first {
 			out = out + " "
 		}
+		first = false
 		out = out + overrides.VcAdditionalFlags
 	}
+	if cfg.Smartnode.EnableKeymanagerApi.Value.(bool) {
+		if !first {
+			out = out + " "
+		}
+		first = false
+		out = out + cfg.getVcKeymanagerFlags(cc)
+	}
+	gasLimit := cfg.Smartnode.PreferredGasLimit.Value.(uint64)
+	if gasLimit > 0 {
+		if !first {
+			out = out + " "
+		}
+		out = out + getVcGasLimitFlag(cc, gasLimit)
+	}
 	return out, nil
 }
 
+// Get the flag that sets the preferred gas limit (including for builder registrations) on the selected Validator Client
+func getVcGasLimitFlag(cc config.ConsensusClient, gasLimit uint64) string {
+	switch cc {
+	case config.ConsensusClient_Lighthouse:
+		return fmt.Sprintf("--gas-limit=%d", gasLimit)
+	case config.ConsensusClient_Lodestar:
+		return fmt.Sprintf("--defaultGasLimit=%d", gasLimit)
+	case config.ConsensusClient_Nimbus, config.ConsensusClient_Prysm:
+		return fmt.Sprintf("--suggested-gas-limit=%d", gasLimit)
+	case config.ConsensusClient_Teku:
+		return fmt.Sprintf("--validators-builder-registration-default-gas-limit=%d", gasLimit)
+	default:
+		return ""
+	}
+}
+
+// Get the flags that enable the keymanager API on the selected Validator Client
+func (cfg *RocketPoolConfig) getVcKeymanagerFlags(cc config.ConsensusClient) string {
+	port := cfg.Smartnode.KeymanagerApiPort.Value
+	tokenPath := "/validators/" + KeymanagerTokenFilename
+	switch cc {
+	case config.ConsensusClient_Lighthouse:
+		return fmt.Sprintf("--http --http-address=0.0.0.0 --http-port=%d --http-allow-origin=* --unencrypted-http-transport --http-token-path=%s", port, tokenPath)
+	case config.ConsensusClient_Lodestar:
+		return fmt.Sprintf("--keymanager --keymanager.address=0.0.0.0 --keymanager.port=%d --keymanager.tokenFile=%s", port, tokenPath)
+	case config.ConsensusClient_Nimbus:
+		return fmt.Sprintf("--keymanager --keymanager-address=0.0.0.0 --keymanager-port=%d --keymanager-token-file=%s", port, tokenPath)
+	case config.ConsensusClient_Prysm:
+		return fmt.Sprintf("--rpc --http-host=0.0.0.0 --http-port=%d --keymanager-token-file=%s", port, tokenPath)
+	case config.ConsensusClient_Teku:
+		return fmt.Sprintf("--validator-api-enabled=true --validator-api-interface=0.0.0.0 --validator-api-port=%d --validator-api-host-allowlist=* --validator-api-ssl-enabled=false --validator-api-bearer-file=%s", port, tokenPath)
+	default:
+		return ""
+	}
+}
+
 // Used by text/template to format validator.yml
 func (cfg *RocketPoolConfig) FeeRecipientFile() string {
 	return FeeRecipientFilename
@@ -1226,6 +1347,16 @@ func (cfg *RocketPoolConfig) GetECAdditionalFlags() (string, error) {
 
 // Used by text/template to format eth1.yml
 func (cfg *RocketPoolConfig) GetExternalIp() string {
+	// Advertise the address the router reported for the port mappings, if the Node process has forwarded them
+	if cfg.Smartnode.EnablePortForwarding.Value == true {
+		bytes, err := os.ReadFile(cfg.Smartnode.GetExternalIpPath(false))
+		if err == nil {
+			if ip := net.ParseIP(strings.TrimSpace(string(bytes))); ip != nil {
+				return ip.String()
+			}
+		}
+	}
+
 	// Get the external IP address
 	ip, err := getExternalIP()
 	if err != nil {
@@ -1436,6 +1567,14 @@ func (cfg *RocketPoolConfig) GetChanges(oldConfig *RocketPoolConfig) (map[string
 func (cfg *RocketPoolConfig) Validate() []string {
 	errors := []string{}
 
+	// Make sure the network is one the Smartnode knows about
+	if cfg.networksLoadError != nil {
+		errors = append(errors, fmt.Sprintf("The additional network descriptors couldn't be loaded: %s", cfg.networksLoadError.Error()))
+	}
+	if cfg.GetNetworkDescriptor(cfg.Smartnode.Network.Value.(config.Network)) == nil {
+		errors = append(errors, fmt.Sprintf("The Smartnode is set to use network [%v], but there's no descriptor for it in the %s folder.", cfg.Smartnode.Network.Value, NetworksFolder))
+	}
+
 	// Check for illegal blank strings
 	/* TODO - this needs to be smarter and ignore irrelevant settings
 	for _, param := range config.GetParameters() {
@@ -1460,13 +1599,111 @@ func (cfg *RocketPoolConfig) Validate() []string {
 		errors = append(errors, "You are using an externally-managed Execution client and a locally-managed Consensus client.\nThis configuration is not compatible with The Merge; please select either locally-managed or externally-managed for both the EC and CC.")
 	}
 
+	// Make sure the additional fallback clients can be parsed
+	if _, err := cfg.GetFallbackClientPairs(); err != nil {
+		errors = append(errors, err.Error())
+	}
+
 	if cfg.ExecutionClient.Value.(cfgtypes.ExecutionClient) == cfgtypes.ExecutionClient_Reth && cfg.Smartnode.Network.Value.(cfgtypes.Network) == cfgtypes.Network_Mainnet {
 		errors = append(errors, "The Reth client is currently an alpha release and not to be used on Mainnet")
 	}
 
+	// Make sure a local devnet has a deployment and external clients to use
+	if cfg.Devnet.IsLocalDevnet() {
+		if cfg.Devnet.StorageAddress.Value.(string) == "" {
+			errors = append(errors, "You have a local devnet enabled, but haven't set the address of its RocketStorage contract.")
+		}
+		if cfg.ExecutionClientMode.Value.(config.Mode) != config.Mode_External || cfg.ConsensusClientMode.Value.(config.Mode) != config.Mode_External {
+			errors = append(errors, "You have a local devnet enabled, but your Execution and Consensus clients aren't Externally Managed. Set them to External and point them at the devnet's clients.")
+		}
+	}
+
+	// Make sure metrics exporting has somewhere to send the metrics
+	if cfg.EnableMetrics.Value == true && cfg.MetricsExport.Mode.Value.(config.MetricsExportMode) != config.MetricsExportMode_None {
+		if cfg.MetricsExport.Url.Value.(string) == "" {
+			errors = append(errors, "You have metrics exporting enabled but haven't set the URL of the pushgateway or remote-write endpoint to send them to.")
+		}
+		if cfg.MetricsExport.Interval.Value.(uint64) == 0 {
+			errors = append(errors, "The metrics export interval must be at least 1 second.")
+		}
+		if cfg.MetricsExport.BatchSize.Value.(uint64) == 0 {
+			errors = append(errors, "The metrics export batch size must be at least 1.")
+		}
+	}
+
+	// Make sure alert emails can be sent
+	if cfg.Notifications.EmailMode.Value.(config.EmailMode) != config.EmailMode_Disabled {
+		if cfg.Notifications.SmtpHost.Value.(string) == "" || cfg.Notifications.EmailFrom.Value.(string) == "" || cfg.Notifications.EmailTo.Value.(string) == "" {
+			errors = append(errors, "You have alert emails enabled, but haven't set the SMTP server, the From address, and the To addresses.")
+		}
+		if cfg.Notifications.DigestHour.Value.(uint64) > 23 {
+			errors = append(errors, "The digest hour must be between 0 and 23.")
+		}
+	}
+
+	// Make sure the IPv6 settings can be used
+	errors = append(errors, cfg.validateIpv6()...)
+
+	// Make sure the selected clients can run on this machine
+	errors = append(errors, cfg.validateArchitecture()...)
+
+	// Offline generation only happens on machines that generate their own trees
+	if cfg.Smartnode.OfflineRewardsGeneration.Value == true && cfg.Smartnode.RewardsTreeMode.Value.(config.RewardsMode) != config.RewardsMode_Generate {
+		errors = append(errors, "You have offline rewards generation enabled, but the Rewards Tree Mode isn't set to Generate.")
+	}
+
+	// Make sure the rewards files can be pinned
+	if cfg.Smartnode.EnableIpfsPinning.Value == true {
+		services := strings.TrimSpace(cfg.Smartnode.IpfsPinningServices.Value.(string))
+		if services == "" && strings.TrimSpace(cfg.Smartnode.IpfsApiUrl.Value.(string)) == "" {
+			errors = append(errors, "You have IPFS pinning enabled, but haven't set an IPFS node or any pinning services.")
+		}
+		for _, service := range strings.Split(services, ";") {
+			service = strings.TrimSpace(service)
+			if service != "" && !strings.Contains(service, "|") {
+				errors = append(errors, "Each IPFS pinning service needs its access token after a '|'.")
+				break
+			}
+		}
+		if cfg.Smartnode.IpfsPinCheckInterval.Value.(uint64) == 0 {
+			errors = append(errors, "The IPFS pin check interval must be at least 1 hour.")
+		}
+	}
+
+	// Make sure alerts can be pushed
+	if cfg.Notifications.NtfyTopic.Value.(string) != "" && cfg.Notifications.NtfyServer.Value.(string) == "" {
+		errors = append(errors, "You have an ntfy topic set, but haven't set the ntfy server.")
+	}
+	if (cfg.Notifications.PushoverAppToken.Value.(string) == "") != (cfg.Notifications.PushoverUserKey.Value.(string) == "") {
+		errors = append(errors, "Pushover needs both an app token and a user key.")
+	}
+	if cfg.Notifications.PushBatchInterval.Value.(uint64) == 0 {
+		errors = append(errors, "The push batch interval must be at least 1 minute.")
+	}
+
+	// Make sure scheduled backups can run and be uploaded
+	if schedule := strings.TrimSpace(cfg.Backup.Schedule.Value.(string)); schedule != "" {
+		if _, err := cron.Parse(schedule); err != nil {
+			errors = append(errors, fmt.Sprintf("The backup schedule isn't valid: %s", err.Error()))
+		}
+	}
+	if cfg.Backup.RetentionDays.Value.(uint64) == 0 {
+		errors = append(errors, "The backup retention must be at least 1 day.")
+	}
+	if cfg.Backup.Storage.Value.(config.BackupStorage) != config.BackupStorage_None && strings.TrimSpace(cfg.Backup.StorageUrl.Value.(string)) == "" {
+		errors = append(errors, "You have remote backup storage enabled, but haven't set the storage URL.")
+	}
+
+	// Make sure the bandwidth limit schedule can be used
+	if schedule := strings.TrimSpace(cfg.Bandwidth.Schedule.Value.(string)); schedule != "" {
+		if _, err := cron.ParseWindow(schedule, cfg.Bandwidth.ScheduleDays.Value.(string)); err != nil {
+			errors = append(errors, fmt.Sprintf("The bandwidth limit schedule isn't valid: %s", err.Error()))
+		}
+	}
+
 	// Ensure there's a MEV-boost URL
-	if cfg.Smartnode.Network.Value == config.Network_Holesky || cfg.Smartnode.Network.Value == config.Network_Devnet {
-		// Disabled on Holesky
+	if !cfg.IsMevBoostAvailable() {
+		// Disabled on the testnets
 		cfg.EnableMevBoost.Value = false
 	}
 	if !cfg.IsNativeMode && cfg.EnableMevBoost.Value == true {
@@ -1525,11 +1762,34 @@ func (cfg *RocketPoolConfig) Validate() []string {
 	portMap, errors = addAndCheckForDuplicate(portMap, cfg.MevBoost.Port, errors)
 	portMap, errors = addAndCheckForDuplicate(portMap, cfg.Prometheus.Port, errors)
 	portMap, errors = addAndCheckForDuplicate(portMap, cfg.Alertmanager.Port, errors)
+	if cfg.Smartnode.EnableProofServer.Value == true {
+		portMap, errors = addAndCheckForDuplicate(portMap, cfg.Smartnode.ProofServerPort, errors)
+	}
+	if cfg.Smartnode.EnableProfiling.Value == true {
+		portMap, errors = addAndCheckForDuplicate(portMap, cfg.Smartnode.NodeProfilingPort, errors)
+		portMap, errors = addAndCheckForDuplicate(portMap, cfg.Smartnode.WatchtowerProfilingPort, errors)
+	}
 	_, errors = addAndCheckForDuplicate(portMap, cfg.Lighthouse.P2pQuicPort, errors)
 
 	return errors
 }
 
+// Checks the config for settings that can be saved but probably won't work the way the user expects, returning a warning for each one
+func (cfg *RocketPoolConfig) GetWarnings() []string {
+	warnings := []string{}
+	warnings = append(warnings, cfg.getResourceLimitWarnings()...)
+
+	// The Rescue Node is meant for short outages, so remind the user it's still on
+	if rescueNode, ok := cfg.RescueNode.(*rescue_node.RescueNode); ok && rescueNode.GetEnabledParameter().Value == true {
+		if rescueNode.IsExpired() {
+			warnings = append(warnings, fmt.Sprintf("The Rescue Node's time limit passed at %s, but the Validator Client keeps using it until you run `rocketpool service rescue-node disable` or `rocketpool service start`.", rescueNode.GetExpiry().Local().Format(time.RFC1123)))
+		} else if rescueNode.GetExpiry().IsZero() {
+			warnings = append(warnings, "The Rescue Node is enabled without a time limit. Your validators depend on it until you disable it, so remember to run `rocketpool service rescue-node disable` once your own Beacon Node is synced.")
+		}
+	}
+	return warnings
+}
+
 func addAndCheckForDuplicate(portMap map[interface{}]bool, param config.Parameter, errors []string) (map[interface{}]bool, []string) {
 	port := fmt.Sprintf("%v", param.Value)
 	if port == "" {
@@ -1548,6 +1808,80 @@ func (cfg *RocketPoolConfig) GetNetwork() config.Network {
 	return cfg.Smartnode.Network.Value.(config.Network)
 }
 
+// Set up a new config for an additional Smartnode instance so it can run alongside the others on the same machine.
+// Its containers get their own project name, which also gives them their own volumes, its watchtower state goes in its own folder,
+// and all of its ports are moved up by the offset.
+func (cfg *RocketPoolConfig) ApplyInstanceDefaults(instanceName string, portOffset uint16) {
+	cfg.Smartnode.ProjectName.Value = fmt.Sprintf("%s-%s", defaultProjectName, instanceName)
+	cfg.Smartnode.WatchtowerStatePath.Value = filepath.Join(cfg.RocketPoolDirectory, WatchtowerFolder)
+
+	params := cfg.GetParameters()
+	for _, subconfig := range cfg.GetSubconfigs() {
+		params = append(params, subconfig.GetParameters()...)
+	}
+	for _, param := range params {
+		if param.Type != config.ParameterType_Uint16 || !strings.HasSuffix(strings.ToLower(param.ID), "port") {
+			continue
+		}
+		port, ok := param.Value.(uint16)
+		if ok && port != 0 {
+			param.Value = port + portOffset
+		}
+	}
+}
+
+// Get the descriptors for all of the networks the Smartnode can run on
+func (cfg *RocketPoolConfig) GetNetworkDescriptors() []*NetworkDescriptor {
+	return cfg.networks
+}
+
+// Get the descriptor for the provided network, or nil if it isn't known
+func (cfg *RocketPoolConfig) GetNetworkDescriptor(network config.Network) *NetworkDescriptor {
+	for _, descriptor := range cfg.networks {
+		if descriptor.Network == network {
+			return descriptor
+		}
+	}
+	return nil
+}
+
+// Check if MEV-Boost can be used on the selected network
+func (cfg *RocketPoolConfig) IsMevBoostAvailable() bool {
+	descriptor := cfg.GetNetworkDescriptor(cfg.Smartnode.Network.Value.(config.Network))
+	return descriptor != nil && !descriptor.DisableMevBoost
+}
+
+// Copy the defaults of each additional network's base network into its own defaults, so parameters that vary by network
+// (like container tags) have a value for it, and apply its default checkpoint sync URL
+func (cfg *RocketPoolConfig) applyNetworkDescriptorDefaults() {
+	params := cfg.GetParameters()
+	for _, subconfig := range cfg.GetSubconfigs() {
+		params = append(params, subconfig.GetParameters()...)
+	}
+
+	for _, descriptor := range cfg.additionalNetworks {
+		if descriptor.BaseNetwork != config.Network_Unknown {
+			for _, param := range params {
+				if _, exists := param.Default[descriptor.Network]; !exists {
+					if baseDefault, exists := param.Default[descriptor.BaseNetwork]; exists {
+						param.Default[descriptor.Network] = baseDefault
+					}
+				}
+				if param.DescriptionsByNetwork != nil {
+					if _, exists := param.DescriptionsByNetwork[descriptor.Network]; !exists {
+						if baseDescription, exists := param.DescriptionsByNetwork[descriptor.BaseNetwork]; exists {
+							param.DescriptionsByNetwork[descriptor.Network] = baseDescription
+						}
+					}
+				}
+			}
+		}
+		if descriptor.CheckpointSyncUrl != "" {
+			cfg.ConsensusCommon.CheckpointSyncProvider.Default[descriptor.Network] = descriptor.CheckpointSyncUrl
+		}
+	}
+}
+
 // Applies all of the defaults to all of the settings that have them defined
 func (cfg *RocketPoolConfig) applyAllDefaults() error {
 	for _, param := range cfg.GetParameters() {
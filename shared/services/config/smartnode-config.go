@@ -17,15 +17,50 @@ const (
 	ecMigratorTag                      string = "rocketpool/ec-migrator:v1.0.0"
 	NetworkID                          string = "network"
 	ProjectNameID                      string = "projectName"
+	HardwareProfileID                  string = "hardwareProfile"
 	SnapshotID                         string = "rocketpool-dao.eth"
 	RewardsTreeFilenameFormat          string = "rp-rewards-%s-%d.json"
 	MinipoolPerformanceFilenameFormat  string = "rp-minipool-performance-%s-%d.json"
+	RewardsSubmissionFilenameFormat    string = "rp-rewards-submission-%s-%d.json"
 	RewardsTreeIpfsExtension           string = ".zst"
+	RewardsTorrentExtension            string = ".torrent"
+	DefaultRewardsTorrentTrackers      string = "udp://tracker.opentrackr.org:1337/announce;udp://open.demonii.com:1337/announce"
 	RewardsTreesFolder                 string = "rewards-trees"
 	ChecksumTableFilename              string = "checksums.sha384"
+	RecordManifestFilename             string = "manifest.json"
+	RecordBlobsFolder                  string = "blobs"
+	RecordsIntervalFolderPrefix        string = "interval-"
 	DaemonDataPath                     string = "/.rocketpool/data"
 	WatchtowerFolder                   string = "watchtower"
 	WatchtowerStateFile                string = "state.yml"
+	BalancesReportsFolder              string = "balances-reports"
+	QueueStatsFilename                 string = "queue-stats.json"
+	CatchUpProgressFilename            string = "catch-up-progress.json"
+	RewardsLedgerFilename              string = "rewards-ledger.json"
+	NotificationsStateFilename         string = "notifications-state.json"
+	PinningStatusFilename              string = "pinning-status.json"
+	SubmissionLedgerFilename           string = "submission-ledger.json"
+	DefaultPinningGateways             string = "https://%s.ipfs.dweb.link/%s;https://ipfs.io/ipfs/%s/%s;https://%s.ipfs.w3s.link/%s"
+	ValidatorKeyArchiveFilename        string = "validators.enc"
+	KeymanagerTokenFilename            string = "keymanager-token.txt"
+	SlashingProtectionImportFilename   string = "slashing-protection-import.json"
+	NetworkStateCacheFilename          string = "network-state.bin.zst"
+	BackupsFolder                      string = "backups"
+	ProfilesFolder                     string = "profiles"
+	BackupRestoreFilename              string = "backup-restore.rpbk"
+	BackupPasswordFilename             string = "backup-password"
+	ScheduledBackupsStateFilename      string = "scheduled-backups.json"
+	HostIdFilename                     string = "host-id"
+	ActiveHostFilename                 string = "active-host.json"
+	ActiveHostConflictFilename         string = "active-host-conflict.json"
+	PortMappingsFilename               string = "port-mappings.json"
+	ExternalIpFilename                 string = "external-ip"
+	DelegateUpgradesFilename           string = "delegate-upgrades.json"
+	MinipoolEventsStateFilename        string = "minipool-events.json"
+	GasWalletFilename                  string = "gas-wallet.json"
+	GasWalletPasswordFilename          string = "gas-wallet-password"
+	NodeAddressFilename                string = "node-address"
+	SignRequestFilename                string = "sign-request.json"
 	RegenerateRewardsTreeRequestSuffix string = ".request"
 	RegenerateRewardsTreeRequestFormat string = "%d" + RegenerateRewardsTreeRequestSuffix
 	PrimaryRewardsFileUrl              string = "https://%s.ipfs.dweb.link/%s"
@@ -40,6 +75,8 @@ const (
 	defaultProjectName       string = "rocketpool"
 	WatchtowerMaxFeeDefault  uint64 = 200
 	WatchtowerPrioFeeDefault uint64 = 3
+
+	defaultWatchtowerConcurrency uint64 = 12
 )
 
 // Configuration for the Smartnode
@@ -59,6 +96,9 @@ type SmartnodeConfig struct {
 	// The path of the data folder where everything is stored
 	DataPath config.Parameter `yaml:"dataPath,omitempty"`
 
+	// The kind of hardware the client settings are tuned for
+	HardwareProfile config.Parameter `yaml:"hardwareProfile,omitempty"`
+
 	// The path of the watchtower's persistent state storage
 	WatchtowerStatePath config.Parameter `yaml:"watchtowerStatePath"`
 
@@ -77,147 +117,257 @@ type SmartnodeConfig struct {
 	// The amount of ETH in a minipool's balance before auto-distribute kicks in
 	DistributeThreshold config.Parameter `yaml:"distributeThreshold,omitempty"`
 
+	// Toggle for automatically managing the node's RPL stake
+	ManageRplStake config.Parameter `yaml:"manageRplStake,omitempty"`
+
+	// The collateral ratio the RPL stake manager aims for
+	RplStakeTargetRatio config.Parameter `yaml:"rplStakeTargetRatio,omitempty"`
+
+	// The collateral ratio below which the RPL stake manager stakes more RPL
+	RplStakeLowerBound config.Parameter `yaml:"rplStakeLowerBound,omitempty"`
+
+	// The collateral ratio above which the RPL stake manager reports excess RPL
+	RplStakeUpperBound config.Parameter `yaml:"rplStakeUpperBound,omitempty"`
+
+	// Toggle for withdrawing excess RPL once it's unlocked
+	RplStakeAutoWithdraw config.Parameter `yaml:"rplStakeAutoWithdraw,omitempty"`
+
+	// Toggle for only logging what the RPL stake manager would do
+	RplStakeSimulationMode config.Parameter `yaml:"rplStakeSimulationMode,omitempty"`
+
+	// Toggle for automatically upgrading the delegates of opted-in minipools
+	AutoUpgradeDelegates config.Parameter `yaml:"autoUpgradeDelegates,omitempty"`
+
+	// The most ETH the delegate upgrade campaign can spend on gas in a day
+	DelegateUpgradeDailyBudget config.Parameter `yaml:"delegateUpgradeDailyBudget,omitempty"`
+
+	// The most delegate upgrades the campaign submits at a time
+	DelegateUpgradeBatchSize config.Parameter `yaml:"delegateUpgradeBatchSize,omitempty"`
+
+	// DEX aggregator quote URLs for comparing rETH prices against the protocol rate
+	RethDexQuoteUrls config.Parameter `yaml:"rethDexQuoteUrls,omitempty"`
+
+	// Extra DEX routers that routed rETH deposits are allowed to be sent to
+	RethDexRouters config.Parameter `yaml:"rethDexRouters,omitempty"`
+
+	// Where to retrieve the node wallet password from
+	PasswordSource config.Parameter `yaml:"passwordSource,omitempty"`
+
+	// The OS keyring service name for the node wallet password
+	PasswordKeyringService config.Parameter `yaml:"passwordKeyringService,omitempty"`
+
+	// The HashiCorp Vault server for the node wallet password
+	PasswordVaultAddress config.Parameter `yaml:"passwordVaultAddress,omitempty"`
+
+	// The path of the HashiCorp Vault secret holding the node wallet password
+	PasswordVaultSecretPath config.Parameter `yaml:"passwordVaultSecretPath,omitempty"`
+
+	// The field of the HashiCorp Vault secret holding the node wallet password
+	PasswordVaultField config.Parameter `yaml:"passwordVaultField,omitempty"`
+
+	// The file holding the HashiCorp Vault token
+	PasswordVaultTokenPath config.Parameter `yaml:"passwordVaultTokenPath,omitempty"`
+
+	// The command that prints the node wallet password
+	PasswordCommand config.Parameter `yaml:"passwordCommand,omitempty"`
+
+	// Toggle for keeping the validator keys in an encrypted archive and only unlocking them into memory
+	EncryptValidatorKeys config.Parameter `yaml:"encryptValidatorKeys,omitempty"`
+
+	// Toggle for enabling the Validator Client's keymanager API
+	EnableKeymanagerApi config.Parameter `yaml:"enableKeymanagerApi,omitempty"`
+
+	// The port for the Validator Client's keymanager API
+	KeymanagerApiPort config.Parameter `yaml:"keymanagerApiPort,omitempty"`
+
+	// Toggle for allowing messages to be signed with the node's private key
+	EnableMessageSigning config.Parameter `yaml:"enableMessageSigning,omitempty"`
+
+	// The block gas limit the node's validators signal for
+	PreferredGasLimit config.Parameter `yaml:"preferredGasLimit,omitempty"`
+
 	// Mode for acquiring Merkle rewards trees
 	RewardsTreeMode config.Parameter `yaml:"rewardsTreeMode,omitempty"`
 
+	// Toggle for generating trees for an Oracle DAO watchtower on a separate machine, without submitting them
+	OfflineRewardsGeneration config.Parameter `yaml:"offlineRewardsGeneration,omitempty"`
+
 	// Timestamp used as reference for prices/balances submissions
 	PriceBalanceSubmissionReferenceTimestamp config.Parameter `yaml:"priceBalanceSubmissionReferenceTimestamp,omitempty"`
 
 	// Custom URL to download a rewards tree
 	RewardsTreeCustomUrl config.Parameter `yaml:"rewardsTreeCustomUrl,omitempty"`
 
-	// URL for an EC with archive mode, for manual rewards tree generation
-	ArchiveECUrl config.Parameter `yaml:"archiveEcUrl,omitempty"`
+	// Toggle for pinning the submitted rewards files with several IPFS pinning services
+	EnableIpfsPinning config.Parameter `yaml:"enableIpfsPinning,omitempty"`
 
-	// Manual override for the watchtower's max fee
-	WatchtowerMaxFeeOverride config.Parameter `yaml:"watchtowerMaxFeeOverride,omitempty"`
+	// The Kubo RPC API of an IPFS node to upload the rewards files to
+	IpfsApiUrl config.Parameter `yaml:"ipfsApiUrl,omitempty"`
 
-	// Manual override for the watchtower's priority fee
-	WatchtowerPrioFeeOverride config.Parameter `yaml:"watchtowerPrioFeeOverride,omitempty"`
+	// The IPFS pinning services to pin the rewards files with, and their access tokens
+	IpfsPinningServices config.Parameter `yaml:"ipfsPinningServices,omitempty"`
 
-	// The toggle for rolling records
-	UseRollingRecords config.Parameter `yaml:"useRollingRecords,omitempty"`
+	// The public gateways used to check that the rewards files can be retrieved
+	IpfsVerificationGateways config.Parameter `yaml:"ipfsVerificationGateways,omitempty"`
 
-	// The rolling record checkpoint interval
-	RecordCheckpointInterval config.Parameter `yaml:"recordCheckpointInterval,omitempty"`
+	// How often the pins are checked, in hours
+	IpfsPinCheckInterval config.Parameter `yaml:"ipfsPinCheckInterval,omitempty"`
 
-	// The checkpoint retention limit
-	CheckpointRetentionLimit config.Parameter `yaml:"checkpointRetentionLimit,omitempty"`
+	// HTTP mirrors to download the rewards files from when the IPFS gateways are slow
+	RewardsMirrorUrls config.Parameter `yaml:"rewardsMirrorUrls,omitempty"`
 
-	// The path of the records folder where snapshots of rolling record info is stored during a rewards interval
-	RecordsPath config.Parameter `yaml:"recordsPath,omitempty"`
+	// A folder shared by several instances to cache the verified rewards files in
+	RewardsFileCacheFolder config.Parameter `yaml:"rewardsFileCacheFolder,omitempty"`
 
-	// The toggle for enabling pDAO proposal verification duties
-	VerifyProposals config.Parameter `yaml:"verifyProposals,omitempty"`
+	// The HTTP mirror to publish the submitted rewards files to
+	RewardsMirrorUploadUrl config.Parameter `yaml:"rewardsMirrorUploadUrl,omitempty"`
+
+	// The Authorization header to send with each upload to the mirror
+	RewardsMirrorUploadAuth config.Parameter `yaml:"rewardsMirrorUploadAuth,omitempty"`
+
+	// Toggle for creating a torrent for each submitted rewards file
+	GenerateRewardsTorrents config.Parameter `yaml:"generateRewardsTorrents,omitempty"`
+
+	// The trackers to list in the rewards file torrents
+	RewardsTorrentTrackers config.Parameter `yaml:"rewardsTorrentTrackers,omitempty"`
+
+	// Toggle for serving Merkle proofs from the local rewards files
+	EnableProofServer config.Parameter `yaml:"enableProofServer,omitempty"`
+
+	// The port to serve Merkle proofs on
+	ProofServerPort config.Parameter `yaml:"proofServerPort,omitempty"`
+
+	// Toggle for serving the daemons' runtime profiles
+	EnableProfiling config.Parameter `yaml:"enableProfiling,omitempty"`
+
+	// The port the Node process serves its profiles on
+	NodeProfilingPort config.Parameter `yaml:"nodeProfilingPort,omitempty"`
 
-	///////////////////////////
-	// Non-editable settings //
-	///////////////////////////
+	// The port the Watchtower process serves its profiles on
+	WatchtowerProfilingPort config.Parameter `yaml:"watchtowerProfilingPort,omitempty"`
 
-	// The URL to provide the user so they can follow pending transactions
-	txWatchUrl map[config.Network]string `yaml:"-"`
+	// URL for an EC with archive mode, for historical state queries
+	ArchiveECUrl config.Parameter `yaml:"archiveEcUrl,omitempty"`
+
+	// How many blocks behind the head a query has to be before it's sent straight to the archive EC
+	ArchiveECRoutingHorizon config.Parameter `yaml:"archiveEcRoutingHorizon,omitempty"`
+
+	// URL for a private transaction relay, such as Flashbots Protect
+	PrivateTxRelayUrl config.Parameter `yaml:"privateTxRelayUrl,omitempty"`
+
+	// The kinds of transactions sent through the private relay
+	PrivateTxTypes config.Parameter `yaml:"privateTxTypes,omitempty"`
 
-	// The URL to use for staking rETH
-	stakeUrl map[config.Network]string `yaml:"-"`
+	// How long to wait for a private transaction to be included before broadcasting it publicly, in minutes
+	PrivateTxTimeout config.Parameter `yaml:"privateTxTimeout,omitempty"`
 
-	// The map of networks to execution chain IDs
-	chainID map[config.Network]uint `yaml:"-"`
+	// The maximum number of validators to request from the Beacon Node at once
+	ValidatorStatusChunkSize config.Parameter `yaml:"validatorStatusChunkSize,omitempty"`
 
-	// The contract address of RocketStorage
-	storageAddress map[config.Network]string `yaml:"-"`
+	// How long a cached snapshot of the node's network state can be used for, in seconds
+	NetworkStateCacheTTL config.Parameter `yaml:"networkStateCacheTTL,omitempty"`
 
-	// The contract address of the RPL token
-	rplTokenAddress map[config.Network]string `yaml:"-"`
+	// How many times a failed call to the Execution or Beacon client is retried
+	ClientCallRetries config.Parameter `yaml:"clientCallRetries,omitempty"`
 
-	// The contract address for Snapshot delegation
-	snapshotDelegationAddress map[config.Network]string `yaml:"-"`
+	// How many failed calls in a row it takes to switch a client over to its fallback
+	ClientCircuitBreakerThreshold config.Parameter `yaml:"clientCircuitBreakerThreshold,omitempty"`
 
-	// The Snapshot API domain
-	snapshotApiDomain map[config.Network]string `yaml:"-"`
+	// The NTP servers the local clock is compared against
+	NtpServers config.Parameter `yaml:"ntpServers,omitempty"`
 
-	// The contract address of rETH
-	rethAddress map[config.Network]string `yaml:"-"`
+	// URL of the service used to check if the P2P ports can be reached from the internet
+	PortCheckerUrl config.Parameter `yaml:"portCheckerUrl,omitempty"`
 
-	// The contract address of rocketRewardsPool from v1.0.0
-	v1_0_0_RewardsPoolAddress map[config.Network]string `yaml:"-"`
+	// Whether to forward the P2P ports on the router with UPnP or NAT-PMP
+	EnablePortForwarding config.Parameter `yaml:"enablePortForwarding,omitempty"`
 
-	// The contract address of rocketClaimNode from v1.0.0
-	v1_0_0_ClaimNodeAddress map[config.Network]string `yaml:"-"`
+	// The protocol used to forward the P2P ports
+	PortForwardingMethod config.Parameter `yaml:"portForwardingMethod,omitempty"`
 
-	// The contract address of rocketClaimTrustedNode from v1.0.0
-	v1_0_0_ClaimTrustedNodeAddress map[config.Network]string `yaml:"-"`
+	// The LAN address of the router that forwards the P2P ports
+	PortForwardingGateway config.Parameter `yaml:"portForwardingGateway,omitempty"`
 
-	// The contract address of rocketMinipoolManager from v1.0.0
-	v1_0_0_MinipoolManagerAddress map[config.Network]string `yaml:"-"`
+	// The LAN address of this machine, which the router forwards the P2P ports to
+	PortForwardingHostIp config.Parameter `yaml:"portForwardingHostIp,omitempty"`
 
-	// The contract address of rocketNetworkPrices from v1.1.0
-	v1_1_0_NetworkPricesAddress map[config.Network]string `yaml:"-"`
+	// Whether the clients should use IPv6 alongside IPv4
+	EnableIpv6 config.Parameter `yaml:"enableIpv6,omitempty"`
 
-	// The contract address of rocketNodeStaking from v1.1.0
-	v1_1_0_NodeStakingAddress map[config.Network]string `yaml:"-"`
+	// The IPv6 address the clients listen on
+	Ipv6ListenAddress config.Parameter `yaml:"ipv6ListenAddress,omitempty"`
 
-	// The contract address of rocketNodeDeposit from v1.1.0
-	v1_1_0_NodeDepositAddress map[config.Network]string `yaml:"-"`
+	// Whether the Beacon Node advertises its IPv6 address to peers
+	Ipv6Discovery config.Parameter `yaml:"ipv6Discovery,omitempty"`
+
+	// The public IPv6 address the Beacon Node advertises, if it shouldn't discover it on its own
+	Ipv6AdvertisedAddress config.Parameter `yaml:"ipv6AdvertisedAddress,omitempty"`
+
+	// Whether to stop the Validator Client if another host appears to be running the node's validators
+	EnableActiveHostGuard config.Parameter `yaml:"enableActiveHostGuard,omitempty"`
+
+	// Manual override for the watchtower's max fee
+	WatchtowerMaxFeeOverride config.Parameter `yaml:"watchtowerMaxFeeOverride,omitempty"`
 
-	// The contract address of rocketMinipoolQueue from v1.1.0
-	v1_1_0_MinipoolQueueAddress map[config.Network]string `yaml:"-"`
+	// Manual override for the watchtower's priority fee
+	WatchtowerPrioFeeOverride config.Parameter `yaml:"watchtowerPrioFeeOverride,omitempty"`
 
-	// The contract address of rocketMinipoolFactory from v1.1.0
-	v1_1_0_MinipoolFactoryAddress map[config.Network]string `yaml:"-"`
+	// URL of a relay that accepts bundles for the watchtower's multi-transaction rounds
+	WatchtowerBundleRelayUrl config.Parameter `yaml:"watchtowerBundleRelayUrl,omitempty"`
 
-	// The contract address of rocketNetworkPrices from v1.2.0
-	v1_2_0_NetworkPricesAddress map[config.Network]string `yaml:"-"`
+	// How many blocks the watchtower targets a bundle for before sending its transactions one at a time
+	WatchtowerBundleBlocks config.Parameter `yaml:"watchtowerBundleBlocks,omitempty"`
 
-	// The contract address of rocketNetworkBalances from v1.2.0
-	v1_2_0_NetworkBalancesAddress map[config.Network]string `yaml:"-"`
+	// How many calculations the watchtower runs at once when it builds rewards trees
+	WatchtowerConcurrency config.Parameter `yaml:"watchtowerConcurrency,omitempty"`
 
-	// Addresses for RocketRewardsPool that have been upgraded during development
-	previousRewardsPoolAddresses map[config.Network][]common.Address `yaml:"-"`
+	// How much the watchtower's heavy calculations give way to the other clients when the machine is busy
+	WatchtowerPriority config.Parameter `yaml:"watchtowerPriority,omitempty"`
 
-	// Addresses for RocketDAOProtocolVerifier that have been upgraded during development
-	previousRocketDAOProtocolVerifier map[config.Network][]common.Address `yaml:"-"`
+	// Address of a secondary UniswapV3 pool used to cross-check the RPL price
+	RplPriceSecondaryTwapPool config.Parameter `yaml:"rplPriceSecondaryTwapPool,omitempty"`
 
-	// Addresses for RocketNetworkPrices that have been upgraded during development
-	previousRocketNetworkPricesAddresses map[config.Network][]common.Address `yaml:"-"`
+	// URL of an external API used to cross-check the RPL price
+	RplPriceApiUrl config.Parameter `yaml:"rplPriceApiUrl,omitempty"`
 
-	// Addresses for RocketNetworkBalances that have been upgraded during development
-	previousRocketNetworkBalancesAddresses map[config.Network][]common.Address `yaml:"-"`
+	// Path of the RPL price field within the external API's JSON response
+	RplPriceApiJsonPath config.Parameter `yaml:"rplPriceApiJsonPath,omitempty"`
 
-	// The RocketOvmPriceMessenger Optimism address for each network
-	optimismPriceMessengerAddress map[config.Network]string `yaml:"-"`
+	// The max divergence (in percent) allowed between the RPL price and the secondary sources
+	RplPriceMaxDivergence config.Parameter `yaml:"rplPriceMaxDivergence,omitempty"`
 
-	// The RocketPolygonPriceMessenger Polygon address for each network
-	polygonPriceMessengerAddress map[config.Network]string `yaml:"-"`
+	// The toggle for rolling records
+	UseRollingRecords config.Parameter `yaml:"useRollingRecords,omitempty"`
 
-	// The RocketArbitumPriceMessenger Arbitrum address for each network
-	arbitrumPriceMessengerAddress map[config.Network]string `yaml:"-"`
+	// The rolling record checkpoint interval
+	RecordCheckpointInterval config.Parameter `yaml:"recordCheckpointInterval,omitempty"`
 
-	// The RocketArbitumPriceMessengerV2 Arbitrum address for each network
-	arbitrumPriceMessengerAddressV2 map[config.Network]string `yaml:"-"`
+	// The checkpoint retention limit
+	CheckpointRetentionLimit config.Parameter `yaml:"checkpointRetentionLimit,omitempty"`
 
-	// The RocketZkSyncPriceMessenger zkSyncEra address for each network
-	zkSyncEraPriceMessengerAddress map[config.Network]string `yaml:"-"`
+	// The compression used for rolling record checkpoints
+	RecordCompressionCodec config.Parameter `yaml:"recordCompressionCodec,omitempty"`
 
-	// The RocketBasePriceMessenger Base address for each network
-	basePriceMessengerAddress map[config.Network]string `yaml:"-"`
+	// The zstd level used for rolling record checkpoints
+	RecordCompressionLevel config.Parameter `yaml:"recordCompressionLevel,omitempty"`
 
-	// The RocketScrollPriceMessenger Scroll address for each network
-	scrollPriceMessengerAddress map[config.Network]string `yaml:"-"`
+	// Toggle for training a zstd dictionary from the saved rolling record checkpoints
+	UseRecordCompressionDictionary config.Parameter `yaml:"useRecordCompressionDictionary,omitempty"`
 
-	// The Scroll L2 message fee estimator address for each network
-	scrollFeeEstimatorAddress map[config.Network]string `yaml:"-"`
+	// The compression used for the local rewards and minipool performance files
+	RewardsFileCompression config.Parameter `yaml:"rewardsFileCompression,omitempty"`
 
-	// The UniswapV3 pool address for each network (used for RPL price TWAP info)
-	rplTwapPoolAddress map[config.Network]string `yaml:"-"`
+	// How often the Watchtower reports its progress while catching a rolling record up, in seconds
+	CatchUpProgressInterval config.Parameter `yaml:"catchUpProgressInterval,omitempty"`
 
-	// The multicall contract address
-	multicallAddress map[config.Network]string `yaml:"-"`
+	// How many hours before the end of each rewards interval the Watchtower checks that it's ready to submit the tree
+	ReadinessCheckLeadTime config.Parameter `yaml:"readinessCheckLeadTime,omitempty"`
 
-	// The BalanceChecker contract address
-	balancebatcherAddress map[config.Network]string `yaml:"-"`
+	// The path of the records folder where snapshots of rolling record info is stored during a rewards interval
+	RecordsPath config.Parameter `yaml:"recordsPath,omitempty"`
 
-	// The FlashBots Protect RPC endpoint
-	flashbotsProtectUrl map[config.Network]string `yaml:"-"`
+	// The toggle for enabling pDAO proposal verification duties
+	VerifyProposals config.Parameter `yaml:"verifyProposals,omitempty"`
 }
 
 // Generates a new Smartnode configuration
@@ -249,6 +399,34 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade: false,
 		},
 
+		HardwareProfile: config.Parameter{
+			ID:                 HardwareProfileID,
+			Name:               "Hardware Profile",
+			Description:        "The kind of machine your node runs on. Choosing a profile tunes your clients' cache sizes, peer counts, and pruning, along with the watchtower's concurrency, to suit it, so you don't have to adjust each of those settings yourself.\n\nThe profile's values are applied when you select it; you can still change any of them afterwards.",
+			Type:               config.ParameterType_Choice,
+			Default:            map[config.Network]interface{}{config.Network_All: config.HardwareProfile_Custom},
+			AffectsContainers:  []config.ContainerID{},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+			Options: []config.ParameterOption{{
+				Name:        "Custom",
+				Description: "Don't tune anything; use the defaults calculated from your RAM and CPU, along with any changes you've made yourself.",
+				Value:       config.HardwareProfile_Custom,
+			}, {
+				Name:        "Low-Power",
+				Description: "For a Raspberry Pi or another ARM board with 8 to 16 GB of RAM. Uses small caches and fewer peers, keeps every client pruned, and limits the watchtower's concurrency.",
+				Value:       config.HardwareProfile_LowPower,
+			}, {
+				Name:        "Standard",
+				Description: "For a NUC or a similar mini PC with 16 to 32 GB of RAM. Uses each client's usual peer count and moderate caches, and keeps every client pruned.",
+				Value:       config.HardwareProfile_Standard,
+			}, {
+				Name:        "Server",
+				Description: "For a server or a workstation with 64 GB of RAM or more. Uses large caches and more peers; archive modes are left as they are.",
+				Value:       config.HardwareProfile_Server,
+			}},
+		},
+
 		WatchtowerStatePath: config.Parameter{
 			ID:                 "watchtowerPath",
 			Name:               "Watchtower Path",
@@ -269,7 +447,7 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower, config.ContainerID_Eth1, config.ContainerID_Eth2, config.ContainerID_Validator},
 			CanBeBlank:         false,
 			OverwriteOnUpgrade: false,
-			Options:            getNetworkOptions(),
+			Options:            getNetworkOptions(cfg),
 		},
 
 		ManualMaxFee: config.Parameter{
@@ -318,6 +496,276 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade: false,
 		},
 
+		ManageRplStake: config.Parameter{
+			ID:                 "manageRplStake",
+			Name:               "Manage RPL Stake",
+			Description:        "Enable this to have the Smartnode keep your node's collateral ratio (the value of your staked RPL as a percentage of the ETH borrowed from the protocol) inside the band set by the lower and upper bounds below.\n\nWhen the ratio drops below the lower bound, any RPL in your node wallet (such as RPL you have claimed from rewards) will be staked until the target is reached. When the ratio rises above the upper bound, you will be notified, and the excess can optionally be withdrawn once it is unlocked.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		RplStakeTargetRatio: config.Parameter{
+			ID:                 "rplStakeTargetRatio",
+			Name:               "RPL Stake Target Ratio",
+			Description:        "The collateral ratio (in percent of borrowed ETH) that the RPL stake manager will aim for when it stakes or withdraws RPL.\n\nMust be between the lower and upper bounds.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: float64(15)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		RplStakeLowerBound: config.Parameter{
+			ID:                 "rplStakeLowerBound",
+			Name:               "RPL Stake Lower Bound",
+			Description:        "If your node's collateral ratio (in percent of borrowed ETH) drops below this value, the RPL stake manager will stake RPL from your node wallet to bring it back to the target.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: float64(12)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		RplStakeUpperBound: config.Parameter{
+			ID:                 "rplStakeUpperBound",
+			Name:               "RPL Stake Upper Bound",
+			Description:        "If your node's collateral ratio (in percent of borrowed ETH) rises above this value, the RPL stake manager will notify you that you have excess RPL staked.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: float64(30)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		RplStakeAutoWithdraw: config.Parameter{
+			ID:                 "rplStakeAutoWithdraw",
+			Name:               "Withdraw Excess RPL",
+			Description:        "Enable this to have the RPL stake manager withdraw RPL down to the target ratio when your collateral ratio is above the upper bound, as long as the RPL is unlocked and can be withdrawn.\n\n[orange]NOTE: withdrawn RPL is sent to your RPL withdrawal address (or your node's primary withdrawal address if one isn't set), not your node wallet.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		RplStakeSimulationMode: config.Parameter{
+			ID:                 "rplStakeSimulationMode",
+			Name:               "RPL Stake Simulation Mode",
+			Description:        "Enable this to have the RPL stake manager only log the stakes and withdrawals it would make, without submitting any transactions. This is useful for checking your bounds before letting it act.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		AutoUpgradeDelegates: config.Parameter{
+			ID:                 "autoUpgradeDelegates",
+			Name:               "Auto-Upgrade Delegates",
+			Description:        "Enable this to have the Smartnode upgrade the delegates of your minipools when a new one is released, in small batches that stay within the daily gas budget below.\n\nOnly minipools you opt in with `rocketpool minipool upgrade-campaign --auto-upgrade on` are upgraded. Upgrades also wait for the network's gas price to drop below your Automatic TX Gas Threshold.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		DelegateUpgradeDailyBudget: config.Parameter{
+			ID:                 "delegateUpgradeDailyBudget",
+			Name:               "Delegate Upgrade Daily Budget",
+			Description:        "The most ETH the delegate upgrade campaign can spend on gas in any 24 hour period. Minipools that don't fit in the budget are upgraded on later days.\n\nSet this to 0 for no limit.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: float64(0.02)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		DelegateUpgradeBatchSize: config.Parameter{
+			ID:                 "delegateUpgradeBatchSize",
+			Name:               "Delegate Upgrade Batch Size",
+			Description:        "The most delegate upgrades the campaign submits each time the node daemon runs its tasks (every 5 minutes).\n\nSet this to 0 for no limit.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(5)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		RethDexQuoteUrls: config.Parameter{
+			ID:                 "rethDexQuoteUrls",
+			Name:               "rETH DEX Quote URLs",
+			Description:        "A comma-separated list of 0x-compatible swap quote URLs that `rocketpool node reth-arbitrage` will use to compare secondary market rETH prices against the protocol's deposit and burn rates.\n\nEach URL can use the {sellToken}, {buyToken}, {sellAmount}, and {taker} placeholders, which will be filled in for each quote. For example:\nhttps://api.0x.org/swap/v1/quote?sellToken={sellToken}&buyToken={buyToken}&sellAmount={sellAmount}&takerAddress={taker}\n\nLeave this blank to only show the protocol rates.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		RethDexRouters: config.Parameter{
+			ID:                 "rethDexRouters",
+			Name:               "Extra rETH DEX Routers",
+			Description:        "A comma-separated list of extra router contract addresses that `rocketpool node reth-arbitrage --route` is allowed to send swaps to, in addition to the well-known 0x, 1inch, and ParaSwap routers.\n\n[orange]WARNING: your node wallet will sign swaps to these contracts, so only add routers you trust. Every swap is still simulated first to make sure the node receives the rETH, but a malicious router could misbehave in ways the simulation can't catch.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		PasswordSource: config.Parameter{
+			ID:                 "passwordSource",
+			Name:               "Wallet Password Source",
+			Description:        "Select where the Smartnode should retrieve your node wallet's password from. By default it's stored in a plaintext file next to the wallet; the other options let you keep it in a secret store instead.\n\n[orange]NOTE: the OS Keyring and Command options run inside the Smartnode's containers in Docker Mode, so they are mainly intended for Native Mode.",
+			Type:               config.ParameterType_Choice,
+			Default:            map[config.Network]interface{}{config.Network_All: config.PasswordSource_File},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+			Options: []config.ParameterOption{{
+				Name:        "File",
+				Description: "Store the password in a plaintext file on disk.",
+				Value:       config.PasswordSource_File,
+			}, {
+				Name:        "OS Keyring",
+				Description: "Retrieve the password from the OS keyring using `secret-tool` on Linux or `security` on macOS. Store it first under the service name below with `node-wallet` as the account.",
+				Value:       config.PasswordSource_Keyring,
+			}, {
+				Name:        "HashiCorp Vault",
+				Description: "Retrieve the password from a HashiCorp Vault KV secret.",
+				Value:       config.PasswordSource_Vault,
+			}, {
+				Name:        "Command",
+				Description: "Run a command (such as `pass show rocketpool`) and use its first line of output as the password.",
+				Value:       config.PasswordSource_Command,
+			}},
+		},
+
+		PasswordKeyringService: config.Parameter{
+			ID:                 "passwordKeyringService",
+			Name:               "Keyring Service Name",
+			Description:        "The service name the node wallet password is stored under in the OS keyring.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: "rocketpool"},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		PasswordVaultAddress: config.Parameter{
+			ID:                 "passwordVaultAddress",
+			Name:               "Vault Address",
+			Description:        "The URL of the HashiCorp Vault server, such as https://vault.example.com:8200.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		PasswordVaultSecretPath: config.Parameter{
+			ID:                 "passwordVaultSecretPath",
+			Name:               "Vault Secret Path",
+			Description:        "The API path of the secret holding the password, such as `secret/data/rocketpool` for a KV version 2 engine mounted at `secret`.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: "secret/data/rocketpool"},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		PasswordVaultField: config.Parameter{
+			ID:                 "passwordVaultField",
+			Name:               "Vault Secret Field",
+			Description:        "The field of the Vault secret that holds the password.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: "password"},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		PasswordVaultTokenPath: config.Parameter{
+			ID:                 "passwordVaultTokenPath",
+			Name:               "Vault Token File",
+			Description:        "The path of a file holding the Vault token to authenticate with. In Docker Mode, put it in your node's data folder so the containers can read it. Leave this blank to use the VAULT_TOKEN environment variable instead.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		PasswordCommand: config.Parameter{
+			ID:                 "passwordCommand",
+			Name:               "Password Command",
+			Description:        "The command to run to retrieve the password. It is run with `sh -c`, and the first line it prints is used as the password.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		EncryptValidatorKeys: config.Parameter{
+			ID:                 "encryptValidatorKeys",
+			Name:               "Encrypt Validator Keys at Rest",
+			Description:        "Enable this to keep your validator keystores in an archive encrypted with your node wallet password, instead of as plain files on disk.\n\nWhen the node starts, it decrypts the archive into a folder held in memory (tmpfs) and restarts your Validator Client to load them, so a stolen disk does not expose your keys. Any keys added while it runs are saved back into the archive. The keys are never decrypted to disk; if the folder isn't in memory, they stay locked until `rocketpool service start` mounts it (in Native Mode, you'll need to mount a tmpfs there yourself).\n\nRun `rocketpool wallet encrypt-validator-keys` after enabling this to create the archive.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Validator},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		EnableKeymanagerApi: config.Parameter{
+			ID:                 "enableKeymanagerApi",
+			Name:               "Enable Keymanager API",
+			Description:        "Enable the Validator Client's standard keymanager API so the Smartnode can load new validator keys (such as after creating a minipool or recovering your wallet) and change per-validator settings without restarting it.\n\nThe API is only reachable from inside the Smartnode's Docker network, and requires a token that the Smartnode generates in your validators folder.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		KeymanagerApiPort: config.Parameter{
+			ID:                 "keymanagerApiPort",
+			Name:               "Keymanager API Port",
+			Description:        "The port the Validator Client should serve its keymanager API on.",
+			Type:               config.ParameterType_Uint16,
+			Default:            map[config.Network]interface{}{config.Network_All: uint16(7500)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		EnableMessageSigning: config.Parameter{
+			ID:                 "enableMessageSigning",
+			Name:               "Enable Message Signing",
+			Description:        "Enable this to allow `rocketpool node sign-message` to sign messages with your node's private key, such as to prove you own the node to a third-party service or for a community airdrop.\n\n[orange]NOTE: anyone holding a signature can use it to prove your node approved the message, and anything on this machine that can reach the Smartnode's API can request one. Only enable this while you need it.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		PreferredGasLimit: config.Parameter{
+			ID:                 "preferredGasLimit",
+			Name:               "Preferred Gas Limit",
+			Description:        "The block gas limit your validators should vote for when they propose blocks. Each block can move the gas limit a small step towards this value, which is how validators collectively signal for gas limit changes.\n\nThis is set on your Validator Client and included in its builder registrations, so blocks built by MEV-Boost relays follow it too. If the keymanager API is enabled, it is also applied to the running Validator Client without a restart. The node will warn you if one of your proposed blocks did not honor it.\n\nSet this to 0 to use your Validator Client's default.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Validator},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
 		VerifyProposals: config.Parameter{
 			ID:                 "verifyProposals",
 			Name:               "Enable PDAO Proposal Checker",
@@ -360,6 +808,17 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade: true,
 		},
 
+		OfflineRewardsGeneration: config.Parameter{
+			ID:                 "offlineRewardsGeneration",
+			Name:               "Offline Rewards Generation",
+			Description:        "For Oracle DAO members that generate their trees on a dedicated machine. Enable this on that machine (with the Rewards Tree Mode set to Generate) to have its Watchtower build each tree the same way an Oracle DAO member would, and save a submission payload next to it instead of submitting it.\n\nCopy the tree, its compressed copy, the minipool performance files, and the payload to your Oracle DAO node and run `rocketpool odao import-rewards-tree <payload>` there to check and submit them.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
 		RewardsTreeCustomUrl: config.Parameter{
 			ID:                 "rewardsTreeCustomUrl",
 			Name:               "Rewards Tree Custom Download URLs",
@@ -371,10 +830,21 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade: false,
 		},
 
-		ArchiveECUrl: config.Parameter{
-			ID:                 "archiveECUrl",
-			Name:               "Archive-Mode EC URL",
-			Description:        "[orange]**For manual Merkle rewards tree generation only.**[white]\n\nGenerating the Merkle rewards tree files for past rewards intervals typically requires an Execution client with Archive mode enabled, which is usually disabled on your primary and fallback Execution clients to save disk space.\nIf you want to generate your own rewards tree files for intervals from a long time ago, you may enter the URL of an Execution client with Archive access here.\n\nFor a free light client with Archive access, you may use https://www.alchemy.com/supernode.",
+		EnableIpfsPinning: config.Parameter{
+			ID:                 "enableIpfsPinning",
+			Name:               "Enable IPFS Pinning",
+			Description:        "Oracle DAO members only. Enable this to have the Watchtower upload each rewards tree and minipool performance file it submits to your IPFS node, ask each of your pinning services to pin it, and check that it can be retrieved through several independent public gateways. Files that become unreachable are re-pinned automatically.\n\nRun `rocketpool odao pinning-status` to see how each file is doing.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		IpfsApiUrl: config.Parameter{
+			ID:                 "ipfsApiUrl",
+			Name:               "IPFS Node API URL",
+			Description:        "The URL of the Kubo RPC API of an IPFS node to upload the rewards files to, such as `http://192.168.1.10:5001`. The pinning services fetch the files from the IPFS network, so at least one node needs to provide them; leave this blank if another node already does.",
 			Type:               config.ParameterType_String,
 			Default:            map[config.Network]interface{}{config.Network_All: ""},
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
@@ -382,32 +852,609 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade: false,
 		},
 
-		WatchtowerMaxFeeOverride: config.Parameter{
-			ID:                 "watchtowerMaxFeeOverride",
-			Name:               "Watchtower Max Fee Override",
-			Description:        fmt.Sprintf("[orange]**For Oracle DAO members only.**\n\n[white]Use this to override the max fee (in gwei) for watchtower transactions. Note that if you set it below %d, the setting will be ignored; it can only be used to set the max fee higher than %d during times of extreme network stress.", WatchtowerMaxFeeDefault, WatchtowerMaxFeeDefault),
-			Type:               config.ParameterType_Float,
-			Default:            map[config.Network]interface{}{config.Network_All: float64(WatchtowerMaxFeeDefault)},
+		IpfsPinningServices: config.Parameter{
+			ID:                 "ipfsPinningServices",
+			Name:               "IPFS Pinning Services",
+			Description:        "The pinning services to pin the rewards files with. They must support the standard IPFS Pinning Service API. Enter each one as its API endpoint and access token separated by '|', and separate the services with ';' - for example: `https://api.pinata.cloud/psa|<token>;https://api.filebase.io/v1/ipfs|<token>`.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
-			CanBeBlank:         false,
-			OverwriteOnUpgrade: true,
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
 		},
 
-		WatchtowerPrioFeeOverride: config.Parameter{
-			ID:                 "watchtowerPrioFeeOverride",
-			Name:               "Watchtower Priority Fee Override",
-			Description:        fmt.Sprintf("[orange]**For Oracle DAO members only.**\n\n[white]Use this to override the priority fee (in gwei) for watchtower transactions. Note that if you set it below %d, the setting will be ignored; it can only be used to set the priority fee higher than %d during times of extreme network stress.", WatchtowerPrioFeeDefault, WatchtowerPrioFeeDefault),
-			Type:               config.ParameterType_Float,
-			Default:            map[config.Network]interface{}{config.Network_All: float64(WatchtowerPrioFeeDefault)},
+		IpfsVerificationGateways: config.Parameter{
+			ID:                 "ipfsVerificationGateways",
+			Name:               "IPFS Verification Gateways",
+			Description:        "The public IPFS gateways used to check that the rewards files can be retrieved, separated by ';'. Use '%s' twice in each one: first for the CID, then for the file name.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: DefaultPinningGateways},
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
-			CanBeBlank:         false,
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		IpfsPinCheckInterval: config.Parameter{
+			ID:                 "ipfsPinCheckInterval",
+			Name:               "IPFS Pin Check Interval",
+			Description:        "How often, in hours, the Watchtower checks the pins and gateways for every rewards file it has pinned.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(6)},
+			Advanced:           true,
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		RewardsMirrorUrls: config.Parameter{
+			ID:                 "rewardsMirrorUrls",
+			Name:               "Rewards File Mirrors",
+			Description:        "HTTP mirrors that host the compressed rewards files published by Oracle DAO members, separated by ';'. The Smartnode tries these alongside the IPFS gateways when it downloads a missing rewards tree, so a slow gateway doesn't hold it up. Enter the folder that holds the files - for example: `https://rewards.my-cool-domain.com/mainnet`.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		RewardsFileCacheFolder: config.Parameter{
+			ID:                 "rewardsFileCacheFolder",
+			Name:               "Rewards File Cache Folder",
+			Description:        "A folder to keep a copy of each rewards tree the Smartnode downloads and verifies. If you run several Smartnode instances on this machine, point them all at the same folder so each tree only has to be downloaded once. Leave this blank to turn the cache off.\n\nIn Docker mode, only the `rocketpool` command uses the cache, since the daemons can't see folders outside of their data folder.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		RewardsMirrorUploadUrl: config.Parameter{
+			ID:                 "rewardsMirrorUploadUrl",
+			Name:               "Rewards Mirror Upload URL",
+			Description:        "Oracle DAO members only. The folder on an HTTP mirror to publish each submitted rewards file to, along with its torrent if those are enabled. The files are uploaded with an HTTP PUT to this URL followed by the file name, which works with S3-compatible buckets (using a pre-authorized URL or an upload proxy), WebDAV shares, and most static hosts. Leave this blank to turn publishing off.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		RewardsMirrorUploadAuth: config.Parameter{
+			ID:                 "rewardsMirrorUploadAuth",
+			Name:               "Rewards Mirror Upload Authorization",
+			Description:        "The value of the `Authorization` header to send with each upload to the mirror, such as `Bearer <token>` or `Basic <credentials>`. Leave this blank if the mirror doesn't need one.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		GenerateRewardsTorrents: config.Parameter{
+			ID:                 "generateRewardsTorrents",
+			Name:               "Generate Rewards Torrents",
+			Description:        "Oracle DAO members only. Enable this to have the Watchtower create a torrent for each rewards file it submits, and save it next to the file. The torrent is built the same way on every node and includes the file's CID, so its info-hash is the same for every Oracle DAO member and can be worked out from the CID that was submitted. The IPFS gateways and your mirrors are listed as web seeds.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		RewardsTorrentTrackers: config.Parameter{
+			ID:                 "rewardsTorrentTrackers",
+			Name:               "Rewards Torrent Trackers",
+			Description:        "The trackers to list in the rewards file torrents, separated by ';'. Torrent clients can also find peers through the DHT, so this can be left blank.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: DefaultRewardsTorrentTrackers},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		EnableProofServer: config.Parameter{
+			ID:                 "enableProofServer",
+			Name:               "Enable Proof Server",
+			Description:        "Enable this to have the Node process serve the Merkle proofs for any node and interval from the rewards files it has verified against the chain. Claim frontends and scripts can then get proofs from your own node instead of a centralized API.\n\nThe server is read-only: `/v1/intervals` lists the intervals it can serve, `/v1/proofs/<address>` returns a node's proofs for every interval, and `/v1/proofs/<address>/<interval>` returns a single one.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		ProofServerPort: config.Parameter{
+			ID:                 "proofServerPort",
+			Name:               "Proof Server Port",
+			Description:        "The port the proof server listens on.",
+			Type:               config.ParameterType_Uint16,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultProofServerPort},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		EnableProfiling: config.Parameter{
+			ID:                 "enableProfiling",
+			Name:               "Enable Profiling",
+			Description:        "Enable this to have the Node and Watchtower processes serve their CPU, memory, and goroutine profiles, so `rocketpool service capture-profile` can bundle them up for a bug report. Leave it off unless you're chasing down a slowdown; profiles show what the daemons are doing, so the ports should never be exposed outside of this machine.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		NodeProfilingPort: config.Parameter{
+			ID:                 "nodeProfilingPort",
+			Name:               "Node Profiling Port",
+			Description:        "The port the Node process serves its profiles on.",
+			Type:               config.ParameterType_Uint16,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultNodeProfilingPort},
+			Advanced:           true,
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		WatchtowerProfilingPort: config.Parameter{
+			ID:                 "watchtowerProfilingPort",
+			Name:               "Watchtower Profiling Port",
+			Description:        "The port the Watchtower process serves its profiles on.",
+			Type:               config.ParameterType_Uint16,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultWatchtowerProfilingPort},
+			Advanced:           true,
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		ArchiveECUrl: config.Parameter{
+			ID:                 "archiveECUrl",
+			Name:               "Archive-Mode EC URL",
+			Description:        "Generating past Merkle rewards trees, regenerating rolling records, and auditing historical rewards all need the state of old blocks, which is usually pruned from your primary and fallback Execution clients to save disk space.\nIf you enter the URL of an Execution client with Archive access here, the Smartnode will send only those historical queries to it; everything else keeps using your own clients.\n\nFor a free light client with Archive access, you may use https://www.alchemy.com/supernode.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		ArchiveECRoutingHorizon: config.Parameter{
+			ID:                 "archiveECRoutingHorizon",
+			Name:               "Archive EC Routing Horizon",
+			Description:        "Queries for blocks more than this many blocks behind the chain head are sent straight to the Archive-Mode EC. Set this to your Execution client's pruning horizon (for example, 90000 for Geth with path-based state) to avoid a failed query on your own client first.\n\nSet it to 0 to only use the Archive-Mode EC after your own client reports that it doesn't have the state for a block.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(0)},
+			Advanced:           true,
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		PrivateTxRelayUrl: config.Parameter{
+			ID:                 "privateTxRelayUrl",
+			Name:               "Private Transaction Relay URL",
+			Description:        "The RPC URL of a private transaction relay, such as Flashbots Protect (https://rpc.flashbots.net). The transactions selected below are sent to it instead of the public mempool, so they can't be front-run or sandwiched.\n\nIf the relay doesn't get a transaction included within the timeout below, the Smartnode broadcasts it publicly instead.\n\nLeave this blank to send every transaction publicly.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		PrivateTxTypes: config.Parameter{
+			ID:                 "privateTxTypes",
+			Name:               "Private Transaction Types",
+			Description:        "A comma-separated list of the kinds of transactions to send through the private relay. The supported kinds are:\n\nclaim-rewards: rewards claims, including claiming and restaking\nreth-arbitrage: rETH deposits routed by `rocketpool node reth-arbitrage`\nburn: burning rETH for ETH\nsend: sending ETH or tokens from the node wallet",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: "claim-rewards,reth-arbitrage"},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		PrivateTxTimeout: config.Parameter{
+			ID:                 "privateTxTimeout",
+			Name:               "Private Transaction Timeout",
+			Description:        "How many minutes to wait for the private relay to get a transaction included before broadcasting it to the public mempool instead.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(5)},
+			Advanced:           true,
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		ValidatorStatusChunkSize: config.Parameter{
+			ID:                 "validatorStatusChunkSize",
+			Name:               "Validator Status Batch Size",
+			Description:        "The maximum number of validators to look up in a single request to your Beacon Node. Lower this if your Beacon Node rejects or times out on requests for large numbers of validators.\n\nRequests that your Beacon Node rejects as too large are split in half and retried automatically.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(600)},
+			Advanced:           true,
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		NetworkStateCacheTTL: config.Parameter{
+			ID:                 "networkStateCacheTTL",
+			Name:               "Network State Cache Lifetime",
+			Description:        "The node process can save a snapshot of your node's network state to disk every time it updates it. Commands that support it will show this snapshot instead of querying your clients again, as long as it is younger than this many seconds; use their `--refresh` flag to skip it.\n\nSet this to 0 to disable the snapshot.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(0)},
+			Advanced:           true,
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		ClientCallRetries: config.Parameter{
+			ID:                 "clientCallRetries",
+			Name:               "Client Call Retries",
+			Description:        "The number of times a call to your Execution or Beacon client is retried if it fails because of a timeout, a dropped connection, or the client being overloaded. Retries wait twice as long each time, starting at half a second.\n\nTransactions are never retried.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(2)},
+			Advanced:           true,
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		ClientCircuitBreakerThreshold: config.Parameter{
+			ID:                 "clientCircuitBreakerThreshold",
+			Name:               "Client Failure Threshold",
+			Description:        "If a call to your primary Execution or Beacon client fails this many times in a row, the Smartnode will use your fallback client for a minute before trying the primary again. Without a fallback, the primary is always used.\n\nSet this to 0 to only switch to the fallback when the primary can't be reached at all.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(5)},
+			Advanced:           true,
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		NtpServers: config.Parameter{
+			ID:                 "ntpServers",
+			Name:               "NTP Servers",
+			Description:        "A comma-separated list of NTP servers the Smartnode will compare your system clock against. Validators whose clocks are off by even half a second can miss duties without any other sign of a problem, so the Node process checks regularly and sends an alert if your clock drifts too far.\n\nThe median of the servers' answers is used, so a single bad server won't cause a false alarm.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: "pool.ntp.org,time.cloudflare.com,time.google.com"},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		PortCheckerUrl: config.Parameter{
+			ID:                 "portCheckerUrl",
+			Name:               "Port Checker URL",
+			Description:        "The URL of the service `rocketpool service check-ports` and the config wizard use to check if your clients' P2P ports can be reached from the internet. `{port}` is replaced with the port to check, and the service has to reply with a JSON object that has a `reachable` field, like `https://ifconfig.co/port/{port}` does.\n\nThe check connects to your public IP address, so only TCP ports can be checked. Leave this blank to skip the check.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: "https://ifconfig.co/port/{port}"},
+			Advanced:           true,
+			AffectsContainers:  []config.ContainerID{},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		EnablePortForwarding: config.Parameter{
+			ID:                 "enablePortForwarding",
+			Name:               "Enable Automatic Port Forwarding",
+			Description:        "Have the Node process ask your router to forward your clients' P2P ports to this machine with UPnP or NAT-PMP, and keep renewing the mappings while it runs. This helps your clients find more peers if you're behind a home router and haven't forwarded the ports yourself.\n\nYour router has to have UPnP or NAT-PMP turned on. The router's external IP address is saved to `external-ip` in your data folder, and your clients advertise it the next time they're started with `rocketpool service start`.\n\n[orange]NOTE: in Docker Mode, the Node container can't find your router on its own, so you have to fill in the Router IP Address and Host LAN IP Address settings.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		PortForwardingMethod: config.Parameter{
+			ID:                 "portForwardingMethod",
+			Name:               "Port Forwarding Method",
+			Description:        "The protocol used to ask your router to forward the P2P ports.",
+			Type:               config.ParameterType_Choice,
+			Default:            map[config.Network]interface{}{config.Network_All: config.PortForwardingMethod_Auto},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+			Options: []config.ParameterOption{{
+				Name:        "Automatic",
+				Description: "Use whichever of UPnP and NAT-PMP your router answers first.",
+				Value:       config.PortForwardingMethod_Auto,
+			}, {
+				Name:        "UPnP",
+				Description: "Use UPnP, which most consumer routers support.",
+				Value:       config.PortForwardingMethod_Upnp,
+			}, {
+				Name:        "NAT-PMP",
+				Description: "Use NAT-PMP (or its successor PCP), which is common on Apple and some open source routers.",
+				Value:       config.PortForwardingMethod_Pmp,
+			}},
+		},
+
+		PortForwardingGateway: config.Parameter{
+			ID:                 "portForwardingGateway",
+			Name:               "Router IP Address",
+			Description:        "The LAN IP address of your router, such as `192.168.1.1`. The Node process asks this router for the port mappings directly instead of searching the network for it.\n\nThis is required in Docker Mode, since the Node container is on its own Docker network and can't find the router on its own. In Native Mode, leave it blank to search for the router automatically.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		PortForwardingHostIp: config.Parameter{
+			ID:                 "portForwardingHostIp",
+			Name:               "Host LAN IP Address",
+			Description:        "The LAN IP address of this machine, such as `192.168.1.50`. UPnP mappings are pointed at this address, so the router sends the P2P traffic to this machine rather than to the Node container's internal Docker address.\n\nThis is required for UPnP in Docker Mode. In Native Mode, leave it blank to use the address of the network interface that reaches the router.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		EnableIpv6: config.Parameter{
+			ID:                 "enableIpv6",
+			Name:               "Enable IPv6",
+			Description:        "Have your Execution and Consensus clients use IPv6 alongside IPv4 (dual-stack) for their P2P traffic. Many ISPs now give homes a public IPv6 address even when IPv4 is shared behind carrier-grade NAT, so this can help your clients find more peers.\n\nLighthouse, Lodestar, and Teku listen on a separate IPv6 P2P port, which you can set in the Consensus Client settings. Prysm doesn't support IPv6 yet, so it keeps using IPv4 only. Run `rocketpool service check-ports` after enabling this to check your IPv6 connectivity.\n\n[orange]NOTE: in Docker Mode, IPv6 has to be enabled in Docker's daemon settings as well.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Eth1, config.ContainerID_Eth2},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		Ipv6ListenAddress: config.Parameter{
+			ID:                 "ipv6ListenAddress",
+			Name:               "IPv6 Listen Address",
+			Description:        "The IPv6 address your clients listen for P2P traffic on. The default, `::`, listens on every address. Geth always listens on every address, so it ignores this.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: "::"},
+			Advanced:           true,
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Eth1, config.ContainerID_Eth2},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		Ipv6Discovery: config.Parameter{
+			ID:                 "ipv6Discovery",
+			Name:               "Advertise IPv6 for Discovery",
+			Description:        "Have your Consensus client advertise its IPv6 address and port in its node record, so other nodes can find it and connect to it over IPv6. Disable this if your IPv6 address can't be reached from the internet; your clients will still be able to connect out over IPv6.\n\nLighthouse, Lodestar, and Teku support this. The other clients learn their IPv6 address from their peers.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Eth2},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		Ipv6AdvertisedAddress: config.Parameter{
+			ID:                 "ipv6AdvertisedAddress",
+			Name:               "Advertised IPv6 Address",
+			Description:        "The public IPv6 address your Consensus client advertises to other nodes. Leave this blank to let the client work it out from its peers, which is what most users want.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			Advanced:           true,
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Eth2},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		EnableActiveHostGuard: config.Parameter{
+			ID:                 "enableActiveHostGuard",
+			Name:               "Enable Active Host Guard",
+			Description:        "Protect against running your validators on two machines at once, such as after a migration or restoring a backup.\n\nWhen enabled, the Node process signs an \"active host\" beacon with your node wallet every few minutes. If you've set up remote backup storage, the beacon is also uploaded there so other machines running your node can see it. The Validator Client is stopped, and an alert is sent, if another machine has published a beacon recently, or if your validators attested on chain while this machine's Validator Client wasn't running. When the Node process starts, a Validator Client that has only just started is paused until these checks pass, so it can't sign anything in the meantime.\n\nOnce the other machine is offline, run `rocketpool service active-host --claim` to resume your duties here.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		WatchtowerMaxFeeOverride: config.Parameter{
+			ID:                 "watchtowerMaxFeeOverride",
+			Name:               "Watchtower Max Fee Override",
+			Description:        fmt.Sprintf("[orange]**For Oracle DAO members only.**\n\n[white]Use this to override the max fee (in gwei) for watchtower transactions. Note that if you set it below %d, the setting will be ignored; it can only be used to set the max fee higher than %d during times of extreme network stress.", WatchtowerMaxFeeDefault, WatchtowerMaxFeeDefault),
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: float64(WatchtowerMaxFeeDefault)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: true,
+		},
+
+		WatchtowerPrioFeeOverride: config.Parameter{
+			ID:                 "watchtowerPrioFeeOverride",
+			Name:               "Watchtower Priority Fee Override",
+			Description:        fmt.Sprintf("[orange]**For Oracle DAO members only.**\n\n[white]Use this to override the priority fee (in gwei) for watchtower transactions. Note that if you set it below %d, the setting will be ignored; it can only be used to set the priority fee higher than %d during times of extreme network stress.", WatchtowerPrioFeeDefault, WatchtowerPrioFeeDefault),
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: float64(WatchtowerPrioFeeDefault)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         false,
 			OverwriteOnUpgrade: true,
 		},
 
-		UseRollingRecords: config.Parameter{
-			ID:                 "useRollingRecords",
-			Name:               "Use Rolling Records",
-			Description:        "[orange]**WARNING: EXPERIMENTAL**\n\n[white]Enable this to use the new rolling records feature, which stores attestation records for the entire Rocket Pool network in real time instead of collecting them all after a rewards period during tree generation.\n\nOnly useful for the Oracle DAO, or if you generate your own rewards trees.",
+		WatchtowerBundleRelayUrl: config.Parameter{
+			ID:                 "watchtowerBundleRelayUrl",
+			Name:               "Watchtower Bundle Relay URL",
+			Description:        "[orange]**For Oracle DAO members only.**\n\n[white]The URL of a relay that accepts transaction bundles through `eth_sendBundle`, such as `https://relay.flashbots.net`. When set, rounds that take several watchtower transactions are submitted as one atomic bundle, so they're either all included in the same block or not at all. If the relay doesn't get the bundle included, the transactions are sent one at a time instead.\n\nLeave this blank to always send them one at a time.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		WatchtowerBundleBlocks: config.Parameter{
+			ID:                 "watchtowerBundleBlocks",
+			Name:               "Watchtower Bundle Target Blocks",
+			Description:        "[orange]**For Oracle DAO members only.**\n\n[white]How many blocks in a row the watchtower asks the bundle relay to include a bundle in. If it still isn't included after that, the transactions are sent one at a time.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(5)},
+			Advanced:           true,
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		WatchtowerConcurrency: config.Parameter{
+			ID:                 "watchtowerConcurrency",
+			Name:               "Watchtower Concurrency",
+			Description:        "How many calculations the watchtower runs at once when it builds rewards trees. Lower values use less CPU and RAM, but take longer.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultWatchtowerConcurrency},
+			Advanced:           true,
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		WatchtowerPriority: config.Parameter{
+			ID:                 "watchtowerPriority",
+			Name:               "Watchtower Priority",
+			Description:        "How much the watchtower's rewards tree generation and rolling record catch-up give way to your Validator Client when the machine's CPU or disk is under pressure. When it gives way, the watchtower runs fewer calculations at once and waits for the quiet part of each slot, after attestations are made, before it continues.",
+			Type:               config.ParameterType_Choice,
+			Default:            map[config.Network]interface{}{config.Network_All: config.WatchtowerPriority_Normal},
+			Advanced:           true,
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+			Options: []config.ParameterOption{{
+				Name:        "Low",
+				Description: "Give way as soon as the machine gets busy. Best for small machines, but catching up can take much longer.",
+				Value:       config.WatchtowerPriority_Low,
+			}, {
+				Name:        "Normal",
+				Description: "Give way only when the machine is under heavy pressure, for a limited time.",
+				Value:       config.WatchtowerPriority_Normal,
+			}, {
+				Name:        "High",
+				Description: "Never give way. Use this on Oracle DAO nodes that have to submit their trees on time.",
+				Value:       config.WatchtowerPriority_High,
+			}},
+		},
+
+		RplPriceSecondaryTwapPool: config.Parameter{
+			ID:                 "rplPriceSecondaryTwapPool",
+			Name:               "Secondary RPL Price Pool",
+			Description:        "[orange]**For Oracle DAO members only.**\n\n[white]The address of a secondary UniswapV3-compatible pool with the same token ordering as the primary RPL / ETH pool. If set, the watchtower will calculate the RPL TWAP from this pool as well and will refuse to submit the RPL price if the two diverge by more than the Max RPL Price Divergence.\n\nLeave this blank to disable the secondary pool check.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		RplPriceApiUrl: config.Parameter{
+			ID:                 "rplPriceApiUrl",
+			Name:               "External RPL Price API URL",
+			Description:        "[orange]**For Oracle DAO members only.**\n\n[white]The URL of an external API that provides the current RPL price denominated in ETH as JSON, such as `https://api.coingecko.com/api/v3/simple/price?ids=rocket-pool&vs_currencies=eth`. If set, the watchtower will refuse to submit the RPL price if it diverges from this price by more than the Max RPL Price Divergence.\n\nLeave this blank to disable the external API check.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		RplPriceApiJsonPath: config.Parameter{
+			ID:                 "rplPriceApiJsonPath",
+			Name:               "External RPL Price JSON Path",
+			Description:        "[orange]**For Oracle DAO members only.**\n\n[white]The path of the RPL price field in the External RPL Price API's response, with each level separated by a '.' - for example, `rocket-pool.eth` for the CoinGecko simple price API. Leave this blank if the API returns the price as a bare number.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: "rocket-pool.eth"},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		RplPriceMaxDivergence: config.Parameter{
+			ID:                 "rplPriceMaxDivergence",
+			Name:               "Max RPL Price Divergence",
+			Description:        "[orange]**For Oracle DAO members only.**\n\n[white]The maximum difference (in percent) allowed between the RPL price from the primary TWAP pool and the price reported by any of the secondary sources above. If a secondary source diverges by more than this amount, the watchtower will not submit the RPL price and will send an alert instead.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: float64(5)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		UseRollingRecords: config.Parameter{
+			ID:                 "useRollingRecords",
+			Name:               "Use Rolling Records",
+			Description:        "[orange]**WARNING: EXPERIMENTAL**\n\n[white]Enable this to use the new rolling records feature, which stores attestation records for the entire Rocket Pool network in real time instead of collecting them all after a rewards period during tree generation.\n\nOnly useful for the Oracle DAO, or if you generate your own rewards trees.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		RecordCheckpointInterval: config.Parameter{
+			ID:                 "recordCheckpointInterval",
+			Name:               "Record Checkpoint Interval",
+			Description:        "The number of epochs that should pass before saving a new rolling record checkpoint. Used if Rolling Records is enabled.\n\nOnly useful for the Oracle DAO, or if you generate your own rewards trees.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(45)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		CheckpointRetentionLimit: config.Parameter{
+			ID:                 "checkpointRetentionLimit",
+			Name:               "Checkpoint Retention Limit",
+			Description:        "The number of checkpoint files to save on-disk before pruning old ones. Used if Rolling Records is enabled.\n\nOnly useful for the Oracle DAO, or if you generate your own rewards trees.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(200)},
+			Advanced:           true,
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		RecordCompressionCodec: config.Parameter{
+			ID:                 "recordCompressionCodec",
+			Name:               "Record Compression",
+			Description:        "The compression to use when saving rolling record checkpoints. Checkpoints saved with a different setting can still be loaded, since each file records how it was compressed.\n\nThe local rewards files use the Rewards File Compression setting instead.\n\nOnly useful for the Oracle DAO, or if you generate your own rewards trees.",
+			Type:               config.ParameterType_Choice,
+			Default:            map[config.Network]interface{}{config.Network_All: config.RecordCodec_Zstd},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+			Options: []config.ParameterOption{{
+				Name:        "zstd",
+				Description: "Compress checkpoints with zstd at the level below. This gives the smallest files.",
+				Value:       config.RecordCodec_Zstd,
+			}, {
+				Name:        "LZ4",
+				Description: "Compress checkpoints with LZ4, which loads the fastest of the compressed options but produces larger files than zstd.",
+				Value:       config.RecordCodec_Lz4,
+			}, {
+				Name:        "S2",
+				Description: "Compress checkpoints with S2, which is much faster than zstd but produces larger files.",
+				Value:       config.RecordCodec_S2,
+			}, {
+				Name:        "None",
+				Description: "Save checkpoints without compression. They load fastest but take up the most space.",
+				Value:       config.RecordCodec_None,
+			}},
+		},
+
+		RecordCompressionLevel: config.Parameter{
+			ID:                 "recordCompressionLevel",
+			Name:               "Record Compression Level",
+			Description:        "The zstd compression level (1 to 22) to use for rolling record checkpoints and local rewards files when zstd compression is selected. Higher levels produce smaller files but take longer to save.\n\nOnly useful for the Oracle DAO, or if you generate your own rewards trees.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(19)},
+			Advanced:           true,
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		UseRecordCompressionDictionary: config.Parameter{
+			ID:                 "useRecordCompressionDictionary",
+			Name:               "Use Record Compression Dictionary",
+			Description:        "Enable this to train a zstd dictionary from your saved rolling record checkpoints and use it to compress new ones, which makes them smaller and faster to load. The dictionary is trained once enough checkpoints have been saved, and is kept with the records so the checkpoints that use it can always be loaded.\n\nOnly used with zstd compression.",
 			Type:               config.ParameterType_Bool,
 			Default:            map[config.Network]interface{}{config.Network_All: false},
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
@@ -415,23 +1462,49 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade: false,
 		},
 
-		RecordCheckpointInterval: config.Parameter{
-			ID:                 "recordCheckpointInterval",
-			Name:               "Record Checkpoint Interval",
-			Description:        "The number of epochs that should pass before saving a new rolling record checkpoint. Used if Rolling Records is enabled.\n\nOnly useful for the Oracle DAO, or if you generate your own rewards trees.",
+		RewardsFileCompression: config.Parameter{
+			ID:                 "rewardsFileCompression",
+			Name:               "Rewards File Compression",
+			Description:        "The compression to use when saving the rewards and minipool performance files for each interval to your data folder. Files saved with a different setting can still be loaded, since each compressed file records how it was compressed.\n\nThe compressed copies published to IPFS always use standard zstd, since every Oracle DAO member has to produce the same file. Leave this on None if you use other tools that read the rewards files directly.",
+			Type:               config.ParameterType_Choice,
+			Default:            map[config.Network]interface{}{config.Network_All: config.RecordCodec_None},
+			Advanced:           true,
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+			Options: []config.ParameterOption{{
+				Name:        "None",
+				Description: "Save the files as plain JSON, which any tool can read.",
+				Value:       config.RecordCodec_None,
+			}, {
+				Name:        "zstd",
+				Description: "Compress the files with zstd at the Record Compression Level. This gives the smallest files.",
+				Value:       config.RecordCodec_Zstd,
+			}, {
+				Name:        "LZ4",
+				Description: "Compress the files with LZ4, which loads faster than zstd but produces larger files.",
+				Value:       config.RecordCodec_Lz4,
+			}},
+		},
+
+		CatchUpProgressInterval: config.Parameter{
+			ID:                 "catchUpProgressInterval",
+			Name:               "Catch-Up Progress Interval",
+			Description:        "How often, in seconds, the Watchtower reports its progress while it catches a rolling record up to the chain head. Each report includes the epochs processed and remaining, the processing rate, an estimated completion time, and whether the Consensus Client or the CPU is the bottleneck. The reports are logged, shown in the dashboard, and exported as metrics.\n\nOnly useful if you're an Oracle DAO member, or if you generate your own rewards trees.",
 			Type:               config.ParameterType_Uint,
-			Default:            map[config.Network]interface{}{config.Network_All: uint64(45)},
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(60)},
+			Advanced:           true,
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
 			CanBeBlank:         false,
 			OverwriteOnUpgrade: false,
 		},
 
-		CheckpointRetentionLimit: config.Parameter{
-			ID:                 "checkpointRetentionLimit",
-			Name:               "Checkpoint Retention Limit",
-			Description:        "The number of checkpoint files to save on-disk before pruning old ones. Used if Rolling Records is enabled.\n\nOnly useful for the Oracle DAO, or if you generate your own rewards trees.",
+		ReadinessCheckLeadTime: config.Parameter{
+			ID:                 "readinessCheckLeadTime",
+			Name:               "Readiness Check Lead Time",
+			Description:        "How many hours before the end of each rewards interval the Watchtower checks that it's ready to build and submit the rewards tree: that the rolling record is caught up, the state at the start of the interval can be read, and the node wallet has enough ETH for gas. Any problems are logged and sent as alerts, and the check is repeated every hour until they're fixed or the interval ends.\n\nSet this to 0 to disable the check. Only useful for the Oracle DAO.",
 			Type:               config.ParameterType_Uint,
-			Default:            map[config.Network]interface{}{config.Network_All: uint64(200)},
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(6)},
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
 			CanBeBlank:         false,
 			OverwriteOnUpgrade: false,
@@ -447,230 +1520,6 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			CanBeBlank:         false,
 			OverwriteOnUpgrade: false,
 		},
-
-		txWatchUrl: map[config.Network]string{
-			config.Network_Mainnet: "https://etherscan.io/tx",
-			config.Network_Devnet:  "https://holesky.etherscan.io/tx",
-			config.Network_Holesky: "https://holesky.etherscan.io/tx",
-		},
-
-		stakeUrl: map[config.Network]string{
-			config.Network_Mainnet: "https://stake.rocketpool.net",
-			config.Network_Devnet:  "TBD",
-			config.Network_Holesky: "https://testnet.rocketpool.net",
-		},
-
-		chainID: map[config.Network]uint{
-			config.Network_Mainnet: 1,     // Mainnet
-			config.Network_Devnet:  17000, // Also Holesky
-			config.Network_Holesky: 17000, // Holesky
-		},
-
-		storageAddress: map[config.Network]string{
-			config.Network_Mainnet: "0x1d8f8f00cfa6758d7bE78336684788Fb0ee0Fa46",
-			config.Network_Devnet:  "0xf04de123993761Bb9F08c9C39112b0E0b0eccE50",
-			config.Network_Holesky: "0x594Fb75D3dc2DFa0150Ad03F99F97817747dd4E1",
-		},
-
-		rplTokenAddress: map[config.Network]string{
-			config.Network_Mainnet: "0xD33526068D116cE69F19A9ee46F0bd304F21A51f",
-			config.Network_Devnet:  "0x59A1a7AebCbF103B3C4f85261fbaC166117E1979",
-			config.Network_Holesky: "0x1Cc9cF5586522c6F483E84A19c3C2B0B6d027bF0",
-		},
-
-		rethAddress: map[config.Network]string{
-			config.Network_Mainnet: "0xae78736Cd615f374D3085123A210448E74Fc6393",
-			config.Network_Devnet:  "0x4be7161080b5d890500194cee2c40B1428002Bd3",
-			config.Network_Holesky: "0x7322c24752f79c05FFD1E2a6FCB97020C1C264F1",
-		},
-
-		v1_0_0_RewardsPoolAddress: map[config.Network]string{
-			config.Network_Mainnet: "0xA3a18348e6E2d3897B6f2671bb8c120e36554802",
-			config.Network_Devnet:  "0x4A1b5Ab9F6C36E7168dE5F994172028Ca8554e02",
-			config.Network_Holesky: "",
-		},
-
-		v1_0_0_ClaimNodeAddress: map[config.Network]string{
-			config.Network_Mainnet: "0x899336A2a86053705E65dB61f52C686dcFaeF548",
-			config.Network_Devnet:  "",
-			config.Network_Holesky: "",
-		},
-
-		v1_0_0_ClaimTrustedNodeAddress: map[config.Network]string{
-			config.Network_Mainnet: "0x6af730deB0463b432433318dC8002C0A4e9315e8",
-			config.Network_Devnet:  "",
-			config.Network_Holesky: "",
-		},
-
-		v1_0_0_MinipoolManagerAddress: map[config.Network]string{
-			config.Network_Mainnet: "0x6293B8abC1F36aFB22406Be5f96D893072A8cF3a",
-			config.Network_Devnet:  "",
-			config.Network_Holesky: "",
-		},
-
-		v1_1_0_NetworkPricesAddress: map[config.Network]string{
-			config.Network_Mainnet: "0xd3f500F550F46e504A4D2153127B47e007e11166",
-			config.Network_Devnet:  "",
-			config.Network_Holesky: "",
-		},
-
-		v1_1_0_NodeStakingAddress: map[config.Network]string{
-			config.Network_Mainnet: "0xA73ec45Fe405B5BFCdC0bF4cbc9014Bb32a01cd2",
-			config.Network_Devnet:  "",
-			config.Network_Holesky: "",
-		},
-
-		v1_1_0_NodeDepositAddress: map[config.Network]string{
-			config.Network_Mainnet: "0x1Cc9cF5586522c6F483E84A19c3C2B0B6d027bF0",
-			config.Network_Devnet:  "",
-			config.Network_Holesky: "",
-		},
-
-		v1_1_0_MinipoolQueueAddress: map[config.Network]string{
-			config.Network_Mainnet: "0x5870dA524635D1310Dc0e6F256Ce331012C9C19E",
-			config.Network_Devnet:  "",
-			config.Network_Holesky: "",
-		},
-
-		v1_1_0_MinipoolFactoryAddress: map[config.Network]string{
-			config.Network_Mainnet: "0x54705f80D7C51Fcffd9C659ce3f3C9a7dCCf5788",
-			config.Network_Devnet:  "",
-			config.Network_Holesky: "",
-		},
-
-		v1_2_0_NetworkPricesAddress: map[config.Network]string{
-			config.Network_Mainnet: "0x751826b107672360b764327631cC5764515fFC37",
-			config.Network_Devnet:  "0xBba3FBCD4Bdbfc79118B1B31218602E5A71B426c",
-			config.Network_Holesky: "0x029d946F28F93399a5b0D09c879FC8c94E596AEb",
-		},
-
-		v1_2_0_NetworkBalancesAddress: map[config.Network]string{
-			config.Network_Mainnet: "0x07FCaBCbe4ff0d80c2b1eb42855C0131b6cba2F4",
-			config.Network_Devnet:  "0xBe8Dc8CA5f339c196Aef634DfcDFbA61E30DC743",
-			config.Network_Holesky: "0x9294Fc6F03c64Cc217f5BE8697EA3Ed2De77e2F8",
-		},
-
-		snapshotDelegationAddress: map[config.Network]string{
-			config.Network_Mainnet: "0x469788fE6E9E9681C6ebF3bF78e7Fd26Fc015446",
-			config.Network_Devnet:  "",
-			config.Network_Holesky: "",
-		},
-
-		snapshotApiDomain: map[config.Network]string{
-			config.Network_Mainnet: "hub.snapshot.org",
-			config.Network_Devnet:  "",
-			config.Network_Holesky: "",
-		},
-
-		previousRewardsPoolAddresses: map[config.Network][]common.Address{
-			config.Network_Mainnet: {
-				common.HexToAddress("0x594Fb75D3dc2DFa0150Ad03F99F97817747dd4E1"),
-			},
-			config.Network_Devnet: {
-				common.HexToAddress("0x4d581a552490fb6fce5F978e66560C8b7E481818"),
-			},
-			config.Network_Holesky: {
-				common.HexToAddress("0x4a625C617a44E60F74E3fe3bf6d6333b63766e91"),
-			},
-		},
-
-		previousRocketDAOProtocolVerifier: map[config.Network][]common.Address{
-			config.Network_Mainnet: {},
-			config.Network_Devnet:  {},
-			config.Network_Holesky: {},
-		},
-
-		previousRocketNetworkPricesAddresses: map[config.Network][]common.Address{
-			config.Network_Mainnet: {
-				common.HexToAddress("0x751826b107672360b764327631cC5764515fFC37"),
-			},
-			config.Network_Devnet: {},
-			config.Network_Holesky: {
-				common.HexToAddress("0x029d946f28f93399a5b0d09c879fc8c94e596aeb"),
-			},
-		},
-
-		previousRocketNetworkBalancesAddresses: map[config.Network][]common.Address{
-			config.Network_Mainnet: {
-				common.HexToAddress("0x07FCaBCbe4ff0d80c2b1eb42855C0131b6cba2F4"),
-			},
-			config.Network_Devnet: {},
-			config.Network_Holesky: {
-				common.HexToAddress("0x9294Fc6F03c64Cc217f5BE8697EA3Ed2De77e2F8"),
-			},
-		},
-
-		optimismPriceMessengerAddress: map[config.Network]string{
-			config.Network_Mainnet: "0xdddcf2c25d50ec22e67218e873d46938650d03a7",
-			config.Network_Devnet:  "",
-			config.Network_Holesky: "",
-		},
-
-		polygonPriceMessengerAddress: map[config.Network]string{
-			config.Network_Mainnet: "0xb1029Ac2Be4e08516697093e2AFeC435057f3511",
-			config.Network_Devnet:  "",
-			config.Network_Holesky: "",
-		},
-
-		arbitrumPriceMessengerAddress: map[config.Network]string{
-			config.Network_Mainnet: "0x05330300f829AD3fC8f33838BC88CFC4093baD53",
-			config.Network_Devnet:  "",
-			config.Network_Holesky: "",
-		},
-
-		arbitrumPriceMessengerAddressV2: map[config.Network]string{
-			config.Network_Mainnet: "0x312FcFB03eC9B1Ea38CB7BFCd26ee7bC3b505aB1",
-			config.Network_Devnet:  "",
-			config.Network_Holesky: "",
-		},
-
-		zkSyncEraPriceMessengerAddress: map[config.Network]string{
-			config.Network_Mainnet: "0x6cf6CB29754aEBf88AF12089224429bD68b0b8c8",
-			config.Network_Devnet:  "",
-			config.Network_Holesky: "",
-		},
-
-		basePriceMessengerAddress: map[config.Network]string{
-			config.Network_Mainnet: "0x64A5856869C06B0188C84A5F83d712bbAc03517d",
-			config.Network_Devnet:  "",
-			config.Network_Holesky: "",
-		},
-
-		scrollPriceMessengerAddress: map[config.Network]string{
-			config.Network_Mainnet: "0x0f22dc9b9c03757d4676539203d7549c8f22c15c",
-			config.Network_Devnet:  "",
-			config.Network_Holesky: "",
-		},
-
-		scrollFeeEstimatorAddress: map[config.Network]string{
-			config.Network_Mainnet: "0x0d7E906BD9cAFa154b048cFa766Cc1E54E39AF9B",
-			config.Network_Devnet:  "",
-			config.Network_Holesky: "",
-		},
-
-		rplTwapPoolAddress: map[config.Network]string{
-			config.Network_Mainnet: "0xe42318ea3b998e8355a3da364eb9d48ec725eb45",
-			config.Network_Devnet:  "0x7bb10d2a3105ed5cc150c099a06cafe43d8aa15d",
-			config.Network_Holesky: "0x7bb10d2a3105ed5cc150c099a06cafe43d8aa15d",
-		},
-
-		multicallAddress: map[config.Network]string{
-			config.Network_Mainnet: "0x5BA1e12693Dc8F9c48aAD8770482f4739bEeD696",
-			config.Network_Devnet:  "0x0540b786f03c9491f3a2ab4b0e3ae4ecd4f63ce7",
-			config.Network_Holesky: "0x0540b786f03c9491f3a2ab4b0e3ae4ecd4f63ce7",
-		},
-
-		balancebatcherAddress: map[config.Network]string{
-			config.Network_Mainnet: "0xb1f8e55c7f64d203c1400b9d8555d050f94adf39",
-			config.Network_Devnet:  "0xfAa2e7C84eD801dd9D27Ac1ed957274530796140",
-			config.Network_Holesky: "0xfAa2e7C84eD801dd9D27Ac1ed957274530796140",
-		},
-
-		flashbotsProtectUrl: map[config.Network]string{
-			config.Network_Mainnet: "https://rpc.flashbots.net/",
-			config.Network_Devnet:  "https://rpc-holesky.flashbots.net/",
-			config.Network_Holesky: "",
-		},
 	}
 
 }
@@ -681,20 +1530,94 @@ func (cfg *SmartnodeConfig) GetParameters() []*config.Parameter {
 		&cfg.Network,
 		&cfg.ProjectName,
 		&cfg.DataPath,
+		&cfg.HardwareProfile,
 		&cfg.ManualMaxFee,
 		&cfg.PriorityFee,
 		&cfg.AutoTxGasThreshold,
 		&cfg.DistributeThreshold,
+		&cfg.ManageRplStake,
+		&cfg.RplStakeTargetRatio,
+		&cfg.RplStakeLowerBound,
+		&cfg.RplStakeUpperBound,
+		&cfg.RplStakeAutoWithdraw,
+		&cfg.RplStakeSimulationMode,
+		&cfg.AutoUpgradeDelegates,
+		&cfg.DelegateUpgradeDailyBudget,
+		&cfg.DelegateUpgradeBatchSize,
+		&cfg.RethDexQuoteUrls,
+		&cfg.RethDexRouters,
+		&cfg.PasswordSource,
+		&cfg.PasswordKeyringService,
+		&cfg.PasswordVaultAddress,
+		&cfg.PasswordVaultSecretPath,
+		&cfg.PasswordVaultField,
+		&cfg.PasswordVaultTokenPath,
+		&cfg.PasswordCommand,
+		&cfg.EncryptValidatorKeys,
+		&cfg.EnableKeymanagerApi,
+		&cfg.KeymanagerApiPort,
+		&cfg.EnableMessageSigning,
+		&cfg.PreferredGasLimit,
 		&cfg.VerifyProposals,
 		&cfg.RewardsTreeMode,
+		&cfg.OfflineRewardsGeneration,
 		&cfg.PriceBalanceSubmissionReferenceTimestamp,
 		&cfg.RewardsTreeCustomUrl,
+		&cfg.EnableIpfsPinning,
+		&cfg.IpfsApiUrl,
+		&cfg.IpfsPinningServices,
+		&cfg.IpfsVerificationGateways,
+		&cfg.IpfsPinCheckInterval,
+		&cfg.RewardsMirrorUrls,
+		&cfg.RewardsFileCacheFolder,
+		&cfg.RewardsMirrorUploadUrl,
+		&cfg.RewardsMirrorUploadAuth,
+		&cfg.GenerateRewardsTorrents,
+		&cfg.RewardsTorrentTrackers,
+		&cfg.EnableProofServer,
+		&cfg.ProofServerPort,
+		&cfg.EnableProfiling,
+		&cfg.NodeProfilingPort,
+		&cfg.WatchtowerProfilingPort,
 		&cfg.ArchiveECUrl,
+		&cfg.ArchiveECRoutingHorizon,
+		&cfg.PrivateTxRelayUrl,
+		&cfg.PrivateTxTypes,
+		&cfg.PrivateTxTimeout,
+		&cfg.ValidatorStatusChunkSize,
+		&cfg.NetworkStateCacheTTL,
+		&cfg.ClientCallRetries,
+		&cfg.ClientCircuitBreakerThreshold,
+		&cfg.NtpServers,
+		&cfg.PortCheckerUrl,
+		&cfg.EnablePortForwarding,
+		&cfg.PortForwardingMethod,
+		&cfg.PortForwardingGateway,
+		&cfg.PortForwardingHostIp,
+		&cfg.EnableIpv6,
+		&cfg.Ipv6ListenAddress,
+		&cfg.Ipv6Discovery,
+		&cfg.Ipv6AdvertisedAddress,
+		&cfg.EnableActiveHostGuard,
 		&cfg.WatchtowerMaxFeeOverride,
 		&cfg.WatchtowerPrioFeeOverride,
+		&cfg.WatchtowerBundleRelayUrl,
+		&cfg.WatchtowerBundleBlocks,
+		&cfg.WatchtowerConcurrency,
+		&cfg.WatchtowerPriority,
+		&cfg.RplPriceSecondaryTwapPool,
+		&cfg.RplPriceApiUrl,
+		&cfg.RplPriceApiJsonPath,
+		&cfg.RplPriceMaxDivergence,
 		&cfg.UseRollingRecords,
 		&cfg.RecordCheckpointInterval,
 		&cfg.CheckpointRetentionLimit,
+		&cfg.RecordCompressionCodec,
+		&cfg.RecordCompressionLevel,
+		&cfg.UseRecordCompressionDictionary,
+		&cfg.RewardsFileCompression,
+		&cfg.CatchUpProgressInterval,
+		&cfg.ReadinessCheckLeadTime,
 		&cfg.RecordsPath,
 	}
 }
@@ -702,15 +1625,18 @@ func (cfg *SmartnodeConfig) GetParameters() []*config.Parameter {
 // Getters for the non-editable parameters
 
 func (cfg *SmartnodeConfig) GetTxWatchUrl() string {
-	return cfg.txWatchUrl[cfg.Network.Value.(config.Network)]
+	return cfg.getNetworkDescriptor().TxWatchUrl
 }
 
 func (cfg *SmartnodeConfig) GetStakeUrl() string {
-	return cfg.stakeUrl[cfg.Network.Value.(config.Network)]
+	return cfg.getNetworkDescriptor().StakeUrl
 }
 
 func (cfg *SmartnodeConfig) GetChainID() uint {
-	return cfg.chainID[cfg.Network.Value.(config.Network)]
+	if cfg.parent.Devnet.IsLocalDevnet() {
+		return uint(cfg.parent.Devnet.ChainID.Value.(uint64))
+	}
+	return cfg.getNetworkDescriptor().ChainID
 }
 
 func (cfg *SmartnodeConfig) GetWalletPath() string {
@@ -721,6 +1647,38 @@ func (cfg *SmartnodeConfig) GetWalletPath() string {
 	return filepath.Join(DaemonDataPath, "wallet")
 }
 
+func (cfg *SmartnodeConfig) GetGasWalletPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), GasWalletFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, GasWalletFilename)
+}
+
+func (cfg *SmartnodeConfig) GetGasWalletPasswordPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), GasWalletPasswordFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, GasWalletPasswordFilename)
+}
+
+func (cfg *SmartnodeConfig) GetSignRequestPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), SignRequestFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, SignRequestFilename)
+}
+
+func (cfg *SmartnodeConfig) GetNodeAddressPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), NodeAddressFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, NodeAddressFilename)
+}
+
 func (cfg *SmartnodeConfig) GetPasswordPath() string {
 	if cfg.parent.IsNativeMode {
 		return filepath.Join(cfg.DataPath.Value.(string), "password")
@@ -737,6 +1695,14 @@ func (cfg *SmartnodeConfig) GetValidatorKeychainPath() string {
 	return filepath.Join(DaemonDataPath, "validators")
 }
 
+func (cfg *SmartnodeConfig) GetValidatorKeyArchivePath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), ValidatorKeyArchiveFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, ValidatorKeyArchiveFilename)
+}
+
 func (cfg *SmartnodeConfig) GetRecordsPath() string {
 	if cfg.parent.IsNativeMode {
 		return filepath.Join(cfg.DataPath.Value.(string), "records")
@@ -745,6 +1711,14 @@ func (cfg *SmartnodeConfig) GetRecordsPath() string {
 	return filepath.Join(DaemonDataPath, "records")
 }
 
+func (cfg *SmartnodeConfig) GetRecordsIntervalPath(interval uint64) string {
+	return filepath.Join(cfg.GetRecordsPath(), fmt.Sprintf("%s%d", RecordsIntervalFolderPrefix, interval))
+}
+
+func (cfg *SmartnodeConfig) GetRecordBlobsPath(interval uint64) string {
+	return filepath.Join(cfg.GetRecordsIntervalPath(interval), RecordBlobsFolder)
+}
+
 func (cfg *SmartnodeConfig) GetVotingPath() string {
 	if cfg.parent.IsNativeMode {
 		return filepath.Join(cfg.DataPath.Value.(string), "voting", string(cfg.Network.Value.(config.Network)))
@@ -765,6 +1739,10 @@ func (cfg *SmartnodeConfig) GetValidatorKeychainPathInCLI() string {
 	return filepath.Join(cfg.DataPath.Value.(string), "validators")
 }
 
+func (cfg *SmartnodeConfig) GetValidatorKeyArchivePathInCLI() string {
+	return filepath.Join(cfg.DataPath.Value.(string), ValidatorKeyArchiveFilename)
+}
+
 func (config *SmartnodeConfig) GetWatchtowerStatePath() string {
 	if config.parent.IsNativeMode {
 		return filepath.Join(config.DataPath.Value.(string), WatchtowerFolder, "state.yml")
@@ -790,15 +1768,21 @@ func (cfg *SmartnodeConfig) GetCustomKeyPasswordFilePath() string {
 }
 
 func (cfg *SmartnodeConfig) GetStorageAddress() string {
-	return cfg.storageAddress[cfg.Network.Value.(config.Network)]
+	if cfg.parent.Devnet.IsLocalDevnet() {
+		return cfg.parent.Devnet.StorageAddress.Value.(string)
+	}
+	return cfg.getNetworkDescriptor().Contracts.Storage
 }
 
 func (cfg *SmartnodeConfig) GetRplTokenAddress() string {
-	return cfg.rplTokenAddress[cfg.Network.Value.(config.Network)]
+	if cfg.parent.Devnet.IsLocalDevnet() {
+		return cfg.parent.Devnet.RplTokenAddress.Value.(string)
+	}
+	return cfg.getNetworkDescriptor().Contracts.RplToken
 }
 
 func (cfg *SmartnodeConfig) GetSnapshotDelegationAddress() string {
-	return cfg.snapshotDelegationAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getNetworkDescriptor().Contracts.SnapshotDelegation
 }
 
 func (cfg *SmartnodeConfig) GetSmartnodeContainerTag() string {
@@ -814,7 +1798,11 @@ func (cfg *SmartnodeConfig) GetEcMigratorContainerTag() string {
 }
 
 func (cfg *SmartnodeConfig) GetSnapshotApiDomain() string {
-	return cfg.snapshotApiDomain[cfg.Network.Value.(config.Network)]
+	return cfg.getNetworkDescriptor().SnapshotApiDomain
+}
+
+func (cfg *SmartnodeConfig) GetSnapshotSequencerDomain() string {
+	return cfg.getNetworkDescriptor().SnapshotSequencerDomain
 }
 
 func (cfg *SmartnodeConfig) GetVotingSnapshotID() [32]byte {
@@ -835,7 +1823,10 @@ func (cfg *SmartnodeConfig) GetConfigTitle() string {
 }
 
 func (cfg *SmartnodeConfig) GetRethAddress() common.Address {
-	return common.HexToAddress(cfg.rethAddress[cfg.Network.Value.(config.Network)])
+	if cfg.parent.Devnet.IsLocalDevnet() {
+		return common.HexToAddress(cfg.parent.Devnet.RethAddress.Value.(string))
+	}
+	return common.HexToAddress(cfg.getNetworkDescriptor().Contracts.Reth)
 }
 
 func getDefaultDataDir(config *RocketPoolConfig) string {
@@ -862,6 +1853,14 @@ func (cfg *SmartnodeConfig) GetMinipoolPerformancePath(interval uint64, daemon b
 	return filepath.Join(cfg.DataPath.Value.(string), RewardsTreesFolder, fmt.Sprintf(MinipoolPerformanceFilenameFormat, string(cfg.Network.Value.(config.Network)), interval))
 }
 
+func (cfg *SmartnodeConfig) GetRewardsSubmissionPath(interval uint64, daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, RewardsTreesFolder, fmt.Sprintf(RewardsSubmissionFilenameFormat, string(cfg.Network.Value.(config.Network)), interval))
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), RewardsTreesFolder, fmt.Sprintf(RewardsSubmissionFilenameFormat, string(cfg.Network.Value.(config.Network)), interval))
+}
+
 func (cfg *SmartnodeConfig) GetRegenerateRewardsTreeRequestPath(interval uint64, daemon bool) string {
 	if daemon && !cfg.parent.IsNativeMode {
 		return filepath.Join(DaemonDataPath, WatchtowerFolder, fmt.Sprintf(RegenerateRewardsTreeRequestFormat, interval))
@@ -878,6 +1877,190 @@ func (cfg *SmartnodeConfig) GetWatchtowerFolder(daemon bool) string {
 	return filepath.Join(cfg.DataPath.Value.(string), WatchtowerFolder)
 }
 
+func (cfg *SmartnodeConfig) GetCatchUpProgressPath(daemon bool) string {
+	return filepath.Join(cfg.GetWatchtowerFolder(daemon), CatchUpProgressFilename)
+}
+
+func (cfg *SmartnodeConfig) GetPinningStatusPath(daemon bool) string {
+	return filepath.Join(cfg.GetWatchtowerFolder(daemon), PinningStatusFilename)
+}
+
+func (cfg *SmartnodeConfig) GetSubmissionLedgerPath(daemon bool) string {
+	return filepath.Join(cfg.GetWatchtowerFolder(daemon), SubmissionLedgerFilename)
+}
+
+func (cfg *SmartnodeConfig) GetBalancesReportsFolder(daemon bool) string {
+	return filepath.Join(cfg.GetWatchtowerFolder(daemon), BalancesReportsFolder)
+}
+
+func (cfg *SmartnodeConfig) GetQueueStatsPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, QueueStatsFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), QueueStatsFilename)
+}
+
+func (cfg *SmartnodeConfig) GetRewardsLedgerPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, RewardsLedgerFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), RewardsLedgerFilename)
+}
+
+func (cfg *SmartnodeConfig) GetNotificationsStatePath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, NotificationsStateFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), NotificationsStateFilename)
+}
+
+func (cfg *SmartnodeConfig) GetSlashingProtectionImportPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, SlashingProtectionImportFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), SlashingProtectionImportFilename)
+}
+
+func (cfg *SmartnodeConfig) GetNetworkStateCachePath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, NetworkStateCacheFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), NetworkStateCacheFilename)
+}
+
+func (cfg *SmartnodeConfig) GetBackupsFolder(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, BackupsFolder)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), BackupsFolder)
+}
+
+func (cfg *SmartnodeConfig) GetProfilesFolder(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, ProfilesFolder)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), ProfilesFolder)
+}
+
+func (cfg *SmartnodeConfig) GetBackupRestorePath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, BackupRestoreFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), BackupRestoreFilename)
+}
+
+func (cfg *SmartnodeConfig) GetBackupPasswordPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, BackupPasswordFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), BackupPasswordFilename)
+}
+
+func (cfg *SmartnodeConfig) GetHostIdPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, HostIdFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), HostIdFilename)
+}
+
+func (cfg *SmartnodeConfig) GetActiveHostPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, ActiveHostFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), ActiveHostFilename)
+}
+
+func (cfg *SmartnodeConfig) GetActiveHostConflictPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, ActiveHostConflictFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), ActiveHostConflictFilename)
+}
+
+func (cfg *SmartnodeConfig) GetPortMappingsPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, PortMappingsFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), PortMappingsFilename)
+}
+
+func (cfg *SmartnodeConfig) GetExternalIpPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, ExternalIpFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), ExternalIpFilename)
+}
+
+func (cfg *SmartnodeConfig) GetMinipoolEventsStatePath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, MinipoolEventsStateFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), MinipoolEventsStateFilename)
+}
+
+func (cfg *SmartnodeConfig) GetScheduledBackupsStatePath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, ScheduledBackupsStateFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), ScheduledBackupsStateFilename)
+}
+
+func (cfg *SmartnodeConfig) GetDelegateUpgradesPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, DelegateUpgradesFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), DelegateUpgradesFilename)
+}
+
+func (cfg *SmartnodeConfig) GetKeymanagerTokenPath() string {
+	if !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, "validators", KeymanagerTokenFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), "validators", KeymanagerTokenFilename)
+}
+
+func (cfg *SmartnodeConfig) GetKeymanagerApiUrl() string {
+	if cfg.parent.IsNativeMode {
+		return fmt.Sprintf("http://localhost:%d", cfg.KeymanagerApiPort.Value)
+	}
+
+	return fmt.Sprintf("http://%s:%d", ValidatorContainerName, cfg.KeymanagerApiPort.Value)
+}
+
+func (cfg *SmartnodeConfig) GetNodeProfilingUrl() string {
+	if cfg.parent.IsNativeMode {
+		return fmt.Sprintf("http://localhost:%d", cfg.NodeProfilingPort.Value)
+	}
+
+	return fmt.Sprintf("http://%s:%d", NodeContainerName, cfg.NodeProfilingPort.Value)
+}
+
+func (cfg *SmartnodeConfig) GetWatchtowerProfilingUrl() string {
+	if cfg.parent.IsNativeMode {
+		return fmt.Sprintf("http://localhost:%d", cfg.WatchtowerProfilingPort.Value)
+	}
+
+	return fmt.Sprintf("http://%s:%d", WatchtowerContainerName, cfg.WatchtowerProfilingPort.Value)
+}
+
 func (cfg *SmartnodeConfig) GetFeeRecipientFilePath() string {
 	if !cfg.parent.IsNativeMode {
 		return filepath.Join(DaemonDataPath, "validators", FeeRecipientFilename)
@@ -887,132 +2070,143 @@ func (cfg *SmartnodeConfig) GetFeeRecipientFilePath() string {
 }
 
 func (cfg *SmartnodeConfig) GetV100RewardsPoolAddress() common.Address {
-	return common.HexToAddress(cfg.v1_0_0_RewardsPoolAddress[cfg.Network.Value.(config.Network)])
+	return common.HexToAddress(cfg.getNetworkDescriptor().Contracts.V100RewardsPool)
 }
 
 func (cfg *SmartnodeConfig) GetV100ClaimNodeAddress() common.Address {
-	return common.HexToAddress(cfg.v1_0_0_ClaimNodeAddress[cfg.Network.Value.(config.Network)])
+	return common.HexToAddress(cfg.getNetworkDescriptor().Contracts.V100ClaimNode)
 }
 
 func (cfg *SmartnodeConfig) GetV100ClaimTrustedNodeAddress() common.Address {
-	return common.HexToAddress(cfg.v1_0_0_ClaimTrustedNodeAddress[cfg.Network.Value.(config.Network)])
+	return common.HexToAddress(cfg.getNetworkDescriptor().Contracts.V100ClaimTrustedNode)
 }
 
 func (cfg *SmartnodeConfig) GetV100MinipoolManagerAddress() common.Address {
-	return common.HexToAddress(cfg.v1_0_0_MinipoolManagerAddress[cfg.Network.Value.(config.Network)])
+	return common.HexToAddress(cfg.getNetworkDescriptor().Contracts.V100MinipoolManager)
 }
 
 func (cfg *SmartnodeConfig) GetV110NetworkPricesAddress() common.Address {
-	return common.HexToAddress(cfg.v1_1_0_NetworkPricesAddress[cfg.Network.Value.(config.Network)])
+	return common.HexToAddress(cfg.getNetworkDescriptor().Contracts.V110NetworkPrices)
 }
 
 func (cfg *SmartnodeConfig) GetV120NetworkPricesAddress() common.Address {
-	return common.HexToAddress(cfg.v1_2_0_NetworkPricesAddress[cfg.Network.Value.(config.Network)])
+	return common.HexToAddress(cfg.getNetworkDescriptor().Contracts.V120NetworkPrices)
 }
 
 func (cfg *SmartnodeConfig) GetV120NetworkBalancesAddress() common.Address {
-	return common.HexToAddress(cfg.v1_2_0_NetworkBalancesAddress[cfg.Network.Value.(config.Network)])
+	return common.HexToAddress(cfg.getNetworkDescriptor().Contracts.V120NetworkBalances)
 }
 
 func (cfg *SmartnodeConfig) GetV110NodeStakingAddress() common.Address {
-	return common.HexToAddress(cfg.v1_1_0_NodeStakingAddress[cfg.Network.Value.(config.Network)])
+	return common.HexToAddress(cfg.getNetworkDescriptor().Contracts.V110NodeStaking)
 }
 
 func (cfg *SmartnodeConfig) GetV110NodeDepositAddress() common.Address {
-	return common.HexToAddress(cfg.v1_1_0_NodeDepositAddress[cfg.Network.Value.(config.Network)])
+	return common.HexToAddress(cfg.getNetworkDescriptor().Contracts.V110NodeDeposit)
 }
 
 func (cfg *SmartnodeConfig) GetV110MinipoolQueueAddress() common.Address {
-	return common.HexToAddress(cfg.v1_1_0_MinipoolQueueAddress[cfg.Network.Value.(config.Network)])
+	return common.HexToAddress(cfg.getNetworkDescriptor().Contracts.V110MinipoolQueue)
 }
 
 func (cfg *SmartnodeConfig) GetV110MinipoolFactoryAddress() common.Address {
-	return common.HexToAddress(cfg.v1_1_0_MinipoolFactoryAddress[cfg.Network.Value.(config.Network)])
+	return common.HexToAddress(cfg.getNetworkDescriptor().Contracts.V110MinipoolFactory)
 }
 
 func (cfg *SmartnodeConfig) GetPreviousRewardsPoolAddresses() []common.Address {
-	return cfg.previousRewardsPoolAddresses[cfg.Network.Value.(config.Network)]
+	if cfg.parent.Devnet.IsLocalDevnet() {
+		return []common.Address{}
+	}
+	return toAddresses(cfg.getNetworkDescriptor().Contracts.PreviousRewardsPools)
 }
 
 func (cfg *SmartnodeConfig) GetPreviousRocketDAOProtocolVerifierAddresses() []common.Address {
-	return cfg.previousRocketDAOProtocolVerifier[cfg.Network.Value.(config.Network)]
+	return toAddresses(cfg.getNetworkDescriptor().Contracts.PreviousDAOProtocolVerifier)
 }
 
 func (cfg *SmartnodeConfig) GetPreviousRocketNetworkPricesAddresses() []common.Address {
-	return cfg.previousRocketNetworkPricesAddresses[cfg.Network.Value.(config.Network)]
+	return toAddresses(cfg.getNetworkDescriptor().Contracts.PreviousNetworkPrices)
 }
 
 func (cfg *SmartnodeConfig) GetPreviousRocketNetworkBalancesAddresses() []common.Address {
-	return cfg.previousRocketNetworkBalancesAddresses[cfg.Network.Value.(config.Network)]
+	return toAddresses(cfg.getNetworkDescriptor().Contracts.PreviousNetworkBalances)
 }
 func (cfg *SmartnodeConfig) GetOptimismMessengerAddress() string {
-	return cfg.optimismPriceMessengerAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getNetworkDescriptor().Contracts.OptimismPriceMessenger
 }
 
 func (cfg *SmartnodeConfig) GetPolygonMessengerAddress() string {
-	return cfg.polygonPriceMessengerAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getNetworkDescriptor().Contracts.PolygonPriceMessenger
 }
 
 func (cfg *SmartnodeConfig) GetArbitrumMessengerAddress() string {
-	return cfg.arbitrumPriceMessengerAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getNetworkDescriptor().Contracts.ArbitrumPriceMessenger
 }
 
 func (cfg *SmartnodeConfig) GetArbitrumMessengerAddressV2() string {
-	return cfg.arbitrumPriceMessengerAddressV2[cfg.Network.Value.(config.Network)]
+	return cfg.getNetworkDescriptor().Contracts.ArbitrumPriceMessengerV2
 }
 
 func (cfg *SmartnodeConfig) GetZkSyncEraMessengerAddress() string {
-	return cfg.zkSyncEraPriceMessengerAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getNetworkDescriptor().Contracts.ZkSyncEraPriceMessenger
 }
 
 func (cfg *SmartnodeConfig) GetBaseMessengerAddress() string {
-	return cfg.basePriceMessengerAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getNetworkDescriptor().Contracts.BasePriceMessenger
 }
 
 func (cfg *SmartnodeConfig) GetScrollMessengerAddress() string {
-	return cfg.scrollPriceMessengerAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getNetworkDescriptor().Contracts.ScrollPriceMessenger
 }
 
 func (cfg *SmartnodeConfig) GetScrollFeeEstimatorAddress() string {
-	return cfg.scrollFeeEstimatorAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getNetworkDescriptor().Contracts.ScrollFeeEstimator
 }
 
 func (cfg *SmartnodeConfig) GetRplTwapPoolAddress() string {
-	return cfg.rplTwapPoolAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getNetworkDescriptor().Contracts.RplTwapPool
 }
 
 func (cfg *SmartnodeConfig) GetMulticallAddress() string {
-	return cfg.multicallAddress[cfg.Network.Value.(config.Network)]
+	if cfg.parent.Devnet.IsLocalDevnet() {
+		return cfg.parent.Devnet.MulticallAddress.Value.(string)
+	}
+	return cfg.getNetworkDescriptor().Contracts.Multicall
 }
 
 func (cfg *SmartnodeConfig) GetBalanceBatcherAddress() string {
-	return cfg.balancebatcherAddress[cfg.Network.Value.(config.Network)]
+	if cfg.parent.Devnet.IsLocalDevnet() {
+		return cfg.parent.Devnet.BalanceBatcherAddress.Value.(string)
+	}
+	return cfg.getNetworkDescriptor().Contracts.BalanceBatcher
 }
 
 func (cfg *SmartnodeConfig) GetFlashbotsProtectUrl() string {
-	return cfg.flashbotsProtectUrl[cfg.Network.Value.(config.Network)]
+	return cfg.getNetworkDescriptor().FlashbotsProtectUrl
 }
 
-func getNetworkOptions() []config.ParameterOption {
-	options := []config.ParameterOption{
-		{
-			Name:        "Ethereum Mainnet",
-			Description: "This is the real Ethereum main network, using real ETH and real RPL to make real validators.",
-			Value:       config.Network_Mainnet,
-		}, {
-			Name:        "Holesky Testnet",
-			Description: "This is the Holešky (Holešovice) test network, which is the next generation of long-lived testnets for Ethereum. It uses free fake ETH and free fake RPL to make fake validators.\nUse this if you want to practice running the Smartnode in a free, safe environment before moving to Mainnet.",
-			Value:       config.Network_Holesky,
-		},
+// Get the descriptor for the selected network, or an empty one if the network isn't known
+func (cfg *SmartnodeConfig) getNetworkDescriptor() *NetworkDescriptor {
+	descriptor := cfg.parent.GetNetworkDescriptor(cfg.Network.Value.(config.Network))
+	if descriptor == nil {
+		return &NetworkDescriptor{}
 	}
+	return descriptor
+}
 
-	if strings.HasSuffix(shared.RocketPoolVersion, "-dev") {
+// Get the network selector's options from the network descriptors
+func getNetworkOptions(cfg *RocketPoolConfig) []config.ParameterOption {
+	isDevBuild := strings.HasSuffix(shared.RocketPoolVersion, "-dev")
+	options := []config.ParameterOption{}
+	for _, descriptor := range cfg.GetNetworkDescriptors() {
+		if descriptor.DevOnly && !isDevBuild {
+			continue
+		}
 		options = append(options, config.ParameterOption{
-			Name:        "Devnet",
-			Description: "This is a development network used by Rocket Pool engineers to test new features and contract upgrades before they are promoted to a Testnet for staging. You should not use this network unless invited to do so by the developers.",
-			Value:       config.Network_Devnet,
+			Name:        descriptor.Name,
+			Description: descriptor.Description,
+			Value:       descriptor.Network,
 		})
 	}
-
 	return options
 }
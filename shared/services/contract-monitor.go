@@ -0,0 +1,135 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/hashicorp/go-version"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The network contracts the daemons depend on, which are checked for upgrades
+var monitoredContracts = []string{
+	"rocketDAONodeTrusted",
+	"rocketDAONodeTrustedSettingsMinipool",
+	"rocketDAOProtocolProposal",
+	"rocketDAOProtocolSettingsMinipool",
+	"rocketDAOProtocolSettingsNetwork",
+	"rocketDAOProtocolSettingsNode",
+	"rocketDAOProtocolVerifier",
+	"rocketDepositPool",
+	"rocketMerkleDistributorMainnet",
+	"rocketMinipoolBondReducer",
+	"rocketMinipoolManager",
+	"rocketMinipoolQueue",
+	"rocketNetworkBalances",
+	"rocketNetworkFees",
+	"rocketNetworkPenalties",
+	"rocketNetworkPrices",
+	"rocketNodeDeposit",
+	"rocketNodeDistributorFactory",
+	"rocketNodeManager",
+	"rocketNodeStaking",
+	"rocketRewardsPool",
+	"rocketSmoothingPool",
+	"rocketTokenRETH",
+	"rocketTokenRPL",
+}
+
+// Watches RocketStorage for upgrades to the network contracts, so the daemons pick up the new contracts without a restart
+type ContractUpgradeMonitor struct {
+	cfg             *config.RocketPoolConfig
+	rp              *rocketpool.RocketPool
+	log             *log.ColorLogger
+	addresses       map[string]common.Address
+	protocolVersion *version.Version
+}
+
+// Create a new contract upgrade monitor for the daemon's Rocket Pool binding
+func NewContractUpgradeMonitor(c *cli.Context, logger *log.ColorLogger) (*ContractUpgradeMonitor, error) {
+	cfg, err := GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ContractUpgradeMonitor{
+		cfg: cfg,
+		rp:  rp,
+		log: logger,
+	}, nil
+}
+
+// Check the monitored contracts' addresses in RocketStorage against the ones seen last time.
+// Upgraded contracts are reloaded into the binding's cache immediately, the protocol version is checked again, and the operator is alerted.
+// Returns true if any contract was upgraded.
+func (m *ContractUpgradeMonitor) Check() (bool, error) {
+	// Passing call options skips the binding's cache, so these always come straight from RocketStorage
+	opts := &bind.CallOpts{}
+	addresses, err := m.rp.GetAddresses(opts, monitoredContracts...)
+	if err != nil {
+		return false, fmt.Errorf("error getting network contract addresses: %w", err)
+	}
+
+	// The first check just records the current contracts
+	if m.addresses == nil {
+		m.addresses = map[string]common.Address{}
+		for i, name := range monitoredContracts {
+			m.addresses[name] = *addresses[i]
+		}
+		m.protocolVersion, err = utils.GetCurrentVersion(m.rp, nil)
+		if err != nil {
+			return false, fmt.Errorf("error getting protocol version: %w", err)
+		}
+		return false, nil
+	}
+
+	upgraded := []string{}
+	for i, name := range monitoredContracts {
+		newAddress := *addresses[i]
+		oldAddress := m.addresses[name]
+		if newAddress == oldAddress {
+			continue
+		}
+		m.log.Printlnf("Contract %s was upgraded from %s to %s.", name, oldAddress.Hex(), newAddress.Hex())
+		m.addresses[name] = newAddress
+		upgraded = append(upgraded, name)
+
+		// An address of zero means the contract was removed, so there's nothing to reload
+		if newAddress == (common.Address{}) {
+			continue
+		}
+		_, err := m.rp.GetContract(name, opts)
+		if err != nil {
+			m.log.Printlnf("WARNING: couldn't reload contract %s: %s", name, err.Error())
+		}
+	}
+	if len(upgraded) == 0 {
+		return false, nil
+	}
+
+	// Check if the upgrade changed the protocol version, since some features depend on it
+	newVersion, err := utils.GetCurrentVersion(m.rp, opts)
+	if err != nil {
+		m.log.Printlnf("WARNING: couldn't check the protocol version after the upgrade: %s", err.Error())
+	} else if m.protocolVersion == nil || !newVersion.Equal(m.protocolVersion) {
+		m.log.Printlnf("The Rocket Pool protocol version is now %s.", newVersion.String())
+		m.protocolVersion = newVersion
+	}
+
+	err = alerting.AlertContractsUpgraded(m.cfg, upgraded)
+	if err != nil {
+		m.log.Printlnf("WARNING: couldn't send the contract upgrade alert: %s", err.Error())
+	}
+	return true, nil
+}
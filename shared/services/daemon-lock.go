@@ -0,0 +1,47 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// Lock the folders a daemon writes to, so a second instance of the same daemon (e.g. from starting the stack twice) fails fast
+// instead of interleaving its writes with the first one. Other daemons use their own lock files, so they can share the folders.
+// Returns a function that releases the locks.
+func LockDaemonFolders(daemonName string, folders ...string) (func(), error) {
+	locks := []*files.Lock{}
+	release := func() {
+		for _, lock := range locks {
+			lock.Release()
+		}
+	}
+
+	for _, folder := range folders {
+		err := os.MkdirAll(folder, 0755)
+		if err != nil {
+			release()
+			return nil, fmt.Errorf("error creating %s: %w", folder, err)
+		}
+		lock, err := files.AcquireLock(GetDaemonLockPath(daemonName, folder))
+		if errors.Is(err, files.ErrLocked) {
+			release()
+			return nil, fmt.Errorf("another %s daemon is already using %s (%w); stop it before starting this one", daemonName, folder, err)
+		}
+		if err != nil {
+			release()
+			return nil, err
+		}
+		locks = append(locks, lock)
+	}
+
+	return release, nil
+}
+
+// Get the path of the lock file a daemon holds in one of its folders, so other processes can check if the daemon is using it
+func GetDaemonLockPath(daemonName string, folder string) string {
+	return filepath.Join(folder, fmt.Sprintf(".%s.lock", daemonName))
+}
@@ -0,0 +1,138 @@
+package delegates
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// How far back upgrade costs count against the daily gas budget
+const BudgetWindow time.Duration = 24 * time.Hour
+
+// A delegate upgrade the campaign submitted
+type Upgrade struct {
+	Minipool     common.Address `json:"minipool"`
+	FromDelegate common.Address `json:"fromDelegate"`
+	ToDelegate   common.Address `json:"toDelegate"`
+	TxHash       common.Hash    `json:"txHash"`
+	Cost         *big.Int       `json:"cost"`
+	Time         time.Time      `json:"time"`
+}
+
+// The node's delegate upgrade campaign: which minipools the node operator opted into automatic upgrades, and what's been upgraded so far
+type Campaign struct {
+	AutoUpgrade map[common.Address]bool `json:"autoUpgrade"`
+	Upgrades    []Upgrade               `json:"upgrades"`
+}
+
+// A minipool that can be upgraded, along with what it would cost
+type Candidate struct {
+	Minipool common.Address
+	Delegate common.Address
+	Gas      uint64
+	Cost     *big.Int
+}
+
+// Create an empty campaign
+func NewCampaign() *Campaign {
+	return &Campaign{
+		AutoUpgrade: map[common.Address]bool{},
+		Upgrades:    []Upgrade{},
+	}
+}
+
+// Load the campaign from disk, returning an empty one if it hasn't been saved yet
+func LoadCampaign(path string) (*Campaign, error) {
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return NewCampaign(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading delegate upgrade campaign from [%s]: %w", path, err)
+	}
+
+	campaign := NewCampaign()
+	err = json.Unmarshal(bytes, campaign)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing delegate upgrade campaign from [%s]: %w", path, err)
+	}
+	if campaign.AutoUpgrade == nil {
+		campaign.AutoUpgrade = map[common.Address]bool{}
+	}
+	return campaign, nil
+}
+
+// Save the campaign to disk
+func (c *Campaign) Save(path string) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating delegate upgrade campaign folder: %w", err)
+	}
+	bytes, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("error serializing delegate upgrade campaign: %w", err)
+	}
+	err = files.WriteFileAtomic(path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error saving delegate upgrade campaign to [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Get the total cost of the upgrades submitted since the given time
+func (c *Campaign) GetSpentSince(since time.Time) *big.Int {
+	spent := big.NewInt(0)
+	for _, upgrade := range c.Upgrades {
+		if upgrade.Time.After(since) && upgrade.Cost != nil {
+			spent.Add(spent, upgrade.Cost)
+		}
+	}
+	return spent
+}
+
+// Get the most recent upgrade submitted for a minipool, or nil if there hasn't been one
+func (c *Campaign) GetLastUpgrade(minipool common.Address) *Upgrade {
+	for i := len(c.Upgrades) - 1; i >= 0; i-- {
+		if c.Upgrades[i].Minipool == minipool {
+			return &c.Upgrades[i]
+		}
+	}
+	return nil
+}
+
+// Pick the next batch of upgrades: the cheapest candidates first, up to maxCount of them, without going over the remaining budget.
+// A nil budget means there's no limit on cost.
+func PlanBatch(candidates []Candidate, remainingBudget *big.Int, maxCount int) []Candidate {
+	sorted := make([]Candidate, len(candidates))
+	copy(sorted, candidates)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Cost.Cmp(sorted[j].Cost) < 0
+	})
+
+	batch := []Candidate{}
+	var remaining *big.Int
+	if remainingBudget != nil {
+		remaining = new(big.Int).Set(remainingBudget)
+	}
+	for _, candidate := range sorted {
+		if maxCount > 0 && len(batch) >= maxCount {
+			break
+		}
+		if remaining != nil {
+			if candidate.Cost.Cmp(remaining) > 0 {
+				break
+			}
+			remaining.Sub(remaining, candidate.Cost)
+		}
+		batch = append(batch, candidate)
+	}
+	return batch
+}
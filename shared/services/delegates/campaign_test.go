@@ -0,0 +1,52 @@
+package delegates
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestPlanBatch(t *testing.T) {
+	candidates := []Candidate{
+		{Minipool: common.HexToAddress("0x01"), Cost: big.NewInt(30)},
+		{Minipool: common.HexToAddress("0x02"), Cost: big.NewInt(10)},
+		{Minipool: common.HexToAddress("0x03"), Cost: big.NewInt(20)},
+	}
+
+	// The cheapest go first, and the batch stops at the first one that doesn't fit
+	batch := PlanBatch(candidates, big.NewInt(35), 0)
+	if len(batch) != 2 || batch[0].Minipool != common.HexToAddress("0x02") || batch[1].Minipool != common.HexToAddress("0x03") {
+		t.Fatalf("unexpected batch for a budget of 35: %v", batch)
+	}
+
+	// The count limit applies on top of the budget
+	if batch := PlanBatch(candidates, nil, 1); len(batch) != 1 {
+		t.Fatalf("expected 1 upgrade with a batch size of 1, got %d", len(batch))
+	}
+
+	// No budget means no limit
+	if batch := PlanBatch(candidates, nil, 0); len(batch) != 3 {
+		t.Fatalf("expected every upgrade without limits, got %d", len(batch))
+	}
+
+	// An exhausted budget means nothing is upgraded
+	if batch := PlanBatch(candidates, big.NewInt(0), 0); len(batch) != 0 {
+		t.Fatalf("expected no upgrades with an empty budget, got %d", len(batch))
+	}
+}
+
+func TestGetSpentSince(t *testing.T) {
+	now := time.Now()
+	campaign := NewCampaign()
+	campaign.Upgrades = []Upgrade{
+		{Cost: big.NewInt(5), Time: now.Add(-48 * time.Hour)},
+		{Cost: big.NewInt(7), Time: now.Add(-time.Hour)},
+		{Cost: big.NewInt(11), Time: now},
+	}
+	spent := campaign.GetSpentSince(now.Add(-BudgetWindow))
+	if spent.Cmp(big.NewInt(18)) != 0 {
+		t.Fatalf("expected 18 spent in the window, got %s", spent)
+	}
+}
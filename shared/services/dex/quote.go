@@ -0,0 +1,154 @@
+package dex
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// The placeholder address DEX aggregators use for native ETH
+	NativeEthAddress string = "0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"
+
+	// Placeholders that are replaced in each quote URL
+	SellTokenPlaceholder  string = "{sellToken}"
+	BuyTokenPlaceholder   string = "{buyToken}"
+	SellAmountPlaceholder string = "{sellAmount}"
+	TakerPlaceholder      string = "{taker}"
+
+	quoteTimeout time.Duration = 15 * time.Second
+)
+
+// A quote from a DEX aggregator, including the transaction that executes the swap
+type Quote struct {
+	Source          string         `json:"source"`
+	BuyAmount       *big.Int       `json:"buyAmount"`
+	Gas             uint64         `json:"gas"`
+	To              common.Address `json:"to"`
+	Data            hexutil.Bytes  `json:"data"`
+	Value           *big.Int       `json:"value"`
+	AllowanceTarget common.Address `json:"allowanceTarget"`
+}
+
+// The subset of a 0x-compatible swap quote response that the Smartnode uses
+type quoteResponse struct {
+	BuyAmount       string `json:"buyAmount"`
+	Gas             string `json:"gas"`
+	EstimatedGas    string `json:"estimatedGas"`
+	To              string `json:"to"`
+	Data            string `json:"data"`
+	Value           string `json:"value"`
+	AllowanceTarget string `json:"allowanceTarget"`
+	Reason          string `json:"reason"`
+}
+
+// Parse a comma-separated list of quote URLs from the config
+func ParseQuoteUrls(setting string) []string {
+	urls := []string{}
+	for _, quoteUrl := range strings.Split(setting, ",") {
+		quoteUrl = strings.TrimSpace(quoteUrl)
+		if quoteUrl != "" {
+			urls = append(urls, quoteUrl)
+		}
+	}
+	return urls
+}
+
+// Get a swap quote from a 0x-compatible endpoint. The URL can use the {sellToken}, {buyToken}, {sellAmount}, and {taker} placeholders.
+func GetQuote(quoteUrl string, sellToken common.Address, buyToken common.Address, sellAmount *big.Int, taker common.Address) (Quote, error) {
+
+	// Build the request URL
+	replacer := strings.NewReplacer(
+		SellTokenPlaceholder, sellToken.Hex(),
+		BuyTokenPlaceholder, buyToken.Hex(),
+		SellAmountPlaceholder, sellAmount.String(),
+		TakerPlaceholder, taker.Hex(),
+	)
+	requestUrl := replacer.Replace(quoteUrl)
+	parsedUrl, err := url.Parse(requestUrl)
+	if err != nil {
+		return Quote{}, fmt.Errorf("invalid quote URL [%s]: %w", quoteUrl, err)
+	}
+	quote := Quote{
+		Source: parsedUrl.Host,
+	}
+
+	// Send the request
+	client := http.Client{
+		Timeout: quoteTimeout,
+	}
+	response, err := client.Get(requestUrl)
+	if err != nil {
+		return quote, fmt.Errorf("error requesting quote from %s: %w", quote.Source, err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return quote, fmt.Errorf("error reading quote from %s: %w", quote.Source, err)
+	}
+
+	// Deserialize the response
+	var qResponse quoteResponse
+	if err := json.Unmarshal(body, &qResponse); err != nil {
+		return quote, fmt.Errorf("error decoding quote from %s: %w", quote.Source, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		if qResponse.Reason != "" {
+			return quote, fmt.Errorf("quote request to %s failed with code %d: %s", quote.Source, response.StatusCode, qResponse.Reason)
+		}
+		return quote, fmt.Errorf("quote request to %s failed with code %d", quote.Source, response.StatusCode)
+	}
+
+	// Parse the values
+	var ok bool
+	quote.BuyAmount, ok = big.NewInt(0).SetString(qResponse.BuyAmount, 10)
+	if !ok {
+		return quote, fmt.Errorf("quote from %s had an invalid buy amount [%s]", quote.Source, qResponse.BuyAmount)
+	}
+	gas := qResponse.Gas
+	if gas == "" {
+		gas = qResponse.EstimatedGas
+	}
+	if gas != "" {
+		quote.Gas, err = strconv.ParseUint(gas, 10, 64)
+		if err != nil {
+			return quote, fmt.Errorf("quote from %s had an invalid gas estimate [%s]: %w", quote.Source, gas, err)
+		}
+	}
+	quote.Value = big.NewInt(0)
+	if qResponse.Value != "" {
+		quote.Value, ok = quote.Value.SetString(qResponse.Value, 10)
+		if !ok {
+			return quote, fmt.Errorf("quote from %s had an invalid value [%s]", quote.Source, qResponse.Value)
+		}
+	}
+	if qResponse.To != "" {
+		if !common.IsHexAddress(qResponse.To) {
+			return quote, fmt.Errorf("quote from %s had an invalid target address [%s]", quote.Source, qResponse.To)
+		}
+		quote.To = common.HexToAddress(qResponse.To)
+	}
+	if qResponse.AllowanceTarget != "" && common.IsHexAddress(qResponse.AllowanceTarget) {
+		quote.AllowanceTarget = common.HexToAddress(qResponse.AllowanceTarget)
+	}
+	if qResponse.Data != "" {
+		quote.Data, err = hexutil.Decode(qResponse.Data)
+		if err != nil {
+			return quote, fmt.Errorf("quote from %s had invalid transaction data: %w", quote.Source, err)
+		}
+	}
+
+	return quote, nil
+
+}
@@ -0,0 +1,116 @@
+package dex
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The well-known aggregator routers that swaps are allowed to be sent to, by network
+var knownRouters = map[cfgtypes.Network]map[common.Address]string{
+	cfgtypes.Network_Mainnet: {
+		common.HexToAddress("0xDef1C0ded9bec7F1a1670819833240f027b25EfF"): "0x Exchange Proxy",
+		common.HexToAddress("0x1111111254EEB25477B68fb85Ed929f73A960582"): "1inch Aggregation Router v5",
+		common.HexToAddress("0x111111125421cA6dc452d289314280a0f8842A65"): "1inch Aggregation Router v6",
+		common.HexToAddress("0xDEF171Fe48CF0115B1d80b88dc8eAB59176FEe57"): "ParaSwap Augustus v5",
+		common.HexToAddress("0x6A000F20005980200259B80c5102003040001068"): "ParaSwap Augustus v6.2",
+	},
+}
+
+// The swap functions of the known routers, used to show what a quote's transaction will do before it's signed
+const routerAbi string = `[
+	{"name":"sellEthForTokenToUniswapV3","type":"function","stateMutability":"payable","inputs":[{"name":"encodedPath","type":"bytes"},{"name":"minBuyAmount","type":"uint256"},{"name":"recipient","type":"address"}],"outputs":[{"name":"buyAmount","type":"uint256"}]},
+	{"name":"sellToUniswap","type":"function","stateMutability":"payable","inputs":[{"name":"tokens","type":"address[]"},{"name":"sellAmount","type":"uint256"},{"name":"minBuyAmount","type":"uint256"},{"name":"isSushi","type":"bool"}],"outputs":[{"name":"buyAmount","type":"uint256"}]},
+	{"name":"sellToLiquidityProvider","type":"function","stateMutability":"payable","inputs":[{"name":"inputToken","type":"address"},{"name":"outputToken","type":"address"},{"name":"provider","type":"address"},{"name":"recipient","type":"address"},{"name":"sellAmount","type":"uint256"},{"name":"minBuyAmount","type":"uint256"},{"name":"auxiliaryData","type":"bytes"}],"outputs":[{"name":"boughtAmount","type":"uint256"}]},
+	{"name":"transformERC20","type":"function","stateMutability":"payable","inputs":[{"name":"inputToken","type":"address"},{"name":"outputToken","type":"address"},{"name":"inputTokenAmount","type":"uint256"},{"name":"minOutputTokenAmount","type":"uint256"},{"name":"transformations","type":"tuple[]","components":[{"name":"deploymentNonce","type":"uint32"},{"name":"data","type":"bytes"}]}],"outputs":[{"name":"outputTokenAmount","type":"uint256"}]},
+	{"name":"multiplexBatchSellEthForToken","type":"function","stateMutability":"payable","inputs":[{"name":"outputToken","type":"address"},{"name":"calls","type":"tuple[]","components":[{"name":"id","type":"uint8"},{"name":"sellAmount","type":"uint256"},{"name":"data","type":"bytes"}]},{"name":"minBuyAmount","type":"uint256"}],"outputs":[{"name":"boughtAmount","type":"uint256"}]},
+	{"name":"multiplexMultiHopSellEthForToken","type":"function","stateMutability":"payable","inputs":[{"name":"tokens","type":"address[]"},{"name":"calls","type":"tuple[]","components":[{"name":"id","type":"uint8"},{"name":"data","type":"bytes"}]},{"name":"minBuyAmount","type":"uint256"}],"outputs":[{"name":"boughtAmount","type":"uint256"}]},
+	{"name":"swap","type":"function","stateMutability":"payable","inputs":[{"name":"executor","type":"address"},{"name":"desc","type":"tuple","components":[{"name":"srcToken","type":"address"},{"name":"dstToken","type":"address"},{"name":"srcReceiver","type":"address"},{"name":"dstReceiver","type":"address"},{"name":"amount","type":"uint256"},{"name":"minReturnAmount","type":"uint256"},{"name":"flags","type":"uint256"}]},{"name":"permit","type":"bytes"},{"name":"data","type":"bytes"}],"outputs":[{"name":"returnAmount","type":"uint256"},{"name":"spentAmount","type":"uint256"}]},
+	{"name":"ethUnoswap","type":"function","stateMutability":"payable","inputs":[{"name":"minReturn","type":"uint256"},{"name":"dex","type":"uint256"}],"outputs":[{"name":"returnAmount","type":"uint256"}]},
+	{"name":"uniswapV3Swap","type":"function","stateMutability":"payable","inputs":[{"name":"amount","type":"uint256"},{"name":"minReturn","type":"uint256"},{"name":"pools","type":"uint256[]"}],"outputs":[{"name":"returnAmount","type":"uint256"}]}
+]`
+
+// The parsed router ABI
+var parsedRouterAbi abi.ABI
+
+func init() {
+	var err error
+	parsedRouterAbi, err = abi.JSON(strings.NewReader(routerAbi))
+	if err != nil {
+		panic(fmt.Sprintf("error parsing DEX router ABI: %s", err.Error()))
+	}
+}
+
+// Get the routers that swaps are allowed to be sent to on the network, including any extra ones from the comma-separated setting
+func GetAllowedRouters(network cfgtypes.Network, extraRouters string) (map[common.Address]string, error) {
+	routers := map[common.Address]string{}
+	for address, name := range knownRouters[network] {
+		routers[address] = name
+	}
+	for _, router := range strings.Split(extraRouters, ",") {
+		router = strings.TrimSpace(router)
+		if router == "" {
+			continue
+		}
+		if !common.IsHexAddress(router) {
+			return nil, fmt.Errorf("invalid DEX router address [%s]", router)
+		}
+		address := common.HexToAddress(router)
+		if _, exists := routers[address]; !exists {
+			routers[address] = "Custom router"
+		}
+	}
+	return routers, nil
+}
+
+// Decode a swap transaction's calldata so it can be shown before it's signed.
+// If the function isn't one of the known router functions, only its selector and raw arguments are included.
+func DecodeCall(data []byte) api.DecodedCall {
+	call := api.DecodedCall{
+		Arguments: []api.DecodedArgument{},
+	}
+	if len(data) < 4 {
+		return call
+	}
+	call.Selector = "0x" + hex.EncodeToString(data[:4])
+	method, err := parsedRouterAbi.MethodById(data[:4])
+	if err == nil {
+		values, err := method.Inputs.Unpack(data[4:])
+		if err == nil {
+			call.Function = method.Sig
+			for i, input := range method.Inputs {
+				value := fmt.Sprintf("%v", values[i])
+				if bytes, ok := values[i].([]byte); ok {
+					value = "0x" + hex.EncodeToString(bytes)
+				}
+				call.Arguments = append(call.Arguments, api.DecodedArgument{
+					Name:  input.Name,
+					Type:  input.Type.String(),
+					Value: value,
+				})
+			}
+			return call
+		}
+	}
+
+	// Fall back to the raw 32-byte words
+	args := data[4:]
+	for i := 0; i < len(args); i += 32 {
+		end := i + 32
+		if end > len(args) {
+			end = len(args)
+		}
+		call.Arguments = append(call.Arguments, api.DecodedArgument{
+			Name:  fmt.Sprintf("word %d", i/32),
+			Type:  "bytes32",
+			Value: "0x" + hex.EncodeToString(args[i:end]),
+		})
+	}
+	return call
+}
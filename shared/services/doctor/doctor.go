@@ -0,0 +1,458 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/shirou/gopsutil/v3/disk"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/clock"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/hardware"
+	rpsvc "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Settings
+const (
+	// The relay endpoint that returns a validator's latest registration
+	validatorRegistrationPath string = "/relay/v1/data/validator_registration"
+
+	// Below these, a client has too few peers to reliably follow the chain
+	minExecutionPeers uint64 = 5
+	minBeaconPeers    uint64 = 15
+
+	// The disk usage that stops the clients from writing, regardless of the alert threshold
+	criticalDiskUsagePercent float64 = 97
+
+	requestTimeout time.Duration = 10 * time.Second
+)
+
+// The services the checks use
+type Environment struct {
+	Cfg    *config.RocketPoolConfig
+	Ec     *services.ExecutionClientManager
+	Bc     *services.BeaconClientManager
+	Rp     *rocketpool.RocketPool
+	Wallet *wallet.Wallet
+
+	// The node account and whether it's registered, which the checks that depend on the node's on-chain state need
+	nodeAccount   *accounts.Account
+	registered    bool
+	registeredErr error
+	once          sync.Once
+}
+
+// A diagnostic that looks for one kind of problem, which can report more than one result.
+// To add a new check, write one of these and add it to getChecks.
+type Check func(env *Environment) []api.DoctorCheck
+
+// Get every check, in the order their problems are most likely to cause the others:
+// a full disk or a bad clock breaks syncing, and an unsynced client breaks everything on chain
+func getChecks() []Check {
+	return []Check{
+		checkDisk,
+		checkClock,
+		checkExecutionClient,
+		checkBeaconClient,
+		checkPeers,
+		checkWallet,
+		checkFeeRecipient,
+		checkRelayRegistration,
+		checkCollateral,
+	}
+}
+
+// Run every check in parallel, and return the results with the most urgent problems first.
+// Problems of the same severity keep the order of getChecks, so the likely root cause comes first.
+func RunChecks(env *Environment) []api.DoctorCheck {
+	checks := getChecks()
+	results := make([][]api.DoctorCheck, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			results[i] = check(env)
+		}(i, check)
+	}
+	wg.Wait()
+
+	ranked := []api.DoctorCheck{}
+	for _, result := range results {
+		ranked = append(ranked, result...)
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return GetRank(ranked[i].Severity) < GetRank(ranked[j].Severity)
+	})
+	return ranked
+}
+
+// Get how urgent a severity is, where lower is more urgent
+func GetRank(severity api.DoctorSeverity) int {
+	switch severity {
+	case api.DoctorSeverity_Critical:
+		return 0
+	case api.DoctorSeverity_Warning:
+		return 1
+	case api.DoctorSeverity_Unknown:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// Get the node account and whether it's registered, loading them the first time they're needed
+func (env *Environment) getNode() (*accounts.Account, bool, error) {
+	env.once.Do(func() {
+		if !env.Wallet.IsInitialized() {
+			return
+		}
+		nodeAccount, err := env.Wallet.GetNodeAccount()
+		if err != nil {
+			env.registeredErr = fmt.Errorf("couldn't get the node account: %w", err)
+			return
+		}
+		env.nodeAccount = &nodeAccount
+		env.registered, err = node.GetNodeExists(env.Rp, nodeAccount.Address, nil)
+		if err != nil {
+			env.registeredErr = fmt.Errorf("couldn't check if the node is registered: %w", err)
+		}
+	})
+	return env.nodeAccount, env.registered, env.registeredErr
+}
+
+// Get the node account for a check that needs a registered node, or the result to report instead if the node isn't ready for it
+func (env *Environment) requireRegisteredNode(name string) (*accounts.Account, *api.DoctorCheck) {
+	nodeAccount, registered, err := env.getNode()
+	switch {
+	case err != nil:
+		return nil, &api.DoctorCheck{Name: name, Severity: api.DoctorSeverity_Unknown, Details: err.Error()}
+	case nodeAccount == nil || !registered:
+		return nil, &api.DoctorCheck{Name: name, Severity: api.DoctorSeverity_Ok, Details: "skipped because the node isn't registered yet"}
+	}
+	return nodeAccount, nil
+}
+
+// Make sure the disks the Smartnode writes to have room left
+func checkDisk(env *Environment) []api.DoctorCheck {
+	results := []api.DoctorCheck{}
+	threshold := float64(env.Cfg.Alertmanager.HostDiskUsageThreshold.Value.(uint64))
+	for _, path := range hardware.GetMonitoredPaths(env.Cfg) {
+		check := api.DoctorCheck{Name: fmt.Sprintf("Disk space (%s)", path)}
+		usage, err := disk.Usage(path)
+		switch {
+		case err != nil:
+			check.Severity = api.DoctorSeverity_Unknown
+			check.Details = fmt.Sprintf("couldn't get the disk usage: %s", err.Error())
+		case usage.UsedPercent >= criticalDiskUsagePercent:
+			check.Severity = api.DoctorSeverity_Critical
+			check.Details = fmt.Sprintf("the disk is %.1f%% full, so the clients will soon stop being able to write to it", usage.UsedPercent)
+			check.Remedy = "Free up space now, e.g. with `rocketpool service prune-docker` or `rocketpool service prune-eth1`"
+		case usage.UsedPercent >= threshold:
+			check.Severity = api.DoctorSeverity_Warning
+			check.Details = fmt.Sprintf("the disk is %.1f%% full", usage.UsedPercent)
+			check.Remedy = "Free up space before it fills, e.g. with `rocketpool service prune-docker` or `rocketpool service prune-eth1`"
+		default:
+			check.Severity = api.DoctorSeverity_Ok
+			check.Details = fmt.Sprintf("%.1f%% full", usage.UsedPercent)
+		}
+		results = append(results, check)
+	}
+	return results
+}
+
+// Make sure the local clock agrees with the NTP servers and the Beacon Chain
+func checkClock(env *Environment) []api.DoctorCheck {
+	check := api.DoctorCheck{Name: "System clock"}
+	status := clock.CheckClock(env.Cfg, env.Bc)
+	switch {
+	case status.Drifted:
+		check.Severity = api.DoctorSeverity_Critical
+		check.Details = status.Problem
+		check.Remedy = "Make sure time synchronization is running, e.g. with `timedatectl status`, and enable it with `sudo timedatectl set-ntp true`"
+	case status.Problem != "":
+		check.Severity = api.DoctorSeverity_Unknown
+		check.Details = status.Problem
+	default:
+		check.Severity = api.DoctorSeverity_Ok
+		check.Details = status.Summary()
+	}
+	return []api.DoctorCheck{check}
+}
+
+// Make sure the Execution client is up and synced
+func checkExecutionClient(env *Environment) []api.DoctorCheck {
+	check := api.DoctorCheck{Name: "Execution client sync"}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	version, err := env.Ec.ClientVersion(ctx)
+	if err != nil {
+		check.Severity = api.DoctorSeverity_Critical
+		check.Details = fmt.Sprintf("couldn't reach the client: %s", err.Error())
+		check.Remedy = "Make sure it's running with `rocketpool service status`, and look for errors with `rocketpool service logs eth1`"
+		return []api.DoctorCheck{check}
+	}
+	progress, err := env.Ec.SyncProgress(ctx)
+	switch {
+	case err != nil:
+		check.Severity = api.DoctorSeverity_Unknown
+		check.Details = fmt.Sprintf("%s, couldn't get the sync status: %s", version, err.Error())
+	case progress != nil:
+		check.Severity = api.DoctorSeverity_Critical
+		check.Details = fmt.Sprintf("%s is still syncing (block %d of %d)", version, progress.CurrentBlock, progress.HighestBlock)
+		check.Remedy = "Wait for it to finish, and follow its progress with `rocketpool service logs eth1`"
+	default:
+		check.Severity = api.DoctorSeverity_Ok
+		check.Details = fmt.Sprintf("%s is synced", version)
+	}
+	return []api.DoctorCheck{check}
+}
+
+// Make sure the Beacon Node is up and synced
+func checkBeaconClient(env *Environment) []api.DoctorCheck {
+	check := api.DoctorCheck{Name: "Beacon Node sync"}
+	version, err := env.Bc.GetNodeVersion()
+	if err != nil {
+		check.Severity = api.DoctorSeverity_Critical
+		check.Details = fmt.Sprintf("couldn't reach the client: %s", err.Error())
+		check.Remedy = "Make sure it's running with `rocketpool service status`, and look for errors with `rocketpool service logs eth2`"
+		return []api.DoctorCheck{check}
+	}
+	status, err := env.Bc.GetSyncStatus()
+	switch {
+	case err != nil:
+		check.Severity = api.DoctorSeverity_Unknown
+		check.Details = fmt.Sprintf("%s, couldn't get the sync status: %s", version, err.Error())
+	case status.Syncing:
+		check.Severity = api.DoctorSeverity_Critical
+		check.Details = fmt.Sprintf("%s is still syncing (%.2f%%)", version, status.Progress*100)
+		check.Remedy = "Wait for it to finish, and follow its progress with `rocketpool service logs eth2`; if it'll take a long time, `rocketpool service rescue-node enable` keeps your validators attesting meanwhile"
+	default:
+		check.Severity = api.DoctorSeverity_Ok
+		check.Details = fmt.Sprintf("%s is synced", version)
+	}
+	return []api.DoctorCheck{check}
+}
+
+// Make sure both clients have enough peers to follow the chain
+func checkPeers(env *Environment) []api.DoctorCheck {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	ecPeers, ecErr := env.Ec.PeerCount(ctx)
+	bcPeers, bcErr := env.Bc.GetPeerCount()
+	return []api.DoctorCheck{
+		getPeerCheck("Execution client peers", ecPeers, ecErr, minExecutionPeers),
+		getPeerCheck("Beacon Node peers", bcPeers, bcErr, minBeaconPeers),
+	}
+}
+
+// Get the result of a client's peer count
+func getPeerCheck(name string, peers uint64, err error, minPeers uint64) api.DoctorCheck {
+	check := api.DoctorCheck{Name: name}
+	remedy := "Make sure the client's P2P ports are open and forwarded with `rocketpool service check-ports`"
+	switch {
+	case err != nil:
+		check.Severity = api.DoctorSeverity_Unknown
+		check.Details = fmt.Sprintf("couldn't get the peer count: %s", err.Error())
+	case peers == 0:
+		check.Severity = api.DoctorSeverity_Critical
+		check.Details = "the client has no peers, so it can't follow the chain"
+		check.Remedy = remedy
+	case peers < minPeers:
+		check.Severity = api.DoctorSeverity_Warning
+		check.Details = fmt.Sprintf("the client only has %d peers", peers)
+		check.Remedy = remedy
+	default:
+		check.Severity = api.DoctorSeverity_Ok
+		check.Details = fmt.Sprintf("%d peers", peers)
+	}
+	return check
+}
+
+// Make sure the node wallet is loaded and registered
+func checkWallet(env *Environment) []api.DoctorCheck {
+	check := api.DoctorCheck{Name: "Node wallet"}
+	nodeAccount, registered, err := env.getNode()
+	switch {
+	case nodeAccount == nil && err == nil:
+		check.Severity = api.DoctorSeverity_Critical
+		check.Details = "the node wallet isn't initialized or its password isn't available"
+		check.Remedy = "Check it with `rocketpool wallet status`, and restore it with `rocketpool wallet recover` if it's missing"
+	case err != nil:
+		check.Severity = api.DoctorSeverity_Unknown
+		check.Details = err.Error()
+	case !registered:
+		check.Severity = api.DoctorSeverity_Warning
+		check.Details = fmt.Sprintf("node account %s isn't registered with Rocket Pool", nodeAccount.Address.Hex())
+		check.Remedy = "Register it with `rocketpool node register`"
+	default:
+		check.Severity = api.DoctorSeverity_Ok
+		check.Details = fmt.Sprintf("node account %s is loaded and registered", nodeAccount.Address.Hex())
+	}
+	return []api.DoctorCheck{check}
+}
+
+// Make sure the Validator Client is sending priority fees to the right address
+func checkFeeRecipient(env *Environment) []api.DoctorCheck {
+	name := "Fee recipient"
+	nodeAccount, skipped := env.requireRegisteredNode(name)
+	if skipped != nil {
+		return []api.DoctorCheck{*skipped}
+	}
+	check := api.DoctorCheck{Name: name}
+
+	info, err := rputils.GetFeeRecipientInfoWithoutState(env.Rp, env.Bc, nodeAccount.Address, nil)
+	if err != nil {
+		check.Severity = api.DoctorSeverity_Unknown
+		check.Details = fmt.Sprintf("couldn't get the correct fee recipient: %s", err.Error())
+		return []api.DoctorCheck{check}
+	}
+	correctFeeRecipient := info.FeeDistributorAddress
+	if info.IsInSmoothingPool || info.IsInOptOutCooldown {
+		correctFeeRecipient = info.SmoothingPoolAddress
+	}
+	fileExists, correctAddress, err := rpsvc.CheckFeeRecipientFile(correctFeeRecipient, env.Cfg)
+	switch {
+	case err != nil:
+		check.Severity = api.DoctorSeverity_Unknown
+		check.Details = fmt.Sprintf("couldn't read the fee recipient file: %s", err.Error())
+	case !fileExists || !correctAddress:
+		check.Severity = api.DoctorSeverity_Critical
+		check.Details = fmt.Sprintf("the Validator Client isn't set to use %s, so proposals could send their fees to the wrong address and be penalized", correctFeeRecipient.Hex())
+		check.Remedy = "The node daemon fixes this within a few minutes; if it doesn't, look for errors with `rocketpool service logs node`"
+	default:
+		check.Severity = api.DoctorSeverity_Ok
+		check.Details = fmt.Sprintf("the Validator Client is using %s", correctFeeRecipient.Hex())
+	}
+	return []api.DoctorCheck{check}
+}
+
+// Make sure the enabled MEV relays have the node's validators registered, so they can build its blocks
+func checkRelayRegistration(env *Environment) []api.DoctorCheck {
+	if env.Cfg.EnableMevBoost.Value != true {
+		return []api.DoctorCheck{}
+	}
+	name := "MEV relay registration"
+	nodeAccount, skipped := env.requireRegisteredNode(name)
+	if skipped != nil {
+		return []api.DoctorCheck{*skipped}
+	}
+
+	// Relays only know about active validators, so check one of them
+	pubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(env.Rp, nodeAccount.Address, nil)
+	if err != nil {
+		return []api.DoctorCheck{{Name: name, Severity: api.DoctorSeverity_Unknown, Details: fmt.Sprintf("couldn't get the node's validators: %s", err.Error())}}
+	}
+	statuses, err := env.Bc.GetValidatorStatuses(pubkeys, nil)
+	if err != nil {
+		return []api.DoctorCheck{{Name: name, Severity: api.DoctorSeverity_Unknown, Details: fmt.Sprintf("couldn't get the node's validator statuses: %s", err.Error())}}
+	}
+	pubkey := ""
+	for _, candidate := range pubkeys {
+		if statuses[candidate].Status == beacon.ValidatorState_ActiveOngoing {
+			pubkey = candidate.Hex()
+			break
+		}
+	}
+	if pubkey == "" {
+		return []api.DoctorCheck{{Name: name, Severity: api.DoctorSeverity_Ok, Details: "skipped because the node doesn't have any active validators yet"}}
+	}
+
+	results := []api.DoctorCheck{}
+	network := env.Cfg.Smartnode.Network.Value.(cfgtypes.Network)
+	for _, relay := range env.Cfg.MevBoost.GetEnabledMevRelays() {
+		check := api.DoctorCheck{Name: fmt.Sprintf("%s relay registration", relay.Name)}
+		registered, err := isRegisteredWithRelay(relay.Urls[network], pubkey)
+		switch {
+		case err != nil:
+			check.Severity = api.DoctorSeverity_Unknown
+			check.Details = err.Error()
+		case !registered:
+			check.Severity = api.DoctorSeverity_Warning
+			check.Details = fmt.Sprintf("validator %s isn't registered with the relay, so it won't build your blocks", pubkey)
+			check.Remedy = "The Validator Client registers every epoch; look for errors with `rocketpool service logs validator mev-boost`"
+		default:
+			check.Severity = api.DoctorSeverity_Ok
+			check.Details = fmt.Sprintf("validator %s is registered", pubkey)
+		}
+		results = append(results, check)
+	}
+	return results
+}
+
+// Check if a relay has a registration for the validator
+func isRegisteredWithRelay(relayUrlString string, pubkey string) (bool, error) {
+	// Relay URLs include the relay's public key, which isn't part of the request
+	relayUrl, err := url.Parse(relayUrlString)
+	if err != nil {
+		return false, fmt.Errorf("couldn't parse the relay URL: %w", err)
+	}
+	relayUrl.User = nil
+	relayUrl.Path = validatorRegistrationPath
+	relayUrl.RawQuery = url.Values{"pubkey": []string{pubkey}}.Encode()
+
+	client := http.Client{Timeout: requestTimeout}
+	response, err := client.Get(relayUrl.String())
+	if err != nil {
+		return false, fmt.Errorf("couldn't reach the relay: %w", err)
+	}
+	defer response.Body.Close()
+	switch response.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusBadRequest, http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("the relay returned HTTP %d", response.StatusCode)
+	}
+}
+
+// Make sure the node has enough RPL staked to earn RPL rewards
+func checkCollateral(env *Environment) []api.DoctorCheck {
+	name := "RPL collateral"
+	nodeAccount, skipped := env.requireRegisteredNode(name)
+	if skipped != nil {
+		return []api.DoctorCheck{*skipped}
+	}
+	check := api.DoctorCheck{Name: name}
+
+	rplStake, err := node.GetNodeRPLStake(env.Rp, nodeAccount.Address, nil)
+	if err != nil {
+		check.Severity = api.DoctorSeverity_Unknown
+		check.Details = fmt.Sprintf("couldn't get the node's RPL stake: %s", err.Error())
+		return []api.DoctorCheck{check}
+	}
+	minimumStake, err := node.GetNodeMinimumRPLStake(env.Rp, nodeAccount.Address, nil)
+	if err != nil {
+		check.Severity = api.DoctorSeverity_Unknown
+		check.Details = fmt.Sprintf("couldn't get the node's minimum RPL stake: %s", err.Error())
+		return []api.DoctorCheck{check}
+	}
+	if minimumStake.Cmp(big.NewInt(0)) > 0 && rplStake.Cmp(minimumStake) < 0 {
+		check.Severity = api.DoctorSeverity_Warning
+		check.Details = fmt.Sprintf("the node has %.6f RPL staked, which is below the minimum of %.6f RPL, so it won't earn RPL rewards", eth.WeiToEth(rplStake), eth.WeiToEth(minimumStake))
+		check.Remedy = "Stake more RPL with `rocketpool node stake-rpl`"
+		return []api.DoctorCheck{check}
+	}
+	check.Severity = api.DoctorSeverity_Ok
+	check.Details = fmt.Sprintf("the node has %.6f RPL staked, and the minimum is %.6f RPL", eth.WeiToEth(rplStake), eth.WeiToEth(minimumStake))
+	return []api.DoctorCheck{check}
+}
@@ -2,18 +2,25 @@ package services
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/fatih/color"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/revert"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -25,12 +32,44 @@ type ExecutionClientManager struct {
 	fallbackEcUrl   string
 	primaryEc       *ethclient.Client
 	fallbackEc      *ethclient.Client
+	fallbackEcUrls  []string
+	fallbackEcs     []*ethclient.Client
+	fallbackIndex   int
+	archiveEcUrl    string
+	archiveEc       *ethclient.Client
+	archiveHorizon  uint64
+	headLock        sync.Mutex
+	headBlock       uint64
+	headBlockTime   time.Time
 	logger          log.ColorLogger
+	policy          clientCallPolicy
+	primaryBreaker  *circuitBreaker
+	fallbackBreaker *circuitBreaker
 	primaryReady    bool
+	primaryDisabled bool
 	fallbackReady   bool
 	ignoreSyncCheck bool
+	revertAbis      func() []*abi.ABI
+
+	// Optional private relay for selected transactions
+	privateRelay     *ethclient.Client
+	privateTxTypes   map[PrivateTxType]bool
+	privateTxTimeout time.Duration
+
+	// Optional relay for atomic transaction bundles
+	bundleRelayUrl string
+	bundleBlocks   uint64
+	bundleKey      *ecdsa.PrivateKey
+
+	// Calls that gas was estimated for, kept so the API can preview them
+	estimatedCalls     []ethereum.CallMsg
+	sentTransaction    bool
+	estimatedCallsLock sync.Mutex
 }
 
+// How long to cache the latest block number for archive routing
+const headBlockCacheDuration time.Duration = 12 * time.Second
+
 // This is a signature for a wrapped ethclient.Client function
 type ecFunction func(*ethclient.Client) (interface{}, error)
 
@@ -38,7 +77,6 @@ type ecFunction func(*ethclient.Client) (interface{}, error)
 func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientManager, error) {
 
 	var primaryEcUrl string
-	var fallbackEcUrl string
 
 	// Get the primary EC url
 	if cfg.IsNativeMode {
@@ -49,19 +87,10 @@ func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientMa
 		primaryEcUrl = cfg.ExternalExecution.HttpUrl.Value.(string)
 	}
 
-	// Get the fallback EC url, if applicable
-	if cfg.UseFallbackClients.Value == true {
-		if cfg.IsNativeMode {
-			fallbackEcUrl = cfg.FallbackNormal.EcHttpUrl.Value.(string)
-		} else {
-			cc, _ := cfg.GetSelectedConsensusClient()
-			switch cc {
-			case cfgtypes.ConsensusClient_Prysm:
-				fallbackEcUrl = cfg.FallbackPrysm.EcHttpUrl.Value.(string)
-			default:
-				fallbackEcUrl = cfg.FallbackNormal.EcHttpUrl.Value.(string)
-			}
-		}
+	// Get the fallback EC urls, if applicable
+	pairs, err := cfg.GetFallbackClientPairs()
+	if err != nil {
+		return nil, err
 	}
 
 	primaryEc, err := ethclient.Dial(primaryEcUrl)
@@ -69,22 +98,79 @@ func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientMa
 		return nil, fmt.Errorf("error connecting to primary EC at [%s]: %w", primaryEcUrl, err)
 	}
 
-	var fallbackEc *ethclient.Client
-	if fallbackEcUrl != "" {
-		fallbackEc, err = ethclient.Dial(fallbackEcUrl)
+	fallbackEcUrls := []string{}
+	fallbackEcs := []*ethclient.Client{}
+	for _, pair := range pairs {
+		fallbackEc, err := ethclient.Dial(pair.EcHttpUrl)
 		if err != nil {
-			return nil, fmt.Errorf("error connecting to fallback EC at [%s]: %w", fallbackEcUrl, err)
+			return nil, fmt.Errorf("error connecting to fallback EC at [%s]: %w", pair.EcHttpUrl, err)
 		}
+		fallbackEcUrls = append(fallbackEcUrls, pair.EcHttpUrl)
+		fallbackEcs = append(fallbackEcs, fallbackEc)
 	}
 
+	// Get the archive EC, if applicable
+	var archiveEc *ethclient.Client
+	archiveEcUrl := cfg.Smartnode.ArchiveECUrl.Value.(string)
+	if archiveEcUrl != "" {
+		archiveEc, err = ethclient.Dial(archiveEcUrl)
+		if err != nil {
+			return nil, fmt.Errorf("error connecting to archive EC at [%s]: %w", archiveEcUrl, err)
+		}
+	}
+
+	// Get the private transaction relay, if applicable
+	var privateRelay *ethclient.Client
+	privateRelayUrl := cfg.Smartnode.PrivateTxRelayUrl.Value.(string)
+	if privateRelayUrl != "" {
+		privateRelay, err = ethclient.Dial(privateRelayUrl)
+		if err != nil {
+			return nil, fmt.Errorf("error connecting to private transaction relay at [%s]: %w", privateRelayUrl, err)
+		}
+	}
+
+	// Get a key to sign bundle relay requests with, if applicable. Relays only use it to tell senders apart, so it doesn't need to be kept.
+	var bundleKey *ecdsa.PrivateKey
+	bundleRelayUrl := cfg.Smartnode.WatchtowerBundleRelayUrl.Value.(string)
+	if bundleRelayUrl != "" {
+		bundleKey, err = crypto.GenerateKey()
+		if err != nil {
+			return nil, fmt.Errorf("error creating the bundle relay signing key: %w", err)
+		}
+	}
+
+	var fallbackEcUrl string
+	var fallbackEc *ethclient.Client
+	if len(fallbackEcs) > 0 {
+		fallbackEcUrl = fallbackEcUrls[0]
+		fallbackEc = fallbackEcs[0]
+	}
+
+	policy := newClientCallPolicy(cfg)
 	return &ExecutionClientManager{
-		primaryEcUrl:  primaryEcUrl,
-		fallbackEcUrl: fallbackEcUrl,
-		primaryEc:     primaryEc,
-		fallbackEc:    fallbackEc,
-		logger:        log.NewColorLogger(color.FgYellow),
-		primaryReady:  true,
-		fallbackReady: fallbackEc != nil,
+		primaryEcUrl:    primaryEcUrl,
+		fallbackEcUrl:   fallbackEcUrl,
+		primaryEc:       primaryEc,
+		fallbackEc:      fallbackEc,
+		fallbackEcUrls:  fallbackEcUrls,
+		fallbackEcs:     fallbackEcs,
+		archiveEcUrl:    archiveEcUrl,
+		archiveEc:       archiveEc,
+		archiveHorizon:  cfg.Smartnode.ArchiveECRoutingHorizon.Value.(uint64),
+		logger:          log.NewColorLogger(color.FgYellow),
+		policy:          policy,
+		primaryBreaker:  &circuitBreaker{threshold: policy.breakerThreshold},
+		fallbackBreaker: &circuitBreaker{threshold: policy.breakerThreshold},
+		primaryReady:    true,
+		fallbackReady:   fallbackEc != nil,
+
+		privateRelay:     privateRelay,
+		privateTxTypes:   parsePrivateTxTypes(cfg.Smartnode.PrivateTxTypes.Value.(string)),
+		privateTxTimeout: time.Duration(cfg.Smartnode.PrivateTxTimeout.Value.(uint64)) * time.Minute,
+
+		bundleRelayUrl: bundleRelayUrl,
+		bundleBlocks:   cfg.Smartnode.WatchtowerBundleBlocks.Value.(uint64),
+		bundleKey:      bundleKey,
 	}, nil
 
 }
@@ -96,7 +182,7 @@ func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientMa
 // CodeAt returns the code of the given account. This is needed to differentiate
 // between contract internal errors and the local chain being out of sync.
 func (p *ExecutionClientManager) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runHistoricalFunction(ctx, blockNumber, func(client *ethclient.Client) (interface{}, error) {
 		return client.CodeAt(ctx, contract, blockNumber)
 	})
 	if err != nil {
@@ -108,7 +194,7 @@ func (p *ExecutionClientManager) CodeAt(ctx context.Context, contract common.Add
 // CallContract executes an Ethereum contract call with the specified data as the
 // input.
 func (p *ExecutionClientManager) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runHistoricalFunction(ctx, blockNumber, func(client *ethclient.Client) (interface{}, error) {
 		return client.CallContract(ctx, call, blockNumber)
 	})
 	if err != nil {
@@ -123,7 +209,7 @@ func (p *ExecutionClientManager) CallContract(ctx context.Context, call ethereum
 
 // HeaderByHash returns the block header with the given hash.
 func (p *ExecutionClientManager) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, func(client *ethclient.Client) (interface{}, error) {
 		return client.HeaderByHash(ctx, hash)
 	})
 	if err != nil {
@@ -135,7 +221,7 @@ func (p *ExecutionClientManager) HeaderByHash(ctx context.Context, hash common.H
 // HeaderByNumber returns a block header from the current canonical chain. If number is
 // nil, the latest known header is returned.
 func (p *ExecutionClientManager) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, func(client *ethclient.Client) (interface{}, error) {
 		return client.HeaderByNumber(ctx, number)
 	})
 	if err != nil {
@@ -146,7 +232,7 @@ func (p *ExecutionClientManager) HeaderByNumber(ctx context.Context, number *big
 
 // PendingCodeAt returns the code of the given account in the pending state.
 func (p *ExecutionClientManager) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, func(client *ethclient.Client) (interface{}, error) {
 		return client.PendingCodeAt(ctx, account)
 	})
 	if err != nil {
@@ -157,7 +243,7 @@ func (p *ExecutionClientManager) PendingCodeAt(ctx context.Context, account comm
 
 // PendingNonceAt retrieves the current pending nonce associated with an account.
 func (p *ExecutionClientManager) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, func(client *ethclient.Client) (interface{}, error) {
 		return client.PendingNonceAt(ctx, account)
 	})
 	if err != nil {
@@ -169,7 +255,7 @@ func (p *ExecutionClientManager) PendingNonceAt(ctx context.Context, account com
 // SuggestGasPrice retrieves the currently suggested gas price to allow a timely
 // execution of a transaction.
 func (p *ExecutionClientManager) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, func(client *ethclient.Client) (interface{}, error) {
 		return client.SuggestGasPrice(ctx)
 	})
 	if err != nil {
@@ -181,7 +267,7 @@ func (p *ExecutionClientManager) SuggestGasPrice(ctx context.Context) (*big.Int,
 // SuggestGasTipCap retrieves the currently suggested 1559 priority fee to allow
 // a timely execution of a transaction.
 func (p *ExecutionClientManager) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, func(client *ethclient.Client) (interface{}, error) {
 		return client.SuggestGasTipCap(ctx)
 	})
 	if err != nil {
@@ -196,23 +282,93 @@ func (p *ExecutionClientManager) SuggestGasTipCap(ctx context.Context) (*big.Int
 // transactions may be added or removed by miners, but it should provide a basis
 // for setting a reasonable default.
 func (p *ExecutionClientManager) EstimateGas(ctx context.Context, call ethereum.CallMsg) (gas uint64, err error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, func(client *ethclient.Client) (interface{}, error) {
 		return client.EstimateGas(ctx, call)
 	})
 	if err != nil {
-		return 0, err
+		return 0, revert.Wrap(err, p.revertAbis)
+	}
+	if recordEstimatedCalls {
+		p.estimatedCallsLock.Lock()
+		p.estimatedCalls = append(p.estimatedCalls, call)
+		p.estimatedCallsLock.Unlock()
 	}
 	return result.(uint64), err
 }
 
 // SendTransaction injects the transaction into the pending pool for execution.
 func (p *ExecutionClientManager) SendTransaction(ctx context.Context, tx *types.Transaction) error {
-	_, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	// Simulate it first so a transaction that would revert isn't broadcast and doesn't cost any gas
+	if err := p.simulateTransaction(ctx, tx); err != nil {
+		return err
+	}
+
+	// Send selected transactions through the private relay instead of the public mempool
+	var err error
+	if p.usePrivateRelay(ctx) {
+		err = p.sendPrivateTransaction(ctx, tx)
+	} else {
+		err = p.sendPublicTransaction(ctx, tx)
+	}
+	if err == nil {
+		p.estimatedCallsLock.Lock()
+		p.sentTransaction = true
+		p.estimatedCallsLock.Unlock()
+	}
+	return err
+}
+
+// Broadcast a transaction to the public mempool through the Execution clients
+func (p *ExecutionClientManager) sendPublicTransaction(ctx context.Context, tx *types.Transaction) error {
+	// Don't retry, since a call that timed out may still have broadcast the transaction
+	policy := p.policy
+	policy.retries = 0
+	_, err := p.runFunctionWithPolicy(ctx, policy, func(client *ethclient.Client) (interface{}, error) {
 		return nil, client.SendTransaction(ctx, tx)
 	})
 	return err
 }
 
+// Simulate a signed transaction against the pending state, returning a decoded revert error if it would fail.
+// Errors that aren't reverts are ignored here, since sending the transaction will run into them too.
+func (p *ExecutionClientManager) simulateTransaction(ctx context.Context, tx *types.Transaction) error {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return nil
+	}
+	call := ethereum.CallMsg{
+		From:      from,
+		To:        tx.To(),
+		Gas:       tx.Gas(),
+		GasFeeCap: tx.GasFeeCap(),
+		GasTipCap: tx.GasTipCap(),
+		Value:     tx.Value(),
+		Data:      tx.Data(),
+	}
+	if tx.Type() == types.LegacyTxType {
+		call.GasPrice = tx.GasPrice()
+		call.GasFeeCap = nil
+		call.GasTipCap = nil
+	}
+	_, err = p.runFunction(ctx, func(client *ethclient.Client) (interface{}, error) {
+		return client.PendingCallContract(ctx, call)
+	})
+	if err == nil {
+		return nil
+	}
+	var revertErr *revert.Error
+	if errors.As(revert.Wrap(err, p.revertAbis), &revertErr) {
+		return revertErr
+	}
+	return nil
+}
+
+// Set the function that provides the contract ABIs used to decode custom revert errors.
+// It's only called when a revert can't be decoded with the standard error types.
+func (p *ExecutionClientManager) SetRevertABISource(getAbis func() []*abi.ABI) {
+	p.revertAbis = getAbis
+}
+
 /// ==========================
 /// ContractFilterer Functions
 /// ==========================
@@ -222,7 +378,7 @@ func (p *ExecutionClientManager) SendTransaction(ctx context.Context, tx *types.
 //
 // TODO(karalabe): Deprecate when the subscription one can return past data too.
 func (p *ExecutionClientManager) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, func(client *ethclient.Client) (interface{}, error) {
 		return client.FilterLogs(ctx, query)
 	})
 	if err != nil {
@@ -234,7 +390,7 @@ func (p *ExecutionClientManager) FilterLogs(ctx context.Context, query ethereum.
 // SubscribeFilterLogs creates a background log filtering operation, returning
 // a subscription immediately, which can be used to stream the found events.
 func (p *ExecutionClientManager) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, func(client *ethclient.Client) (interface{}, error) {
 		return client.SubscribeFilterLogs(ctx, query, ch)
 	})
 	if err != nil {
@@ -250,7 +406,7 @@ func (p *ExecutionClientManager) SubscribeFilterLogs(ctx context.Context, query
 // TransactionReceipt returns the receipt of a transaction by transaction hash.
 // Note that the receipt is not available for pending transactions.
 func (p *ExecutionClientManager) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, func(client *ethclient.Client) (interface{}, error) {
 		return client.TransactionReceipt(ctx, txHash)
 	})
 	if err != nil {
@@ -265,7 +421,7 @@ func (p *ExecutionClientManager) TransactionReceipt(ctx context.Context, txHash
 
 // BlockNumber returns the most recent block number
 func (p *ExecutionClientManager) BlockNumber(ctx context.Context) (uint64, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, func(client *ethclient.Client) (interface{}, error) {
 		return client.BlockNumber(ctx)
 	})
 	if err != nil {
@@ -277,7 +433,7 @@ func (p *ExecutionClientManager) BlockNumber(ctx context.Context) (uint64, error
 // BalanceAt returns the wei balance of the given account.
 // The block number can be nil, in which case the balance is taken from the latest known block.
 func (p *ExecutionClientManager) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runHistoricalFunction(ctx, blockNumber, func(client *ethclient.Client) (interface{}, error) {
 		return client.BalanceAt(ctx, account, blockNumber)
 	})
 	if err != nil {
@@ -288,7 +444,7 @@ func (p *ExecutionClientManager) BalanceAt(ctx context.Context, account common.A
 
 // TransactionByHash returns the transaction with the given hash.
 func (p *ExecutionClientManager) TransactionByHash(ctx context.Context, hash common.Hash) (tx *types.Transaction, isPending bool, err error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, func(client *ethclient.Client) (interface{}, error) {
 		tx, isPending, err := client.TransactionByHash(ctx, hash)
 		result := []interface{}{tx, isPending}
 		return result, err
@@ -307,7 +463,7 @@ func (p *ExecutionClientManager) TransactionByHash(ctx context.Context, hash com
 // NonceAt returns the account nonce of the given account.
 // The block number can be nil, in which case the nonce is taken from the latest known block.
 func (p *ExecutionClientManager) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runHistoricalFunction(ctx, blockNumber, func(client *ethclient.Client) (interface{}, error) {
 		return client.NonceAt(ctx, account, blockNumber)
 	})
 	if err != nil {
@@ -319,7 +475,7 @@ func (p *ExecutionClientManager) NonceAt(ctx context.Context, account common.Add
 // SyncProgress retrieves the current progress of the sync algorithm. If there's
 // no sync currently running, it returns nil.
 func (p *ExecutionClientManager) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, func(client *ethclient.Client) (interface{}, error) {
 		return client.SyncProgress(ctx)
 	})
 	if err != nil {
@@ -328,6 +484,97 @@ func (p *ExecutionClientManager) SyncProgress(ctx context.Context) (*ethereum.Sy
 	return result.(*ethereum.SyncProgress), err
 }
 
+// ClientVersion returns the name and version the client reports
+func (p *ExecutionClientManager) ClientVersion(ctx context.Context) (string, error) {
+	result, err := p.runFunction(ctx, func(client *ethclient.Client) (interface{}, error) {
+		var version string
+		err := client.Client().CallContext(ctx, &version, "web3_clientVersion")
+		return version, err
+	})
+	if err != nil {
+		return "", err
+	}
+	return result.(string), err
+}
+
+// Get the number of peers the client is connected to
+func (p *ExecutionClientManager) PeerCount(ctx context.Context) (uint64, error) {
+	result, err := p.runFunction(ctx, func(client *ethclient.Client) (interface{}, error) {
+		var count hexutil.Uint64
+		err := client.Client().CallContext(ctx, &count, "net_peerCount")
+		return uint64(count), err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(uint64), err
+}
+
+// The result of one call in a simulation
+type SimulatedCall struct {
+	ReturnData []byte
+	Error      string
+}
+
+// The subset of an eth_simulateV1 result that the Smartnode uses
+type simulatedBlock struct {
+	Calls []struct {
+		ReturnData hexutil.Bytes  `json:"returnData"`
+		Status     hexutil.Uint64 `json:"status"`
+		Error      *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	} `json:"calls"`
+}
+
+// Simulate a sequence of calls on top of the latest block with eth_simulateV1, so each call sees the state changes of the ones before it.
+// Nothing is signed or sent; this is used to check what a transaction will actually do before it's submitted.
+func (p *ExecutionClientManager) SimulateCalls(ctx context.Context, calls []ethereum.CallMsg) ([]SimulatedCall, error) {
+	simCalls := []map[string]interface{}{}
+	for _, call := range calls {
+		simCall := map[string]interface{}{
+			"from":  call.From,
+			"input": hexutil.Bytes(call.Data),
+		}
+		if call.To != nil {
+			simCall["to"] = *call.To
+		}
+		if call.Value != nil {
+			simCall["value"] = (*hexutil.Big)(call.Value)
+		}
+		simCalls = append(simCalls, simCall)
+	}
+	params := map[string]interface{}{
+		"blockStateCalls": []map[string]interface{}{
+			{"calls": simCalls},
+		},
+	}
+
+	result, err := p.runFunction(ctx, func(client *ethclient.Client) (interface{}, error) {
+		var blocks []simulatedBlock
+		err := client.Client().CallContext(ctx, &blocks, "eth_simulateV1", params, "latest")
+		return blocks, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error simulating calls (your Execution client must support eth_simulateV1): %w", err)
+	}
+	blocks := result.([]simulatedBlock)
+	if len(blocks) != 1 || len(blocks[0].Calls) != len(calls) {
+		return nil, fmt.Errorf("simulation returned results for %d blocks instead of 1 block with %d calls", len(blocks), len(calls))
+	}
+	results := make([]SimulatedCall, len(calls))
+	for i, call := range blocks[0].Calls {
+		results[i].ReturnData = call.ReturnData
+		if call.Status != 1 {
+			results[i].Error = "reverted"
+			if call.Error != nil && call.Error.Message != "" {
+				results[i].Error = call.Error.Message
+			}
+		}
+	}
+	return results, nil
+}
+
 /// ==================
 /// Internal functions
 /// ==================
@@ -354,6 +601,10 @@ func (p *ExecutionClientManager) CheckStatus(cfg *config.RocketPoolConfig) *api.
 
 	// Flag if primary client is ready
 	p.primaryReady = (status.PrimaryClientStatus.IsWorking && status.PrimaryClientStatus.IsSynced)
+	if p.primaryReady && p.primaryDisabled {
+		p.primaryReady = false
+		status.PrimaryClientStatus.Error = "The primary Execution client is unavailable, so the Smartnode has switched to a fallback client pair"
+	}
 
 	// Get the fallback EC status if applicable
 	if status.FallbackEnabled {
@@ -456,18 +707,30 @@ func checkEcStatus(client *ethclient.Client) api.ClientStatus {
 }
 
 // Attempts to run a function progressively through each client until one succeeds or they all fail.
-func (p *ExecutionClientManager) runFunction(function ecFunction) (interface{}, error) {
+func (p *ExecutionClientManager) runFunction(ctx context.Context, function ecFunction) (interface{}, error) {
+	return p.runFunctionWithPolicy(ctx, p.policy, function)
+}
 
-	// Check if we can use the primary
-	if p.primaryReady {
+// Runs a function with the given retry policy, skipping clients whose circuit breaker is open
+func (p *ExecutionClientManager) runFunctionWithPolicy(ctx context.Context, policy clientCallPolicy, function ecFunction) (interface{}, error) {
+
+	// Check if we can use the primary; its breaker only matters if there's a fallback to use instead
+	if p.primaryReady && (p.primaryBreaker.allow() || !p.fallbackReady) {
 		// Try to run the function on the primary
-		result, err := function(p.primaryEc)
+		result, err := callWithRetry(ctx, policy, p.primaryBreaker, p.fallbackReady, func() (interface{}, error) {
+			return function(p.primaryEc)
+		})
 		if err != nil {
 			if p.isDisconnected(err) {
 				// If it's disconnected, log it and try the fallback
 				p.logger.Printlnf("WARNING: Primary Execution client disconnected (%s), using fallback...", err.Error())
 				p.primaryReady = false
-				return p.runFunction(function)
+				return p.runFunctionWithPolicy(ctx, policy, function)
+			}
+			if p.primaryBreaker.isOpen() && p.fallbackReady {
+				// If it keeps failing, stop using it until the breaker's cooldown is over
+				p.logger.Printlnf("WARNING: Primary Execution client keeps failing (%s), using fallback for %s...", err.Error(), circuitBreakerCooldown)
+				return p.runFunctionWithPolicy(ctx, policy, function)
 			}
 
 			// If it's a different error, just return it
@@ -478,9 +741,12 @@ func (p *ExecutionClientManager) runFunction(function ecFunction) (interface{},
 		return result, nil
 	}
 
-	if p.fallbackReady {
+	// Likewise, the fallback's breaker only matters if the primary is ready
+	if p.fallbackReady && (p.fallbackBreaker.allow() || !p.primaryReady) {
 		// Try to run the function on the fallback
-		result, err := function(p.fallbackEc)
+		result, err := callWithRetry(ctx, policy, p.fallbackBreaker, false, func() (interface{}, error) {
+			return function(p.fallbackEc)
+		})
 		if err != nil {
 			if p.isDisconnected(err) {
 				// If it's disconnected, log it and try the fallback
@@ -497,9 +763,85 @@ func (p *ExecutionClientManager) runFunction(function ecFunction) (interface{},
 		return result, nil
 	}
 
+	if p.primaryBreaker.isOpen() || p.fallbackBreaker.isOpen() {
+		return nil, fmt.Errorf("all available Execution clients have failed too many times in a row; waiting for them to recover")
+	}
 	return nil, fmt.Errorf("no Execution clients were ready")
 }
 
+// Runs a function that reads the state at a specific block, sending it to the archive EC if the block is too old for the primary and fallback clients.
+// Only calls pinned to a block before the head are eligible; calls against the latest state always stay on the regular clients.
+func (p *ExecutionClientManager) runHistoricalFunction(ctx context.Context, blockNumber *big.Int, function ecFunction) (interface{}, error) {
+	if p.archiveEc == nil || blockNumber == nil || !blockNumber.IsUint64() {
+		return p.runFunction(ctx, function)
+	}
+	head, err := p.getHeadBlock()
+	if err != nil || blockNumber.Uint64() >= head {
+		return p.runFunction(ctx, function)
+	}
+
+	// Skip straight to the archive EC for blocks past the configured horizon
+	if p.archiveHorizon > 0 && head-blockNumber.Uint64() > p.archiveHorizon {
+		return function(p.archiveEc)
+	}
+
+	// Otherwise only use it once the regular clients report that they've pruned the block's state
+	result, err := p.runFunction(ctx, function)
+	if err != nil && IsMissingStateError(err) {
+		p.logger.Printlnf("Execution client doesn't have the state for block %d, using the archive EC [%s]...", blockNumber.Uint64(), p.archiveEcUrl)
+		return function(p.archiveEc)
+	}
+	return result, err
+}
+
+// Get the latest block number, cached for a slot so historical queries don't each need an extra request
+func (p *ExecutionClientManager) getHeadBlock() (uint64, error) {
+	p.headLock.Lock()
+	defer p.headLock.Unlock()
+
+	if time.Since(p.headBlockTime) < headBlockCacheDuration {
+		return p.headBlock, nil
+	}
+	head, err := p.BlockNumber(context.Background())
+	if err != nil {
+		return 0, err
+	}
+	p.headBlock = head
+	p.headBlockTime = time.Now()
+	return head, nil
+}
+
+// Returns true if the error means the client has pruned the state for the requested block.
+// Generic errors (such as Besu's "Internal error") aren't included, since they'd send unrelated failures to the archive EC.
+func IsMissingStateError(err error) bool {
+	errMessage := err.Error()
+	return strings.Contains(errMessage, "missing trie node") || // Geth
+		strings.Contains(errMessage, "historical state") || // Geth (path scheme)
+		strings.Contains(errMessage, "No state available for block") || // Nethermind
+		strings.Contains(errMessage, "World state unavailable") || // Besu
+		strings.Contains(errMessage, "is pruned") // Reth
+}
+
+// Get the status of the fallback client at the given index, flagging it if it's on the wrong chain
+func (p *ExecutionClientManager) checkFallbackStatus(cfg *config.RocketPoolConfig, index int) api.ClientStatus {
+	status := checkEcStatus(p.fallbackEcs[index])
+	expectedChainID := cfg.Smartnode.GetChainID()
+	if status.Error == "" && status.NetworkId != expectedChainID {
+		status.IsSynced = false
+		status.Error = fmt.Sprintf("The fallback client is using a different chain [%s, Chain ID %d] than what your node is configured for [%s, Chain ID %d]", getNetworkNameFromId(status.NetworkId), status.NetworkId, getNetworkNameFromId(expectedChainID), expectedChainID)
+	}
+	return status
+}
+
+// Switch to the fallback client at the given index
+func (p *ExecutionClientManager) setActiveFallback(index int) {
+	p.fallbackIndex = index
+	p.fallbackEcUrl = p.fallbackEcUrls[index]
+	p.fallbackEc = p.fallbackEcs[index]
+	p.fallbackReady = true
+	p.fallbackBreaker.reset()
+}
+
 // Returns true if the error was a connection failure and a backup client is available
 func (p *ExecutionClientManager) isDisconnected(err error) bool {
 	return strings.Contains(err.Error(), "dial tcp")
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The pair indices used by the fallback monitor for the primary clients and for no healthy pair
+const (
+	primaryClientPair int = -1
+	noHealthyPair     int = -2
+)
+
+// Watches the primary and fallback client pairs, and switches the Execution and Beacon client managers together to the first pair where both clients are healthy
+type FallbackMonitor struct {
+	cfg        *config.RocketPoolConfig
+	ec         *ExecutionClientManager
+	bc         *BeaconClientManager
+	log        *log.ColorLogger
+	activePair int
+}
+
+// Create a new fallback monitor for the daemon's client managers
+func NewFallbackMonitor(c *cli.Context, logger *log.ColorLogger) (*FallbackMonitor, error) {
+	cfg, err := GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FallbackMonitor{
+		cfg:        cfg,
+		ec:         ec,
+		bc:         bc,
+		log:        logger,
+		activePair: primaryClientPair,
+	}, nil
+}
+
+// Check every client pair in order of preference and switch to the first healthy one, logging and alerting when the active pair changes.
+// This should run before the sync checks so they see the selected pair.
+func (m *FallbackMonitor) Check() {
+	if len(m.ec.fallbackEcs) == 0 || len(m.ec.fallbackEcs) != len(m.bc.fallbackBcs) || m.ec.ignoreSyncCheck || m.bc.ignoreSyncCheck {
+		return
+	}
+
+	// Prefer the primary pair whenever it's healthy
+	ecStatus := checkEcStatus(m.ec.primaryEc)
+	bcStatus := checkBcStatus(m.bc.primaryBc)
+	selectedPair := primaryClientPair
+	if !isClientHealthy(ecStatus) || !isClientHealthy(bcStatus) {
+		selectedPair = noHealthyPair
+		for i := range m.ec.fallbackEcs {
+			if isClientHealthy(m.ec.checkFallbackStatus(m.cfg, i)) && isClientHealthy(checkBcStatus(m.bc.fallbackBcs[i])) {
+				selectedPair = i
+				break
+			}
+		}
+	}
+
+	// Point both managers at the selected pair
+	switch selectedPair {
+	case primaryClientPair:
+		m.ec.primaryDisabled = false
+		m.bc.primaryDisabled = false
+	case noHealthyPair:
+		// Nothing is healthy, so let each manager use whatever clients it can reach
+		m.ec.primaryDisabled = false
+		m.bc.primaryDisabled = false
+	default:
+		m.ec.setActiveFallback(selectedPair)
+		m.bc.setActiveFallback(selectedPair)
+		m.ec.primaryDisabled = true
+		m.bc.primaryDisabled = true
+	}
+
+	// Report the change
+	if selectedPair == m.activePair {
+		return
+	}
+	m.activePair = selectedPair
+	switch selectedPair {
+	case primaryClientPair:
+		m.log.Println("Primary Execution and Beacon clients have recovered, switching back to them.")
+		alerting.AlertFallbackClientsChanged(m.cfg, "primary client pair", true)
+	case noHealthyPair:
+		m.log.Printlnf("WARNING: none of the primary or fallback client pairs are healthy (primary Execution client: %s, primary Beacon Node: %s).", describeClientStatus(ecStatus), describeClientStatus(bcStatus))
+		alerting.AlertFallbackClientsChanged(m.cfg, "", false)
+	default:
+		pairName := getFallbackPairName(selectedPair)
+		m.log.Printlnf("WARNING: primary clients are unhealthy (Execution client: %s, Beacon Node: %s), switching to the %s (%s and %s).", describeClientStatus(ecStatus), describeClientStatus(bcStatus), pairName, m.ec.fallbackEcUrls[selectedPair], m.bc.fallbackBcUrls[selectedPair])
+		alerting.AlertFallbackClientsChanged(m.cfg, pairName, true)
+	}
+}
+
+// Get the name of the client pair the managers are using, or an empty string if none of them are healthy
+func (m *FallbackMonitor) GetActivePairName() string {
+	switch m.activePair {
+	case primaryClientPair:
+		return "primary client pair"
+	case noHealthyPair:
+		return ""
+	default:
+		return getFallbackPairName(m.activePair)
+	}
+}
+
+// Check if a client is working, synced, and on the right chain
+func isClientHealthy(status api.ClientStatus) bool {
+	return status.IsWorking && status.IsSynced && status.Error == ""
+}
+
+// Describe a client's status for the logs
+func describeClientStatus(status api.ClientStatus) string {
+	if isClientHealthy(status) {
+		return "healthy"
+	}
+	if status.Error != "" {
+		return status.Error
+	}
+	return fmt.Sprintf("syncing (%.2f%%)", status.SyncProgress*100)
+}
+
+// Get the name of a fallback pair for logs and alerts
+func getFallbackPairName(index int) string {
+	if index == 0 {
+		return "fallback client pair"
+	}
+	return fmt.Sprintf("additional fallback client pair #%d", index)
+}
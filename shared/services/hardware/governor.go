@@ -0,0 +1,212 @@
+package hardware
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// How often the host's pressure is sampled
+	governorSampleInterval = 5 * time.Second
+
+	// How long after the start of a slot to wait so attestations have been made
+	attestationGapDelay = 1 * time.Second
+
+	cpuPressurePath = "/proc/pressure/cpu"
+	ioPressurePath  = "/proc/pressure/io"
+)
+
+// When a priority gives way to the other clients, and for how long
+type governorLimits struct {
+	// The share of time tasks were stalled on CPU or IO in the last 10 seconds, from the kernel's pressure stall information
+	stallPercent float64
+
+	// The CPU usage and IO wait to use instead on kernels without pressure stall information
+	cpuUsagePercent float64
+	ioWaitPercent   float64
+
+	// The longest a single piece of work waits before it runs anyway, so deadlines can still be met
+	maxDeferral time.Duration
+}
+
+var priorityLimits = map[cfgtypes.WatchtowerPriority]governorLimits{
+	cfgtypes.WatchtowerPriority_Low: {
+		stallPercent:    10,
+		cpuUsagePercent: 70,
+		ioWaitPercent:   10,
+		maxDeferral:     5 * time.Minute,
+	},
+	cfgtypes.WatchtowerPriority_Normal: {
+		stallPercent:    30,
+		cpuUsagePercent: 90,
+		ioWaitPercent:   25,
+		maxDeferral:     1 * time.Minute,
+	},
+}
+
+// Throttles the watchtower's heavy calculations while the host's CPU or disk is under pressure, so they don't starve the Validator Client.
+// A nil governor never throttles anything.
+type Governor struct {
+	limits         governorLimits
+	genesisTime    time.Time
+	secondsPerSlot uint64
+	log            *log.ColorLogger
+
+	lock       sync.Mutex
+	lastSample time.Time
+	lastCpu    *cpu.TimesStat
+	busy       bool
+}
+
+// Create a governor for the configured priority, or nil if the watchtower shouldn't give way at all
+func NewGovernor(cfg *config.RocketPoolConfig, genesisTime time.Time, secondsPerSlot uint64, logger *log.ColorLogger) *Governor {
+	limits, exists := priorityLimits[cfg.Smartnode.WatchtowerPriority.Value.(cfgtypes.WatchtowerPriority)]
+	if !exists {
+		return nil
+	}
+	return &Governor{
+		limits:         limits,
+		genesisTime:    genesisTime,
+		secondsPerSlot: secondsPerSlot,
+		log:            logger,
+	}
+}
+
+// Get how many calculations to run at once, which is cut to a quarter of the maximum while the host is busy
+func (g *Governor) Limit(max int) int {
+	if g == nil || !g.isBusy() {
+		return max
+	}
+	limit := max / 4
+	if limit < 1 {
+		limit = 1
+	}
+	return limit
+}
+
+// Wait before the next piece of work while the host is busy, starting it in the gap after a slot's attestations have been made.
+// It gives up waiting after the priority's max deferral so the work still finishes in time, and returns an error only if the context is done.
+func (g *Governor) Wait(ctx context.Context) error {
+	if g == nil {
+		return ctx.Err()
+	}
+	start := time.Now()
+	for g.isBusy() {
+		if time.Since(start) >= g.limits.maxDeferral {
+			return ctx.Err()
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(g.timeUntilAttestationGap()):
+		}
+	}
+	return ctx.Err()
+}
+
+// Get how long until the next slot's attestations have been made, since they're due a third of the way into the slot
+func (g *Governor) timeUntilAttestationGap() time.Duration {
+	slotTime := time.Duration(g.secondsPerSlot) * time.Second
+	if slotTime <= 0 {
+		return governorSampleInterval
+	}
+	attestationTime := slotTime/3 + attestationGapDelay
+	intoSlot := time.Since(g.genesisTime) % slotTime
+	if intoSlot < attestationTime {
+		return attestationTime - intoSlot
+	}
+	return slotTime - intoSlot + attestationTime
+}
+
+// Check if the host is under pressure, sampling it again if the last sample is old
+func (g *Governor) isBusy() bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if time.Since(g.lastSample) < governorSampleInterval {
+		return g.busy
+	}
+	g.lastSample = time.Now()
+
+	busy, description, err := g.sample()
+	if err != nil {
+		g.log.Printlnf("WARNING: couldn't check how busy the machine is, so the watchtower isn't being throttled: %s", err.Error())
+		busy = false
+	}
+	if busy && !g.busy {
+		g.log.Printlnf("The machine is busy (%s), so the watchtower is slowing down to give the other clients room.", description)
+	} else if !busy && g.busy {
+		g.log.Println("The machine isn't busy anymore, so the watchtower is running at full speed again.")
+	}
+	g.busy = busy
+	return busy
+}
+
+// Sample the host's pressure, preferring the kernel's pressure stall information
+func (g *Governor) sample() (bool, string, error) {
+	cpuStall, cpuErr := readPressure(cpuPressurePath)
+	ioStall, ioErr := readPressure(ioPressurePath)
+	if cpuErr == nil && ioErr == nil {
+		if cpuStall >= g.limits.stallPercent {
+			return true, fmt.Sprintf("tasks were waiting for the CPU %.0f%% of the time", cpuStall), nil
+		}
+		if ioStall >= g.limits.stallPercent {
+			return true, fmt.Sprintf("tasks were waiting for the disk %.0f%% of the time", ioStall), nil
+		}
+		return false, "", nil
+	}
+
+	// Fall back to the CPU usage since the last sample
+	times, err := cpu.Times(false)
+	if err != nil {
+		return false, "", fmt.Errorf("error getting CPU times: %w", err)
+	}
+	if len(times) == 0 {
+		return false, "", nil
+	}
+	previous := g.lastCpu
+	g.lastCpu = &times[0]
+	if previous == nil {
+		return false, "", nil
+	}
+	usage, ioWait := getCpuUsage(*previous, times[0])
+	if usage >= g.limits.cpuUsagePercent {
+		return true, fmt.Sprintf("%.0f%% CPU usage", usage), nil
+	}
+	if ioWait >= g.limits.ioWaitPercent {
+		return true, fmt.Sprintf("%.0f%% IO wait", ioWait), nil
+	}
+	return false, "", nil
+}
+
+// Read the share of time some tasks were stalled over the last 10 seconds from a pressure stall information file
+func readPressure(path string) (float64, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	for _, line := range strings.Split(string(bytes), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "some" {
+			continue
+		}
+		value, found := strings.CutPrefix(fields[1], "avg10=")
+		if !found {
+			break
+		}
+		return strconv.ParseFloat(value, 64)
+	}
+	return 0, fmt.Errorf("[%s] doesn't have a 10 second average", path)
+}
@@ -0,0 +1,202 @@
+package hardware
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/mem"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// A snapshot of the host's resource usage
+type HostMetrics struct {
+	Time              time.Time    `json:"time"`
+	CpuUsagePercent   float64      `json:"cpuUsagePercent"`
+	IoWaitPercent     float64      `json:"ioWaitPercent"`
+	MemoryTotal       uint64       `json:"memoryTotal"`
+	MemoryUsed        uint64       `json:"memoryUsed"`
+	MemoryUsedPercent float64      `json:"memoryUsedPercent"`
+	Filesystems       []Filesystem `json:"filesystems"`
+	Devices           []DeviceIo   `json:"devices"`
+	NvmeDrives        []NvmeHealth `json:"nvmeDrives"`
+}
+
+// The space used on one of the filesystems the Smartnode writes to
+type Filesystem struct {
+	Path        string  `json:"path"`
+	Total       uint64  `json:"total"`
+	Used        uint64  `json:"used"`
+	UsedPercent float64 `json:"usedPercent"`
+}
+
+// The IO activity of a block device over the sample period
+type DeviceIo struct {
+	Name               string  `json:"name"`
+	ReadsPerSecond     float64 `json:"readsPerSecond"`
+	WritesPerSecond    float64 `json:"writesPerSecond"`
+	ReadLatencyMs      float64 `json:"readLatencyMs"`
+	WriteLatencyMs     float64 `json:"writeLatencyMs"`
+	UtilizationPercent float64 `json:"utilizationPercent"`
+}
+
+// The health details from an NVMe drive's SMART log
+type NvmeHealth struct {
+	Name                 string  `json:"name"`
+	Model                string  `json:"model"`
+	CriticalWarning      uint8   `json:"criticalWarning"`
+	TemperatureCelsius   float64 `json:"temperatureCelsius"`
+	AvailableSparePct    uint8   `json:"availableSparePct"`
+	PercentageUsed       uint8   `json:"percentageUsed"`
+	DataUnitsWrittenTb   float64 `json:"dataUnitsWrittenTb"`
+	MediaErrors          uint64  `json:"mediaErrors"`
+	UnsafeShutdowns      uint64  `json:"unsafeShutdowns"`
+	PowerOnHours         uint64  `json:"powerOnHours"`
+	SmartUnavailableNote string  `json:"smartUnavailableNote,omitempty"`
+}
+
+// Sample the host's resource usage.
+// CPU and disk IO are rates, so they're measured over the provided interval; the space used is reported for each of the provided paths.
+func Sample(paths []string, interval time.Duration) (*HostMetrics, error) {
+	metrics := &HostMetrics{
+		Filesystems: []Filesystem{},
+		Devices:     []DeviceIo{},
+	}
+
+	// Take the starting counters
+	cpuStart, err := cpu.Times(false)
+	if err != nil {
+		return nil, fmt.Errorf("error getting CPU times: %w", err)
+	}
+	ioStart, err := disk.IOCounters()
+	if err != nil {
+		return nil, fmt.Errorf("error getting disk IO counters: %w", err)
+	}
+	start := time.Now()
+	time.Sleep(interval)
+
+	// Take the ending counters
+	cpuEnd, err := cpu.Times(false)
+	if err != nil {
+		return nil, fmt.Errorf("error getting CPU times: %w", err)
+	}
+	ioEnd, err := disk.IOCounters()
+	if err != nil {
+		return nil, fmt.Errorf("error getting disk IO counters: %w", err)
+	}
+	metrics.Time = time.Now()
+	elapsed := metrics.Time.Sub(start)
+
+	// Get the CPU usage
+	if len(cpuStart) > 0 && len(cpuEnd) > 0 {
+		metrics.CpuUsagePercent, metrics.IoWaitPercent = getCpuUsage(cpuStart[0], cpuEnd[0])
+	}
+
+	// Get the memory usage
+	memory, err := mem.VirtualMemory()
+	if err != nil {
+		return nil, fmt.Errorf("error getting memory usage: %w", err)
+	}
+	metrics.MemoryTotal = memory.Total
+	metrics.MemoryUsed = memory.Used
+	metrics.MemoryUsedPercent = memory.UsedPercent
+
+	// Get the space used on each filesystem, skipping paths that share a filesystem
+	seen := map[string]bool{}
+	for _, path := range paths {
+		usage, err := disk.Usage(path)
+		if err != nil {
+			return nil, fmt.Errorf("error getting disk usage for [%s]: %w", path, err)
+		}
+		key := fmt.Sprintf("%s-%d", usage.Fstype, usage.Total)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		metrics.Filesystems = append(metrics.Filesystems, Filesystem{
+			Path:        path,
+			Total:       usage.Total,
+			Used:        usage.Used,
+			UsedPercent: usage.UsedPercent,
+		})
+	}
+
+	// Get the IO activity of each device
+	for name, end := range ioEnd {
+		begin, exists := ioStart[name]
+		if !exists || isVirtualDevice(name) {
+			continue
+		}
+		metrics.Devices = append(metrics.Devices, getDeviceIo(name, begin, end, elapsed))
+	}
+	sortDevices(metrics.Devices)
+
+	// Get the NVMe health, which isn't available on every system
+	metrics.NvmeDrives = getNvmeHealth()
+
+	return metrics, nil
+}
+
+// Get the overall and IO wait CPU usage between two samples, as percentages
+func getCpuUsage(start cpu.TimesStat, end cpu.TimesStat) (float64, float64) {
+	total := end.Total() - start.Total()
+	if total <= 0 {
+		return 0, 0
+	}
+	idle := (end.Idle + end.Iowait) - (start.Idle + start.Iowait)
+	iowait := end.Iowait - start.Iowait
+	return 100 * (total - idle) / total, 100 * iowait / total
+}
+
+// Get the IO rates and latencies of a device between two samples
+func getDeviceIo(name string, start disk.IOCountersStat, end disk.IOCountersStat, elapsed time.Duration) DeviceIo {
+	seconds := elapsed.Seconds()
+	reads := float64(end.ReadCount - start.ReadCount)
+	writes := float64(end.WriteCount - start.WriteCount)
+	device := DeviceIo{
+		Name:               name,
+		ReadsPerSecond:     reads / seconds,
+		WritesPerSecond:    writes / seconds,
+		UtilizationPercent: 100 * float64(end.IoTime-start.IoTime) / float64(elapsed.Milliseconds()),
+	}
+	if reads > 0 {
+		device.ReadLatencyMs = float64(end.ReadTime-start.ReadTime) / reads
+	}
+	if writes > 0 {
+		device.WriteLatencyMs = float64(end.WriteTime-start.WriteTime) / writes
+	}
+	if device.UtilizationPercent > 100 {
+		device.UtilizationPercent = 100
+	}
+	return device
+}
+
+// Check if a device is a loopback or RAM disk, which aren't worth reporting
+func isVirtualDevice(name string) bool {
+	for _, prefix := range []string{"loop", "ram", "zram"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Sort devices by name so they're listed in a stable order
+func sortDevices(devices []DeviceIo) {
+	sort.Slice(devices, func(i, j int) bool {
+		return devices[i].Name < devices[j].Name
+	})
+}
+
+// Get the paths whose filesystems should be monitored: the root filesystem and the Smartnode's data folder
+func GetMonitoredPaths(cfg *config.RocketPoolConfig) []string {
+	dataPath := config.DaemonDataPath
+	if cfg.IsNativeMode {
+		dataPath = cfg.Smartnode.DataPath.Value.(string)
+	}
+	return []string{"/", dataPath}
+}
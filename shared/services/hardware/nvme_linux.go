@@ -0,0 +1,132 @@
+//go:build linux
+// +build linux
+
+package hardware
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+// NVMe admin command settings
+const (
+	// _IOWR('N', 0x41, struct nvme_admin_cmd)
+	nvmeIoctlAdminCmd uintptr = 0xC0484E41
+
+	// The Get Log Page admin command
+	nvmeAdminGetLogPage uint8 = 0x02
+
+	// The SMART / Health Information log page, which is 512 bytes long
+	nvmeLogSmart   uint32 = 0x02
+	nvmeLogSmartSz uint32 = 512
+
+	// The namespace ID that refers to the whole controller
+	nvmeNsidAll uint32 = 0xFFFFFFFF
+
+	// The folder the kernel lists NVMe controllers in
+	nvmeClassPath string = "/sys/class/nvme"
+)
+
+// The kernel's struct nvme_passthru_cmd
+type nvmePassthruCmd struct {
+	opcode      uint8
+	flags       uint8
+	rsvd1       uint16
+	nsid        uint32
+	cdw2        uint32
+	cdw3        uint32
+	metadata    uint64
+	addr        uint64
+	metadataLen uint32
+	dataLen     uint32
+	cdw10       uint32
+	cdw11       uint32
+	cdw12       uint32
+	cdw13       uint32
+	cdw14       uint32
+	cdw15       uint32
+	timeoutMs   uint32
+	result      uint32
+}
+
+// Read the SMART health log of every NVMe controller the kernel knows about.
+// Controllers whose device nodes can't be opened (such as inside a container without access to them) are listed with a note instead.
+func getNvmeHealth() []NvmeHealth {
+	drives := []NvmeHealth{}
+	entries, err := os.ReadDir(nvmeClassPath)
+	if err != nil {
+		return drives
+	}
+
+	names := []string{}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), "nvme") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		drive := NvmeHealth{
+			Name: name,
+		}
+		model, err := os.ReadFile(filepath.Join(nvmeClassPath, name, "model"))
+		if err == nil {
+			drive.Model = strings.TrimSpace(string(model))
+		}
+		log, err := readNvmeSmartLog("/dev/" + name)
+		if err != nil {
+			drive.SmartUnavailableNote = err.Error()
+		} else {
+			parseNvmeSmartLog(log, &drive)
+		}
+		drives = append(drives, drive)
+	}
+	return drives
+}
+
+// Read the raw SMART log page from an NVMe controller
+func readNvmeSmartLog(devicePath string) ([]byte, error) {
+	file, err := os.Open(devicePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	buffer := make([]byte, nvmeLogSmartSz)
+	numDwords := nvmeLogSmartSz/4 - 1
+	cmd := nvmePassthruCmd{
+		opcode:  nvmeAdminGetLogPage,
+		nsid:    nvmeNsidAll,
+		addr:    uint64(uintptr(unsafe.Pointer(&buffer[0]))),
+		dataLen: nvmeLogSmartSz,
+		cdw10:   (numDwords << 16) | nvmeLogSmart,
+	}
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, file.Fd(), nvmeIoctlAdminCmd, uintptr(unsafe.Pointer(&cmd)))
+	runtime.KeepAlive(buffer)
+	if errno != 0 {
+		return nil, errno
+	}
+	return buffer, nil
+}
+
+// Pull the interesting fields out of a SMART log page
+func parseNvmeSmartLog(log []byte, drive *NvmeHealth) {
+	drive.CriticalWarning = log[0]
+	drive.TemperatureCelsius = float64(binary.LittleEndian.Uint16(log[1:3])) - 273.15
+	drive.AvailableSparePct = log[3]
+	drive.PercentageUsed = log[5]
+
+	// The 128-bit counters are read as 64-bit, which won't overflow on any real drive.
+	// Data units are thousands of 512-byte blocks.
+	drive.DataUnitsWrittenTb = float64(binary.LittleEndian.Uint64(log[48:56])) * 512000 / 1e12
+	drive.PowerOnHours = binary.LittleEndian.Uint64(log[128:136])
+	drive.UnsafeShutdowns = binary.LittleEndian.Uint64(log[144:152])
+	drive.MediaErrors = binary.LittleEndian.Uint64(log[160:168])
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+// +build !linux
+
+package hardware
+
+// NVMe SMART logs are only read on Linux
+func getNvmeHealth() []NvmeHealth {
+	return []NvmeHealth{}
+}
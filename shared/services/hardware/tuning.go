@@ -0,0 +1,277 @@
+package hardware
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+const (
+	recommendedSwappiness    uint64 = 10
+	recommendedFileMax       uint64 = 1048576
+	recommendedHugePages     string = "madvise"
+	hugePagesPath            string = "/sys/kernel/mm/transparent_hugepage/enabled"
+	sysctlDropInFormat       string = "/etc/sysctl.d/99-rocketpool-%s.conf"
+	hugePagesTmpfilesDropIn  string = "/etc/tmpfiles.d/rocketpool-transparent-hugepage.conf"
+	defaultAtimeMountOptions string = "relatime"
+)
+
+// A host setting that affects how well the clients run, with what it should be changed to
+type HostTuning struct {
+	// The name of the setting
+	Name string
+
+	// What the setting does and why the recommendation helps
+	Description string
+
+	// The setting's current value
+	Current string
+
+	// The value it's recommended to have
+	Recommended string
+
+	// Whether the setting already matches the recommendation
+	Tuned bool
+
+	// The shell commands that apply the recommendation as root, or blank if it has to be changed by hand
+	ApplyScript string
+
+	// The shell commands that put the current value back as root
+	UndoScript string
+}
+
+// Inspect the host's kernel and system settings that matter for staking, comparing each one to the recommended value.
+// The mount options are checked for the filesystems holding the provided paths.
+func GetHostTunings(dataPaths []string) ([]HostTuning, error) {
+	if runtime.GOOS != "linux" {
+		return nil, fmt.Errorf("host tuning is only supported on Linux")
+	}
+
+	tunings := []HostTuning{}
+	swappiness, err := getSwappinessTuning()
+	if err != nil {
+		return nil, err
+	}
+	tunings = append(tunings, swappiness)
+	fileMax, err := getFileMaxTuning()
+	if err != nil {
+		return nil, err
+	}
+	tunings = append(tunings, fileMax)
+	tunings = append(tunings, getNtpTuning())
+	mounts, err := getNoatimeTunings(dataPaths)
+	if err != nil {
+		return nil, err
+	}
+	tunings = append(tunings, mounts...)
+	hugePages, err := getHugePagesTuning()
+	if err != nil {
+		return nil, err
+	}
+	tunings = append(tunings, hugePages)
+	return tunings, nil
+}
+
+// Read a number from one of the kernel's settings files
+func readKernelNumber(path string) (uint64, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	value, err := strconv.ParseUint(strings.TrimSpace(string(bytes)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing %s: %w", path, err)
+	}
+	return value, nil
+}
+
+// Get the scripts that set a sysctl value now and on every boot, and put the old value back
+func getSysctlScripts(key string, name string, value uint64, oldValue uint64) (string, string) {
+	dropIn := fmt.Sprintf(sysctlDropInFormat, name)
+	apply := fmt.Sprintf("sysctl -w %s=%d && echo '%s=%d' > %s", key, value, key, value, dropIn)
+	undo := fmt.Sprintf("rm -f %s && sysctl -w %s=%d", dropIn, key, oldValue)
+	return apply, undo
+}
+
+// Check how eagerly the kernel swaps memory out
+func getSwappinessTuning() (HostTuning, error) {
+	swappiness, err := readKernelNumber("/proc/sys/vm/swappiness")
+	if err != nil {
+		return HostTuning{}, err
+	}
+	swapTotal, err := getSwapTotal()
+	if err != nil {
+		return HostTuning{}, err
+	}
+
+	tuning := HostTuning{
+		Name:        "Swappiness",
+		Description: "The clients keep their hot data in RAM. A low swappiness keeps the kernel from moving it to swap while there's still free memory, which would slow down attestations.",
+		Current:     fmt.Sprint(swappiness),
+		Recommended: fmt.Sprint(recommendedSwappiness),
+		Tuned:       swappiness <= recommendedSwappiness,
+	}
+	if swapTotal == 0 {
+		// Without swap there's nothing to tune
+		tuning.Current = fmt.Sprintf("%d (no swap)", swappiness)
+		tuning.Tuned = true
+	}
+	tuning.ApplyScript, tuning.UndoScript = getSysctlScripts("vm.swappiness", "swappiness", recommendedSwappiness, swappiness)
+	return tuning, nil
+}
+
+// Get the amount of swap space on the host
+func getSwapTotal() (uint64, error) {
+	bytes, err := os.ReadFile("/proc/meminfo")
+	if err != nil {
+		return 0, fmt.Errorf("error reading /proc/meminfo: %w", err)
+	}
+	for _, line := range strings.Split(string(bytes), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "SwapTotal:" {
+			return strconv.ParseUint(fields[1], 10, 64)
+		}
+	}
+	return 0, nil
+}
+
+// Check how many files the kernel allows to be open at once
+func getFileMaxTuning() (HostTuning, error) {
+	fileMax, err := readKernelNumber("/proc/sys/fs/file-max")
+	if err != nil {
+		return HostTuning{}, err
+	}
+	tuning := HostTuning{
+		Name:        "Open File Limit",
+		Description: "The clients' databases keep many files open at once. If the kernel's limit is reached, the clients can't open new files or peer connections and will crash.",
+		Current:     fmt.Sprint(fileMax),
+		Recommended: fmt.Sprintf("at least %d", recommendedFileMax),
+		Tuned:       fileMax >= recommendedFileMax,
+	}
+	tuning.ApplyScript, tuning.UndoScript = getSysctlScripts("fs.file-max", "file-max", recommendedFileMax, fileMax)
+	return tuning, nil
+}
+
+// Check that the system clock is kept in sync with an NTP server
+func getNtpTuning() HostTuning {
+	tuning := HostTuning{
+		Name:        "Time Synchronization",
+		Description: "Attestations and proposals are only accepted during their slot, so the system clock has to be kept in sync with an NTP server.",
+		Recommended: "enabled and synchronized",
+	}
+
+	output, err := exec.Command("timedatectl", "show", "--property=NTP", "--property=NTPSynchronized").Output()
+	if err != nil {
+		tuning.Current = "unknown"
+		tuning.Description += " This system doesn't use systemd-timesyncd, so make sure an NTP service such as chrony is installed and running."
+		return tuning
+	}
+	properties := map[string]string{}
+	for _, line := range strings.Split(string(output), "\n") {
+		key, value, found := strings.Cut(strings.TrimSpace(line), "=")
+		if found {
+			properties[key] = value
+		}
+	}
+
+	switch {
+	case properties["NTP"] != "yes":
+		tuning.Current = "disabled"
+		tuning.ApplyScript = "timedatectl set-ntp true"
+		tuning.UndoScript = "timedatectl set-ntp false"
+	case properties["NTPSynchronized"] != "yes":
+		tuning.Current = "enabled, but not synchronized"
+		tuning.Description += " Make sure the host can reach its NTP servers on UDP port 123."
+	default:
+		tuning.Current = "enabled and synchronized"
+		tuning.Tuned = true
+	}
+	return tuning
+}
+
+// Check that the filesystems holding the chain data don't write a new access time every time a file is read
+func getNoatimeTunings(dataPaths []string) ([]HostTuning, error) {
+	partitions, err := disk.Partitions(true)
+	if err != nil {
+		return nil, fmt.Errorf("error getting partition list: %w", err)
+	}
+
+	tunings := []HostTuning{}
+	checked := map[string]bool{}
+	for _, path := range dataPaths {
+		// Find the filesystem the path is on
+		longestPath := 0
+		bestPartition := disk.PartitionStat{}
+		for _, partition := range partitions {
+			if strings.HasPrefix(path, partition.Mountpoint) && len(partition.Mountpoint) > longestPath {
+				bestPartition = partition
+				longestPath = len(partition.Mountpoint)
+			}
+		}
+		if longestPath == 0 || checked[bestPartition.Mountpoint] {
+			continue
+		}
+		checked[bestPartition.Mountpoint] = true
+
+		atime := defaultAtimeMountOptions
+		noatime := false
+		for _, option := range bestPartition.Opts {
+			switch option {
+			case "noatime":
+				noatime = true
+			case "relatime", "strictatime":
+				atime = option
+			}
+		}
+		current := atime
+		if noatime {
+			current = "noatime"
+		}
+		tunings = append(tunings, HostTuning{
+			Name:        fmt.Sprintf("Access Times on %s", bestPartition.Mountpoint),
+			Description: fmt.Sprintf("The chain data in %s is read constantly. With noatime, reads don't cause extra writes to the disk. This only lasts until the next reboot; add noatime to the options for %s in /etc/fstab to keep it.", path, bestPartition.Mountpoint),
+			Current:     current,
+			Recommended: "noatime",
+			Tuned:       noatime,
+			ApplyScript: fmt.Sprintf("mount -o remount,noatime %s", bestPartition.Mountpoint),
+			UndoScript:  fmt.Sprintf("mount -o remount,%s %s", atime, bestPartition.Mountpoint),
+		})
+	}
+	return tunings, nil
+}
+
+// Check when the kernel backs memory with transparent huge pages
+func getHugePagesTuning() (HostTuning, error) {
+	tuning := HostTuning{
+		Name:        "Transparent Huge Pages",
+		Description: "When huge pages are always on, the kernel stalls the clients while it compacts memory for them. With madvise, only programs that ask for huge pages get them.",
+		Recommended: recommendedHugePages,
+	}
+
+	bytes, err := os.ReadFile(hugePagesPath)
+	if os.IsNotExist(err) {
+		// The kernel was built without them
+		tuning.Current = "not supported"
+		tuning.Tuned = true
+		return tuning, nil
+	}
+	if err != nil {
+		return HostTuning{}, fmt.Errorf("error reading %s: %w", hugePagesPath, err)
+	}
+
+	// The selected mode is the one in brackets, e.g. `always [madvise] never`
+	for _, mode := range strings.Fields(string(bytes)) {
+		if strings.HasPrefix(mode, "[") && strings.HasSuffix(mode, "]") {
+			tuning.Current = strings.Trim(mode, "[]")
+		}
+	}
+	tuning.Tuned = tuning.Current != "always"
+	tuning.ApplyScript = fmt.Sprintf("echo %s > %s && echo 'w %s - - - - %s' > %s", recommendedHugePages, hugePagesPath, hugePagesPath, recommendedHugePages, hugePagesTmpfilesDropIn)
+	tuning.UndoScript = fmt.Sprintf("rm -f %s && echo %s > %s", hugePagesTmpfilesDropIn, tuning.Current, hugePagesPath)
+	return tuning, nil
+}
@@ -0,0 +1,112 @@
+package heartbeat
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	pingTimeout time.Duration = 10 * time.Second
+
+	// The shortest time between failure pings, so a daemon that's retrying quickly doesn't flood the monitor
+	failureCooldown time.Duration = time.Minute
+
+	// The most of an error message to send with a failure ping
+	maxFailureMessageLength int = 1000
+)
+
+// Pings an external uptime monitor, such as Healthchecks.io or Better Uptime, to show that a daemon is still doing its job.
+// A nil Heartbeat is valid and does nothing, so callers don't need to check whether one is configured.
+type Heartbeat struct {
+	url            string
+	reportFailures bool
+	client         *http.Client
+	logger         *log.ColorLogger
+
+	lock            sync.Mutex
+	lastFailureTime time.Time
+}
+
+// Create a heartbeat that pings the provided URL, or nil if the URL is blank
+func NewHeartbeat(cfg *config.RocketPoolConfig, url string, logger *log.ColorLogger) *Heartbeat {
+	url = strings.TrimSpace(url)
+	if url == "" {
+		return nil
+	}
+	return &Heartbeat{
+		url:            strings.TrimSuffix(url, "/"),
+		reportFailures: cfg.Heartbeat.ReportFailures.Value == true,
+		client:         &http.Client{Timeout: pingTimeout},
+		logger:         logger,
+	}
+}
+
+// Report that a cycle finished successfully
+func (h *Heartbeat) Success() {
+	if h == nil {
+		return
+	}
+	go h.ping(h.url, "")
+}
+
+// Report that a cycle failed
+func (h *Heartbeat) Failure(err error) {
+	if h == nil || !h.reportFailures {
+		return
+	}
+
+	h.lock.Lock()
+	if time.Since(h.lastFailureTime) < failureCooldown {
+		h.lock.Unlock()
+		return
+	}
+	h.lastFailureTime = time.Now()
+	h.lock.Unlock()
+
+	message := err.Error()
+	if len(message) > maxFailureMessageLength {
+		message = message[:maxFailureMessageLength]
+	}
+	go h.ping(h.url+"/fail", message)
+}
+
+// Send a ping, with an optional message in the body. Errors are logged, since a missed ping shouldn't stop the daemon.
+func (h *Heartbeat) ping(url string, message string) {
+	var err error
+	var response *http.Response
+	if message == "" {
+		response, err = h.client.Get(url)
+	} else {
+		response, err = h.client.Post(url, "text/plain", strings.NewReader(message))
+	}
+	if err != nil {
+		h.logger.Printlnf("WARNING: error sending heartbeat: %s", err.Error())
+		return
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+	if response.StatusCode/100 != 2 {
+		h.logger.Printlnf("WARNING: heartbeat monitor returned status %d", response.StatusCode)
+	}
+}
+
+// Describe where the heartbeat is sent, without any secret tokens in the URL
+func (h *Heartbeat) String() string {
+	if h == nil {
+		return "disabled"
+	}
+	parts := strings.SplitN(h.url, "://", 2)
+	host := parts[len(parts)-1]
+	if index := strings.Index(host, "/"); index >= 0 {
+		host = host[:index]
+	}
+	return fmt.Sprintf("enabled (%s)", host)
+}
@@ -0,0 +1,212 @@
+package hostguard
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/rocket-pool/smartnode/shared/services/backup"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// Settings
+const (
+	hostIdLength int         = 16
+	fileMode     os.FileMode = 0600
+
+	// The message signed for each beacon: the network, node address, host ID, and Unix timestamp
+	beaconMessageFormat string = "rocketpool-active-host:%s:%s:%s:%d"
+
+	// The name of the beacon in remote storage: the network and node address
+	remoteBeaconNameFormat string = "active-host-%s-%s.json"
+)
+
+// Get this machine's host ID, creating one if it doesn't have one yet.
+// The ID lives in the data folder but isn't part of backups, so a restored copy of the node on another machine gets a new one.
+func GetHostId(cfg *config.RocketPoolConfig) (string, error) {
+	path := cfg.Smartnode.GetHostIdPath(true)
+	bytes, err := os.ReadFile(path)
+	if err == nil {
+		return strings.TrimSpace(string(bytes)), nil
+	}
+	if !os.IsNotExist(err) {
+		return "", fmt.Errorf("error reading host ID [%s]: %w", path, err)
+	}
+
+	id := make([]byte, hostIdLength)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("error generating host ID: %w", err)
+	}
+	hostId := hex.EncodeToString(id)
+	if err := files.WriteFileAtomic(path, []byte(hostId), fileMode); err != nil {
+		return "", fmt.Errorf("error saving host ID [%s]: %w", path, err)
+	}
+	return hostId, nil
+}
+
+// Create a beacon that claims this machine is the one running the node's validators, signed with the node wallet
+func NewBeacon(cfg *config.RocketPoolConfig, w *wallet.Wallet, hostId string) (api.ActiveHostBeacon, error) {
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return api.ActiveHostBeacon{}, err
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	beacon := api.ActiveHostBeacon{
+		Network:     string(cfg.Smartnode.Network.Value.(cfgtypes.Network)),
+		NodeAddress: nodeAccount.Address,
+		HostId:      hostId,
+		Hostname:    hostname,
+		Timestamp:   time.Unix(time.Now().Unix(), 0).UTC(),
+	}
+	signature, err := w.SignMessage(getBeaconMessage(beacon))
+	if err != nil {
+		return api.ActiveHostBeacon{}, fmt.Errorf("error signing active host beacon: %w", err)
+	}
+	beacon.Signature = hex.EncodeToString(signature)
+	return beacon, nil
+}
+
+// Check that a beacon was signed by the node's wallet
+func VerifyBeacon(beacon api.ActiveHostBeacon, nodeAddress common.Address) error {
+	if beacon.NodeAddress != nodeAddress {
+		return fmt.Errorf("the beacon is for node %s, not %s", beacon.NodeAddress.Hex(), nodeAddress.Hex())
+	}
+	signature, err := hex.DecodeString(beacon.Signature)
+	if err != nil || len(signature) != crypto.SignatureLength {
+		return fmt.Errorf("the beacon's signature is malformed")
+	}
+	signature[crypto.RecoveryIDOffset] -= 27
+	publicKey, err := crypto.SigToPub(accounts.TextHash([]byte(getBeaconMessage(beacon))), signature)
+	if err != nil {
+		return fmt.Errorf("error recovering the beacon's signer: %w", err)
+	}
+	if signer := crypto.PubkeyToAddress(*publicKey); signer != nodeAddress {
+		return fmt.Errorf("the beacon was signed by %s instead of the node wallet", signer.Hex())
+	}
+	return nil
+}
+
+// Get the beacon this machine last published, or nil if it hasn't published one
+func LoadLocalBeacon(cfg *config.RocketPoolConfig) (*api.ActiveHostBeacon, error) {
+	var beacon api.ActiveHostBeacon
+	found, err := loadJson(cfg.Smartnode.GetActiveHostPath(true), &beacon)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &beacon, nil
+}
+
+// Get the beacon most recently published to remote storage by any machine running the node, or nil if there isn't one.
+// Beacons that weren't signed by the node wallet are rejected.
+func LoadRemoteBeacon(storage backup.RemoteStorage, network string, nodeAddress common.Address) (*api.ActiveHostBeacon, error) {
+	bytes, err := storage.Download(getRemoteBeaconName(network, nodeAddress))
+	if errors.Is(err, backup.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var beacon api.ActiveHostBeacon
+	if err := json.Unmarshal(bytes, &beacon); err != nil {
+		return nil, fmt.Errorf("error decoding the remote active host beacon: %w", err)
+	}
+	if err := VerifyBeacon(beacon, nodeAddress); err != nil {
+		return nil, fmt.Errorf("the remote active host beacon isn't valid: %w", err)
+	}
+	return &beacon, nil
+}
+
+// Save a beacon locally, and upload it to remote storage if there is any
+func PublishBeacon(cfg *config.RocketPoolConfig, storage backup.RemoteStorage, beacon api.ActiveHostBeacon) error {
+	bytes, err := json.Marshal(beacon)
+	if err != nil {
+		return fmt.Errorf("error serializing active host beacon: %w", err)
+	}
+	path := cfg.Smartnode.GetActiveHostPath(true)
+	if err := files.WriteFileAtomic(path, bytes, fileMode); err != nil {
+		return fmt.Errorf("error saving active host beacon [%s]: %w", path, err)
+	}
+	if storage != nil {
+		if err := storage.Upload(getRemoteBeaconName(beacon.Network, beacon.NodeAddress), bytes); err != nil {
+			return fmt.Errorf("error uploading active host beacon: %w", err)
+		}
+	}
+	return nil
+}
+
+// Get the conflict that's blocking validator duties, or nil if there isn't one
+func LoadConflict(cfg *config.RocketPoolConfig) (*api.ActiveHostConflict, error) {
+	var conflict api.ActiveHostConflict
+	found, err := loadJson(cfg.Smartnode.GetActiveHostConflictPath(true), &conflict)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &conflict, nil
+}
+
+// Record a conflict, which blocks validator duties until it's cleared
+func SaveConflict(cfg *config.RocketPoolConfig, reason string) (api.ActiveHostConflict, error) {
+	conflict := api.ActiveHostConflict{
+		Time:   time.Now().UTC(),
+		Reason: reason,
+	}
+	bytes, err := json.Marshal(conflict)
+	if err != nil {
+		return conflict, fmt.Errorf("error serializing active host conflict: %w", err)
+	}
+	path := cfg.Smartnode.GetActiveHostConflictPath(true)
+	if err := files.WriteFileAtomic(path, bytes, fileMode); err != nil {
+		return conflict, fmt.Errorf("error saving active host conflict [%s]: %w", path, err)
+	}
+	return conflict, nil
+}
+
+// Clear the conflict so validator duties can resume
+func ClearConflict(cfg *config.RocketPoolConfig) error {
+	path := cfg.Smartnode.GetActiveHostConflictPath(true)
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error removing active host conflict [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Get the message a beacon's signature covers
+func getBeaconMessage(beacon api.ActiveHostBeacon) string {
+	return fmt.Sprintf(beaconMessageFormat, beacon.Network, beacon.NodeAddress.Hex(), beacon.HostId, beacon.Timestamp.Unix())
+}
+
+// Get the name of a node's beacon in remote storage
+func getRemoteBeaconName(network string, nodeAddress common.Address) string {
+	return fmt.Sprintf(remoteBeaconNameFormat, network, strings.ToLower(nodeAddress.Hex()))
+}
+
+// Load a JSON file, returning false if it doesn't exist
+func loadJson(path string, value interface{}) (bool, error) {
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error reading [%s]: %w", path, err)
+	}
+	if err := json.Unmarshal(bytes, value); err != nil {
+		return false, fmt.Errorf("error decoding [%s]: %w", path, err)
+	}
+	return true, nil
+}
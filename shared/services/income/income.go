@@ -0,0 +1,108 @@
+package income
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// The kind of income an event represents
+type EventType string
+
+const (
+	EventType_RplRewards           EventType = "rpl_rewards"
+	EventType_SmoothingPool        EventType = "smoothing_pool"
+	EventType_MinipoolDistribution EventType = "minipool_distribution"
+	EventType_FeeDistribution      EventType = "fee_distribution"
+)
+
+// The asset an event was paid in
+type Asset string
+
+const (
+	Asset_Eth Asset = "ETH"
+	Asset_Rpl Asset = "RPL"
+)
+
+// A single income event for the node
+type Event struct {
+	Time         time.Time   `json:"time"`
+	Type         EventType   `json:"type"`
+	Asset        Asset       `json:"asset"`
+	Amount       *big.Int    `json:"amount"`
+	Source       string      `json:"source"`
+	Block        uint64      `json:"block,omitempty"`
+	TxHash       common.Hash `json:"txHash,omitempty"`
+	Claimed      bool        `json:"claimed"`
+	FiatCurrency string      `json:"fiatCurrency,omitempty"`
+	FiatPrice    float64     `json:"fiatPrice,omitempty"`
+	FiatValue    float64     `json:"fiatValue,omitempty"`
+}
+
+// Sort events by time, oldest first
+func SortEvents(events []Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+}
+
+// Write the events as a CSV file with a header row
+func WriteCsv(writer io.Writer, events []Event) error {
+	csvWriter := csv.NewWriter(writer)
+	err := csvWriter.Write([]string{"time", "type", "asset", "amount", "source", "block", "tx_hash", "claimed", "fiat_currency", "fiat_price", "fiat_value"})
+	if err != nil {
+		return fmt.Errorf("error writing CSV header: %w", err)
+	}
+
+	for _, event := range events {
+		block := ""
+		txHash := ""
+		if event.Block != 0 {
+			block = strconv.FormatUint(event.Block, 10)
+			txHash = event.TxHash.Hex()
+		}
+		fiatPrice := ""
+		fiatValue := ""
+		if event.FiatCurrency != "" {
+			fiatPrice = strconv.FormatFloat(event.FiatPrice, 'f', -1, 64)
+			fiatValue = strconv.FormatFloat(event.FiatValue, 'f', 2, 64)
+		}
+		err = csvWriter.Write([]string{
+			event.Time.UTC().Format(time.RFC3339),
+			string(event.Type),
+			string(event.Asset),
+			strconv.FormatFloat(eth.WeiToEth(event.Amount), 'f', 18, 64),
+			event.Source,
+			block,
+			txHash,
+			strconv.FormatBool(event.Claimed),
+			event.FiatCurrency,
+			fiatPrice,
+			fiatValue,
+		})
+		if err != nil {
+			return fmt.Errorf("error writing CSV row: %w", err)
+		}
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// Write the events as an indented JSON array
+func WriteJson(writer io.Writer, events []Event) error {
+	bytes, err := json.MarshalIndent(events, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing income events: %w", err)
+	}
+	_, err = writer.Write(bytes)
+	return err
+}
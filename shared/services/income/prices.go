@@ -0,0 +1,107 @@
+package income
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+const (
+	PriceFeed_None      string = "none"
+	PriceFeed_CoinGecko string = "coingecko"
+
+	coinGeckoHistoryUrl string = "https://api.coingecko.com/api/v3/coins/%s/history?date=%s&localization=false"
+)
+
+// A source of historical asset prices in a fiat currency
+type PriceFeed interface {
+	// Get the price of one unit of the asset on the day of the provided time
+	GetPrice(asset Asset, date time.Time) (float64, error)
+}
+
+// Create the price feed with the provided name
+func NewPriceFeed(name string, currency string) (PriceFeed, error) {
+	switch name {
+	case PriceFeed_None:
+		return nil, nil
+	case PriceFeed_CoinGecko:
+		return &coinGeckoPriceFeed{
+			currency: strings.ToLower(currency),
+			cache:    map[string]float64{},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown price feed [%s]", name)
+	}
+}
+
+// Add the fiat price and value of each event using the provided feed
+func AddFiatValues(events []Event, feed PriceFeed, currency string) error {
+	for i := range events {
+		price, err := feed.GetPrice(events[i].Asset, events[i].Time)
+		if err != nil {
+			return fmt.Errorf("error getting the %s price on %s: %w", events[i].Asset, events[i].Time.UTC().Format("2006-01-02"), err)
+		}
+		events[i].FiatCurrency = strings.ToUpper(currency)
+		events[i].FiatPrice = price
+		events[i].FiatValue = eth.WeiToEth(events[i].Amount) * price
+	}
+	return nil
+}
+
+// Daily historical prices from the public CoinGecko API
+type coinGeckoPriceFeed struct {
+	currency string
+	cache    map[string]float64
+}
+
+func (f *coinGeckoPriceFeed) GetPrice(asset Asset, date time.Time) (float64, error) {
+	var coinID string
+	switch asset {
+	case Asset_Eth:
+		coinID = "ethereum"
+	case Asset_Rpl:
+		coinID = "rocket-pool"
+	default:
+		return 0, fmt.Errorf("unsupported asset [%s]", asset)
+	}
+
+	dateString := date.UTC().Format("02-01-2006")
+	cacheKey := coinID + "-" + dateString
+	if price, exists := f.cache[cacheKey]; exists {
+		return price, nil
+	}
+
+	response, err := http.Get(fmt.Sprintf(coinGeckoHistoryUrl, coinID, dateString))
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return 0, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("CoinGecko returned HTTP status %d: %s", response.StatusCode, string(body))
+	}
+
+	var history struct {
+		MarketData struct {
+			CurrentPrice map[string]float64 `json:"current_price"`
+		} `json:"market_data"`
+	}
+	err = json.Unmarshal(body, &history)
+	if err != nil {
+		return 0, fmt.Errorf("error decoding CoinGecko response: %w", err)
+	}
+	price, exists := history.MarketData.CurrentPrice[f.currency]
+	if !exists {
+		return 0, fmt.Errorf("CoinGecko has no %s price for %s on %s", strings.ToUpper(f.currency), asset, dateString)
+	}
+	f.cache[cacheKey] = price
+	return price, nil
+}
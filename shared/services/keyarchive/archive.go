@@ -0,0 +1,314 @@
+package keyarchive
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+const (
+	// The file written into an unlocked key folder, holding the hash of the archive contents it was unlocked from
+	MarkerFilename string = ".rocketpool-unlocked"
+
+	archiveMagic string = "RPKA1"
+	saltLength   int    = 16
+	keyLength    int    = 32
+
+	// scrypt parameters, matching the ones used for validator keystores
+	scryptN int = 262144
+	scryptR int = 8
+	scryptP int = 1
+
+	dirMode  os.FileMode = 0770
+	fileMode os.FileMode = 0600
+)
+
+// Encrypt the contents of a folder into an archive at archivePath, replacing any existing archive.
+// Returns the hash of the archived contents.
+func EncryptDir(dir string, archivePath string, password string) (string, error) {
+	contents, err := buildTar(dir)
+	if err != nil {
+		return "", err
+	}
+	hash := hashContents(contents)
+
+	// Compress
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	_, err = gzipWriter.Write(contents)
+	if err != nil {
+		return "", fmt.Errorf("error compressing validator keys: %w", err)
+	}
+	err = gzipWriter.Close()
+	if err != nil {
+		return "", fmt.Errorf("error compressing validator keys: %w", err)
+	}
+
+	// Encrypt with a fresh salt and nonce every time, so re-encrypting also rotates the key
+	salt := make([]byte, saltLength)
+	_, err = rand.Read(salt)
+	if err != nil {
+		return "", fmt.Errorf("error generating salt: %w", err)
+	}
+	aead, err := getCipher(password, salt)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return "", fmt.Errorf("error generating nonce: %w", err)
+	}
+	header := append([]byte(archiveMagic), salt...)
+	ciphertext := aead.Seal(nil, nonce, compressed.Bytes(), header)
+
+	// Write atomically so an interrupted write never corrupts the existing archive
+	archive := append(append(header, nonce...), ciphertext...)
+	err = files.WriteFileAtomic(archivePath, archive, fileMode)
+	if err != nil {
+		return "", fmt.Errorf("error writing validator key archive to [%s]: %w", archivePath, err)
+	}
+	return hash, nil
+}
+
+// Decrypt an archive into a folder and mark the folder as unlocked. Returns the hash of the archived contents.
+func DecryptToDir(archivePath string, dir string, password string) (string, error) {
+	contents, err := readArchive(archivePath, password)
+	if err != nil {
+		return "", err
+	}
+	hash := hashContents(contents)
+
+	// Extract
+	err = os.MkdirAll(dir, dirMode)
+	if err != nil {
+		return "", fmt.Errorf("error creating validator key folder [%s]: %w", dir, err)
+	}
+	err = extractTar(contents, dir)
+	if err != nil {
+		return "", err
+	}
+	err = MarkUnlocked(dir, hash)
+	if err != nil {
+		return "", err
+	}
+	return hash, nil
+}
+
+// Decrypt an archive without extracting it, returning the hash of the archived contents
+func VerifyArchive(archivePath string, password string) (string, error) {
+	contents, err := readArchive(archivePath, password)
+	if err != nil {
+		return "", err
+	}
+	return hashContents(contents), nil
+}
+
+// Check if a folder was unlocked from an archive, and get the hash of the contents it was unlocked with
+func GetUnlockedHash(dir string) (string, bool, error) {
+	hash, err := os.ReadFile(filepath.Join(dir, MarkerFilename))
+	if os.IsNotExist(err) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("error reading validator key folder marker: %w", err)
+	}
+	return string(hash), true, nil
+}
+
+// Mark a folder as unlocked with the provided contents hash
+func MarkUnlocked(dir string, hash string) error {
+	err := files.WriteFileAtomic(filepath.Join(dir, MarkerFilename), []byte(hash), fileMode)
+	if err != nil {
+		return fmt.Errorf("error marking validator key folder as unlocked: %w", err)
+	}
+	return nil
+}
+
+// Get the hash of a folder's current contents, for checking if it has changed since it was unlocked
+func GetDirHash(dir string) (string, error) {
+	contents, err := buildTar(dir)
+	if err != nil {
+		return "", err
+	}
+	return hashContents(contents), nil
+}
+
+// Check if a path is a tmpfs mount point, so decrypted keys are never written to disk
+func IsTmpfs(path string) (bool, error) {
+	file, err := os.Open("/proc/mounts")
+	if err != nil {
+		return false, fmt.Errorf("error reading mounts: %w", err)
+	}
+	defer file.Close()
+
+	path = filepath.Clean(path)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 3 && fields[1] == path && fields[2] == "tmpfs" {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
+
+// Read, decrypt, and decompress an archive into its tarball
+func readArchive(archivePath string, password string) ([]byte, error) {
+	archive, err := os.ReadFile(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading validator key archive [%s]: %w", archivePath, err)
+	}
+	headerLength := len(archiveMagic) + saltLength
+	if len(archive) < headerLength || string(archive[:len(archiveMagic)]) != archiveMagic {
+		return nil, fmt.Errorf("[%s] is not a validator key archive", archivePath)
+	}
+
+	// Decrypt
+	header := archive[:headerLength]
+	aead, err := getCipher(password, archive[len(archiveMagic):headerLength])
+	if err != nil {
+		return nil, err
+	}
+	if len(archive) < headerLength+aead.NonceSize() {
+		return nil, fmt.Errorf("validator key archive [%s] is truncated", archivePath)
+	}
+	nonce := archive[headerLength : headerLength+aead.NonceSize()]
+	compressed, err := aead.Open(nil, nonce, archive[headerLength+aead.NonceSize():], header)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting validator key archive; the password may be incorrect or the archive may be corrupt")
+	}
+
+	// Decompress
+	gzipReader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing validator key archive: %w", err)
+	}
+	contents, err := io.ReadAll(gzipReader)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing validator key archive: %w", err)
+	}
+	return contents, nil
+}
+
+// Derive the archive cipher from the password
+func getCipher(password string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(password), salt, scryptN, scryptR, scryptP, keyLength)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving archive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating archive cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// Hash archive contents
+func hashContents(contents []byte) string {
+	hash := sha256.Sum256(contents)
+	return hex.EncodeToString(hash[:])
+}
+
+// Build a deterministic tarball of a folder (sorted, with no timestamps) so identical contents always hash the same
+func buildTar(dir string) ([]byte, error) {
+	var buffer bytes.Buffer
+	tarWriter := tar.NewWriter(&buffer)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if relPath == "." || relPath == MarkerFilename {
+			return nil
+		}
+		if !info.Mode().IsDir() && !info.Mode().IsRegular() {
+			return nil
+		}
+
+		header := &tar.Header{
+			Name:    filepath.ToSlash(relPath),
+			Mode:    int64(info.Mode().Perm()),
+			ModTime: time.Unix(0, 0),
+		}
+		if info.IsDir() {
+			header.Typeflag = tar.TypeDir
+			header.Name += "/"
+			return tarWriter.WriteHeader(header)
+		}
+		header.Typeflag = tar.TypeReg
+		header.Size = info.Size()
+		err = tarWriter.WriteHeader(header)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		_, err = tarWriter.Write(data)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error archiving validator key folder [%s]: %w", dir, err)
+	}
+	err = tarWriter.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error archiving validator key folder [%s]: %w", dir, err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// Extract a tarball into a folder, refusing any entries that would escape it
+func extractTar(contents []byte, dir string) error {
+	tarReader := tar.NewReader(bytes.NewReader(contents))
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error reading validator key archive: %w", err)
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(target, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return fmt.Errorf("validator key archive has an invalid entry [%s]", header.Name)
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, os.FileMode(header.Mode)|0700)
+		case tar.TypeReg:
+			err = os.MkdirAll(filepath.Dir(target), dirMode)
+			if err == nil {
+				var data []byte
+				data, err = io.ReadAll(tarReader)
+				if err == nil {
+					err = os.WriteFile(target, data, os.FileMode(header.Mode))
+				}
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("error extracting [%s] from validator key archive: %w", header.Name, err)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package keyarchive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestArchiveRoundTrip(t *testing.T) {
+	sourceDir := t.TempDir()
+	err := os.MkdirAll(filepath.Join(sourceDir, "lighthouse", "validators", "0x01"), 0770)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(sourceDir, "lighthouse", "validators", "0x01", "voting-keystore.json"), []byte(`{"crypto":{}}`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	archivePath := filepath.Join(t.TempDir(), "validators.enc")
+
+	hash, err := EncryptDir(sourceDir, archivePath, "password1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = VerifyArchive(archivePath, "wrong password")
+	if err == nil {
+		t.Fatal("expected decrypting with the wrong password to fail")
+	}
+
+	// Unlock into a new folder and make sure it hashes the same, ignoring the marker
+	targetDir := t.TempDir()
+	unlockedHash, err := DecryptToDir(archivePath, targetDir, "password1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unlockedHash != hash {
+		t.Fatalf("unlocked hash %s did not match archived hash %s", unlockedHash, hash)
+	}
+	markerHash, unlocked, err := GetUnlockedHash(targetDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !unlocked || markerHash != hash {
+		t.Fatalf("expected the folder to be marked as unlocked with hash %s, got %t / %s", hash, unlocked, markerHash)
+	}
+	dirHash, err := GetDirHash(targetDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dirHash != hash {
+		t.Fatalf("unlocked folder hash %s did not match archived hash %s", dirHash, hash)
+	}
+	data, err := os.ReadFile(filepath.Join(targetDir, "lighthouse", "validators", "0x01", "voting-keystore.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"crypto":{}}` {
+		t.Fatalf("unexpected keystore contents: %s", string(data))
+	}
+}
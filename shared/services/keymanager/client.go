@@ -0,0 +1,256 @@
+package keymanager
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Config
+const (
+	RequestUrlFormat   = "%s%s"
+	RequestContentType = "application/json"
+
+	RequestKeystoresPath    = "/eth/v1/keystores"
+	RequestFeeRecipientPath = "/eth/v1/validator/%s/feerecipient"
+	RequestGasLimitPath     = "/eth/v1/validator/%s/gas_limit"
+
+	requestTimeout = 30 * time.Second
+)
+
+// The result of importing or deleting a single key
+type KeyStatus string
+
+const (
+	KeyStatus_Imported  KeyStatus = "imported"
+	KeyStatus_Duplicate KeyStatus = "duplicate"
+	KeyStatus_Deleted   KeyStatus = "deleted"
+	KeyStatus_NotActive KeyStatus = "not_active"
+	KeyStatus_NotFound  KeyStatus = "not_found"
+	KeyStatus_Error     KeyStatus = "error"
+)
+
+// A key loaded by the Validator Client
+type LoadedKey struct {
+	Pubkey         types.ValidatorPubkey
+	DerivationPath string
+	ReadOnly       bool
+}
+
+// The status of a single key in a keymanager response
+type KeyResult struct {
+	Status  KeyStatus `json:"status"`
+	Message string    `json:"message"`
+}
+
+// Client for a Validator Client's standard keymanager API (https://ethereum.github.io/keymanager-APIs/),
+// so keys and per-validator settings can be changed without restarting it
+type Client struct {
+	providerAddress string
+	tokenPath       string
+	httpClient      *http.Client
+}
+
+// Create a new client instance; the token is read from tokenPath on every request so it can be rotated
+func NewClient(providerAddress string, tokenPath string) *Client {
+	return &Client{
+		providerAddress: strings.TrimSuffix(providerAddress, "/"),
+		tokenPath:       tokenPath,
+		httpClient:      &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// Get the keys the Validator Client has loaded
+func (c *Client) ListKeys() ([]LoadedKey, error) {
+	var response struct {
+		Data []struct {
+			ValidatingPubkey string `json:"validating_pubkey"`
+			DerivationPath   string `json:"derivation_path"`
+			ReadOnly         bool   `json:"readonly"`
+		} `json:"data"`
+	}
+	err := c.request(http.MethodGet, RequestKeystoresPath, nil, &response)
+	if err != nil {
+		return nil, fmt.Errorf("error listing validator keys: %w", err)
+	}
+
+	keys := make([]LoadedKey, len(response.Data))
+	for i, key := range response.Data {
+		pubkey, err := types.HexToValidatorPubkey(hexutil.RemovePrefix(key.ValidatingPubkey))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing validator key [%s]: %w", key.ValidatingPubkey, err)
+		}
+		keys[i] = LoadedKey{
+			Pubkey:         pubkey,
+			DerivationPath: key.DerivationPath,
+			ReadOnly:       key.ReadOnly,
+		}
+	}
+	return keys, nil
+}
+
+// Import EIP-2335 keystores into the Validator Client, with an optional EIP-3076 slashing protection interchange
+func (c *Client) ImportKeys(keystores []string, passwords []string, slashingProtection string) ([]KeyResult, error) {
+	request := struct {
+		Keystores          []string `json:"keystores"`
+		Passwords          []string `json:"passwords"`
+		SlashingProtection string   `json:"slashing_protection,omitempty"`
+	}{
+		Keystores:          keystores,
+		Passwords:          passwords,
+		SlashingProtection: slashingProtection,
+	}
+	var response struct {
+		Data []KeyResult `json:"data"`
+	}
+	err := c.request(http.MethodPost, RequestKeystoresPath, request, &response)
+	if err != nil {
+		return nil, fmt.Errorf("error importing validator keys: %w", err)
+	}
+	return response.Data, nil
+}
+
+// Delete keys from the Validator Client, returning their EIP-3076 slashing protection interchange
+func (c *Client) DeleteKeys(pubkeys []types.ValidatorPubkey) ([]KeyResult, string, error) {
+	request := struct {
+		Pubkeys []string `json:"pubkeys"`
+	}{
+		Pubkeys: make([]string, len(pubkeys)),
+	}
+	for i, pubkey := range pubkeys {
+		request.Pubkeys[i] = hexutil.AddPrefix(pubkey.Hex())
+	}
+	var response struct {
+		Data               []KeyResult `json:"data"`
+		SlashingProtection string      `json:"slashing_protection"`
+	}
+	err := c.request(http.MethodDelete, RequestKeystoresPath, request, &response)
+	if err != nil {
+		return nil, "", fmt.Errorf("error deleting validator keys: %w", err)
+	}
+	return response.Data, response.SlashingProtection, nil
+}
+
+// Get the fee recipient the Validator Client uses for a validator
+func (c *Client) GetFeeRecipient(pubkey types.ValidatorPubkey) (common.Address, error) {
+	var response struct {
+		Data struct {
+			EthAddress string `json:"ethaddress"`
+		} `json:"data"`
+	}
+	err := c.request(http.MethodGet, fmt.Sprintf(RequestFeeRecipientPath, hexutil.AddPrefix(pubkey.Hex())), nil, &response)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error getting fee recipient for validator %s: %w", pubkey.Hex(), err)
+	}
+	return common.HexToAddress(response.Data.EthAddress), nil
+}
+
+// Set the fee recipient the Validator Client uses for a validator
+func (c *Client) SetFeeRecipient(pubkey types.ValidatorPubkey, feeRecipient common.Address) error {
+	request := struct {
+		EthAddress string `json:"ethaddress"`
+	}{
+		EthAddress: feeRecipient.Hex(),
+	}
+	err := c.request(http.MethodPost, fmt.Sprintf(RequestFeeRecipientPath, hexutil.AddPrefix(pubkey.Hex())), request, nil)
+	if err != nil {
+		return fmt.Errorf("error setting fee recipient for validator %s: %w", pubkey.Hex(), err)
+	}
+	return nil
+}
+
+// Get the gas limit the Validator Client uses for a validator's blocks
+func (c *Client) GetGasLimit(pubkey types.ValidatorPubkey) (uint64, error) {
+	var response struct {
+		Data struct {
+			GasLimit string `json:"gas_limit"`
+		} `json:"data"`
+	}
+	err := c.request(http.MethodGet, fmt.Sprintf(RequestGasLimitPath, hexutil.AddPrefix(pubkey.Hex())), nil, &response)
+	if err != nil {
+		return 0, fmt.Errorf("error getting gas limit for validator %s: %w", pubkey.Hex(), err)
+	}
+	gasLimit, err := strconv.ParseUint(response.Data.GasLimit, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing gas limit [%s] for validator %s: %w", response.Data.GasLimit, pubkey.Hex(), err)
+	}
+	return gasLimit, nil
+}
+
+// Set the gas limit the Validator Client uses for a validator's blocks
+func (c *Client) SetGasLimit(pubkey types.ValidatorPubkey, gasLimit uint64) error {
+	request := struct {
+		GasLimit string `json:"gas_limit"`
+	}{
+		GasLimit: strconv.FormatUint(gasLimit, 10),
+	}
+	err := c.request(http.MethodPost, fmt.Sprintf(RequestGasLimitPath, hexutil.AddPrefix(pubkey.Hex())), request, nil)
+	if err != nil {
+		return fmt.Errorf("error setting gas limit for validator %s: %w", pubkey.Hex(), err)
+	}
+	return nil
+}
+
+// Send an authenticated request to the keymanager API, decoding the response into responseBody if it's provided
+func (c *Client) request(method string, requestPath string, requestBody interface{}, responseBody interface{}) error {
+
+	// Read the token
+	token, err := os.ReadFile(c.tokenPath)
+	if err != nil {
+		return fmt.Errorf("error reading keymanager API token from [%s]: %w", c.tokenPath, err)
+	}
+
+	// Build the request
+	var bodyReader io.Reader
+	if requestBody != nil {
+		requestBodyBytes, err := json.Marshal(requestBody)
+		if err != nil {
+			return fmt.Errorf("error serializing request: %w", err)
+		}
+		bodyReader = bytes.NewReader(requestBodyBytes)
+	}
+	request, err := http.NewRequest(method, fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), bodyReader)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	if requestBody != nil {
+		request.Header.Set("Content-Type", RequestContentType)
+	}
+
+	// Send it
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("the Validator Client responded with HTTP status %d: %s", response.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if responseBody == nil || len(body) == 0 {
+		return nil
+	}
+	err = json.Unmarshal(body, responseBody)
+	if err != nil {
+		return fmt.Errorf("error deserializing response: %w", err)
+	}
+	return nil
+
+}
@@ -0,0 +1,57 @@
+package keymanager
+
+import (
+	"fmt"
+
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+)
+
+// An EIP-2335 keystore
+type eip2335Keystore struct {
+	Crypto  map[string]interface{} `json:"crypto"`
+	Version uint                   `json:"version"`
+	UUID    uuid.UUID              `json:"uuid"`
+	Path    string                 `json:"path"`
+	Pubkey  types.ValidatorPubkey  `json:"pubkey"`
+}
+
+// Import validator keys into the Validator Client, encrypting each one into a keystore with a random password for the transfer.
+// The slashing protection interchange is optional.
+func (c *Client) ImportValidatorKeys(keys []*eth2types.BLSPrivateKey, derivationPaths []string, slashingProtection string) ([]KeyResult, error) {
+	encryptor := eth2ks.New()
+	keystores := make([]string, len(keys))
+	passwords := make([]string, len(keys))
+	for i, key := range keys {
+		password, err := keystore.GenerateRandomPassword()
+		if err != nil {
+			return nil, fmt.Errorf("error generating keystore password: %w", err)
+		}
+		encryptedKey, err := encryptor.Encrypt(key.Marshal(), password)
+		if err != nil {
+			return nil, fmt.Errorf("error encrypting validator key: %w", err)
+		}
+		derivationPath := ""
+		if i < len(derivationPaths) {
+			derivationPath = derivationPaths[i]
+		}
+		keystoreBytes, err := json.Marshal(eip2335Keystore{
+			Crypto:  encryptedKey,
+			Version: encryptor.Version(),
+			UUID:    uuid.New(),
+			Path:    derivationPath,
+			Pubkey:  types.BytesToValidatorPubkey(key.PublicKey().Marshal()),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error encoding validator keystore: %w", err)
+		}
+		keystores[i] = string(keystoreBytes)
+		passwords[i] = password
+	}
+	return c.ImportKeys(keystores, passwords, slashingProtection)
+}
@@ -0,0 +1,212 @@
+package ledger
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// The topic of the event minipools emit when their balance is distributed
+var EtherWithdrawalProcessedTopic = crypto.Keccak256Hash([]byte("EtherWithdrawalProcessed(address,uint256,uint256,uint256,uint256)"))
+
+// The topic of the event fee distributors emit when their balance is distributed
+var FeesDistributedTopic = crypto.Keccak256Hash([]byte("FeesDistributed(address,uint256,uint256,uint256)"))
+
+// A single Beacon Chain withdrawal (a skim or a full withdrawal) that was sent to a minipool
+type Withdrawal struct {
+	Slot       uint64 `json:"slot"`
+	Block      uint64 `json:"block"`
+	AmountGwei uint64 `json:"amountGwei"`
+}
+
+// A single distribution of a minipool's or fee distributor's balance
+type Distribution struct {
+	Block      uint64      `json:"block"`
+	Time       uint64      `json:"time"`
+	TxHash     common.Hash `json:"txHash"`
+	NodeAmount *big.Int    `json:"nodeAmount"`
+	UserAmount *big.Int    `json:"userAmount"`
+}
+
+// The tracked history of a single minipool
+type MinipoolLedger struct {
+	ValidatorIndex string         `json:"validatorIndex"`
+	StartBlock     uint64         `json:"startBlock"`
+	StartBalance   *big.Int       `json:"startBalance"`
+	Withdrawals    []Withdrawal   `json:"withdrawals"`
+	Distributions  []Distribution `json:"distributions"`
+}
+
+// The withdrawal and distribution history of all of a node's minipools
+type Ledger struct {
+	StartSlot        uint64                             `json:"startSlot"`
+	LastScannedSlot  uint64                             `json:"lastScannedSlot"`
+	LastScannedBlock uint64                             `json:"lastScannedBlock"`
+	Minipools        map[common.Address]*MinipoolLedger `json:"minipools"`
+	FeeDistributions []Distribution                     `json:"feeDistributions"`
+}
+
+// How one minipool's tracked inflows and outflows compare to its actual balance
+type Reconciliation struct {
+	Address           common.Address `json:"address"`
+	ValidatorIndex    string         `json:"validatorIndex"`
+	StartBlock        uint64         `json:"startBlock"`
+	WithdrawalCount   int            `json:"withdrawalCount"`
+	TotalWithdrawn    *big.Int       `json:"totalWithdrawn"`
+	DistributionCount int            `json:"distributionCount"`
+	TotalDistributed  *big.Int       `json:"totalDistributed"`
+	ExpectedBalance   *big.Int       `json:"expectedBalance"`
+	ActualBalance     *big.Int       `json:"actualBalance"`
+	Discrepancy       *big.Int       `json:"discrepancy"`
+}
+
+// Parse a distribution from an EtherWithdrawalProcessed event log
+func ParseDistribution(log types.Log) (Distribution, error) {
+	// The non-indexed fields are nodeAmount, userAmount, totalBalance, and time
+	data, err := getEventWords(log, 4)
+	if err != nil {
+		return Distribution{}, err
+	}
+	return Distribution{
+		Block:      log.BlockNumber,
+		Time:       data[3].Uint64(),
+		TxHash:     log.TxHash,
+		NodeAmount: data[0],
+		UserAmount: data[1],
+	}, nil
+}
+
+// Parse a distribution from a FeesDistributed event log
+func ParseFeeDistribution(log types.Log) (Distribution, error) {
+	// The fields are nodeAddress, userAmount, nodeAmount, and time
+	data, err := getEventWords(log, 4)
+	if err != nil {
+		return Distribution{}, err
+	}
+	return Distribution{
+		Block:      log.BlockNumber,
+		Time:       data[3].Uint64(),
+		TxHash:     log.TxHash,
+		NodeAmount: data[2],
+		UserAmount: data[1],
+	}, nil
+}
+
+// Split the data of an event log into its 32-byte words
+func getEventWords(log types.Log, count int) ([]*big.Int, error) {
+	if len(log.Data) < count*32 {
+		return nil, fmt.Errorf("event log in tx %s had %d bytes of data, expected %d", log.TxHash.Hex(), len(log.Data), count*32)
+	}
+	words := make([]*big.Int, count)
+	for i := range words {
+		words[i] = big.NewInt(0).SetBytes(log.Data[i*32 : (i+1)*32])
+	}
+	return words, nil
+}
+
+// Create a new, empty ledger that starts tracking at the provided slot
+func NewLedger(startSlot uint64) *Ledger {
+	return &Ledger{
+		StartSlot:        startSlot,
+		LastScannedSlot:  startSlot,
+		Minipools:        map[common.Address]*MinipoolLedger{},
+		FeeDistributions: []Distribution{},
+	}
+}
+
+// Load the ledger from disk. Returns nil if there isn't one yet.
+func LoadLedger(path string) (*Ledger, error) {
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading rewards ledger from [%s]: %w", path, err)
+	}
+
+	var ledger Ledger
+	err = json.Unmarshal(bytes, &ledger)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing rewards ledger from [%s]: %w", path, err)
+	}
+	if ledger.Minipools == nil {
+		ledger.Minipools = map[common.Address]*MinipoolLedger{}
+	}
+	return &ledger, nil
+}
+
+// Save the ledger to disk
+func (l *Ledger) Save(path string) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating rewards ledger folder: %w", err)
+	}
+	bytes, err := json.Marshal(l)
+	if err != nil {
+		return fmt.Errorf("error serializing rewards ledger: %w", err)
+	}
+	err = files.WriteFileAtomic(path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error saving rewards ledger to [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Reconcile a minipool's tracked history against its actual balance at the ledger's last scanned block.
+// The expected balance is its starting balance, plus everything withdrawn to it, minus everything distributed from it;
+// a non-zero discrepancy means some balance change wasn't accounted for.
+func (l *Ledger) Reconcile(address common.Address, actualBalance *big.Int) (Reconciliation, error) {
+	mpLedger, exists := l.Minipools[address]
+	if !exists {
+		return Reconciliation{}, fmt.Errorf("minipool %s is not in the rewards ledger", address.Hex())
+	}
+
+	totalWithdrawn := big.NewInt(0)
+	for _, withdrawal := range mpLedger.Withdrawals {
+		amount := big.NewInt(0).SetUint64(withdrawal.AmountGwei)
+		totalWithdrawn.Add(totalWithdrawn, amount.Mul(amount, big.NewInt(1e9)))
+	}
+	totalDistributed := big.NewInt(0)
+	for _, distribution := range mpLedger.Distributions {
+		totalDistributed.Add(totalDistributed, distribution.NodeAmount)
+		totalDistributed.Add(totalDistributed, distribution.UserAmount)
+	}
+
+	expectedBalance := big.NewInt(0).Set(mpLedger.StartBalance)
+	expectedBalance.Add(expectedBalance, totalWithdrawn)
+	expectedBalance.Sub(expectedBalance, totalDistributed)
+
+	return Reconciliation{
+		Address:           address,
+		ValidatorIndex:    mpLedger.ValidatorIndex,
+		StartBlock:        mpLedger.StartBlock,
+		WithdrawalCount:   len(mpLedger.Withdrawals),
+		TotalWithdrawn:    totalWithdrawn,
+		DistributionCount: len(mpLedger.Distributions),
+		TotalDistributed:  totalDistributed,
+		ExpectedBalance:   expectedBalance,
+		ActualBalance:     actualBalance,
+		Discrepancy:       big.NewInt(0).Sub(actualBalance, expectedBalance),
+	}, nil
+}
+
+// Get the addresses of the minipools in the ledger, sorted for display
+func (l *Ledger) GetMinipoolAddresses() []common.Address {
+	addresses := make([]common.Address, 0, len(l.Minipools))
+	for address := range l.Minipools {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].Hex() < addresses[j].Hex()
+	})
+	return addresses
+}
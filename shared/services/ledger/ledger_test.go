@@ -0,0 +1,45 @@
+package ledger
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestReconcile(t *testing.T) {
+	address := common.HexToAddress("0x1234")
+	ledger := NewLedger(100)
+	ledger.Minipools[address] = &MinipoolLedger{
+		ValidatorIndex: "42",
+		StartBalance:   big.NewInt(1e18),
+		Withdrawals: []Withdrawal{
+			{Slot: 101, AmountGwei: 50000000},
+			{Slot: 102, AmountGwei: 25000000},
+		},
+		Distributions: []Distribution{
+			{NodeAmount: big.NewInt(4e17), UserAmount: big.NewInt(6e17)},
+		},
+	}
+
+	// 1 ETH start + 0.075 ETH withdrawn - 1 ETH distributed = 0.075 ETH
+	reconciliation, err := ledger.Reconcile(address, big.NewInt(75e15))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reconciliation.ExpectedBalance.Cmp(big.NewInt(75e15)) != 0 {
+		t.Fatalf("expected a balance of 0.075 ETH, got %s wei", reconciliation.ExpectedBalance.String())
+	}
+	if reconciliation.Discrepancy.Sign() != 0 {
+		t.Fatalf("expected no discrepancy, got %s wei", reconciliation.Discrepancy.String())
+	}
+
+	// An unexplained transfer shows up as a discrepancy
+	reconciliation, err = ledger.Reconcile(address, big.NewInt(1e17))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reconciliation.Discrepancy.Cmp(big.NewInt(25e15)) != 0 {
+		t.Fatalf("expected a discrepancy of 0.025 ETH, got %s wei", reconciliation.Discrepancy.String())
+	}
+}
@@ -0,0 +1,122 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	exportTimeout time.Duration = 30 * time.Second
+)
+
+// Pushes a daemon's metrics to a pushgateway or remote-write endpoint on a timer
+type Exporter struct {
+	mode        cfgtypes.MetricsExportMode
+	url         string
+	interval    time.Duration
+	batchSize   int
+	job         string
+	instance    string
+	username    string
+	password    string
+	bearerToken string
+	gatherer    prometheus.Gatherer
+	client      *http.Client
+	logger      log.ColorLogger
+}
+
+// Create a new exporter for the metrics in the provided gatherer, labelled with the provided instance name (such as "node" or "watchtower").
+// Returns nil if metrics exporting is disabled.
+func NewExporter(cfg *config.RocketPoolConfig, gatherer prometheus.Gatherer, instance string, logger log.ColorLogger) (*Exporter, error) {
+	mode := cfg.MetricsExport.Mode.Value.(cfgtypes.MetricsExportMode)
+	if mode == cfgtypes.MetricsExportMode_None {
+		return nil, nil
+	}
+
+	url := cfg.MetricsExport.Url.Value.(string)
+	if url == "" {
+		return nil, fmt.Errorf("metrics exporting is enabled but no URL has been set")
+	}
+	interval := cfg.MetricsExport.Interval.Value.(uint64)
+	if interval == 0 {
+		return nil, fmt.Errorf("the metrics export interval must be at least 1 second")
+	}
+	batchSize := cfg.MetricsExport.BatchSize.Value.(uint64)
+	if batchSize == 0 {
+		return nil, fmt.Errorf("the metrics export batch size must be at least 1")
+	}
+
+	return &Exporter{
+		mode:        mode,
+		url:         url,
+		interval:    time.Duration(interval) * time.Second,
+		batchSize:   int(batchSize),
+		job:         cfg.MetricsExport.Job.Value.(string),
+		instance:    instance,
+		username:    cfg.MetricsExport.Username.Value.(string),
+		password:    cfg.MetricsExport.Password.Value.(string),
+		bearerToken: cfg.MetricsExport.BearerToken.Value.(string),
+		gatherer:    gatherer,
+		client:      &http.Client{Timeout: exportTimeout},
+		logger:      logger,
+	}, nil
+}
+
+// Push the metrics every interval until the daemon exits. Failures are logged and retried on the next interval.
+func (e *Exporter) Run() {
+	e.logger.Printlnf("Exporting metrics to %s every %s (mode: %s).", e.url, e.interval, e.mode)
+	for {
+		if err := e.Export(); err != nil {
+			e.logger.Printlnf("WARNING: error exporting metrics: %s", err.Error())
+		}
+		time.Sleep(e.interval)
+	}
+}
+
+// Push the current metrics once
+func (e *Exporter) Export() error {
+	switch e.mode {
+	case cfgtypes.MetricsExportMode_Pushgateway:
+		return e.pushToGateway()
+	case cfgtypes.MetricsExportMode_RemoteWrite:
+		return e.remoteWrite()
+	default:
+		return fmt.Errorf("unknown metrics export mode [%s]", e.mode)
+	}
+}
+
+// Replace this daemon's group on the pushgateway with the current metrics
+func (e *Exporter) pushToGateway() error {
+	pusher := push.New(e.url, e.job).
+		Gatherer(e.gatherer).
+		Grouping("instance", e.instance).
+		Client(e.client)
+	if e.username != "" {
+		pusher = pusher.BasicAuth(e.username, e.password)
+	}
+	if e.bearerToken != "" {
+		pusher = pusher.Header(http.Header{"Authorization": []string{"Bearer " + e.bearerToken}})
+	}
+
+	if err := pusher.Push(); err != nil {
+		return fmt.Errorf("error pushing to the pushgateway: %w", err)
+	}
+	return nil
+}
+
+// Add the configured credentials to a request
+func (e *Exporter) addAuth(request *http.Request) {
+	if e.bearerToken != "" {
+		request.Header.Set("Authorization", "Bearer "+e.bearerToken)
+	} else if e.username != "" {
+		request.SetBasicAuth(e.username, e.password)
+	}
+}
@@ -0,0 +1,184 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// A single label on a time series
+type label struct {
+	name  string
+	value string
+}
+
+// A time series with a single sample, ready to be sent to a remote-write endpoint
+type timeSeries struct {
+	labels      []label
+	value       float64
+	timestampMs int64
+}
+
+// Send the current metrics to the remote-write endpoint, in batches of up to batchSize series
+func (e *Exporter) remoteWrite() error {
+	families, err := e.gatherer.Gather()
+	if err != nil {
+		return fmt.Errorf("error gathering metrics: %w", err)
+	}
+
+	series := e.flattenMetricFamilies(families, time.Now().UnixMilli())
+	for start := 0; start < len(series); start += e.batchSize {
+		end := start + e.batchSize
+		if end > len(series) {
+			end = len(series)
+		}
+		if err := e.sendBatch(series[start:end]); err != nil {
+			return fmt.Errorf("error sending series %d to %d of %d: %w", start, end, len(series), err)
+		}
+	}
+	return nil
+}
+
+// Post one batch of series to the remote-write endpoint
+func (e *Exporter) sendBatch(series []timeSeries) error {
+	body := snappy.Encode(nil, encodeWriteRequest(series))
+	request, err := http.NewRequest(http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	request.Header.Set("Content-Encoding", "snappy")
+	request.Header.Set("Content-Type", "application/x-protobuf")
+	request.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	e.addAuth(request)
+
+	response, err := e.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 512))
+		return fmt.Errorf("endpoint returned status %d: %s", response.StatusCode, string(bytes.TrimSpace(message)))
+	}
+	return nil
+}
+
+// Turn the gathered metric families into individual time series, the way Prometheus would store them after a scrape
+func (e *Exporter) flattenMetricFamilies(families []*dto.MetricFamily, timestampMs int64) []timeSeries {
+	series := []timeSeries{}
+	for _, family := range families {
+		name := family.GetName()
+		for _, metric := range family.GetMetric() {
+			timestamp := timestampMs
+			if metric.TimestampMs != nil {
+				timestamp = metric.GetTimestampMs()
+			}
+			add := func(suffix string, value float64, extra ...label) {
+				series = append(series, timeSeries{
+					labels:      e.getLabels(name+suffix, metric.GetLabel(), extra...),
+					value:       value,
+					timestampMs: timestamp,
+				})
+			}
+
+			switch family.GetType() {
+			case dto.MetricType_COUNTER:
+				add("", metric.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				add("", metric.GetGauge().GetValue())
+			case dto.MetricType_UNTYPED:
+				add("", metric.GetUntyped().GetValue())
+			case dto.MetricType_SUMMARY:
+				summary := metric.GetSummary()
+				for _, quantile := range summary.GetQuantile() {
+					add("", quantile.GetValue(), label{"quantile", formatFloat(quantile.GetQuantile())})
+				}
+				add("_sum", summary.GetSampleSum())
+				add("_count", float64(summary.GetSampleCount()))
+			case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+				histogram := metric.GetHistogram()
+				hasInf := false
+				for _, bucket := range histogram.GetBucket() {
+					if math.IsInf(bucket.GetUpperBound(), 1) {
+						hasInf = true
+					}
+					add("_bucket", float64(bucket.GetCumulativeCount()), label{"le", formatFloat(bucket.GetUpperBound())})
+				}
+				if !hasInf {
+					add("_bucket", float64(histogram.GetSampleCount()), label{"le", "+Inf"})
+				}
+				add("_sum", histogram.GetSampleSum())
+				add("_count", float64(histogram.GetSampleCount()))
+			}
+		}
+	}
+	return series
+}
+
+// Build the sorted label set for a series, including its name and the job and instance labels
+func (e *Exporter) getLabels(name string, pairs []*dto.LabelPair, extra ...label) []label {
+	labels := []label{
+		{"__name__", name},
+		{"job", e.job},
+		{"instance", e.instance},
+	}
+	for _, pair := range pairs {
+		labels = append(labels, label{pair.GetName(), pair.GetValue()})
+	}
+	labels = append(labels, extra...)
+
+	// Remote-write endpoints expect the labels sorted by name
+	sort.SliceStable(labels, func(i, j int) bool {
+		return labels[i].name < labels[j].name
+	})
+	return labels
+}
+
+// Format a float the way Prometheus does for le and quantile labels
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// Encode a prometheus.WriteRequest protobuf message holding the provided series.
+//
+//	message WriteRequest { repeated TimeSeries timeseries = 1; }
+//	message TimeSeries { repeated Label labels = 1; repeated Sample samples = 2; }
+//	message Label { string name = 1; string value = 2; }
+//	message Sample { double value = 1; int64 timestamp = 2; }
+func encodeWriteRequest(series []timeSeries) []byte {
+	request := []byte{}
+	for _, s := range series {
+		ts := []byte{}
+		for _, l := range s.labels {
+			labelBytes := protowire.AppendTag(nil, 1, protowire.BytesType)
+			labelBytes = protowire.AppendString(labelBytes, l.name)
+			labelBytes = protowire.AppendTag(labelBytes, 2, protowire.BytesType)
+			labelBytes = protowire.AppendString(labelBytes, l.value)
+			ts = protowire.AppendTag(ts, 1, protowire.BytesType)
+			ts = protowire.AppendBytes(ts, labelBytes)
+		}
+
+		sample := protowire.AppendTag(nil, 1, protowire.Fixed64Type)
+		sample = protowire.AppendFixed64(sample, math.Float64bits(s.value))
+		sample = protowire.AppendTag(sample, 2, protowire.VarintType)
+		sample = protowire.AppendVarint(sample, uint64(s.timestampMs))
+		ts = protowire.AppendTag(ts, 2, protowire.BytesType)
+		ts = protowire.AppendBytes(ts, sample)
+
+		request = protowire.AppendTag(request, 1, protowire.BytesType)
+		request = protowire.AppendBytes(request, ts)
+	}
+	return request
+}
@@ -0,0 +1,139 @@
+package mirror
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How long to wait for an upload to the mirror
+const uploadTimeout time.Duration = 5 * time.Minute
+
+// Publishes the submitted rewards files to an HTTP mirror and creates torrents for them
+type Publisher struct {
+	cfg        *config.RocketPoolConfig
+	log        *log.ColorLogger
+	uploadUrl  string
+	uploadAuth string
+	torrents   bool
+	trackers   []string
+	client     *http.Client
+}
+
+// Create a new publisher from the config
+func NewPublisher(cfg *config.RocketPoolConfig, logger *log.ColorLogger) *Publisher {
+	return &Publisher{
+		cfg:        cfg,
+		log:        logger,
+		uploadUrl:  strings.TrimSuffix(strings.TrimSpace(cfg.Smartnode.RewardsMirrorUploadUrl.Value.(string)), "/"),
+		uploadAuth: strings.TrimSpace(cfg.Smartnode.RewardsMirrorUploadAuth.Value.(string)),
+		torrents:   cfg.Smartnode.GenerateRewardsTorrents.Value == true,
+		trackers:   splitList(cfg.Smartnode.RewardsTorrentTrackers.Value.(string)),
+		client:     &http.Client{Timeout: uploadTimeout},
+	}
+}
+
+// Check if the publisher has anything to do
+func (p *Publisher) IsEnabled() bool {
+	return p.uploadUrl != "" || p.torrents
+}
+
+// Publish a compressed rewards file: create its torrent if those are enabled, and upload both to the mirror if one is set
+func (p *Publisher) Publish(path string, cid string) error {
+	if !p.IsEnabled() {
+		return nil
+	}
+
+	filename := filepath.Base(path)
+	uploads := []string{path}
+	if p.torrents {
+		torrent, err := CreateTorrent(path, cid, p.trackers, GetRewardsFileUrls(p.cfg, cid, filename))
+		if err != nil {
+			return fmt.Errorf("error creating torrent for %s: %w", filename, err)
+		}
+		torrentPath := path + config.RewardsTorrentExtension
+		if err := files.WriteFileAtomic(torrentPath, torrent.Bytes, 0644); err != nil {
+			return fmt.Errorf("error saving torrent [%s]: %w", torrentPath, err)
+		}
+		p.log.Printlnf("Created torrent for %s with info-hash %s.", filename, torrent.InfoHash)
+		p.log.Printlnf("Magnet link: %s", torrent.Magnet)
+		uploads = append(uploads, torrentPath)
+	}
+
+	if p.uploadUrl == "" {
+		return nil
+	}
+	for _, upload := range uploads {
+		if err := p.upload(upload); err != nil {
+			return fmt.Errorf("error uploading %s to the mirror: %w", filepath.Base(upload), err)
+		}
+		p.log.Printlnf("Uploaded %s to the mirror.", filepath.Base(upload))
+	}
+	return nil
+}
+
+// Upload a file to the mirror with an HTTP PUT
+func (p *Publisher) upload(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading [%s]: %w", path, err)
+	}
+	request, err := http.NewRequest(http.MethodPut, p.uploadUrl+"/"+filepath.Base(path), bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	request.ContentLength = int64(len(data))
+	request.Header.Set("Content-Type", "application/octet-stream")
+	if p.uploadAuth != "" {
+		request.Header.Set("Authorization", p.uploadAuth)
+	}
+	response, err := p.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 200))
+		return fmt.Errorf("mirror returned status %d: %s", response.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+// Get the URLs of a compressed rewards file on each of the configured mirrors
+func GetMirrorUrls(cfg *config.RocketPoolConfig, filename string) []string {
+	urls := []string{}
+	for _, mirror := range splitList(cfg.Smartnode.RewardsMirrorUrls.Value.(string)) {
+		urls = append(urls, strings.TrimSuffix(mirror, "/")+"/"+filename)
+	}
+	return urls
+}
+
+// Get every HTTP URL a compressed rewards file can be downloaded from: the IPFS gateways and the configured mirrors
+func GetRewardsFileUrls(cfg *config.RocketPoolConfig, cid string, filename string) []string {
+	urls := []string{
+		fmt.Sprintf(config.PrimaryRewardsFileUrl, cid, filename),
+		fmt.Sprintf(config.SecondaryRewardsFileUrl, cid, filename),
+	}
+	return append(urls, GetMirrorUrls(cfg, filename)...)
+}
+
+// Split a ';' separated list, dropping the empty entries
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ";") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
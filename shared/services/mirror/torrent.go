@@ -0,0 +1,144 @@
+package mirror
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// The piece length of every rewards file torrent. It's fixed so every node builds the same torrent from the same file.
+const torrentPieceLength int = 256 * 1024
+
+// A torrent for a rewards file
+type Torrent struct {
+	InfoHash string
+	Magnet   string
+	Bytes    []byte
+}
+
+// Create a single-file torrent for a rewards file.
+// The info dictionary only holds the file's name, length, and pieces plus its CID as the source, so the info-hash is
+// determined entirely by the file and the CID it was submitted with. Trackers and web seeds sit outside it and don't affect it.
+func CreateTorrent(path string, cid string, trackers []string, webSeeds []string) (*Torrent, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading [%s]: %w", path, err)
+	}
+	name := filepath.Base(path)
+
+	// Hash the pieces
+	pieces := make([]byte, 0, (len(data)/torrentPieceLength+1)*sha1.Size)
+	for start := 0; start < len(data); start += torrentPieceLength {
+		end := start + torrentPieceLength
+		if end > len(data) {
+			end = len(data)
+		}
+		hash := sha1.Sum(data[start:end])
+		pieces = append(pieces, hash[:]...)
+	}
+
+	info := map[string]interface{}{
+		"length":       len(data),
+		"name":         name,
+		"piece length": torrentPieceLength,
+		"pieces":       string(pieces),
+		"source":       cid,
+	}
+	infoBytes, err := bencode(info)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding torrent info: %w", err)
+	}
+	infoHash := sha1.Sum(infoBytes)
+
+	torrent := map[string]interface{}{
+		"info": info,
+	}
+	if len(trackers) > 0 {
+		torrent["announce"] = trackers[0]
+		announceList := []interface{}{}
+		for _, tracker := range trackers {
+			announceList = append(announceList, []interface{}{tracker})
+		}
+		torrent["announce-list"] = announceList
+	}
+	if len(webSeeds) > 0 {
+		urlList := []interface{}{}
+		for _, seed := range webSeeds {
+			urlList = append(urlList, seed)
+		}
+		torrent["url-list"] = urlList
+	}
+	torrentBytes, err := bencode(torrent)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding torrent: %w", err)
+	}
+
+	// Build the magnet link
+	infoHashString := hex.EncodeToString(infoHash[:])
+	magnet := strings.Builder{}
+	magnet.WriteString("magnet:?xt=urn:btih:" + infoHashString)
+	magnet.WriteString("&dn=" + url.QueryEscape(name))
+	for _, tracker := range trackers {
+		magnet.WriteString("&tr=" + url.QueryEscape(tracker))
+	}
+	for _, seed := range webSeeds {
+		magnet.WriteString("&ws=" + url.QueryEscape(seed))
+	}
+
+	return &Torrent{
+		InfoHash: infoHashString,
+		Magnet:   magnet.String(),
+		Bytes:    torrentBytes,
+	}, nil
+}
+
+// Encode a value with bencoding. Only the types a torrent needs are supported.
+func bencode(value interface{}) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := bencodeValue(&buffer, value); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// Append a single value to a bencoded buffer
+func bencodeValue(buffer *bytes.Buffer, value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		fmt.Fprintf(buffer, "%d:%s", len(v), v)
+	case int:
+		fmt.Fprintf(buffer, "i%de", v)
+	case []interface{}:
+		buffer.WriteByte('l')
+		for _, item := range v {
+			if err := bencodeValue(buffer, item); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte('e')
+	case map[string]interface{}:
+		// Dictionary keys have to be sorted
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		buffer.WriteByte('d')
+		for _, key := range keys {
+			fmt.Fprintf(buffer, "%d:%s", len(key), key)
+			if err := bencodeValue(buffer, v[key]); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte('e')
+	default:
+		return fmt.Errorf("can't bencode a value of type %T", value)
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+package notifications
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Settings
+const (
+	smtpTimeout time.Duration = 30 * time.Second
+)
+
+// The body of an email about new alerts
+var alertEmailTemplate = template.Must(template.New("alert").Parse(`{{range .Notifications}}[{{.Severity}}] {{.Summary}}
+{{.Description}}
+Started at {{.StartsAt.UTC.Format "2006-01-02 15:04:05 MST"}}
+
+{{end}}--
+Sent by the Rocket Pool Smartnode.
+`))
+
+// The body of the daily digest email
+var digestEmailTemplate = template.Must(template.New("digest").Parse(`Rocket Pool daily digest for {{.Time.UTC.Format "Monday, January 2 2006"}}
+{{with .Summary}}
+NODE STATUS ({{.Network}})
+Node address:        {{.NodeAddress.Hex}}
+ETH balance:         {{printf "%.6f" .EthBalance}} ETH
+RPL balance:         {{printf "%.6f" .RplBalance}} RPL
+RPL staked:          {{printf "%.6f" .RplStake}} RPL ({{printf "%.6f" .EffectiveRplStake}} effective)
+Smoothing pool:      {{if .SmoothingPoolRegistered}}opted in{{else}}not opted in{{end}}
+Minipools:           {{range $status, $count := .MinipoolStatusCounts}}{{$count}} {{$status}}  {{else}}none{{end}}
+Validators:          {{range $status, $count := .ValidatorStatusCounts}}{{$count}} {{$status}}  {{else}}none{{end}}
+
+REWARDS (LAST 24 HOURS)
+Beacon Chain withdrawals to your minipools: {{printf "%.6f" .BeaconWithdrawalsEth}} ETH
+Fee distributor payouts to your node:       {{printf "%.6f" .NodeDistributionsEth}} ETH
+
+Alerts active right now: {{.ActiveAlertCount}}
+{{else}}
+The node's status couldn't be retrieved for this digest; check the node daemon's logs for details.
+{{end}}
+WARNINGS AND ALERTS (LAST 24 HOURS)
+{{range .Notifications}}{{.StartsAt.UTC.Format "15:04 MST"}} [{{.Severity}}] {{.Summary}}
+    {{.Description}}
+{{else}}No alerts fired. 
+{{end}}
+--
+Sent by the Rocket Pool Smartnode.
+`))
+
+// Sends notifications by email, either as they happen or as a daily digest
+type emailSink struct {
+	mode          cfgtypes.EmailMode
+	host          string
+	port          uint16
+	security      cfgtypes.SmtpSecurity
+	username      string
+	password      string
+	from          string
+	to            []string
+	subjectPrefix string
+	digestHour    int
+}
+
+// Create an email sink from the config, or nil if email is disabled
+func newEmailSink(cfg *config.RocketPoolConfig) (*emailSink, error) {
+	mode := cfg.Notifications.EmailMode.Value.(cfgtypes.EmailMode)
+	if mode == cfgtypes.EmailMode_Disabled {
+		return nil, nil
+	}
+
+	sink := &emailSink{
+		mode:          mode,
+		host:          strings.TrimSpace(cfg.Notifications.SmtpHost.Value.(string)),
+		port:          cfg.Notifications.SmtpPort.Value.(uint16),
+		security:      cfg.Notifications.SmtpSecurity.Value.(cfgtypes.SmtpSecurity),
+		username:      cfg.Notifications.SmtpUsername.Value.(string),
+		password:      cfg.Notifications.SmtpPassword.Value.(string),
+		from:          strings.TrimSpace(cfg.Notifications.EmailFrom.Value.(string)),
+		subjectPrefix: strings.TrimSpace(cfg.Notifications.EmailSubjectPrefix.Value.(string)),
+		digestHour:    int(cfg.Notifications.DigestHour.Value.(uint64)),
+	}
+	for _, address := range strings.Split(cfg.Notifications.EmailTo.Value.(string), ",") {
+		address = strings.TrimSpace(address)
+		if address != "" {
+			sink.to = append(sink.to, address)
+		}
+	}
+
+	if sink.host == "" || sink.from == "" || len(sink.to) == 0 {
+		return nil, fmt.Errorf("the SMTP server, From address, and To addresses must all be set")
+	}
+	if sink.digestHour > 23 {
+		return nil, fmt.Errorf("the digest hour must be between 0 and 23")
+	}
+	return sink, nil
+}
+
+func (s *emailSink) Name() string {
+	return "email"
+}
+
+// In immediate mode everything is sent right away; in digest mode everything waits for the digest
+func (s *emailSink) IsImmediate(notification Notification) bool {
+	return s.mode == cfgtypes.EmailMode_Immediate
+}
+
+// The digest is due once a day, at the configured hour
+func (s *emailSink) IsBatchDue(lastBatchTime time.Time, now time.Time) bool {
+	if s.mode != cfgtypes.EmailMode_Digest {
+		return false
+	}
+	now = now.UTC()
+	digestTime := time.Date(now.Year(), now.Month(), now.Day(), s.digestHour, 0, 0, 0, time.UTC)
+	if now.Before(digestTime) {
+		digestTime = digestTime.AddDate(0, 0, -1)
+	}
+	return lastBatchTime.Before(digestTime)
+}
+
+// Send an email about new alerts
+func (s *emailSink) Send(notifications []Notification) error {
+	subject := fmt.Sprintf("%d new alerts", len(notifications))
+	if len(notifications) == 1 {
+		subject = notifications[0].Summary
+		if notifications[0].Severity != "" {
+			subject = fmt.Sprintf("[%s] %s", notifications[0].Severity, subject)
+		}
+	}
+
+	var body bytes.Buffer
+	err := alertEmailTemplate.Execute(&body, map[string]interface{}{
+		"Notifications": notifications,
+	})
+	if err != nil {
+		return fmt.Errorf("error formatting email: %w", err)
+	}
+	return s.sendEmail(subject, body.String())
+}
+
+// Send the daily digest
+func (s *emailSink) SendBatch(notifications []Notification, summary *Summary) error {
+	var body bytes.Buffer
+	err := digestEmailTemplate.Execute(&body, map[string]interface{}{
+		"Time":          time.Now(),
+		"Summary":       summary,
+		"Notifications": notifications,
+	})
+	if err != nil {
+		return fmt.Errorf("error formatting digest: %w", err)
+	}
+
+	subject := "Daily digest: no alerts"
+	if len(notifications) == 1 {
+		subject = "Daily digest: 1 alert"
+	} else if len(notifications) > 1 {
+		subject = fmt.Sprintf("Daily digest: %d alerts", len(notifications))
+	}
+	return s.sendEmail(subject, body.String())
+}
+
+// Send a plain text email through the SMTP server
+func (s *emailSink) sendEmail(subject string, body string) error {
+	if s.subjectPrefix != "" {
+		subject = s.subjectPrefix + " " + subject
+	}
+
+	// Build the message
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", s.from)
+	fmt.Fprintf(&message, "To: %s\r\n", strings.Join(s.to, ", "))
+	fmt.Fprintf(&message, "Subject: %s\r\n", sanitizeHeader(subject))
+	fmt.Fprintf(&message, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	message.WriteString("\r\n")
+	message.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	// Connect to the server
+	address := net.JoinHostPort(s.host, fmt.Sprint(s.port))
+	tlsConfig := &tls.Config{ServerName: s.host}
+	var conn net.Conn
+	var err error
+	dialer := &net.Dialer{Timeout: smtpTimeout}
+	if s.security == cfgtypes.SmtpSecurity_Tls {
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", address)
+	}
+	if err != nil {
+		return fmt.Errorf("error connecting to SMTP server %s: %w", address, err)
+	}
+	conn.SetDeadline(time.Now().Add(smtpTimeout))
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("error starting SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	if s.security == cfgtypes.SmtpSecurity_StartTls {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("error starting TLS: %w", err)
+		}
+	}
+	if s.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return fmt.Errorf("error logging into SMTP server: %w", err)
+		}
+	}
+
+	// Send the message
+	if err := client.Mail(s.from); err != nil {
+		return fmt.Errorf("error setting sender: %w", err)
+	}
+	for _, recipient := range s.to {
+		if err := client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("error adding recipient %s: %w", recipient, err)
+		}
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("error starting message: %w", err)
+	}
+	if _, err := writer.Write(message.Bytes()); err != nil {
+		return fmt.Errorf("error writing message: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("error sending message: %w", err)
+	}
+	return client.Quit()
+}
+
+// Remove line breaks from a header value so it can't inject other headers
+func sanitizeHeader(value string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+}
@@ -0,0 +1,321 @@
+package notifications
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/alerting/alertmanager/models"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// How long to remember alerts that have already been delivered, so they aren't sent again if they're still firing
+	seenAlertRetention time.Duration = 7 * 24 * time.Hour
+
+	// The most notifications to hold for a batch, so a noisy alert can't grow the state file forever
+	maxPendingNotifications int = 500
+)
+
+// A single alert to deliver
+type Notification struct {
+	Fingerprint string    `json:"fingerprint"`
+	Name        string    `json:"name"`
+	Summary     string    `json:"summary"`
+	Description string    `json:"description"`
+	Severity    string    `json:"severity"`
+	StartsAt    time.Time `json:"startsAt"`
+}
+
+// An overview of the node, included with digests
+type Summary struct {
+	Time                    time.Time      `json:"time"`
+	Network                 string         `json:"network"`
+	NodeAddress             common.Address `json:"nodeAddress"`
+	EthBalance              float64        `json:"ethBalance"`
+	RplBalance              float64        `json:"rplBalance"`
+	RplStake                float64        `json:"rplStake"`
+	EffectiveRplStake       float64        `json:"effectiveRplStake"`
+	SmoothingPoolRegistered bool           `json:"smoothingPoolRegistered"`
+	MinipoolStatusCounts    map[string]int `json:"minipoolStatusCounts"`
+	ValidatorStatusCounts   map[string]int `json:"validatorStatusCounts"`
+	BeaconWithdrawalsEth    float64        `json:"beaconWithdrawalsEth"`
+	NodeDistributionsEth    float64        `json:"nodeDistributionsEth"`
+	ActiveAlertCount        int            `json:"activeAlertCount"`
+}
+
+// Something that can deliver notifications to the node operator
+type Sink interface {
+	// The name of the sink, used in logs and to track its state
+	Name() string
+
+	// Check if a notification should be sent right away, rather than held for the next batch
+	IsImmediate(notification Notification) bool
+
+	// Check if the held notifications should be sent now, given when the last batch was sent
+	IsBatchDue(lastBatchTime time.Time, now time.Time) bool
+
+	// Send notifications right away
+	Send(notifications []Notification) error
+
+	// Send a batch of held notifications, along with a summary of the node if one is available
+	SendBatch(notifications []Notification, summary *Summary) error
+}
+
+// The state the dispatcher keeps between runs
+type dispatcherState struct {
+	Seen           map[string]time.Time      `json:"seen"`
+	Pending        map[string][]Notification `json:"pending"`
+	LastBatchTimes map[string]time.Time      `json:"lastBatchTimes"`
+}
+
+// Delivers the alerts from Alertmanager to the configured sinks
+type Dispatcher struct {
+	cfg       *config.RocketPoolConfig
+	log       *log.ColorLogger
+	sinks     []Sink
+	statePath string
+	state     *dispatcherState
+}
+
+// Create a new dispatcher for all of the sinks enabled in the config
+func NewDispatcher(cfg *config.RocketPoolConfig, logger *log.ColorLogger) (*Dispatcher, error) {
+	sinks, err := GetSinks(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Dispatcher{
+		cfg:       cfg,
+		log:       logger,
+		sinks:     sinks,
+		statePath: cfg.Smartnode.GetNotificationsStatePath(true),
+	}
+	if err := d.loadState(); err != nil {
+		logger.Printlnf("WARNING: %s; starting with a fresh notification state.", err.Error())
+		d.state = newDispatcherState()
+	}
+	return d, nil
+}
+
+// Get all of the sinks enabled in the config
+func GetSinks(cfg *config.RocketPoolConfig) ([]Sink, error) {
+	sinks := []Sink{}
+	emailSink, err := newEmailSink(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error creating email sink: %w", err)
+	}
+	if emailSink != nil {
+		sinks = append(sinks, emailSink)
+	}
+	if ntfySink := newNtfySink(cfg); ntfySink != nil {
+		sinks = append(sinks, ntfySink)
+	}
+	if pushoverSink := newPushoverSink(cfg); pushoverSink != nil {
+		sinks = append(sinks, pushoverSink)
+	}
+	return sinks, nil
+}
+
+// Check if any sinks are enabled
+func (d *Dispatcher) HasSinks() bool {
+	return len(d.sinks) > 0
+}
+
+// Deliver any new alerts, and send any batches that are due.
+// The summary is only created when a batch is actually being sent.
+func (d *Dispatcher) Run(getSummary func() (*Summary, error)) error {
+	if !d.HasSinks() {
+		return nil
+	}
+	now := time.Now()
+
+	// Get the alerts that haven't been delivered yet
+	alerts, err := alerting.FetchAlerts(d.cfg)
+	if err != nil {
+		return err
+	}
+	newNotifications := []Notification{}
+	activeCount := 0
+	for _, alert := range alerts {
+		if alert.Status == nil || alert.Status.State == nil || *alert.Status.State != "active" {
+			continue
+		}
+		activeCount++
+		notification := newNotification(alert)
+		key := notification.key()
+		if _, exists := d.state.Seen[key]; exists {
+			continue
+		}
+		d.state.Seen[key] = now
+		newNotifications = append(newNotifications, notification)
+	}
+	sort.SliceStable(newNotifications, func(i, j int) bool {
+		return newNotifications[i].StartsAt.Before(newNotifications[j].StartsAt)
+	})
+
+	// Deliver them
+	errs := []error{}
+	var summary *Summary
+	for _, sink := range d.sinks {
+		name := sink.Name()
+		immediate := []Notification{}
+		for _, notification := range newNotifications {
+			if sink.IsImmediate(notification) {
+				immediate = append(immediate, notification)
+			} else {
+				d.state.Pending[name] = append(d.state.Pending[name], notification)
+			}
+		}
+		if len(d.state.Pending[name]) > maxPendingNotifications {
+			d.state.Pending[name] = d.state.Pending[name][len(d.state.Pending[name])-maxPendingNotifications:]
+		}
+
+		if len(immediate) > 0 {
+			if err := sink.Send(immediate); err != nil {
+				errs = append(errs, fmt.Errorf("error sending %d notifications to %s: %w", len(immediate), name, err))
+			} else {
+				d.log.Printlnf("Sent %d notifications to %s.", len(immediate), name)
+			}
+		}
+
+		// Start the batch schedule from now the first time a sink runs, rather than sending a batch right away
+		lastBatchTime, exists := d.state.LastBatchTimes[name]
+		if !exists {
+			d.state.LastBatchTimes[name] = now
+		} else if sink.IsBatchDue(lastBatchTime, now) {
+			if summary == nil && getSummary != nil {
+				summary, err = getSummary()
+				if err != nil {
+					d.log.Printlnf("WARNING: error creating the node summary: %s", err.Error())
+					summary = nil
+				} else {
+					summary.ActiveAlertCount = activeCount
+				}
+			}
+			pending := d.state.Pending[name]
+			if err := sink.SendBatch(pending, summary); err != nil {
+				errs = append(errs, fmt.Errorf("error sending batch of %d notifications to %s: %w", len(pending), name, err))
+			} else {
+				d.log.Printlnf("Sent a batch of %d notifications to %s.", len(pending), name)
+				delete(d.state.Pending, name)
+				d.state.LastBatchTimes[name] = now
+			}
+		}
+	}
+
+	// Forget alerts that are old enough that they won't still be firing
+	for key, seenTime := range d.state.Seen {
+		if now.Sub(seenTime) > seenAlertRetention {
+			delete(d.state.Seen, key)
+		}
+	}
+
+	if err := d.saveState(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// Make a notification from an Alertmanager alert
+func newNotification(alert *models.GettableAlert) Notification {
+	notification := Notification{
+		Name:        alert.Labels["alertname"],
+		Severity:    alert.Labels["severity"],
+		Summary:     alert.Annotations["summary"],
+		Description: alert.Annotations["description"],
+	}
+	if alert.Fingerprint != nil {
+		notification.Fingerprint = *alert.Fingerprint
+	}
+	if alert.StartsAt != nil {
+		notification.StartsAt = time.Time(*alert.StartsAt)
+	}
+	return notification
+}
+
+// The key used to tell whether a notification has already been delivered.
+// The start time is included so an alert that resolves and fires again is delivered again.
+func (n Notification) key() string {
+	return fmt.Sprintf("%s-%d", n.Fingerprint, n.StartsAt.Unix())
+}
+
+// Create an empty dispatcher state
+func newDispatcherState() *dispatcherState {
+	return &dispatcherState{
+		Seen:           map[string]time.Time{},
+		Pending:        map[string][]Notification{},
+		LastBatchTimes: map[string]time.Time{},
+	}
+}
+
+// Load the dispatcher state from disk
+func (d *Dispatcher) loadState() error {
+	d.state = newDispatcherState()
+	bytes, err := os.ReadFile(d.statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading notification state [%s]: %w", d.statePath, err)
+	}
+	state := newDispatcherState()
+	if err := json.Unmarshal(bytes, state); err != nil {
+		return fmt.Errorf("error parsing notification state [%s]: %w", d.statePath, err)
+	}
+	if state.Seen == nil {
+		state.Seen = map[string]time.Time{}
+	}
+	if state.Pending == nil {
+		state.Pending = map[string][]Notification{}
+	}
+	if state.LastBatchTimes == nil {
+		state.LastBatchTimes = map[string]time.Time{}
+	}
+	d.state = state
+	return nil
+}
+
+// Save the dispatcher state to disk
+func (d *Dispatcher) saveState() error {
+	bytes, err := json.Marshal(d.state)
+	if err != nil {
+		return fmt.Errorf("error serializing notification state: %w", err)
+	}
+	if err := files.WriteFileAtomic(d.statePath, bytes, 0644); err != nil {
+		return fmt.Errorf("error saving notification state [%s]: %w", d.statePath, err)
+	}
+	return nil
+}
+
+// Send a test notification straight to every enabled sink, so the operator can check that they're set up correctly.
+// The results are keyed by the sink names; a nil error means the sink accepted the notification.
+func SendTestNotification(cfg *config.RocketPoolConfig, severity string) (map[string]error, error) {
+	sinks, err := GetSinks(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	notification := Notification{
+		Fingerprint: fmt.Sprintf("test-%d", time.Now().UnixNano()),
+		Name:        "TestAlert",
+		Summary:     "Test alert from the Rocket Pool Smartnode",
+		Description: fmt.Sprintf("This is a test %s alert sent with `rocketpool service test-alert`. If you can read this, notifications are working.", severity),
+		Severity:    severity,
+		StartsAt:    time.Now(),
+	}
+	results := map[string]error{}
+	for _, sink := range sinks {
+		results[sink.Name()] = sink.Send([]Notification{notification})
+	}
+	return results, nil
+}
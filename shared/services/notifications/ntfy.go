@@ -0,0 +1,75 @@
+package notifications
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Pushes alerts to a topic on an ntfy server
+type ntfySink struct {
+	pushRouting
+	url    string
+	token  string
+	client *http.Client
+}
+
+// Create an ntfy sink from the config, or nil if no topic is set
+func newNtfySink(cfg *config.RocketPoolConfig) *ntfySink {
+	topic := strings.Trim(strings.TrimSpace(cfg.Notifications.NtfyTopic.Value.(string)), "/")
+	if topic == "" {
+		return nil
+	}
+	server := strings.TrimSuffix(strings.TrimSpace(cfg.Notifications.NtfyServer.Value.(string)), "/")
+	return &ntfySink{
+		pushRouting: newPushRouting(cfg),
+		url:         fmt.Sprintf("%s/%s", server, topic),
+		token:       strings.TrimSpace(cfg.Notifications.NtfyToken.Value.(string)),
+		client:      &http.Client{Timeout: pushTimeout},
+	}
+}
+
+func (s *ntfySink) Name() string {
+	return "ntfy"
+}
+
+// Push new alerts
+func (s *ntfySink) Send(notifications []Notification) error {
+	title, message := getPushMessage(notifications, false)
+	return s.push(title, message, getHighestSeverity(notifications))
+}
+
+// Push a summary of the held alerts
+func (s *ntfySink) SendBatch(notifications []Notification, summary *Summary) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	title, message := getPushMessage(notifications, true)
+	return s.push(title, message, getHighestSeverity(notifications))
+}
+
+// Publish a message to the topic
+func (s *ntfySink) push(title string, message string, severityRank int) error {
+	request, err := http.NewRequest(http.MethodPost, s.url, strings.NewReader(message))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	request.Header.Set("Title", sanitizeHeader(title))
+	switch severityRank {
+	case 3:
+		request.Header.Set("Priority", "urgent")
+		request.Header.Set("Tags", "rotating_light")
+	case 2:
+		request.Header.Set("Priority", "high")
+		request.Header.Set("Tags", "warning")
+	default:
+		request.Header.Set("Priority", "default")
+		request.Header.Set("Tags", "information_source")
+	}
+	if s.token != "" {
+		request.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	return sendPushRequest(s.client, request)
+}
@@ -0,0 +1,102 @@
+package notifications
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Settings
+const (
+	pushTimeout time.Duration = 15 * time.Second
+
+	// The most alerts to list in a single batched push, since phones truncate long messages anyway
+	maxBatchedPushLines int = 10
+)
+
+// Decides which alerts are pushed to a phone right away and which are held for the next batch, based on their severity
+type pushRouting struct {
+	immediateRank int
+	batchInterval time.Duration
+}
+
+// Create the push routing rules from the config
+func newPushRouting(cfg *config.RocketPoolConfig) pushRouting {
+	return pushRouting{
+		immediateRank: getSeverityRank(string(cfg.Notifications.PushImmediateSeverity.Value.(cfgtypes.PushSeverity))),
+		batchInterval: time.Duration(cfg.Notifications.PushBatchInterval.Value.(uint64)) * time.Minute,
+	}
+}
+
+// Alerts at or above the configured severity are pushed right away
+func (r pushRouting) IsImmediate(notification Notification) bool {
+	return getSeverityRank(notification.Severity) >= r.immediateRank
+}
+
+// The rest are pushed together once per batch interval
+func (r pushRouting) IsBatchDue(lastBatchTime time.Time, now time.Time) bool {
+	return now.Sub(lastBatchTime) >= r.batchInterval
+}
+
+// Rank an alert's severity so they can be compared; alerts without a known severity are treated as warnings
+func getSeverityRank(severity string) int {
+	switch cfgtypes.PushSeverity(strings.ToLower(severity)) {
+	case cfgtypes.PushSeverity_Critical:
+		return 3
+	case cfgtypes.PushSeverity_Info:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Get the title and body of a push for a set of alerts
+func getPushMessage(notifications []Notification, batched bool) (string, string) {
+	if len(notifications) == 1 && !batched {
+		return notifications[0].Summary, notifications[0].Description
+	}
+
+	title := fmt.Sprintf("%d new alerts", len(notifications))
+	if batched {
+		title = fmt.Sprintf("%d alerts since the last summary", len(notifications))
+	}
+	lines := []string{}
+	for i, notification := range notifications {
+		if i == maxBatchedPushLines {
+			lines = append(lines, fmt.Sprintf("...and %d more", len(notifications)-maxBatchedPushLines))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("[%s] %s", notification.Severity, notification.Summary))
+	}
+	return title, strings.Join(lines, "\n")
+}
+
+// Get the highest severity in a set of alerts
+func getHighestSeverity(notifications []Notification) int {
+	highest := 0
+	for _, notification := range notifications {
+		if rank := getSeverityRank(notification.Severity); rank > highest {
+			highest = rank
+		}
+	}
+	return highest
+}
+
+// Send an HTTP request for a push and make sure it was accepted
+func sendPushRequest(client *http.Client, request *http.Request) error {
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+	if response.StatusCode/100 != 2 {
+		return fmt.Errorf("server returned status %d: %s", response.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package notifications
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Pushover settings; the lengths are in characters
+const (
+	pushoverApiUrl           string = "https://api.pushover.net/1/messages.json"
+	pushoverMaxTitleLength   int    = 250
+	pushoverMaxMessageLength int    = 1024
+)
+
+// Pushes alerts to a Pushover user or group
+type pushoverSink struct {
+	pushRouting
+	appToken string
+	userKey  string
+	client   *http.Client
+}
+
+// Create a Pushover sink from the config, or nil if it isn't set up
+func newPushoverSink(cfg *config.RocketPoolConfig) *pushoverSink {
+	appToken := strings.TrimSpace(cfg.Notifications.PushoverAppToken.Value.(string))
+	userKey := strings.TrimSpace(cfg.Notifications.PushoverUserKey.Value.(string))
+	if appToken == "" || userKey == "" {
+		return nil
+	}
+	return &pushoverSink{
+		pushRouting: newPushRouting(cfg),
+		appToken:    appToken,
+		userKey:     userKey,
+		client:      &http.Client{Timeout: pushTimeout},
+	}
+}
+
+func (s *pushoverSink) Name() string {
+	return "pushover"
+}
+
+// Push new alerts
+func (s *pushoverSink) Send(notifications []Notification) error {
+	title, message := getPushMessage(notifications, false)
+	return s.push(title, message, getHighestSeverity(notifications))
+}
+
+// Push a summary of the held alerts
+func (s *pushoverSink) SendBatch(notifications []Notification, summary *Summary) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	title, message := getPushMessage(notifications, true)
+	return s.push(title, message, getHighestSeverity(notifications))
+}
+
+// Send a message through the Pushover API
+func (s *pushoverSink) push(title string, message string, severityRank int) error {
+	if message == "" {
+		message = title
+	}
+	values := url.Values{}
+	values.Set("token", s.appToken)
+	values.Set("user", s.userKey)
+	values.Set("title", truncate(title, pushoverMaxTitleLength))
+	values.Set("message", truncate(message, pushoverMaxMessageLength))
+	switch severityRank {
+	case 3:
+		values.Set("priority", "1")
+	case 2:
+		values.Set("priority", "0")
+	default:
+		values.Set("priority", "-1")
+	}
+
+	request, err := http.NewRequest(http.MethodPost, pushoverApiUrl, strings.NewReader(values.Encode()))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return sendPushRequest(s.client, request)
+}
+
+// Shorten a string to the given number of characters
+func truncate(value string, length int) string {
+	runes := []rune(value)
+	if len(runes) <= length {
+		return value
+	}
+	return string(runes[:length-3]) + "..."
+}
@@ -0,0 +1,185 @@
+package offlinetx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// The name and version of the envelope format, which identify it the way an EIP-712 domain does
+	EnvelopeName    string = "Rocket Pool Smartnode Offline Transactions"
+	EnvelopeVersion string = "1"
+)
+
+// Identifies the envelope format and the chain its transactions are for, like an EIP-712 domain
+type Domain struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	ChainID uint64 `json:"chainId"`
+}
+
+// A set of transactions from one address, exported on an online node to be signed on an air-gapped machine
+type Envelope struct {
+	Domain       Domain         `json:"domain"`
+	From         common.Address `json:"from"`
+	CreatedAt    time.Time      `json:"createdAt"`
+	Transactions []*Transaction `json:"transactions"`
+}
+
+// An unsigned transaction in an envelope, and its signed copy once it's been signed
+type Transaction struct {
+	To                   common.Address `json:"to"`
+	Value                *big.Int       `json:"value"`
+	Data                 hexutil.Bytes  `json:"data"`
+	Nonce                uint64         `json:"nonce"`
+	GasLimit             uint64         `json:"gasLimit"`
+	MaxFeePerGas         *big.Int       `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *big.Int       `json:"maxPriorityFeePerGas"`
+
+	// The serialized unsigned transaction, and the hash that signing it covers so it can be compared on both machines
+	UnsignedTx  hexutil.Bytes `json:"unsignedTx"`
+	SigningHash common.Hash   `json:"signingHash"`
+
+	// The serialized signed transaction, added by the air-gapped machine
+	SignedTx hexutil.Bytes `json:"signedTx,omitempty"`
+}
+
+// Create an envelope for transactions from the provided address on the provided chain
+func NewEnvelope(chainID uint64, from common.Address) *Envelope {
+	return &Envelope{
+		Domain: Domain{
+			Name:    EnvelopeName,
+			Version: EnvelopeVersion,
+			ChainID: chainID,
+		},
+		From:         from,
+		CreatedAt:    time.Now().UTC(),
+		Transactions: []*Transaction{},
+	}
+}
+
+// Load an envelope from a file, making sure it's one this version of the Smartnode understands
+func Load(path string) (*Envelope, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	envelope := new(Envelope)
+	if err := json.Unmarshal(contents, envelope); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", path, err)
+	}
+	if envelope.Domain.Name != EnvelopeName || envelope.Domain.Version != EnvelopeVersion {
+		return nil, fmt.Errorf("%s isn't a version %s offline transaction file", path, EnvelopeVersion)
+	}
+	return envelope, nil
+}
+
+// Save the envelope to a file
+func (e *Envelope) Save(path string) error {
+	contents, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing the offline transactions: %w", err)
+	}
+	if err := os.WriteFile(path, contents, 0644); err != nil {
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+	return nil
+}
+
+// Get the nonce the next transaction added to the envelope should use, given the account's pending nonce on the chain
+func (e *Envelope) GetNextNonce(pendingNonce uint64) uint64 {
+	nonce := pendingNonce
+	for _, tx := range e.Transactions {
+		if tx.Nonce >= nonce {
+			nonce = tx.Nonce + 1
+		}
+	}
+	return nonce
+}
+
+// Add an unsigned transaction to the envelope
+func (e *Envelope) AddTransaction(to common.Address, value *big.Int, data []byte, nonce uint64, gasLimit uint64, maxFee *big.Int, maxPriorityFee *big.Int) (*Transaction, error) {
+	chainID := new(big.Int).SetUint64(e.Domain.ChainID)
+	unsignedTx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: maxPriorityFee,
+		GasFeeCap: maxFee,
+		Gas:       gasLimit,
+		To:        &to,
+		Value:     value,
+		Data:      data,
+	})
+	serializedTx, err := unsignedTx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("error serializing the transaction: %w", err)
+	}
+
+	tx := &Transaction{
+		To:                   to,
+		Value:                value,
+		Data:                 data,
+		Nonce:                nonce,
+		GasLimit:             gasLimit,
+		MaxFeePerGas:         maxFee,
+		MaxPriorityFeePerGas: maxPriorityFee,
+		UnsignedTx:           serializedTx,
+		SigningHash:          types.NewLondonSigner(chainID).Hash(unsignedTx),
+	}
+	e.Transactions = append(e.Transactions, tx)
+	return tx, nil
+}
+
+// Check that a transaction's serialized form matches its listed fields, so the fields shown for review are the ones that get signed
+func (e *Envelope) CheckUnsignedTransaction(tx *Transaction) (*types.Transaction, error) {
+	unsignedTx := new(types.Transaction)
+	if err := unsignedTx.UnmarshalBinary(tx.UnsignedTx); err != nil {
+		return nil, fmt.Errorf("error parsing the unsigned transaction: %w", err)
+	}
+	chainID := new(big.Int).SetUint64(e.Domain.ChainID)
+	if unsignedTx.ChainId().Cmp(chainID) != 0 ||
+		unsignedTx.Nonce() != tx.Nonce ||
+		unsignedTx.Gas() != tx.GasLimit ||
+		unsignedTx.To() == nil || *unsignedTx.To() != tx.To ||
+		unsignedTx.Value().Cmp(tx.Value) != 0 ||
+		unsignedTx.GasFeeCap().Cmp(tx.MaxFeePerGas) != 0 ||
+		unsignedTx.GasTipCap().Cmp(tx.MaxPriorityFeePerGas) != 0 ||
+		!bytes.Equal(unsignedTx.Data(), tx.Data) {
+		return nil, fmt.Errorf("the unsigned transaction with nonce %d doesn't match its listed fields", tx.Nonce)
+	}
+	if types.NewLondonSigner(chainID).Hash(unsignedTx) != tx.SigningHash {
+		return nil, fmt.Errorf("the unsigned transaction with nonce %d doesn't match its signing hash", tx.Nonce)
+	}
+	return unsignedTx, nil
+}
+
+// Check that a transaction's signed copy is the unsigned transaction signed by the envelope's sender
+func (e *Envelope) CheckSignedTransaction(tx *Transaction) (*types.Transaction, error) {
+	if len(tx.SignedTx) == 0 {
+		return nil, fmt.Errorf("the transaction with nonce %d hasn't been signed", tx.Nonce)
+	}
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(tx.SignedTx); err != nil {
+		return nil, fmt.Errorf("error parsing the signed transaction with nonce %d: %w", tx.Nonce, err)
+	}
+	signer := types.NewLondonSigner(new(big.Int).SetUint64(e.Domain.ChainID))
+	if signer.Hash(signedTx) != tx.SigningHash {
+		return nil, fmt.Errorf("the signed transaction with nonce %d isn't the one that was exported", tx.Nonce)
+	}
+	sender, err := types.Sender(signer, signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("error recovering the signer of the transaction with nonce %d: %w", tx.Nonce, err)
+	}
+	if sender != e.From {
+		return nil, fmt.Errorf("the transaction with nonce %d was signed by %s instead of %s", tx.Nonce, sender.Hex(), e.From.Hex())
+	}
+	return signedTx, nil
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"path/filepath"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Get the folders the daemons save their artifacts to
+func getArtifactFolders(cfg *config.RocketPoolConfig) []string {
+	folders := []string{
+		filepath.Dir(cfg.Smartnode.GetWalletPath()),
+		cfg.Smartnode.GetRecordsPath(),
+		cfg.Smartnode.GetVotingPath(),
+		filepath.Dir(cfg.Smartnode.GetRewardsTreePath(0, true)),
+		cfg.Smartnode.GetWatchtowerFolder(true),
+		cfg.Smartnode.GetBalancesReportsFolder(true),
+		filepath.Dir(cfg.Smartnode.GetQueueStatsPath(true)),
+		filepath.Dir(cfg.Smartnode.GetRewardsLedgerPath(true)),
+		filepath.Dir(cfg.Smartnode.GetNetworkStateCachePath(true)),
+	}
+
+	// Each rewards interval has its own record store
+	intervalFolders, _ := filepath.Glob(filepath.Join(cfg.Smartnode.GetRecordsPath(), config.RecordsIntervalFolderPrefix+"*"))
+	for _, folder := range intervalFolders {
+		folders = append(folders, folder, filepath.Join(folder, config.RecordBlobsFolder))
+	}
+
+	// Remove duplicates, since several artifacts share a folder
+	seen := map[string]bool{}
+	unique := []string{}
+	for _, folder := range folders {
+		if !seen[folder] {
+			seen[folder] = true
+			unique = append(unique, folder)
+		}
+	}
+	return unique
+}
+
+// Remove the temporary files left behind by writes that were interrupted the last time the daemon stopped.
+// The files they were meant to replace still have their previous contents, so this only reports what was found.
+func CleanupPartialWrites(cfg *config.RocketPoolConfig, logger *log.ColorLogger) {
+	for _, folder := range getArtifactFolders(cfg) {
+		removed, err := files.RemovePartialWrites(folder)
+		if err != nil {
+			logger.Printlnf("WARNING: couldn't clean up partially written files: %s", err.Error())
+			continue
+		}
+		for _, path := range removed {
+			logger.Printlnf("Removed %s, which was left behind by an interrupted write.", path)
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"os"
+
+	"github.com/rocket-pool/smartnode/shared/utils/files"
 )
 
 // Config
@@ -15,17 +17,23 @@ const (
 // Password manager
 type PasswordManager struct {
 	passwordPath string
+	source       PasswordSource
 }
 
-// Create new password manager
-func NewPasswordManager(passwordPath string) *PasswordManager {
+// Create new password manager. If source is nil, the password is stored in a file at passwordPath.
+func NewPasswordManager(passwordPath string, source PasswordSource) *PasswordManager {
 	return &PasswordManager{
 		passwordPath: passwordPath,
+		source:       source,
 	}
 }
 
 // Check if the password has been set
 func (pm *PasswordManager) IsPasswordSet() bool {
+	if pm.source != nil {
+		_, err := pm.source.GetPassword()
+		return (err == nil)
+	}
 	_, err := os.ReadFile(pm.passwordPath)
 	return (err == nil)
 }
@@ -33,6 +41,15 @@ func (pm *PasswordManager) IsPasswordSet() bool {
 // Get the password
 func (pm *PasswordManager) GetPassword() (string, error) {
 
+	// Use the external source if there is one
+	if pm.source != nil {
+		password, err := pm.source.GetPassword()
+		if err != nil {
+			return "", fmt.Errorf("Could not get password from %s: %w", pm.source.Name(), err)
+		}
+		return password, nil
+	}
+
 	// Read from disk
 	password, err := os.ReadFile(pm.passwordPath)
 	if err != nil {
@@ -52,13 +69,18 @@ func (pm *PasswordManager) SetPassword(password string) error {
 		return errors.New("Password is already set")
 	}
 
+	// External sources are managed outside of the Smartnode
+	if pm.source != nil {
+		return fmt.Errorf("The password is retrieved from %s, which doesn't have it yet; please store your password there instead", pm.source.Name())
+	}
+
 	// Check password length
 	if len(password) < MinPasswordLength {
 		return fmt.Errorf("Password must be at least %d characters long", MinPasswordLength)
 	}
 
 	// Write to disk
-	if err := os.WriteFile(pm.passwordPath, []byte(password), FileMode); err != nil {
+	if err := files.WriteFileAtomic(pm.passwordPath, []byte(password), FileMode); err != nil {
 		return fmt.Errorf("Could not write password to disk: %w", err)
 	}
 
@@ -67,9 +89,13 @@ func (pm *PasswordManager) SetPassword(password string) error {
 
 }
 
-// Delete the password
+// Delete the password. Passwords in external sources are left alone.
 func (pm *PasswordManager) DeletePassword() error {
 
+	if pm.source != nil {
+		return nil
+	}
+
 	// Check if it exists
 	_, err := os.Stat(pm.passwordPath)
 	if os.IsNotExist(err) {
@@ -0,0 +1,206 @@
+package passwords
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// The account name the node wallet password is stored under in OS keyrings
+const KeyringAccount string = "node-wallet"
+
+// The timeout for retrieving the password from an external source
+const sourceTimeout time.Duration = 30 * time.Second
+
+// An external secret store that holds the node wallet password instead of a file on disk
+type PasswordSource interface {
+	// A human-readable description of the source
+	Name() string
+
+	// Retrieve the password from the source
+	GetPassword() (string, error)
+}
+
+// Retrieves the password from the OS keyring
+type keyringSource struct {
+	service string
+}
+
+// Create a password source that reads from the OS keyring
+func NewKeyringSource(service string) PasswordSource {
+	return &keyringSource{
+		service: service,
+	}
+}
+
+func (s *keyringSource) Name() string {
+	return fmt.Sprintf("the OS keyring (service %s, account %s)", s.service, KeyringAccount)
+}
+
+func (s *keyringSource) GetPassword() (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "linux":
+		cmd = exec.Command("secret-tool", "lookup", "service", s.service, "account", KeyringAccount)
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", s.service, "-a", KeyringAccount, "-w")
+	default:
+		return "", fmt.Errorf("the OS keyring is not supported on %s", runtime.GOOS)
+	}
+	return runPasswordCommand(cmd)
+}
+
+// Retrieves the password from the output of an arbitrary command
+type commandSource struct {
+	command string
+}
+
+// Create a password source that runs a shell command
+func NewCommandSource(command string) PasswordSource {
+	return &commandSource{
+		command: command,
+	}
+}
+
+func (s *commandSource) Name() string {
+	return fmt.Sprintf("the command [%s]", s.command)
+}
+
+func (s *commandSource) GetPassword() (string, error) {
+	if s.command == "" {
+		return "", fmt.Errorf("no password command has been configured")
+	}
+	return runPasswordCommand(exec.Command("sh", "-c", s.command))
+}
+
+// Retrieves the password from a HashiCorp Vault KV secret
+type vaultSource struct {
+	address    string
+	secretPath string
+	field      string
+	tokenPath  string
+}
+
+// Create a password source that reads from HashiCorp Vault.
+// If tokenPath is blank, the VAULT_TOKEN environment variable is used instead.
+func NewVaultSource(address string, secretPath string, field string, tokenPath string) PasswordSource {
+	return &vaultSource{
+		address:    strings.TrimSuffix(address, "/"),
+		secretPath: strings.Trim(secretPath, "/"),
+		field:      field,
+		tokenPath:  tokenPath,
+	}
+}
+
+func (s *vaultSource) Name() string {
+	return fmt.Sprintf("HashiCorp Vault (%s/v1/%s)", s.address, s.secretPath)
+}
+
+func (s *vaultSource) GetPassword() (string, error) {
+	if s.address == "" {
+		return "", fmt.Errorf("no Vault address has been configured")
+	}
+
+	// Get the token
+	token := os.Getenv("VAULT_TOKEN")
+	if s.tokenPath != "" {
+		tokenBytes, err := os.ReadFile(s.tokenPath)
+		if err != nil {
+			return "", fmt.Errorf("error reading Vault token from [%s]: %w", s.tokenPath, err)
+		}
+		token = strings.TrimSpace(string(tokenBytes))
+	}
+	if token == "" {
+		return "", fmt.Errorf("no Vault token file has been configured and VAULT_TOKEN is not set")
+	}
+
+	// Read the secret
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/v1/%s", s.address, s.secretPath), nil)
+	if err != nil {
+		return "", fmt.Errorf("error creating Vault request: %w", err)
+	}
+	request.Header.Set("X-Vault-Token", token)
+	client := http.Client{Timeout: sourceTimeout}
+	response, err := client.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("error reading secret from Vault: %w", err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", fmt.Errorf("error reading Vault response: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Vault returned HTTP status %d: %s", response.StatusCode, string(body))
+	}
+
+	// KV v2 secrets nest the values inside another data object, v1 secrets don't
+	var secret struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	err = json.Unmarshal(body, &secret)
+	if err != nil {
+		return "", fmt.Errorf("error decoding Vault response: %w", err)
+	}
+	fields := map[string]interface{}{}
+	if nested, exists := secret.Data["data"]; exists {
+		err = json.Unmarshal(nested, &fields)
+	} else {
+		for key, value := range secret.Data {
+			var decoded interface{}
+			err = json.Unmarshal(value, &decoded)
+			if err != nil {
+				break
+			}
+			fields[key] = decoded
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("error decoding Vault secret: %w", err)
+	}
+	password, ok := fields[s.field].(string)
+	if !ok || password == "" {
+		return "", fmt.Errorf("the Vault secret does not have a string field named [%s]", s.field)
+	}
+	return password, nil
+}
+
+// Run a command and return the first line it printed
+func runPasswordCommand(cmd *exec.Cmd) (string, error) {
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Start()
+	if err != nil {
+		return "", fmt.Errorf("error running %s: %w", cmd.Path, err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+	select {
+	case err = <-done:
+	case <-time.After(sourceTimeout):
+		_ = cmd.Process.Kill()
+		return "", fmt.Errorf("%s did not finish within %s", cmd.Path, sourceTimeout)
+	}
+	if err != nil {
+		return "", fmt.Errorf("error running %s: %w (%s)", cmd.Path, err, strings.TrimSpace(stderr.String()))
+	}
+
+	scanner := bufio.NewScanner(&stdout)
+	if !scanner.Scan() || scanner.Text() == "" {
+		return "", fmt.Errorf("%s did not print a password", cmd.Path)
+	}
+	return scanner.Text(), nil
+}
@@ -0,0 +1,106 @@
+package pinning
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// The options that make Kubo build the same DAG the Smartnode uses to calculate a rewards file's CID
+const kuboAddOptions string = "cid-version=1&raw-leaves=true&chunker=size-1048576&wrap-with-directory=true&pin=true"
+
+// An IPFS node's Kubo RPC API
+type kuboClient struct {
+	url    string
+	client *http.Client
+}
+
+// Create a client for a Kubo RPC API, or nil if no URL is set
+func newKuboClient(url string, timeout time.Duration) *kuboClient {
+	url = strings.TrimSuffix(strings.TrimSpace(url), "/")
+	if url == "" {
+		return nil
+	}
+	return &kuboClient{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Upload a file inside a directory, the way rewards files are published, and return the CID of the directory
+func (k *kuboClient) add(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("error opening [%s]: %w", path, err)
+	}
+	defer file.Close()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filepath.Base(path))
+	if err != nil {
+		return "", fmt.Errorf("error creating upload: %w", err)
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return "", fmt.Errorf("error reading [%s]: %w", path, err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("error creating upload: %w", err)
+	}
+
+	response, err := k.client.Post(fmt.Sprintf("%s/api/v0/add?%s", k.url, kuboAddOptions), writer.FormDataContentType(), &body)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	if response.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 200))
+		return "", fmt.Errorf("IPFS node returned status %d: %s", response.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	// Kubo returns one line per added object; the wrapping directory has an empty name
+	scanner := bufio.NewScanner(response.Body)
+	for scanner.Scan() {
+		var entry struct {
+			Name string `json:"Name"`
+			Hash string `json:"Hash"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return "", fmt.Errorf("error decoding IPFS node response: %w", err)
+		}
+		if entry.Name == "" {
+			return entry.Hash, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("error reading IPFS node response: %w", err)
+	}
+	return "", fmt.Errorf("IPFS node didn't return the directory CID")
+}
+
+// Get the addresses the IPFS node can be reached at, so pinning services can fetch files from it directly
+func (k *kuboClient) getAddresses() ([]string, error) {
+	response, err := k.client.Post(k.url+"/api/v0/id", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("IPFS node returned status %d", response.StatusCode)
+	}
+	var id struct {
+		Addresses []string `json:"Addresses"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&id); err != nil {
+		return nil, fmt.Errorf("error decoding IPFS node response: %w", err)
+	}
+	return id.Addresses, nil
+}
@@ -0,0 +1,378 @@
+package pinning
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// How long to wait for a pinning service, IPFS node, or gateway to respond
+	requestTimeout time.Duration = 30 * time.Second
+
+	// Uploads can be large, so they get more time
+	uploadTimeout time.Duration = 5 * time.Minute
+
+	// How much of each file to fetch from the gateways; this is enough to prove they can serve it
+	gatewayCheckBytes int = 1024
+)
+
+// The pin for a file at a single pinning service
+type ServicePin struct {
+	Service   string    `json:"service"`
+	RequestID string    `json:"requestId"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	Updated   time.Time `json:"updated"`
+}
+
+// The result of fetching a file through a single gateway
+type GatewayCheck struct {
+	Gateway   string    `json:"gateway"`
+	Reachable bool      `json:"reachable"`
+	Error     string    `json:"error,omitempty"`
+	Checked   time.Time `json:"checked"`
+}
+
+// A rewards file that's being kept pinned
+type TrackedFile struct {
+	Interval    uint64         `json:"interval"`
+	Filename    string         `json:"filename"`
+	Path        string         `json:"path"`
+	Cid         string         `json:"cid"`
+	AddedTime   time.Time      `json:"addedTime"`
+	Uploaded    bool           `json:"uploaded"`
+	UploadError string         `json:"uploadError,omitempty"`
+	Pins        []ServicePin   `json:"pins"`
+	Gateways    []GatewayCheck `json:"gateways"`
+	RepinCount  uint64         `json:"repinCount"`
+	LastChecked time.Time      `json:"lastChecked"`
+}
+
+// The pinning status of every tracked file
+type Status struct {
+	Files []TrackedFile `json:"files"`
+}
+
+// Keeps the submitted rewards files pinned with several pinning services, and re-pins them when they can't be retrieved
+type Manager struct {
+	cfg        *config.RocketPoolConfig
+	log        *log.ColorLogger
+	statusPath string
+	kubo       *kuboClient
+	services   []*pinningService
+	gateways   []string
+	lock       sync.Mutex
+}
+
+// Create a new pinning manager from the config
+func NewManager(cfg *config.RocketPoolConfig, logger *log.ColorLogger) *Manager {
+	gateways := []string{}
+	for _, gateway := range strings.Split(cfg.Smartnode.IpfsVerificationGateways.Value.(string), ";") {
+		gateway = strings.TrimSpace(gateway)
+		if gateway != "" {
+			gateways = append(gateways, gateway)
+		}
+	}
+	return &Manager{
+		cfg:        cfg,
+		log:        logger,
+		statusPath: cfg.Smartnode.GetPinningStatusPath(true),
+		kubo:       newKuboClient(cfg.Smartnode.IpfsApiUrl.Value.(string), uploadTimeout),
+		services:   parsePinningServices(cfg.Smartnode.IpfsPinningServices.Value.(string), requestTimeout),
+		gateways:   gateways,
+	}
+}
+
+// Check if pinning is enabled
+func (m *Manager) IsEnabled() bool {
+	return m.cfg.Smartnode.EnableIpfsPinning.Value == true
+}
+
+// Start keeping a file pinned. The file is uploaded and pinned the next time the manager runs.
+func (m *Manager) Track(interval uint64, path string, cid string) error {
+	if !m.IsEnabled() {
+		return nil
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	status, err := LoadStatus(m.statusPath)
+	if err != nil {
+		return err
+	}
+	filename := filepath.Base(path)
+	for i, file := range status.Files {
+		if file.Filename == filename {
+			if file.Cid == cid {
+				return nil
+			}
+			// The file was regenerated, so start over with the new one
+			status.Files = append(status.Files[:i], status.Files[i+1:]...)
+			break
+		}
+	}
+	status.Files = append(status.Files, TrackedFile{
+		Interval:  interval,
+		Filename:  filename,
+		Path:      path,
+		Cid:       cid,
+		AddedTime: time.Now(),
+		Pins:      []ServicePin{},
+		Gateways:  []GatewayCheck{},
+	})
+	return m.saveStatus(status)
+}
+
+// Check every tracked file that's due: upload it if it hasn't been, make sure each service has it pinned, and check that it can be retrieved through the gateways
+func (m *Manager) Run() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	status, err := LoadStatus(m.statusPath)
+	if err != nil {
+		return err
+	}
+
+	checkInterval := time.Duration(m.cfg.Smartnode.IpfsPinCheckInterval.Value.(uint64)) * time.Hour
+	origins := []string{}
+	if m.kubo != nil {
+		origins, err = m.kubo.getAddresses()
+		if err != nil {
+			m.log.Printlnf("WARNING: couldn't get the IPFS node's addresses: %s", err.Error())
+		}
+	}
+
+	for i := range status.Files {
+		file := &status.Files[i]
+		if time.Since(file.LastChecked) < checkInterval {
+			continue
+		}
+		m.checkFile(file, origins)
+		file.LastChecked = time.Now()
+
+		// Save after each file so progress isn't lost if the next one takes a long time
+		if err := m.saveStatus(status); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Make sure a single file is uploaded, pinned, and retrievable
+func (m *Manager) checkFile(file *TrackedFile, origins []string) {
+	// Upload it to the IPFS node
+	if !file.Uploaded && m.kubo != nil {
+		if err := m.upload(file); err != nil {
+			file.UploadError = err.Error()
+			m.log.Printlnf("WARNING: couldn't upload %s to the IPFS node: %s", file.Filename, err.Error())
+		} else {
+			file.Uploaded = true
+			file.UploadError = ""
+			m.log.Printlnf("Uploaded %s to the IPFS node.", file.Filename)
+		}
+	}
+
+	// Check the pins, and re-request any that failed or disappeared
+	allPinned := true
+	for _, service := range m.services {
+		pin := getServicePin(file, service.name())
+		repinned, err := m.checkPin(file, pin, service, origins)
+		if err != nil {
+			pin.Error = err.Error()
+			m.log.Printlnf("WARNING: couldn't check the pin for %s at %s: %s", file.Filename, pin.Service, err.Error())
+		} else {
+			pin.Error = ""
+		}
+		if repinned {
+			file.RepinCount++
+		}
+		pin.Updated = time.Now()
+		if pin.Status != PinState_Pinned {
+			allPinned = false
+		}
+	}
+
+	// Check the gateways
+	unreachable := []string{}
+	file.Gateways = []GatewayCheck{}
+	for _, gateway := range m.gateways {
+		url := fmt.Sprintf(gateway, file.Cid, file.Filename)
+		check := GatewayCheck{
+			Gateway: url,
+			Checked: time.Now(),
+		}
+		if err := checkGateway(url); err != nil {
+			check.Error = err.Error()
+			unreachable = append(unreachable, url)
+		} else {
+			check.Reachable = true
+		}
+		file.Gateways = append(file.Gateways, check)
+	}
+
+	// If the services say the file is pinned but a gateway can't get it, the copies aren't being provided - pin it again
+	if len(unreachable) > 0 && allPinned && len(m.services) > 0 {
+		m.log.Printlnf("%s couldn't be retrieved through %s; pinning it again.", file.Filename, strings.Join(unreachable, ", "))
+		for _, service := range m.services {
+			pin := getServicePin(file, service.name())
+			if err := m.repin(file, pin, service, origins); err != nil {
+				pin.Error = err.Error()
+				m.log.Printlnf("WARNING: couldn't pin %s at %s again: %s", file.Filename, pin.Service, err.Error())
+			}
+		}
+		file.RepinCount++
+		if m.kubo != nil {
+			file.Uploaded = false
+		}
+	}
+}
+
+// Upload a file to the IPFS node and make sure it gets the CID that was submitted
+func (m *Manager) upload(file *TrackedFile) error {
+	cid, err := m.kubo.add(file.Path)
+	if err != nil {
+		return err
+	}
+	if cid != file.Cid {
+		return fmt.Errorf("the IPFS node calculated CID %s, but %s was submitted", cid, file.Cid)
+	}
+	return nil
+}
+
+// Check a file's pin at a service, requesting it if it's missing or failed. Returns true if the file was pinned again.
+func (m *Manager) checkPin(file *TrackedFile, pin *ServicePin, service *pinningService, origins []string) (bool, error) {
+	if pin.RequestID == "" {
+		return false, m.requestPin(file, pin, service, origins)
+	}
+
+	current, err := service.getPin(pin.RequestID)
+	if err != nil {
+		return false, err
+	}
+	if current == nil {
+		m.log.Printlnf("%s no longer has %s pinned; pinning it again.", pin.Service, file.Filename)
+		return true, m.requestPin(file, pin, service, origins)
+	}
+	pin.Status = current.Status
+	if pin.Status == PinState_Failed {
+		m.log.Printlnf("%s failed to pin %s; pinning it again.", pin.Service, file.Filename)
+		return true, m.repin(file, pin, service, origins)
+	}
+	return false, nil
+}
+
+// Replace a file's pin request at a service with a new one
+func (m *Manager) repin(file *TrackedFile, pin *ServicePin, service *pinningService, origins []string) error {
+	if pin.RequestID != "" {
+		if err := service.removePin(pin.RequestID); err != nil {
+			return fmt.Errorf("error removing the old pin request: %w", err)
+		}
+	}
+	return m.requestPin(file, pin, service, origins)
+}
+
+// Ask a service to pin a file
+func (m *Manager) requestPin(file *TrackedFile, pin *ServicePin, service *pinningService, origins []string) error {
+	status, err := service.addPin(file.Cid, file.Filename, origins)
+	if err != nil {
+		return err
+	}
+	pin.RequestID = status.RequestID
+	pin.Status = status.Status
+	return nil
+}
+
+// Make sure the IPFS node and every pinning service accept the configured credentials, returning a description of each problem
+func (m *Manager) CheckCredentials() []string {
+	problems := []string{}
+	if m.kubo != nil {
+		if _, err := m.kubo.getAddresses(); err != nil {
+			problems = append(problems, fmt.Sprintf("The IPFS node couldn't be reached: %s", err.Error()))
+		}
+	}
+	for _, service := range m.services {
+		if err := service.checkAccess(); err != nil {
+			problems = append(problems, fmt.Sprintf("The pinning service %s rejected its access token: %s", service.name(), err.Error()))
+		}
+	}
+	return problems
+}
+
+// Get a file's pin for a service, adding one if the service is new
+func getServicePin(file *TrackedFile, service string) *ServicePin {
+	for i := range file.Pins {
+		if file.Pins[i].Service == service {
+			return &file.Pins[i]
+		}
+	}
+	file.Pins = append(file.Pins, ServicePin{
+		Service: service,
+	})
+	return &file.Pins[len(file.Pins)-1]
+}
+
+// Fetch the start of a file through a gateway
+func checkGateway(url string) error {
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	request.Header.Set("Range", fmt.Sprintf("bytes=0-%d", gatewayCheckBytes-1))
+	client := http.Client{Timeout: requestTimeout}
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusPartialContent {
+		return fmt.Errorf("gateway returned status %d", response.StatusCode)
+	}
+	if _, err := io.CopyN(io.Discard, response.Body, 1); err != nil {
+		return fmt.Errorf("error reading from the gateway: %w", err)
+	}
+	return nil
+}
+
+// Load the pinning status file, or an empty status if it doesn't exist yet
+func LoadStatus(path string) (*Status, error) {
+	status := &Status{
+		Files: []TrackedFile{},
+	}
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return status, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading pinning status [%s]: %w", path, err)
+	}
+	if err := json.Unmarshal(bytes, status); err != nil {
+		return nil, fmt.Errorf("error parsing pinning status [%s]: %w", path, err)
+	}
+	return status, nil
+}
+
+// Save the pinning status file
+func (m *Manager) saveStatus(status *Status) error {
+	bytes, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("error serializing pinning status: %w", err)
+	}
+	if err := files.WriteFileAtomic(m.statusPath, bytes, 0644); err != nil {
+		return fmt.Errorf("error saving pinning status [%s]: %w", m.statusPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package pinning
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// The states a pin request can be in, from the IPFS Pinning Service API
+const (
+	PinState_Queued  string = "queued"
+	PinState_Pinning string = "pinning"
+	PinState_Pinned  string = "pinned"
+	PinState_Failed  string = "failed"
+)
+
+// A service that implements the IPFS Pinning Service API (https://ipfs.github.io/pinning-services-api-spec/)
+type pinningService struct {
+	endpoint string
+	token    string
+	client   *http.Client
+}
+
+// A pin request's status, as returned by the service
+type pinStatus struct {
+	RequestID string    `json:"requestid"`
+	Status    string    `json:"status"`
+	Created   time.Time `json:"created"`
+}
+
+// Parse the pinning services from the config string, formatted as `endpoint|token;endpoint|token`
+func parsePinningServices(value string, timeout time.Duration) []*pinningService {
+	services := []*pinningService{}
+	for _, entry := range strings.Split(value, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		endpoint, token, _ := strings.Cut(entry, "|")
+		services = append(services, &pinningService{
+			endpoint: strings.TrimSuffix(strings.TrimSpace(endpoint), "/"),
+			token:    strings.TrimSpace(token),
+			client:   &http.Client{Timeout: timeout},
+		})
+	}
+	return services
+}
+
+// The name of the service, used in logs and the status file; this is the host so the token is never shown
+func (s *pinningService) name() string {
+	parsed, err := url.Parse(s.endpoint)
+	if err != nil || parsed.Host == "" {
+		return s.endpoint
+	}
+	return parsed.Host
+}
+
+// Ask the service to pin a CID
+func (s *pinningService) addPin(cid string, name string, origins []string) (*pinStatus, error) {
+	body := map[string]interface{}{
+		"cid":  cid,
+		"name": name,
+	}
+	if len(origins) > 0 {
+		body["origins"] = origins
+	}
+	var status pinStatus
+	err := s.call(http.MethodPost, "/pins", body, &status)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// Get the status of a pin request; returns nil if the service doesn't have the request anymore
+func (s *pinningService) getPin(requestID string) (*pinStatus, error) {
+	var status pinStatus
+	err := s.call(http.MethodGet, "/pins/"+url.PathEscape(requestID), nil, &status)
+	if err == errPinNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// Remove a pin request, so it can be replaced
+func (s *pinningService) removePin(requestID string) error {
+	err := s.call(http.MethodDelete, "/pins/"+url.PathEscape(requestID), nil, nil)
+	if err == errPinNotFound {
+		return nil
+	}
+	return err
+}
+
+// Make sure the service accepts the access token
+func (s *pinningService) checkAccess() error {
+	return s.call(http.MethodGet, "/pins?limit=1", nil, nil)
+}
+
+var errPinNotFound = fmt.Errorf("pin request not found")
+
+// Call the service's API
+func (s *pinningService) call(method string, path string, body interface{}, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error serializing request: %w", err)
+		}
+		reader = bytes.NewReader(bodyBytes)
+	}
+
+	request, err := http.NewRequest(method, s.endpoint+path, reader)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	request.Header.Set("Authorization", "Bearer "+s.token)
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	response, err := s.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	responseBytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response: %w", err)
+	}
+
+	if response.StatusCode == http.StatusNotFound {
+		return errPinNotFound
+	}
+	if response.StatusCode/100 != 2 {
+		message := strings.TrimSpace(string(responseBytes))
+		if len(message) > 200 {
+			message = message[:200]
+		}
+		return fmt.Errorf("service returned status %d: %s", response.StatusCode, message)
+	}
+	if result != nil {
+		if err := json.Unmarshal(responseBytes, result); err != nil {
+			return fmt.Errorf("error decoding response: %w", err)
+		}
+	}
+	return nil
+}
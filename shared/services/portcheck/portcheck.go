@@ -0,0 +1,203 @@
+package portcheck
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/net"
+)
+
+// Settings
+const reachabilityTimeout = 15 * time.Second
+
+// The result of checking one of the ports the Smartnode publishes on the host
+type PortCheck struct {
+	config.HostPort
+
+	// The configured port
+	Port uint16
+
+	// Why the port can't be used, or blank if it can
+	Conflict string
+
+	// A free port to use instead if there's a conflict, or 0 if none was found
+	SuggestedPort uint16
+
+	// Whether the port could be reached from the internet; this is only checked for TCP P2P ports
+	ReachabilityChecked bool
+	Reachable           bool
+	ReachabilityError   error
+
+	// Whether the port could be reached from the internet over IPv6, if IPv6 is enabled
+	Ipv6ReachabilityChecked bool
+	Ipv6Reachable           bool
+	Ipv6ReachabilityError   error
+}
+
+// The result of checking the host's IPv6 connectivity
+type Ipv6Check struct {
+	// The public IPv6 addresses on the host's network interfaces
+	Addresses []string
+
+	// Why the addresses couldn't be read, or nil if they could
+	AddressError error
+
+	// Why the host couldn't connect to the internet over IPv6, or nil if it could
+	ConnectivityError error
+
+	// Whether the Advertised IPv6 Address is set but isn't one of the host's addresses
+	AdvertisedAddressMissing bool
+}
+
+// Get a readable name for the port's setting
+func (check PortCheck) Name() string {
+	return fmt.Sprintf("%s %s (%s)", check.Container, check.Parameter.Name, check.FlagName)
+}
+
+// Check the ports the Smartnode publishes for conflicts with other programs on the host and with each other.
+// ownPorts has the ports the Smartnode's running containers already publish, formatted as `port/protocol`, so they aren't
+// counted as conflicts.
+func CheckLocalPorts(cfg *config.RocketPoolConfig, ownPorts map[string]bool) []PortCheck {
+	hostPorts := cfg.GetHostPorts()
+	checks := make([]PortCheck, len(hostPorts))
+	usedBy := map[string]int{}
+	for i, hostPort := range hostPorts {
+		checks[i] = PortCheck{
+			HostPort: hostPort,
+			Port:     hostPort.Parameter.Value.(uint16),
+		}
+	}
+
+	// Look for conflicts
+	for i := range checks {
+		check := &checks[i]
+		for _, protocol := range check.Protocols {
+			key := portKey(check.Port, protocol)
+			if other, exists := usedBy[key]; exists {
+				check.Conflict = fmt.Sprintf("%d/%s is also used by %s", check.Port, protocol, checks[other].Name())
+				break
+			}
+			usedBy[key] = i
+			if !ownPorts[key] && !net.IsPortFree(check.Port, protocol) {
+				check.Conflict = fmt.Sprintf("%d/%s is already in use by another program", check.Port, protocol)
+				break
+			}
+		}
+	}
+
+	// Suggest free ports for the conflicts
+	for i := range checks {
+		check := &checks[i]
+		if check.Conflict == "" {
+			continue
+		}
+		for port := uint32(check.Port) + 1; port <= 65535; port++ {
+			if isAvailable(uint16(port), check.Protocols, usedBy, ownPorts) {
+				check.SuggestedPort = uint16(port)
+				for _, protocol := range check.Protocols {
+					usedBy[portKey(check.SuggestedPort, protocol)] = i
+				}
+				break
+			}
+		}
+	}
+
+	return checks
+}
+
+// Check if the P2P ports can be reached from the internet with the configured port checker
+func CheckReachability(cfg *config.RocketPoolConfig, checks []PortCheck) {
+	checkerUrl := strings.TrimSpace(cfg.Smartnode.PortCheckerUrl.Value.(string))
+	if checkerUrl == "" {
+		return
+	}
+	for i := range checks {
+		check := &checks[i]
+		if !check.P2P || check.Conflict != "" || !hasProtocol(check.Protocols, "tcp") {
+			continue
+		}
+		check.ReachabilityChecked = true
+		check.Reachable, check.ReachabilityError = net.CheckPortReachable(checkerUrl, check.Port, reachabilityTimeout)
+	}
+}
+
+// Check if the host has working IPv6 connectivity for the clients to use
+func CheckIpv6(cfg *config.RocketPoolConfig) Ipv6Check {
+	check := Ipv6Check{}
+	addresses, err := net.GetPublicIpv6Addresses()
+	if err != nil {
+		check.AddressError = err
+	}
+	for _, address := range addresses {
+		check.Addresses = append(check.Addresses, address.String())
+	}
+	check.ConnectivityError = net.CheckIpv6Connectivity(reachabilityTimeout)
+
+	advertisedAddress := strings.TrimSpace(cfg.Smartnode.Ipv6AdvertisedAddress.Value.(string))
+	if cfg.Smartnode.Ipv6Discovery.Value == true && advertisedAddress != "" && check.AddressError == nil {
+		check.AdvertisedAddressMissing = true
+		for _, address := range addresses {
+			if address.String() == advertisedAddress {
+				check.AdvertisedAddressMissing = false
+				break
+			}
+		}
+	}
+	return check
+}
+
+// Check if the P2P ports can be reached from the internet over IPv6 with the configured port checker
+func CheckReachabilityOverIpv6(cfg *config.RocketPoolConfig, checks []PortCheck) {
+	checkerUrl := strings.TrimSpace(cfg.Smartnode.PortCheckerUrl.Value.(string))
+	if checkerUrl == "" {
+		return
+	}
+	for i := range checks {
+		check := &checks[i]
+		if !check.P2P || check.Conflict != "" || !hasProtocol(check.Protocols, "tcp") {
+			continue
+		}
+		check.Ipv6ReachabilityChecked = true
+		check.Ipv6Reachable, check.Ipv6ReachabilityError = net.CheckPortReachableOverIpv6(checkerUrl, check.Port, reachabilityTimeout)
+	}
+}
+
+// Change the ports with conflicts to their suggested replacements, returning how many were changed
+func ApplySuggestions(checks []PortCheck) int {
+	changed := 0
+	for _, check := range checks {
+		if check.Conflict != "" && check.SuggestedPort != 0 {
+			check.Parameter.Value = check.SuggestedPort
+			changed++
+		}
+	}
+	return changed
+}
+
+// Check if a port is free for all of the given protocols and isn't planned for anything else
+func isAvailable(port uint16, protocols []string, usedBy map[string]int, ownPorts map[string]bool) bool {
+	for _, protocol := range protocols {
+		key := portKey(port, protocol)
+		if _, exists := usedBy[key]; exists || ownPorts[key] || !net.IsPortFree(port, protocol) {
+			return false
+		}
+	}
+	return true
+}
+
+// Check if a list of protocols includes the given one
+func hasProtocol(protocols []string, protocol string) bool {
+	for _, candidate := range protocols {
+		if candidate == protocol {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the key for a port and protocol, like `30303/tcp`
+func portKey(port uint16, protocol string) string {
+	return fmt.Sprintf("%d/%s", port, protocol)
+}
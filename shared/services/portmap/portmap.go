@@ -0,0 +1,261 @@
+package portmap
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/nat"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// How long the router is asked to keep each mapping
+	mappingLifetime = 20 * time.Minute
+
+	// Mappings are renewed once they have less than this much time left
+	renewalMargin = 10 * time.Minute
+
+	// How long to wait before looking for the router again after it couldn't be found
+	discoveryRetryInterval = 5 * time.Minute
+
+	fileMode os.FileMode = 0644
+)
+
+// Keeps the router's port mappings for the P2P ports up to date
+type Forwarder struct {
+	cfg *config.RocketPoolConfig
+	log *log.ColorLogger
+
+	nat           nat.Interface
+	lastDiscovery time.Time
+	status        api.PortForwardingStatus
+}
+
+// Create a new port forwarder
+func NewForwarder(cfg *config.RocketPoolConfig, logger *log.ColorLogger) *Forwarder {
+	return &Forwarder{
+		cfg: cfg,
+		log: logger,
+		status: api.PortForwardingStatus{
+			Method:   fmt.Sprint(cfg.Smartnode.PortForwardingMethod.Value),
+			Mappings: []api.PortMapping{},
+		},
+	}
+}
+
+// Find the router if it hasn't been found yet, add or renew the mappings that are due, and save the status
+func (f *Forwarder) Refresh() error {
+	if f.cfg.Smartnode.EnablePortForwarding.Value != true {
+		return nil
+	}
+	defer f.save()
+	f.status.UpdatedAt = time.Now().UTC()
+
+	// Find the router
+	if f.nat == nil {
+		if time.Since(f.lastDiscovery) < discoveryRetryInterval {
+			return nil
+		}
+		f.lastDiscovery = time.Now()
+		natInterface, err := f.discover()
+		if err != nil {
+			f.status.Error = err.Error()
+			return fmt.Errorf("error finding the router: %w", err)
+		}
+		f.nat = natInterface
+	}
+
+	// Get the external IP; this also waits for the router to be discovered
+	externalIp, err := f.nat.ExternalIP()
+	if err != nil {
+		f.nat = nil
+		f.status.Error = fmt.Sprintf("couldn't reach the router: %s", err.Error())
+		return fmt.Errorf("error getting the external IP address from the router (is UPnP or NAT-PMP enabled on it?): %w", err)
+	}
+	f.status.Error = ""
+	if f.status.ExternalIp != externalIp.String() {
+		f.log.Printlnf("Found the router with %s; its external IP address is %s. Run `rocketpool service start` if your clients need to advertise the new address.", f.nat.String(), externalIp.String())
+		f.status.ExternalIp = externalIp.String()
+		if err := files.WriteFileAtomic(f.cfg.Smartnode.GetExternalIpPath(true), []byte(f.status.ExternalIp+"\n"), fileMode); err != nil {
+			f.log.Printlnf("WARNING: couldn't save the external IP address: %s", err.Error())
+		}
+	}
+
+	// Add or renew the mappings
+	mappings := []api.PortMapping{}
+	for _, hostPort := range f.cfg.GetHostPorts() {
+		if !hostPort.P2P {
+			continue
+		}
+		port := hostPort.Parameter.Value.(uint16)
+		for _, protocol := range hostPort.Protocols {
+			mapping := f.getMapping(protocol, port)
+			mapping.Name = fmt.Sprintf("%s %s", hostPort.Container, hostPort.Parameter.Name)
+			if mapping.Error == "" && time.Until(mapping.ExpiresAt) > renewalMargin {
+				mappings = append(mappings, mapping)
+				continue
+			}
+			mappings = append(mappings, f.addMapping(mapping))
+		}
+	}
+	f.status.Mappings = mappings
+	return nil
+}
+
+// Get the client for the router.
+// If the router's address is set, it's contacted directly and UPnP mappings point at the host's LAN address; this is the only way that works from a bridged Docker container.
+// Otherwise the router is discovered on the local network, which only works in Native Mode.
+func (f *Forwarder) discover() (nat.Interface, error) {
+	method := f.cfg.Smartnode.PortForwardingMethod.Value.(cfgtypes.PortForwardingMethod)
+	gateway, err := parseAddress(&f.cfg.Smartnode.PortForwardingGateway)
+	if err != nil {
+		return nil, err
+	}
+	hostIp, err := parseAddress(&f.cfg.Smartnode.PortForwardingHostIp)
+	if err != nil {
+		return nil, err
+	}
+
+	if gateway == nil {
+		if !f.cfg.IsNativeMode {
+			return nil, fmt.Errorf("the Node container can't find your router on its own in Docker Mode; set the %s and %s settings in the Smartnode section of the `rocketpool service config` TUI", f.cfg.Smartnode.PortForwardingGateway.Name, f.cfg.Smartnode.PortForwardingHostIp.Name)
+		}
+		switch method {
+		case cfgtypes.PortForwardingMethod_Upnp:
+			return nat.UPnP(), nil
+		case cfgtypes.PortForwardingMethod_Pmp:
+			return nat.PMP(nil), nil
+		default:
+			return nat.Any(), nil
+		}
+	}
+
+	// NAT-PMP maps the ports to whichever address the request comes from, which is the host's once Docker has translated it
+	if method == cfgtypes.PortForwardingMethod_Pmp {
+		return nat.PMP(gateway), nil
+	}
+
+	// UPnP has to be told the host's address
+	if hostIp == nil {
+		if !f.cfg.IsNativeMode {
+			if method == cfgtypes.PortForwardingMethod_Upnp {
+				return nil, fmt.Errorf("UPnP needs the %s setting in Docker Mode", f.cfg.Smartnode.PortForwardingHostIp.Name)
+			}
+			return nat.PMP(gateway), nil
+		}
+		hostIp, err = getRouteAddress(gateway)
+		if err != nil {
+			return nil, err
+		}
+	}
+	upnp, err := discoverGatewayUpnp(gateway, hostIp)
+	if err != nil && method != cfgtypes.PortForwardingMethod_Upnp {
+		f.log.Printlnf("Couldn't use UPnP (%s), trying NAT-PMP instead...", err.Error())
+		return nat.PMP(gateway), nil
+	}
+	return upnp, err
+}
+
+// Parse an optional IP address setting
+func parseAddress(param *cfgtypes.Parameter) (net.IP, error) {
+	value := strings.TrimSpace(param.Value.(string))
+	if value == "" {
+		return nil, nil
+	}
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid %s [%s]", param.Name, value)
+	}
+	return ip, nil
+}
+
+// Get the address of this machine's interface that reaches the router
+func getRouteAddress(gateway net.IP) (net.IP, error) {
+	conn, err := net.Dial("udp", net.JoinHostPort(gateway.String(), strconv.Itoa(ssdpPort)))
+	if err != nil {
+		return nil, fmt.Errorf("error finding the local address that reaches the router at %s: %w", gateway, err)
+	}
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).IP, nil
+}
+
+// Ask the router to add or renew a mapping
+func (f *Forwarder) addMapping(mapping api.PortMapping) api.PortMapping {
+	isRenewal := !mapping.ExpiresAt.IsZero()
+	description := fmt.Sprintf("Rocket Pool %s", mapping.Name)
+	externalPort, err := f.nat.AddMapping(strings.ToUpper(mapping.Protocol), int(mapping.InternalPort), int(mapping.InternalPort), description, mappingLifetime)
+	if err != nil {
+		mapping.Error = err.Error()
+		f.log.Printlnf("WARNING: couldn't forward %s port %d/%s: %s", mapping.Name, mapping.InternalPort, mapping.Protocol, err.Error())
+		return mapping
+	}
+
+	mapping.Error = ""
+	mapping.RenewedAt = time.Now().UTC()
+	mapping.ExpiresAt = mapping.RenewedAt.Add(mappingLifetime)
+	if externalPort == 0 {
+		externalPort = mapping.InternalPort
+	}
+	if externalPort != mapping.ExternalPort || !isRenewal {
+		if externalPort != mapping.InternalPort {
+			f.log.Printlnf("WARNING: the router forwarded external port %d to %s port %d/%s because the same port wasn't available. Your client advertises port %d, so peers won't be able to use this mapping; free up the port on your router or change the client's P2P port.", externalPort, mapping.Name, mapping.InternalPort, mapping.Protocol, mapping.InternalPort)
+		} else {
+			f.log.Printlnf("Forwarded %s port %d/%s.", mapping.Name, mapping.InternalPort, mapping.Protocol)
+		}
+	}
+	mapping.ExternalPort = externalPort
+	return mapping
+}
+
+// Get the current mapping for a port, or a new one if it hasn't been mapped yet
+func (f *Forwarder) getMapping(protocol string, port uint16) api.PortMapping {
+	for _, mapping := range f.status.Mappings {
+		if mapping.Protocol == protocol && mapping.InternalPort == port {
+			return mapping
+		}
+	}
+	return api.PortMapping{
+		Protocol:     protocol,
+		InternalPort: port,
+	}
+}
+
+// Save the status so the API can report it
+func (f *Forwarder) save() {
+	bytes, err := json.Marshal(f.status)
+	if err != nil {
+		f.log.Printlnf("WARNING: couldn't serialize the port forwarding status: %s", err.Error())
+		return
+	}
+	if err := files.WriteFileAtomic(f.cfg.Smartnode.GetPortMappingsPath(true), bytes, fileMode); err != nil {
+		f.log.Printlnf("WARNING: couldn't save the port forwarding status: %s", err.Error())
+	}
+}
+
+// Load the status the node daemon last saved, or nil if it hasn't saved one
+func LoadStatus(cfg *config.RocketPoolConfig) (*api.PortForwardingStatus, error) {
+	path := cfg.Smartnode.GetPortMappingsPath(true)
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading [%s]: %w", path, err)
+	}
+	var status api.PortForwardingStatus
+	if err := json.Unmarshal(bytes, &status); err != nil {
+		return nil, fmt.Errorf("error decoding [%s]: %w", path, err)
+	}
+	return &status, nil
+}
@@ -0,0 +1,130 @@
+package portmap
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/nat"
+	"github.com/huin/goupnp"
+	"github.com/huin/goupnp/dcps/internetgateway2"
+	"github.com/huin/goupnp/httpu"
+	"github.com/huin/goupnp/ssdp"
+)
+
+// Settings
+const (
+	ssdpPort          = 1900
+	ssdpSearchTimeout = 3 * time.Second
+	ssdpSearchSends   = 2
+)
+
+// The port mapping functions shared by the UPnP WAN connection services
+type upnpConnection interface {
+	AddPortMapping(remoteHost string, externalPort uint16, protocol string, internalPort uint16, internalClient string, enabled bool, description string, leaseDuration uint32) error
+	DeletePortMapping(remoteHost string, externalPort uint16, protocol string) error
+	GetExternalIPAddress() (string, error)
+}
+
+// A UPnP connection to a known router that maps the ports to a known host address.
+// Unlike go-ethereum's UPnP client, this doesn't need multicast discovery or the machine's own interface address,
+// so it works from a bridged Docker container.
+type gatewayUpnp struct {
+	gateway net.IP
+	hostIp  net.IP
+	conn    upnpConnection
+}
+
+// Ask the router for its UPnP description directly and connect to its WAN connection service
+func discoverGatewayUpnp(gateway net.IP, hostIp net.IP) (nat.Interface, error) {
+	client, err := httpu.NewHTTPUClient()
+	if err != nil {
+		return nil, fmt.Errorf("error creating SSDP client: %w", err)
+	}
+	defer client.Close()
+
+	// Send the search straight to the router instead of the multicast group
+	address := net.JoinHostPort(gateway.String(), strconv.Itoa(ssdpPort))
+	request := &http.Request{
+		Method: "M-SEARCH",
+		Host:   address,
+		URL:    &url.URL{Opaque: "*"},
+		Header: http.Header{
+			"HOST": []string{address},
+			"MX":   []string{strconv.Itoa(int(ssdpSearchTimeout.Seconds()))},
+			"MAN":  []string{`"ssdp:discover"`},
+			"ST":   []string{ssdp.UPNPRootDevice},
+		},
+	}
+	responses, err := client.Do(request, ssdpSearchTimeout, ssdpSearchSends)
+	if err != nil {
+		return nil, fmt.Errorf("error searching for UPnP devices on %s: %w", gateway, err)
+	}
+
+	for _, response := range responses {
+		location, err := response.Location()
+		if err != nil {
+			continue
+		}
+		root, err := goupnp.DeviceByURL(location)
+		if err != nil {
+			continue
+		}
+		if conn := getUpnpConnection(root, location); conn != nil {
+			return &gatewayUpnp{
+				gateway: gateway,
+				hostIp:  hostIp,
+				conn:    conn,
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("the router at %s didn't offer a UPnP WAN connection service", gateway)
+}
+
+// Get the first WAN connection service the router offers
+func getUpnpConnection(root *goupnp.RootDevice, location *url.URL) upnpConnection {
+	if clients, err := internetgateway2.NewWANIPConnection2ClientsFromRootDevice(root, location); err == nil && len(clients) > 0 {
+		return clients[0]
+	}
+	if clients, err := internetgateway2.NewWANIPConnection1ClientsFromRootDevice(root, location); err == nil && len(clients) > 0 {
+		return clients[0]
+	}
+	if clients, err := internetgateway2.NewWANPPPConnection1ClientsFromRootDevice(root, location); err == nil && len(clients) > 0 {
+		return clients[0]
+	}
+	return nil
+}
+
+// Map the external port to the same port on the host
+func (u *gatewayUpnp) AddMapping(protocol string, extport, intport int, name string, lifetime time.Duration) (uint16, error) {
+	err := u.conn.AddPortMapping("", uint16(extport), protocol, uint16(intport), u.hostIp.String(), true, name, uint32(lifetime/time.Second))
+	if err != nil {
+		return 0, err
+	}
+	return uint16(extport), nil
+}
+
+// Remove a mapping
+func (u *gatewayUpnp) DeleteMapping(protocol string, extport, intport int) error {
+	return u.conn.DeletePortMapping("", uint16(extport), protocol)
+}
+
+// Get the router's external IP address
+func (u *gatewayUpnp) ExternalIP() (net.IP, error) {
+	address, err := u.conn.GetExternalIPAddress()
+	if err != nil {
+		return nil, err
+	}
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return nil, fmt.Errorf("the router reported an invalid external IP address [%s]", address)
+	}
+	return ip, nil
+}
+
+func (u *gatewayUpnp) String() string {
+	return fmt.Sprintf("UPnP at %s (forwarding to %s)", u.gateway, u.hostIp)
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// A kind of transaction that can be sent through the private relay
+type PrivateTxType string
+
+const (
+	PrivateTx_ClaimRewards  PrivateTxType = "claim-rewards"
+	PrivateTx_RethArbitrage PrivateTxType = "reth-arbitrage"
+	PrivateTx_Burn          PrivateTxType = "burn"
+	PrivateTx_Send          PrivateTxType = "send"
+)
+
+// How often to check if a privately sent transaction has been included
+const privateTxPollInterval time.Duration = 12 * time.Second
+
+// The context key for the kind of a transaction
+type privateTxTypeKey struct{}
+
+// Mark the transactions sent with a transactor as a kind that can go through the private relay.
+// They're only sent privately if a relay is set up and the kind is selected in the config.
+func UsePrivateRelay(opts *bind.TransactOpts, txType PrivateTxType) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	opts.Context = context.WithValue(ctx, privateTxTypeKey{}, txType)
+}
+
+// Parse the kinds of transactions selected for the private relay
+func parsePrivateTxTypes(setting string) map[PrivateTxType]bool {
+	txTypes := map[PrivateTxType]bool{}
+	for _, txType := range strings.Split(setting, ",") {
+		txType = strings.ToLower(strings.TrimSpace(txType))
+		if txType != "" {
+			txTypes[PrivateTxType(txType)] = true
+		}
+	}
+	return txTypes
+}
+
+// Check if a transaction should go through the private relay
+func (p *ExecutionClientManager) usePrivateRelay(ctx context.Context) bool {
+	if p.privateRelay == nil || ctx == nil {
+		return false
+	}
+	txType, ok := ctx.Value(privateTxTypeKey{}).(PrivateTxType)
+	return ok && p.privateTxTypes[txType]
+}
+
+// Send a transaction through the private relay and wait for it to be included, broadcasting it publicly if the relay rejects it
+// or doesn't get it included before the timeout
+func (p *ExecutionClientManager) sendPrivateTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := p.privateRelay.SendTransaction(ctx, tx); err != nil {
+		p.logger.Printlnf("WARNING: The private relay rejected transaction %s (%s), broadcasting it publicly...", tx.Hash().Hex(), err.Error())
+		return p.sendPublicTransaction(ctx, tx)
+	}
+
+	// Poll for inclusion
+	deadline := time.Now().Add(p.privateTxTimeout)
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(privateTxPollInterval):
+		}
+		receipt, err := p.TransactionReceipt(ctx, tx.Hash())
+		if err == nil && receipt != nil {
+			return nil
+		}
+	}
+
+	// Fall back to the public mempool; if it got included at the last moment, the client will refuse it as already known
+	p.logger.Printlnf("WARNING: The private relay didn't get transaction %s included within %s, broadcasting it publicly...", tx.Hash().Hex(), p.privateTxTimeout)
+	err := p.sendPublicTransaction(ctx, tx)
+	if err != nil {
+		message := strings.ToLower(err.Error())
+		if strings.Contains(message, "already known") || strings.Contains(message, "nonce too low") {
+			return nil
+		}
+	}
+	return err
+}
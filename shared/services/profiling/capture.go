@@ -0,0 +1,186 @@
+package profiling
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// Settings
+const (
+	// How long to wait for a daemon beyond the CPU profile's duration
+	captureTimeoutMargin time.Duration = 30 * time.Second
+
+	manifestFilename string      = "manifest.json"
+	bundlePrefix     string      = "rocketpool-profile-"
+	bundleExtension  string      = ".tar.gz"
+	timestampFormat  string      = "20060102-150405"
+	bundlesDirMode   os.FileMode = 0755
+	bundleFileMode   os.FileMode = 0644
+)
+
+// A daemon serving its profiles
+type Daemon struct {
+	Name string
+	Url  string
+}
+
+// A profile to capture from each daemon
+type profileRequest struct {
+	name  string
+	query string
+}
+
+// Get the profiles to capture, which profile the CPU for the given duration
+func getProfileRequests(duration time.Duration) []profileRequest {
+	return []profileRequest{
+		{name: "cpu.pprof", query: fmt.Sprintf("profile?seconds=%d", int(duration.Seconds()))},
+		{name: "heap.pprof", query: "heap?gc=1"},
+		{name: "goroutine.txt", query: "goroutine?debug=2"},
+	}
+}
+
+// Capture the CPU, heap, and goroutine profiles of each daemon at the same time and bundle them into a tarball with a manifest.
+// A daemon that can't be profiled doesn't stop the others from being captured; its error is kept in the manifest instead.
+func Capture(ctx context.Context, daemons []Daemon, duration time.Duration, nativeMode bool, smartnodeVersion string) ([]byte, api.ProfileManifest, error) {
+	if duration <= 0 || duration > MaxCpuProfileDuration {
+		return nil, api.ProfileManifest{}, fmt.Errorf("the duration must be more than 0 and at most %s", MaxCpuProfileDuration)
+	}
+	manifest := api.ProfileManifest{
+		Created:          time.Now().UTC(),
+		SmartnodeVersion: smartnodeVersion,
+		NativeMode:       nativeMode,
+		Duration:         duration,
+		Profiles:         []api.ProfileFile{},
+	}
+	client := &http.Client{
+		Timeout: duration + captureTimeoutMargin,
+	}
+
+	// Capture everything at once, so the profiles all cover the same window
+	requests := getProfileRequests(duration)
+	contents := make([][]byte, len(daemons)*len(requests))
+	for _, daemon := range daemons {
+		for _, request := range requests {
+			manifest.Profiles = append(manifest.Profiles, api.ProfileFile{
+				Daemon: daemon.Name,
+				Name:   daemon.Name + "/" + request.name,
+			})
+		}
+	}
+	var wg sync.WaitGroup
+	for i, daemon := range daemons {
+		for j, request := range requests {
+			index := i*len(requests) + j
+			url := daemon.Url + ProfilesPath + request.query
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				data, err := getProfile(ctx, client, url)
+				if err != nil {
+					manifest.Profiles[index].Error = err.Error()
+					return
+				}
+				contents[index] = data
+				manifest.Profiles[index].Size = uint64(len(data))
+			}()
+		}
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, api.ProfileManifest{}, err
+	}
+
+	// Build the tarball, skipping the profiles that couldn't be captured
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, api.ProfileManifest{}, fmt.Errorf("error serializing profile manifest: %w", err)
+	}
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	tarWriter := tar.NewWriter(gzipWriter)
+	if err := writeTarFile(tarWriter, manifestFilename, manifestBytes); err != nil {
+		return nil, api.ProfileManifest{}, err
+	}
+	for i, profile := range manifest.Profiles {
+		if profile.Error != "" {
+			continue
+		}
+		if err := writeTarFile(tarWriter, profile.Name, contents[i]); err != nil {
+			return nil, api.ProfileManifest{}, err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return nil, api.ProfileManifest{}, fmt.Errorf("error building profile bundle: %w", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, api.ProfileManifest{}, fmt.Errorf("error compressing profile bundle: %w", err)
+	}
+	return compressed.Bytes(), manifest, nil
+}
+
+// Write a profile bundle into the folder, named after the time it was created. Returns the bundle's filename.
+func Save(dir string, bundle []byte, manifest api.ProfileManifest) (string, error) {
+	err := os.MkdirAll(dir, bundlesDirMode)
+	if err != nil {
+		return "", fmt.Errorf("error creating profiles folder [%s]: %w", dir, err)
+	}
+	filename := bundlePrefix + manifest.Created.Format(timestampFormat) + bundleExtension
+	path := filepath.Join(dir, filename)
+	err = files.WriteFileAtomic(path, bundle, bundleFileMode)
+	if err != nil {
+		return "", fmt.Errorf("error writing profile bundle to [%s]: %w", path, err)
+	}
+	return filename, nil
+}
+
+// Download a profile from a daemon
+func getProfile(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request for [%s]: %w", url, err)
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting [%s]: %w", url, err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading [%s]: %w", url, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("[%s] returned %s: %s", url, response.Status, bytes.TrimSpace(body))
+	}
+	return body, nil
+}
+
+// Add a file to a tarball
+func writeTarFile(tarWriter *tar.Writer, name string, data []byte) error {
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     int64(bundleFileMode),
+		Size:     int64(len(data)),
+		ModTime:  time.Now(),
+	}
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return fmt.Errorf("error adding [%s] to profile bundle: %w", name, err)
+	}
+	if _, err := tarWriter.Write(data); err != nil {
+		return fmt.Errorf("error adding [%s] to profile bundle: %w", name, err)
+	}
+	return nil
+}
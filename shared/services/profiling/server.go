@@ -0,0 +1,137 @@
+package profiling
+
+import (
+	"fmt"
+	"net/http"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// The path the profiles are served under, matching net/http/pprof so `go tool pprof` can read them straight from a daemon
+	ProfilesPath string = "/debug/pprof/"
+
+	// The longest CPU profile that can be requested
+	MaxCpuProfileDuration time.Duration = 5 * time.Minute
+)
+
+// Serve the daemon's runtime profiles until the server fails.
+// This doesn't use net/http/pprof, since importing it registers its handlers on the default mux that the metrics servers use,
+// which would expose the profiles on the metrics port whether profiling is enabled or not.
+func RunServer(address string, port uint16, logger log.ColorLogger) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(ProfilesPath, handleProfile)
+
+	logger.Printlnf("Starting profiling server on %s:%d.", address, port)
+	err := http.ListenAndServe(fmt.Sprintf("%s:%d", address, port), mux)
+	if err != nil {
+		return fmt.Errorf("Error running profiling server: %w", err)
+	}
+
+	return nil
+}
+
+// Write a profile, or the list of profiles if none is named
+func handleProfile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	name := strings.TrimPrefix(r.URL.Path, ProfilesPath)
+	switch name {
+	case "":
+		writeProfileList(w)
+	case "profile":
+		writeCpuProfile(w, r)
+	default:
+		writeNamedProfile(w, r, name)
+	}
+}
+
+// List the profiles that can be requested
+func writeProfileList(w http.ResponseWriter) {
+	names := []string{"profile"}
+	for _, profile := range pprof.Profiles() {
+		names = append(names, profile.Name())
+	}
+	sort.Strings(names)
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(w, strings.Join(names, "\n"))
+}
+
+// Profile the CPU for the requested number of seconds
+func writeCpuProfile(w http.ResponseWriter, r *http.Request) {
+	seconds, err := getIntParam(r, "seconds", 30)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	duration := time.Duration(seconds) * time.Second
+	if duration <= 0 || duration > MaxCpuProfileDuration {
+		http.Error(w, fmt.Sprintf("seconds must be more than 0 and at most %d", int(MaxCpuProfileDuration.Seconds())), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", `attachment; filename="profile"`)
+	if err := pprof.StartCPUProfile(w); err != nil {
+		// Only one CPU profile can run at a time
+		http.Error(w, fmt.Sprintf("error starting CPU profile: %s", err.Error()), http.StatusConflict)
+		return
+	}
+	select {
+	case <-time.After(duration):
+	case <-r.Context().Done():
+	}
+	pprof.StopCPUProfile()
+}
+
+// Write one of the runtime's named profiles, such as heap or goroutine
+func writeNamedProfile(w http.ResponseWriter, r *http.Request, name string) {
+	profile := pprof.Lookup(name)
+	if profile == nil {
+		http.Error(w, fmt.Sprintf("unknown profile [%s]", name), http.StatusNotFound)
+		return
+	}
+	debug, err := getIntParam(r, "debug", 0)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	gc, err := getIntParam(r, "gc", 0)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if name == "heap" && gc > 0 {
+		runtime.GC()
+	}
+
+	if debug > 0 {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	} else {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, name))
+	}
+	profile.WriteTo(w, debug)
+}
+
+// Get an integer query parameter, or its default if it isn't set
+func getIntParam(r *http.Request, name string, defaultValue int) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s [%s]", name, value)
+	}
+	return parsed, nil
+}
@@ -12,6 +12,8 @@ import (
 
 	"github.com/goccy/go-json"
 	"github.com/klauspost/compress/zstd"
+	"github.com/rocket-pool/smartnode/shared/types/errcodes"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
 )
 
 type IDataType interface {
@@ -72,7 +74,7 @@ func SaveToFile[ContextType any, DataType IDataType](m *ChecksumManager[ContextT
 	fullFilename := filepath.Join(filepath.Dir(m.checksumFilename), baseFilename)
 
 	// Write it to a file
-	err = os.WriteFile(fullFilename, compressedBytes, 0664)
+	err = files.WriteFileAtomic(fullFilename, compressedBytes, 0664)
 	if err != nil {
 		return fmt.Errorf("error writing file [%s]: %w", fullFilename, err)
 	}
@@ -116,7 +118,7 @@ func SaveToFile[ContextType any, DataType IDataType](m *ChecksumManager[ContextT
 	checksumBytes := []byte(fileContents)
 
 	// Save the new file
-	err = os.WriteFile(m.checksumFilename, checksumBytes, 0644)
+	err = files.WriteFileAtomic(m.checksumFilename, checksumBytes, 0644)
 	if err != nil {
 		return fmt.Errorf("error writing checksum file: %w", err)
 	}
@@ -174,7 +176,7 @@ func LoadFromFile[ContextType any, DataType IDataType](m *ChecksumManager[Contex
 		calculatedChecksum := sha512.Sum384(compressedBytes)
 		if !bytes.Equal(savedChecksum, calculatedChecksum[:]) {
 			actualString := hex.EncodeToString(calculatedChecksum[:])
-			return nil, "", fmt.Errorf("checksum mismatch (expected %s, but it was %s)", checksumString, actualString)
+			return nil, "", errcodes.Wrap(errcodes.ChecksumError, fmt.Errorf("checksum mismatch (expected %s, but it was %s)", checksumString, actualString))
 		}
 
 		// Decompress it
@@ -1,6 +1,7 @@
 package proposals
 
 import (
+	"errors"
 	"fmt"
 	"math"
 
@@ -11,6 +12,9 @@ const (
 	latestCompatibleVersionString string = "1.12.0-dev"
 )
 
+// Returned when a node isn't part of a proposal's voting snapshot
+var ErrNodeNotInSnapshot = errors.New("node is not in the RP node set")
+
 // Gets the address of the Rocket Pool Node corresponding to the tree index provided.
 // If nil, this is an index into the network tree instead.
 func getRPNodeIndexFromTreeNodeIndex(snapshot *VotingInfoSnapshot, virtualIndex uint64) *uint64 {
@@ -50,5 +54,5 @@ func getRPNodeIndexFromSnapshot(snapshot *VotingInfoSnapshot, address common.Add
 		}
 	}
 
-	return 0, fmt.Errorf("address %s is not in the RP node set", address.Hex())
+	return 0, fmt.Errorf("address %s: %w", address.Hex(), ErrNodeNotInSnapshot)
 }
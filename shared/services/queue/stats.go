@@ -0,0 +1,103 @@
+package queue
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+const (
+	// How often the node daemon records a new sample
+	SampleInterval time.Duration = time.Hour
+
+	// How many samples to keep (30 days' worth at the default interval)
+	SampleRetentionLimit int = 24 * 30
+)
+
+// A snapshot of the deposit pool and minipool queue at a single point in time
+type Sample struct {
+	Time               time.Time `json:"time"`
+	Block              uint64    `json:"block"`
+	DepositPoolBalance *big.Int  `json:"depositPoolBalance"`
+	QueueLength        uint64    `json:"queueLength"`
+	QueueCapacity      *big.Int  `json:"queueCapacity"`
+	MinipoolCount      uint64    `json:"minipoolCount"`
+}
+
+// Load the samples from disk, oldest first. Returns an empty slice if there are none yet.
+func LoadSamples(path string) ([]Sample, error) {
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return []Sample{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading queue samples from [%s]: %w", path, err)
+	}
+
+	samples := []Sample{}
+	err = json.Unmarshal(bytes, &samples)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing queue samples from [%s]: %w", path, err)
+	}
+	return samples, nil
+}
+
+// Append a sample to the samples on disk, dropping the oldest ones beyond the retention limit
+func AddSample(path string, sample Sample) error {
+	samples, err := LoadSamples(path)
+	if err != nil {
+		return err
+	}
+	samples = append(samples, sample)
+	if len(samples) > SampleRetentionLimit {
+		samples = samples[len(samples)-SampleRetentionLimit:]
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating queue samples folder: %w", err)
+	}
+	bytes, err := json.Marshal(samples)
+	if err != nil {
+		return fmt.Errorf("error serializing queue samples: %w", err)
+	}
+	err = files.WriteFileAtomic(path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error saving queue samples to [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Estimate how many minipools were assigned from the queue per day across the provided samples.
+// Each new minipool is assumed to join the queue, so assignments between two samples are the
+// previous queue length plus the new minipools, minus the current queue length.
+// Returns false if there aren't enough samples to make an estimate.
+func GetAssignmentRate(samples []Sample) (float64, bool) {
+	if len(samples) < 2 {
+		return 0, false
+	}
+
+	assigned := uint64(0)
+	for i := 1; i < len(samples); i++ {
+		previous := samples[i-1]
+		current := samples[i]
+		newMinipools := uint64(0)
+		if current.MinipoolCount > previous.MinipoolCount {
+			newMinipools = current.MinipoolCount - previous.MinipoolCount
+		}
+		if previous.QueueLength+newMinipools > current.QueueLength {
+			assigned += previous.QueueLength + newMinipools - current.QueueLength
+		}
+	}
+
+	duration := samples[len(samples)-1].Time.Sub(samples[0].Time)
+	if duration <= 0 {
+		return 0, false
+	}
+	return float64(assigned) / duration.Hours() * 24, true
+}
@@ -0,0 +1,30 @@
+package queue
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestGetAssignmentRate(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, ok := GetAssignmentRate([]Sample{{Time: start}}); ok {
+		t.Fatal("expected no estimate from a single sample")
+	}
+
+	samples := []Sample{
+		{Time: start, QueueLength: 10, MinipoolCount: 100},
+		// 2 new minipools joined and 4 were assigned
+		{Time: start.Add(12 * time.Hour), QueueLength: 8, MinipoolCount: 102},
+		// No new minipools and 2 were assigned
+		{Time: start.Add(24 * time.Hour), QueueLength: 6, MinipoolCount: 102},
+	}
+	rate, ok := GetAssignmentRate(samples)
+	if !ok {
+		t.Fatal("expected an estimate")
+	}
+	if math.Abs(rate-6) > 1e-9 {
+		t.Fatalf("expected 6 assignments per day, got %f", rate)
+	}
+}
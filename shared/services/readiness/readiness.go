@@ -0,0 +1,183 @@
+package readiness
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/clock"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Settings
+const (
+	// The MEV-Boost and relay endpoint that reports whether the service is up
+	builderStatusPath string = "/eth/v1/builder/status"
+
+	requestTimeout time.Duration = 10 * time.Second
+)
+
+// Check that everything the node's validators depend on is healthy before they take on an important duty, such as a sync committee.
+// The checks run in parallel and are returned in a fixed order.
+func CheckDutyReadiness(cfg *config.RocketPoolConfig, ec *services.ExecutionClientManager, bc *services.BeaconClientManager) []api.DutyReadinessCheck {
+	checks := []func() api.DutyReadinessCheck{
+		func() api.DutyReadinessCheck { return checkExecutionClient(ec) },
+		func() api.DutyReadinessCheck { return checkBeaconClient(bc) },
+		func() api.DutyReadinessCheck { return checkClock(cfg, bc) },
+	}
+	if cfg.EnableMevBoost.Value == true {
+		checks = append(checks, func() api.DutyReadinessCheck { return checkMevBoost(cfg) })
+		for _, relay := range cfg.MevBoost.GetEnabledMevRelays() {
+			relay := relay
+			network := cfg.Smartnode.Network.Value.(cfgtypes.Network)
+			checks = append(checks, func() api.DutyReadinessCheck { return checkRelay(relay, network) })
+		}
+	}
+
+	results := make([]api.DutyReadinessCheck, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check func() api.DutyReadinessCheck) {
+			defer wg.Done()
+			results[i] = check()
+		}(i, check)
+	}
+	wg.Wait()
+	return results
+}
+
+// Get descriptions of the checks that failed
+func GetFailures(checks []api.DutyReadinessCheck) []string {
+	failures := []string{}
+	for _, check := range checks {
+		if !check.Passed {
+			failures = append(failures, fmt.Sprintf("%s: %s", check.Name, check.Details))
+		}
+	}
+	return failures
+}
+
+// Make sure the Execution client is synced, and get its version
+func checkExecutionClient(ec *services.ExecutionClientManager) api.DutyReadinessCheck {
+	check := api.DutyReadinessCheck{Name: "Execution client"}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	version, err := ec.ClientVersion(ctx)
+	if err != nil {
+		check.Details = fmt.Sprintf("couldn't get the client version: %s", err.Error())
+		return check
+	}
+	progress, err := ec.SyncProgress(ctx)
+	if err != nil {
+		check.Details = fmt.Sprintf("%s, couldn't get the sync status: %s", version, err.Error())
+		return check
+	}
+	if progress != nil {
+		check.Details = fmt.Sprintf("%s is still syncing (block %d of %d)", version, progress.CurrentBlock, progress.HighestBlock)
+		return check
+	}
+	check.Passed = true
+	check.Details = fmt.Sprintf("%s is synced", version)
+	return check
+}
+
+// Make sure the Beacon Node is synced, and get its version
+func checkBeaconClient(bc *services.BeaconClientManager) api.DutyReadinessCheck {
+	check := api.DutyReadinessCheck{Name: "Beacon Node"}
+	version, err := bc.GetNodeVersion()
+	if err != nil {
+		check.Details = fmt.Sprintf("couldn't get the client version: %s", err.Error())
+		return check
+	}
+	status, err := bc.GetSyncStatus()
+	if err != nil {
+		check.Details = fmt.Sprintf("%s, couldn't get the sync status: %s", version, err.Error())
+		return check
+	}
+	if status.Syncing {
+		check.Details = fmt.Sprintf("%s is still syncing (%.2f%%)", version, status.Progress*100)
+		return check
+	}
+	check.Passed = true
+	check.Details = fmt.Sprintf("%s is synced", version)
+	return check
+}
+
+// Make sure the local clock agrees with the NTP servers and the Beacon Chain
+func checkClock(cfg *config.RocketPoolConfig, bc *services.BeaconClientManager) api.DutyReadinessCheck {
+	check := api.DutyReadinessCheck{Name: "System clock"}
+	status := clock.CheckClock(cfg, bc)
+	if status.Problem != "" {
+		check.Details = status.Problem
+		if status.Drifted {
+			check.Details += "; make sure time synchronization (e.g. chrony or systemd-timesyncd) is running"
+		}
+		return check
+	}
+	check.Passed = true
+	check.Details = status.Summary()
+	return check
+}
+
+// Make sure the MEV-Boost service the validator client uses is up
+func checkMevBoost(cfg *config.RocketPoolConfig) api.DutyReadinessCheck {
+	check := api.DutyReadinessCheck{Name: "MEV-Boost"}
+	mevBoostUrl := cfg.MevBoostUrl()
+	if mevBoostUrl == "" {
+		check.Details = "MEV-Boost is enabled but doesn't have a URL"
+		return check
+	}
+	if err := checkBuilderStatus(strings.TrimSuffix(mevBoostUrl, "/") + builderStatusPath); err != nil {
+		check.Details = err.Error()
+		return check
+	}
+	check.Passed = true
+	check.Details = "MEV-Boost is up and can reach at least one relay"
+	return check
+}
+
+// Make sure one of the enabled relays is up
+func checkRelay(relay cfgtypes.MevRelay, network cfgtypes.Network) api.DutyReadinessCheck {
+	check := api.DutyReadinessCheck{Name: fmt.Sprintf("%s relay", relay.Name)}
+
+	// Relay URLs include the relay's public key, which isn't part of the request
+	relayUrl, err := url.Parse(relay.Urls[network])
+	if err != nil {
+		check.Details = fmt.Sprintf("couldn't parse the relay URL: %s", err.Error())
+		return check
+	}
+	relayUrl.User = nil
+	relayUrl.RawQuery = ""
+	relayUrl.Path = builderStatusPath
+
+	if err := checkBuilderStatus(relayUrl.String()); err != nil {
+		check.Details = err.Error()
+		return check
+	}
+	check.Passed = true
+	check.Details = "the relay is up"
+	return check
+}
+
+// Query a builder API status endpoint
+func checkBuilderStatus(statusUrl string) error {
+	client := http.Client{Timeout: requestTimeout}
+	response, err := client.Get(statusUrl)
+	if err != nil {
+		return fmt.Errorf("couldn't reach it: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("its status endpoint returned HTTP %d", response.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package readiness
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Get which of the provided validators are in the sync committee for the period containing the given epoch and for the one after it.
+// Assignments are only known one period in advance, so the next period is as far ahead as anyone can look.
+func GetSyncCommitteePeriods(bc beacon.Client, eth2Config beacon.Eth2Config, indices []string, epoch uint64) (api.SyncCommitteePeriod, api.SyncCommitteePeriod, error) {
+	current, err := getSyncCommitteePeriod(bc, eth2Config, indices, epoch/eth2Config.EpochsPerSyncCommitteePeriod)
+	if err != nil {
+		return api.SyncCommitteePeriod{}, api.SyncCommitteePeriod{}, err
+	}
+	next, err := getSyncCommitteePeriod(bc, eth2Config, indices, epoch/eth2Config.EpochsPerSyncCommitteePeriod+1)
+	if err != nil {
+		return api.SyncCommitteePeriod{}, api.SyncCommitteePeriod{}, err
+	}
+	return current, next, nil
+}
+
+// Get which of the provided validators are in a sync committee period
+func getSyncCommitteePeriod(bc beacon.Client, eth2Config beacon.Eth2Config, indices []string, period uint64) (api.SyncCommitteePeriod, error) {
+	startEpoch := period * eth2Config.EpochsPerSyncCommitteePeriod
+	endEpoch := startEpoch + eth2Config.EpochsPerSyncCommitteePeriod - 1
+	syncPeriod := api.SyncCommitteePeriod{
+		Period:     period,
+		StartEpoch: startEpoch,
+		EndEpoch:   endEpoch,
+		StartTime:  getEpochTime(eth2Config, startEpoch),
+		EndTime:    getEpochTime(eth2Config, endEpoch+1),
+		Validators: []string{},
+	}
+	if len(indices) == 0 {
+		return syncPeriod, nil
+	}
+
+	duties, err := bc.GetValidatorSyncDuties(indices, startEpoch)
+	if err != nil {
+		return api.SyncCommitteePeriod{}, fmt.Errorf("error getting the sync committee duties for period %d: %w", period, err)
+	}
+	for index, duty := range duties {
+		if duty {
+			syncPeriod.Validators = append(syncPeriod.Validators, index)
+		}
+	}
+	sort.Strings(syncPeriod.Validators)
+	return syncPeriod, nil
+}
+
+// Get the time an epoch starts
+func getEpochTime(eth2Config beacon.Eth2Config, epoch uint64) time.Time {
+	return time.Unix(int64(eth2Config.GenesisTime+epoch*eth2Config.SlotsPerEpoch*eth2Config.SecondsPerSlot), 0)
+}
@@ -2,7 +2,6 @@ package services
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"sync"
@@ -15,6 +14,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/services/alerting"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/errcodes"
 	"github.com/urfave/cli"
 )
 
@@ -40,7 +40,7 @@ func RequireNodePassword(c *cli.Context) error {
 		return err
 	}
 	if !nodePasswordSet {
-		return errors.New("The node password has not been set. Please run 'rocketpool wallet init' and try again.")
+		return errcodes.New(errcodes.WalletNotReady, "The node password has not been set. Please run 'rocketpool wallet init' and try again.")
 	}
 	return nil
 }
@@ -54,7 +54,26 @@ func RequireNodeWallet(c *cli.Context) error {
 		return err
 	}
 	if !nodeWalletInitialized {
-		return errors.New("The node wallet has not been initialized. Please run 'rocketpool wallet init' and try again.")
+		return errcodes.New(errcodes.WalletNotReady, "The node wallet has not been initialized. Please run 'rocketpool wallet init' and try again.")
+	}
+	return nil
+}
+
+// Require the node's address for transactions the gas wallet pays for; once it's set up, the node's key doesn't have to be available
+func RequireNodeAddress(c *cli.Context) error {
+	gw, err := GetGasWallet(c)
+	if err != nil {
+		return err
+	}
+	if !gw.IsInitialized() {
+		return RequireNodeWallet(c)
+	}
+	w, err := GetWallet(c)
+	if err != nil {
+		return err
+	}
+	if _, err := w.GetNodeAddress(); err != nil {
+		return errcodes.New(errcodes.WalletNotReady, "The node wallet has not been initialized and its address hasn't been saved. Please run 'rocketpool wallet init' and try again.")
 	}
 	return nil
 }
@@ -65,7 +84,7 @@ func RequireEthClientSynced(c *cli.Context) error {
 		return err
 	}
 	if !ethClientSynced {
-		return errors.New("The Eth 1.0 node is currently syncing. Please try again later.")
+		return errcodes.New(errcodes.ClientSyncing, "The Eth 1.0 node is currently syncing. Please try again later.")
 	}
 	return nil
 }
@@ -76,7 +95,7 @@ func RequireBeaconClientSynced(c *cli.Context) error {
 		return err
 	}
 	if !beaconClientSynced {
-		return errors.New("The Eth 2.0 node is currently syncing. Please try again later.")
+		return errcodes.New(errcodes.ClientSyncing, "The Eth 2.0 node is currently syncing. Please try again later.")
 	}
 	return nil
 }
@@ -90,7 +109,7 @@ func RequireRocketStorage(c *cli.Context) error {
 		return err
 	}
 	if !rocketStorageLoaded {
-		return errors.New("The Rocket Pool storage contract was not found; the configured address may be incorrect, or the Eth 1.0 node may not be synced. Please try again later.")
+		return errcodes.New(errcodes.ContractNotFound, "The Rocket Pool storage contract was not found; the configured address may be incorrect, or the Eth 1.0 node may not be synced. Please try again later.")
 	}
 	return nil
 }
@@ -107,7 +126,7 @@ func RequireNodeRegistered(c *cli.Context) error {
 		return err
 	}
 	if !nodeRegistered {
-		return errors.New("The node is not registered with Rocket Pool. Please run 'rocketpool node register' and try again.")
+		return errcodes.New(errcodes.NodeNotRegistered, "The node is not registered with Rocket Pool. Please run 'rocketpool node register' and try again.")
 	}
 	return nil
 }
@@ -124,7 +143,7 @@ func RequireNodeTrusted(c *cli.Context) error {
 		return err
 	}
 	if !nodeTrusted {
-		return errors.New("The node is not a member of the oracle DAO. Nodes can only join the oracle DAO by invite.")
+		return errcodes.New(errcodes.NotAuthorized, "The node is not a member of the oracle DAO. Nodes can only join the oracle DAO by invite.")
 	}
 	return nil
 }
@@ -141,7 +160,7 @@ func RequireNodeSecurityMember(c *cli.Context) error {
 		return err
 	}
 	if !nodeIsSecurityMember {
-		return errors.New("The node is not a member of the security council. Nodes can only join the security council by invite.")
+		return errcodes.New(errcodes.NotAuthorized, "The node is not a member of the security council. Nodes can only join the security council by invite.")
 	}
 	return nil
 }
@@ -364,10 +383,10 @@ func checkExecutionClientStatus(ecMgr *ExecutionClientManager, cfg *config.Rocke
 
 	// If neither client is working, report the errors
 	if mgrStatus.FallbackEnabled {
-		return false, nil, fmt.Errorf("Primary execution client is unavailable (%s) and fallback execution client is unavailable (%s), no execution clients are ready.", mgrStatus.PrimaryClientStatus.Error, mgrStatus.FallbackClientStatus.Error)
+		return false, nil, errcodes.Wrap(errcodes.ClientUnavailable, fmt.Errorf("Primary execution client is unavailable (%s) and fallback execution client is unavailable (%s), no execution clients are ready.", mgrStatus.PrimaryClientStatus.Error, mgrStatus.FallbackClientStatus.Error))
 	}
 
-	return false, nil, fmt.Errorf("Primary execution client is unavailable (%s) and no fallback execution client is configured.", mgrStatus.PrimaryClientStatus.Error)
+	return false, nil, errcodes.Wrap(errcodes.ClientUnavailable, fmt.Errorf("Primary execution client is unavailable (%s) and no fallback execution client is configured.", mgrStatus.PrimaryClientStatus.Error))
 }
 
 func checkBeaconClientStatus(bcMgr *BeaconClientManager) (bool, error) {
@@ -404,10 +423,10 @@ func checkBeaconClientStatus(bcMgr *BeaconClientManager) (bool, error) {
 
 	// If neither client is working, report the errors
 	if mgrStatus.FallbackEnabled {
-		return false, fmt.Errorf("Primary consensus client is unavailable (%s) and fallback consensus client is unavailable (%s), no consensus clients are ready.", mgrStatus.PrimaryClientStatus.Error, mgrStatus.FallbackClientStatus.Error)
+		return false, errcodes.Wrap(errcodes.ClientUnavailable, fmt.Errorf("Primary consensus client is unavailable (%s) and fallback consensus client is unavailable (%s), no consensus clients are ready.", mgrStatus.PrimaryClientStatus.Error, mgrStatus.FallbackClientStatus.Error))
 	}
 
-	return false, fmt.Errorf("Primary consensus client is unavailable (%s) and no fallback consensus client is configured.", mgrStatus.PrimaryClientStatus.Error)
+	return false, errcodes.Wrap(errcodes.ClientUnavailable, fmt.Errorf("Primary consensus client is unavailable (%s) and no fallback consensus client is configured.", mgrStatus.PrimaryClientStatus.Error))
 }
 
 func waitEthClientSynced(c *cli.Context, verbose bool, timeout int64) (bool, error) {
@@ -0,0 +1,216 @@
+package revert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	// Selector of the standard Error(string) revert
+	errorSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+
+	// Selector of the standard Panic(uint256) revert
+	panicSelector = []byte{0x4e, 0x48, 0x7b, 0x71}
+)
+
+// Descriptions of the Solidity panic codes
+var panicReasons = map[uint64]string{
+	0x01: "assertion failed",
+	0x11: "arithmetic overflow or underflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array",
+	0x31: "pop on an empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to an uninitialized function",
+}
+
+// Plain-language explanations for revert strings the Rocket Pool contracts commonly return, matched by fragment
+var hints = []struct {
+	fragment string
+	hint     string
+}{
+	{"exceeds limit based on node rpl stake", "not enough RPL collateral; stake more RPL with `rocketpool node stake-rpl` first"},
+	{"insufficient rpl stake", "not enough RPL collateral; stake more RPL with `rocketpool node stake-rpl` first"},
+	{"rpl stake would fall below", "the RPL you're withdrawing is still needed as collateral for your minipools"},
+	{"withdrawal cooldown period has not passed", "you staked RPL too recently to withdraw it yet"},
+	{"deposits into rocket pool are currently disabled", "deposits are currently disabled by the protocol"},
+	{"node deposits are currently disabled", "minipool deposits are currently disabled by the protocol"},
+	{"invalid node address", "this node isn't registered with Rocket Pool, or the transaction came from the wrong account"},
+	{"invalid minipool owner", "the minipool belongs to a different node"},
+	{"only the node operator", "the transaction has to come from the minipool's node account"},
+	{"only the minipool owner", "the transaction has to come from the minipool's node account"},
+	{"invalid or outdated network contract", "the contract has been upgraded; make sure your Smartnode is up to date"},
+	{"invalid minipool status", "the minipool isn't in the right state for this action; check `rocketpool minipool status`"},
+	{"the minipool can only", "the minipool isn't in the right state for this action; check `rocketpool minipool status`"},
+	{"already claimed", "these rewards have already been claimed"},
+	{"invalid proof", "the rewards tree on this node doesn't match the one on chain; try `rocketpool node claim-rewards` again after it's redownloaded"},
+	{"insufficient balance", "the account doesn't have enough balance for this transaction"},
+	{"insufficient allowance", "the RPL allowance is too low; approve the RPL spend again"},
+	{"wait period not passed", "the minipool's bond reduction isn't ready yet; check `rocketpool minipool status`"},
+}
+
+// A transaction that was simulated and would revert
+type Error struct {
+	Reason string
+	Hint   string
+	Data   []byte
+}
+
+func (e *Error) Error() string {
+	message := "the transaction would revert"
+	if e.Reason != "" {
+		message += ": " + e.Reason
+	}
+	if e.Hint != "" {
+		message += " (" + e.Hint + ")"
+	}
+	return message
+}
+
+// Turn a call or gas estimation error into a decoded revert error, looking custom errors up in the provided ABIs.
+// Errors that aren't reverts are returned unchanged.
+func Wrap(err error, getAbis func() []*abi.ABI) error {
+	if err == nil {
+		return nil
+	}
+	var revertErr *Error
+	if errors.As(err, &revertErr) {
+		return err
+	}
+	data, hasData := GetData(err)
+	message := err.Error()
+	if !hasData && !strings.Contains(strings.ToLower(message), "execution reverted") {
+		return err
+	}
+
+	// Decode the data if there is any, otherwise fall back to the client's own reason
+	var reason string
+	if len(data) > 0 {
+		var abis []*abi.ABI
+		if getAbis != nil && !isStandard(data) {
+			abis = getAbis()
+		}
+		reason = Decode(data, abis)
+	} else {
+		reason = message
+		if index := strings.Index(strings.ToLower(reason), "execution reverted"); index >= 0 {
+			reason = strings.TrimLeft(reason[index+len("execution reverted"):], ": ")
+		}
+	}
+	return &Error{
+		Reason: reason,
+		Hint:   GetHint(reason),
+		Data:   data,
+	}
+}
+
+// Get the revert data attached to a JSON-RPC error, if there is any
+func GetData(err error) ([]byte, bool) {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil, false
+	}
+	switch errData := dataErr.ErrorData().(type) {
+	case string:
+		data, decodeErr := hexutil.Decode(errData)
+		if decodeErr != nil {
+			return nil, false
+		}
+		return data, true
+	case []byte:
+		return errData, true
+	}
+	return nil, false
+}
+
+// Decode revert data into a readable reason, using the provided ABIs for custom errors
+func Decode(data []byte, abis []*abi.ABI) string {
+	if len(data) < 4 {
+		if len(data) == 0 {
+			return "no reason given"
+		}
+		return fmt.Sprintf("unknown error %s", hexutil.Encode(data))
+	}
+	selector := data[:4]
+
+	// Error(string)
+	if bytes.Equal(selector, errorSelector) {
+		reason, err := abi.UnpackRevert(data)
+		if err == nil {
+			return reason
+		}
+	}
+
+	// Panic(uint256)
+	if bytes.Equal(selector, panicSelector) && len(data) >= 36 {
+		code := new(big.Int).SetBytes(data[4:36])
+		if code.IsUint64() {
+			if description, exists := panicReasons[code.Uint64()]; exists {
+				return fmt.Sprintf("panic: %s", description)
+			}
+		}
+		return fmt.Sprintf("panic code 0x%x", code)
+	}
+
+	// Custom errors
+	var id [4]byte
+	copy(id[:], selector)
+	for _, contractAbi := range abis {
+		if contractAbi == nil {
+			continue
+		}
+		abiErr, err := contractAbi.ErrorByID(id)
+		if err != nil {
+			continue
+		}
+		values, err := abiErr.Inputs.Unpack(data[4:])
+		if err != nil {
+			return abiErr.Name
+		}
+		args := make([]string, len(values))
+		for i, value := range values {
+			args[i] = formatValue(value)
+		}
+		return fmt.Sprintf("%s(%s)", abiErr.Name, strings.Join(args, ", "))
+	}
+	return fmt.Sprintf("unknown error %s", hexutil.Encode(selector))
+}
+
+// Get a plain-language explanation of a revert reason, if it's a known one
+func GetHint(reason string) string {
+	reason = strings.ToLower(reason)
+	for _, hint := range hints {
+		if strings.Contains(reason, hint.fragment) {
+			return hint.hint
+		}
+	}
+	return ""
+}
+
+// Check if revert data uses one of the standard Solidity encodings, which don't need an ABI to decode
+func isStandard(data []byte) bool {
+	return len(data) < 4 || bytes.Equal(data[:4], errorSelector) || bytes.Equal(data[:4], panicSelector)
+}
+
+// Format a decoded error argument
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case common.Address:
+		return v.Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	case [32]byte:
+		return hexutil.Encode(v[:])
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,67 @@
+package revert
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// A JSON-RPC error carrying revert data, like the ones returned by eth_call and eth_estimateGas
+type testDataError struct {
+	data string
+}
+
+func (e testDataError) Error() string          { return "execution reverted" }
+func (e testDataError) ErrorData() interface{} { return e.data }
+
+func TestDecodeRevertString(t *testing.T) {
+	// Error("ETH matched after deposit exceeds limit based on node RPL stake")
+	data := hexutil.MustDecode("0x08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"000000000000000000000000000000000000000000000000000000000000003f" +
+		"455448206d617463686564206166746572206465706f7369742065786365656473206c696d6974206261736564206f6e206e6f64652052504c207374616b6500")
+	err := Wrap(testDataError{data: hexutil.Encode(data)}, nil)
+	var revertErr *Error
+	if !errors.As(err, &revertErr) {
+		t.Fatalf("expected a revert error, got %v", err)
+	}
+	if revertErr.Reason != "ETH matched after deposit exceeds limit based on node RPL stake" {
+		t.Errorf("unexpected reason %q", revertErr.Reason)
+	}
+	if !strings.Contains(revertErr.Hint, "not enough RPL collateral") {
+		t.Errorf("unexpected hint %q", revertErr.Hint)
+	}
+}
+
+func TestDecodePanicAndCustomErrors(t *testing.T) {
+	panicData := hexutil.MustDecode("0x4e487b710000000000000000000000000000000000000000000000000000000000000011")
+	if reason := Decode(panicData, nil); reason != "panic: arithmetic overflow or underflow" {
+		t.Errorf("unexpected panic reason %q", reason)
+	}
+
+	contractAbi, err := abi.JSON(strings.NewReader(`[{"type":"error","name":"InsufficientStake","inputs":[{"name":"required","type":"uint256"}]}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	customData := append(contractAbi.Errors["InsufficientStake"].ID.Bytes()[:4], hexutil.MustDecode("0x00000000000000000000000000000000000000000000000000000000000003e8")...)
+	if reason := Decode(customData, []*abi.ABI{&contractAbi}); reason != "InsufficientStake(1000)" {
+		t.Errorf("unexpected custom error reason %q", reason)
+	}
+	if reason := Decode(customData, nil); !strings.HasPrefix(reason, "unknown error 0x") {
+		t.Errorf("unexpected reason without ABIs %q", reason)
+	}
+}
+
+func TestWrapIgnoresOtherErrors(t *testing.T) {
+	err := errors.New("connection refused")
+	if Wrap(err, nil) != err {
+		t.Error("expected a non-revert error to be returned unchanged")
+	}
+	err = Wrap(errors.New("execution reverted: Invalid minipool owner"), nil)
+	if err.Error() != "the transaction would revert: Invalid minipool owner (the minipool belongs to a different node)" {
+		t.Errorf("unexpected error %q", err.Error())
+	}
+}
@@ -0,0 +1,181 @@
+package rewards
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// The part of a rolling record update that took the most time
+type CatchUpBottleneck string
+
+const (
+	CatchUpBottleneck_None            CatchUpBottleneck = ""
+	CatchUpBottleneck_ConsensusClient CatchUpBottleneck = "consensus client"
+	CatchUpBottleneck_Cpu             CatchUpBottleneck = "cpu"
+)
+
+// The progress of a rolling record update that's catching up to the chain
+type CatchUpProgress struct {
+	Active          bool              `json:"active"`
+	RewardsInterval uint64            `json:"rewardsInterval"`
+	StartEpoch      uint64            `json:"startEpoch"`
+	CurrentEpoch    uint64            `json:"currentEpoch"`
+	TargetEpoch     uint64            `json:"targetEpoch"`
+	EpochsDone      uint64            `json:"epochsDone"`
+	EpochsRemaining uint64            `json:"epochsRemaining"`
+	EpochsPerMinute float64           `json:"epochsPerMinute"`
+	Eta             time.Time         `json:"eta"`
+	Bottleneck      CatchUpBottleneck `json:"bottleneck"`
+	BeaconTime      time.Duration     `json:"beaconTime"`
+	ProcessingTime  time.Duration     `json:"processingTime"`
+	StartTime       time.Time         `json:"startTime"`
+	UpdatedTime     time.Time         `json:"updatedTime"`
+}
+
+// Save the catch-up progress so the API can read it
+func SaveCatchUpProgress(path string, progress CatchUpProgress) error {
+	bytes, err := json.Marshal(progress)
+	if err != nil {
+		return fmt.Errorf("error serializing catch-up progress: %w", err)
+	}
+	err = files.WriteFileAtomic(path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing catch-up progress: %w", err)
+	}
+	return nil
+}
+
+// Load the catch-up progress saved by the Watchtower; returns nil if there isn't any
+func LoadCatchUpProgress(path string) (*CatchUpProgress, error) {
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading catch-up progress from %s: %w", path, err)
+	}
+
+	var progress CatchUpProgress
+	err = json.Unmarshal(bytes, &progress)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing catch-up progress: %w", err)
+	}
+	return &progress, nil
+}
+
+// Tracks how far a rolling record update has gotten and where its time is going, reporting it at a fixed cadence
+type catchUpTracker struct {
+	progress   CatchUpProgress
+	interval   time.Duration
+	lastReport time.Time
+	report     func(CatchUpProgress)
+	lock       sync.Mutex
+}
+
+// Create a tracker for an update from the start epoch to the target epoch
+func newCatchUpTracker(rewardsInterval uint64, startEpoch uint64, targetEpoch uint64, interval time.Duration, report func(CatchUpProgress)) *catchUpTracker {
+	now := time.Now()
+	t := &catchUpTracker{
+		progress: CatchUpProgress{
+			Active:          true,
+			RewardsInterval: rewardsInterval,
+			StartEpoch:      startEpoch,
+			CurrentEpoch:    startEpoch,
+			TargetEpoch:     targetEpoch,
+			StartTime:       now,
+			UpdatedTime:     now,
+		},
+		interval:   interval,
+		lastReport: now,
+		report:     report,
+	}
+	if targetEpoch >= startEpoch {
+		t.progress.EpochsRemaining = targetEpoch - startEpoch + 1
+	}
+	return t
+}
+
+// Add time spent waiting on the Beacon Node
+func (t *catchUpTracker) addBeaconTime(duration time.Duration) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.progress.BeaconTime += duration
+}
+
+// Add time spent processing the Beacon Node's data
+func (t *catchUpTracker) addProcessingTime(duration time.Duration) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.progress.ProcessingTime += duration
+}
+
+// Record that an epoch was processed, reporting the progress if it's time to
+func (t *catchUpTracker) epochDone(epoch uint64) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	t.progress.CurrentEpoch = epoch
+	t.progress.EpochsDone++
+	if t.progress.EpochsRemaining > 0 {
+		t.progress.EpochsRemaining--
+	}
+	t.update()
+	progress := t.progress
+	due := t.interval > 0 && time.Since(t.lastReport) >= t.interval
+	if due {
+		t.lastReport = time.Now()
+	}
+	t.lock.Unlock()
+
+	if due && t.report != nil {
+		t.report(progress)
+	}
+}
+
+// Finish the update and report the final progress
+func (t *catchUpTracker) finish() {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	t.progress.Active = false
+	t.update()
+	progress := t.progress
+	t.lock.Unlock()
+
+	if t.report != nil {
+		t.report(progress)
+	}
+}
+
+// Update the rate, ETA, and bottleneck from the progress so far
+func (t *catchUpTracker) update() {
+	now := time.Now()
+	t.progress.UpdatedTime = now
+	elapsed := now.Sub(t.progress.StartTime)
+	if elapsed > 0 && t.progress.EpochsDone > 0 {
+		t.progress.EpochsPerMinute = float64(t.progress.EpochsDone) / elapsed.Minutes()
+		remaining := time.Duration(float64(t.progress.EpochsRemaining) / t.progress.EpochsPerMinute * float64(time.Minute))
+		t.progress.Eta = now.Add(remaining)
+	}
+	switch {
+	case t.progress.BeaconTime == 0 && t.progress.ProcessingTime == 0:
+		t.progress.Bottleneck = CatchUpBottleneck_None
+	case t.progress.BeaconTime >= t.progress.ProcessingTime:
+		t.progress.Bottleneck = CatchUpBottleneck_ConsensusClient
+	default:
+		t.progress.Bottleneck = CatchUpBottleneck_Cpu
+	}
+}
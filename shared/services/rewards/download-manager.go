@@ -0,0 +1,275 @@
+package rewards
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/errcodes"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// Settings
+const (
+	// How long all of the sources get to race before the download is abandoned
+	downloadRaceTimeout time.Duration = 3 * time.Minute
+
+	// How many times a source is resumed after its connection drops
+	downloadSourceRetries int = 3
+
+	// The extension for partial downloads, which are kept so a later attempt can pick up where this one stopped
+	partialDownloadExtension string = ".part"
+)
+
+// Downloads a rewards file by racing every source against each other, keeping the first copy that verifies
+type rewardsFileDownloader struct {
+	urls         []string
+	partialPath  string
+	ipfsFilename string
+	expectedCid  string
+	expectedRoot string
+}
+
+// The outcome of downloading from one source
+type downloadResult struct {
+	url  string
+	file IRewardsFile
+	err  error
+}
+
+// Download the file from all of the sources at once and return the first one that verifies.
+// If none of them do, the error describes what went wrong with each.
+func (d *rewardsFileDownloader) download() (IRewardsFile, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), downloadRaceTimeout)
+	defer cancel()
+
+	results := make(chan downloadResult, len(d.urls))
+	wg := sync.WaitGroup{}
+	for _, url := range d.urls {
+		wg.Add(1)
+		go func(url string) {
+			defer wg.Done()
+			file, err := d.downloadFromSource(ctx, url)
+			results <- downloadResult{url: url, file: file, err: err}
+		}(url)
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	errBuilder := strings.Builder{}
+	var mismatchErr error
+	for result := range results {
+		if result.err == nil {
+			// Stop the others and clean up their partial files
+			cancel()
+			wg.Wait()
+			d.removePartials()
+			return result.file, nil
+		}
+		errBuilder.WriteString(fmt.Sprintf("Downloading %s failed (%s)\n", result.url, result.err.Error()))
+		if errcodes.Get(result.err) == errcodes.ConsensusMismatch {
+			mismatchErr = result.err
+		}
+	}
+
+	// Surface a bad tree as such, since it's more serious than a source that couldn't be reached
+	if mismatchErr != nil {
+		return nil, errcodes.Wrap(errcodes.ConsensusMismatch, errors.New(errBuilder.String()))
+	}
+	return nil, errors.New(errBuilder.String())
+}
+
+// Download the file from a single source, resuming if the connection drops, then verify it
+func (d *rewardsFileDownloader) downloadFromSource(ctx context.Context, url string) (IRewardsFile, error) {
+	partialPath := d.getPartialPath(url)
+
+	var err error
+	for attempt := 0; attempt <= downloadSourceRetries; attempt++ {
+		err = resumeDownload(ctx, url, partialPath)
+		if err == nil || ctx.Err() != nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	bytes, err := os.ReadFile(partialPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the downloaded file: %w", err)
+	}
+	file, err := d.verify(url, bytes)
+	if err != nil {
+		// Don't resume from a bad copy next time
+		_ = os.Remove(partialPath)
+		return nil, err
+	}
+	return file, nil
+}
+
+// Make sure a downloaded copy has the expected CID (for the compressed files) and Merkle root
+func (d *rewardsFileDownloader) verify(url string, bytes []byte) (IRewardsFile, error) {
+	if strings.HasSuffix(url, config.RewardsTreeIpfsExtension) {
+		if d.expectedCid != "" {
+			cid, err := singleFileDirIPFSCid(bytes, d.ipfsFilename)
+			if err != nil {
+				return nil, fmt.Errorf("error calculating the CID: %w", err)
+			}
+			if cid.String() != d.expectedCid {
+				return nil, errcodes.Wrap(errcodes.ConsensusMismatch, fmt.Errorf("the file from %s has CID %s, but the canonical one is %s", url, cid.String(), d.expectedCid))
+			}
+		}
+
+		// Decompress it
+		var err error
+		bytes, err = decompressFile(bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error decompressing: %w", err)
+		}
+	}
+
+	file, err := DeserializeRewardsFile(bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing: %w", err)
+	}
+	if err := VerifyMerkleRoot(file, d.expectedRoot); err != nil {
+		return nil, errcodes.Wrap(errcodes.ConsensusMismatch, fmt.Errorf("the file from %s is invalid: %w", url, err))
+	}
+	return file, nil
+}
+
+// Get the path of the partial download for a source
+func (d *rewardsFileDownloader) getPartialPath(url string) string {
+	hash := sha256.Sum256([]byte(url))
+	return fmt.Sprintf("%s-%s%s", d.partialPath, hex.EncodeToString(hash[:4]), partialDownloadExtension)
+}
+
+// Remove the partial downloads from every source
+func (d *rewardsFileDownloader) removePartials() {
+	for _, url := range d.urls {
+		_ = os.Remove(d.getPartialPath(url))
+	}
+}
+
+// Download a URL into a partial file, continuing from the end of it if the server supports range requests
+func resumeDownload(ctx context.Context, url string, partialPath string) error {
+	var offset int64
+	if info, err := os.Stat(partialPath); err == nil {
+		offset = info.Size()
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	if offset > 0 {
+		request.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch response.StatusCode {
+	case http.StatusPartialContent:
+		flags |= os.O_APPEND
+	case http.StatusOK:
+		// The server is sending the whole file, so start over
+		flags |= os.O_TRUNC
+	case http.StatusRequestedRangeNotSatisfiable:
+		// The partial file already has everything
+		if offset > 0 {
+			return nil
+		}
+		return fmt.Errorf("status %s", response.Status)
+	default:
+		return fmt.Errorf("status %s", response.Status)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(partialPath), 0755); err != nil {
+		return fmt.Errorf("error creating the download folder: %w", err)
+	}
+	file, err := os.OpenFile(partialPath, flags, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening the partial download: %w", err)
+	}
+	defer file.Close()
+	if _, err := io.Copy(file, response.Body); err != nil {
+		return fmt.Errorf("error reading the response: %w", err)
+	}
+	return nil
+}
+
+// Rebuild a rewards file's Merkle tree from its data and make sure the root matches the file's own and the canonical one
+func VerifyMerkleRoot(file IRewardsFile, expectedRoot string) error {
+	// Get the original merkle root
+	downloadedRoot := file.GetHeader().MerkleRoot
+
+	// Clear the merkle root so we have a safer comparison after calculating it again
+	file.GetHeader().MerkleRoot = ""
+
+	// Reconstruct the merkle tree from the file data, this should overwrite the stored Merkle Root with a new one
+	if err := file.generateMerkleTree(); err != nil {
+		return fmt.Errorf("error generating the Merkle tree: %w", err)
+	}
+
+	// Get the resulting merkle root
+	calculatedRoot := file.GetHeader().MerkleRoot
+
+	// Compare the merkle roots to see if the original is correct
+	if !strings.EqualFold(downloadedRoot, calculatedRoot) {
+		return fmt.Errorf("the merkle root does not match the root generated by its tree data (had %s, but generated %s)", downloadedRoot, calculatedRoot)
+	}
+
+	// Make sure the calculated root matches the canonical one
+	if !strings.EqualFold(calculatedRoot, expectedRoot) {
+		return fmt.Errorf("the merkle root does not match the canonical one (had %s, but generated %s)", calculatedRoot, expectedRoot)
+	}
+	return nil
+}
+
+// Load a rewards file from the shared cache, returning nil if it isn't there or doesn't verify
+func loadCachedRewardsFile(cachePath string, expectedRoot string) IRewardsFile {
+	bytes, err := os.ReadFile(cachePath)
+	if err != nil {
+		return nil
+	}
+	bytes, err = decodeLocalFile(bytes)
+	if err != nil {
+		return nil
+	}
+	file, err := DeserializeRewardsFile(bytes)
+	if err != nil {
+		return nil
+	}
+	if err := VerifyMerkleRoot(file, expectedRoot); err != nil {
+		return nil
+	}
+	return file
+}
+
+// Copy a verified rewards file into the shared cache
+func cacheRewardsFile(rewardsTreePath string, cachePath string) error {
+	bytes, err := os.ReadFile(rewardsTreePath)
+	if err != nil {
+		return fmt.Errorf("error reading [%s]: %w", rewardsTreePath, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err != nil {
+		return fmt.Errorf("error creating the cache folder: %w", err)
+	}
+	return files.WriteFileAtomic(cachePath, bytes, 0644)
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/ipfs/go-cid"
 	"github.com/klauspost/compress/zstd"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
 )
 
 // Reads an existing RewardsFile from disk and wraps it in a LocalFile
@@ -16,6 +18,10 @@ func ReadLocalRewardsFile(path string) (*LocalRewardsFile, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error reading rewards file from %s: %w", path, err)
 	}
+	fileBytes, err = decodeLocalFile(fileBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing rewards file from %s: %w", path, err)
+	}
 
 	// Unmarshal it
 	proofWrapper, err := DeserializeRewardsFile(fileBytes)
@@ -32,6 +38,10 @@ func ReadLocalMinipoolPerformanceFile(path string) (*LocalMinipoolPerformanceFil
 	if err != nil {
 		return nil, fmt.Errorf("error reading rewards file from %s: %w", path, err)
 	}
+	fileBytes, err = decodeLocalFile(fileBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing rewards file from %s: %w", path, err)
+	}
 
 	// Unmarshal it
 	minipoolPerformance, err := DeserializeMinipoolPerformanceFile(fileBytes)
@@ -53,6 +63,7 @@ type ILocalFile interface {
 type LocalFile[T ILocalFile] struct {
 	f        T
 	fullPath string
+	codec    *recordCodec
 }
 
 // Type aliases
@@ -68,6 +79,20 @@ func NewLocalFile[T ILocalFile](ilf T, fullpath string) *LocalFile[T] {
 	}
 }
 
+// NewCompressedLocalFile creates the wrapper like NewLocalFile, but the file is compressed with the configured rewards file compression when it's written.
+// The compressed copy published to IPFS is unaffected.
+func NewCompressedLocalFile[T ILocalFile](ilf T, fullpath string, cfg *config.RocketPoolConfig) (*LocalFile[T], error) {
+	codec, err := newRewardsFileCodec(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &LocalFile[T]{
+		f:        ilf,
+		fullPath: fullpath,
+		codec:    codec,
+	}, nil
+}
+
 // Returns the underlying interface, IRewardsFile for rewards file, IMinipoolPerformanceFile for performance, etc.
 func (lf *LocalFile[T]) Impl() T {
 	return lf.f
@@ -84,8 +109,14 @@ func (lf *LocalFile[T]) Write() error {
 	if err != nil {
 		return fmt.Errorf("error serializing file: %w", err)
 	}
+	if lf.codec != nil && lf.codec.codec != cfgtypes.RecordCodec_None {
+		data, err = lf.codec.encode(data)
+		if err != nil {
+			return fmt.Errorf("error compressing file: %w", err)
+		}
+	}
 
-	err = os.WriteFile(lf.fullPath, data, 0644)
+	err = files.WriteFileAtomic(lf.fullPath, data, 0644)
 	if err != nil {
 		return fmt.Errorf("error writing file to %s: %w", lf.fullPath, err)
 	}
@@ -105,7 +136,7 @@ func (lf *LocalFile[T]) CreateCompressedFileAndCid() (cid.Cid, error) {
 		return cid.Cid{}, fmt.Errorf("error serializing file: %w", err)
 	}
 
-	// Compress
+	// Compress; this always uses the same zstd settings regardless of the local file compression, since every Oracle DAO member has to produce the same CID
 	encoder, _ := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedBestCompression))
 	compressedBytes := encoder.EncodeAll(data, make([]byte, 0, len(data)))
 
@@ -117,7 +148,7 @@ func (lf *LocalFile[T]) CreateCompressedFileAndCid() (cid.Cid, error) {
 
 	// Write to disk
 	// Take care to write to `filename` since it has the .zst extension added
-	err = os.WriteFile(filename, compressedBytes, 0644)
+	err = files.WriteFileAtomic(filename, compressedBytes, 0644)
 	if err != nil {
 		return cid.Cid{}, fmt.Errorf("error writing file to %s: %w", lf.fullPath, err)
 	}
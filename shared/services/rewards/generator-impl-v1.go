@@ -34,6 +34,7 @@ type treeGeneratorImpl_v1 struct {
 	rp                   *rocketpool.RocketPool
 	cfg                  *config.RocketPoolConfig
 	bc                   beacon.Client
+	ctx                  context.Context
 	opts                 *bind.CallOpts
 	nodeAddresses        []common.Address
 	nodeDetails          []*NodeSmoothingDetails
@@ -95,7 +96,7 @@ func newTreeGeneratorImpl_v1(log *log.ColorLogger, logPrefix string, index uint6
 	}
 }
 
-func (r *treeGeneratorImpl_v1) generateTree(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (IRewardsFile, error) {
+func (r *treeGeneratorImpl_v1) generateTree(ctx context.Context, rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (IRewardsFile, error) {
 
 	r.log.Printlnf("%s Generating tree using Ruleset v%d.", r.logPrefix, r.rewardsFile.RulesetVersion)
 
@@ -103,6 +104,7 @@ func (r *treeGeneratorImpl_v1) generateTree(rp *rocketpool.RocketPool, cfg *conf
 	r.rp = rp
 	r.cfg = cfg
 	r.bc = bc
+	r.ctx = ctx
 	r.validNetworkCache = map[uint64]bool{
 		0: true,
 	}
@@ -163,12 +165,13 @@ func (r *treeGeneratorImpl_v1) generateTree(rp *rocketpool.RocketPool, cfg *conf
 
 // Quickly calculates an approximate of the staker's share of the smoothing pool balance without processing Beacon performance
 // Used for approximate returns in the rETH ratio update
-func (r *treeGeneratorImpl_v1) approximateStakerShareOfSmoothingPool(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (*big.Int, error) {
+func (r *treeGeneratorImpl_v1) approximateStakerShareOfSmoothingPool(ctx context.Context, rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (*big.Int, error) {
 	r.log.Printlnf("%s Approximating tree using Ruleset v%d.", r.logPrefix, r.rewardsFile.RulesetVersion)
 
 	r.rp = rp
 	r.cfg = cfg
 	r.bc = bc
+	r.ctx = ctx
 	r.validNetworkCache = map[uint64]bool{
 		0: true,
 	}
@@ -747,6 +750,10 @@ func (r *treeGeneratorImpl_v1) processAttestationsForInterval() error {
 	epochsDone := 0
 	reportStartTime := time.Now()
 	for epoch := startEpoch; epoch < endEpoch+1; epoch++ {
+		if err := r.ctx.Err(); err != nil {
+			return fmt.Errorf("stopped checking participation at epoch %d: %w", epoch, err)
+		}
+
 		if epochsDone == 100 {
 			timeTaken := time.Since(reportStartTime)
 			r.log.Printlnf("%s On Epoch %d of %d (%.2f%%)... (%s so far)", r.logPrefix, epoch, endEpoch, float64(epoch-startEpoch)/float64(endEpoch-startEpoch)*100.0, timeTaken)
@@ -38,6 +38,7 @@ type treeGeneratorImpl_v2 struct {
 	rp                   *rocketpool.RocketPool
 	cfg                  *config.RocketPoolConfig
 	bc                   beacon.Client
+	ctx                  context.Context
 	opts                 *bind.CallOpts
 	nodeAddresses        []common.Address
 	nodeDetails          []*NodeSmoothingDetails
@@ -99,7 +100,7 @@ func (r *treeGeneratorImpl_v2) getRulesetVersion() uint64 {
 	return r.rewardsFile.RulesetVersion
 }
 
-func (r *treeGeneratorImpl_v2) generateTree(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (IRewardsFile, error) {
+func (r *treeGeneratorImpl_v2) generateTree(ctx context.Context, rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (IRewardsFile, error) {
 
 	r.log.Printlnf("%s Generating tree using Ruleset v%d.", r.logPrefix, r.rewardsFile.RulesetVersion)
 
@@ -107,6 +108,7 @@ func (r *treeGeneratorImpl_v2) generateTree(rp *rocketpool.RocketPool, cfg *conf
 	r.rp = rp
 	r.cfg = cfg
 	r.bc = bc
+	r.ctx = ctx
 	r.validNetworkCache = map[uint64]bool{
 		0: true,
 	}
@@ -167,12 +169,13 @@ func (r *treeGeneratorImpl_v2) generateTree(rp *rocketpool.RocketPool, cfg *conf
 
 // Quickly calculates an approximate of the staker's share of the smoothing pool balance without processing Beacon performance
 // Used for approximate returns in the rETH ratio update
-func (r *treeGeneratorImpl_v2) approximateStakerShareOfSmoothingPool(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (*big.Int, error) {
+func (r *treeGeneratorImpl_v2) approximateStakerShareOfSmoothingPool(ctx context.Context, rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (*big.Int, error) {
 	r.log.Printlnf("%s Approximating tree using Ruleset v%d.", r.logPrefix, r.rewardsFile.RulesetVersion)
 
 	r.rp = rp
 	r.cfg = cfg
 	r.bc = bc
+	r.ctx = ctx
 	r.validNetworkCache = map[uint64]bool{
 		0: true,
 	}
@@ -766,6 +769,10 @@ func (r *treeGeneratorImpl_v2) processAttestationsForInterval() error {
 	epochsDone := 0
 	reportStartTime := time.Now()
 	for epoch := startEpoch; epoch < endEpoch+1; epoch++ {
+		if err := r.ctx.Err(); err != nil {
+			return fmt.Errorf("stopped checking participation at epoch %d: %w", epoch, err)
+		}
+
 		if epochsDone == 100 {
 			timeTaken := time.Since(reportStartTime)
 			r.log.Printlnf("%s On Epoch %d of %d (%.2f%%)... (%s so far)", r.logPrefix, epoch, endEpoch, float64(epoch-startEpoch)/float64(endEpoch-startEpoch)*100.0, timeTaken)
@@ -1,6 +1,7 @@
 package rewards
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"time"
@@ -10,7 +11,6 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/state"
-	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
@@ -64,8 +64,8 @@ type TreeGenerator struct {
 }
 
 type treeGeneratorImpl interface {
-	generateTree(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (IRewardsFile, error)
-	approximateStakerShareOfSmoothingPool(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (*big.Int, error)
+	generateTree(ctx context.Context, rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (IRewardsFile, error)
+	approximateStakerShareOfSmoothingPool(ctx context.Context, rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (*big.Int, error)
 	getRulesetVersion() uint64
 }
 
@@ -173,16 +173,13 @@ func NewTreeGenerator(logger *log.ColorLogger, logPrefix string, rp *rocketpool.
 		t.rewardsIntervalInfos[info.rewardsRulesetVersion] = info
 	}
 
-	// Get the current network
-	network := t.cfg.Smartnode.Network.Value.(cfgtypes.Network)
-
 	// Determine which actual rulesets to use based on the current interval number, checking in descending order from the latest
 	// to interval 2 since interval 1 is the default
 	foundGenerator := false
 	foundApproximator := false
 	for i := uint64(len(t.rewardsIntervalInfos)); i > 1; i-- {
 		info := t.rewardsIntervalInfos[i]
-		startInterval, err := info.GetStartInterval(network)
+		startInterval, err := info.GetStartInterval(t.cfg)
 		if err != nil {
 			return nil, fmt.Errorf("error getting start interval for rewards period %d: %w", i, err)
 		}
@@ -211,12 +208,13 @@ func NewTreeGenerator(logger *log.ColorLogger, logPrefix string, rp *rocketpool.
 	return t, nil
 }
 
-func (t *TreeGenerator) GenerateTree() (IRewardsFile, error) {
-	return t.generatorImpl.generateTree(t.rp, t.cfg, t.bc)
+// Generate the rewards tree; cancelling the context stops the generation, which can be started again from scratch later
+func (t *TreeGenerator) GenerateTree(ctx context.Context) (IRewardsFile, error) {
+	return t.generatorImpl.generateTree(ctx, t.rp, t.cfg, t.bc)
 }
 
-func (t *TreeGenerator) ApproximateStakerShareOfSmoothingPool() (*big.Int, error) {
-	return t.approximatorImpl.approximateStakerShareOfSmoothingPool(t.rp, t.cfg, t.bc)
+func (t *TreeGenerator) ApproximateStakerShareOfSmoothingPool(ctx context.Context) (*big.Int, error) {
+	return t.approximatorImpl.approximateStakerShareOfSmoothingPool(ctx, t.rp, t.cfg, t.bc)
 }
 
 func (t *TreeGenerator) GetGeneratorRulesetVersion() uint64 {
@@ -227,20 +225,20 @@ func (t *TreeGenerator) GetApproximatorRulesetVersion() uint64 {
 	return t.approximatorImpl.getRulesetVersion()
 }
 
-func (t *TreeGenerator) GenerateTreeWithRuleset(ruleset uint64) (IRewardsFile, error) {
+func (t *TreeGenerator) GenerateTreeWithRuleset(ctx context.Context, ruleset uint64) (IRewardsFile, error) {
 	info, exists := t.rewardsIntervalInfos[ruleset]
 	if !exists {
 		return nil, fmt.Errorf("ruleset v%d does not exist", ruleset)
 	}
 
-	return info.generator.generateTree(t.rp, t.cfg, t.bc)
+	return info.generator.generateTree(ctx, t.rp, t.cfg, t.bc)
 }
 
-func (t *TreeGenerator) ApproximateStakerShareOfSmoothingPoolWithRuleset(ruleset uint64) (*big.Int, error) {
+func (t *TreeGenerator) ApproximateStakerShareOfSmoothingPoolWithRuleset(ctx context.Context, ruleset uint64) (*big.Int, error) {
 	info, exists := t.rewardsIntervalInfos[ruleset]
 	if !exists {
 		return nil, fmt.Errorf("ruleset v%d does not exist", ruleset)
 	}
 
-	return info.generator.approximateStakerShareOfSmoothingPool(t.rp, t.cfg, t.bc)
+	return info.generator.approximateStakerShareOfSmoothingPool(ctx, t.rp, t.cfg, t.bc)
 }
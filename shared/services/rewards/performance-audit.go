@@ -0,0 +1,359 @@
+package rewards
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/mirror"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+// The result of recomputing one minipool's attestation performance from the Beacon Chain
+type MinipoolPerformanceAudit struct {
+	Address            common.Address `json:"address"`
+	ValidatorIndex     string         `json:"validatorIndex"`
+	ReportedSuccessful uint64         `json:"reportedSuccessful"`
+	ReportedMissed     uint64         `json:"reportedMissed"`
+	ReportedScore      *QuotedBigInt  `json:"reportedScore,omitempty"`
+	CheckedDuties      uint64         `json:"checkedDuties"`
+	Successful         uint64         `json:"successful"`
+	Missed             uint64         `json:"missed"`
+	Score              *QuotedBigInt  `json:"score,omitempty"`
+	MismatchedSlots    []uint64       `json:"mismatchedSlots"`
+	Passed             bool           `json:"passed"`
+	Note               string         `json:"note,omitempty"`
+}
+
+// Pick a random sample of minipools from a performance file.
+// Anyone using the same seed gets the same sample, so an audit can be reproduced.
+func SampleMinipools(file IMinipoolPerformanceFile, count int, seed string) []common.Address {
+	addresses := file.GetMinipoolAddresses()
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].Hex() < addresses[j].Hex()
+	})
+	rng := newAuditRng(seed, "minipools")
+	rng.Shuffle(len(addresses), func(i, j int) {
+		addresses[i], addresses[j] = addresses[j], addresses[i]
+	})
+	if count < len(addresses) {
+		addresses = addresses[:count]
+	}
+	return addresses
+}
+
+// Pick a random sample of the epochs in an interval, or all of them if count is 0
+func SampleEpochs(startEpoch uint64, endEpoch uint64, count uint64, seed string) []uint64 {
+	epochs := []uint64{}
+	for epoch := startEpoch; epoch <= endEpoch; epoch++ {
+		epochs = append(epochs, epoch)
+	}
+	if count == 0 || count >= uint64(len(epochs)) {
+		return epochs
+	}
+	rng := newAuditRng(seed, "epochs")
+	rng.Shuffle(len(epochs), func(i, j int) {
+		epochs[i], epochs[j] = epochs[j], epochs[i]
+	})
+	epochs = epochs[:count]
+	sort.Slice(epochs, func(i, j int) bool {
+		return epochs[i] < epochs[j]
+	})
+	return epochs
+}
+
+// Create a deterministic random number generator for one of the audit's samples
+func newAuditRng(seed string, purpose string) *rand.Rand {
+	hash := sha256.Sum256([]byte(seed + "/" + purpose))
+	return rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(hash[:8]))))
+}
+
+// An attestation duty of one of the sampled minipools
+type auditDuty struct {
+	slot           uint64
+	committeeIndex uint64
+	position       uint64
+	audit          *MinipoolPerformanceAudit
+}
+
+// Recompute the attestation performance of the given minipools for the given epochs of an interval and compare it with what the performance file reports.
+// The counts and scores can only be compared when every epoch is checked; otherwise only the duties in the sampled epochs are compared.
+// The network state must be the one the tree was generated with (the state at the interval's consensus end block).
+func AuditMinipoolPerformance(ctx context.Context, bc beacon.Client, networkState *state.NetworkState, header *RewardsFileHeader, file IMinipoolPerformanceFile, addresses []common.Address, epochs []uint64) ([]*MinipoolPerformanceAudit, error) {
+	beaconConfig := networkState.BeaconConfig
+	slotsPerEpoch := beaconConfig.SlotsPerEpoch
+	genesisTime := time.Unix(int64(beaconConfig.GenesisTime), 0)
+	endEpoch := header.ConsensusEndBlock / slotsPerEpoch
+	allEpochs := uint64(len(epochs)) == endEpoch-header.ConsensusStartBlock/slotsPerEpoch+1
+
+	// Get the minipools' validator indices and what the file says about them
+	audits := make([]*MinipoolPerformanceAudit, 0, len(addresses))
+	validatorMap := map[string]*MinipoolPerformanceAudit{}
+	reportedMissing := map[*MinipoolPerformanceAudit]map[uint64]bool{}
+	chainMissing := map[*MinipoolPerformanceAudit]map[uint64]bool{}
+	scores := map[*MinipoolPerformanceAudit]*big.Int{}
+	for _, address := range addresses {
+		audit := &MinipoolPerformanceAudit{
+			Address:         address,
+			MismatchedSlots: []uint64{},
+		}
+		audits = append(audits, audit)
+
+		performance, exists := file.GetSmoothingPoolPerformance(address)
+		if !exists {
+			audit.Note = "minipool isn't in the performance file"
+			continue
+		}
+		audit.ReportedSuccessful = performance.GetSuccessfulAttestationCount()
+		audit.ReportedMissed = performance.GetMissedAttestationCount()
+		if v3, ok := performance.(*SmoothingPoolMinipoolPerformance_v3); ok && v3.AttestationScore != nil {
+			audit.ReportedScore = &QuotedBigInt{Int: v3.AttestationScore.Int}
+		}
+		reportedMissing[audit] = map[uint64]bool{}
+		for _, slot := range performance.GetMissingAttestationSlots() {
+			reportedMissing[audit][slot] = true
+		}
+		chainMissing[audit] = map[uint64]bool{}
+		scores[audit] = big.NewInt(0)
+
+		pubkey, err := performance.GetPubkey()
+		if err != nil {
+			audit.Note = fmt.Sprintf("error parsing the pubkey: %s", err.Error())
+			continue
+		}
+		if _, exists := networkState.MinipoolDetailsByAddress[address]; !exists {
+			audit.Note = "minipool isn't in the network state"
+			continue
+		}
+		status, exists := networkState.ValidatorDetails[pubkey]
+		if !exists {
+			audit.Note = "validator doesn't exist on the Beacon Chain"
+			continue
+		}
+		switch status.Status {
+		case beacon.ValidatorState_PendingInitialized, beacon.ValidatorState_PendingQueued:
+			audit.Note = "validator wasn't active during the interval"
+			continue
+		}
+		audit.ValidatorIndex = status.Index
+		validatorMap[status.Index] = audit
+	}
+
+	// Attestations are looked up by the block they were included in
+	attestationCache := map[uint64][]beacon.AttestationInfo{}
+	getAttestations := func(slot uint64) ([]beacon.AttestationInfo, error) {
+		if attestations, exists := attestationCache[slot]; exists {
+			return attestations, nil
+		}
+		attestations, found, err := bc.GetAttestations(fmt.Sprint(slot))
+		if err != nil {
+			return nil, fmt.Errorf("error getting the attestations in slot %d: %w", slot, err)
+		}
+		if !found {
+			attestations = []beacon.AttestationInfo{}
+		}
+		attestationCache[slot] = attestations
+		return attestations, nil
+	}
+
+	one := eth.EthToWei(1)
+	validatorReq := eth.EthToWei(32)
+	checkedEpochs := map[uint64]bool{}
+	for _, epoch := range epochs {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("stopped the audit at epoch %d: %w", epoch, err)
+		}
+		checkedEpochs[epoch] = true
+
+		// Blocks before this epoch can't include any of its attestations
+		for slot := range attestationCache {
+			if slot < epoch*slotsPerEpoch {
+				delete(attestationCache, slot)
+			}
+		}
+
+		// Get the sampled minipools' duties in this epoch, using the same eligibility rules as the tree generator
+		epoch := epoch
+		committees, err := bc.GetCommitteesForEpoch(&epoch)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the committees for epoch %d: %w", epoch, err)
+		}
+		duties := []auditDuty{}
+		for idx := 0; idx < committees.Count(); idx++ {
+			slot := committees.Slot(idx)
+			if slot < header.ConsensusStartBlock || slot > header.ConsensusEndBlock {
+				continue
+			}
+			blockTime := genesisTime.Add(time.Second * time.Duration(beaconConfig.SecondsPerSlot*slot))
+			for position, validator := range committees.Validators(idx) {
+				audit, exists := validatorMap[validator]
+				if !exists {
+					continue
+				}
+
+				// Check if this minipool was opted into the SP for this block
+				mpd := networkState.MinipoolDetailsByAddress[audit.Address]
+				nodeDetails := networkState.NodeDetailsByAddress[mpd.NodeAddress]
+				isOptedIn := nodeDetails.SmoothingPoolRegistrationState
+				spRegistrationTime := time.Unix(nodeDetails.SmoothingPoolRegistrationChanged.Int64(), 0)
+				if (isOptedIn && blockTime.Sub(spRegistrationTime) < 0) ||
+					(!isOptedIn && spRegistrationTime.Sub(blockTime) < 0) {
+					continue
+				}
+
+				// Check if this minipool was in the `staking` state during this time
+				statusChangeTime := time.Unix(mpd.StatusTime.Int64(), 0)
+				if mpd.Status != rptypes.Staking || blockTime.Sub(statusChangeTime) < 0 {
+					continue
+				}
+
+				duties = append(duties, auditDuty{
+					slot:           slot,
+					committeeIndex: committees.Index(idx),
+					position:       uint64(position),
+					audit:          audit,
+				})
+			}
+		}
+		committees.Release()
+
+		// Look for each duty's attestation in the blocks that could have included it
+		for _, duty := range duties {
+			lastSlot := (duty.slot/slotsPerEpoch+2)*slotsPerEpoch - 1
+			if limit := (endEpoch+2)*slotsPerEpoch - 1; lastSlot > limit {
+				lastSlot = limit
+			}
+			attested := false
+			for slot := duty.slot + 1; slot <= lastSlot && !attested; slot++ {
+				attestations, err := getAttestations(slot)
+				if err != nil {
+					return nil, err
+				}
+				for _, attestation := range attestations {
+					if attestation.SlotIndex == duty.slot && attestation.CommitteeIndex == duty.committeeIndex && attestation.AggregationBits.BitAt(duty.position) {
+						attested = true
+						break
+					}
+				}
+			}
+
+			audit := duty.audit
+			audit.CheckedDuties++
+			if !attested {
+				audit.Missed++
+				chainMissing[audit][duty.slot] = true
+				continue
+			}
+			audit.Successful++
+
+			// Get the pseudoscore for this attestation
+			blockTime := genesisTime.Add(time.Second * time.Duration(beaconConfig.SecondsPerSlot*duty.slot))
+			bond, fee := getMinipoolBondAndNodeFee(networkState.MinipoolDetailsByAddress[audit.Address], blockTime)
+			minipoolScore := big.NewInt(0).Sub(one, fee)   // 1 - fee
+			minipoolScore.Mul(minipoolScore, bond)         // Multiply by bond
+			minipoolScore.Div(minipoolScore, validatorReq) // Divide by 32 to get the bond as a fraction of a total validator
+			minipoolScore.Add(minipoolScore, fee)          // Total = fee + (bond/32)(1 - fee)
+			scores[audit].Add(scores[audit], minipoolScore)
+		}
+	}
+
+	// Compare the results with the file
+	for _, audit := range audits {
+		if reportedMissing[audit] == nil {
+			continue
+		}
+
+		// Find the slots in the checked epochs that the file and the chain disagree on
+		for slot := range reportedMissing[audit] {
+			if checkedEpochs[slot/slotsPerEpoch] && !chainMissing[audit][slot] {
+				audit.MismatchedSlots = append(audit.MismatchedSlots, slot)
+			}
+		}
+		for slot := range chainMissing[audit] {
+			if !reportedMissing[audit][slot] {
+				audit.MismatchedSlots = append(audit.MismatchedSlots, slot)
+			}
+		}
+		sort.Slice(audit.MismatchedSlots, func(i, j int) bool {
+			return audit.MismatchedSlots[i] < audit.MismatchedSlots[j]
+		})
+		audit.Passed = len(audit.MismatchedSlots) == 0
+
+		// The totals only line up when the whole interval was checked
+		if allEpochs {
+			audit.Score = &QuotedBigInt{Int: *scores[audit]}
+			if audit.Successful != audit.ReportedSuccessful || audit.Missed != audit.ReportedMissed {
+				audit.Passed = false
+			}
+			if audit.ReportedScore != nil && audit.ReportedScore.Cmp(&audit.Score.Int) != 0 {
+				audit.Passed = false
+			}
+		}
+	}
+
+	return audits, nil
+}
+
+// Download the minipool performance file for an interval from IPFS or the mirrors, making sure it has the canonical CID
+func DownloadMinipoolPerformanceFile(cfg *config.RocketPoolConfig, interval uint64, expectedCid string, isDaemon bool) (IMinipoolPerformanceFile, error) {
+	path := cfg.Smartnode.GetMinipoolPerformancePath(interval, isDaemon)
+	ipfsFilename := filepath.Base(path) + config.RewardsTreeIpfsExtension
+	partialPath := path + partialDownloadExtension
+
+	errs := []string{}
+	for _, url := range mirror.GetRewardsFileUrls(cfg, expectedCid, ipfsFilename) {
+		ctx, cancel := context.WithTimeout(context.Background(), downloadRaceTimeout)
+		err := resumeDownload(ctx, url, partialPath)
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("Downloading %s failed (%s)", url, err.Error()))
+			continue
+		}
+
+		bytes, err := os.ReadFile(partialPath)
+		_ = os.Remove(partialPath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading the downloaded file: %w", err)
+		}
+		cid, err := singleFileDirIPFSCid(bytes, ipfsFilename)
+		if err != nil {
+			return nil, fmt.Errorf("error calculating the CID: %w", err)
+		}
+		if cid.String() != expectedCid {
+			errs = append(errs, fmt.Sprintf("The file from %s has CID %s, but the canonical one is %s", url, cid.String(), expectedCid))
+			continue
+		}
+		bytes, err = decompressFile(bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error decompressing the file from %s: %w", url, err)
+		}
+		file, err := DeserializeMinipoolPerformanceFile(bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error deserializing the file from %s: %w", url, err)
+		}
+
+		// Save it for next time
+		localFile, err := NewCompressedLocalFile[IMinipoolPerformanceFile](file, path, cfg)
+		if err != nil {
+			return nil, err
+		}
+		if err := localFile.Write(); err != nil {
+			return nil, fmt.Errorf("error saving the minipool performance file to %s: %w", path, err)
+		}
+		return file, nil
+	}
+	return nil, fmt.Errorf("error downloading the minipool performance file for interval %d:\n%s", interval, strings.Join(errs, "\n"))
+}
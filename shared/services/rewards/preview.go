@@ -0,0 +1,179 @@
+package rewards
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"regexp"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+const previewLogPrefix string = "[Rewards Preview]"
+
+// A node's rewards for the current interval so far, as if it had ended at the latest finalized epoch
+type RewardsPreview struct {
+	Index               uint64                   `json:"index"`
+	RulesetVersion      uint64                   `json:"rulesetVersion"`
+	StartTime           time.Time                `json:"startTime"`
+	EndTime             time.Time                `json:"endTime"`
+	IntervalEndTime     time.Time                `json:"intervalEndTime"`
+	ConsensusStartBlock uint64                   `json:"consensusStartBlock"`
+	ConsensusEndBlock   uint64                   `json:"consensusEndBlock"`
+	CheckpointSlot      uint64                   `json:"checkpointSlot"`
+	HasCheckpoint       bool                     `json:"hasCheckpoint"`
+	CollateralRpl       *QuotedBigInt            `json:"collateralRpl"`
+	OracleDaoRpl        *QuotedBigInt            `json:"oracleDaoRpl"`
+	SmoothingPoolEth    *QuotedBigInt            `json:"smoothingPoolEth"`
+	Minipools           []MinipoolRewardsPreview `json:"minipools"`
+}
+
+// A minipool's share of a rewards preview
+type MinipoolRewardsPreview struct {
+	Address                common.Address          `json:"address"`
+	Pubkey                 rptypes.ValidatorPubkey `json:"pubkey"`
+	InSmoothingPool        bool                    `json:"inSmoothingPool"`
+	SuccessfulAttestations uint64                  `json:"successfulAttestations"`
+	MissedAttestations     uint64                  `json:"missedAttestations"`
+	SmoothingPoolEth       *QuotedBigInt           `json:"smoothingPoolEth"`
+}
+
+// Generate the rewards tree for the current interval up to the latest finalized epoch, starting from the latest rolling record checkpoint
+// on disk, and get a node's share of it. Nothing is saved, so the watchtower's checkpoints and files are left as they are.
+func PreviewNodeRewards(ctx context.Context, logger *log.ColorLogger, cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress common.Address) (*RewardsPreview, error) {
+	beaconCfg, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon config: %w", err)
+	}
+	stateMgr, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, logger)
+	if err != nil {
+		return nil, fmt.Errorf("error creating network state manager: %w", err)
+	}
+
+	// Get the state at the latest finalized block
+	block, err := stateMgr.GetLatestFinalizedBeaconBlock()
+	if err != nil {
+		return nil, fmt.Errorf("error getting the latest finalized Beacon block: %w", err)
+	}
+	if !block.HasExecutionPayload {
+		return nil, fmt.Errorf("the latest finalized Beacon block (slot %d) doesn't have an execution payload", block.Slot)
+	}
+	networkState, err := stateMgr.GetStateForSlot(ctx, block.Slot)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the network state at slot %d: %w", block.Slot, err)
+	}
+	elBlockHeader, err := rp.Client.HeaderByNumber(ctx, big.NewInt(0).SetUint64(block.ExecutionBlockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("error getting execution block %d: %w", block.ExecutionBlockNumber, err)
+	}
+
+	// Get the current interval's bounds, ending it at the finalized block like the balances report does
+	index := networkState.NetworkDetails.RewardIndex
+	if index == 0 {
+		return nil, fmt.Errorf("rolling records cannot be used for the first rewards interval")
+	}
+	genesisTime := time.Unix(int64(beaconCfg.GenesisTime), 0)
+	startTime := networkState.NetworkDetails.IntervalStart
+	intervalTime := networkState.NetworkDetails.IntervalDuration
+	endTime := genesisTime.Add(time.Duration(block.Slot*beaconCfg.SecondsPerSlot) * time.Second)
+	intervalsPassed := endTime.Sub(startTime) / intervalTime
+
+	previousEvent, err := GetRewardSnapshotEvent(rp, cfg, index-1, nil)
+	if err != nil {
+		return nil, err
+	}
+	startSlot, err := GetStartSlotForInterval(previousEvent, bc, beaconCfg)
+	if err != nil {
+		return nil, fmt.Errorf("error getting start slot for interval %d: %w", index, err)
+	}
+
+	// Load the latest checkpoint without the manager's constructor, which migrates the saved checkpoints
+	codec, err := newRecordCodec(cfg)
+	if err != nil {
+		return nil, err
+	}
+	recordMgr := &RollingRecordManager{
+		log:                  logger,
+		errLog:               logger,
+		logPrefix:            previewLogPrefix,
+		cfg:                  cfg,
+		rp:                   rp,
+		bc:                   bc,
+		mgr:                  stateMgr,
+		startSlot:            startSlot,
+		beaconCfg:            beaconCfg,
+		genesisTime:          genesisTime,
+		codec:                codec,
+		recordsFilenameRegex: regexp.MustCompile(recordsFilenamePattern),
+	}
+	record, err := recordMgr.LoadBestRecordFromDisk(startSlot, block.Slot, index)
+	if err != nil {
+		return nil, fmt.Errorf("error loading rolling record checkpoint: %w", err)
+	}
+	preview := &RewardsPreview{
+		Index:               index,
+		StartTime:           startTime,
+		EndTime:             endTime,
+		IntervalEndTime:     startTime.Add(intervalTime),
+		ConsensusStartBlock: startSlot,
+		ConsensusEndBlock:   block.Slot,
+		CheckpointSlot:      record.LastDutiesSlot,
+		HasCheckpoint:       record.LastDutiesSlot != 0,
+		CollateralRpl:       NewQuotedBigInt(0),
+		OracleDaoRpl:        NewQuotedBigInt(0),
+		SmoothingPoolEth:    NewQuotedBigInt(0),
+		Minipools:           []MinipoolRewardsPreview{},
+	}
+
+	// Catch the record up to the finalized block in memory
+	if record.LastDutiesSlot < block.Slot {
+		err = record.UpdateToSlot(ctx, block.Slot, networkState)
+		if err != nil {
+			return nil, fmt.Errorf("error updating rolling record to slot %d: %w", block.Slot, err)
+		}
+	}
+
+	// Generate the tree
+	treegen, err := NewTreeGenerator(logger, previewLogPrefix, rp, cfg, bc, index, startTime, endTime, block.Slot, elBlockHeader, uint64(intervalsPassed), networkState, record)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Merkle tree generator: %w", err)
+	}
+	rewardsFile, err := treegen.GenerateTree(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error generating Merkle tree: %w", err)
+	}
+	preview.RulesetVersion = rewardsFile.GetHeader().RulesetVersion
+
+	// Get the node's share
+	nodeRewards, exists := rewardsFile.GetNodeRewardsInfo(nodeAddress)
+	if exists {
+		preview.CollateralRpl = nodeRewards.GetCollateralRpl()
+		preview.OracleDaoRpl = nodeRewards.GetOracleDaoRpl()
+		preview.SmoothingPoolEth = nodeRewards.GetSmoothingPoolEth()
+	}
+	performanceFile := rewardsFile.GetMinipoolPerformanceFile()
+	for _, mpd := range networkState.MinipoolDetailsByNode[nodeAddress] {
+		minipool := MinipoolRewardsPreview{
+			Address:          mpd.MinipoolAddress,
+			Pubkey:           mpd.Pubkey,
+			SmoothingPoolEth: NewQuotedBigInt(0),
+		}
+		performance, exists := performanceFile.GetSmoothingPoolPerformance(mpd.MinipoolAddress)
+		if exists {
+			minipool.InSmoothingPool = true
+			minipool.SuccessfulAttestations = performance.GetSuccessfulAttestationCount()
+			minipool.MissedAttestations = performance.GetMissedAttestationCount()
+			minipool.SmoothingPoolEth.Set(performance.GetEthEarned())
+		}
+		preview.Minipools = append(preview.Minipools, minipool)
+	}
+	return preview, nil
+}
@@ -0,0 +1,201 @@
+package rewards
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// A problem with a checkpoint found by the record check
+type RecordCheckIssue struct {
+	Interval uint64 `json:"interval"`
+	Slot     uint64 `json:"slot"`
+	Hash     string `json:"hash,omitempty"`
+	Problem  string `json:"problem"`
+}
+
+// The results of checking the rolling record checkpoints
+type RecordCheckResult struct {
+	ManifestExists  bool               `json:"manifestExists"`
+	NeedsMigration  bool               `json:"needsMigration"`
+	Intervals       []uint64           `json:"intervals"`
+	CheckpointCount int                `json:"checkpointCount"`
+	Issues          []RecordCheckIssue `json:"issues"`
+	OutOfOrder      bool               `json:"outOfOrder"`
+	UnusedBlobs     []string           `json:"unusedBlobs"`
+	LatestInterval  uint64             `json:"latestInterval"`
+	LatestSlot      uint64             `json:"latestSlot"`
+	LatestLoadable  bool               `json:"latestLoadable"`
+	Repaired        bool               `json:"repaired"`
+}
+
+// Check if the check found anything wrong with the checkpoints
+func (r *RecordCheckResult) HasProblems() bool {
+	return len(r.Issues) > 0 || r.OutOfOrder || len(r.UnusedBlobs) > 0
+}
+
+// Re-hash every checkpoint in the record stores against their manifests, and make sure the newest one of each interval can be deserialized.
+// If repair is set, the checkpoints with problems are dropped from the manifests and unused blobs are removed, so the next
+// update falls back to the latest good checkpoint instead of failing on a bad one.
+func CheckRecords(cfg *config.RocketPoolConfig, repair bool) (*RecordCheckResult, error) {
+	recordStoreLock.Lock()
+	defer recordStoreLock.Unlock()
+
+	result := &RecordCheckResult{
+		Intervals:   []uint64{},
+		Issues:      []RecordCheckIssue{},
+		UnusedBlobs: []string{},
+	}
+	_, err := os.Stat(filepath.Join(cfg.Smartnode.GetRecordsPath(), config.ChecksumTableFilename))
+	result.NeedsMigration = (err == nil)
+	_, err = os.Stat(getUnsplitRecordStore(cfg).manifestPath)
+	result.NeedsMigration = result.NeedsMigration || (err == nil)
+
+	codec, err := newRecordCodec(cfg)
+	if err != nil {
+		return nil, err
+	}
+	intervals, err := getRecordIntervals(cfg)
+	if err != nil {
+		return nil, err
+	}
+	for _, interval := range intervals {
+		store := newRecordStore(cfg, interval)
+		exists, err := checkRecordStore(store, codec, repair, result)
+		if err != nil {
+			return nil, fmt.Errorf("error checking the records for interval %d: %w", interval, err)
+		}
+		if exists {
+			result.ManifestExists = true
+			result.Intervals = append(result.Intervals, interval)
+		}
+	}
+	return result, nil
+}
+
+// Check the checkpoints in a single interval's record store, adding what was found to the result.
+// Returns false if the store doesn't have a manifest.
+func checkRecordStore(store *recordStore, codec *recordCodec, repair bool, result *RecordCheckResult) (bool, error) {
+	manifest, exists, err := store.loadManifest()
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		return false, nil
+	}
+	result.CheckpointCount += len(manifest.Checkpoints)
+	outOfOrder := !sort.SliceIsSorted(manifest.Checkpoints, func(i int, j int) bool {
+		return manifest.Checkpoints[i].Slot < manifest.Checkpoints[j].Slot
+	})
+	if outOfOrder {
+		result.OutOfOrder = true
+		sort.Slice(manifest.Checkpoints, func(i int, j int) bool {
+			return manifest.Checkpoints[i].Slot < manifest.Checkpoints[j].Slot
+		})
+	}
+
+	// Re-hash every chunk of every checkpoint
+	bad := map[int]bool{}
+	for i, checkpoint := range manifest.Checkpoints {
+		if len(checkpoint.Chunks) == 0 {
+			result.Issues = append(result.Issues, RecordCheckIssue{
+				Interval: store.interval,
+				Slot:     checkpoint.Slot,
+				Problem:  "checkpoint doesn't have any chunks",
+			})
+			bad[i] = true
+			continue
+		}
+		for _, hash := range checkpoint.Chunks {
+			_, err := store.getBlob(hash)
+			if err != nil {
+				result.Issues = append(result.Issues, RecordCheckIssue{
+					Interval: store.interval,
+					Slot:     checkpoint.Slot,
+					Hash:     hash,
+					Problem:  err.Error(),
+				})
+				bad[i] = true
+				break
+			}
+		}
+	}
+
+	// Make sure the newest good checkpoint can actually be loaded, falling back to older ones if it can't
+	for i := len(manifest.Checkpoints) - 1; i >= 0; i-- {
+		if bad[i] {
+			continue
+		}
+		checkpoint := manifest.Checkpoints[i]
+		err := checkRecordCheckpoint(store, codec, manifest, checkpoint)
+		if err != nil {
+			result.Issues = append(result.Issues, RecordCheckIssue{
+				Interval: store.interval,
+				Slot:     checkpoint.Slot,
+				Problem:  err.Error(),
+			})
+			bad[i] = true
+			continue
+		}
+
+		// Intervals are checked in order, so the last one with a usable checkpoint is the newest
+		result.LatestInterval = store.interval
+		result.LatestSlot = checkpoint.Slot
+		result.LatestLoadable = true
+		break
+	}
+
+	// Find blobs that no checkpoint uses
+	unused, err := store.getUnusedBlobs(manifest)
+	if err != nil {
+		return true, err
+	}
+	result.UnusedBlobs = append(result.UnusedBlobs, unused...)
+
+	if !repair || (len(bad) == 0 && !outOfOrder && len(unused) == 0) {
+		return true, nil
+	}
+
+	// Drop the bad checkpoints and the blobs nothing uses anymore
+	goodCheckpoints := make([]recordManifestEntry, 0, len(manifest.Checkpoints))
+	for i, checkpoint := range manifest.Checkpoints {
+		if !bad[i] {
+			goodCheckpoints = append(goodCheckpoints, checkpoint)
+		}
+	}
+	manifest.Checkpoints = goodCheckpoints
+	err = store.saveManifest(manifest)
+	if err != nil {
+		return true, err
+	}
+	_, err = store.collectGarbage(manifest)
+	if err != nil {
+		return true, err
+	}
+	result.Repaired = true
+	return true, nil
+}
+
+// Decompress and deserialize a checkpoint, making sure it's for the interval and slot the manifest says it is
+func checkRecordCheckpoint(store *recordStore, codec *recordCodec, manifest *recordManifest, checkpoint recordManifestEntry) error {
+	bytes, err := store.getCheckpoint(codec, checkpoint)
+	if err != nil {
+		return err
+	}
+	var record RollingRecord
+	err = json.Unmarshal(bytes, &record)
+	if err != nil {
+		return fmt.Errorf("error deserializing record: %w", err)
+	}
+	if record.RewardsInterval != manifest.RewardsInterval || record.StartSlot != manifest.StartSlot {
+		return fmt.Errorf("record is for interval %d starting on slot %d, but the manifest is for interval %d starting on slot %d", record.RewardsInterval, record.StartSlot, manifest.RewardsInterval, manifest.StartSlot)
+	}
+	if record.LastDutiesSlot != checkpoint.Slot {
+		return fmt.Errorf("record ends on slot %d, but the manifest lists it for slot %d", record.LastDutiesSlot, checkpoint.Slot)
+	}
+	return nil
+}
@@ -0,0 +1,250 @@
+package rewards
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/klauspost/compress/dict"
+	"github.com/klauspost/compress/s2"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+const (
+	// Checkpoints and compressed local rewards files start with this, followed by a byte identifying the codec.
+	// Checkpoints without it were saved before the codec was configurable, and are plain zstd; rewards files without it are uncompressed.
+	recordCodecMagic string = "RPRC"
+
+	recordDictionaryFilenameFormat  string = "record-dictionary-%d.zdict"
+	recordDictionaryFilenamePattern string = "^record-dictionary-\\d+\\.zdict$"
+
+	// How many checkpoints need to be saved before a dictionary is trained, and how many of the latest ones are used to train it
+	minDictionarySamples int = 5
+	maxDictionarySamples int = 10
+
+	// The largest dictionary to train
+	maxDictionarySize int = 112 << 10
+)
+
+// The codec IDs stored in checkpoint headers
+const (
+	recordCodecId_None byte = 0
+	recordCodecId_Zstd byte = 1
+	recordCodecId_S2   byte = 2
+	recordCodecId_Lz4  byte = 3
+)
+
+// Compresses and decompresses rolling record checkpoints and local rewards files
+type recordCodec struct {
+	codec         cfgtypes.RecordCodec
+	encoder       *zstd.Encoder
+	decoder       *zstd.Decoder
+	hasDictionary bool
+}
+
+// Create a codec for the checkpoints in the records folder using the configured compression.
+// Every dictionary in the folder is loaded so older checkpoints can still be read; the newest one is used for new checkpoints if enabled.
+func newRecordCodec(cfg *config.RocketPoolConfig) (*recordCodec, error) {
+	codec := cfg.Smartnode.RecordCompressionCodec.Value.(cfgtypes.RecordCodec)
+	level := zstd.EncoderLevelFromZstd(int(cfg.Smartnode.RecordCompressionLevel.Value.(uint64)))
+	useDictionary := cfg.Smartnode.UseRecordCompressionDictionary.Value.(bool)
+
+	dictionaries, err := loadRecordDictionaries(cfg.Smartnode.GetRecordsPath())
+	if err != nil {
+		return nil, err
+	}
+
+	encoderOptions := []zstd.EOption{zstd.WithEncoderLevel(level)}
+	hasDictionary := false
+	if useDictionary && len(dictionaries) > 0 {
+		encoderOptions = append(encoderOptions, zstd.WithEncoderDict(dictionaries[len(dictionaries)-1]))
+		hasDictionary = true
+	}
+	encoder, err := zstd.NewWriter(nil, encoderOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating zstd compressor for rolling records: %w", err)
+	}
+	decoder, err := zstd.NewReader(nil, zstd.WithDecoderDicts(dictionaries...))
+	if err != nil {
+		return nil, fmt.Errorf("error creating zstd decompressor for rolling records: %w", err)
+	}
+
+	return &recordCodec{
+		codec:         codec,
+		encoder:       encoder,
+		decoder:       decoder,
+		hasDictionary: hasDictionary,
+	}, nil
+}
+
+// Create a codec for the local rewards and minipool performance files using the configured compression.
+// These never use a dictionary, so they can be read without the records folder.
+func newRewardsFileCodec(cfg *config.RocketPoolConfig) (*recordCodec, error) {
+	level := zstd.EncoderLevelFromZstd(int(cfg.Smartnode.RecordCompressionLevel.Value.(uint64)))
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(level))
+	if err != nil {
+		return nil, fmt.Errorf("error creating zstd compressor for rewards files: %w", err)
+	}
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating zstd decompressor for rewards files: %w", err)
+	}
+
+	return &recordCodec{
+		codec:   cfg.Smartnode.RewardsFileCompression.Value.(cfgtypes.RecordCodec),
+		encoder: encoder,
+		decoder: decoder,
+	}, nil
+}
+
+// Compress a serialized record, prefixing it with the header that identifies the codec
+func (c *recordCodec) encode(data []byte) ([]byte, error) {
+	var codecId byte
+	var payload []byte
+	switch c.codec {
+	case cfgtypes.RecordCodec_None:
+		codecId = recordCodecId_None
+		payload = data
+	case cfgtypes.RecordCodec_S2:
+		codecId = recordCodecId_S2
+		payload = s2.EncodeBetter(nil, data)
+	case cfgtypes.RecordCodec_Lz4:
+		codecId = recordCodecId_Lz4
+		var buffer bytes.Buffer
+		writer := lz4.NewWriter(&buffer)
+		if _, err := writer.Write(data); err != nil {
+			return nil, fmt.Errorf("error compressing with lz4: %w", err)
+		}
+		if err := writer.Close(); err != nil {
+			return nil, fmt.Errorf("error compressing with lz4: %w", err)
+		}
+		payload = buffer.Bytes()
+	default:
+		codecId = recordCodecId_Zstd
+		payload = c.encoder.EncodeAll(data, make([]byte, 0, len(data)))
+	}
+
+	encoded := make([]byte, 0, len(recordCodecMagic)+1+len(payload))
+	encoded = append(encoded, recordCodecMagic...)
+	encoded = append(encoded, codecId)
+	return append(encoded, payload...), nil
+}
+
+// Decompress a checkpoint, using the codec from its header
+func (c *recordCodec) decode(data []byte) ([]byte, error) {
+	if !hasCodecHeader(data) {
+		// Saved before the codec was configurable
+		return c.decoder.DecodeAll(data, []byte{})
+	}
+
+	codecId := data[len(recordCodecMagic)]
+	payload := data[len(recordCodecMagic)+1:]
+	switch codecId {
+	case recordCodecId_None:
+		return payload, nil
+	case recordCodecId_Zstd:
+		return c.decoder.DecodeAll(payload, []byte{})
+	case recordCodecId_S2:
+		return s2.Decode(nil, payload)
+	case recordCodecId_Lz4:
+		return io.ReadAll(lz4.NewReader(bytes.NewReader(payload)))
+	default:
+		return nil, fmt.Errorf("unknown record codec %d", codecId)
+	}
+}
+
+// Check if the data starts with the header that identifies its codec
+func hasCodecHeader(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(recordCodecMagic)) && len(data) > len(recordCodecMagic)
+}
+
+// Decompress a local rewards or minipool performance file if it was saved with compression
+func decodeLocalFile(data []byte) ([]byte, error) {
+	if !hasCodecHeader(data) {
+		return data, nil
+	}
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating zstd decompressor: %w", err)
+	}
+	defer decoder.Close()
+	codec := &recordCodec{
+		decoder: decoder,
+	}
+	return codec.decode(data)
+}
+
+// Load the dictionaries in the records folder, oldest first
+func loadRecordDictionaries(recordsPath string) ([][]byte, error) {
+	entries, err := os.ReadDir(recordsPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading rolling records folder: %w", err)
+	}
+
+	type dictionaryFile struct {
+		path    string
+		modTime int64
+	}
+	dictionaryFiles := []dictionaryFile{}
+	filenameRegex := regexp.MustCompile(recordDictionaryFilenamePattern)
+	for _, entry := range entries {
+		if entry.IsDir() || !filenameRegex.MatchString(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("error checking record dictionary %s: %w", entry.Name(), err)
+		}
+		dictionaryFiles = append(dictionaryFiles, dictionaryFile{
+			path:    filepath.Join(recordsPath, entry.Name()),
+			modTime: info.ModTime().UnixNano(),
+		})
+	}
+	sort.Slice(dictionaryFiles, func(i int, j int) bool {
+		return dictionaryFiles[i].modTime < dictionaryFiles[j].modTime
+	})
+
+	dictionaries := make([][]byte, 0, len(dictionaryFiles))
+	for _, file := range dictionaryFiles {
+		dictionary, err := os.ReadFile(file.path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading record dictionary %s: %w", file.path, err)
+		}
+		dictionaries = append(dictionaries, dictionary)
+	}
+	return dictionaries, nil
+}
+
+// Train a zstd dictionary from serialized records and save it to the records folder
+func trainRecordDictionary(recordsPath string, samples [][]byte, level zstd.EncoderLevel) (string, error) {
+	dictionary, err := dict.BuildZstdDict(samples, dict.Options{
+		MaxDictSize: maxDictionarySize,
+		HashBytes:   6,
+		ZstdLevel:   level,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error training record dictionary: %w", err)
+	}
+	info, err := zstd.InspectDictionary(dictionary)
+	if err != nil {
+		return "", fmt.Errorf("error checking trained record dictionary: %w", err)
+	}
+
+	path := filepath.Join(recordsPath, fmt.Sprintf(recordDictionaryFilenameFormat, info.ID()))
+	err = files.WriteFileAtomic(path, dictionary, 0644)
+	if err != nil {
+		return "", fmt.Errorf("error saving record dictionary: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,505 @@
+package rewards
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/errcodes"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+const (
+	// The version of the record manifest format; version 1 manifests stored each checkpoint as a single blob
+	recordManifestVersion uint64 = 2
+
+	// How many rewards intervals to keep records for; the closing interval's records are kept while the new one's are built,
+	// so the closing interval can still be regenerated until its rewards have been submitted
+	recordIntervalsRetained uint64 = 2
+
+	// The bounds on the size of a checkpoint chunk, and how many of the top bits of the rolling hash have to be zero to end one early;
+	// 20 bits gives chunks of about 1 MiB past the minimum
+	minRecordChunkSize int    = 256 << 10
+	maxRecordChunkSize int    = 4 << 20
+	recordChunkBits    uint64 = 20
+)
+
+// The table for the rolling hash that picks the chunk boundaries.
+// It's generated from a fixed seed so the boundaries, and therefore the chunks, are the same across restarts and versions.
+var recordChunkGear [256]uint64
+
+func init() {
+	seed := uint64(0x9e3779b97f4a7c15)
+	for i := range recordChunkGear {
+		seed += 0x9e3779b97f4a7c15
+		z := seed
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		recordChunkGear[i] = z ^ (z >> 31)
+	}
+}
+
+// Serializes changes to the manifest, since the record check can run alongside the tasks that save checkpoints
+var recordStoreLock sync.Mutex
+
+// A checkpoint in the record manifest
+type recordManifestEntry struct {
+	Slot   uint64   `json:"slot"`
+	Epoch  uint64   `json:"epoch"`
+	Chunks []string `json:"chunks"`
+
+	// The single blob holding the checkpoint in version 1 manifests; it's moved into the chunks when the manifest is loaded
+	Hash string `json:"hash,omitempty"`
+}
+
+// The index of a rewards interval's rolling record checkpoints, mapping each checkpoint's slot to the chunk blobs holding it, in order
+type recordManifest struct {
+	Version         uint64                `json:"version"`
+	RewardsInterval uint64                `json:"rewardsInterval"`
+	StartSlot       uint64                `json:"startSlot"`
+	Checkpoints     []recordManifestEntry `json:"checkpoints"`
+}
+
+// Add or replace the checkpoint for a slot, keeping the checkpoints sorted by slot
+func (m *recordManifest) setCheckpoint(entry recordManifestEntry) {
+	for i, checkpoint := range m.Checkpoints {
+		if checkpoint.Slot == entry.Slot {
+			m.Checkpoints[i] = entry
+			return
+		}
+	}
+	m.Checkpoints = append(m.Checkpoints, entry)
+	sort.Slice(m.Checkpoints, func(i int, j int) bool {
+		return m.Checkpoints[i].Slot < m.Checkpoints[j].Slot
+	})
+}
+
+// A content-addressed store for a rewards interval's rolling record checkpoints.
+// Each checkpoint is split into chunks at content-defined boundaries and every chunk is compressed and saved as a blob named after the SHA384
+// hash of its contents, so the parts of the record that didn't change since the last checkpoint are only stored once; the manifest maps the
+// checkpoints' slots to their chunks. Every interval has its own store, so the records for the closing interval and the
+// new one don't get in each other's way when they overlap.
+type recordStore struct {
+	interval     uint64
+	manifestPath string
+	blobsPath    string
+}
+
+// Create a store for a rewards interval's records
+func newRecordStore(cfg *config.RocketPoolConfig, interval uint64) *recordStore {
+	return &recordStore{
+		interval:     interval,
+		manifestPath: filepath.Join(cfg.Smartnode.GetRecordsIntervalPath(interval), config.RecordManifestFilename),
+		blobsPath:    cfg.Smartnode.GetRecordBlobsPath(interval),
+	}
+}
+
+// Get the rewards intervals that have a record store, in ascending order
+func getRecordIntervals(cfg *config.RocketPoolConfig) ([]uint64, error) {
+	entries, err := os.ReadDir(cfg.Smartnode.GetRecordsPath())
+	if os.IsNotExist(err) {
+		return []uint64{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading rolling records folder: %w", err)
+	}
+
+	intervals := []uint64{}
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), config.RecordsIntervalFolderPrefix) {
+			continue
+		}
+		interval, err := strconv.ParseUint(strings.TrimPrefix(entry.Name(), config.RecordsIntervalFolderPrefix), 10, 64)
+		if err != nil {
+			continue
+		}
+		intervals = append(intervals, interval)
+	}
+	sort.Slice(intervals, func(i int, j int) bool {
+		return intervals[i] < intervals[j]
+	})
+	return intervals, nil
+}
+
+// Load the manifest; returns false if it hasn't been created yet
+func (s *recordStore) loadManifest() (*recordManifest, bool, error) {
+	bytes, err := os.ReadFile(s.manifestPath)
+	if os.IsNotExist(err) {
+		return &recordManifest{
+			Version:         recordManifestVersion,
+			RewardsInterval: s.interval,
+			Checkpoints:     []recordManifestEntry{},
+		}, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("error reading record manifest (%s): %w", s.manifestPath, err)
+	}
+
+	var manifest recordManifest
+	err = json.Unmarshal(bytes, &manifest)
+	if err != nil {
+		return nil, false, fmt.Errorf("error deserializing record manifest (%s): %w", s.manifestPath, err)
+	}
+	if manifest.Version == 0 || manifest.Version > recordManifestVersion {
+		return nil, false, fmt.Errorf("record manifest (%s) has version %d, but only versions up to %d are supported", s.manifestPath, manifest.Version, recordManifestVersion)
+	}
+	if manifest.Checkpoints == nil {
+		manifest.Checkpoints = []recordManifestEntry{}
+	}
+
+	// A single-blob checkpoint is just a checkpoint with one chunk, since the chunks are decompressed separately and joined
+	for i, checkpoint := range manifest.Checkpoints {
+		if len(checkpoint.Chunks) == 0 && checkpoint.Hash != "" {
+			manifest.Checkpoints[i].Chunks = []string{checkpoint.Hash}
+		}
+		manifest.Checkpoints[i].Hash = ""
+	}
+	manifest.Version = recordManifestVersion
+	return &manifest, true, nil
+}
+
+// Save the manifest
+func (s *recordStore) saveManifest(manifest *recordManifest) error {
+	bytes, err := json.MarshalIndent(manifest, "", "\t")
+	if err != nil {
+		return fmt.Errorf("error serializing record manifest: %w", err)
+	}
+	err = files.WriteFileAtomic(s.manifestPath, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing record manifest: %w", err)
+	}
+	return nil
+}
+
+// Get the path of the blob with the provided hash
+func (s *recordStore) blobPath(hash string) string {
+	return filepath.Join(s.blobsPath, hash)
+}
+
+// Store a blob, returning its hash. If a blob with the same contents is already stored, it's reused.
+func (s *recordStore) putBlob(data []byte) (string, error) {
+	checksum := sha512.Sum384(data)
+	hash := hex.EncodeToString(checksum[:])
+	path := s.blobPath(hash)
+
+	_, err := os.Stat(path)
+	if err == nil {
+		return hash, nil
+	}
+	if !os.IsNotExist(err) {
+		return "", fmt.Errorf("error checking record blob [%s]: %w", hash, err)
+	}
+
+	err = os.MkdirAll(s.blobsPath, 0755)
+	if err != nil {
+		return "", fmt.Errorf("error creating rolling record blobs folder: %w", err)
+	}
+	err = files.WriteFileAtomic(path, data, 0644)
+	if err != nil {
+		return "", fmt.Errorf("error writing record blob [%s]: %w", hash, err)
+	}
+	return hash, nil
+}
+
+// Get a blob, making sure its contents still match its hash
+func (s *recordStore) getBlob(hash string) ([]byte, error) {
+	data, err := os.ReadFile(s.blobPath(hash))
+	if err != nil {
+		return nil, fmt.Errorf("error reading record blob [%s]: %w", hash, err)
+	}
+
+	checksum := sha512.Sum384(data)
+	actualHash := hex.EncodeToString(checksum[:])
+	if actualHash != hash {
+		return nil, errcodes.Wrap(errcodes.ChecksumError, fmt.Errorf("checksum mismatch (expected %s, but it was %s)", hash, actualHash))
+	}
+	return data, nil
+}
+
+// Split a serialized record into chunks, ending each one where a rolling hash of the last 64 bytes hits the target.
+// The boundaries follow the contents rather than the offsets, so a change to one part of the record only changes the chunks around it.
+func splitRecordChunks(data []byte) [][]byte {
+	chunks := [][]byte{}
+	for len(data) > 0 {
+		size := min(len(data), maxRecordChunkSize)
+		var hash uint64
+		for i := minRecordChunkSize; i < size; i++ {
+			hash = (hash << 1) + recordChunkGear[data[i]]
+			if hash>>(64-recordChunkBits) == 0 {
+				size = i + 1
+				break
+			}
+		}
+		chunks = append(chunks, data[:size])
+		data = data[size:]
+	}
+	return chunks
+}
+
+// Split a serialized record into chunks and store each one compressed, returning the hashes of its chunks in order
+func (s *recordStore) putCheckpoint(codec *recordCodec, data []byte) ([]string, error) {
+	hashes := []string{}
+	for _, chunk := range splitRecordChunks(data) {
+		encoded, err := codec.encode(chunk)
+		if err != nil {
+			return nil, err
+		}
+		hash, err := s.putBlob(encoded)
+		if err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, hash)
+	}
+	return hashes, nil
+}
+
+// Get a checkpoint's chunks, making sure each one still matches its hash, and join them back into the serialized record
+func (s *recordStore) getCheckpoint(codec *recordCodec, checkpoint recordManifestEntry) ([]byte, error) {
+	if len(checkpoint.Chunks) == 0 {
+		return nil, fmt.Errorf("checkpoint doesn't have any chunks")
+	}
+	data := []byte{}
+	for _, hash := range checkpoint.Chunks {
+		compressedBytes, err := s.getBlob(hash)
+		if err != nil {
+			return nil, err
+		}
+		chunk, err := codec.decode(compressedBytes)
+		if err != nil {
+			return nil, fmt.Errorf("error decompressing record blob [%s]: %w", hash, err)
+		}
+		data = append(data, chunk...)
+	}
+	return data, nil
+}
+
+// Get the blobs that aren't referenced by any checkpoint in the manifest
+func (s *recordStore) getUnusedBlobs(manifest *recordManifest) ([]string, error) {
+	referenced := map[string]bool{}
+	for _, checkpoint := range manifest.Checkpoints {
+		for _, hash := range checkpoint.Chunks {
+			referenced[hash] = true
+		}
+	}
+
+	entries, err := os.ReadDir(s.blobsPath)
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading rolling record blobs folder: %w", err)
+	}
+	unused := []string{}
+	for _, entry := range entries {
+		// Interrupted writes are left for the startup cleanup
+		if entry.IsDir() || referenced[entry.Name()] || strings.HasSuffix(entry.Name(), files.TempFileSuffix) {
+			continue
+		}
+		unused = append(unused, entry.Name())
+	}
+	return unused, nil
+}
+
+// Remove the blobs that aren't referenced by any checkpoint in the manifest, returning the hashes of the removed blobs
+func (s *recordStore) collectGarbage(manifest *recordManifest) ([]string, error) {
+	unused, err := s.getUnusedBlobs(manifest)
+	if err != nil {
+		return nil, err
+	}
+	removed := []string{}
+	for _, hash := range unused {
+		err = os.Remove(s.blobPath(hash))
+		if err != nil {
+			return removed, fmt.Errorf("error removing record blob [%s]: %w", hash, err)
+		}
+		removed = append(removed, hash)
+	}
+	return removed, nil
+}
+
+// Remove the record stores for intervals that are too old to be needed anymore, returning the intervals that were removed
+func pruneRecordIntervals(cfg *config.RocketPoolConfig, currentInterval uint64) ([]uint64, error) {
+	recordStoreLock.Lock()
+	defer recordStoreLock.Unlock()
+
+	intervals, err := getRecordIntervals(cfg)
+	if err != nil {
+		return nil, err
+	}
+	removed := []uint64{}
+	for _, interval := range intervals {
+		if interval+recordIntervalsRetained > currentInterval {
+			continue
+		}
+		err = os.RemoveAll(cfg.Smartnode.GetRecordsIntervalPath(interval))
+		if err != nil {
+			return removed, fmt.Errorf("error removing records for interval %d: %w", interval, err)
+		}
+		removed = append(removed, interval)
+	}
+	return removed, nil
+}
+
+// Get the store used before the records were split by interval, which held every interval's checkpoints in the records folder itself
+func getUnsplitRecordStore(cfg *config.RocketPoolConfig) *recordStore {
+	recordsPath := cfg.Smartnode.GetRecordsPath()
+	return &recordStore{
+		manifestPath: filepath.Join(recordsPath, config.RecordManifestFilename),
+		blobsPath:    filepath.Join(recordsPath, config.RecordBlobsFolder),
+	}
+}
+
+// A checkpoint saved in one of the older layouts of the records folder
+type legacyCheckpoint struct {
+	name string
+	path string
+	hash string
+	slot uint64
+}
+
+// Move the checkpoints saved in the older layouts of the records folder into the record stores of their intervals, then remove the old files.
+// That's the checksum table with a file per checkpoint, and the single store shared by every interval.
+// Checkpoints that are missing or don't match their checksum are dropped, just like they'd be skipped when loading.
+func (r *RollingRecordManager) migrateRecordsToStore() error {
+	recordStoreLock.Lock()
+	defer recordStoreLock.Unlock()
+
+	recordsPath := r.cfg.Smartnode.GetRecordsPath()
+	checkpoints := []legacyCheckpoint{}
+	oldPaths := []string{}
+
+	// Get the checkpoints in the checksum table
+	tableExists, lines, err := r.parseChecksumFile()
+	if err != nil {
+		return fmt.Errorf("error parsing checkpoint file: %w", err)
+	}
+	if tableExists {
+		for _, line := range lines {
+			checksumString, filename, slot, err := r.parseChecksumEntry(line)
+			if err != nil {
+				r.log.Printlnf("%s WARNING: skipping checkpoint entry during migration: %s", r.logPrefix, err.Error())
+				continue
+			}
+			path := filepath.Join(recordsPath, filename)
+			checkpoints = append(checkpoints, legacyCheckpoint{
+				name: filename,
+				path: path,
+				hash: checksumString,
+				slot: slot,
+			})
+			oldPaths = append(oldPaths, path)
+		}
+		oldPaths = append(oldPaths, filepath.Join(recordsPath, config.ChecksumTableFilename))
+	}
+
+	// Get the checkpoints in the shared store
+	unsplitStore := getUnsplitRecordStore(r.cfg)
+	unsplitManifest, unsplitExists, err := unsplitStore.loadManifest()
+	if err != nil {
+		return err
+	}
+	if unsplitExists {
+		// The shared store predates chunking, so each of its checkpoints is a single blob
+		for _, checkpoint := range unsplitManifest.Checkpoints {
+			if len(checkpoint.Chunks) != 1 {
+				continue
+			}
+			checkpoints = append(checkpoints, legacyCheckpoint{
+				name: fmt.Sprintf(recordsFilenameFormat, checkpoint.Slot, checkpoint.Epoch),
+				path: unsplitStore.blobPath(checkpoint.Chunks[0]),
+				hash: checkpoint.Chunks[0],
+				slot: checkpoint.Slot,
+			})
+		}
+		oldPaths = append(oldPaths, unsplitStore.manifestPath, unsplitStore.blobsPath)
+	}
+	if !tableExists && !unsplitExists {
+		return nil
+	}
+
+	// Add each checkpoint to the store for its interval
+	stores := map[uint64]*recordStore{}
+	manifests := map[uint64]*recordManifest{}
+	migrated := 0
+	for _, checkpoint := range checkpoints {
+		data, err := os.ReadFile(checkpoint.path)
+		if err != nil {
+			r.log.Printlnf("%s WARNING: skipping checkpoint [%s] during migration: %s", r.logPrefix, checkpoint.name, err.Error())
+			continue
+		}
+		checksum := sha512.Sum384(data)
+		if hex.EncodeToString(checksum[:]) != checkpoint.hash {
+			r.log.Printlnf("%s WARNING: skipping checkpoint [%s] during migration because it doesn't match its checksum.", r.logPrefix, checkpoint.name)
+			continue
+		}
+
+		// Get the interval it's for
+		serializedRecord, err := r.codec.decode(data)
+		if err != nil {
+			r.log.Printlnf("%s WARNING: skipping checkpoint [%s] during migration because it couldn't be decompressed: %s", r.logPrefix, checkpoint.name, err.Error())
+			continue
+		}
+		var header struct {
+			StartSlot       uint64 `json:"startSlot"`
+			RewardsInterval uint64 `json:"rewardsInterval"`
+		}
+		err = json.Unmarshal(serializedRecord, &header)
+		if err != nil {
+			r.log.Printlnf("%s WARNING: skipping checkpoint [%s] during migration because it couldn't be deserialized: %s", r.logPrefix, checkpoint.name, err.Error())
+			continue
+		}
+		store, exists := stores[header.RewardsInterval]
+		if !exists {
+			store = newRecordStore(r.cfg, header.RewardsInterval)
+			manifest, _, err := store.loadManifest()
+			if err != nil {
+				return err
+			}
+			stores[header.RewardsInterval] = store
+			manifests[header.RewardsInterval] = manifest
+		}
+		manifest := manifests[header.RewardsInterval]
+		if len(manifest.Checkpoints) == 0 {
+			manifest.StartSlot = header.StartSlot
+		} else if manifest.StartSlot != header.StartSlot {
+			r.log.Printlnf("%s WARNING: skipping checkpoint [%s] during migration because it starts on slot %d instead of %d.", r.logPrefix, checkpoint.name, header.StartSlot, manifest.StartSlot)
+			continue
+		}
+
+		chunks, err := store.putCheckpoint(r.codec, serializedRecord)
+		if err != nil {
+			return err
+		}
+		manifest.setCheckpoint(recordManifestEntry{
+			Slot:   checkpoint.slot,
+			Epoch:  checkpoint.slot / r.beaconCfg.SlotsPerEpoch,
+			Chunks: chunks,
+		})
+		migrated++
+	}
+
+	// Save the manifests before removing anything, so an interrupted migration just runs again on the next start
+	for interval, store := range stores {
+		err = store.saveManifest(manifests[interval])
+		if err != nil {
+			return err
+		}
+	}
+	for _, path := range oldPaths {
+		err = os.RemoveAll(path)
+		if err != nil {
+			return fmt.Errorf("error removing migrated checkpoint [%s]: %w", path, err)
+		}
+	}
+
+	r.log.Printlnf("%s Migrated %d checkpoints into the record stores for %d intervals.", r.logPrefix, migrated, len(stores))
+	return nil
+}
@@ -3,6 +3,7 @@ package rewards
 import (
 	"fmt"
 
+	"github.com/rocket-pool/smartnode/shared/services/config"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 )
 
@@ -14,7 +15,10 @@ type rewardsIntervalInfo struct {
 	generator             treeGeneratorImpl
 }
 
-func (r *rewardsIntervalInfo) GetStartInterval(network cfgtypes.Network) (uint64, error) {
+// Get the first interval this ruleset applies to on the selected network.
+// The built-in networks use the intervals above; additional networks get them from their descriptors.
+func (r *rewardsIntervalInfo) GetStartInterval(cfg *config.RocketPoolConfig) (uint64, error) {
+	network := cfg.Smartnode.Network.Value.(cfgtypes.Network)
 	switch network {
 	case cfgtypes.Network_Mainnet:
 		return r.mainnetStartInterval, nil
@@ -22,7 +26,11 @@ func (r *rewardsIntervalInfo) GetStartInterval(network cfgtypes.Network) (uint64
 		return r.devnetStartInterval, nil
 	case cfgtypes.Network_Holesky:
 		return r.holeskyStartInterval, nil
-	default:
+	}
+
+	descriptor := cfg.GetNetworkDescriptor(network)
+	if descriptor == nil {
 		return 0, fmt.Errorf("unknown network: %s", string(network))
 	}
+	return descriptor.RewardsRulesetStartIntervals[r.rewardsRulesetVersion], nil
 }
@@ -1,14 +1,11 @@
 package rewards
 
 import (
-	"bytes"
-	"crypto/sha512"
-	"encoding/hex"
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
-	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -20,6 +17,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
@@ -48,9 +46,9 @@ type RollingRecordManager struct {
 
 	beaconCfg            beacon.Eth2Config
 	genesisTime          time.Time
-	compressor           *zstd.Encoder
-	decompressor         *zstd.Decoder
+	codec                *recordCodec
 	recordsFilenameRegex *regexp.Regexp
+	progressHandler      func(CatchUpProgress)
 }
 
 // Creates a new manager for rolling records.
@@ -58,16 +56,6 @@ func NewRollingRecordManager(log *log.ColorLogger, errLog *log.ColorLogger, cfg
 	// Get the Beacon genesis time
 	genesisTime := time.Unix(int64(beaconCfg.GenesisTime), 0)
 
-	// Create the zstd compressor and decompressor
-	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedBestCompression))
-	if err != nil {
-		return nil, fmt.Errorf("error creating zstd compressor for rolling record manager: %w", err)
-	}
-	decoder, err := zstd.NewReader(nil)
-	if err != nil {
-		return nil, fmt.Errorf("error creating zstd decompressor for rolling record manager: %w", err)
-	}
-
 	// Create the records filename regex
 	recordsFilenameRegex := regexp.MustCompile(recordsFilenamePattern)
 
@@ -85,9 +73,14 @@ func NewRollingRecordManager(log *log.ColorLogger, errLog *log.ColorLogger, cfg
 		return nil, fmt.Errorf("rolling records folder location exists (%s), but is not a folder", recordsPath)
 	}
 
+	// Create the checkpoint codec
+	codec, err := newRecordCodec(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	logPrefix := "[Rolling Record]"
-	log.Printlnf("%s Created Rolling Record manager for start slot %d.", logPrefix, startSlot)
-	return &RollingRecordManager{
+	manager := &RollingRecordManager{
 		Record: NewRollingRecord(log, logPrefix, bc, startSlot, &beaconCfg, rewardsInterval),
 
 		log:                  log,
@@ -100,14 +93,22 @@ func NewRollingRecordManager(log *log.ColorLogger, errLog *log.ColorLogger, cfg
 		startSlot:            startSlot,
 		beaconCfg:            beaconCfg,
 		genesisTime:          genesisTime,
-		compressor:           encoder,
-		decompressor:         decoder,
+		codec:                codec,
 		recordsFilenameRegex: recordsFilenameRegex,
-	}, nil
+	}
+
+	// Move checkpoints saved in the older layouts into the record stores
+	err = manager.migrateRecordsToStore()
+	if err != nil {
+		log.Printlnf("%s WARNING: couldn't migrate the saved checkpoints into the record store, will try again on the next start: %s", logPrefix, err.Error())
+	}
+
+	log.Printlnf("%s Created Rolling Record manager for start slot %d.", logPrefix, startSlot)
+	return manager, nil
 }
 
 // Generate a new record for the provided slot using the latest viable saved record
-func (r *RollingRecordManager) GenerateRecordForState(state *state.NetworkState) (*RollingRecord, error) {
+func (r *RollingRecordManager) GenerateRecordForState(ctx context.Context, state *state.NetworkState) (*RollingRecord, error) {
 	// Load the latest viable record
 	slot := state.BeaconSlotNumber
 	rewardsInterval := state.NetworkDetails.RewardIndex
@@ -126,7 +127,7 @@ func (r *RollingRecordManager) GenerateRecordForState(state *state.NetworkState)
 	}
 
 	// Update to the target slot
-	err = r.UpdateRecordToState(state, slot)
+	err = r.UpdateRecordToState(ctx, state, slot)
 	if err != nil {
 		return nil, fmt.Errorf("error updating record to slot %d: %w", slot, err)
 	}
@@ -134,7 +135,7 @@ func (r *RollingRecordManager) GenerateRecordForState(state *state.NetworkState)
 	return record, nil
 }
 
-// Save the rolling record to a file and update the record info catalog
+// Save the rolling record to the record store for its interval and add it to the manifest
 func (r *RollingRecordManager) SaveRecordToFile(record *RollingRecord) error {
 
 	// Serialize the record
@@ -143,113 +144,102 @@ func (r *RollingRecordManager) SaveRecordToFile(record *RollingRecord) error {
 		return fmt.Errorf("error saving rolling record: %w", err)
 	}
 
-	// Compress the record
-	compressedBytes := r.compressor.EncodeAll(bytes, make([]byte, 0, len(bytes)))
-
-	// Get the record filename
-	slot := record.LastDutiesSlot
-	epoch := record.LastDutiesSlot / r.beaconCfg.SlotsPerEpoch
-	recordsPath := r.cfg.Smartnode.GetRecordsPath()
-	filename := filepath.Join(recordsPath, fmt.Sprintf(recordsFilenameFormat, slot, epoch))
-
-	// Write it to a file
-	err = os.WriteFile(filename, compressedBytes, 0664)
+	// Compress the record's chunks and store them; the lock keeps the blobs from being collected before they're in the manifest
+	store := newRecordStore(r.cfg, record.RewardsInterval)
+	recordStoreLock.Lock()
+	defer recordStoreLock.Unlock()
+	chunks, err := store.putCheckpoint(r.codec, bytes)
 	if err != nil {
-		return fmt.Errorf("error writing file [%s]: %w", filename, err)
+		return fmt.Errorf("error saving rolling record: %w", err)
 	}
 
-	// Compute the SHA384 hash to act as a checksum
-	checksum := sha512.Sum384(compressedBytes)
-
-	// Load the existing checksum table
-	_, lines, err := r.parseChecksumFile()
+	// Add it to the manifest, replacing any checkpoints from a record with a different start slot since they can't be used anymore
+	manifest, _, err := store.loadManifest()
 	if err != nil {
-		return fmt.Errorf("error parsing checkpoint file: %w", err)
+		return err
 	}
-	if lines == nil {
-		lines = []string{}
+	if manifest.StartSlot != record.StartSlot {
+		if len(manifest.Checkpoints) > 0 {
+			r.log.Printlnf("%s Replacing the %d checkpoints for interval %d that started on slot %d, since the record now starts on slot %d.", r.logPrefix, len(manifest.Checkpoints), record.RewardsInterval, manifest.StartSlot, record.StartSlot)
+		}
+		manifest.StartSlot = record.StartSlot
+		manifest.Checkpoints = []recordManifestEntry{}
 	}
+	slot := record.LastDutiesSlot
+	manifest.setCheckpoint(recordManifestEntry{
+		Slot:   slot,
+		Epoch:  slot / r.beaconCfg.SlotsPerEpoch,
+		Chunks: chunks,
+	})
 
-	// Add the new record checksum
-	baseFilename := filepath.Base(filename)
-	checksumLine := fmt.Sprintf("%s  %s", hex.EncodeToString(checksum[:]), baseFilename)
-
-	// Sort the lines by their slot
-	err = r.sortChecksumEntries(lines)
-	if err != nil {
-		return fmt.Errorf("error sorting checkpoint file entries: %w", err)
+	// Drop the oldest checkpoints that shouldn't be retained
+	checkpointRetentionLimit := int(r.cfg.Smartnode.CheckpointRetentionLimit.Value.(uint64))
+	if len(manifest.Checkpoints) > checkpointRetentionLimit {
+		cullCount := len(manifest.Checkpoints) - checkpointRetentionLimit
+		for _, checkpoint := range manifest.Checkpoints[:cullCount] {
+			r.log.Printlnf("%s Removed checkpoint for slot %d based on the retention limit.", r.logPrefix, checkpoint.Slot)
+		}
+		manifest.Checkpoints = manifest.Checkpoints[cullCount:]
 	}
 
-	overwritten := false
-	for i, line := range lines {
-		if strings.HasSuffix(line, baseFilename) {
-			// If there is already a line with the filename, overwrite it
-			lines[i] = checksumLine
-			overwritten = true
-			break
-		}
+	// Save the manifest, then remove the blobs that no checkpoint uses anymore
+	err = store.saveManifest(manifest)
+	if err != nil {
+		return err
 	}
-	if !overwritten {
-		// If there's no existing lines, add this to the end
-		lines = append(lines, checksumLine)
+	removed, err := store.collectGarbage(manifest)
+	if err != nil {
+		r.log.Printlnf("%s WARNING: couldn't remove unused record blobs: %s", r.logPrefix, err.Error())
+	} else if len(removed) > 0 {
+		r.log.Printlnf("%s Removed %d record blobs that are no longer used.", r.logPrefix, len(removed))
 	}
 
-	// Get the number of lines to write
-	checkpointRetentionLimit := r.cfg.Smartnode.CheckpointRetentionLimit.Value.(uint64)
-	var newLines []string
-	if len(lines) > int(checkpointRetentionLimit) {
-		numberOfNewLines := int(checkpointRetentionLimit)
-		cullCount := len(lines) - numberOfNewLines
-
-		// Remove old lines and delete the corresponding files that shouldn't be retained
-		for i := 0; i < cullCount; i++ {
-			line := lines[i]
-
-			// Extract the filename
-			elems := strings.Split(line, "  ")
-			if len(elems) != 2 {
-				return fmt.Errorf("error parsing checkpoint line (%s): expected 2 elements, but got %d", line, len(elems))
-			}
-			filename := elems[1]
-			fullFilename := filepath.Join(recordsPath, filename)
-
-			// Delete the file if it exists
-			_, err := os.Stat(fullFilename)
-			if os.IsNotExist(err) {
-				r.log.Printlnf("%s NOTE: tried removing checkpoint file [%s] based on the retention limit, but it didn't exist.", r.logPrefix, filename)
-				continue
-			}
-			err = os.Remove(fullFilename)
-			if err != nil {
-				return fmt.Errorf("error deleting file [%s]: %w", fullFilename, err)
-			}
+	// Train a compression dictionary once there are enough checkpoints to learn from
+	r.trainDictionaryIfNeeded(store, manifest.Checkpoints)
 
-			r.log.Printlnf("%s Removed checkpoint file [%s] based on the retention limit.", r.logPrefix, filename)
-		}
+	return nil
+}
 
-		// Store the rest
-		newLines = make([]string, numberOfNewLines)
-		for i := cullCount; i <= numberOfNewLines; i++ {
-			newLines[i-cullCount] = lines[i]
+// Train a zstd dictionary from the latest checkpoints if dictionaries are enabled and there isn't one yet, then switch the codec over to it.
+// Failures here only cost compression ratio, so they're logged instead of returned.
+func (r *RollingRecordManager) trainDictionaryIfNeeded(store *recordStore, checkpoints []recordManifestEntry) {
+	if r.codec.hasDictionary ||
+		r.codec.codec != cfgtypes.RecordCodec_Zstd ||
+		!r.cfg.Smartnode.UseRecordCompressionDictionary.Value.(bool) ||
+		len(checkpoints) < minDictionarySamples {
+		return
+	}
+
+	// Get the serialized records of the latest checkpoints
+	samples := [][]byte{}
+	for i := len(checkpoints) - 1; i >= 0 && len(samples) < maxDictionarySamples; i-- {
+		slot := checkpoints[i].Slot
+		sample, err := store.getCheckpoint(r.codec, checkpoints[i])
+		if err != nil {
+			r.log.Printlnf("%s WARNING: couldn't read checkpoint for slot %d for dictionary training: %s", r.logPrefix, slot, err.Error())
+			return
 		}
-	} else {
-		newLines = lines
+		samples = append(samples, sample)
 	}
 
-	fileContents := strings.Join(newLines, "\n")
-	checksumBytes := []byte(fileContents)
-
-	// Save the new file
-	checksumFilename := filepath.Join(recordsPath, config.ChecksumTableFilename)
-	err = os.WriteFile(checksumFilename, checksumBytes, 0644)
+	level := zstd.EncoderLevelFromZstd(int(r.cfg.Smartnode.RecordCompressionLevel.Value.(uint64)))
+	path, err := trainRecordDictionary(r.cfg.Smartnode.GetRecordsPath(), samples, level)
 	if err != nil {
-		return fmt.Errorf("error writing checksum file after culling: %w", err)
+		r.log.Printlnf("%s WARNING: %s", r.logPrefix, err.Error())
+		return
 	}
 
-	return nil
+	// Reload the codec so new checkpoints use the dictionary
+	codec, err := newRecordCodec(r.cfg)
+	if err != nil {
+		r.log.Printlnf("%s WARNING: couldn't load the new record dictionary: %s", r.logPrefix, err.Error())
+		return
+	}
+	r.codec = codec
+	r.log.Printlnf("%s Trained a compression dictionary from %d checkpoints and saved it to [%s].", r.logPrefix, len(samples), path)
 }
 
-// Load the most recent appropriate rolling record from disk, using the checksum table as an index
+// Load the most recent appropriate rolling record from disk, using the record manifest as an index
 func (r *RollingRecordManager) LoadBestRecordFromDisk(startSlot uint64, targetSlot uint64, rewardsInterval uint64) (*RollingRecord, error) {
 	recordCheckpointInterval := r.cfg.Smartnode.RecordCheckpointInterval.Value.(uint64)
 	latestCompatibleVersion, err := semver.New(latestCompatibleVersionString)
@@ -257,66 +247,55 @@ func (r *RollingRecordManager) LoadBestRecordFromDisk(startSlot uint64, targetSl
 		return nil, fmt.Errorf("error parsing latest compatible version string [%s]: %w", latestCompatibleVersionString, err)
 	}
 
-	// Parse the checksum file
-	exists, lines, err := r.parseChecksumFile()
+	// Load the manifest for the interval
+	store := newRecordStore(r.cfg, rewardsInterval)
+	manifest, exists, err := store.loadManifest()
 	if err != nil {
-		return nil, fmt.Errorf("error parsing checkpoint file: %w", err)
+		return nil, err
 	}
 	if !exists {
-		// There isn't a checksum file so start over
-		r.log.Printlnf("%s Checksum file not found, creating a new record from the start of the interval.", r.logPrefix)
+		// There isn't a manifest so start over
+		r.log.Printlnf("%s Record manifest for interval %d not found, creating a new record from the start of the interval.", r.logPrefix, rewardsInterval)
 		record := NewRollingRecord(r.log, r.logPrefix, r.bc, startSlot, &r.beaconCfg, rewardsInterval)
 		r.Record = record
 		r.nextEpochToSave = startSlot/r.beaconCfg.SlotsPerEpoch + recordCheckpointInterval - 1
 		return record, nil
 	}
 
-	// Iterate over each file, counting backwards from the bottom
-	recordsPath := r.cfg.Smartnode.GetRecordsPath()
-	for i := len(lines) - 1; i >= 0; i-- {
-		line := lines[i]
-
-		// Extract the checksum, filename, and slot number
-		checksumString, filename, slot, err := r.parseChecksumEntry(line)
-		if err != nil {
-			return nil, err
-		}
+	// Iterate over each checkpoint, counting backwards from the latest
+	for i := len(manifest.Checkpoints) - 1; i >= 0; i-- {
+		checkpoint := manifest.Checkpoints[i]
+		slot := checkpoint.Slot
+		filename := fmt.Sprintf(recordsFilenameFormat, slot, checkpoint.Epoch)
 
 		// Check if the slot was too far into the future
 		if slot > targetSlot {
-			r.log.Printlnf("%s File [%s] was too far into the future, trying an older one...", r.logPrefix, filename)
+			r.log.Printlnf("%s Checkpoint [%s] was too far into the future, trying an older one...", r.logPrefix, filename)
 			continue
 		}
 
 		// Check if it was too far into the past
 		if slot < startSlot {
-			r.log.Printlnf("%s File [%s] was too old (generated before the target start slot), none of the remaining records can be used.", r.logPrefix, filename)
+			r.log.Printlnf("%s Checkpoint [%s] was too old (generated before the target start slot), none of the remaining records can be used.", r.logPrefix, filename)
 			break
 		}
 
-		// Make sure the checksum parses properly
-		checksum, err := hex.DecodeString(checksumString)
-		if err != nil {
-			return nil, fmt.Errorf("error scanning checkpoint line (%s): checksum (%s) could not be parsed", line, checksumString)
-		}
-
 		// Try to load it
-		fullFilename := filepath.Join(recordsPath, filename)
-		record, err := r.loadRecordFromFile(fullFilename, checksum)
+		record, err := r.loadRecordFromStore(store, checkpoint)
 		if err != nil {
-			r.log.Printlnf("%s WARNING: error loading record from file [%s]: %s... attempting previous file", r.logPrefix, fullFilename, err.Error())
+			r.log.Printlnf("%s WARNING: error loading checkpoint [%s]: %s... attempting previous checkpoint", r.logPrefix, filename, err.Error())
 			continue
 		}
 
 		// Check if it was for the proper interval
 		if record.RewardsInterval != rewardsInterval {
-			r.log.Printlnf("%s File [%s] was for rewards interval %d instead of %d so it cannot be used, trying an earlier checkpoint.", r.logPrefix, filename, record.RewardsInterval, rewardsInterval)
+			r.log.Printlnf("%s Checkpoint [%s] was for rewards interval %d instead of %d so it cannot be used, trying an earlier checkpoint.", r.logPrefix, filename, record.RewardsInterval, rewardsInterval)
 			continue
 		}
 
 		// Check if it has the proper start slot
 		if record.StartSlot != startSlot {
-			r.log.Printlnf("%s File [%s] started on slot %d instead of %d so it cannot be used, trying an earlier checkpoint.", r.logPrefix, filename, record.StartSlot, startSlot)
+			r.log.Printlnf("%s Checkpoint [%s] started on slot %d instead of %d so it cannot be used, trying an earlier checkpoint.", r.logPrefix, filename, record.StartSlot, startSlot)
 			continue
 		}
 
@@ -327,24 +306,24 @@ func (r *RollingRecordManager) LoadBestRecordFromDisk(startSlot uint64, targetSl
 		}
 		recordVersion, err := semver.New(recordVersionString)
 		if err != nil {
-			r.log.Printlnf("%s Failed to parse the version info for file [%s] so it cannot be used, trying an earlier checkpoint.", r.logPrefix, filename)
+			r.log.Printlnf("%s Failed to parse the version info for checkpoint [%s] so it cannot be used, trying an earlier checkpoint.", r.logPrefix, filename)
 			continue
 		}
 		if recordVersion.LT(*latestCompatibleVersion) {
-			r.log.Printlnf("%s File [%s] was made with Smartnode v%s which is not compatible (lowest compatible = v%s) so it cannot be used, trying an earlier checkpoint.", r.logPrefix, filename, recordVersionString, latestCompatibleVersionString)
+			r.log.Printlnf("%s Checkpoint [%s] was made with Smartnode v%s which is not compatible (lowest compatible = v%s) so it cannot be used, trying an earlier checkpoint.", r.logPrefix, filename, recordVersionString, latestCompatibleVersionString)
 			continue
 		}
 
 		epoch := slot / r.beaconCfg.SlotsPerEpoch
-		r.log.Printlnf("%s Loaded file [%s] which ended on slot %d (epoch %d) for rewards interval %d.", r.logPrefix, filename, slot, epoch, record.RewardsInterval)
+		r.log.Printlnf("%s Loaded checkpoint [%s] which ended on slot %d (epoch %d) for rewards interval %d.", r.logPrefix, filename, slot, epoch, record.RewardsInterval)
 		r.Record = record
 		r.nextEpochToSave = record.LastDutiesSlot/r.beaconCfg.SlotsPerEpoch + recordCheckpointInterval
 		return record, nil
 
 	}
 
-	// If we got here then none of the saved files worked so we have to make a new record
-	r.log.Printlnf("%s None of the saved record checkpoint files were eligible for use, creating a new record from the start of the interval.", r.logPrefix)
+	// If we got here then none of the saved checkpoints worked so we have to make a new record
+	r.log.Printlnf("%s None of the saved record checkpoints were eligible for use, creating a new record from the start of the interval.", r.logPrefix)
 	record := NewRollingRecord(r.log, r.logPrefix, r.bc, startSlot, &r.beaconCfg, rewardsInterval)
 	r.Record = record
 	r.nextEpochToSave = startSlot/r.beaconCfg.SlotsPerEpoch + recordCheckpointInterval - 1
@@ -352,10 +331,17 @@ func (r *RollingRecordManager) LoadBestRecordFromDisk(startSlot uint64, targetSl
 
 }
 
-// Updates the manager's record to the provided state, retrying upon errors until success
-func (r *RollingRecordManager) UpdateRecordToState(state *state.NetworkState, latestFinalizedSlot uint64) error {
-	err := r.updateImpl(state, latestFinalizedSlot)
+// Updates the manager's record to the provided state, retrying upon errors until success.
+// If the context is cancelled, the update stops at the next checkpoint it can safely save.
+func (r *RollingRecordManager) UpdateRecordToState(ctx context.Context, state *state.NetworkState, latestFinalizedSlot uint64) error {
+	err := r.updateImpl(ctx, state, latestFinalizedSlot)
 	if err != nil {
+		if ctx.Err() != nil {
+			// Everything up to the last checkpoint is already on disk; a partly processed chunk is redone from there next time
+			r.log.Printlnf("%s Stopped updating the rolling record at slot %d.", r.logPrefix, r.Record.LastDutiesSlot)
+			return err
+		}
+
 		// Revert to the latest saved state
 		r.log.Printlnf("%s WARNING: failed to update rolling record to slot %d, block %d: %s", r.logPrefix, state.BeaconSlotNumber, state.ElBlockNumber, err.Error())
 		r.log.Printlnf("%s Reverting to the last saved checkpoint to prevent corruption...", r.logPrefix)
@@ -373,7 +359,7 @@ func (r *RollingRecordManager) UpdateRecordToState(state *state.NetworkState, la
 }
 
 // Updates the manager's record to the provided state
-func (r *RollingRecordManager) updateImpl(state *state.NetworkState, latestFinalizedSlot uint64) error {
+func (r *RollingRecordManager) updateImpl(ctx context.Context, state *state.NetworkState, latestFinalizedSlot uint64) error {
 	var err error
 	r.log.Printlnf("Updating record to target slot %d...", latestFinalizedSlot)
 
@@ -390,7 +376,7 @@ func (r *RollingRecordManager) updateImpl(state *state.NetworkState, latestFinal
 	finalTarget := latestFinalizedSlot
 	finalizedState := state
 	if finalTarget != state.BeaconSlotNumber {
-		finalizedState, err = r.mgr.GetStateForSlot(finalTarget)
+		finalizedState, err = r.mgr.GetStateForSlot(ctx, finalTarget)
 		if err != nil {
 			return fmt.Errorf("error getting state for latest finalized slot (%d): %w", finalTarget, err)
 		}
@@ -419,13 +405,32 @@ func (r *RollingRecordManager) updateImpl(state *state.NetworkState, latestFinal
 
 	r.log.Printlnf("%s Collecting records from slot %d (epoch %d) to slot %d (epoch %d).", r.logPrefix, nextStartSlot, nextStartEpoch, finalTarget, finalEpoch)
 	startTime := time.Now()
+	progressInterval := time.Duration(r.cfg.Smartnode.CatchUpProgressInterval.Value.(uint64)) * time.Second
+	tracker := newCatchUpTracker(r.Record.RewardsInterval, nextStartEpoch, finalEpoch, progressInterval, r.reportCatchUpProgress)
+	r.Record.progress = tracker
+	defer func() {
+		r.Record.progress = nil
+	}()
+	savedSlot := uint64(0)
 	for {
 		if nextStartSlot > finalTarget {
 			break
 		}
 
+		// Stop between chunks if the daemon is shutting down, saving the progress since the last checkpoint
+		if ctx.Err() != nil {
+			if r.Record.LastDutiesSlot > 0 && r.Record.LastDutiesSlot != savedSlot {
+				err = r.SaveRecordToFile(r.Record)
+				if err != nil {
+					return fmt.Errorf("error saving record before stopping: %w", err)
+				}
+				r.log.Printlnf("%s Saved record checkpoint at slot %d before stopping.", r.logPrefix, r.Record.LastDutiesSlot)
+			}
+			return fmt.Errorf("record update was stopped: %w", ctx.Err())
+		}
+
 		// Update the record to the target state
-		err = r.Record.UpdateToSlot(nextTargetSlot, finalizedState)
+		err = r.Record.UpdateToSlot(ctx, nextTargetSlot, finalizedState)
 		if err != nil {
 			return fmt.Errorf("error updating rolling record to slot %d, block %d: %w", state.BeaconSlotNumber, state.ElBlockNumber, err)
 		}
@@ -439,6 +444,7 @@ func (r *RollingRecordManager) updateImpl(state *state.NetworkState, latestFinal
 				return fmt.Errorf("error saving record: %w", err)
 			}
 			r.log.Printlnf("%s Saved record checkpoint.", r.logPrefix)
+			savedSlot = r.Record.LastDutiesSlot
 			r.nextEpochToSave += recordCheckpointInterval // Set the next epoch to save 1 checkpoint in the future
 		}
 
@@ -455,6 +461,8 @@ func (r *RollingRecordManager) updateImpl(state *state.NetworkState, latestFinal
 		}
 	}
 
+	tracker.finish()
+
 	// Log the update
 	startEpoch := r.Record.StartSlot / r.beaconCfg.SlotsPerEpoch
 	currentEpoch := r.Record.LastDutiesSlot / r.beaconCfg.SlotsPerEpoch
@@ -463,15 +471,36 @@ func (r *RollingRecordManager) updateImpl(state *state.NetworkState, latestFinal
 	return nil
 }
 
+// Set a function to call each time the progress of a record update is reported, in addition to logging it
+func (r *RollingRecordManager) SetCatchUpProgressHandler(handler func(CatchUpProgress)) {
+	r.progressHandler = handler
+}
+
+// Log the progress of a record update and pass it to the handler
+func (r *RollingRecordManager) reportCatchUpProgress(progress CatchUpProgress) {
+	if progress.Active {
+		bottleneck := "unknown"
+		if progress.Bottleneck != CatchUpBottleneck_None {
+			bottleneck = string(progress.Bottleneck)
+		}
+		r.log.Printlnf("%s Catch-up progress: epoch %d of %d (%d done, %d remaining), %.2f epochs/min, ETA %s, bottleneck: %s (Beacon Node %s, processing %s).",
+			r.logPrefix, progress.CurrentEpoch, progress.TargetEpoch, progress.EpochsDone, progress.EpochsRemaining, progress.EpochsPerMinute,
+			progress.Eta.Format(time.RFC1123), bottleneck, progress.BeaconTime.Round(time.Second), progress.ProcessingTime.Round(time.Second))
+	}
+	if r.progressHandler != nil {
+		r.progressHandler(progress)
+	}
+}
+
 // Prepares the record for a rewards interval report
-func (r *RollingRecordManager) PrepareRecordForReport(state *state.NetworkState) error {
+func (r *RollingRecordManager) PrepareRecordForReport(ctx context.Context, state *state.NetworkState) error {
 	rewardsSlot := state.BeaconSlotNumber
 
 	// Check if the current record has gone past the requested slot or if it can be updated / used
 	if rewardsSlot < r.Record.LastDutiesSlot {
 		r.log.Printlnf("%s Current record has extended too far (need slot %d, but record has processed slot %d)... reverting to a previous checkpoint.", r.logPrefix, rewardsSlot, r.Record.LastDutiesSlot)
 
-		newRecord, err := r.GenerateRecordForState(state)
+		newRecord, err := r.GenerateRecordForState(ctx, state)
 		if err != nil {
 			return fmt.Errorf("error creating record for rewards slot: %w", err)
 		}
@@ -479,7 +508,7 @@ func (r *RollingRecordManager) PrepareRecordForReport(state *state.NetworkState)
 		r.Record = newRecord
 	} else {
 		r.log.Printlnf("%s Current record can be used (need slot %d, record has only processed slot %d), updating to target slot.", r.logPrefix, rewardsSlot, r.Record.LastDutiesSlot)
-		err := r.UpdateRecordToState(state, rewardsSlot)
+		err := r.UpdateRecordToState(ctx, state, rewardsSlot)
 		if err != nil {
 			return fmt.Errorf("error updating record to rewards slot: %w", err)
 		}
@@ -488,6 +517,59 @@ func (r *RollingRecordManager) PrepareRecordForReport(state *state.NetworkState)
 	return nil
 }
 
+// Get the slot of the latest rolling record checkpoint saved to disk, without loading it.
+// Returns false if no checkpoints have been saved yet.
+func GetLatestRecordSlot(cfg *config.RocketPoolConfig) (uint64, bool, error) {
+	r := &RollingRecordManager{
+		cfg:                  cfg,
+		recordsFilenameRegex: regexp.MustCompile(recordsFilenamePattern),
+	}
+
+	// Use the newest interval that has a checkpoint
+	intervals, err := getRecordIntervals(cfg)
+	if err != nil {
+		return 0, false, err
+	}
+	for i := len(intervals) - 1; i >= 0; i-- {
+		manifest, _, err := newRecordStore(cfg, intervals[i]).loadManifest()
+		if err != nil {
+			return 0, false, err
+		}
+		if len(manifest.Checkpoints) > 0 {
+			return manifest.Checkpoints[len(manifest.Checkpoints)-1].Slot, true, nil
+		}
+	}
+
+	// The watchtower hasn't migrated the checkpoints into the record stores yet
+	manifest, exists, err := getUnsplitRecordStore(cfg).loadManifest()
+	if err != nil {
+		return 0, false, err
+	}
+	if exists {
+		if len(manifest.Checkpoints) == 0 {
+			return 0, false, nil
+		}
+		return manifest.Checkpoints[len(manifest.Checkpoints)-1].Slot, true, nil
+	}
+	exists, lines, err := r.parseChecksumFile()
+	if err != nil {
+		return 0, false, err
+	}
+	if !exists || len(lines) == 0 {
+		return 0, false, nil
+	}
+
+	var latestSlot uint64
+	for _, line := range lines {
+		_, _, slot, err := r.parseChecksumEntry(line)
+		if err != nil {
+			return 0, false, err
+		}
+		latestSlot = max(latestSlot, slot)
+	}
+	return latestSlot, true, nil
+}
+
 // Get the slot number from a record filename
 func (r *RollingRecordManager) getSlotFromFilename(filename string) (uint64, error) {
 	matches := r.recordsFilenameRegex.FindStringSubmatch(filename)
@@ -507,33 +589,19 @@ func (r *RollingRecordManager) getSlotFromFilename(filename string) (uint64, err
 	return slot, nil
 }
 
-// Load a record from a file, making sure its contents match the provided checksum
-func (r *RollingRecordManager) loadRecordFromFile(filename string, expectedChecksum []byte) (*RollingRecord, error) {
-	// Read the file
-	compressedBytes, err := os.ReadFile(filename)
+// Load a record from a checkpoint's chunks, making sure their contents still match their hashes
+func (r *RollingRecordManager) loadRecordFromStore(store *recordStore, checkpoint recordManifestEntry) (*RollingRecord, error) {
+	// Read and decompress the chunks
+	bytes, err := store.getCheckpoint(r.codec, checkpoint)
 	if err != nil {
-		return nil, fmt.Errorf("error reading file: %w", err)
-	}
-
-	// Calculate the hash and validate it
-	checksum := sha512.Sum384(compressedBytes)
-	if !bytes.Equal(expectedChecksum, checksum[:]) {
-		expectedString := hex.EncodeToString(expectedChecksum)
-		actualString := hex.EncodeToString(checksum[:])
-		return nil, fmt.Errorf("checksum mismatch (expected %s, but it was %s)", expectedString, actualString)
-	}
-
-	// Decompress it
-	bytes, err := r.decompressor.DecodeAll(compressedBytes, []byte{})
-	if err != nil {
-		return nil, fmt.Errorf("error decompressing data: %w", err)
+		return nil, err
 	}
 
 	// Create a new record from the data
 	return DeserializeRollingRecord(r.log, r.logPrefix, r.bc, &r.beaconCfg, bytes)
 }
 
-// Get the lines from the checksum file
+// Get the lines from the legacy checksum file, which indexed the checkpoints before the record store
 func (r *RollingRecordManager) parseChecksumFile() (bool, []string, error) {
 	// Get the checksum filename
 	recordsPath := r.cfg.Smartnode.GetRecordsPath()
@@ -566,27 +634,6 @@ func (r *RollingRecordManager) parseChecksumFile() (bool, []string, error) {
 	return true, lines, nil
 }
 
-// Sort the checksum file entries by their slot
-func (r *RollingRecordManager) sortChecksumEntries(lines []string) error {
-	var sortErr error
-	sort.Slice(lines, func(i int, j int) bool {
-		_, _, firstSlot, err := r.parseChecksumEntry(lines[i])
-		if err != nil && sortErr == nil {
-			sortErr = err
-			return false
-		}
-
-		_, _, secondSlot, err := r.parseChecksumEntry(lines[j])
-		if err != nil && sortErr == nil {
-			sortErr = err
-			return false
-		}
-
-		return firstSlot < secondSlot
-	})
-	return sortErr
-}
-
 // Get the checksum, the filename, and the slot number from a checksum entry.
 func (r *RollingRecordManager) parseChecksumEntry(line string) (string, string, uint64, error) {
 	// Extract the checksum and filename
@@ -641,5 +688,14 @@ func (r *RollingRecordManager) createNewRecord(state *state.NetworkState) error
 	recordCheckpointInterval := r.cfg.Smartnode.RecordCheckpointInterval.Value.(uint64)
 	r.nextEpochToSave = startSlot/r.beaconCfg.SlotsPerEpoch + recordCheckpointInterval - 1
 
+	// The closing interval's records are kept in case it needs to be regenerated, but anything older can go
+	removed, err := pruneRecordIntervals(r.cfg, state.NetworkDetails.RewardIndex)
+	if err != nil {
+		r.log.Printlnf("%s WARNING: couldn't remove the records for old intervals: %s", r.logPrefix, err.Error())
+	}
+	for _, interval := range removed {
+		r.log.Printlnf("%s Removed the records for interval %d.", r.logPrefix, interval)
+	}
+
 	return nil
 }
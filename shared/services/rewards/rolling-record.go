@@ -1,6 +1,7 @@
 package rewards
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"time"
@@ -11,14 +12,29 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/hardware"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"golang.org/x/sync/errgroup"
 )
 
-const (
-	threadLimit int = 12
-)
+// How many validators' attestations are processed at once; the watchtower sets this from its config
+var threadLimit int = 12
+
+// Set how many validators' attestations are processed at once
+func SetThreadLimit(limit int) {
+	if limit > 0 {
+		threadLimit = limit
+	}
+}
+
+// Throttles the attestation processing while the machine is busy; the watchtower sets this from its config
+var governor *hardware.Governor
+
+// Set the governor that throttles the attestation processing while the machine is busy
+func SetGovernor(g *hardware.Governor) {
+	governor = g
+}
 
 type RollingRecord struct {
 	StartSlot         uint64                   `json:"startSlot"`
@@ -34,6 +50,7 @@ type RollingRecord struct {
 	log                *log.ColorLogger    `json:"-"`
 	logPrefix          string              `json:"-"`
 	intervalDutiesInfo *IntervalDutiesInfo `json:"-"`
+	progress           *catchUpTracker     `json:"-"`
 
 	// Constants for convenience
 	one          *big.Int `json:"-"`
@@ -89,7 +106,7 @@ func DeserializeRollingRecord(log *log.ColorLogger, logPrefix string, bc beacon.
 
 // Update the record to the requested slot, using the provided state as a reference.
 // Requires the epoch *after* the requested slot to be finalized so it can accurately count attestations.
-func (r *RollingRecord) UpdateToSlot(slot uint64, state *state.NetworkState) error {
+func (r *RollingRecord) UpdateToSlot(ctx context.Context, slot uint64, state *state.NetworkState) error {
 
 	// Get the slot to start processing from
 	startSlot := r.LastDutiesSlot + 1
@@ -109,6 +126,10 @@ func (r *RollingRecord) UpdateToSlot(slot uint64, state *state.NetworkState) err
 
 	// Process every epoch from the start to the current one
 	for epoch := startEpoch; epoch <= stateEpoch; epoch++ {
+		// A partly processed chunk can't be saved, so it's redone from the last checkpoint
+		if err := governor.Wait(ctx); err != nil {
+			return fmt.Errorf("stopped processing at epoch %d: %w", epoch, err)
+		}
 
 		// Retrieve the duties for the epoch - this won't get duties higher than the given state
 		err := r.getDutiesForEpoch(epoch, slot, state)
@@ -121,6 +142,7 @@ func (r *RollingRecord) UpdateToSlot(slot uint64, state *state.NetworkState) err
 		if err != nil {
 			return fmt.Errorf("error processing attestations in epoch %d: %w", epoch, err)
 		}
+		r.progress.epochDone(epoch)
 
 	}
 
@@ -230,11 +252,17 @@ func (r *RollingRecord) getDutiesForEpoch(epoch uint64, endSlot uint64, state *s
 	}
 
 	// Get the attestation committees for the epoch
+	beaconStart := time.Now()
 	committees, err := r.bc.GetCommitteesForEpoch(&epoch)
 	if err != nil {
 		return fmt.Errorf("error getting committees for epoch %d: %w", epoch, err)
 	}
 	defer committees.Release()
+	processingStart := time.Now()
+	r.progress.addBeaconTime(processingStart.Sub(beaconStart))
+	defer func() {
+		r.progress.addProcessingTime(time.Since(processingStart))
+	}()
 
 	// Crawl the committees
 	for idx := 0; idx < committees.Count(); idx++ {
@@ -312,10 +340,11 @@ func (r *RollingRecord) processAttestationsInEpoch(epoch uint64, state *state.Ne
 
 	slotsPerEpoch := r.beaconConfig.SlotsPerEpoch
 	var wg errgroup.Group
-	wg.SetLimit(threadLimit)
+	wg.SetLimit(governor.Limit(threadLimit))
 	attestationsPerSlot := make([][]beacon.AttestationInfo, r.beaconConfig.SlotsPerEpoch)
 
 	// Get the attestation records for this epoch
+	beaconStart := time.Now()
 	for i := uint64(0); i < slotsPerEpoch; i++ {
 		i := i
 		slot := epoch*slotsPerEpoch + i
@@ -338,6 +367,11 @@ func (r *RollingRecord) processAttestationsInEpoch(epoch uint64, state *state.Ne
 	if err != nil {
 		return fmt.Errorf("error getting attestation records for epoch %d: %w", epoch, err)
 	}
+	processingStart := time.Now()
+	r.progress.addBeaconTime(processingStart.Sub(beaconStart))
+	defer func() {
+		r.progress.addProcessingTime(time.Since(processingStart))
+	}()
 
 	// Process all of the slots in the epoch
 	for i, attestations := range attestationsPerSlot {
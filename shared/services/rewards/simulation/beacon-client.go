@@ -0,0 +1,232 @@
+package simulation
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// A Beacon client that records the responses of another client, or replays them from a fixture
+type beaconClient struct {
+	session *session
+	client  beacon.Client
+}
+
+// A single committee from a committees response
+type recordedCommittee struct {
+	Index      uint64
+	Slot       uint64
+	Validators []string
+}
+
+// A committees response that was recorded, so it doesn't depend on the Beacon client's buffers
+type recordedCommittees struct {
+	Committees []recordedCommittee
+}
+
+func (c *recordedCommittees) Index(idx int) uint64 {
+	return c.Committees[idx].Index
+}
+
+func (c *recordedCommittees) Slot(idx int) uint64 {
+	return c.Committees[idx].Slot
+}
+
+func (c *recordedCommittees) Validators(idx int) []string {
+	return c.Committees[idx].Validators
+}
+
+func (c *recordedCommittees) Count() int {
+	return len(c.Committees)
+}
+
+func (c *recordedCommittees) Release() {
+}
+
+// The result of a lookup that might not find anything
+type foundResult[T any] struct {
+	Value T
+	Found bool
+}
+
+func (c *beaconClient) GetClientType() (beacon.BeaconClientType, error) {
+	return simCall(c.session, "GetClientType", []interface{}{}, func() (beacon.BeaconClientType, error) {
+		return c.client.GetClientType()
+	})
+}
+
+func (c *beaconClient) GetSyncStatus() (beacon.SyncStatus, error) {
+	return simCall(c.session, "GetSyncStatus", []interface{}{}, func() (beacon.SyncStatus, error) {
+		return c.client.GetSyncStatus()
+	})
+}
+
+func (c *beaconClient) GetNodeVersion() (string, error) {
+	return simCall(c.session, "GetNodeVersion", []interface{}{}, func() (string, error) {
+		return c.client.GetNodeVersion()
+	})
+}
+
+func (c *beaconClient) GetPeerCount() (uint64, error) {
+	return simCall(c.session, "GetPeerCount", []interface{}{}, func() (uint64, error) {
+		return c.client.GetPeerCount()
+	})
+}
+
+func (c *beaconClient) GetEth2Config() (beacon.Eth2Config, error) {
+	return simCall(c.session, "GetEth2Config", []interface{}{}, func() (beacon.Eth2Config, error) {
+		return c.client.GetEth2Config()
+	})
+}
+
+func (c *beaconClient) GetEth2DepositContract() (beacon.Eth2DepositContract, error) {
+	return simCall(c.session, "GetEth2DepositContract", []interface{}{}, func() (beacon.Eth2DepositContract, error) {
+		return c.client.GetEth2DepositContract()
+	})
+}
+
+func (c *beaconClient) GetAttestations(blockId string) ([]beacon.AttestationInfo, bool, error) {
+	result, err := simCall(c.session, "GetAttestations", []interface{}{blockId}, func() (foundResult[[]beacon.AttestationInfo], error) {
+		attestations, found, err := c.client.GetAttestations(blockId)
+		return foundResult[[]beacon.AttestationInfo]{Value: attestations, Found: found}, err
+	})
+	return result.Value, result.Found, err
+}
+
+func (c *beaconClient) GetBeaconBlock(blockId string) (beacon.BeaconBlock, bool, error) {
+	result, err := simCall(c.session, "GetBeaconBlock", []interface{}{blockId}, func() (foundResult[beacon.BeaconBlock], error) {
+		block, found, err := c.client.GetBeaconBlock(blockId)
+		return foundResult[beacon.BeaconBlock]{Value: block, Found: found}, err
+	})
+	return result.Value, result.Found, err
+}
+
+func (c *beaconClient) GetBeaconBlockHeader(blockId string) (beacon.BeaconBlockHeader, bool, error) {
+	result, err := simCall(c.session, "GetBeaconBlockHeader", []interface{}{blockId}, func() (foundResult[beacon.BeaconBlockHeader], error) {
+		header, found, err := c.client.GetBeaconBlockHeader(blockId)
+		return foundResult[beacon.BeaconBlockHeader]{Value: header, Found: found}, err
+	})
+	return result.Value, result.Found, err
+}
+
+func (c *beaconClient) GetBeaconHead() (beacon.BeaconHead, error) {
+	return simCall(c.session, "GetBeaconHead", []interface{}{}, func() (beacon.BeaconHead, error) {
+		return c.client.GetBeaconHead()
+	})
+}
+
+func (c *beaconClient) GetValidatorStatusByIndex(index string, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
+	return simCall(c.session, "GetValidatorStatusByIndex", []interface{}{index, opts}, func() (beacon.ValidatorStatus, error) {
+		return c.client.GetValidatorStatusByIndex(index, opts)
+	})
+}
+
+func (c *beaconClient) GetValidatorStatus(pubkey types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
+	return simCall(c.session, "GetValidatorStatus", []interface{}{pubkey, opts}, func() (beacon.ValidatorStatus, error) {
+		return c.client.GetValidatorStatus(pubkey, opts)
+	})
+}
+
+func (c *beaconClient) GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (map[types.ValidatorPubkey]beacon.ValidatorStatus, error) {
+	return simCall(c.session, "GetValidatorStatuses", []interface{}{pubkeys, opts}, func() (map[types.ValidatorPubkey]beacon.ValidatorStatus, error) {
+		return c.client.GetValidatorStatuses(pubkeys, opts)
+	})
+}
+
+func (c *beaconClient) GetValidatorStatusesByIndex(indices []string, opts *beacon.ValidatorStatusOptions) (map[string]beacon.ValidatorStatus, error) {
+	return simCall(c.session, "GetValidatorStatusesByIndex", []interface{}{indices, opts}, func() (map[string]beacon.ValidatorStatus, error) {
+		return c.client.GetValidatorStatusesByIndex(indices, opts)
+	})
+}
+
+func (c *beaconClient) GetValidatorStatusesByState(states []beacon.ValidatorState) ([]beacon.ValidatorStatus, error) {
+	return simCall(c.session, "GetValidatorStatusesByState", []interface{}{states}, func() ([]beacon.ValidatorStatus, error) {
+		return c.client.GetValidatorStatusesByState(states)
+	})
+}
+
+func (c *beaconClient) GetValidatorIndex(pubkey types.ValidatorPubkey) (string, error) {
+	return simCall(c.session, "GetValidatorIndex", []interface{}{pubkey}, func() (string, error) {
+		return c.client.GetValidatorIndex(pubkey)
+	})
+}
+
+func (c *beaconClient) GetValidatorSyncDuties(indices []string, epoch uint64) (map[string]bool, error) {
+	return simCall(c.session, "GetValidatorSyncDuties", []interface{}{indices, epoch}, func() (map[string]bool, error) {
+		return c.client.GetValidatorSyncDuties(indices, epoch)
+	})
+}
+
+func (c *beaconClient) GetValidatorProposerDuties(indices []string, epoch uint64) (map[string]uint64, error) {
+	return simCall(c.session, "GetValidatorProposerDuties", []interface{}{indices, epoch}, func() (map[string]uint64, error) {
+		return c.client.GetValidatorProposerDuties(indices, epoch)
+	})
+}
+
+func (c *beaconClient) GetValidatorProposerSlots(indices []string, epoch uint64) (map[string][]uint64, error) {
+	return simCall(c.session, "GetValidatorProposerSlots", []interface{}{indices, epoch}, func() (map[string][]uint64, error) {
+		return c.client.GetValidatorProposerSlots(indices, epoch)
+	})
+}
+
+func (c *beaconClient) GetDomainData(domainType []byte, epoch uint64, useGenesisFork bool) ([]byte, error) {
+	return simCall(c.session, "GetDomainData", []interface{}{domainType, epoch, useGenesisFork}, func() ([]byte, error) {
+		return c.client.GetDomainData(domainType, epoch, useGenesisFork)
+	})
+}
+
+func (c *beaconClient) ExitValidator(validatorIndex string, epoch uint64, signature types.ValidatorSignature) error {
+	return ErrNotSupported
+}
+
+func (c *beaconClient) Close() error {
+	if c.client == nil {
+		return nil
+	}
+	return c.client.Close()
+}
+
+func (c *beaconClient) GetEth1DataForEth2Block(blockId string) (beacon.Eth1Data, bool, error) {
+	result, err := simCall(c.session, "GetEth1DataForEth2Block", []interface{}{blockId}, func() (foundResult[beacon.Eth1Data], error) {
+		data, found, err := c.client.GetEth1DataForEth2Block(blockId)
+		return foundResult[beacon.Eth1Data]{Value: data, Found: found}, err
+	})
+	return result.Value, result.Found, err
+}
+
+func (c *beaconClient) GetCommitteesForEpoch(epoch *uint64) (beacon.Committees, error) {
+	recorded, err := simCall(c.session, "GetCommitteesForEpoch", []interface{}{epoch}, func() (*recordedCommittees, error) {
+		committees, err := c.client.GetCommitteesForEpoch(epoch)
+		if err != nil {
+			return nil, err
+		}
+		defer committees.Release()
+
+		// Copy the committees out, since the client reuses their buffers once they're released
+		recorded := &recordedCommittees{
+			Committees: make([]recordedCommittee, committees.Count()),
+		}
+		for idx := range recorded.Committees {
+			validators := committees.Validators(idx)
+			recorded.Committees[idx] = recordedCommittee{
+				Index:      committees.Index(idx),
+				Slot:       committees.Slot(idx),
+				Validators: append([]string(nil), validators...),
+			}
+		}
+		return recorded, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recorded, nil
+}
+
+func (c *beaconClient) ChangeWithdrawalCredentials(validatorIndex string, fromBlsPubkey types.ValidatorPubkey, toExecutionAddress common.Address, signature types.ValidatorSignature) error {
+	return ErrNotSupported
+}
+
+func (c *beaconClient) ChangeWithdrawalCredentialsBatch(changes []beacon.WithdrawalCredentialsChange) error {
+	return ErrNotSupported
+}
@@ -0,0 +1,133 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// Returned when something tries to send a transaction or subscribe to the chain during a simulation
+var ErrNotSupported = errors.New("this operation isn't supported during a simulation")
+
+// An Execution client that records the responses of another client, or replays them from a fixture
+type executionClient struct {
+	session *session
+	client  rocketpool.ExecutionClient
+}
+
+// The result of a transaction lookup
+type transactionResult struct {
+	Transaction *types.Transaction
+	IsPending   bool
+}
+
+func (c *executionClient) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return simCall(c.session, "CodeAt", []interface{}{contract, blockNumber}, func() ([]byte, error) {
+		return c.client.CodeAt(ctx, contract, blockNumber)
+	})
+}
+
+func (c *executionClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return simCall(c.session, "CallContract", []interface{}{call, blockNumber}, func() ([]byte, error) {
+		return c.client.CallContract(ctx, call, blockNumber)
+	})
+}
+
+func (c *executionClient) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	return simCall(c.session, "HeaderByHash", []interface{}{hash}, func() (*types.Header, error) {
+		return c.client.HeaderByHash(ctx, hash)
+	})
+}
+
+func (c *executionClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return simCall(c.session, "HeaderByNumber", []interface{}{number}, func() (*types.Header, error) {
+		return c.client.HeaderByNumber(ctx, number)
+	})
+}
+
+func (c *executionClient) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return simCall(c.session, "PendingCodeAt", []interface{}{account}, func() ([]byte, error) {
+		return c.client.PendingCodeAt(ctx, account)
+	})
+}
+
+func (c *executionClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return simCall(c.session, "PendingNonceAt", []interface{}{account}, func() (uint64, error) {
+		return c.client.PendingNonceAt(ctx, account)
+	})
+}
+
+func (c *executionClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return simCall(c.session, "SuggestGasPrice", []interface{}{}, func() (*big.Int, error) {
+		return c.client.SuggestGasPrice(ctx)
+	})
+}
+
+func (c *executionClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return simCall(c.session, "SuggestGasTipCap", []interface{}{}, func() (*big.Int, error) {
+		return c.client.SuggestGasTipCap(ctx)
+	})
+}
+
+func (c *executionClient) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return simCall(c.session, "EstimateGas", []interface{}{call}, func() (uint64, error) {
+		return c.client.EstimateGas(ctx, call)
+	})
+}
+
+func (c *executionClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return ErrNotSupported
+}
+
+func (c *executionClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	return simCall(c.session, "FilterLogs", []interface{}{query}, func() ([]types.Log, error) {
+		return c.client.FilterLogs(ctx, query)
+	})
+}
+
+func (c *executionClient) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return nil, ErrNotSupported
+}
+
+func (c *executionClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return simCall(c.session, "TransactionReceipt", []interface{}{txHash}, func() (*types.Receipt, error) {
+		return c.client.TransactionReceipt(ctx, txHash)
+	})
+}
+
+func (c *executionClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return simCall(c.session, "BlockNumber", []interface{}{}, func() (uint64, error) {
+		return c.client.BlockNumber(ctx)
+	})
+}
+
+func (c *executionClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return simCall(c.session, "BalanceAt", []interface{}{account, blockNumber}, func() (*big.Int, error) {
+		return c.client.BalanceAt(ctx, account, blockNumber)
+	})
+}
+
+func (c *executionClient) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	result, err := simCall(c.session, "TransactionByHash", []interface{}{hash}, func() (transactionResult, error) {
+		tx, isPending, err := c.client.TransactionByHash(ctx, hash)
+		return transactionResult{Transaction: tx, IsPending: isPending}, err
+	})
+	return result.Transaction, result.IsPending, err
+}
+
+func (c *executionClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return simCall(c.session, "NonceAt", []interface{}{account, blockNumber}, func() (uint64, error) {
+		return c.client.NonceAt(ctx, account, blockNumber)
+	})
+}
+
+func (c *executionClient) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	return simCall(c.session, "SyncProgress", []interface{}{}, func() (*ethereum.SyncProgress, error) {
+		return c.client.SyncProgress(ctx)
+	})
+}
@@ -0,0 +1,167 @@
+package simulation
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// The version of the fixture format; fixtures with a different version can't be replayed
+const fixtureVersion uint64 = 1
+
+// The extension used for fixture files
+const FixtureExtension string = ".fixture.zst"
+
+// Returned when a replay makes a request that wasn't made while the fixture was recorded
+var ErrNotRecorded = errors.New("request was not recorded in the fixture")
+
+// A single response from the Execution or Beacon client
+type recordedResponse struct {
+	Result []byte
+	Error  string
+}
+
+// The client responses recorded while generating the rewards for a past interval
+type Fixture struct {
+	Version      uint64
+	Network      cfgtypes.Network
+	Interval     uint64
+	RecordedTime time.Time
+	Responses    map[string]recordedResponse
+}
+
+// Create an empty fixture for an interval
+func newFixture(network cfgtypes.Network, interval uint64) *Fixture {
+	return &Fixture{
+		Version:      fixtureVersion,
+		Network:      network,
+		Interval:     interval,
+		RecordedTime: time.Now(),
+		Responses:    map[string]recordedResponse{},
+	}
+}
+
+// Save the fixture to disk
+func (f *Fixture) Save(path string) error {
+	var buffer bytes.Buffer
+	err := gob.NewEncoder(&buffer).Encode(f)
+	if err != nil {
+		return fmt.Errorf("error serializing fixture: %w", err)
+	}
+
+	encoder, _ := zstd.NewWriter(nil)
+	compressedBytes := encoder.EncodeAll(buffer.Bytes(), nil)
+	err = files.WriteFileAtomic(path, compressedBytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing fixture: %w", err)
+	}
+	return nil
+}
+
+// Load a fixture from disk
+func LoadFixture(path string) (*Fixture, error) {
+	compressedBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading fixture from %s: %w", path, err)
+	}
+
+	decoder, _ := zstd.NewReader(nil)
+	defer decoder.Close()
+	data, err := decoder.DecodeAll(compressedBytes, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing fixture: %w", err)
+	}
+
+	var fixture Fixture
+	err = gob.NewDecoder(bytes.NewReader(data)).Decode(&fixture)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing fixture: %w", err)
+	}
+	if fixture.Version != fixtureVersion {
+		return nil, fmt.Errorf("fixture %s has version %d, but version %d is required", path, fixture.Version, fixtureVersion)
+	}
+	return &fixture, nil
+}
+
+// Records client responses into a fixture, or replays them from one
+type session struct {
+	fixture   *Fixture
+	recording bool
+	lock      sync.Mutex
+}
+
+// Get the key a request is stored under in the fixture
+func requestKey(method string, args []interface{}) (string, error) {
+	argBytes, err := json.Marshal(args)
+	if err != nil {
+		return "", fmt.Errorf("error serializing arguments for %s: %w", method, err)
+	}
+	hash := sha256.Sum256(argBytes)
+	return method + ":" + hex.EncodeToString(hash[:]), nil
+}
+
+// The wrapper responses are encoded in, so empty values can be stored too
+type responseValue[T any] struct {
+	Value T
+}
+
+// Run a client request. While recording, the request is sent to the real client and its response is saved;
+// while replaying, the saved response is returned instead.
+func simCall[T any](s *session, method string, args []interface{}, call func() (T, error)) (T, error) {
+	var empty T
+	key, err := requestKey(method, args)
+	if err != nil {
+		return empty, err
+	}
+
+	if s.recording {
+		result, callErr := call()
+		response := recordedResponse{}
+		if callErr != nil {
+			response.Error = callErr.Error()
+		} else {
+			var buffer bytes.Buffer
+			err = gob.NewEncoder(&buffer).Encode(responseValue[T]{Value: result})
+			if err != nil {
+				return empty, fmt.Errorf("error serializing response for %s: %w", method, err)
+			}
+			response.Result = buffer.Bytes()
+		}
+
+		// Keep the first response so repeated requests replay the same way they were first seen
+		s.lock.Lock()
+		if _, exists := s.fixture.Responses[key]; !exists {
+			s.fixture.Responses[key] = response
+		}
+		s.lock.Unlock()
+		return result, callErr
+	}
+
+	s.lock.Lock()
+	response, exists := s.fixture.Responses[key]
+	s.lock.Unlock()
+	if !exists {
+		return empty, fmt.Errorf("%w: %s", ErrNotRecorded, key)
+	}
+	if response.Error != "" {
+		return empty, errors.New(response.Error)
+	}
+	var value responseValue[T]
+	err = gob.NewDecoder(bytes.NewReader(response.Result)).Decode(&value)
+	if err != nil {
+		return empty, fmt.Errorf("error deserializing response for %s: %w", method, err)
+	}
+	return value.Value, nil
+}
@@ -0,0 +1,142 @@
+// Package simulation records the Execution and Beacon client responses used to generate the rewards for a past interval into a fixture,
+// and replays them deterministically through the tree generator and rolling record, so changes to the reward math can be regression
+// tested without live clients.
+package simulation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The rewards generated for an interval during a simulation
+type Result struct {
+	RewardsFile         rprewards.IRewardsFile
+	MerkleRoot          common.Hash
+	CanonicalMerkleRoot common.Hash
+}
+
+// Check if the generated Merkle root matches the one the Oracle DAO submitted for the interval
+func (r *Result) MatchesCanonical() bool {
+	return r.MerkleRoot == r.CanonicalMerkleRoot
+}
+
+// Generate the rewards for a past interval with the provided clients, recording every response they return along the way.
+// The interval is generated both with and without a rolling record (when it has a previous interval), so the fixture can replay both.
+func RecordInterval(ctx context.Context, cfg *config.RocketPoolConfig, ec rocketpool.ExecutionClient, bc beacon.Client, interval uint64, logger *log.ColorLogger) (*Fixture, error) {
+	fixture := newFixture(cfg.Smartnode.Network.Value.(cfgtypes.Network), interval)
+	s := &session{
+		fixture:   fixture,
+		recording: true,
+	}
+	simEc := &executionClient{session: s, client: ec}
+	simBc := &beaconClient{session: s, client: bc}
+
+	_, err := runInterval(ctx, cfg, simEc, simBc, interval, false, logger)
+	if err != nil {
+		return nil, fmt.Errorf("error recording interval %d: %w", interval, err)
+	}
+	if interval > 0 {
+		_, err = runInterval(ctx, cfg, simEc, simBc, interval, true, logger)
+		if err != nil {
+			return nil, fmt.Errorf("error recording interval %d with a rolling record: %w", interval, err)
+		}
+	}
+	return fixture, nil
+}
+
+// Generate the rewards for the fixture's interval from its recorded responses, without any live clients
+func ReplayInterval(ctx context.Context, cfg *config.RocketPoolConfig, fixture *Fixture, useRollingRecord bool, logger *log.ColorLogger) (*Result, error) {
+	network := cfg.Smartnode.Network.Value.(cfgtypes.Network)
+	if network != fixture.Network {
+		return nil, fmt.Errorf("fixture was recorded on %s but the config is for %s", fixture.Network, network)
+	}
+	if useRollingRecord && fixture.Interval == 0 {
+		return nil, fmt.Errorf("interval 0 can't be generated with a rolling record")
+	}
+
+	s := &session{
+		fixture:   fixture,
+		recording: false,
+	}
+	return runInterval(ctx, cfg, &executionClient{session: s}, &beaconClient{session: s}, fixture.Interval, useRollingRecord, logger)
+}
+
+// Generate the rewards for a past interval the same way the Watchtower does
+func runInterval(ctx context.Context, cfg *config.RocketPoolConfig, ec rocketpool.ExecutionClient, bc beacon.Client, interval uint64, useRollingRecord bool, logger *log.ColorLogger) (*Result, error) {
+	logPrefix := fmt.Sprintf("[Interval %d Simulation]", interval)
+	if useRollingRecord {
+		logPrefix = fmt.Sprintf("[Interval %d Rolling Simulation]", interval)
+	}
+
+	rp, err := rocketpool.NewRocketPool(ec, common.HexToAddress(cfg.Smartnode.GetStorageAddress()))
+	if err != nil {
+		return nil, fmt.Errorf("error creating Rocket Pool binding: %w", err)
+	}
+
+	// Get the interval's rewards event and the state it was generated from
+	rewardsEvent, err := rprewards.GetRewardSnapshotEvent(rp, cfg, interval, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting event for interval %d: %w", interval, err)
+	}
+	elBlockHeader, err := ec.HeaderByNumber(ctx, rewardsEvent.ExecutionBlock)
+	if err != nil {
+		return nil, fmt.Errorf("error getting execution block %s: %w", rewardsEvent.ExecutionBlock.String(), err)
+	}
+	stateMgr, err := state.NewNetworkStateManager(rp, cfg, ec, bc, logger)
+	if err != nil {
+		return nil, fmt.Errorf("error creating network state manager: %w", err)
+	}
+	consensusSlot := rewardsEvent.ConsensusBlock.Uint64()
+	networkState, err := stateMgr.GetStateForSlot(ctx, consensusSlot)
+	if err != nil {
+		return nil, fmt.Errorf("error getting state for beacon slot %d: %w", consensusSlot, err)
+	}
+
+	// Build the rolling record for the whole interval if requested
+	var record *rprewards.RollingRecord
+	if useRollingRecord {
+		beaconCfg, err := bc.GetEth2Config()
+		if err != nil {
+			return nil, fmt.Errorf("error getting Beacon config: %w", err)
+		}
+		previousEvent, err := rprewards.GetRewardSnapshotEvent(rp, cfg, interval-1, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting event for interval %d: %w", interval-1, err)
+		}
+		startSlot, err := rprewards.GetStartSlotForInterval(previousEvent, bc, beaconCfg)
+		if err != nil {
+			return nil, fmt.Errorf("error getting start slot for interval %d: %w", interval, err)
+		}
+		record = rprewards.NewRollingRecord(logger, logPrefix, bc, startSlot, &beaconCfg, interval)
+		err = record.UpdateToSlot(ctx, consensusSlot, networkState)
+		if err != nil {
+			return nil, fmt.Errorf("error updating rolling record to slot %d: %w", consensusSlot, err)
+		}
+	}
+
+	// Generate the tree
+	treegen, err := rprewards.NewTreeGenerator(logger, logPrefix, rp, cfg, bc, interval, rewardsEvent.IntervalStartTime, rewardsEvent.IntervalEndTime, consensusSlot, elBlockHeader, rewardsEvent.IntervalsPassed.Uint64(), networkState, record)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Merkle tree generator: %w", err)
+	}
+	rewardsFile, err := treegen.GenerateTree(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error generating Merkle tree: %w", err)
+	}
+
+	return &Result{
+		RewardsFile:         rewardsFile,
+		MerkleRoot:          common.BytesToHash(rewardsFile.GetHeader().MerkleTree.Root()),
+		CanonicalMerkleRoot: rewardsEvent.MerkleRoot,
+	}, nil
+}
@@ -0,0 +1,140 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/fatih/color"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon/client"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Record a new fixture with:
+// go test ./shared/services/rewards/simulation -run TestRecordFixture -record-interval 20 -record-network mainnet -record-ec <url> -record-bn <url>
+var (
+	recordInterval = flag.Int64("record-interval", -1, "the rewards interval to record a fixture for")
+	recordNetwork  = flag.String("record-network", string(cfgtypes.Network_Mainnet), "the network the interval is on")
+	recordEc       = flag.String("record-ec", "", "the URL of an archive Execution client to record from")
+	recordBn       = flag.String("record-bn", "", "the URL of a Beacon Node to record from")
+)
+
+// The folder fixtures are kept in
+const fixturesFolder = "testdata"
+
+func newSimulationConfig(network cfgtypes.Network) *config.RocketPoolConfig {
+	cfg := config.NewRocketPoolConfig("", false)
+	cfg.ChangeNetwork(network)
+	return cfg
+}
+
+func TestRecordFixture(t *testing.T) {
+	if *recordInterval < 0 {
+		t.Skip("no interval to record")
+	}
+	if *recordEc == "" || *recordBn == "" {
+		t.Fatal("an Execution client and Beacon Node are required to record a fixture")
+	}
+
+	ec, err := ethclient.Dial(*recordEc)
+	if err != nil {
+		t.Fatalf("error connecting to the Execution client: %s", err.Error())
+	}
+	cfg := newSimulationConfig(cfgtypes.Network(*recordNetwork))
+	bc := client.NewStandardHttpClient(*recordBn, int(cfg.Smartnode.ValidatorStatusChunkSize.Value.(uint64)))
+	logger := log.NewColorLogger(color.FgHiWhite)
+
+	fixture, err := RecordInterval(context.Background(), cfg, ec, bc, uint64(*recordInterval), &logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(fixturesFolder, fmt.Sprintf("%s-%d%s", *recordNetwork, *recordInterval, FixtureExtension))
+	err = fixture.Save(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("Recorded %d responses to %s", len(fixture.Responses), path)
+}
+
+func TestReplayFixtures(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join(fixturesFolder, "*"+FixtureExtension))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Skip("no fixtures have been recorded")
+	}
+
+	logger := log.NewColorLogger(color.FgHiWhite)
+	for _, path := range paths {
+		fixture, err := LoadFixture(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, useRollingRecord := range []bool{false, true} {
+			if useRollingRecord && fixture.Interval == 0 {
+				continue
+			}
+			name := fmt.Sprintf("%s/rolling=%t", filepath.Base(path), useRollingRecord)
+			t.Run(name, func(t *testing.T) {
+				result, err := ReplayInterval(context.Background(), newSimulationConfig(fixture.Network), fixture, useRollingRecord, &logger)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !result.MatchesCanonical() {
+					t.Errorf("generated Merkle root %s doesn't match the canonical root %s", result.MerkleRoot.Hex(), result.CanonicalMerkleRoot.Hex())
+				}
+			})
+		}
+	}
+}
+
+func TestSessionReplaysRecordedResponses(t *testing.T) {
+	fixture := newFixture(cfgtypes.Network_Mainnet, 1)
+	recorder := &session{fixture: fixture, recording: true}
+	calls := 0
+	value, err := simCall(recorder, "Method", []interface{}{"a", uint64(1)}, func() (map[string]uint64, error) {
+		calls++
+		return map[string]uint64{"a": 1}, nil
+	})
+	if err != nil || value["a"] != 1 {
+		t.Fatalf("unexpected recorded response %v, %v", value, err)
+	}
+	_, err = simCall(recorder, "Failing", []interface{}{}, func() (uint64, error) {
+		return 0, errors.New("call failed")
+	})
+	if err == nil {
+		t.Fatal("expected the recorded call to fail")
+	}
+
+	replayer := &session{fixture: fixture, recording: false}
+	value, err = simCall(replayer, "Method", []interface{}{"a", uint64(1)}, func() (map[string]uint64, error) {
+		calls++
+		return nil, nil
+	})
+	if err != nil || value["a"] != 1 {
+		t.Fatalf("unexpected replayed response %v, %v", value, err)
+	}
+	if calls != 1 {
+		t.Fatalf("the client was called %d times, but the replay shouldn't call it", calls)
+	}
+	_, err = simCall(replayer, "Failing", []interface{}{}, func() (uint64, error) {
+		return 0, nil
+	})
+	if err == nil || err.Error() != "call failed" {
+		t.Fatalf("expected the recorded error, got %v", err)
+	}
+	_, err = simCall(replayer, "Method", []interface{}{"b", uint64(1)}, func() (map[string]uint64, error) {
+		return nil, nil
+	})
+	if !errors.Is(err, ErrNotRecorded) {
+		t.Fatalf("expected an unrecorded request to fail, got %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package rewards
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Everything an online watchtower needs to submit a rewards tree that was generated on a different machine.
+// The amounts aren't included since they come from the tree's header, which is checked against the Merkle root on import.
+type SubmissionPayload struct {
+	RewardIndex                uint64    `json:"rewardIndex"`
+	ConsensusBlock             uint64    `json:"consensusBlock"`
+	ExecutionBlock             uint64    `json:"executionBlock"`
+	IntervalsPassed            uint64    `json:"intervalsPassed"`
+	MerkleRoot                 string    `json:"merkleRoot"`
+	MerkleTreeCID              string    `json:"merkleTreeCid"`
+	MinipoolPerformanceFileCID string    `json:"minipoolPerformanceFileCid"`
+	GeneratedAt                time.Time `json:"generatedAt"`
+}
+
+// Create the submission payload for a generated tree
+func NewSubmissionPayload(index uint64, consensusBlock uint64, executionBlock uint64, header *RewardsFileHeader, cid string, intervalsPassed uint64) *SubmissionPayload {
+	return &SubmissionPayload{
+		RewardIndex:                index,
+		ConsensusBlock:             consensusBlock,
+		ExecutionBlock:             executionBlock,
+		IntervalsPassed:            intervalsPassed,
+		MerkleRoot:                 header.MerkleRoot,
+		MerkleTreeCID:              cid,
+		MinipoolPerformanceFileCID: header.MinipoolPerformanceFileCID,
+		GeneratedAt:                time.Now(),
+	}
+}
+
+// Load a submission payload from disk
+func LoadSubmissionPayload(path string) (*SubmissionPayload, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading submission payload [%s]: %w", path, err)
+	}
+	payload := new(SubmissionPayload)
+	if err := json.Unmarshal(bytes, payload); err != nil {
+		return nil, fmt.Errorf("error parsing submission payload [%s]: %w", path, err)
+	}
+	return payload, nil
+}
+
+// Save a submission payload to disk
+func (p *SubmissionPayload) Save(path string) error {
+	bytes, err := json.MarshalIndent(p, "", "\t")
+	if err != nil {
+		return fmt.Errorf("error serializing submission payload: %w", err)
+	}
+	if err := files.WriteFileAtomic(path, bytes, 0644); err != nil {
+		return fmt.Errorf("error saving submission payload [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Make sure the rewards tree and the compressed files that will be published match the payload.
+// This rebuilds the tree's Merkle root from its data and recalculates the CIDs of the compressed files.
+func (p *SubmissionPayload) Verify(rewardsFile IRewardsFile, compressedPath string, compressedMinipoolPerformancePath string) error {
+	header := rewardsFile.GetHeader()
+	if header.Index != p.RewardIndex {
+		return fmt.Errorf("the rewards tree is for interval %d, but the payload is for interval %d", header.Index, p.RewardIndex)
+	}
+	if header.ConsensusEndBlock != p.ConsensusBlock {
+		return fmt.Errorf("the rewards tree ends at Beacon block %d, but the payload uses %d", header.ConsensusEndBlock, p.ConsensusBlock)
+	}
+	if header.ExecutionEndBlock != p.ExecutionBlock {
+		return fmt.Errorf("the rewards tree ends at EL block %d, but the payload uses %d", header.ExecutionEndBlock, p.ExecutionBlock)
+	}
+	if header.IntervalsPassed != p.IntervalsPassed {
+		return fmt.Errorf("the rewards tree covers %d intervals, but the payload covers %d", header.IntervalsPassed, p.IntervalsPassed)
+	}
+	if header.MinipoolPerformanceFileCID != p.MinipoolPerformanceFileCID {
+		return fmt.Errorf("the rewards tree has minipool performance CID %s, but the payload has %s", header.MinipoolPerformanceFileCID, p.MinipoolPerformanceFileCID)
+	}
+	if err := VerifyMerkleRoot(rewardsFile, p.MerkleRoot); err != nil {
+		return err
+	}
+
+	// Check the CID of the compressed file that will be published
+	compressedBytes, err := os.ReadFile(compressedPath)
+	if err != nil {
+		return fmt.Errorf("error reading the compressed rewards tree [%s]: %w", compressedPath, err)
+	}
+	decompressedBytes, err := decompressFile(compressedBytes)
+	if err != nil {
+		return err
+	}
+	compressedFile, err := DeserializeRewardsFile(decompressedBytes)
+	if err != nil {
+		return fmt.Errorf("error deserializing the compressed rewards tree: %w", err)
+	}
+	if !strings.EqualFold(compressedFile.GetHeader().MerkleRoot, p.MerkleRoot) {
+		return fmt.Errorf("the compressed rewards tree has Merkle root %s, but the payload has %s", compressedFile.GetHeader().MerkleRoot, p.MerkleRoot)
+	}
+	cid, err := singleFileDirIPFSCid(compressedBytes, filepath.Base(compressedPath))
+	if err != nil {
+		return fmt.Errorf("error calculating the CID of the compressed rewards tree: %w", err)
+	}
+	if cid.String() != p.MerkleTreeCID {
+		return fmt.Errorf("the compressed rewards tree has CID %s, but the payload has %s", cid.String(), p.MerkleTreeCID)
+	}
+
+	// Check the minipool performance file too, since the tree points to it
+	if p.MinipoolPerformanceFileCID == "" || p.MinipoolPerformanceFileCID == "---" {
+		return nil
+	}
+	compressedBytes, err = os.ReadFile(compressedMinipoolPerformancePath)
+	if err != nil {
+		return fmt.Errorf("error reading the compressed minipool performance file [%s]: %w", compressedMinipoolPerformancePath, err)
+	}
+	cid, err = singleFileDirIPFSCid(compressedBytes, filepath.Base(compressedMinipoolPerformancePath))
+	if err != nil {
+		return fmt.Errorf("error calculating the CID of the compressed minipool performance file: %w", err)
+	}
+	if cid.String() != p.MinipoolPerformanceFileCID {
+		return fmt.Errorf("the compressed minipool performance file has CID %s, but the payload has %s", cid.String(), p.MinipoolPerformanceFileCID)
+	}
+	return nil
+}
+
+// Build the on-chain submission for a rewards tree
+func NewRewardSubmission(index uint64, consensusBlock uint64, executionBlock uint64, header *RewardsFileHeader, cid string, intervalsPassed uint64) (rewards.RewardSubmission, error) {
+	treeRootBytes, err := hex.DecodeString(hexutil.RemovePrefix(header.MerkleRoot))
+	if err != nil {
+		return rewards.RewardSubmission{}, fmt.Errorf("error decoding merkle root: %w", err)
+	}
+
+	// Create the arrays of rewards per network
+	collateralRplRewards := []*big.Int{}
+	oDaoRplRewards := []*big.Int{}
+	smoothingPoolEthRewards := []*big.Int{}
+	for network := uint64(0); ; network++ {
+		networkRewards, exists := header.NetworkRewards[network]
+		if !exists {
+			break
+		}
+		collateralRplRewards = append(collateralRplRewards, &networkRewards.CollateralRpl.Int)
+		oDaoRplRewards = append(oDaoRplRewards, &networkRewards.OracleDaoRpl.Int)
+		smoothingPoolEthRewards = append(smoothingPoolEthRewards, &networkRewards.SmoothingPoolEth.Int)
+	}
+
+	return rewards.RewardSubmission{
+		RewardIndex:     big.NewInt(0).SetUint64(index),
+		ExecutionBlock:  big.NewInt(0).SetUint64(executionBlock),
+		ConsensusBlock:  big.NewInt(0).SetUint64(consensusBlock),
+		MerkleRoot:      common.BytesToHash(treeRootBytes),
+		MerkleTreeCID:   cid,
+		IntervalsPassed: big.NewInt(0).SetUint64(intervalsPassed),
+		TreasuryRPL:     &header.TotalRewards.ProtocolDaoRpl.Int,
+		NodeRPL:         collateralRplRewards,
+		TrustedNodeRPL:  oDaoRplRewards,
+		NodeETH:         smoothingPoolEthRewards,
+		UserETH:         &header.TotalRewards.PoolStakerSmoothingPoolEth.Int,
+	}, nil
+}
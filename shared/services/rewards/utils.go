@@ -3,10 +3,8 @@ package rewards
 import (
 	"context"
 	"fmt"
-	"io"
 	"math"
 	"math/big"
-	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -25,6 +23,7 @@ import (
 	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/mirror"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 )
 
@@ -256,12 +255,9 @@ func (i *IntervalInfo) DownloadRewardsFile(cfg *config.RocketPoolConfig, isDaemo
 	rewardsTreeFilename := filepath.Base(rewardsTreePath)
 	ipfsFilename := rewardsTreeFilename + config.RewardsTreeIpfsExtension
 
-	// Create URL list
-	urls := []string{
-		fmt.Sprintf(config.PrimaryRewardsFileUrl, expectedCid, ipfsFilename),
-		fmt.Sprintf(config.SecondaryRewardsFileUrl, expectedCid, ipfsFilename),
-		fmt.Sprintf(config.GithubRewardsFileUrl, string(cfg.Smartnode.Network.Value.(cfgtypes.Network)), rewardsTreeFilename),
-	}
+	// Create URL list; the sources are raced against each other, so a slow IPFS gateway doesn't hold up the download
+	urls := mirror.GetRewardsFileUrls(cfg, expectedCid, ipfsFilename)
+	urls = append(urls, fmt.Sprintf(config.GithubRewardsFileUrl, string(cfg.Smartnode.Network.Value.(cfgtypes.Network)), rewardsTreeFilename))
 
 	rewardsTreeCustomUrl := cfg.Smartnode.RewardsTreeCustomUrl.Value.(string)
 	rewardsTreeCustomUrl = strings.TrimSpace(rewardsTreeCustomUrl)
@@ -273,88 +269,59 @@ func (i *IntervalInfo) DownloadRewardsFile(cfg *config.RocketPoolConfig, isDaemo
 		}
 	}
 
-	// Attempt downloads
-	errBuilder := strings.Builder{}
-	// ipfs http services are very unreliable and like to hold the connection open for several
-	// minutes before returning a 504. Force a short timeout, but if all sources fail,
-	// gradually increase the timeout to be unreasonably long.
-	for _, timeout := range []time.Duration{200 * time.Millisecond, 2 * time.Second, 60 * time.Second} {
-		client := http.Client{
-			Timeout: timeout,
+	// Use the shared cache if another instance has already downloaded and verified this file.
+	// The daemons can't see it in Docker mode, since it's outside of their data folder.
+	cachePath := ""
+	cacheFolder := strings.TrimSpace(cfg.Smartnode.RewardsFileCacheFolder.Value.(string))
+	if cacheFolder != "" && (!isDaemon || cfg.IsNativeMode) {
+		cacheFolder, err = homedir.Expand(cacheFolder)
+		if err != nil {
+			return fmt.Errorf("error expanding rewards file cache path: %w", err)
 		}
-		for _, url := range urls {
-			resp, err := client.Get(url)
-			if err != nil {
-				errBuilder.WriteString(fmt.Sprintf("Downloading %s failed (%s)\n", url, err.Error()))
-				continue
-			}
-			defer resp.Body.Close()
-
-			if resp.StatusCode != http.StatusOK {
-				errBuilder.WriteString(fmt.Sprintf("Downloading %s failed with status %s\n", url, resp.Status))
-				continue
-			}
-			// If we got here, we have a successful download
-			bytes, err := io.ReadAll(resp.Body)
-			if err != nil {
-				errBuilder.WriteString(fmt.Sprintf("Error reading response bytes from %s: %s\n", url, err.Error()))
-				continue
-			}
-			writeBytes := bytes
-			if strings.HasSuffix(url, config.RewardsTreeIpfsExtension) {
-				// Decompress it
-				writeBytes, err = decompressFile(bytes)
-				if err != nil {
-					errBuilder.WriteString(fmt.Sprintf("Error decompressing %s: %s\n", url, err.Error()))
-					continue
-				}
-			}
-
-			deserializedRewardsFile, err := DeserializeRewardsFile(writeBytes)
-			if err != nil {
-				return fmt.Errorf("Error deserializing file %s: %w", rewardsTreePath, err)
-			}
-
-			// Get the original merkle root
-			downloadedRoot := deserializedRewardsFile.GetHeader().MerkleRoot
-
-			// Clear the merkle root so we have a safer comparison after calculating it again
-			deserializedRewardsFile.GetHeader().MerkleRoot = ""
-
-			// Reconstruct the merkle tree from the file data, this should overwrite the stored Merkle Root with a new one
-			deserializedRewardsFile.generateMerkleTree()
-
-			// Get the resulting merkle root
-			calculatedRoot := deserializedRewardsFile.GetHeader().MerkleRoot
-
-			// Compare the merkle roots to see if the original is correct
-			if !strings.EqualFold(downloadedRoot, calculatedRoot) {
-				return fmt.Errorf("the merkle root from %s does not match the root generated by its tree data (had %s, but generated %s)", url, downloadedRoot, calculatedRoot)
-			}
-
-			// Make sure the calculated root matches the canonical one
-			if !strings.EqualFold(calculatedRoot, expectedRoot.Hex()) {
-				return fmt.Errorf("the merkle root from %s does not match the canonical one (had %s, but generated %s)", url, calculatedRoot, expectedRoot.Hex())
-			}
+		cachePath = filepath.Join(cacheFolder, rewardsTreeFilename)
+	}
+	var rewardsFile IRewardsFile
+	if cachePath != "" {
+		rewardsFile = loadCachedRewardsFile(cachePath, expectedRoot.Hex())
+	}
 
-			// Serialize again so we're sure to have all the correct proofs that we've generated (instead of verifying every proof on the file)
-			localRewardsFile := NewLocalFile[IRewardsFile](
-				deserializedRewardsFile,
-				rewardsTreePath,
-			)
-			err = localRewardsFile.Write()
-			if err != nil {
-				return fmt.Errorf("error saving interval %d file to %s: %w", interval, rewardsTreePath, err)
-			}
+	// Race all of the sources against each other and keep the first copy that verifies
+	if rewardsFile == nil {
+		downloader := &rewardsFileDownloader{
+			urls:         urls,
+			partialPath:  rewardsTreePath,
+			ipfsFilename: ipfsFilename,
+			expectedCid:  expectedCid,
+			expectedRoot: expectedRoot.Hex(),
+		}
+		rewardsFile, err = downloader.download()
+		if err != nil {
+			return err
+		}
+	}
 
-			return nil
+	// Serialize again so we're sure to have all the correct proofs that we've generated (instead of verifying every proof on the file)
+	localRewardsFile, err := NewCompressedLocalFile[IRewardsFile](
+		rewardsFile,
+		rewardsTreePath,
+		cfg,
+	)
+	if err != nil {
+		return err
+	}
+	err = localRewardsFile.Write()
+	if err != nil {
+		return fmt.Errorf("error saving interval %d file to %s: %w", interval, rewardsTreePath, err)
+	}
 
+	// Share it with the other instances
+	if cachePath != "" {
+		if err := cacheRewardsFile(rewardsTreePath, cachePath); err != nil {
+			return fmt.Errorf("error caching interval %d file to %s: %w", interval, cachePath, err)
 		}
-
-		errBuilder.WriteString(fmt.Sprintf("Downloading files with timeout %v failed.\n", timeout))
 	}
 
-	return fmt.Errorf(errBuilder.String())
+	return nil
 
 }
 
@@ -12,6 +12,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -26,9 +27,11 @@ import (
 	"github.com/mitchellh/go-homedir"
 	"github.com/rocket-pool/smartnode/addons/graffiti_wall_writer"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/keyarchive"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool/template"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/types/errcodes"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
@@ -59,6 +62,9 @@ const (
 	DebugColor = color.FgYellow
 )
 
+// Matches the host port and protocol of a published port in `docker ps` output
+var publishedPortRegex = regexp.MustCompile(`:(\d+)->\d+/(tcp|udp)`)
+
 // When printing sync percents, we should avoid printing 100%.
 // This function is only called if we're still syncing,
 // and the `%0.2f` token will round up if we're above 99.99%.
@@ -69,6 +75,8 @@ func SyncRatioToPercent(in float64) float64 {
 // Rocket Pool client
 type Client struct {
 	configPath         string
+	mainConfigPath     string
+	instanceName       string
 	daemonPath         string
 	maxFee             float64
 	maxPrioFee         float64
@@ -81,6 +89,7 @@ type Client struct {
 	debugPrint         bool
 	ignoreSyncCheck    bool
 	forceFallbacks     bool
+	txPreviews         []api.TransactionPreview
 }
 
 func getClientStatusString(clientStatus api.ClientStatus) string {
@@ -144,9 +153,19 @@ func checkClientStatus(rp *Client) (bool, error) {
 // Most users should call NewClientFromCtx(c).WithStatus() or NewClientFromCtx(c).WithReady()
 func NewClientFromCtx(c *cli.Context) *Client {
 
+	// Use the instance's config folder if one was selected
+	mainConfigPath := os.ExpandEnv(c.GlobalString("config-path"))
+	instanceName := c.GlobalString("instance")
+	configPath := mainConfigPath
+	if instanceName != "" {
+		configPath = GetInstanceConfigPath(mainConfigPath, instanceName)
+	}
+
 	// Return client
 	client := &Client{
-		configPath:         os.ExpandEnv(c.GlobalString("config-path")),
+		configPath:         configPath,
+		mainConfigPath:     mainConfigPath,
+		instanceName:       instanceName,
 		daemonPath:         os.ExpandEnv(c.GlobalString("daemon-path")),
 		maxFee:             c.GlobalFloat64("maxFee"),
 		maxPrioFee:         c.GlobalFloat64("maxPrioFee"),
@@ -231,7 +250,16 @@ func (c *Client) LoadConfig() (*config.RocketPoolConfig, bool, error) {
 	}
 
 	// Config wasn't loaded, but there was no error- we should create one.
-	return config.NewRocketPoolConfig(c.configPath, c.daemonPath != ""), true, nil
+	cfg = config.NewRocketPoolConfig(c.configPath, c.daemonPath != "")
+	if c.instanceName != "" {
+		// Keep a new instance out of the way of the others
+		portOffset, err := c.getFreeInstancePortOffset()
+		if err != nil {
+			return nil, false, fmt.Errorf("error assigning ports to instance [%s]: %w", c.instanceName, err)
+		}
+		cfg.ApplyInstanceDefaults(c.instanceName, portOffset)
+	}
+	return cfg, true, nil
 }
 
 // Load the backup config
@@ -263,6 +291,33 @@ func (c *Client) RemoveUpgradeFlagFile() error {
 	return rp.RemoveUpgradeFlagFile(expandedPath)
 }
 
+// Returns when the node was migrated to another machine, and whether it has been
+func (c *Client) GetMigrationTime() (time.Time, bool, error) {
+	expandedPath, err := homedir.Expand(c.configPath)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("error expanding settings file path: %w", err)
+	}
+	return rp.GetMigrationTime(expandedPath)
+}
+
+// Mark the node as migrated to another machine
+func (c *Client) SetMigrated(migrationTime time.Time) error {
+	expandedPath, err := homedir.Expand(c.configPath)
+	if err != nil {
+		return fmt.Errorf("error expanding settings file path: %w", err)
+	}
+	return rp.SetMigratedFlag(expandedPath, migrationTime)
+}
+
+// Clear the mark that the node was migrated to another machine
+func (c *Client) ClearMigrated() error {
+	expandedPath, err := homedir.Expand(c.configPath)
+	if err != nil {
+		return fmt.Errorf("error expanding settings file path: %w", err)
+	}
+	return rp.RemoveMigratedFlag(expandedPath)
+}
+
 // Returns whether or not this is the first run of the configurator since a previous installation
 func (c *Client) IsFirstRun() (bool, error) {
 	expandedPath, err := homedir.Expand(c.configPath)
@@ -485,6 +540,15 @@ func (c *Client) StartService(composeFiles []string) error {
 	return c.printOutput(cmd)
 }
 
+// Start the Rocket Pool service without the Validator Client, so the node can sync and run its daemons without attesting
+func (c *Client) StartServiceWithoutValidator(composeFiles []string) error {
+	cmd, err := c.compose(composeFiles, fmt.Sprintf("up -d --remove-orphans --quiet-pull --scale %s=0", cfgtypes.ContainerID_Validator))
+	if err != nil {
+		return err
+	}
+	return c.printOutput(cmd)
+}
+
 // Pause the Rocket Pool service
 func (c *Client) PauseService(composeFiles []string) error {
 	cmd, err := c.compose(composeFiles, "stop")
@@ -560,6 +624,37 @@ func (c *Client) PrintServiceLogs(composeFiles []string, tail string, serviceNam
 	return c.printOutput(cmd)
 }
 
+// Get the most recent logs of a Rocket Pool service without following them
+func (c *Client) GetServiceLogs(composeFiles []string, tail string, serviceName string) ([]byte, error) {
+	cmd, err := c.compose(composeFiles, fmt.Sprintf("logs --no-color --timestamps --tail %s %s", shellescape.Quote(tail), shellescape.Quote(serviceName)))
+	if err != nil {
+		return nil, err
+	}
+	return c.readOutput(cmd)
+}
+
+// Get the names of the Rocket Pool services
+func (c *Client) GetServiceNames(composeFiles []string) ([]string, error) {
+	cmd, err := c.compose(composeFiles, "config --services")
+	if err != nil {
+		return nil, err
+	}
+	output, err := c.readOutput(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(output)), nil
+}
+
+// Get the state of each of the Rocket Pool service's containers, including the stopped ones
+func (c *Client) GetServiceStatus(composeFiles []string) ([]byte, error) {
+	cmd, err := c.compose(composeFiles, "ps -a")
+	if err != nil {
+		return nil, err
+	}
+	return c.readOutput(cmd)
+}
+
 // Print the Rocket Pool service stats
 func (c *Client) PrintServiceStats(composeFiles []string) error {
 
@@ -818,6 +913,22 @@ func (c *Client) GetAllDockerImages() ([]DockerImage, error) {
 	return images, nil
 }
 
+// Get the host ports published by the running containers of a compose project, formatted as `port/protocol`
+func (c *Client) GetPublishedPorts(projectName string) (map[string]bool, error) {
+	cmd := fmt.Sprintf("docker ps --filter label=com.docker.compose.project=%s --format {{.Ports}}", shellescape.Quote(projectName))
+	output, err := c.readOutput(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each mapping looks like `0.0.0.0:30303->30303/tcp`
+	ports := map[string]bool{}
+	for _, match := range publishedPortRegex.FindAllStringSubmatch(string(output), -1) {
+		ports[fmt.Sprintf("%s/%s", match[1], match[2])] = true
+	}
+	return ports, nil
+}
+
 // Gets the absolute file path of the client volume
 func (c *Client) GetClientVolumeSource(container string, volumeTarget string) (string, error) {
 
@@ -840,6 +951,25 @@ func (c *Client) GetClientVolumeName(container string, volumeTarget string) (str
 	return strings.TrimSpace(string(output)), nil
 }
 
+// Gets the directory Docker keeps its volumes and images in
+func (c *Client) GetDockerRootDir() (string, error) {
+	output, err := c.readOutput("docker info --format '{{.DockerRootDir}}'")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// Runs a shell script on the host with root privileges, printing its output
+func (c *Client) RunHostScript(script string) error {
+	// Get the command to run with root privileges
+	rootCmd, err := c.getEscalationCommand()
+	if err != nil {
+		return fmt.Errorf("could not get privilege escalation command: %w", err)
+	}
+	return c.printOutput(fmt.Sprintf("%s sh -c %s", rootCmd, shellescape.Quote(script)))
+}
+
 // Gets the disk usage of the given volume
 func (c *Client) GetVolumeSize(volumeName string) (string, error) {
 
@@ -988,6 +1118,97 @@ func (c *Client) PurgeAllKeys(composeFiles []string) error {
 	return nil
 }
 
+// Replaces the plaintext validator keys on disk with an in-memory tmpfs mount for the node to unlock the encrypted archive into.
+// Does nothing if they're already in memory, or if encryption at rest isn't enabled and set up.
+func (c *Client) MountValidatorKeysInMemory(composeFiles []string) error {
+	// Get the config
+	cfg, _, err := c.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading user settings: %w", err)
+	}
+	if cfg.IsNativeMode || !cfg.Smartnode.EncryptValidatorKeys.Value.(bool) {
+		return nil
+	}
+
+	// Make sure there's an archive to unlock
+	archivePath, err := homedir.Expand(cfg.Smartnode.GetValidatorKeyArchivePathInCLI())
+	if err != nil {
+		return fmt.Errorf("error loading validator key archive path: %w", err)
+	}
+	_, err = os.Stat(archivePath)
+	if os.IsNotExist(err) {
+		fmt.Printf("%sNOTE: Validator key encryption is enabled, but your keys haven't been encrypted yet. Run `rocketpool wallet encrypt-validator-keys` once the node is running.%s\n\n", colorYellow, colorReset)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error checking for the validator key archive: %w", err)
+	}
+
+	// Check if the keys are already in memory
+	validatorsPath, err := homedir.Expand(cfg.Smartnode.GetValidatorKeychainPathInCLI())
+	if err != nil {
+		return fmt.Errorf("error loading validators folder path: %w", err)
+	}
+	fsType, err := c.readOutput(fmt.Sprintf("stat -f -c %%T %s", shellescape.Quote(validatorsPath)))
+	if err != nil {
+		return fmt.Errorf("error checking the validators folder's filesystem: %w", err)
+	}
+	if strings.TrimSpace(string(fsType)) == "tmpfs" {
+		return nil
+	}
+
+	// Only delete the plaintext keys if the archive has everything in them
+	unlockedHash, unlocked, err := keyarchive.GetUnlockedHash(validatorsPath)
+	if err != nil {
+		return err
+	}
+	if !unlocked {
+		return fmt.Errorf("the validator keys in %s were not unlocked from or saved to the encrypted archive; run `rocketpool wallet encrypt-validator-keys` before restarting", validatorsPath)
+	}
+	currentHash, err := keyarchive.GetDirHash(validatorsPath)
+	if err != nil {
+		return err
+	}
+	if currentHash != unlockedHash {
+		return fmt.Errorf("the validator keys in %s have changed since they were last saved to the encrypted archive; wait for the node daemon to save them, or run `rocketpool wallet encrypt-validator-keys`, before restarting", validatorsPath)
+	}
+
+	// Get the command to run with root privileges
+	rootCmd, err := c.getEscalationCommand()
+	if err != nil {
+		return fmt.Errorf("could not get privilege escalation command: %w", err)
+	}
+
+	// Stop the containers so they let go of the keys
+	fmt.Println("Moving your validator keys into memory...")
+	err = c.PauseService(composeFiles)
+	if err != nil {
+		return fmt.Errorf("error stopping Docker containers: %w", err)
+	}
+
+	// Delete the plaintext keys
+	cmd := fmt.Sprintf("%s rm -rf %s/*", rootCmd, validatorsPath)
+	_, err = c.readOutput(cmd)
+	if err != nil {
+		return fmt.Errorf("error deleting validator keys: %w", err)
+	}
+	cmd = fmt.Sprintf("%s rm -rf %s/.[a-zA-Z0-9]*", rootCmd, validatorsPath)
+	_, err = c.readOutput(cmd)
+	if err != nil {
+		return fmt.Errorf("error deleting hidden files in validator folder: %w", err)
+	}
+
+	// Mount the tmpfs
+	cmd = fmt.Sprintf("%s mount -t tmpfs -o size=64m,mode=0770 tmpfs %s", rootCmd, validatorsPath)
+	_, err = c.readOutput(cmd)
+	if err != nil {
+		return fmt.Errorf("error mounting tmpfs for validator keys: %w", err)
+	}
+
+	fmt.Println("Your validator keys will be unlocked from the encrypted archive when the node starts.")
+	return nil
+}
+
 // Get the gas settings
 func (c *Client) GetGasSettings() (float64, float64, uint64) {
 	return c.maxFee, c.maxPrioFee, c.gasLimit
@@ -1364,9 +1585,32 @@ func (c *Client) runApiCall(cmd string) ([]byte, error) {
 	c.maxPrioFee = c.originalMaxPrioFee
 	c.gasLimit = c.originalGasLimit
 
+	// Return errors that have an error code as coded errors, so the caller can check the code
+	if err == nil {
+		var response api.APIResponse
+		if json.Unmarshal(output, &response) == nil && response.ErrorCode != errcodes.None {
+			return output, errcodes.New(response.ErrorCode, response.Error)
+		}
+
+		// Keep any transaction previews until they're shown before the confirmation prompt
+		var previews struct {
+			TxPreviews []api.TransactionPreview `json:"txPreviews"`
+		}
+		if json.Unmarshal(output, &previews) == nil && len(previews.TxPreviews) > 0 {
+			c.txPreviews = previews.TxPreviews
+		}
+	}
+
 	return output, err
 }
 
+// Get the previews of the transactions the last API call that estimated gas is about to send, and clear them
+func (c *Client) TakeTransactionPreviews() []api.TransactionPreview {
+	previews := c.txPreviews
+	c.txPreviews = nil
+	return previews
+}
+
 // Get the API container name
 func (c *Client) getAPIContainerName() (string, error) {
 	cfg, _, err := c.LoadConfig()
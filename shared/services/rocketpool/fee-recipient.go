@@ -7,6 +7,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
 )
 
 // Config
@@ -52,7 +53,7 @@ func UpdateFeeRecipientFile(feeRecipient common.Address, cfg *config.RocketPoolC
 
 	// Write the file
 	path := cfg.Smartnode.GetFeeRecipientFilePath()
-	err := os.WriteFile(path, bytes, FileMode)
+	err := files.WriteFileAtomic(path, bytes, FileMode)
 	if err != nil {
 		return fmt.Errorf("error writing fee recipient file: %w", err)
 	}
@@ -0,0 +1,134 @@
+package rocketpool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Instances
+const (
+	// The folder inside the main config folder that holds the config folders of the additional instances
+	InstancesDir string = "instances"
+
+	// How far each additional instance's ports are moved up from the defaults, so they don't collide with the other instances
+	InstancePortOffsetStep uint16 = 1000
+
+	// The most additional instances that can be created before their ports run out of room
+	maxInstances uint16 = 30
+)
+
+// Instance names are used in folder and container names, so they're kept simple
+var instanceNameRegex = regexp.MustCompile("^[a-z0-9][a-z0-9-]*$")
+
+// Info about a Smartnode instance managed by this CLI
+type InstanceInfo struct {
+	Name       string
+	ConfigPath string
+	Config     *config.RocketPoolConfig
+}
+
+// Make sure an instance name can be used
+func ValidateInstanceName(name string) error {
+	if !instanceNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid instance name [%s]: names can only contain lowercase letters, numbers, and dashes, and must start with a letter or number", name)
+	}
+	return nil
+}
+
+// Get the config folder of an additional instance
+func GetInstanceConfigPath(mainConfigPath string, name string) string {
+	return filepath.Join(mainConfigPath, InstancesDir, name)
+}
+
+// Get the name of the instance this client is using, or an empty string for the main instance
+func (c *Client) InstanceName() string {
+	return c.instanceName
+}
+
+// Get the main instance and all of the additional instances that have been configured
+func (c *Client) GetInstances() ([]InstanceInfo, error) {
+	mainConfigPath, err := homedir.Expand(c.mainConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("error expanding config path: %w", err)
+	}
+
+	// Get the main instance
+	instances := []InstanceInfo{}
+	cfg, err := rp.LoadConfigFromFile(filepath.Join(mainConfigPath, SettingsFile))
+	if err != nil {
+		return nil, fmt.Errorf("error loading the main instance's config: %w", err)
+	}
+	instances = append(instances, InstanceInfo{
+		ConfigPath: mainConfigPath,
+		Config:     cfg,
+	})
+
+	// Get the additional ones
+	instancesFolder := filepath.Join(mainConfigPath, InstancesDir)
+	entries, err := os.ReadDir(instancesFolder)
+	if os.IsNotExist(err) {
+		return instances, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading the instances folder [%s]: %w", instancesFolder, err)
+	}
+	names := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() && ValidateInstanceName(entry.Name()) == nil {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		configPath := GetInstanceConfigPath(mainConfigPath, name)
+		cfg, err := rp.LoadConfigFromFile(filepath.Join(configPath, SettingsFile))
+		if err != nil {
+			return nil, fmt.Errorf("error loading the config for instance [%s]: %w", name, err)
+		}
+		instances = append(instances, InstanceInfo{
+			Name:       name,
+			ConfigPath: configPath,
+			Config:     cfg,
+		})
+	}
+
+	return instances, nil
+}
+
+// Find a port offset that none of the other instances use yet.
+// An instance's offset is worked out from its Execution client's HTTP port, since that's the same for every client.
+func (c *Client) getFreeInstancePortOffset() (uint16, error) {
+	instances, err := c.GetInstances()
+	if err != nil {
+		return 0, err
+	}
+
+	used := map[uint16]bool{}
+	for _, instance := range instances {
+		if instance.Config == nil || instance.Name == c.instanceName {
+			continue
+		}
+		defaultPort, err := instance.Config.ExecutionCommon.HttpPort.GetDefault(instance.Config.GetNetwork())
+		if err != nil {
+			return 0, fmt.Errorf("error getting the default Execution client HTTP port: %w", err)
+		}
+		port := instance.Config.ExecutionCommon.HttpPort.Value.(uint16)
+		if port >= defaultPort.(uint16) {
+			used[(port-defaultPort.(uint16))/InstancePortOffsetStep] = true
+		}
+	}
+
+	for i := uint16(1); i <= maxInstances; i++ {
+		if !used[i] {
+			return i * InstancePortOffsetStep, nil
+		}
+	}
+	return 0, fmt.Errorf("all %d instance port ranges are already in use", maxInstances)
+}
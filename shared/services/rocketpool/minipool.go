@@ -3,6 +3,7 @@ package rocketpool
 import (
 	"fmt"
 	"math/big"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/goccy/go-json"
@@ -216,6 +217,38 @@ func (c *Client) ExitMinipool(address common.Address) (api.ExitMinipoolResponse,
 	return response, nil
 }
 
+// Get the tracked withdrawals and distributions of the node's minipools, reconciled against their balances
+func (c *Client) GetRewardsLedger() (api.MinipoolRewardsLedgerResponse, error) {
+	responseBytes, err := c.callAPI("minipool get-rewards-ledger")
+	if err != nil {
+		return api.MinipoolRewardsLedgerResponse{}, fmt.Errorf("Could not get minipool rewards ledger: %w", err)
+	}
+	var response api.MinipoolRewardsLedgerResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MinipoolRewardsLedgerResponse{}, fmt.Errorf("Could not decode minipool rewards ledger response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MinipoolRewardsLedgerResponse{}, fmt.Errorf("Could not get minipool rewards ledger: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Estimate when a minipool's exit would be processed and when its balance would be withdrawn
+func (c *Client) MinipoolExitEstimate(address common.Address) (api.MinipoolExitEstimateResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool get-exit-estimate %s", address.Hex()))
+	if err != nil {
+		return api.MinipoolExitEstimateResponse{}, fmt.Errorf("Could not get minipool exit estimate: %w", err)
+	}
+	var response api.MinipoolExitEstimateResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MinipoolExitEstimateResponse{}, fmt.Errorf("Could not decode minipool exit estimate response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MinipoolExitEstimateResponse{}, fmt.Errorf("Could not get minipool exit estimate: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check all of the node's minipools for closure eligibility, and return the details of the closeable ones
 func (c *Client) GetMinipoolCloseDetailsForNode() (api.GetMinipoolCloseDetailsForNodeResponse, error) {
 	responseBytes, err := c.callAPI("minipool get-minipool-close-details-for-node")
@@ -360,6 +393,58 @@ func (c *Client) GetVanityArtifacts(depositAmount *big.Int, nodeAddress string)
 	return response, nil
 }
 
+// Survey the node's minipools for outdated delegates, and get the progress of the delegate upgrade campaign
+func (c *Client) GetDelegateUpgradeCampaign() (api.DelegateUpgradeCampaignResponse, error) {
+	responseBytes, err := c.callAPI("minipool get-delegate-upgrade-campaign")
+	if err != nil {
+		return api.DelegateUpgradeCampaignResponse{}, fmt.Errorf("Could not get delegate upgrade campaign: %w", err)
+	}
+	var response api.DelegateUpgradeCampaignResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.DelegateUpgradeCampaignResponse{}, fmt.Errorf("Could not decode get delegate upgrade campaign response: %w", err)
+	}
+	if response.Error != "" {
+		return api.DelegateUpgradeCampaignResponse{}, fmt.Errorf("Could not get delegate upgrade campaign: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Opt minipools in or out of automatic delegate upgrades
+func (c *Client) SetAutoUpgradeDelegate(addresses []common.Address, enabled bool) (api.SetAutoUpgradeDelegateResponse, error) {
+	addressStrings := make([]string, len(addresses))
+	for i, address := range addresses {
+		addressStrings[i] = address.Hex()
+	}
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool set-auto-upgrade-delegate %s %t", strings.Join(addressStrings, ","), enabled))
+	if err != nil {
+		return api.SetAutoUpgradeDelegateResponse{}, fmt.Errorf("Could not set delegate auto-upgrade: %w", err)
+	}
+	var response api.SetAutoUpgradeDelegateResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SetAutoUpgradeDelegateResponse{}, fmt.Errorf("Could not decode set delegate auto-upgrade response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SetAutoUpgradeDelegateResponse{}, fmt.Errorf("Could not set delegate auto-upgrade: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Create the deposit data and keystore for a minipool's validator
+func (c *Client) ExportMinipoolDepositData(address common.Address, password string) (api.ExportDepositDataResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool export-deposit-data %s", address.Hex()), password)
+	if err != nil {
+		return api.ExportDepositDataResponse{}, fmt.Errorf("Could not export minipool deposit data: %w", err)
+	}
+	var response api.ExportDepositDataResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ExportDepositDataResponse{}, fmt.Errorf("Could not decode export minipool deposit data response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ExportDepositDataResponse{}, fmt.Errorf("Could not export minipool deposit data: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether the minipool can begin the bond reduction process
 func (c *Client) CanBeginReduceBondAmount(address common.Address, newBondAmountWei *big.Int) (api.CanBeginReduceBondAmountResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("minipool can-begin-reduce-bond-amount %s %s", address.Hex(), newBondAmountWei.String()))
@@ -457,17 +542,17 @@ func (c *Client) DistributeBalance(address common.Address) (api.DistributeBalanc
 }
 
 // Import a validator private key for a vacant minipool
-func (c *Client) ImportKey(address common.Address, mnemonic string) (api.ChangeWithdrawalCredentialsResponse, error) {
+func (c *Client) ImportKey(address common.Address, mnemonic string) (api.ImportKeyResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("minipool import-key %s", address.Hex()), mnemonic)
 	if err != nil {
-		return api.ChangeWithdrawalCredentialsResponse{}, fmt.Errorf("Could not import validator key: %w", err)
+		return api.ImportKeyResponse{}, fmt.Errorf("Could not import validator key: %w", err)
 	}
-	var response api.ChangeWithdrawalCredentialsResponse
+	var response api.ImportKeyResponse
 	if err := json.Unmarshal(responseBytes, &response); err != nil {
-		return api.ChangeWithdrawalCredentialsResponse{}, fmt.Errorf("Could not decode import-key response: %w", err)
+		return api.ImportKeyResponse{}, fmt.Errorf("Could not decode import-key response: %w", err)
 	}
 	if response.Error != "" {
-		return api.ChangeWithdrawalCredentialsResponse{}, fmt.Errorf("Could not import validator key: %s", response.Error)
+		return api.ImportKeyResponse{}, fmt.Errorf("Could not import validator key: %s", response.Error)
 	}
 	return response, nil
 }
@@ -504,6 +589,22 @@ func (c *Client) ChangeWithdrawalCredentials(address common.Address, mnemonic st
 	return response, nil
 }
 
+// Find the node's minipools that are stuck in prelaunch or dissolved, and diagnose why
+func (c *Client) GetStuckMinipools() (api.GetStuckMinipoolsResponse, error) {
+	responseBytes, err := c.callAPI("minipool get-stuck-minipools")
+	if err != nil {
+		return api.GetStuckMinipoolsResponse{}, fmt.Errorf("Could not get stuck minipools: %w", err)
+	}
+	var response api.GetStuckMinipoolsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.GetStuckMinipoolsResponse{}, fmt.Errorf("Could not decode get stuck minipools response: %w", err)
+	}
+	if response.Error != "" {
+		return api.GetStuckMinipoolsResponse{}, fmt.Errorf("Could not get stuck minipools: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check all of the node's minipools for rescue eligibility, and return the details of the rescuable ones
 func (c *Client) GetMinipoolRescueDissolvedDetailsForNode() (api.GetMinipoolRescueDissolvedDetailsForNodeResponse, error) {
 	responseBytes, err := c.callAPI("minipool get-rescue-dissolved-details-for-node")
@@ -65,6 +65,22 @@ func (c *Client) NetworkStats() (api.NetworkStatsResponse, error) {
 	return response, nil
 }
 
+// Get deposit pool and minipool queue stats
+func (c *Client) QueueStats() (api.NetworkQueueStatsResponse, error) {
+	responseBytes, err := c.callAPI("network queue-stats")
+	if err != nil {
+		return api.NetworkQueueStatsResponse{}, fmt.Errorf("Could not get queue stats: %w", err)
+	}
+	var response api.NetworkQueueStatsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkQueueStatsResponse{}, fmt.Errorf("Could not decode queue stats response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkQueueStatsResponse{}, fmt.Errorf("Could not get queue stats: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Get the timezone map
 func (c *Client) TimezoneMap() (api.NetworkTimezonesResponse, error) {
 	responseBytes, err := c.callAPI("network timezone-map")
@@ -129,6 +145,38 @@ func (c *Client) GetActiveDAOProposals() (api.NetworkDAOProposalsResponse, error
 	return response, nil
 }
 
+// Check whether the node can vote on a Snapshot DAO proposal
+func (c *Client) CanVoteOnDAOProposal(proposalId string, choice uint64) (api.NetworkCanVoteOnDAOProposalResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network can-vote-dao-proposal %s %d", proposalId, choice))
+	if err != nil {
+		return api.NetworkCanVoteOnDAOProposalResponse{}, fmt.Errorf("could not request can-vote-dao-proposal: %w", err)
+	}
+	var response api.NetworkCanVoteOnDAOProposalResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkCanVoteOnDAOProposalResponse{}, fmt.Errorf("could not decode can-vote-dao-proposal response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkCanVoteOnDAOProposalResponse{}, fmt.Errorf("error after requesting can-vote-dao-proposal: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Sign and submit a vote on a Snapshot DAO proposal
+func (c *Client) VoteOnDAOProposal(proposalId string, choice uint64) (api.NetworkVoteOnDAOProposalResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network vote-dao-proposal %s %d", proposalId, choice))
+	if err != nil {
+		return api.NetworkVoteOnDAOProposalResponse{}, fmt.Errorf("could not request vote-dao-proposal: %w", err)
+	}
+	var response api.NetworkVoteOnDAOProposalResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkVoteOnDAOProposalResponse{}, fmt.Errorf("could not decode vote-dao-proposal response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkVoteOnDAOProposalResponse{}, fmt.Errorf("error after requesting vote-dao-proposal: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Download a rewards info file from IPFS for the given interval
 func (c *Client) DownloadRewardsFile(interval uint64) (api.DownloadRewardsFileResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("network download-rewards-file %d", interval))
@@ -145,6 +193,54 @@ func (c *Client) DownloadRewardsFile(interval uint64) (api.DownloadRewardsFileRe
 	return response, nil
 }
 
+// Get the node's rewards and attestation performance for every finalized interval
+func (c *Client) RewardsHistory(includePerformance bool) (api.RewardsHistoryResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network rewards-history %t", includePerformance))
+	if err != nil {
+		return api.RewardsHistoryResponse{}, fmt.Errorf("could not get rewards history: %w", err)
+	}
+	var response api.RewardsHistoryResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RewardsHistoryResponse{}, fmt.Errorf("could not decode rewards-history response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RewardsHistoryResponse{}, fmt.Errorf("could not get rewards history: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Query the minipool performance file for an interval
+func (c *Client) MinipoolPerformance(interval string, below float64, limit uint64, node string) (api.MinipoolPerformanceResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network minipool-performance %s %f %d", interval, below, limit), node)
+	if err != nil {
+		return api.MinipoolPerformanceResponse{}, fmt.Errorf("could not get minipool performance: %w", err)
+	}
+	var response api.MinipoolPerformanceResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MinipoolPerformanceResponse{}, fmt.Errorf("could not decode minipool-performance response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MinipoolPerformanceResponse{}, fmt.Errorf("could not get minipool performance: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Recompute the attestation performance of a random sample of minipools in an interval's performance file
+func (c *Client) AuditPerformance(interval uint64, samples uint64, epochs uint64, seed string) (api.AuditPerformanceResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network audit-performance %d %d %d", interval, samples, epochs), seed)
+	if err != nil {
+		return api.AuditPerformanceResponse{}, fmt.Errorf("could not audit the minipool performance file: %w", err)
+	}
+	var response api.AuditPerformanceResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.AuditPerformanceResponse{}, fmt.Errorf("could not decode audit-performance response: %w", err)
+	}
+	if response.Error != "" {
+		return api.AuditPerformanceResponse{}, fmt.Errorf("could not audit the minipool performance file: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check if Houston has been deployed yet
 func (c *Client) IsHoustonDeployed() (api.IsHoustonDeployedResponse, error) {
 	responseBytes, err := c.callAPI("network is-houston-deployed")
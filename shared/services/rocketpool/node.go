@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/goccy/go-json"
@@ -584,6 +585,38 @@ func (c *Client) CanNodeDeposit(amountWei *big.Int, minFee float64, salt *big.In
 	return response, nil
 }
 
+// Get the node's income events between two times
+func (c *Client) GetIncomeEvents(startTime time.Time, endTime time.Time) (api.NodeIncomeEventsResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node get-income-events %d %d", startTime.Unix(), endTime.Unix()))
+	if err != nil {
+		return api.NodeIncomeEventsResponse{}, fmt.Errorf("Could not get node income events: %w", err)
+	}
+	var response api.NodeIncomeEventsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeIncomeEventsResponse{}, fmt.Errorf("Could not decode node income events response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeIncomeEventsResponse{}, fmt.Errorf("Could not get node income events: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Run the pre-flight checks for a node deposit
+func (c *Client) SimulateNodeDeposit(amountWei *big.Int, minFee float64, salt *big.Int) (api.NodeSimulateDepositResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node simulate-deposit %s %f %s", amountWei.String(), minFee, salt.String()))
+	if err != nil {
+		return api.NodeSimulateDepositResponse{}, fmt.Errorf("Could not simulate node deposit: %w", err)
+	}
+	var response api.NodeSimulateDepositResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeSimulateDepositResponse{}, fmt.Errorf("Could not decode simulate node deposit response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeSimulateDepositResponse{}, fmt.Errorf("Could not simulate node deposit: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Make a node deposit
 func (c *Client) NodeDeposit(amountWei *big.Int, minFee float64, salt *big.Int, useCreditBalance bool, submit bool) (api.NodeDepositResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("node deposit %s %f %s %t %t", amountWei.String(), minFee, salt.String(), useCreditBalance, submit))
@@ -600,6 +633,38 @@ func (c *Client) NodeDeposit(amountWei *big.Int, minFee float64, salt *big.Int,
 	return response, nil
 }
 
+// Create the deposit data and keystore for the validator of the node's next minipool
+func (c *Client) ExportDepositData(salt *big.Int, password string) (api.ExportDepositDataResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node export-deposit-data %s", salt.String()), password)
+	if err != nil {
+		return api.ExportDepositDataResponse{}, fmt.Errorf("Could not export deposit data: %w", err)
+	}
+	var response api.ExportDepositDataResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ExportDepositDataResponse{}, fmt.Errorf("Could not decode export deposit data response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ExportDepositDataResponse{}, fmt.Errorf("Could not export deposit data: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check deposit data created elsewhere against the minipool the node would create with the given salt
+func (c *Client) ValidateDepositData(salt *big.Int, depositDataJson string) (api.ValidateDepositDataResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node validate-deposit-data %s", salt.String()), depositDataJson)
+	if err != nil {
+		return api.ValidateDepositDataResponse{}, fmt.Errorf("Could not validate deposit data: %w", err)
+	}
+	var response api.ValidateDepositDataResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ValidateDepositDataResponse{}, fmt.Errorf("Could not decode validate deposit data response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ValidateDepositDataResponse{}, fmt.Errorf("Could not validate deposit data: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether the node can send tokens
 func (c *Client) CanNodeSend(amountWei *big.Int, token string, toAddress common.Address) (api.CanNodeSendResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("node can-send %s %s %s", amountWei.String(), token, toAddress.Hex()))
@@ -664,6 +729,70 @@ func (c *Client) NodeBurn(amountWei *big.Int, token string) (api.NodeBurnRespons
 	return response, nil
 }
 
+// Compare the protocol's rETH rate against DEX quotes
+func (c *Client) GetRethArbitrage(amountWei *big.Int, direction string) (api.NodeRethArbitrageResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node get-reth-arbitrage %s %s", amountWei.String(), direction))
+	if err != nil {
+		return api.NodeRethArbitrageResponse{}, fmt.Errorf("Could not get rETH arbitrage routes: %w", err)
+	}
+	var response api.NodeRethArbitrageResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeRethArbitrageResponse{}, fmt.Errorf("Could not decode rETH arbitrage response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeRethArbitrageResponse{}, fmt.Errorf("Could not get rETH arbitrage routes: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Deposit ETH for rETH through the provided route
+func (c *Client) RouteRethDeposit(amountWei *big.Int, source string, minOutput *big.Int) (api.NodeRouteRethDepositResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node route-reth-deposit %s %s %s", amountWei.String(), source, minOutput.String()))
+	if err != nil {
+		return api.NodeRouteRethDepositResponse{}, fmt.Errorf("Could not route rETH deposit: %w", err)
+	}
+	var response api.NodeRouteRethDepositResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeRouteRethDepositResponse{}, fmt.Errorf("Could not decode route rETH deposit response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeRouteRethDepositResponse{}, fmt.Errorf("Could not route rETH deposit: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the swap transaction for depositing ETH for rETH through a DEX quote source, along with its simulated output
+func (c *Client) GetRethDepositSwap(amountWei *big.Int, source string) (api.NodeRethDepositSwapResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node get-reth-deposit-swap %s %s", amountWei.String(), source))
+	if err != nil {
+		return api.NodeRethDepositSwapResponse{}, fmt.Errorf("Could not get rETH deposit swap: %w", err)
+	}
+	var response api.NodeRethDepositSwapResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeRethDepositSwapResponse{}, fmt.Errorf("Could not decode rETH deposit swap response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeRethDepositSwapResponse{}, fmt.Errorf("Could not get rETH deposit swap: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Send a previewed DEX swap of ETH for rETH
+func (c *Client) SwapRethDeposit(value *big.Int, to common.Address, data []byte, minOutput *big.Int) (api.NodeRouteRethDepositResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node swap-reth-deposit %s %s %s %s", value.String(), to.Hex(), hex.EncodeToString(data), minOutput.String()))
+	if err != nil {
+		return api.NodeRouteRethDepositResponse{}, fmt.Errorf("Could not swap rETH deposit: %w", err)
+	}
+	var response api.NodeRouteRethDepositResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeRouteRethDepositResponse{}, fmt.Errorf("Could not decode swap rETH deposit response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeRouteRethDepositResponse{}, fmt.Errorf("Could not swap rETH deposit: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Get node sync progress
 func (c *Client) NodeSync() (api.NodeSyncProgressResponse, error) {
 	responseBytes, err := c.callAPI("node sync")
@@ -680,6 +809,57 @@ func (c *Client) NodeSync() (api.NodeSyncProgressResponse, error) {
 	return response, nil
 }
 
+// Get the node's minipools and their validators, optionally skipping the node process's network state snapshot
+func (c *Client) NodeValidators(refresh bool) (api.NodeValidatorsResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node validators %t", refresh))
+	if err != nil {
+		return api.NodeValidatorsResponse{}, fmt.Errorf("Could not get node validators: %w", err)
+	}
+	var response api.NodeValidatorsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeValidatorsResponse{}, fmt.Errorf("Could not decode node validators response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeValidatorsResponse{}, fmt.Errorf("Could not get node validators: %s", response.Error)
+	}
+	for i := range response.Validators {
+		utils.ZeroIfNil(&response.Validators[i].NodeShareOfTotal)
+	}
+	return response, nil
+}
+
+// Get the node's sync committee assignments and check that it's ready for them
+func (c *Client) NodeSyncCommittee() (api.NodeSyncCommitteeResponse, error) {
+	responseBytes, err := c.callAPI("node sync-committee")
+	if err != nil {
+		return api.NodeSyncCommitteeResponse{}, fmt.Errorf("Could not get node sync committee duties: %w", err)
+	}
+	var response api.NodeSyncCommitteeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeSyncCommitteeResponse{}, fmt.Errorf("Could not decode node sync committee response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeSyncCommitteeResponse{}, fmt.Errorf("Could not get node sync committee duties: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the attestation performance of the node's validators in each finished epoch since the start epoch
+func (c *Client) NodeAttestationPerformance(startEpoch uint64) (api.NodeAttestationPerformanceResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node attestation-performance %d", startEpoch))
+	if err != nil {
+		return api.NodeAttestationPerformanceResponse{}, fmt.Errorf("Could not get node attestation performance: %w", err)
+	}
+	var response api.NodeAttestationPerformanceResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeAttestationPerformanceResponse{}, fmt.Errorf("Could not decode node attestation performance response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeAttestationPerformanceResponse{}, fmt.Errorf("Could not get node attestation performance: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether the node has RPL rewards available to claim
 func (c *Client) CanNodeClaimRpl() (api.CanNodeClaimRplResponse, error) {
 	responseBytes, err := c.callAPI("node can-claim-rpl-rewards")
@@ -728,6 +908,22 @@ func (c *Client) NodeRewards() (api.NodeRewardsResponse, error) {
 	return response, nil
 }
 
+// Preview the node's rewards for the current interval so far from the local rolling record
+func (c *Client) PreviewRewards() (api.NodePreviewRewardsResponse, error) {
+	responseBytes, err := c.callAPI("node preview-rewards")
+	if err != nil {
+		return api.NodePreviewRewardsResponse{}, fmt.Errorf("Could not preview node rewards: %w", err)
+	}
+	var response api.NodePreviewRewardsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodePreviewRewardsResponse{}, fmt.Errorf("Could not decode preview rewards response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodePreviewRewardsResponse{}, fmt.Errorf("Could not preview node rewards: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Get the deposit contract info for Rocket Pool and the Beacon Client
 func (c *Client) DepositContractInfo() (api.DepositContractInfoResponse, error) {
 	responseBytes, err := c.callAPI("node deposit-contract-info")
@@ -1061,11 +1257,30 @@ func (c *Client) ReverseResolveEnsName(name string) (api.ResolveEnsNameResponse,
 	return response, nil
 }
 
-// Use the node private key to sign an arbitrary message
-func (c *Client) SignMessage(message string) (api.NodeSignResponse, error) {
+// Get a one-time confirmation code for signing a message with the node private key
+func (c *Client) RequestSignMessage(message string) (api.NodeRequestSignMessageResponse, error) {
 	// Ignore sync status so we can sign messages even without ready clients
 	c.ignoreSyncCheck = true
-	responseBytes, err := c.callAPI("node sign-message", message)
+	responseBytes, err := c.callAPI("node request-sign-message", message)
+	if err != nil {
+		return api.NodeRequestSignMessageResponse{}, fmt.Errorf("Could not request message signature: %w", err)
+	}
+
+	var response api.NodeRequestSignMessageResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeRequestSignMessageResponse{}, fmt.Errorf("Could not decode node request sign response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeRequestSignMessageResponse{}, fmt.Errorf("Could not request message signature: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Use the node private key to sign an arbitrary message, with the confirmation code from RequestSignMessage
+func (c *Client) SignMessage(code string, message string) (api.NodeSignResponse, error) {
+	// Ignore sync status so we can sign messages even without ready clients
+	c.ignoreSyncCheck = true
+	responseBytes, err := c.callAPI("node sign-message", code, message)
 	if err != nil {
 		return api.NodeSignResponse{}, fmt.Errorf("Could not sign message: %w", err)
 	}
@@ -1080,6 +1295,94 @@ func (c *Client) SignMessage(message string) (api.NodeSignResponse, error) {
 	return response, nil
 }
 
+// Check that an EIP-191 signature of a message was made by the provided address
+func (c *Client) VerifyMessage(address common.Address, message string, signature string) (api.NodeVerifyMessageResponse, error) {
+	// Ignore sync status since this doesn't need the clients
+	c.ignoreSyncCheck = true
+	responseBytes, err := c.callAPI("node verify-message", address.Hex(), message, signature)
+	if err != nil {
+		return api.NodeVerifyMessageResponse{}, fmt.Errorf("Could not verify message: %w", err)
+	}
+
+	var response api.NodeVerifyMessageResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeVerifyMessageResponse{}, fmt.Errorf("Could not decode node verify message response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeVerifyMessageResponse{}, fmt.Errorf("Could not verify message: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Decode a serialized transaction or its calldata
+func (c *Client) DecodeTransaction(data string) (api.NodeDecodeTransactionResponse, error) {
+	responseBytes, err := c.callAPI("node decode-tx", data)
+	if err != nil {
+		return api.NodeDecodeTransactionResponse{}, fmt.Errorf("Could not decode transaction: %w", err)
+	}
+
+	var response api.NodeDecodeTransactionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeDecodeTransactionResponse{}, fmt.Errorf("Could not decode node decode-tx response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeDecodeTransactionResponse{}, fmt.Errorf("Could not decode transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the chain ID, pending nonce, and gas estimate for a transaction that will be signed on an air-gapped machine
+func (c *Client) PrepareOfflineTransaction(from common.Address, to common.Address, amountWei *big.Int, data []byte) (api.NodePrepareOfflineTransactionResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node prepare-offline-tx %s %s %s %s", from.Hex(), to.Hex(), amountWei.String(), "0x"+hex.EncodeToString(data)))
+	if err != nil {
+		return api.NodePrepareOfflineTransactionResponse{}, fmt.Errorf("Could not prepare offline transaction: %w", err)
+	}
+
+	var response api.NodePrepareOfflineTransactionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodePrepareOfflineTransactionResponse{}, fmt.Errorf("Could not decode node prepare-offline-tx response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodePrepareOfflineTransactionResponse{}, fmt.Errorf("Could not prepare offline transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Sign a transaction exported from an online node, without needing the clients to be ready
+func (c *Client) SignOfflineTransaction(serializedTx []byte) (api.NodeSignOfflineTransactionResponse, error) {
+	c.ignoreSyncCheck = true
+	responseBytes, err := c.callAPI("node sign-offline-tx", hex.EncodeToString(serializedTx))
+	if err != nil {
+		return api.NodeSignOfflineTransactionResponse{}, fmt.Errorf("Could not sign offline transaction: %w", err)
+	}
+
+	var response api.NodeSignOfflineTransactionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeSignOfflineTransactionResponse{}, fmt.Errorf("Could not decode node sign-offline-tx response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeSignOfflineTransactionResponse{}, fmt.Errorf("Could not sign offline transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Broadcast a transaction that was signed on an air-gapped machine
+func (c *Client) BroadcastOfflineTransaction(serializedTx []byte) (api.NodeBroadcastOfflineTransactionResponse, error) {
+	responseBytes, err := c.callAPI("node broadcast-offline-tx", hex.EncodeToString(serializedTx))
+	if err != nil {
+		return api.NodeBroadcastOfflineTransactionResponse{}, fmt.Errorf("Could not broadcast offline transaction: %w", err)
+	}
+
+	var response api.NodeBroadcastOfflineTransactionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeBroadcastOfflineTransactionResponse{}, fmt.Errorf("Could not decode node broadcast-offline-tx response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeBroadcastOfflineTransactionResponse{}, fmt.Errorf("Could not broadcast offline transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether a vacant minipool can be created for solo staker migration
 func (c *Client) CanCreateVacantMinipool(amountWei *big.Int, minFee float64, salt *big.Int, pubkey types.ValidatorPubkey) (api.CanCreateVacantMinipoolResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("node can-create-vacant-minipool %s %f %s %s", amountWei.String(), minFee, salt.String(), pubkey.Hex()))
@@ -852,3 +852,67 @@ func (c *Client) GetTNDAOMinipoolSettings() (api.GetTNDAOMinipoolSettingsRespons
 	}
 	return response, nil
 }
+
+// Get the latest network balances report generated by the watchtower
+func (c *Client) TNDAOBalancesReport() (api.TNDAOBalancesReportResponse, error) {
+	responseBytes, err := c.callAPI("odao balances-report")
+	if err != nil {
+		return api.TNDAOBalancesReportResponse{}, fmt.Errorf("Could not get oracle DAO balances report: %w", err)
+	}
+	var response api.TNDAOBalancesReportResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.TNDAOBalancesReportResponse{}, fmt.Errorf("Could not decode oracle DAO balances report response: %w", err)
+	}
+	if response.Error != "" {
+		return api.TNDAOBalancesReportResponse{}, fmt.Errorf("Could not get oracle DAO balances report: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether the node can submit a rewards tree that was generated on another machine
+func (c *Client) CanImportRewardsTree(index uint64) (api.CanImportRewardsTreeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("odao can-import-rewards-tree %d", index))
+	if err != nil {
+		return api.CanImportRewardsTreeResponse{}, fmt.Errorf("Could not get can import rewards tree status: %w", err)
+	}
+	var response api.CanImportRewardsTreeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanImportRewardsTreeResponse{}, fmt.Errorf("Could not decode can import rewards tree response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanImportRewardsTreeResponse{}, fmt.Errorf("Could not get can import rewards tree status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Submit a rewards tree that was generated on another machine
+func (c *Client) ImportRewardsTree(index uint64) (api.ImportRewardsTreeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("odao import-rewards-tree %d", index))
+	if err != nil {
+		return api.ImportRewardsTreeResponse{}, fmt.Errorf("Could not import rewards tree: %w", err)
+	}
+	var response api.ImportRewardsTreeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ImportRewardsTreeResponse{}, fmt.Errorf("Could not decode import rewards tree response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ImportRewardsTreeResponse{}, fmt.Errorf("Could not import rewards tree: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the pinning status of the rewards files submitted by the watchtower
+func (c *Client) TNDAOPinningStatus() (api.TNDAOPinningStatusResponse, error) {
+	responseBytes, err := c.callAPI("odao pinning-status")
+	if err != nil {
+		return api.TNDAOPinningStatusResponse{}, fmt.Errorf("Could not get oracle DAO pinning status: %w", err)
+	}
+	var response api.TNDAOPinningStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.TNDAOPinningStatusResponse{}, fmt.Errorf("Could not decode oracle DAO pinning status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.TNDAOPinningStatusResponse{}, fmt.Errorf("Could not get oracle DAO pinning status: %s", response.Error)
+	}
+	return response, nil
+}
@@ -575,6 +575,22 @@ func (c *Client) PDAODefeatProposal(proposalID uint64, index uint64) (api.PDAODe
 	return response, nil
 }
 
+// Compare a proposal's voting power tree root against a locally generated tree
+func (c *Client) PDAOVerifyProposalRoot(proposalID uint64) (api.PDAOVerifyProposalRootResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("pdao verify-proposal-root %d", proposalID))
+	if err != nil {
+		return api.PDAOVerifyProposalRootResponse{}, fmt.Errorf("Could not get protocol DAO verify-proposal-root: %w", err)
+	}
+	var response api.PDAOVerifyProposalRootResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PDAOVerifyProposalRootResponse{}, fmt.Errorf("Could not decode protocol DAO verify-proposal-root response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PDAOVerifyProposalRootResponse{}, fmt.Errorf("Could not get protocol DAO verify-proposal-root: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether the node can finalize a proposal
 func (c *Client) PDAOCanFinalizeProposal(proposalID uint64) (api.PDAOCanFinalizeProposalResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("pdao can-finalize-proposal %d", proposalID))
@@ -2,6 +2,8 @@ package rocketpool
 
 import (
 	"fmt"
+	"math/big"
+	"time"
 
 	"github.com/goccy/go-json"
 
@@ -41,6 +43,246 @@ func (c *Client) GetClientStatus() (api.ClientStatusResponse, error) {
 	return response, nil
 }
 
+// Gets the status shown on the live dashboard
+func (c *Client) GetDashboard() (api.DashboardResponse, error) {
+	responseBytes, err := c.callAPI("service get-dashboard")
+	if err != nil {
+		return api.DashboardResponse{}, fmt.Errorf("Could not get dashboard status: %w", err)
+	}
+	var response api.DashboardResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.DashboardResponse{}, fmt.Errorf("Could not decode dashboard status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.DashboardResponse{}, fmt.Errorf("Could not get dashboard status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Checks the rolling record checkpoints for corruption, optionally repairing them
+func (c *Client) CheckRecords(repair bool) (api.CheckRecordsResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("service check-records %t", repair))
+	if err != nil {
+		return api.CheckRecordsResponse{}, fmt.Errorf("Could not check rolling records: %w", err)
+	}
+	var response api.CheckRecordsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CheckRecordsResponse{}, fmt.Errorf("Could not decode check-records response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CheckRecordsResponse{}, fmt.Errorf("Could not check rolling records: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Gets the watchtower's record of the submissions it has made, for one kind or "all" of them
+func (c *Client) GetSubmissionLedger(kind string) (api.SubmissionLedgerResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("service submission-ledger %s", kind))
+	if err != nil {
+		return api.SubmissionLedgerResponse{}, fmt.Errorf("Could not get the submission ledger: %w", err)
+	}
+	var response api.SubmissionLedgerResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SubmissionLedgerResponse{}, fmt.Errorf("Could not decode submission-ledger response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SubmissionLedgerResponse{}, fmt.Errorf("Could not get the submission ledger: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Checks the node for common problems, most urgent first
+func (c *Client) RunDoctor() (api.DoctorResponse, error) {
+	responseBytes, err := c.callAPI("service doctor")
+	if err != nil {
+		return api.DoctorResponse{}, fmt.Errorf("Could not run the doctor's checks: %w", err)
+	}
+	var response api.DoctorResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.DoctorResponse{}, fmt.Errorf("Could not decode doctor response: %w", err)
+	}
+	if response.Error != "" {
+		return api.DoctorResponse{}, fmt.Errorf("Could not run the doctor's checks: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Captures the running daemons' profiles into a bundle in the profiles folder
+func (c *Client) CaptureProfile(duration time.Duration) (api.CaptureProfileResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("service capture-profile %s", duration.String()))
+	if err != nil {
+		return api.CaptureProfileResponse{}, fmt.Errorf("Could not capture profiles: %w", err)
+	}
+	var response api.CaptureProfileResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CaptureProfileResponse{}, fmt.Errorf("Could not decode capture-profile response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CaptureProfileResponse{}, fmt.Errorf("Could not capture profiles: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Sends ETH and RPL to the node wallet from the local devnet's funder account
+func (c *Client) DevnetFund(ethAmountWei *big.Int, rplAmountWei *big.Int) (api.DevnetFundResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("service devnet-fund %s %s", ethAmountWei.String(), rplAmountWei.String()))
+	if err != nil {
+		return api.DevnetFundResponse{}, fmt.Errorf("Could not fund node on the devnet: %w", err)
+	}
+	var response api.DevnetFundResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.DevnetFundResponse{}, fmt.Errorf("Could not decode devnet-fund response: %w", err)
+	}
+	if response.Error != "" {
+		return api.DevnetFundResponse{}, fmt.Errorf("Could not fund node on the devnet: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Advances the local devnet's clock past the end of the current rewards interval, plus any extra intervals
+func (c *Client) DevnetFastForward(intervals uint64) (api.DevnetFastForwardResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("service devnet-fast-forward %d", intervals))
+	if err != nil {
+		return api.DevnetFastForwardResponse{}, fmt.Errorf("Could not fast-forward the devnet: %w", err)
+	}
+	var response api.DevnetFastForwardResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.DevnetFastForwardResponse{}, fmt.Errorf("Could not decode devnet-fast-forward response: %w", err)
+	}
+	if response.Error != "" {
+		return api.DevnetFastForwardResponse{}, fmt.Errorf("Could not fast-forward the devnet: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Backs up the selected components of the Smartnode's state into an encrypted archive in the backups folder
+func (c *Client) CreateBackup(components string, password string) (api.CreateBackupResponse, error) {
+	responseBytes, err := c.callAPI("service create-backup", components, password)
+	if err != nil {
+		return api.CreateBackupResponse{}, fmt.Errorf("Could not create backup: %w", err)
+	}
+	var response api.CreateBackupResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CreateBackupResponse{}, fmt.Errorf("Could not decode create-backup response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CreateBackupResponse{}, fmt.Errorf("Could not create backup: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Verifies a backup and restores the selected components ("all" for every component in the backup), or only verifies it
+func (c *Client) RestoreBackup(filename string, components string, password string, verifyOnly bool) (api.RestoreBackupResponse, error) {
+	responseBytes, err := c.callAPI("service restore-backup", filename, components, password, fmt.Sprint(verifyOnly))
+	if err != nil {
+		return api.RestoreBackupResponse{}, fmt.Errorf("Could not restore backup: %w", err)
+	}
+	var response api.RestoreBackupResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RestoreBackupResponse{}, fmt.Errorf("Could not decode restore-backup response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RestoreBackupResponse{}, fmt.Errorf("Could not restore backup: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Sets the password the node daemon encrypts scheduled backups with
+func (c *Client) SetBackupPassword(password string) (api.SetBackupPasswordResponse, error) {
+	responseBytes, err := c.callAPI("service set-backup-password", password)
+	if err != nil {
+		return api.SetBackupPasswordResponse{}, fmt.Errorf("Could not set backup password: %w", err)
+	}
+	var response api.SetBackupPasswordResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SetBackupPasswordResponse{}, fmt.Errorf("Could not decode set-backup-password response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SetBackupPasswordResponse{}, fmt.Errorf("Could not set backup password: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get this machine's host ID, the active host beacons, and any conflict that's blocking validator duties
+func (c *Client) GetActiveHostStatus() (api.ActiveHostStatusResponse, error) {
+	responseBytes, err := c.callAPI("service get-active-host")
+	if err != nil {
+		return api.ActiveHostStatusResponse{}, fmt.Errorf("Could not get active host status: %w", err)
+	}
+	var response api.ActiveHostStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ActiveHostStatusResponse{}, fmt.Errorf("Could not decode get-active-host response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ActiveHostStatusResponse{}, fmt.Errorf("Could not get active host status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the port mappings and external IP address the node daemon last got from the router
+func (c *Client) GetPortForwardingStatus() (api.PortForwardingStatusResponse, error) {
+	responseBytes, err := c.callAPI("service get-port-forwarding")
+	if err != nil {
+		return api.PortForwardingStatusResponse{}, fmt.Errorf("Could not get port forwarding status: %w", err)
+	}
+	var response api.PortForwardingStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PortForwardingStatusResponse{}, fmt.Errorf("Could not decode get-port-forwarding response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PortForwardingStatusResponse{}, fmt.Errorf("Could not get port forwarding status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Claim this machine as the active host so the node daemon resumes validator duties
+func (c *Client) ClaimActiveHost() (api.ClaimActiveHostResponse, error) {
+	responseBytes, err := c.callAPI("service claim-active-host")
+	if err != nil {
+		return api.ClaimActiveHostResponse{}, fmt.Errorf("Could not claim active host: %w", err)
+	}
+	var response api.ClaimActiveHostResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ClaimActiveHostResponse{}, fmt.Errorf("Could not decode claim-active-host response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ClaimActiveHostResponse{}, fmt.Errorf("Could not claim active host: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Sends a test alert to every enabled notification sink
+func (c *Client) TestAlert(severity string) (api.TestAlertResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("service test-alert %s", severity))
+	if err != nil {
+		return api.TestAlertResponse{}, fmt.Errorf("Could not send test alert: %w", err)
+	}
+	var response api.TestAlertResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.TestAlertResponse{}, fmt.Errorf("Could not decode test-alert response: %w", err)
+	}
+	if response.Error != "" {
+		return api.TestAlertResponse{}, fmt.Errorf("Could not send test alert: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Sends a synthetic failure alert through the alerting pipeline
+func (c *Client) TestFailure(scenario string) (api.TestFailureResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("service test-failure %s", scenario))
+	if err != nil {
+		return api.TestFailureResponse{}, fmt.Errorf("Could not send test failure: %w", err)
+	}
+	var response api.TestFailureResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.TestFailureResponse{}, fmt.Errorf("Could not decode test-failure response: %w", err)
+	}
+	if response.Error != "" {
+		return api.TestFailureResponse{}, fmt.Errorf("Could not send test failure: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Restarts the Validator client
 func (c *Client) RestartVc() (api.RestartVcResponse, error) {
 	responseBytes, err := c.callAPI("service restart-vc")
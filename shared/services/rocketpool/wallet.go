@@ -2,6 +2,8 @@ package rocketpool
 
 import (
 	"fmt"
+	"math/big"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/goccy/go-json"
@@ -149,6 +151,31 @@ func (c *Client) TestSearchAndRecoverWallet(mnemonic string, address common.Addr
 	return response, nil
 }
 
+// Verify that a mnemonic recovers the node wallet and its validator keys, without writing anything
+func (c *Client) VerifyRecovery(mnemonic string, expectedAddress common.Address, derivationPath string, walletIndex uint) (api.VerifyRecoveryResponse, error) {
+	command := "wallet verify-recovery "
+	if expectedAddress != (common.Address{}) {
+		command += fmt.Sprintf("--address %s ", expectedAddress.Hex())
+	}
+	if walletIndex != 0 {
+		command += fmt.Sprintf("--wallet-index %d ", walletIndex)
+	}
+	command += "--derivation-path"
+
+	responseBytes, err := c.callAPI(command, derivationPath, mnemonic)
+	if err != nil {
+		return api.VerifyRecoveryResponse{}, fmt.Errorf("Could not verify recovery: %w", err)
+	}
+	var response api.VerifyRecoveryResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.VerifyRecoveryResponse{}, fmt.Errorf("Could not decode verify recovery response: %w", err)
+	}
+	if response.Error != "" {
+		return api.VerifyRecoveryResponse{}, fmt.Errorf("Could not verify recovery: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Rebuild wallet
 func (c *Client) RebuildWallet() (api.RebuildWalletResponse, error) {
 	responseBytes, err := c.callAPI("wallet rebuild")
@@ -165,6 +192,54 @@ func (c *Client) RebuildWallet() (api.RebuildWalletResponse, error) {
 	return response, nil
 }
 
+// Regenerate the node's validator keys without loading them, and verify them against the Beacon Chain
+func (c *Client) RecoverValidatorKeys() (api.RecoverValidatorKeysResponse, error) {
+	responseBytes, err := c.callAPI("wallet recover-validator-keys")
+	if err != nil {
+		return api.RecoverValidatorKeysResponse{}, fmt.Errorf("Could not recover validator keys: %w", err)
+	}
+	var response api.RecoverValidatorKeysResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RecoverValidatorKeysResponse{}, fmt.Errorf("Could not decode recover validator keys response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RecoverValidatorKeysResponse{}, fmt.Errorf("Could not recover validator keys: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Load the verified validator keys into the Validator Client
+func (c *Client) LoadRecoveredValidatorKeys() (api.LoadRecoveredValidatorKeysResponse, error) {
+	responseBytes, err := c.callAPI("wallet load-recovered-validator-keys")
+	if err != nil {
+		return api.LoadRecoveredValidatorKeysResponse{}, fmt.Errorf("Could not load validator keys: %w", err)
+	}
+	var response api.LoadRecoveredValidatorKeysResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.LoadRecoveredValidatorKeysResponse{}, fmt.Errorf("Could not decode load validator keys response: %w", err)
+	}
+	if response.Error != "" {
+		return api.LoadRecoveredValidatorKeysResponse{}, fmt.Errorf("Could not load validator keys: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Encrypt the validator keys into an archive, or re-encrypt the existing one
+func (c *Client) EncryptValidatorKeys() (api.EncryptValidatorKeysResponse, error) {
+	responseBytes, err := c.callAPI("wallet encrypt-validator-keys")
+	if err != nil {
+		return api.EncryptValidatorKeysResponse{}, fmt.Errorf("Could not encrypt validator keys: %w", err)
+	}
+	var response api.EncryptValidatorKeysResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.EncryptValidatorKeysResponse{}, fmt.Errorf("Could not decode encrypt validator keys response: %w", err)
+	}
+	if response.Error != "" {
+		return api.EncryptValidatorKeysResponse{}, fmt.Errorf("Could not encrypt validator keys: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Estimate the gas required to set an ENS reverse record to a name
 func (c *Client) EstimateGasSetEnsName(name string) (api.SetEnsNameResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("wallet estimate-gas-set-ens-name %s", name))
@@ -212,3 +287,106 @@ func (c *Client) ExportWallet() (api.ExportWalletResponse, error) {
 	}
 	return response, nil
 }
+
+// Get the withdrawal credentials state of the node's vacant minipools
+func (c *Client) GetCredentialChanges(mnemonic string) (api.WalletCredentialChangesResponse, error) {
+	responseBytes, err := c.callAPI("wallet get-credential-changes", mnemonic)
+	if err != nil {
+		return api.WalletCredentialChangesResponse{}, fmt.Errorf("Could not get withdrawal credential changes: %w", err)
+	}
+	var response api.WalletCredentialChangesResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.WalletCredentialChangesResponse{}, fmt.Errorf("Could not decode withdrawal credential changes response: %w", err)
+	}
+	if response.Error != "" {
+		return api.WalletCredentialChangesResponse{}, fmt.Errorf("Could not get withdrawal credential changes: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Broadcast withdrawal credential changes for a batch of minipools
+func (c *Client) SendCredentialChanges(minipoolAddresses []common.Address, mnemonic string) (api.WalletSendCredentialChangesResponse, error) {
+	addressStrings := make([]string, len(minipoolAddresses))
+	for i, address := range minipoolAddresses {
+		addressStrings[i] = address.Hex()
+	}
+	responseBytes, err := c.callAPI(fmt.Sprintf("wallet send-credential-changes %s", strings.Join(addressStrings, ",")), mnemonic)
+	if err != nil {
+		return api.WalletSendCredentialChangesResponse{}, fmt.Errorf("Could not send withdrawal credential changes: %w", err)
+	}
+	var response api.WalletSendCredentialChangesResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.WalletSendCredentialChangesResponse{}, fmt.Errorf("Could not decode send withdrawal credential changes response: %w", err)
+	}
+	if response.Error != "" {
+		return api.WalletSendCredentialChangesResponse{}, fmt.Errorf("Could not send withdrawal credential changes: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the gas wallet's address, balance, and routing rules
+func (c *Client) GasWalletStatus() (api.GasWalletStatusResponse, error) {
+	responseBytes, err := c.callAPI("wallet gas-wallet-status")
+	if err != nil {
+		return api.GasWalletStatusResponse{}, fmt.Errorf("Could not get gas wallet status: %w", err)
+	}
+	var response api.GasWalletStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.GasWalletStatusResponse{}, fmt.Errorf("Could not decode gas wallet status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.GasWalletStatusResponse{}, fmt.Errorf("Could not get gas wallet status: %s", response.Error)
+	}
+	if response.Balance == nil {
+		response.Balance = big.NewInt(0)
+	}
+	return response, nil
+}
+
+// Generate a new key for the gas wallet
+func (c *Client) CreateGasWallet() (api.SetGasWalletResponse, error) {
+	responseBytes, err := c.callAPI("wallet create-gas-wallet")
+	if err != nil {
+		return api.SetGasWalletResponse{}, fmt.Errorf("Could not create gas wallet: %w", err)
+	}
+	var response api.SetGasWalletResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SetGasWalletResponse{}, fmt.Errorf("Could not decode create gas wallet response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SetGasWalletResponse{}, fmt.Errorf("Could not create gas wallet: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Use an existing private key for the gas wallet
+func (c *Client) ImportGasWallet(privateKey string) (api.SetGasWalletResponse, error) {
+	responseBytes, err := c.callAPI("wallet import-gas-wallet", privateKey)
+	if err != nil {
+		return api.SetGasWalletResponse{}, fmt.Errorf("Could not import gas wallet: %w", err)
+	}
+	var response api.SetGasWalletResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SetGasWalletResponse{}, fmt.Errorf("Could not decode import gas wallet response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SetGasWalletResponse{}, fmt.Errorf("Could not import gas wallet: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Remove the gas wallet's key from the node
+func (c *Client) DeleteGasWallet() (api.DeleteGasWalletResponse, error) {
+	responseBytes, err := c.callAPI("wallet delete-gas-wallet")
+	if err != nil {
+		return api.DeleteGasWalletResponse{}, fmt.Errorf("Could not delete gas wallet: %w", err)
+	}
+	var response api.DeleteGasWalletResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.DeleteGasWalletResponse{}, fmt.Errorf("Could not decode delete gas wallet response: %w", err)
+	}
+	if response.Error != "" {
+		return api.DeleteGasWalletResponse{}, fmt.Errorf("Could not delete gas wallet: %s", response.Error)
+	}
+	return response, nil
+}
@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"math/big"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/docker/docker/client"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
@@ -16,6 +19,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/contracts"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	lhkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
@@ -23,6 +27,7 @@ import (
 	nmkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
 	prkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/prysm"
 	tkkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/teku"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
@@ -31,21 +36,43 @@ const (
 	dockerAPIVersion string = "1.40"
 )
 
+// The contracts whose custom errors are decoded when a transaction reverts
+var revertContractNames = []string{
+	"rocketDepositPool",
+	"rocketNodeDeposit",
+	"rocketNodeDistributorFactory",
+	"rocketNodeManager",
+	"rocketNodeStaking",
+	"rocketMinipoolBondReducer",
+	"rocketMinipoolDelegate",
+	"rocketMinipoolManager",
+	"rocketMerkleDistributorMainnet",
+	"rocketTokenRETH",
+	"rocketTokenRPL",
+	"rocketDAONodeTrustedActions",
+	"rocketDAONodeTrustedProposals",
+	"rocketDAOProtocolProposal",
+	"rocketDAOProtocolVerifier",
+}
+
 // Service instances & initializers
 var (
 	cfg                *config.RocketPoolConfig
 	passwordManager    *passwords.PasswordManager
 	nodeWallet         *wallet.Wallet
+	gasWallet          *wallet.GasWallet
 	ecManager          *ExecutionClientManager
 	bcManager          *BeaconClientManager
 	rocketPool         *rocketpool.RocketPool
 	snapshotDelegation *contracts.SnapshotDelegation
 	beaconClient       beacon.Client
 	docker             *client.Client
+	keymanagerClient   *keymanager.Client
 
 	initCfg                sync.Once
 	initPasswordManager    sync.Once
 	initNodeWallet         sync.Once
+	initGasWallet          sync.Once
 	initECManager          sync.Once
 	initBCManager          sync.Once
 	initRocketPool         sync.Once
@@ -53,6 +80,7 @@ var (
 	initSnapshotDelegation sync.Once
 	initBeaconClient       sync.Once
 	initDocker             sync.Once
+	initKeymanager         sync.Once
 )
 
 //
@@ -80,6 +108,14 @@ func GetWallet(c *cli.Context) (*wallet.Wallet, error) {
 	return getWallet(c, cfg, pm)
 }
 
+func GetGasWallet(c *cli.Context) (*wallet.GasWallet, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return getGasWallet(c, cfg)
+}
+
 func GetEthClient(c *cli.Context) (*ExecutionClientManager, error) {
 	cfg, err := getConfig(c)
 	if err != nil {
@@ -139,6 +175,21 @@ func GetDocker(c *cli.Context) (*client.Client, error) {
 	return docker, err
 }
 
+// Get the Validator Client's keymanager API client; returns nil if the keymanager API isn't enabled
+func GetKeymanager(c *cli.Context) (*keymanager.Client, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.Smartnode.EnableKeymanagerApi.Value.(bool) {
+		return nil, nil
+	}
+	initKeymanager.Do(func() {
+		keymanagerClient = keymanager.NewClient(cfg.Smartnode.GetKeymanagerApiUrl(), cfg.Smartnode.GetKeymanagerTokenPath())
+	})
+	return keymanagerClient, nil
+}
+
 //
 // Service instance getters
 //
@@ -157,11 +208,35 @@ func getConfig(c *cli.Context) (*config.RocketPoolConfig, error) {
 
 func getPasswordManager(cfg *config.RocketPoolConfig) *passwords.PasswordManager {
 	initPasswordManager.Do(func() {
-		passwordManager = passwords.NewPasswordManager(os.ExpandEnv(cfg.Smartnode.GetPasswordPath()))
+		passwordManager = passwords.NewPasswordManager(os.ExpandEnv(cfg.Smartnode.GetPasswordPath()), getPasswordSource(cfg))
 	})
 	return passwordManager
 }
 
+func getPasswordSource(cfg *config.RocketPoolConfig) passwords.PasswordSource {
+	switch cfg.Smartnode.PasswordSource.Value.(cfgtypes.PasswordSource) {
+	case cfgtypes.PasswordSource_Keyring:
+		return passwords.NewKeyringSource(cfg.Smartnode.PasswordKeyringService.Value.(string))
+	case cfgtypes.PasswordSource_Vault:
+		// Token files in the user's data folder are mounted at a different path inside the containers
+		tokenPath := os.ExpandEnv(cfg.Smartnode.PasswordVaultTokenPath.Value.(string))
+		dataPath := os.ExpandEnv(cfg.Smartnode.DataPath.Value.(string))
+		if !cfg.IsNativeMode && tokenPath != "" && strings.HasPrefix(tokenPath, dataPath) {
+			tokenPath = filepath.Join(config.DaemonDataPath, strings.TrimPrefix(tokenPath, dataPath))
+		}
+		return passwords.NewVaultSource(
+			cfg.Smartnode.PasswordVaultAddress.Value.(string),
+			cfg.Smartnode.PasswordVaultSecretPath.Value.(string),
+			cfg.Smartnode.PasswordVaultField.Value.(string),
+			tokenPath,
+		)
+	case cfgtypes.PasswordSource_Command:
+		return passwords.NewCommandSource(cfg.Smartnode.PasswordCommand.Value.(string))
+	default:
+		return nil
+	}
+}
+
 func getWallet(c *cli.Context, cfg *config.RocketPoolConfig, pm *passwords.PasswordManager) (*wallet.Wallet, error) {
 	var err error
 	initNodeWallet.Do(func() {
@@ -185,7 +260,7 @@ func getWallet(c *cli.Context, cfg *config.RocketPoolConfig, pm *passwords.Passw
 
 		chainId := cfg.Smartnode.GetChainID()
 
-		nodeWallet, err = wallet.NewWallet(os.ExpandEnv(cfg.Smartnode.GetWalletPath()), chainId, maxFee, maxPriorityFee, 0, pm)
+		nodeWallet, err = wallet.NewWallet(os.ExpandEnv(cfg.Smartnode.GetWalletPath()), os.ExpandEnv(cfg.Smartnode.GetNodeAddressPath()), chainId, maxFee, maxPriorityFee, 0, pm)
 		if err != nil {
 			return
 		}
@@ -205,6 +280,32 @@ func getWallet(c *cli.Context, cfg *config.RocketPoolConfig, pm *passwords.Passw
 	return nodeWallet, err
 }
 
+func getGasWallet(c *cli.Context, cfg *config.RocketPoolConfig) (*wallet.GasWallet, error) {
+	var err error
+	initGasWallet.Do(func() {
+		var maxFee *big.Int
+		maxFeeFloat := c.GlobalFloat64("maxFee")
+		if maxFeeFloat == 0 {
+			maxFeeFloat = cfg.Smartnode.ManualMaxFee.Value.(float64)
+		}
+		if maxFeeFloat != 0 {
+			maxFee = eth.GweiToWei(maxFeeFloat)
+		}
+
+		var maxPriorityFee *big.Int
+		maxPriorityFeeFloat := c.GlobalFloat64("maxPrioFee")
+		if maxPriorityFeeFloat == 0 {
+			maxPriorityFeeFloat = cfg.Smartnode.PriorityFee.Value.(float64)
+		}
+		if maxPriorityFeeFloat != 0 {
+			maxPriorityFee = eth.GweiToWei(maxPriorityFeeFloat)
+		}
+
+		gasWallet, err = wallet.NewGasWallet(os.ExpandEnv(cfg.Smartnode.GetGasWalletPath()), os.ExpandEnv(cfg.Smartnode.GetGasWalletPasswordPath()), cfg.Smartnode.GetChainID(), maxFee, maxPriorityFee, 0)
+	})
+	return gasWallet, err
+}
+
 func getEthClient(c *cli.Context, cfg *config.RocketPoolConfig) (*ExecutionClientManager, error) {
 	var err error
 	initECManager.Do(func() {
@@ -227,10 +328,29 @@ func getRocketPool(cfg *config.RocketPoolConfig, client rocketpool.ExecutionClie
 	var err error
 	initRocketPool.Do(func() {
 		rocketPool, err = rocketpool.NewRocketPool(client, common.HexToAddress(cfg.Smartnode.GetStorageAddress()))
+		if err == nil {
+			if ecm, ok := client.(*ExecutionClientManager); ok {
+				ecm.SetRevertABISource(getRevertABIs(rocketPool))
+			}
+		}
 	})
 	return rocketPool, err
 }
 
+// Get a function that loads the ABIs of the contracts the Smartnode sends transactions to, for decoding their custom revert errors
+func getRevertABIs(rpClient *rocketpool.RocketPool) func() []*abi.ABI {
+	return func() []*abi.ABI {
+		abis := []*abi.ABI{}
+		for _, contractName := range revertContractNames {
+			contractAbi, err := rpClient.GetABI(contractName, nil)
+			if err == nil {
+				abis = append(abis, contractAbi)
+			}
+		}
+		return abis
+	}
+}
+
 func getSnapshotDelegation(cfg *config.RocketPoolConfig, client rocketpool.ExecutionClient) (*contracts.SnapshotDelegation, error) {
 	var err error
 	initSnapshotDelegation.Do(func() {
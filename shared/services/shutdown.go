@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// How long the daemons wait for their background work to checkpoint and stop once they're asked to shut down.
+// This stays under Docker's default 10 second grace period so the work isn't killed partway through a write.
+const ShutdownTimeout time.Duration = 8 * time.Second
+
+// Get a context that is cancelled when the process is interrupted or asked to terminate
+func NewShutdownContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+// Long-running work started in the background by the daemon tasks, which is cancelled and waited for on shutdown
+type BackgroundTasks struct {
+	ctx context.Context
+	wg  sync.WaitGroup
+}
+
+// Create a group of background tasks that are cancelled along with the given context
+func NewBackgroundTasks(ctx context.Context) *BackgroundTasks {
+	return &BackgroundTasks{
+		ctx: ctx,
+	}
+}
+
+// Get the context the background tasks run with
+func (b *BackgroundTasks) Context() context.Context {
+	return b.ctx
+}
+
+// Run a task in the background; it should save its progress and return promptly once its context is cancelled
+func (b *BackgroundTasks) Go(task func(ctx context.Context)) {
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		task(b.ctx)
+	}()
+}
+
+// Wait for the background tasks to stop, giving up after the timeout.
+// Returns false if any of them were still running.
+func (b *BackgroundTasks) Wait(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
@@ -0,0 +1,102 @@
+package state
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/klauspost/compress/zstd"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// The version of the network state cache format; caches with a different version are ignored
+const stateCacheVersion uint64 = 1
+
+// A snapshot of a node's network state that was saved to disk
+type CachedNodeState struct {
+	Version             uint64
+	SavedTime           time.Time
+	NodeAddress         common.Address
+	State               *NetworkState
+	TotalEffectiveStake *big.Int
+}
+
+// Save a snapshot of a node's network state to disk
+func SaveNodeStateCache(path string, nodeAddress common.Address, state *NetworkState, totalEffectiveStake *big.Int) error {
+	// The lookups point into the detail slices, so they're rebuilt on load instead of being saved twice
+	snapshot := *state
+	snapshot.NodeDetailsByAddress = nil
+	snapshot.MinipoolDetailsByAddress = nil
+	snapshot.MinipoolDetailsByNode = nil
+
+	var buffer bytes.Buffer
+	err := gob.NewEncoder(&buffer).Encode(CachedNodeState{
+		Version:             stateCacheVersion,
+		SavedTime:           time.Now(),
+		NodeAddress:         nodeAddress,
+		State:               &snapshot,
+		TotalEffectiveStake: totalEffectiveStake,
+	})
+	if err != nil {
+		return fmt.Errorf("error serializing network state: %w", err)
+	}
+
+	encoder, _ := zstd.NewWriter(nil)
+	compressedBytes := encoder.EncodeAll(buffer.Bytes(), nil)
+
+	// Write atomically so readers never see a partial cache
+	err = files.WriteFileAtomic(path, compressedBytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing network state cache: %w", err)
+	}
+	return nil
+}
+
+// Load a node's network state snapshot from disk; returns nil if there isn't one or it was saved by an incompatible version
+func LoadNodeStateCache(path string) (*CachedNodeState, error) {
+	compressedBytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading network state cache from %s: %w", path, err)
+	}
+
+	decoder, _ := zstd.NewReader(nil)
+	defer decoder.Close()
+	data, err := decoder.DecodeAll(compressedBytes, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing network state cache: %w", err)
+	}
+
+	var cache CachedNodeState
+	err = gob.NewDecoder(bytes.NewReader(data)).Decode(&cache)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing network state cache: %w", err)
+	}
+	if cache.Version != stateCacheVersion || cache.State == nil {
+		return nil, nil
+	}
+	cache.State.createLookups()
+	return &cache, nil
+}
+
+// Create the node and minipool lookups from the detail slices
+func (s *NetworkState) createLookups() {
+	s.NodeDetailsByAddress = map[common.Address]*rpstate.NativeNodeDetails{}
+	s.MinipoolDetailsByAddress = map[common.Address]*rpstate.NativeMinipoolDetails{}
+	s.MinipoolDetailsByNode = map[common.Address][]*rpstate.NativeMinipoolDetails{}
+	for i, details := range s.NodeDetails {
+		s.NodeDetailsByAddress[details.NodeAddress] = &s.NodeDetails[i]
+	}
+	for i, details := range s.MinipoolDetails {
+		s.MinipoolDetailsByAddress[details.MinipoolAddress] = &s.MinipoolDetails[i]
+		s.MinipoolDetailsByNode[details.NodeAddress] = append(s.MinipoolDetailsByNode[details.NodeAddress], &s.MinipoolDetails[i])
+	}
+}
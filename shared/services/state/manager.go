@@ -53,12 +53,12 @@ func NewNetworkStateManager(rp *rocketpool.RocketPool, cfg *config.RocketPoolCon
 }
 
 // Get the state of the network using the latest Execution layer block
-func (m *NetworkStateManager) GetHeadState() (*NetworkState, error) {
+func (m *NetworkStateManager) GetHeadState(ctx context.Context) (*NetworkState, error) {
 	targetSlot, err := m.GetHeadSlot()
 	if err != nil {
 		return nil, fmt.Errorf("error getting latest Beacon slot: %w", err)
 	}
-	return m.getState(targetSlot)
+	return m.getState(ctx, targetSlot)
 }
 
 // Get the state of the network for a single node using the latest Execution layer block, along with the total effective RPL stake for the network
@@ -67,12 +67,53 @@ func (m *NetworkStateManager) GetHeadStateForNode(nodeAddress common.Address, ca
 	if err != nil {
 		return nil, nil, fmt.Errorf("error getting latest Beacon slot: %w", err)
 	}
-	return m.getStateForNode(nodeAddress, targetSlot, calculateTotalEffectiveStake)
+	return m.GetStateForNode(nodeAddress, targetSlot, calculateTotalEffectiveStake)
 }
 
-// Get the state of the network at the provided Beacon slot
-func (m *NetworkStateManager) GetStateForSlot(slotNumber uint64) (*NetworkState, error) {
-	return m.getState(slotNumber)
+// Get the state of the network at the provided Beacon slot; cancelling the context stops the collection between steps
+func (m *NetworkStateManager) GetStateForSlot(ctx context.Context, slotNumber uint64) (*NetworkState, error) {
+	return m.getState(ctx, slotNumber)
+}
+
+// Get the state of the network for a single node at the provided Beacon slot, along with the total effective RPL stake for the network.
+// Only the node's own minipools and validators are queried, which is far cheaper than building the full network state.
+func (m *NetworkStateManager) GetStateForNode(nodeAddress common.Address, slotNumber uint64, calculateTotalEffectiveStake bool) (*NetworkState, *big.Int, error) {
+	state, totalEffectiveStake, err := CreateNetworkStateForNode(m.cfg, m.rp, m.ec, m.bc, m.log, slotNumber, m.BeaconConfig, nodeAddress, calculateTotalEffectiveStake)
+	if err != nil {
+		return nil, nil, err
+	}
+	return state, totalEffectiveStake, nil
+}
+
+// Get the state of the network for a single node, using the snapshot saved by the node process if it's younger than the configured cache lifetime.
+// Also returns the time the snapshot was saved, or the zero time if the state was just created.
+func (m *NetworkStateManager) GetCachedHeadStateForNode(nodeAddress common.Address, calculateTotalEffectiveStake bool, refresh bool) (*NetworkState, *big.Int, time.Time, error) {
+	ttl := time.Duration(m.cfg.Smartnode.NetworkStateCacheTTL.Value.(uint64)) * time.Second
+	if ttl > 0 && !refresh {
+		cache, err := LoadNodeStateCache(m.cfg.Smartnode.GetNetworkStateCachePath(true))
+		if err != nil {
+			m.logLine("WARNING: couldn't load the network state cache: %s", err.Error())
+		} else if cache != nil &&
+			cache.NodeAddress == nodeAddress &&
+			time.Since(cache.SavedTime) < ttl &&
+			(!calculateTotalEffectiveStake || cache.TotalEffectiveStake != nil) {
+			return cache.State, cache.TotalEffectiveStake, cache.SavedTime, nil
+		}
+	}
+
+	state, totalEffectiveStake, err := m.GetHeadStateForNode(nodeAddress, calculateTotalEffectiveStake)
+	if err != nil {
+		return nil, nil, time.Time{}, err
+	}
+	return state, totalEffectiveStake, time.Time{}, nil
+}
+
+// Save a snapshot of a node's network state for other processes to use, if the network state cache is enabled
+func (m *NetworkStateManager) SaveStateForNode(nodeAddress common.Address, state *NetworkState, totalEffectiveStake *big.Int) error {
+	if m.cfg.Smartnode.NetworkStateCacheTTL.Value.(uint64) == 0 {
+		return nil
+	}
+	return SaveNodeStateCache(m.cfg.Smartnode.GetNetworkStateCachePath(true), nodeAddress, state, totalEffectiveStake)
 }
 
 // Gets the latest valid block
@@ -130,23 +171,14 @@ func (m *NetworkStateManager) GetLatestProposedBeaconBlock(targetSlot uint64) (b
 }
 
 // Get the state of the network at the provided Beacon slot
-func (m *NetworkStateManager) getState(slotNumber uint64) (*NetworkState, error) {
-	state, err := CreateNetworkState(m.cfg, m.rp, m.ec, m.bc, m.log, slotNumber, m.BeaconConfig)
+func (m *NetworkStateManager) getState(ctx context.Context, slotNumber uint64) (*NetworkState, error) {
+	state, err := CreateNetworkState(ctx, m.cfg, m.rp, m.ec, m.bc, m.log, slotNumber, m.BeaconConfig)
 	if err != nil {
 		return nil, err
 	}
 	return state, nil
 }
 
-// Get the state of the network for a specific node only at the provided Beacon slot
-func (m *NetworkStateManager) getStateForNode(nodeAddress common.Address, slotNumber uint64, calculateTotalEffectiveStake bool) (*NetworkState, *big.Int, error) {
-	state, totalEffectiveStake, err := CreateNetworkStateForNode(m.cfg, m.rp, m.ec, m.bc, m.log, slotNumber, m.BeaconConfig, nodeAddress, calculateTotalEffectiveStake)
-	if err != nil {
-		return nil, nil, err
-	}
-	return state, totalEffectiveStake, nil
-}
-
 // Logs a line if the logger is specified
 func (m *NetworkStateManager) logLine(format string, v ...interface{}) {
 	if m.log != nil {
@@ -1,6 +1,7 @@
 package state
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"time"
@@ -66,7 +67,7 @@ type NetworkState struct {
 }
 
 // Creates a snapshot of the entire Rocket Pool network state, on both the Execution and Consensus layers
-func CreateNetworkState(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, ec rocketpool.ExecutionClient, bc beacon.Client, log *log.ColorLogger, slotNumber uint64, beaconConfig beacon.Eth2Config) (*NetworkState, error) {
+func CreateNetworkState(ctx context.Context, cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, ec rocketpool.ExecutionClient, bc beacon.Client, log *log.ColorLogger, slotNumber uint64, beaconConfig beacon.Eth2Config) (*NetworkState, error) {
 	// Get the relevant network contracts
 	multicallerAddress := common.HexToAddress(cfg.Smartnode.GetMulticallAddress())
 	balanceBatcherAddress := common.HexToAddress(cfg.Smartnode.GetBalanceBatcherAddress())
@@ -117,6 +118,10 @@ func CreateNetworkState(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool,
 	}
 	state.logLine("1/6 - Retrieved network details (%s so far)", time.Since(start))
 
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("stopped getting network state: %w", err)
+	}
+
 	// Node details
 	state.NodeDetails, err = rpstate.GetAllNativeNodeDetails(rp, contracts)
 	if err != nil {
@@ -124,6 +129,10 @@ func CreateNetworkState(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool,
 	}
 	state.logLine("2/6 - Retrieved node details (%s so far)", time.Since(start))
 
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("stopped getting network state: %w", err)
+	}
+
 	// Minipool details
 	state.MinipoolDetails, err = rpstate.GetAllNativeMinipoolDetails(rp, contracts)
 	if err != nil {
@@ -165,6 +174,9 @@ func CreateNetworkState(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool,
 		return nil, fmt.Errorf("error getting Oracle DAO details: %w", err)
 	}
 	state.logLine("4/6 - Retrieved Oracle DAO details (%s so far)", time.Since(start))
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("stopped getting network state: %w", err)
+	}
 
 	// Get the validator stats from Beacon
 	statusMap, err := bc.GetValidatorStatuses(pubkeys, &beacon.ValidatorStatusOptions{
@@ -175,6 +187,9 @@ func CreateNetworkState(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool,
 	}
 	state.ValidatorDetails = statusMap
 	state.logLine("5/6 - Retrieved validator details (total time: %s)", time.Since(start))
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("stopped getting network state: %w", err)
+	}
 
 	// Get the complete node and user shares
 	mpds := make([]*rpstate.NativeMinipoolDetails, len(state.MinipoolDetails))
@@ -268,7 +283,7 @@ func CreateNetworkStateForNode(cfg *config.RocketPoolConfig, rp *rocketpool.Rock
 	state.logLine("2/%d - Retrieved node details (%s so far)", steps, time.Since(start))
 
 	// Minipool details
-	state.MinipoolDetails, err = rpstate.GetNodeNativeMinipoolDetails(rp, contracts, nodeAddress)
+	minipoolDetails, err := rpstate.GetNodeNativeMinipoolDetails(rp, contracts, nodeAddress)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error getting all minipool details: %w", err)
 	}
@@ -280,22 +295,7 @@ func CreateNetworkStateForNode(cfg *config.RocketPoolConfig, rp *rocketpool.Rock
 	}
 
 	// Create the minipool lookups
-	pubkeys := make([]types.ValidatorPubkey, 0, len(state.MinipoolDetails))
-	emptyPubkey := types.ValidatorPubkey{}
-	for i, details := range state.MinipoolDetails {
-		state.MinipoolDetailsByAddress[details.MinipoolAddress] = &state.MinipoolDetails[i]
-		if details.Pubkey != emptyPubkey {
-			pubkeys = append(pubkeys, details.Pubkey)
-		}
-
-		// The map of nodes to minipools
-		nodeList, exists := state.MinipoolDetailsByNode[details.NodeAddress]
-		if !exists {
-			nodeList = []*rpstate.NativeMinipoolDetails{}
-		}
-		nodeList = append(nodeList, &state.MinipoolDetails[i])
-		state.MinipoolDetailsByNode[details.NodeAddress] = nodeList
-	}
+	pubkeys := state.addNodeMinipools(nodeAddress, minipoolDetails)
 
 	// Calculate avg node fees and distributor shares
 	for _, details := range state.NodeDetails {
@@ -358,6 +358,28 @@ func CreateNetworkStateForNode(cfg *config.RocketPoolConfig, rp *rocketpool.Rock
 	return state, totalEffectiveStake, nil
 }
 
+// Add a node's minipools to the state and its lookups, returning the pubkeys of their validators.
+// Minipools that belong to other nodes are left out, so only the node's own validators get queried.
+func (s *NetworkState) addNodeMinipools(nodeAddress common.Address, minipools []rpstate.NativeMinipoolDetails) []types.ValidatorPubkey {
+	s.MinipoolDetails = make([]rpstate.NativeMinipoolDetails, 0, len(minipools))
+	for _, details := range minipools {
+		if details.NodeAddress == nodeAddress {
+			s.MinipoolDetails = append(s.MinipoolDetails, details)
+		}
+	}
+
+	pubkeys := make([]types.ValidatorPubkey, 0, len(s.MinipoolDetails))
+	emptyPubkey := types.ValidatorPubkey{}
+	for i, details := range s.MinipoolDetails {
+		s.MinipoolDetailsByAddress[details.MinipoolAddress] = &s.MinipoolDetails[i]
+		if details.Pubkey != emptyPubkey {
+			pubkeys = append(pubkeys, details.Pubkey)
+		}
+		s.MinipoolDetailsByNode[nodeAddress] = append(s.MinipoolDetailsByNode[nodeAddress], &s.MinipoolDetails[i])
+	}
+	return pubkeys
+}
+
 func (s *NetworkState) GetNodeWeight(eligibleBorrowedEth *big.Int, nodeStake *big.Int) *big.Int {
 	rplPrice := s.NetworkDetails.RplPrice
 
@@ -0,0 +1,47 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+)
+
+func TestAddNodeMinipools(t *testing.T) {
+	node := common.HexToAddress("0x01")
+	otherNode := common.HexToAddress("0x02")
+	minipools := []rpstate.NativeMinipoolDetails{
+		{MinipoolAddress: common.HexToAddress("0xa1"), NodeAddress: node, Pubkey: types.ValidatorPubkey{0xa1}},
+		{MinipoolAddress: common.HexToAddress("0xb1"), NodeAddress: otherNode, Pubkey: types.ValidatorPubkey{0xb1}},
+		// Not deposited yet, so it doesn't have a validator
+		{MinipoolAddress: common.HexToAddress("0xa2"), NodeAddress: node},
+		{MinipoolAddress: common.HexToAddress("0xb2"), NodeAddress: otherNode, Pubkey: types.ValidatorPubkey{0xb2}},
+	}
+
+	state := &NetworkState{
+		MinipoolDetailsByAddress: map[common.Address]*rpstate.NativeMinipoolDetails{},
+		MinipoolDetailsByNode:    map[common.Address][]*rpstate.NativeMinipoolDetails{},
+	}
+	pubkeys := state.addNodeMinipools(node, minipools)
+
+	if len(state.MinipoolDetails) != 2 {
+		t.Fatalf("expected the node's 2 minipools, got %d", len(state.MinipoolDetails))
+	}
+	for _, mpd := range state.MinipoolDetails {
+		if mpd.NodeAddress != node {
+			t.Fatalf("minipool %s of node %s was loaded", mpd.MinipoolAddress.Hex(), mpd.NodeAddress.Hex())
+		}
+	}
+	if _, exists := state.MinipoolDetailsByAddress[common.HexToAddress("0xb1")]; exists {
+		t.Fatal("another node's minipool is in the address lookup")
+	}
+	if len(state.MinipoolDetailsByNode) != 1 || len(state.MinipoolDetailsByNode[node]) != 2 {
+		t.Fatalf("expected only the node in the node lookup with 2 minipools, got %d nodes", len(state.MinipoolDetailsByNode))
+	}
+
+	// Only the node's deposited validator should be queried
+	if len(pubkeys) != 1 || pubkeys[0] != (types.ValidatorPubkey{0xa1}) {
+		t.Fatalf("expected only the node's validator to be queried, got %v", pubkeys)
+	}
+}
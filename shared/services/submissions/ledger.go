@@ -0,0 +1,262 @@
+package submissions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// Settings
+const (
+	// How long a submission can be missing from the Execution Client's mempool before it's considered dropped
+	DroppedTimeout time.Duration = 15 * time.Minute
+
+	// How many submissions to keep; older ones are removed once they're resolved
+	maxSubmissions int = 1000
+)
+
+// The kind of report a submission was for
+type Kind string
+
+const (
+	Kind_Balances    Kind = "balances"
+	Kind_Prices      Kind = "prices"
+	Kind_RewardsTree Kind = "rewards-tree"
+)
+
+// What happened to a submission
+type Status string
+
+const (
+	Status_Pending   Status = "pending"
+	Status_Succeeded Status = "succeeded"
+	Status_Failed    Status = "failed"
+	Status_Dropped   Status = "dropped"
+)
+
+// A single submission the watchtower made
+type Submission struct {
+	Kind        Kind        `json:"kind"`
+	ID          uint64      `json:"id"`
+	PayloadHash common.Hash `json:"payloadHash"`
+	TxHash      common.Hash `json:"txHash"`
+	SubmittedAt time.Time   `json:"submittedAt"`
+	Status      Status      `json:"status"`
+	Block       uint64      `json:"block,omitempty"`
+	Result      string      `json:"result,omitempty"`
+	ResolvedAt  time.Time   `json:"resolvedAt,omitempty"`
+}
+
+// Every submission the watchtower has made, so a restart or a clock problem can't make it submit the same report twice
+// or a conflicting one while an earlier one is still pending.
+// The ID of a submission is the block its balances or prices are for, or the rewards interval of its tree.
+type Ledger struct {
+	Submissions []*Submission `json:"submissions"`
+
+	path string
+	lock sync.Mutex
+}
+
+// Get the hash that identifies a submission's payload, so two submissions for the same ID can be told apart
+func HashPayload(values ...interface{}) common.Hash {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = fmt.Sprint(value)
+	}
+	return crypto.Keccak256Hash([]byte(strings.Join(parts, ":")))
+}
+
+// Load the ledger from disk, returning an empty one if it hasn't been saved yet
+func LoadLedger(path string) (*Ledger, error) {
+	ledger := &Ledger{
+		Submissions: []*Submission{},
+		path:        path,
+	}
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ledger, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading submission ledger from [%s]: %w", path, err)
+	}
+	if err := json.Unmarshal(bytes, ledger); err != nil {
+		return nil, fmt.Errorf("error deserializing submission ledger from [%s]: %w", path, err)
+	}
+	return ledger, nil
+}
+
+// Save the ledger to disk; the lock must be held
+func (l *Ledger) save() error {
+	err := os.MkdirAll(filepath.Dir(l.path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating submission ledger folder: %w", err)
+	}
+	bytes, err := json.Marshal(l)
+	if err != nil {
+		return fmt.Errorf("error serializing submission ledger: %w", err)
+	}
+	err = files.WriteFileAtomic(l.path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error saving submission ledger to [%s]: %w", l.path, err)
+	}
+	return nil
+}
+
+// Check whether a submission can be made, and why not if it can't.
+// It can't while an earlier submission of the same kind is still pending, if the same payload was already submitted successfully,
+// or if a later ID has already been submitted successfully. Pending submissions are resolved against the chain first.
+func (l *Ledger) CheckSubmission(ec rocketpool.ExecutionClient, kind Kind, id uint64, payloadHash common.Hash) (bool, string, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	changed := false
+	for _, submission := range l.Submissions {
+		if submission.Kind != kind || submission.Status != Status_Pending {
+			continue
+		}
+		resolved, err := resolve(ec, submission)
+		if err != nil {
+			return false, "", err
+		}
+		changed = changed || resolved
+	}
+	if changed {
+		if err := l.save(); err != nil {
+			return false, "", err
+		}
+	}
+
+	for _, submission := range l.Submissions {
+		if submission.Kind != kind {
+			continue
+		}
+		switch {
+		case submission.Status == Status_Pending:
+			return false, fmt.Sprintf("the %s submission for %d in transaction %s is still pending", kind, submission.ID, submission.TxHash.Hex()), nil
+		case submission.Status == Status_Succeeded && submission.ID == id && submission.PayloadHash == payloadHash:
+			return false, fmt.Sprintf("the same %s were already submitted for %d in transaction %s", kind, id, submission.TxHash.Hex()), nil
+		case submission.Status == Status_Succeeded && submission.ID > id:
+			return false, fmt.Sprintf("%s for %d were already submitted in transaction %s, which is later than %d", kind, submission.ID, submission.TxHash.Hex(), id), nil
+		}
+	}
+	return true, "", nil
+}
+
+// Record a submission that was just sent
+func (l *Ledger) RecordSubmission(kind Kind, id uint64, payloadHash common.Hash, txHash common.Hash) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.Submissions = append(l.Submissions, &Submission{
+		Kind:        kind,
+		ID:          id,
+		PayloadHash: payloadHash,
+		TxHash:      txHash,
+		SubmittedAt: time.Now().UTC(),
+		Status:      Status_Pending,
+	})
+
+	// Remove the oldest resolved submissions once there are too many
+	for len(l.Submissions) > maxSubmissions {
+		oldest := -1
+		for i, submission := range l.Submissions {
+			if submission.Status != Status_Pending {
+				oldest = i
+				break
+			}
+		}
+		if oldest == -1 {
+			break
+		}
+		l.Submissions = append(l.Submissions[:oldest], l.Submissions[oldest+1:]...)
+	}
+	return l.save()
+}
+
+// Record the result of waiting for a submission's transaction
+func (l *Ledger) RecordResult(ec rocketpool.ExecutionClient, txHash common.Hash, waitErr error) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	for _, submission := range l.Submissions {
+		if submission.TxHash != txHash || submission.Status != Status_Pending {
+			continue
+		}
+		if _, err := resolve(ec, submission); err != nil {
+			return err
+		}
+		if submission.Status == Status_Pending && waitErr != nil {
+			submission.Result = waitErr.Error()
+		}
+		return l.save()
+	}
+	return nil
+}
+
+// Get the submissions of a kind, or of every kind if it's blank, newest first
+func (l *Ledger) GetSubmissions(kind Kind) []Submission {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	submissions := []Submission{}
+	for _, submission := range l.Submissions {
+		if kind == "" || submission.Kind == kind {
+			submissions = append(submissions, *submission)
+		}
+	}
+	sort.SliceStable(submissions, func(i, j int) bool {
+		return submissions[i].SubmittedAt.After(submissions[j].SubmittedAt)
+	})
+	return submissions
+}
+
+// Update a pending submission from its transaction's receipt, returning true if it's no longer pending
+func resolve(ec rocketpool.ExecutionClient, submission *Submission) (bool, error) {
+	receipt, err := ec.TransactionReceipt(context.Background(), submission.TxHash)
+	if err == nil {
+		submission.Block = receipt.BlockNumber.Uint64()
+		submission.ResolvedAt = time.Now().UTC()
+		if receipt.Status == types.ReceiptStatusSuccessful {
+			submission.Status = Status_Succeeded
+			submission.Result = ""
+		} else {
+			submission.Status = Status_Failed
+			submission.Result = "the transaction reverted"
+		}
+		return true, nil
+	}
+	if !errors.Is(err, ethereum.NotFound) {
+		return false, fmt.Errorf("error getting the receipt for submission %s: %w", submission.TxHash.Hex(), err)
+	}
+
+	// It isn't in a block yet, so see if it's still waiting to be
+	_, _, err = ec.TransactionByHash(context.Background(), submission.TxHash)
+	if err == nil {
+		return false, nil
+	}
+	if !errors.Is(err, ethereum.NotFound) {
+		return false, fmt.Errorf("error getting submission %s: %w", submission.TxHash.Hex(), err)
+	}
+	if time.Since(submission.SubmittedAt) < DroppedTimeout {
+		return false, nil
+	}
+	submission.Status = Status_Dropped
+	submission.Result = "the transaction was dropped from the mempool"
+	submission.ResolvedAt = time.Now().UTC()
+	return true, nil
+}
@@ -0,0 +1,161 @@
+package support
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Settings
+const (
+	manifestFilename string      = "manifest.json"
+	bundlePrefix     string      = "rocketpool-support-"
+	bundleExtension  string      = ".tar.gz"
+	timestampFormat  string      = "20060102-150405"
+	bundleFileMode   os.FileMode = 0600
+)
+
+// Lists everything in a support bundle, and what couldn't be collected
+type Manifest struct {
+	Created          time.Time `json:"created"`
+	ClientVersion    string    `json:"clientVersion"`
+	SmartnodeVersion string    `json:"smartnodeVersion"`
+	Files            []File    `json:"files"`
+}
+
+// A file in a support bundle. If it couldn't be collected, only the error is kept.
+type File struct {
+	Name  string `json:"name"`
+	Size  uint64 `json:"size"`
+	Error string `json:"error,omitempty"`
+}
+
+// Collects the details of a node into a single archive for a bug report.
+// Every URL in the files is redacted as they're added, since logs and statuses can include the ones with API keys in them.
+type Bundle struct {
+	Manifest Manifest
+
+	contents map[string][]byte
+}
+
+// Create an empty bundle
+func NewBundle(clientVersion string) *Bundle {
+	return &Bundle{
+		Manifest: Manifest{
+			Created:       time.Now().UTC(),
+			ClientVersion: clientVersion,
+			Files:         []File{},
+		},
+		contents: map[string][]byte{},
+	}
+}
+
+// Add a file, or the error that kept it from being collected
+func (b *Bundle) AddFile(name string, data []byte, err error) {
+	if err != nil {
+		b.Manifest.Files = append(b.Manifest.Files, File{
+			Name:  name,
+			Error: err.Error(),
+		})
+		return
+	}
+	data = []byte(RedactUrls(string(data)))
+	b.contents[name] = data
+	b.Manifest.Files = append(b.Manifest.Files, File{
+		Name: name,
+		Size: uint64(len(data)),
+	})
+}
+
+// Add a value as an indented JSON file, or the error that kept it from being collected
+func (b *Bundle) AddJson(name string, value interface{}, err error) {
+	if err != nil {
+		b.AddFile(name, nil, err)
+		return
+	}
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		err = fmt.Errorf("error serializing %s: %w", name, err)
+	}
+	b.AddFile(name, data, err)
+}
+
+// Get the number of files that couldn't be collected
+func (b *Bundle) GetErrorCount() int {
+	count := 0
+	for _, file := range b.Manifest.Files {
+		if file.Error != "" {
+			count++
+		}
+	}
+	return count
+}
+
+// Get the default filename of the bundle, which includes when it was created
+func (b *Bundle) GetFilename() string {
+	return bundlePrefix + b.Manifest.Created.Format(timestampFormat) + bundleExtension
+}
+
+// Build the bundle's tarball, with the manifest first
+func (b *Bundle) Build() ([]byte, error) {
+	manifestBytes, err := json.MarshalIndent(b.Manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error serializing support bundle manifest: %w", err)
+	}
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	tarWriter := tar.NewWriter(gzipWriter)
+	if err := writeTarFile(tarWriter, manifestFilename, manifestBytes, b.Manifest.Created); err != nil {
+		return nil, err
+	}
+	for _, file := range b.Manifest.Files {
+		if file.Error != "" {
+			continue
+		}
+		if err := writeTarFile(tarWriter, file.Name, b.contents[file.Name], b.Manifest.Created); err != nil {
+			return nil, err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return nil, fmt.Errorf("error building support bundle: %w", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, fmt.Errorf("error compressing support bundle: %w", err)
+	}
+	return compressed.Bytes(), nil
+}
+
+// Write the bundle to the path
+func (b *Bundle) Save(path string) error {
+	archive, err := b.Build()
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(path, archive, bundleFileMode)
+	if err != nil {
+		return fmt.Errorf("error writing support bundle to [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Add a file to a tarball
+func writeTarFile(tarWriter *tar.Writer, name string, data []byte, modTime time.Time) error {
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     int64(bundleFileMode),
+		Size:     int64(len(data)),
+		ModTime:  modTime,
+	}
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return fmt.Errorf("error adding [%s] to support bundle: %w", name, err)
+	}
+	if _, err := tarWriter.Write(data); err != nil {
+		return fmt.Errorf("error adding [%s] to support bundle: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package support
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/host"
+
+	"github.com/rocket-pool/smartnode/shared/services/hardware"
+)
+
+// How long to measure the host's CPU and disk activity for
+const hostSampleInterval time.Duration = 1 * time.Second
+
+// The machine the Smartnode is running on
+type HostInfo struct {
+	Os              string                `json:"os"`
+	Arch            string                `json:"arch"`
+	Platform        string                `json:"platform"`
+	PlatformVersion string                `json:"platformVersion"`
+	KernelVersion   string                `json:"kernelVersion"`
+	Virtualization  string                `json:"virtualization"`
+	Uptime          time.Duration         `json:"uptime"`
+	CpuModel        string                `json:"cpuModel"`
+	CpuCores        int                   `json:"cpuCores"`
+	CpuThreads      int                   `json:"cpuThreads"`
+	Metrics         *hardware.HostMetrics `json:"metrics"`
+}
+
+// Get the host's OS, CPU, and current resource usage, including the space used on each of the provided paths
+func GetHostInfo(paths []string) (*HostInfo, error) {
+	info := &HostInfo{
+		Os:   runtime.GOOS,
+		Arch: runtime.GOARCH,
+	}
+
+	hostStat, err := host.Info()
+	if err != nil {
+		return nil, fmt.Errorf("error getting host info: %w", err)
+	}
+	info.Platform = hostStat.Platform
+	info.PlatformVersion = hostStat.PlatformVersion
+	info.KernelVersion = hostStat.KernelVersion
+	info.Virtualization = hostStat.VirtualizationSystem
+	info.Uptime = time.Duration(hostStat.Uptime) * time.Second
+
+	cpus, err := cpu.Info()
+	if err != nil {
+		return nil, fmt.Errorf("error getting CPU info: %w", err)
+	}
+	if len(cpus) > 0 {
+		info.CpuModel = cpus[0].ModelName
+	}
+	info.CpuCores, err = cpu.Counts(false)
+	if err != nil {
+		return nil, fmt.Errorf("error getting CPU core count: %w", err)
+	}
+	info.CpuThreads, err = cpu.Counts(true)
+	if err != nil {
+		return nil, fmt.Errorf("error getting CPU thread count: %w", err)
+	}
+
+	info.Metrics, err = hardware.Sample(paths, hostSampleInterval)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
@@ -0,0 +1,56 @@
+package support
+
+import (
+	"net/url"
+	"regexp"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Put in place of anything that was redacted
+const RedactedValue string = "[redacted]"
+
+// Parameters whose IDs match this hold credentials, so their values are never included
+var sensitiveIdPattern = regexp.MustCompile(`(?i)(password|secret|token|auth|webhook|username|accesskey|userkey|funderkey|credential|apikey)`)
+
+// Parameters that match the pattern above but only say how a credential is provided
+var nonSensitiveIds = map[string]bool{
+	"passwordSource": true,
+}
+
+// Anything that looks like a URL, which may have an API key or credentials in it
+var urlPattern = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s;,"'<>]+`)
+
+// Serialize the config the same way the settings file is saved, with every credential redacted.
+// Sensitive parameters that are blank are left blank, so it's still clear whether they were set.
+func RedactConfig(cfg *config.RocketPoolConfig) map[string]map[string]string {
+	masterMap := cfg.Serialize()
+	for _, params := range masterMap {
+		for id, value := range params {
+			if value != "" && sensitiveIdPattern.MatchString(id) && !nonSensitiveIds[id] {
+				params[id] = RedactedValue
+				continue
+			}
+			params[id] = RedactUrls(value)
+		}
+	}
+	return masterMap
+}
+
+// Cut every URL in the text down to its scheme and host, since hosted providers put API keys in the path or query
+// and the user info holds credentials
+func RedactUrls(text string) string {
+	return urlPattern.ReplaceAllStringFunc(text, func(match string) string {
+		parsed, err := url.Parse(match)
+		if err != nil {
+			return RedactedValue
+		}
+		if parsed.Host == "" {
+			return match
+		}
+		if parsed.User == nil && (parsed.Path == "" || parsed.Path == "/") && parsed.RawQuery == "" && parsed.Fragment == "" {
+			return match
+		}
+		return parsed.Scheme + "://" + parsed.Host + "/" + RedactedValue
+	})
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// How often to check for a new block while waiting for a bundle's target block
+const bundlePollInterval time.Duration = 2 * time.Second
+
+// How long to wait for a bundle request to the relay
+const bundleRequestTimeout time.Duration = 10 * time.Second
+
+// A JSON-RPC response from the bundle relay
+type bundleRelayResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Check if a bundle relay is set up
+func (p *ExecutionClientManager) CanSendTransactionBundles() bool {
+	return p.bundleRelayUrl != ""
+}
+
+// Simulate a signed transaction that's meant for a bundle, so one that would revert can be left out before the bundle's nonces are set
+func (p *ExecutionClientManager) SimulateBundleTransaction(ctx context.Context, tx *types.Transaction) error {
+	return p.simulateTransaction(ctx, tx)
+}
+
+// Send signed transactions with consecutive nonces as one atomic bundle through the bundle relay, so they're either all included
+// in the same block or not at all. If the relay doesn't get them included within the configured number of blocks, or there isn't
+// a relay, they're sent one at a time in order instead.
+func (p *ExecutionClientManager) SendTransactionBundle(ctx context.Context, txs []*types.Transaction) error {
+	if len(txs) == 0 {
+		return nil
+	}
+
+	// Simulate them first so a bundle that would revert isn't sent at all
+	for _, tx := range txs {
+		if err := p.simulateTransaction(ctx, tx); err != nil {
+			return fmt.Errorf("error simulating transaction %s: %w", tx.Hash().Hex(), err)
+		}
+	}
+	if p.bundleRelayUrl == "" {
+		return p.sendTransactionsInOrder(ctx, txs)
+	}
+
+	rawTxs := make([]string, len(txs))
+	for i, tx := range txs {
+		rawTx, err := tx.MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("error serializing transaction %s: %w", tx.Hash().Hex(), err)
+		}
+		rawTxs[i] = hexutil.Encode(rawTx)
+	}
+
+	for attempt := uint64(0); attempt < p.bundleBlocks; attempt++ {
+		// Target the next block
+		head, err := p.BlockNumber(ctx)
+		if err != nil {
+			return fmt.Errorf("error getting the latest block: %w", err)
+		}
+		target := head + 1
+		if err := p.sendBundle(ctx, rawTxs, target); err != nil {
+			p.logger.Printlnf("WARNING: The bundle relay rejected the bundle for block %d (%s).", target, err.Error())
+			break
+		}
+
+		// Wait for the target block and check if the bundle made it in
+		for head < target {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(bundlePollInterval):
+			}
+			head, err = p.BlockNumber(ctx)
+			if err != nil {
+				return fmt.Errorf("error getting the latest block: %w", err)
+			}
+		}
+		included := true
+		for _, tx := range txs {
+			receipt, err := p.TransactionReceipt(ctx, tx.Hash())
+			if err != nil || receipt == nil {
+				included = false
+				break
+			}
+		}
+		if included {
+			return nil
+		}
+	}
+
+	// Fall back to sending them one at a time
+	p.logger.Printlnf("WARNING: The bundle relay didn't get the %d transactions included within %d blocks, sending them one at a time...", len(txs), p.bundleBlocks)
+	return p.sendTransactionsInOrder(ctx, txs)
+}
+
+// Send transactions to the public mempool one at a time. Ones that were already included or broadcast are skipped.
+func (p *ExecutionClientManager) sendTransactionsInOrder(ctx context.Context, txs []*types.Transaction) error {
+	for _, tx := range txs {
+		if receipt, err := p.TransactionReceipt(ctx, tx.Hash()); err == nil && receipt != nil {
+			continue
+		}
+		err := p.sendPublicTransaction(ctx, tx)
+		if err != nil {
+			message := strings.ToLower(err.Error())
+			if !strings.Contains(message, "already known") {
+				return fmt.Errorf("error sending transaction %s: %w", tx.Hash().Hex(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// Submit a bundle to the relay for a single block.
+// Relays identify senders by a signature of the request body in the X-Flashbots-Signature header.
+func (p *ExecutionClientManager) sendBundle(ctx context.Context, rawTxs []string, blockNumber uint64) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "eth_sendBundle",
+		"params": []interface{}{
+			map[string]interface{}{
+				"txs":         rawTxs,
+				"blockNumber": hexutil.EncodeUint64(blockNumber),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error serializing the request: %w", err)
+	}
+	signature, err := crypto.Sign(accounts.TextHash([]byte(crypto.Keccak256Hash(body).Hex())), p.bundleKey)
+	if err != nil {
+		return fmt.Errorf("error signing the request: %w", err)
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+
+	ctx, cancel := context.WithTimeout(ctx, bundleRequestTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, p.bundleRelayUrl, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating the request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Flashbots-Signature", fmt.Sprintf("%s:%s", crypto.PubkeyToAddress(p.bundleKey.PublicKey).Hex(), hexutil.Encode(signature)))
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("error reading the response: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("the relay returned status %d: %s", response.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+	var result bundleRelayResponse
+	if err := json.Unmarshal(responseBody, &result); err != nil {
+		return fmt.Errorf("error decoding the response: %w", err)
+	}
+	if result.Error != nil {
+		return fmt.Errorf("%s (code %d)", result.Error.Message, result.Error.Code)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"github.com/rocket-pool/smartnode/shared/services/txdecoder"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Whether the EC manager keeps the calls it estimates gas for
+var recordEstimatedCalls bool
+
+// Keep the calls gas is estimated for so they can be previewed; used by the API, where each process handles a single command
+func EnableTransactionPreviews() {
+	recordEstimatedCalls = true
+}
+
+// Get decoded previews of the transactions gas was estimated for.
+// Nothing is returned once a transaction has been sent, since the estimates were for that transaction and it no longer needs confirming.
+func GetTransactionPreviews() []api.TransactionPreview {
+	if ecManager == nil {
+		return nil
+	}
+	ecManager.estimatedCallsLock.Lock()
+	calls := ecManager.estimatedCalls
+	sentTransaction := ecManager.sentTransaction
+	ecManager.estimatedCalls = nil
+	ecManager.estimatedCallsLock.Unlock()
+	if sentTransaction {
+		return nil
+	}
+
+	previews := []api.TransactionPreview{}
+	for _, call := range calls {
+		contractName := ""
+		if rocketPool != nil && call.To != nil {
+			contractName = txdecoder.ResolveContract(rocketPool, *call.To, call.From)
+		}
+
+		// Previews that can't be decoded are still shown, so the user knows which contract they're calling
+		preview, _ := txdecoder.Decode(call.To, call.Value, call.Data, contractName)
+		previews = append(previews, preview)
+	}
+	return previews
+}
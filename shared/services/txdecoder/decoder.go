@@ -0,0 +1,226 @@
+package txdecoder
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// A bundled method and the units of its arguments
+type bundledMethod struct {
+	contract string
+	method   abi.Method
+	units    []string
+}
+
+var (
+	methodsBySelector map[[4]byte][]*bundledMethod
+	loadMethodsOnce   sync.Once
+	loadMethodsErr    error
+)
+
+// Decode a transaction's calldata with the bundled Rocket Pool ABIs.
+// contractName is the name of the target contract if it's known; if it's blank, the method is matched by its selector alone.
+func Decode(to *common.Address, value *big.Int, data []byte, contractName string) (api.TransactionPreview, error) {
+	loadMethodsOnce.Do(func() {
+		loadMethodsErr = loadMethods()
+	})
+	if loadMethodsErr != nil {
+		return api.TransactionPreview{}, loadMethodsErr
+	}
+
+	preview := api.TransactionPreview{
+		To:               to,
+		Contract:         contractName,
+		ContractResolved: contractName != "",
+		Value:            value,
+	}
+	if len(data) == 0 {
+		preview.Method = "(plain ETH transfer)"
+		preview.Decoded = true
+		return preview, nil
+	}
+	if len(data) < 4 {
+		return preview, fmt.Errorf("calldata is too short to contain a method selector")
+	}
+	preview.Selector = hexutil.Encode(data[:4])
+
+	// Find the method, preferring the one on the target contract if it's known
+	var selector [4]byte
+	copy(selector[:], data[:4])
+	candidates := methodsBySelector[selector]
+	if len(candidates) == 0 {
+		return preview, nil
+	}
+	match := candidates[0]
+	for _, candidate := range candidates {
+		if candidate.contract == contractName {
+			match = candidate
+			break
+		}
+	}
+	if preview.Contract == "" {
+		preview.Contract = match.contract
+	}
+	preview.Method = match.method.Sig
+
+	// Decode the arguments
+	values, err := match.method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return preview, fmt.Errorf("error decoding the arguments of %s: %w", match.method.Sig, err)
+	}
+	preview.Arguments = make([]api.TransactionPreviewArgument, len(values))
+	for i, value := range values {
+		input := match.method.Inputs[i]
+		preview.Arguments[i] = api.TransactionPreviewArgument{
+			Name:  input.Name,
+			Type:  input.Type.String(),
+			Value: formatValue(value, match.units[i], preview.Contract),
+		}
+	}
+	preview.Decoded = true
+	return preview, nil
+}
+
+// Get the name of the Rocket Pool contract at an address, or a blank string if it isn't one.
+// from is the account sending the transaction, used to recognize its node's fee distributor.
+func ResolveContract(rp *rocketpool.RocketPool, address common.Address, from common.Address) string {
+	if rp.RocketStorageContract != nil && *rp.RocketStorageContract.Address == address {
+		return "rocketStorage"
+	}
+	for _, group := range bundledMethods {
+		if !strings.HasPrefix(group.contract, "rocket") || group.contract == "rocketStorage" {
+			continue
+		}
+		contractAddress, err := rp.GetAddress(group.contract, nil)
+		if err == nil && *contractAddress == address {
+			return group.contract
+		}
+	}
+	contractAddress, err := rp.GetAddress("rocketTokenRPLFixedSupply", nil)
+	if err == nil && *contractAddress == address {
+		return "rocketTokenRPLFixedSupply"
+	}
+	if exists, err := minipool.GetMinipoolExists(rp, address, nil); err == nil && exists {
+		return ContractMinipool
+	}
+	if distributorAddress, err := node.GetDistributorAddress(rp, from, nil); err == nil && distributorAddress == address {
+		return ContractFeeDistributor
+	}
+	return ""
+}
+
+// Parse the bundled method signatures
+func loadMethods() error {
+	methodsBySelector = map[[4]byte][]*bundledMethod{}
+	for _, group := range bundledMethods {
+		for _, signature := range group.methods {
+			method, err := parseSignature(group.contract, signature)
+			if err != nil {
+				return fmt.Errorf("error parsing bundled method %s: %w", signature, err)
+			}
+			var selector [4]byte
+			copy(selector[:], method.method.ID)
+			methodsBySelector[selector] = append(methodsBySelector[selector], method)
+		}
+	}
+	return nil
+}
+
+// Parse a signature like `name(type arg:unit,type arg)` into a method
+func parseSignature(contract string, signature string) (*bundledMethod, error) {
+	open := strings.Index(signature, "(")
+	if open < 1 || !strings.HasSuffix(signature, ")") {
+		return nil, fmt.Errorf("invalid signature")
+	}
+	name := signature[:open]
+	argList := signature[open+1 : len(signature)-1]
+
+	inputs := abi.Arguments{}
+	units := []string{}
+	if argList != "" {
+		for _, arg := range strings.Split(argList, ",") {
+			parts := strings.Fields(arg)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("invalid argument [%s]", arg)
+			}
+			argName, unit, _ := strings.Cut(parts[1], ":")
+			argType, err := abi.NewType(parts[0], "", nil)
+			if err != nil {
+				return nil, fmt.Errorf("invalid type for argument [%s]: %w", arg, err)
+			}
+			inputs = append(inputs, abi.Argument{Name: argName, Type: argType})
+			units = append(units, unit)
+		}
+	}
+	return &bundledMethod{
+		contract: contract,
+		method:   abi.NewMethod(name, name, abi.Function, "", false, false, inputs, nil),
+		units:    units,
+	}, nil
+}
+
+// Format a decoded argument for display
+func formatValue(value interface{}, unit string, contract string) string {
+	switch v := value.(type) {
+	case *big.Int:
+		return formatAmount(v, unit, contract)
+	case []*big.Int:
+		amounts := make([]string, len(v))
+		for i, amount := range v {
+			amounts[i] = formatAmount(amount, unit, contract)
+		}
+		return "[" + strings.Join(amounts, ", ") + "]"
+	case common.Address:
+		return v.Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	case [32]byte:
+		return hexutil.Encode(v[:])
+	case [][][32]byte:
+		proofs := make([]string, len(v))
+		for i, proof := range v {
+			hashes := make([]string, len(proof))
+			for j, hash := range proof {
+				hashes[j] = hexutil.Encode(hash[:])
+			}
+			proofs[i] = "[" + strings.Join(hashes, ", ") + "]"
+		}
+		return "[" + strings.Join(proofs, ", ") + "]"
+	case string:
+		return fmt.Sprintf("%q", v)
+	}
+	return fmt.Sprint(value)
+}
+
+// Format an amount in its unit, keeping the raw value so it can be checked exactly
+func formatAmount(amount *big.Int, unit string, contract string) string {
+	if unit == "token" {
+		switch contract {
+		case "rocketTokenRPL", "rocketTokenRPLFixedSupply":
+			unit = "RPL"
+		case "rocketTokenRETH":
+			unit = "rETH"
+		default:
+			unit = "tokens"
+		}
+	}
+	switch unit {
+	case "":
+		return amount.String()
+	case "pct":
+		return fmt.Sprintf("%.2f%%", eth.WeiToEth(amount)*100)
+	}
+	return fmt.Sprintf("%.6f %s (%s wei)", eth.WeiToEth(amount), unit, amount.String())
+}
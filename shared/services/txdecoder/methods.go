@@ -0,0 +1,101 @@
+package txdecoder
+
+// Contract names for the things that aren't network contracts, but that the Smartnode still sends transactions to
+const (
+	ContractMinipool       string = "minipool"
+	ContractFeeDistributor string = "feeDistributor"
+	ContractToken          string = "token"
+)
+
+// The methods the Smartnode calls, grouped by contract. Arguments are written as `type name`, optionally followed by the unit
+// of the amount: ETH, RPL, rETH, token (the target token's own unit), or pct (a fraction where 1e18 is 100%).
+var bundledMethods = []struct {
+	contract string
+	methods  []string
+}{
+	{"rocketNodeDeposit", []string{
+		"deposit(uint256 bondAmount:ETH,uint256 minimumNodeFee:pct,bytes validatorPubkey,bytes validatorSignature,bytes32 depositDataRoot,uint256 salt,address expectedMinipoolAddress)",
+		"depositWithCredit(uint256 bondAmount:ETH,uint256 minimumNodeFee:pct,bytes validatorPubkey,bytes validatorSignature,bytes32 depositDataRoot,uint256 salt,address expectedMinipoolAddress)",
+		"createVacantMinipool(uint256 bondAmount:ETH,uint256 minimumNodeFee:pct,bytes validatorPubkey,uint256 salt,address expectedMinipoolAddress,uint256 currentBalance:ETH)",
+		"withdrawEth(address nodeAddress,uint256 amount:ETH)",
+	}},
+	{"rocketNodeManager", []string{
+		"registerNode(string timezoneLocation)",
+		"setTimezoneLocation(string timezoneLocation)",
+		"initialiseFeeDistributor()",
+		"setSmoothingPoolRegistrationState(bool state)",
+		"setRPLWithdrawalAddress(address nodeAddress,address newRPLWithdrawalAddress,bool confirm)",
+		"confirmRPLWithdrawalAddress(address nodeAddress)",
+	}},
+	{"rocketNodeStaking", []string{
+		"stakeRPL(uint256 amount:RPL)",
+		"withdrawRPL(address nodeAddress,uint256 amount:RPL)",
+		"setRPLLockingAllowed(address nodeAddress,bool allowed)",
+		"setStakeRPLForAllowed(address caller,bool allowed)",
+	}},
+	{"rocketStorage", []string{
+		"setWithdrawalAddress(address nodeAddress,address newWithdrawalAddress,bool confirm)",
+		"confirmWithdrawalAddress(address nodeAddress)",
+	}},
+	{"rocketMinipoolBondReducer", []string{
+		"beginReduceBondAmount(address minipoolAddress,uint256 newBondAmount:ETH)",
+		"voteCancelReduction(address minipoolAddress)",
+	}},
+	{"rocketMerkleDistributorMainnet", []string{
+		"claim(address nodeAddress,uint256[] rewardIndex,uint256[] amountRPL:RPL,uint256[] amountETH:ETH,bytes32[][] merkleProof)",
+		"claimAndStake(address nodeAddress,uint256[] rewardIndex,uint256[] amountRPL:RPL,uint256[] amountETH:ETH,bytes32[][] merkleProof,uint256 stakeAmount:RPL)",
+	}},
+	{"rocketDepositPool", []string{
+		"deposit()",
+		"assignDeposits()",
+	}},
+	{"rocketTokenRETH", []string{
+		"burn(uint256 rethAmount:rETH)",
+	}},
+	{"rocketTokenRPL", []string{
+		"swapTokens(uint256 amount:RPL)",
+		"inflationMintTokens()",
+	}},
+	{"rocketAuctionManager", []string{
+		"createLot()",
+		"placeBid(uint256 lotIndex)",
+		"claimBid(uint256 lotIndex)",
+		"recoverUnclaimedRPL(uint256 lotIndex)",
+	}},
+	{"rocketNetworkVoting", []string{
+		"initialiseVoting()",
+		"setDelegate(address newDelegate)",
+	}},
+	{"rocketDAOProtocolProposal", []string{
+		"overrideVote(uint256 proposalID,uint8 voteDirection)",
+		"finalise(uint256 proposalID)",
+		"execute(uint256 proposalID)",
+	}},
+	{"rocketDAOProtocolVerifier", []string{
+		"defeatProposal(uint256 proposalID,uint256 index)",
+		"claimBondProposer(uint256 proposalID,uint256[] indices)",
+		"claimBondChallenger(uint256 proposalID,uint256[] indices)",
+	}},
+	{ContractMinipool, []string{
+		"stake(bytes validatorSignature,bytes32 depositDataRoot)",
+		"promote()",
+		"dissolve()",
+		"close()",
+		"refund()",
+		"finalise()",
+		"distributeBalance(bool rewardsOnly)",
+		"delegateUpgrade()",
+		"delegateRollback()",
+		"setUseLatestDelegate(bool setting)",
+		"reduceBondAmount()",
+		"voteScrub()",
+	}},
+	{ContractFeeDistributor, []string{
+		"distribute()",
+	}},
+	{ContractToken, []string{
+		"approve(address spender,uint256 amount:token)",
+		"transfer(address to,uint256 amount:token)",
+		"transferFrom(address from,address to,uint256 amount:token)",
+	}},
+}
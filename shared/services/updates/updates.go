@@ -0,0 +1,305 @@
+package updates
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-version"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Settings
+const (
+	githubLatestReleaseUrl string = "https://api.github.com/repos/%s/releases/latest"
+	dockerHubTagUrl        string = "https://hub.docker.com/v2/repositories/%s/tags/%s"
+	smartnodeGithubRepo    string = "rocket-pool/smartnode"
+	requestTimeout                = 15 * time.Second
+)
+
+// Finds the version in an image tag or release name, keeping any alpha, beta, or rc suffix
+var versionRegex = regexp.MustCompile(`\d+\.\d+(\.\d+)?(-(alpha|beta|rc)[.\d]*)?`)
+
+// The GitHub repositories each client publishes its releases in
+var clientRepos = map[string]string{
+	"Geth":       "ethereum/go-ethereum",
+	"Nethermind": "NethermindEth/nethermind",
+	"Besu":       "hyperledger/besu",
+	"Reth":       "paradigmxyz/reth",
+	"Lighthouse": "sigp/lighthouse",
+	"Lodestar":   "ChainSafe/lodestar",
+	"Nimbus":     "status-im/nimbus-eth2",
+	"Prysm":      "prysmaticlabs/prysm",
+	"Teku":       "Consensys/teku",
+	"MEV-Boost":  "flashbots/mev-boost",
+}
+
+// A container the Smartnode runs whose image tag can be upgraded in the config
+type ManagedImage struct {
+	Name            string
+	ContainerSuffix string
+	GithubRepo      string
+	Param           *cfgtypes.Parameter
+}
+
+// The result of checking a client or the Smartnode for a new release
+type ImageUpdate struct {
+	Name            string    `json:"name"`
+	ContainerSuffix string    `json:"containerSuffix"`
+	CurrentTag      string    `json:"currentTag"`
+	LatestTag       string    `json:"latestTag"`
+	LatestVersion   string    `json:"latestVersion"`
+	ReleaseUrl      string    `json:"releaseUrl"`
+	ReleaseTime     time.Time `json:"releaseTime"`
+	Changelog       string    `json:"changelog"`
+	UpdateAvailable bool      `json:"updateAvailable"`
+	Error           string    `json:"error,omitempty"`
+}
+
+// A release from the GitHub API
+type githubRelease struct {
+	TagName     string    `json:"tag_name"`
+	HtmlUrl     string    `json:"html_url"`
+	Body        string    `json:"body"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// Get the images of the locally managed clients in the config
+func GetManagedImages(cfg *config.RocketPoolConfig) []ManagedImage {
+	images := []ManagedImage{}
+	add := func(name string, container string, param *cfgtypes.Parameter) {
+		images = append(images, ManagedImage{
+			Name:            name,
+			ContainerSuffix: container,
+			GithubRepo:      clientRepos[name],
+			Param:           param,
+		})
+	}
+
+	if cfg.ExecutionClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+		switch cfg.ExecutionClient.Value.(cfgtypes.ExecutionClient) {
+		case cfgtypes.ExecutionClient_Geth:
+			add("Geth", "_eth1", &cfg.Geth.ContainerTag)
+		case cfgtypes.ExecutionClient_Nethermind:
+			add("Nethermind", "_eth1", &cfg.Nethermind.ContainerTag)
+		case cfgtypes.ExecutionClient_Besu:
+			add("Besu", "_eth1", &cfg.Besu.ContainerTag)
+		case cfgtypes.ExecutionClient_Reth:
+			add("Reth", "_eth1", &cfg.Reth.ContainerTag)
+		}
+	}
+
+	switch cfg.ConsensusClientMode.Value.(cfgtypes.Mode) {
+	case cfgtypes.Mode_Local:
+		switch cfg.ConsensusClient.Value.(cfgtypes.ConsensusClient) {
+		case cfgtypes.ConsensusClient_Lighthouse:
+			add("Lighthouse", "_eth2", &cfg.Lighthouse.ContainerTag)
+		case cfgtypes.ConsensusClient_Lodestar:
+			add("Lodestar", "_eth2", &cfg.Lodestar.ContainerTag)
+		case cfgtypes.ConsensusClient_Nimbus:
+			add("Nimbus", "_eth2", &cfg.Nimbus.BnContainerTag)
+			add("Nimbus", "_validator", &cfg.Nimbus.VcContainerTag)
+		case cfgtypes.ConsensusClient_Prysm:
+			add("Prysm", "_eth2", &cfg.Prysm.BnContainerTag)
+			add("Prysm", "_validator", &cfg.Prysm.VcContainerTag)
+		case cfgtypes.ConsensusClient_Teku:
+			add("Teku", "_eth2", &cfg.Teku.ContainerTag)
+		}
+	case cfgtypes.Mode_External:
+		switch cfg.ExternalConsensusClient.Value.(cfgtypes.ConsensusClient) {
+		case cfgtypes.ConsensusClient_Lighthouse:
+			add("Lighthouse", "_validator", &cfg.ExternalLighthouse.ContainerTag)
+		case cfgtypes.ConsensusClient_Lodestar:
+			add("Lodestar", "_validator", &cfg.ExternalLodestar.ContainerTag)
+		case cfgtypes.ConsensusClient_Nimbus:
+			add("Nimbus", "_validator", &cfg.ExternalNimbus.ContainerTag)
+		case cfgtypes.ConsensusClient_Prysm:
+			add("Prysm", "_validator", &cfg.ExternalPrysm.ContainerTag)
+		case cfgtypes.ConsensusClient_Teku:
+			add("Teku", "_validator", &cfg.ExternalTeku.ContainerTag)
+		}
+	}
+
+	if cfg.EnableMevBoost.Value == true && cfg.MevBoost.Mode.Value == cfgtypes.Mode_Local {
+		add("MEV-Boost", "_mev-boost", &cfg.MevBoost.ContainerTag)
+	}
+
+	return images
+}
+
+// Check the managed clients and the Smartnode itself for new releases.
+// The checks run in parallel; clients come first, in the order they're configured, and the Smartnode is last.
+func CheckForUpdates(cfg *config.RocketPoolConfig, smartnodeVersion string) []ImageUpdate {
+	images := GetManagedImages(cfg)
+	results := make([]ImageUpdate, len(images)+1)
+	var wg sync.WaitGroup
+	for i, image := range images {
+		wg.Add(1)
+		go func(i int, image ManagedImage) {
+			defer wg.Done()
+			results[i] = CheckImage(image)
+		}(i, image)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		results[len(images)] = checkSmartnode(smartnodeVersion)
+	}()
+	wg.Wait()
+	return results
+}
+
+// Check a client image for a new release, and make sure the new tag has been published before offering it
+func CheckImage(image ManagedImage) ImageUpdate {
+	currentTag := image.Param.Value.(string)
+	update := ImageUpdate{
+		Name:            image.Name,
+		ContainerSuffix: image.ContainerSuffix,
+		CurrentTag:      currentTag,
+	}
+
+	currentVersion, err := parseVersion(currentTag)
+	if err != nil {
+		update.Error = err.Error()
+		return update
+	}
+	release, err := getLatestRelease(image.GithubRepo)
+	if err != nil {
+		update.Error = err.Error()
+		return update
+	}
+	latestVersion, err := parseVersion(release.TagName)
+	if err != nil {
+		update.Error = err.Error()
+		return update
+	}
+	update.LatestVersion = release.TagName
+	update.ReleaseUrl = release.HtmlUrl
+	update.ReleaseTime = release.PublishedAt
+	update.Changelog = strings.TrimSpace(release.Body)
+	if !latestVersion.GreaterThan(currentVersion) {
+		update.LatestTag = currentTag
+		return update
+	}
+
+	// Keep everything in the tag around the version, like a "v" prefix or a "-modern" suffix
+	offset := strings.LastIndex(currentTag, ":") + 1
+	location := versionRegex.FindStringIndex(currentTag[offset:])
+	latestTag := currentTag[:offset+location[0]] + versionRegex.FindString(release.TagName) + currentTag[offset+location[1]:]
+
+	exists, err := tagExists(latestTag)
+	if err != nil {
+		update.Error = err.Error()
+		return update
+	}
+	if !exists {
+		update.Error = fmt.Sprintf("%s has been released, but the %s image hasn't been published yet", release.TagName, latestTag)
+		return update
+	}
+	update.LatestTag = latestTag
+	update.UpdateAvailable = true
+	return update
+}
+
+// Check for a new Smartnode release; the Smartnode can't be upgraded by changing a tag, so this is only informational
+func checkSmartnode(smartnodeVersion string) ImageUpdate {
+	update := ImageUpdate{
+		Name:       "Smartnode",
+		CurrentTag: smartnodeVersion,
+	}
+	currentVersion, err := version.NewVersion(strings.TrimPrefix(smartnodeVersion, "v"))
+	if err != nil {
+		update.Error = fmt.Sprintf("error parsing the Smartnode version [%s]: %s", smartnodeVersion, err.Error())
+		return update
+	}
+	release, err := getLatestRelease(smartnodeGithubRepo)
+	if err != nil {
+		update.Error = err.Error()
+		return update
+	}
+	latestVersion, err := parseVersion(release.TagName)
+	if err != nil {
+		update.Error = err.Error()
+		return update
+	}
+	update.LatestTag = release.TagName
+	update.LatestVersion = release.TagName
+	update.ReleaseUrl = release.HtmlUrl
+	update.ReleaseTime = release.PublishedAt
+	update.Changelog = strings.TrimSpace(release.Body)
+	update.UpdateAvailable = latestVersion.GreaterThan(currentVersion)
+	return update
+}
+
+// Get the version number from an image tag or release name
+func parseVersion(tag string) (*version.Version, error) {
+	versionString := versionRegex.FindString(tag[strings.LastIndex(tag, ":")+1:])
+	if versionString == "" {
+		return nil, fmt.Errorf("couldn't find a version number in [%s]", tag)
+	}
+	parsed, err := version.NewVersion(versionString)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing version [%s] from [%s]: %w", versionString, tag, err)
+	}
+	return parsed, nil
+}
+
+// Get the latest stable release of a GitHub repository
+func getLatestRelease(repo string) (githubRelease, error) {
+	client := http.Client{Timeout: requestTimeout}
+	response, err := client.Get(fmt.Sprintf(githubLatestReleaseUrl, repo))
+	if err != nil {
+		return githubRelease{}, fmt.Errorf("error getting the latest release of %s: %w", repo, err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return githubRelease{}, fmt.Errorf("error reading the latest release of %s: %w", repo, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return githubRelease{}, fmt.Errorf("error getting the latest release of %s: HTTP status %d; response body: '%s'", repo, response.StatusCode, string(body))
+	}
+	var release githubRelease
+	if err := json.Unmarshal(body, &release); err != nil {
+		return githubRelease{}, fmt.Errorf("error decoding the latest release of %s: %w", repo, err)
+	}
+	return release, nil
+}
+
+// Check if an image tag has been published. Only Docker Hub can be checked; images on other registries are assumed to exist.
+func tagExists(image string) (bool, error) {
+	separator := strings.LastIndex(image, ":")
+	if separator == -1 {
+		return false, fmt.Errorf("image [%s] doesn't have a tag", image)
+	}
+	repo := image[:separator]
+	tag := image[separator+1:]
+	if strings.Contains(strings.Split(repo, "/")[0], ".") {
+		return true, nil
+	}
+	if !strings.Contains(repo, "/") {
+		repo = "library/" + repo
+	}
+
+	client := http.Client{Timeout: requestTimeout}
+	response, err := client.Get(fmt.Sprintf(dockerHubTagUrl, repo, tag))
+	if err != nil {
+		return false, fmt.Errorf("error checking Docker Hub for %s: %w", image, err)
+	}
+	defer response.Body.Close()
+	switch response.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("error checking Docker Hub for %s: HTTP status %d", image, response.StatusCode)
+	}
+}
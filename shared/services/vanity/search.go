@@ -0,0 +1,345 @@
+package vanity
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Settings
+const (
+	// How many salts a thread takes at a time; small enough that a resumed search repeats very little work
+	chunkSize uint64 = 1 << 16
+
+	// How many salts a thread checks between updates to the shared counter
+	reportBatchSize uint64 = 4096
+
+	saltLength int = 32
+)
+
+// The minipool address a search is looking for, and everything needed to compute minipool addresses from salts
+type Target struct {
+	NodeAddress            common.Address `json:"nodeAddress"`
+	MinipoolFactoryAddress common.Address `json:"minipoolFactoryAddress"`
+	InitHash               common.Hash    `json:"initHash"`
+	Prefix                 string         `json:"prefix"`
+
+	// The prefix as nibbles, for fast comparisons
+	nibbles []byte
+}
+
+// A salt that produces a matching address
+type Result struct {
+	Salt    *big.Int
+	Address common.Address
+}
+
+// How far a search has gotten
+type Progress struct {
+	// Every salt below this one has been checked, so the search can be resumed from here
+	Checkpoint *big.Int
+
+	// How many salts have been checked since the search started
+	Checked uint64
+
+	// How many salts are being checked per second
+	Rate float64
+
+	Elapsed time.Duration
+}
+
+// Create a target for the given prefix, which must be a hex string starting with 0x
+func NewTarget(nodeAddress common.Address, minipoolFactoryAddress common.Address, initHash common.Hash, prefix string) (Target, error) {
+	if !strings.HasPrefix(prefix, "0x") {
+		return Target{}, fmt.Errorf("prefix must start with 0x")
+	}
+	prefix = strings.ToLower(prefix)
+	digits := strings.TrimPrefix(prefix, "0x")
+	if len(digits) == 0 || len(digits) > common.AddressLength*2 {
+		return Target{}, fmt.Errorf("prefix must have between 1 and %d hex digits", common.AddressLength*2)
+	}
+	nibbles := make([]byte, len(digits))
+	for i, digit := range digits {
+		value, err := hex.DecodeString("0" + string(digit))
+		if err != nil {
+			return Target{}, fmt.Errorf("invalid prefix %s: %w", prefix, err)
+		}
+		nibbles[i] = value[0]
+	}
+	return Target{
+		NodeAddress:            nodeAddress,
+		MinipoolFactoryAddress: minipoolFactoryAddress,
+		InitHash:               initHash,
+		Prefix:                 prefix,
+		nibbles:                nibbles,
+	}, nil
+}
+
+// Get the address of the minipool the node would create with the given salt
+func (t *Target) GetAddress(salt *big.Int) common.Address {
+	saltBytes := [saltLength]byte{}
+	salt.FillBytes(saltBytes[:])
+	nodeSalt := crypto.Keccak256Hash(t.NodeAddress.Bytes(), saltBytes[:])
+	return crypto.CreateAddress2(t.MinipoolFactoryAddress, nodeSalt, t.InitHash.Bytes())
+}
+
+// Check if an address starts with the target prefix
+func (t *Target) Matches(address common.Address) bool {
+	return t.matchesBytes(address.Bytes())
+}
+
+// Check if a result is a real match; results from external workers must be verified before they're used
+func (t *Target) Verify(salt *big.Int) (common.Address, error) {
+	if salt.Sign() < 0 || salt.BitLen() > saltLength*8 {
+		return common.Address{}, fmt.Errorf("salt 0x%x is out of range", salt)
+	}
+	address := t.GetAddress(salt)
+	if !t.Matches(address) {
+		return address, fmt.Errorf("salt 0x%x produces address %s, which doesn't start with %s", salt, address.Hex(), t.Prefix)
+	}
+	return address, nil
+}
+
+// Get the expected number of salts that have to be checked to find a match
+func (t *Target) ExpectedAttempts() float64 {
+	expected := 1.0
+	for range t.nibbles {
+		expected *= 16
+	}
+	return expected
+}
+
+// Check if the 20 address bytes start with the target prefix
+func (t *Target) matchesBytes(address []byte) bool {
+	for i, nibble := range t.nibbles {
+		b := address[i/2]
+		if i%2 == 0 {
+			b >>= 4
+		} else {
+			b &= 0x0f
+		}
+		if b != nibble {
+			return false
+		}
+	}
+	return true
+}
+
+// Search the salts from start up to (but not including) end for a matching address, using the given number of threads.
+// If end is nil, the search runs until a match is found or the context is cancelled.
+// The progress callback is called every reportInterval; the final progress is returned along with the result, which is nil if there wasn't a match.
+func Search(ctx context.Context, target Target, start *big.Int, end *big.Int, threads int, reportInterval time.Duration, report func(Progress)) (*Result, Progress, error) {
+	if len(target.nibbles) == 0 {
+		return nil, Progress{}, fmt.Errorf("the target doesn't have a prefix")
+	}
+	if threads < 1 {
+		threads = 1
+	}
+	if end != nil && end.Cmp(start) <= 0 {
+		return nil, Progress{Checkpoint: new(big.Int).Set(start)}, nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	tracker := newChunkTracker(start, end)
+	var checked atomic.Uint64
+	var result *Result
+	var resultLock sync.Mutex
+
+	// Spawn the workers
+	startTime := time.Now()
+	wg := new(sync.WaitGroup)
+	wg.Add(threads)
+	for i := 0; i < threads; i++ {
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				index, chunkStart, count, ok := tracker.next()
+				if !ok {
+					return
+				}
+				found := searchChunk(ctx, &target, chunkStart, count, &checked)
+				if found != nil {
+					resultLock.Lock()
+					if result == nil || found.Salt.Cmp(result.Salt) < 0 {
+						result = found
+					}
+					resultLock.Unlock()
+					cancel()
+					return
+				}
+				if ctx.Err() == nil {
+					tracker.finish(index)
+				}
+			}
+		}()
+	}
+
+	// Report progress until the workers are done
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	getProgress := func() Progress {
+		elapsed := time.Since(startTime)
+		count := checked.Load()
+		return Progress{
+			Checkpoint: tracker.checkpoint(),
+			Checked:    count,
+			Rate:       float64(count) / elapsed.Seconds(),
+			Elapsed:    elapsed,
+		}
+	}
+	ticker := time.NewTicker(reportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if report != nil {
+				report(getProgress())
+			}
+		case <-done:
+			return result, getProgress(), nil
+		}
+	}
+}
+
+// Check a run of salts, returning the first match
+func searchChunk(ctx context.Context, target *Target, start *big.Int, count uint64, checked *atomic.Uint64) *Result {
+	hasher := crypto.NewKeccakState()
+	saltBytes := [saltLength]byte{}
+	start.FillBytes(saltBytes[:])
+	nodeAddress := target.NodeAddress.Bytes()
+	factoryAddress := target.MinipoolFactoryAddress.Bytes()
+	initHash := target.InitHash.Bytes()
+	nodeSalt := common.Hash{}
+	addressResult := common.Hash{}
+
+	// Don't update the counter or check the context on every salt, it's much slower than the hashing
+	reported := uint64(0)
+	for i := uint64(0); i < count; i++ {
+		if i-reported == reportBatchSize {
+			checked.Add(reportBatchSize)
+			reported = i
+			if ctx.Err() != nil {
+				return nil
+			}
+		}
+
+		// This is the fast way to do `nodeSalt := crypto.Keccak256Hash(nodeAddress, saltBytes)`
+		hasher.Write(nodeAddress)
+		hasher.Write(saltBytes[:])
+		hasher.Read(nodeSalt[:])
+		hasher.Reset()
+
+		// This is the fast way to do `crypto.CreateAddress2(minipoolFactoryAddress, nodeSalt, initHash)`,
+		// keeping the result as bytes; the first 12 bytes aren't part of the address
+		hasher.Write([]byte{0xff})
+		hasher.Write(factoryAddress)
+		hasher.Write(nodeSalt[:])
+		hasher.Write(initHash)
+		hasher.Read(addressResult[:])
+		hasher.Reset()
+
+		if target.matchesBytes(addressResult[12:]) {
+			checked.Add(i + 1 - reported)
+			return &Result{
+				Salt:    new(big.Int).SetBytes(saltBytes[:]),
+				Address: common.BytesToAddress(addressResult[12:]),
+			}
+		}
+
+		// Increment the salt in place
+		for j := saltLength - 1; j >= 0; j-- {
+			saltBytes[j]++
+			if saltBytes[j] != 0 {
+				break
+			}
+		}
+	}
+	checked.Add(count - reported)
+	return nil
+}
+
+// Hands out chunks of the search range and keeps track of which ones are done, so the search can be resumed
+type chunkTracker struct {
+	start *big.Int
+	end   *big.Int
+
+	lock      sync.Mutex
+	nextIndex uint64
+	exhausted bool
+
+	// The first chunk that isn't finished, and the ones after it that are
+	firstUnfinished uint64
+	finished        map[uint64]bool
+}
+
+func newChunkTracker(start *big.Int, end *big.Int) *chunkTracker {
+	return &chunkTracker{
+		start:    new(big.Int).Set(start),
+		end:      end,
+		finished: map[uint64]bool{},
+	}
+}
+
+// Get the next chunk to search
+func (t *chunkTracker) next() (uint64, *big.Int, uint64, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.exhausted {
+		return 0, nil, 0, false
+	}
+	index := t.nextIndex
+	chunkStart := t.chunkStart(index)
+	count := chunkSize
+	if t.end != nil {
+		remaining := new(big.Int).Sub(t.end, chunkStart)
+		if remaining.Sign() <= 0 {
+			t.exhausted = true
+			return 0, nil, 0, false
+		}
+		if remaining.IsUint64() && remaining.Uint64() <= count {
+			count = remaining.Uint64()
+			t.exhausted = true
+		}
+	}
+	t.nextIndex++
+	return index, chunkStart, count, true
+}
+
+// Mark a chunk as completely searched
+func (t *chunkTracker) finish(index uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.finished[index] = true
+	for t.finished[t.firstUnfinished] {
+		delete(t.finished, t.firstUnfinished)
+		t.firstUnfinished++
+	}
+}
+
+// Get the salt below which every chunk has been searched
+func (t *chunkTracker) checkpoint() *big.Int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	checkpoint := t.chunkStart(t.firstUnfinished)
+	if t.end != nil && checkpoint.Cmp(t.end) > 0 {
+		checkpoint.Set(t.end)
+	}
+	return checkpoint
+}
+
+func (t *chunkTracker) chunkStart(index uint64) *big.Int {
+	offset := new(big.Int).Mul(new(big.Int).SetUint64(index), new(big.Int).SetUint64(chunkSize))
+	return offset.Add(offset, t.start)
+}
@@ -0,0 +1,74 @@
+package vanity
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+const stateFileMode os.FileMode = 0644
+
+// A search's progress, saved so it can be resumed after it's interrupted
+type SearchState struct {
+	Job Job `json:"job"`
+
+	// Every salt below this one has been checked
+	Checkpoint string `json:"checkpoint"`
+
+	// How many salts have been checked across every run
+	Checked uint64 `json:"checked"`
+
+	// The matching salt and address, once one is found
+	FoundSalt    string `json:"foundSalt,omitempty"`
+	FoundAddress string `json:"foundAddress,omitempty"`
+
+	Updated time.Time `json:"updated"`
+}
+
+// Load a saved search, returning nil if the file doesn't exist
+func LoadState(path string) (*SearchState, error) {
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading vanity search state [%s]: %w", path, err)
+	}
+	var state SearchState
+	if err := json.Unmarshal(bytes, &state); err != nil {
+		return nil, fmt.Errorf("error decoding vanity search state [%s]: %w", path, err)
+	}
+	return &state, nil
+}
+
+// Save a search's progress
+func SaveState(path string, state *SearchState) error {
+	state.Updated = time.Now().UTC()
+	bytes, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing vanity search state: %w", err)
+	}
+	if err := files.WriteFileAtomic(path, bytes, stateFileMode); err != nil {
+		return fmt.Errorf("error saving vanity search state [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Check if a saved search is for the same target and range as a job, so it can be resumed
+func (s *SearchState) Matches(job Job) bool {
+	return s.Job.NodeAddress == job.NodeAddress &&
+		s.Job.MinipoolFactoryAddress == job.MinipoolFactoryAddress &&
+		s.Job.InitHash == job.InitHash &&
+		s.Job.Prefix == job.Prefix &&
+		s.Job.Start == job.Start &&
+		s.Job.End == job.End
+}
+
+// Get the salt the search should resume from
+func (s *SearchState) GetCheckpoint() (*big.Int, error) {
+	return parseSalt(s.Checkpoint)
+}
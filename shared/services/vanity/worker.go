@@ -0,0 +1,193 @@
+package vanity
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"os/exec"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// External workers, such as GPU searchers, are run as a shell command. The protocol is:
+//
+//   - The Smartnode writes the Job as a single line of JSON to the worker's stdin, then closes it.
+//   - The worker searches the salts from start up to (but not including) end, or without limit if end is empty.
+//     A salt's address is keccak256(0xff ++ minipoolFactoryAddress ++ keccak256(nodeAddress ++ salt) ++ initHash)[12:],
+//     where the salt is a 32-byte big-endian integer.
+//   - The worker writes WorkerMessages to stdout as one line of JSON each: "progress" messages as it goes,
+//     and a "result" message when it finds a match. It can exit after a result, or when the range is exhausted.
+//   - Anything the worker writes to stderr is shown to the user.
+//
+// Every result is verified before it's accepted, so a faulty worker can't produce a salt with the wrong address.
+
+// The current version of the worker protocol
+const WorkerProtocolVersion uint64 = 1
+
+// Message types sent by workers
+const (
+	WorkerMessageType_Progress string = "progress"
+	WorkerMessageType_Result   string = "result"
+)
+
+// A search range sent to an external worker
+type Job struct {
+	Version                uint64         `json:"version"`
+	NodeAddress            common.Address `json:"nodeAddress"`
+	MinipoolFactoryAddress common.Address `json:"minipoolFactoryAddress"`
+	InitHash               common.Hash    `json:"initHash"`
+	Prefix                 string         `json:"prefix"`
+	Start                  string         `json:"start"`
+	End                    string         `json:"end,omitempty"`
+}
+
+// A message from an external worker
+type WorkerMessage struct {
+	Type string `json:"type"`
+
+	// For progress messages, the salt below which everything has been checked; for results, the matching salt
+	Salt string `json:"salt"`
+
+	// How many salts have been checked so far
+	Checked uint64 `json:"checked,omitempty"`
+}
+
+// Create a job for searching a range; end can be nil for an unlimited search
+func NewJob(target Target, start *big.Int, end *big.Int) Job {
+	job := Job{
+		Version:                WorkerProtocolVersion,
+		NodeAddress:            target.NodeAddress,
+		MinipoolFactoryAddress: target.MinipoolFactoryAddress,
+		InitHash:               target.InitHash,
+		Prefix:                 target.Prefix,
+		Start:                  fmt.Sprintf("0x%x", start),
+	}
+	if end != nil {
+		job.End = fmt.Sprintf("0x%x", end)
+	}
+	return job
+}
+
+// Get the target the job is searching for
+func (j Job) GetTarget() (Target, error) {
+	return NewTarget(j.NodeAddress, j.MinipoolFactoryAddress, j.InitHash, j.Prefix)
+}
+
+// Get the range the job covers; end is nil for an unlimited search
+func (j Job) GetRange() (*big.Int, *big.Int, error) {
+	start, err := parseSalt(j.Start)
+	if err != nil {
+		return nil, nil, err
+	}
+	if j.End == "" {
+		return start, nil, nil
+	}
+	end, err := parseSalt(j.End)
+	if err != nil {
+		return nil, nil, err
+	}
+	return start, end, nil
+}
+
+// Run an external worker on a job, returning its verified result or nil if it finished the range without one
+func RunWorker(ctx context.Context, command string, job Job, report func(Progress)) (*Result, error) {
+	target, err := job.GetTarget()
+	if err != nil {
+		return nil, err
+	}
+	jobBytes, err := json.Marshal(job)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing vanity search job: %w", err)
+	}
+
+	// Start the worker
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("error creating worker stdin: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("error creating worker stdout: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("error starting worker [%s]: %w", command, err)
+	}
+	if _, err := stdin.Write(append(jobBytes, '\n')); err != nil {
+		return nil, fmt.Errorf("error sending the job to the worker: %w", err)
+	}
+	stdin.Close()
+
+	// Read its messages until it reports a match or exits
+	result, readErr := readWorkerMessages(bufio.NewScanner(stdout), &target, report)
+	if result != nil || readErr != nil {
+		cancel()
+	}
+	waitErr := cmd.Wait()
+	if readErr != nil {
+		return nil, readErr
+	}
+	if result != nil {
+		return result, nil
+	}
+	if waitErr != nil {
+		return nil, fmt.Errorf("worker failed: %w", waitErr)
+	}
+	return nil, nil
+}
+
+// Process messages from a worker, returning the first verified result
+func readWorkerMessages(scanner *bufio.Scanner, target *Target, report func(Progress)) (*Result, error) {
+	for scanner.Scan() {
+		var message WorkerMessage
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			return nil, fmt.Errorf("error decoding worker message [%s]: %w", scanner.Text(), err)
+		}
+		salt, err := parseSalt(message.Salt)
+		if err != nil {
+			return nil, fmt.Errorf("worker sent an invalid salt: %w", err)
+		}
+
+		switch message.Type {
+		case WorkerMessageType_Progress:
+			if report != nil {
+				report(Progress{
+					Checkpoint: salt,
+					Checked:    message.Checked,
+				})
+			}
+
+		case WorkerMessageType_Result:
+			address, err := target.Verify(salt)
+			if err != nil {
+				return nil, fmt.Errorf("worker sent a result that failed verification: %w", err)
+			}
+			return &Result{
+				Salt:    salt,
+				Address: address,
+			}, nil
+
+		default:
+			return nil, fmt.Errorf("worker sent an unknown message type '%s'", message.Type)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading from worker: %w", err)
+	}
+	return nil, nil
+}
+
+// Parse a salt, which can be decimal or hex with a 0x prefix
+func parseSalt(value string) (*big.Int, error) {
+	salt, success := new(big.Int).SetString(value, 0)
+	if !success || salt.Sign() < 0 || salt.BitLen() > saltLength*8 {
+		return nil, fmt.Errorf("invalid salt '%s'", value)
+	}
+	return salt, nil
+}
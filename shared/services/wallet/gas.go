@@ -0,0 +1,226 @@
+package wallet
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// The length of the gas wallet's generated password, in bytes
+const gasWalletPasswordLength int = 32
+
+// A transaction the Smartnode can send from the gas wallet
+type GasWalletTx string
+
+const (
+	GasWalletTx_DistributeMinipool GasWalletTx = "distribute-minipool"
+	GasWalletTx_DistributeFees     GasWalletTx = "distribute-fees"
+	GasWalletTx_UpgradeDelegate    GasWalletTx = "upgrade-delegate"
+	GasWalletTx_StakeMinipool      GasWalletTx = "stake-minipool"
+	GasWalletTx_PromoteMinipool    GasWalletTx = "promote-minipool"
+	GasWalletTx_ReduceBond         GasWalletTx = "reduce-bond"
+	GasWalletTx_ClaimRewards       GasWalletTx = "claim-rewards"
+)
+
+// Which account pays for a kind of transaction once the gas wallet is set up, and why
+type GasWalletRoute struct {
+	Tx            GasWalletTx
+	Description   string
+	UsesGasWallet bool
+	Reason        string
+}
+
+// The routing rules for the gas wallet. Only transactions the contracts accept from any sender can use it; everything else has to
+// come from the node account, no matter which account pays.
+var GasWalletRoutes = []GasWalletRoute{
+	{GasWalletTx_DistributeMinipool, "Automatic minipool balance distributions (under 8 ETH)", true, "anyone can distribute a minipool's skimmed rewards"},
+	{GasWalletTx_DistributeFees, "Fee distributor distributions (`rocketpool node distribute-fees`)", true, "anyone can distribute a fee distributor's balance"},
+	{GasWalletTx_UpgradeDelegate, "Delegate upgrades", false, "a minipool only accepts delegate upgrades from its node"},
+	{GasWalletTx_StakeMinipool, "Staking prelaunch minipools", false, "a minipool can only be staked by its node"},
+	{GasWalletTx_PromoteMinipool, "Promoting vacant minipools", false, "a minipool can only be promoted by its node"},
+	{GasWalletTx_ReduceBond, "Bond reductions", false, "a minipool's bond can only be reduced by its node"},
+	{GasWalletTx_ClaimRewards, "Rewards claims", false, "rewards can only be claimed by the node or its withdrawal address"},
+}
+
+// Check if a kind of transaction is sent from the gas wallet when it's set up
+func UsesGasWallet(tx GasWalletTx) bool {
+	for _, route := range GasWalletRoutes {
+		if route.Tx == tx {
+			return route.UsesGasWallet
+		}
+	}
+	return false
+}
+
+// An optional hot account, separate from the node account, that pays for low-risk automated transactions.
+// Its key is stored next to the node wallet, encrypted with its own generated password so it doesn't depend on the node password.
+type GasWallet struct {
+	path         string
+	passwordPath string
+	chainID      *big.Int
+	key          *ecdsa.PrivateKey
+
+	// Desired gas price & limit from config
+	maxFee         *big.Int
+	maxPriorityFee *big.Int
+	gasLimit       uint64
+}
+
+// Create a gas wallet, loading its key if it's been set up
+func NewGasWallet(path string, passwordPath string, chainId uint, maxFee *big.Int, maxPriorityFee *big.Int, gasLimit uint64) (*GasWallet, error) {
+	gw := &GasWallet{
+		path:           path,
+		passwordPath:   passwordPath,
+		chainID:        big.NewInt(int64(chainId)),
+		maxFee:         maxFee,
+		maxPriorityFee: maxPriorityFee,
+		gasLimit:       gasLimit,
+	}
+	if err := gw.load(); err != nil {
+		return nil, err
+	}
+	return gw, nil
+}
+
+// Check if the gas wallet has been set up
+func (gw *GasWallet) IsInitialized() bool {
+	return gw.key != nil
+}
+
+// Get the gas wallet's address
+func (gw *GasWallet) GetAddress() (common.Address, error) {
+	if !gw.IsInitialized() {
+		return common.Address{}, errors.New("Gas wallet is not initialized")
+	}
+	return crypto.PubkeyToAddress(gw.key.PublicKey), nil
+}
+
+// Get a transactor for the gas wallet
+func (gw *GasWallet) GetTransactor() (*bind.TransactOpts, error) {
+	if !gw.IsInitialized() {
+		return nil, errors.New("Gas wallet is not initialized")
+	}
+	transactor, err := bind.NewKeyedTransactorWithChainID(gw.key, gw.chainID)
+	if err != nil {
+		return nil, err
+	}
+	transactor.GasFeeCap = gw.maxFee
+	transactor.GasTipCap = gw.maxPriorityFee
+	transactor.GasLimit = gw.gasLimit
+	transactor.Context = context.Background()
+	return transactor, nil
+}
+
+// Generate a new key for the gas wallet and save it
+func (gw *GasWallet) Create() (common.Address, error) {
+	if gw.IsInitialized() {
+		return common.Address{}, errors.New("The gas wallet has already been set up")
+	}
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error generating gas wallet key: %w", err)
+	}
+	return gw.setKey(key)
+}
+
+// Import an existing private key, as a hex string, for the gas wallet and save it
+func (gw *GasWallet) Import(privateKey string) (common.Address, error) {
+	if gw.IsInitialized() {
+		return common.Address{}, errors.New("The gas wallet has already been set up")
+	}
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(privateKey), "0x"))
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid private key: %w", err)
+	}
+	return gw.setKey(key)
+}
+
+// Remove the gas wallet's key from the node
+func (gw *GasWallet) Delete() error {
+	if !gw.IsInitialized() {
+		return errors.New("Gas wallet is not initialized")
+	}
+	if err := os.Remove(gw.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error deleting gas wallet: %w", err)
+	}
+	if err := os.Remove(gw.passwordPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error deleting gas wallet password: %w", err)
+	}
+	gw.key = nil
+	return nil
+}
+
+// Encrypt a key with a new password and save both as the gas wallet's
+func (gw *GasWallet) setKey(key *ecdsa.PrivateKey) (common.Address, error) {
+	passwordBytes := make([]byte, gasWalletPasswordLength)
+	if _, err := rand.Read(passwordBytes); err != nil {
+		return common.Address{}, fmt.Errorf("error generating gas wallet password: %w", err)
+	}
+	password := hex.EncodeToString(passwordBytes)
+	if err := files.WriteFileAtomic(gw.passwordPath, []byte(password), FileMode); err != nil {
+		return common.Address{}, fmt.Errorf("error saving gas wallet password: %w", err)
+	}
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error generating gas wallet keystore ID: %w", err)
+	}
+	keyJson, err := ethkeystore.EncryptKey(&ethkeystore.Key{
+		Id:         id,
+		Address:    address,
+		PrivateKey: key,
+	}, password, ethkeystore.StandardScryptN, ethkeystore.StandardScryptP)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error encrypting gas wallet key: %w", err)
+	}
+	if err := files.WriteFileAtomic(gw.path, keyJson, FileMode); err != nil {
+		return common.Address{}, fmt.Errorf("error saving gas wallet: %w", err)
+	}
+	gw.key = key
+	return address, nil
+}
+
+// Load and decrypt the gas wallet's key if it exists
+func (gw *GasWallet) load() error {
+	keyJson, err := os.ReadFile(gw.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading gas wallet [%s]: %w", gw.path, err)
+	}
+	password, err := os.ReadFile(gw.passwordPath)
+	if err != nil {
+		return fmt.Errorf("error reading gas wallet password [%s]: %w", gw.passwordPath, err)
+	}
+	key, err := ethkeystore.DecryptKey(keyJson, string(password))
+	if err != nil {
+		return fmt.Errorf("error decrypting gas wallet: %w", err)
+	}
+	gw.key = key.PrivateKey
+	return nil
+}
+
+// Get the transactor for a kind of transaction: the gas wallet's if the routing rules allow it and it's set up, otherwise the node account's
+func GetTransactorForTx(w *Wallet, gw *GasWallet, tx GasWalletTx) (*bind.TransactOpts, bool, error) {
+	if gw != nil && gw.IsInitialized() && UsesGasWallet(tx) {
+		opts, err := gw.GetTransactor()
+		return opts, true, err
+	}
+	opts, err := w.GetNodeAccountTransactor()
+	return opts, false, err
+}
@@ -2,7 +2,6 @@ package lodestar
 
 import (
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 
@@ -14,6 +13,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
 	keystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
 	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
 )
 
@@ -99,7 +99,7 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	}
 
 	// Write secret to disk
-	if err := ioutil.WriteFile(secretFilePath, []byte(password), FileMode); err != nil {
+	if err := files.WriteFileAtomic(secretFilePath, []byte(password), FileMode); err != nil {
 		return fmt.Errorf("Could not write validator secret to disk: %w", err)
 	}
 
@@ -112,7 +112,7 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	}
 
 	// Write key store to disk
-	if err := ioutil.WriteFile(keyFilePath, keyStoreBytes, FileMode); err != nil {
+	if err := files.WriteFileAtomic(keyFilePath, keyStoreBytes, FileMode); err != nil {
 		return fmt.Errorf("Could not write validator key to disk: %w", err)
 	}
 
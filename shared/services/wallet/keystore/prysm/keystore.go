@@ -15,6 +15,7 @@ import (
 	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
 )
 
 // Config
@@ -134,7 +135,7 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	}
 
 	// Write keystore to disk
-	if err := os.WriteFile(keystoreFilePath, ksBytes, FileMode); err != nil {
+	if err := files.WriteFileAtomic(keystoreFilePath, ksBytes, FileMode); err != nil {
 		return fmt.Errorf("Could not write keystore to disk: %w", err)
 	}
 
@@ -152,7 +153,7 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	}
 
 	// Write wallet config to disk
-	if err := os.WriteFile(configFilePath, configBytes, FileMode); err != nil {
+	if err := files.WriteFileAtomic(configFilePath, configBytes, FileMode); err != nil {
 		return fmt.Errorf("Could not write wallet config to disk: %w", err)
 	}
 
@@ -188,7 +189,7 @@ func (ks *Keystore) initialize() error {
 		if err != nil {
 			return fmt.Errorf("Error creating account password directory: %w", err)
 		}
-		err = os.WriteFile(passwordFilePath, passwordBytes, FileMode)
+		err = files.WriteFileAtomic(passwordFilePath, passwordBytes, FileMode)
 		if err != nil {
 			return fmt.Errorf("Error writing account password file: %w", err)
 		}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
 	keystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
 	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
 )
 
@@ -97,7 +98,7 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	}
 
 	// Write secret to disk
-	if err := os.WriteFile(secretFilePath, []byte(password), FileMode); err != nil {
+	if err := files.WriteFileAtomic(secretFilePath, []byte(password), FileMode); err != nil {
 		return fmt.Errorf("Could not write validator secret to disk: %w", err)
 	}
 
@@ -110,7 +111,7 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	}
 
 	// Write key store to disk
-	if err := os.WriteFile(keyFilePath, keyStoreBytes, FileMode); err != nil {
+	if err := files.WriteFileAtomic(keyFilePath, keyStoreBytes, FileMode); err != nil {
 		return fmt.Errorf("Could not write validator key to disk: %w", err)
 	}
 
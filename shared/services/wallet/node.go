@@ -5,11 +5,16 @@ import (
 	"crypto/ecdsa"
 	"errors"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/rocket-pool/smartnode/shared/utils/files"
 )
 
 // Get the node account
@@ -44,6 +49,39 @@ func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 
 }
 
+// Get the node account's address without needing its key.
+// If the wallet isn't loaded, this uses the address saved the last time it was, so tasks paid for by the gas wallet can keep running while
+// the node's key isn't available.
+func (w *Wallet) GetNodeAddress() (common.Address, error) {
+
+	// Use the key if it's loaded
+	if w.IsInitialized() {
+		account, err := w.GetNodeAccount()
+		if err != nil {
+			return common.Address{}, err
+		}
+		return account.Address, nil
+	}
+
+	// Read the saved address
+	if w.addressPath == "" {
+		return common.Address{}, errors.New("Wallet is not initialized")
+	}
+	bytes, err := os.ReadFile(w.addressPath)
+	if os.IsNotExist(err) {
+		return common.Address{}, errors.New("Wallet is not initialized and the node address hasn't been saved")
+	}
+	if err != nil {
+		return common.Address{}, fmt.Errorf("Could not read node address: %w", err)
+	}
+	address := strings.TrimSpace(string(bytes))
+	if !common.IsHexAddress(address) {
+		return common.Address{}, fmt.Errorf("Saved node address [%s] is invalid", address)
+	}
+	return common.HexToAddress(address), nil
+
+}
+
 // Get a transactor for the node account
 func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 
@@ -147,3 +185,30 @@ func (w *Wallet) getNodeDerivedKey(index uint) (*hdkeychain.ExtendedKey, string,
 	return key, derivationPath, nil
 
 }
+
+// Save the node account's address so it can be used while the wallet isn't loaded
+func (w *Wallet) saveNodeAddress() error {
+
+	// Wallets that aren't backed by the data folder don't save it
+	if w.addressPath == "" {
+		return nil
+	}
+
+	// Get the address
+	account, err := w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	address := account.Address.Hex()
+
+	// Only write it if it changed
+	bytes, err := os.ReadFile(w.addressPath)
+	if err == nil && strings.TrimSpace(string(bytes)) == address {
+		return nil
+	}
+	if err := files.WriteFileAtomic(w.addressPath, []byte(address), FileMode); err != nil {
+		return fmt.Errorf("Could not write node address to disk: %w", err)
+	}
+	return nil
+
+}
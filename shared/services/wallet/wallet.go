@@ -12,6 +12,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/goccy/go-json"
 	"github.com/google/uuid"
 	"github.com/tyler-smith/go-bip39"
@@ -20,6 +21,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
 )
 
 // Config
@@ -35,10 +37,11 @@ const (
 type Wallet struct {
 
 	// Core
-	walletPath string
-	pm         *passwords.PasswordManager
-	encryptor  *eth2ks.Encryptor
-	chainID    *big.Int
+	walletPath  string
+	addressPath string
+	pm          *passwords.PasswordManager
+	encryptor   *eth2ks.Encryptor
+	chainID     *big.Int
 
 	// Encrypted store
 	ws *walletStore
@@ -75,11 +78,12 @@ type walletStore struct {
 }
 
 // Create new wallet
-func NewWallet(walletPath string, chainId uint, maxFee *big.Int, maxPriorityFee *big.Int, gasLimit uint64, passwordManager *passwords.PasswordManager) (*Wallet, error) {
+func NewWallet(walletPath string, addressPath string, chainId uint, maxFee *big.Int, maxPriorityFee *big.Int, gasLimit uint64, passwordManager *passwords.PasswordManager) (*Wallet, error) {
 
 	// Initialize wallet
 	w := &Wallet{
 		walletPath:     walletPath,
+		addressPath:    addressPath,
 		pm:             passwordManager,
 		encryptor:      eth2ks.New(),
 		chainID:        big.NewInt(int64(chainId)),
@@ -244,12 +248,12 @@ func (w *Wallet) Save() error {
 	}
 
 	// Write wallet store to disk
-	if err := os.WriteFile(w.walletPath, wsBytes, FileMode); err != nil {
+	if err := files.WriteFileAtomic(w.walletPath, wsBytes, FileMode); err != nil {
 		return fmt.Errorf("Could not write wallet to disk: %w", err)
 	}
 
-	// Return
-	return nil
+	// Save the node address
+	return w.saveNodeAddress()
 
 }
 
@@ -317,6 +321,28 @@ func (w *Wallet) SignMessage(message string) ([]byte, error) {
 	return signedMessage, nil
 }
 
+// Signs EIP-712 typed data using the wallet's private key
+func (w *Wallet) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	// Get the wallet's private key
+	privateKey, _, err := w.getNodePrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	dataHash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("Error hashing typed data: %w", err)
+	}
+	signedData, err := crypto.Sign(dataHash, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("Error signing typed data: %w", err)
+	}
+
+	// fix the ECDSA 'v' the same way as SignMessage
+	signedData[crypto.RecoveryIDOffset] += 27
+	return signedData, nil
+}
+
 // Reloads wallet from disk
 func (w *Wallet) Reload() error {
 	_, err := w.loadStore()
@@ -361,6 +387,11 @@ func (w *Wallet) loadStore() (bool, error) {
 		return false, fmt.Errorf("Could not create wallet master key: %w", err)
 	}
 
+	// Save the node address for wallets that were loaded before it was saved separately
+	if err := w.saveNodeAddress(); err != nil {
+		return false, err
+	}
+
 	// Return
 	return true, nil
 
@@ -1,6 +1,32 @@
 package api
 
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared/types/errcodes"
+)
+
 type APIResponse struct {
-	Status string `json:"status"`
-	Error  string `json:"error"`
+	Status    string        `json:"status"`
+	Error     string        `json:"error"`
+	ErrorCode errcodes.Code `json:"errorCode,omitempty"`
+}
+
+// A transaction decoded for the user to check before they sign it
+type TransactionPreview struct {
+	To               *common.Address              `json:"to"`
+	Contract         string                       `json:"contract"`
+	ContractResolved bool                         `json:"contractResolved"`
+	Method           string                       `json:"method"`
+	Selector         string                       `json:"selector"`
+	Value            *big.Int                     `json:"value"`
+	Arguments        []TransactionPreviewArgument `json:"arguments"`
+	Decoded          bool                         `json:"decoded"`
+}
+type TransactionPreviewArgument struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/tokens"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/ledger"
 )
 
 type MinipoolStatusResponse struct {
@@ -101,6 +102,36 @@ type ExitMinipoolResponse struct {
 	Error  string `json:"error"`
 }
 
+type MinipoolRewardsLedgerResponse struct {
+	Status           string                                    `json:"status"`
+	Error            string                                    `json:"error"`
+	LedgerExists     bool                                      `json:"ledgerExists"`
+	StartSlot        uint64                                    `json:"startSlot"`
+	LastScannedSlot  uint64                                    `json:"lastScannedSlot"`
+	LastScannedBlock uint64                                    `json:"lastScannedBlock"`
+	Reconciliations  []ledger.Reconciliation                   `json:"reconciliations"`
+	History          map[common.Address]*ledger.MinipoolLedger `json:"history"`
+}
+
+type MinipoolExitEstimateResponse struct {
+	Status             string                `json:"status"`
+	Error              string                `json:"error"`
+	MinipoolAddress    common.Address        `json:"minipoolAddress"`
+	ValidatorIndex     string                `json:"validatorIndex"`
+	ValidatorStatus    beacon.ValidatorState `json:"validatorStatus"`
+	AlreadyExiting     bool                  `json:"alreadyExiting"`
+	WithdrawalComplete bool                  `json:"withdrawalComplete"`
+	ExitQueueLength    uint64                `json:"exitQueueLength"`
+	ChurnLimit         uint64                `json:"churnLimit"`
+	ExitEpoch          uint64                `json:"exitEpoch"`
+	ExitTime           time.Time             `json:"exitTime"`
+	WithdrawableEpoch  uint64                `json:"withdrawableEpoch"`
+	WithdrawableTime   time.Time             `json:"withdrawableTime"`
+	WithdrawalSlot     uint64                `json:"withdrawalSlot"`
+	WithdrawalTime     time.Time             `json:"withdrawalTime"`
+	SweepDuration      time.Duration         `json:"sweepDuration"`
+}
+
 type CanChangeWithdrawalCredentialsResponse struct {
 	Status    string `json:"status"`
 	Error     string `json:"error"`
@@ -112,8 +143,9 @@ type ChangeWithdrawalCredentialsResponse struct {
 }
 
 type ImportKeyResponse struct {
-	Status string `json:"status"`
-	Error  string `json:"error"`
+	Status    string `json:"status"`
+	Error     string `json:"error"`
+	KeyLoaded bool   `json:"keyLoaded"`
 }
 
 type CanProcessWithdrawalResponse struct {
@@ -164,9 +196,10 @@ type GetMinipoolCloseDetailsForNodeResponse struct {
 	Details                     []MinipoolCloseDetails `json:"details"`
 }
 type CloseMinipoolResponse struct {
-	Status string      `json:"status"`
-	Error  string      `json:"error"`
-	TxHash common.Hash `json:"txHash"`
+	Status     string      `json:"status"`
+	Error      string      `json:"error"`
+	TxHash     common.Hash `json:"txHash"`
+	KeyRemoved bool        `json:"keyRemoved"`
 }
 
 type GetDistributeBalanceDetailsResponse struct {
@@ -217,6 +250,35 @@ type DelegateUpgradeResponse struct {
 	TxHash common.Hash `json:"txHash"`
 }
 
+type DelegateUpgradeCampaignResponse struct {
+	Status             string                            `json:"status"`
+	Error              string                            `json:"error"`
+	LatestDelegate     common.Address                    `json:"latestDelegate"`
+	AutoUpgradeEnabled bool                              `json:"autoUpgradeEnabled"`
+	DailyBudget        *big.Int                          `json:"dailyBudget"`
+	BatchSize          uint64                            `json:"batchSize"`
+	SpentInWindow      *big.Int                          `json:"spentInWindow"`
+	UpgradeCount       int                               `json:"upgradeCount"`
+	Minipools          []DelegateUpgradeCampaignMinipool `json:"minipools"`
+}
+type DelegateUpgradeCampaignMinipool struct {
+	Address           common.Address       `json:"address"`
+	Status            types.MinipoolStatus `json:"status"`
+	Delegate          common.Address       `json:"delegate"`
+	EffectiveDelegate common.Address       `json:"effectiveDelegate"`
+	UseLatestDelegate bool                 `json:"useLatestDelegate"`
+	Outdated          bool                 `json:"outdated"`
+	AutoUpgrade       bool                 `json:"autoUpgrade"`
+	GasInfo           rocketpool.GasInfo   `json:"gasInfo"`
+	LastUpgradeTxHash common.Hash          `json:"lastUpgradeTxHash"`
+	LastUpgradeTime   time.Time            `json:"lastUpgradeTime"`
+}
+type SetAutoUpgradeDelegateResponse struct {
+	Status  string           `json:"status"`
+	Error   string           `json:"error"`
+	Updated []common.Address `json:"updated"`
+}
+
 type CanDelegateRollbackResponse struct {
 	Status          string             `json:"status"`
 	Error           string             `json:"error"`
@@ -344,6 +406,63 @@ type GetMinipoolRescueDissolvedDetailsForNodeResponse struct {
 	Error   string                           `json:"error"`
 	Details []MinipoolRescueDissolvedDetails `json:"details"`
 }
+
+// Why a minipool is stuck before staking, or after being dissolved
+type StuckMinipoolProblem string
+
+const (
+	StuckMinipoolProblem_InQueue            StuckMinipoolProblem = "in_queue"
+	StuckMinipoolProblem_MissingKey         StuckMinipoolProblem = "missing_key"
+	StuckMinipoolProblem_DepositPending     StuckMinipoolProblem = "deposit_pending"
+	StuckMinipoolProblem_WrongCredentials   StuckMinipoolProblem = "wrong_credentials"
+	StuckMinipoolProblem_ScrubPeriod        StuckMinipoolProblem = "scrub_period"
+	StuckMinipoolProblem_NotStaked          StuckMinipoolProblem = "not_staked"
+	StuckMinipoolProblem_LaunchTimeout      StuckMinipoolProblem = "launch_timeout"
+	StuckMinipoolProblem_LegacyDissolved    StuckMinipoolProblem = "legacy_dissolved"
+	StuckMinipoolProblem_DissolvedNoDeposit StuckMinipoolProblem = "dissolved_no_deposit"
+	StuckMinipoolProblem_DissolvedUnfunded  StuckMinipoolProblem = "dissolved_unfunded"
+	StuckMinipoolProblem_DissolvedActive    StuckMinipoolProblem = "dissolved_active"
+	StuckMinipoolProblem_DissolvedPending   StuckMinipoolProblem = "dissolved_pending"
+	StuckMinipoolProblem_DissolvedExited    StuckMinipoolProblem = "dissolved_exited"
+)
+
+// The supported way out for a stuck minipool
+type StuckMinipoolRemediation string
+
+const (
+	StuckMinipoolRemediation_Wait       StuckMinipoolRemediation = "wait"
+	StuckMinipoolRemediation_RecoverKey StuckMinipoolRemediation = "recover_key"
+	StuckMinipoolRemediation_Stake      StuckMinipoolRemediation = "stake"
+	StuckMinipoolRemediation_Dissolve   StuckMinipoolRemediation = "dissolve"
+	StuckMinipoolRemediation_Rescue     StuckMinipoolRemediation = "rescue"
+	StuckMinipoolRemediation_Exit       StuckMinipoolRemediation = "exit"
+	StuckMinipoolRemediation_Close      StuckMinipoolRemediation = "close"
+	StuckMinipoolRemediation_None       StuckMinipoolRemediation = "none"
+)
+
+type StuckMinipoolDiagnosis struct {
+	Address                       common.Address           `json:"address"`
+	MinipoolStatus                types.MinipoolStatus     `json:"minipoolStatus"`
+	MinipoolVersion               uint8                    `json:"minipoolVersion"`
+	StatusTime                    time.Time                `json:"statusTime"`
+	Problem                       StuckMinipoolProblem     `json:"problem"`
+	Explanation                   string                   `json:"explanation"`
+	Remediation                   StuckMinipoolRemediation `json:"remediation"`
+	WaitTime                      time.Duration            `json:"waitTime"`
+	HasValidatorKey               bool                     `json:"hasValidatorKey"`
+	BeaconState                   beacon.ValidatorState    `json:"beaconState"`
+	BeaconBalance                 *big.Int                 `json:"beaconBalance"`
+	ExpectedWithdrawalCredentials common.Hash              `json:"expectedWithdrawalCredentials"`
+	ActualWithdrawalCredentials   common.Hash              `json:"actualWithdrawalCredentials"`
+	RefundAvailable               bool                     `json:"refundAvailable"`
+	RefundBalance                 *big.Int                 `json:"refundBalance"`
+}
+type GetStuckMinipoolsResponse struct {
+	Status    string                   `json:"status"`
+	Error     string                   `json:"error"`
+	Minipools []StuckMinipoolDiagnosis `json:"minipools"`
+}
+
 type RescueDissolvedMinipoolResponse struct {
 	Status string      `json:"status"`
 	Error  string      `json:"error"`
@@ -2,8 +2,13 @@ package api
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/queue"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
 )
 
 type NodeFeeResponse struct {
@@ -48,6 +53,27 @@ type NetworkStatsResponse struct {
 	SmoothingPoolBalance      float64        `json:"smoothingPoolBalance"`
 }
 
+type NetworkQueueStatsResponse struct {
+	Status              string              `json:"status"`
+	Error               string              `json:"error"`
+	Block               uint64              `json:"block"`
+	DepositPoolBalance  *big.Int            `json:"depositPoolBalance"`
+	QueueLength         uint64              `json:"queueLength"`
+	QueueCapacity       *big.Int            `json:"queueCapacity"`
+	QueueComposition    []QueuedBondSummary `json:"queueComposition"`
+	FundableMinipools   uint64              `json:"fundableMinipools"`
+	AssignmentRateKnown bool                `json:"assignmentRateKnown"`
+	AssignmentsPerDay   float64             `json:"assignmentsPerDay"`
+	NewMinipoolEta      time.Duration       `json:"newMinipoolEta"`
+	History             []queue.Sample      `json:"history"`
+}
+
+type QueuedBondSummary struct {
+	BondAmount    *big.Int `json:"bondAmount"`
+	Count         uint64   `json:"count"`
+	TotalCapacity *big.Int `json:"totalCapacity"`
+}
+
 type NetworkTimezonesResponse struct {
 	Status         string            `json:"status"`
 	Error          string            `json:"error"`
@@ -77,6 +103,81 @@ type NetworkDAOProposalsResponse struct {
 	ProposalVotes           []SnapshotProposalVote `json:"proposalVotes"`
 }
 
+type NetworkCanVoteOnDAOProposalResponse struct {
+	Status              string  `json:"status"`
+	Error               string  `json:"error"`
+	CanVote             bool    `json:"canVote"`
+	SnapshotUnavailable bool    `json:"snapshotUnavailable"`
+	DoesNotExist        bool    `json:"doesNotExist"`
+	InvalidState        bool    `json:"invalidState"`
+	UnsupportedType     bool    `json:"unsupportedType"`
+	InvalidChoice       bool    `json:"invalidChoice"`
+	InsufficientPower   bool    `json:"insufficientPower"`
+	AlreadyVoted        bool    `json:"alreadyVoted"`
+	VotingPower         float64 `json:"votingPower"`
+}
+
+type NetworkVoteOnDAOProposalResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	VoteID string `json:"voteId"`
+}
+
+type AuditPerformanceResponse struct {
+	Status                    string                              `json:"status"`
+	Error                     string                              `json:"error"`
+	Index                     uint64                              `json:"index"`
+	Seed                      string                              `json:"seed"`
+	PerformanceFileDownloaded bool                                `json:"performanceFileDownloaded"`
+	TotalMinipools            int                                 `json:"totalMinipools"`
+	TotalEpochs               int                                 `json:"totalEpochs"`
+	CheckedEpochs             int                                 `json:"checkedEpochs"`
+	Minipools                 []*rewards.MinipoolPerformanceAudit `json:"minipools"`
+}
+
+type MinipoolPerformanceResponse struct {
+	Status                    string                       `json:"status"`
+	Error                     string                       `json:"error"`
+	Index                     uint64                       `json:"index"`
+	NodeAddress               *common.Address              `json:"nodeAddress,omitempty"`
+	PerformanceFileDownloaded bool                         `json:"performanceFileDownloaded"`
+	TotalMinipools            int                          `json:"totalMinipools"`
+	NetworkParticipation      float64                      `json:"networkParticipation"`
+	MatchingMinipools         int                          `json:"matchingMinipools"`
+	Minipools                 []MinipoolPerformanceDetails `json:"minipools"`
+}
+type MinipoolPerformanceDetails struct {
+	Address                common.Address        `json:"address"`
+	Pubkey                 types.ValidatorPubkey `json:"pubkey"`
+	SuccessfulAttestations uint64                `json:"successfulAttestations"`
+	MissedAttestations     uint64                `json:"missedAttestations"`
+	Participation          float64               `json:"participation"`
+	Percentile             float64               `json:"percentile"`
+	AttestationScore       *big.Int              `json:"attestationScore,omitempty"`
+	EthEarned              *big.Int              `json:"ethEarned"`
+}
+
+type RewardsHistoryResponse struct {
+	Status           string                   `json:"status"`
+	Error            string                   `json:"error"`
+	NodeAddress      common.Address           `json:"nodeAddress"`
+	MissingIntervals []uint64                 `json:"missingIntervals"`
+	Intervals        []RewardsHistoryInterval `json:"intervals"`
+}
+type RewardsHistoryInterval struct {
+	Index            uint64    `json:"index"`
+	StartTime        time.Time `json:"startTime"`
+	EndTime          time.Time `json:"endTime"`
+	Available        bool      `json:"available"`
+	Claimed          bool      `json:"claimed"`
+	CollateralRpl    *big.Int  `json:"collateralRpl"`
+	OracleDaoRpl     *big.Int  `json:"oracleDaoRpl"`
+	SmoothingPoolEth *big.Int  `json:"smoothingPoolEth"`
+	Minipools        int       `json:"minipools"`
+	Participation    float64   `json:"participation"`
+	Percentile       *float64  `json:"percentile,omitempty"`
+}
+
 type DownloadRewardsFileResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
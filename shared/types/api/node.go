@@ -6,10 +6,12 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/tokens"
 	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services/income"
 	"github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
@@ -378,6 +380,29 @@ type NodeDepositResponse struct {
 	ScrubPeriod     time.Duration           `json:"scrubPeriod"`
 }
 
+type DepositPreflightCheck struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message"`
+}
+type NodeIncomeEventsResponse struct {
+	Status           string         `json:"status"`
+	Error            string         `json:"error"`
+	Events           []income.Event `json:"events"`
+	MissingIntervals []uint64       `json:"missingIntervals"`
+	LedgerExists     bool           `json:"ledgerExists"`
+	LedgerStartSlot  uint64         `json:"ledgerStartSlot"`
+}
+
+type NodeSimulateDepositResponse struct {
+	Status          string                  `json:"status"`
+	Error           string                  `json:"error"`
+	Go              bool                    `json:"go"`
+	Checks          []DepositPreflightCheck `json:"checks"`
+	ValidatorPubkey rptypes.ValidatorPubkey `json:"validatorPubkey"`
+	Deposit         CanNodeDepositResponse  `json:"deposit"`
+}
+
 type CanCreateVacantMinipoolResponse struct {
 	Status               string             `json:"status"`
 	Error                string             `json:"error"`
@@ -438,6 +463,54 @@ type NodeBurnResponse struct {
 	TxHash common.Hash `json:"txHash"`
 }
 
+type RethArbitrageRoute struct {
+	Source            string   `json:"source"`
+	Available         bool     `json:"available"`
+	UnavailableReason string   `json:"unavailableReason"`
+	Output            *big.Int `json:"output"`
+	GasCost           *big.Int `json:"gasCost"`
+	NetOutput         *big.Int `json:"netOutput"`
+}
+type NodeRethArbitrageResponse struct {
+	Status              string               `json:"status"`
+	Error               string               `json:"error"`
+	Direction           string               `json:"direction"`
+	Amount              *big.Int             `json:"amount"`
+	InsufficientBalance bool                 `json:"insufficientBalance"`
+	Routes              []RethArbitrageRoute `json:"routes"`
+	BestRoute           int                  `json:"bestRoute"`
+	CanRoute            bool                 `json:"canRoute"`
+	GasInfo             rocketpool.GasInfo   `json:"gasInfo"`
+}
+type NodeRouteRethDepositResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+type DecodedArgument struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+type DecodedCall struct {
+	Selector  string            `json:"selector"`
+	Function  string            `json:"function"`
+	Arguments []DecodedArgument `json:"arguments"`
+}
+type NodeRethDepositSwapResponse struct {
+	Status          string             `json:"status"`
+	Error           string             `json:"error"`
+	Source          string             `json:"source"`
+	To              common.Address     `json:"to"`
+	RouterName      string             `json:"routerName"`
+	Value           *big.Int           `json:"value"`
+	Data            hexutil.Bytes      `json:"data"`
+	Call            DecodedCall        `json:"call"`
+	QuotedOutput    *big.Int           `json:"quotedOutput"`
+	SimulatedOutput *big.Int           `json:"simulatedOutput"`
+	GasInfo         rocketpool.GasInfo `json:"gasInfo"`
+}
+
 type NodeSyncProgressResponse struct {
 	Status   string              `json:"status"`
 	Error    string              `json:"error"`
@@ -480,6 +553,14 @@ type NodeRewardsResponse struct {
 	TxHash                      common.Hash   `json:"txHash"`
 }
 
+type NodePreviewRewardsResponse struct {
+	Status                string                 `json:"status"`
+	Error                 string                 `json:"error"`
+	Registered            bool                   `json:"registered"`
+	RollingRecordsEnabled bool                   `json:"rollingRecordsEnabled"`
+	Preview               rewards.RewardsPreview `json:"preview"`
+}
+
 type DepositContractInfoResponse struct {
 	Status                string         `json:"status"`
 	Error                 string         `json:"error"`
@@ -496,6 +577,55 @@ type NodeSignResponse struct {
 	SignedData string `json:"signedData"`
 }
 
+type NodeRequestSignMessageResponse struct {
+	Status    string    `json:"status"`
+	Error     string    `json:"error"`
+	Code      string    `json:"code"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type NodeVerifyMessageResponse struct {
+	Status string         `json:"status"`
+	Error  string         `json:"error"`
+	Valid  bool           `json:"valid"`
+	Signer common.Address `json:"signer"`
+}
+
+type NodeDecodeTransactionResponse struct {
+	Status         string             `json:"status"`
+	Error          string             `json:"error"`
+	IsTransaction  bool               `json:"isTransaction"`
+	IsSigned       bool               `json:"isSigned"`
+	From           *common.Address    `json:"from"`
+	ChainID        *big.Int           `json:"chainId"`
+	Nonce          uint64             `json:"nonce"`
+	GasLimit       uint64             `json:"gasLimit"`
+	MaxFee         *big.Int           `json:"maxFee"`
+	MaxPriorityFee *big.Int           `json:"maxPriorityFee"`
+	Preview        TransactionPreview `json:"preview"`
+}
+
+type NodePrepareOfflineTransactionResponse struct {
+	Status       string             `json:"status"`
+	Error        string             `json:"error"`
+	ChainID      uint64             `json:"chainId"`
+	PendingNonce uint64             `json:"pendingNonce"`
+	GasInfo      rocketpool.GasInfo `json:"gasInfo"`
+}
+
+type NodeSignOfflineTransactionResponse struct {
+	Status   string         `json:"status"`
+	Error    string         `json:"error"`
+	From     common.Address `json:"from"`
+	SignedTx string         `json:"signedTx"`
+}
+
+type NodeBroadcastOfflineTransactionResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
 type EstimateSetSnapshotDelegateGasResponse struct {
 	Status  string             `json:"status"`
 	Error   string             `json:"error"`
@@ -615,6 +745,7 @@ type ResolveEnsNameResponse struct {
 type SnapshotProposal struct {
 	Id            string    `json:"id"`
 	Title         string    `json:"title"`
+	Type          string    `json:"type"`
 	Start         int64     `json:"start"`
 	End           int64     `json:"end"`
 	State         string    `json:"state"`
@@ -683,3 +814,101 @@ type NodeAlertsResponse struct {
 	// TODO: change to GettableAlerts
 	Message string `json:"message"`
 }
+
+type NodeValidatorsResponse struct {
+	Status           string                 `json:"status"`
+	Error            string                 `json:"error"`
+	ElBlockNumber    uint64                 `json:"elBlockNumber"`
+	BeaconSlotNumber uint64                 `json:"beaconSlotNumber"`
+	Cached           bool                   `json:"cached"`
+	CachedTime       time.Time              `json:"cachedTime"`
+	Validators       []NodeValidatorDetails `json:"validators"`
+}
+type NodeValidatorDetails struct {
+	MinipoolAddress  common.Address          `json:"minipoolAddress"`
+	MinipoolStatus   rptypes.MinipoolStatus  `json:"minipoolStatus"`
+	Pubkey           rptypes.ValidatorPubkey `json:"pubkey"`
+	Index            string                  `json:"index"`
+	ValidatorStatus  string                  `json:"validatorStatus"`
+	BeaconBalance    uint64                  `json:"beaconBalance"`
+	NodeShareOfTotal *big.Int                `json:"nodeShareOfTotal"`
+}
+
+type NodeSyncCommitteeResponse struct {
+	Status        string               `json:"status"`
+	Error         string               `json:"error"`
+	CurrentEpoch  uint64               `json:"currentEpoch"`
+	CurrentPeriod SyncCommitteePeriod  `json:"currentPeriod"`
+	NextPeriod    SyncCommitteePeriod  `json:"nextPeriod"`
+	Checks        []DutyReadinessCheck `json:"checks"`
+}
+type SyncCommitteePeriod struct {
+	Period     uint64    `json:"period"`
+	StartEpoch uint64    `json:"startEpoch"`
+	EndEpoch   uint64    `json:"endEpoch"`
+	StartTime  time.Time `json:"startTime"`
+	EndTime    time.Time `json:"endTime"`
+	Validators []string  `json:"validators"`
+}
+type DutyReadinessCheck struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Details string `json:"details"`
+}
+
+type NodeAttestationPerformanceResponse struct {
+	Status           string                        `json:"status"`
+	Error            string                        `json:"error"`
+	CurrentEpoch     uint64                        `json:"currentEpoch"`
+	ActiveValidators uint64                        `json:"activeValidators"`
+	Epochs           []EpochAttestationPerformance `json:"epochs"`
+}
+type EpochAttestationPerformance struct {
+	Epoch        uint64   `json:"epoch"`
+	Duties       uint64   `json:"duties"`
+	Included     uint64   `json:"included"`
+	TimelySource uint64   `json:"timelySource"`
+	TimelyTarget uint64   `json:"timelyTarget"`
+	TimelyHead   uint64   `json:"timelyHead"`
+	Efficiency   float64  `json:"efficiency"`
+	LikelyCauses []string `json:"likelyCauses"`
+}
+
+type ExportDepositDataResponse struct {
+	Status          string                  `json:"status"`
+	Error           string                  `json:"error"`
+	MinipoolAddress common.Address          `json:"minipoolAddress"`
+	Salt            *big.Int                `json:"salt,omitempty"`
+	Pubkey          rptypes.ValidatorPubkey `json:"pubkey"`
+	DerivationPath  string                  `json:"derivationPath"`
+	DepositData     []ExportedDepositData   `json:"depositData"`
+	Keystore        string                  `json:"keystore"`
+}
+
+// Deposit data in the format produced by the staking deposit CLI (deposit_data-*.json)
+type ExportedDepositData struct {
+	Pubkey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                uint64 `json:"amount"`
+	Signature             string `json:"signature"`
+	DepositMessageRoot    string `json:"deposit_message_root"`
+	DepositDataRoot       string `json:"deposit_data_root"`
+	ForkVersion           string `json:"fork_version"`
+	NetworkName           string `json:"network_name"`
+	DepositCliVersion     string `json:"deposit_cli_version"`
+}
+
+type ValidateDepositDataResponse struct {
+	Status                        string                  `json:"status"`
+	Error                         string                  `json:"error"`
+	MinipoolAddress               common.Address          `json:"minipoolAddress"`
+	MinipoolExists                bool                    `json:"minipoolExists"`
+	ExpectedWithdrawalCredentials common.Hash             `json:"expectedWithdrawalCredentials"`
+	Entries                       []DepositDataValidation `json:"entries"`
+}
+type DepositDataValidation struct {
+	Pubkey   string   `json:"pubkey"`
+	Amount   uint64   `json:"amount"`
+	Problems []string `json:"problems"`
+	Warnings []string `json:"warnings"`
+}
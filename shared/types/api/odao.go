@@ -7,6 +7,8 @@ import (
 	"github.com/rocket-pool/rocketpool-go/dao"
 	tn "github.com/rocket-pool/rocketpool-go/dao/trustednode"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/balances"
+	"github.com/rocket-pool/smartnode/shared/services/pinning"
 )
 
 type TNDAOStatusResponse struct {
@@ -314,3 +316,33 @@ type GetTNDAOMinipoolSettingsResponse struct {
 	BondReductionWindowStart  uint64 `json:"bondReductionWindowStart"`
 	BondReductionWindowLength uint64 `json:"bondReductionWindowLength"`
 }
+
+type TNDAOBalancesReportResponse struct {
+	Status       string           `json:"status"`
+	Error        string           `json:"error"`
+	ReportExists bool             `json:"reportExists"`
+	Report       *balances.Report `json:"report"`
+}
+
+type CanImportRewardsTreeResponse struct {
+	Status           string             `json:"status"`
+	Error            string             `json:"error"`
+	CanImport        bool               `json:"canImport"`
+	CurrentIndex     uint64             `json:"currentIndex"`
+	WrongInterval    bool               `json:"wrongInterval"`
+	AlreadySubmitted bool               `json:"alreadySubmitted"`
+	InvalidReason    string             `json:"invalidReason"`
+	GasInfo          rocketpool.GasInfo `json:"gasInfo"`
+}
+type ImportRewardsTreeResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
+type TNDAOPinningStatusResponse struct {
+	Status  string                `json:"status"`
+	Error   string                `json:"error"`
+	Enabled bool                  `json:"enabled"`
+	Files   []pinning.TrackedFile `json:"files"`
+}
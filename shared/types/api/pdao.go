@@ -409,3 +409,17 @@ type GetPDAOVotePowerResponse struct {
 	Error       string   `json:"error"`
 	VotingPower *big.Int `json:"votingPower"`
 }
+
+type PDAOVerifyProposalRootResponse struct {
+	Status          string                 `json:"status"`
+	Error           string                 `json:"error"`
+	DoesNotExist    bool                   `json:"doesNotExist"`
+	TargetBlock     uint32                 `json:"targetBlock"`
+	ProposalRoot    types.VotingTreeNode   `json:"proposalRoot"`
+	LocalRoot       types.VotingTreeNode   `json:"localRoot"`
+	RootsMatch      bool                   `json:"rootsMatch"`
+	NodeInTree      bool                   `json:"nodeInTree"`
+	NodeIndex       uint64                 `json:"nodeIndex"`
+	NodeVotingPower *big.Int               `json:"nodeVotingPower"`
+	NodeProof       []types.VotingTreeNode `json:"nodeProof"`
+}
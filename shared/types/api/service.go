@@ -1,6 +1,14 @@
 package api
 
-import "github.com/ethereum/go-ethereum/common"
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared/services/hardware"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/submissions"
+)
 
 type TerminateDataFolderResponse struct {
 	Status        string `json:"status"`
@@ -41,3 +49,214 @@ type RestartVcResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
+
+type CheckRecordsResponse struct {
+	Status                string                    `json:"status"`
+	Error                 string                    `json:"error"`
+	RollingRecordsEnabled bool                      `json:"rollingRecordsEnabled"`
+	Result                rewards.RecordCheckResult `json:"result"`
+}
+
+type SubmissionLedgerResponse struct {
+	Status      string                   `json:"status"`
+	Error       string                   `json:"error"`
+	Submissions []submissions.Submission `json:"submissions"`
+}
+
+type DevnetFundResponse struct {
+	Status        string         `json:"status"`
+	Error         string         `json:"error"`
+	NodeAddress   common.Address `json:"nodeAddress"`
+	FunderAddress common.Address `json:"funderAddress"`
+	EthTxHash     common.Hash    `json:"ethTxHash"`
+	RplTxHash     common.Hash    `json:"rplTxHash"`
+}
+
+type DevnetFastForwardResponse struct {
+	Status          string    `json:"status"`
+	Error           string    `json:"error"`
+	SecondsAdvanced uint64    `json:"secondsAdvanced"`
+	BlockNumber     uint64    `json:"blockNumber"`
+	BlockTime       time.Time `json:"blockTime"`
+}
+
+type CreateBackupResponse struct {
+	Status   string         `json:"status"`
+	Error    string         `json:"error"`
+	Filename string         `json:"filename"`
+	Size     uint64         `json:"size"`
+	Sha256   string         `json:"sha256"`
+	Manifest BackupManifest `json:"manifest"`
+}
+type RestoreBackupResponse struct {
+	Status        string         `json:"status"`
+	Error         string         `json:"error"`
+	Manifest      BackupManifest `json:"manifest"`
+	RestoredFiles uint64         `json:"restoredFiles"`
+	Settings      string         `json:"settings"`
+}
+type ActiveHostStatusResponse struct {
+	Status       string              `json:"status"`
+	Error        string              `json:"error"`
+	Enabled      bool                `json:"enabled"`
+	HostId       string              `json:"hostId"`
+	Conflict     *ActiveHostConflict `json:"conflict,omitempty"`
+	LocalBeacon  *ActiveHostBeacon   `json:"localBeacon,omitempty"`
+	RemoteBeacon *ActiveHostBeacon   `json:"remoteBeacon,omitempty"`
+	RemoteError  string              `json:"remoteError,omitempty"`
+}
+type ClaimActiveHostResponse struct {
+	Status string           `json:"status"`
+	Error  string           `json:"error"`
+	Beacon ActiveHostBeacon `json:"beacon"`
+}
+type ActiveHostBeacon struct {
+	Network     string         `json:"network"`
+	NodeAddress common.Address `json:"nodeAddress"`
+	HostId      string         `json:"hostId"`
+	Hostname    string         `json:"hostname"`
+	Timestamp   time.Time      `json:"timestamp"`
+	Signature   string         `json:"signature"`
+}
+type ActiveHostConflict struct {
+	Time   time.Time `json:"time"`
+	Reason string    `json:"reason"`
+}
+type PortForwardingStatusResponse struct {
+	Status     string                `json:"status"`
+	Error      string                `json:"error"`
+	Enabled    bool                  `json:"enabled"`
+	Forwarding *PortForwardingStatus `json:"forwarding,omitempty"`
+}
+type PortForwardingStatus struct {
+	Method     string        `json:"method"`
+	ExternalIp string        `json:"externalIp"`
+	UpdatedAt  time.Time     `json:"updatedAt"`
+	Error      string        `json:"error,omitempty"`
+	Mappings   []PortMapping `json:"mappings"`
+}
+type PortMapping struct {
+	Name         string    `json:"name"`
+	Protocol     string    `json:"protocol"`
+	InternalPort uint16    `json:"internalPort"`
+	ExternalPort uint16    `json:"externalPort"`
+	RenewedAt    time.Time `json:"renewedAt"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+	Error        string    `json:"error,omitempty"`
+}
+type SetBackupPasswordResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+type BackupManifest struct {
+	Version          uint64       `json:"version"`
+	Created          time.Time    `json:"created"`
+	SmartnodeVersion string       `json:"smartnodeVersion"`
+	Network          string       `json:"network"`
+	NativeMode       bool         `json:"nativeMode"`
+	Components       []string     `json:"components"`
+	Files            []BackupFile `json:"files"`
+}
+type BackupFile struct {
+	Component string `json:"component"`
+	Name      string `json:"name"`
+	Size      uint64 `json:"size"`
+	Mode      uint32 `json:"mode"`
+	Sha256    string `json:"sha256"`
+}
+
+type CaptureProfileResponse struct {
+	Status   string          `json:"status"`
+	Error    string          `json:"error"`
+	Filename string          `json:"filename"`
+	Size     uint64          `json:"size"`
+	Manifest ProfileManifest `json:"manifest"`
+}
+type ProfileManifest struct {
+	Created          time.Time     `json:"created"`
+	SmartnodeVersion string        `json:"smartnodeVersion"`
+	NativeMode       bool          `json:"nativeMode"`
+	Duration         time.Duration `json:"duration"`
+	Profiles         []ProfileFile `json:"profiles"`
+}
+type ProfileFile struct {
+	Daemon string `json:"daemon"`
+	Name   string `json:"name"`
+	Size   uint64 `json:"size"`
+	Error  string `json:"error,omitempty"`
+}
+
+type DoctorResponse struct {
+	Status string        `json:"status"`
+	Error  string        `json:"error"`
+	Checks []DoctorCheck `json:"checks"`
+}
+type DoctorCheck struct {
+	Name     string         `json:"name"`
+	Severity DoctorSeverity `json:"severity"`
+	Details  string         `json:"details"`
+	Remedy   string         `json:"remedy,omitempty"`
+}
+
+// How urgent a problem found by the doctor is
+type DoctorSeverity string
+
+const (
+	DoctorSeverity_Critical DoctorSeverity = "critical"
+	DoctorSeverity_Warning  DoctorSeverity = "warning"
+	DoctorSeverity_Unknown  DoctorSeverity = "unknown"
+	DoctorSeverity_Ok       DoctorSeverity = "ok"
+)
+
+type TestAlertResponse struct {
+	Status  string                `json:"status"`
+	Error   string                `json:"error"`
+	Results []TestAlertSinkResult `json:"results"`
+}
+type TestAlertSinkResult struct {
+	Sink  string `json:"sink"`
+	Error string `json:"error"`
+}
+
+type TestFailureResponse struct {
+	Status     string    `json:"status"`
+	Error      string    `json:"error"`
+	AlertName  string    `json:"alertName"`
+	Summary    string    `json:"summary"`
+	Severity   string    `json:"severity"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	AlertState string    `json:"alertState"`
+	Sinks      []string  `json:"sinks"`
+}
+
+type DashboardResponse struct {
+	Status                    string                   `json:"status"`
+	Error                     string                   `json:"error"`
+	Warning                   string                   `json:"warning"`
+	EcManagerStatus           ClientManagerStatus      `json:"ecManagerStatus"`
+	BcManagerStatus           ClientManagerStatus      `json:"bcManagerStatus"`
+	CurrentSlot               uint64                   `json:"currentSlot"`
+	CurrentEpoch              uint64                   `json:"currentEpoch"`
+	NextEpochTime             time.Time                `json:"nextEpochTime"`
+	WalletInitialized         bool                     `json:"walletInitialized"`
+	NodeAddress               common.Address           `json:"nodeAddress"`
+	PendingTransactionCount   uint64                   `json:"pendingTransactionCount"`
+	ValidatorCount            int                      `json:"validatorCount"`
+	Proposals                 []DashboardProposal      `json:"proposals"`
+	SyncCommitteeCount        int                      `json:"syncCommitteeCount"`
+	NextSyncCommitteeCount    int                      `json:"nextSyncCommitteeCount"`
+	SyncPeriodEndTime         time.Time                `json:"syncPeriodEndTime"`
+	RollingRecordsEnabled     bool                     `json:"rollingRecordsEnabled"`
+	RollingRecordSaved        bool                     `json:"rollingRecordSaved"`
+	RollingRecordSlot         uint64                   `json:"rollingRecordSlot"`
+	RollingRecordSlotsBehind  uint64                   `json:"rollingRecordSlotsBehind"`
+	RollingRecordCheckpointAt time.Time                `json:"rollingRecordCheckpointAt"`
+	RollingRecordCatchUp      *rewards.CatchUpProgress `json:"rollingRecordCatchUp"`
+	Hardware                  *hardware.HostMetrics    `json:"hardware"`
+	Alerts                    []NodeAlert              `json:"alerts"`
+}
+type DashboardProposal struct {
+	ValidatorIndex string    `json:"validatorIndex"`
+	Slot           uint64    `json:"slot"`
+	Time           time.Time `json:"time"`
+}
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"math/big"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/uuid"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
@@ -42,6 +44,17 @@ type RecoverWalletResponse struct {
 	Error          string                  `json:"error"`
 	AccountAddress common.Address          `json:"accountAddress"`
 	ValidatorKeys  []types.ValidatorPubkey `json:"validatorKeys"`
+	KeysLoaded     bool                    `json:"keysLoaded"`
+}
+
+type VerifyRecoveryResponse struct {
+	Status         string                  `json:"status"`
+	Error          string                  `json:"error"`
+	AccountAddress common.Address          `json:"accountAddress"`
+	NodeAddress    common.Address          `json:"nodeAddress"`
+	AddressMatches bool                    `json:"addressMatches"`
+	ValidatorKeys  []types.ValidatorPubkey `json:"validatorKeys"`
+	MissingKeys    []types.ValidatorPubkey `json:"missingKeys"`
 }
 
 type SearchAndRecoverWalletResponse struct {
@@ -52,12 +65,50 @@ type SearchAndRecoverWalletResponse struct {
 	DerivationPath string                  `json:"derivationPath"`
 	Index          uint                    `json:"index"`
 	ValidatorKeys  []types.ValidatorPubkey `json:"validatorKeys"`
+	KeysLoaded     bool                    `json:"keysLoaded"`
 }
 
 type RebuildWalletResponse struct {
 	Status        string                  `json:"status"`
 	Error         string                  `json:"error"`
 	ValidatorKeys []types.ValidatorPubkey `json:"validatorKeys"`
+	KeysLoaded    bool                    `json:"keysLoaded"`
+}
+
+// A minipool validator found while recovering a node, and whether it's safe to start validating with
+type RecoveryValidator struct {
+	MinipoolAddress  common.Address        `json:"minipoolAddress"`
+	Pubkey           types.ValidatorPubkey `json:"pubkey"`
+	Index            string                `json:"index"`
+	ValidatorStatus  string                `json:"validatorStatus"`
+	ExistsOnBeacon   bool                  `json:"existsOnBeacon"`
+	Slashed          bool                  `json:"slashed"`
+	CredentialsMatch bool                  `json:"credentialsMatch"`
+	KeyRecovered     bool                  `json:"keyRecovered"`
+	Verified         bool                  `json:"verified"`
+}
+
+type RecoverValidatorKeysResponse struct {
+	Status     string              `json:"status"`
+	Error      string              `json:"error"`
+	Validators []RecoveryValidator `json:"validators"`
+}
+
+type LoadRecoveredValidatorKeysResponse struct {
+	Status                     string                  `json:"status"`
+	Error                      string                  `json:"error"`
+	LoadedKeys                 []types.ValidatorPubkey `json:"loadedKeys"`
+	SkippedKeys                []types.ValidatorPubkey `json:"skippedKeys"`
+	SlashingProtectionImported bool                    `json:"slashingProtectionImported"`
+	Restarted                  bool                    `json:"restarted"`
+}
+
+type EncryptValidatorKeysResponse struct {
+	Status       string `json:"status"`
+	Error        string `json:"error"`
+	ArchivePath  string `json:"archivePath"`
+	Rekeyed      bool   `json:"rekeyed"`
+	KeysInMemory bool   `json:"keysInMemory"`
 }
 
 type ExportWalletResponse struct {
@@ -88,3 +139,50 @@ type PurgeResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
+
+type CredentialChangeValidator struct {
+	MinipoolAddress       common.Address        `json:"minipoolAddress"`
+	Pubkey                types.ValidatorPubkey `json:"pubkey"`
+	ValidatorIndex        string                `json:"validatorIndex"`
+	WithdrawalCredentials common.Hash           `json:"withdrawalCredentials"`
+	RequiresChange        bool                  `json:"requiresChange"`
+	KeyFound              bool                  `json:"keyFound"`
+	KeyIndex              uint                  `json:"keyIndex"`
+	ChangeLanded          bool                  `json:"changeLanded"`
+	Reason                string                `json:"reason"`
+}
+type WalletCredentialChangesResponse struct {
+	Status     string                      `json:"status"`
+	Error      string                      `json:"error"`
+	Validators []CredentialChangeValidator `json:"validators"`
+}
+type WalletSendCredentialChangesResponse struct {
+	Status string           `json:"status"`
+	Error  string           `json:"error"`
+	Sent   []common.Address `json:"sent"`
+}
+
+type GasWalletRoute struct {
+	Tx            string `json:"tx"`
+	Description   string `json:"description"`
+	UsesGasWallet bool   `json:"usesGasWallet"`
+	Reason        string `json:"reason"`
+}
+type GasWalletStatusResponse struct {
+	Status      string           `json:"status"`
+	Error       string           `json:"error"`
+	Initialized bool             `json:"initialized"`
+	Address     common.Address   `json:"address"`
+	Balance     *big.Int         `json:"balance"`
+	Routes      []GasWalletRoute `json:"routes"`
+}
+type SetGasWalletResponse struct {
+	Status  string         `json:"status"`
+	Error   string         `json:"error"`
+	Address common.Address `json:"address"`
+}
+type DeleteGasWalletResponse struct {
+	Status  string         `json:"status"`
+	Error   string         `json:"error"`
+	Address common.Address `json:"address"`
+}
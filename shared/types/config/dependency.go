@@ -0,0 +1,91 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A condition on another parameter that has to hold for a parameter to apply
+type ParameterDependency struct {
+	// The parameter this one depends on
+	Parameter *Parameter
+
+	// The values of that parameter this one applies to; if there aren't any, it applies whenever that parameter isn't blank
+	Values []interface{}
+}
+
+// Check if the dependency holds
+func (dependency ParameterDependency) IsMet() bool {
+	if !dependency.Parameter.IsApplicable() {
+		return false
+	}
+	value := dependency.Parameter.Value
+	if len(dependency.Values) == 0 {
+		return value != nil && fmt.Sprint(value) != ""
+	}
+	for _, allowed := range dependency.Values {
+		if value == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// Describe the values the dependency needs, such as "is Locally Managed", "is enabled", or "is set"
+func (dependency ParameterDependency) DescribeValues() string {
+	if len(dependency.Values) == 0 {
+		return "is set"
+	}
+	names := make([]string, len(dependency.Values))
+	for i, value := range dependency.Values {
+		names[i] = fmt.Sprint(value)
+		switch v := value.(type) {
+		case bool:
+			if v {
+				names[i] = "enabled"
+			} else {
+				names[i] = "disabled"
+			}
+		default:
+			for _, option := range dependency.Parameter.Options {
+				if option.Value == value {
+					names[i] = option.Name
+					break
+				}
+			}
+		}
+	}
+	if len(names) == 1 {
+		return "is " + names[0]
+	}
+	return "is " + strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+}
+
+// Check if the parameter applies with the current values of the parameters it depends on
+func (param *Parameter) IsApplicable() bool {
+	for _, dependency := range param.Dependencies {
+		if !dependency.IsMet() {
+			return false
+		}
+	}
+	return true
+}
+
+// Check if the parameter should be shown, which depends on whether it applies and whether advanced settings are shown
+func (param *Parameter) IsVisible(showAdvanced bool) bool {
+	return (showAdvanced || !param.Advanced) && param.IsApplicable()
+}
+
+// Get the dependencies that keep the parameter from applying, including the ones of the parameters it depends on
+func (param *Parameter) GetUnmetDependencies() []ParameterDependency {
+	unmet := []ParameterDependency{}
+	for _, dependency := range param.Dependencies {
+		if !dependency.Parameter.IsApplicable() {
+			unmet = append(unmet, dependency.Parameter.GetUnmetDependencies()...)
+		}
+		if !dependency.IsMet() {
+			unmet = append(unmet, dependency)
+		}
+	}
+	return unmet
+}
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"regexp"
 	"strconv"
+	"strings"
 )
 
 // A parameter that can be configured by the user
@@ -23,6 +24,7 @@ type Parameter struct {
 	Options               []ParameterOption       `yaml:"options,omitempty"`
 	Value                 interface{}             `yaml:"-"`
 	DescriptionsByNetwork map[Network]string      `yaml:"-"`
+	Dependencies          []ParameterDependency   `yaml:"-"`
 }
 
 // A single option in a choice parameter
@@ -130,6 +132,55 @@ func (param *Parameter) Deserialize(serializedParams map[string]string, network
 	return nil
 }
 
+// Set the value from a string, checking that it's valid for the parameter's type and constraints
+func (param *Parameter) SetValueFromString(value string) error {
+	var newValue interface{}
+	var err error
+	switch param.Type {
+	case ParameterType_Int:
+		newValue, err = strconv.ParseInt(value, 0, 0)
+	case ParameterType_Uint:
+		newValue, err = strconv.ParseUint(value, 0, 0)
+	case ParameterType_Uint16:
+		var result uint64
+		result, err = strconv.ParseUint(value, 0, 16)
+		newValue = uint16(result)
+	case ParameterType_Bool:
+		newValue, err = strconv.ParseBool(value)
+	case ParameterType_Float:
+		newValue, err = strconv.ParseFloat(value, 64)
+	case ParameterType_String:
+		if !param.CanBeBlank && value == "" {
+			return fmt.Errorf("this setting can't be blank")
+		}
+		if param.MaxLength > 0 && len(value) > param.MaxLength {
+			return fmt.Errorf("[%s] is longer than the max length of %d", value, param.MaxLength)
+		}
+		if param.Regex != "" && value != "" && !regexp.MustCompile(param.Regex).MatchString(value) {
+			return fmt.Errorf("[%s] isn't in the expected format", value)
+		}
+		newValue = value
+	case ParameterType_Choice:
+		options := make([]string, len(param.Options))
+		for i, option := range param.Options {
+			options[i] = fmt.Sprint(option.Value)
+			if options[i] == value {
+				param.Value = option.Value
+				return nil
+			}
+		}
+		return fmt.Errorf("[%s] isn't one of the options: %s", value, strings.Join(options, ", "))
+	default:
+		return fmt.Errorf("parameter type [%s] is unknown", param.Type)
+	}
+
+	if err != nil {
+		return fmt.Errorf("[%s] isn't a valid %s", value, param.Type)
+	}
+	param.Value = newValue
+	return nil
+}
+
 // Set the value to the default for the provided config's network
 func (param *Parameter) SetToDefault(network Network) error {
 	defaultSetting, err := param.GetDefault(network)
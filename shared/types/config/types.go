@@ -7,10 +7,20 @@ type ParameterType string
 type ExecutionClient string
 type ConsensusClient string
 type RewardsMode string
+type PasswordSource string
 type MevRelayID string
 type MevSelectionMode string
 type NimbusPruningMode string
 type PBSubmissionRef int
+type RecordCodec string
+type WatchtowerPriority string
+type MetricsExportMode string
+type EmailMode string
+type SmtpSecurity string
+type PushSeverity string
+type BackupStorage string
+type PortForwardingMethod string
+type HardwareProfile string
 
 // Enum to describe which container(s) a parameter impacts, so the Smartnode knows which
 // ones to restart upon a settings change
@@ -84,10 +94,84 @@ const (
 	RewardsMode_Generate RewardsMode = "generate"
 )
 
+// Enum to describe where the node wallet password is retrieved from
+const (
+	PasswordSource_File    PasswordSource = "file"
+	PasswordSource_Keyring PasswordSource = "keyring"
+	PasswordSource_Vault   PasswordSource = "vault"
+	PasswordSource_Command PasswordSource = "command"
+)
+
 const (
 	PBSubmission_6AM PBSubmissionRef = 1713420000
 )
 
+// Enum to describe the compression used for rolling record checkpoints and local rewards files
+const (
+	RecordCodec_Zstd RecordCodec = "zstd"
+	RecordCodec_Lz4  RecordCodec = "lz4"
+	RecordCodec_S2   RecordCodec = "s2"
+	RecordCodec_None RecordCodec = "none"
+)
+
+// Enum to describe how much the watchtower's heavy calculations give way to the other clients when the machine is busy
+const (
+	WatchtowerPriority_Low    WatchtowerPriority = "low"
+	WatchtowerPriority_Normal WatchtowerPriority = "normal"
+	WatchtowerPriority_High   WatchtowerPriority = "high"
+)
+
+// Enum to describe how the daemons export their metrics besides the scrape endpoint
+const (
+	MetricsExportMode_None        MetricsExportMode = "none"
+	MetricsExportMode_Pushgateway MetricsExportMode = "pushgateway"
+	MetricsExportMode_RemoteWrite MetricsExportMode = "remoteWrite"
+)
+
+// Enum to describe where scheduled backups are uploaded
+const (
+	BackupStorage_None   BackupStorage = "none"
+	BackupStorage_S3     BackupStorage = "s3"
+	BackupStorage_B2     BackupStorage = "b2"
+	BackupStorage_WebDav BackupStorage = "webdav"
+)
+
+// Enum to describe how the P2P ports are forwarded on the router
+const (
+	PortForwardingMethod_Auto PortForwardingMethod = "auto"
+	PortForwardingMethod_Upnp PortForwardingMethod = "upnp"
+	PortForwardingMethod_Pmp  PortForwardingMethod = "pmp"
+)
+
+// Enum to describe the kind of hardware the Smartnode's client settings are tuned for
+const (
+	HardwareProfile_Custom   HardwareProfile = "custom"
+	HardwareProfile_LowPower HardwareProfile = "low-power"
+	HardwareProfile_Standard HardwareProfile = "standard"
+	HardwareProfile_Server   HardwareProfile = "server"
+)
+
+// Enum to describe when alert emails are sent
+const (
+	EmailMode_Disabled  EmailMode = "disabled"
+	EmailMode_Immediate EmailMode = "immediate"
+	EmailMode_Digest    EmailMode = "digest"
+)
+
+// Enum to describe how the connection to the SMTP server is secured
+const (
+	SmtpSecurity_None     SmtpSecurity = "none"
+	SmtpSecurity_StartTls SmtpSecurity = "starttls"
+	SmtpSecurity_Tls      SmtpSecurity = "tls"
+)
+
+// Enum to describe the lowest alert severity that's pushed to mobile devices right away
+const (
+	PushSeverity_Critical PushSeverity = "critical"
+	PushSeverity_Warning  PushSeverity = "warning"
+	PushSeverity_Info     PushSeverity = "info"
+)
+
 // Enum to identify MEV-boost relays
 const (
 	MevRelayID_Unknown            MevRelayID = ""
@@ -0,0 +1,64 @@
+package errcodes
+
+import (
+	"errors"
+)
+
+// A machine-readable category for an error, so the CLI and scripts can react to it without parsing the message
+type Code string
+
+const (
+	None                Code = ""
+	WalletNotReady      Code = "wallet_not_ready"
+	ClientSyncing       Code = "client_syncing"
+	ClientUnavailable   Code = "client_unavailable"
+	ContractNotFound    Code = "contract_not_found"
+	NodeNotRegistered   Code = "node_not_registered"
+	NotAuthorized       Code = "not_authorized"
+	InsufficientBalance Code = "insufficient_balance"
+	NetworkMismatch     Code = "network_mismatch"
+	ConsensusMismatch   Code = "consensus_mismatch"
+	ChecksumError       Code = "checksum_error"
+)
+
+// An error with an error code attached to it
+type CodedError struct {
+	Code Code
+	Err  error
+}
+
+// Create a new error with an error code
+func New(code Code, message string) error {
+	return &CodedError{
+		Code: code,
+		Err:  errors.New(message),
+	}
+}
+
+// Attach an error code to an existing error
+func Wrap(code Code, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &CodedError{
+		Code: code,
+		Err:  err,
+	}
+}
+
+func (e *CodedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CodedError) Unwrap() error {
+	return e.Err
+}
+
+// Get the code of the first coded error in an error chain, or None if there isn't one
+func Get(err error) Code {
+	var codedErr *CodedError
+	if errors.As(err, &codedErr) {
+		return codedErr.Code
+	}
+	return None
+}
@@ -9,8 +9,17 @@ import (
 	"github.com/goccy/go-json"
 
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/types/errcodes"
 )
 
+// Provides previews of the transactions an API command estimated gas for
+var transactionPreviewSource func() []api.TransactionPreview
+
+// Set the source of the transaction previews attached to successful responses, so the CLI can show them before asking for confirmation
+func SetTransactionPreviewSource(source func() []api.TransactionPreview) {
+	transactionPreviewSource = source
+}
+
 func ZeroIfNil(in **big.Int) {
 	if *in == nil {
 		*in = big.NewInt(0)
@@ -42,6 +51,22 @@ func PrintResponse(response interface{}, responseError error) {
 		return
 	}
 
+	// Errors with a code are printed as a plain error response so the code is included
+	if code := errcodes.Get(responseError); code != errcodes.None {
+		response = &api.APIResponse{
+			Status:    "error",
+			Error:     responseError.Error(),
+			ErrorCode: code,
+		}
+		responseBytes, err := json.Marshal(response)
+		if err != nil {
+			PrintErrorResponse(fmt.Errorf("Could not encode API response: %w", err))
+			return
+		}
+		fmt.Println(string(responseBytes))
+		return
+	}
+
 	// Populate error
 	if responseError != nil {
 		ef.SetString(responseError.Error())
@@ -60,12 +85,36 @@ func PrintResponse(response interface{}, responseError error) {
 		PrintErrorResponse(fmt.Errorf("Could not encode API response: %w", err))
 		return
 	}
+	if transactionPreviewSource != nil && ef.String() == "" {
+		responseBytes = attachTransactionPreviews(responseBytes, transactionPreviewSource())
+	}
 
 	// Print
 	fmt.Println(string(responseBytes))
 
 }
 
+// Add transaction previews to an encoded response as the txPreviews field
+func attachTransactionPreviews(responseBytes []byte, previews []api.TransactionPreview) []byte {
+	if len(previews) == 0 {
+		return responseBytes
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(responseBytes, &fields); err != nil {
+		return responseBytes
+	}
+	previewBytes, err := json.Marshal(previews)
+	if err != nil {
+		return responseBytes
+	}
+	fields["txPreviews"] = previewBytes
+	merged, err := json.Marshal(fields)
+	if err != nil {
+		return responseBytes
+	}
+	return merged
+}
+
 // Print an API error response
 func PrintErrorResponse(err error) {
 	PrintResponse(&api.APIResponse{}, err)
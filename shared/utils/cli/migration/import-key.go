@@ -33,7 +33,7 @@ func ImportKey(c *cli.Context, rp *rocketpool.Client, minipoolAddress common.Add
 
 	// Import the key
 	fmt.Printf("Importing validator key... ")
-	_, err := rp.ImportKey(minipoolAddress, mnemonic)
+	response, err := rp.ImportKey(minipoolAddress, mnemonic)
 	if err != nil {
 		fmt.Printf("error importing validator key: %s\n", err.Error())
 		return false
@@ -41,6 +41,10 @@ func ImportKey(c *cli.Context, rp *rocketpool.Client, minipoolAddress common.Add
 	fmt.Println("done!")
 
 	// Restart the VC if necessary
+	if response.KeyLoaded {
+		fmt.Println("The key was loaded into your Validator Client.")
+		return true
+	}
 	if c.Bool("no-restart") {
 		return true
 	}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Prints decoded transactions so the user can check what they're about to sign
+func PrintTransactionPreviews(previews []api.TransactionPreview) {
+	if len(previews) == 0 {
+		return
+	}
+	if len(previews) == 1 {
+		fmt.Printf("%sYou are about to sign this transaction:%s\n", colorLightBlue, colorReset)
+	} else {
+		fmt.Printf("%sYou are about to sign these %d transactions:%s\n", colorLightBlue, len(previews), colorReset)
+	}
+	for _, preview := range previews {
+		PrintTransactionPreview(preview)
+	}
+	fmt.Println()
+}
+
+// Prints a single decoded transaction
+func PrintTransactionPreview(preview api.TransactionPreview) {
+	contract := preview.Contract
+	if contract != "" && !preview.ContractResolved {
+		contract = fmt.Sprintf("%slooks like %s, but the address wasn't checked%s", colorYellow, contract, colorReset)
+	}
+	if preview.To != nil {
+		if contract != "" {
+			fmt.Printf("\tTo:     %s (%s)\n", preview.To.Hex(), contract)
+		} else {
+			fmt.Printf("\tTo:     %s\n", preview.To.Hex())
+		}
+	} else if contract != "" {
+		fmt.Printf("\tTo:     %s\n", contract)
+	}
+	if preview.Decoded {
+		fmt.Printf("\tMethod: %s\n", preview.Method)
+	} else if preview.Method != "" {
+		fmt.Printf("\tMethod: %s %s(its arguments couldn't be decoded)%s\n", preview.Method, colorYellow, colorReset)
+	} else {
+		fmt.Printf("\tMethod: %sunknown method %s; it couldn't be decoded with the bundled Rocket Pool ABIs%s\n", colorYellow, preview.Selector, colorReset)
+	}
+	if preview.Value != nil {
+		fmt.Printf("\tValue:  %.6f ETH\n", eth.WeiToEth(preview.Value))
+	}
+	for _, arg := range preview.Arguments {
+		fmt.Printf("\t\t%s (%s): %s\n", arg.Name, arg.Type, arg.Value)
+	}
+}
@@ -2,12 +2,14 @@ package cli
 
 import (
 	"fmt"
+	"github.com/rocket-pool/smartnode/shared/services/config"
 	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/types/errcodes"
 )
 
 const colorReset string = "\033[0m"
@@ -109,6 +111,9 @@ func PrettyPrintError(err error) {
 		}
 	}
 	fmt.Println(prettyErr)
+	if code := errcodes.Get(err); code != errcodes.None {
+		fmt.Printf("(error code: %s)\n", code)
+	}
 }
 
 // Prints an error message when the Beacon client is not using the deposit contract address that Rocket Pool expects
@@ -129,11 +134,12 @@ func PrintDepositMismatchError(rpNetwork, beaconNetwork uint64, rpDepositAddress
 }
 
 // Prints what network you're currently on
-func PrintNetwork(currentNetwork cfgtypes.Network, isNew bool) error {
+func PrintNetwork(cfg *config.RocketPoolConfig, isNew bool) error {
 	if isNew {
 		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
 	}
 
+	currentNetwork := cfg.GetNetwork()
 	switch currentNetwork {
 	case cfgtypes.Network_Mainnet:
 		fmt.Printf("Your Smartnode is currently using the %sEthereum Mainnet.%s\n\n", colorGreen, colorReset)
@@ -142,7 +148,12 @@ func PrintNetwork(currentNetwork cfgtypes.Network, isNew bool) error {
 	case cfgtypes.Network_Holesky:
 		fmt.Printf("Your Smartnode is currently using the %sHolesky Test Network.%s\n\n", colorYellow, colorReset)
 	default:
-		fmt.Printf("%sYou are on an unexpected network [%v].%s\n\n", colorYellow, currentNetwork, colorReset)
+		descriptor := cfg.GetNetworkDescriptor(currentNetwork)
+		if descriptor == nil {
+			fmt.Printf("%sYou are on an unexpected network [%v].%s\n\n", colorYellow, currentNetwork, colorReset)
+		} else {
+			fmt.Printf("Your Smartnode is currently using the %s%s.%s\n\n", colorYellow, descriptor.Name, colorReset)
+		}
 	}
 
 	return nil
@@ -262,6 +262,42 @@ func ValidateBurnableTokenType(name, value string) (string, error) {
 	return val, nil
 }
 
+// Validate an rETH arbitrage direction
+func ValidateRethArbitrageDirection(name, value string) (string, error) {
+	val := strings.ToLower(value)
+	if !(val == "deposit" || val == "withdraw") {
+		return "", fmt.Errorf("Invalid %s '%s' - valid directions are 'deposit' and 'withdraw'", name, value)
+	}
+	return val, nil
+}
+
+// Validate an alert severity
+func ValidateAlertSeverity(name, value string) (string, error) {
+	val := strings.ToLower(value)
+	if !(val == "critical" || val == "warning" || val == "info") {
+		return "", fmt.Errorf("Invalid %s '%s' - valid severities are 'critical', 'warning', and 'info'", name, value)
+	}
+	return val, nil
+}
+
+// Validate a synthetic failure scenario for testing the alerting pipeline
+func ValidateFailureScenario(name, value string) (string, error) {
+	val := strings.ToLower(value)
+	if !(val == "client-unreachable" || val == "missed-attestation" || val == "low-disk") {
+		return "", fmt.Errorf("Invalid %s '%s' - valid scenarios are 'client-unreachable', 'missed-attestation', and 'low-disk'", name, value)
+	}
+	return val, nil
+}
+
+// Validate a watchtower submission kind, where "all" means every kind
+func ValidateSubmissionKind(name, value string) (string, error) {
+	val := strings.ToLower(value)
+	if !(val == "all" || val == "balances" || val == "prices" || val == "rewards-tree") {
+		return "", fmt.Errorf("Invalid %s '%s' - valid kinds are 'all', 'balances', 'prices', and 'rewards-tree'", name, value)
+	}
+	return val, nil
+}
+
 // Validate a node password
 func ValidateNodePassword(name, value string) (string, error) {
 	if len(value) < passwords.MinPasswordLength {
@@ -0,0 +1,211 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The furthest ahead to look for the next matching time, which covers schedules that only match on leap days
+const maxSearchYears = 5
+
+// A cron schedule with the standard five fields: minute, hour, day of month, month, and day of week.
+// Schedules are always evaluated in UTC.
+type Schedule struct {
+	minutes     uint64
+	hours       uint64
+	daysOfMonth uint64
+	months      uint64
+	daysOfWeek  uint64
+
+	// Like standard cron, if both day fields are restricted then a time matches if either of them does
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+// The range and name of each field
+type field struct {
+	name string
+	min  uint64
+	max  uint64
+}
+
+var fields = []field{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// The shortcuts for common schedules
+var shortcuts = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+}
+
+// Parse a cron expression, such as "0 3 * * *" or "@daily"
+func Parse(expression string) (*Schedule, error) {
+	expression = strings.TrimSpace(expression)
+	if shortcut, exists := shortcuts[expression]; exists {
+		expression = shortcut
+	}
+
+	parts := strings.Fields(expression)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("cron expression [%s] should have %d fields but it has %d", expression, len(fields), len(parts))
+	}
+
+	values := make([]uint64, len(fields))
+	for i, part := range parts {
+		bits, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("error parsing cron expression [%s]: %w", expression, err)
+		}
+		values[i] = bits
+	}
+
+	// Sunday can be either 0 or 7
+	daysOfWeek := values[4]
+	if daysOfWeek&(1<<7) != 0 {
+		daysOfWeek = (daysOfWeek | 1) &^ (1 << 7)
+	}
+
+	return &Schedule{
+		minutes:       values[0],
+		hours:         values[1],
+		daysOfMonth:   values[2],
+		months:        values[3],
+		daysOfWeek:    daysOfWeek,
+		anyDayOfMonth: parts[2] == "*",
+		anyDayOfWeek:  parts[4] == "*",
+	}, nil
+}
+
+// Get the first time after the provided one that matches the schedule, or the zero time if there isn't one
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if !has(s.months, uint64(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !has(s.hours, uint64(t.Hour())) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !has(s.minutes, uint64(t.Minute())) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// Get the shortest time between two consecutive runs of the schedule within the next week, which is used to tell when a job is overdue
+func (s *Schedule) Interval(t time.Time) time.Duration {
+	var shortest time.Duration
+	previous := s.Next(t)
+	if previous.IsZero() {
+		return 0
+	}
+	limit := previous.AddDate(0, 0, 7)
+	for {
+		next := s.Next(previous)
+		if next.IsZero() {
+			break
+		}
+		if interval := next.Sub(previous); shortest == 0 || interval < shortest {
+			shortest = interval
+		}
+		if next.After(limit) {
+			break
+		}
+		previous = next
+	}
+	return shortest
+}
+
+// Check if a day matches the day of month and day of week fields
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dayOfMonth := has(s.daysOfMonth, uint64(t.Day()))
+	dayOfWeek := has(s.daysOfWeek, uint64(t.Weekday()))
+	if s.anyDayOfMonth || s.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+// Parse one field into a bitmask of the values it matches
+func parseField(part string, f field) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(part, ",") {
+		rangePart, step := item, uint64(1)
+		if index := strings.Index(item, "/"); index != -1 {
+			rangePart = item[:index]
+			parsedStep, err := strconv.ParseUint(item[index+1:], 10, 64)
+			if err != nil || parsedStep == 0 {
+				return 0, fmt.Errorf("invalid step [%s] in the %s field", item[index+1:], f.name)
+			}
+			step = parsedStep
+		}
+
+		start, end := f.min, f.max
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			start, err = parseValue(bounds[0], f)
+			if err != nil {
+				return 0, err
+			}
+			end = start
+			if len(bounds) == 2 {
+				end, err = parseValue(bounds[1], f)
+				if err != nil {
+					return 0, err
+				}
+			} else if step > 1 {
+				// A single value with a step, like 5/15, runs to the end of the field
+				end = f.max
+			}
+			if end < start {
+				return 0, fmt.Errorf("invalid range [%s] in the %s field", rangePart, f.name)
+			}
+		}
+
+		for value := start; value <= end; value += step {
+			bits |= 1 << value
+		}
+	}
+	return bits, nil
+}
+
+// Parse a single value and make sure it's in range for its field
+func parseValue(value string, f field) (uint64, error) {
+	parsed, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value [%s] in the %s field", value, f.name)
+	}
+	if parsed < f.min || parsed > f.max {
+		return 0, fmt.Errorf("value %d in the %s field must be between %d and %d", parsed, f.name, f.min, f.max)
+	}
+	return parsed, nil
+}
+
+// Check if a bitmask contains a value
+func has(bits uint64, value uint64) bool {
+	return bits&(1<<value) != 0
+}
@@ -0,0 +1,88 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// The names accepted for the days of the week, in time.Weekday order
+var dayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// A daily time window such as `09:00-17:00`, optionally limited to some days of the week.
+// Windows are always evaluated in UTC, and ones that end before they start run past midnight.
+type Window struct {
+	start int
+	end   int
+
+	// A bit for each day of the week the window starts on
+	days uint64
+}
+
+// Parse a time window like `09:00-17:00` and a comma-separated list of days like `mon,tue,wed`; a blank list means every day
+func ParseWindow(window string, days string) (*Window, error) {
+	parts := strings.Split(strings.TrimSpace(window), "-")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("[%s] isn't a window like 09:00-17:00", window)
+	}
+	start, err := parseTimeOfDay(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseTimeOfDay(parts[1])
+	if err != nil {
+		return nil, err
+	}
+	if start == end {
+		return nil, fmt.Errorf("the window [%s] starts and ends at the same time", window)
+	}
+
+	w := &Window{start: start, end: end}
+	if strings.TrimSpace(days) == "" {
+		w.days = 1<<len(dayNames) - 1
+		return w, nil
+	}
+	for _, day := range strings.Split(days, ",") {
+		day = strings.ToLower(strings.TrimSpace(day))
+		found := false
+		for i, name := range dayNames {
+			if len(day) >= 3 && strings.HasPrefix(day, name) {
+				w.days |= 1 << i
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("[%s] isn't a day of the week", day)
+		}
+	}
+	return w, nil
+}
+
+// Check if a time is inside the window
+func (w *Window) Contains(t time.Time) bool {
+	t = t.UTC()
+	minute := t.Hour()*60 + t.Minute()
+	day := t.Weekday()
+	if w.start < w.end {
+		return minute >= w.start && minute < w.end && has(w.days, uint64(day))
+	}
+
+	// The window runs past midnight, so the early part belongs to the day before
+	if minute >= w.start {
+		return has(w.days, uint64(day))
+	}
+	if minute < w.end {
+		return has(w.days, uint64((day+6)%7))
+	}
+	return false
+}
+
+// Parse a time of day like `17:30` into minutes after midnight
+func parseTimeOfDay(value string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("[%s] isn't a time like 17:30", strings.TrimSpace(value))
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
@@ -0,0 +1,21 @@
+package eth1
+
+import (
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Get the gas limit a block should have if its proposer is voting for desiredGasLimit.
+// Each block can only move the gas limit by just under 1/1024th of its parent's, so it approaches the desired one in steps.
+func GetExpectedGasLimit(parentGasLimit uint64, desiredGasLimit uint64) uint64 {
+	delta := parentGasLimit/params.GasLimitBoundDivisor - 1
+	if desiredGasLimit < params.MinGasLimit {
+		desiredGasLimit = params.MinGasLimit
+	}
+	if parentGasLimit < desiredGasLimit {
+		return min(parentGasLimit+delta, desiredGasLimit)
+	}
+	if parentGasLimit > desiredGasLimit {
+		return max(parentGasLimit-delta, desiredGasLimit)
+	}
+	return parentGasLimit
+}
@@ -0,0 +1,24 @@
+package eth1
+
+import "testing"
+
+func TestGetExpectedGasLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		parent   uint64
+		desired  uint64
+		expected uint64
+	}{
+		{"at target", 30_000_000, 30_000_000, 30_000_000},
+		{"raising", 30_000_000, 36_000_000, 30_029_295},
+		{"raising to nearby target", 35_990_000, 36_000_000, 36_000_000},
+		{"lowering", 36_000_000, 30_000_000, 35_964_845},
+		{"lowering to nearby target", 30_010_000, 30_000_000, 30_000_000},
+	}
+	for _, test := range tests {
+		actual := GetExpectedGasLimit(test.parent, test.desired)
+		if actual != test.expected {
+			t.Errorf("%s: expected %d, got %d", test.name, test.expected, actual)
+		}
+	}
+}
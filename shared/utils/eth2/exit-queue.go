@@ -0,0 +1,59 @@
+package eth2
+
+import "math"
+
+// Beacon chain spec values used by the exit queue and withdrawal sweep
+const (
+	FarFutureEpoch                   uint64 = math.MaxUint64
+	MinPerEpochChurnLimit            uint64 = 4
+	ChurnLimitQuotient               uint64 = 65536
+	MaxSeedLookahead                 uint64 = 4
+	MinValidatorWithdrawabilityDelay uint64 = 256
+	MaxWithdrawalsPerPayload         uint64 = 16
+)
+
+// Get the number of validators that can exit per epoch
+func GetChurnLimit(activeValidatorCount uint64) uint64 {
+	churnLimit := activeValidatorCount / ChurnLimitQuotient
+	if churnLimit < MinPerEpochChurnLimit {
+		return MinPerEpochChurnLimit
+	}
+	return churnLimit
+}
+
+// Get the epoch that a voluntary exit submitted during the current epoch would be assigned, given the exit epochs of the validators already in the exit queue
+func GetExitQueueEpoch(currentEpoch uint64, activeValidatorCount uint64, pendingExitEpochs []uint64) uint64 {
+	exitQueueEpoch := currentEpoch + 1 + MaxSeedLookahead
+	for _, epoch := range pendingExitEpochs {
+		if epoch != FarFutureEpoch && epoch > exitQueueEpoch {
+			exitQueueEpoch = epoch
+		}
+	}
+
+	churn := uint64(0)
+	for _, epoch := range pendingExitEpochs {
+		if epoch == exitQueueEpoch {
+			churn++
+		}
+	}
+	if churn >= GetChurnLimit(activeValidatorCount) {
+		exitQueueEpoch++
+	}
+	return exitQueueEpoch
+}
+
+// Get the first slot at or after withdrawableSlot where the withdrawal sweep will reach the validator.
+// This assumes every validator ahead of it in the sweep has a withdrawal to process, which is true in practice on networks with partial withdrawals enabled.
+func GetWithdrawalSweepSlot(headSlot uint64, nextWithdrawalValidatorIndex uint64, validatorIndex uint64, validatorCount uint64, withdrawableSlot uint64) (uint64, uint64) {
+	if validatorCount == 0 {
+		return withdrawableSlot, 0
+	}
+	sweepSlots := (validatorCount + MaxWithdrawalsPerPayload - 1) / MaxWithdrawalsPerPayload
+	distance := (validatorIndex + validatorCount - (nextWithdrawalValidatorIndex % validatorCount)) % validatorCount
+	slot := headSlot + distance/MaxWithdrawalsPerPayload + 1
+	if slot < withdrawableSlot {
+		cycles := (withdrawableSlot - slot + sweepSlots - 1) / sweepSlots
+		slot += cycles * sweepSlots
+	}
+	return slot, sweepSlots
+}
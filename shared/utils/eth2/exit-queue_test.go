@@ -0,0 +1,44 @@
+package eth2
+
+import "testing"
+
+func TestGetExitQueueEpoch(t *testing.T) {
+	// Empty queue uses the activation exit epoch
+	if epoch := GetExitQueueEpoch(100, 500000, nil); epoch != 105 {
+		t.Fatalf("expected epoch 105 for an empty queue, got %d", epoch)
+	}
+
+	// The churn limit is 7 with 500k validators, so a full epoch pushes the exit back by one
+	pending := []uint64{110, 110, 110, 110, 110, 110, 110, FarFutureEpoch}
+	if epoch := GetExitQueueEpoch(100, 500000, pending); epoch != 111 {
+		t.Fatalf("expected epoch 111 for a full queue epoch, got %d", epoch)
+	}
+
+	// A partially full epoch still has room
+	if epoch := GetExitQueueEpoch(100, 500000, pending[:6]); epoch != 110 {
+		t.Fatalf("expected epoch 110 for a partial queue epoch, got %d", epoch)
+	}
+}
+
+func TestGetWithdrawalSweepSlot(t *testing.T) {
+	// 1600 validators take 100 slots to sweep; validator 320 is 20 slots ahead of the sweep at index 0
+	slot, sweepSlots := GetWithdrawalSweepSlot(1000, 0, 320, 1600, 0)
+	if sweepSlots != 100 {
+		t.Fatalf("expected a 100 slot sweep, got %d", sweepSlots)
+	}
+	if slot != 1021 {
+		t.Fatalf("expected slot 1021, got %d", slot)
+	}
+
+	// If the validator isn't withdrawable yet, the sweep has to come around again
+	slot, _ = GetWithdrawalSweepSlot(1000, 0, 320, 1600, 1050)
+	if slot != 1121 {
+		t.Fatalf("expected slot 1121, got %d", slot)
+	}
+
+	// The sweep wraps around the end of the registry
+	slot, _ = GetWithdrawalSweepSlot(1000, 1590, 5, 1600, 0)
+	if slot != 1001 {
+		t.Fatalf("expected slot 1001, got %d", slot)
+	}
+}
@@ -0,0 +1,100 @@
+package files
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// The suffix of the temporary files used for atomic writes.
+// A file with this suffix left behind after a restart is from a write that was interrupted.
+const TempFileSuffix string = ".partial"
+
+// Write data to a file so that readers, and the file left behind after a crash, only ever have the old contents or the new contents.
+// The data is written to a temporary file in the same directory, synced to disk, and then renamed over the target; the directory is
+// synced afterwards so the rename itself survives a power loss.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	tempFile, err := os.CreateTemp(dir, filepath.Base(path)+".*"+TempFileSuffix)
+	if err != nil {
+		return fmt.Errorf("error creating temporary file for %s: %w", path, err)
+	}
+	tempPath := tempFile.Name()
+
+	// Clean up the temporary file if anything fails before it's renamed
+	success := false
+	defer func() {
+		if !success {
+			tempFile.Close()
+			os.Remove(tempPath)
+		}
+	}()
+
+	_, err = tempFile.Write(data)
+	if err != nil {
+		return fmt.Errorf("error writing temporary file for %s: %w", path, err)
+	}
+	err = tempFile.Chmod(perm)
+	if err != nil {
+		return fmt.Errorf("error setting permissions of temporary file for %s: %w", path, err)
+	}
+	err = tempFile.Sync()
+	if err != nil {
+		return fmt.Errorf("error syncing temporary file for %s: %w", path, err)
+	}
+	err = tempFile.Close()
+	if err != nil {
+		return fmt.Errorf("error closing temporary file for %s: %w", path, err)
+	}
+
+	err = os.Rename(tempPath, path)
+	if err != nil {
+		return fmt.Errorf("error moving temporary file to %s: %w", path, err)
+	}
+	success = true
+
+	return syncDir(dir)
+}
+
+// Find the temporary files left in a directory by atomic writes that never finished, and delete them.
+// The targets of those writes still have their previous contents, so nothing else needs to be repaired.
+// Returns the paths of the files that were removed.
+func RemovePartialWrites(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading directory %s: %w", dir, err)
+	}
+
+	removed := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), TempFileSuffix) {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		err = os.Remove(path)
+		if err != nil {
+			return removed, fmt.Errorf("error removing partially written file %s: %w", path, err)
+		}
+		removed = append(removed, path)
+	}
+	return removed, nil
+}
+
+// Sync a directory so the files created or renamed in it are persisted
+func syncDir(dir string) error {
+	dirFile, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("error opening directory %s: %w", dir, err)
+	}
+	defer dirFile.Close()
+
+	err = dirFile.Sync()
+	if err != nil {
+		return fmt.Errorf("error syncing directory %s: %w", dir, err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package files
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Returned when a lock is already held by another process
+var ErrLocked = errors.New("the lock is held by another process")
+
+// An advisory lock on a file, held until it is released or the process exits
+type Lock struct {
+	path string
+	file *os.File
+}
+
+// Try to take the lock at the given path without waiting, creating the lock file if it doesn't exist.
+// If another process holds it, the returned error wraps ErrLocked and names that process if it can.
+func AcquireLock(path string) (*Lock, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("error opening lock file %s: %w", path, err)
+	}
+
+	err = lockFile(file)
+	if err != nil {
+		file.Close()
+		if errors.Is(err, ErrLocked) {
+			if pid := readLockOwner(path); pid != 0 {
+				return nil, fmt.Errorf("%s is locked by process %d: %w", path, pid, ErrLocked)
+			}
+			return nil, fmt.Errorf("%s is locked: %w", path, ErrLocked)
+		}
+		return nil, fmt.Errorf("error locking %s: %w", path, err)
+	}
+
+	// Record the owner so a second instance can say who has the lock
+	err = file.Truncate(0)
+	if err == nil {
+		_, err = file.WriteAt([]byte(strconv.Itoa(os.Getpid())), 0)
+	}
+	if err != nil {
+		unlockFile(file)
+		file.Close()
+		return nil, fmt.Errorf("error writing lock file %s: %w", path, err)
+	}
+
+	return &Lock{
+		path: path,
+		file: file,
+	}, nil
+}
+
+// Release the lock
+func (l *Lock) Release() error {
+	err := unlockFile(l.file)
+	closeErr := l.file.Close()
+	if err != nil {
+		return fmt.Errorf("error unlocking %s: %w", l.path, err)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("error closing lock file %s: %w", l.path, closeErr)
+	}
+	return nil
+}
+
+// Get the ID of the process that wrote the lock file, or 0 if it isn't known
+func readLockOwner(path string) int {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(bytes)))
+	if err != nil {
+		return 0
+	}
+	return pid
+}
@@ -0,0 +1,24 @@
+//go:build !windows
+// +build !windows
+
+package files
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// Take an exclusive advisory lock on the file without blocking
+func lockFile(file *os.File) error {
+	err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		return ErrLocked
+	}
+	return err
+}
+
+// Release the advisory lock on the file
+func unlockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,18 @@
+//go:build windows
+// +build windows
+
+package files
+
+import (
+	"os"
+)
+
+// The daemons don't run on Windows, so locks always succeed there
+func lockFile(file *os.File) error {
+	return nil
+}
+
+// The daemons don't run on Windows, so there's nothing to unlock
+func unlockFile(file *os.File) error {
+	return nil
+}
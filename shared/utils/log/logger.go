@@ -1,9 +1,12 @@
 package log
 
 import (
+	"fmt"
 	"log"
 
 	"github.com/fatih/color"
+
+	"github.com/rocket-pool/smartnode/shared/types/errcodes"
 )
 
 // Logger with ANSI color output
@@ -27,8 +30,15 @@ func (l *ColorLogger) Print(v ...interface{}) {
 	log.Print(l.sprintFunc(v...))
 }
 
-// Print values with a newline
+// Print values with a newline; errors with an error code are prefixed with it
 func (l *ColorLogger) Println(v ...interface{}) {
+	for i, value := range v {
+		if err, ok := value.(error); ok {
+			if code := errcodes.Get(err); code != errcodes.None {
+				v[i] = fmt.Sprintf("[%s] %s", code, err.Error())
+			}
+		}
+	}
 	log.Println(l.sprintFunc(v...))
 }
 
@@ -0,0 +1,40 @@
+package net
+
+import (
+	"fmt"
+	"net"
+	"time"
+)
+
+// An address on the public internet that's only reachable over IPv6, used to check for outbound IPv6 connectivity
+const ipv6ProbeAddress string = "[2606:4700:4700::1111]:443"
+
+// Get the public IPv6 addresses assigned to the host's network interfaces
+func GetPublicIpv6Addresses() ([]net.IP, error) {
+	addresses, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil, fmt.Errorf("error getting the network interface addresses: %w", err)
+	}
+	ips := []net.IP{}
+	for _, address := range addresses {
+		ipNet, ok := address.(*net.IPNet)
+		if !ok {
+			continue
+		}
+		ip := ipNet.IP
+		if ip.To4() == nil && ip.IsGlobalUnicast() && !ip.IsPrivate() {
+			ips = append(ips, ip)
+		}
+	}
+	return ips, nil
+}
+
+// Check if the host can open connections to the internet over IPv6
+func CheckIpv6Connectivity(timeout time.Duration) error {
+	conn, err := net.DialTimeout("tcp6", ipv6ProbeAddress, timeout)
+	if err != nil {
+		return fmt.Errorf("couldn't connect to %s: %w", ipv6ProbeAddress, err)
+	}
+	conn.Close()
+	return nil
+}
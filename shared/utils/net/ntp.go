@@ -0,0 +1,55 @@
+package net
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+)
+
+// The number of seconds between the NTP epoch (1900) and the Unix epoch (1970)
+const ntpEpochOffset = 2208988800
+
+// Get how far the local clock is from an NTP server's clock, using a single SNTP request.
+// A positive offset means the local clock is behind the server.
+func GetClockOffset(server string, timeout time.Duration) (time.Duration, error) {
+	conn, err := net.DialTimeout("udp", DefaultPort(server, "123"), timeout)
+	if err != nil {
+		return 0, fmt.Errorf("error connecting to NTP server [%s]: %w", server, err)
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return 0, fmt.Errorf("error setting the NTP request deadline: %w", err)
+	}
+
+	// Send a version 4 client request
+	request := make([]byte, 48)
+	request[0] = 0x23
+	sendTime := time.Now()
+	if _, err := conn.Write(request); err != nil {
+		return 0, fmt.Errorf("error sending NTP request to [%s]: %w", server, err)
+	}
+
+	response := make([]byte, 48)
+	if _, err := conn.Read(response); err != nil {
+		return 0, fmt.Errorf("error reading NTP response from [%s]: %w", server, err)
+	}
+	receiveTime := time.Now()
+	if response[1] == 0 {
+		return 0, fmt.Errorf("NTP server [%s] sent a kiss-of-death response", server)
+	}
+
+	// Offset = ((server receive - client send) + (server transmit - client receive)) / 2
+	serverReceive := parseNtpTime(response[32:40])
+	serverTransmit := parseNtpTime(response[40:48])
+	offset := (serverReceive.Sub(sendTime) + serverTransmit.Sub(receiveTime)) / 2
+	return offset, nil
+}
+
+// Convert a 64-bit NTP timestamp into a time
+func parseNtpTime(timestamp []byte) time.Time {
+	seconds := binary.BigEndian.Uint32(timestamp[0:4])
+	fraction := binary.BigEndian.Uint32(timestamp[4:8])
+	nanoseconds := (int64(fraction) * 1e9) >> 32
+	return time.Unix(int64(seconds)-ntpEpochOffset, nanoseconds)
+}
@@ -0,0 +1,102 @@
+package net
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Check if a port is free to bind on all of the host's interfaces
+func IsPortFree(port uint16, protocol string) bool {
+	address := fmt.Sprintf(":%d", port)
+	switch protocol {
+	case "udp":
+		conn, err := net.ListenPacket("udp", address)
+		if err != nil {
+			return false
+		}
+		conn.Close()
+	default:
+		listener, err := net.Listen("tcp", address)
+		if err != nil {
+			return false
+		}
+		listener.Close()
+	}
+	return true
+}
+
+// Check if a TCP port can be reached from the internet with a port checking service.
+// checkerUrl has `{port}` replaced with the port, and the service has to reply with a JSON object with a `reachable` field.
+// If nothing is listening on the port yet, it's opened here for the length of the check so the service has something to connect to.
+func CheckPortReachable(checkerUrl string, port uint16, timeout time.Duration) (bool, error) {
+	return checkPortReachable(http.DefaultClient, checkerUrl, port, timeout)
+}
+
+// Check if a TCP port can be reached from the internet over IPv6, by only contacting the port checking service over IPv6
+// so it connects back to the host's public IPv6 address
+func CheckPortReachableOverIpv6(checkerUrl string, port uint16, timeout time.Duration) (bool, error) {
+	dialer := &net.Dialer{}
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network string, address string) (net.Conn, error) {
+				return dialer.DialContext(ctx, "tcp6", address)
+			},
+		},
+	}
+	return checkPortReachable(client, checkerUrl, port, timeout)
+}
+
+// Check if a TCP port can be reached with the given HTTP client
+func checkPortReachable(client *http.Client, checkerUrl string, port uint16, timeout time.Duration) (bool, error) {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err == nil {
+		defer listener.Close()
+		go func() {
+			for {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				conn.Close()
+			}
+		}()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	url := strings.ReplaceAll(checkerUrl, "{port}", fmt.Sprint(port))
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, fmt.Errorf("error creating the port check request: %w", err)
+	}
+	request.Header.Set("Accept", "application/json")
+	response, err := client.Do(request)
+	if err != nil {
+		return false, fmt.Errorf("error contacting the port checker: %w", err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(response.Body, 1<<16))
+	if err != nil {
+		return false, fmt.Errorf("error reading the port checker's response: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("the port checker returned status %d: %s", response.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var result struct {
+		Reachable *bool `json:"reachable"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return false, fmt.Errorf("error parsing the port checker's response: %w", err)
+	}
+	if result.Reachable == nil {
+		return false, fmt.Errorf("the port checker's response didn't say whether the port is reachable")
+	}
+	return *result.Reachable, nil
+}
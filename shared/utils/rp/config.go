@@ -6,6 +6,8 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/alessio/shellescape"
 	"github.com/rocket-pool/smartnode/shared/services/config"
@@ -13,7 +15,8 @@ import (
 )
 
 const (
-	upgradeFlagFile string = ".firstrun"
+	upgradeFlagFile  string = ".firstrun"
+	migratedFlagFile string = ".migrated"
 )
 
 // Loads a config without updating it if it exists
@@ -108,6 +111,40 @@ func IsFirstRun(configDir string) bool {
 	return true
 }
 
+// Get when the node was migrated to another machine, if it has been
+func GetMigrationTime(configDir string) (time.Time, bool, error) {
+	bytes, err := os.ReadFile(filepath.Join(configDir, migratedFlagFile))
+	if os.IsNotExist(err) {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("error reading migration flag file: %w", err)
+	}
+	migrationTime, err := time.Parse(time.RFC3339, strings.TrimSpace(string(bytes)))
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("error parsing migration flag file: %w", err)
+	}
+	return migrationTime, true, nil
+}
+
+// Mark the node as migrated to another machine, so it isn't started here again by accident
+func SetMigratedFlag(configDir string, migrationTime time.Time) error {
+	err := os.WriteFile(filepath.Join(configDir, migratedFlagFile), []byte(migrationTime.UTC().Format(time.RFC3339)), 0644)
+	if err != nil {
+		return fmt.Errorf("error writing migration flag file: %w", err)
+	}
+	return nil
+}
+
+// Remove the migration flag file
+func RemoveMigratedFlag(configDir string) error {
+	err := os.Remove(filepath.Join(configDir, migratedFlagFile))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error removing migration flag file: %w", err)
+	}
+	return nil
+}
+
 // Remove the upgrade flag file
 func RemoveUpgradeFlagFile(configDir string) error {
 
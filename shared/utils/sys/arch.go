@@ -0,0 +1,39 @@
+package sys
+
+import (
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+var (
+	hostArchitecture     string
+	hostArchitectureOnce sync.Once
+)
+
+// Returns the host's CPU architecture with Docker's name for it, such as amd64 or arm64.
+// This comes from the kernel rather than the binary, so it's still right if the binary is running under emulation.
+func GetHostArchitecture() string {
+	hostArchitectureOnce.Do(func() {
+		hostArchitecture = runtime.GOARCH
+		if runtime.GOOS != "linux" {
+			return
+		}
+		output, err := exec.Command("uname", "-m").Output()
+		if err != nil {
+			return
+		}
+		switch strings.TrimSpace(string(output)) {
+		case "x86_64":
+			hostArchitecture = "amd64"
+		case "aarch64", "arm64":
+			hostArchitecture = "arm64"
+		case "armv6l", "armv7l":
+			hostArchitecture = "arm"
+		case "riscv64":
+			hostArchitecture = "riscv64"
+		}
+	})
+	return hostArchitecture
+}
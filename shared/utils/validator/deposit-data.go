@@ -1,13 +1,23 @@
 package validator
 
 import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/shared/types/eth2"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
 )
 
+// The deposit CLI version reported in exported deposit data, which some tools check
+const ExportedDepositCliVersion string = "2.7.0"
+
 // Get deposit data & root for a given validator key and withdrawal credentials
 func GetDepositData(validatorKey *eth2types.BLSPrivateKey, withdrawalCredentials common.Hash, eth2Config beacon.Eth2Config, depositAmount uint64) (eth2.DepositData, common.Hash, error) {
 
@@ -53,3 +63,169 @@ func GetDepositData(validatorKey *eth2types.BLSPrivateKey, withdrawalCredentials
 	return depositData, depositDataRoot, nil
 
 }
+
+// Convert deposit data into the format produced by the staking deposit CLI, so it can be used by tools that expect deposit_data-*.json files
+func ExportDepositData(depositData eth2.DepositData, eth2Config beacon.Eth2Config, networkName string) (api.ExportedDepositData, error) {
+
+	// Get the roots
+	depositMessage := eth2.DepositDataNoSignature{
+		PublicKey:             depositData.PublicKey,
+		WithdrawalCredentials: depositData.WithdrawalCredentials,
+		Amount:                depositData.Amount,
+	}
+	messageRoot, err := depositMessage.HashTreeRoot()
+	if err != nil {
+		return api.ExportedDepositData{}, err
+	}
+	dataRoot, err := depositData.HashTreeRoot()
+	if err != nil {
+		return api.ExportedDepositData{}, err
+	}
+
+	// Return
+	return api.ExportedDepositData{
+		Pubkey:                hex.EncodeToString(depositData.PublicKey),
+		WithdrawalCredentials: hex.EncodeToString(depositData.WithdrawalCredentials),
+		Amount:                depositData.Amount,
+		Signature:             hex.EncodeToString(depositData.Signature),
+		DepositMessageRoot:    hex.EncodeToString(messageRoot[:]),
+		DepositDataRoot:       hex.EncodeToString(dataRoot[:]),
+		ForkVersion:           hex.EncodeToString(eth2Config.GenesisForkVersion),
+		NetworkName:           networkName,
+		DepositCliVersion:     ExportedDepositCliVersion,
+	}, nil
+
+}
+
+// Check deposit data in the staking deposit CLI's format against the withdrawal credentials and network it should be for.
+// Returns every problem that was found, or an empty list if the deposit data is valid.
+func VerifyExportedDepositData(data api.ExportedDepositData, withdrawalCredentials common.Hash, eth2Config beacon.Eth2Config) []string {
+	problems := []string{}
+
+	// Decode the fields
+	pubkey, err := decodeDepositDataField(data.Pubkey, 48)
+	if err != nil {
+		return append(problems, fmt.Sprintf("invalid pubkey: %s", err.Error()))
+	}
+	credentials, err := decodeDepositDataField(data.WithdrawalCredentials, 32)
+	if err != nil {
+		return append(problems, fmt.Sprintf("invalid withdrawal credentials: %s", err.Error()))
+	}
+	signature, err := decodeDepositDataField(data.Signature, 96)
+	if err != nil {
+		return append(problems, fmt.Sprintf("invalid signature: %s", err.Error()))
+	}
+
+	// Check it's for the right minipool and network
+	if !bytes.Equal(credentials, withdrawalCredentials[:]) {
+		problems = append(problems, fmt.Sprintf("the withdrawal credentials are 0x%x, but the minipool's are %s", credentials, withdrawalCredentials.Hex()))
+	}
+	if data.ForkVersion != "" && hexutil.RemovePrefix(data.ForkVersion) != hex.EncodeToString(eth2Config.GenesisForkVersion) {
+		problems = append(problems, fmt.Sprintf("the fork version is %s, but this network's is %x, so it's for a different network", data.ForkVersion, eth2Config.GenesisForkVersion))
+	}
+
+	// Check the roots
+	depositData := eth2.DepositData{
+		PublicKey:             pubkey,
+		WithdrawalCredentials: credentials,
+		Amount:                data.Amount,
+		Signature:             signature,
+	}
+	exported, err := ExportDepositData(depositData, eth2Config, data.NetworkName)
+	if err != nil {
+		return append(problems, fmt.Sprintf("error computing the deposit roots: %s", err.Error()))
+	}
+	if data.DepositMessageRoot != "" && hexutil.RemovePrefix(data.DepositMessageRoot) != exported.DepositMessageRoot {
+		problems = append(problems, fmt.Sprintf("the deposit message root is %s, but the deposit data's is %s", data.DepositMessageRoot, exported.DepositMessageRoot))
+	}
+	if hexutil.RemovePrefix(data.DepositDataRoot) != exported.DepositDataRoot {
+		problems = append(problems, fmt.Sprintf("the deposit data root is %s, but the deposit data's is %s", data.DepositDataRoot, exported.DepositDataRoot))
+	}
+
+	// Check the signature
+	if err := verifyDepositSignature(depositData, eth2Config); err != nil {
+		problems = append(problems, err.Error())
+	}
+	return problems
+
+}
+
+// Check that deposit data was signed by its validator key for this network
+func verifyDepositSignature(depositData eth2.DepositData, eth2Config beacon.Eth2Config) error {
+	if err := InitializeBLS(); err != nil {
+		return fmt.Errorf("error initializing BLS library: %w", err)
+	}
+	pubkey, err := eth2types.BLSPublicKeyFromBytes(depositData.PublicKey)
+	if err != nil {
+		return fmt.Errorf("the pubkey isn't a valid BLS key: %w", err)
+	}
+	signature, err := eth2types.BLSSignatureFromBytes(depositData.Signature)
+	if err != nil {
+		return fmt.Errorf("the signature isn't a valid BLS signature: %w", err)
+	}
+
+	// Get the signing root
+	depositMessage := eth2.DepositDataNoSignature{
+		PublicKey:             depositData.PublicKey,
+		WithdrawalCredentials: depositData.WithdrawalCredentials,
+		Amount:                depositData.Amount,
+	}
+	or, err := depositMessage.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	sr := eth2.SigningRoot{
+		ObjectRoot: or[:],
+		Domain:     eth2types.Domain(eth2types.DomainDeposit, eth2Config.GenesisForkVersion, eth2types.ZeroGenesisValidatorsRoot),
+	}
+	srHash, err := sr.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	if !signature.Verify(srHash[:], pubkey) {
+		return fmt.Errorf("the signature doesn't match the deposit, or wasn't made for this network")
+	}
+	return nil
+}
+
+// Decode a hex field from exported deposit data and check its length
+func decodeDepositDataField(value string, length int) ([]byte, error) {
+	decoded, err := hex.DecodeString(hexutil.RemovePrefix(value))
+	if err != nil {
+		return nil, err
+	}
+	if len(decoded) != length {
+		return nil, fmt.Errorf("expected %d bytes but got %d", length, len(decoded))
+	}
+	return decoded, nil
+}
+
+// Create the deposit data and keystore needed to run a minipool's validator somewhere else, such as with a staking service.
+// Each deposit amount gets its own deposit data entry, in gwei.
+func ExportMinipoolValidator(key *eth2types.BLSPrivateKey, derivationPath string, password string, withdrawalCredentials common.Hash, eth2Config beacon.Eth2Config, networkName string, depositAmounts []uint64) ([]api.ExportedDepositData, string, error) {
+
+	// Create the deposit data, checking each entry the same way externally produced ones are
+	exported := []api.ExportedDepositData{}
+	for _, amount := range depositAmounts {
+		depositData, _, err := GetDepositData(key, withdrawalCredentials, eth2Config, amount)
+		if err != nil {
+			return nil, "", err
+		}
+		entry, err := ExportDepositData(depositData, eth2Config, networkName)
+		if err != nil {
+			return nil, "", err
+		}
+		if problems := VerifyExportedDepositData(entry, withdrawalCredentials, eth2Config); len(problems) > 0 {
+			return nil, "", fmt.Errorf("the deposit data for %d gwei failed validation: %s", amount, strings.Join(problems, "; "))
+		}
+		exported = append(exported, entry)
+	}
+
+	// Create the keystore
+	keystore, err := CreateKeystore(key, derivationPath, password)
+	if err != nil {
+		return nil, "", err
+	}
+	return exported, string(keystore), nil
+
+}
@@ -0,0 +1,57 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/client"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Load validator keys that were just saved to disk into the Validator Client.
+// Uses the keymanager API if it's enabled so the Validator Client keeps running, and falls back to restarting it otherwise.
+// Returns true if the keys were loaded without a restart.
+func LoadValidatorKeys(cfg *config.RocketPoolConfig, bc beacon.Client, log *log.ColorLogger, d *client.Client, km *keymanager.Client, keys []*eth2types.BLSPrivateKey, derivationPaths []string) (bool, error) {
+
+	if len(keys) == 0 {
+		return false, nil
+	}
+
+	if km != nil {
+		err := ImportValidatorKeys(km, keys, derivationPaths, "")
+		if err == nil {
+			if log != nil {
+				log.Printlnf("Loaded %d validator key(s) with the keymanager API.", len(keys))
+			}
+			return true, nil
+		}
+		if log != nil {
+			log.Printlnf("WARNING: couldn't load validator keys with the keymanager API, restarting the Validator Client instead: %s", err.Error())
+		}
+	}
+
+	return false, RestartValidator(cfg, bc, log, d)
+
+}
+
+// Import keys with the keymanager API, treating keys that were already loaded as a success.
+// The EIP-3076 slashing protection interchange is optional.
+func ImportValidatorKeys(km *keymanager.Client, keys []*eth2types.BLSPrivateKey, derivationPaths []string, slashingProtection string) error {
+	results, err := km.ImportValidatorKeys(keys, derivationPaths, slashingProtection)
+	if err != nil {
+		return err
+	}
+	if len(results) != len(keys) {
+		return fmt.Errorf("the Validator Client returned %d results for %d keys", len(results), len(keys))
+	}
+	for i, result := range results {
+		if result.Status != keymanager.KeyStatus_Imported && result.Status != keymanager.KeyStatus_Duplicate {
+			return fmt.Errorf("key %d was not imported (%s): %s", i, result.Status, result.Message)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package validator
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// An EIP-2335 keystore, in the format produced by the staking deposit CLI
+type exportedKeystore struct {
+	Crypto      map[string]interface{} `json:"crypto"`
+	Description string                 `json:"description"`
+	Pubkey      string                 `json:"pubkey"`
+	Path        string                 `json:"path"`
+	UUID        uuid.UUID              `json:"uuid"`
+	Version     uint                   `json:"version"`
+}
+
+// Encrypt a validator key into an EIP-2335 keystore that any Validator Client can import
+func CreateKeystore(key *eth2types.BLSPrivateKey, derivationPath string, password string) ([]byte, error) {
+	encryptor := eth2ks.New(eth2ks.WithCipher("scrypt"))
+	encryptedKey, err := encryptor.Encrypt(key.Marshal(), password)
+	if err != nil {
+		return nil, fmt.Errorf("Could not encrypt validator key: %w", err)
+	}
+	keystore := exportedKeystore{
+		Crypto:  encryptedKey,
+		Pubkey:  hex.EncodeToString(key.PublicKey().Marshal()),
+		Path:    derivationPath,
+		UUID:    uuid.New(),
+		Version: encryptor.Version(),
+	}
+	bytes, err := json.Marshal(keystore)
+	if err != nil {
+		return nil, fmt.Errorf("Could not encode validator keystore: %w", err)
+	}
+	return bytes, nil
+}
+
+// Save exported deposit data and a keystore to a folder, using the file names the staking deposit CLI uses
+func SaveExportedValidator(folder string, export api.ExportDepositDataResponse) (string, string, error) {
+	if err := os.MkdirAll(folder, 0700); err != nil {
+		return "", "", fmt.Errorf("Could not create folder [%s]: %w", folder, err)
+	}
+	timestamp := time.Now().Unix()
+
+	// Save the deposit data as a list, which is what staking services expect
+	depositDataBytes, err := json.MarshalIndent(export.DepositData, "", "  ")
+	if err != nil {
+		return "", "", fmt.Errorf("Could not encode deposit data: %w", err)
+	}
+	depositDataPath := filepath.Join(folder, fmt.Sprintf("deposit_data-%d.json", timestamp))
+	if err := os.WriteFile(depositDataPath, depositDataBytes, 0644); err != nil {
+		return "", "", fmt.Errorf("Could not save deposit data [%s]: %w", depositDataPath, err)
+	}
+
+	// Save the keystore, named after its derivation path (or its pubkey if the path isn't known)
+	keystoreName := hex.EncodeToString(export.Pubkey.Bytes())
+	if export.DerivationPath != "" {
+		keystoreName = strings.ReplaceAll(export.DerivationPath, "/", "_")
+	}
+	keystorePath := filepath.Join(folder, fmt.Sprintf("keystore-%s-%d.json", keystoreName, timestamp))
+	if err := os.WriteFile(keystorePath, []byte(export.Keystore), 0600); err != nil {
+		return "", "", fmt.Errorf("Could not save keystore [%s]: %w", keystorePath, err)
+	}
+
+	return depositDataPath, keystorePath, nil
+}
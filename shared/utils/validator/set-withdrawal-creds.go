@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"bytes"
 	"fmt"
 	"strconv"
 	"strings"
@@ -9,8 +10,31 @@ import (
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/shared/types/eth2"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	util "github.com/wealdtech/go-eth2-util"
 )
 
+// Find the private key and index for a validator pubkey by searching the first limit indices of a mnemonic
+func FindValidatorKey(mnemonic string, pubkey types.ValidatorPubkey, validatorKeyPath string, limit uint) (*eth2types.BLSPrivateKey, uint, error) {
+	for index := uint(0); index < limit; index++ {
+		key, err := GetPrivateKey(mnemonic, index, validatorKeyPath)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error deriving key for index %d: %w", index, err)
+		}
+		if bytes.Equal(pubkey[:], key.PublicKey().Marshal()) {
+			return key, index, nil
+		}
+	}
+	return nil, 0, fmt.Errorf("couldn't find the validator key for this mnemonic after %d tries", limit)
+}
+
+// Get the 0x00 (BLS) withdrawal credentials that correspond to a withdrawal key
+func GetBlsWithdrawalCredentials(withdrawalKey *eth2types.BLSPrivateKey) common.Hash {
+	// Withdrawal creds use sha256, *not* Keccak
+	withdrawalPubkeyHash := common.BytesToHash(util.SHA256(withdrawalKey.PublicKey().Marshal()))
+	withdrawalPubkeyHash[0] = 0x00 // BLS prefix
+	return withdrawalPubkeyHash
+}
+
 // Get the withdrawal private key for a validator based on its mnemonic, index, and path
 func GetWithdrawalKey(mnemonic string, index uint, validatorKeyPath string) (*eth2types.BLSPrivateKey, error) {
 
@@ -17,6 +17,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	hexutils "github.com/rocket-pool/smartnode/shared/utils/hex"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
 	"github.com/urfave/cli"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
@@ -51,22 +52,72 @@ func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address comm
 	}
 	pubkeys = filteredPubkeys
 
+	err = RecoverKeysForPubkeys(cfg, w, pubkeys, testOnly)
+	if err != nil {
+		return nil, err
+	}
+	return pubkeys, nil
+
+}
+
+// Regenerate the validator keys for the provided pubkeys from the wallet (or the custom key folder) and save them to disk
+func RecoverKeysForPubkeys(cfg *config.RocketPoolConfig, w *wallet.Wallet, pubkeys []types.ValidatorPubkey, testOnly bool) error {
+
 	pubkeyMap := map[types.ValidatorPubkey]bool{}
 	for _, pubkey := range pubkeys {
 		pubkeyMap[pubkey] = true
 	}
 
-	pubkeyMap, err = CheckForAndRecoverCustomMinipoolKeys(cfg, pubkeyMap, w, testOnly)
+	pubkeyMap, err := CheckForAndRecoverCustomMinipoolKeys(cfg, pubkeyMap, w, testOnly)
 	if err != nil {
-		return nil, fmt.Errorf("error checking for or recovering custom validator keys: %w", err)
+		return fmt.Errorf("error checking for or recovering custom validator keys: %w", err)
 	}
 
 	// Recover conventionally generated keys
-	bucketStart := uint(0)
-	for {
-		if bucketStart >= bucketLimit {
-			return nil, fmt.Errorf("attempt limit exceeded (%d keys)", bucketLimit)
+	err = recoverDerivedKeys(w, pubkeyMap, testOnly)
+	if err != nil {
+		return err
+	}
+	if len(pubkeyMap) > 0 {
+		return fmt.Errorf("attempt limit exceeded (%d keys)", bucketLimit)
+	}
+
+	return nil
+
+}
+
+// Find which of the provided pubkeys can't be regenerated from the wallet (or the custom key folder), without saving anything to disk
+func FindUnrecoverableKeys(cfg *config.RocketPoolConfig, w *wallet.Wallet, pubkeys []types.ValidatorPubkey) ([]types.ValidatorPubkey, error) {
+
+	pubkeyMap := map[types.ValidatorPubkey]bool{}
+	for _, pubkey := range pubkeys {
+		pubkeyMap[pubkey] = true
+	}
+
+	pubkeyMap, err := CheckForAndRecoverCustomMinipoolKeys(cfg, pubkeyMap, w, true)
+	if err != nil {
+		return nil, fmt.Errorf("error checking for custom validator keys: %w", err)
+	}
+	err = recoverDerivedKeys(w, pubkeyMap, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the original order so the results are stable
+	missing := []types.ValidatorPubkey{}
+	for _, pubkey := range pubkeys {
+		if pubkeyMap[pubkey] {
+			missing = append(missing, pubkey)
 		}
+	}
+	return missing, nil
+
+}
+
+// Search the wallet's derived validator keys for the pubkeys in the map, removing each one as it's found
+func recoverDerivedKeys(w *wallet.Wallet, pubkeyMap map[types.ValidatorPubkey]bool, testOnly bool) error {
+
+	for bucketStart := uint(0); bucketStart < bucketLimit && len(pubkeyMap) > 0; bucketStart += bucketSize {
 		bucketEnd := bucketStart + bucketSize
 		if bucketEnd > bucketLimit {
 			bucketEnd = bucketLimit
@@ -75,7 +126,7 @@ func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address comm
 		// Get the keys for this bucket
 		keys, err := w.GetValidatorKeys(bucketStart, bucketEnd-bucketStart)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		for _, validatorKey := range keys {
 			_, exists := pubkeyMap[validatorKey.PublicKey]
@@ -85,22 +136,14 @@ func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address comm
 				if !testOnly {
 					err := w.SaveValidatorKey(validatorKey)
 					if err != nil {
-						return nil, fmt.Errorf("error recovering validator keys: %w", err)
+						return fmt.Errorf("error recovering validator keys: %w", err)
 					}
 				}
 			}
 		}
-
-		if len(pubkeyMap) == 0 {
-			// All keys recovered!
-			break
-		}
-
-		// Run another iteration with the next bucket
-		bucketStart = bucketEnd
 	}
 
-	return pubkeys, nil
+	return nil
 
 }
 
@@ -212,3 +255,21 @@ func CheckForAndRecoverCustomMinipoolKeys(cfg *config.RocketPoolConfig, pubkeyMa
 	return pubkeyMap, nil
 
 }
+
+// Load recovered validator keys into the Validator Client with its keymanager API, if it's enabled.
+// Returns false if they couldn't be loaded this way, in which case the Validator Client has to be restarted to pick them up.
+func LoadRecoveredKeys(c *cli.Context, w *wallet.Wallet, pubkeys []types.ValidatorPubkey) bool {
+	km, err := services.GetKeymanager(c)
+	if err != nil || km == nil || len(pubkeys) == 0 {
+		return false
+	}
+
+	keys := make([]*eth2types.BLSPrivateKey, len(pubkeys))
+	for i, pubkey := range pubkeys {
+		keys[i], err = w.GetValidatorKeyByPubkey(pubkey)
+		if err != nil {
+			return false
+		}
+	}
+	return validator.ImportValidatorKeys(km, keys, nil, "") == nil
+}